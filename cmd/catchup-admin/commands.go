@@ -0,0 +1,540 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"catchup-feed/internal/domain/entity"
+	pgRepo "catchup-feed/internal/infra/adapter/persistence/postgres"
+	"catchup-feed/internal/infra/db"
+	artUC "catchup-feed/internal/usecase/article"
+	auditUC "catchup-feed/internal/usecase/audit"
+	crawlUC "catchup-feed/internal/usecase/crawl"
+	srcUC "catchup-feed/internal/usecase/source"
+	subUC "catchup-feed/internal/usecase/subscriber"
+	userUC "catchup-feed/internal/usecase/user"
+)
+
+// app holds the use cases the commands call, wired the way cmd/server
+// wires them (minus the HTTP-only collaborators: replica, webhooks,
+// previewer).
+type app struct {
+	sources     *srcUC.Service
+	articles    *artUC.Service
+	crawl       *crawlUC.Service
+	users       *userUC.Service
+	subscribers *subUC.Service
+	// feedBaseURL is feed.Config.PublicBaseURL, for the URL printed by
+	// token issue.
+	feedBaseURL string
+	// db is used directly by backup, which copies tables rather than
+	// going through the use cases.
+	db *sql.DB
+}
+
+func newApp(database *sql.DB, logger *slog.Logger) *app {
+	auditSvc := &auditUC.Service{Repo: pgRepo.NewAuditLogRepo(database), Logger: logger}
+	return &app{
+		sources: &srcUC.Service{
+			Repo:       pgRepo.NewSourceRepo(database),
+			Audit:      auditSvc,
+			HealthRepo: pgRepo.NewSourceHealthRepo(database),
+		},
+		articles: &artUC.Service{Repo: pgRepo.NewArticleRepo(database), Audit: auditSvc},
+		crawl: &crawlUC.Service{
+			Jobs:    pgRepo.NewJobRepo(database),
+			Sources: pgRepo.NewSourceRepo(database),
+			Runs:    pgRepo.NewCrawlRunRepo(database),
+		},
+		users: &userUC.Service{Users: pgRepo.NewUserRepo(database)},
+		subscribers: &subUC.Service{
+			Subscribers: pgRepo.NewSubscriberRepo(database),
+			Tokens:      pgRepo.NewFeedTokenRepo(database),
+		},
+		db: database,
+	}
+}
+
+// opener opens the app a command runs against. Commands call it once
+// their flags and arguments are checked, so usage errors and -h never
+// reach the configuration or the database.
+type opener func(ctx context.Context) (*app, error)
+
+// newRootCmd builds the catchup-admin command tree: one group per
+// resource, one subcommand per task.
+func newRootCmd(open opener) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "catchup-admin",
+		Short: "Operational tasks against the catchup-feed database",
+		// main prints the error; the usage only follows flag and argument
+		// mistakes (see PersistentPreRun).
+		SilenceErrors: true,
+		PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+			cmd.SilenceUsage = true
+		},
+		CompletionOptions: cobra.CompletionOptions{DisableDefaultCmd: true},
+	}
+	root.AddCommand(
+		newGroupCmd("source", "Manage feed sources",
+			sourceAddCmd(open), sourceListCmd(open), sourceDisableCmd(open)),
+		newGroupCmd("article", "Manage articles",
+			articleDeleteCmd(open)),
+		newGroupCmd("crawl", "Control crawling",
+			crawlTriggerCmd(open)),
+		newGroupCmd("user", "Manage dashboard accounts",
+			userCreateCmd(open), userResetPasswordCmd(open)),
+		newGroupCmd("token", "Manage subscriber feed tokens",
+			tokenIssueCmd(open)),
+		newGroupCmd("backup", "Dump and restore the database",
+			backupCreateCmd(open), backupRestoreCmd(open)),
+	)
+	return root
+}
+
+// newGroupCmd holds subcommands. cobra answers a group without a runnable
+// body with its help and exit status 0 even for a mistyped subcommand;
+// here that is an error.
+func newGroupCmd(use, short string, subcommands ...*cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return cmd.Help()
+			}
+			return fmt.Errorf("unknown command %q for %q", args[0], cmd.CommandPath())
+		},
+	}
+	cmd.AddCommand(subcommands...)
+	return cmd
+}
+
+// requireID rejects a missing or non-positive --name ID flag.
+func requireID(name string, id int64) error {
+	if id <= 0 {
+		return fmt.Errorf("--%s is required", name)
+	}
+	return nil
+}
+
+/* ───────── source ───────── */
+
+func sourceAddCmd(open opener) *cobra.Command {
+	in := srcUC.CreateInput{}
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Register a feed source",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			a, err := open(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if err := a.sources.Create(cmd.Context(), in); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "created source %q (%s)\n", in.Name, in.FeedURL)
+			return nil
+		},
+	}
+	f := cmd.Flags()
+	f.StringVar(&in.Name, "name", "", "display name (required)")
+	f.StringVar(&in.FeedURL, "feed-url", "", "feed URL (required)")
+	f.StringVar(&in.Category, "category", "", "radio corner category (required)")
+	f.StringVar(&in.Lang, "lang", "", "language (default: en)")
+	f.StringVar(&in.Kind, "kind", "", "rss | youtube | podcast (default: rss)")
+	f.StringVar(&in.CrawlSchedule, "schedule", "", "own crawl schedule, cron or interval (default: CRON_SCHEDULE)")
+	f.IntVar(&in.RetentionDays, "retention-days", 0, "own article retention in days (default: RETENTION_DAYS)")
+	f.BoolVar(&in.RenderJS, "render-js", false, "fetch article pages with the headless browser")
+	return cmd
+}
+
+func sourceListCmd(open opener) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List sources",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			a, err := open(cmd.Context())
+			if err != nil {
+				return err
+			}
+			sources, err := a.sources.List(cmd.Context())
+			if err != nil {
+				return err
+			}
+			tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "ID\tNAME\tKIND\tCATEGORY\tACTIVE\tFEED URL")
+			for _, src := range sources {
+				fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%t\t%s\n", src.ID, src.Name, src.Kind, src.Category, src.Active, src.FeedURL)
+			}
+			return tw.Flush()
+		},
+	}
+}
+
+func sourceDisableCmd(open opener) *cobra.Command {
+	var id int64
+	cmd := &cobra.Command{
+		Use:   "disable",
+		Short: "Stop crawling a source",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := requireID("id", id); err != nil {
+				return err
+			}
+			a, err := open(cmd.Context())
+			if err != nil {
+				return err
+			}
+			active := false
+			if err := a.sources.Update(cmd.Context(), srcUC.UpdateInput{ID: id, Active: &active}); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "disabled source %d\n", id)
+			return nil
+		},
+	}
+	cmd.Flags().Int64Var(&id, "id", 0, "source ID (required)")
+	return cmd
+}
+
+/* ───────── article ───────── */
+
+func articleDeleteCmd(open opener) *cobra.Command {
+	var id int64
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete an article",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := requireID("id", id); err != nil {
+				return err
+			}
+			a, err := open(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if err := a.articles.Delete(cmd.Context(), id); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "deleted article %d\n", id)
+			return nil
+		},
+	}
+	cmd.Flags().Int64Var(&id, "id", 0, "article ID (required)")
+	return cmd
+}
+
+/* ───────── crawl ───────── */
+
+func crawlTriggerCmd(open opener) *cobra.Command {
+	var sourceID int64
+	cmd := &cobra.Command{
+		Use:   "trigger",
+		Short: "Enqueue an immediate crawl for the worker",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			a, err := open(cmd.Context())
+			if err != nil {
+				return err
+			}
+			var job *entity.Job
+			if sourceID != 0 {
+				job, err = a.crawl.TriggerSource(cmd.Context(), sourceID)
+			} else {
+				job, err = a.crawl.TriggerAll(cmd.Context())
+			}
+			if err != nil {
+				return err
+			}
+			// ジョブは worker が拾う(ここでは積むだけ)
+			fmt.Fprintf(cmd.OutOrStdout(), "enqueued crawl job %d\n", job.ID)
+			return nil
+		},
+	}
+	cmd.Flags().Int64Var(&sourceID, "source", 0, "crawl only this source (default: every active source)")
+	return cmd
+}
+
+/* ───────── user ───────── */
+
+func userCreateCmd(open opener) *cobra.Command {
+	in := userUC.CreateInput{}
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a dashboard account (password on stdin)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			password, err := readPassword(cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			in.Password = password
+			a, err := open(cmd.Context())
+			if err != nil {
+				return err
+			}
+			user, err := a.users.Create(cmd.Context(), in)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "created user %d (%s, %s)\n", user.ID, user.Email, user.Role)
+			return nil
+		},
+	}
+	f := cmd.Flags()
+	f.StringVar(&in.Name, "name", "", "display name (required)")
+	f.StringVar(&in.Email, "email", "", "login email (required)")
+	f.StringVar(&in.Role, "role", userUC.RoleViewer, "admin | viewer | an AUTH_ROLES role")
+	return cmd
+}
+
+func userResetPasswordCmd(open opener) *cobra.Command {
+	var (
+		id    int64
+		email string
+	)
+	cmd := &cobra.Command{
+		Use:   "reset-password",
+		Short: "Replace an account's password (password on stdin)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if (id > 0) == (email != "") {
+				return errors.New("exactly one of --id and --email is required")
+			}
+			password, err := readPassword(cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			a, err := open(cmd.Context())
+			if err != nil {
+				return err
+			}
+			user, err := findUser(cmd.Context(), a.users, id, email)
+			if err != nil {
+				return err
+			}
+			if _, err := a.users.Update(cmd.Context(), user.ID, userUC.UpdateInput{
+				Name:     user.Name,
+				Email:    user.Email,
+				Role:     user.Role,
+				Password: &password,
+			}); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "reset password of user %d (%s)\n", user.ID, user.Email)
+			return nil
+		},
+	}
+	cmd.Flags().Int64Var(&id, "id", 0, "user ID (this or --email)")
+	cmd.Flags().StringVar(&email, "email", "", "login email (this or --id)")
+	return cmd
+}
+
+// findUser looks a user up by ID or, including deactivated accounts, by
+// email (case-insensitive like login).
+func findUser(ctx context.Context, users *userUC.Service, id int64, email string) (*entity.User, error) {
+	if id > 0 {
+		return users.Get(ctx, id)
+	}
+	all, err := users.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range all {
+		if strings.EqualFold(user.Email, email) {
+			return user, nil
+		}
+	}
+	return nil, userUC.ErrUserNotFound
+}
+
+// readPassword reads the first line of r without its line ending. Length
+// rules are the use case's (user.MinPasswordLength).
+func readPassword(r io.Reader) (string, error) {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("read password: %w", err)
+	}
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", errors.New("password is required on stdin")
+	}
+	return password, nil
+}
+
+/* ───────── token ───────── */
+
+// tokenIssueCmd prints the plaintext once, like POST
+// /subscribers/{id}/tokens; only its hash is stored.
+func tokenIssueCmd(open opener) *cobra.Command {
+	var subscriberID int64
+	cmd := &cobra.Command{
+		Use:   "issue",
+		Short: "Issue a subscriber feed token",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := requireID("subscriber", subscriberID); err != nil {
+				return err
+			}
+			a, err := open(cmd.Context())
+			if err != nil {
+				return err
+			}
+			token, plaintext, err := a.subscribers.IssueToken(cmd.Context(), subscriberID)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "token id: %d\ntoken:    %s\nfeed url: %s/feeds/%s/feed.xml\n",
+				token.ID, plaintext, a.feedBaseURL, plaintext)
+			return nil
+		},
+	}
+	cmd.Flags().Int64Var(&subscriberID, "subscriber", 0, "subscriber ID (required)")
+	return cmd
+}
+
+/* ───────── backup ───────── */
+
+func backupCreateCmd(open opener) *cobra.Command {
+	var (
+		output         string
+		compress       bool
+		withoutContent bool
+	)
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Dump sources, accounts and articles to a file or stdout",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			// 接続できてから出力先を開く(既存ファイルを空にしないため)
+			a, err := open(cmd.Context())
+			if err != nil {
+				return err
+			}
+			var w io.Writer = cmd.OutOrStdout()
+			var file *os.File
+			if output != "-" {
+				f, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = f.Close() }()
+				file, w = f, f
+			}
+			var zw *gzip.Writer
+			if compress || strings.HasSuffix(output, ".gz") {
+				zw = gzip.NewWriter(w)
+				w = zw
+			}
+			bw := bufio.NewWriter(w)
+
+			counts, err := db.Backup(cmd.Context(), a.db, bw, db.BackupOptions{WithoutContent: withoutContent})
+			if err != nil {
+				return err
+			}
+			if err := bw.Flush(); err != nil {
+				return err
+			}
+			if zw != nil {
+				if err := zw.Close(); err != nil {
+					return err
+				}
+			}
+			if file == nil {
+				// the dump is on stdout; no summary
+				return nil
+			}
+			if err := file.Close(); err != nil {
+				return err
+			}
+			return printTableCounts(cmd.OutOrStdout(), counts)
+		},
+	}
+	f := cmd.Flags()
+	f.StringVarP(&output, "output", "o", "-", "output file (- = stdout)")
+	f.BoolVar(&compress, "gzip", false, "gzip the dump (implied by an --output ending in .gz)")
+	f.BoolVar(&withoutContent, "without-content", false, "leave out article full text (articles.content, article_contents)")
+	return cmd
+}
+
+func backupRestoreCmd(open opener) *cobra.Command {
+	var (
+		input   string
+		replace bool
+	)
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Load a dump from a file or stdin",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			r := cmd.InOrStdin()
+			if input != "-" {
+				f, err := os.Open(input)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = f.Close() }()
+				r = f
+			}
+			br := bufio.NewReader(r)
+			if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+				zr, err := gzip.NewReader(br)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = zr.Close() }()
+				r = zr
+			} else {
+				r = br
+			}
+
+			a, err := open(cmd.Context())
+			if err != nil {
+				return err
+			}
+			res, err := db.Restore(cmd.Context(), a.db, r, db.RestoreOptions{Replace: replace})
+			if errors.Is(err, db.ErrRestoreTargetNotEmpty) {
+				return fmt.Errorf("%w (use --replace to overwrite)", err)
+			}
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			if err := printTableCounts(out, res.Restored); err != nil {
+				return err
+			}
+			if len(res.Cleared) == 0 {
+				return nil
+			}
+			fmt.Fprintln(out, "\nCleared by --replace (not in the dump, not restored):")
+			return printTableCounts(out, res.Cleared)
+		},
+	}
+	f := cmd.Flags()
+	f.StringVarP(&input, "input", "i", "-", "dump file, plain or gzipped (- = stdin)")
+	f.BoolVar(&replace, "replace", false, "empty the dumped tables, and the tables referencing them, first")
+	return cmd
+}
+
+func printTableCounts(w io.Writer, counts []db.TableCount) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TABLE\tROWS")
+	for _, c := range counts {
+		fmt.Fprintf(tw, "%s\t%d\n", c.Table, c.Rows)
+	}
+	return tw.Flush()
+}
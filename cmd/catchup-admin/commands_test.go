@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/* ───────── command tree ───────── */
+
+// execute runs catchup-admin with args against database, which the opener
+// hands out without any configuration.
+func execute(t *testing.T, database *sql.DB, stdin string, args ...string) (string, error) {
+	t.Helper()
+	root := newRootCmd(func(context.Context) (*app, error) {
+		return newApp(database, nil), nil
+	})
+	var out bytes.Buffer
+	root.SetIn(strings.NewReader(stdin))
+	root.SetOut(&out)
+	root.SetErr(&out)
+	root.SetArgs(args)
+	err := root.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+func TestRootCmd_HasEveryCommand(t *testing.T) {
+	root := newRootCmd(nil)
+	for _, path := range []string{
+		"source add", "source list", "source disable",
+		"article delete",
+		"crawl trigger",
+		"user create", "user reset-password",
+		"token issue",
+		"backup create", "backup restore",
+	} {
+		cmd, _, err := root.Find(strings.Fields(path))
+		require.NoError(t, err, path)
+		assert.Equal(t, "catchup-admin "+path, cmd.CommandPath())
+	}
+}
+
+func TestRootCmd_Usage(t *testing.T) {
+	out, err := execute(t, nil, "", "--help")
+	require.NoError(t, err)
+	for _, group := range []string{"source", "article", "crawl", "user", "token", "backup"} {
+		assert.Contains(t, out, group)
+	}
+
+	out, err = execute(t, nil, "", "user")
+	require.NoError(t, err, "a bare group prints its help")
+	assert.Contains(t, out, "reset-password")
+
+	_, err = execute(t, nil, "", "source", "purge")
+	assert.EqualError(t, err, `unknown command "purge" for "catchup-admin source"`)
+
+	_, err = execute(t, nil, "", "purge")
+	assert.ErrorContains(t, err, `unknown command "purge" for "catchup-admin"`)
+}
+
+/* ───────── argument validation ───────── */
+
+// Invalid arguments are rejected before any query: the mock expects none.
+func TestCommands_RejectInvalidArgs(t *testing.T) {
+	tests := []struct {
+		args    []string
+		stdin   string
+		wantErr string
+	}{
+		{[]string{"source", "disable"}, "", "--id is required"},
+		{[]string{"source", "disable", "12"}, "", `unknown command "12" for "catchup-admin source disable"`},
+		{[]string{"article", "delete", "--id", "-3"}, "", "--id is required"},
+		{[]string{"token", "issue"}, "", "--subscriber is required"},
+		{[]string{"user", "reset-password", "--password", "x"}, "", "unknown flag: --password"},
+		{[]string{"user", "reset-password"}, "longenoughpassword\n", "exactly one of --id and --email is required"},
+		{[]string{"user", "reset-password", "--id", "1", "--email", "a@example.com"}, "longenoughpassword\n", "exactly one of --id and --email is required"},
+		{[]string{"user", "reset-password", "--id", "1"}, "", "password is required on stdin"},
+		{[]string{"backup", "create", "-o"}, "", "flag needs an argument: 'o' in -o"},
+		{[]string{"backup", "restore"}, "not a dump\n", "restore: read header"},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = database.Close() }()
+
+			_, err = execute(t, database, tt.stdin, tt.args...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// A command that runs prints no usage with its error: only flag and
+// argument mistakes do.
+func TestCommands_RunErrorsSkipUsage(t *testing.T) {
+	database, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = database.Close() }()
+	// the audit "before" read of the article
+	mock.ExpectQuery("SELECT").WillReturnError(errors.New("connection refused"))
+
+	out, err := execute(t, database, "", "article", "delete", "--id", "3")
+	assert.ErrorContains(t, err, "connection refused")
+	assert.NotContains(t, out, "Usage:")
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	out, err = execute(t, database, "", "article", "delete", "--idd", "3")
+	require.Error(t, err)
+	assert.Contains(t, out, "Usage:")
+}
+
+/* ───────── readPassword ───────── */
+
+func TestReadPassword(t *testing.T) {
+	password, err := readPassword(strings.NewReader("s3cret pass\r\nignored\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret pass", password)
+
+	password, err = readPassword(strings.NewReader("no-newline"))
+	require.NoError(t, err)
+	assert.Equal(t, "no-newline", password)
+
+	_, err = readPassword(strings.NewReader("\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,111 @@
+// Command catchup-admin runs operational tasks directly against the
+// database through the same use cases as the admin API, for when the
+// dashboard is unavailable or a task is scripted (cron, deploy hooks).
+// Mutations are recorded in audit_logs with the actor "cli:<$USER>".
+//
+// Usage:
+//
+//	catchup-admin source add --name 'Go Blog' --feed-url https://go.dev/blog/feed.atom --category tech
+//	catchup-admin source list
+//	catchup-admin source disable --id 12
+//	catchup-admin article delete --id 345
+//	catchup-admin crawl trigger [--source 12]
+//	printf '%s' 'password' | catchup-admin user create --name Alice --email alice@example.com --role admin
+//	printf '%s' 'password' | catchup-admin user reset-password --email alice@example.com
+//	catchup-admin token issue --subscriber 3
+//	catchup-admin backup create -o catchup-feed.jsonl.gz [--without-content]
+//	catchup-admin backup restore -i catchup-feed.jsonl.gz [--replace]
+//
+// Passwords are read from stdin (first line) so they stay out of the shell
+// history and the process list. It reads the same DATABASE_URL /
+// CONFIG_FILE / secret references as the server; in the Compose setup run
+// it as `docker compose exec app catchup-admin ...`.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"catchup-feed/internal/feed"
+	hauth "catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/infra/db"
+	"catchup-feed/internal/infra/secrets"
+	"catchup-feed/internal/pkg/logging"
+	"catchup-feed/internal/usecase/audit"
+	pkgconfig "catchup-feed/pkg/config"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx = audit.WithMeta(ctx, audit.Meta{Actor: cliActor()})
+
+	var closeDB func()
+	defer func() {
+		if closeDB != nil {
+			closeDB()
+		}
+	}()
+	// 使い方の誤りや -h は DB に繋ぐ前に cobra が返す(open はコマンド実行時のみ)
+	root := newRootCmd(func(ctx context.Context) (*app, error) {
+		a, closeFn, err := openApp(ctx)
+		closeDB = closeFn
+		return a, err
+	})
+	return root.ExecuteContext(ctx)
+}
+
+// openApp reads the configuration and connects the database the way
+// cmd/server does. The returned func closes the database; it is non-nil
+// whenever the database was opened, even if the ping failed.
+func openApp(ctx context.Context) (*app, func(), error) {
+	// CONFIG_FILE は LOG_LEVEL などを読む前に反映する(環境変数が優先)
+	if _, err := pkgconfig.ApplyFileFromEnv(); err != nil {
+		return nil, nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	logger := logging.Init()
+	// vault:// / awssm:// の参照を実値に置き換える(DATABASE_URL を読む前)
+	if _, err := secrets.ResolveEnv(ctx, logger); err != nil {
+		return nil, nil, fmt.Errorf("resolve secrets: %w", err)
+	}
+	roles, err := hauth.LoadRoles()
+	if err != nil {
+		return nil, nil, fmt.Errorf("load auth roles: %w", err)
+	}
+
+	database := db.Open()
+	closeFn := func() {
+		if err := database.Close(); err != nil {
+			logger.Error("failed to close database", slog.Any("error", err))
+		}
+	}
+	if err := database.PingContext(ctx); err != nil {
+		return nil, closeFn, fmt.Errorf("connect database: %w", err)
+	}
+
+	a := newApp(database, logger)
+	a.users.CustomRoles = roles.Custom()
+	a.feedBaseURL = feed.LoadConfig().PublicBaseURL
+	return a, closeFn, nil
+}
+
+// cliActor is the audit actor of CLI mutations: the OS user when known.
+func cliActor() string {
+	if user := os.Getenv("USER"); user != "" {
+		return "cli:" + user
+	}
+	return "cli"
+}
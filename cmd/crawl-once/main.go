@@ -17,12 +17,29 @@ import (
 	"catchup-feed/internal/infra/db"
 	"catchup-feed/internal/infra/fetcher"
 	"catchup-feed/internal/infra/scraper"
+	"catchup-feed/internal/infra/secrets"
 	"catchup-feed/internal/infra/summarizer"
+	workerPkg "catchup-feed/internal/infra/worker"
+	"catchup-feed/internal/pkg/logging"
 	fetchUC "catchup-feed/internal/usecase/fetch"
+	pkgconfig "catchup-feed/pkg/config"
 )
 
 func main() {
-	logger := initLogger()
+	// CONFIG_FILE は LOG_LEVEL などを読む前に反映する(環境変数が優先)
+	fileCfg, fileErr := pkgconfig.ApplyFileFromEnv()
+	logger := logging.Init()
+	if fileErr != nil {
+		logger.Error("invalid configuration", slog.Any("error", fileErr))
+		os.Exit(1)
+	}
+	fileCfg.LogSummary(logger)
+	// vault:// / awssm:// の参照を実値に置き換える(各設定を読む前)
+	_, secretErr := secrets.ResolveEnv(context.Background(), logger)
+	if secretErr != nil {
+		logger.Error("failed to resolve secrets", slog.Any("error", secretErr))
+		os.Exit(1)
+	}
 	logger.Info("Starting one-time crawl...")
 
 	database := db.Open()
@@ -37,10 +54,18 @@ func main() {
 
 	// Setup fetch service
 	svc := setupFetchService(logger, database)
+	// Same source pool and near-duplicate threshold as the worker
+	// (CRAWL_CONCURRENCY / CRAWL_SOURCE_TIMEOUT / NEAR_DUPLICATE_MAX_DISTANCE).
+	workerConfig, _ := workerPkg.LoadConfigFromEnv(logger)
+	svc.SourceConcurrency = workerConfig.CrawlConcurrency
+	svc.SourceTimeout = workerConfig.CrawlSourceTimeout
+	svc.NearDuplicateMaxDistance = workerConfig.NearDuplicateMaxDistance
 
 	// Execute crawl with 30-minute timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
 	defer cancel()
+	usage := summarizer.NewUsageMeter()
+	ctx = summarizer.WithUsageMeter(ctx, usage)
 
 	logger.Info("Crawling all sources...")
 	stats, err := svc.CrawlAllSources(ctx)
@@ -51,26 +76,21 @@ func main() {
 
 	logger.Info("Crawl completed successfully",
 		slog.Int("sources", stats.Sources),
+		slog.Int("fetch_failed_sources", stats.FetchFailedSources()),
 		slog.Int64("feed_items", stats.FeedItems),
 		slog.Int64("inserted", stats.Inserted),
 		slog.Int64("duplicated", stats.Duplicated),
+		slog.Int64("duplicated_by_hash", stats.DuplicatedByHash),
+		slog.Int64("near_duplicates", stats.NearDuplicates),
+		slog.Int64("filtered_junk", stats.FilteredJunk),
 		slog.Int64("summarize_errors", stats.SummarizeError),
+		slog.Int64("prompt_tokens", usage.Total().PromptTokens),
+		slog.Int64("completion_tokens", usage.Total().CompletionTokens),
+		slog.Any("token_usage", usage),
 		slog.Duration("duration", stats.Duration),
 	)
 }
 
-func initLogger() *slog.Logger {
-	logLevel := slog.LevelInfo
-	if os.Getenv("LOG_LEVEL") == "debug" {
-		logLevel = slog.LevelDebug
-	}
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-		Level: logLevel,
-	}))
-	slog.SetDefault(logger)
-	return logger
-}
-
 func waitForMigrations(logger *slog.Logger, db *sql.DB) {
 	const probe = "SELECT 1 FROM sources LIMIT 1"
 	for i := range 10 {
@@ -100,7 +120,11 @@ func setupFetchService(logger *slog.Logger, database *sql.DB) fetchUC.Service {
 	}
 
 	httpClient := createHTTPClient(contentFetchConfig.MaxRedirects, contentFetchConfig.DenyPrivateIPs)
-	feedFetcher := scraper.NewRSSFetcher(httpClient)
+	feedFetcher, err := scraper.NewFeedFetcherFromEnv(httpClient, logger)
+	if err != nil {
+		logger.Error("failed to load scraper config", slog.Any("error", err))
+		os.Exit(1)
+	}
 
 	// Create ContentFetcher if enabled
 	var contentFetcher fetchUC.ContentFetcher
@@ -120,7 +144,7 @@ func setupFetchService(logger *slog.Logger, database *sql.DB) fetchUC.Service {
 		Threshold:   contentFetchConfig.Threshold,
 	}
 
-	return fetchUC.NewService(
+	svc := fetchUC.NewService(
 		srcRepo,
 		artRepo,
 		sum,
@@ -128,6 +152,23 @@ func setupFetchService(logger *slog.Logger, database *sql.DB) fetchUC.Service {
 		contentFetcher,
 		fetchConfig,
 	)
+	// A manual crawl updates source health and the crawl history like the
+	// scheduled one.
+	svc.HealthRepo = pgRepo.NewSourceHealthRepo(database)
+	svc.ContentRepo = pgRepo.NewArticleContentRepo(database)
+	svc.RunRepo = pgRepo.NewCrawlRunRepo(database)
+	// Skip the sources a running worker is crawling right now.
+	svc.Locker = workerPkg.NewAdvisoryLocker(database, logger)
+	svc.NearDuplicates = pgRepo.NewArticleSimilarityRepo(database)
+	svc.Quality = fetchUC.LoadQualityFilterFromEnv(logger)
+	// HEADLESS_ENABLED: render_js sources are fetched with headless Chrome
+	// (killed with the process, like in cmd/worker).
+	if cfg, err := fetcher.LoadHeadlessConfigFromEnv(); err != nil {
+		logger.Warn("Headless fetching disabled due to configuration error", slog.Any("error", err))
+	} else if cfg.Enabled {
+		svc.HeadlessFetcher = fetcher.NewHeadlessFetcher(cfg, contentFetchConfig)
+	}
+	return svc
 }
 
 // createSummarizer builds the Gemini -> Groq -> Ollama fallback chain from
@@ -140,6 +181,9 @@ func createSummarizer(logger *slog.Logger) fetchUC.Summarizer {
 			slog.Any("error", err))
 		return summarizer.NewNoOp()
 	}
+	if cfg := summarizer.LoadBatchConfig(summarizer.LoadOptions()); cfg.Enabled() {
+		return summarizer.NewBatcher(chain, cfg, logger)
+	}
 	return chain
 }
 
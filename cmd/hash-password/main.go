@@ -1,5 +1,6 @@
 // Command hash-password generates a bcrypt hash for the ADMIN_PASSWORD_HASH
-// environment variable (C-7/C-20: 管理者資格情報は環境変数+bcrypt)。
+// environment variable (C-7/C-20: 管理者資格情報は bcrypt。環境変数は最初の
+// admin を users テーブルに作るブートストラップ用)。
 //
 // Usage:
 //
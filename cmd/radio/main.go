@@ -34,9 +34,11 @@ import (
 	"catchup-feed/internal/domain/entity"
 	pgRepo "catchup-feed/internal/infra/adapter/persistence/postgres"
 	"catchup-feed/internal/infra/db"
+	"catchup-feed/internal/infra/secrets"
 	"catchup-feed/internal/infra/summarizer"
 	"catchup-feed/internal/jobs"
 	"catchup-feed/internal/learning"
+	"catchup-feed/internal/pkg/logging"
 	"catchup-feed/internal/radio"
 	"catchup-feed/internal/repository"
 	"catchup-feed/internal/script"
@@ -57,7 +59,20 @@ func main() {
 	sinceFlag := flag.String("since", "", "article selection cursor override (RFC 3339)")
 	flag.Parse()
 
+	// CONFIG_FILE は LOG_LEVEL などを読む前に反映する(環境変数が優先)
+	fileCfg, fileErr := pkgconfig.ApplyFileFromEnv()
 	logger := initLogger()
+	if fileErr != nil {
+		logger.Error("invalid configuration", slog.Any("error", fileErr))
+		os.Exit(1)
+	}
+	fileCfg.LogSummary(logger)
+	// vault:// / awssm:// の参照を実値に置き換える(各設定を読む前)
+	_, secretErr := secrets.ResolveEnv(context.Background(), logger)
+	if secretErr != nil {
+		logger.Error("failed to resolve secrets", slog.Any("error", secretErr))
+		os.Exit(1)
+	}
 
 	opts := radio.RunOptions{DryRun: *dryRun}
 	if *sinceFlag != "" {
@@ -173,13 +188,9 @@ func enqueueFailureNotice(logger *slog.Logger, queue repository.JobRepository, r
 }
 
 func initLogger() *slog.Logger {
-	logLevel := slog.LevelInfo
-	if os.Getenv("LOG_LEVEL") == "debug" {
-		logLevel = slog.LevelDebug
-	}
 	// Dry-run prints scripts to stdout; keep logs on stderr so the two
 	// streams stay separable.
-	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel}))
+	logger := logging.New(os.Stderr, logging.LevelFromEnv())
 	slog.SetDefault(logger)
 	return logger
 }
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	hhttp "catchup-feed/internal/handler/http"
+	hauth "catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/handler/http/middleware"
+	"catchup-feed/internal/infra/cache"
+	"catchup-feed/pkg/config"
+)
+
+// certCheckInterval throttles how often the TLS certificate files are
+// checked for a renewal (certbot / cert-manager replace them in place).
+const certCheckInterval = 30 * time.Second
+
+// httpServerConfig is the public listener configuration.
+//
+// Environment variables:
+//   - HTTP_ADDR (default ":8080")
+//   - HTTP_READ_HEADER_TIMEOUT (default 10s, Slowloris protection)
+//   - HTTP_READ_TIMEOUT / HTTP_WRITE_TIMEOUT (default 0 = none). The SSE
+//     and export endpoints lift the write deadline for their streams.
+//   - HTTP_IDLE_TIMEOUT (default 120s)
+//   - HTTP_MAX_HEADER_BYTES (default 1 MiB)
+//   - TLS_CERT_FILE / TLS_KEY_FILE: serve HTTPS directly (both or neither)
+type httpServerConfig struct {
+	Addr              string
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	TLSCertFile       string
+	TLSKeyFile        string
+}
+
+func loadHTTPServerConfig() (httpServerConfig, error) {
+	cfg := httpServerConfig{
+		Addr:              config.GetEnvString("HTTP_ADDR", ":8080"),
+		ReadHeaderTimeout: config.GetEnvDuration("HTTP_READ_HEADER_TIMEOUT", 10*time.Second),
+		ReadTimeout:       config.GetEnvDuration("HTTP_READ_TIMEOUT", 0),
+		WriteTimeout:      config.GetEnvDuration("HTTP_WRITE_TIMEOUT", 0),
+		IdleTimeout:       config.GetEnvDuration("HTTP_IDLE_TIMEOUT", 120*time.Second),
+		MaxHeaderBytes:    config.GetEnvInt("HTTP_MAX_HEADER_BYTES", http.DefaultMaxHeaderBytes),
+		TLSCertFile:       os.Getenv("TLS_CERT_FILE"),
+		TLSKeyFile:        os.Getenv("TLS_KEY_FILE"),
+	}
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		return cfg, errors.New("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	if cfg.ReadHeaderTimeout <= 0 {
+		return cfg, fmt.Errorf("HTTP_READ_HEADER_TIMEOUT must be positive, got %v", cfg.ReadHeaderTimeout)
+	}
+	if cfg.MaxHeaderBytes <= 0 {
+		return cfg, fmt.Errorf("HTTP_MAX_HEADER_BYTES must be positive, got %d", cfg.MaxHeaderBytes)
+	}
+	return cfg, nil
+}
+
+// TLS reports whether the listener serves HTTPS itself.
+func (c httpServerConfig) TLS() bool { return c.TLSCertFile != "" }
+
+// newHTTPServer builds the public server. With TLS configured the
+// certificate is loaded (and validated) here and reloaded when its files
+// change, so a renewal needs no restart.
+func newHTTPServer(ctx context.Context, cfg httpServerConfig, handler http.Handler, logger *slog.Logger) (*http.Server, error) {
+	srv := &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+		BaseContext: func(_ net.Listener) context.Context {
+			return ctx
+		},
+	}
+	if cfg.TLS() {
+		certs, err := newCertReloader(cfg.TLSCertFile, cfg.TLSKeyFile, logger)
+		if err != nil {
+			return nil, err
+		}
+		srv.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: certs.GetCertificate,
+		}
+	}
+	return srv, nil
+}
+
+// certReloader serves a certificate / key pair from disk and picks up a
+// replaced pair on a later handshake. A pair that fails to load (e.g.
+// half-written during renewal) keeps the previous one in use and is
+// retried on the next check.
+type certReloader struct {
+	certFile, keyFile string
+	interval          time.Duration
+	logger            *slog.Logger
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time
+	checked time.Time
+}
+
+func newCertReloader(certFile, keyFile string, logger *slog.Logger) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile, interval: certCheckInterval, logger: logger}
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return nil, err
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load TLS certificate: %w", err)
+	}
+	r.cert, r.modTime, r.checked = &cert, modTime, time.Now()
+	return r, nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate.
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if time.Since(r.checked) >= r.interval {
+		r.checked = time.Now()
+		r.reloadIfChanged()
+	}
+	return r.cert, nil
+}
+
+func (r *certReloader) reloadIfChanged() {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		r.logger.Warn("TLS certificate check failed, keeping current certificate", slog.Any("error", err))
+		return
+	}
+	if !modTime.After(r.modTime) {
+		return
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		r.logger.Warn("TLS certificate reload failed, keeping current certificate", slog.Any("error", err))
+		return
+	}
+	r.cert, r.modTime = &cert, modTime
+	r.logger.Info("TLS certificate reloaded", slog.String("cert_file", r.certFile))
+}
+
+func (r *certReloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, path := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("stat TLS file: %w", err)
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// defaultHandlerTimeoutRoutes run without the per-request deadline: the
+// SSE stream, WebSocket and export are open-ended, and a PDF upload or mp3
+// download is bounded by the client's bandwidth, not by the server.
+var defaultHandlerTimeoutRoutes = map[string]time.Duration{
+	"GET /articles/events":               0,
+	"GET /ws":                            0,
+	"GET /articles/export":               0,
+	"POST /books":                        0,
+	"GET /feeds/{token}/episodes/{file}": 0,
+}
+
+// loadHandlerTimeout builds the per-request deadline middleware.
+//
+// Environment variables:
+//   - HTTP_HANDLER_TIMEOUT: deadline of every handler (default 30s, 0 = none)
+//   - HTTP_HANDLER_TIMEOUT_ROUTES: comma-separated "PATTERN=DURATION"
+//     overrides using ServeMux patterns, e.g.
+//     "POST /sources/import=2m,GET /sources/export.opml=0"
+//     (0 = no deadline). They extend defaultHandlerTimeoutRoutes.
+func loadHandlerTimeout() (func(http.Handler) http.Handler, error) {
+	defaultTimeout := config.GetEnvDuration("HTTP_HANDLER_TIMEOUT", 30*time.Second)
+	routes := maps.Clone(defaultHandlerTimeoutRoutes)
+	for _, entry := range config.GetEnvStringList("HTTP_HANDLER_TIMEOUT_ROUTES", nil) {
+		i := strings.LastIndex(entry, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("HTTP_HANDLER_TIMEOUT_ROUTES: %q: want PATTERN=DURATION", entry)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(entry[i+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("HTTP_HANDLER_TIMEOUT_ROUTES: %q: %w", entry, err)
+		}
+		routes[strings.TrimSpace(entry[:i])] = d
+	}
+	return hhttp.TimeoutPerRoute(defaultTimeout, routes)
+}
+
+// loadInboundWebhookSignature builds the inbound webhook verification
+// middleware; nil when no endpoint has a secret.
+//
+// Environment variables:
+//   - WEBHOOK_INBOUND_SECRETS: comma-separated "PATH=SECRET" pairs, e.g.
+//     "/hooks/websub=s3cret". Repeat a path to accept several secrets
+//     while rotating one.
+//   - WEBHOOK_INBOUND_TOLERANCE: accepted clock difference of the
+//     delivery timestamp (default 5m)
+func loadInboundWebhookSignature(logger *slog.Logger) (func(http.Handler) http.Handler, error) {
+	secrets := make(map[string][]string)
+	for _, entry := range config.GetEnvStringList("WEBHOOK_INBOUND_SECRETS", nil) {
+		path, secret, ok := strings.Cut(entry, "=")
+		path, secret = strings.TrimSpace(path), strings.TrimSpace(secret)
+		if !ok || !strings.HasPrefix(path, "/") || secret == "" {
+			// The entry holds a secret: report only the path
+			return nil, fmt.Errorf("WEBHOOK_INBOUND_SECRETS: entry for %q: want PATH=SECRET", path)
+		}
+		secrets[path] = append(secrets[path], secret)
+	}
+	if len(secrets) == 0 {
+		return nil, nil
+	}
+	tolerance := config.GetEnvDuration("WEBHOOK_INBOUND_TOLERANCE", middleware.DefaultWebhookTolerance)
+	if tolerance <= 0 {
+		return nil, fmt.Errorf("WEBHOOK_INBOUND_TOLERANCE: must be positive, got %s", tolerance)
+	}
+	logger.Info("inbound webhook signatures enabled",
+		slog.Any("paths", slices.Sorted(maps.Keys(secrets))),
+		slog.Duration("tolerance", tolerance))
+	return middleware.WebhookSignature(middleware.WebhookSignatureConfig{
+		Secrets:   secrets,
+		Tolerance: tolerance,
+		Logger:    logger,
+	}), nil
+}
+
+// loadResponseCache builds the HTTP response cache on the read cache's
+// store; nil when disabled.
+//
+// Environment variables:
+//   - RESPONSE_CACHE_ENABLED: cache whole GET responses (default false,
+//     requires CACHE_BACKEND)
+//   - RESPONSE_CACHE_TTL: lifetime of a cached response (default 10s)
+//   - RESPONSE_CACHE_PATHS: comma-separated cacheable paths (default
+//     middleware.DefaultResponseCachePaths). Only list endpoints whose
+//     response does not depend on the caller beyond their role.
+func loadResponseCache(logger *slog.Logger, store cache.Store) (*middleware.ResponseCache, error) {
+	if !config.GetEnvBool("RESPONSE_CACHE_ENABLED", false) {
+		return nil, nil
+	}
+	if store == nil {
+		return nil, fmt.Errorf("RESPONSE_CACHE_ENABLED requires CACHE_BACKEND=memory or redis")
+	}
+	ttl := config.GetEnvDuration("RESPONSE_CACHE_TTL", middleware.DefaultResponseCacheTTL)
+	if ttl <= 0 {
+		return nil, fmt.Errorf("RESPONSE_CACHE_TTL: must be positive, got %s", ttl)
+	}
+	paths := config.GetEnvStringList("RESPONSE_CACHE_PATHS", middleware.DefaultResponseCachePaths)
+	for _, p := range paths {
+		if !strings.HasPrefix(p, "/") {
+			return nil, fmt.Errorf("RESPONSE_CACHE_PATHS: %q: want an absolute path", p)
+		}
+	}
+	logger.Info("response cache enabled",
+		slog.Any("paths", paths),
+		slog.Duration("ttl", ttl))
+	return middleware.NewResponseCache(middleware.ResponseCacheConfig{
+		Store:  store,
+		Role:   hauth.RoleFromContext,
+		Paths:  paths,
+		TTL:    ttl,
+		Logger: logger,
+	}), nil
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/infra/cache"
+)
+
+// writeTestCert writes a self-signed certificate for commonName and
+// returns the cert / key paths.
+func writeTestCert(t *testing.T, dir, commonName string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+func commonName(t *testing.T, cert *tls.Certificate) string {
+	t.Helper()
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	return leaf.Subject.CommonName
+}
+
+/* ───────── loadHTTPServerConfig ───────── */
+
+func TestLoadHTTPServerConfig_Defaults(t *testing.T) {
+	for _, key := range []string{"HTTP_ADDR", "HTTP_READ_HEADER_TIMEOUT", "HTTP_READ_TIMEOUT", "HTTP_WRITE_TIMEOUT",
+		"HTTP_IDLE_TIMEOUT", "HTTP_MAX_HEADER_BYTES", "TLS_CERT_FILE", "TLS_KEY_FILE"} {
+		t.Setenv(key, "")
+	}
+
+	cfg, err := loadHTTPServerConfig()
+	require.NoError(t, err)
+	assert.Equal(t, httpServerConfig{
+		Addr:              ":8080",
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       120 * time.Second,
+		MaxHeaderBytes:    http.DefaultMaxHeaderBytes,
+	}, cfg)
+	assert.False(t, cfg.TLS())
+}
+
+func TestLoadHTTPServerConfig_FromEnv(t *testing.T) {
+	t.Setenv("HTTP_ADDR", "127.0.0.1:9000")
+	t.Setenv("HTTP_READ_TIMEOUT", "15s")
+	t.Setenv("HTTP_WRITE_TIMEOUT", "30s")
+	t.Setenv("HTTP_MAX_HEADER_BYTES", "65536")
+	t.Setenv("TLS_CERT_FILE", "/etc/tls/tls.crt")
+	t.Setenv("TLS_KEY_FILE", "/etc/tls/tls.key")
+
+	cfg, err := loadHTTPServerConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:9000", cfg.Addr)
+	assert.Equal(t, 15*time.Second, cfg.ReadTimeout)
+	assert.Equal(t, 30*time.Second, cfg.WriteTimeout)
+	assert.Equal(t, 65536, cfg.MaxHeaderBytes)
+	assert.True(t, cfg.TLS())
+}
+
+func TestLoadHTTPServerConfig_Invalid(t *testing.T) {
+	t.Run("cert without key", func(t *testing.T) {
+		t.Setenv("TLS_CERT_FILE", "/etc/tls/tls.crt")
+		t.Setenv("TLS_KEY_FILE", "")
+		_, err := loadHTTPServerConfig()
+		assert.Error(t, err)
+	})
+	t.Run("non-positive header limit", func(t *testing.T) {
+		t.Setenv("HTTP_MAX_HEADER_BYTES", "-1")
+		_, err := loadHTTPServerConfig()
+		assert.Error(t, err)
+	})
+}
+
+/* ───────── TLS ───────── */
+
+func TestNewHTTPServer_InvalidCertificate(t *testing.T) {
+	dir := t.TempDir()
+	cfg := httpServerConfig{TLSCertFile: filepath.Join(dir, "missing.crt"), TLSKeyFile: filepath.Join(dir, "missing.key")}
+
+	_, err := newHTTPServer(context.Background(), cfg, http.NotFoundHandler(), testLogger())
+	assert.Error(t, err)
+}
+
+// A renewed certificate written over the old files is served on a later
+// handshake; a broken pair keeps the current certificate.
+func TestCertReloader_PicksUpRenewal(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCert(t, dir, "first")
+	r, err := newCertReloader(certFile, keyFile, testLogger())
+	require.NoError(t, err)
+	r.interval = 0
+
+	cert, err := r.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "first", commonName(t, cert))
+
+	writeTestCert(t, dir, "second")
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, future, future))
+	cert, err = r.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "second", commonName(t, cert))
+
+	require.NoError(t, os.WriteFile(keyFile, []byte("half written"), 0o600))
+	later := future.Add(time.Minute)
+	require.NoError(t, os.Chtimes(keyFile, later, later))
+	cert, err = r.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "second", commonName(t, cert))
+}
+
+/* ───────── loadHandlerTimeout ───────── */
+
+func TestLoadHandlerTimeout(t *testing.T) {
+	t.Setenv("HTTP_HANDLER_TIMEOUT", "20ms")
+	t.Setenv("HTTP_HANDLER_TIMEOUT_ROUTES", "POST /sources/import=0")
+	mw, err := loadHandlerTimeout()
+	require.NoError(t, err)
+	h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(60 * time.Millisecond)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	for _, tc := range []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodGet, "/articles", http.StatusGatewayTimeout},
+		{http.MethodGet, "/articles/events", http.StatusNoContent},
+		{http.MethodPost, "/sources/import", http.StatusNoContent},
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, nil))
+		assert.Equal(t, tc.want, rec.Code, "%s %s", tc.method, tc.path)
+	}
+}
+
+func TestLoadHandlerTimeout_Invalid(t *testing.T) {
+	for _, routes := range []string{"POST /sources/import", "POST /sources/import=soon", "GET /articles/{id=1s"} {
+		t.Setenv("HTTP_HANDLER_TIMEOUT_ROUTES", routes)
+		_, err := loadHandlerTimeout()
+		assert.Error(t, err, routes)
+	}
+}
+
+func TestLoadInboundWebhookSignature(t *testing.T) {
+	t.Setenv("WEBHOOK_INBOUND_SECRETS", "")
+	mw, err := loadInboundWebhookSignature(slog.Default())
+	require.NoError(t, err)
+	assert.Nil(t, mw, "no secrets, no middleware")
+
+	t.Setenv("WEBHOOK_INBOUND_SECRETS", "/hooks/websub=old, /hooks/websub=new==")
+	mw, err = loadInboundWebhookSignature(slog.Default())
+	require.NoError(t, err)
+	h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	body := []byte(`{"ok":true}`)
+	ts := time.Now().Unix()
+	for _, tc := range []struct {
+		name, path, secret string
+		want               int
+	}{
+		{"rotated-in secret", "/hooks/websub", "new==", http.StatusNoContent},
+		{"previous secret", "/hooks/websub", "old", http.StatusNoContent},
+		{"unknown secret", "/hooks/websub", "other", http.StatusUnauthorized},
+		{"unconfigured path", "/articles", "other", http.StatusNoContent},
+	} {
+		req := httptest.NewRequest(http.MethodPost, tc.path, bytes.NewReader(body))
+		req.Header.Set("X-Catchup-Timestamp", strconv.FormatInt(ts, 10))
+		req.Header.Set("X-Catchup-Signature", "sha256="+entity.SignWebhookPayload(tc.secret, ts, body))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		assert.Equal(t, tc.want, rec.Code, tc.name)
+	}
+}
+
+func TestLoadInboundWebhookSignature_Invalid(t *testing.T) {
+	for _, secrets := range []string{"/hooks/websub", "hooks/websub=s", "/hooks/websub="} {
+		t.Setenv("WEBHOOK_INBOUND_SECRETS", secrets)
+		_, err := loadInboundWebhookSignature(slog.Default())
+		assert.Error(t, err, secrets)
+	}
+	t.Setenv("WEBHOOK_INBOUND_SECRETS", "/hooks/websub=s")
+	t.Setenv("WEBHOOK_INBOUND_TOLERANCE", "-1m")
+	_, err := loadInboundWebhookSignature(slog.Default())
+	assert.Error(t, err)
+}
+
+func TestLoadResponseCache(t *testing.T) {
+	t.Setenv("RESPONSE_CACHE_ENABLED", "false")
+	rc, err := loadResponseCache(slog.Default(), cache.NewMemoryStore(10))
+	require.NoError(t, err)
+	assert.Nil(t, rc, "disabled by default")
+
+	t.Setenv("RESPONSE_CACHE_ENABLED", "true")
+	_, err = loadResponseCache(slog.Default(), nil)
+	assert.Error(t, err, "needs a cache backend")
+
+	rc, err = loadResponseCache(slog.Default(), cache.NewMemoryStore(10))
+	require.NoError(t, err)
+	assert.NotNil(t, rc)
+
+	for env, value := range map[string]string{"RESPONSE_CACHE_TTL": "0s", "RESPONSE_CACHE_PATHS": "sources"} {
+		t.Run(env, func(t *testing.T) {
+			t.Setenv(env, value)
+			_, err := loadResponseCache(slog.Default(), cache.NewMemoryStore(10))
+			assert.Error(t, err)
+		})
+	}
+}
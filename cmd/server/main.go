@@ -2,13 +2,16 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"database/sql"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -16,32 +19,75 @@ import (
 	httpSwagger "github.com/swaggo/http-swagger/v2"
 
 	"catchup-feed/internal/common/pagination"
+	"catchup-feed/internal/domain/entity"
 	"catchup-feed/internal/feed"
 	pgRepo "catchup-feed/internal/infra/adapter/persistence/postgres"
+	"catchup-feed/internal/infra/cache"
 	"catchup-feed/internal/infra/db"
+	"catchup-feed/internal/infra/fetcher"
+	"catchup-feed/internal/infra/oidc"
+	"catchup-feed/internal/infra/redis"
+	"catchup-feed/internal/infra/scraper"
+	"catchup-feed/internal/infra/secrets"
+	"catchup-feed/internal/infra/summarizer"
 	learncore "catchup-feed/internal/learning"
+	"catchup-feed/internal/notify"
+	"catchup-feed/internal/pkg/buildinfo"
+	"catchup-feed/internal/pkg/logging"
+	"catchup-feed/internal/pkg/search"
+	"catchup-feed/internal/pkg/shutdown"
 	"catchup-feed/pkg/config"
 	"catchup-feed/pkg/security/csp"
 
 	alUC "catchup-feed/internal/usecase/accesslog"
+	alertUC "catchup-feed/internal/usecase/alert"
+	apikeyUC "catchup-feed/internal/usecase/apikey"
 	artUC "catchup-feed/internal/usecase/article"
+	auditUC "catchup-feed/internal/usecase/audit"
 	bookUC "catchup-feed/internal/usecase/book"
+	crawlUC "catchup-feed/internal/usecase/crawl"
+	dashUC "catchup-feed/internal/usecase/dashboard"
+	favoriteUC "catchup-feed/internal/usecase/favorite"
 	learnUC "catchup-feed/internal/usecase/learning"
+	mfaUC "catchup-feed/internal/usecase/mfa"
+	readstateUC "catchup-feed/internal/usecase/readstate"
+	refreshUC "catchup-feed/internal/usecase/refreshtoken"
 	srcUC "catchup-feed/internal/usecase/source"
+	statsUC "catchup-feed/internal/usecase/stats"
 	subUC "catchup-feed/internal/usecase/subscriber"
+	subscriptionUC "catchup-feed/internal/usecase/subscription"
+	tagUC "catchup-feed/internal/usecase/tag"
+	revocationUC "catchup-feed/internal/usecase/tokenrevocation"
+	userUC "catchup-feed/internal/usecase/user"
 	viewerUC "catchup-feed/internal/usecase/viewer"
+	webhookUC "catchup-feed/internal/usecase/webhook"
 
 	hhttp "catchup-feed/internal/handler/http"
 	haccesslog "catchup-feed/internal/handler/http/accesslog"
+	halert "catchup-feed/internal/handler/http/alert"
+	hapikey "catchup-feed/internal/handler/http/apikey"
 	harticle "catchup-feed/internal/handler/http/article"
+	haudit "catchup-feed/internal/handler/http/audit"
 	hauth "catchup-feed/internal/handler/http/auth"
 	hbook "catchup-feed/internal/handler/http/book"
+	hcrawl "catchup-feed/internal/handler/http/crawl"
+	hdashboard "catchup-feed/internal/handler/http/dashboard"
+	hfavorite "catchup-feed/internal/handler/http/favorite"
 	hlearning "catchup-feed/internal/handler/http/learning"
+	hloglevel "catchup-feed/internal/handler/http/loglevel"
+	hmfa "catchup-feed/internal/handler/http/mfa"
 	"catchup-feed/internal/handler/http/middleware"
+	hratelimit "catchup-feed/internal/handler/http/ratelimit"
+	hreadstate "catchup-feed/internal/handler/http/readstate"
 	"catchup-feed/internal/handler/http/requestid"
 	hsrc "catchup-feed/internal/handler/http/source"
+	hstats "catchup-feed/internal/handler/http/stats"
 	hsub "catchup-feed/internal/handler/http/subscriber"
+	hsubscription "catchup-feed/internal/handler/http/subscription"
+	htag "catchup-feed/internal/handler/http/tag"
+	huser "catchup-feed/internal/handler/http/user"
 	hviewer "catchup-feed/internal/handler/http/viewer"
+	hwebhook "catchup-feed/internal/handler/http/webhook"
 	authservice "catchup-feed/internal/service/auth"
 
 	_ "catchup-feed/docs" // swagger docs
@@ -68,42 +114,115 @@ import (
 // @description JWT トークンによる認証。ヘッダーに "Bearer {token}" 形式で指定してください。
 
 func main() {
-	logger := initLogger()
-	validateAdminCredentials(logger)
+	// CONFIG_FILE は LOG_LEVEL などを読む前に反映する(環境変数が優先)
+	fileCfg, fileErr := config.ApplyFileFromEnv()
+	logger := logging.Init()
+	if fileErr != nil {
+		logger.Error("invalid configuration", slog.Any("error", fileErr))
+		os.Exit(1)
+	}
+	fileCfg.LogSummary(logger)
+	// vault:// / awssm:// の参照を実値に置き換える(各設定を読む前)
+	secretRefs, secretErr := secrets.ResolveEnv(context.Background(), logger)
+	if secretErr != nil {
+		logger.Error("failed to resolve secrets", slog.Any("error", secretErr))
+		os.Exit(1)
+	}
 	validateJWTSecret(logger)
+
+	// 公開リスナーはマイグレーション前に起動し、起動中も /live・/startup・
+	// /ready に答える(長いマイグレーションで Pod を kill させない)。
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	build := buildinfo.Get()
+	startup := hhttp.NewStartupState(hhttp.StartupMigrations, hhttp.StartupCacheWarmup)
+	httpCfg, err := loadHTTPServerConfig()
+	if err != nil {
+		logger.Error("invalid HTTP server configuration", slog.Any("error", err))
+		os.Exit(1)
+	}
+	listener, err := startPublicListener(ctx, httpCfg, startup, build, logger)
+	if err != nil {
+		logger.Error("failed to start HTTP server", slog.Any("error", err))
+		os.Exit(1)
+	}
+
 	database := initDatabase(logger)
 	defer func() {
 		if err := database.Close(); err != nil {
 			logger.Error("failed to close database", slog.Any("error", err))
 		}
 	}()
+	startup.Done(hhttp.StartupMigrations)
 
-	version := getVersion()
-	serverComponents := setupServer(logger, database, version)
-
-	runServer(logger, serverComponents, version)
-}
+	serverComponents := setupServer(logger, database, build, startup)
+	serverComponents.Secrets = secretRefs
+	defer func() {
+		if err := serverComponents.Replica.Close(); err != nil {
+			logger.Error("failed to close read replica", slog.Any("error", err))
+		}
+		if err := serverComponents.Cache.Close(); err != nil {
+			logger.Error("failed to close cache", slog.Any("error", err))
+		}
+	}()
 
-// initLogger initializes and returns a structured logger based on environment configuration.
-func initLogger() *slog.Logger {
-	logLevel := slog.LevelInfo
-	if os.Getenv("LOG_LEVEL") == "debug" {
-		logLevel = slog.LevelDebug
-	}
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-		Level: logLevel,
-	}))
-	slog.SetDefault(logger)
-	return logger
+	runServer(ctx, logger, listener, serverComponents)
 }
 
-// validateAdminCredentials validates the admin credentials at startup.
-// This prevents the server from starting with empty or weak admin credentials.
-func validateAdminCredentials(logger *slog.Logger) {
+// bootstrapAdmin makes sure the users table has an administrator. While it
+// has none, ADMIN_USER / ADMIN_PASSWORD_HASH are required and validated
+// (empty or weak credentials stop the server) and become the first admin
+// account. Once an admin exists the variables are ignored: accounts are
+// managed through /users from then on.
+func bootstrapAdmin(logger *slog.Logger, users *userUC.Service) {
+	ctx := context.Background()
+	exists, err := users.HasActiveAdmin(ctx)
+	if err != nil {
+		logger.Error("failed to check admin accounts", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if exists {
+		if os.Getenv(hauth.EnvAdminUser) != "" {
+			logger.Info("admin accounts exist in the users table; ADMIN_USER / ADMIN_PASSWORD_HASH are ignored")
+		}
+		return
+	}
 	if err := hauth.ValidateAdminCredentials(); err != nil {
 		logger.Error("admin credentials validation failed", slog.Any("error", err))
 		os.Exit(1)
 	}
+	created, err := users.BootstrapAdmin(ctx, os.Getenv(hauth.EnvAdminUser), os.Getenv(hauth.EnvAdminPasswordHash))
+	if err != nil {
+		logger.Error("failed to bootstrap admin account", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if created != nil {
+		logger.Info("bootstrapped the first admin account from ADMIN_USER",
+			slog.Int64("user_id", created.ID))
+	}
+}
+
+// setupOIDC builds the POST /auth/oidc handler from OIDC_ISSUER /
+// OIDC_CLIENT_ID / OIDC_ROLE_MAP, or returns nil when OIDC_ISSUER is unset
+// (password login only). Invalid settings stop the server.
+func setupOIDC(logger *slog.Logger, roles *hauth.Roles, users *userUC.Service, refresh *refreshUC.Service, audit *auditUC.Service) http.Handler {
+	cfg, enabled, err := oidc.LoadConfig()
+	if err != nil {
+		logger.Error("invalid OIDC configuration", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if !enabled {
+		return nil
+	}
+	mapping, err := hauth.LoadOIDCRoleMapping(roles)
+	if err != nil {
+		logger.Error("invalid OIDC configuration", slog.Any("error", err))
+		os.Exit(1)
+	}
+	logger.Info("auth: OIDC login enabled",
+		slog.String("issuer", cfg.Issuer),
+		slog.Int("role_rules", len(mapping)))
+	return hauth.OIDCTokenHandler(oidc.NewVerifier(cfg, nil), mapping, users, refresh, audit)
 }
 
 // validateJWTSecret validates the JWT_SECRET environment variable for security requirements.
@@ -138,30 +257,121 @@ func initDatabase(logger *slog.Logger) *sql.DB {
 	return database
 }
 
-// getVersion returns the application version from environment or default.
-func getVersion() string {
-	version := os.Getenv("VERSION")
-	if version == "" {
-		version = "dev"
-	}
-	return version
-}
-
 // ServerComponents holds components needed for server operation and cleanup.
 type ServerComponents struct {
 	Handler      http.Handler
 	RateLimiters []*middleware.RateLimiter // Endpoint rate limiters needing periodic cleanup
+	// Secrets re-reads vault:// / awssm:// references (SECRETS_REFRESH_INTERVAL).
+	Secrets *secrets.Resolver
+	// RateLimitStores are stores used outside a RateLimiter (API key
+	// quotas) that also need periodic cleanup.
+	RateLimitStores []middleware.RateLimitStore
 
 	// PrivateFeedHandler / PrivateFeedAddr describe the tailnet-only
 	// feed listener (§3.1, C-5). An empty addr disables the listener.
 	PrivateFeedHandler http.Handler
 	PrivateFeedAddr    string
+
+	// RefreshTokens has its expired tokens deleted periodically.
+	RefreshTokens *refreshUC.Service
+	// RevokedTokens has its expired denylist entries deleted periodically.
+	RevokedTokens *revocationUC.Service
+
+	// DB is sampled for connection pool statistics while serving.
+	DB *sql.DB
+	// Replica serves the article / source list, search and count queries
+	// (DATABASE_REPLICA_URL) and is health-checked while serving.
+	Replica *db.Replica
+	// Cache fronts the article / source reads (CACHE_BACKEND); nil when
+	// disabled. Worker inserts invalidate it through LISTEN article_events.
+	Cache *cache.Cache
+	// ResponseCache holds whole GET responses (RESPONSE_CACHE_ENABLED);
+	// nil when disabled. The worker's NOTIFYs invalidate it.
+	ResponseCache *middleware.ResponseCache
+	// ArticleEvents is fed by LISTEN article_events while serving and
+	// streams to GET /articles/events.
+	ArticleEvents *artUC.EventBus
+	// DashboardEvents is fed by LISTEN article_events / dashboard_events
+	// while serving and pushes to GET /ws.
+	DashboardEvents *dashUC.Hub
+	// Draining makes /ready fail once shutdown begins.
+	Draining *atomic.Bool
+	// Startup is marked StartupCacheWarmup once Warmup has run.
+	Startup *hhttp.StartupState
+	Warmup  func(ctx context.Context) error
 }
 
 // setupServer configures and returns the HTTP handler with all routes and middleware.
-func setupServer(logger *slog.Logger, database *sql.DB, version string) *ServerComponents {
-	srcSvc := srcUC.Service{Repo: pgRepo.NewSourceRepo(database)}
-	artSvc := artUC.Service{Repo: pgRepo.NewArticleRepo(database)}
+func setupServer(logger *slog.Logger, database *sql.DB, build buildinfo.Info, startup *hhttp.StartupState) *ServerComponents {
+	// 読み取りレプリカ: 一覧・検索・件数だけを振り分け、落ちていれば primary。
+	replica := db.OpenReplica(database, logger)
+	// 読み取りキャッシュ(CACHE_BACKEND): 記事一覧・詳細とソース一覧の前段。
+	// API 経由の書き込みで無効化し、worker の記事追加は article_events で
+	// 無効化する。none(既定)なら repository をそのまま使う。
+	readCache := openReadCache(logger)
+	// GET レスポンス全体のキャッシュ(RESPONSE_CACHE_ENABLED)。ロール単位で
+	// 共有し、API 経由の変更と worker の NOTIFY で全体を無効化する。
+	responseCache, err := loadResponseCache(logger, readCache.Store())
+	if err != nil {
+		logger.Error("invalid response cache configuration", slog.Any("error", err))
+		os.Exit(1)
+	}
+	// 監査ログ: ソース・記事の作成/更新/削除と JWT 発行を audit_logs に
+	// 記録する。実行者・request_id・IP は haudit.RequestContext が渡す。
+	auditSvc := &auditUC.Service{Repo: pgRepo.NewAuditLogRepo(database), Logger: logger}
+	srcSvc := srcUC.Service{
+		Repo:       cache.NewSourceRepo(pgRepo.NewSourceRepoWithReplica(database, replica), readCache),
+		Audit:      auditSvc,
+		HealthRepo: pgRepo.NewSourceHealthRepo(database),
+		Previewer:  newFeedPreviewer(logger),
+		// PATCH /sources/bulk を1トランザクションで適用する。
+		Tx: pgRepo.NewTxManager(database),
+		// ソースグループ(フォルダ)。所属変更はキャッシュ済みのソースを無効化する。
+		Groups: cache.NewSourceGroupRepo(pgRepo.NewSourceGroupRepo(database), readCache),
+	}
+	// 外部 Webhook(article.created / crawl.completed)。配信は worker の
+	// deliver_webhook ジョブが行い、ここでは登録管理と API 経由の記事作成の
+	// イベント発行だけ。
+	webhookSvc := &webhookUC.Service{
+		Webhooks: pgRepo.NewWebhookRepo(database),
+		Sources:  pgRepo.NewSourceRepo(database), // category routes
+		Logger:   logger,
+	}
+	// 即時クロール: API はジョブを積むだけで、実行は worker(C-4)。
+	// 履歴(crawl_runs)は worker / crawl-once が書き、API は読むだけ。
+	crawlSvc := &crawlUC.Service{
+		Jobs:    pgRepo.NewJobRepo(database),
+		Sources: pgRepo.NewSourceRepo(database),
+		Runs:    pgRepo.NewCrawlRunRepo(database),
+		Control: pgRepo.NewCrawlControlRepo(database),
+	}
+	// /ready fails from the start of shutdown (internal/pkg/shutdown).
+	draining := &atomic.Bool{}
+	// GET /articles/events: worker / API の記事 INSERT を NOTIFY 経由で受ける。
+	articleEvents := artUC.NewEventBus()
+	dashboardEvents := dashUC.NewHub()
+	artSvc := artUC.Service{
+		Repo:       cache.NewArticleRepo(pgRepo.NewArticleRepoWithReplica(database, replica, loadSearchLanguage(logger)), readCache),
+		Audit:      auditSvc,
+		Events:     webhookSvc,
+		Contents:   pgRepo.NewArticleContentRepo(database),
+		Similarity: pgRepo.NewArticleSimilarityRepo(database),
+		Digest:     pgRepo.NewArticleDigestRepo(database),
+		Groups:     srcSvc.Groups,
+		// 再要約(POST /articles/{id}/summarize)はジョブを積むだけで、
+		// 要約器を持つ worker が実行する。
+		Jobs: crawlSvc.Jobs,
+		// 記事と article.created の配信を同じトランザクションで書く。
+		Tx:     pgRepo.NewTxManager(database),
+		Stream: articleEvents,
+	}
+	// 記事タグ。候補はソースのカテゴリと同じソースの記事で使われている
+	// タグから出す。
+	tagSvc := &tagUC.Service{
+		Tags:     pgRepo.NewTagRepo(database),
+		Articles: artSvc.Repo,
+		Sources:  srcSvc.Repo,
+	}
 
 	// 友人・トークン・アクセスログ管理(§5.1 admin API)。フィードトークン
 	// リポジトリは公開フィード配信(feedServer)と同じテーブルを共有する。
@@ -170,12 +380,82 @@ func setupServer(logger *slog.Logger, database *sql.DB, version string) *ServerC
 		Tokens:      pgRepo.NewFeedTokenRepo(database),
 	}
 	logSvc := alUC.Service{Logs: pgRepo.NewFeedAccessLogRepo(database)}
+	// ダッシュボード統計(GET /stats)。集計 SQL はレプリカへ送り、結果は
+	// 読み取りキャッシュに置く(記事・ソースの変更と worker の NOTIFY で無効化)。
+	statsSvc := &statsUC.Service{Repo: cache.NewStatsRepo(pgRepo.NewStatsRepo(replica), readCache)}
 
 	// 閲覧専用アカウント(viewer, D-27): admin 管理の CRUD に加えて、
 	// ログイン照合(TokenHandler のフォールバック)とリクエスト毎の
 	// 有効性再検証(AuthzWithViewer)を担う。
 	viewerSvc := &viewerUC.Service{Viewers: pgRepo.NewViewerRepo(database)}
 
+	// ダッシュボードのアカウント(users テーブル、admin / viewer)。admin の
+	// ログイン照合とリクエスト毎の再検証を担う。admin が1人もいなければ
+	// 環境変数から最初の admin を作る。
+	userSvc := &userUC.Service{Users: pgRepo.NewUserRepo(database)}
+	bootstrapAdmin(logger, userSvc)
+
+	// カスタムロール(AUTH_ROLES、例: editor=articles:read,articles:write)。
+	// 不正な定義は起動時に fail-closed で止める。
+	roles, err := hauth.LoadRoles()
+	if err != nil {
+		logger.Error("failed to load auth roles", slog.Any("error", err))
+		os.Exit(1)
+	}
+	userSvc.CustomRoles = roles.Custom()
+	if len(userSvc.CustomRoles) > 0 {
+		logger.Info("auth: custom roles loaded", slog.Any("roles", userSvc.CustomRoles))
+	}
+
+	// 記事の既読/未読(ユーザーごと)。既読は users の行に紐づくため、
+	// API キーの呼び出しは対象外(403)。
+	readStateSvc := &readstateUC.Service{
+		Users:  userSvc.Users,
+		States: pgRepo.NewReadStateRepo(database),
+	}
+	// お気に入り(ユーザーごと)。記事の応答の favorited フラグも
+	// artSvc.Favorites 経由でここから引く。
+	favoriteSvc := &favoriteUC.Service{
+		Users:     userSvc.Users,
+		Favorites: pgRepo.NewFavoriteRepo(database),
+	}
+	artSvc.Favorites = favoriteSvc
+	// ソース購読(ユーザーごと)。admin 以外のアカウントの記事一覧・検索・
+	// 詳細・/feed.xml・記事イベント(SSE と /ws)は購読中のソースに絞られる
+	// (artSvc.Subscriptions と hdashboard.Register 経由)。
+	subscriptionSvc := &subscriptionUC.Service{
+		Users:         userSvc.Users,
+		Subscriptions: pgRepo.NewSourceSubscriptionRepo(database),
+	}
+	artSvc.Subscriptions = subscriptionSvc
+	// キーワードアラートのルール管理(ユーザーごと)。記事との照合と通知は
+	// worker のクロールが行う。
+	alertSvc := &alertUC.Service{
+		Users:  userSvc.Users,
+		Rules:  pgRepo.NewAlertRuleRepo(database),
+		Logger: logger,
+	}
+
+	// リフレッシュトークン(/auth/refresh): ログイン時に発行し、1回ごとに
+	// ローテーションする。使用済みトークンの再提示は系列ごと失効させる。
+	refreshSvc := &refreshUC.Service{
+		Tokens: pgRepo.NewRefreshTokenRepo(database),
+		TTL:    config.GetEnvDuration("REFRESH_TOKEN_TTL", refreshUC.DefaultTTL),
+		Logger: logger,
+	}
+
+	// アクセストークンの失効リスト(/auth/revoke・ログアウト)。失効した
+	// JWT の jti を exp まで保持し、認証ミドルウェアが毎リクエスト照合する。
+	revocationSvc := &revocationUC.Service{Tokens: pgRepo.NewRevokedTokenRepo(database)}
+
+	// admin の多要素認証(TOTP、任意)。有効にした admin のログインは
+	// パスワード → /auth/token/mfa でのコード入力の2段階になる。
+	mfaSvc := &mfaUC.Service{
+		Users:  userSvc.Users,
+		MFA:    pgRepo.NewMFARepo(database),
+		Issuer: config.GetEnvString("MFA_ISSUER", mfaUC.DefaultIssuer),
+	}
+
 	// 学習ループ管理 API(Phase 3 §8.1)。採点遷移のラダーは radio 側の
 	// 自動解決と同じ QUIZ_LADDER_DAYS(D-18)を読む — 両者が同じ
 	// learning.Transition を同じパラメータで適用する。
@@ -253,13 +533,98 @@ func setupServer(logger *slog.Logger, database *sql.DB, version string) *ServerC
 	)
 
 	// Setup routes with per-endpoint rate limiting
-	rootMux, rateLimiters := setupRoutes(database, version, srcSvc, artSvc, subSvc, logSvc, learnSvc, bookSvc, viewerSvc, ipExtractor, logger, feedServer, feedCfg.PublicBaseURL)
+	rateLimitStore := loadRateLimitStore(logger, database)
+
+	// サービス間アクセス用 API キー(X-API-Key)。キー単位の rate_limit /
+	// daily_quota は IP レート制限と同じストアで数える。
+	apiKeyLimits := rateLimitStore
+	var rateLimitStores []middleware.RateLimitStore
+	if apiKeyLimits == nil {
+		apiKeyLimits = middleware.NewMemoryRateLimitStore()
+		rateLimitStores = append(rateLimitStores, apiKeyLimits)
+	}
+	apiKeySvc := &apikeyUC.Service{
+		Keys:        pgRepo.NewAPIKeyRepo(database),
+		Limits:      apiKeyLimits,
+		Logger:      logger,
+		CustomRoles: userSvc.CustomRoles,
+	}
+
+	// Per-route limits from RATE_LIMIT_ROUTES (e.g. POST /articles stricter
+	// than GET /articles), on top of the fixed per-endpoint limiters.
+	routePolicies, err := middleware.LoadRoutePolicies()
+	if err != nil {
+		logger.Error("failed to load rate limit routes", slog.Any("error", err))
+		os.Exit(1)
+	}
+	routeRateLimiter, err := middleware.NewRouteRateLimiter(routePolicies, ipExtractor, rateLimitStore)
+	if err != nil {
+		logger.Error("failed to load rate limit routes", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if len(routePolicies) > 0 {
+		logger.Info("rate limiting: per-route policies loaded", slog.Int("routes", len(routePolicies)))
+	}
+	// 外部 OIDC プロバイダでのログイン(POST /auth/oidc)。OIDC_ISSUER
+	// 未設定なら無効で、パスワードの /auth/token のみ。
+	oidcLogin := setupOIDC(logger, roles, userSvc, refreshSvc, auditSvc)
+
+	rootMux, rateLimiters := setupRoutes(routeDeps{
+		database:          database,
+		draining:          draining,
+		dashboardEvents:   dashboardEvents,
+		readCache:         readCache,
+		responseCache:     responseCache,
+		build:             build,
+		startup:           startup,
+		srcSvc:            srcSvc,
+		artSvc:            artSvc,
+		tagSvc:            tagSvc,
+		readStateSvc:      readStateSvc,
+		favoriteSvc:       favoriteSvc,
+		subscriptionSvc:   subscriptionSvc,
+		alertSvc:          alertSvc,
+		subSvc:            subSvc,
+		logSvc:            logSvc,
+		statsSvc:          statsSvc,
+		learnSvc:          learnSvc,
+		bookSvc:           bookSvc,
+		viewerSvc:         viewerSvc,
+		userSvc:           userSvc,
+		auditSvc:          auditSvc,
+		apiKeySvc:         apiKeySvc,
+		webhookSvc:        webhookSvc,
+		crawlSvc:          crawlSvc,
+		refreshSvc:        refreshSvc,
+		revocationSvc:     revocationSvc,
+		mfaSvc:            mfaSvc,
+		oidcLogin:         oidcLogin,
+		ipExtractor:       ipExtractor,
+		rateLimitStore:    rateLimitStore,
+		routeRateLimiters: routeRateLimiter.Limiters(),
+		logger:            logger,
+		feedServer:        feedServer,
+		publicBaseURL:     feedCfg.PublicBaseURL,
+	})
+
+	// Quota headers (X-RateLimit-* / IETF draft RateLimit-*) on every
+	// rate-limited response, selected by RATE_LIMIT_HEADERS.
+	rateLimitHeaders, err := middleware.LoadRateLimitHeaderMode()
+	if err != nil {
+		logger.Error("failed to load rate limit headers", slog.Any("error", err))
+		os.Exit(1)
+	}
+	for _, l := range rateLimiters {
+		l.SetHeaderMode(rateLimitHeaders)
+	}
+
 	// The PDF upload route needs a bigger request ceiling than the 1MB
 	// default (D-25: 100MB/冊; +1MB は multipart 境界と title の余裕分)。
 	bodyLimitOverrides := map[string]int64{
 		"POST /books": bookUC.DefaultMaxUploadBytes + 1<<20,
 	}
-	handler := applyMiddleware(logger, rootMux, bodyLimitOverrides)
+	errorReporter := loadErrorReporter(logger)
+	handler := applyMiddleware(logger, routeRateLimiter.Middleware(rootMux), bodyLimitOverrides, errorReporter)
 
 	// The private listener skips CORS/CSP/auth entirely: physical boundary
 	// (tailnet bind) is the authentication (C-5). Recovery and logging
@@ -269,59 +634,129 @@ func setupServer(logger *slog.Logger, database *sql.DB, version string) *ServerC
 	privateMux.Handle("/", feedServer.PrivateHandler())
 	privateMux.Handle("GET /private/books/{file}", hbook.PrivateFileHandler{Dir: bookCfg.Dir, Logger: logger})
 	privateHandler := requestid.Middleware(
-		hhttp.Recover(logger)(hhttp.Logging(logger)(privateMux)))
+		hhttp.RecoverWithReporter(logger, errorReporter)(hhttp.Logging(logger)(privateMux)))
 
 	return &ServerComponents{
 		Handler:            handler,
 		RateLimiters:       rateLimiters,
+		RateLimitStores:    rateLimitStores,
 		PrivateFeedHandler: privateHandler,
 		PrivateFeedAddr:    feedCfg.PrivateAddr,
+		RefreshTokens:      refreshSvc,
+		RevokedTokens:      revocationSvc,
+		DB:                 database,
+		Replica:            replica,
+		Cache:              readCache,
+		ResponseCache:      responseCache,
+		ArticleEvents:      articleEvents,
+		DashboardEvents:    dashboardEvents,
+		Draining:           draining,
+		Startup:            startup,
+		Warmup: func(ctx context.Context) error {
+			return warmReadCache(ctx, readCache, srcSvc, artSvc, statsSvc, pagination.LoadFromEnv())
+		},
 	}
 }
 
+// routeDeps is what setupRoutes wires into the handlers: the services
+// built by setupServer plus the shared infrastructure (database, caches,
+// rate limit store, event hub).
+type routeDeps struct {
+	database          *sql.DB
+	draining          *atomic.Bool
+	dashboardEvents   *dashUC.Hub
+	readCache         *cache.Cache
+	responseCache     *middleware.ResponseCache
+	build             buildinfo.Info
+	startup           *hhttp.StartupState
+	srcSvc            srcUC.Service
+	artSvc            artUC.Service
+	tagSvc            *tagUC.Service
+	readStateSvc      *readstateUC.Service
+	favoriteSvc       *favoriteUC.Service
+	subscriptionSvc   *subscriptionUC.Service
+	alertSvc          *alertUC.Service
+	subSvc            subUC.Service
+	logSvc            alUC.Service
+	statsSvc          *statsUC.Service
+	learnSvc          learnUC.Service
+	bookSvc           *bookUC.Service
+	viewerSvc         *viewerUC.Service
+	userSvc           *userUC.Service
+	auditSvc          *auditUC.Service
+	apiKeySvc         *apikeyUC.Service
+	webhookSvc        *webhookUC.Service
+	crawlSvc          *crawlUC.Service
+	refreshSvc        *refreshUC.Service
+	revocationSvc     *revocationUC.Service
+	mfaSvc            *mfaUC.Service
+	oidcLogin         http.Handler
+	ipExtractor       middleware.IPExtractor
+	rateLimitStore    middleware.RateLimitStore
+	routeRateLimiters []*middleware.RateLimiter
+	logger            *slog.Logger
+	feedServer        *feed.Server
+	publicBaseURL     string
+}
+
 // setupRoutes registers all HTTP routes (public and protected).
-func setupRoutes(
-	database *sql.DB,
-	version string,
-	srcSvc srcUC.Service,
-	artSvc artUC.Service,
-	subSvc subUC.Service,
-	logSvc alUC.Service,
-	learnSvc learnUC.Service,
-	bookSvc *bookUC.Service,
-	viewerSvc *viewerUC.Service,
-	ipExtractor middleware.IPExtractor,
-	logger *slog.Logger,
-	feedServer *feed.Server,
-	publicBaseURL string,
-) (*http.ServeMux, []*middleware.RateLimiter) {
+func setupRoutes(d routeDeps) (*http.ServeMux, []*middleware.RateLimiter) {
 	// レート制限: 認証エンドポイントは1分間に5リクエストまで
-	authRateLimiter := middleware.NewRateLimiter(5, 1*time.Minute, ipExtractor)
+	authRateLimiter := middleware.NewRateLimiterWithStore("auth", 5, 1*time.Minute, d.ipExtractor, d.rateLimitStore)
 
 	// レート制限: 検索エンドポイントは1分間に100リクエストまで
-	searchRateLimiter := middleware.NewRateLimiter(100, 1*time.Minute, ipExtractor)
+	searchRateLimiter := middleware.NewRateLimiterWithStore("search", 100, 1*time.Minute, d.ipExtractor, d.rateLimitStore)
 
 	// レート制限: 公開フィードは per-IP で1分間に60リクエストまで(§5.2、
 	// 無効トークン連打対策程度の軽いもの。ポッドキャストアプリの巡回は
 	// フィード1回+mp3数回なので通常運用では到達しない)
-	feedRateLimiter := middleware.NewRateLimiter(60, 1*time.Minute, ipExtractor)
+	feedRateLimiter := middleware.NewRateLimiterWithStore("feed", 60, 1*time.Minute, d.ipExtractor, d.rateLimitStore)
+
+	rateLimiters := append([]*middleware.RateLimiter{authRateLimiter, searchRateLimiter, feedRateLimiter}, d.routeRateLimiters...)
 
-	// 管理者の資格情報検証(環境変数+bcrypt、C-7/C-20)。不一致時は
-	// viewers テーブルへのフォールバック照合(D-27 (2))。
-	authService := authservice.NewAuthService(hauth.NewAdminAuthProvider())
+	// 管理者の資格情報検証(users テーブルの role=admin、bcrypt、C-20)。
+	// 不一致時は viewer アカウントへのフォールバック照合(D-27 (2))。
+	authService := authservice.NewAuthService(hauth.NewUserAuthProvider(d.userSvc))
 
 	publicMux := http.NewServeMux()
-	publicMux.Handle("/auth/token", authRateLimiter.Middleware(hauth.TokenHandler(authService, viewerSvc)))
+	// JWT 発行は監査対象。認証前なので RequestContext は request_id / IP
+	// のみを載せ、実行者は発行先の sub になる。
+	publicMux.Handle("/auth/token", authRateLimiter.Middleware(
+		haudit.RequestContext(d.ipExtractor)(hauth.TokenHandlerWithMFA(authService, d.viewerSvc, d.auditSvc, d.refreshSvc, d.mfaSvc))))
+	// MFA ログインの2段階目(TOTP コード)。パスワード段階と同じレート制限を
+	// 共有し、コードの総当たりを抑える。発行は監査対象。
+	publicMux.Handle("POST /auth/token/mfa", authRateLimiter.Middleware(
+		haudit.RequestContext(d.ipExtractor)(hauth.MFATokenHandler(d.mfaSvc, d.refreshSvc, d.auditSvc))))
+	// JWT 再発行(リフレッシュトークンのローテーション)。/auth/token と同じ
+	// レート制限を共有し、再発行も監査対象にする。
+	publicMux.Handle("POST /auth/refresh", authRateLimiter.Middleware(
+		haudit.RequestContext(d.ipExtractor)(hauth.RefreshHandler(d.refreshSvc, d.viewerSvc, d.auditSvc))))
 	// ログアウト: HttpOnly cookie を backend で失効させる(D-22)。冪等・
 	// 認証不要(期限切れトークンでも cookie を消せること)。POST 限定 —
 	// メソッド未制限だと <img src=".../auth/logout"> の反射 GET で被害者を
 	// 強制ログアウトできる(GET CSRF)。他メソッドは ServeMux が 405 を返す。
-	publicMux.Handle("POST /auth/logout", hauth.LogoutHandler())
+	// アクセストークン(JWT)も失効リストに載せ、cookie の複製でも使えなくする。
+	publicMux.Handle("POST /auth/logout", hauth.LogoutHandlerWithRevocation(d.refreshSvc, d.revocationSvc))
+	// トークン失効(RFC 7009 相当): JWT は jti を失効リストへ、リフレッシュ
+	// トークンは系列ごと失効。認証不要なので /auth/token と同じレート制限。
+	publicMux.Handle("POST /auth/revoke", authRateLimiter.Middleware(
+		hauth.RevokeHandler(d.refreshSvc, d.revocationSvc)))
+	// OIDC ログイン(外部プロバイダの ID トークンを JWT に交換)。/auth/token
+	// と同じレート制限・監査。無効時は 404。
+	if d.oidcLogin != nil {
+		publicMux.Handle("POST /auth/oidc", authRateLimiter.Middleware(
+			haudit.RequestContext(d.ipExtractor)(d.oidcLogin)))
+	}
 
 	// ヘルスチェックエンドポイント（認証不要）
-	publicMux.Handle("/health", &hhttp.HealthHandler{DB: database, Version: version})
-	publicMux.Handle("/ready", &hhttp.ReadyHandler{DB: database})
+	publicMux.Handle("/health", &hhttp.HealthHandler{DB: d.database, Version: d.build.Version, Build: &d.build,
+		WebSocketStats: func() any { return d.dashboardEvents.Stats() },
+		CacheStats:     func() any { return cacheStats(d.readCache, d.responseCache) },
+		Probes:         loadHealthProbes(d.logger)})
+	publicMux.Handle("/ready", &hhttp.ReadyHandler{DB: d.database, Draining: d.draining, Startup: d.startup})
 	publicMux.Handle("/live", &hhttp.LiveHandler{})
+	publicMux.Handle("/startup", &hhttp.StartupHandler{State: d.startup})
+	publicMux.Handle("GET /version", &hhttp.VersionHandler{Info: d.build})
 
 	// Swagger UI（認証不要）
 	publicMux.Handle("/swagger/", httpSwagger.WrapHandler)
@@ -330,20 +765,65 @@ func setupRoutes(
 	paginationCfg := pagination.LoadFromEnv()
 
 	privateMux := http.NewServeMux()
-	hsrc.Register(privateMux, srcSvc, searchRateLimiter)
-	harticle.Register(privateMux, artSvc, paginationCfg, logger, searchRateLimiter)
+	hsrc.Register(privateMux, d.srcSvc, searchRateLimiter)
+	// 即時クロール(POST /crawl・POST /sources/{id}/crawl)とジョブ状態。
+	// 外部フィードの取得を誘発するため検索と同じレート制限。
+	hcrawl.Register(privateMux, d.crawlSvc, paginationCfg, searchRateLimiter)
+	harticle.Register(privateMux, d.artSvc, paginationCfg, d.logger, searchRateLimiter)
+	// 記事タグ(C-21 フラット構成)。記事と同じ articles:read / articles:write。
+	htag.Register(privateMux, d.tagSvc)
+	// 記事の既読/未読・未読件数(C-21 フラット構成)。自分の状態のみを
+	// 操作するため articles:read で足りる。
+	hreadstate.Register(privateMux, d.readStateSvc)
+	// お気に入りの追加・削除(C-21 フラット構成)。既読と同じく articles:read。
+	hfavorite.Register(privateMux, d.favoriteSvc)
+	// ソースの購読・解除・購読一覧(C-21 フラット構成)。自分の購読のみを
+	// 操作するため sources:read で足りる。
+	hsubscription.Register(privateMux, d.subscriptionSvc)
+	// キーワードアラートのルール管理(C-21 フラット構成)。自分のルールのみを
+	// 操作するため articles:read で足りる。
+	halert.Register(privateMux, d.alertSvc)
 	// 友人管理・トークン発行/失効・アクセスログ(§5.1)。管理 API は
 	// すべて単一管理者の JWT 必須(C-20)。トークン発行レスポンスの
 	// 購読 URL は publicBaseURL(D-6)から組み立てる。
-	hsub.Register(privateMux, subSvc, publicBaseURL)
-	haccesslog.Register(privateMux, logSvc)
+	hsub.Register(privateMux, d.subSvc, d.publicBaseURL)
+	haccesslog.Register(privateMux, d.logSvc)
+	// ダッシュボード統計(記事数・クロール所要時間・要約エラー率)。admin 専用。
+	hstats.Register(privateMux, d.statsSvc)
 	// 学習ループ管理 API(Phase 3 §8.1、C-21 フラット構成)。全ルート
 	// JWT 必須 — 理解状態は私的データ(§10)。
-	hlearning.Register(privateMux, learnSvc)
+	hlearning.Register(privateMux, d.learnSvc)
 	// 書籍 PDF 管理(D-25、C-21 フラット構成)。全ルート JWT 必須。
-	hbook.Register(privateMux, bookSvc)
+	hbook.Register(privateMux, d.bookSvc)
 	// viewer 管理 API(D-27、C-21 フラット構成)。admin 専用。
-	hviewer.Register(privateMux, viewerSvc)
+	hviewer.Register(privateMux, d.viewerSvc)
+	// アカウント管理 API(admin / viewer、C-21 フラット構成)。admin 専用。
+	huser.Register(privateMux, d.userSvc)
+	// MFA(TOTP)の登録・有効化・無効化(C-21 フラット構成)。admin 専用、
+	// 自分のアカウントのみ。
+	hmfa.Register(privateMux, d.mfaSvc)
+	// 実行時ログレベル切り替え(C-21 フラット構成)。admin 専用。
+	hloglevel.Register(privateMux, d.logger)
+	// 監査ログ閲覧(C-21 フラット構成)。admin 専用。
+	haudit.Register(privateMux, d.auditSvc, paginationCfg)
+	// API キー管理(C-21 フラット構成)。admin 専用。
+	hapikey.Register(privateMux, d.apiKeySvc)
+	// 外部 Webhook の登録・削除・配信履歴(C-21 フラット構成)。admin 専用。
+	hwebhook.Register(privateMux, d.webhookSvc)
+	// ダッシュボードのリアルタイム更新(GET /ws)。articles:read /
+	// sources:read の範囲のイベントだけを送る。Cookie 認証のブラウザ
+	// から他サイト経由で開かれないよう、Origin は CORS の許可リストで
+	// 検証する。
+	wsCORS, err := middleware.LoadCORSConfig()
+	if err != nil {
+		d.logger.Error("failed to load CORS configuration", slog.Any("error", err))
+		os.Exit(1)
+	}
+	hdashboard.Register(privateMux, d.dashboardEvents, wsCORS.Validator.IsAllowed,
+		config.GetEnvInt("WS_MAX_CONNECTIONS", 100), d.subscriptionSvc, d.logger)
+	// レート制限の状況確認・クライアント別リセット(C-21 フラット構成)。
+	// admin 専用。
+	hratelimit.Register(privateMux, rateLimiters)
 	// GET /auth/me: 認証済みユーザーの sub / role を返す(D-27 (5))。
 	// 外側の AuthzWithViewer が識別情報を context に載せる。viewer の
 	// 許可リストに含まれる数少ないルートのひとつ。
@@ -352,30 +832,42 @@ func setupRoutes(
 	// Apply the role-aware authentication middleware (D-27): admin は全
 	// ルート、viewer はリクエスト毎の DB 再検証を経て許可リスト
 	// (GET /sources / GET /auth/me)のみ。既定は admin 専用。
-	protected := hauth.AuthzWithViewer(viewerSvc)(privateMux)
+	// X-API-Key のクライアントはキーの role で同じ規則に従う。admin の
+	// JWT は users テーブルでリクエスト毎に再検証する。
+	// 失効リスト(/auth/revoke・ログアウト)に載った JWT は 401。
+	// RequestContext は認証の内側に置き、検証済みの sub を実行者として
+	// 監査ログへ渡す。
+	protected := hauth.AuthzWithUsers(d.viewerSvc, d.apiKeySvc, d.userSvc, d.revocationSvc)(haudit.RequestContext(d.ipExtractor)(d.responseCache.Middleware(privateMux)))
 
 	rootMux := http.NewServeMux()
 	rootMux.Handle("/auth/token", publicMux)
+	rootMux.Handle("/auth/token/mfa", publicMux)
 	rootMux.Handle("/auth/logout", publicMux)
+	rootMux.Handle("/auth/refresh", publicMux)
+	rootMux.Handle("/auth/oidc", publicMux)
+	rootMux.Handle("/auth/revoke", publicMux)
 	rootMux.Handle("/health", publicMux)
 	rootMux.Handle("/ready", publicMux)
 	rootMux.Handle("/live", publicMux)
+	rootMux.Handle("/startup", publicMux)
+	rootMux.Handle("/version", publicMux)
 	rootMux.Handle("/swagger/", publicMux)
 	rootMux.Handle("/", protected)
 
 	// 公開フィード(§5.1): JWT ではなく URL 埋め込みトークンで認証する
 	// (C-6)。パターンが "/" より特定的なので管理 API には影響しない。
-	feedServer.RegisterPublic(rootMux, feedRateLimiter.Middleware)
+	d.feedServer.RegisterPublic(rootMux, feedRateLimiter.Middleware)
 
 	// Return rate limiters for periodic cleanup
-	return rootMux, []*middleware.RateLimiter{authRateLimiter, searchRateLimiter, feedRateLimiter}
+	return rootMux, rateLimiters
 }
 
 // applyMiddleware wraps the handler with middleware chain.
-// Middleware order: CORS → Request ID → Recovery → Logging → Body Limit → CSP
+// Middleware order: CORS → Request ID → Recovery → Logging → Timeout → CSRF → Body Limit → Webhook Signature → CSP
 // bodyLimitOverrides loosens the 1MB body-limit default per route
 // ("METHOD /path"), used by the book PDF upload (D-25).
-func applyMiddleware(logger *slog.Logger, handler http.Handler, bodyLimitOverrides map[string]int64) http.Handler {
+// errorReporter (nil = disabled) receives panics and 5xx responses.
+func applyMiddleware(logger *slog.Logger, handler http.Handler, bodyLimitOverrides map[string]int64, errorReporter hhttp.ErrorReporter) http.Handler {
 	// Load CORS configuration from environment variables
 	corsConfig, err := middleware.LoadCORSConfig()
 	if err != nil {
@@ -423,22 +915,210 @@ func applyMiddleware(logger *slog.Logger, handler http.Handler, bodyLimitOverrid
 		logger.Warn("CSP is disabled")
 	}
 
+	// Double-submit CSRF check for cookie-authenticated browsers (opt-in)
+	csrfConfig, err := config.LoadCSRFConfig()
+	if err != nil {
+		logger.Error("failed to load CSRF configuration", slog.Any("error", err))
+		os.Exit(1)
+	}
+	csrfMiddleware := func(next http.Handler) http.Handler { return next }
+	if csrfConfig.Enabled {
+		csrfMiddleware = middleware.CSRF(middleware.CSRFConfig{
+			SessionCookie: hauth.SessionCookieName,
+			ExemptPaths:   csrfConfig.ExemptPaths,
+			CookieDomain:  csrfConfig.CookieDomain,
+			Logger:        logger,
+		})
+		logger.Info("CSRF protection enabled",
+			slog.Any("extra_exempt_paths", csrfConfig.ExemptPaths))
+	}
+
+	// HMAC verification of inbound webhook deliveries (WEBHOOK_INBOUND_SECRETS)
+	webhookSignature, err := loadInboundWebhookSignature(logger)
+	if err != nil {
+		logger.Error("invalid inbound webhook configuration", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if webhookSignature == nil {
+		webhookSignature = func(next http.Handler) http.Handler { return next }
+	}
+
+	// Per-request deadline (504 problem+json), lifted for streams and large transfers
+	timeoutMiddleware, err := loadHandlerTimeout()
+	if err != nil {
+		logger.Error("invalid handler timeout configuration", slog.Any("error", err))
+		os.Exit(1)
+	}
+
 	// Build middleware chain (applied in reverse order, innermost to outermost)
 	middlewareChain := handler
 
 	middlewareChain = cspMiddleware(middlewareChain)
+	middlewareChain = webhookSignature(middlewareChain)
 	middlewareChain = hhttp.LimitRequestBodyPerRoute(1<<20, bodyLimitOverrides)(middlewareChain) // 1MB limit (overrides: PDF upload)
+	middlewareChain = csrfMiddleware(middlewareChain)
+	middlewareChain = timeoutMiddleware(middlewareChain)
 	middlewareChain = hhttp.Logging(logger)(middlewareChain)
-	middlewareChain = hhttp.Recover(logger)(middlewareChain)
+	middlewareChain = hhttp.RecoverWithReporter(logger, errorReporter)(middlewareChain)
 	middlewareChain = requestid.Middleware(middlewareChain)
 	middlewareChain = middleware.CORS(*corsConfig)(middlewareChain)
 
 	return middlewareChain
 }
 
+// loadRateLimitStore selects the rate limiter backend from RATE_LIMIT_STORE:
+// "memory" (default) keeps the windows in process, exact for the single
+// Pi instance; "postgres" shares them through the rate_limit_hits table
+// and "redis" through the Redis at REDIS_URL, for deployments running
+// several server instances. An unknown value falls back to memory with a
+// warning; "redis" without a valid REDIS_URL is fatal. Returns nil for
+// memory.
+func loadRateLimitStore(logger *slog.Logger, database *sql.DB) middleware.RateLimitStore {
+	switch kind := config.GetEnvString("RATE_LIMIT_STORE", "memory"); kind {
+	case "memory":
+		return nil
+	case "postgres":
+		logger.Info("rate limiting: using shared postgres store")
+		return pgRepo.NewRateLimitStore(database)
+	case "redis":
+		client, err := redis.New(config.GetEnvString("REDIS_URL", ""))
+		if err != nil {
+			logger.Error("rate limiting: RATE_LIMIT_STORE=redis needs REDIS_URL", slog.Any("error", err))
+			os.Exit(1)
+		}
+		logger.Info("rate limiting: using shared redis store", slog.String("addr", client.Addr()))
+		return redis.NewRateLimitStore(client)
+	default:
+		logger.Warn("rate limiting: unknown RATE_LIMIT_STORE, using memory",
+			slog.String("value", kind))
+		return nil
+	}
+}
+
+// newFeedPreviewer builds the fetcher behind POST /sources/validate. It
+// uses the content-fetch SSRF settings (CONTENT_FETCH_MAX_REDIRECTS /
+// CONTENT_FETCH_DENY_PRIVATE_IPS) like the worker's feed-fetch client, so a
+// URL the worker would refuse is refused here too.
+func newFeedPreviewer(logger *slog.Logger) *scraper.FeedPreviewer {
+	cfg, err := fetcher.LoadConfigFromEnv()
+	if err != nil {
+		logger.Warn("feed validation: invalid content fetch configuration, using defaults",
+			slog.Any("error", err))
+		cfg = fetcher.DefaultConfig()
+	}
+	client := &http.Client{
+		Timeout:       15 * time.Second,
+		Transport:     &http.Transport{TLSClientConfig: &tls.Config{MinVersion: tls.VersionTLS12}},
+		CheckRedirect: fetcher.SSRFCheckRedirect(cfg.MaxRedirects, cfg.DenyPrivateIPs),
+	}
+	return scraper.NewFeedPreviewer(client, cfg.DenyPrivateIPs)
+}
+
+// cacheStats builds the stats of the "cache" check in GET /health: the
+// read cache's counters at the top level (backend "none" when it is
+// disabled) and, with RESPONSE_CACHE_ENABLED, the response cache's under
+// "http".
+func cacheStats(readCache *cache.Cache, responseCache *middleware.ResponseCache) any {
+	stats := struct {
+		cache.Stats
+		HTTP *middleware.ResponseCacheStats `json:"http,omitempty"`
+	}{Stats: readCache.Stats()}
+	if responseCache != nil {
+		httpStats := responseCache.Stats()
+		stats.HTTP = &httpStats
+	}
+	return stats
+}
+
+// openReadCache builds the read cache from CACHE_BACKEND / CACHE_TTL /
+// CACHE_MAX_ENTRIES / REDIS_URL; nil when disabled. A bad configuration
+// stops the server rather than silently serving uncached.
+func openReadCache(logger *slog.Logger) *cache.Cache {
+	cfg, err := cache.LoadConfigFromEnv()
+	if err != nil {
+		logger.Error("invalid cache configuration", slog.Any("error", err))
+		os.Exit(1)
+	}
+	c, err := cache.Open(cfg, logger)
+	if err != nil {
+		logger.Error("failed to open cache", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if c != nil {
+		logger.Info("read cache enabled",
+			slog.String("backend", cfg.Backend),
+			slog.Duration("ttl", cfg.TTL))
+	}
+	return c
+}
+
+// loadSearchLanguage reads SEARCH_LANGUAGE, the PostgreSQL text search
+// configuration article keyword search parses keywords with (default
+// "simple", which the stored tsv columns and their GIN indexes use). An
+// unknown value falls back to the default with a warning.
+func loadSearchLanguage(logger *slog.Logger) string {
+	lang := config.GetEnvString("SEARCH_LANGUAGE", search.DefaultTextSearchConfig)
+	if !search.IsTextSearchConfig(lang) {
+		logger.Warn("article search: unknown SEARCH_LANGUAGE, using default",
+			slog.String("value", lang), slog.String("default", search.DefaultTextSearchConfig))
+		return search.DefaultTextSearchConfig
+	}
+	if lang != search.DefaultTextSearchConfig {
+		logger.Info("article search: non-default language, full-text indexes are not used",
+			slog.String("language", lang))
+	}
+	return lang
+}
+
+// loadErrorReporter builds the panic / 5xx reporter. Opt-in via
+// ERROR_REPORT_ENABLED=true; reports go to the admin notification
+// channels (DISCORD_* / SLACK_*, same configuration as the worker),
+// throttled per route and status by ERROR_REPORT_INTERVAL (default 10m).
+// Returns nil when disabled or when no channel is configured.
+func loadErrorReporter(logger *slog.Logger) hhttp.ErrorReporter {
+	if !config.GetEnvBool("ERROR_REPORT_ENABLED", false) {
+		return nil
+	}
+	destinations := notify.LoadDestinationsFromEnv(logger)
+	if len(destinations) == 0 {
+		logger.Warn("error reporting enabled but no notification channel is configured")
+		return nil
+	}
+	interval := config.GetEnvDuration("ERROR_REPORT_INTERVAL", 10*time.Minute)
+	logger.Info("error reporting enabled",
+		slog.Int("channels", len(destinations)),
+		slog.Duration("interval", interval))
+	return hhttp.NewNotifyReporter(destinations, interval, logger)
+}
+
+// loadHealthProbes builds the external dependency checks of /health: the
+// Ollama summarizer API (unless OLLAMA_ENABLED=false) and each configured
+// admin notification webhook (DISCORD_* / SLACK_*). Each check is bounded
+// by HEALTH_PROBE_TIMEOUT (default 2s); HEALTH_DEPENDENCY_CHECKS=false
+// turns them off, leaving the database check only.
+func loadHealthProbes(logger *slog.Logger) []hhttp.DependencyProbe {
+	if !config.GetEnvBool("HEALTH_DEPENDENCY_CHECKS", true) {
+		return nil
+	}
+	timeout := config.GetEnvDuration("HEALTH_PROBE_TIMEOUT", 2*time.Second)
+
+	var probes []hhttp.DependencyProbe
+	if config.GetEnvBool("OLLAMA_ENABLED", true) {
+		ollama := summarizer.NewOllama(summarizer.LoadOllamaConfig(summarizer.Options{}))
+		probes = append(probes, hhttp.DependencyProbe{Name: "ai", Timeout: timeout, Check: ollama.Probe})
+	}
+	for _, d := range notify.LoadDestinationsFromEnv(logger) {
+		if p, ok := d.(notify.Prober); ok {
+			probes = append(probes, hhttp.DependencyProbe{Name: "notify_" + d.Name(), Timeout: timeout, Check: p.Probe})
+		}
+	}
+	return probes
+}
+
 // startRateLimiterCleanup periodically evicts expired entries from the
-// endpoint rate limiters to prevent unbounded memory growth.
-func startRateLimiterCleanup(ctx context.Context, limiters []*middleware.RateLimiter, interval time.Duration) {
+// endpoint rate limiters and standalone stores to prevent unbounded
+// memory growth.
+func startRateLimiterCleanup(ctx context.Context, limiters []*middleware.RateLimiter, stores []middleware.RateLimitStore, interval time.Duration) {
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
@@ -450,42 +1130,98 @@ func startRateLimiterCleanup(ctx context.Context, limiters []*middleware.RateLim
 			for _, rl := range limiters {
 				rl.CleanupExpired()
 			}
+			for _, store := range stores {
+				_ = store.Cleanup(ctx)
+			}
+		}
+	}
+}
+
+// startTokenCleanup periodically deletes expired token rows through
+// cleanup: refresh tokens (rotation leaves one consumed row per refresh, so
+// without this the table grows with every access token renewal) and
+// revoked access-token jtis (useless once the token has expired).
+func startTokenCleanup(ctx context.Context, logger *slog.Logger, kind string, cleanup func(context.Context) (int64, error), interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := cleanup(ctx)
+			if err != nil {
+				logger.Warn("token cleanup failed", slog.String("kind", kind), slog.Any("error", err))
+				continue
+			}
+			if n > 0 {
+				logger.Info("expired tokens deleted", slog.String("kind", kind), slog.Int64("count", n))
+			}
 		}
 	}
 }
 
-// runServer starts the HTTP server and handles graceful shutdown.
-func runServer(logger *slog.Logger, components *ServerComponents, version string) {
+// runServer switches the public listener to the full handler, runs the
+// background tasks and handles graceful shutdown.
+func runServer(ctx context.Context, logger *slog.Logger, listener *publicListener, components *ServerComponents) {
 	// Create a context for background goroutines
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	// Start background cleanup for endpoint rate limiters
-	go startRateLimiterCleanup(ctx, components.RateLimiters, 5*time.Minute)
-
-	// Error channel for coordinated shutdown when the public server fails.
-	// The private listener never writes here: its failure is degraded to an
-	// Error log (§8) so the public side keeps serving.
-	serverErrCh := make(chan error, 1)
+	go startRateLimiterCleanup(ctx, components.RateLimiters, components.RateLimitStores, 5*time.Minute)
 
-	// Start HTTP server
-	srv := &http.Server{
-		Addr:              ":8080",
-		Handler:           components.Handler,
-		ReadHeaderTimeout: 10 * time.Second, // Prevent Slowloris attacks
-		BaseContext: func(_ net.Listener) context.Context {
-			return ctx
-		},
+	// Start background cleanup for expired refresh tokens and denylist entries
+	if components.RefreshTokens != nil {
+		go startTokenCleanup(ctx, logger, "refresh_token", components.RefreshTokens.Cleanup, time.Hour)
+	}
+	if components.RevokedTokens != nil {
+		go startTokenCleanup(ctx, logger, "revoked_token", components.RevokedTokens.Cleanup, time.Hour)
 	}
 
+	// Periodic connection pool statistics (DB_STATS_INTERVAL, 0 = off)
+	go db.SampleStats(ctx, components.DB, db.StatsIntervalFromEnv(), logger)
+	go components.Replica.Watch(ctx, db.ReplicaCheckIntervalFromEnv())
+	// Secret rotation (SECRETS_REFRESH_INTERVAL, 0 = off)
+	go components.Secrets.Refresh(ctx)
+	// Article inserts (worker crawl / POST /articles) for GET /articles/events and GET /ws
+	go db.Listen(ctx, os.Getenv("DATABASE_URL"), entity.ArticleEventChannel, func(payload string) {
+		components.Cache.Invalidate(ctx, cache.NamespaceArticles)
+		components.Cache.Invalidate(ctx, cache.NamespaceStats)
+		components.ResponseCache.Invalidate(ctx)
+		components.ArticleEvents.HandleNotification(payload)
+		components.DashboardEvents.HandleArticleNotification(payload)
+	}, logger)
+	// Finished crawls and source health changes (worker) for GET /ws
+	go db.Listen(ctx, os.Getenv("DATABASE_URL"), entity.DashboardEventChannel, func(payload string) {
+		components.Cache.Invalidate(ctx, cache.NamespaceStats)
+		components.ResponseCache.Invalidate(ctx)
+		components.DashboardEvents.HandleNotification(payload)
+	}, logger)
+
+	// The public listener has been serving the boot probes since before the
+	// migrations (HTTP_ADDR, timeouts, optional native TLS). Its errCh
+	// carries a public server failure for coordinated shutdown; the private
+	// listener never writes there: its failure is degraded to an Error log
+	// (§8) so the public side keeps serving.
+	srv, inFlight := listener.srv, listener.inFlight
+	// SSE streams and WebSockets never finish on their own; end them when
+	// Shutdown starts (Shutdown does not wait for hijacked connections).
+	srv.RegisterOnShutdown(components.ArticleEvents.Close)
+	srv.RegisterOnShutdown(components.DashboardEvents.Close)
+	listener.Serve(components.Handler)
+
+	// Startup finishes with the read cache warm-up; a failure only leaves
+	// the cache cold (§8).
 	go func() {
-		logger.Info("HTTP server starting",
-			slog.String("addr", ":8080"),
-			slog.String("version", version))
-		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			logger.Error("HTTP server failed", slog.Any("error", err))
-			serverErrCh <- err
+		warmCtx, cancelWarm := context.WithTimeout(ctx, cacheWarmupTimeout)
+		defer cancelWarm()
+		if err := components.Warmup(warmCtx); err != nil {
+			logger.Warn("read cache warm-up failed, starting with a cold cache", slog.Any("error", err))
 		}
+		components.Startup.Done(hhttp.StartupCacheWarmup)
+		logger.Info("server startup complete")
 	}()
 
 	// 私的フィードリスナー(§3.1): tailnet アドレスにのみバインドする
@@ -503,27 +1239,39 @@ func runServer(logger *slog.Logger, components *ServerComponents, version string
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
 	// Wait for shutdown signal or server error
+	shutdownCfg := shutdown.LoadConfigFromEnv()
 	select {
 	case <-quit:
-		logger.Info("shutting down server...")
-	case err := <-serverErrCh:
+		logger.Info("shutting down server...", slog.Int64("in_flight", inFlight.Count()))
+	case err := <-listener.errCh:
 		logger.Error("server startup failed, initiating shutdown", slog.Any("error", err))
+		shutdownCfg.DrainPeriod = 0 // nothing is routed to a server that never served
 	}
 
-	// Cancel background goroutines
-	cancel()
-
-	// Shutdown HTTP server with timeout
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer shutdownCancel()
-	if err := srv.Shutdown(shutdownCtx); err != nil {
-		logger.Error("HTTP server shutdown failed", slog.Any("error", err))
-	}
-	if privateSrv != nil {
-		if err := privateSrv.Shutdown(shutdownCtx); err != nil {
-			logger.Error("private feed listener shutdown failed", slog.Any("error", err))
+	// /ready fails first, then in-flight requests finish before the
+	// background goroutines (and the request base context) are canceled.
+	err := shutdownCfg.Drain(logger, func() { components.Draining.Store(true) }, func(ctx context.Context) error {
+		var errs []error
+		if err := srv.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("public: %w", err))
+		}
+		if privateSrv != nil {
+			if err := privateSrv.Shutdown(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("private feed: %w", err))
+			}
+		}
+		return errors.Join(errs...)
+	})
+	if err != nil {
+		logger.Error("graceful shutdown deadline exceeded, closing remaining connections",
+			slog.Int64("in_flight", inFlight.Count()),
+			slog.Any("error", err))
+		_ = srv.Close()
+		if privateSrv != nil {
+			_ = privateSrv.Close()
 		}
 	}
+	cancel()
 	logger.Info("HTTP server stopped")
 }
 
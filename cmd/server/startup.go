@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"catchup-feed/internal/common/pagination"
+	hhttp "catchup-feed/internal/handler/http"
+	"catchup-feed/internal/infra/cache"
+	"catchup-feed/internal/pkg/buildinfo"
+	"catchup-feed/internal/pkg/shutdown"
+	artUC "catchup-feed/internal/usecase/article"
+	srcUC "catchup-feed/internal/usecase/source"
+	statsUC "catchup-feed/internal/usecase/stats"
+)
+
+// cacheWarmupTimeout bounds the read cache warm-up; a slow database only
+// delays /startup by this much and the server then runs with a cold cache.
+const cacheWarmupTimeout = 30 * time.Second
+
+// publicListener is the public HTTP server. It starts before the database
+// migrations so /live, /startup and /ready answer while the server boots —
+// a long migration must not look like a dead process. Until Serve hands
+// it the full handler, only those probes are routed and everything else
+// gets 503.
+type publicListener struct {
+	srv      *http.Server
+	tls      bool
+	inFlight *shutdown.InFlight
+	// errCh receives a Serve failure, for coordinated shutdown.
+	errCh   chan error
+	handler atomic.Pointer[http.Handler]
+}
+
+// startPublicListener binds cfg.Addr synchronously (a bind failure is
+// returned, not discovered after the migrations) and serves the boot
+// probes of startup in the background. The returned server's Addr is the
+// actual listen address.
+func startPublicListener(ctx context.Context, cfg httpServerConfig, startup *hhttp.StartupState, build buildinfo.Info, logger *slog.Logger) (*publicListener, error) {
+	l := &publicListener{tls: cfg.TLS(), inFlight: &shutdown.InFlight{}, errCh: make(chan error, 1)}
+	l.Serve(bootProbes(startup))
+
+	srv, err := newHTTPServer(ctx, cfg, l.inFlight.Middleware(l), logger)
+	if err != nil {
+		return nil, err
+	}
+	ln, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("listen on %s: %w", srv.Addr, err)
+	}
+	srv.Addr = ln.Addr().String() // the actual port when cfg.Addr asks for any
+	l.srv = srv
+
+	logger.Info("HTTP server starting",
+		slog.String("addr", srv.Addr),
+		slog.Bool("tls", l.tls),
+		slog.String("version", build.Version),
+		slog.String("git_commit", build.GitCommit),
+		slog.String("build_date", build.BuildDate),
+		slog.String("go_version", build.GoVersion))
+	go func() {
+		var err error
+		if l.tls {
+			err = srv.ServeTLS(ln, "", "") // certificates come from TLSConfig
+		} else {
+			err = srv.Serve(ln)
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("HTTP server failed", slog.Any("error", err))
+			l.errCh <- err
+		}
+	}()
+	return l, nil
+}
+
+// Serve switches the listener to handler for the following requests.
+func (l *publicListener) Serve(handler http.Handler) {
+	l.handler.Store(&handler)
+}
+
+func (l *publicListener) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	(*l.handler.Load()).ServeHTTP(w, r)
+}
+
+// bootProbes is the handler while the server is starting: the Kubernetes
+// probes, with /ready failing until startup is complete.
+func bootProbes(startup *hhttp.StartupState) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/live", &hhttp.LiveHandler{})
+	mux.Handle("/startup", &hhttp.StartupHandler{State: startup})
+	mux.Handle("/ready", &hhttp.ReadyHandler{Startup: startup})
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "starting", http.StatusServiceUnavailable)
+	})
+	return mux
+}
+
+// warmReadCache pre-fills the read cache with what the dashboard loads
+// first: the source list, the first article page and the stats report.
+// Nothing to do when the cache is disabled.
+func warmReadCache(ctx context.Context, readCache *cache.Cache, srcSvc srcUC.Service, artSvc artUC.Service, statsSvc *statsUC.Service, paginationCfg pagination.Config) error {
+	if readCache == nil {
+		return nil
+	}
+	var errs []error
+	if _, err := srcSvc.List(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("sources: %w", err))
+	}
+	if _, err := artSvc.ListWithSourcePaginated(ctx, pagination.Params{Page: 1, Limit: paginationCfg.DefaultLimit}); err != nil {
+		errs = append(errs, fmt.Errorf("articles: %w", err))
+	}
+	if _, err := statsSvc.Get(ctx, 0, 0); err != nil {
+		errs = append(errs, fmt.Errorf("stats: %w", err))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/feed"
+	hhttp "catchup-feed/internal/handler/http"
+	"catchup-feed/internal/pkg/buildinfo"
+	dashUC "catchup-feed/internal/usecase/dashboard"
+)
+
+// TestPublicListener_BootProbes covers the start sequence: the probes
+// answer while migrations run, and the full handler takes over afterwards.
+func TestPublicListener_BootProbes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	startup := hhttp.NewStartupState(hhttp.StartupMigrations, hhttp.StartupCacheWarmup)
+	cfg := httpServerConfig{Addr: "127.0.0.1:0", ReadHeaderTimeout: time.Second, MaxHeaderBytes: 1 << 20}
+	l, err := startPublicListener(ctx, cfg, startup, buildinfo.Info{}, testLogger())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), time.Second)
+		defer shutdownCancel()
+		_ = l.srv.Shutdown(shutdownCtx)
+	})
+	base := "http://" + l.srv.Addr
+
+	get := func(path string) (int, string) {
+		t.Helper()
+		resp := waitGet(t, base+path)
+		defer func() { _ = resp.Body.Close() }()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	// マイグレーション中: プロセスは生きているがトラフィックは受けない。
+	code, _ := get("/live")
+	assert.Equal(t, http.StatusOK, code)
+	code, body := get("/startup")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Contains(t, body, "migrations")
+	code, _ = get("/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	code, _ = get("/articles")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+
+	startup.Done(hhttp.StartupMigrations)
+	l.Serve(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	code, _ = get("/articles")
+	assert.Equal(t, http.StatusTeapot, code)
+}
+
+func TestStartPublicListener_BindFailure(t *testing.T) {
+	first, err := startPublicListener(context.Background(),
+		httpServerConfig{Addr: "127.0.0.1:0", ReadHeaderTimeout: time.Second, MaxHeaderBytes: 1 << 20},
+		hhttp.NewStartupState(), buildinfo.Info{}, testLogger())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = first.srv.Close() })
+
+	// 使用中のポートはマイグレーション前にエラーになる。
+	_, err = startPublicListener(context.Background(),
+		httpServerConfig{Addr: first.srv.Addr, ReadHeaderTimeout: time.Second, MaxHeaderBytes: 1 << 20},
+		hhttp.NewStartupState(), buildinfo.Info{}, testLogger())
+	assert.Error(t, err)
+}
+
+// TestSetupRoutes_ProbesArePublic goes through the full handler that
+// runServer switches to before the cache warm-up finishes: /startup must
+// still reach the probe, not the protected "/" mux (401).
+func TestSetupRoutes_ProbesArePublic(t *testing.T) {
+	t.Setenv("CORS_ALLOWED_ORIGINS", "http://localhost:3000")
+	startup := hhttp.NewStartupState(hhttp.StartupCacheWarmup)
+	mux, _ := setupRoutes(routeDeps{
+		startup:         startup,
+		dashboardEvents: dashUC.NewHub(),
+		logger:          testLogger(),
+		feedServer:      feed.NewServer(feed.Config{}, nil, nil, nil, testLogger()),
+	})
+
+	get := func(path string) int {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Code
+	}
+	assert.Equal(t, http.StatusServiceUnavailable, get("/startup"), "warm-up still running")
+	assert.Equal(t, http.StatusOK, get("/live"))
+	assert.Equal(t, http.StatusOK, get("/version"))
+	assert.Equal(t, http.StatusUnauthorized, get("/articles"))
+
+	startup.Done(hhttp.StartupCacheWarmup)
+	assert.Equal(t, http.StatusOK, get("/startup"))
+}
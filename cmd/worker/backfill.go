@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"catchup-feed/internal/infra/summarizer"
+	fetchUC "catchup-feed/internal/usecase/fetch"
+)
+
+// backfillDateLayout is the -from / -to format.
+const backfillDateLayout = "2006-01-02"
+
+// runBackfill implements `worker backfill`: a one-shot ingest of a
+// source's back catalog (fetch.Service.BackfillSource) for sources added
+// with a long history the crawl's D-15b cutoff drops. It shares the
+// worker's fetch service — summarizer chain, SSRF-checked client, source
+// locks — and exits when done.
+//
+//	worker backfill -source 12 -from 2025-01-01 -to 2025-06-30 \
+//	    -page-url 'https://example.com/feed?paged={page}'
+func runBackfill(ctx context.Context, logger *slog.Logger, database *sql.DB, args []string) error {
+	fs := flag.NewFlagSet("backfill", flag.ContinueOnError)
+	sourceID := fs.Int64("source", 0, "source ID to backfill (required)")
+	from := fs.String("from", "", "oldest published date to ingest, YYYY-MM-DD (default: unbounded)")
+	to := fs.String("to", "", "newest published date to ingest, YYYY-MM-DD, inclusive (default: unbounded)")
+	pageURL := fs.String("page-url", "", "archive page URL template containing "+fetchUC.BackfillPagePlaceholder+" (default: the feed URL only)")
+	maxPages := fs.Int("max-pages", fetchUC.DefaultBackfillMaxPages, "maximum number of archive pages to walk")
+	batchSize := fs.Int("batch", fetchUC.DefaultBackfillBatchSize, "articles summarized between two pauses")
+	delay := fs.Duration("delay", fetchUC.DefaultBackfillDelay, "pause between pages and batches")
+	timeout := fs.Duration("timeout", 2*time.Hour, "overall time limit")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *sourceID <= 0 {
+		return errors.New("-source is required")
+	}
+	opts := fetchUC.BackfillOptions{
+		PageURL:   *pageURL,
+		MaxPages:  *maxPages,
+		BatchSize: *batchSize,
+		Delay:     *delay,
+	}
+	var err error
+	if opts.From, err = parseBackfillDate(*from); err != nil {
+		return fmt.Errorf("-from: %w", err)
+	}
+	if opts.To, err = parseBackfillDate(*to); err != nil {
+		return fmt.Errorf("-to: %w", err)
+	}
+	if !opts.To.IsZero() {
+		opts.To = opts.To.Add(24*time.Hour - time.Nanosecond) // the whole day
+	}
+
+	svc := setupFetchService(logger, database)
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+	usage := summarizer.NewUsageMeter()
+	ctx = summarizer.WithUsageMeter(ctx, usage)
+
+	logger.Info("backfill started",
+		slog.Int64("source_id", *sourceID),
+		slog.String("from", *from),
+		slog.String("to", *to),
+		slog.String("page_url", *pageURL))
+	stats, err := svc.BackfillSource(ctx, *sourceID, opts)
+	if err != nil {
+		return err
+	}
+	logger.Info("backfill finished",
+		slog.Int("pages", stats.Pages),
+		slog.Int64("inserted", stats.Inserted),
+		slog.Int64("out_of_range", stats.OutOfRange),
+		slog.Int64("summarize_errors", stats.SummarizeError),
+		slog.Int64("prompt_tokens", usage.Total().PromptTokens),
+		slog.Int64("completion_tokens", usage.Total().CompletionTokens),
+		slog.Any("token_usage", usage),
+		slog.Duration("duration", stats.Duration))
+	return nil
+}
+
+// parseBackfillDate parses a -from / -to value; empty leaves the range
+// open.
+func parseBackfillDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(backfillDateLayout, s)
+}
@@ -1,22 +1,40 @@
-// Command worker is the Pi-resident daemon (§3.2 / §3.3): robfig/cron
-// drives the hourly crawl → summarize pipeline, and a jobs-table consumer
-// executes the follow-up work the radio batch enqueues (regenerate_feed,
-// notify_episode, notify_error) plus the daily media retention job (D-4).
-// All inter-process coordination happens through PostgreSQL (C-4).
+// Command worker is the Pi-resident daemon (§3.2 / §3.3). robfig/cron only
+// enqueues: the hourly crawl + summary sweep (crawl, resummarize; sources
+// with their own crawl_schedule get their own cron entry), the daily media
+// retention job (D-4), the daily article retention job
+// (purge_old_articles) and, with EXPORT_BUCKET, the article export to
+// object storage (export_articles). Two jobs-table consumers execute
+// everything: one runs crawl / resummarize under CRAWL_TIMEOUT, the other
+// the follow-up work the radio batch enqueues (regenerate_feed,
+// notify_episode, notify_error), the retention and export jobs and
+// outbound webhook deliveries (deliver_webhook). Scheduled jobs are enqueued with a dedupe key, so
+// several worker replicas can run side by side: each tick yields one job,
+// claimed by whichever replica gets there first, and a job orphaned by a
+// crashed replica is retried by the others. All inter-process
+// coordination happens through PostgreSQL (C-4) — including the
+// maintenance controls: an admin pauses the scheduled crawls or skips a
+// source through the API, and every replica's cron reads them per tick.
+//
+// `worker backfill` is a one-shot mode that ingests a source's back
+// catalog between two dates (see runBackfill).
 package main
 
 import (
 	"context"
 	"crypto/tls"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/google/uuid"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/robfig/cron/v3"
 
@@ -26,20 +44,45 @@ import (
 	pgRepo "catchup-feed/internal/infra/adapter/persistence/postgres"
 	"catchup-feed/internal/infra/db"
 	"catchup-feed/internal/infra/fetcher"
+	"catchup-feed/internal/infra/objectstore"
 	"catchup-feed/internal/infra/scraper"
+	"catchup-feed/internal/infra/secrets"
 	"catchup-feed/internal/infra/summarizer"
 	workerPkg "catchup-feed/internal/infra/worker"
 	"catchup-feed/internal/jobs"
 	"catchup-feed/internal/notify"
+	"catchup-feed/internal/pkg/logging"
+	"catchup-feed/internal/pkg/shutdown"
 	"catchup-feed/internal/repository"
+	alertUC "catchup-feed/internal/usecase/alert"
 	fetchUC "catchup-feed/internal/usecase/fetch"
+	webhookUC "catchup-feed/internal/usecase/webhook"
 	pkgconfig "catchup-feed/pkg/config"
 )
 
+// sourceScheduleSyncSpec is how often the worker re-reads per-source
+// crawl_schedule overrides edited through the API.
+const sourceScheduleSyncSpec = "@every 1m"
+
 // cleanupCronDefault schedules the daily cleanup_old_media enqueue (D-4:
 // worker の日次ジョブ), after the 04:30 radio batch window.
 const cleanupCronDefault = "30 6 * * *"
 
+// retentionCronDefault schedules the daily purge_old_articles enqueue,
+// after the media cleanup.
+const retentionCronDefault = "0 7 * * *"
+
+// exportCronDefault schedules the daily export_articles enqueue, after the
+// article retention job.
+const exportCronDefault = "30 7 * * *"
+
+// Default DIGEST_CRON_SCHEDULE per DIGEST_MODE: every morning, and Monday
+// morning for the weekly digest.
+const (
+	digestDailyCronDefault  = "0 8 * * *"
+	digestWeeklyCronDefault = "0 8 * * 1"
+)
+
 func waitForMigrations(logger *slog.Logger, db *sql.DB) {
 	const probe = "SELECT 1 FROM sources LIMIT 1"
 	for i := 0; i < 10; i++ {
@@ -54,7 +97,20 @@ func waitForMigrations(logger *slog.Logger, db *sql.DB) {
 }
 
 func main() {
-	logger := initLogger()
+	// CONFIG_FILE は LOG_LEVEL などを読む前に反映する(環境変数が優先)
+	fileCfg, fileErr := pkgconfig.ApplyFileFromEnv()
+	logger := logging.Init()
+	if fileErr != nil {
+		logger.Error("invalid configuration", slog.Any("error", fileErr))
+		os.Exit(1)
+	}
+	fileCfg.LogSummary(logger)
+	// vault:// / awssm:// の参照を実値に置き換える(各設定を読む前)
+	secretRefs, secretErr := secrets.ResolveEnv(context.Background(), logger)
+	if secretErr != nil {
+		logger.Error("failed to resolve secrets", slog.Any("error", secretErr))
+		os.Exit(1)
+	}
 	database := initDatabase(logger)
 	defer func() {
 		if err := database.Close(); err != nil {
@@ -62,9 +118,26 @@ func main() {
 		}
 	}()
 
-	// SIGINT/SIGTERM stop the consumer loop and the main wait.
-	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	// SIGINT/SIGTERM start the drain (internal/pkg/shutdown): /health/ready
+	// fails first, and ctx — the consumers, cron and background loops — is
+	// canceled only after SHUTDOWN_DRAIN_PERIOD.
+	sigCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	// Secret rotation (SECRETS_REFRESH_INTERVAL, 0 = off)
+	go secretRefs.Refresh(ctx)
+
+	// `worker backfill ...` ingests a source's back catalog and exits.
+	if len(os.Args) > 1 && os.Args[1] == "backfill" {
+		if err := runBackfill(sigCtx, logger, database, os.Args[2:]); err != nil {
+			logger.Error("backfill failed", slog.Any("error", hhttp.SanitizeError(err)))
+			cancel()
+			_ = database.Close()
+			os.Exit(1)
+		}
+		return
+	}
 
 	// Load worker configuration (fail-open strategy)
 	workerConfig, err := workerPkg.LoadConfigFromEnv(logger)
@@ -76,42 +149,88 @@ func main() {
 		slog.String("cron_schedule", workerConfig.CronSchedule),
 		slog.String("timezone", workerConfig.Timezone),
 		slog.Duration("crawl_timeout", workerConfig.CrawlTimeout),
+		slog.Int("crawl_concurrency", workerConfig.CrawlConcurrency),
+		slog.Duration("crawl_source_timeout", workerConfig.CrawlSourceTimeout),
+		slog.Int("near_duplicate_max_distance", workerConfig.NearDuplicateMaxDistance),
 		slog.Int("health_port", workerConfig.HealthPort))
 
 	// Start health check server
 	healthAddr := fmt.Sprintf(":%d", workerConfig.HealthPort)
 	healthServer := workerPkg.NewHealthServer(healthAddr, logger)
+	// The health server outlives the drain: liveness keeps answering and
+	// readiness reports "not ready" until the worker has stopped.
+	healthCtx, stopHealth := context.WithCancel(context.Background())
+	defer stopHealth()
 	go func() {
-		if err := healthServer.Start(ctx); err != nil && err != http.ErrServerClosed {
+		if err := healthServer.Start(healthCtx); err != nil && err != http.ErrServerClosed {
 			logger.Error("health server failed", slog.Any("error", err))
 		}
 	}()
 	logger.Info("health check server started", slog.String("addr", healthAddr))
 
+	// Periodic connection pool statistics (DB_STATS_INTERVAL, 0 = off)
+	go db.SampleStats(ctx, database, db.StatsIntervalFromEnv(), logger)
+
 	svc := setupFetchService(logger, database)
+	svc.SourceConcurrency = workerConfig.CrawlConcurrency
+	svc.SourceTimeout = workerConfig.CrawlSourceTimeout
+	svc.NearDuplicateMaxDistance = workerConfig.NearDuplicateMaxDistance
+
+	// running tracks the consumers, so shutdown waits for their in-flight
+	// jobs to return after ctx is canceled.
+	var running sync.WaitGroup
 
 	// jobs consumer (§3.3): drains the queue the radio batch feeds.
-	consumer := setupJobsConsumer(logger, database)
-	go func() {
+	consumer := setupJobsConsumer(logger, database, workerConfig)
+	running.Go(func() {
 		if err := consumer.Run(ctx); err != nil && ctx.Err() == nil {
 			logger.Error("jobs consumer stopped unexpectedly", slog.Any("error", err))
 		}
+	})
+
+	// Crawls (scheduled and on-demand) and the summary sweep get their own
+	// consumer: a crawl may run up to CrawlTimeout, far beyond the general
+	// consumer's job timeout, and must not hold up notifications.
+	crawlConsumer := setupCrawlConsumer(logger, database, &svc, workerConfig)
+	running.Go(func() {
+		if err := crawlConsumer.Run(ctx); err != nil && ctx.Err() == nil {
+			logger.Error("crawl consumer stopped unexpectedly", slog.Any("error", err))
+		}
+	})
+
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		<-sigCtx.Done()
+		drainWorker(logger, shutdown.LoadConfigFromEnv(), healthServer, cancel, &running)
 	}()
 
-	startCronWorker(ctx, logger, svc, workerConfig, healthServer, pgRepo.NewJobRepo(database))
+	startCronWorker(ctx, logger, svc.SourceRepo, workerConfig, healthServer, pgRepo.NewJobRepo(database), svc.Control, svc.Groups)
+	<-drained
 }
 
-// initLogger initializes and returns a structured logger based on environment configuration.
-func initLogger() *slog.Logger {
-	logLevel := slog.LevelInfo
-	if os.Getenv("LOG_LEVEL") == "debug" {
-		logLevel = slog.LevelDebug
-	}
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-		Level: logLevel,
-	}))
-	slog.SetDefault(logger)
-	return logger
+// drainWorker runs the shutdown sequence: readiness fails, the drain
+// period passes, then the consumers and cron are stopped and their
+// in-flight jobs get until the deadline to return. A job cut off at the
+// deadline is left running in the table and requeued by the stale sweep.
+func drainWorker(logger *slog.Logger, cfg shutdown.Config, health *workerPkg.HealthServer, stop context.CancelFunc, running *sync.WaitGroup) {
+	err := cfg.Drain(logger, func() { health.SetReady(false) }, func(ctx context.Context) error {
+		stop()
+		done := make(chan struct{})
+		go func() {
+			running.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	if err != nil {
+		logger.Error("shutdown deadline exceeded, exiting with jobs still running", slog.Any("error", err))
+	}
 }
 
 // initDatabase opens the database connection and waits for migrations to complete.
@@ -122,10 +241,10 @@ func initDatabase(logger *slog.Logger) *sql.DB {
 }
 
 // setupJobsConsumer wires the §3.3 consumer: destinations from environment
-// (D-7: 宣言的に有効/無効), the friend mailer (C-11) and the four Phase 1
-// handlers. Feed config supplies the audio dir (D-4 cleanup) and the
+// (D-7: 宣言的に有効/無効), the friend mailer (C-11), the four Phase 1
+// handlers and the webhook delivery handler. Feed config supplies the audio dir (D-4 cleanup) and the
 // private base URL used for the admin-facing episode link.
-func setupJobsConsumer(logger *slog.Logger, database *sql.DB) *jobs.Consumer {
+func setupJobsConsumer(logger *slog.Logger, database *sql.DB, cfg *workerPkg.WorkerConfig) *jobs.Consumer {
 	destinations := notify.LoadDestinationsFromEnv(logger)
 	mailer := notify.LoadSMTPFromEnv(logger)
 	feedCfg := feed.LoadConfig()
@@ -154,12 +273,137 @@ func setupJobsConsumer(logger *slog.Logger, database *sql.DB) *jobs.Consumer {
 				AudioDir: feedCfg.AudioDir,
 				Logger:   logger,
 			},
+			entity.JobKindPurgeOldArticles: newRetentionHandler(logger, database),
+			// Outbound webhooks: the consumer's retry ceiling is the
+			// delivery retry policy.
+			entity.JobKindDeliverWebhook: &jobs.DeliverWebhookHandler{
+				Webhooks: pgRepo.NewWebhookRepo(database),
+				Logger:   logger,
+			},
+			entity.JobKindNotifyDigest:   newDigestHandler(logger, database, destinations, cfg),
+			entity.JobKindNotifyAlert:    &jobs.NotifyAlertHandler{Destinations: destinations, Logger: logger},
+			entity.JobKindExportArticles: newExportHandler(logger, database),
 		},
 		PollInterval: pkgconfig.GetEnvDuration("JOBS_POLL_INTERVAL", jobs.DefaultPollInterval),
 		Logger:       logger,
 	}
 }
 
+// setupCrawlConsumer wires the consumer of 'crawl' and 'resummarize' jobs.
+// Its job timeout is CrawlTimeout; its stale sweep covers only those kinds,
+// so it never touches the general consumer's jobs.
+func setupCrawlConsumer(logger *slog.Logger, database *sql.DB, svc *fetchUC.Service, cfg *workerPkg.WorkerConfig) *jobs.Consumer {
+	return &jobs.Consumer{
+		Jobs: pgRepo.NewJobRepo(database),
+		Handlers: map[string]jobs.Handler{
+			entity.JobKindCrawl:       withCrawlContext(logger, &jobs.CrawlHandler{Crawler: svc, Logger: logger}),
+			entity.JobKindResummarize: withCrawlContext(logger, &jobs.ResummarizeHandler{Sweeper: svc, Logger: logger}),
+		},
+		PollInterval: pkgconfig.GetEnvDuration("JOBS_POLL_INTERVAL", jobs.DefaultPollInterval),
+		JobTimeout:   cfg.CrawlTimeout,
+		Logger:       logger,
+	}
+}
+
+// newRetentionHandler configures purge_old_articles from environment:
+// RETENTION_DAYS (0 = keep forever unless a source sets retention_days),
+// RETENTION_MODE (archive | delete) and RETENTION_DRY_RUN.
+func newRetentionHandler(logger *slog.Logger, database *sql.DB) *jobs.RetentionHandler {
+	days := pkgconfig.GetEnvInt("RETENTION_DAYS", 0)
+	if days < 0 {
+		days = 0
+	}
+	if days > 0 && days < entity.MinRetentionDays {
+		logger.Warn("RETENTION_DAYS below the crawl backfill window, raising it",
+			slog.Int("configured", days), slog.Int("used", entity.MinRetentionDays))
+		days = entity.MinRetentionDays
+	}
+	mode := pkgconfig.GetEnvString("RETENTION_MODE", jobs.RetentionModeArchive)
+	if mode != jobs.RetentionModeArchive && mode != jobs.RetentionModeDelete {
+		logger.Warn("invalid RETENTION_MODE, using archive", slog.String("mode", mode))
+		mode = jobs.RetentionModeArchive
+	}
+	return &jobs.RetentionHandler{
+		Articles:    pgRepo.NewArticleRetentionRepo(database),
+		DefaultDays: days,
+		Mode:        mode,
+		DryRun:      pkgconfig.GetEnvBool("RETENTION_DRY_RUN", false),
+		Logger:      logger,
+	}
+}
+
+// newExportHandler configures export_articles from environment: the bucket
+// (objectstore.NewS3FromEnv), EXPORT_PREFIX, EXPORT_GZIP,
+// EXPORT_RETENTION_DAYS, EXPORT_MAX_ROWS_PER_OBJECT and EXPORT_SAFETY_LAG. Like the digest,
+// the handler is registered even without a bucket, so a leftover job fails
+// permanently instead of staying pending. A misconfigured bucket disables
+// the export with an error log.
+func newExportHandler(logger *slog.Logger, database *sql.DB) *jobs.ExportArticlesHandler {
+	handler := &jobs.ExportArticlesHandler{
+		Articles:         pgRepo.NewArticleExportRepo(database),
+		Prefix:           pkgconfig.GetEnvString("EXPORT_PREFIX", "catchup-feed"),
+		Gzip:             pkgconfig.GetEnvBool("EXPORT_GZIP", true),
+		RetentionDays:    max(pkgconfig.GetEnvInt("EXPORT_RETENTION_DAYS", 0), 0),
+		MaxRowsPerObject: pkgconfig.GetEnvInt("EXPORT_MAX_ROWS_PER_OBJECT", jobs.DefaultExportMaxRowsPerObject),
+		SafetyLag:        pkgconfig.GetEnvDuration("EXPORT_SAFETY_LAG", jobs.DefaultExportSafetyLag),
+		Logger:           logger,
+	}
+	store, err := objectstore.NewS3FromEnv()
+	if err != nil {
+		logger.Error("invalid export bucket configuration, article export disabled", slog.Any("error", err))
+		return handler
+	}
+	// Assigned only when configured (a nil *S3 would make a non-nil
+	// interface).
+	if store != nil {
+		handler.Store = store
+	}
+	return handler
+}
+
+// digestMode reads DIGEST_MODE: off (default), daily or weekly. An unknown
+// value turns the digest off with a warning.
+func digestMode(logger *slog.Logger) string {
+	mode := pkgconfig.GetEnvString("DIGEST_MODE", "off")
+	if mode != "off" && jobs.DigestPeriod(mode) == 0 {
+		logger.Warn("invalid DIGEST_MODE, digest disabled", slog.String("mode", mode))
+		return "off"
+	}
+	return mode
+}
+
+// newDigestHandler configures notify_digest from environment: DIGEST_MODE,
+// DIGEST_MAX_ITEMS and DIGEST_MAX_TOPICS. With the digest off the handler is still
+// registered, so a job left over from a previous configuration fails
+// permanently instead of staying pending.
+func newDigestHandler(logger *slog.Logger, database *sql.DB, destinations []notify.Destination, cfg *workerPkg.WorkerConfig) *jobs.NotifyDigestHandler {
+	maxItems := pkgconfig.GetEnvInt("DIGEST_MAX_ITEMS", jobs.DefaultDigestMaxItems)
+	if maxItems <= 0 {
+		logger.Warn("invalid DIGEST_MAX_ITEMS, using default",
+			slog.Int("value", maxItems), slog.Int("default", jobs.DefaultDigestMaxItems))
+		maxItems = jobs.DefaultDigestMaxItems
+	}
+	maxTopics := pkgconfig.GetEnvInt("DIGEST_MAX_TOPICS", jobs.DefaultDigestMaxTopics)
+	if maxTopics <= 0 {
+		logger.Warn("invalid DIGEST_MAX_TOPICS, using default",
+			slog.Int("value", maxTopics), slog.Int("default", jobs.DefaultDigestMaxTopics))
+		maxTopics = jobs.DefaultDigestMaxTopics
+	}
+	loc, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	return &jobs.NotifyDigestHandler{
+		Articles:     pgRepo.NewArticleDigestRepo(database),
+		Destinations: destinations,
+		Mode:         digestMode(logger),
+		MaxItems:     maxItems,
+		MaxTopics:    maxTopics,
+		Location:     loc,
+		Logger:       logger,
+	}
+}
+
 // setupFetchService creates and configures the fetch service with all dependencies.
 func setupFetchService(logger *slog.Logger, database *sql.DB) fetchUC.Service {
 	srcRepo := pgRepo.NewSourceRepo(database)
@@ -180,7 +424,12 @@ func setupFetchService(logger *slog.Logger, database *sql.DB) fetchUC.Service {
 	}
 
 	httpClient := createHTTPClient(contentFetchConfig.MaxRedirects, contentFetchConfig.DenyPrivateIPs)
-	feedFetcher := scraper.NewRSSFetcher(httpClient)
+	// SCRAPER_CONFIG: sites without a feed, scraped by CSS selectors.
+	feedFetcher, err := scraper.NewFeedFetcherFromEnv(httpClient, logger)
+	if err != nil {
+		logger.Error("failed to load scraper config", slog.Any("error", err))
+		os.Exit(1)
+	}
 
 	// Create ContentFetcher if enabled
 	var contentFetcher fetchUC.ContentFetcher
@@ -221,13 +470,72 @@ func setupFetchService(logger *slog.Logger, database *sql.DB) fetchUC.Service {
 	if vd := summarizer.NewVideoDescriberFromEnv(logger); vd != nil {
 		svc.VideoDescriber = vd
 	}
+	// article.created / crawl.completed for the registered webhooks. With
+	// no webhook registered the publish is a no-op INSERT ... SELECT. Every
+	// new article is also matched against the users' keyword alert rules;
+	// a hit queues a notify_alert job.
+	svc.Events = fetchUC.EventPublishers{
+		&webhookUC.Service{Webhooks: pgRepo.NewWebhookRepo(database), Sources: srcRepo, Logger: logger},
+		&alertUC.Service{Rules: pgRepo.NewAlertRuleRepo(database), Logger: logger},
+	}
+	// The article, its article.created deliveries and its alert hits commit
+	// together (outbox): the deliver_webhook / notify_alert jobs dispatch
+	// and retry them.
+	svc.Tx = pgRepo.NewTxManager(database)
+	// source_health: per-source fetch outcome for GET /sources/{id}/health.
+	svc.HealthRepo = pgRepo.NewSourceHealthRepo(database)
+	svc.ContentRepo = pgRepo.NewArticleContentRepo(database)
+	// crawl_runs: every run (cron, per-source schedule, on-demand) for GET /crawls.
+	svc.RunRepo = pgRepo.NewCrawlRunRepo(database)
+	// Sources an admin skipped (PUT /sources/{id}/crawl-skip) are left out
+	// of the default-schedule crawl.
+	svc.Control = pgRepo.NewCrawlControlRepo(database)
+	// Source groups: paused groups are left out and group crawl schedules
+	// are left to the source scheduler.
+	svc.Groups = pgRepo.NewSourceGroupRepo(database)
+	// Per-source advisory locks: with several worker replicas, a source
+	// already being crawled by one of them is skipped by the others.
+	svc.Locker = workerPkg.NewAdvisoryLocker(database, logger)
+	// SimHash near-duplicate grouping; active once the caller sets
+	// NearDuplicateMaxDistance (NEAR_DUPLICATE_MAX_DISTANCE).
+	svc.NearDuplicates = pgRepo.NewArticleSimilarityRepo(database)
+	// HEADLESS_ENABLED: render_js sources are fetched with headless Chrome.
+	// Assigned only when enabled (a nil *HeadlessFetcher would make a
+	// non-nil interface).
+	if hf := setupHeadlessFetcher(logger, contentFetchConfig); hf != nil {
+		svc.HeadlessFetcher = hf
+	}
+	// QUALITY_*: junk items are dropped or marked before summarization.
+	svc.Quality = fetchUC.LoadQualityFilterFromEnv(logger)
 	return svc
 }
 
+// setupHeadlessFetcher builds the headless browser fallback for render_js
+// sources, or returns nil when HEADLESS_ENABLED is off or misconfigured.
+// The browser starts on the first render_js fetch and is killed with the
+// process (chromedp sets the parent-death signal), so it is never closed
+// explicitly.
+func setupHeadlessFetcher(logger *slog.Logger, contentFetchConfig fetcher.ContentFetchConfig) *fetcher.HeadlessFetcher {
+	cfg, err := fetcher.LoadHeadlessConfigFromEnv()
+	if err != nil {
+		logger.Warn("Headless fetching disabled due to configuration error", slog.Any("error", err))
+		return nil
+	}
+	if !cfg.Enabled {
+		return nil
+	}
+	logger.Info("Headless fetching enabled for render_js sources",
+		slog.Int("concurrency", cfg.Concurrency),
+		slog.Duration("timeout", cfg.Timeout))
+	return fetcher.NewHeadlessFetcher(cfg, contentFetchConfig)
+}
+
 // createSummarizer builds the Gemini -> Groq -> Ollama fallback chain from
 // environment variables (GEMINI_API_KEY, GROQ_API_KEY, OLLAMA_HOST, ...).
 // Providers without an API key are excluded automatically. The worker cannot
 // run without at least one provider, so an empty chain is fatal.
+// SUMMARIZER_BATCH_SIZE wraps the chain in a Batcher that sends short
+// articles several to a request.
 func createSummarizer(logger *slog.Logger) fetchUC.Summarizer {
 	chain, err := summarizer.NewChainFromEnv(logger)
 	if err != nil {
@@ -236,6 +544,12 @@ func createSummarizer(logger *slog.Logger) fetchUC.Summarizer {
 			slog.String("hint", "set GEMINI_API_KEY / GROQ_API_KEY or enable Ollama"))
 		os.Exit(1)
 	}
+	if cfg := summarizer.LoadBatchConfig(summarizer.LoadOptions()); cfg.Enabled() {
+		logger.Info("Batch summarization enabled",
+			slog.Int("batch_size", cfg.Size),
+			slog.Duration("batch_wait", cfg.Wait))
+		return summarizer.NewBatcher(chain, cfg, logger)
+	}
 	return chain
 }
 
@@ -263,61 +577,164 @@ func createHTTPClient(maxRedirects int, denyPrivateIPs bool) *http.Client {
 	}
 }
 
-// cronSlogLogger adapts slog to the robfig/cron Logger interface so chain
-// decorators (SkipIfStillRunning) log through the worker's JSON logger.
-type cronSlogLogger struct{ logger *slog.Logger }
-
-func (l cronSlogLogger) Info(msg string, keysAndValues ...any) {
-	l.logger.Info("cron: "+msg, slog.Any("details", keysAndValues))
-}
-
-func (l cronSlogLogger) Error(err error, msg string, keysAndValues ...any) {
-	l.logger.Error("cron: "+msg, slog.Any("error", err), slog.Any("details", keysAndValues))
+// tickKey is the dedupe key of the scheduled job entry enqueues at now:
+// the entry and the tick it fired for, e.g.
+// "notify_digest:2026-07-04T05:30:00Z". The cron specs have minute
+// resolution and every replica fires a tick within its minute, so the
+// tick is now truncated to the minute.
+func tickKey(entry string, now time.Time) string {
+	return entry + ":" + now.Truncate(time.Minute).UTC().Format(time.RFC3339)
 }
 
-// startCronWorker starts the cron scheduler (crawl + daily cleanup
-// enqueue) and blocks until ctx is done.
-func startCronWorker(ctx context.Context, logger *slog.Logger, svc fetchUC.Service, cfg *workerPkg.WorkerConfig, healthServer *workerPkg.HealthServer, jobQueue repository.JobRepository) {
+// startCronWorker starts the cron scheduler and blocks until ctx is done.
+// Every entry only enqueues a job; the consumers execute them. Scheduled
+// jobs carry a dedupe key naming the entry and its tick (tickKey), so a
+// tick runs once however many replicas fire it, and whenever they do.
+// The crawl controls (POST /crawl/pause, PUT /sources/{id}/crawl-skip)
+// are read on every crawl tick, so a pause set through the API reaches
+// every replica without a restart.
+func startCronWorker(ctx context.Context, logger *slog.Logger, sources repository.SourceRepository, cfg *workerPkg.WorkerConfig, healthServer *workerPkg.HealthServer, jobQueue repository.JobRepository, control repository.CrawlControlRepository, groups repository.SourceGroupRepository) {
 	// Load timezone
 	loc, err := time.LoadLocation(cfg.Timezone)
 	if err != nil {
 		logger.Error("invalid timezone, using UTC", slog.String("timezone", cfg.Timezone), slog.Any("error", err))
 		loc = time.UTC
 	}
-	// SkipIfStillRunning: crawl+sweep は逐次で最悪 CrawlTimeout×2(既定60分)
-	// まで走り得るため、前回実行が毎時発火と接触したら重ねずスキップする
-	// (次の毎時発火が回収する、縮退許容)。スキップはログで観測できる。
-	c := cron.New(
-		cron.WithLocation(loc),
-		cron.WithChain(cron.SkipIfStillRunning(cronSlogLogger{logger: logger})),
-	)
+	c := cron.New(cron.WithLocation(loc))
+
+	enqueue := func(kind, entry string, payload any) {
+		dedupeKey := tickKey(entry, time.Now())
+		var raw json.RawMessage
+		if payload != nil {
+			b, err := json.Marshal(payload)
+			if err != nil {
+				logger.Error("failed to encode job payload", slog.String("kind", kind), slog.Any("error", err))
+				return
+			}
+			raw = b
+		}
+		id, enqueued, err := jobQueue.EnqueueUnique(ctx, kind, dedupeKey, raw, time.Time{})
+		if err != nil {
+			logger.Error("failed to enqueue scheduled job",
+				slog.String("kind", kind), slog.String("dedupe_key", dedupeKey), slog.Any("error", err))
+			return
+		}
+		if !enqueued {
+			logger.Info("scheduled job already queued, skipping",
+				slog.String("kind", kind), slog.String("dedupe_key", dedupeKey))
+			return
+		}
+		logger.Info("scheduled job enqueued",
+			slog.String("kind", kind), slog.String("dedupe_key", dedupeKey), slog.Int64("job_id", id))
+	}
+
+	// crawlControl reads the maintenance controls before a scheduled crawl.
+	// A failed read crawls anyway: a database hiccup must not silently
+	// stop the crawls.
+	crawlControl := func() *entity.CrawlControl {
+		ctl, err := control.Get(ctx)
+		if err != nil {
+			logger.Error("failed to read crawl controls, crawling anyway", slog.Any("error", err))
+			return nil
+		}
+		if ctl.Paused {
+			logger.Info("scheduled crawls are paused, skipping",
+				slog.String("reason", ctl.Reason), slog.String("paused_by", ctl.UpdatedBy))
+		}
+		return ctl
+	}
 
 	_, err = c.AddFunc(cfg.CronSchedule, func() {
-		// Crawl first, then sweep (§5.2b: クロールの後に掃き取り). The sweep
-		// runs even when the crawl errored: its targets (transcripts filled
-		// in by the Mac worker overnight) do not depend on this cycle's
-		// crawl succeeding.
-		runCrawlJob(logger, svc, cfg)
-		runSweepJob(logger, svc, cfg)
+		if ctl := crawlControl(); ctl != nil && ctl.Paused {
+			return
+		}
+		// Crawl first, then sweep (§5.2b: クロールの後に掃き取り): the crawl
+		// consumer claims in enqueue order. The sweep runs even when the
+		// crawl fails: its targets (transcripts filled in by the Mac worker
+		// overnight) do not depend on this cycle's crawl succeeding.
+		// Sources with their own crawl_schedule are skipped by the crawl;
+		// the source scheduler below crawls them.
+		enqueue(entity.JobKindCrawl, "crawl:default", entity.CrawlPayload{DefaultSchedule: true})
+		enqueue(entity.JobKindResummarize, entity.JobKindResummarize, nil)
 	})
 	if err != nil {
 		logger.Error("failed to add cron job", slog.Any("error", err))
 		os.Exit(1)
 	}
 
+	// Per-source crawl_schedule overrides (the source's own or its
+	// group's): one cron entry per source, kept in sync with the sources
+	// table. A failed sync only delays schedule
+	// changes until the next one.
+	sourceScheduler := workerPkg.NewSourceScheduler(c, sources, func(sourceID int64) {
+		if ctl := crawlControl(); ctl != nil && (ctl.Paused || ctl.Skips(sourceID)) {
+			return
+		}
+		enqueue(entity.JobKindCrawl, fmt.Sprintf("crawl:source:%d", sourceID), entity.CrawlPayload{SourceID: sourceID})
+	}, logger)
+	sourceScheduler.Groups = groups
+	if err := sourceScheduler.Sync(ctx); err != nil {
+		logger.Error("failed to load source crawl schedules", slog.Any("error", err))
+	}
+	_, err = c.AddFunc(sourceScheduleSyncSpec, func() {
+		if err := sourceScheduler.Sync(ctx); err != nil {
+			logger.Error("failed to sync source crawl schedules", slog.Any("error", err))
+		}
+	})
+	if err != nil {
+		logger.Error("failed to add source schedule sync job", slog.Any("error", err))
+		os.Exit(1)
+	}
+
 	// D-4: enqueue the daily media retention job. Going through the queue
 	// (instead of running inline) gives the cleanup the same retry /
 	// last_error bookkeeping as every other job.
 	cleanupSchedule := pkgconfig.GetEnvString("CLEANUP_CRON_SCHEDULE", cleanupCronDefault)
 	_, err = c.AddFunc(cleanupSchedule, func() {
-		if _, err := jobQueue.Enqueue(context.Background(), entity.JobKindCleanupOldMedia, nil, time.Time{}); err != nil {
-			logger.Error("failed to enqueue cleanup_old_media", slog.Any("error", err))
-		}
+		enqueue(entity.JobKindCleanupOldMedia, entity.JobKindCleanupOldMedia, nil)
 	})
 	if err != nil {
 		logger.Error("failed to add cleanup cron job", slog.Any("error", err))
 		os.Exit(1)
 	}
+	// Article retention: queued like the media cleanup. Enqueued even with
+	// RETENTION_DAYS=0 because sources may set their own retention_days.
+	retentionSchedule := pkgconfig.GetEnvString("RETENTION_CRON_SCHEDULE", retentionCronDefault)
+	_, err = c.AddFunc(retentionSchedule, func() {
+		enqueue(entity.JobKindPurgeOldArticles, entity.JobKindPurgeOldArticles, nil)
+	})
+	if err != nil {
+		logger.Error("failed to add retention cron job", slog.Any("error", err))
+		os.Exit(1)
+	}
+	// Article digest for the admin destinations (DIGEST_MODE=daily|weekly).
+	digestSchedule := "off"
+	if mode := digestMode(logger); mode != "off" {
+		digestSchedule = digestDailyCronDefault
+		if mode == jobs.DigestModeWeekly {
+			digestSchedule = digestWeeklyCronDefault
+		}
+		digestSchedule = pkgconfig.GetEnvString("DIGEST_CRON_SCHEDULE", digestSchedule)
+		_, err = c.AddFunc(digestSchedule, func() {
+			enqueue(entity.JobKindNotifyDigest, entity.JobKindNotifyDigest, nil)
+		})
+		if err != nil {
+			logger.Error("failed to add digest cron job", slog.Any("error", err))
+			os.Exit(1)
+		}
+	}
+	// Article export to object storage (EXPORT_BUCKET).
+	exportSchedule := "off"
+	if os.Getenv(objectstore.EnvBucket) != "" {
+		exportSchedule = pkgconfig.GetEnvString("EXPORT_CRON_SCHEDULE", exportCronDefault)
+		_, err = c.AddFunc(exportSchedule, func() {
+			enqueue(entity.JobKindExportArticles, entity.JobKindExportArticles, nil)
+		})
+		if err != nil {
+			logger.Error("failed to add export cron job", slog.Any("error", err))
+			os.Exit(1)
+		}
+	}
 	c.Start()
 
 	// Mark as ready after cron is set up
@@ -326,7 +743,11 @@ func startCronWorker(ctx context.Context, logger *slog.Logger, svc fetchUC.Servi
 
 	logger.Info("worker started",
 		slog.String("schedule", cfg.CronSchedule),
+		slog.Int("source_schedules", sourceScheduler.Scheduled()),
 		slog.String("cleanup_schedule", cleanupSchedule),
+		slog.String("retention_schedule", retentionSchedule),
+		slog.String("digest_schedule", digestSchedule),
+		slog.String("export_schedule", exportSchedule),
 		slog.String("timezone", cfg.Timezone))
 
 	<-ctx.Done()
@@ -334,60 +755,34 @@ func startCronWorker(ctx context.Context, logger *slog.Logger, svc fetchUC.Servi
 	<-c.Stop().Done()
 }
 
-// runCrawlJob executes a single crawl job with timeout and error handling.
-func runCrawlJob(logger *slog.Logger, svc fetchUC.Service, cfg *workerPkg.WorkerConfig) {
-	startTime := time.Now()
-	logger.Info("crawl started")
-
-	// クロール処理のタイムアウト（設定から取得）
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.CrawlTimeout)
-	defer cancel()
-
-	stats, err := svc.CrawlAllSources(ctx)
-	if err != nil {
-		// 機密情報をマスクしてログ出力
-		logger.Error("crawl failed",
-			slog.Any("error", hhttp.SanitizeError(err)),
-			slog.Duration("duration", time.Since(startTime)))
-		return
-	}
-
-	logger.Info("crawl completed",
-		slog.Int("sources", stats.Sources),
-		slog.Int64("feed_items", stats.FeedItems),
-		slog.Int64("inserted", stats.Inserted),
-		slog.Int64("duplicated", stats.Duplicated),
-		slog.Int64("summarize_errors", stats.SummarizeError),
-		slog.Int64("youtube_direct_attempts", stats.YouTubeDirectAttempts),
-		slog.Int64("youtube_direct_succeeded", stats.YouTubeDirectSucceeded),
-		slog.Duration("duration", stats.Duration),
-	)
-}
-
-// runSweepJob executes the §5.2b summary sweep: summarize articles whose
-// content was filled in after insert (transcribe path). Failed articles
-// are simply left in place — the next hourly run retries them (縮退許容,
-// no jobs-table bookkeeping).
-func runSweepJob(logger *slog.Logger, svc fetchUC.Service, cfg *workerPkg.WorkerConfig) {
-	startTime := time.Now()
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.CrawlTimeout)
-	defer cancel()
-
-	stats, err := svc.SweepUnsummarized(ctx)
-	if err != nil {
-		logger.Error("summary sweep failed",
-			slog.Any("error", hhttp.SanitizeError(err)),
-			slog.Duration("duration", time.Since(startTime)))
-		return
-	}
-	if stats.Candidates == 0 {
-		return // the common case: nothing transcribed since last cycle
-	}
-	logger.Info("summary sweep completed",
-		slog.Int("candidates", stats.Candidates),
-		slog.Int64("summarized", stats.Summarized),
-		slog.Int64("failed", stats.Failed),
-		slog.Bool("limit_hit", stats.LimitHit),
-		slog.Duration("duration", stats.Duration),
-	)
+// withCrawlContext runs a crawl-consumer job the way the cron crawl used to
+// run: one crawl_id threads the log lines of the whole run (source →
+// article → summarizer chain), and the summarizer tokens it consumed are
+// logged per provider/model.
+func withCrawlContext(logger *slog.Logger, h jobs.Handler) jobs.Handler {
+	return jobs.HandlerFunc(func(ctx context.Context, job *entity.Job) error {
+		ctx = logging.WithAttrs(ctx, slog.String("crawl_id", uuid.NewString()))
+		usage := summarizer.NewUsageMeter()
+		ctx = summarizer.WithUsageMeter(ctx, usage)
+
+		err := h.Handle(ctx, job)
+		if total := usage.Total(); total.PromptTokens > 0 || total.CompletionTokens > 0 {
+			logger.InfoContext(ctx, "crawl token usage",
+				slog.Int64("job_id", job.ID),
+				slog.String("kind", job.Kind),
+				slog.Int64("prompt_tokens", total.PromptTokens),
+				slog.Int64("completion_tokens", total.CompletionTokens),
+				slog.Any("token_usage", usage))
+		}
+		if err == nil {
+			return nil
+		}
+		// 機密情報をマスクする: the consumer logs the error and stores it
+		// in jobs.last_error.
+		sanitized := errors.New(hhttp.SanitizeError(err))
+		if jobs.IsPermanent(err) {
+			return jobs.Permanent(sanitized)
+		}
+		return sanitized
+	})
 }
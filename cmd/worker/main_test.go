@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTickKey(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	tick := time.Date(2026, 7, 4, 14, 30, 0, 0, jst)
+
+	want := "notify_digest:2026-07-04T05:30:00Z"
+	for _, firedAt := range []time.Time{tick, tick.Add(15 * time.Millisecond), tick.Add(59 * time.Second)} {
+		if got := tickKey("notify_digest", firedAt); got != want {
+			t.Errorf("tickKey(%v) = %q, want %q", firedAt, got, want)
+		}
+	}
+	if got := tickKey("notify_digest", tick.Add(time.Minute)); got == want {
+		t.Errorf("the next minute's tick shares the key %q", got)
+	}
+	if got, want := tickKey("crawl:source:3", tick), "crawl:source:3:2026-07-04T05:30:00Z"; got != want {
+		t.Errorf("tickKey = %q, want %q", got, want)
+	}
+}
@@ -13,6 +13,10 @@ type Config struct {
 	DefaultPage  int // Default page number (typically 1)
 	DefaultLimit int // Default items per page (typically 20)
 	MaxLimit     int // Maximum allowed items per page (typically 100)
+	// TierMaxLimits overrides MaxLimit per caller tier (TierAnonymous,
+	// TierViewer, TierAdmin, TierAPIKey); a tier without an entry uses
+	// MaxLimit. See MaxLimitFor.
+	TierMaxLimits map[string]int
 }
 
 // DefaultConfig returns the default pagination configuration.
@@ -30,14 +34,33 @@ func DefaultConfig() Config {
 //   - PAGINATION_DEFAULT_PAGE: Default page number
 //   - PAGINATION_DEFAULT_LIMIT: Default items per page
 //   - PAGINATION_MAX_LIMIT: Maximum items per page
+//   - PAGINATION_MAX_LIMIT_ANONYMOUS / _VIEWER / _ADMIN / _APIKEY: Maximum
+//     items per page for one caller tier (unset or not positive = MaxLimit)
 //
 // Falls back to DefaultConfig() if environment variables are not set.
 func LoadFromEnv() Config {
-	return Config{
+	cfg := Config{
 		DefaultPage:  getEnvAsInt("PAGINATION_DEFAULT_PAGE", 1),
 		DefaultLimit: getEnvAsInt("PAGINATION_DEFAULT_LIMIT", 20),
 		MaxLimit:     getEnvAsInt("PAGINATION_MAX_LIMIT", 100),
 	}
+	for tier, key := range tierEnvKeys {
+		if limit := getEnvAsInt(key, 0); limit > 0 {
+			if cfg.TierMaxLimits == nil {
+				cfg.TierMaxLimits = make(map[string]int, len(tierEnvKeys))
+			}
+			cfg.TierMaxLimits[tier] = limit
+		}
+	}
+	return cfg
+}
+
+// tierEnvKeys are the per-tier PAGINATION_MAX_LIMIT variables.
+var tierEnvKeys = map[string]string{
+	TierAnonymous: "PAGINATION_MAX_LIMIT_ANONYMOUS",
+	TierViewer:    "PAGINATION_MAX_LIMIT_VIEWER",
+	TierAdmin:     "PAGINATION_MAX_LIMIT_ADMIN",
+	TierAPIKey:    "PAGINATION_MAX_LIMIT_APIKEY",
 }
 
 // getEnvAsInt retrieves an environment variable and parses it as an integer.
@@ -105,3 +105,29 @@ func TestLoadFromEnv(t *testing.T) {
 		}
 	})
 }
+
+func TestLoadFromEnv_TierMaxLimits(t *testing.T) {
+	t.Setenv("PAGINATION_MAX_LIMIT", "100")
+	t.Setenv("PAGINATION_MAX_LIMIT_ANONYMOUS", "50")
+	t.Setenv("PAGINATION_MAX_LIMIT_VIEWER", "")
+	t.Setenv("PAGINATION_MAX_LIMIT_ADMIN", "invalid")
+	t.Setenv("PAGINATION_MAX_LIMIT_APIKEY", "1000")
+
+	config := pagination.LoadFromEnv()
+
+	tests := []struct {
+		tier string
+		want int
+	}{
+		{pagination.TierAnonymous, 50},
+		{pagination.TierViewer, 100},
+		{pagination.TierAdmin, 100},
+		{pagination.TierAPIKey, 1000},
+		{"unknown", 100},
+	}
+	for _, tt := range tests {
+		if got := config.MaxLimitFor(tt.tier); got != tt.want {
+			t.Errorf("MaxLimitFor(%q) = %d, want %d", tt.tier, got, tt.want)
+		}
+	}
+}
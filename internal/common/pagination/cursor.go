@@ -0,0 +1,81 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCursor is returned when a cursor query parameter cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid query parameter: cursor is invalid")
+
+// Cursor is a keyset pagination position: the sort key of the last row of
+// the previous page (Time, nil when the row has none and sorts last) and
+// its ID as the tie-breaker. Clients only see the opaque Encode form.
+type Cursor struct {
+	Time *time.Time
+	ID   int64
+}
+
+// Encode returns the opaque, URL-safe form of the cursor:
+// base64url("<unix nanos>:<id>"), with "-" instead of the time when Time is nil.
+func (c Cursor) Encode() string {
+	t := "-"
+	if c.Time != nil {
+		t = strconv.FormatInt(c.Time.UnixNano(), 10)
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(t + ":" + strconv.FormatInt(c.ID, 10)))
+}
+
+// DecodeCursor parses a cursor produced by Cursor.Encode.
+// Returns ErrInvalidCursor for anything else.
+func DecodeCursor(s string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	t, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var c Cursor
+	if c.ID, err = strconv.ParseInt(id, 10, 64); err != nil || c.ID <= 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+	if t != "-" {
+		nanos, err := strconv.ParseInt(t, 10, 64)
+		if err != nil {
+			return Cursor{}, ErrInvalidCursor
+		}
+		ts := time.Unix(0, nanos).UTC()
+		c.Time = &ts
+	}
+	return c, nil
+}
+
+// ParseCursor reads the cursor query parameter. Its presence switches the
+// request to keyset pagination (use is true): an empty value requests the
+// first page, otherwise after is the decoded position to continue from.
+// page cannot be combined with cursor.
+func ParseCursor(r *http.Request) (use bool, after *Cursor, err error) {
+	query := r.URL.Query()
+	if !query.Has("cursor") {
+		return false, nil, nil
+	}
+	if query.Has("page") {
+		return false, nil, fmt.Errorf("invalid query parameter: page cannot be combined with cursor")
+	}
+	raw := query.Get("cursor")
+	if raw == "" {
+		return true, nil, nil
+	}
+	c, err := DecodeCursor(raw)
+	if err != nil {
+		return false, nil, err
+	}
+	return true, &c, nil
+}
@@ -0,0 +1,94 @@
+package pagination_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"catchup-feed/internal/common/pagination"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2026, 10, 1, 9, 30, 0, 123456789, time.UTC)
+	tests := []struct {
+		name   string
+		cursor pagination.Cursor
+	}{
+		{name: "with time", cursor: pagination.Cursor{Time: &ts, ID: 42}},
+		{name: "without time (sorts last)", cursor: pagination.Cursor{ID: 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pagination.DecodeCursor(tt.cursor.Encode())
+			if err != nil {
+				t.Fatalf("DecodeCursor() error = %v", err)
+			}
+			if got.ID != tt.cursor.ID {
+				t.Errorf("ID = %d, want %d", got.ID, tt.cursor.ID)
+			}
+			if (got.Time == nil) != (tt.cursor.Time == nil) {
+				t.Fatalf("Time = %v, want %v", got.Time, tt.cursor.Time)
+			}
+			if got.Time != nil && !got.Time.Equal(*tt.cursor.Time) {
+				t.Errorf("Time = %v, want %v", got.Time, tt.cursor.Time)
+			}
+		})
+	}
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	t.Parallel()
+
+	invalid := []string{
+		"!!!",         // not base64url
+		"bm8tY29sb24", // "no-colon"
+		"LTow",        // "-:0" (non-positive ID)
+		"eDox",        // "x:1" (bad time)
+		"MTo",         // "1:" (missing ID)
+	}
+	for _, s := range invalid {
+		if _, err := pagination.DecodeCursor(s); !errors.Is(err, pagination.ErrInvalidCursor) {
+			t.Errorf("DecodeCursor(%q) error = %v, want ErrInvalidCursor", s, err)
+		}
+	}
+}
+
+func TestParseCursor(t *testing.T) {
+	t.Parallel()
+
+	valid := pagination.Cursor{ID: 3}.Encode()
+	tests := []struct {
+		name      string
+		query     string
+		wantUse   bool
+		wantAfter bool
+		wantError bool
+	}{
+		{name: "no cursor (offset pagination)", query: "page=2", wantUse: false},
+		{name: "empty cursor (first page)", query: "cursor=", wantUse: true},
+		{name: "cursor", query: "cursor=" + valid + "&limit=5", wantUse: true, wantAfter: true},
+		{name: "invalid cursor", query: "cursor=abc", wantError: true},
+		{name: "page with cursor", query: "cursor=&page=2", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/articles?"+tt.query, nil)
+			use, after, err := pagination.ParseCursor(req)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("ParseCursor() error = %v, wantError %v", err, tt.wantError)
+			}
+			if use != tt.wantUse {
+				t.Errorf("use = %v, want %v", use, tt.wantUse)
+			}
+			if (after != nil) != tt.wantAfter {
+				t.Errorf("after = %v, want set = %v", after, tt.wantAfter)
+			}
+		})
+	}
+}
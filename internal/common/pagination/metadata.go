@@ -2,8 +2,9 @@ package pagination
 
 // Metadata contains pagination metadata included in API responses.
 type Metadata struct {
-	Total      int64 `json:"total"`       // Total number of items across all pages
-	Page       int   `json:"page"`        // Current page number (1-based)
-	Limit      int   `json:"limit"`       // Items per page
-	TotalPages int   `json:"total_pages"` // Calculated total number of pages
+	Total      int64  `json:"total"`                 // Total number of items across all pages
+	Page       int    `json:"page,omitempty"`        // Current page number (1-based); omitted for cursor pagination
+	Limit      int    `json:"limit"`                 // Items per page
+	TotalPages int    `json:"total_pages"`           // Calculated total number of pages
+	NextCursor string `json:"next_cursor,omitempty"` // Cursor of the next page (cursor pagination only); empty on the last page
 }
@@ -17,7 +17,8 @@ type Params struct {
 //
 // Query parameters:
 //   - page: Page number (must be positive integer)
-//   - limit: Items per page (must be between 1 and config.MaxLimit)
+//   - limit: Items per page (must be between 1 and the maximum of the
+//     caller's tier, config.MaxLimitFor(TierFromContext(r.Context())))
 //
 // Returns an error if parameters are invalid.
 func ParseQueryParams(r *http.Request, config Config) (Params, error) {
@@ -37,9 +38,10 @@ func ParseQueryParams(r *http.Request, config Config) (Params, error) {
 
 	// Parse limit parameter
 	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		maxLimit := config.MaxLimitFor(TierFromContext(r.Context()))
 		limit, err := strconv.Atoi(limitStr)
-		if err != nil || limit < 1 || limit > config.MaxLimit {
-			return params, fmt.Errorf("invalid query parameter: limit must be between 1 and %d", config.MaxLimit)
+		if err != nil || limit < 1 || limit > maxLimit {
+			return params, fmt.Errorf("invalid query parameter: limit must be between 1 and %d", maxLimit)
 		}
 		params.Limit = limit
 	}
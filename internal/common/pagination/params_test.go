@@ -192,3 +192,44 @@ func TestParseQueryParams_ErrorMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestParseQueryParams_TierMaxLimit(t *testing.T) {
+	t.Parallel()
+
+	config := pagination.Config{
+		DefaultPage:  1,
+		DefaultLimit: 20,
+		MaxLimit:     100,
+		TierMaxLimits: map[string]int{
+			pagination.TierAnonymous: 50,
+			pagination.TierAPIKey:    1000,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		tier      string
+		limit     string
+		wantError bool
+	}{
+		{"anonymous within its limit", "", "50", false},
+		{"anonymous over its limit", "", "51", true},
+		{"viewer falls back to MaxLimit", pagination.TierViewer, "100", false},
+		{"viewer over MaxLimit", pagination.TierViewer, "101", true},
+		{"api key bulk page", pagination.TierAPIKey, "1000", false},
+		{"api key over its limit", pagination.TierAPIKey, "1001", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/?limit="+tt.limit, nil)
+			if tt.tier != "" {
+				req = req.WithContext(pagination.WithTier(req.Context(), tt.tier))
+			}
+			_, err := pagination.ParseQueryParams(req, config)
+			if (err != nil) != tt.wantError {
+				t.Errorf("ParseQueryParams() error = %v, wantError %v", err, tt.wantError)
+			}
+		})
+	}
+}
@@ -46,11 +46,8 @@ func (s OffsetStrategy) BuildMetadata(params Params, total int64, hasMore bool)
 	}
 }
 
-// CursorStrategy is planned for future implementation.
-// Design considerations documented in:
-// - docs/designs/security-and-quality-improvements.md (Section 14)
-//
-// Future implementation will use:
-// - Opaque cursors: base64(published_at + article_id)
-// - No total count (performance optimization)
-// - hasMore flag instead of total_pages
+// Cursor (keyset) pagination is not a PaginationStrategy: its position is a
+// WHERE condition, not offset/limit arithmetic. See Cursor / ParseCursor
+// (opaque base64(published_at + article_id) cursors) and the article
+// repository's ListWithSourceAfter. The total count is still reported so
+// the response envelope stays the same; next_cursor replaces page.
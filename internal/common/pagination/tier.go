@@ -0,0 +1,45 @@
+package pagination
+
+import "context"
+
+// Caller tiers, each with its own maximum page size (Config.TierMaxLimits):
+// bulk consumers on API keys can page through larger result sets while
+// requests without an identity stay bounded.
+const (
+	// TierAnonymous is a request that carries no identity.
+	TierAnonymous = "anonymous"
+	// TierViewer is a JWT user without the admin role (viewers and custom
+	// roles).
+	TierViewer = "viewer"
+	// TierAdmin is a JWT administrator.
+	TierAdmin = "admin"
+	// TierAPIKey is a service identity authenticated with an API key,
+	// whatever its role.
+	TierAPIKey = "apikey"
+)
+
+type tierCtxKey struct{}
+
+// WithTier returns a context carrying the caller's tier. Set by the auth
+// middleware once the caller is authenticated.
+func WithTier(ctx context.Context, tier string) context.Context {
+	return context.WithValue(ctx, tierCtxKey{}, tier)
+}
+
+// TierFromContext returns the caller's tier, TierAnonymous when none was
+// set.
+func TierFromContext(ctx context.Context) string {
+	if tier, ok := ctx.Value(tierCtxKey{}).(string); ok && tier != "" {
+		return tier
+	}
+	return TierAnonymous
+}
+
+// MaxLimitFor returns the maximum page size of tier: its TierMaxLimits
+// entry when set (> 0), else MaxLimit.
+func (c Config) MaxLimitFor(tier string) int {
+	if limit := c.TierMaxLimits[tier]; limit > 0 {
+		return limit
+	}
+	return c.MaxLimit
+}
@@ -0,0 +1,276 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Alert rule limits, in characters (runes) and terms.
+const (
+	MaxAlertRuleNameLength  = 100
+	MaxAlertRuleQueryLength = 500
+	MaxAlertRuleQueryTerms  = 50
+)
+
+// AlertRule is a user's keyword alert (alert_rules table): every article a
+// crawl stores whose title or summary matches Query raises a notification
+// tagged with Name. HitCount / LastHitAt count the matches so far.
+type AlertRule struct {
+	ID        int64
+	UserID    int64
+	Name      string
+	Query     string
+	Enabled   bool
+	HitCount  int64
+	LastHitAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ValidateAlertRuleName checks an already trimmed rule name.
+func ValidateAlertRuleName(name string) error {
+	switch {
+	case name == "":
+		return &ValidationError{Field: "name", Message: "is required"}
+	case utf8.RuneCountInString(name) > MaxAlertRuleNameLength:
+		return &ValidationError{Field: "name", Message: fmt.Sprintf("is too long (max %d characters)", MaxAlertRuleNameLength)}
+	}
+	return nil
+}
+
+// AlertQuery is a parsed alert rule query. The syntax:
+//
+//	go generics            both terms (AND is implicit; "AND" may be written)
+//	go OR rust             either term
+//	NOT beta, -beta        the term must not appear
+//	(go OR rust) release   parentheses group
+//	"go 1.23"              a phrase, matched as written
+//
+// AND / OR / NOT are operators only in upper case. Terms match as
+// case-insensitive substrings of the article's title and summary, like
+// the webhook route keywords.
+type AlertQuery struct {
+	root alertNode
+}
+
+// MatchArticle reports whether the article's title or summary satisfies
+// the query.
+func (q *AlertQuery) MatchArticle(title, summary string) bool {
+	return q.root.match(strings.ToLower(title + "\n" + summary))
+}
+
+type alertNode interface {
+	match(text string) bool // text is lower-cased
+}
+
+type alertTerm string
+
+func (t alertTerm) match(text string) bool { return strings.Contains(text, string(t)) }
+
+type alertNot struct{ node alertNode }
+
+func (n alertNot) match(text string) bool { return !n.node.match(text) }
+
+type alertAnd []alertNode
+
+func (a alertAnd) match(text string) bool {
+	for _, n := range a {
+		if !n.match(text) {
+			return false
+		}
+	}
+	return true
+}
+
+type alertOr []alertNode
+
+func (o alertOr) match(text string) bool {
+	for _, n := range o {
+		if n.match(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseAlertQuery parses and checks a rule query. Errors are
+// *ValidationError on the "query" field.
+func ParseAlertQuery(query string) (*AlertQuery, error) {
+	query = strings.TrimSpace(query)
+	switch {
+	case query == "":
+		return nil, &ValidationError{Field: "query", Message: "is required"}
+	case utf8.RuneCountInString(query) > MaxAlertRuleQueryLength:
+		return nil, &ValidationError{Field: "query", Message: fmt.Sprintf("is too long (max %d characters)", MaxAlertRuleQueryLength)}
+	}
+	tokens, err := tokenizeAlertQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &alertParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, alertQueryError("has an unmatched ')'")
+	}
+	if p.terms > MaxAlertRuleQueryTerms {
+		return nil, alertQueryError(fmt.Sprintf("must have at most %d terms", MaxAlertRuleQueryTerms))
+	}
+	return &AlertQuery{root: root}, nil
+}
+
+func alertQueryError(message string) error {
+	return &ValidationError{Field: "query", Message: message}
+}
+
+// alertToken is one lexical unit; phrase marks a quoted term, which is
+// never an operator.
+type alertToken struct {
+	text   string
+	phrase bool
+}
+
+func tokenizeAlertQuery(query string) ([]alertToken, error) {
+	var tokens []alertToken
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, alertToken{text: string(c)})
+			i++
+		case c == '"':
+			end := strings.IndexByte(query[i+1:], '"')
+			if end < 0 {
+				return nil, alertQueryError("has an unterminated phrase")
+			}
+			tokens = append(tokens, alertToken{text: query[i+1 : i+1+end], phrase: true})
+			i += end + 2
+		case c == '-' && i+1 < len(query) && !strings.ContainsRune(" \t\n\r()", rune(query[i+1])):
+			tokens = append(tokens, alertToken{text: "NOT"})
+			i++
+		default:
+			end := strings.IndexAny(query[i:], " \t\n\r()\"")
+			if end < 0 {
+				end = len(query) - i
+			}
+			tokens = append(tokens, alertToken{text: query[i : i+end]})
+			i += end
+		}
+	}
+	return tokens, nil
+}
+
+// alertParser is a recursive-descent parser over the tokens:
+//
+//	or    = and { "OR" and }
+//	and   = unary { [ "AND" ] unary }
+//	unary = "NOT" unary | "(" or ")" | term
+type alertParser struct {
+	tokens []alertToken
+	pos    int
+	terms  int
+}
+
+func (p *alertParser) peek() (alertToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return alertToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+// peekOperator reports whether the next token is the operator op.
+func (p *alertParser) peekOperator(op string) bool {
+	t, ok := p.peek()
+	return ok && !t.phrase && t.text == op
+}
+
+func (p *alertParser) parseOr() (alertNode, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	nodes := alertOr{first}
+	for p.peekOperator("OR") {
+		p.pos++
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, next)
+	}
+	if len(nodes) == 1 {
+		return first, nil
+	}
+	return nodes, nil
+}
+
+func (p *alertParser) parseAnd() (alertNode, error) {
+	first, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	nodes := alertAnd{first}
+	for {
+		if p.peekOperator("AND") {
+			p.pos++
+		} else if _, ok := p.peek(); !ok || p.peekOperator("OR") || p.peekOperator(")") {
+			break
+		}
+		next, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, next)
+	}
+	if len(nodes) == 1 {
+		return first, nil
+	}
+	return nodes, nil
+}
+
+func (p *alertParser) parseUnary() (alertNode, error) {
+	t, ok := p.peek()
+	if !ok {
+		return nil, alertQueryError("must not end with an operator")
+	}
+	p.pos++
+	if t.phrase {
+		return p.term(t.text)
+	}
+	switch t.text {
+	case "NOT":
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return alertNot{node}, nil
+	case "(":
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.peekOperator(")") {
+			return nil, alertQueryError("has an unmatched '('")
+		}
+		p.pos++
+		return node, nil
+	case ")", "AND", "OR":
+		return nil, alertQueryError(fmt.Sprintf("has a misplaced %q", t.text))
+	}
+	return p.term(t.text)
+}
+
+func (p *alertParser) term(text string) (alertNode, error) {
+	text = strings.ToLower(strings.Join(strings.Fields(text), " "))
+	if text == "" {
+		return nil, alertQueryError("must not contain an empty phrase")
+	}
+	p.terms++
+	return alertTerm(text), nil
+}
@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAlertQuery_MatchArticle(t *testing.T) {
+	const title, summary = "Go 1.23 リリース", "Range over func iterators are stable; the beta tag is gone"
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"go", true},
+		{"GO iterators", true}, // 暗黙の AND、大文字小文字を区別しない
+		{"go AND rust", false},
+		{"go OR rust", true},
+		{"rust OR python", false},
+		{"go NOT beta", false},
+		{"go -beta", false},
+		{"go -alpha", true},
+		{"(rust OR iterators) リリース", true},
+		{"(rust OR python) リリース", false},
+		{`"go 1.23"`, true},
+		{`"1.23 go"`, false},
+		{`"AND"`, false}, // 引用符内は演算子ではない
+		{"or", true},     // 小文字の or は語("for" など)
+		{"NOT (rust OR python)", true},
+		{"co-op", false},
+	}
+	for _, tt := range tests {
+		q, err := ParseAlertQuery(tt.query)
+		if err != nil {
+			t.Fatalf("ParseAlertQuery(%q) error = %v", tt.query, err)
+		}
+		if got := q.MatchArticle(title, summary); got != tt.want {
+			t.Errorf("%q matches = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestParseAlertQuery_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"   ",
+		"go AND",
+		"OR go",
+		"NOT",
+		"(go OR rust",
+		"go)",
+		"()",
+		`"unterminated`,
+		`""`,
+		strings.Repeat("a", MaxAlertRuleQueryLength+1),
+		strings.Repeat("a ", MaxAlertRuleQueryTerms+1),
+	}
+	for _, query := range tests {
+		_, err := ParseAlertQuery(query)
+		var verr *ValidationError
+		if !errors.As(err, &verr) || verr.Field != "query" {
+			t.Errorf("ParseAlertQuery(%q) error = %v, want a query ValidationError", query, err)
+		}
+	}
+}
+
+func TestValidateAlertRuleName(t *testing.T) {
+	if err := ValidateAlertRuleName("Go releases"); err != nil {
+		t.Errorf("valid name: %v", err)
+	}
+	for _, name := range []string{"", strings.Repeat("あ", MaxAlertRuleNameLength+1)} {
+		if err := ValidateAlertRuleName(name); err == nil {
+			t.Errorf("ValidateAlertRuleName(%q) = nil, want error", name)
+		}
+	}
+}
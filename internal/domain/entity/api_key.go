@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
+// apiKeyPrefix marks a plaintext API key so a leaked key is recognizable
+// in logs and secret scanners.
+const apiKeyPrefix = "cfk_"
+
+// apiKeyDisplayLen is how many leading plaintext characters are kept in
+// key_prefix to tell keys apart in the admin list.
+const apiKeyDisplayLen = 12
+
+// APIKey represents a service-to-service credential (api_keys table).
+// Like feed tokens (D-5) only the SHA-256 hex hash is stored; the
+// plaintext is shown once at creation and can never be re-displayed.
+type APIKey struct {
+	ID         int64
+	Name       string
+	Role       string // "admin" | "viewer"(JWT の role と同じ2値)
+	KeyHash    string // SHA-256 hex of the plaintext
+	KeyPrefix  string // 先頭 12 文字(一覧での識別用)
+	RateLimit  int    // 1分あたりのリクエスト上限
+	DailyQuota *int   // 24時間あたりのリクエスト上限。nil = 無制限
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time // nil = 有効
+}
+
+// IsRevoked reports whether the key has been revoked.
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// GenerateAPIKey generates a new key and returns the plaintext (to display
+// once), its hash (to persist) and the display prefix.
+func GenerateAPIKey() (plaintext, hash, prefix string, err error) {
+	buf := make([]byte, feedTokenByteLen)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", "", fmt.Errorf("generate api key: %w", err)
+	}
+	plaintext = apiKeyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return plaintext, HashAPIKey(plaintext), plaintext[:apiKeyDisplayLen], nil
+}
+
+// HashAPIKey returns the SHA-256 hex digest stored in api_keys.key_hash.
+// Same scheme as HashFeedToken: verification is a hash lookup, so no
+// constant-time comparison is needed.
+func HashAPIKey(plaintext string) string {
+	return HashFeedToken(plaintext)
+}
@@ -14,6 +14,18 @@ import "time"
 // from summaries.body via LEFT JOIN (empty string when no summary exists
 // yet) and is ignored on writes. Persist summaries through
 // repository.SummaryRepository instead.
+//
+// ContentHash is the articles.content_hash dedupe key
+// (ArticleContentHash). It is written on insert only; the repository
+// computes it from URL, Title and Content when it is empty.
+//
+// SimHash (ArticleSimHash, 0 = no fingerprint) and DuplicateOf (the
+// canonical article of a near-duplicate group, nil = standalone) are set by
+// the crawl and written on insert only, like ContentHash.
+//
+// ImageURL is the article thumbnail (og:image / twitter:image of the page
+// fetched for content enhancement; empty when none), an absolute http(s)
+// URL. Written on insert only, like ContentHash.
 type Article struct {
 	ID          int64
 	SourceID    int64
@@ -23,4 +35,8 @@ type Article struct {
 	Summary     string // read-only: joined from summaries.body
 	PublishedAt time.Time
 	CrawledAt   time.Time
+	ContentHash string
+	SimHash     uint64
+	DuplicateOf *int64
+	ImageURL    string
 }
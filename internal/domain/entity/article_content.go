@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// ArticleContent is the origin page of an article as the crawler fetched
+// it (article_contents table): the raw HTML and the readability-extracted
+// text. URL is the final URL after redirects. Only articles whose feed
+// content was too short trigger a page fetch, so most articles have none.
+type ArticleContent struct {
+	ArticleID int64
+	URL       string
+	HTML      string
+	Text      string
+	FetchedAt time.Time
+}
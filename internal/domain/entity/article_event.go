@@ -0,0 +1,17 @@
+package entity
+
+// ArticleEventChannel is the PostgreSQL NOTIFY channel the articles insert
+// trigger (notify_article_event) signals on, whichever process inserted
+// the row: the worker's crawl or POST /articles.
+const ArticleEventChannel = "article_events"
+
+// ArticleEvent is the NOTIFY payload of ArticleEventChannel and the data
+// of the GET /articles/events stream. Type is WebhookEventArticleCreated,
+// the only kind the trigger sends. The event carries IDs only — NOTIFY
+// payloads are limited to 8000 bytes, and clients fetch the article
+// through GET /articles/{id} once its summary is in.
+type ArticleEvent struct {
+	Type      string `json:"type"`
+	ArticleID int64  `json:"article_id"`
+	SourceID  int64  `json:"source_id"`
+}
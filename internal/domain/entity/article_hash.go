@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+)
+
+// trackingParams are query parameters that only identify the campaign or
+// referrer a link was shared through; NormalizeArticleURL drops them so the
+// same article linked with different ones hashes the same. Any key with
+// the "utm_" prefix is dropped as well.
+var trackingParams = map[string]bool{
+	"fbclid":  true,
+	"gclid":   true,
+	"dclid":   true,
+	"msclkid": true,
+	"yclid":   true,
+	"igshid":  true,
+	"mc_cid":  true,
+	"mc_eid":  true,
+	"ref":     true,
+	"ref_src": true,
+	"_ga":     true,
+}
+
+// NormalizeArticleURL canonicalizes an article URL for content hashing:
+// lower-case scheme and host, no default port, no fragment, no trailing
+// slash, tracking parameters removed and the remaining query sorted.
+// A URL that does not parse is returned trimmed but otherwise unchanged.
+func NormalizeArticleURL(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	if port := u.Port(); port != "" &&
+		!(u.Scheme == "http" && port == "80") && !(u.Scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+	u.Host = host
+	u.Fragment = ""
+	u.RawFragment = ""
+	if len(u.Path) > 1 {
+		u.Path = strings.TrimRight(u.Path, "/")
+		u.RawPath = ""
+	}
+
+	query := u.Query()
+	for key := range query {
+		if trackingParams[strings.ToLower(key)] || strings.HasPrefix(strings.ToLower(key), "utm_") {
+			query.Del(key)
+		}
+	}
+	u.RawQuery = query.Encode() // Encode sorts by key
+	u.ForceQuery = false
+	return u.String()
+}
+
+// ArticleContentHash is the articles.content_hash dedupe key: the SHA-256
+// (hex) of the normalized URL, the title and the content with whitespace
+// collapsed. The crawl hashes the feed entry's own content, before any
+// full-text enhancement, so the same entry hashes the same on every run.
+func ArticleContentHash(rawURL, title, content string) string {
+	h := sha256.New()
+	h.Write([]byte(NormalizeArticleURL(rawURL)))
+	h.Write([]byte{'\n'})
+	h.Write([]byte(strings.Join(strings.Fields(title), " ")))
+	h.Write([]byte{'\n'})
+	h.Write([]byte(strings.Join(strings.Fields(content), " ")))
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeArticleURL(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"unchanged", "https://example.com/post/1", "https://example.com/post/1"},
+		{"tracking params dropped", "https://example.com/post/1?utm_source=rss&utm_medium=feed&fbclid=x", "https://example.com/post/1"},
+		{"other params kept and sorted", "https://example.com/p?id=2&utm_campaign=a&b=1", "https://example.com/p?b=1&id=2"},
+		{"host case, default port, fragment, trailing slash", "HTTPS://Example.COM:443/post/1/#comments", "https://example.com/post/1"},
+		{"non-default port kept", "http://example.com:8080/a", "http://example.com:8080/a"},
+		{"root path kept", "https://example.com/", "https://example.com/"},
+		{"unparseable returned trimmed", " not a url ", "not a url"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeArticleURL(tt.in))
+		})
+	}
+}
+
+func TestArticleContentHash(t *testing.T) {
+	base := ArticleContentHash("https://example.com/post/1", "Go 1.26", "Release notes")
+
+	assert.Len(t, base, 64)
+	assert.Equal(t, base, ArticleContentHash("https://example.com/post/1?utm_source=twitter", "Go  1.26", " Release\nnotes "),
+		"tracking params and whitespace must not change the hash")
+	assert.NotEqual(t, base, ArticleContentHash("https://example.com/post/2", "Go 1.26", "Release notes"))
+	assert.NotEqual(t, base, ArticleContentHash("https://example.com/post/1", "Go 1.26", "Updated notes"))
+}
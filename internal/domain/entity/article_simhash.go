@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"unicode"
+)
+
+// DefaultSimHashMaxDistance is the default Hamming distance up to which
+// two articles' SimHashes mark them as near-duplicates (the same story
+// republished by another source). Feed articles are short — a few hundred
+// shingles, not the thousands the web-scale threshold of 3 assumes — so a
+// copy with a new headline and a byline typically lands 3-8 bits away,
+// while unrelated articles are 25 or more apart.
+const DefaultSimHashMaxDistance = 10
+
+// MaxSimHashDistance bounds the configurable threshold: beyond it
+// unrelated articles start to collide.
+const MaxSimHashDistance = 20
+
+// minSimHashFeatures is how many shingles a text needs to be fingerprinted.
+// Shorter texts (title-only feed entries) match each other far too easily.
+const minSimHashFeatures = 16
+
+// ArticleSimHash is the articles.simhash near-duplicate fingerprint: a
+// 64-bit SimHash over the two-token shingles of the title and content.
+// Tokens are lower-cased words; Han, Hiragana and Katakana runs, which have
+// no spaces, are split into characters so Japanese text shingles into
+// character bigrams. A text with too few shingles returns 0, meaning "no
+// fingerprint" — such articles are never grouped.
+func ArticleSimHash(title, content string) uint64 {
+	tokens := simHashTokens(title + "\n" + content)
+	if len(tokens) < minSimHashFeatures+1 {
+		return 0
+	}
+	var weights [64]int
+	for i := 0; i+1 < len(tokens); i++ {
+		h := fnv.New64a()
+		h.Write([]byte(tokens[i]))
+		h.Write([]byte{0})
+		h.Write([]byte(tokens[i+1]))
+		sum := mix64(h.Sum64())
+		for bit := 0; bit < 64; bit++ {
+			if sum&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+	var fp uint64
+	for bit, w := range weights {
+		if w > 0 {
+			fp |= 1 << bit
+		}
+	}
+	return fp
+}
+
+// mix64 is the splitmix64 finalizer: FNV-1a alone spreads short inputs
+// poorly over the high bits, which skews the per-bit votes.
+func mix64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// SimHashDistance is the Hamming distance between two fingerprints.
+func SimHashDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+func simHashTokens(text string) []string {
+	var (
+		tokens []string
+		word   strings.Builder
+	)
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana):
+			flush()
+			tokens = append(tokens, string(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
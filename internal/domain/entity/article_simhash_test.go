@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const simHashStory = `The Go team announced a new release today with improvements to the
+garbage collector, faster builds and a redesigned standard library package for
+structured logging. The release also ships range-over-func iterators and better
+profile guided optimization across the toolchain.
+
+Developers upgrading from the previous version should not need to change their
+code: the compatibility promise still holds, and the new vet checks only warn
+about patterns that were already broken. Module authors are encouraged to test
+against the release candidate before the final tag.
+
+The team thanked the hundreds of contributors who filed issues, reviewed
+changes and tested early builds on unusual platforms, including the new
+support for 64-bit RISC-V and LoongArch systems.`
+
+const simHashOtherStory = `PostgreSQL 19 beta adds asynchronous I/O for
+sequential scans, virtual generated columns, a new OAuth authentication method
+and many planner improvements for partitioned tables and parallel queries.
+
+Operators should test the beta against their workloads and report regressions
+on the mailing list; the final release is planned for the autumn after several
+more beta and release candidate rounds.
+
+Extension authors will find new hooks for custom table access methods and an
+improved API for background workers.`
+
+func TestArticleSimHash_NearDuplicates(t *testing.T) {
+	a := ArticleSimHash("Go 1.30 released", simHashStory)
+	// Republished with a different headline and a trailing byline.
+	b := ArticleSimHash("Go 1.30 is out", simHashStory+" Reported by Example News.")
+	unrelated := ArticleSimHash("PostgreSQL 19 beta", simHashOtherStory)
+
+	assert.NotZero(t, a)
+	assert.LessOrEqual(t, SimHashDistance(a, b), DefaultSimHashMaxDistance)
+	assert.Less(t, SimHashDistance(a, b), SimHashDistance(a, unrelated))
+	assert.Greater(t, SimHashDistance(a, unrelated), MaxSimHashDistance)
+}
+
+func TestArticleSimHash_Japanese(t *testing.T) {
+	text := "政府は本日、新しい経済対策を発表した。物価高騰への対応として家計への給付金を拡充し、" +
+		"中小企業の賃上げを支援する。エネルギー価格の抑制策も来年三月まで延長される。" +
+		"財源には今年度の補正予算を充て、不足分は国債の追加発行で賄う方針だ。" +
+		"野党は効果が限定的だとして、消費税の減税を含む対案を国会に提出する構えを見せている。"
+	a := ArticleSimHash("経済対策を発表", text)
+	b := ArticleSimHash("政府が経済対策", text+"(共同)")
+
+	assert.NotZero(t, a)
+	assert.LessOrEqual(t, SimHashDistance(a, b), DefaultSimHashMaxDistance)
+}
+
+func TestArticleSimHash_ShortTextHasNoFingerprint(t *testing.T) {
+	assert.Zero(t, ArticleSimHash("Go 1.30 released", ""))
+	assert.Zero(t, ArticleSimHash("", "short summary only"))
+}
+
+func TestArticleSimHash_Deterministic(t *testing.T) {
+	assert.Equal(t, ArticleSimHash("t", simHashStory), ArticleSimHash("t", simHashStory))
+	assert.Equal(t, ArticleSimHash("Title", simHashStory), ArticleSimHash("TITLE", simHashStory),
+		"case-insensitive")
+}
+
+func TestSimHashDistance(t *testing.T) {
+	assert.Equal(t, 0, SimHashDistance(0xff, 0xff))
+	assert.Equal(t, 8, SimHashDistance(0xff, 0))
+	assert.Equal(t, 64, SimHashDistance(0, ^uint64(0)))
+}
@@ -0,0 +1,222 @@
+package entity
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// DefaultTopicSimilarity is the cosine similarity at or above which an
+// article joins a topic cluster. Articles about the same story or release
+// from different angles share their key terms and land around 0.3-0.6;
+// unrelated articles sharing only common words stay below 0.15.
+const DefaultTopicSimilarity = 0.25
+
+// maxTopicTerms is how many of a cluster's heaviest terms label it.
+const maxTopicTerms = 3
+
+// ArticleCluster is one topic of ClusterArticles: articles whose title and
+// summary share their key terms. Articles keeps the input order, and
+// Representative (one of them) is the article closest to the cluster
+// centroid. Terms are the heaviest shared terms, a label for the topic.
+type ArticleCluster struct {
+	Representative *Article
+	Articles       []*Article
+	Terms          []string
+}
+
+// ClusterArticles groups articles into topics by the cosine similarity of
+// their term vectors: TF-IDF weights over the words of the title (counted
+// twice) and summary, with Han, Hiragana and Katakana runs split into
+// character bigrams. The vectors are computed here rather than stored — the
+// crawl keeps no article embeddings (bge-m3 runs on the Mac, C-12) — and the
+// IDF comes from the batch itself, so terms every article shares carry
+// little weight. An article joins the cluster whose centroid is most similar, if
+// at least minSimilarity (<= 0 = DefaultTopicSimilarity), or starts a new
+// one; pass articles newest first so a cluster forms around its latest
+// coverage. Clusters are ordered by size, then by their first article.
+func ClusterArticles(articles []*Article, minSimilarity float64) []ArticleCluster {
+	if minSimilarity <= 0 {
+		minSimilarity = DefaultTopicSimilarity
+	}
+	vectors := topicVectors(articles)
+
+	type cluster struct {
+		members  []int
+		centroid map[string]float64
+	}
+	var clusters []*cluster
+	for i, v := range vectors {
+		var best *cluster
+		bestSim := minSimilarity
+		for _, c := range clusters {
+			if sim := cosine(v, c.centroid); sim >= bestSim {
+				best, bestSim = c, sim
+			}
+		}
+		if best == nil {
+			best = &cluster{centroid: map[string]float64{}}
+			clusters = append(clusters, best)
+		}
+		best.members = append(best.members, i)
+		for term, w := range v {
+			best.centroid[term] += w
+		}
+	}
+
+	out := make([]ArticleCluster, 0, len(clusters))
+	for _, c := range clusters {
+		rep, repSim := c.members[0], -1.0
+		members := make([]*Article, 0, len(c.members))
+		for _, i := range c.members {
+			members = append(members, articles[i])
+			if sim := cosine(vectors[i], c.centroid); sim > repSim {
+				rep, repSim = i, sim
+			}
+		}
+		out = append(out, ArticleCluster{
+			Representative: articles[rep],
+			Articles:       members,
+			Terms:          topTerms(c.centroid),
+		})
+	}
+	// clusters are already in first-article order; the stable sort keeps it
+	// among equal sizes.
+	slices.SortStableFunc(out, func(a, b ArticleCluster) int {
+		return cmp.Compare(len(b.Articles), len(a.Articles))
+	})
+	return out
+}
+
+// topicVectors returns the L2-normalised TF-IDF vector of each article.
+func topicVectors(articles []*Article) []map[string]float64 {
+	tfs := make([]map[string]float64, len(articles))
+	df := map[string]int{}
+	for i, a := range articles {
+		tf := map[string]float64{}
+		for _, term := range topicTerms(a.Title) {
+			tf[term] += 2
+		}
+		for _, term := range topicTerms(a.Summary) {
+			tf[term]++
+		}
+		for term := range tf {
+			df[term]++
+		}
+		tfs[i] = tf
+	}
+
+	n := float64(len(articles))
+	for _, tf := range tfs {
+		var norm float64
+		for term, w := range tf {
+			// smoothed IDF: a term in every article still weighs a little,
+			// so a batch of one topic does not come out empty.
+			w *= math.Log((1+n)/(1+float64(df[term]))) + 0.1
+			tf[term] = w
+			norm += w * w
+		}
+		norm = math.Sqrt(norm)
+		for term := range tf {
+			tf[term] /= norm
+		}
+	}
+	return tfs
+}
+
+// topicTerms splits text into lower-cased words of two or more characters
+// and, for the scripts written without spaces (Han, Hiragana, Katakana),
+// character bigrams within each run.
+func topicTerms(text string) []string {
+	var (
+		terms []string
+		word  []rune
+		run   []rune
+	)
+	flushWord := func() {
+		if len(word) >= 2 && !topicStopWords[string(word)] {
+			terms = append(terms, string(word))
+		}
+		word = word[:0]
+	}
+	flushRun := func() {
+		for i := 0; i+1 < len(run); i++ {
+			terms = append(terms, string(run[i:i+2]))
+		}
+		run = run[:0]
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana):
+			flushWord()
+			run = append(run, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushRun()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushRun()
+		}
+	}
+	flushWord()
+	flushRun()
+	return terms
+}
+
+// topicStopWords are English function words too common in titles and
+// summaries to say anything about the topic.
+var topicStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "has": true, "have": true,
+	"in": true, "is": true, "it": true, "its": true, "new": true, "of": true,
+	"on": true, "or": true, "that": true, "the": true, "this": true, "to": true,
+	"was": true, "we": true, "with": true, "you": true, "your": true, "how": true,
+	"what": true, "why": true, "will": true, "can": true, "now": true, "not": true,
+}
+
+// topTerms labels a cluster with its heaviest centroid terms; terms its
+// articles share add up, so they outweigh any one article's own words.
+func topTerms(centroid map[string]float64) []string {
+	type weighted struct {
+		term string
+		w    float64
+	}
+	var all []weighted
+	for term, w := range centroid {
+		all = append(all, weighted{term, w})
+	}
+	slices.SortFunc(all, func(a, b weighted) int {
+		if c := cmp.Compare(b.w, a.w); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.term, b.term)
+	})
+	terms := make([]string, 0, maxTopicTerms)
+	for _, t := range all {
+		if len(terms) == maxTopicTerms {
+			break
+		}
+		terms = append(terms, t.term)
+	}
+	return terms
+}
+
+func cosine(a, b map[string]float64) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	var dot, normA, normB float64
+	for term, w := range a {
+		dot += w * b[term]
+		normA += w * w
+	}
+	for _, w := range b {
+		normB += w * w
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(normA*normB)
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func topicArticle(id int64, title, summary string) *Article {
+	return &Article{ID: id, Title: title, Summary: summary}
+}
+
+func TestClusterArticles(t *testing.T) {
+	articles := []*Article{
+		topicArticle(6, "Go 1.30 released", "The Go team shipped Go 1.30 with a faster garbage collector."),
+		topicArticle(5, "PostgreSQL 19 beta", "PostgreSQL 19 beta adds asynchronous I/O for sequential scans."),
+		topicArticle(4, "What's in Go 1.30", "A tour of the Go 1.30 release: garbage collector, iterators and vet."),
+		topicArticle(3, "生成AIのセキュリティ対策", "生成AIを業務で使う際のセキュリティ対策をまとめました。"),
+		topicArticle(2, "Upgrading to Go 1.30", "Notes from upgrading our services to the Go 1.30 release."),
+		topicArticle(1, "生成AIセキュリティの最新動向", "生成AIのセキュリティリスクと対策の動向。"),
+	}
+
+	clusters := ClusterArticles(articles, 0)
+	require.Len(t, clusters, 3)
+
+	assert.Equal(t, []*Article{articles[0], articles[2], articles[4]}, clusters[0].Articles)
+	assert.Contains(t, clusters[0].Articles, clusters[0].Representative)
+	assert.Contains(t, clusters[0].Terms, "go")
+	assert.Len(t, clusters[0].Terms, maxTopicTerms)
+
+	// 日本語の記事は文字 bigram でまとまる
+	assert.Equal(t, []*Article{articles[3], articles[5]}, clusters[1].Articles)
+	assert.Equal(t, []*Article{articles[1]}, clusters[2].Articles)
+	assert.Same(t, articles[1], clusters[2].Representative)
+
+	assert.Len(t, ClusterArticles(articles, 0.99), len(articles), "a strict threshold keeps every article apart")
+}
+
+func TestClusterArticles_Empty(t *testing.T) {
+	assert.Empty(t, ClusterArticles(nil, 0))
+
+	// 語を持たない記事は単独のクラスタになる
+	clusters := ClusterArticles([]*Article{topicArticle(1, "!", ""), topicArticle(2, "?", "")}, 0)
+	assert.Len(t, clusters, 2)
+}
+
+func TestTopicTerms(t *testing.T) {
+	assert.Equal(t, []string{"go", "30", "生成", "成す", "ai"}, topicTerms("The Go 1.30 生成す AI"))
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Audit actions (audit_logs.action).
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+	AuditActionIssue  = "issue" // JWT 発行(POST /auth/token)
+)
+
+// Audit resource types (audit_logs.resource_type).
+const (
+	AuditResourceSource    = "source"
+	AuditResourceArticle   = "article"
+	AuditResourceAuthToken = "auth_token"
+)
+
+// AuditLog is one recorded admin mutation (audit_logs table). Before and
+// After are JSON snapshots of the resource: Before is nil for creates,
+// After is nil for deletes. ResourceID is nil when the resource has no row
+// of its own (token issuance).
+type AuditLog struct {
+	ID           int64
+	Actor        string
+	Action       string
+	ResourceType string
+	ResourceID   *int64
+	RequestID    string
+	IP           string
+	Before       json.RawMessage
+	After        json.RawMessage
+	CreatedAt    time.Time
+}
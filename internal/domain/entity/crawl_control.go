@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// CrawlControl is the maintenance state of the scheduled crawls
+// (crawl_control + crawl_skipped_sources tables), set by an admin and read
+// by every worker replica before enqueueing a scheduled crawl. While
+// Paused the cron enqueues no crawl; SkippedSources are left out of the
+// scheduled crawls only. On-demand crawls are not affected. UpdatedAt is
+// nil when the pause has never been set.
+type CrawlControl struct {
+	Paused         bool
+	Reason         string
+	UpdatedBy      string
+	UpdatedAt      *time.Time
+	SkippedSources []int64
+}
+
+// Skips reports whether the scheduled crawls leave sourceID out.
+func (c *CrawlControl) Skips(sourceID int64) bool {
+	if c == nil {
+		return false
+	}
+	for _, id := range c.SkippedSources {
+		if id == sourceID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+// Crawl run statuses (crawl_runs.status).
+const (
+	CrawlRunStatusRunning   = "running"
+	CrawlRunStatusSucceeded = "succeeded"
+	CrawlRunStatusFailed    = "failed" // the crawl was aborted; Error says why
+)
+
+// CrawlRun is one execution of the crawl pipeline (crawl_runs table): a
+// cron tick, a per-source schedule, an on-demand crawl or crawl-once.
+// FinishedAt is nil while the run is in progress — or when the worker died
+// before finishing it. Sources lists the per-source outcome in the order
+// the sources were started.
+type CrawlRun struct {
+	ID                 int64
+	Status             string
+	StartedAt          time.Time
+	FinishedAt         *time.Time
+	SourceCount        int
+	FetchFailedSources int
+	FeedItems          int64
+	Inserted           int64
+	Duplicated         int64
+	SummarizeErrors    int64
+	Sources            []CrawlRunSource
+	Error              *string
+}
+
+// CrawlRunSource is the outcome of one source within a CrawlRun, stored
+// as an element of crawl_runs.source_stats. HTTPStatus is 0 when no
+// response was received; FetchError is empty on success.
+type CrawlRunSource struct {
+	SourceID        int64  `json:"source_id"`
+	Kind            string `json:"kind"`
+	HTTPStatus      int    `json:"http_status"`
+	FetchError      string `json:"fetch_error,omitempty"`
+	FeedItems       int64  `json:"feed_items"`
+	Inserted        int64  `json:"inserted"`
+	Duplicated      int64  `json:"duplicated"`
+	SummarizeErrors int64  `json:"summarize_errors"`
+	TimedOut        bool   `json:"timed_out,omitempty"`
+	NotModified     bool   `json:"not_modified,omitempty"`
+	Locked          bool   `json:"locked,omitempty"` // skipped: another crawl held the source
+	DurationMS      int64  `json:"duration_ms"`
+}
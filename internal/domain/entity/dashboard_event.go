@@ -0,0 +1,42 @@
+package entity
+
+import "encoding/json"
+
+// DashboardEventChannel is the PostgreSQL NOTIFY channel of the
+// crawl_runs and source_health triggers (notify_crawl_completed,
+// notify_source_health), so the server hears about the worker's crawls
+// the same way it hears about new articles on ArticleEventChannel.
+const DashboardEventChannel = "dashboard_events"
+
+// Dashboard event types (GET /ws). article.created and crawl.completed
+// share the webhook event names; source.health is sent when a source
+// starts or stops failing (SourceHealthOK <-> SourceHealthFailing) and
+// the first time it is crawled.
+const (
+	DashboardEventArticleCreated = WebhookEventArticleCreated
+	DashboardEventCrawlCompleted = WebhookEventCrawlCompleted
+	DashboardEventSourceHealth   = "source.health"
+)
+
+// DashboardEvents lists every event type a GET /ws client may subscribe to.
+var DashboardEvents = []string{DashboardEventArticleCreated, DashboardEventCrawlCompleted, DashboardEventSourceHealth}
+
+// DashboardEvent is the NOTIFY payload of DashboardEventChannel and one
+// GET /ws message. SourceID is set for the events about a single source
+// (article.created, source.health) and is what the per-connection source
+// filter looks at; crawl.completed covers a whole run and leaves it 0.
+// Data is the event body, passed through as-is.
+type DashboardEvent struct {
+	Type     string          `json:"type"`
+	SourceID int64           `json:"source_id,omitempty"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// NewArticleDashboardEvent wraps an article event for GET /ws.
+func NewArticleDashboardEvent(ev ArticleEvent) (DashboardEvent, error) {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return DashboardEvent{}, err
+	}
+	return DashboardEvent{Type: ev.Type, SourceID: ev.SourceID, Data: data}, nil
+}
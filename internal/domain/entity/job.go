@@ -20,6 +20,18 @@ const (
 	JobKindNotifyEpisode   = "notify_episode"
 	JobKindNotifyError     = "notify_error"      // §8: radio バッチ失敗の本人通知(best-effort)
 	JobKindCleanupOldMedia = "cleanup_old_media" // D-4: 45日より古い mp3 の掃除
+	// JobKindPurgeOldArticles archives or deletes articles past their
+	// retention window (RETENTION_DAYS / sources.retention_days).
+	JobKindPurgeOldArticles = "purge_old_articles"
+	// JobKindCrawl is a crawl: enqueued by the worker cron (CRON_SCHEDULE
+	// and per-source crawl_schedule) and on demand through the API
+	// (POST /crawl, POST /sources/{id}/crawl).
+	JobKindCrawl = "crawl"
+	// JobKindResummarize is the §5.2b summary sweep: summarize articles
+	// whose content was filled in after insert (transcripts). Enqueued
+	// right after each CRON_SCHEDULE crawl, and with an article_id
+	// (ResummarizePayload) by POST /articles/{id}/summarize.
+	JobKindResummarize = "resummarize"
 	// JobKindTranscribe is enqueued by the Pi worker for youtube/podcast
 	// sources (Phase 2 §5) and claimed ONLY by the Mac transcribe worker
 	// (catchup-feed-ai). The Pi consumer must never register a handler for
@@ -31,6 +43,19 @@ const (
 	// local-LLM-only (C-12) and Ollama lives on the Mac. Like transcribe,
 	// the Pi consumer must never register a handler for it.
 	JobKindBookIngest = "book_ingest"
+	// JobKindDeliverWebhook sends one webhook_deliveries row; the consumer's
+	// retry policy doubles as the delivery retry policy.
+	JobKindDeliverWebhook = "deliver_webhook"
+	// JobKindNotifyDigest sends the daily / weekly article digest to the
+	// admin destinations (DIGEST_MODE, DIGEST_CRON_SCHEDULE).
+	JobKindNotifyDigest = "notify_digest"
+	// JobKindNotifyAlert sends one keyword alert hit (an article that
+	// matched an alert rule during a crawl) to the admin destinations.
+	JobKindNotifyAlert = "notify_alert"
+	// JobKindExportArticles writes the articles stored or summarized since
+	// the previous run to object storage as NDJSON (EXPORT_BUCKET,
+	// EXPORT_CRON_SCHEDULE).
+	JobKindExportArticles = "export_articles"
 )
 
 // TranscribePayload is the jobs.payload contract for kind='transcribe'
@@ -59,6 +84,37 @@ type BookIngestPayload struct {
 	Title    string `json:"title"`
 }
 
+// DeliverWebhookPayload is the jobs.payload contract for
+// kind='deliver_webhook'. The delivery row holds the body, so the job only
+// points at it.
+type DeliverWebhookPayload struct {
+	DeliveryID int64 `json:"delivery_id"`
+}
+
+// NotifyAlertPayload is the jobs.payload contract for kind='notify_alert'.
+// The repository builds it in SQL when it records the hit, so the article
+// is the one the crawl stored, not reread at send time.
+type NotifyAlertPayload struct {
+	RuleID   int64              `json:"rule_id"`
+	RuleName string             `json:"rule_name"`
+	Article  WebhookArticleData `json:"article"`
+}
+
+// CrawlPayload is the jobs.payload contract for kind='crawl'. SourceID 0
+// crawls every active source; with DefaultSchedule (the CRON_SCHEDULE
+// tick) sources with their own crawl_schedule are left to that schedule.
+type CrawlPayload struct {
+	SourceID        int64 `json:"source_id,omitempty"`
+	DefaultSchedule bool  `json:"default_schedule,omitempty"`
+}
+
+// ResummarizePayload is the jobs.payload contract for kind='resummarize'.
+// ArticleID 0 (the cron's empty payload) runs the §5.2b sweep; otherwise
+// only that article is summarized again and its summary replaced.
+type ResummarizePayload struct {
+	ArticleID int64 `json:"article_id,omitempty"`
+}
+
 // Job is one row of the jobs table (§4), the sole inter-process channel
 // between worker (Pi) and radio (Mac): C-4 — no internal HTTP/RPC. A DB
 // queue survives restarts and fits the nightly-batch cadence.
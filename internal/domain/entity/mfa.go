@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" // #nosec G505 -- RFC 6238 TOTP is HMAC-SHA1, the authenticator app default
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP parameters (RFC 6238 defaults, which every authenticator app
+// supports): HMAC-SHA1, 6 digits, 30-second steps.
+const (
+	TOTPDigits = 6
+	TOTPPeriod = 30 * time.Second
+
+	// totpSecretBytes is the RFC 4226 recommended 160-bit secret.
+	totpSecretBytes = 20
+)
+
+// totpEncoding is the unpadded base32 alphabet authenticator apps expect.
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// UserMFA is a user's TOTP enrollment (user_mfa table). Enrollment is two
+// steps: the secret is stored pending (EnabledAt nil) and only becomes
+// active once the user proves their authenticator produces valid codes.
+// Unlike passwords the secret cannot be hashed — verifying a code needs it.
+type UserMFA struct {
+	UserID    int64
+	Secret    string     // base32(RFC 4648、パディングなし)
+	EnabledAt *time.Time // nil = 登録途中(確認コード未入力)
+	LastStep  int64      // 最後に受理したコードの時間ステップ(再利用防止)
+	CreatedAt time.Time
+}
+
+// IsEnabled reports whether login requires a TOTP code.
+func (m *UserMFA) IsEnabled() bool {
+	return m.EnabledAt != nil
+}
+
+// GenerateTOTPSecret returns a new random base32 TOTP secret.
+func GenerateTOTPSecret() (string, error) {
+	buf := make([]byte, totpSecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate totp secret: %w", err)
+	}
+	return totpEncoding.EncodeToString(buf), nil
+}
+
+// TOTPStep returns the RFC 6238 time step containing t.
+func TOTPStep(t time.Time) int64 {
+	return t.Unix() / int64(TOTPPeriod/time.Second)
+}
+
+// TOTPCode returns the code for secret at time step step.
+func TOTPCode(secret string, step int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", fmt.Errorf("decode totp secret: %w", err)
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step)) // #nosec G115 -- steps are positive
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// RFC 4226 §5.3 dynamic truncation.
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", TOTPDigits, value%1_000_000), nil
+}
+
+// MatchTOTP checks code against secret at t, tolerating skew steps of
+// clock drift either way, and returns the matching step. Callers must
+// reject steps at or before the last accepted one so a code cannot be
+// replayed.
+func MatchTOTP(secret, code string, t time.Time, skew int64) (int64, bool) {
+	if len(code) != TOTPDigits {
+		return 0, false
+	}
+	now := TOTPStep(t)
+	for step := now - skew; step <= now+skew; step++ {
+		want, err := TOTPCode(secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(want), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// TOTPProvisioningURI returns the otpauth:// URI authenticator apps import
+// (usually rendered as a QR code by the client).
+func TOTPProvisioningURI(issuer, account, secret string) string {
+	q := url.Values{}
+	q.Set("secret", secret)
+	q.Set("issuer", issuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", fmt.Sprint(TOTPDigits))
+	q.Set("period", fmt.Sprint(int(TOTPPeriod/time.Second)))
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	return "otpauth://totp/" + label + "?" + q.Encode()
+}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6238Secret is the RFC 6238 Appendix B SHA-1 seed "12345678901234567890".
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPCode_RFC6238Vectors(t *testing.T) {
+	// RFC 6238 Appendix B(8桁)の下6桁。
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := TOTPCode(rfc6238Secret, TOTPStep(time.Unix(tt.unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, code, tt.unix)
+	}
+}
+
+func TestMatchTOTP(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	code, err := TOTPCode(rfc6238Secret, TOTPStep(now))
+	require.NoError(t, err)
+
+	step, ok := MatchTOTP(rfc6238Secret, code, now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, TOTPStep(now), step)
+
+	// 1ステップのずれは許容、2ステップは拒否。
+	_, ok = MatchTOTP(rfc6238Secret, code, now.Add(TOTPPeriod), 1)
+	assert.True(t, ok)
+	_, ok = MatchTOTP(rfc6238Secret, code, now.Add(2*TOTPPeriod), 1)
+	assert.False(t, ok)
+
+	_, ok = MatchTOTP(rfc6238Secret, "12345", now, 1)
+	assert.False(t, ok)
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	a, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+	b, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+	assert.Len(t, a, 32)
+	assert.NotEqual(t, a, b)
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	uri := TOTPProvisioningURI("catchup-feed", "admin@example.com", "JBSWY3DPEHPK3PXP")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/catchup-feed:admin@example.com?"))
+
+	u, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", u.Query().Get("secret"))
+	assert.Equal(t, "catchup-feed", u.Query().Get("issuer"))
+	assert.Equal(t, "6", u.Query().Get("digits"))
+}
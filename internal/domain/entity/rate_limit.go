@@ -0,0 +1,8 @@
+package entity
+
+// RateLimitDenial is one client key ranked by how many of its requests a
+// rate limiter rejected inside the current window (admin inspection API).
+type RateLimitDenial struct {
+	Key    string `json:"key"`
+	Denied int    `json:"denied"`
+}
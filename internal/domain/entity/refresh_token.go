@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// refreshTokenPrefix marks a plaintext refresh token so a leaked token is
+// recognizable in logs and secret scanners (same idea as apiKeyPrefix).
+const refreshTokenPrefix = "cfr_"
+
+// RefreshToken is one issued refresh token (refresh_tokens table). Tokens
+// are single-use: each /auth/refresh consumes the presented token (UsedAt)
+// and issues its successor in the same family. Presenting a consumed token
+// again means it was stolen or replayed, and the whole family is revoked.
+// Like feed tokens (D-5) only the SHA-256 hex hash is stored.
+type RefreshToken struct {
+	ID        int64
+	FamilyID  string // ログイン1回ごとの系列 ID(ローテーションで引き継ぐ)
+	Subject   string // JWT の sub
+	Role      string // JWT の role(admin / viewer)
+	TokenHash string // SHA-256 hex of the plaintext
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    *time.Time // ローテーション済み(再提示 = 再利用検知)
+	RevokedAt *time.Time // ログアウト / 再利用検知で失効
+}
+
+// IsExpired reports whether the token has expired as of now.
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// GenerateRefreshToken generates a new refresh token and returns the
+// plaintext (handed to the client) and its hash (to persist).
+func GenerateRefreshToken() (plaintext, hash string, err error) {
+	buf := make([]byte, feedTokenByteLen)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("generate refresh token: %w", err)
+	}
+	plaintext = refreshTokenPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return plaintext, HashRefreshToken(plaintext), nil
+}
+
+// NewRefreshFamilyID generates the family ID for a fresh login.
+func NewRefreshFamilyID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate refresh family id: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// HashRefreshToken returns the SHA-256 hex digest stored in
+// refresh_tokens.token_hash (same scheme as HashFeedToken).
+func HashRefreshToken(plaintext string) string {
+	return HashFeedToken(plaintext)
+}
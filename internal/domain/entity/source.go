@@ -29,20 +29,42 @@ func ValidSourceKind(kind string) bool {
 	return false
 }
 
+// MinRetentionDays is the shortest article retention window a source may
+// set. It matches the crawl's 14-day backfill cutoff, so a purged article
+// is never old enough to be crawled again while it is still in the feed.
+const MinRetentionDays = 14
+
 // Source represents a feed source in the pulse schema (§4).
 // Sources are RSS/Atom feeds crawled with gofeed; the category drives the
 // radio script corner assignment (§4: 台本のコーナー分けに使用).
 // Kind selects the content pipeline (Phase 2 §5): 'rss' extracts content
 // with go-readability, 'youtube'/'podcast' enqueue a transcribe job.
+// CrawlSchedule overrides the worker's global CRON_SCHEDULE for this source
+// (cron expression or interval, see internal/pkg/schedule); nil follows the
+// global schedule. RetentionDays overrides the worker's RETENTION_DAYS for
+// the articles of this source; nil follows the global window. RenderJS
+// fetches the article pages with the headless browser fallback instead of
+// a plain GET, for sites that only render their content in JavaScript. ETag and
+// LastModified are the cache validators of the last feed response, replayed
+// on the next crawl (conditional GET); they are written only by the crawl.
+// GroupID is the source group (folder) the source belongs to, nil when
+// ungrouped; it is written only through the group membership
+// (SourceGroupRepository.SetSources).
 type Source struct {
-	ID        int64
-	Name      string
-	FeedURL   string
-	Category  string
-	Lang      string
-	Kind      string
-	Active    bool
-	CreatedAt time.Time
+	ID            int64
+	Name          string
+	FeedURL       string
+	Category      string
+	Lang          string
+	Kind          string
+	Active        bool
+	CrawlSchedule *string
+	RetentionDays *int
+	RenderJS      bool
+	ETag          string
+	LastModified  string
+	CreatedAt     time.Time
+	GroupID       *int64
 }
 
 // Validate validates the Source entity fields against the pulse schema.
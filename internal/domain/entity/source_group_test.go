@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSourceGroupName(t *testing.T) {
+	assert.NoError(t, ValidateSourceGroupName("Go"))
+	assert.Error(t, ValidateSourceGroupName(""))
+	assert.Error(t, ValidateSourceGroupName(strings.Repeat("あ", MaxSourceGroupNameLength+1)))
+}
+
+func TestSourceGroups(t *testing.T) {
+	groupSchedule, ownSchedule := "2h", "30m"
+	groupID, pausedID, unknownID := int64(1), int64(2), int64(9)
+	groups := NewSourceGroups([]*SourceGroup{
+		{ID: groupID, Name: "Go", CrawlSchedule: &groupSchedule},
+		{ID: pausedID, Name: "Seasonal", CrawlPaused: true},
+	})
+
+	ungrouped := &Source{ID: 1}
+	member := &Source{ID: 2, GroupID: &groupID}
+	ownScheduleMember := &Source{ID: 3, GroupID: &groupID, CrawlSchedule: &ownSchedule}
+	pausedMember := &Source{ID: 4, GroupID: &pausedID}
+	deletedGroupMember := &Source{ID: 5, GroupID: &unknownID}
+
+	assert.Nil(t, groups.CrawlSchedule(ungrouped))
+	assert.Equal(t, &groupSchedule, groups.CrawlSchedule(member), "members follow the group schedule")
+	assert.Equal(t, &ownSchedule, groups.CrawlSchedule(ownScheduleMember), "a source schedule wins")
+	assert.Nil(t, groups.CrawlSchedule(pausedMember))
+	assert.Nil(t, groups.CrawlSchedule(deletedGroupMember))
+
+	assert.False(t, groups.CrawlPaused(member))
+	assert.True(t, groups.CrawlPaused(pausedMember))
+	assert.False(t, groups.CrawlPaused(deletedGroupMember))
+
+	var none SourceGroups
+	assert.Equal(t, &ownSchedule, none.CrawlSchedule(ownScheduleMember))
+	assert.False(t, none.CrawlPaused(pausedMember), "no groups, no pause")
+}
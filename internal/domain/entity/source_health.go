@@ -0,0 +1,42 @@
+package entity
+
+import "time"
+
+// Source health statuses, derived from SourceHealth (see
+// SourceHealth.Status).
+const (
+	SourceHealthOK           = "ok"            // last fetch succeeded recently
+	SourceHealthFailing      = "failing"       // the latest fetch failed
+	SourceHealthStale        = "stale"         // no successful fetch within the stale window
+	SourceHealthNeverCrawled = "never_crawled" // no fetch recorded yet
+)
+
+// SourceHealth is the crawl outcome history of one source (source_health
+// table), updated by the worker after every feed fetch. LastHTTPStatus is
+// the feed response status of the latest fetch (nil when no response was
+// received: DNS, TLS, timeout); ConsecutiveFailures resets to 0 on
+// success; LastError is the latest fetch error (nil after a success).
+type SourceHealth struct {
+	SourceID            int64
+	LastCrawledAt       *time.Time
+	LastSuccessAt       *time.Time
+	LastHTTPStatus      *int
+	ConsecutiveFailures int
+	LastError           *string
+}
+
+// Status classifies the source. A source is stale when it has not been
+// fetched successfully within staleAfter of now; failing takes precedence
+// because it is the more actionable signal.
+func (h *SourceHealth) Status(now time.Time, staleAfter time.Duration) string {
+	if h == nil || h.LastCrawledAt == nil {
+		return SourceHealthNeverCrawled
+	}
+	if h.ConsecutiveFailures > 0 {
+		return SourceHealthFailing
+	}
+	if h.LastSuccessAt == nil || now.Sub(*h.LastSuccessAt) > staleAfter {
+		return SourceHealthStale
+	}
+	return SourceHealthOK
+}
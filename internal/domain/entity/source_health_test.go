@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSourceHealth_Status(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time { t := now.Add(-d); return &t }
+
+	tests := []struct {
+		name   string
+		health *SourceHealth
+		want   string
+	}{
+		{"no record", nil, SourceHealthNeverCrawled},
+		{"recent success", &SourceHealth{LastCrawledAt: at(time.Hour), LastSuccessAt: at(time.Hour)}, SourceHealthOK},
+		{"latest fetch failed", &SourceHealth{LastCrawledAt: at(time.Hour), LastSuccessAt: at(2 * time.Hour), ConsecutiveFailures: 1}, SourceHealthFailing},
+		{"old success", &SourceHealth{LastCrawledAt: at(time.Hour), LastSuccessAt: at(72 * time.Hour)}, SourceHealthStale},
+		{"never succeeded", &SourceHealth{LastCrawledAt: at(time.Hour)}, SourceHealthStale},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.health.Status(now, 48*time.Hour); got != tt.want {
+				t.Errorf("Status() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
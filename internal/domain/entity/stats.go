@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+// SourceArticleStats is one source's article counts for the admin
+// dashboard (GET /stats). Sources without any article still appear, with
+// LastCrawledAt nil.
+type SourceArticleStats struct {
+	SourceID      int64
+	SourceName    string
+	Active        bool
+	Total         int64      // every stored article
+	Recent        int64      // articles crawled within the stats window
+	LastCrawledAt *time.Time // newest articles.crawled_at
+}
+
+// ArticleCountBucket is the number of articles crawled in one day or week,
+// starting on Day (JST, "2006-01-02").
+type ArticleCountBucket struct {
+	Day   string
+	Count int64
+}
+
+// CrawlRunStats aggregates the crawl runs started within the stats window
+// (crawl_runs). Durations only cover finished runs and are nil when there
+// is none.
+type CrawlRunStats struct {
+	Runs            int64
+	Succeeded       int64
+	Failed          int64
+	Running         int64 // unfinished: in progress, or the worker died
+	Inserted        int64
+	SummarizeErrors int64
+	AvgDurationSec  *float64
+	P95DurationSec  *float64
+	MaxDurationSec  *float64
+}
@@ -9,6 +9,10 @@ const (
 	// SummaryProviderUnknown is stored when the summarizer implementation
 	// cannot report a provider name (e.g. a plain Summarizer stub).
 	SummaryProviderUnknown = "unknown"
+	// SummaryProviderFiltered is stored, with a note naming the reason as
+	// the body, for an article the crawl's quality filter marked as junk
+	// instead of summarizing it.
+	SummaryProviderFiltered = "filtered"
 )
 
 // Summary represents the Japanese summary of an article (summaries table,
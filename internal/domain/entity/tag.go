@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxTagNameLength bounds a tag name in characters (runes).
+const MaxTagNameLength = 50
+
+// Tag is a free-form article label (tags / article_tags tables). Names are
+// stored normalized (see NormalizeTagName), so "Go" and " go " are the same
+// tag.
+//
+// ArticleCount is NOT a column: it is filled in by listings that count the
+// article_tags rows and is zero elsewhere.
+type Tag struct {
+	ID           int64
+	Name         string
+	CreatedAt    time.Time
+	ArticleCount int64 // read-only: counted from article_tags
+}
+
+// NormalizeTagName trims name, lowercases it and collapses runs of
+// whitespace into a single space.
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// ValidateTagName checks an already normalized tag name. Commas are
+// rejected because clients commonly send tag lists comma-separated.
+func ValidateTagName(name string) error {
+	switch {
+	case name == "":
+		return &ValidationError{Field: "name", Message: "is required"}
+	case utf8.RuneCountInString(name) > MaxTagNameLength:
+		return &ValidationError{Field: "name", Message: fmt.Sprintf("is too long (max %d characters)", MaxTagNameLength)}
+	case strings.Contains(name, ","):
+		return &ValidationError{Field: "name", Message: "must not contain commas"}
+	}
+	return nil
+}
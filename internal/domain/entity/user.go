@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// User is a dashboard account in the users table: administrators and
+// read-only viewers (D-27) share one table and are told apart by Role.
+// Admin accounts used to live in environment variables (C-7); those are
+// now only the bootstrap credentials for the very first admin.
+type User struct {
+	ID            int64
+	Name          string
+	Email         string // ログイン識別子(小文字に正規化して保存)
+	Role          string // "admin" | "viewer"(JWT の role と同じ2値)
+	PasswordHash  string // bcrypt。ハンドラ層(DTO)には決して載せない
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeactivatedAt *time.Time // nil = アクティブ
+}
+
+// IsActive reports whether the user may currently log in.
+func (u *User) IsActive() bool {
+	return u.DeactivatedAt == nil
+}
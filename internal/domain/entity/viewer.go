@@ -3,7 +3,7 @@ package entity
 import "time"
 
 // Viewer represents a friend with a read-only dashboard account
-// (viewers table, D-27). Viewers log in with email + password (bcrypt) and
+// (a role=viewer row of the users table, D-27). Viewers log in with email + password (bcrypt) and
 // may only browse active sources. They are a separate entity from
 // Subscriber: web access control and podcast delivery control have
 // independent lifecycles (D-27 (1)).
@@ -0,0 +1,209 @@
+package entity
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Webhook event names. A webhook subscribes to one or more of them.
+const (
+	WebhookEventArticleCreated = "article.created"
+	WebhookEventCrawlCompleted = "crawl.completed"
+)
+
+// WebhookEvents lists every event a webhook may subscribe to.
+var WebhookEvents = []string{WebhookEventArticleCreated, WebhookEventCrawlCompleted}
+
+// IsValidWebhookEvent reports whether name is a known event.
+func IsValidWebhookEvent(name string) bool {
+	return slices.Contains(WebhookEvents, name)
+}
+
+// Webhook delivery statuses (webhook_deliveries.status). pending covers
+// both "not tried yet" and "failed, retry scheduled".
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Webhook is an outbound subscription (webhooks table): the system POSTs
+// signed JSON to URL for each subscribed event. Secret is the HMAC key and
+// has to be kept in plaintext (like the TOTP secret); it is never returned
+// by the API.
+type Webhook struct {
+	ID        int64
+	URL       string
+	Secret    string
+	Events    []string
+	Route     WebhookRoute
+	CreatedAt time.Time
+}
+
+// Subscribes reports whether the webhook receives event.
+func (w *Webhook) Subscribes(event string) bool {
+	return slices.Contains(w.Events, event)
+}
+
+// WebhookRoute is the routing rule of a webhook's article.created events
+// (webhooks.route). A route without conditions receives every article.
+// Otherwise an article matches when its source is one of SourceIDs, its
+// source's category is one of Categories, or its title or summary
+// contains one of Keywords (case-insensitive) — any single condition is
+// enough. A Fallback webhook has no conditions and receives only the
+// articles no conditional webhook matched ("everything else").
+// crawl.completed is never routed.
+type WebhookRoute struct {
+	SourceIDs  []int64  `json:"source_ids,omitempty"`
+	Categories []string `json:"categories,omitempty"`
+	Keywords   []string `json:"keywords,omitempty"`
+	Fallback   bool     `json:"fallback,omitempty"`
+}
+
+// HasConditions reports whether the route narrows the articles at all.
+func (r WebhookRoute) HasConditions() bool {
+	return len(r.SourceIDs) > 0 || len(r.Categories) > 0 || len(r.Keywords) > 0
+}
+
+// IsZero reports whether the route is the default "every article".
+func (r WebhookRoute) IsZero() bool {
+	return !r.HasConditions() && !r.Fallback
+}
+
+// WebhookRouteArticle is what routing looks at in a new article.
+type WebhookRouteArticle struct {
+	SourceID int64
+	Category string // the source's category
+	Title    string
+	Summary  string
+}
+
+// Matches reports whether the route's conditions select the article. A
+// route without conditions matches nothing here; see RouteWebhookArticle.
+func (r WebhookRoute) Matches(a WebhookRouteArticle) bool {
+	if slices.Contains(r.SourceIDs, a.SourceID) {
+		return true
+	}
+	if a.Category != "" && slices.ContainsFunc(r.Categories, func(c string) bool { return strings.EqualFold(c, a.Category) }) {
+		return true
+	}
+	if len(r.Keywords) > 0 {
+		text := strings.ToLower(a.Title + "\n" + a.Summary)
+		for _, keyword := range r.Keywords {
+			if strings.Contains(text, strings.ToLower(keyword)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RouteWebhookArticle returns the IDs of the webhooks, among those
+// subscribed to article.created, that receive the article: unrouted ones,
+// conditional ones whose route matches, and the fallback ones when no
+// conditional webhook matched.
+func RouteWebhookArticle(webhooks []*Webhook, a WebhookRouteArticle) []int64 {
+	var ids, fallbacks []int64
+	matched := false
+	for _, w := range webhooks {
+		switch {
+		case w.Route.Fallback:
+			fallbacks = append(fallbacks, w.ID)
+		case !w.Route.HasConditions():
+			ids = append(ids, w.ID)
+		case w.Route.Matches(a):
+			ids = append(ids, w.ID)
+			matched = true
+		}
+	}
+	if !matched {
+		ids = append(ids, fallbacks...)
+	}
+	return ids
+}
+
+// WebhookDelivery is one event sent (or to be sent) to one webhook
+// (webhook_deliveries table). Payload is the exact request body, so every
+// retry signs and sends the same bytes.
+type WebhookDelivery struct {
+	ID             int64
+	WebhookID      int64
+	Event          string
+	Payload        json.RawMessage
+	Status         string // pending | delivered | failed
+	Attempts       int
+	ResponseStatus *int    // HTTP status of the last attempt; nil = no response
+	LastError      *string // nil after a successful attempt
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+// WebhookPayload is the JSON body of every delivery. Data is the
+// event-specific object (WebhookArticleData / WebhookCrawlData). Treat
+// renames as a breaking change for receivers.
+type WebhookPayload struct {
+	Event      string    `json:"event"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+}
+
+// WebhookArticleData is the data of article.created.
+type WebhookArticleData struct {
+	ID          int64      `json:"id"`
+	SourceID    int64      `json:"source_id"`
+	Title       string     `json:"title"`
+	URL         string     `json:"url"`
+	Summary     string     `json:"summary"`
+	PublishedAt *time.Time `json:"published_at"`
+	DuplicateOf *int64     `json:"duplicate_of"` // canonical article of a near-duplicate; null otherwise
+	ImageURL    string     `json:"image_url,omitempty"`
+}
+
+// NewWebhookArticleData builds the article.created data from a stored
+// article. A zero PublishedAt is sent as null.
+func NewWebhookArticleData(a *Article) WebhookArticleData {
+	data := WebhookArticleData{
+		ID:          a.ID,
+		SourceID:    a.SourceID,
+		Title:       a.Title,
+		URL:         a.URL,
+		Summary:     a.Summary,
+		DuplicateOf: a.DuplicateOf,
+		ImageURL:    a.ImageURL,
+	}
+	if !a.PublishedAt.IsZero() {
+		published := a.PublishedAt
+		data.PublishedAt = &published
+	}
+	return data
+}
+
+// WebhookCrawlData is the data of crawl.completed.
+type WebhookCrawlData struct {
+	Sources            int   `json:"sources"`
+	FetchFailedSources int   `json:"fetch_failed_sources"`
+	FeedItems          int64 `json:"feed_items"`
+	Inserted           int64 `json:"inserted"`
+	Duplicated         int64 `json:"duplicated"`
+	DuplicatedByHash   int64 `json:"duplicated_by_hash"`
+	NearDuplicates     int64 `json:"near_duplicates"`
+	FilteredJunk       int64 `json:"filtered_junk"`
+	SummarizeErrors    int64 `json:"summarize_errors"`
+	DurationMS         int64 `json:"duration_ms"`
+}
+
+// SignWebhookPayload returns the hex HMAC-SHA256 of "<timestamp>.<body>"
+// under secret. Binding the timestamp lets receivers reject replays.
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSignWebhookPayload(t *testing.T) {
+	body := []byte(`{"event":"crawl.completed"}`)
+
+	mac := hmac.New(sha256.New, []byte("0123456789abcdef"))
+	mac.Write([]byte(`1760000000.{"event":"crawl.completed"}`))
+	want := hex.EncodeToString(mac.Sum(nil))
+
+	if got := SignWebhookPayload("0123456789abcdef", 1760000000, body); got != want {
+		t.Errorf("SignWebhookPayload() = %s, want %s", got, want)
+	}
+	// タイムスタンプも署名対象(リプレイ対策)
+	if SignWebhookPayload("0123456789abcdef", 1760000001, body) == want {
+		t.Error("signature does not depend on the timestamp")
+	}
+}
+
+func TestNewWebhookArticleData(t *testing.T) {
+	published := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	data := NewWebhookArticleData(&Article{ID: 1, SourceID: 2, Title: "t", URL: "https://example.com", Summary: "s", PublishedAt: published})
+	if data.PublishedAt == nil || !data.PublishedAt.Equal(published) {
+		t.Errorf("PublishedAt = %v, want %v", data.PublishedAt, published)
+	}
+
+	if data := NewWebhookArticleData(&Article{ID: 1}); data.PublishedAt != nil {
+		t.Errorf("PublishedAt = %v, want nil for an undated article", data.PublishedAt)
+	}
+
+	canonical := int64(7)
+	if data := NewWebhookArticleData(&Article{ID: 8, DuplicateOf: &canonical}); data.DuplicateOf == nil || *data.DuplicateOf != 7 {
+		t.Errorf("DuplicateOf = %v, want 7", data.DuplicateOf)
+	}
+}
+
+func TestIsValidWebhookEvent(t *testing.T) {
+	for _, event := range WebhookEvents {
+		if !IsValidWebhookEvent(event) {
+			t.Errorf("IsValidWebhookEvent(%q) = false", event)
+		}
+	}
+	if IsValidWebhookEvent("article.deleted") {
+		t.Error("IsValidWebhookEvent(article.deleted) = true")
+	}
+}
+
+func TestRouteWebhookArticle(t *testing.T) {
+	security := &Webhook{ID: 1, Route: WebhookRoute{Categories: []string{"Security"}, Keywords: []string{"CVE-"}}}
+	goBlog := &Webhook{ID: 2, Route: WebhookRoute{SourceIDs: []int64{7}}}
+	everythingElse := &Webhook{ID: 3, Route: WebhookRoute{Fallback: true}}
+	archive := &Webhook{ID: 4}
+	webhooks := []*Webhook{security, goBlog, everythingElse, archive}
+
+	tests := []struct {
+		name    string
+		article WebhookRouteArticle
+		want    []int64
+	}{
+		{"category match, case-insensitive", WebhookRouteArticle{SourceID: 1, Category: "security"}, []int64{1, 4}},
+		{"keyword in summary", WebhookRouteArticle{SourceID: 1, Title: "patch", Summary: "fixes cve-2026-1234"}, []int64{1, 4}},
+		{"source match", WebhookRouteArticle{SourceID: 7, Title: "Go 1.26"}, []int64{2, 4}},
+		{"several routes match", WebhookRouteArticle{SourceID: 7, Title: "CVE-2026-1 in net/http"}, []int64{1, 2, 4}},
+		{"no route matches: fallback", WebhookRouteArticle{SourceID: 1, Category: "tech", Title: "hello"}, []int64{4, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RouteWebhookArticle(webhooks, tt.article); !slices.Equal(got, tt.want) {
+				t.Errorf("RouteWebhookArticle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package alert
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/handler/http/respond"
+	alertUC "catchup-feed/internal/usecase/alert"
+)
+
+type ListHandler struct{ Svc *alertUC.Service }
+
+// ServeHTTP アラートルール一覧取得
+// @Summary      アラートルール一覧取得
+// @Description  呼び出し元ユーザーのキーワードアラートルールを作成順に返します。hit_count は一致した記事の件数です。
+// @Tags         alerts
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {array} DTO "アラートルール一覧"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - ユーザーアカウントが必要"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /alerts [get]
+func (h ListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.Svc.List(r.Context(), auth.SubjectFromContext(r.Context()))
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	out := make([]DTO, 0, len(rules))
+	for _, rule := range rules {
+		out = append(out, toDTO(rule))
+	}
+	respond.JSON(w, http.StatusOK, out)
+}
+
+type GetHandler struct{ Svc *alertUC.Service }
+
+// ServeHTTP アラートルール取得
+// @Summary      アラートルール取得
+// @Description  呼び出し元ユーザーのアラートルールを1件返します。
+// @Tags         alerts
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "ルール ID"
+// @Success      200 {object} DTO "アラートルール"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid ID"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - ユーザーアカウントが必要"
+// @Failure      404 {object} respond.ErrorResponse "Not found - ルールが存在しない"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /alerts/{id} [get]
+func (h GetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	rule, err := h.Svc.Get(r.Context(), auth.SubjectFromContext(r.Context()), id)
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toDTO(rule))
+}
+
+type CreateHandler struct{ Svc *alertUC.Service }
+
+// ServeHTTP アラートルール作成
+// @Summary      アラートルール作成
+// @Description  キーワードアラートルールを作成します。クロールで保存された記事のタイトル・要約が query に一致すると、
+// @Description  ルール名を付けて管理者の通知先(Discord / Slack)へ通知し、hit_count を増やします。
+// @Description  query はキーワードの論理式です: 空白区切りは AND、OR で「いずれか」、NOT または先頭の - で除外、
+// @Description  括弧でグループ化、"..." でフレーズ。演算子は大文字のみ、語は大文字小文字を区別しない部分一致です
+// @Description  (最大500文字・50語)。name はユーザー内で一意(最大100文字)、ルールは1ユーザー50件まで。
+// @Tags         alerts
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        rule body Request true "ルール(name / query 必須)"
+// @Success      201 {object} DTO "作成されたルール"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - 入力が不正・上限超過"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - ユーザーアカウントが必要"
+// @Failure      409 {object} respond.ErrorResponse "Conflict - 同名のルールが存在"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /alerts [post]
+func (h CreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	created, err := h.Svc.Create(r.Context(), auth.SubjectFromContext(r.Context()), alertUC.RuleInput(req))
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusCreated, toDTO(created))
+}
+
+type UpdateHandler struct{ Svc *alertUC.Service }
+
+// ServeHTTP アラートルール更新
+// @Summary      アラートルール更新
+// @Description  アラートルールの name / query / enabled を置き換えます(enabled 省略時は true)。hit_count は引き継がれます。
+// @Tags         alerts
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "ルール ID"
+// @Param        rule body Request true "ルール(name / query 必須)"
+// @Success      200 {object} DTO "更新後のルール"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - 入力が不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - ユーザーアカウントが必要"
+// @Failure      404 {object} respond.ErrorResponse "Not found - ルールが存在しない"
+// @Failure      409 {object} respond.ErrorResponse "Conflict - 同名のルールが存在"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /alerts/{id} [put]
+func (h UpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	updated, err := h.Svc.Update(r.Context(), auth.SubjectFromContext(r.Context()), id, alertUC.RuleInput(req))
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toDTO(updated))
+}
+
+type DeleteHandler struct{ Svc *alertUC.Service }
+
+// ServeHTTP アラートルール削除
+// @Summary      アラートルール削除
+// @Description  アラートルールを削除します。送信待ちの通知はそのまま送られます。
+// @Tags         alerts
+// @Security     BearerAuth
+// @Param        id path int true "ルール ID"
+// @Success      204 "No Content"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid ID"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - ユーザーアカウントが必要"
+// @Failure      404 {object} respond.ErrorResponse "Not found - ルールが存在しない"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /alerts/{id} [delete]
+func (h DeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.Svc.Delete(r.Context(), auth.SubjectFromContext(r.Context()), id); err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
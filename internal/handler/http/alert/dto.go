@@ -0,0 +1,55 @@
+// Package alert provides the keyword alert HTTP handlers: the caller's
+// alert rules (name + boolean keyword query) with their hit counts,
+// following the flat-path convention (C-21: /alerts, /alerts/{id}).
+package alert
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/pathutil"
+)
+
+// DTO is one alert rule. hit_count / last_hit_at count the articles that
+// matched since the rule was created.
+type DTO struct {
+	ID        int64      `json:"id" example:"1"`
+	Name      string     `json:"name" example:"Go リリース"`
+	Query     string     `json:"query" example:"go AND (release OR リリース) -beta"`
+	Enabled   bool       `json:"enabled" example:"true"`
+	HitCount  int64      `json:"hit_count" example:"12"`
+	LastHitAt *time.Time `json:"last_hit_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func toDTO(r *entity.AlertRule) DTO {
+	return DTO{
+		ID:        r.ID,
+		Name:      r.Name,
+		Query:     r.Query,
+		Enabled:   r.Enabled,
+		HitCount:  r.HitCount,
+		LastHitAt: r.LastHitAt,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+// Request is the POST /alerts and PUT /alerts/{id} body. enabled defaults
+// to true.
+type Request struct {
+	Name    string `json:"name" example:"Go リリース"`
+	Query   string `json:"query" example:"go AND (release OR リリース) -beta"`
+	Enabled *bool  `json:"enabled,omitempty" example:"true"`
+}
+
+func pathID(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, pathutil.ErrInvalidID
+	}
+	return id, nil
+}
@@ -0,0 +1,154 @@
+package alert_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/alert"
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/repository"
+	alertUC "catchup-feed/internal/usecase/alert"
+)
+
+/* ───────── モック実装 ───────── */
+
+type stubUserRepo struct {
+	repository.UserRepository
+}
+
+func (stubUserRepo) GetActiveByEmail(_ context.Context, email string) (*entity.User, error) {
+	if email == "alice@example.com" {
+		return &entity.User{ID: 7, Email: email}, nil
+	}
+	return nil, nil
+}
+
+// stubRules keeps rules in memory; names are unique across all users.
+type stubRules struct {
+	repository.AlertRuleRepository
+	rules  map[int64]*entity.AlertRule
+	nextID int64
+}
+
+func (s *stubRules) List(_ context.Context, userID int64) ([]*entity.AlertRule, error) {
+	out := []*entity.AlertRule{}
+	for _, r := range s.rules {
+		if r.UserID == userID {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (s *stubRules) Get(_ context.Context, id int64) (*entity.AlertRule, error) {
+	if r, ok := s.rules[id]; ok {
+		copied := *r
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (s *stubRules) Create(_ context.Context, rule *entity.AlertRule) error {
+	for _, r := range s.rules {
+		if r.Name == rule.Name {
+			return repository.ErrDuplicateAlertRuleName
+		}
+	}
+	s.nextID++
+	rule.ID = s.nextID
+	s.rules[rule.ID] = rule
+	return nil
+}
+
+func (s *stubRules) Update(_ context.Context, rule *entity.AlertRule) error {
+	s.rules[rule.ID] = rule
+	return nil
+}
+
+func (s *stubRules) Delete(_ context.Context, id int64) error {
+	delete(s.rules, id)
+	return nil
+}
+
+/* ───────── テストケース ───────── */
+
+func TestRegister_NoRouteConflicts(t *testing.T) {
+	assert.NotPanics(t, func() {
+		alert.Register(http.NewServeMux(), &alertUC.Service{Users: stubUserRepo{}, Rules: &stubRules{}})
+	})
+}
+
+func TestAlertHandlers(t *testing.T) {
+	rules := &stubRules{rules: map[int64]*entity.AlertRule{
+		99: {ID: 99, UserID: 8, Name: "bob's", Query: "rust", Enabled: true},
+	}}
+	svc := &alertUC.Service{Users: stubUserRepo{}, Rules: rules}
+	// スコープ判定は auth パッケージでテスト済みのため、ハンドラを直接登録する。
+	mux := http.NewServeMux()
+	mux.Handle("GET /alerts", alert.ListHandler{Svc: svc})
+	mux.Handle("POST /alerts", alert.CreateHandler{Svc: svc})
+	mux.Handle("GET /alerts/{id}", alert.GetHandler{Svc: svc})
+	mux.Handle("PUT /alerts/{id}", alert.UpdateHandler{Svc: svc})
+	mux.Handle("DELETE /alerts/{id}", alert.DeleteHandler{Svc: svc})
+
+	serve := func(method, target, subject, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req = req.WithContext(auth.WithIdentity(req.Context(), subject, "viewer"))
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := serve(http.MethodPost, "/alerts", "alice@example.com", `{"name":"Go","query":"go -beta"}`)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var created alert.DTO
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+	assert.Equal(t, "go -beta", created.Query)
+	assert.True(t, created.Enabled)
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		subject  string
+		body     string
+		wantCode int
+	}{
+		{"invalid query", http.MethodPost, "/alerts", "alice@example.com", `{"name":"x","query":"go AND"}`, http.StatusBadRequest},
+		{"missing name", http.MethodPost, "/alerts", "alice@example.com", `{"query":"go"}`, http.StatusBadRequest},
+		{"malformed body", http.MethodPost, "/alerts", "alice@example.com", `{`, http.StatusBadRequest},
+		{"duplicate name", http.MethodPost, "/alerts", "alice@example.com", `{"name":"Go","query":"golang"}`, http.StatusConflict},
+		{"api key identity", http.MethodGet, "/alerts", "apikey:ci", "", http.StatusForbidden},
+		{"get own", http.MethodGet, "/alerts/1", "alice@example.com", "", http.StatusOK},
+		{"someone else's rule", http.MethodGet, "/alerts/99", "alice@example.com", "", http.StatusNotFound},
+		{"invalid id", http.MethodGet, "/alerts/0", "alice@example.com", "", http.StatusBadRequest},
+		{"disable", http.MethodPut, "/alerts/1", "alice@example.com", `{"name":"Go","query":"go","enabled":false}`, http.StatusOK},
+		{"update someone else's", http.MethodPut, "/alerts/99", "alice@example.com", `{"name":"x","query":"go"}`, http.StatusNotFound},
+		{"delete someone else's", http.MethodDelete, "/alerts/99", "alice@example.com", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := serve(tt.method, tt.target, tt.subject, tt.body)
+			assert.Equal(t, tt.wantCode, rr.Code, rr.Body.String())
+		})
+	}
+
+	rr = serve(http.MethodGet, "/alerts", "alice@example.com", "")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var got []alert.DTO
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	require.Len(t, got, 1)
+	assert.False(t, got[0].Enabled)
+
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodDelete, "/alerts/1", "alice@example.com", "").Code)
+	assert.NotContains(t, rules.rules, int64(1))
+	assert.Contains(t, rules.rules, int64(99))
+}
@@ -0,0 +1,21 @@
+package alert
+
+import (
+	"net/http"
+
+	"catchup-feed/internal/handler/http/auth"
+	alertUC "catchup-feed/internal/usecase/alert"
+)
+
+// Register registers the keyword alert routes (C-21 flat paths). Rules
+// only touch the caller's own state, so every route needs just
+// articles:read (auth.RequireScope; admins hold every scope).
+func Register(mux *http.ServeMux, svc *alertUC.Service) {
+	read := auth.RequireScope(auth.ScopeArticlesRead)
+
+	mux.Handle("GET /alerts", read(ListHandler{svc}))
+	mux.Handle("POST /alerts", read(CreateHandler{svc}))
+	mux.Handle("GET /alerts/{id}", read(GetHandler{svc}))
+	mux.Handle("PUT /alerts/{id}", read(UpdateHandler{svc}))
+	mux.Handle("DELETE /alerts/{id}", read(DeleteHandler{svc}))
+}
@@ -0,0 +1,30 @@
+package alert
+
+import (
+	"errors"
+	"net/http"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/respond"
+	alertUC "catchup-feed/internal/usecase/alert"
+)
+
+// respondUsecaseError maps use case errors to HTTP statuses: caller
+// without a user account → 403, unknown rule → 404, name collision → 409,
+// validation → 400, anything else → sanitized 500.
+func respondUsecaseError(w http.ResponseWriter, err error) {
+	var verr *entity.ValidationError
+	switch {
+	case errors.Is(err, alertUC.ErrAccountNotFound):
+		respond.SafeError(w, http.StatusForbidden, err)
+	case errors.Is(err, alertUC.ErrRuleNotFound):
+		respond.SafeError(w, http.StatusNotFound, err)
+	case errors.Is(err, alertUC.ErrRuleNameTaken):
+		respond.SafeError(w, http.StatusConflict, err)
+	case errors.Is(err, alertUC.ErrTooManyRules),
+		errors.As(err, &verr):
+		respond.SafeError(w, http.StatusBadRequest, err)
+	default:
+		respond.SafeError(w, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,144 @@
+package apikey
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"catchup-feed/internal/handler/http/respond"
+	apikeyUC "catchup-feed/internal/usecase/apikey"
+)
+
+type ListHandler struct{ Svc *apikeyUC.Service }
+
+// ServeHTTP API キー一覧取得
+// @Summary      API キー一覧取得
+// @Description  サービス間アクセス用の API キーを失効済みも含めてすべて取得します。
+// @Description  キー本体は返さず、識別用の key_prefix のみ返します。admin 専用
+// @Tags         api-keys
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {array} DTO "API キー一覧"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /api-keys [get]
+func (h ListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	list, err := h.Svc.List(r.Context())
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	out := make([]DTO, 0, len(list))
+	for _, k := range list {
+		out = append(out, toDTO(k))
+	}
+	respond.JSON(w, http.StatusOK, out)
+}
+
+type CreateHandler struct{ Svc *apikeyUC.Service }
+
+// ServeHTTP API キー発行
+// @Summary      API キー発行
+// @Description  API キーを発行します。レスポンスの key は平文で、この1回しか表示されません
+// @Description  (保存されるのは SHA-256 ハッシュのみ)。クライアントは X-API-Key ヘッダで送ります。
+// @Description  role は admin / viewer / AUTH_ROLES のカスタムロール、rate_limit は1分あたり(省略時 60)、daily_quota は
+// @Description  24時間あたり(null で無制限)の上限です。admin 専用
+// @Tags         api-keys
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        api_key body Request true "API キー情報(name / role 必須)"
+// @Success      201 {object} CreatedDTO "発行された API キー(key は平文、1回限り)"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - 入力が不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Router       /api-keys [post]
+func (h CreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	created, plaintext, err := h.Svc.Create(r.Context(), apikeyUC.CreateInput{
+		Name:       req.Name,
+		Role:       req.Role,
+		RateLimit:  req.RateLimit,
+		DailyQuota: req.DailyQuota,
+	})
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusCreated, CreatedDTO{DTO: toDTO(created), Key: plaintext})
+}
+
+type UpdateHandler struct{ Svc *apikeyUC.Service }
+
+// ServeHTTP API キー更新
+// @Summary      API キー更新
+// @Description  API キーの name / role / rate_limit / daily_quota を更新します(キー本体は変わりません)。admin 専用
+// @Tags         api-keys
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "API キー ID"
+// @Param        api_key body Request true "更新する API キー情報"
+// @Success      200 {object} DTO "更新後の API キー"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - 入力が不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      404 {object} respond.ErrorResponse "Not found - API キーが存在しない"
+// @Router       /api-keys/{id} [put]
+func (h UpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	updated, err := h.Svc.Update(r.Context(), id, apikeyUC.UpdateInput{
+		Name:       req.Name,
+		Role:       req.Role,
+		RateLimit:  req.RateLimit,
+		DailyQuota: req.DailyQuota,
+	})
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toDTO(updated))
+}
+
+type RevokeHandler struct{ Svc *apikeyUC.Service }
+
+// ServeHTTP API キー失効
+// @Summary      API キー失効
+// @Description  API キーを失効させます(revoked_at を記録、行は残す)。次のリクエストから 401 になります。
+// @Description  冪等。失効したキーは再有効化できないため、必要なら新しいキーを発行します。admin 専用
+// @Tags         api-keys
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "API キー ID"
+// @Success      200 {object} DTO "失効後の API キー"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid ID"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      404 {object} respond.ErrorResponse "Not found - API キーが存在しない"
+// @Router       /api-keys/{id} [delete]
+func (h RevokeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	revoked, err := h.Svc.Revoke(r.Context(), id)
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toDTO(revoked))
+}
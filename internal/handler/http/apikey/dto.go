@@ -0,0 +1,69 @@
+// Package apikey provides the API key management HTTP handlers: admin-only
+// issue / list / update / revoke of service-to-service keys, following the
+// flat-path convention (C-21: /api-keys, /api-keys/{id}).
+package apikey
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/pathutil"
+)
+
+// DTO mirrors the api_keys schema without key_hash. key_prefix identifies
+// the key; the plaintext is only ever in CreatedDTO.
+type DTO struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Role       string     `json:"role"`
+	KeyPrefix  string     `json:"key_prefix"`
+	RateLimit  int        `json:"rate_limit"`
+	DailyQuota *int       `json:"daily_quota"`
+	Active     bool       `json:"active"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+// CreatedDTO is the POST /api-keys response: the DTO plus the plaintext
+// key, shown this one time only.
+type CreatedDTO struct {
+	DTO
+	Key string `json:"key"`
+}
+
+func toDTO(k *entity.APIKey) DTO {
+	return DTO{
+		ID:         k.ID,
+		Name:       k.Name,
+		Role:       k.Role,
+		KeyPrefix:  k.KeyPrefix,
+		RateLimit:  k.RateLimit,
+		DailyQuota: k.DailyQuota,
+		Active:     !k.IsRevoked(),
+		CreatedAt:  k.CreatedAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+	}
+}
+
+// Request is the POST /api-keys and PUT /api-keys/{id} body. rate_limit is
+// requests per minute (omitted = 60); daily_quota is requests per 24 hours
+// (null = unlimited).
+type Request struct {
+	Name       string `json:"name" example:"ingest-bot"`
+	Role       string `json:"role" example:"viewer"`
+	RateLimit  int    `json:"rate_limit,omitempty" example:"60"`
+	DailyQuota *int   `json:"daily_quota,omitempty" example:"5000"`
+}
+
+// pathID extracts the positive integer {id} path value.
+func pathID(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, pathutil.ErrInvalidID
+	}
+	return id, nil
+}
@@ -0,0 +1,147 @@
+package apikey_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/apikey"
+	apikeyUC "catchup-feed/internal/usecase/apikey"
+)
+
+/* ───────── モック実装 ───────── */
+
+type stubKeyRepo struct{ keys map[int64]*entity.APIKey }
+
+func (s *stubKeyRepo) Create(_ context.Context, k *entity.APIKey) error {
+	k.ID = int64(len(s.keys) + 1)
+	k.CreatedAt = time.Now()
+	s.keys[k.ID] = k
+	return nil
+}
+
+func (s *stubKeyRepo) Get(_ context.Context, id int64) (*entity.APIKey, error) {
+	return s.keys[id], nil
+}
+
+func (s *stubKeyRepo) List(_ context.Context) ([]*entity.APIKey, error) {
+	out := make([]*entity.APIKey, 0, len(s.keys))
+	for id := int64(1); id <= int64(len(s.keys)); id++ {
+		if k, ok := s.keys[id]; ok {
+			out = append(out, k)
+		}
+	}
+	return out, nil
+}
+
+func (s *stubKeyRepo) Update(_ context.Context, k *entity.APIKey) error {
+	s.keys[k.ID] = k
+	return nil
+}
+
+func (s *stubKeyRepo) Revoke(_ context.Context, id int64, t time.Time) error {
+	if k, ok := s.keys[id]; ok && k.RevokedAt == nil {
+		k.RevokedAt = &t
+	}
+	return nil
+}
+
+func (s *stubKeyRepo) GetActiveByHash(_ context.Context, _ string) (*entity.APIKey, error) {
+	return nil, nil
+}
+
+func (s *stubKeyRepo) TouchLastUsed(_ context.Context, _ int64, _ time.Time) error { return nil }
+
+func newMux() (*http.ServeMux, *stubKeyRepo) {
+	repo := &stubKeyRepo{keys: map[int64]*entity.APIKey{}}
+	svc := &apikeyUC.Service{Keys: repo}
+	mux := http.NewServeMux()
+	// 認可ミドルウェアなしに Register と同じパターンで直接張る。
+	mux.Handle("GET /api-keys", apikey.ListHandler{Svc: svc})
+	mux.Handle("POST /api-keys", apikey.CreateHandler{Svc: svc})
+	mux.Handle("PUT /api-keys/{id}", apikey.UpdateHandler{Svc: svc})
+	mux.Handle("DELETE /api-keys/{id}", apikey.RevokeHandler{Svc: svc})
+	return mux, repo
+}
+
+func do(mux *http.ServeMux, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+/* ───────── テストケース ───────── */
+
+func TestCreateHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantCode int
+	}{
+		{name: "valid", body: `{"name":"bot","role":"viewer","daily_quota":100}`, wantCode: http.StatusCreated},
+		{name: "missing name", body: `{"role":"viewer"}`, wantCode: http.StatusBadRequest},
+		{name: "invalid role", body: `{"name":"bot","role":"root"}`, wantCode: http.StatusBadRequest},
+		{name: "invalid json", body: `{`, wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, _ := newMux()
+			rec := do(mux, http.MethodPost, "/api-keys", tt.body)
+			require.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantCode == http.StatusCreated {
+				var got apikey.CreatedDTO
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+				assert.True(t, strings.HasPrefix(got.Key, got.KeyPrefix))
+				assert.Equal(t, apikeyUC.DefaultRateLimit, got.RateLimit)
+				assert.True(t, got.Active)
+				assert.NotContains(t, rec.Body.String(), "key_hash")
+			}
+		})
+	}
+}
+
+func TestListHandler_NeverReturnsPlaintext(t *testing.T) {
+	mux, _ := newMux()
+	created := do(mux, http.MethodPost, "/api-keys", `{"name":"bot","role":"admin"}`)
+	var c apikey.CreatedDTO
+	require.NoError(t, json.Unmarshal(created.Body.Bytes(), &c))
+
+	rec := do(mux, http.MethodGet, "/api-keys", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), c.Key)
+
+	var got []apikey.DTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Len(t, got, 1)
+	assert.Equal(t, "bot", got[0].Name)
+}
+
+func TestUpdateAndRevokeHandlers(t *testing.T) {
+	mux, repo := newMux()
+	do(mux, http.MethodPost, "/api-keys", `{"name":"bot","role":"viewer"}`)
+
+	rec := do(mux, http.MethodPut, "/api-keys/1", `{"name":"bot-2","role":"admin","rate_limit":5}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 5, repo.keys[1].RateLimit)
+	assert.Equal(t, "admin", repo.keys[1].Role)
+
+	rec = do(mux, http.MethodDelete, "/api-keys/1", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var got apikey.DTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.False(t, got.Active)
+	assert.NotNil(t, got.RevokedAt)
+
+	assert.Equal(t, http.StatusNotFound, do(mux, http.MethodDelete, "/api-keys/9", "").Code)
+	assert.Equal(t, http.StatusBadRequest, do(mux, http.MethodDelete, "/api-keys/abc", "").Code)
+}
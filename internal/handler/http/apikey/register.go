@@ -0,0 +1,17 @@
+package apikey
+
+import (
+	"net/http"
+
+	"catchup-feed/internal/handler/http/auth"
+	apikeyUC "catchup-feed/internal/usecase/apikey"
+)
+
+// Register registers the API key management routes (C-21 flat paths).
+// Every route is wrapped in auth.Authz: key management is admin-only.
+func Register(mux *http.ServeMux, svc *apikeyUC.Service) {
+	mux.Handle("GET /api-keys", auth.Authz(ListHandler{svc}))
+	mux.Handle("POST /api-keys", auth.Authz(CreateHandler{svc}))
+	mux.Handle("PUT /api-keys/{id}", auth.Authz(UpdateHandler{svc}))
+	mux.Handle("DELETE /api-keys/{id}", auth.Authz(RevokeHandler{svc}))
+}
@@ -0,0 +1,25 @@
+package apikey
+
+import (
+	"errors"
+	"net/http"
+
+	"catchup-feed/internal/handler/http/respond"
+	apikeyUC "catchup-feed/internal/usecase/apikey"
+)
+
+// respondUsecaseError maps use case sentinel errors to HTTP statuses:
+// not-found → 404, validation → 400, anything else → sanitized 500.
+func respondUsecaseError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, apikeyUC.ErrKeyNotFound):
+		respond.SafeError(w, http.StatusNotFound, err)
+	case errors.Is(err, apikeyUC.ErrNameRequired),
+		errors.Is(err, apikeyUC.ErrInvalidRole),
+		errors.Is(err, apikeyUC.ErrInvalidRateLimit),
+		errors.Is(err, apikeyUC.ErrInvalidQuota):
+		respond.SafeError(w, http.StatusBadRequest, err)
+	default:
+		respond.SafeError(w, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,201 @@
+package article
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"catchup-feed/internal/handler/http/respond"
+	"catchup-feed/internal/repository"
+	artUC "catchup-feed/internal/usecase/article"
+)
+
+// Entry limits of GET /feed.xml.
+const (
+	atomDefaultLimit = 50
+	atomMaxLimit     = 200
+)
+
+// atomNS is the Atom 1.0 namespace (RFC 4287).
+const atomNS = "http://www.w3.org/2005/Atom"
+
+var errInvalidFeedLimit = errors.New("invalid limit: must be between 1 and 200")
+
+type AtomFeedHandler struct{ Svc artUC.Service }
+
+// ServeHTTP 記事の Atom フィード
+// @Summary      記事の Atom フィード
+// @Description  要約済みの新着記事を Atom 1.0 フィードとして返します（公開日時の新しい順）。
+// @Description  他の RSS リーダーから購読するためのエンドポイントです。要約待ちの記事は含まれません。
+// @Description  リーダーからは X-API-Key ヘッダ（articles:read）で認証してください。
+// @Description  admin 以外のアカウントには購読中のソースの記事のみ含まれます。
+// @Tags         articles
+// @Security     BearerAuth
+// @Produce      application/atom+xml
+// @Param        source_id query int false "ソースIDでフィルタ"
+// @Param        group_id query int false "ソースグループIDでフィルタ"
+// @Param        tag query string false "タグ名でフィルタ"
+// @Param        subscribed query bool false "true で呼び出し元ユーザーが購読中のソースの記事のみ(admin 以外のアカウントは常に購読中のソースに限定)"
+// @Param        limit query int false "件数（既定 50、最大 200）"
+// @Success      200 {string} string "Atom フィード"
+// @Failure      400 {object} respond.ErrorResponse "Bad request"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      500 {object} respond.ErrorResponse "Server error"
+// @Router       /feed.xml [get]
+func (h AtomFeedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		filters repository.ArticleSearchFilters
+		err     error
+	)
+	if filters.SourceID, err = parseSourceIDParam(r); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if filters.GroupID, err = parseGroupIDParam(r); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if filters.Tag, err = parseTagParam(r); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if filters.SubscribedBy, err = parseSubscribedParam(r); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	limit := atomDefaultLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > atomMaxLimit {
+			respond.SafeError(w, http.StatusBadRequest, errInvalidFeedLimit)
+			return
+		}
+	}
+
+	articles, err := h.Svc.Recent(r.Context(), filters, limit)
+	if err != nil {
+		respond.SafeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	var groupName string
+	if filters.GroupID != nil {
+		if groupName, err = h.Svc.GroupName(r.Context(), *filters.GroupID); err != nil {
+			respond.SafeError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	body, err := xml.MarshalIndent(buildAtomFeed(filters, groupName, articles, time.Now()), "", "  ")
+	if err != nil {
+		respond.SafeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(xml.Header))
+	_, _ = w.Write(body)
+}
+
+// atomFeed is the Atom 1.0 <feed> document.
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	NS      string      `xml:"xmlns,attr"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  atomPerson  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
+
+type atomText struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type atomEntry struct {
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Link      atomLink    `xml:"link"`
+	Updated   string      `xml:"updated"`
+	Published string      `xml:"published,omitempty"`
+	Author    *atomPerson `xml:"author,omitempty"`
+	Summary   atomText    `xml:"summary"`
+}
+
+// buildAtomFeed renders articles (newest first) as a feed. The feed ID
+// depends only on the filters, so a reader keeps one subscription per
+// filter combination; entry IDs are the article URLs, which are unique
+// and stable. The title names the filtered source and group (groupName,
+// "" when unknown). Entries are dated by published_at, falling back to
+// crawled_at; the feed is dated by its newest entry, or now when empty.
+func buildAtomFeed(filters repository.ArticleSearchFilters, groupName string, articles []repository.ArticleWithSource, now time.Time) atomFeed {
+	id, title := "urn:catchup-feed:articles", "catchup-feed"
+	if filters.SourceID != nil {
+		id += ":source:" + strconv.FormatInt(*filters.SourceID, 10)
+	}
+	if filters.GroupID != nil {
+		id += ":group:" + strconv.FormatInt(*filters.GroupID, 10)
+	}
+	if filters.SubscribedBy != nil {
+		id += ":subscribed"
+	}
+	if filters.Tag != nil {
+		id += ":tag:" + *filters.Tag
+		title += " #" + *filters.Tag
+	}
+	if filters.SourceID != nil && len(articles) > 0 {
+		title += " - " + articles[0].SourceName
+	}
+	if groupName != "" {
+		title += " - " + groupName
+	}
+
+	feed := atomFeed{
+		NS:      atomNS,
+		ID:      id,
+		Title:   title,
+		Updated: now.UTC().Format(time.RFC3339),
+		Author:  atomPerson{Name: "catchup-feed"},
+		Entries: make([]atomEntry, 0, len(articles)),
+	}
+	var newest time.Time
+	for _, item := range articles {
+		a := item.Article
+		updated := a.PublishedAt
+		if updated.IsZero() {
+			updated = a.CrawledAt
+		}
+		if updated.After(newest) {
+			newest = updated
+		}
+		entry := atomEntry{
+			ID:      a.URL,
+			Title:   a.Title,
+			Link:    atomLink{Href: a.URL, Rel: "alternate"},
+			Updated: updated.UTC().Format(time.RFC3339),
+			Summary: atomText{Type: "text", Body: a.Summary},
+		}
+		if !a.PublishedAt.IsZero() {
+			entry.Published = a.PublishedAt.UTC().Format(time.RFC3339)
+		}
+		if item.SourceName != "" {
+			entry.Author = &atomPerson{Name: item.SourceName}
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	if !newest.IsZero() {
+		feed.Updated = newest.UTC().Format(time.RFC3339)
+	}
+	return feed
+}
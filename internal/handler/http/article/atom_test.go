@@ -0,0 +1,200 @@
+package article_test
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"catchup-feed/internal/handler/http/article"
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/repository"
+	artUC "catchup-feed/internal/usecase/article"
+)
+
+/* ───────── ヘルパー ───────── */
+
+// atomDoc は検証に必要な Atom の要素だけを読む。
+type atomDoc struct {
+	ID      string `xml:"id"`
+	Title   string `xml:"title"`
+	Updated string `xml:"updated"`
+	Entries []struct {
+		ID        string `xml:"id"`
+		Title     string `xml:"title"`
+		Updated   string `xml:"updated"`
+		Published string `xml:"published"`
+		Author    string `xml:"author>name"`
+		Summary   string `xml:"summary"`
+		Link      struct {
+			Href string `xml:"href,attr"`
+		} `xml:"link"`
+	} `xml:"entry"`
+}
+
+/* ───────── テストケース ───────── */
+
+func TestAtomFeedHandler(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubSearchPaginatedRepo{articlesWithSrc: exportArticles()}
+	req := httptest.NewRequest(http.MethodGet, "/feed.xml?source_id=10&tag=Go&limit=5", nil)
+	rr := httptest.NewRecorder()
+	article.AtomFeedHandler{Svc: artUC.Service{Repo: stub}}.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d: %s", rr.Code, http.StatusOK, rr.Body.String())
+	}
+	if ct := rr.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/atom+xml") {
+		t.Errorf("Content-Type = %q, want application/atom+xml", ct)
+	}
+	var doc atomDoc
+	if err := xml.Unmarshal(rr.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("body is not XML: %v\n%s", err, rr.Body.String())
+	}
+	if doc.ID != "urn:catchup-feed:articles:source:10:tag:go" || doc.Title != "catchup-feed #go - Go Blog" {
+		t.Errorf("feed id = %q, title = %q", doc.ID, doc.Title)
+	}
+	if doc.Updated != "2026-10-01T09:00:00Z" {
+		t.Errorf("feed updated = %q, want the newest entry", doc.Updated)
+	}
+	if len(doc.Entries) != 2 {
+		t.Fatalf("entries = %d, want 2", len(doc.Entries))
+	}
+	first := doc.Entries[0]
+	if first.ID != "https://example.com/2" || first.Link.Href != "https://example.com/2" ||
+		first.Summary != "要約" || first.Author != "Go Blog" || first.Published != "2026-10-01T09:00:00Z" {
+		t.Errorf("entry = %+v", first)
+	}
+	// 公開日時のない記事は crawled_at で日付を付け、published は出さない
+	if second := doc.Entries[1]; second.Published != "" || second.Updated != "2026-10-01T09:00:00Z" {
+		t.Errorf("undated entry = %+v", second)
+	}
+
+	// 要約済みの記事だけを、指定した条件で取得する
+	f := stub.lastFilters
+	if !f.Summarized || f.SourceID == nil || *f.SourceID != 10 || f.Tag == nil || *f.Tag != "go" {
+		t.Errorf("filters = %+v, want summarized articles of source 10 tagged go", f)
+	}
+}
+
+// stubGroupRepo は Get だけを使う。
+type stubGroupRepo struct {
+	repository.SourceGroupRepository
+	group *entity.SourceGroup
+}
+
+func (s stubGroupRepo) Get(_ context.Context, id int64) (*entity.SourceGroup, error) {
+	if s.group != nil && s.group.ID == id {
+		return s.group, nil
+	}
+	return nil, nil
+}
+
+// TestAtomFeedHandler_Group: group_id で絞ったフィードは ID とタイトルにも
+// グループが入る(ソース指定と同じ扱い)。
+func TestAtomFeedHandler_Group(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		query     string
+		wantID    string
+		wantTitle string
+	}{
+		{"?group_id=4", "urn:catchup-feed:articles:group:4", "catchup-feed - Go"},
+		{"?group_id=4&tag=Go", "urn:catchup-feed:articles:group:4:tag:go", "catchup-feed #go - Go"},
+		{"?group_id=9", "urn:catchup-feed:articles:group:9", "catchup-feed"}, // 存在しないグループ
+	}
+	for _, tt := range tests {
+		stub := &stubSearchPaginatedRepo{articlesWithSrc: exportArticles()}
+		svc := artUC.Service{Repo: stub, Groups: stubGroupRepo{group: &entity.SourceGroup{ID: 4, Name: "Go"}}}
+		rr := httptest.NewRecorder()
+		article.AtomFeedHandler{Svc: svc}.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/feed.xml"+tt.query, nil))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: status code = %d, want %d", tt.query, rr.Code, http.StatusOK)
+		}
+		var doc atomDoc
+		if err := xml.Unmarshal(rr.Body.Bytes(), &doc); err != nil {
+			t.Fatalf("%s: body is not XML: %v", tt.query, err)
+		}
+		if doc.ID != tt.wantID || doc.Title != tt.wantTitle {
+			t.Errorf("%s: feed id = %q, title = %q, want %q, %q", tt.query, doc.ID, doc.Title, tt.wantID, tt.wantTitle)
+		}
+	}
+}
+
+// TestAtomFeedHandler_SubscriptionScope: admin 以外のアカウントのフィードは
+// 購読中のソースに絞られ、フィード ID も別になる。
+func TestAtomFeedHandler_SubscriptionScope(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubSearchPaginatedRepo{articlesWithSrc: exportArticles()}
+	req := httptest.NewRequest(http.MethodGet, "/feed.xml", nil)
+	req = req.WithContext(auth.WithIdentity(req.Context(), "alice@example.com", "editor"))
+	rr := httptest.NewRecorder()
+	article.AtomFeedHandler{Svc: artUC.Service{Repo: stub}}.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	if got := stub.lastFilters.SubscribedBy; got == nil || *got != "alice@example.com" {
+		t.Errorf("SubscribedBy filter = %v, want alice@example.com", got)
+	}
+	var doc atomDoc
+	if err := xml.Unmarshal(rr.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("body is not XML: %v", err)
+	}
+	if doc.ID != "urn:catchup-feed:articles:subscribed" {
+		t.Errorf("feed id = %q", doc.ID)
+	}
+}
+
+func TestAtomFeedHandler_Empty(t *testing.T) {
+	t.Parallel()
+
+	rr := httptest.NewRecorder()
+	article.AtomFeedHandler{Svc: artUC.Service{Repo: &stubSearchPaginatedRepo{}}}.
+		ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/feed.xml", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	var doc atomDoc
+	if err := xml.Unmarshal(rr.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("body is not XML: %v", err)
+	}
+	if doc.ID != "urn:catchup-feed:articles" || doc.Updated == "" || len(doc.Entries) != 0 {
+		t.Errorf("feed = %+v, want an empty feed dated now", doc)
+	}
+}
+
+func TestAtomFeedHandler_BadRequest(t *testing.T) {
+	t.Parallel()
+
+	for _, query := range []string{"source_id=abc", "source_id=0", "limit=0", "limit=201", "limit=x"} {
+		rr := httptest.NewRecorder()
+		article.AtomFeedHandler{Svc: artUC.Service{Repo: &stubSearchPaginatedRepo{}}}.
+			ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/feed.xml?"+query, nil))
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: status code = %d, want %d", query, rr.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestAtomFeedHandler_Error(t *testing.T) {
+	t.Parallel()
+
+	rr := httptest.NewRecorder()
+	article.AtomFeedHandler{Svc: artUC.Service{Repo: &stubSearchPaginatedRepo{searchErr: errors.New("db down")}}}.
+		ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/feed.xml", nil))
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Errorf("status code = %d, want %d", rr.Code, http.StatusInternalServerError)
+	}
+}
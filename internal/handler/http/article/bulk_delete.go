@@ -0,0 +1,45 @@
+package article
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"catchup-feed/internal/handler/http/respond"
+	artUC "catchup-feed/internal/usecase/article"
+)
+
+type BulkDeleteHandler struct{ Svc artUC.Service }
+
+// ServeHTTP 記事一括削除
+// @Summary      記事一括削除
+// @Description  指定した記事（最大500件）を1トランザクションで削除し、ID ごとの結果（deleted / not_found）を返します。
+// @Description  存在しない ID はエラーにせず not_found として報告します。
+// @Tags         articles
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body BulkDeleteRequest true "削除する記事 ID のリスト"
+// @Success      200 {object} BulkDeleteResponse "ID ごとの削除結果"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - article_ids が空・501件以上・正でない"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required - missing or invalid JWT token"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - articles:write が必要"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /articles/bulk-delete [post]
+func (h BulkDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req BulkDeleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	deleted, err := h.Svc.DeleteBatch(r.Context(), req.ArticleIDs)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, artUC.ErrInvalidArticleIDs) {
+			code = http.StatusBadRequest
+		}
+		respond.SafeError(w, code, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toBulkDeleteResponse(req.ArticleIDs, deleted))
+}
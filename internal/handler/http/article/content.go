@@ -0,0 +1,87 @@
+package article
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/handler/http/pathutil"
+	"catchup-feed/internal/handler/http/respond"
+	artUC "catchup-feed/internal/usecase/article"
+)
+
+// ContentDTO is the page the crawler fetched for an article: the final URL
+// after redirects, the readability text and the raw HTML.
+type ContentDTO struct {
+	ArticleID int64     `json:"article_id" example:"1"`
+	URL       string    `json:"url" example:"https://example.com/article/1"`
+	Text      string    `json:"text" example:"Go 1.23 がリリースされました。..."`
+	HTML      string    `json:"html" example:"<!DOCTYPE html><html>...</html>"`
+	FetchedAt time.Time `json:"fetched_at" example:"2025-10-26T12:00:00Z"`
+}
+
+type ContentHandler struct{ Svc artUC.Service }
+
+// ServeHTTP 記事本文取得
+// @Summary      記事本文取得
+// @Description  クロール時に取得した記事ページ(リダイレクト後の URL・抽出テキスト・生 HTML)を返します。
+// @Description  RSS の本文で足りた記事や取得に失敗した記事には保存されておらず 404 になります。admin 以外のアカウントが購読していないソースの記事も 404 です。
+// @Tags         articles
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "記事ID"
+// @Success      200 {object} ContentDTO "記事本文"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid article ID"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - articles:read が必要"
+// @Failure      404 {object} respond.ErrorResponse "Not found - article or article content not found"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /articles/{id}/content [get]
+func (h ContentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		respond.SafeError(w, http.StatusBadRequest, pathutil.ErrInvalidID)
+		return
+	}
+
+	if auth.SubscriptionScoped(r.Context()) {
+		article, err := h.Svc.Get(r.Context(), id)
+		if err != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, artUC.ErrArticleNotFound) {
+				code = http.StatusNotFound
+			}
+			respond.SafeError(w, code, err)
+			return
+		}
+		hidden, err := hiddenBySubscription(r, h.Svc, article.SourceID)
+		if err != nil {
+			respond.SafeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if hidden {
+			respond.SafeError(w, http.StatusNotFound, artUC.ErrArticleNotFound)
+			return
+		}
+	}
+
+	content, err := h.Svc.Content(r.Context(), id)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, artUC.ErrArticleNotFound) || errors.Is(err, artUC.ErrArticleContentNotFound) {
+			code = http.StatusNotFound
+		}
+		respond.SafeError(w, code, err)
+		return
+	}
+
+	respond.JSON(w, http.StatusOK, ContentDTO{
+		ArticleID: content.ArticleID,
+		URL:       content.URL,
+		Text:      content.Text,
+		HTML:      content.HTML,
+		FetchedAt: content.FetchedAt,
+	})
+}
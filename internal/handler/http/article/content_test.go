@@ -0,0 +1,107 @@
+package article_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/article"
+	artUC "catchup-feed/internal/usecase/article"
+)
+
+/* ───────── モック実装 ───────── */
+
+// stubContentArticleRepo は Get で記事を返す(stubGetRepo は Get が常に nil)。
+type stubContentArticleRepo struct {
+	stubGetRepo
+}
+
+func (s *stubContentArticleRepo) Get(_ context.Context, id int64) (*entity.Article, error) {
+	if s.article != nil && s.article.ID == id {
+		return s.article, nil
+	}
+	return nil, nil
+}
+
+type stubArticleContentRepo struct {
+	content *entity.ArticleContent
+	err     error
+}
+
+func (s *stubArticleContentRepo) Save(_ context.Context, _ *entity.ArticleContent) error {
+	return nil
+}
+
+func (s *stubArticleContentRepo) Get(_ context.Context, id int64) (*entity.ArticleContent, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.content != nil && s.content.ArticleID == id {
+		return s.content, nil
+	}
+	return nil, nil
+}
+
+func newContentMux(contents *stubArticleContentRepo) *http.ServeMux {
+	repo := &stubContentArticleRepo{stubGetRepo{article: &entity.Article{ID: 1, Title: "Go"}}}
+	mux := http.NewServeMux()
+	mux.Handle("GET /articles/{id}/content", article.ContentHandler{Svc: artUC.Service{Repo: repo, Contents: contents}})
+	return mux
+}
+
+/* ───────── テストケース ───────── */
+
+func TestContentHandler_Success(t *testing.T) {
+	fetchedAt := time.Date(2025, 10, 26, 12, 0, 0, 0, time.UTC)
+	mux := newContentMux(&stubArticleContentRepo{content: &entity.ArticleContent{
+		ArticleID: 1,
+		URL:       "https://example.com/go",
+		HTML:      "<html><p>Go</p></html>",
+		Text:      "Go",
+		FetchedAt: fetchedAt,
+	}})
+
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/articles/1/content", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d; body=%s", rr.Code, http.StatusOK, rr.Body.String())
+	}
+	var got article.ContentDTO
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	want := article.ContentDTO{ArticleID: 1, URL: "https://example.com/go", Text: "Go", HTML: "<html><p>Go</p></html>", FetchedAt: fetchedAt}
+	if got != want {
+		t.Errorf("response = %+v, want %+v", got, want)
+	}
+}
+
+func TestContentHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		contents *stubArticleContentRepo
+		wantCode int
+	}{
+		{"invalid id", "/articles/abc/content", &stubArticleContentRepo{}, http.StatusBadRequest},
+		{"zero id", "/articles/0/content", &stubArticleContentRepo{}, http.StatusBadRequest},
+		{"article not found", "/articles/2/content", &stubArticleContentRepo{}, http.StatusNotFound},
+		{"content not stored", "/articles/1/content", &stubArticleContentRepo{}, http.StatusNotFound},
+		{"repository error", "/articles/1/content", &stubArticleContentRepo{err: errors.New("db down")}, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			newContentMux(tt.contents).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rr.Code != tt.wantCode {
+				t.Errorf("status code = %d, want %d", rr.Code, tt.wantCode)
+			}
+		})
+	}
+}
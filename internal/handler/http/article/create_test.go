@@ -46,12 +46,22 @@ func (s *stubCreateRepo) Update(_ context.Context, _ *entity.Article) error {
 func (s *stubCreateRepo) Delete(_ context.Context, _ int64) error {
 	return nil
 }
+func (s *stubCreateRepo) DeleteBatch(_ context.Context, _ []int64) ([]int64, error) {
+	return nil, nil
+}
+func (s *stubCreateRepo) ForEachWithSource(_ context.Context, _ []string, _ repository.ArticleSearchFilters, _ func(repository.ArticleWithSource) error) error {
+	return nil
+}
 func (s *stubCreateRepo) ExistsByURL(_ context.Context, _ string) (bool, error) {
 	return false, nil
 }
 func (s *stubCreateRepo) ExistsByURLBatch(_ context.Context, _ []string) (map[string]bool, error) {
 	return nil, nil
 }
+
+func (s *stubCreateRepo) ExistsByContentHashBatch(_ context.Context, _ []string) (map[string]bool, error) {
+	return map[string]bool{}, nil
+}
 func (s *stubCreateRepo) GetWithSource(_ context.Context, _ int64) (*entity.Article, string, error) {
 	return nil, "", nil
 }
@@ -71,6 +81,10 @@ func (s *stubCreateRepo) SearchWithFiltersPaginated(_ context.Context, _ []strin
 	return nil, nil
 }
 
+func (s *stubCreateRepo) ListWithSourceAfter(_ context.Context, _ []string, _ repository.ArticleSearchFilters, _ *repository.ArticleCursor, _ int) ([]repository.ArticleWithSource, error) {
+	return nil, nil
+}
+
 func TestCreateHandler_Success(t *testing.T) {
 	stub := &stubCreateRepo{}
 	handler := article.CreateHandler{Svc: artUC.Service{Repo: stub}}
@@ -191,6 +205,9 @@ func TestCreateHandler_ServiceError(t *testing.T) {
 func (s *stubCreateRepo) CreateWithSummary(_ context.Context, _ *entity.Article, _ *entity.Summary) error {
 	return nil
 }
+func (s *stubCreateRepo) CreateBatch(_ context.Context, _ []repository.NewArticle) (int, error) {
+	return 0, nil
+}
 func (s *stubCreateRepo) CreateWithTranscribeJob(_ context.Context, _ *entity.Article, _, _ string) error {
 	return nil
 }
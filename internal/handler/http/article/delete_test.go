@@ -2,9 +2,12 @@ package article_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 
 	"catchup-feed/internal/domain/entity"
@@ -17,6 +20,8 @@ type stubDeleteRepo struct {
 	deleteErr error
 	deleted   bool
 	deletedID int64
+	existing  map[int64]bool // DeleteBatch が削除できる ID
+	batchIDs  []int64
 }
 
 func (s *stubDeleteRepo) Delete(_ context.Context, id int64) error {
@@ -28,6 +33,24 @@ func (s *stubDeleteRepo) Delete(_ context.Context, id int64) error {
 	return nil
 }
 
+func (s *stubDeleteRepo) DeleteBatch(_ context.Context, ids []int64) ([]int64, error) {
+	if s.deleteErr != nil {
+		return nil, s.deleteErr
+	}
+	s.batchIDs = ids
+	deleted := []int64{}
+	for _, id := range ids {
+		if s.existing[id] {
+			deleted = append(deleted, id)
+		}
+	}
+	return deleted, nil
+}
+
+func (s *stubDeleteRepo) ForEachWithSource(_ context.Context, _ []string, _ repository.ArticleSearchFilters, _ func(repository.ArticleWithSource) error) error {
+	return nil
+}
+
 // 以下は未使用だが、インターフェース満たすために実装
 func (s *stubDeleteRepo) List(_ context.Context) ([]*entity.Article, error) {
 	return nil, nil
@@ -56,6 +79,10 @@ func (s *stubDeleteRepo) ExistsByURL(_ context.Context, _ string) (bool, error)
 func (s *stubDeleteRepo) ExistsByURLBatch(_ context.Context, _ []string) (map[string]bool, error) {
 	return nil, nil
 }
+
+func (s *stubDeleteRepo) ExistsByContentHashBatch(_ context.Context, _ []string) (map[string]bool, error) {
+	return map[string]bool{}, nil
+}
 func (s *stubDeleteRepo) GetWithSource(_ context.Context, _ int64) (*entity.Article, string, error) {
 	return nil, "", nil
 }
@@ -75,6 +102,10 @@ func (s *stubDeleteRepo) SearchWithFiltersPaginated(_ context.Context, _ []strin
 	return nil, nil
 }
 
+func (s *stubDeleteRepo) ListWithSourceAfter(_ context.Context, _ []string, _ repository.ArticleSearchFilters, _ *repository.ArticleCursor, _ int) ([]repository.ArticleWithSource, error) {
+	return nil, nil
+}
+
 func TestDeleteHandler_Success(t *testing.T) {
 	stub := &stubDeleteRepo{}
 	handler := article.DeleteHandler{Svc: artUC.Service{Repo: stub}}
@@ -152,9 +183,72 @@ func TestDeleteHandler_DeleteError(t *testing.T) {
 	}
 }
 
+func TestBulkDeleteHandler_Success(t *testing.T) {
+	stub := &stubDeleteRepo{existing: map[int64]bool{1: true, 3: true}}
+	handler := article.BulkDeleteHandler{Svc: artUC.Service{Repo: stub}}
+
+	req := httptest.NewRequest(http.MethodPost, "/articles/bulk-delete",
+		strings.NewReader(`{"article_ids":[3,2,3,1]}`))
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	var got article.BulkDeleteResponse
+	if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	want := []article.BulkResult{{ID: 3, Result: "deleted"}, {ID: 2, Result: "not_found"}, {ID: 1, Result: "deleted"}}
+	if got.Deleted != 2 || !reflect.DeepEqual(got.Results, want) {
+		t.Errorf("response = %+v, want deleted 2 and results %+v", got, want)
+	}
+	if !reflect.DeepEqual(stub.batchIDs, []int64{3, 2, 1}) {
+		t.Errorf("DeleteBatch ids = %v, want [3 2 1]", stub.batchIDs)
+	}
+}
+
+func TestBulkDeleteHandler_BadRequest(t *testing.T) {
+	for name, body := range map[string]string{
+		"empty":     `{"article_ids":[]}`,
+		"negative":  `{"article_ids":[-1]}`,
+		"malformed": `{"article_ids":`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			handler := article.BulkDeleteHandler{Svc: artUC.Service{Repo: &stubDeleteRepo{}}}
+			req := httptest.NewRequest(http.MethodPost, "/articles/bulk-delete", strings.NewReader(body))
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("status code = %d, want %d", rr.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+func TestBulkDeleteHandler_DeleteError(t *testing.T) {
+	stub := &stubDeleteRepo{deleteErr: errors.New("database error")}
+	handler := article.BulkDeleteHandler{Svc: artUC.Service{Repo: stub}}
+
+	req := httptest.NewRequest(http.MethodPost, "/articles/bulk-delete", strings.NewReader(`{"article_ids":[1]}`))
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusInternalServerError)
+	}
+}
+
 func (s *stubDeleteRepo) CreateWithSummary(_ context.Context, _ *entity.Article, _ *entity.Summary) error {
 	return nil
 }
+func (s *stubDeleteRepo) CreateBatch(_ context.Context, _ []repository.NewArticle) (int, error) {
+	return 0, nil
+}
 func (s *stubDeleteRepo) CreateWithTranscribeJob(_ context.Context, _ *entity.Article, _, _ string) error {
 	return nil
 }
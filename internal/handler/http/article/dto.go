@@ -2,7 +2,13 @@
 // It includes handlers for creating, listing, searching, updating, and deleting articles.
 package article
 
-import "time"
+import (
+	"context"
+	"time"
+
+	"catchup-feed/internal/handler/http/auth"
+	artUC "catchup-feed/internal/usecase/article"
+)
 
 // DTO represents the JSON structure for article data transfer.
 // Summary comes from the summaries table (empty until the crawl pipeline
@@ -12,7 +18,8 @@ import "time"
 // 含めない: ダッシュボードはタイトル+要約しか表示せず、一覧系エンドポイント
 // で全文を返すとペイロードが桁で膨らむため。Create/Update のリクエストが
 // content を受けるのはパイプライン外から記事を投入する管理経路のためで、
-// 応答との非対称は仕様。全文が必要になったら専用エンドポイントを検討する。
+// 応答との非対称は仕様。クロール時に取得したページ全文は
+// GET /articles/{id}/content (ContentDTO) で返す。
 type DTO struct {
 	ID          int64     `json:"id" example:"1"`
 	SourceID    int64     `json:"source_id" example:"1"`
@@ -22,6 +29,27 @@ type DTO struct {
 	Summary     string    `json:"summary" example:"Go 1.23 がリリースされました。新機能には..."`
 	PublishedAt time.Time `json:"published_at" example:"2025-10-26T10:00:00Z"`
 	CrawledAt   time.Time `json:"crawled_at" example:"2025-10-26T12:00:00Z"`
+	// ImageURL is the page's og:image / twitter:image thumbnail, captured
+	// when the crawler fetched the article body; omitted when it had none.
+	ImageURL string `json:"image_url,omitempty" example:"https://example.com/og.png"`
+	// Favorited reports whether the caller has starred the article.
+	Favorited bool `json:"favorited" example:"false"`
+}
+
+// markFavorited sets Favorited on dtos for the authenticated caller.
+func markFavorited(ctx context.Context, svc artUC.Service, dtos []DTO) error {
+	ids := make([]int64, len(dtos))
+	for i, d := range dtos {
+		ids[i] = d.ID
+	}
+	favorited, err := svc.FavoritedIDs(ctx, auth.SubjectFromContext(ctx), ids)
+	if err != nil {
+		return err
+	}
+	for i := range dtos {
+		dtos[i].Favorited = favorited[dtos[i].ID]
+	}
+	return nil
 }
 
 // CreateRequest is the POST /articles body (パイプライン外から記事を投入する
@@ -45,3 +73,42 @@ type UpdateRequest struct {
 	// PublishedAt is an RFC 3339 timestamp.
 	PublishedAt *string `json:"published_at,omitempty" example:"2025-10-26T10:00:00Z"`
 }
+
+// BulkDeleteRequest is the POST /articles/bulk-delete body.
+type BulkDeleteRequest struct {
+	ArticleIDs []int64 `json:"article_ids" example:"1,2,3"`
+}
+
+// BulkResult is the outcome for one requested article ID: "deleted" or
+// "not_found".
+type BulkResult struct {
+	ID     int64  `json:"id" example:"1"`
+	Result string `json:"result" example:"deleted"`
+}
+
+// BulkDeleteResponse reports the per-ID outcome, in request order.
+type BulkDeleteResponse struct {
+	Deleted int          `json:"deleted" example:"2"`
+	Results []BulkResult `json:"results"`
+}
+
+func toBulkDeleteResponse(requested, deleted []int64) BulkDeleteResponse {
+	done := make(map[int64]bool, len(deleted))
+	for _, id := range deleted {
+		done[id] = true
+	}
+	resp := BulkDeleteResponse{Deleted: len(deleted), Results: make([]BulkResult, 0, len(requested))}
+	seen := make(map[int64]bool, len(requested))
+	for _, id := range requested {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result := "not_found"
+		if done[id] {
+			result = "deleted"
+		}
+		resp.Results = append(resp.Results, BulkResult{ID: id, Result: result})
+	}
+	return resp
+}
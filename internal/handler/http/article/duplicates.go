@@ -0,0 +1,74 @@
+package article
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/handler/http/pathutil"
+	"catchup-feed/internal/handler/http/respond"
+	artUC "catchup-feed/internal/usecase/article"
+)
+
+type DuplicatesHandler struct{ Svc artUC.Service }
+
+// ServeHTTP 近似重複グループ取得
+// @Summary      近似重複グループ取得
+// @Description  複数ソースが配信した同一記事(SimHash による近似重複)のグループを返します。先頭がグループの元記事(最初に取得された記事)で、以降が他ソースの重複記事です。
+// @Description  重複のない記事は自身のみのグループになります。admin 以外のアカウントには購読中のソースの記事のみ含まれ、指定した記事が購読外なら 404 です。
+// @Tags         articles
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "記事ID(グループ内のどの記事でも可)"
+// @Success      200 {array} DTO "近似重複グループ"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid article ID"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - articles:read が必要"
+// @Failure      404 {object} respond.ErrorResponse "Not found - article not found"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /articles/{id}/duplicates [get]
+func (h DuplicatesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		respond.SafeError(w, http.StatusBadRequest, pathutil.ErrInvalidID)
+		return
+	}
+
+	group, err := h.Svc.Duplicates(r.Context(), id)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, artUC.ErrArticleNotFound) {
+			code = http.StatusNotFound
+		}
+		respond.SafeError(w, code, err)
+		return
+	}
+
+	// A subscription-scoped caller sees the members from its sources, and
+	// a 404 when the requested article is not one of them.
+	var sources map[int64]bool
+	if auth.SubscriptionScoped(r.Context()) {
+		sources, err = h.Svc.SubscribedSourceIDs(r.Context(), auth.SubjectFromContext(r.Context()))
+		if err != nil {
+			respond.SafeError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	dtos := make([]DTO, 0, len(group))
+	for _, item := range group {
+		if sources != nil && !sources[item.Article.SourceID] {
+			if item.Article.ID == id {
+				respond.SafeError(w, http.StatusNotFound, artUC.ErrArticleNotFound)
+				return
+			}
+			continue
+		}
+		dtos = append(dtos, withSourceDTO(item))
+	}
+	if err := markFavorited(r.Context(), h.Svc, dtos); err != nil {
+		respond.SafeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, dtos)
+}
@@ -0,0 +1,100 @@
+package article_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/article"
+	"catchup-feed/internal/repository"
+	artUC "catchup-feed/internal/usecase/article"
+)
+
+/* ───────── モック実装 ───────── */
+
+type stubSimilarityRepo struct {
+	group []repository.ArticleWithSource
+	err   error
+}
+
+func (s *stubSimilarityRepo) FindNearDuplicate(context.Context, uint64, int, time.Time, int64) (int64, error) {
+	return 0, nil
+}
+
+func (s *stubSimilarityRepo) ListGroup(context.Context, int64) ([]repository.ArticleWithSource, error) {
+	return s.group, s.err
+}
+
+func newDuplicatesMux(similarity repository.ArticleSimilarityRepository) *http.ServeMux {
+	repo := &stubGetRepo{article: &entity.Article{ID: 2, SourceID: 20, Title: "Go 1.26 (copy)"}, sourceName: "Mirror"}
+	mux := http.NewServeMux()
+	mux.Handle("GET /articles/{id}/duplicates", article.DuplicatesHandler{Svc: artUC.Service{Repo: repo, Similarity: similarity}})
+	return mux
+}
+
+/* ───────── テストケース ───────── */
+
+func TestDuplicatesHandler_Group(t *testing.T) {
+	mux := newDuplicatesMux(&stubSimilarityRepo{group: []repository.ArticleWithSource{
+		{Article: &entity.Article{ID: 1, SourceID: 10, Title: "Go 1.26"}, SourceName: "Go Blog"},
+		{Article: &entity.Article{ID: 2, SourceID: 20, Title: "Go 1.26 (copy)"}, SourceName: "Mirror"},
+	}})
+
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/articles/2/duplicates", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d; body=%s", rr.Code, http.StatusOK, rr.Body.String())
+	}
+	var got []article.DTO
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != 1 || got[0].SourceName != "Go Blog" || got[1].ID != 2 {
+		t.Errorf("group = %+v, want the canonical article 1 then 2", got)
+	}
+}
+
+func TestDuplicatesHandler_WithoutSimilarityRepoIsStandalone(t *testing.T) {
+	rr := httptest.NewRecorder()
+	newDuplicatesMux(nil).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/articles/2/duplicates", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	var got []article.DTO
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != 2 || got[0].SourceName != "Mirror" {
+		t.Errorf("group = %+v, want only article 2", got)
+	}
+}
+
+func TestDuplicatesHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		similarity *stubSimilarityRepo
+		wantCode   int
+	}{
+		{"invalid id", "/articles/abc/duplicates", &stubSimilarityRepo{}, http.StatusBadRequest},
+		{"zero id", "/articles/0/duplicates", &stubSimilarityRepo{}, http.StatusBadRequest},
+		{"article not found", "/articles/9/duplicates", &stubSimilarityRepo{}, http.StatusNotFound},
+		{"repository error", "/articles/2/duplicates", &stubSimilarityRepo{err: errors.New("db down")}, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			newDuplicatesMux(tt.similarity).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rr.Code != tt.wantCode {
+				t.Errorf("status code = %d, want %d", rr.Code, tt.wantCode)
+			}
+		})
+	}
+}
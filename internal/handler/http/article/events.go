@@ -0,0 +1,101 @@
+package article
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/handler/http/respond"
+	artUC "catchup-feed/internal/usecase/article"
+)
+
+// eventsHeartbeat is how often an idle stream sends a comment line, so
+// proxies do not close it and a dead client is noticed.
+const eventsHeartbeat = 30 * time.Second
+
+type EventsHandler struct{ Svc artUC.Service }
+
+// ServeHTTP 記事イベントストリーム
+// @Summary      記事イベントストリーム(SSE)
+// @Description  新しい記事が保存されるたびに Server-Sent Events で通知します(event: article.created)。data は {"type","article_id","source_id"} の JSON で、記事本体は GET /articles/{id} で取得します。
+// @Description  接続中のイベントのみ届きます(再接続までの間のイベントは再送されません)。30 秒ごとにコメント行(ハートビート)を送ります。
+// @Description  admin 以外のアカウントには接続時点で購読中のソースの記事のみ届きます(購読の変更は再接続後に反映)。
+// @Tags         articles
+// @Security     BearerAuth
+// @Produce      text/event-stream
+// @Success      200 {string} string "イベントストリーム"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - articles:read が必要"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Failure      503 {object} respond.ErrorResponse "Service unavailable - event stream not configured"
+// @Router       /articles/events [get]
+func (h EventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Subscription-scoped callers only hear about their sources, as of
+	// the time they connect.
+	var sources map[int64]bool
+	if auth.SubscriptionScoped(r.Context()) {
+		var err error
+		sources, err = h.Svc.SubscribedSourceIDs(r.Context(), auth.SubjectFromContext(r.Context()))
+		if err != nil {
+			respond.SafeError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	events, cancel, err := h.Svc.SubscribeEvents()
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, artUC.ErrEventStreamUnavailable) {
+			code = http.StatusServiceUnavailable
+		}
+		respond.SafeError(w, code, err)
+		return
+	}
+	defer cancel()
+
+	rc := http.NewResponseController(w)
+	// The stream outlives any server WriteTimeout (HTTP_WRITE_TIMEOUT).
+	_ = rc.SetWriteDeadline(time.Time{})
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // nginx: do not buffer the stream
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprint(w, ": connected\n\n"); err != nil {
+		return
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(eventsHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+			if sources != nil && !sources[ev.SourceID] {
+				continue
+			}
+			data, err := json.Marshal(ev)
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.Type, data); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package article_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/article"
+	"catchup-feed/internal/handler/http/auth"
+	artUC "catchup-feed/internal/usecase/article"
+)
+
+/* ───────── テストケース ───────── */
+
+func TestEventsHandler_StreamsArticleEvents(t *testing.T) {
+	bus := artUC.NewEventBus()
+	srv := httptest.NewServer(article.EventsHandler{Svc: artUC.Service{Stream: bus}})
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	// ": connected" の時点で購読は登録済み
+	if line, _ := reader.ReadString('\n'); line != ": connected\n" {
+		t.Fatalf("first line = %q", line)
+	}
+	_, _ = reader.ReadString('\n')
+
+	bus.Publish(entity.ArticleEvent{Type: entity.WebhookEventArticleCreated, ArticleID: 7, SourceID: 3})
+
+	done := make(chan []string, 1)
+	go func() {
+		var lines []string
+		for range 2 {
+			line, _ := reader.ReadString('\n')
+			lines = append(lines, strings.TrimSuffix(line, "\n"))
+		}
+		done <- lines
+	}()
+	select {
+	case lines := <-done:
+		want := []string{
+			"event: article.created",
+			`data: {"type":"article.created","article_id":7,"source_id":3}`,
+		}
+		if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+			t.Fatalf("event = %q, want %q", lines, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event received")
+	}
+}
+
+// stubSubscriptionLookup subscribes every subject to source 3.
+type stubSubscriptionLookup struct{}
+
+func (stubSubscriptionLookup) SubscribedSourceIDs(context.Context, string) (map[int64]bool, error) {
+	return map[int64]bool{3: true}, nil
+}
+
+// TestEventsHandler_SubscriptionScope: admin 以外のアカウントには購読中の
+// ソースの記事イベントのみ届く。
+func TestEventsHandler_SubscriptionScope(t *testing.T) {
+	bus := artUC.NewEventBus()
+	handler := article.EventsHandler{Svc: artUC.Service{Stream: bus, Subscriptions: stubSubscriptionLookup{}}}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), "alice@example.com", "editor")))
+	}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	reader := bufio.NewReader(resp.Body)
+	_, _ = reader.ReadString('\n') // ": connected"
+	_, _ = reader.ReadString('\n')
+
+	bus.Publish(entity.ArticleEvent{Type: entity.WebhookEventArticleCreated, ArticleID: 7, SourceID: 5})
+	bus.Publish(entity.ArticleEvent{Type: entity.WebhookEventArticleCreated, ArticleID: 8, SourceID: 3})
+
+	done := make(chan string, 1)
+	go func() {
+		_, _ = reader.ReadString('\n') // event:
+		line, _ := reader.ReadString('\n')
+		done <- strings.TrimSuffix(line, "\n")
+	}()
+	select {
+	case line := <-done:
+		if want := `data: {"type":"article.created","article_id":8,"source_id":3}`; line != want {
+			t.Fatalf("data = %q, want %q (source 5 is not subscribed)", line, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event received")
+	}
+}
+
+func TestEventsHandler_Unavailable(t *testing.T) {
+	rr := httptest.NewRecorder()
+	article.EventsHandler{Svc: artUC.Service{}}.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/articles/events", nil))
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503", rr.Code)
+	}
+}
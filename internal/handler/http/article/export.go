@@ -0,0 +1,283 @@
+package article
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"catchup-feed/internal/handler/http/respond"
+	"catchup-feed/internal/repository"
+	artUC "catchup-feed/internal/usecase/article"
+)
+
+// Export formats of GET /articles/export.
+const (
+	exportJSON   = "json"
+	exportCSV    = "csv"
+	exportNDJSON = "ndjson"
+)
+
+// exportFlushEvery is how many rows are written between flushes, so the
+// client receives data while the query is still running.
+const exportFlushEvery = 100
+
+// exportContentTypes maps each format to its Content-Type.
+var exportContentTypes = map[string]string{
+	exportJSON:   "application/json; charset=utf-8",
+	exportCSV:    "text/csv; charset=utf-8",
+	exportNDJSON: "application/x-ndjson; charset=utf-8",
+}
+
+// exportCSVHeader is the header row of CSV exports (ExportDTO fields).
+var exportCSVHeader = []string{"id", "source_id", "source_name", "title", "url", "summary", "published_at", "crawled_at"}
+
+var errInvalidExportFormat = errors.New("invalid format: must be json, csv or ndjson")
+
+type ExportHandler struct {
+	Svc    artUC.Service
+	Logger *slog.Logger
+}
+
+// ServeHTTP 記事エクスポート
+// @Summary      記事エクスポート
+// @Description  検索条件に一致する記事をすべて書き出します（JSON 配列 / CSV / NDJSON）。
+// @Description  形式は format パラメータ、なければ Accept ヘッダ（application/json, text/csv, application/x-ndjson）で決まり、既定は JSON です。
+// @Description  絞り込み・並び順のパラメータは GET /articles/search と同じです。結果は逐次ストリーミングされ、
+// @Description  途中でエラーになった場合は応答が途中で切れます（JSON は閉じ括弧が欠けます）。
+// @Tags         articles
+// @Security     BearerAuth
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Param        format query string false "出力形式" Enums(json, csv, ndjson)
+// @Param        keyword query string false "検索キーワード（スペース区切り）"
+// @Param        source_id query int false "ソースIDでフィルタ"
+// @Param        group_id query int false "ソースグループIDでフィルタ"
+// @Param        from query string false "公開日時の開始（ISO 8601）"
+// @Param        to query string false "公開日時の終了（ISO 8601）"
+// @Param        tag query string false "タグ名でフィルタ"
+// @Param        unread_only query bool false "true で呼び出し元ユーザーの未読記事のみ"
+// @Param        favorites query bool false "true で呼び出し元ユーザーのお気に入り記事のみ"
+// @Param        subscribed query bool false "true で呼び出し元ユーザーが購読中のソースの記事のみ(admin 以外のアカウントは常に購読中のソースに限定)"
+// @Param        sort query string false "並び順のキー" Enums(published_at, created_at, title, relevance)
+// @Param        order query string false "昇順・降順" Enums(asc, desc)
+// @Success      200 {array} ExportDTO "記事（Content-Disposition: attachment）"
+// @Failure      400 {object} respond.ErrorResponse "Bad request"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      429 {string} string "Too many requests - rate limit exceeded"
+// @Failure      500 {object} respond.ErrorResponse "Server error"
+// @Router       /articles/export [get]
+func (h ExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	format, err := negotiateExportFormat(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	keywords, filters, err := parseSearchCriteria(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	stream := newExportStream(w, format, `attachment; filename="catchup-feed-articles.`+format+`"`)
+	err = h.Svc.Export(r.Context(), keywords, filters, stream.write)
+	if err == nil {
+		err = stream.finish()
+	}
+	if err != nil {
+		if !stream.started {
+			respond.SafeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		// The status line is gone; the truncated body tells the client.
+		h.Logger.ErrorContext(r.Context(), "Article export aborted",
+			"error", err.Error(),
+			"format", format,
+			"rows", stream.rows)
+	}
+}
+
+// negotiateExportFormat picks the format from the format query parameter,
+// else from the first supported media type in Accept, else JSON.
+func negotiateExportFormat(r *http.Request) (string, error) {
+	if format := r.URL.Query().Get("format"); format != "" {
+		if _, ok := exportContentTypes[format]; !ok {
+			return "", errInvalidExportFormat
+		}
+		return format, nil
+	}
+	return acceptFormat(r), nil
+}
+
+// acceptFormat returns the format of the first supported media type in
+// Accept, else JSON. q-values are not weighed: clients list the type they
+// want first.
+func acceptFormat(r *http.Request) string {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, _ := strings.Cut(part, ";")
+		switch strings.ToLower(strings.TrimSpace(mediaType)) {
+		case "application/json":
+			return exportJSON
+		case "text/csv":
+			return exportCSV
+		case "application/x-ndjson", "application/ndjson":
+			return exportNDJSON
+		}
+	}
+	return exportJSON
+}
+
+// exportStream writes rows in one format as they arrive. Headers are sent
+// with the first row (or by finish for an empty export), so a failure
+// before any row can still be answered with an error status.
+type exportStream struct {
+	w           http.ResponseWriter
+	rc          *http.ResponseController
+	format      string
+	disposition string // Content-Disposition; empty sends none
+	started     bool
+	rows        int
+	csv         *csv.Writer
+	enc         *json.Encoder
+}
+
+func newExportStream(w http.ResponseWriter, format, disposition string) *exportStream {
+	return &exportStream{w: w, rc: http.NewResponseController(w), format: format, disposition: disposition}
+}
+
+func (s *exportStream) start() error {
+	s.started = true
+	// Large exports outlive any server WriteTimeout (HTTP_WRITE_TIMEOUT).
+	_ = s.rc.SetWriteDeadline(time.Time{})
+	s.w.Header().Set("Content-Type", exportContentTypes[s.format])
+	if s.disposition != "" {
+		s.w.Header().Set("Content-Disposition", s.disposition)
+	}
+	s.w.WriteHeader(http.StatusOK)
+
+	switch s.format {
+	case exportCSV:
+		s.csv = csv.NewWriter(s.w)
+		return s.csv.Write(exportCSVHeader)
+	case exportJSON:
+		s.enc = json.NewEncoder(s.w)
+		_, err := io.WriteString(s.w, "[")
+		return err
+	default:
+		s.enc = json.NewEncoder(s.w)
+		return nil
+	}
+}
+
+func (s *exportStream) write(item repository.ArticleWithSource) error {
+	if !s.started {
+		if err := s.start(); err != nil {
+			return err
+		}
+	}
+	row := toExportDTO(item)
+
+	var err error
+	switch s.format {
+	case exportCSV:
+		err = s.csv.Write(row.csvRecord())
+	case exportJSON:
+		if s.rows > 0 {
+			if _, err = io.WriteString(s.w, ","); err != nil {
+				return err
+			}
+		}
+		err = s.enc.Encode(row)
+	default:
+		err = s.enc.Encode(row)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.rows++
+	if s.rows%exportFlushEvery == 0 {
+		return s.flush()
+	}
+	return nil
+}
+
+func (s *exportStream) finish() error {
+	if !s.started {
+		if err := s.start(); err != nil {
+			return err
+		}
+	}
+	if s.format == exportJSON {
+		if _, err := io.WriteString(s.w, "]\n"); err != nil {
+			return err
+		}
+	}
+	return s.flush()
+}
+
+func (s *exportStream) flush() error {
+	if s.csv != nil {
+		s.csv.Flush()
+		if err := s.csv.Error(); err != nil {
+			return err
+		}
+	}
+	// Not every ResponseWriter can flush (e.g. behind some middleware);
+	// the data is then sent when the handler returns.
+	if err := s.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// ExportDTO is one exported article: DTO without the per-user favorited
+// flag, which would cost a lookup per row.
+type ExportDTO struct {
+	ID          int64     `json:"id" example:"1"`
+	SourceID    int64     `json:"source_id" example:"1"`
+	SourceName  string    `json:"source_name" example:"Go Blog"`
+	Title       string    `json:"title" example:"Go 1.23 リリース"`
+	URL         string    `json:"url" example:"https://example.com/article/1"`
+	Summary     string    `json:"summary" example:"Go 1.23 がリリースされました。新機能には..."`
+	PublishedAt time.Time `json:"published_at" example:"2025-10-26T10:00:00Z"`
+	CrawledAt   time.Time `json:"crawled_at" example:"2025-10-26T12:00:00Z"`
+}
+
+func toExportDTO(item repository.ArticleWithSource) ExportDTO {
+	return ExportDTO{
+		ID:          item.Article.ID,
+		SourceID:    item.Article.SourceID,
+		SourceName:  item.SourceName,
+		Title:       item.Article.Title,
+		URL:         item.Article.URL,
+		Summary:     item.Article.Summary,
+		PublishedAt: item.Article.PublishedAt,
+		CrawledAt:   item.Article.CrawledAt,
+	}
+}
+
+// csvRecord returns the row in exportCSVHeader order. Times are RFC 3339;
+// a missing published_at is an empty cell.
+func (d ExportDTO) csvRecord() []string {
+	published := ""
+	if !d.PublishedAt.IsZero() {
+		published = d.PublishedAt.Format(time.RFC3339)
+	}
+	return []string{
+		strconv.FormatInt(d.ID, 10),
+		strconv.FormatInt(d.SourceID, 10),
+		d.SourceName,
+		d.Title,
+		d.URL,
+		d.Summary,
+		published,
+		d.CrawledAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,164 @@
+package article_test
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/article"
+	"catchup-feed/internal/repository"
+	artUC "catchup-feed/internal/usecase/article"
+)
+
+/* ───────── ヘルパー ───────── */
+
+func newExportHandler(stub *stubSearchPaginatedRepo) article.ExportHandler {
+	return article.ExportHandler{Svc: artUC.Service{Repo: stub}, Logger: slog.Default()}
+}
+
+func exportArticles() []repository.ArticleWithSource {
+	published := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	return []repository.ArticleWithSource{
+		{Article: &entity.Article{ID: 2, SourceID: 10, Title: "Go, again", URL: "https://example.com/2", Summary: "要約", PublishedAt: published, CrawledAt: published}, SourceName: "Go Blog"},
+		{Article: &entity.Article{ID: 1, SourceID: 10, Title: "Hello", URL: "https://example.com/1", CrawledAt: published}, SourceName: "Go Blog"},
+	}
+}
+
+/* ───────── テストケース ───────── */
+
+func TestExportHandler_JSON(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubSearchPaginatedRepo{articlesWithSrc: exportArticles()}
+	req := httptest.NewRequest(http.MethodGet, "/articles/export?keyword=go&source_id=10", nil)
+	rr := httptest.NewRecorder()
+	newExportHandler(stub).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	if ct := rr.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	if cd := rr.Header().Get("Content-Disposition"); !strings.Contains(cd, "catchup-feed-articles.json") {
+		t.Errorf("Content-Disposition = %q, want the json filename", cd)
+	}
+	var got []article.ExportDTO
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatalf("body is not a JSON array: %v\n%s", err, rr.Body.String())
+	}
+	if len(got) != 2 || got[0].ID != 2 || got[0].SourceName != "Go Blog" {
+		t.Errorf("exported = %+v, want articles 2 and 1", got)
+	}
+	// 検索 API と同じ条件がリポジトリに渡る
+	if len(stub.lastKeywords) != 1 || stub.lastFilters.SourceID == nil || *stub.lastFilters.SourceID != 10 {
+		t.Errorf("keywords = %v, filters = %+v, want [go] and source 10", stub.lastKeywords, stub.lastFilters)
+	}
+}
+
+func TestExportHandler_EmptyJSON(t *testing.T) {
+	t.Parallel()
+
+	rr := httptest.NewRecorder()
+	newExportHandler(&stubSearchPaginatedRepo{}).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/articles/export", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	if body := strings.TrimSpace(rr.Body.String()); body != "[]" {
+		t.Errorf("body = %q, want []", body)
+	}
+}
+
+func TestExportHandler_CSV(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/articles/export", nil)
+	req.Header.Set("Accept", "text/csv;q=0.9, application/json;q=0.5")
+	rr := httptest.NewRecorder()
+	newExportHandler(&stubSearchPaginatedRepo{articlesWithSrc: exportArticles()}).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	if ct := rr.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+		t.Errorf("Content-Type = %q, want text/csv", ct)
+	}
+	records, err := csv.NewReader(rr.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("body is not CSV: %v", err)
+	}
+	if len(records) != 3 || records[0][0] != "id" {
+		t.Fatalf("records = %v, want header and 2 rows", records)
+	}
+	if records[1][3] != "Go, again" || records[1][6] != "2026-10-01T09:00:00Z" {
+		t.Errorf("row 1 = %v, want quoted title and RFC 3339 published_at", records[1])
+	}
+	if records[2][6] != "" {
+		t.Errorf("published_at = %q, want empty for an undated article", records[2][6])
+	}
+}
+
+func TestExportHandler_NDJSON(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/articles/export?format=ndjson", nil)
+	req.Header.Set("Accept", "text/csv") // format パラメータが優先
+	rr := httptest.NewRecorder()
+	newExportHandler(&stubSearchPaginatedRepo{articlesWithSrc: exportArticles()}).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	lines := strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("lines = %d, want 2", len(lines))
+	}
+	var first article.ExportDTO
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil || first.ID != 2 {
+		t.Errorf("first line = %q (err %v), want article 2", lines[0], err)
+	}
+}
+
+func TestExportHandler_BadRequest(t *testing.T) {
+	t.Parallel()
+
+	for _, query := range []string{"format=xml", "source_id=abc", "sort=relevance"} {
+		rr := httptest.NewRecorder()
+		newExportHandler(&stubSearchPaginatedRepo{}).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/articles/export?"+query, nil))
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: status code = %d, want %d", query, rr.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+// TestExportHandler_Error: 1 行も書く前の失敗は 500、書き始めた後は途中で打ち切る。
+func TestExportHandler_Error(t *testing.T) {
+	t.Parallel()
+
+	rr := httptest.NewRecorder()
+	newExportHandler(&stubSearchPaginatedRepo{searchErr: errors.New("db down")}).
+		ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/articles/export", nil))
+	if rr.Code != http.StatusInternalServerError {
+		t.Errorf("status code = %d, want %d", rr.Code, http.StatusInternalServerError)
+	}
+
+	rr = httptest.NewRecorder()
+	newExportHandler(&stubSearchPaginatedRepo{articlesWithSrc: exportArticles(), searchErr: errors.New("db down")}).
+		ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/articles/export", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	var got []article.ExportDTO
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err == nil {
+		t.Errorf("truncated export parsed as complete JSON: %s", rr.Body.String())
+	}
+}
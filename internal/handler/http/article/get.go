@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 
+	"catchup-feed/internal/handler/http/auth"
 	"catchup-feed/internal/handler/http/pathutil"
 	"catchup-feed/internal/handler/http/respond"
 	artUC "catchup-feed/internal/usecase/article"
@@ -14,11 +15,15 @@ type GetHandler struct{ Svc artUC.Service }
 // ServeHTTP 記事詳細取得
 // @Summary      記事詳細取得
 // @Description  指定されたIDの記事を取得します（ソース名を含む）
+// @Description  admin 以外のアカウントが購読していないソースの記事は 404 になります。
 // @Tags         articles
 // @Security     BearerAuth
 // @Produce      json
+// @Param        If-None-Match header string false "前回受け取った ETag"
 // @Param        id path int true "記事ID"
 // @Success      200 {object} DTO "記事詳細"
+// @Header       200 {string} ETag "レスポンス本文の弱い ETag"
+// @Success      304 "If-None-Match が現在の ETag と一致(本文なし)"
 // @Failure      400 {object} respond.ErrorResponse "Bad request - invalid article ID"
 // @Failure      401 {object} respond.ErrorResponse "Authentication required - missing or invalid JWT token"
 // @Failure      404 {object} respond.ErrorResponse "Not found - article not found"
@@ -42,6 +47,15 @@ func (h GetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		respond.SafeError(w, code, err)
 		return
 	}
+	hidden, err := hiddenBySubscription(r, h.Svc, article.SourceID)
+	if err != nil {
+		respond.SafeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if hidden {
+		respond.SafeError(w, http.StatusNotFound, artUC.ErrArticleNotFound)
+		return
+	}
 
 	out := DTO{
 		ID:          article.ID,
@@ -52,7 +66,14 @@ func (h GetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		Summary:     article.Summary,
 		PublishedAt: article.PublishedAt,
 		CrawledAt:   article.CrawledAt,
+		ImageURL:    article.ImageURL,
+	}
+	favorited, err := h.Svc.FavoritedIDs(r.Context(), auth.SubjectFromContext(r.Context()), []int64{out.ID})
+	if err != nil {
+		respond.SafeError(w, http.StatusInternalServerError, err)
+		return
 	}
+	out.Favorited = favorited[out.ID]
 
-	respond.JSON(w, http.StatusOK, out)
+	respond.JSONWithETag(w, r, http.StatusOK, out)
 }
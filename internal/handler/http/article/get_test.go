@@ -12,6 +12,7 @@ import (
 
 	"catchup-feed/internal/domain/entity"
 	"catchup-feed/internal/handler/http/article"
+	"catchup-feed/internal/handler/http/auth"
 	"catchup-feed/internal/repository"
 	artUC "catchup-feed/internal/usecase/article"
 )
@@ -56,6 +57,12 @@ func (s *stubGetRepo) Update(_ context.Context, _ *entity.Article) error {
 func (s *stubGetRepo) Delete(_ context.Context, _ int64) error {
 	return nil
 }
+func (s *stubGetRepo) DeleteBatch(_ context.Context, _ []int64) ([]int64, error) {
+	return nil, nil
+}
+func (s *stubGetRepo) ForEachWithSource(_ context.Context, _ []string, _ repository.ArticleSearchFilters, _ func(repository.ArticleWithSource) error) error {
+	return nil
+}
 func (s *stubGetRepo) ListUnsummarized(_ context.Context, _ int) ([]*entity.Article, error) {
 	return nil, nil
 }
@@ -65,6 +72,10 @@ func (s *stubGetRepo) ExistsByURL(_ context.Context, _ string) (bool, error) {
 func (s *stubGetRepo) ExistsByURLBatch(_ context.Context, _ []string) (map[string]bool, error) {
 	return nil, nil
 }
+
+func (s *stubGetRepo) ExistsByContentHashBatch(_ context.Context, _ []string) (map[string]bool, error) {
+	return map[string]bool{}, nil
+}
 func (s *stubGetRepo) ListWithSource(_ context.Context) ([]repository.ArticleWithSource, error) {
 	return nil, nil
 }
@@ -81,6 +92,10 @@ func (s *stubGetRepo) SearchWithFiltersPaginated(_ context.Context, _ []string,
 	return nil, nil
 }
 
+func (s *stubGetRepo) ListWithSourceAfter(_ context.Context, _ []string, _ repository.ArticleSearchFilters, _ *repository.ArticleCursor, _ int) ([]repository.ArticleWithSource, error) {
+	return nil, nil
+}
+
 /* ───────── テストケース ───────── */
 
 func TestGetHandler_Success(t *testing.T) {
@@ -94,6 +109,7 @@ func TestGetHandler_Success(t *testing.T) {
 			Summary:     "Test Summary",
 			PublishedAt: now,
 			CrawledAt:   now,
+			ImageURL:    "https://example.com/og.png",
 		},
 		sourceName: "Test Source",
 	}
@@ -134,6 +150,69 @@ func TestGetHandler_Success(t *testing.T) {
 	if result.Summary != "Test Summary" {
 		t.Errorf("result.Summary = %q, want %q", result.Summary, "Test Summary")
 	}
+	if result.ImageURL != "https://example.com/og.png" {
+		t.Errorf("result.ImageURL = %q, want %q", result.ImageURL, "https://example.com/og.png")
+	}
+}
+
+// A client that sends back the ETag it got gets 304 without a body until
+// the article changes.
+func TestGetHandler_IfNoneMatch(t *testing.T) {
+	stub := &stubGetRepo{
+		article:    &entity.Article{ID: 1, SourceID: 10, Title: "Before", URL: "https://example.com/a"},
+		sourceName: "Test Source",
+	}
+	handler := article.GetHandler{Svc: artUC.Service{Repo: stub}}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/articles/1", nil))
+	etag := rr.Header().Get("ETag")
+	if rr.Code != http.StatusOK || etag == "" {
+		t.Fatalf("status = %d, ETag = %q", rr.Code, etag)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/articles/1", nil)
+	req.Header.Set("If-None-Match", etag)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotModified || rr.Body.Len() != 0 {
+		t.Fatalf("status = %d, body = %q, want 304 without body", rr.Code, rr.Body.String())
+	}
+
+	stub.article.Title = "After"
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK || rr.Header().Get("ETag") == etag {
+		t.Fatalf("status = %d, ETag = %q after change", rr.Code, rr.Header().Get("ETag"))
+	}
+}
+
+// TestGetHandler_SubscriptionScope: admin 以外のアカウントには購読していない
+// ソースの記事は存在しないものとして 404 を返す。
+func TestGetHandler_SubscriptionScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		sourceID int64
+		role     string
+		want     int
+	}{
+		{"subscribed source", 3, "editor", http.StatusOK},
+		{"unsubscribed source", 10, "editor", http.StatusNotFound},
+		{"admin sees every source", 10, auth.RoleAdmin, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubGetRepo{article: &entity.Article{ID: 1, SourceID: tt.sourceID, Title: "T", URL: "https://example.com/a"}}
+			handler := article.GetHandler{Svc: artUC.Service{Repo: stub, Subscriptions: stubSubscriptionLookup{}}}
+			req := httptest.NewRequest(http.MethodGet, "/articles/1", nil)
+			req = req.WithContext(auth.WithIdentity(req.Context(), "alice@example.com", tt.role))
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			if rr.Code != tt.want {
+				t.Errorf("status code = %d, want %d", rr.Code, tt.want)
+			}
+		})
+	}
 }
 
 func TestGetHandler_InvalidID(t *testing.T) {
@@ -343,6 +422,9 @@ func TestGetHandler_SQLNoRowsError(t *testing.T) {
 func (s *stubGetRepo) CreateWithSummary(_ context.Context, _ *entity.Article, _ *entity.Summary) error {
 	return nil
 }
+func (s *stubGetRepo) CreateBatch(_ context.Context, _ []repository.NewArticle) (int, error) {
+	return 0, nil
+}
 func (s *stubGetRepo) CreateWithTranscribeJob(_ context.Context, _ *entity.Article, _, _ string) error {
 	return nil
 }
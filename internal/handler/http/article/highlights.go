@@ -0,0 +1,141 @@
+package article
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"catchup-feed/internal/handler/http/respond"
+	"catchup-feed/internal/repository"
+	artUC "catchup-feed/internal/usecase/article"
+)
+
+var errInvalidHighlightTopics = errors.New("invalid topics: must be between 1 and 50")
+
+// HighlightsDTO is the topic summary of a period (GET /articles/highlights).
+type HighlightsDTO struct {
+	Period string    `json:"period" example:"weekly"`
+	From   time.Time `json:"from" example:"2026-10-08T08:00:00Z"`
+	To     time.Time `json:"to" example:"2026-10-15T08:00:00Z"`
+	// Total is the number of articles stored in the period; Clustered
+	// how many of them (the newest 500) were grouped into topics.
+	Total     int64      `json:"total" example:"240"`
+	Clustered int        `json:"clustered" example:"240"`
+	Topics    []TopicDTO `json:"topics"`
+}
+
+// TopicDTO is one topic cluster, largest first.
+type TopicDTO struct {
+	// Terms are the heaviest terms the articles share, a label for the topic.
+	Terms          []string `json:"terms" example:"go,1.30,release"`
+	Size           int      `json:"size" example:"3"`
+	Representative DTO      `json:"representative"`
+	// Articles are the topic's articles, newest first, including the
+	// representative.
+	Articles []DTO `json:"articles"`
+}
+
+type HighlightsHandler struct{ Svc artUC.Service }
+
+// ServeHTTP 期間のトピックハイライト
+// @Summary      期間のトピックハイライト
+// @Description  期間内(daily: 過去24時間、weekly: 過去7日)に取得した記事を、タイトルと要約の語の類似度でトピックごとにまとめて返します。
+// @Description  トピックは記事数の多い順で、それぞれ代表記事(トピックの中心に最も近い記事)と所属記事を持ちます。近似重複は元記事にまとめ済みです。
+// @Description  クラスタリングは期間内の新しい記事 500 件までが対象です。記事ダイジェスト通知の「主なトピック」と同じまとめ方です。
+// @Tags         articles
+// @Security     BearerAuth
+// @Produce      json
+// @Param        period query string false "期間(daily / weekly、既定 weekly)"
+// @Param        topics query int false "トピック数(既定 10、最大 50)"
+// @Success      200 {object} HighlightsDTO "トピックハイライト"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid period or topics"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - articles:read が必要"
+// @Failure      503 {object} respond.ErrorResponse "ハイライトが未設定"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /articles/highlights [get]
+func (h HighlightsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	period := r.URL.Query().Get("period")
+	if period == "" {
+		period = artUC.HighlightPeriodWeekly
+	}
+	from, to, err := artUC.HighlightWindow(period, time.Now())
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	topics := artUC.DefaultHighlightTopics
+	if raw := r.URL.Query().Get("topics"); raw != "" {
+		topics, err = strconv.Atoi(raw)
+		if err != nil || topics < 1 || topics > artUC.MaxHighlightTopics {
+			respond.SafeError(w, http.StatusBadRequest, errInvalidHighlightTopics)
+			return
+		}
+	}
+
+	highlights, err := h.Svc.Highlights(r.Context(), from, to, topics)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, artUC.ErrHighlightsUnavailable) {
+			code = http.StatusServiceUnavailable
+		}
+		respond.SafeError(w, code, err)
+		return
+	}
+
+	// Favorited is looked up once for every listed article.
+	var all []DTO
+	for _, topic := range highlights.Topics {
+		for _, item := range topic.Articles {
+			all = append(all, withSourceDTO(item))
+		}
+	}
+	if err := markFavorited(r.Context(), h.Svc, all); err != nil {
+		respond.SafeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	favorited := make(map[int64]bool, len(all))
+	for _, d := range all {
+		favorited[d.ID] = d.Favorited
+	}
+
+	out := HighlightsDTO{
+		Period:    period,
+		From:      highlights.From,
+		To:        highlights.To,
+		Total:     highlights.Total,
+		Clustered: highlights.Clustered,
+		Topics:    make([]TopicDTO, 0, len(highlights.Topics)),
+	}
+	for _, topic := range highlights.Topics {
+		dto := TopicDTO{
+			Terms:          topic.Terms,
+			Size:           len(topic.Articles),
+			Representative: withSourceDTO(topic.Representative),
+			Articles:       make([]DTO, 0, len(topic.Articles)),
+		}
+		dto.Representative.Favorited = favorited[dto.Representative.ID]
+		for _, item := range topic.Articles {
+			d := withSourceDTO(item)
+			d.Favorited = favorited[d.ID]
+			dto.Articles = append(dto.Articles, d)
+		}
+		out.Topics = append(out.Topics, dto)
+	}
+	respond.JSON(w, http.StatusOK, out)
+}
+
+func withSourceDTO(item repository.ArticleWithSource) DTO {
+	return DTO{
+		ID:          item.Article.ID,
+		SourceID:    item.Article.SourceID,
+		SourceName:  item.SourceName,
+		Title:       item.Article.Title,
+		URL:         item.Article.URL,
+		Summary:     item.Article.Summary,
+		PublishedAt: item.Article.PublishedAt,
+		CrawledAt:   item.Article.CrawledAt,
+		ImageURL:    item.Article.ImageURL,
+	}
+}
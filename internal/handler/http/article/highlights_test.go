@@ -0,0 +1,104 @@
+package article_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/article"
+	"catchup-feed/internal/repository"
+	artUC "catchup-feed/internal/usecase/article"
+)
+
+/* ───────── モック実装 ───────── */
+
+type stubDigestRepo struct {
+	articles []repository.ArticleWithSource
+	err      error
+
+	from, to time.Time
+}
+
+func (s *stubDigestRepo) ListStoredBetween(_ context.Context, from, to time.Time, _ int) ([]repository.ArticleWithSource, int64, error) {
+	s.from, s.to = from, to
+	return s.articles, int64(len(s.articles)), s.err
+}
+
+func highlightArticle(id int64, source, title, summary string) repository.ArticleWithSource {
+	return repository.ArticleWithSource{
+		Article:    &entity.Article{ID: id, Title: title, Summary: summary},
+		SourceName: source,
+	}
+}
+
+/* ───────── テストケース ───────── */
+
+func TestHighlightsHandler(t *testing.T) {
+	digest := &stubDigestRepo{articles: []repository.ArticleWithSource{
+		highlightArticle(4, "Go Blog", "Go 1.30 released", "The Go team shipped Go 1.30 with a faster garbage collector."),
+		highlightArticle(3, "Zenn", "PostgreSQL 19 beta", "PostgreSQL 19 beta adds asynchronous I/O."),
+		highlightArticle(2, "Zenn", "What's in Go 1.30", "A tour of the Go 1.30 release: garbage collector and iterators."),
+		highlightArticle(1, "Qiita", "Upgrading to Go 1.30", "Notes from upgrading our services to the Go 1.30 release."),
+	}}
+	handler := article.HighlightsHandler{Svc: artUC.Service{Digest: digest}}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/articles/highlights", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d; body=%s", rr.Code, http.StatusOK, rr.Body.String())
+	}
+	var got article.HighlightsDTO
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.Period != "weekly" || digest.to.Sub(digest.from) != 7*24*time.Hour {
+		t.Errorf("period = %q over %v, want weekly over 7 days", got.Period, digest.to.Sub(digest.from))
+	}
+	if got.Total != 4 || got.Clustered != 4 || len(got.Topics) != 2 {
+		t.Fatalf("highlights = %+v, want 4 articles in 2 topics", got)
+	}
+	first := got.Topics[0]
+	if first.Size != 3 || len(first.Articles) != 3 || first.Articles[0].ID != 4 || first.Articles[0].SourceName != "Go Blog" {
+		t.Errorf("first topic = %+v, want the three Go 1.30 articles newest first", first)
+	}
+	if first.Representative.ID == 3 || first.Representative.ID == 0 || len(first.Terms) == 0 {
+		t.Errorf("first topic representative = %+v, terms = %v", first.Representative, first.Terms)
+	}
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/articles/highlights?period=daily&topics=1", nil))
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(got.Topics) != 1 || digest.to.Sub(digest.from) != 24*time.Hour {
+		t.Errorf("daily topics=1: %d topics over %v", len(got.Topics), digest.to.Sub(digest.from))
+	}
+}
+
+func TestHighlightsHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		svc    artUC.Service
+		target string
+		want   int
+	}{
+		{"unknown period", artUC.Service{Digest: &stubDigestRepo{}}, "/articles/highlights?period=monthly", http.StatusBadRequest},
+		{"topics out of range", artUC.Service{Digest: &stubDigestRepo{}}, "/articles/highlights?topics=51", http.StatusBadRequest},
+		{"not configured", artUC.Service{}, "/articles/highlights", http.StatusServiceUnavailable},
+		{"repository error", artUC.Service{Digest: &stubDigestRepo{err: errors.New("db down")}}, "/articles/highlights", http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			article.HighlightsHandler{Svc: tt.svc}.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if rr.Code != tt.want {
+				t.Errorf("status code = %d, want %d; body=%s", rr.Code, tt.want, rr.Body.String())
+			}
+		})
+	}
+}
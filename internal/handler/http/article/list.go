@@ -6,8 +6,8 @@ import (
 	"time"
 
 	"catchup-feed/internal/common/pagination"
-	"catchup-feed/internal/handler/http/requestid"
 	"catchup-feed/internal/handler/http/respond"
+	"catchup-feed/internal/repository"
 	artUC "catchup-feed/internal/usecase/article"
 )
 
@@ -19,13 +19,31 @@ type ListHandler struct {
 
 // ServeHTTP 記事一覧取得
 // @Summary      記事一覧取得（ページネーション対応）
-// @Description  登録されている記事を取得します。ページネーションパラメータを指定して、ページ単位で記事を取得できます。
+// @Description  登録されている記事を取得します。ページネーションパラメータを指定して、ページ単位で記事を取得できます。cursor を指定すると公開日時・ID によるキーセットページネーションになり、深いページでも性能が劣化しません。
 // @Tags         articles
 // @Security     BearerAuth
+// @Description  Accept: text/csv / application/x-ndjson では現在のページの記事を GET /articles/export と同じ列で行ごとに返し、ページ情報は X-Total-Count / X-Total-Pages / X-Next-Cursor ヘッダで返します（ETag なし）。
 // @Produce      json
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Param        If-None-Match header string false "前回受け取った ETag"
 // @Param        page   query    int  false  "ページ番号 (1-based)" default(1) minimum(1)
-// @Param        limit  query    int  false  "1ページあたりの件数" default(20) minimum(1) maximum(100)
+// @Param        limit  query    int  false  "1ページあたりの件数(上限は既定 100、呼び出し元の区分ごとに PAGINATION_MAX_LIMIT_* で変更可)" default(20) minimum(1)
+// @Param        cursor query    string  false  "カーソルページネーション。空文字で1ページ目、以降は前レスポンスの next_cursor（page とは併用不可）"
+// @Param        group_id  query  int  false  "ソースグループIDでフィルタ"
+// @Param        tag    query    string  false  "タグ名でフィルタ"
+// @Param        sort   query    string  false  "並び順のキー" Enums(published_at, created_at, title)
+// @Param        order  query    string  false  "昇順・降順（title は asc、それ以外は desc がデフォルト）" Enums(asc, desc)
+// @Param        unread_only  query  bool  false  "true で呼び出し元ユーザーの未読記事のみ"
+// @Param        favorites    query  bool  false  "true で呼び出し元ユーザーのお気に入り記事のみ"
+// @Param        subscribed   query  bool  false  "true で呼び出し元ユーザーが購読中のソースの記事のみ(admin 以外のアカウントは常に購読中のソースに限定)"
+// @Param        collapse_duplicates  query  bool  false  "true で近似重複記事（他ソースの同一記事）をまとめ、各グループの元記事のみ返す"
 // @Success      200 {object} pagination.Response[DTO] "ページネーション付き記事一覧"
+// @Header       200 {string} ETag "レスポンス本文の弱い ETag（JSON のみ）"
+// @Header       200 {integer} X-Total-Count "総件数（CSV / NDJSON のみ）"
+// @Header       200 {integer} X-Total-Pages "総ページ数（CSV / NDJSON のみ）"
+// @Header       200 {string} X-Next-Cursor "次ページのカーソル（CSV / NDJSON、cursor 指定時のみ）"
+// @Success      304 "If-None-Match が現在の ETag と一致(本文なし)"
 // @Failure      400 {object} respond.ErrorResponse "Invalid query parameters"
 // @Failure      401 {object} respond.ErrorResponse "Authentication required - missing or invalid JWT token"
 // @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
@@ -34,37 +52,96 @@ func (h ListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	startTime := time.Now()
 
-	// Get request ID for logging
-	reqID := requestid.FromContext(ctx)
+	// request_id is attached to ctx by requestid.Middleware
 	logger := h.Logger
+	format := listFormat(w, r)
 
 	// Parse pagination parameters
 	params, err := pagination.ParseQueryParams(r, h.PaginationCfg)
 	if err != nil {
-		logger.Warn("Invalid pagination parameters",
-			"error", err.Error(),
-			"request_id", reqID)
+		logger.WarnContext(ctx, "Invalid pagination parameters",
+			"error", err.Error())
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	useCursor, after, err := pagination.ParseCursor(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	groupID, err := parseGroupIDParam(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	tag, err := parseTagParam(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	unreadFor, err := parseUnreadOnlyParam(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	favoritesOf, err := parseFavoritesParam(r)
+	if err != nil {
 		respond.SafeError(w, http.StatusBadRequest, err)
 		return
 	}
+	subscribedBy, err := parseSubscribedParam(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	collapse, err := parseCollapseDuplicatesParam(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	sort, err := parseSortParams(r, false)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if useCursor && sort != (repository.ArticleSort{}) {
+		respond.SafeError(w, http.StatusBadRequest, errSortWithCursor)
+		return
+	}
 
 	// Log request
-	logger.Info("Paginated article list request",
+	logger.InfoContext(ctx, "Paginated article list request",
 		"page", params.Page,
-		"limit", params.Limit,
-		"request_id", reqID)
+		"limit", params.Limit)
 
-	// Get paginated data from service
-	result, err := h.Svc.ListWithSourcePaginated(ctx, params)
+	// Get paginated data from service. Group, tag, unread, favorites,
+	// subscription and duplicate filters and explicit sorts go through the
+	// filtered search path (no keywords).
+	filters := repository.ArticleSearchFilters{
+		GroupID: groupID, Tag: tag, UnreadFor: unreadFor, FavoritesOf: favoritesOf, SubscribedBy: subscribedBy,
+		CollapseDuplicates: collapse, Sort: sort,
+	}
+	var result *artUC.PaginatedResult
+	if useCursor {
+		result, err = h.Svc.ListWithSourceAfter(ctx, nil, filters, after, params.Limit)
+	} else if filters != (repository.ArticleSearchFilters{}) {
+		result, err = h.Svc.SearchWithFiltersPaginated(ctx, nil, filters, params.Page, params.Limit)
+	} else {
+		result, err = h.Svc.ListWithSourcePaginated(ctx, params)
+	}
 	if err != nil {
-		logger.Error("Failed to list articles",
+		logger.ErrorContext(ctx, "Failed to list articles",
 			"error", err.Error(),
 			"page", params.Page,
-			"limit", params.Limit,
-			"request_id", reqID)
+			"limit", params.Limit)
 		respond.SafeError(w, http.StatusInternalServerError, err)
 		return
 	}
+	if format != exportJSON {
+		writeRows(w, r, logger, format, result)
+		return
+	}
 
 	// Convert to DTOs
 	dtos := make([]DTO, 0, len(result.Data))
@@ -78,8 +155,15 @@ func (h ListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			Summary:     item.Article.Summary,
 			PublishedAt: item.Article.PublishedAt,
 			CrawledAt:   item.Article.CrawledAt,
+			ImageURL:    item.Article.ImageURL,
 		})
 	}
+	if err := markFavorited(ctx, h.Svc, dtos); err != nil {
+		logger.ErrorContext(ctx, "Failed to look up favorites",
+			"error", err.Error())
+		respond.SafeError(w, http.StatusInternalServerError, err)
+		return
+	}
 
 	// Build paginated response
 	response := pagination.NewResponse(dtos, result.Pagination)
@@ -87,13 +171,12 @@ func (h ListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	duration := time.Since(startTime)
 
 	// Log response
-	logger.Info("Paginated response",
+	logger.InfoContext(ctx, "Paginated response",
 		"page", params.Page,
 		"limit", params.Limit,
 		"returned_count", len(dtos),
 		"duration_ms", duration.Milliseconds(),
-		"status", http.StatusOK,
-		"request_id", reqID)
+		"status", http.StatusOK)
 
-	respond.JSON(w, http.StatusOK, response)
+	respond.JSONWithETag(w, r, http.StatusOK, response)
 }
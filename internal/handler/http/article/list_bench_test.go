@@ -46,6 +46,12 @@ func (b *benchListRepo) Update(_ context.Context, _ *entity.Article) error {
 func (b *benchListRepo) Delete(_ context.Context, _ int64) error {
 	return nil
 }
+func (b *benchListRepo) DeleteBatch(_ context.Context, _ []int64) ([]int64, error) {
+	return nil, nil
+}
+func (b *benchListRepo) ForEachWithSource(_ context.Context, _ []string, _ repository.ArticleSearchFilters, _ func(repository.ArticleWithSource) error) error {
+	return nil
+}
 func (b *benchListRepo) Search(_ context.Context, _ string) ([]*entity.Article, error) {
 	return nil, nil
 }
@@ -61,6 +67,10 @@ func (b *benchListRepo) ExistsByURL(_ context.Context, _ string) (bool, error) {
 func (b *benchListRepo) ExistsByURLBatch(_ context.Context, _ []string) (map[string]bool, error) {
 	return nil, nil
 }
+
+func (b *benchListRepo) ExistsByContentHashBatch(_ context.Context, _ []string) (map[string]bool, error) {
+	return map[string]bool{}, nil
+}
 func (b *benchListRepo) GetWithSource(_ context.Context, _ int64) (*entity.Article, string, error) {
 	return nil, "", nil
 }
@@ -114,6 +124,10 @@ func (b *benchListRepo) SearchWithFiltersPaginated(_ context.Context, _ []string
 	return nil, nil
 }
 
+func (b *benchListRepo) ListWithSourceAfter(_ context.Context, _ []string, _ repository.ArticleSearchFilters, _ *repository.ArticleCursor, _ int) ([]repository.ArticleWithSource, error) {
+	return nil, nil
+}
+
 // BenchmarkListHandler_100Articles は100件の記事一覧取得の性能を測定
 func BenchmarkListHandler_100Articles(b *testing.B) {
 	repo := &benchListRepo{}
@@ -145,6 +159,9 @@ func BenchmarkListHandler_Parallel(b *testing.B) {
 func (s *benchListRepo) CreateWithSummary(_ context.Context, _ *entity.Article, _ *entity.Summary) error {
 	return nil
 }
+func (s *benchListRepo) CreateBatch(_ context.Context, _ []repository.NewArticle) (int, error) {
+	return 0, nil
+}
 func (s *benchListRepo) CreateWithTranscribeJob(_ context.Context, _ *entity.Article, _, _ string) error {
 	return nil
 }
@@ -50,6 +50,12 @@ func (s *stubArticleRepo) Update(_ context.Context, _ *entity.Article) error {
 func (s *stubArticleRepo) Delete(_ context.Context, _ int64) error {
 	return nil
 }
+func (s *stubArticleRepo) DeleteBatch(_ context.Context, _ []int64) ([]int64, error) {
+	return nil, nil
+}
+func (s *stubArticleRepo) ForEachWithSource(_ context.Context, _ []string, _ repository.ArticleSearchFilters, _ func(repository.ArticleWithSource) error) error {
+	return nil
+}
 func (s *stubArticleRepo) ListUnsummarized(_ context.Context, _ int) ([]*entity.Article, error) {
 	return nil, nil
 }
@@ -59,6 +65,10 @@ func (s *stubArticleRepo) ExistsByURL(_ context.Context, _ string) (bool, error)
 func (s *stubArticleRepo) ExistsByURLBatch(_ context.Context, _ []string) (map[string]bool, error) {
 	return nil, nil
 }
+
+func (s *stubArticleRepo) ExistsByContentHashBatch(_ context.Context, _ []string) (map[string]bool, error) {
+	return map[string]bool{}, nil
+}
 func (s *stubArticleRepo) GetWithSource(_ context.Context, _ int64) (*entity.Article, string, error) {
 	return nil, "", nil
 }
@@ -102,6 +112,10 @@ func (s *stubArticleRepo) SearchWithFiltersPaginated(_ context.Context, _ []stri
 	return nil, nil
 }
 
+func (s *stubArticleRepo) ListWithSourceAfter(_ context.Context, _ []string, _ repository.ArticleSearchFilters, _ *repository.ArticleCursor, _ int) ([]repository.ArticleWithSource, error) {
+	return nil, nil
+}
+
 /* ───────── テストケース ───────── */
 
 func TestListHandler_Success(t *testing.T) {
@@ -489,6 +503,9 @@ func TestListHandler_Pagination_ServiceError(t *testing.T) {
 func (s *stubArticleRepo) CreateWithSummary(_ context.Context, _ *entity.Article, _ *entity.Summary) error {
 	return nil
 }
+func (s *stubArticleRepo) CreateBatch(_ context.Context, _ []repository.NewArticle) (int, error) {
+	return 0, nil
+}
 func (s *stubArticleRepo) CreateWithTranscribeJob(_ context.Context, _ *entity.Article, _, _ string) error {
 	return nil
 }
@@ -12,22 +12,46 @@ import (
 
 // Register registers all article-related HTTP handlers with the given mux.
 // It sets up routes for listing, searching, creating, updating, and deleting articles.
-// Protected routes (create, update, delete) require authentication via the auth middleware.
+// Read routes require the articles:read scope and write routes
+// articles:write (auth.RequireScope; admins hold every scope).
 // Search endpoints are protected by rate limiting to prevent DoS attacks.
 func Register(mux *http.ServeMux, svc artUC.Service, paginationCfg pagination.Config, logger *slog.Logger, searchRateLimiter *middleware.RateLimiter) {
-	mux.Handle("GET    /articles", ListHandler{
+	read := auth.RequireScope(auth.ScopeArticlesRead)
+	write := auth.RequireScope(auth.ScopeArticlesWrite)
+
+	mux.Handle("GET    /articles", read(ListHandler{
 		Svc:           svc,
 		PaginationCfg: paginationCfg,
 		Logger:        logger,
-	})
+	}))
 	// New paginated search endpoint with rate limiting (100 req/min per IP)
-	mux.Handle("GET    /articles/search", searchRateLimiter.Middleware(SearchPaginatedHandler{
+	mux.Handle("GET    /articles/search", read(searchRateLimiter.Middleware(SearchPaginatedHandler{
 		Svc:           svc,
 		PaginationCfg: paginationCfg,
-	}))
-	mux.Handle("GET    /articles/", auth.Authz(GetHandler{svc}))
+		Logger:        logger,
+	})))
+	// Exports stream whole result sets, so they share the search rate limit
+	mux.Handle("GET    /articles/export", read(searchRateLimiter.Middleware(ExportHandler{
+		Svc:    svc,
+		Logger: logger,
+	})))
+	mux.Handle("GET    /articles/", read(GetHandler{svc}))
+	// Server-Sent Events of newly stored articles (LISTEN article_events)
+	mux.Handle("GET    /articles/events", read(EventsHandler{svc}))
+	// Page fetched by the crawler (raw HTML + readability text)
+	mux.Handle("GET    /articles/{id}/content", read(ContentHandler{svc}))
+	// Near-duplicate group (the same story from several sources)
+	mux.Handle("GET    /articles/{id}/duplicates", read(DuplicatesHandler{svc}))
+	// Period topics (articles clustered by term similarity)
+	mux.Handle("GET    /articles/highlights", read(HighlightsHandler{svc}))
+	// Outbound Atom feed of summarized articles for other feed readers
+	mux.Handle("GET    /feed.xml", read(AtomFeedHandler{svc}))
 
-	mux.Handle("POST   /articles", auth.Authz(CreateHandler{svc}))
-	mux.Handle("PUT    /articles/", auth.Authz(UpdateHandler{svc}))
-	mux.Handle("DELETE /articles/", auth.Authz(DeleteHandler{svc}))
+	mux.Handle("POST   /articles", write(CreateHandler{svc}))
+	mux.Handle("POST   /articles/bulk-delete", write(BulkDeleteHandler{svc}))
+	// Re-summarization runs on the worker; each call costs an LLM request,
+	// so it shares the search rate limit
+	mux.Handle("POST   /articles/{id}/summarize", write(searchRateLimiter.Middleware(SummarizeHandler{svc})))
+	mux.Handle("PUT    /articles/", write(UpdateHandler{svc}))
+	mux.Handle("DELETE /articles/", write(DeleteHandler{svc}))
 }
@@ -0,0 +1,69 @@
+package article
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"catchup-feed/internal/common/pagination"
+	"catchup-feed/internal/handler/http/respond"
+	artUC "catchup-feed/internal/usecase/article"
+)
+
+// Pagination headers of the CSV / NDJSON representations of GET /articles
+// and GET /articles/search, which have no envelope for the metadata.
+const (
+	headerTotalCount = "X-Total-Count"
+	headerTotalPages = "X-Total-Pages"
+	headerNextCursor = "X-Next-Cursor"
+)
+
+// listFormat picks the representation of a list endpoint from Accept
+// (acceptFormat): text/csv and application/x-ndjson stream the page as
+// rows, anything else gets the paginated JSON envelope. The response
+// varies on Accept either way.
+func listFormat(w http.ResponseWriter, r *http.Request) string {
+	w.Header().Add("Vary", "Accept")
+	return acceptFormat(r)
+}
+
+// writeRows streams one page as CSV / NDJSON rows in the ExportDTO shape
+// of GET /articles/export, so pipelines read list, search and export the
+// same way. The pagination metadata goes into the X-Total-Count /
+// X-Total-Pages / X-Next-Cursor headers.
+func writeRows(w http.ResponseWriter, r *http.Request, logger *slog.Logger, format string, result *artUC.PaginatedResult) {
+	setPaginationHeaders(w.Header(), result.Pagination)
+	w.Header().Set("Cache-Control", "private, no-cache")
+
+	stream := newExportStream(w, format, "")
+	var err error
+	for _, item := range result.Data {
+		if err = stream.write(item); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = stream.finish()
+	}
+	if err != nil {
+		if !stream.started {
+			respond.SafeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if logger == nil {
+			logger = slog.Default()
+		}
+		logger.ErrorContext(r.Context(), "Article rows aborted",
+			"error", err.Error(),
+			"format", format,
+			"rows", stream.rows)
+	}
+}
+
+func setPaginationHeaders(h http.Header, meta pagination.Metadata) {
+	h.Set(headerTotalCount, strconv.FormatInt(meta.Total, 10))
+	h.Set(headerTotalPages, strconv.Itoa(meta.TotalPages))
+	if meta.NextCursor != "" {
+		h.Set(headerNextCursor, meta.NextCursor)
+	}
+}
@@ -3,10 +3,13 @@ package article
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strconv"
 
 	"catchup-feed/internal/common/pagination"
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/auth"
 	"catchup-feed/internal/handler/http/respond"
 	"catchup-feed/internal/pkg/search"
 	"catchup-feed/internal/pkg/validation"
@@ -17,6 +20,7 @@ import (
 type SearchPaginatedHandler struct {
 	Svc           artUC.Service
 	PaginationCfg pagination.Config
+	Logger        *slog.Logger // nil = slog.Default()
 }
 
 // PaginatedResponse represents the response format for paginated search
@@ -27,72 +31,150 @@ type PaginatedResponse struct {
 
 // ServeHTTP 記事検索（ページネーション付き）
 // @Summary      記事検索（ページネーション付き）
-// @Description  マルチキーワードで記事を検索します（AND論理）、ページネーション対応
+// @Description  マルチキーワードで記事を検索します（AND論理）、ページネーション対応。キーワード指定時は関連度順（同順位は公開日時の新しい順）。sort / order で並び順を変更できます
 // @Tags         articles
 // @Security     BearerAuth
+// @Description  Accept: text/csv / application/x-ndjson では現在のページの記事を GET /articles/export と同じ列で行ごとに返し、ページ情報は X-Total-Count / X-Total-Pages / X-Next-Cursor ヘッダで返します（ETag なし）。
 // @Produce      json
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Param        If-None-Match header string false "前回受け取った ETag"
 // @Param        keyword query string false "検索キーワード（スペース区切り）"
 // @Param        source_id query int false "ソースIDでフィルタ"
+// @Param        group_id query int false "ソースグループIDでフィルタ"
 // @Param        from query string false "公開日時の開始（ISO 8601）"
 // @Param        to query string false "公開日時の終了（ISO 8601）"
+// @Param        tag query string false "タグ名でフィルタ"
+// @Param        unread_only query bool false "true で呼び出し元ユーザーの未読記事のみ"
+// @Param        favorites query bool false "true で呼び出し元ユーザーのお気に入り記事のみ"
+// @Param        subscribed query bool false "true で呼び出し元ユーザーが購読中のソースの記事のみ(admin 以外のアカウントは常に購読中のソースに限定)"
+// @Param        collapse_duplicates query bool false "true で近似重複記事（他ソースの同一記事）をまとめ、各グループの元記事のみ返す"
+// @Param        sort query string false "並び順のキー（published_at / created_at / title / relevance、relevance はキーワード指定時のみ）" Enums(published_at, created_at, title, relevance)
+// @Param        order query string false "昇順・降順（title は asc、それ以外は desc がデフォルト）" Enums(asc, desc)
 // @Param        page query int false "ページ番号（1-indexed、デフォルト: 1）"
-// @Param        limit query int false "1ページあたりの件数（デフォルト: 10、最大: 100）"
+// @Param        limit query int false "1ページあたりの件数（デフォルト: 10、最大: 100。上限は呼び出し元の区分ごとに PAGINATION_MAX_LIMIT_* で変更可）"
+// @Param        cursor query string false "カーソルページネーション。空文字で1ページ目、以降は前レスポンスの next_cursor（page とは併用不可、結果は公開日時の新しい順）"
 // @Success      200 {object} PaginatedResponse "検索結果（ページネーション付き）"
+// @Header       200 {string} ETag "レスポンス本文の弱い ETag（JSON のみ）"
+// @Header       200 {integer} X-Total-Count "総件数（CSV / NDJSON のみ）"
+// @Header       200 {integer} X-Total-Pages "総ページ数（CSV / NDJSON のみ）"
+// @Header       200 {string} X-Next-Cursor "次ページのカーソル（CSV / NDJSON、cursor 指定時のみ）"
+// @Success      304 "If-None-Match が現在の ETag と一致(本文なし)"
 // @Failure      400 {object} respond.ErrorResponse "Bad request"
 // @Failure      401 {object} respond.ErrorResponse "Authentication required"
 // @Failure      429 {string} string "Too many requests - rate limit exceeded"
 // @Failure      500 {object} respond.ErrorResponse "Server error"
 // @Router       /articles/search [get]
 func (h SearchPaginatedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	format := listFormat(w, r)
+
 	// Parse pagination parameters
 	paginationParams, err := pagination.ParseQueryParams(r, h.PaginationCfg)
 	if err != nil {
 		respond.SafeError(w, http.StatusBadRequest, err)
 		return
 	}
+	useCursor, after, err := pagination.ParseCursor(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	keywords, filters, err := parseSearchCriteria(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if useCursor && filters.Sort != (repository.ArticleSort{}) {
+		respond.SafeError(w, http.StatusBadRequest, errSortWithCursor)
+		return
+	}
+
+	// Execute search with filters and pagination
+	var result *artUC.PaginatedResult
+	if useCursor {
+		result, err = h.Svc.ListWithSourceAfter(r.Context(), keywords, filters, after, paginationParams.Limit)
+	} else {
+		result, err = h.Svc.SearchWithFiltersPaginated(
+			r.Context(),
+			keywords,
+			filters,
+			paginationParams.Page,
+			paginationParams.Limit,
+		)
+	}
+	if err != nil {
+		respond.SafeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if format != exportJSON {
+		writeRows(w, r, h.Logger, format, result)
+		return
+	}
+
+	// Convert to DTO
+	out := make([]DTO, 0, len(result.Data))
+	for _, item := range result.Data {
+		out = append(out, DTO{
+			ID:          item.Article.ID,
+			SourceID:    item.Article.SourceID,
+			SourceName:  item.SourceName,
+			Title:       item.Article.Title,
+			URL:         item.Article.URL,
+			Summary:     item.Article.Summary,
+			PublishedAt: item.Article.PublishedAt,
+			CrawledAt:   item.Article.CrawledAt,
+			ImageURL:    item.Article.ImageURL,
+		})
+	}
+	if err := markFavorited(r.Context(), h.Svc, out); err != nil {
+		respond.SafeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Return paginated response
+	respond.JSONWithETag(w, r, http.StatusOK, PaginatedResponse{
+		Data:       out,
+		Pagination: result.Pagination,
+	})
+}
 
+// parseSearchCriteria reads the keyword, filter and sort query parameters
+// shared by GET /articles/search and GET /articles/export.
+func parseSearchCriteria(r *http.Request) ([]string, repository.ArticleSearchFilters, error) {
 	// Parse keyword parameter (optional - allows browsing with filters only)
 	kw := r.URL.Query().Get("keyword")
-	var keywords []string
+	var (
+		keywords []string
+		filters  repository.ArticleSearchFilters
+		err      error
+	)
 	if kw != "" {
 		// Parse and validate keywords
 		keywords, err = search.ParseKeywords(kw, search.DefaultMaxKeywordCount, search.DefaultMaxKeywordLength)
 		if err != nil {
-			respond.SafeError(w, http.StatusBadRequest,
-				fmt.Errorf("invalid keyword: %w", err))
-			return
+			return nil, filters, fmt.Errorf("invalid keyword: %w", err)
 		}
 	} else {
 		// Empty keyword - return all articles with pagination (filtered if filters provided)
 		keywords = []string{}
 	}
 
-	// Build filters
-	var filters repository.ArticleSearchFilters
-
 	// Parse source_id if provided
-	if sourceIDStr := r.URL.Query().Get("source_id"); sourceIDStr != "" {
-		sourceID, err := strconv.ParseInt(sourceIDStr, 10, 64)
-		if err != nil {
-			respond.SafeError(w, http.StatusBadRequest,
-				errors.New("invalid source_id: must be a valid integer"))
-			return
-		}
-		if sourceID <= 0 {
-			respond.SafeError(w, http.StatusBadRequest,
-				errors.New("invalid source_id: must be positive"))
-			return
-		}
-		filters.SourceID = &sourceID
+	filters.SourceID, err = parseSourceIDParam(r)
+	if err != nil {
+		return nil, filters, err
+	}
+	filters.GroupID, err = parseGroupIDParam(r)
+	if err != nil {
+		return nil, filters, err
 	}
 
 	// Parse from date if provided
 	if fromStr := r.URL.Query().Get("from"); fromStr != "" {
 		from, err := validation.ParseDateISO8601(fromStr)
 		if err != nil {
-			respond.SafeError(w, http.StatusBadRequest,
-				fmt.Errorf("invalid from date: %w", err))
-			return
+			return nil, filters, fmt.Errorf("invalid from date: %w", err)
 		}
 		filters.From = from
 	}
@@ -101,53 +183,225 @@ func (h SearchPaginatedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 	if toStr := r.URL.Query().Get("to"); toStr != "" {
 		to, err := validation.ParseDateISO8601(toStr)
 		if err != nil {
-			respond.SafeError(w, http.StatusBadRequest,
-				fmt.Errorf("invalid to date: %w", err))
-			return
+			return nil, filters, fmt.Errorf("invalid to date: %w", err)
 		}
 		filters.To = to
 	}
 
+	// Parse tag if provided
+	tag, err := parseTagParam(r)
+	if err != nil {
+		return nil, filters, err
+	}
+	filters.Tag = tag
+
+	unreadFor, err := parseUnreadOnlyParam(r)
+	if err != nil {
+		return nil, filters, err
+	}
+	filters.UnreadFor = unreadFor
+
+	favoritesOf, err := parseFavoritesParam(r)
+	if err != nil {
+		return nil, filters, err
+	}
+	filters.FavoritesOf = favoritesOf
+
+	filters.SubscribedBy, err = parseSubscribedParam(r)
+	if err != nil {
+		return nil, filters, err
+	}
+
+	filters.CollapseDuplicates, err = parseCollapseDuplicatesParam(r)
+	if err != nil {
+		return nil, filters, err
+	}
+
+	filters.Sort, err = parseSortParams(r, len(keywords) > 0)
+	if err != nil {
+		return nil, filters, err
+	}
 	// Validate date range: from <= to
 	if filters.From != nil && filters.To != nil {
 		if filters.From.After(*filters.To) {
-			respond.SafeError(w, http.StatusBadRequest,
-				errors.New("invalid date range: from date must be before or equal to to date"))
-			return
+			return nil, filters, errors.New("invalid date range: from date must be before or equal to to date")
 		}
 	}
 
-	// Execute search with filters and pagination
-	result, err := h.Svc.SearchWithFiltersPaginated(
-		r.Context(),
-		keywords,
-		filters,
-		paginationParams.Page,
-		paginationParams.Limit,
-	)
+	return keywords, filters, nil
+}
+
+// parseSourceIDParam reads the optional source_id query parameter.
+// Returns nil when the parameter is absent.
+func parseSourceIDParam(r *http.Request) (*int64, error) {
+	raw := r.URL.Query().Get("source_id")
+	if raw == "" {
+		return nil, nil
+	}
+	sourceID, err := strconv.ParseInt(raw, 10, 64)
 	if err != nil {
-		respond.SafeError(w, http.StatusInternalServerError, err)
-		return
+		return nil, errors.New("invalid source_id: must be a valid integer")
+	}
+	if sourceID <= 0 {
+		return nil, errors.New("invalid source_id: must be positive")
 	}
+	return &sourceID, nil
+}
 
-	// Convert to DTO
-	out := make([]DTO, 0, len(result.Data))
-	for _, item := range result.Data {
-		out = append(out, DTO{
-			ID:          item.Article.ID,
-			SourceID:    item.Article.SourceID,
-			SourceName:  item.SourceName,
-			Title:       item.Article.Title,
-			URL:         item.Article.URL,
-			Summary:     item.Article.Summary,
-			PublishedAt: item.Article.PublishedAt,
-			CrawledAt:   item.Article.CrawledAt,
-		})
+// parseGroupIDParam reads the optional group_id query parameter (source
+// group). Returns nil when the parameter is absent.
+func parseGroupIDParam(r *http.Request) (*int64, error) {
+	raw := r.URL.Query().Get("group_id")
+	if raw == "" {
+		return nil, nil
 	}
+	groupID, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid group_id: must be a valid integer")
+	}
+	if groupID <= 0 {
+		return nil, errors.New("invalid group_id: must be positive")
+	}
+	return &groupID, nil
+}
 
-	// Return paginated response
-	respond.JSON(w, http.StatusOK, PaginatedResponse{
-		Data:       out,
-		Pagination: result.Pagination,
-	})
+// parseTagParam reads the optional tag query parameter, normalized the way
+// tag names are stored. Returns nil when the parameter is absent.
+func parseTagParam(r *http.Request) (*string, error) {
+	raw := r.URL.Query().Get("tag")
+	if raw == "" {
+		return nil, nil
+	}
+	tag := entity.NormalizeTagName(raw)
+	if err := entity.ValidateTagName(tag); err != nil {
+		return nil, fmt.Errorf("invalid tag: %w", err)
+	}
+	return &tag, nil
+}
+
+// sortFields whitelists the values of the sort query parameter.
+var sortFields = map[string]repository.ArticleSortField{
+	"published_at": repository.ArticleSortPublishedAt,
+	"created_at":   repository.ArticleSortCreatedAt,
+	"title":        repository.ArticleSortTitle,
+	"relevance":    repository.ArticleSortRelevance,
+}
+
+// parseSortParams reads the optional sort and order query parameters.
+// order defaults to asc for title and desc otherwise; order alone applies
+// to the default key (relevance with keywords, published_at without).
+// relevance is only accepted with keywords. Returns the zero ArticleSort
+// (default order) when both parameters are absent.
+func parseSortParams(r *http.Request, hasKeywords bool) (repository.ArticleSort, error) {
+	query := r.URL.Query()
+	rawSort, rawOrder := query.Get("sort"), query.Get("order")
+	if rawSort == "" && rawOrder == "" {
+		return repository.ArticleSort{}, nil
+	}
+
+	field := repository.ArticleSortPublishedAt
+	if hasKeywords {
+		field = repository.ArticleSortRelevance
+	}
+	if rawSort != "" {
+		var ok bool
+		if field, ok = sortFields[rawSort]; !ok {
+			return repository.ArticleSort{}, errors.New("invalid sort: must be one of published_at, created_at, title, relevance")
+		}
+		if field == repository.ArticleSortRelevance && !hasKeywords {
+			return repository.ArticleSort{}, errors.New("invalid sort: relevance requires a keyword")
+		}
+	}
+
+	sort := repository.ArticleSort{Field: field, Ascending: field == repository.ArticleSortTitle}
+	switch rawOrder {
+	case "":
+	case "asc":
+		sort.Ascending = true
+	case "desc":
+		sort.Ascending = false
+	default:
+		return repository.ArticleSort{}, errors.New("invalid order: must be asc or desc")
+	}
+	return sort, nil
+}
+
+// errSortWithCursor rejects sort/order in cursor mode, whose keyset fixes
+// the order to published_at descending.
+var errSortWithCursor = errors.New("invalid query parameter: sort and order cannot be combined with cursor")
+
+// parseUnreadOnlyParam reads the optional unread_only query parameter and
+// returns the caller's subject to filter by when it is true. Returns nil
+// when the parameter is absent or false.
+func parseUnreadOnlyParam(r *http.Request) (*string, error) {
+	return parseSubjectFlag(r, "unread_only")
+}
+
+// parseFavoritesParam is parseUnreadOnlyParam for the favorites query
+// parameter.
+func parseFavoritesParam(r *http.Request) (*string, error) {
+	return parseSubjectFlag(r, "favorites")
+}
+
+// parseSubscribedParam returns the caller's subject when the results are
+// scoped to the caller's source subscriptions: always for dashboard
+// accounts other than admins (subscribed=false cannot lift it), and for
+// admins and API keys only with subscribed=true — they keep the global
+// view by default.
+func parseSubscribedParam(r *http.Request) (*string, error) {
+	subject, err := parseSubjectFlag(r, "subscribed")
+	if err != nil || subject != nil {
+		return subject, err
+	}
+	if auth.SubscriptionScoped(r.Context()) {
+		subject := auth.SubjectFromContext(r.Context())
+		return &subject, nil
+	}
+	return nil, nil
+}
+
+// hiddenBySubscription reports whether the article of sourceID is outside
+// a subscription-scoped caller's sources. Such an article answers 404 like
+// a missing one, so its existence is not disclosed.
+func hiddenBySubscription(r *http.Request, svc artUC.Service, sourceID int64) (bool, error) {
+	if !auth.SubscriptionScoped(r.Context()) {
+		return false, nil
+	}
+	sources, err := svc.SubscribedSourceIDs(r.Context(), auth.SubjectFromContext(r.Context()))
+	if err != nil {
+		return false, err
+	}
+	return !sources[sourceID], nil
+}
+
+// parseCollapseDuplicatesParam reads the optional collapse_duplicates
+// query parameter (false when absent).
+func parseCollapseDuplicatesParam(r *http.Request) (bool, error) {
+	raw := r.URL.Query().Get("collapse_duplicates")
+	if raw == "" {
+		return false, nil
+	}
+	on, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, errors.New("invalid collapse_duplicates: must be true or false")
+	}
+	return on, nil
+}
+
+// parseSubjectFlag reads a boolean query parameter; when it is true the
+// caller's subject is returned as the per-user filter value.
+func parseSubjectFlag(r *http.Request, name string) (*string, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	on, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: must be true or false", name)
+	}
+	if !on {
+		return nil, nil
+	}
+	subject := auth.SubjectFromContext(r.Context())
+	return &subject, nil
 }
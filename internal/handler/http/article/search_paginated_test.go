@@ -2,16 +2,20 @@ package article_test
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"catchup-feed/internal/common/pagination"
 	"catchup-feed/internal/domain/entity"
 	"catchup-feed/internal/handler/http/article"
+	"catchup-feed/internal/handler/http/auth"
 	"catchup-feed/internal/repository"
 	artUC "catchup-feed/internal/usecase/article"
 )
@@ -23,6 +27,9 @@ type stubSearchPaginatedRepo struct {
 	totalCount      int64
 	searchErr       error
 	countErr        error
+	lastFilters     repository.ArticleSearchFilters
+	lastKeywords    []string
+	lastAfter       *repository.ArticleCursor
 }
 
 func (s *stubSearchPaginatedRepo) List(_ context.Context) ([]*entity.Article, error) {
@@ -53,6 +60,10 @@ func (s *stubSearchPaginatedRepo) Delete(_ context.Context, _ int64) error {
 	return nil
 }
 
+func (s *stubSearchPaginatedRepo) DeleteBatch(_ context.Context, _ []int64) ([]int64, error) {
+	return nil, nil
+}
+
 func (s *stubSearchPaginatedRepo) ListUnsummarized(_ context.Context, _ int) ([]*entity.Article, error) {
 	return nil, nil
 }
@@ -64,6 +75,10 @@ func (s *stubSearchPaginatedRepo) ExistsByURLBatch(_ context.Context, _ []string
 	return nil, nil
 }
 
+func (s *stubSearchPaginatedRepo) ExistsByContentHashBatch(_ context.Context, _ []string) (map[string]bool, error) {
+	return map[string]bool{}, nil
+}
+
 func (s *stubSearchPaginatedRepo) GetWithSource(_ context.Context, _ int64) (*entity.Article, string, error) {
 	return nil, "", nil
 }
@@ -87,7 +102,8 @@ func (s *stubSearchPaginatedRepo) CountArticlesWithFilters(_ context.Context, _
 	return s.totalCount, nil
 }
 
-func (s *stubSearchPaginatedRepo) SearchWithFiltersPaginated(_ context.Context, _ []string, _ repository.ArticleSearchFilters, offset, limit int) ([]repository.ArticleWithSource, error) {
+func (s *stubSearchPaginatedRepo) SearchWithFiltersPaginated(_ context.Context, _ []string, filters repository.ArticleSearchFilters, offset, limit int) ([]repository.ArticleWithSource, error) {
+	s.lastFilters = filters
 	if s.searchErr != nil {
 		return nil, s.searchErr
 	}
@@ -102,6 +118,41 @@ func (s *stubSearchPaginatedRepo) SearchWithFiltersPaginated(_ context.Context,
 	return s.articlesWithSrc[offset:end], nil
 }
 
+func (s *stubSearchPaginatedRepo) ListWithSourceAfter(_ context.Context, keywords []string, filters repository.ArticleSearchFilters, after *repository.ArticleCursor, limit int) ([]repository.ArticleWithSource, error) {
+	s.lastFilters = filters
+	s.lastKeywords = keywords
+	s.lastAfter = after
+	if s.searchErr != nil {
+		return nil, s.searchErr
+	}
+	// articlesWithSrc is assumed to be in listing order already
+	start := 0
+	if after != nil {
+		for i, a := range s.articlesWithSrc {
+			if a.Article.ID == after.ID {
+				start = i + 1
+				break
+			}
+		}
+	}
+	end := start + limit
+	if end > len(s.articlesWithSrc) {
+		end = len(s.articlesWithSrc)
+	}
+	return s.articlesWithSrc[start:end], nil
+}
+
+func (s *stubSearchPaginatedRepo) ForEachWithSource(_ context.Context, keywords []string, filters repository.ArticleSearchFilters, fn func(repository.ArticleWithSource) error) error {
+	s.lastFilters = filters
+	s.lastKeywords = keywords
+	for _, a := range s.articlesWithSrc {
+		if err := fn(a); err != nil {
+			return err
+		}
+	}
+	return s.searchErr
+}
+
 /* ───────── テストケース ───────── */
 
 // TestSearchPaginated_ValidRequest tests basic search with keyword
@@ -392,6 +443,8 @@ func TestSearchPaginated_InvalidSourceID(t *testing.T) {
 		{"non-integer source_id", "source_id=abc"},
 		{"negative source_id", "source_id=-1"},
 		{"zero source_id", "source_id=0"},
+		{"non-integer group_id", "group_id=abc"},
+		{"zero group_id", "group_id=0"},
 	}
 
 	for _, tt := range tests {
@@ -939,6 +992,663 @@ func TestSearchPaginated_NoKeyword(t *testing.T) {
 func (s *stubSearchPaginatedRepo) CreateWithSummary(_ context.Context, _ *entity.Article, _ *entity.Summary) error {
 	return nil
 }
+func (s *stubSearchPaginatedRepo) CreateBatch(_ context.Context, _ []repository.NewArticle) (int, error) {
+	return 0, nil
+}
 func (s *stubSearchPaginatedRepo) CreateWithTranscribeJob(_ context.Context, _ *entity.Article, _, _ string) error {
 	return nil
 }
+
+// TestSearchPaginated_TagFilter: tag は保存時と同じ正規化をしてから渡す。
+func TestSearchPaginated_TagFilter(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubSearchPaginatedRepo{}
+	handler := article.SearchPaginatedHandler{
+		Svc:           artUC.Service{Repo: stub},
+		PaginationCfg: pagination.DefaultConfig(),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/articles/search?tag=%20Machine%20%20Learning", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	if stub.lastFilters.Tag == nil || *stub.lastFilters.Tag != "machine learning" {
+		t.Errorf("Tag filter = %v, want %q", stub.lastFilters.Tag, "machine learning")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/articles/search?tag=a,b", nil)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("invalid tag: status code = %d, want %d", rr.Code, http.StatusBadRequest)
+	}
+}
+
+// TestListHandler_TagFilter: GET /articles?tag= はフィルタ付き検索に切り替わる。
+func TestListHandler_TagFilter(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubSearchPaginatedRepo{
+		articlesWithSrc: []repository.ArticleWithSource{{
+			Article:    &entity.Article{ID: 1, SourceID: 10, Title: "Go 1.26"},
+			SourceName: "Go Blog",
+		}},
+		totalCount: 1,
+	}
+	handler := article.ListHandler{
+		Svc:           artUC.Service{Repo: stub},
+		PaginationCfg: pagination.DefaultConfig(),
+		Logger:        slog.Default(),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/articles?tag=Go", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	if stub.lastFilters.Tag == nil || *stub.lastFilters.Tag != "go" {
+		t.Errorf("Tag filter = %v, want %q", stub.lastFilters.Tag, "go")
+	}
+	var resp pagination.Response[article.DTO]
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Data) != 1 {
+		t.Errorf("response = %+v, want 1 article", resp)
+	}
+}
+
+// TestListHandler_GroupFilter: GET /articles?group_id= はソースグループで絞り込む。
+func TestListHandler_GroupFilter(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubSearchPaginatedRepo{}
+	handler := article.ListHandler{
+		Svc:           artUC.Service{Repo: stub},
+		PaginationCfg: pagination.DefaultConfig(),
+		Logger:        slog.Default(),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/articles?group_id=3", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	if stub.lastFilters.GroupID == nil || *stub.lastFilters.GroupID != 3 {
+		t.Errorf("GroupID filter = %v, want 3", stub.lastFilters.GroupID)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/articles?group_id=-1", nil)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("invalid group_id: status code = %d, want %d", rr.Code, http.StatusBadRequest)
+	}
+}
+
+// TestListHandler_UnreadOnly: unread_only=true は呼び出し元の未読記事に絞り込む。
+func TestListHandler_UnreadOnly(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubSearchPaginatedRepo{}
+	handler := article.ListHandler{
+		Svc:           artUC.Service{Repo: stub},
+		PaginationCfg: pagination.DefaultConfig(),
+		Logger:        slog.Default(),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/articles?unread_only=true", nil)
+	req = req.WithContext(auth.WithIdentity(req.Context(), "alice@example.com", auth.RoleViewer))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	if stub.lastFilters.UnreadFor == nil || *stub.lastFilters.UnreadFor != "alice@example.com" {
+		t.Errorf("UnreadFor filter = %v, want %q", stub.lastFilters.UnreadFor, "alice@example.com")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/articles?unread_only=maybe", nil)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("invalid unread_only: status code = %d, want %d", rr.Code, http.StatusBadRequest)
+	}
+}
+
+// TestSearchPaginated_UnreadOnlyFalse: unread_only=false はフィルタしない。
+func TestSearchPaginated_UnreadOnlyFalse(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubSearchPaginatedRepo{}
+	handler := article.SearchPaginatedHandler{
+		Svc:           artUC.Service{Repo: stub},
+		PaginationCfg: pagination.DefaultConfig(),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/articles/search?keyword=go&unread_only=false", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	if stub.lastFilters.UnreadFor != nil {
+		t.Errorf("UnreadFor filter = %v, want nil", *stub.lastFilters.UnreadFor)
+	}
+}
+
+// TestListHandler_SubscriptionScope: admin 以外のアカウントは常に購読中の
+// ソースに絞り込まれ、admin は subscribed=true のときだけ絞り込まれる。
+func TestListHandler_SubscriptionScope(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		query string
+		role  string
+		want  bool
+	}{
+		{"custom role is scoped", "", "editor", true},
+		{"custom role cannot opt out", "?subscribed=false", "editor", true},
+		{"admin keeps the global view", "", auth.RoleAdmin, false},
+		{"admin opts in", "?subscribed=true", auth.RoleAdmin, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubSearchPaginatedRepo{}
+			handler := article.ListHandler{
+				Svc:           artUC.Service{Repo: stub},
+				PaginationCfg: pagination.DefaultConfig(),
+				Logger:        slog.Default(),
+			}
+			req := httptest.NewRequest(http.MethodGet, "/articles"+tt.query, nil)
+			req = req.WithContext(auth.WithIdentity(req.Context(), "alice@example.com", tt.role))
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+			}
+			got := stub.lastFilters.SubscribedBy
+			if tt.want && (got == nil || *got != "alice@example.com") {
+				t.Errorf("SubscribedBy filter = %v, want %q", got, "alice@example.com")
+			}
+			if !tt.want && got != nil {
+				t.Errorf("SubscribedBy filter = %q, want nil", *got)
+			}
+		})
+	}
+
+	handler := article.SearchPaginatedHandler{
+		Svc:           artUC.Service{Repo: &stubSearchPaginatedRepo{}},
+		PaginationCfg: pagination.DefaultConfig(),
+	}
+	req := httptest.NewRequest(http.MethodGet, "/articles/search?keyword=go&subscribed=maybe", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("invalid subscribed: status code = %d, want %d", rr.Code, http.StatusBadRequest)
+	}
+}
+
+// stubFavoriteLookup reports fixed favorites for one subject.
+type stubFavoriteLookup struct {
+	subject string
+	ids     map[int64]bool
+}
+
+func (s stubFavoriteLookup) FavoritedIDs(_ context.Context, subject string, _ []int64) (map[int64]bool, error) {
+	if subject != s.subject {
+		return map[int64]bool{}, nil
+	}
+	return s.ids, nil
+}
+
+// TestListHandler_Favorites: favorites=true はお気に入りに絞り込み、
+// 応答の favorited フラグは呼び出し元のお気に入りを反映する。
+func TestListHandler_Favorites(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubSearchPaginatedRepo{
+		articlesWithSrc: []repository.ArticleWithSource{
+			{Article: &entity.Article{ID: 1, SourceID: 10, Title: "Go 1.26"}, SourceName: "Go Blog"},
+			{Article: &entity.Article{ID: 2, SourceID: 10, Title: "Go 1.27"}, SourceName: "Go Blog"},
+		},
+		totalCount: 2,
+	}
+	handler := article.ListHandler{
+		Svc: artUC.Service{
+			Repo:      stub,
+			Favorites: stubFavoriteLookup{subject: "alice@example.com", ids: map[int64]bool{2: true}},
+		},
+		PaginationCfg: pagination.DefaultConfig(),
+		Logger:        slog.Default(),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/articles?favorites=true", nil)
+	req = req.WithContext(auth.WithIdentity(req.Context(), "alice@example.com", auth.RoleViewer))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	if stub.lastFilters.FavoritesOf == nil || *stub.lastFilters.FavoritesOf != "alice@example.com" {
+		t.Errorf("FavoritesOf filter = %v, want %q", stub.lastFilters.FavoritesOf, "alice@example.com")
+	}
+	var resp pagination.Response[article.DTO]
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Data) != 2 || resp.Data[0].Favorited || !resp.Data[1].Favorited {
+		t.Errorf("favorited flags = %+v, want [false true]", resp.Data)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/articles?favorites=yes", nil)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("invalid favorites: status code = %d, want %d", rr.Code, http.StatusBadRequest)
+	}
+}
+
+// TestListHandler_Cursor: cursor= で1ページ目、next_cursor で続きを取得する。
+func TestListHandler_Cursor(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	stub := &stubSearchPaginatedRepo{
+		articlesWithSrc: []repository.ArticleWithSource{
+			{Article: &entity.Article{ID: 3, Title: "c", PublishedAt: now}, SourceName: "S"},
+			{Article: &entity.Article{ID: 2, Title: "b", PublishedAt: now.Add(-time.Hour)}, SourceName: "S"},
+			{Article: &entity.Article{ID: 1, Title: "a"}, SourceName: "S"},
+		},
+		totalCount: 3,
+	}
+	handler := article.ListHandler{
+		Svc:           artUC.Service{Repo: stub},
+		PaginationCfg: pagination.DefaultConfig(),
+		Logger:        slog.Default(),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/articles?cursor=&limit=2", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	var first pagination.Response[article.DTO]
+	if err := json.NewDecoder(rr.Body).Decode(&first); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(first.Data) != 2 || first.Pagination.NextCursor == "" || first.Pagination.Page != 0 {
+		t.Fatalf("first page = %+v, want 2 articles and a next_cursor", first)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/articles?limit=2&cursor="+first.Pagination.NextCursor, nil)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	if stub.lastAfter == nil || stub.lastAfter.ID != 2 {
+		t.Errorf("after = %+v, want id 2", stub.lastAfter)
+	}
+	var second pagination.Response[article.DTO]
+	if err := json.NewDecoder(rr.Body).Decode(&second); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(second.Data) != 1 || second.Data[0].ID != 1 || second.Pagination.NextCursor != "" {
+		t.Errorf("second page = %+v, want article 1 and no next_cursor", second)
+	}
+}
+
+// TestSearchPaginated_Cursor: 検索でもキーワードとフィルタがカーソル取得に渡る。
+func TestSearchPaginated_Cursor(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubSearchPaginatedRepo{
+		articlesWithSrc: []repository.ArticleWithSource{
+			{Article: &entity.Article{ID: 5, Title: "Go"}, SourceName: "S"},
+		},
+		totalCount: 1,
+	}
+	handler := article.SearchPaginatedHandler{
+		Svc:           artUC.Service{Repo: stub},
+		PaginationCfg: pagination.DefaultConfig(),
+	}
+
+	cursor := pagination.Cursor{ID: 9}.Encode()
+	req := httptest.NewRequest(http.MethodGet, "/articles/search?keyword=go&source_id=10&cursor="+cursor, nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	if len(stub.lastKeywords) != 1 || stub.lastKeywords[0] != "go" {
+		t.Errorf("keywords = %v, want [go]", stub.lastKeywords)
+	}
+	if stub.lastFilters.SourceID == nil || *stub.lastFilters.SourceID != 10 {
+		t.Errorf("SourceID filter = %v, want 10", stub.lastFilters.SourceID)
+	}
+	if stub.lastAfter == nil || stub.lastAfter.ID != 9 || stub.lastAfter.PublishedAt != nil {
+		t.Errorf("after = %+v, want undated id 9", stub.lastAfter)
+	}
+}
+
+func TestSearchPaginated_InvalidCursor(t *testing.T) {
+	t.Parallel()
+
+	handler := article.SearchPaginatedHandler{
+		Svc:           artUC.Service{Repo: &stubSearchPaginatedRepo{}},
+		PaginationCfg: pagination.DefaultConfig(),
+	}
+
+	for _, query := range []string{"cursor=bm90LWEtY3Vyc29y", "cursor=&page=2"} {
+		req := httptest.NewRequest(http.MethodGet, "/articles/search?"+query, nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: status code = %d, want %d", query, rr.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestSearchPaginated_Sort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		query string
+		want  repository.ArticleSort
+	}{
+		{"no sort keeps the default order", "keyword=go", repository.ArticleSort{}},
+		{"title defaults to ascending", "keyword=go&sort=title", repository.ArticleSort{Field: repository.ArticleSortTitle, Ascending: true}},
+		{"created_at descending", "sort=created_at&order=desc", repository.ArticleSort{Field: repository.ArticleSortCreatedAt}},
+		{"relevance with keyword", "keyword=go&sort=relevance&order=asc", repository.ArticleSort{Field: repository.ArticleSortRelevance, Ascending: true}},
+		{"order alone applies to relevance", "keyword=go&order=asc", repository.ArticleSort{Field: repository.ArticleSortRelevance, Ascending: true}},
+		{"order alone applies to published_at", "source_id=1&order=asc", repository.ArticleSort{Field: repository.ArticleSortPublishedAt, Ascending: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubSearchPaginatedRepo{}
+			handler := article.SearchPaginatedHandler{
+				Svc:           artUC.Service{Repo: stub},
+				PaginationCfg: pagination.DefaultConfig(),
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/articles/search?"+tt.query, nil)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+			}
+			if stub.lastFilters.Sort != tt.want {
+				t.Errorf("Sort = %+v, want %+v", stub.lastFilters.Sort, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchPaginated_InvalidSort(t *testing.T) {
+	t.Parallel()
+
+	handler := article.SearchPaginatedHandler{
+		Svc:           artUC.Service{Repo: &stubSearchPaginatedRepo{}},
+		PaginationCfg: pagination.DefaultConfig(),
+	}
+
+	for _, query := range []string{
+		"keyword=go&sort=url",
+		"keyword=go&order=up",
+		"sort=relevance",
+		"keyword=go&sort=title&cursor=",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/articles/search?"+query, nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: status code = %d, want %d", query, rr.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+// TestListHandler_Sort: sort だけでもフィルタ付き検索経由で並び替える。
+func TestListHandler_Sort(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubSearchPaginatedRepo{
+		articlesWithSrc: []repository.ArticleWithSource{{
+			Article:    &entity.Article{ID: 1, SourceID: 10, Title: "A"},
+			SourceName: "Go Blog",
+		}},
+		totalCount: 1,
+	}
+	handler := article.ListHandler{
+		Svc:           artUC.Service{Repo: stub},
+		PaginationCfg: pagination.DefaultConfig(),
+		Logger:        slog.Default(),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/articles?sort=title&order=desc", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	if want := (repository.ArticleSort{Field: repository.ArticleSortTitle}); stub.lastFilters.Sort != want {
+		t.Errorf("Sort = %+v, want %+v", stub.lastFilters.Sort, want)
+	}
+
+	// 記事一覧にはキーワードがないため relevance は指定できない
+	req = httptest.NewRequest(http.MethodGet, "/articles?sort=relevance", nil)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("sort=relevance: status code = %d, want %d", rr.Code, http.StatusBadRequest)
+	}
+}
+
+// TestListHandler_CollapseDuplicates: collapse_duplicates=true は近似重複を
+// まとめ、各グループの元記事のみ返す。
+func TestListHandler_CollapseDuplicates(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubSearchPaginatedRepo{}
+	handler := article.ListHandler{
+		Svc:           artUC.Service{Repo: stub},
+		PaginationCfg: pagination.DefaultConfig(),
+		Logger:        slog.Default(),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/articles?collapse_duplicates=true", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	if !stub.lastFilters.CollapseDuplicates {
+		t.Error("CollapseDuplicates filter = false, want true")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/articles?collapse_duplicates=1x", nil)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("invalid collapse_duplicates: status code = %d, want %d", rr.Code, http.StatusBadRequest)
+	}
+}
+
+// TestSearchPaginated_CollapseDuplicates: 検索でも collapse_duplicates を受け付ける。
+func TestSearchPaginated_CollapseDuplicates(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubSearchPaginatedRepo{}
+	handler := article.SearchPaginatedHandler{
+		Svc:           artUC.Service{Repo: stub},
+		PaginationCfg: pagination.DefaultConfig(),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/articles/search?keyword=go&collapse_duplicates=true", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	if !stub.lastFilters.CollapseDuplicates {
+		t.Error("CollapseDuplicates filter = false, want true")
+	}
+}
+
+/* ───────── Accept による形式の切り替え ───────── */
+
+// TestSearchPaginated_AcceptCSV: Accept: text/csv ではページの記事を
+// エクスポートと同じ列の CSV で返し、ページ情報はヘッダに載る。
+func TestSearchPaginated_AcceptCSV(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubSearchPaginatedRepo{
+		articlesWithSrc: []repository.ArticleWithSource{
+			{Article: &entity.Article{ID: 1, SourceID: 10, Title: "Go, 1.23", URL: "https://example.com/1"}, SourceName: "Tech Blog"},
+			{Article: &entity.Article{ID: 2, SourceID: 10, Title: "Go Tutorial", URL: "https://example.com/2"}, SourceName: "Tech Blog"},
+		},
+		totalCount: 12,
+	}
+	handler := article.SearchPaginatedHandler{
+		Svc:           artUC.Service{Repo: stub},
+		PaginationCfg: pagination.DefaultConfig(),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/articles/search?keyword=Go&limit=2", nil)
+	req.Header.Set("Accept", "text/csv")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != "text/csv; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want text/csv", ct)
+	}
+	if got := rr.Header().Get("X-Total-Count"); got != "12" {
+		t.Errorf("X-Total-Count = %q, want 12", got)
+	}
+	if got := rr.Header().Get("X-Total-Pages"); got != "6" {
+		t.Errorf("X-Total-Pages = %q, want 6", got)
+	}
+	if got := rr.Header().Get("Vary"); got != "Accept" {
+		t.Errorf("Vary = %q, want Accept", got)
+	}
+	if rr.Header().Get("ETag") != "" || rr.Header().Get("Content-Disposition") != "" {
+		t.Errorf("unexpected ETag / Content-Disposition: %v", rr.Header())
+	}
+	records, err := csv.NewReader(rr.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+	if len(records) != 3 || records[0][0] != "id" || records[1][3] != "Go, 1.23" || records[2][0] != "2" {
+		t.Errorf("records = %v, want a header and articles 1, 2", records)
+	}
+}
+
+// TestListHandler_AcceptNDJSON: NDJSON でもカーソルは X-Next-Cursor で続く。
+func TestListHandler_AcceptNDJSON(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	stub := &stubSearchPaginatedRepo{
+		articlesWithSrc: []repository.ArticleWithSource{
+			{Article: &entity.Article{ID: 3, Title: "c", PublishedAt: now}, SourceName: "S"},
+			{Article: &entity.Article{ID: 2, Title: "b", PublishedAt: now.Add(-time.Hour)}, SourceName: "S"},
+			{Article: &entity.Article{ID: 1, Title: "a"}, SourceName: "S"},
+		},
+		totalCount: 3,
+	}
+	handler := article.ListHandler{
+		Svc:           artUC.Service{Repo: stub},
+		PaginationCfg: pagination.DefaultConfig(),
+		Logger:        slog.Default(),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/articles?cursor=&limit=2", nil)
+	req.Header.Set("Accept", "application/x-ndjson, application/json;q=0.5")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != "application/x-ndjson; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want application/x-ndjson", ct)
+	}
+	if rr.Header().Get("X-Next-Cursor") == "" {
+		t.Error("X-Next-Cursor is empty, want the next page's cursor")
+	}
+	lines := strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("lines = %q, want 2 rows", lines)
+	}
+	var row article.ExportDTO
+	if err := json.Unmarshal([]byte(lines[0]), &row); err != nil {
+		t.Fatalf("failed to decode row: %v", err)
+	}
+	if row.ID != 3 || row.SourceName != "S" {
+		t.Errorf("first row = %+v, want article 3", row)
+	}
+}
+
+// TestListHandler_AcceptUnsupported: 対応していない Accept は JSON(ETag 付き)。
+func TestListHandler_AcceptUnsupported(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubSearchPaginatedRepo{
+		articlesWithSrc: []repository.ArticleWithSource{
+			{Article: &entity.Article{ID: 1, Title: "a"}, SourceName: "S"},
+		},
+		totalCount: 1,
+	}
+	handler := article.ListHandler{
+		Svc:           artUC.Service{Repo: stub},
+		PaginationCfg: pagination.DefaultConfig(),
+		Logger:        slog.Default(),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/articles?cursor=", nil)
+	req.Header.Set("Accept", "application/xml")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	if rr.Header().Get("ETag") == "" || rr.Header().Get("X-Total-Count") != "" {
+		t.Errorf("headers = %v, want the JSON envelope with an ETag", rr.Header())
+	}
+	var resp pagination.Response[article.DTO]
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Data) != 1 {
+		t.Errorf("response = %+v, want 1 article", resp)
+	}
+}
@@ -0,0 +1,76 @@
+package article
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/pathutil"
+	"catchup-feed/internal/handler/http/respond"
+	artUC "catchup-feed/internal/usecase/article"
+)
+
+// SummarizeJobDTO is the re-summarization job queued for the worker.
+// status moves pending → running → done / failed.
+type SummarizeJobDTO struct {
+	ID        int64     `json:"id" example:"42"`
+	ArticleID int64     `json:"article_id" example:"1"`
+	Status    string    `json:"status" example:"pending"`
+	Attempts  int       `json:"attempts" example:"0"`
+	LastError *string   `json:"last_error"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type SummarizeHandler struct{ Svc artUC.Service }
+
+// ServeHTTP 記事の再要約
+// @Summary      記事の再要約
+// @Description  設定済みの要約器で記事を要約し直すジョブを登録し、worker で実行します。完了すると記事の要約が置き換わります。
+// @Description  プロンプト変更後や、取得時の要約に失敗した記事の再処理に使います。articles:write スコープが必要です
+// @Tags         articles
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "記事ID"
+// @Success      202 {object} SummarizeJobDTO "登録された再要約ジョブ"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid article ID"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - articles:write が必要"
+// @Failure      404 {object} respond.ErrorResponse "Not found - article not found"
+// @Failure      429 {object} respond.ErrorResponse "Too many requests"
+// @Failure      503 {object} respond.ErrorResponse "ジョブキューが未設定"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /articles/{id}/summarize [post]
+func (h SummarizeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		respond.SafeError(w, http.StatusBadRequest, pathutil.ErrInvalidID)
+		return
+	}
+
+	job, err := h.Svc.Resummarize(r.Context(), id)
+	if err != nil {
+		code := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, artUC.ErrArticleNotFound):
+			code = http.StatusNotFound
+		case errors.Is(err, artUC.ErrResummarizeUnavailable):
+			code = http.StatusServiceUnavailable
+		}
+		respond.SafeError(w, code, err)
+		return
+	}
+	respond.JSON(w, http.StatusAccepted, toSummarizeJobDTO(job, id))
+}
+
+func toSummarizeJobDTO(j *entity.Job, articleID int64) SummarizeJobDTO {
+	return SummarizeJobDTO{
+		ID:        j.ID,
+		ArticleID: articleID,
+		Status:    j.Status,
+		Attempts:  j.Attempts,
+		LastError: j.LastError,
+		CreatedAt: j.CreatedAt,
+	}
+}
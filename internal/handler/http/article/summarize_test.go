@@ -0,0 +1,80 @@
+package article_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/article"
+	"catchup-feed/internal/repository"
+	artUC "catchup-feed/internal/usecase/article"
+)
+
+/* ───────── モック実装 ───────── */
+
+type stubSummarizeJobs struct {
+	repository.JobRepository
+	err error
+}
+
+func (s *stubSummarizeJobs) Enqueue(context.Context, string, json.RawMessage, time.Time) (int64, error) {
+	return 42, s.err
+}
+
+func (s *stubSummarizeJobs) Get(_ context.Context, id int64) (*entity.Job, error) {
+	return &entity.Job{ID: id, Kind: entity.JobKindResummarize, Status: entity.JobStatusPending}, nil
+}
+
+func newSummarizeMux(jobs repository.JobRepository) *http.ServeMux {
+	repo := &stubContentArticleRepo{stubGetRepo: stubGetRepo{article: &entity.Article{ID: 1, Title: "Go 1.26"}}}
+	mux := http.NewServeMux()
+	mux.Handle("POST /articles/{id}/summarize", article.SummarizeHandler{Svc: artUC.Service{Repo: repo, Jobs: jobs}})
+	return mux
+}
+
+/* ───────── テストケース ───────── */
+
+func TestSummarizeHandler_Accepted(t *testing.T) {
+	rr := httptest.NewRecorder()
+	newSummarizeMux(&stubSummarizeJobs{}).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/articles/1/summarize", nil))
+
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("status code = %d, want %d; body=%s", rr.Code, http.StatusAccepted, rr.Body.String())
+	}
+	var got article.SummarizeJobDTO
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.ID != 42 || got.ArticleID != 1 || got.Status != entity.JobStatusPending {
+		t.Errorf("job = %+v, want pending job 42 for article 1", got)
+	}
+}
+
+func TestSummarizeHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		jobs     repository.JobRepository
+		wantCode int
+	}{
+		{"invalid id", "/articles/abc/summarize", &stubSummarizeJobs{}, http.StatusBadRequest},
+		{"zero id", "/articles/0/summarize", &stubSummarizeJobs{}, http.StatusBadRequest},
+		{"article not found", "/articles/9/summarize", &stubSummarizeJobs{}, http.StatusNotFound},
+		{"jobs not configured", "/articles/1/summarize", nil, http.StatusServiceUnavailable},
+		{"enqueue error", "/articles/1/summarize", &stubSummarizeJobs{err: errors.New("db down")}, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			newSummarizeMux(tt.jobs).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, tt.path, nil))
+			if rr.Code != tt.wantCode {
+				t.Errorf("status code = %d, want %d", rr.Code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -54,6 +54,12 @@ func (s *stubUpdateRepo) Create(_ context.Context, _ *entity.Article) error {
 func (s *stubUpdateRepo) Delete(_ context.Context, _ int64) error {
 	return nil
 }
+func (s *stubUpdateRepo) DeleteBatch(_ context.Context, _ []int64) ([]int64, error) {
+	return nil, nil
+}
+func (s *stubUpdateRepo) ForEachWithSource(_ context.Context, _ []string, _ repository.ArticleSearchFilters, _ func(repository.ArticleWithSource) error) error {
+	return nil
+}
 func (s *stubUpdateRepo) ListUnsummarized(_ context.Context, _ int) ([]*entity.Article, error) {
 	return nil, nil
 }
@@ -63,6 +69,10 @@ func (s *stubUpdateRepo) ExistsByURL(_ context.Context, _ string) (bool, error)
 func (s *stubUpdateRepo) ExistsByURLBatch(_ context.Context, _ []string) (map[string]bool, error) {
 	return nil, nil
 }
+
+func (s *stubUpdateRepo) ExistsByContentHashBatch(_ context.Context, _ []string) (map[string]bool, error) {
+	return map[string]bool{}, nil
+}
 func (s *stubUpdateRepo) GetWithSource(_ context.Context, _ int64) (*entity.Article, string, error) {
 	return nil, "", nil
 }
@@ -82,6 +92,10 @@ func (s *stubUpdateRepo) SearchWithFiltersPaginated(_ context.Context, _ []strin
 	return nil, nil
 }
 
+func (s *stubUpdateRepo) ListWithSourceAfter(_ context.Context, _ []string, _ repository.ArticleSearchFilters, _ *repository.ArticleCursor, _ int) ([]repository.ArticleWithSource, error) {
+	return nil, nil
+}
+
 func TestUpdateHandler_Success(t *testing.T) {
 	stub := &stubUpdateRepo{
 		article: &entity.Article{
@@ -210,6 +224,9 @@ func TestUpdateHandler_UpdateError(t *testing.T) {
 func (s *stubUpdateRepo) CreateWithSummary(_ context.Context, _ *entity.Article, _ *entity.Summary) error {
 	return nil
 }
+func (s *stubUpdateRepo) CreateBatch(_ context.Context, _ []repository.NewArticle) (int, error) {
+	return 0, nil
+}
 func (s *stubUpdateRepo) CreateWithTranscribeJob(_ context.Context, _ *entity.Article, _, _ string) error {
 	return nil
 }
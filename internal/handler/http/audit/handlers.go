@@ -0,0 +1,152 @@
+// Package audit provides the admin audit trail HTTP surface: the
+// RequestContext middleware that hands the actor / request_id / client IP
+// to the use cases, and the admin-only GET /audit-logs listing.
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"catchup-feed/internal/common/pagination"
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/handler/http/middleware"
+	"catchup-feed/internal/handler/http/requestid"
+	"catchup-feed/internal/handler/http/respond"
+	"catchup-feed/internal/pkg/validation"
+	"catchup-feed/internal/repository"
+	auditUC "catchup-feed/internal/usecase/audit"
+)
+
+// RequestContext attaches audit.Meta to every request so mutations can be
+// recorded with who / which request / from where. It must run inside the
+// authentication middleware (the subject comes from auth.WithIdentity).
+// IP extraction failures are not fatal: the entry is recorded without IP.
+func RequestContext(ips middleware.IPExtractor) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip, _ := ips.ExtractIP(r)
+			ctx := auditUC.WithMeta(r.Context(), auditUC.Meta{
+				Actor:     auth.SubjectFromContext(r.Context()),
+				RequestID: requestid.FromContext(r.Context()),
+				IP:        ip,
+			})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// DTO is one audit entry. before / after are the raw JSON snapshots
+// (null for creates / deletes respectively).
+type DTO struct {
+	ID           int64           `json:"id"`
+	Actor        string          `json:"actor"`
+	Action       string          `json:"action"`
+	ResourceType string          `json:"resource_type"`
+	ResourceID   *int64          `json:"resource_id"`
+	RequestID    string          `json:"request_id"`
+	IP           string          `json:"ip"`
+	Before       json.RawMessage `json:"before" swaggertype:"object"`
+	After        json.RawMessage `json:"after" swaggertype:"object"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+func toDTO(e *entity.AuditLog) DTO {
+	return DTO{
+		ID:           e.ID,
+		Actor:        e.Actor,
+		Action:       e.Action,
+		ResourceType: e.ResourceType,
+		ResourceID:   e.ResourceID,
+		RequestID:    e.RequestID,
+		IP:           e.IP,
+		Before:       e.Before,
+		After:        e.After,
+		CreatedAt:    e.CreatedAt,
+	}
+}
+
+type ListHandler struct {
+	Svc           *auditUC.Service
+	PaginationCfg pagination.Config
+}
+
+// ServeHTTP 監査ログ一覧取得
+// @Summary      監査ログ一覧取得
+// @Description  ソース・記事の作成/更新/削除と JWT 発行の監査ログを新しい順に取得します。各行には実行者(JWT の sub)、request_id、クライアント IP、変更前後のスナップショットが含まれます
+// @Tags         audit-logs
+// @Security     BearerAuth
+// @Produce      json
+// @Param        actor query string false "実行者で絞り込み"
+// @Param        action query string false "操作で絞り込み(create / update / delete / issue)"
+// @Param        resource_type query string false "リソース種別で絞り込み(source / article / auth_token)"
+// @Param        resource_id query int false "リソースIDで絞り込み"
+// @Param        from query string false "記録日時の開始(ISO 8601、含む)"
+// @Param        to query string false "記録日時の終了(ISO 8601、含まない)"
+// @Param        page query int false "ページ番号(1-indexed、デフォルト: 1)"
+// @Param        limit query int false "1ページあたりの件数(デフォルト: 20、最大: 100。上限は呼び出し元の区分ごとに PAGINATION_MAX_LIMIT_* で変更可)"
+// @Success      200 {object} pagination.Response[DTO] "監査ログ(新しい順)"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid query parameter"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /audit-logs [get]
+func (h ListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params, err := pagination.ParseQueryParams(r, h.PaginationCfg)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	q := r.URL.Query()
+	filter := repository.AuditLogFilter{
+		Actor:        q.Get("actor"),
+		Action:       q.Get("action"),
+		ResourceType: q.Get("resource_type"),
+	}
+	if raw := q.Get("resource_id"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || id <= 0 {
+			respond.SafeError(w, http.StatusBadRequest, errors.New("invalid resource_id"))
+			return
+		}
+		filter.ResourceID = &id
+	}
+	if raw := q.Get("from"); raw != "" {
+		if filter.From, err = validation.ParseDateISO8601(raw); err != nil {
+			respond.SafeError(w, http.StatusBadRequest, fmt.Errorf("invalid from date: %w", err))
+			return
+		}
+	}
+	if raw := q.Get("to"); raw != "" {
+		if filter.To, err = validation.ParseDateISO8601(raw); err != nil {
+			respond.SafeError(w, http.StatusBadRequest, fmt.Errorf("invalid to date: %w", err))
+			return
+		}
+	}
+
+	result, err := h.Svc.List(r.Context(), filter, params)
+	if err != nil {
+		if errors.Is(err, auditUC.ErrInvalidRange) {
+			respond.SafeError(w, http.StatusBadRequest, err)
+			return
+		}
+		respond.SafeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	out := make([]DTO, 0, len(result.Data))
+	for _, e := range result.Data {
+		out = append(out, toDTO(e))
+	}
+	respond.JSON(w, http.StatusOK, pagination.NewResponse(out, result.Pagination))
+}
+
+// Register registers the audit log route. The trail is admin-only (C-20);
+// viewers are not on the allowlist and the explicit auth.Authz wrap keeps
+// the route protected even if the mux is mounted without the outer Authz.
+func Register(mux *http.ServeMux, svc *auditUC.Service, paginationCfg pagination.Config) {
+	mux.Handle("GET /audit-logs", auth.Authz(ListHandler{Svc: svc, PaginationCfg: paginationCfg}))
+}
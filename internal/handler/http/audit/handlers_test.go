@@ -0,0 +1,122 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/common/pagination"
+	"catchup-feed/internal/domain/entity"
+	haudit "catchup-feed/internal/handler/http/audit"
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/handler/http/middleware"
+	"catchup-feed/internal/handler/http/requestid"
+	"catchup-feed/internal/repository"
+	auditUC "catchup-feed/internal/usecase/audit"
+)
+
+type stubAuditRepo struct {
+	filter  repository.AuditLogFilter
+	entries []*entity.AuditLog
+}
+
+func (s *stubAuditRepo) Insert(context.Context, *entity.AuditLog) error { return nil }
+
+func (s *stubAuditRepo) List(_ context.Context, f repository.AuditLogFilter, _, _ int) ([]*entity.AuditLog, error) {
+	s.filter = f
+	return s.entries, nil
+}
+
+func (s *stubAuditRepo) Count(context.Context, repository.AuditLogFilter) (int64, error) {
+	return int64(len(s.entries)), nil
+}
+
+func TestListHandler(t *testing.T) {
+	resourceID := int64(5)
+	entry := &entity.AuditLog{
+		ID: 1, Actor: "admin@example.com", Action: entity.AuditActionDelete,
+		ResourceType: entity.AuditResourceSource, ResourceID: &resourceID,
+		Before:    json.RawMessage(`{"name":"Qiita"}`),
+		CreatedAt: time.Date(2026, 7, 3, 8, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		wantCode int
+		check    func(t *testing.T, f repository.AuditLogFilter)
+	}{
+		{
+			name:     "no filter",
+			wantCode: http.StatusOK,
+			check: func(t *testing.T, f repository.AuditLogFilter) {
+				assert.Equal(t, repository.AuditLogFilter{}, f)
+			},
+		},
+		{
+			name:     "all filters",
+			query:    "?actor=admin%40example.com&action=delete&resource_type=source&resource_id=5&from=2026-07-01&to=2026-07-04",
+			wantCode: http.StatusOK,
+			check: func(t *testing.T, f repository.AuditLogFilter) {
+				assert.Equal(t, "admin@example.com", f.Actor)
+				assert.Equal(t, "delete", f.Action)
+				assert.Equal(t, "source", f.ResourceType)
+				require.NotNil(t, f.ResourceID)
+				assert.Equal(t, int64(5), *f.ResourceID)
+				require.NotNil(t, f.From)
+				require.NotNil(t, f.To)
+			},
+		},
+		{name: "invalid resource_id", query: "?resource_id=abc", wantCode: http.StatusBadRequest},
+		{name: "invalid from", query: "?from=yesterday", wantCode: http.StatusBadRequest},
+		{name: "reversed range", query: "?from=2026-07-04&to=2026-07-01", wantCode: http.StatusBadRequest},
+		{name: "invalid page", query: "?page=0", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubAuditRepo{entries: []*entity.AuditLog{entry}}
+			h := haudit.ListHandler{
+				Svc:           &auditUC.Service{Repo: repo},
+				PaginationCfg: pagination.DefaultConfig(),
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/audit-logs"+tt.query, nil))
+
+			require.Equal(t, tt.wantCode, rec.Code, rec.Body.String())
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			tt.check(t, repo.filter)
+
+			var got pagination.Response[haudit.DTO]
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			require.Len(t, got.Data, 1)
+			assert.Equal(t, "admin@example.com", got.Data[0].Actor)
+			assert.JSONEq(t, `{"name":"Qiita"}`, string(got.Data[0].Before))
+			assert.Equal(t, int64(1), got.Pagination.Total)
+		})
+	}
+}
+
+func TestRequestContext(t *testing.T) {
+	var meta auditUC.Meta
+	next := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		meta = auditUC.MetaFromContext(r.Context())
+	})
+	h := haudit.RequestContext(&middleware.RemoteAddrExtractor{})(next)
+
+	req := httptest.NewRequest(http.MethodDelete, "/sources/1", nil)
+	req.RemoteAddr = "192.0.2.10:5555"
+	ctx := auth.WithIdentity(req.Context(), "admin@example.com", auth.RoleAdmin)
+	ctx = requestid.WithRequestID(ctx, "req-42")
+	h.ServeHTTP(httptest.NewRecorder(), req.WithContext(ctx))
+
+	assert.Equal(t, auditUC.Meta{Actor: "admin@example.com", RequestID: "req-42", IP: "192.0.2.10"}, meta)
+}
@@ -10,6 +10,18 @@ import (
 // It must match the name the frontend proxy (proxy.ts) reads (D-22).
 const authCookieName = "catchup_feed_auth_token"
 
+// SessionCookieName is authCookieName for the middleware that must tell
+// cookie-authenticated requests apart (middleware.CSRF).
+const SessionCookieName = authCookieName
+
+// refreshCookieName is the HttpOnly cookie that carries the refresh token.
+// Its Path is refreshCookiePath, so browsers only send it to /auth/refresh
+// and /auth/logout — never to the API routes.
+const refreshCookieName = "catchup_feed_refresh_token"
+
+// refreshCookiePath scopes the refresh cookie to the /auth endpoints.
+const refreshCookiePath = "/auth"
+
 // Cookie-related environment variables.
 const (
 	// EnvCookieDomain sets the Domain attribute of the auth cookie.
@@ -72,3 +84,33 @@ func expiredAuthCookie() *http.Cookie {
 		SameSite: http.SameSiteStrictMode,
 	}
 }
+
+// newRefreshCookie builds the refresh token cookie. Same fixed security
+// attributes as newAuthCookie, but scoped to refreshCookiePath.
+func newRefreshCookie(value string, maxAge time.Duration) *http.Cookie {
+	return &http.Cookie{
+		Name:     refreshCookieName,
+		Value:    value,
+		Path:     refreshCookiePath,
+		Domain:   cookieDomain(),
+		MaxAge:   int(maxAge.Seconds()),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+	}
+}
+
+// expiredRefreshCookie deletes the refresh token cookie (see
+// expiredAuthCookie).
+func expiredRefreshCookie() *http.Cookie {
+	return &http.Cookie{
+		Name:     refreshCookieName,
+		Value:    "",
+		Path:     refreshCookiePath,
+		Domain:   cookieDomain(),
+		MaxAge:   -1, // net/http: MaxAge<0 emits "Max-Age=0" (delete now)
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+	}
+}
@@ -6,18 +6,33 @@ import "strings"
 // These endpoints are accessible without a valid JWT token.
 //
 // Justification for each public endpoint:
-//   - /health, /ready, /live: Required for orchestration health checks (Kubernetes, Docker, monitoring)
+//   - /health, /ready, /live, /startup: Required for orchestration health checks (Kubernetes, Docker, monitoring)
+//   - /version: Build info for deploy tooling, next to the health checks
 //   - /swagger/: API documentation for developers
 //   - /auth/token: Token generation endpoint (can't require token to get token)
 //   - /auth/logout: Cookie invalidation (idempotent; must work even with an
 //     already-expired token, so it stays unauthenticated — D-22)
+//   - /auth/refresh: Exchanges a refresh token for a new access token; it is
+//     called precisely when the access token has expired
+//   - /auth/oidc: Exchanges an external provider's ID token for a token
+//     (the OIDC counterpart of /auth/token)
+//   - /auth/revoke: Revokes a token; like logout it must work for a client
+//     whose token is already unusable
+//   - /auth/token/mfa: Second step of an MFA login (TOTP code); the client
+//     has no token yet
 var PublicEndpoints = []string{
 	"/health",
 	"/ready",
 	"/live",
+	"/startup",
+	"/version",
 	"/swagger/",
 	"/auth/token",
 	"/auth/logout",
+	"/auth/refresh",
+	"/auth/oidc",
+	"/auth/revoke",
+	"/auth/token/mfa",
 }
 
 // IsPublicEndpoint checks if a given path is a public endpoint.
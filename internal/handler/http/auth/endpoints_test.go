@@ -30,6 +30,18 @@ func TestIsPublicEndpoint_ExhaustiveCoverage(t *testing.T) {
 			expected: true,
 			reason:   "Required for Kubernetes liveness probes",
 		},
+		{
+			name:     "startup probe exact",
+			path:     "/startup",
+			expected: true,
+			reason:   "Required for Kubernetes startup probes",
+		},
+		{
+			name:     "build info exact",
+			path:     "/version",
+			expected: true,
+			reason:   "Deploy tooling checks the rolled-out build",
+		},
 
 		// Swagger documentation
 		{
@@ -216,9 +228,15 @@ func TestPublicEndpointsList(t *testing.T) {
 		"/health",
 		"/ready",
 		"/live",
+		"/startup",
+		"/version",
 		"/swagger/",
 		"/auth/token",
 		"/auth/logout",
+		"/auth/refresh",
+		"/auth/oidc",
+		"/auth/revoke",
+		"/auth/token/mfa",
 	}
 
 	if len(PublicEndpoints) != len(expectedEndpoints) {
@@ -264,7 +282,7 @@ func TestIsPublicEndpoint_PrefixMatching(t *testing.T) {
 
 		// Should NOT match other auth paths
 		{"auth only", "/auth", false},
-		{"auth with different suffix", "/auth/refresh", false},
+		{"auth with different suffix", "/auth/sessions", false},
 		{"auth with subpath", "/auth/users", false},
 
 		// Health check - exact match only
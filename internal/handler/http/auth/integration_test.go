@@ -143,7 +143,7 @@ func newIntegrationServer(t *testing.T) (http.Handler, *viewerUC.Service) {
 	// the POST-only logout route must 405 other methods instead of falling
 	// through to the catch-all.
 	publicMux := http.NewServeMux()
-	publicMux.Handle("/auth/token", TokenHandler(authservice.NewAuthService(NewAdminAuthProvider()), viewerSvc))
+	publicMux.Handle("/auth/token", TokenHandler(authservice.NewAuthService(NewAdminAuthProvider()), viewerSvc, nil))
 	// logout is POST-only so a reflected GET (<img src=".../auth/logout">)
 	// cannot force-logout a victim.
 	publicMux.Handle("POST /auth/logout", LogoutHandler())
@@ -0,0 +1,175 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"catchup-feed/internal/handler/http/requestid"
+	mfaUC "catchup-feed/internal/usecase/mfa"
+)
+
+// mfaChallengeTTL bounds the time between the password step and the code
+// step of an MFA login.
+const mfaChallengeTTL = 5 * time.Minute
+
+// mfaChallengePurpose is the purpose claim of an MFA challenge token.
+const mfaChallengePurpose = "mfa"
+
+// MFAVerifier is the second login step for admins with TOTP enabled.
+// Verify fails with usecase/mfa.ErrInvalidCode for a wrong or reused code.
+// Implemented by usecase/mfa.Service.
+type MFAVerifier interface {
+	Required(ctx context.Context, email string) (bool, error)
+	Verify(ctx context.Context, email, code string) error
+}
+
+// mfaChallengeResponse replaces tokenResponse when the password was right
+// but a TOTP code is still needed.
+type mfaChallengeResponse struct {
+	MFARequired bool   `json:"mfa_required" example:"true"`
+	MFAToken    string `json:"mfa_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+}
+
+type mfaLoginRequest struct {
+	MFAToken string `json:"mfa_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	Code     string `json:"code" example:"123456"`
+}
+
+// mfaChallengeKey derives the challenge signing key from JWT_SECRET. A
+// separate key means a challenge can never pass the auth middleware as an
+// access token, even though both are HS256 JWTs.
+func mfaChallengeKey() []byte {
+	mac := hmac.New(sha256.New, []byte(os.Getenv("JWT_SECRET")))
+	mac.Write([]byte("catchup-feed mfa challenge"))
+	return mac.Sum(nil)
+}
+
+// signMFAChallenge signs the short-lived token proving the password step
+// succeeded for sub.
+func signMFAChallenge(sub, role string, now time.Time) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":     sub,
+		"role":    role,
+		"purpose": mfaChallengePurpose,
+		"iat":     now.Unix(),
+		"exp":     now.Add(mfaChallengeTTL).Unix(),
+	})
+	return token.SignedString(mfaChallengeKey())
+}
+
+// parseMFAChallenge validates a challenge token and returns its subject
+// and role.
+func parseMFAChallenge(raw string) (sub, role string, err error) {
+	if os.Getenv("JWT_SECRET") == "" {
+		return "", "", errors.New("jwt secret not configured")
+	}
+	tok, err := jwt.Parse(raw, func(*jwt.Token) (any, error) {
+		return mfaChallengeKey(), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return "", "", errors.New("invalid challenge")
+	}
+	claims, ok := tok.Claims.(jwt.MapClaims)
+	if !ok || claims["purpose"] != mfaChallengePurpose {
+		return "", "", errors.New("invalid challenge")
+	}
+	sub, _ = claims["sub"].(string)
+	role, _ = claims["role"].(string)
+	if sub == "" || role == "" {
+		return "", "", errors.New("invalid challenge")
+	}
+	return sub, role, nil
+}
+
+// writeMFAChallenge answers a correct password of an MFA-enabled account.
+// No cookie is set: nothing is authenticated yet.
+func writeMFAChallenge(w http.ResponseWriter, logger *slog.Logger, start time.Time, sub, role string) {
+	challenge, err := signMFAChallenge(sub, role, time.Now())
+	if err != nil {
+		logger.Error("token generation failed",
+			slog.String("error", err.Error()),
+			slog.Int64("duration_ms", time.Since(start).Milliseconds()))
+		http.Error(w, "token generation failed", http.StatusInternalServerError)
+		return
+	}
+	logger.Info("authentication pending mfa",
+		slog.String("user_email", sub),
+		slog.Int64("duration_ms", time.Since(start).Milliseconds()))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(mfaChallengeResponse{MFARequired: true, MFAToken: challenge}); err != nil {
+		logger.Error("failed to encode token response", slog.String("error", err.Error()))
+	}
+}
+
+// MFATokenHandler is the second step of an MFA login: it exchanges the
+// mfa_token from /auth/token and a current TOTP code for the same session
+// TokenHandler issues. Each code is accepted once. refresh and audit may be
+// nil.
+//
+// Failures reply with http.Error (text/plain), like TokenHandler.
+//
+// @Summary      多要素認証(TOTP コード入力)
+// @Description  MFA を有効にした admin は /auth/token がトークンの代わりに mfa_required / mfa_token を返します。
+// @Description  その mfa_token(5分有効)と認証アプリの6桁コードを送ると、/auth/token と同じ JWT(cookie + body)と
+// @Description  リフレッシュトークンを発行します。同じコードは2度使えません。
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body mfaLoginRequest true "mfa_token と6桁コード"
+// @Success      200 {object} tokenResponse "JWT(併せて Set-Cookie: catchup_feed_auth_token を返す)"
+// @Failure      400 {string} string "リクエストが不正"
+// @Failure      401 {string} string "mfa_token またはコードが無効"
+// @Failure      429 {string} string "Too many requests - rate limit exceeded"
+// @Failure      500 {string} string "トークン生成失敗"
+// @Router       /auth/token/mfa [post]
+func MFATokenHandler(mfa MFAVerifier, refresh RefreshTokens, audit TokenAuditor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		logger := slog.With(slog.String("request_id", requestid.FromContext(r.Context())))
+		unauthorized := func(reason string) {
+			logger.Warn("mfa authentication failed",
+				slog.String("reason", reason),
+				slog.Int64("duration_ms", time.Since(start).Milliseconds()))
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+		}
+
+		var req mfaLoginRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.MFAToken == "" || req.Code == "" {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+
+		sub, role, err := parseMFAChallenge(req.MFAToken)
+		if err != nil {
+			unauthorized("invalid_mfa_token")
+			return
+		}
+
+		err = mfa.Verify(r.Context(), sub, req.Code)
+		switch {
+		case errors.Is(err, mfaUC.ErrInvalidCode),
+			errors.Is(err, mfaUC.ErrNotEnrolled),
+			errors.Is(err, mfaUC.ErrAccountNotFound):
+			unauthorized("invalid_mfa_code")
+			return
+		case err != nil:
+			logger.Error("mfa authentication failed",
+				slog.String("reason", "mfa_lookup_failed"),
+				slog.String("error", err.Error()),
+				slog.Int64("duration_ms", time.Since(start).Milliseconds()))
+			http.Error(w, "token generation failed", http.StatusInternalServerError)
+			return
+		}
+
+		issueSession(w, r, logger, start, sub, role, refresh, audit)
+	}
+}
@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mfaUC "catchup-feed/internal/usecase/mfa"
+)
+
+// stubMFA requires MFA for the emails in enabled; code is the only valid
+// code and works once.
+type stubMFA struct {
+	enabled map[string]bool
+	code    string
+	used    bool
+}
+
+func (s *stubMFA) Required(_ context.Context, email string) (bool, error) {
+	return s.enabled[email], nil
+}
+
+func (s *stubMFA) Verify(_ context.Context, email, code string) error {
+	if !s.enabled[email] {
+		return mfaUC.ErrNotEnrolled
+	}
+	if code != s.code || s.used {
+		return mfaUC.ErrInvalidCode
+	}
+	s.used = true
+	return nil
+}
+
+func passwordLogin(t *testing.T, handler http.Handler) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/auth/token",
+		strings.NewReader(`{"email":"`+testAdminUser+`","password":"`+testPassword+`"}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func mfaLogin(t *testing.T, handler http.Handler, mfaToken, code string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/auth/token/mfa",
+		strings.NewReader(`{"mfa_token":"`+mfaToken+`","code":"`+code+`"}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestTokenHandlerWithMFA(t *testing.T) {
+	authService := newTestAuthService(t)
+	mfa := &stubMFA{enabled: map[string]bool{testAdminUser: true}, code: "123456"}
+
+	rec := passwordLogin(t, TokenHandlerWithMFA(authService, nil, nil, newStubRefreshTokens(), mfa))
+	require.Equal(t, http.StatusOK, rec.Code)
+	// パスワードだけではセッションを発行しない。
+	assert.Nil(t, findAuthCookie(t, rec))
+	assert.Nil(t, findCookie(rec, refreshCookieName))
+
+	var challenge mfaChallengeResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &challenge))
+	assert.True(t, challenge.MFARequired)
+	require.NotEmpty(t, challenge.MFAToken)
+
+	// チャレンジはアクセストークンとして通らない。
+	req := httptest.NewRequest(http.MethodGet, "/sources", nil)
+	req.Header.Set("Authorization", "Bearer "+challenge.MFAToken)
+	protected := httptest.NewRecorder()
+	Authz(okHandler()).ServeHTTP(protected, req)
+	assert.Equal(t, http.StatusUnauthorized, protected.Code)
+
+	second := MFATokenHandler(mfa, newStubRefreshTokens(), nil)
+	assert.Equal(t, http.StatusUnauthorized, mfaLogin(t, second, challenge.MFAToken, "000000").Code)
+
+	rec = mfaLogin(t, second, challenge.MFAToken, "123456")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body tokenResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	claims, err := validateJWT(body.Token, []byte(testJWTSecret))
+	require.NoError(t, err)
+	assert.Equal(t, testAdminUser, claims.sub)
+	assert.Equal(t, RoleAdmin, claims.role)
+	assert.NotNil(t, findAuthCookie(t, rec))
+
+	// 同じコードは2度使えない。
+	assert.Equal(t, http.StatusUnauthorized, mfaLogin(t, second, challenge.MFAToken, "123456").Code)
+}
+
+func TestTokenHandlerWithMFA_NotEnrolled(t *testing.T) {
+	authService := newTestAuthService(t)
+
+	rec := passwordLogin(t, TokenHandlerWithMFA(authService, nil, nil, nil, &stubMFA{}))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotNil(t, findAuthCookie(t, rec))
+	assert.NotContains(t, rec.Body.String(), "mfa_required")
+}
+
+func TestMFATokenHandler_RejectsForeignTokens(t *testing.T) {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	mfa := &stubMFA{enabled: map[string]bool{testAdminUser: true}, code: "123456"}
+	handler := MFATokenHandler(mfa, nil, nil)
+
+	expired, err := signMFAChallenge(testAdminUser, RoleAdmin, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		token    string
+		wantCode int
+	}{
+		// アクセストークンを mfa_token として出してもコード検証を迂回できない。
+		{"access token", signToken(t, testJWTSecret, adminClaims()), http.StatusUnauthorized},
+		{"expired challenge", expired, http.StatusUnauthorized},
+		{"garbage", "not-a-jwt", http.StatusUnauthorized},
+		{"missing", "", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantCode, mfaLogin(t, handler, tt.token, "123456").Code)
+		})
+	}
+}
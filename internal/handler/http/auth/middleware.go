@@ -8,33 +8,48 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
+	"catchup-feed/internal/common/pagination"
+	"catchup-feed/internal/domain/entity"
 	"catchup-feed/internal/handler/http/pathutil"
 	"catchup-feed/internal/handler/http/requestid"
 	"catchup-feed/internal/handler/http/respond"
+	apikeyUC "catchup-feed/internal/usecase/apikey"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-// Roles carried by the JWT role claim (D-27). Exactly two values exist —
-// pulse deliberately has no permission framework beyond this (単一ユーザー
-// 右サイズ).
+// Built-in roles carried by the JWT role claim (D-27). Custom roles with
+// their own scopes are configuration (AUTH_ROLES, see Roles); these two
+// always exist and cannot be redefined.
 const (
-	// RoleAdmin is the single administrator (C-7: env + bcrypt, no DB row).
+	// RoleAdmin is an administrator (a role=admin row of the users table;
+	// before the users table, the single env-configured admin of C-7).
 	RoleAdmin = "admin"
-	// RoleViewer is a read-only friend account (viewers table, D-27).
+	// RoleViewer is a read-only friend account (role=viewer users, D-27).
 	RoleViewer = "viewer"
 )
 
 type ctxKey string
 
 const (
-	ctxUser ctxKey = "user"
-	ctxRole ctxKey = "role"
+	ctxUser   ctxKey = "user"
+	ctxRole   ctxKey = "role"
+	ctxAPIKey ctxKey = "api_key"
+	ctxAdmin  ctxKey = "admin_verified"
+	ctxScopes ctxKey = "scopes"
 )
 
+// APIKeyHeader carries a service-to-service API key.
+const APIKeyHeader = "X-API-Key"
+
+// apiKeySubjectPrefix marks API key identities in SubjectFromContext (and
+// therefore in audit logs): "apikey:<name>".
+const apiKeySubjectPrefix = "apikey:"
+
 // WithIdentity returns a context carrying the authenticated subject and
 // role. Exposed for handler tests; production code only sets it from the
 // middleware in this package.
@@ -57,6 +72,21 @@ func RoleFromContext(ctx context.Context) string {
 	return role
 }
 
+// IsAPIKeyFromContext reports whether the caller authenticated with an
+// API key (a service identity without a users row) rather than a JWT.
+func IsAPIKeyFromContext(ctx context.Context) bool {
+	_, ok := apiKeyRoleFromContext(ctx)
+	return ok
+}
+
+// SubscriptionScoped reports whether the caller only sees the articles of
+// the sources it subscribes to: an authenticated account that is neither
+// an admin nor an API key.
+func SubscriptionScoped(ctx context.Context) bool {
+	role := RoleFromContext(ctx)
+	return role != "" && role != RoleAdmin && !IsAPIKeyFromContext(ctx)
+}
+
 // ViewerVerifier re-validates a viewer on every request (D-27 (4)): the
 // viewer must still exist and be active (deactivated_at IS NULL) so
 // deactivation takes effect immediately instead of waiting for JWT expiry.
@@ -65,6 +95,22 @@ type ViewerVerifier interface {
 	IsActiveViewer(ctx context.Context, email string) (bool, error)
 }
 
+// AccountVerifier re-validates an administrator or custom-role user on
+// every request against the users table: the account must exist, be active
+// and still have the token's role, so deactivation, deletion or a role
+// change cuts off existing JWTs immediately. Implemented by
+// usecase/user.Service.
+type AccountVerifier interface {
+	IsActiveAccount(ctx context.Context, email, role string) (bool, error)
+}
+
+// TokenDenylist reports whether an access JWT has been revoked (logout or
+// POST /auth/revoke) before its exp, by its jti claim. Implemented by
+// usecase/tokenrevocation.Service.
+type TokenDenylist interface {
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+}
+
 // viewerAllowedRoutes is the closed allowlist of "METHOD path" routes a
 // viewer may reach (D-27 (3)). Everything else is admin-only by default —
 // a newly added endpoint is never reachable by viewers unless it is
@@ -85,6 +131,44 @@ func viewerAllowed(method, path string) bool {
 	return ok
 }
 
+// scopedRouteGroups are the path prefixes whose every route is wrapped in
+// RequireScope (or the admin-only Authz); /feed.xml is the outbound Atom
+// feed of articles (articles:read), /crawl the on-demand crawl trigger
+// (sources:write / sources:read), /crawls the crawl history
+// (sources:read), /source-groups the source folders (sources:read /
+// sources:write), /alerts the caller's keyword alerts (articles:read) and
+// /ws the dashboard push, which checks articles:read / sources:read per
+// event type itself. Custom roles reach only these groups and GET
+// /auth/me at the outer layer, so routes without a per-route wrapper
+// (private feed, book files, ...) stay closed to them — the same
+// default-deny as viewerAllowedRoutes.
+var scopedRouteGroups = []string{"/articles", "/sources", "/tags", "/feed.xml", "/crawl", "/crawls", "/source-groups", "/alerts", "/ws"}
+
+// customRoleAllowed reports whether a custom role may pass the outer layer
+// for method+path. The scope itself is checked by RequireScope.
+func customRoleAllowed(method, path string) bool {
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	if method == http.MethodGet && path == "/auth/me" {
+		return true
+	}
+	for _, group := range scopedRouteGroups {
+		if path == group || strings.HasPrefix(path, group+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeyAuthenticator resolves an X-API-Key header value and applies the
+// key's rate limit / daily quota. Implemented by usecase/apikey.Service;
+// failures are its sentinel errors (ErrInvalidKey → 401, ErrRateLimited /
+// ErrQuotaExceeded → 429).
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, plaintext string) (*entity.APIKey, error)
+}
+
 // Authz is the admin-only authorization middleware used to wrap individual
 // admin routes. It authenticates the JWT and requires role=admin with the
 // administrator's subject; viewer tokens are rejected with 403 here
@@ -97,17 +181,20 @@ func viewerAllowed(method, path string) bool {
 //  3. The token must carry role=admin (D-27; tokens without a role claim —
 //     pre-D-27 tokens — and unknown roles are rejected with 403: the C-20
 //     regression rule re-read for the two-role world) and its sub claim
-//     must equal the configured administrator (ADMIN_USER, constant-time).
+//     must equal the configured administrator (ADMIN_USER, constant-time) —
+//     unless an outer AuthzWithUsers already verified it as an active
+//     admin in the users table.
 //
 // Security Note:
 // This middleware fixes CVE-CATCHUP-2024-002 (Authorization Bypass for GET
 // Requests): GET requests to protected endpoints require authentication.
 //
-// JWT_SECRET and ADMIN_USER are read when the middleware is constructed, so
-// Authz must be called after startup validation (ValidateAdminCredentials
-// for ADMIN_USER; JWT_SECRET is validated by cmd/server's validateJWTSecret).
+// ADMIN_USER is read when the middleware is constructed, so Authz must be
+// called after startup validation (ValidateAdminCredentials). JWT_SECRET is
+// read on every request, so a refreshed secret takes effect without a
+// restart; cmd/server's validateJWTSecret checks it at startup.
 func Authz(next http.Handler) http.Handler {
-	return newAuthz(nil, next)
+	return newAuthz(authzConfig{}, next)
 }
 
 // AuthzWithViewer builds the role-aware authorization middleware that wraps
@@ -116,15 +203,77 @@ func Authz(next http.Handler) http.Handler {
 // request and then confined to the viewerAllowedRoutes allowlist (D-27).
 func AuthzWithViewer(viewers ViewerVerifier) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
-		return newAuthz(viewers, next)
+		return newAuthz(authzConfig{viewers: viewers}, next)
+	}
+}
+
+// AuthzWithAPIKeys is AuthzWithViewer that additionally authenticates
+// service-to-service clients by the X-API-Key header instead of a JWT.
+// A key carries a role like a token does: admin keys reach every route,
+// viewer keys only the viewer allowlist. The identity is passed on in the
+// context, so the per-route Authz wrappers inside admit admin keys without
+// a JWT.
+func AuthzWithAPIKeys(viewers ViewerVerifier, keys APIKeyAuthenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return newAuthz(authzConfig{viewers: viewers, keys: keys}, next)
+	}
+}
+
+// AuthzWithUsers is AuthzWithAPIKeys with accounts checked against the
+// users table instead of ADMIN_USER: an admin token passes only while its
+// subject is an active role=admin user, and tokens of custom roles
+// (AUTH_ROLES) only while the subject still holds that role. The verified
+// identity and its scopes are marked in the context, so the per-route
+// Authz / RequireScope wrappers inside accept it without consulting the
+// environment (which, once the first admin is bootstrapped, may no longer
+// be set).
+//
+// Custom roles are confined to scopedRouteGroups (plus GET /auth/me) here;
+// inside those groups every route is wrapped in RequireScope or the
+// admin-only Authz, so they reach exactly the routes for their scopes.
+//
+// revoked, when non-nil, rejects JWTs whose jti has been denylisted. Only
+// this outer layer checks it; the per-route wrappers inside trust the
+// identity it passes on.
+func AuthzWithUsers(viewers ViewerVerifier, keys APIKeyAuthenticator, accounts AccountVerifier, revoked TokenDenylist) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return newAuthz(authzConfig{viewers: viewers, keys: keys, accounts: accounts, revoked: revoked}, next)
+	}
+}
+
+// RequireScope is the per-route-group counterpart of Authz for routes that
+// non-admin roles may reach: the request passes when its identity holds
+// scope. Admins hold every scope. Viewers and custom roles must have been
+// verified by the outer AuthzWithViewer / AuthzWithUsers (their scopes are
+// read from the context); a bare viewer or custom-role JWT is rejected
+// like in Authz, since this layer cannot re-validate it against the DB.
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return newAuthz(authzConfig{scope: scope}, next)
 	}
 }
 
-// newAuthz is the shared implementation. viewers == nil means admin-only:
-// any viewer token is rejected with 403.
-func newAuthz(viewers ViewerVerifier, next http.Handler) http.Handler {
-	secret := []byte(os.Getenv("JWT_SECRET"))
+// authzConfig selects the checks newAuthz performs.
+//   - viewers == nil means admin-only: any viewer token is rejected with
+//     403.
+//   - keys == nil disables X-API-Key authentication at this layer.
+//   - accounts == nil falls back to the single ADMIN_USER subject check
+//     and rejects custom roles.
+//   - scope != "" admits non-admin identities verified by an outer layer
+//     that hold the scope (RequireScope).
+//   - revoked == nil skips the jti denylist check.
+type authzConfig struct {
+	viewers  ViewerVerifier
+	keys     APIKeyAuthenticator
+	accounts AccountVerifier
+	scope    string
+	revoked  TokenDenylist
+}
+
+// newAuthz is the shared implementation.
+func newAuthz(cfg authzConfig, next http.Handler) http.Handler {
 	adminUser := os.Getenv(EnvAdminUser)
+	roles := loadRoles()
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Step 1: Public endpoints are accessible without authentication.
 		if IsPublicEndpoint(r.URL.Path) {
@@ -138,12 +287,47 @@ func newAuthz(viewers ViewerVerifier, next http.Handler) http.Handler {
 			slog.String("method", r.Method),
 			slog.String("path", pathutil.RedactPath(r.URL.Path)),
 		)
+		forbidden := func(sub, reason string) {
+			logger.Warn("authorization denied",
+				slog.String("user_email", sub),
+				slog.String("reason", reason))
+			respond.SafeError(w, http.StatusForbidden, errors.New("forbidden"))
+		}
+
+		// Identities already authorized by an outer layer: an API key
+		// authenticated by AuthzWithAPIKeys, an admin verified against the
+		// users table by AuthzWithUsers, or a viewer / custom role whose
+		// scopes an outer layer resolved.
+		if role, ok := apiKeyRoleFromContext(r.Context()); ok {
+			if !apiKeyRoleAllowed(role, cfg, roles, r) {
+				forbidden(SubjectFromContext(r.Context()), "api_key_route_not_allowed")
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+		if verifiedAdmin(r.Context()) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if scopes, ok := verifiedScopes(r.Context()); ok && cfg.scope != "" && RoleFromContext(r.Context()) != RoleAdmin {
+			if !slices.Contains(scopes, cfg.scope) {
+				forbidden(SubjectFromContext(r.Context()), "missing_scope")
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// JWT_SECRET is read per request, like signAccessToken does, so a
+		// rotation by SECRETS_REFRESH_INTERVAL applies to both at once.
+		secret := []byte(os.Getenv("JWT_SECRET"))
 
 		// Fail closed when the administrator or the signing key is not
 		// configured. An empty HS256 key would let anyone forge a validly
 		// signed token. Startup validation makes both branches unreachable
 		// in a correctly booted server.
-		if adminUser == "" {
+		if cfg.accounts == nil && adminUser == "" {
 			logger.Error("authorization denied", slog.String("reason", "admin_user_not_configured"))
 			respond.SafeError(w, http.StatusUnauthorized, errors.New("unauthorized"))
 			return
@@ -154,6 +338,35 @@ func newAuthz(viewers ViewerVerifier, next http.Handler) http.Handler {
 			return
 		}
 
+		// API keys: an X-API-Key header at the outer layer.
+		if plaintext := r.Header.Get(APIKeyHeader); plaintext != "" && cfg.keys != nil {
+			key, err := cfg.keys.AuthenticateAPIKey(r.Context(), plaintext)
+			switch {
+			case errors.Is(err, apikeyUC.ErrRateLimited), errors.Is(err, apikeyUC.ErrQuotaExceeded):
+				logger.Warn("api key limit exceeded", slog.String("reason", err.Error()))
+				respond.JSON(w, http.StatusTooManyRequests, map[string]string{"error": err.Error()})
+				return
+			case errors.Is(err, apikeyUC.ErrInvalidKey):
+				respond.SafeError(w, http.StatusUnauthorized, fmt.Errorf("unauthorized: %w", err))
+				return
+			case err != nil:
+				logger.Error("api key authentication failed", slog.Any("error", err))
+				respond.SafeError(w, http.StatusInternalServerError, errors.New("internal error"))
+				return
+			}
+			sub := apiKeySubjectPrefix + key.Name
+			if !apiKeyRoleAllowed(key.Role, cfg, roles, r) {
+				forbidden(sub, "api_key_route_not_allowed")
+				return
+			}
+			logger.Debug("authorization granted",
+				slog.String("user_email", sub), slog.String("role", key.Role))
+			ctx := context.WithValue(WithIdentity(r.Context(), sub, key.Role), ctxAPIKey, key.Role)
+			ctx = pagination.WithTier(ctx, pagination.TierAPIKey)
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+
 		// Step 2: Protected endpoint - require a valid JWT for ALL methods.
 		// The token is read from the HttpOnly cookie first (D-22) and falls
 		// back to the Authorization: Bearer header (dev / API clients). Both
@@ -163,57 +376,89 @@ func newAuthz(viewers ViewerVerifier, next http.Handler) http.Handler {
 			respond.SafeError(w, http.StatusUnauthorized, fmt.Errorf("unauthorized: %w", err))
 			return
 		}
-		sub, role, err := validateJWT(tokenString, secret)
+		claims, err := validateJWT(tokenString, secret)
 		if err != nil {
 			respond.SafeError(w, http.StatusUnauthorized, fmt.Errorf("unauthorized: %w", err))
 			return
 		}
+		sub, role := claims.sub, claims.role
+
+		// A revoked token (logout / POST /auth/revoke) is as dead as an
+		// expired one. Fail closed when the denylist cannot be read.
+		// Tokens issued before revocation existed carry no jti and simply
+		// run out within tokenTTL.
+		if cfg.revoked != nil && claims.jti != "" {
+			revoked, err := cfg.revoked.IsRevoked(r.Context(), claims.jti)
+			if err != nil {
+				logger.Error("token revocation check failed", slog.Any("error", err))
+				respond.SafeError(w, http.StatusInternalServerError, errors.New("internal error"))
+				return
+			}
+			if revoked {
+				logger.Warn("authentication denied",
+					slog.String("user_email", sub),
+					slog.String("reason", "token_revoked"))
+				respond.SafeError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+				return
+			}
+		}
 
 		// Step 3: Role-based authorization (D-27). A missing role claim
 		// (pre-D-27 token) or an unknown role is rejected with 403 — the
-		// C-20 regression rule re-read for the two-role world.
-		switch role {
-		case RoleAdmin:
+		// C-20 regression rule re-read for the multi-role world.
+		roleScopes, known := roles.Scopes(role)
+		ctx := r.Context()
+		switch {
+		case role == RoleAdmin:
+			if cfg.accounts != nil {
+				// users table: re-validate on every request so a
+				// deactivated, deleted or demoted admin is cut off at once.
+				if !verifyAccount(w, logger, cfg.accounts, r, sub, role) {
+					return
+				}
+				ctx = context.WithValue(ctx, ctxAdmin, true)
+				break
+			}
 			// Single-admin check (C-7): a validly-signed admin token whose
 			// subject is not the administrator must not reach the admin API.
 			if subtle.ConstantTimeCompare([]byte(sub), []byte(adminUser)) != 1 {
-				logger.Warn("authorization denied",
-					slog.String("user_email", sub),
-					slog.String("reason", "subject_is_not_admin"))
-				respond.SafeError(w, http.StatusForbidden, errors.New("forbidden"))
+				forbidden(sub, "subject_is_not_admin")
 				return
 			}
 
-		case RoleViewer:
-			if viewers == nil {
+		case role == RoleViewer:
+			if cfg.viewers == nil {
 				// Admin-only wrapper: viewers never pass, whatever the path.
-				logger.Warn("authorization denied",
-					slog.String("user_email", sub),
-					slog.String("reason", "viewer_on_admin_route"))
-				respond.SafeError(w, http.StatusForbidden, errors.New("forbidden"))
+				forbidden(sub, "viewer_on_admin_route")
 				return
 			}
 			// D-27 (4): re-validate against the DB on every request so
 			// deactivation (or deletion) cuts off existing JWTs immediately.
-			active, err := viewers.IsActiveViewer(r.Context(), sub)
+			active, err := cfg.viewers.IsActiveViewer(r.Context(), sub)
 			if err != nil {
 				logger.Error("viewer re-validation failed", slog.Any("error", err))
 				respond.SafeError(w, http.StatusInternalServerError, errors.New("internal error"))
 				return
 			}
 			if !active {
-				logger.Warn("authorization denied",
-					slog.String("user_email", sub),
-					slog.String("reason", "viewer_deactivated_or_deleted"))
-				respond.SafeError(w, http.StatusForbidden, errors.New("forbidden"))
+				forbidden(sub, "viewer_deactivated_or_deleted")
 				return
 			}
 			// D-27 (3): viewers only reach the closed read-only allowlist.
 			if !viewerAllowed(r.Method, r.URL.Path) {
-				logger.Warn("authorization denied",
-					slog.String("user_email", sub),
-					slog.String("reason", "viewer_route_not_allowed"))
-				respond.SafeError(w, http.StatusForbidden, errors.New("forbidden"))
+				forbidden(sub, "viewer_route_not_allowed")
+				return
+			}
+
+		case known && cfg.accounts != nil && cfg.viewers != nil:
+			// Custom role (AUTH_ROLES), only at the role-aware outer
+			// layer: the subject must still hold the role. Routes enforce
+			// the scopes through RequireScope.
+			if !verifyAccount(w, logger, cfg.accounts, r, sub, role) {
+				return
+			}
+			if !customRoleAllowed(r.Method, r.URL.Path) {
+				forbidden(sub, "custom_role_route_not_allowed")
 				return
 			}
 
@@ -226,13 +471,115 @@ func newAuthz(viewers ViewerVerifier, next http.Handler) http.Handler {
 			return
 		}
 
+		scopes := effectiveScopes(roleScopes, claims.scopes, claims.hasScope)
+		if cfg.scope != "" && role != RoleAdmin && !slices.Contains(scopes, cfg.scope) {
+			forbidden(sub, "missing_scope")
+			return
+		}
+
 		logger.Debug("authorization granted",
 			slog.String("user_email", sub), slog.String("role", role))
 
-		next.ServeHTTP(w, r.WithContext(WithIdentity(r.Context(), sub, role)))
+		ctx = context.WithValue(WithIdentity(ctx, sub, role), ctxScopes, scopes)
+		ctx = pagination.WithTier(ctx, paginationTier(role))
+		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// paginationTier is the pagination tier of a JWT user: admins page with
+// the admin limit, viewers and custom roles with the viewer one.
+func paginationTier(role string) string {
+	if role == RoleAdmin {
+		return pagination.TierAdmin
+	}
+	return pagination.TierViewer
+}
+
+// verifyAccount re-validates sub as an active user holding role. It writes
+// the 500 / 403 response itself and reports whether the request may go on.
+func verifyAccount(w http.ResponseWriter, logger *slog.Logger, accounts AccountVerifier, r *http.Request, sub, role string) bool {
+	active, err := accounts.IsActiveAccount(r.Context(), sub, role)
+	if err != nil {
+		logger.Error("account re-validation failed", slog.Any("error", err))
+		respond.SafeError(w, http.StatusInternalServerError, errors.New("internal error"))
+		return false
+	}
+	if !active {
+		logger.Warn("authorization denied",
+			slog.String("user_email", sub),
+			slog.String("role", role),
+			slog.String("reason", "account_deactivated_deleted_or_role_changed"))
+		respond.SafeError(w, http.StatusForbidden, errors.New("forbidden"))
+		return false
+	}
+	return true
+}
+
+// apiKeyRoleFromContext returns the role of an API key authenticated by an
+// outer AuthzWithAPIKeys. Only this package sets the value.
+func apiKeyRoleFromContext(ctx context.Context) (string, bool) {
+	role, ok := ctx.Value(ctxAPIKey).(string)
+	return role, ok
+}
+
+// verifiedAdmin reports whether an outer AuthzWithUsers already verified
+// the request's admin against the users table. Only this package sets the
+// value.
+func verifiedAdmin(ctx context.Context) bool {
+	ok, _ := ctx.Value(ctxAdmin).(bool)
+	return ok
+}
+
+// verifiedScopes returns the scopes an outer layer resolved for the
+// request's JWT identity. Only this package sets the value.
+func verifiedScopes(ctx context.Context) ([]string, bool) {
+	scopes, ok := ctx.Value(ctxScopes).([]string)
+	return scopes, ok
+}
+
+// ScopesFromContext returns the scopes of the authenticated identity, or
+// nil when the request did not pass the auth middleware. API keys carry
+// their role's scopes.
+func ScopesFromContext(ctx context.Context) []string {
+	if scopes, ok := verifiedScopes(ctx); ok {
+		return scopes
+	}
+	if role, ok := apiKeyRoleFromContext(ctx); ok {
+		scopes, _ := loadRoles().Scopes(role)
+		return scopes
+	}
+	return nil
+}
+
+// apiKeyRoleAllowed applies the role rules of the JWT path to an API key:
+// admin keys pass everywhere; viewer keys reach the viewer allowlist at the
+// role-aware outer layer, a RequireScope route when the viewer role holds
+// its scope, and never an admin-only wrapper.
+func apiKeyRoleAllowed(role string, cfg authzConfig, roles *Roles, r *http.Request) bool {
+	switch role {
+	case RoleAdmin:
+		return true
+	case RoleViewer:
+		if cfg.scope != "" {
+			scopes, _ := roles.Scopes(role)
+			return slices.Contains(scopes, cfg.scope)
+		}
+		return cfg.viewers != nil && viewerAllowed(r.Method, r.URL.Path)
+	default:
+		// Custom role (AUTH_ROLES): like its JWT identities, only the
+		// role-aware outer layer admits it, and RequireScope checks the
+		// role's current scopes.
+		scopes, known := roles.Scopes(role)
+		if !known {
+			return false
+		}
+		if cfg.scope != "" {
+			return slices.Contains(scopes, cfg.scope)
+		}
+		return cfg.accounts != nil && cfg.viewers != nil && customRoleAllowed(r.Method, r.URL.Path)
+	}
+}
+
 // extractToken pulls the raw JWT string from the request. Precedence (D-22):
 //  1. The HttpOnly cookie catchup_feed_auth_token (browser dashboard).
 //  2. The Authorization: Bearer header (dev / non-browser API clients).
@@ -253,12 +600,24 @@ func extractToken(r *http.Request) (string, error) {
 	return strings.TrimPrefix(authz, prefix), nil
 }
 
-// validateJWT parses and validates a raw JWT string and returns its subject
-// and role. It enforces HS256, a valid signature, the presence of exp (not
-// yet expired) and a non-empty sub claim. The role claim is returned as-is
-// ("" when absent); role-based rejection is the caller's job so 401
+// jwtClaims are the claims the middleware reads from a validated token.
+type jwtClaims struct {
+	sub    string
+	role   string
+	jti    string
+	exp    time.Time
+	scopes []string
+	// hasScope distinguishes an absent scope claim (token issued before
+	// scopes existed) from an empty one.
+	hasScope bool
+}
+
+// validateJWT parses and validates a raw JWT string and returns its subject,
+// role, jti, expiry and scopes. It enforces HS256, a valid signature, the presence of exp
+// (not yet expired) and a non-empty sub claim. The role claim is returned
+// as-is ("" when absent); role-based rejection is the caller's job so 401
 // (broken token) and 403 (valid token, wrong role) stay distinct.
-func validateJWT(tokenString string, secret []byte) (sub, role string, err error) {
+func validateJWT(tokenString string, secret []byte) (jwtClaims, error) {
 	tok, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
 		if t.Method.Alg() != jwt.SigningMethodHS256.Alg() {
 			return nil, errors.New("unexpected signing method")
@@ -266,19 +625,25 @@ func validateJWT(tokenString string, secret []byte) (sub, role string, err error
 		return secret, nil
 	})
 	if err != nil || !tok.Valid {
-		return "", "", errors.New("invalid token")
+		return jwtClaims{}, errors.New("invalid token")
 	}
 	claims, ok := tok.Claims.(jwt.MapClaims)
 	if !ok {
-		return "", "", errors.New("invalid claims")
+		return jwtClaims{}, errors.New("invalid claims")
 	}
-	if exp, ok := claims["exp"].(float64); !ok || int64(exp) < time.Now().Unix() {
-		return "", "", errors.New("token expired")
+	exp, ok := claims["exp"].(float64)
+	if !ok || int64(exp) < time.Now().Unix() {
+		return jwtClaims{}, errors.New("token expired")
 	}
-	sub, ok = claims["sub"].(string)
+	sub, ok := claims["sub"].(string)
 	if !ok || sub == "" {
-		return "", "", errors.New("invalid sub claim")
+		return jwtClaims{}, errors.New("invalid sub claim")
+	}
+	out := jwtClaims{sub: sub, exp: time.Unix(int64(exp), 0)}
+	out.role, _ = claims["role"].(string)
+	out.jti, _ = claims["jti"].(string)
+	if scope, ok := claims["scope"].(string); ok {
+		out.scopes, out.hasScope = strings.Fields(scope), true
 	}
-	role, _ = claims["role"].(string)
-	return sub, role, nil
+	return out, nil
 }
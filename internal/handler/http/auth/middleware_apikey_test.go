@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/common/pagination"
+	"catchup-feed/internal/domain/entity"
+	apikeyUC "catchup-feed/internal/usecase/apikey"
+)
+
+// stubAPIKeys is a canned APIKeyAuthenticator for middleware tests.
+type stubAPIKeys struct {
+	keys map[string]*entity.APIKey
+	err  error
+}
+
+func (s *stubAPIKeys) AuthenticateAPIKey(_ context.Context, plaintext string) (*entity.APIKey, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	key, ok := s.keys[plaintext]
+	if !ok {
+		return nil, apikeyUC.ErrInvalidKey
+	}
+	return key, nil
+}
+
+func TestAuthzWithAPIKeys(t *testing.T) {
+	setAuthzEnv(t)
+	keys := &stubAPIKeys{keys: map[string]*entity.APIKey{
+		"admin-key":  {ID: 1, Name: "ops-bot", Role: RoleAdmin},
+		"viewer-key": {ID: 2, Name: "reader", Role: RoleViewer},
+	}}
+	verifier := &stubViewerVerifier{}
+
+	// Mirrors cmd/server: the outer wrapper authenticates, an inner
+	// per-route Authz (admin-only) must admit admin keys without a JWT.
+	inner := http.NewServeMux()
+	inner.Handle("GET /sources", okHandler())
+	inner.Handle("POST /sources", Authz(okHandler()))
+
+	tests := []struct {
+		name     string
+		keys     APIKeyAuthenticator
+		method   string
+		path     string
+		apiKey   string
+		wantCode int
+	}{
+		{name: "admin key reaches admin-only route", keys: keys, method: http.MethodPost, path: "/sources", apiKey: "admin-key", wantCode: http.StatusOK},
+		{name: "viewer key reaches allowlisted route", keys: keys, method: http.MethodGet, path: "/sources", apiKey: "viewer-key", wantCode: http.StatusOK},
+		{name: "viewer key rejected on admin route", keys: keys, method: http.MethodPost, path: "/sources", apiKey: "viewer-key", wantCode: http.StatusForbidden},
+		{name: "unknown key", keys: keys, method: http.MethodGet, path: "/sources", apiKey: "nope", wantCode: http.StatusUnauthorized},
+		{name: "rate limited", keys: &stubAPIKeys{err: apikeyUC.ErrRateLimited}, method: http.MethodGet, path: "/sources", apiKey: "admin-key", wantCode: http.StatusTooManyRequests},
+		{name: "quota exceeded", keys: &stubAPIKeys{err: apikeyUC.ErrQuotaExceeded}, method: http.MethodGet, path: "/sources", apiKey: "admin-key", wantCode: http.StatusTooManyRequests},
+		{name: "lookup failure", keys: &stubAPIKeys{err: errors.New("db down")}, method: http.MethodGet, path: "/sources", apiKey: "admin-key", wantCode: http.StatusInternalServerError},
+		{name: "no key and no token", keys: keys, method: http.MethodGet, path: "/sources", wantCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := AuthzWithAPIKeys(verifier, tt.keys)(inner)
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.apiKey != "" {
+				req.Header.Set(APIKeyHeader, tt.apiKey)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
+
+func TestAuthzWithAPIKeys_IdentityInContext(t *testing.T) {
+	setAuthzEnv(t)
+	keys := &stubAPIKeys{keys: map[string]*entity.APIKey{
+		"admin-key": {ID: 1, Name: "ops-bot", Role: RoleAdmin},
+	}}
+
+	var gotSub, gotRole, gotTier string
+	var gotAPIKey bool
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSub = SubjectFromContext(r.Context())
+		gotRole = RoleFromContext(r.Context())
+		gotAPIKey = IsAPIKeyFromContext(r.Context())
+		gotTier = pagination.TierFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+	req.Header.Set(APIKeyHeader, "admin-key")
+	rec := httptest.NewRecorder()
+	AuthzWithAPIKeys(&stubViewerVerifier{}, keys)(inner).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "apikey:ops-bot", gotSub)
+	assert.Equal(t, RoleAdmin, gotRole)
+	assert.True(t, gotAPIKey)
+	assert.Equal(t, pagination.TierAPIKey, gotTier, "API keys page with their own limit, whatever the role")
+}
+
+// TestAuthzWithUsers_CustomRoleAPIKey: API キーも AUTH_ROLES のカスタム
+// ロールを持てる。JWT のカスタムロールと同じく、スコープのあるルートだけに
+// 届く。
+func TestAuthzWithUsers_CustomRoleAPIKey(t *testing.T) {
+	setAuthzEnv(t)
+	t.Setenv(EnvRoles, "reader=articles:read")
+	keys := &stubAPIKeys{keys: map[string]*entity.APIKey{
+		"reader-key": {ID: 3, Name: "dashboard", Role: "reader"},
+		"stale-key":  {ID: 4, Name: "old", Role: "editor"}, // AUTH_ROLES から消えたロール
+	}}
+
+	inner := http.NewServeMux()
+	inner.Handle("GET /articles", RequireScope(ScopeArticlesRead)(okHandler()))
+	inner.Handle("POST /articles", RequireScope(ScopeArticlesWrite)(okHandler()))
+	inner.Handle("GET /users", Authz(okHandler()))
+	inner.Handle("GET /private/feed.xml", okHandler())
+	handler := AuthzWithUsers(&stubViewerVerifier{}, keys, &stubAccounts{}, nil)(inner)
+
+	tests := []struct {
+		method, path, apiKey string
+		wantCode             int
+	}{
+		{http.MethodGet, "/articles", "reader-key", http.StatusOK},
+		{http.MethodPost, "/articles", "reader-key", http.StatusForbidden},
+		{http.MethodGet, "/users", "reader-key", http.StatusForbidden},
+		{http.MethodGet, "/private/feed.xml", "reader-key", http.StatusForbidden},
+		{http.MethodGet, "/articles", "stale-key", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path+" "+tt.apiKey, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set(APIKeyHeader, tt.apiKey)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
+
+// TestAuthz_IgnoresAPIKeyHeaderWithoutAuthenticator: the plain Authz
+// wrapper never trusts an X-API-Key header on its own.
+func TestAuthz_IgnoresAPIKeyHeaderWithoutAuthenticator(t *testing.T) {
+	setAuthzEnv(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+	req.Header.Set(APIKeyHeader, "admin-key")
+	rec := httptest.NewRecorder()
+	Authz(okHandler()).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
@@ -14,13 +14,15 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/common/pagination"
 )
 
 const testJWTSecret = "test-secret-key-at-least-32-characters-long"
 
 // setAuthzEnv configures everything Authz reads from the environment.
-// Authz captures env at construction time, so tests must call this before
-// building the middleware.
+// Authz captures ADMIN_USER and AUTH_ROLES at construction time, so tests
+// must call this before building the middleware.
 func setAuthzEnv(t *testing.T) {
 	t.Helper()
 	t.Setenv("JWT_SECRET", testJWTSecret)
@@ -470,10 +472,11 @@ func TestAuthzWithViewer_IdentityInContext(t *testing.T) {
 	setAuthzEnv(t)
 	const viewerEmail = "friend@example.com"
 
-	var gotSub, gotRole string
+	var gotSub, gotRole, gotTier string
 	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		gotSub = SubjectFromContext(r.Context())
 		gotRole = RoleFromContext(r.Context())
+		gotTier = pagination.TierFromContext(r.Context())
 		w.WriteHeader(http.StatusOK)
 	})
 	verifier := &stubViewerVerifier{active: map[string]bool{viewerEmail: true}}
@@ -488,6 +491,7 @@ func TestAuthzWithViewer_IdentityInContext(t *testing.T) {
 	require.Equal(t, http.StatusOK, rec.Code)
 	assert.Equal(t, viewerEmail, gotSub)
 	assert.Equal(t, RoleViewer, gotRole)
+	assert.Equal(t, pagination.TierViewer, gotTier)
 }
 
 // TestAuthz_FailsClosedWithoutAdminUser verifies that a server booted
@@ -533,6 +537,31 @@ func TestAuthz_FailsClosedWithoutJWTSecret(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, rec.Code)
 }
 
+// TestAuthz_FollowsJWTSecretRotation verifies that a JWT_SECRET replaced
+// after the middleware is built (SECRETS_REFRESH_INTERVAL) is used for
+// validation: tokens signed with the new secret are accepted and those
+// signed with the old one are not.
+func TestAuthz_FollowsJWTSecretRotation(t *testing.T) {
+	setAuthzEnv(t)
+	middleware := Authz(okHandler())
+
+	const rotated = "rotated-secret-key-at-least-32-characters"
+	t.Setenv("JWT_SECRET", rotated)
+
+	for secret, want := range map[string]int{
+		rotated:       http.StatusOK,
+		testJWTSecret: http.StatusUnauthorized,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+		req.Header.Set("Authorization", "Bearer "+signToken(t, secret, adminClaims()))
+		rec := httptest.NewRecorder()
+
+		middleware.ServeHTTP(rec, req)
+
+		assert.Equal(t, want, rec.Code, secret)
+	}
+}
+
 // TestAuthz_UserInContext verifies the authenticated subject is propagated
 // to downstream handlers.
 func TestAuthz_UserInContext(t *testing.T) {
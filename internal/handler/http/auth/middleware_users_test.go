@@ -0,0 +1,258 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authservice "catchup-feed/internal/service/auth"
+	userUC "catchup-feed/internal/usecase/user"
+)
+
+// stubAccounts is a canned AccountVerifier / AccountAuthenticator for
+// tests: active maps an active user's email to their role.
+type stubAccounts struct {
+	active map[string]string
+	err    error
+}
+
+func (s *stubAccounts) IsActiveAccount(_ context.Context, email, role string) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+	got, ok := s.active[email]
+	return ok && got == role, nil
+}
+
+func (s *stubAccounts) AuthenticateAccount(_ context.Context, email, password string) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	role, ok := s.active[email]
+	if !ok || password != "admin-pass-1" {
+		return "", userUC.ErrInvalidCredentials
+	}
+	return role, nil
+}
+
+func TestAuthzWithUsers(t *testing.T) {
+	// ブートストラップ後は ADMIN_USER が未設定でもよい: admin の判定は
+	// users テーブル(AccountVerifier)が担い、内側の Authz はそれを信頼する。
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	t.Setenv(EnvAdminUser, "")
+
+	inner := http.NewServeMux()
+	inner.Handle("POST /sources", Authz(okHandler()))
+
+	adminToken := func(sub string) string {
+		claims := adminClaims()
+		claims["sub"] = sub
+		return signToken(t, testJWTSecret, claims)
+	}
+
+	tests := []struct {
+		name     string
+		accounts *stubAccounts
+		token    string
+		wantCode int
+	}{
+		{
+			name:     "active admin reaches admin-only route",
+			accounts: &stubAccounts{active: map[string]string{"ops@example.com": RoleAdmin}},
+			token:    adminToken("ops@example.com"),
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "deactivated, deleted or demoted admin is forbidden",
+			accounts: &stubAccounts{active: map[string]string{}},
+			token:    adminToken("ops@example.com"),
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "verifier failure is an internal error",
+			accounts: &stubAccounts{err: errors.New("db down")},
+			token:    adminToken("ops@example.com"),
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name:     "token without role claim stays forbidden",
+			accounts: &stubAccounts{active: map[string]string{"ops@example.com": RoleAdmin}},
+			token:    signToken(t, testJWTSecret, jwt.MapClaims{"sub": "ops@example.com", "exp": adminClaims()["exp"]}),
+			wantCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := AuthzWithUsers(&stubViewerVerifier{}, nil, tt.accounts, nil)(inner)
+
+			req := httptest.NewRequest(http.MethodPost, "/sources", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
+
+// TestAuthz_DoesNotTrustUnverifiedAdmin: the inner Authz alone still
+// requires the ADMIN_USER subject — the users-table marker can only come
+// from AuthzWithUsers.
+func TestAuthz_DoesNotTrustUnverifiedAdmin(t *testing.T) {
+	setAuthzEnv(t)
+	claims := adminClaims()
+	claims["sub"] = "ops@example.com"
+
+	req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+	req.Header.Set("Authorization", "Bearer "+signToken(t, testJWTSecret, claims))
+	rec := httptest.NewRecorder()
+	Authz(okHandler()).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestUserAuthProvider(t *testing.T) {
+	p := NewUserAuthProvider(&stubAccounts{active: map[string]string{"ops@example.com": RoleAdmin, "ed@example.com": "editor", "v@example.com": RoleViewer}})
+	assert.Equal(t, "db-bcrypt", p.Name())
+
+	require.NoError(t, p.ValidateCredentials(context.Background(), authservice.Credentials{Username: "ops@example.com", Password: "admin-pass-1"}))
+	assert.Error(t, p.ValidateCredentials(context.Background(), authservice.Credentials{Username: "ops@example.com", Password: "wrong"}))
+	assert.Error(t, p.ValidateCredentials(context.Background(), authservice.Credentials{Username: "", Password: ""}))
+
+	// custom role はトークン発行(AuthenticateRole)では通るが、admin 専用の
+	// ValidateCredentials では通らない。viewer は viewer フォールバックに任せる。
+	assert.Error(t, p.ValidateCredentials(context.Background(), authservice.Credentials{Username: "ed@example.com", Password: "admin-pass-1"}))
+	role, err := p.AuthenticateRole(context.Background(), authservice.Credentials{Username: "ed@example.com", Password: "admin-pass-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "editor", role)
+	_, err = p.AuthenticateRole(context.Background(), authservice.Credentials{Username: "v@example.com", Password: "admin-pass-1"})
+	assert.Error(t, err)
+
+	failing := NewUserAuthProvider(&stubAccounts{err: errors.New("db down")})
+	err = failing.ValidateCredentials(context.Background(), authservice.Credentials{Username: "ops@example.com", Password: "admin-pass-1"})
+	assert.ErrorContains(t, err, "db down")
+}
+
+// TestAuthzWithUsers_CustomRoles: AUTH_ROLES のカスタムロールは、外側の
+// AuthzWithUsers で users テーブルと照合され、各ルートグループの
+// RequireScope でスコープを検査される。スコープのないルートは admin 専用の
+// まま(既定拒否)。
+func TestAuthzWithUsers_CustomRoles(t *testing.T) {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	t.Setenv(EnvAdminUser, "")
+	t.Setenv(EnvRoles, "editor=articles:read,articles:write;reader=articles:read;curator=sources:read,sources:write")
+
+	inner := http.NewServeMux()
+	inner.Handle("GET /articles", RequireScope(ScopeArticlesRead)(okHandler()))
+	inner.Handle("POST /articles", RequireScope(ScopeArticlesWrite)(okHandler()))
+	inner.Handle("POST /sources", RequireScope(ScopeSourcesWrite)(okHandler()))
+	inner.Handle("GET /users", Authz(okHandler()))
+	inner.Handle("GET /private/feed.xml", okHandler())
+	inner.Handle("GET /feed.xml", RequireScope(ScopeArticlesRead)(okHandler()))
+	inner.Handle("POST /crawl", RequireScope(ScopeSourcesWrite)(okHandler()))
+	inner.Handle("GET /crawls", RequireScope(ScopeSourcesRead)(okHandler()))
+	inner.Handle("GET /alerts", RequireScope(ScopeArticlesRead)(okHandler()))
+	inner.Handle("POST /source-groups", RequireScope(ScopeSourcesWrite)(okHandler()))
+	inner.Handle("GET /auth/me", MeHandler())
+
+	accounts := &stubAccounts{active: map[string]string{
+		"ed@example.com":  "editor",
+		"rd@example.com":  "reader",
+		"cu@example.com":  "curator",
+		"ops@example.com": RoleAdmin,
+	}}
+	handler := AuthzWithUsers(&stubViewerVerifier{}, nil, accounts, nil)(inner)
+
+	token := func(sub, role string, scope any) string {
+		claims := jwt.MapClaims{"sub": sub, "role": role, "exp": adminClaims()["exp"]}
+		if scope != nil {
+			claims["scope"] = scope
+		}
+		return signToken(t, testJWTSecret, claims)
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		token    string
+		wantCode int
+	}{
+		{"editor reads articles", http.MethodGet, "/articles", token("ed@example.com", "editor", "articles:read articles:write"), http.StatusOK},
+		{"editor writes articles", http.MethodPost, "/articles", token("ed@example.com", "editor", "articles:read articles:write"), http.StatusOK},
+		{"editor without sources:write", http.MethodPost, "/sources", token("ed@example.com", "editor", "articles:read articles:write"), http.StatusForbidden},
+		{"reader cannot write", http.MethodPost, "/articles", token("rd@example.com", "reader", "articles:read"), http.StatusForbidden},
+		{"token scope narrows the role", http.MethodPost, "/articles", token("ed@example.com", "editor", "articles:read"), http.StatusForbidden},
+		{"token scope cannot widen the role", http.MethodPost, "/articles", token("rd@example.com", "reader", "articles:read articles:write"), http.StatusForbidden},
+		{"token without scope claim gets the role's scopes", http.MethodPost, "/articles", token("ed@example.com", "editor", nil), http.StatusOK},
+		{"admin-only route stays closed", http.MethodGet, "/users", token("ed@example.com", "editor", "articles:read"), http.StatusForbidden},
+		{"reader reads the article feed", http.MethodGet, "/feed.xml", token("rd@example.com", "reader", "articles:read"), http.StatusOK},
+		{"curator triggers a crawl", http.MethodPost, "/crawl", token("cu@example.com", "curator", "sources:read sources:write"), http.StatusOK},
+		{"curator reads the crawl history", http.MethodGet, "/crawls", token("cu@example.com", "curator", "sources:read sources:write"), http.StatusOK},
+		{"editor cannot read the crawl history", http.MethodGet, "/crawls", token("ed@example.com", "editor", "articles:read articles:write"), http.StatusForbidden},
+		{"reader lists their keyword alerts", http.MethodGet, "/alerts", token("rd@example.com", "reader", "articles:read"), http.StatusOK},
+		{"curator cannot list keyword alerts", http.MethodGet, "/alerts", token("cu@example.com", "curator", "sources:read sources:write"), http.StatusForbidden},
+		{"curator creates a source group", http.MethodPost, "/source-groups", token("cu@example.com", "curator", "sources:read sources:write"), http.StatusOK},
+		{"editor cannot create a source group", http.MethodPost, "/source-groups", token("ed@example.com", "editor", "articles:read articles:write"), http.StatusForbidden},
+		{"editor cannot trigger a crawl", http.MethodPost, "/crawl", token("ed@example.com", "editor", "articles:read articles:write"), http.StatusForbidden},
+		{"unscoped private route stays closed", http.MethodGet, "/private/feed.xml", token("ed@example.com", "editor", "articles:read"), http.StatusForbidden},
+		{"role changed in users table", http.MethodGet, "/articles", token("rd@example.com", "editor", "articles:read"), http.StatusForbidden},
+		{"undefined role", http.MethodGet, "/articles", token("ed@example.com", "owner", "articles:read"), http.StatusForbidden},
+		{"admin holds every scope", http.MethodPost, "/sources", token("ops@example.com", RoleAdmin, nil), http.StatusOK},
+		{"custom role reads /auth/me", http.MethodGet, "/auth/me", token("ed@example.com", "editor", "articles:read"), http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
+
+// TestRequireScope_RejectsUnverifiedCustomRole: RequireScope 単体では
+// カスタムロールを DB 照合できないので通さない(Authz と同じ扱い)。
+func TestRequireScope_RejectsUnverifiedCustomRole(t *testing.T) {
+	setAuthzEnv(t)
+	t.Setenv(EnvRoles, "editor=articles:read")
+
+	claims := jwt.MapClaims{"sub": "ed@example.com", "role": "editor", "scope": "articles:read", "exp": adminClaims()["exp"]}
+	req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+	req.Header.Set("Authorization", "Bearer "+signToken(t, testJWTSecret, claims))
+	rec := httptest.NewRecorder()
+	RequireScope(ScopeArticlesRead)(okHandler()).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestSignAccessToken_ScopeClaim(t *testing.T) {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	t.Setenv(EnvRoles, "editor=articles:read,articles:write")
+
+	for role, want := range map[string]string{
+		"editor":   "articles:read articles:write",
+		RoleViewer: "sources:read",
+		RoleAdmin:  "articles:read articles:write sources:read sources:write ai:ask",
+	} {
+		signed, err := signAccessToken("someone@example.com", role, time.Now())
+		require.NoError(t, err)
+
+		claims, err := validateJWT(signed, []byte(testJWTSecret))
+		require.NoError(t, err)
+		assert.True(t, claims.hasScope)
+		assert.Equal(t, want, strings.Join(claims.scopes, " "), role)
+	}
+}
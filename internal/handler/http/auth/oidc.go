@@ -0,0 +1,206 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/requestid"
+	userUC "catchup-feed/internal/usecase/user"
+)
+
+// EnvOIDCRoleMap maps ID-token claims to roles; see ParseOIDCRoleMapping.
+const EnvOIDCRoleMap = "OIDC_ROLE_MAP"
+
+// IDTokenVerifier verifies an ID token issued by the external OIDC
+// provider (signature via JWKS, issuer, audience, expiry) and returns its
+// claims. Implemented by infra/oidc.Verifier.
+type IDTokenVerifier interface {
+	Verify(ctx context.Context, rawIDToken string) (map[string]any, error)
+}
+
+// ExternalAccounts resolves a verified external identity to a users row,
+// provisioning it with the mapped role on first login. Unmapped identities
+// and deactivated accounts fail with usecase/user.ErrInvalidCredentials.
+// Implemented by usecase/user.Service.
+type ExternalAccounts interface {
+	LoginExternal(ctx context.Context, email, name, role string) (*entity.User, error)
+}
+
+// OIDCRoleRule grants Role to identities whose Claim equals Value
+// (case-insensitive; for array claims such as groups, any element). Value
+// "*" matches any non-empty claim.
+type OIDCRoleRule struct {
+	Claim string
+	Value string
+	Role  string
+}
+
+// OIDCRoleMapping is an ordered rule list; the first matching rule wins.
+type OIDCRoleMapping []OIDCRoleRule
+
+// LoadOIDCRoleMapping reads OIDC_ROLE_MAP. Like LoadRoles this fails
+// closed: a malformed rule or a role that roles does not know is an error
+// that prevents startup.
+func LoadOIDCRoleMapping(roles *Roles) (OIDCRoleMapping, error) {
+	return ParseOIDCRoleMapping(os.Getenv(EnvOIDCRoleMap), roles)
+}
+
+// ParseOIDCRoleMapping parses semicolon-separated "<claim>:<value>=<role>"
+// rules, e.g.
+//
+//	OIDC_ROLE_MAP="email:owner@example.com=admin;groups:catchup-editors=editor;hd:example.com=viewer"
+//
+// The claim name ends at the first ':' and the role starts after the last
+// '=', so values may contain ':' (URLs, group IDs) but not '=' or ';'.
+func ParseOIDCRoleMapping(spec string, roles *Roles) (OIDCRoleMapping, error) {
+	var mapping OIDCRoleMapping
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		match, role, ok := cutLast(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid OIDC role rule %q: must be \"<claim>:<value>=<role>\"", entry)
+		}
+		claim, value, ok := strings.Cut(match, ":")
+		claim, value, role = strings.TrimSpace(claim), strings.TrimSpace(value), strings.TrimSpace(role)
+		if !ok || claim == "" || value == "" {
+			return nil, fmt.Errorf("invalid OIDC role rule %q: must be \"<claim>:<value>=<role>\"", entry)
+		}
+		if _, known := roles.Scopes(role); !known {
+			return nil, fmt.Errorf("invalid OIDC role rule %q: unknown role %q", entry, role)
+		}
+		mapping = append(mapping, OIDCRoleRule{Claim: claim, Value: value, Role: role})
+	}
+	return mapping, nil
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// Role returns the role of the first rule claims match, or "" when none
+// does.
+func (m OIDCRoleMapping) Role(claims map[string]any) string {
+	for _, rule := range m {
+		if claimMatches(claims[rule.Claim], rule.Value) {
+			return rule.Role
+		}
+	}
+	return ""
+}
+
+func claimMatches(claim any, want string) bool {
+	switch v := claim.(type) {
+	case string:
+		return v != "" && (want == "*" || strings.EqualFold(v, want))
+	case bool:
+		return want == "*" || strings.EqualFold(fmt.Sprint(v), want)
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok && claimMatches(s, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type oidcLoginRequest struct {
+	IDToken string `json:"id_token" example:"eyJhbGciOiJSUzI1NiIsImtpZCI6Ii4uLiJ9..."`
+}
+
+// OIDCTokenHandler exchanges an ID token from the external OIDC provider
+// (obtained by the frontend through the provider's own sign-in flow) for
+// this service's JWT and refresh token — the same session TokenHandler
+// issues, so everything downstream is unchanged. The identity is the
+// token's email claim (email_verified must not be false); the role comes
+// from the users table for existing accounts and from mapping for
+// just-in-time provisioned ones. refresh and audit may be nil.
+//
+// Failures reply with http.Error (text/plain), like TokenHandler.
+//
+// @Summary      OIDC ログイン(ID トークン交換)
+// @Description  外部 OIDC プロバイダ(Google / Microsoft Entra ID など、OIDC_ISSUER)が発行した ID トークンを検証し、
+// @Description  /auth/token と同じ JWT(cookie + body)とリフレッシュトークンを発行します。
+// @Description  署名は JWKS で検証し、iss / aud(OIDC_CLIENT_ID)/ exp を確認します。
+// @Description  アカウントは email クレームで users テーブルと照合し、既存アカウントはそのロールのまま、
+// @Description  未登録ならクレーム→ロールの対応表(OIDC_ROLE_MAP)で決めたロールで作成します。
+// @Description  どの規則にも一致しない ID トークン・無効化済みアカウントは 401 です。
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body oidcLoginRequest true "外部プロバイダの ID トークン"
+// @Success      200 {object} tokenResponse "JWT(併せて Set-Cookie: catchup_feed_auth_token を返す)"
+// @Failure      400 {string} string "リクエストが不正"
+// @Failure      401 {string} string "ID トークンが無効、またはロール対応なし"
+// @Failure      429 {string} string "Too many requests - rate limit exceeded"
+// @Failure      500 {string} string "トークン生成失敗"
+// @Router       /auth/oidc [post]
+func OIDCTokenHandler(verifier IDTokenVerifier, mapping OIDCRoleMapping, accounts ExternalAccounts, refresh RefreshTokens, audit TokenAuditor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		logger := slog.With(slog.String("request_id", requestid.FromContext(r.Context())))
+		unauthorized := func(reason string, err error) {
+			attrs := []any{
+				slog.String("reason", reason),
+				slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+			}
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+			}
+			logger.Warn("oidc authentication failed", attrs...)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+		}
+
+		var req oidcLoginRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.IDToken == "" {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+
+		claims, err := verifier.Verify(r.Context(), req.IDToken)
+		if err != nil {
+			unauthorized("invalid_id_token", err)
+			return
+		}
+		email, _ := claims["email"].(string)
+		if email == "" {
+			unauthorized("email_claim_missing", nil)
+			return
+		}
+		if verified, ok := claims["email_verified"].(bool); ok && !verified {
+			unauthorized("email_not_verified", nil)
+			return
+		}
+		name, _ := claims["name"].(string)
+
+		user, err := accounts.LoginExternal(r.Context(), email, name, mapping.Role(claims))
+		switch {
+		case errors.Is(err, userUC.ErrInvalidCredentials), errors.Is(err, userUC.ErrInvalidRole):
+			unauthorized("no_role_mapping_or_deactivated", err)
+			return
+		case err != nil:
+			logger.Error("oidc authentication failed",
+				slog.String("reason", "account_lookup_failed"),
+				slog.String("error", err.Error()),
+				slog.Int64("duration_ms", time.Since(start).Milliseconds()))
+			http.Error(w, "token generation failed", http.StatusInternalServerError)
+			return
+		}
+
+		issueSession(w, r, logger, start, user.Email, user.Role, refresh, audit)
+	}
+}
@@ -0,0 +1,153 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	userUC "catchup-feed/internal/usecase/user"
+)
+
+// stubIDTokens accepts the ID tokens in valid and returns their claims.
+type stubIDTokens struct {
+	valid map[string]map[string]any
+}
+
+func (s *stubIDTokens) Verify(_ context.Context, raw string) (map[string]any, error) {
+	claims, ok := s.valid[raw]
+	if !ok {
+		return nil, errors.New("invalid id token")
+	}
+	return claims, nil
+}
+
+// stubExternalAccounts knows existing accounts by email; unknown emails
+// are provisioned with the mapped role.
+type stubExternalAccounts struct {
+	existing map[string]string
+	gotRole  string
+	err      error
+}
+
+func (s *stubExternalAccounts) LoginExternal(_ context.Context, email, _, role string) (*entity.User, error) {
+	s.gotRole = role
+	if s.err != nil {
+		return nil, s.err
+	}
+	if existing, ok := s.existing[email]; ok {
+		return &entity.User{Email: email, Role: existing}, nil
+	}
+	if role == "" {
+		return nil, userUC.ErrInvalidCredentials
+	}
+	return &entity.User{Email: email, Role: role}, nil
+}
+
+func TestParseOIDCRoleMapping(t *testing.T) {
+	roles, err := ParseRoles("editor=articles:read,articles:write")
+	require.NoError(t, err)
+
+	mapping, err := ParseOIDCRoleMapping("email:owner@example.com=admin; groups:https://idp/groups/ed=editor ;hd:*=viewer", roles)
+	require.NoError(t, err)
+	assert.Equal(t, OIDCRoleMapping{
+		{Claim: "email", Value: "owner@example.com", Role: RoleAdmin},
+		{Claim: "groups", Value: "https://idp/groups/ed", Role: "editor"},
+		{Claim: "hd", Value: "*", Role: RoleViewer},
+	}, mapping)
+
+	for _, spec := range []string{"email=admin", "email:=admin", "email:a@example.com", "email:a@example.com=owner"} {
+		_, err := ParseOIDCRoleMapping(spec, roles)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestOIDCRoleMapping_Role(t *testing.T) {
+	mapping := OIDCRoleMapping{
+		{Claim: "email", Value: "owner@example.com", Role: RoleAdmin},
+		{Claim: "groups", Value: "editors", Role: "editor"},
+		{Claim: "hd", Value: "*", Role: RoleViewer},
+	}
+
+	tests := []struct {
+		name   string
+		claims map[string]any
+		want   string
+	}{
+		{"email match is case-insensitive", map[string]any{"email": "Owner@Example.com"}, RoleAdmin},
+		{"array claim", map[string]any{"email": "ed@example.com", "groups": []any{"staff", "editors"}}, "editor"},
+		{"first rule wins", map[string]any{"email": "owner@example.com", "groups": []any{"editors"}}, RoleAdmin},
+		{"wildcard needs a non-empty claim", map[string]any{"hd": "example.com"}, RoleViewer},
+		{"empty claim does not match the wildcard", map[string]any{"hd": ""}, ""},
+		{"no match", map[string]any{"email": "who@example.com"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mapping.Role(tt.claims))
+		})
+	}
+}
+
+func TestOIDCTokenHandler(t *testing.T) {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+
+	verifier := &stubIDTokens{valid: map[string]map[string]any{
+		"owner":      {"email": "owner@example.com", "email_verified": true},
+		"newcomer":   {"email": "new@example.com", "hd": "example.com"},
+		"stranger":   {"email": "who@gmail.com"},
+		"unverified": {"email": "owner@example.com", "email_verified": false},
+		"no-email":   {"sub": "123"},
+	}}
+	mapping := OIDCRoleMapping{{Claim: "hd", Value: "example.com", Role: RoleViewer}}
+
+	tests := []struct {
+		name     string
+		body     string
+		accounts *stubExternalAccounts
+		wantCode int
+		wantRole string
+	}{
+		{name: "existing account keeps its role", body: `{"id_token":"owner"}`, accounts: &stubExternalAccounts{existing: map[string]string{"owner@example.com": RoleAdmin}}, wantCode: http.StatusOK, wantRole: RoleAdmin},
+		{name: "mapped newcomer is provisioned", body: `{"id_token":"newcomer"}`, accounts: &stubExternalAccounts{}, wantCode: http.StatusOK, wantRole: RoleViewer},
+		{name: "no mapping", body: `{"id_token":"stranger"}`, accounts: &stubExternalAccounts{}, wantCode: http.StatusUnauthorized},
+		{name: "invalid id token", body: `{"id_token":"forged"}`, accounts: &stubExternalAccounts{}, wantCode: http.StatusUnauthorized},
+		{name: "email not verified", body: `{"id_token":"unverified"}`, accounts: &stubExternalAccounts{existing: map[string]string{"owner@example.com": RoleAdmin}}, wantCode: http.StatusUnauthorized},
+		{name: "email claim missing", body: `{"id_token":"no-email"}`, accounts: &stubExternalAccounts{}, wantCode: http.StatusUnauthorized},
+		{name: "missing id token", body: `{}`, accounts: &stubExternalAccounts{}, wantCode: http.StatusBadRequest},
+		{name: "account lookup failure", body: `{"id_token":"owner"}`, accounts: &stubExternalAccounts{err: errors.New("db down")}, wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refresh := newStubRefreshTokens()
+			handler := OIDCTokenHandler(verifier, mapping, tt.accounts, refresh, nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/oidc", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantCode != http.StatusOK {
+				assert.Nil(t, findAuthCookie(t, rec))
+				return
+			}
+			var resp tokenResponse
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+			assert.Equal(t, "cfr_login", resp.RefreshToken)
+
+			// /auth/token と同じ JWT: role / scope クレーム付き。
+			claims, err := validateJWT(resp.Token, []byte(testJWTSecret))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRole, claims.role)
+			assert.True(t, claims.hasScope)
+		})
+	}
+}
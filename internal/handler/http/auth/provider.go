@@ -11,8 +11,10 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
-// Environment variable names for the administrator credentials (C-7:
-// 単一管理者、users テーブルなし。環境変数+bcrypt ハッシュ)。
+// Environment variable names for the administrator credentials. C-7 では
+// 単一管理者を環境変数+bcrypt ハッシュで持っていたが、現在は users
+// テーブルに admin が1人もいない時だけ使うブートストラップ用(最初の
+// admin の作成)。
 const (
 	// EnvAdminUser holds the administrator's login name.
 	EnvAdminUser = "ADMIN_USER"
@@ -79,3 +81,62 @@ func (p *AdminAuthProvider) ValidateCredentials(_ context.Context, creds authser
 func (p *AdminAuthProvider) Name() string {
 	return "env-bcrypt"
 }
+
+// AccountAuthenticator validates a login against the users table and
+// returns the account's role. Credential mismatches (unknown email / wrong
+// password / deactivated) are usecase/user.ErrInvalidCredentials; any
+// other error is an infrastructure failure. Implemented by
+// usecase/user.Service.
+type AccountAuthenticator interface {
+	AuthenticateAccount(ctx context.Context, email, password string) (string, error)
+}
+
+// UserAuthProvider validates credentials against the users table (bcrypt,
+// constant work per attempt — see AuthenticateAccount). It replaces
+// AdminAuthProvider once admins are database accounts, and additionally
+// authenticates custom-role accounts (AUTH_ROLES) through
+// AuthenticateRole. Viewers are left to the viewer fallback of
+// TokenHandler, so their login path is unchanged.
+type UserAuthProvider struct {
+	users AccountAuthenticator
+}
+
+// NewUserAuthProvider creates a provider backed by the users table.
+func NewUserAuthProvider(users AccountAuthenticator) *UserAuthProvider {
+	return &UserAuthProvider{users: users}
+}
+
+// ValidateCredentials accepts administrators only. Like
+// AdminAuthProvider, failures are generic; infrastructure errors are
+// wrapped so they remain visible to errors.Is.
+func (p *UserAuthProvider) ValidateCredentials(ctx context.Context, creds authservice.Credentials) error {
+	role, err := p.AuthenticateRole(ctx, creds)
+	if err != nil {
+		return err
+	}
+	if role != RoleAdmin {
+		return fmt.Errorf("invalid credentials")
+	}
+	return nil
+}
+
+// AuthenticateRole accepts administrators and custom-role accounts and
+// returns the role.
+func (p *UserAuthProvider) AuthenticateRole(ctx context.Context, creds authservice.Credentials) (string, error) {
+	if creds.Username == "" || creds.Password == "" {
+		return "", fmt.Errorf("credentials must not be empty")
+	}
+	role, err := p.users.AuthenticateAccount(ctx, creds.Username, creds.Password)
+	if err != nil {
+		return "", fmt.Errorf("invalid credentials: %w", err)
+	}
+	if role == RoleViewer {
+		return "", fmt.Errorf("invalid credentials")
+	}
+	return role, nil
+}
+
+// Name returns the provider name.
+func (p *UserAuthProvider) Name() string {
+	return "db-bcrypt"
+}
@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"catchup-feed/internal/handler/http/requestid"
+)
+
+// AccessTokenRevoker denylists an access JWT by its jti until expiresAt.
+// Implemented by usecase/tokenrevocation.Service.
+type AccessTokenRevoker interface {
+	Revoke(ctx context.Context, jti, subject string, expiresAt time.Time) error
+}
+
+type revokeRequest struct {
+	Token string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+}
+
+// LogoutHandlerWithRevocation is LogoutHandlerWithRefresh that also
+// denylists the access JWT of the request (cookie, or Bearer header), so a
+// copy of it stops working before exp. refresh and revoker may be nil.
+// Like LogoutHandler it is idempotent and answers 204 even when revocation
+// fails (logged): the cookies are cleared either way.
+func LogoutHandlerWithRevocation(refresh RefreshTokens, revoker AccessTokenRevoker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := revokeSession(r, refresh, revoker); err != nil {
+			slog.Error("logout revocation failed",
+				slog.String("request_id", requestid.FromContext(r.Context())),
+				slog.String("error", err.Error()))
+		}
+		http.SetCookie(w, expiredAuthCookie())
+		http.SetCookie(w, expiredRefreshCookie())
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// RevokeHandler revokes a token before it expires (RFC 7009 style). With a
+// JSON body {"token": "..."} it revokes that token: an access JWT signed by
+// this server is denylisted by jti until its exp, anything else is treated
+// as a refresh token and its family revoked. Without a body it revokes the
+// caller's own session — the access JWT from the cookie / Bearer header and
+// the refresh cookie — and clears both cookies, like logout.
+//
+// Unknown, expired or foreign tokens also answer 204 (RFC 7009 §2.2): the
+// response does not reveal whether a token was valid. Unlike logout, a
+// storage failure answers 500 so the client knows the token may still be
+// live and can retry. Failures reply with http.Error (text/plain), like
+// TokenHandler.
+//
+// @Summary      トークン失効(revoke)
+// @Description  JWT またはリフレッシュトークンを有効期限前に失効させます(RFC 7009 相当)。
+// @Description  body の token が JWT なら jti を失効リストに登録し(exp まで)、以降の API リクエストは 401 になります。
+// @Description  それ以外はリフレッシュトークンとして、そのログイン系列ごと失効させます。
+// @Description  body を省略すると、リクエストの JWT(cookie または Bearer)とリフレッシュ cookie を失効させ、cookie も消します。
+// @Description  未知・期限切れのトークンも 204 を返します(有効だったかは明かさない)。認証不要。
+// @Tags         auth
+// @Accept       json
+// @Param        request body revokeRequest false "失効させるトークン(省略時は自分のセッション)"
+// @Success      204 "失効済み(または未知のトークン)"
+// @Failure      400 {string} string "リクエストが不正"
+// @Failure      429 {string} string "Too many requests - rate limit exceeded"
+// @Failure      500 {string} string "失効処理に失敗"
+// @Router       /auth/revoke [post]
+func RevokeHandler(refresh RefreshTokens, revoker AccessTokenRevoker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := slog.With(slog.String("request_id", requestid.FromContext(r.Context())))
+
+		var req revokeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+
+		if req.Token == "" {
+			if err := revokeSession(r, refresh, revoker); err != nil {
+				logger.Error("token revocation failed", slog.String("error", err.Error()))
+				http.Error(w, "revocation failed", http.StatusInternalServerError)
+				return
+			}
+			http.SetCookie(w, expiredAuthCookie())
+			http.SetCookie(w, expiredRefreshCookie())
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		revoked, err := revokeAccessToken(r.Context(), revoker, req.Token)
+		if err == nil && !revoked && refresh != nil {
+			err = refresh.Revoke(r.Context(), req.Token)
+		}
+		if err != nil {
+			logger.Error("token revocation failed", slog.String("error", err.Error()))
+			http.Error(w, "revocation failed", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// revokeSession revokes the request's own refresh cookie and access JWT.
+// Both are attempted; the first error is returned.
+func revokeSession(r *http.Request, refresh RefreshTokens, revoker AccessTokenRevoker) error {
+	var errs []error
+	if c, err := r.Cookie(refreshCookieName); err == nil && c.Value != "" && refresh != nil {
+		errs = append(errs, refresh.Revoke(r.Context(), c.Value))
+	}
+	if raw, err := extractToken(r); err == nil {
+		_, err := revokeAccessToken(r.Context(), revoker, raw)
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// revokeAccessToken denylists raw when it is a live access JWT of this
+// server and reports whether it was one. Invalid or expired tokens need no
+// revocation and are reported as false without error.
+func revokeAccessToken(ctx context.Context, revoker AccessTokenRevoker, raw string) (bool, error) {
+	secret := []byte(os.Getenv("JWT_SECRET"))
+	if len(secret) == 0 {
+		return false, nil
+	}
+	claims, err := validateJWT(raw, secret)
+	if err != nil {
+		return false, nil
+	}
+	if revoker != nil {
+		if err := revoker.Revoke(ctx, claims.jti, claims.sub, claims.exp); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
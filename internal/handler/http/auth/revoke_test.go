@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubDenylist is an in-memory TokenDenylist / AccessTokenRevoker.
+type stubDenylist struct {
+	revoked map[string]string // jti -> subject
+	err     error
+}
+
+func newStubDenylist() *stubDenylist {
+	return &stubDenylist{revoked: map[string]string{}}
+}
+
+func (s *stubDenylist) Revoke(_ context.Context, jti, subject string, _ time.Time) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.revoked[jti] = subject
+	return nil
+}
+
+func (s *stubDenylist) IsRevoked(_ context.Context, jti string) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+	_, ok := s.revoked[jti]
+	return ok, nil
+}
+
+func tokenWithJTI(t *testing.T, jti string) string {
+	t.Helper()
+	claims := adminClaims()
+	claims["jti"] = jti
+	return signToken(t, testJWTSecret, claims)
+}
+
+func TestAuthzWithUsers_RevokedToken(t *testing.T) {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	t.Setenv(EnvAdminUser, "")
+
+	accounts := &stubAccounts{active: map[string]string{testAdminUser: RoleAdmin}}
+	denylist := newStubDenylist()
+	denylist.revoked["revoked-jti"] = testAdminUser
+	handler := AuthzWithUsers(&stubViewerVerifier{}, nil, accounts, denylist)(okHandler())
+
+	tests := []struct {
+		name     string
+		token    string
+		err      error
+		wantCode int
+	}{
+		{name: "live token", token: tokenWithJTI(t, "live-jti"), wantCode: http.StatusOK},
+		{name: "revoked token", token: tokenWithJTI(t, "revoked-jti"), wantCode: http.StatusUnauthorized},
+		{name: "token without jti", token: signToken(t, testJWTSecret, adminClaims()), wantCode: http.StatusOK},
+		// 失効リストを読めないときは fail-closed。
+		{name: "denylist unavailable", token: tokenWithJTI(t, "live-jti"), err: errors.New("db down"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			denylist.err = tt.err
+			req := httptest.NewRequest(http.MethodGet, "/sources", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
+
+func TestSignAccessToken_UniqueJTI(t *testing.T) {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+
+	first, err := signAccessToken(testAdminUser, RoleAdmin, time.Now())
+	require.NoError(t, err)
+	second, err := signAccessToken(testAdminUser, RoleAdmin, time.Now())
+	require.NoError(t, err)
+
+	a, err := validateJWT(first, []byte(testJWTSecret))
+	require.NoError(t, err)
+	b, err := validateJWT(second, []byte(testJWTSecret))
+	require.NoError(t, err)
+	assert.NotEmpty(t, a.jti)
+	assert.NotEqual(t, a.jti, b.jti)
+}
+
+func TestRevokeHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		bearer      string
+		cookie      string
+		wantCode    int
+		wantJTI     string
+		wantRefresh []string
+	}{
+		{name: "access token in body", body: `{"token":"` + tokenWithJTI(t, "jti-1") + `"}`, wantCode: http.StatusNoContent, wantJTI: "jti-1"},
+		{name: "refresh token in body", body: `{"token":"cfr_a"}`, wantCode: http.StatusNoContent, wantRefresh: []string{"cfr_a"}},
+		{name: "unknown token", body: `{"token":"garbage"}`, wantCode: http.StatusNoContent, wantRefresh: []string{"garbage"}},
+		{name: "own session without body", bearer: tokenWithJTI(t, "jti-2"), cookie: "cfr_a", wantCode: http.StatusNoContent, wantJTI: "jti-2", wantRefresh: []string{"cfr_a"}},
+		{name: "malformed body", body: `{"token":`, wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("JWT_SECRET", testJWTSecret)
+			refresh := newStubRefreshTokens()
+			denylist := newStubDenylist()
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/revoke", strings.NewReader(tt.body))
+			if tt.bearer != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.bearer)
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: refreshCookieName, Value: tt.cookie})
+			}
+			rec := httptest.NewRecorder()
+			RevokeHandler(refresh, denylist).ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantJTI != "" {
+				assert.Equal(t, testAdminUser, denylist.revoked[tt.wantJTI])
+			} else {
+				assert.Empty(t, denylist.revoked)
+			}
+			assert.Equal(t, tt.wantRefresh, refresh.revoked)
+		})
+	}
+}
+
+func TestRevokeHandler_StorageFailure(t *testing.T) {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	denylist := newStubDenylist()
+	denylist.err = errors.New("db down")
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/revoke",
+		strings.NewReader(`{"token":"`+tokenWithJTI(t, "jti-1")+`"}`))
+	rec := httptest.NewRecorder()
+	RevokeHandler(newStubRefreshTokens(), denylist).ServeHTTP(rec, req)
+
+	// トークンがまだ有効かもしれないので 204 にしない。
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestLogoutHandlerWithRevocation(t *testing.T) {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	refresh := newStubRefreshTokens()
+	denylist := newStubDenylist()
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
+	req.AddCookie(&http.Cookie{Name: authCookieName, Value: tokenWithJTI(t, "jti-1")})
+	req.AddCookie(&http.Cookie{Name: refreshCookieName, Value: "cfr_a"})
+	rec := httptest.NewRecorder()
+	LogoutHandlerWithRevocation(refresh, denylist).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, testAdminUser, denylist.revoked["jti-1"])
+	assert.Equal(t, []string{"cfr_a"}, refresh.revoked)
+
+	// 失効に失敗しても logout は cookie を消して 204。
+	denylist.err = errors.New("db down")
+	rec = httptest.NewRecorder()
+	LogoutHandlerWithRevocation(refresh, denylist).ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, -1, findCookie(rec, authCookieName).MaxAge)
+}
@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Scopes are the permissions a role grants, carried in the JWT "scope"
+// claim (space-delimited, as in OAuth 2.0) and checked per route group by
+// RequireScope. The catalog is closed: AUTH_ROLES may only combine these.
+// Everything not guarded by a scope (users, API keys, subscribers, books,
+// learning, audit, ...) stays admin-only.
+const (
+	ScopeArticlesRead  = "articles:read"
+	ScopeArticlesWrite = "articles:write"
+	ScopeSourcesRead   = "sources:read"
+	ScopeSourcesWrite  = "sources:write"
+	// ScopeAIAsk grants asking the AI about the collected articles. No
+	// route of this service requires it yet; it is issued so the AI
+	// question endpoint can authorize on the same token.
+	ScopeAIAsk = "ai:ask"
+)
+
+// AllScopes lists the catalog in a stable order. Admins hold every scope.
+var AllScopes = []string{
+	ScopeArticlesRead, ScopeArticlesWrite,
+	ScopeSourcesRead, ScopeSourcesWrite,
+	ScopeAIAsk,
+}
+
+// viewerScopes are the built-in viewer's scopes. They mirror the viewer
+// allowlist (D-27 (3): GET /sources), which keeps applying on top.
+var viewerScopes = []string{ScopeSourcesRead}
+
+// EnvRoles defines custom roles; see ParseRoles for the format.
+const EnvRoles = "AUTH_ROLES"
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,31}$`)
+
+// Roles resolves a role name to its scopes: the built-in admin (every
+// scope) and viewer (sources:read) plus the custom roles of AUTH_ROLES.
+type Roles struct {
+	custom map[string][]string
+}
+
+// LoadRoles reads the custom roles from AUTH_ROLES. An unset variable
+// yields the built-in roles only. Like LoadRoutePolicies this fails
+// closed: a malformed entry is an error that prevents startup.
+func LoadRoles() (*Roles, error) {
+	return ParseRoles(os.Getenv(EnvRoles))
+}
+
+// ParseRoles parses the AUTH_ROLES format: semicolon-separated
+// "<role>=<scope>,<scope>..." entries, e.g.
+//
+//	AUTH_ROLES="editor=articles:read,articles:write,sources:read;analyst=articles:read,ai:ask"
+//
+// Role names are lowercase ([a-z][a-z0-9_-]*, at most 32 characters) and
+// may not redefine admin or viewer; every scope must be in AllScopes.
+func ParseRoles(spec string) (*Roles, error) {
+	roles := &Roles{custom: map[string][]string{}}
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, scopeList, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok {
+			return nil, fmt.Errorf("invalid role %q: must be \"<role>=<scope>,<scope>...\"", entry)
+		}
+		if !roleNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid role %q: name must be lowercase letters, digits, '-' or '_'", entry)
+		}
+		if name == RoleAdmin || name == RoleViewer {
+			return nil, fmt.Errorf("invalid role %q: built-in role %s cannot be redefined", entry, name)
+		}
+		if _, dup := roles.custom[name]; dup {
+			return nil, fmt.Errorf("invalid role %q: %s is defined more than once", entry, name)
+		}
+		var scopes []string
+		for _, scope := range strings.Split(scopeList, ",") {
+			scope = strings.TrimSpace(scope)
+			if scope == "" {
+				continue
+			}
+			if !slices.Contains(AllScopes, scope) {
+				return nil, fmt.Errorf("invalid role %q: unknown scope %q (must be one of %s)", entry, scope, strings.Join(AllScopes, ", "))
+			}
+			if !slices.Contains(scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+		if len(scopes) == 0 {
+			return nil, fmt.Errorf("invalid role %q: at least one scope is required", entry)
+		}
+		roles.custom[name] = scopes
+	}
+	return roles, nil
+}
+
+// loadRoles is LoadRoles for middleware and token construction, which run
+// after startup validation: a malformed AUTH_ROLES falls back to the
+// built-in roles, so custom-role tokens are rejected rather than trusted.
+func loadRoles() *Roles {
+	roles, err := LoadRoles()
+	if err != nil {
+		return &Roles{custom: map[string][]string{}}
+	}
+	return roles
+}
+
+// Scopes returns the scopes role grants and whether the role is known.
+func (r *Roles) Scopes(role string) ([]string, bool) {
+	switch role {
+	case RoleAdmin:
+		return AllScopes, true
+	case RoleViewer:
+		return viewerScopes, true
+	}
+	scopes, ok := r.custom[role]
+	return scopes, ok
+}
+
+// Custom returns the custom role names, sorted.
+func (r *Roles) Custom() []string {
+	names := make([]string, 0, len(r.custom))
+	for name := range r.custom {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// effectiveScopes narrows the role's current scopes to those the token
+// claims. A token can never grant more than its role does today, so
+// removing a scope from AUTH_ROLES takes effect without waiting for JWT
+// expiry. Tokens without a scope claim (issued before scopes existed) get
+// the role's scopes.
+func effectiveScopes(roleScopes, claimed []string, hasClaim bool) []string {
+	if !hasClaim {
+		return roleScopes
+	}
+	out := make([]string, 0, len(claimed))
+	for _, s := range claimed {
+		if slices.Contains(roleScopes, s) && !slices.Contains(out, s) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
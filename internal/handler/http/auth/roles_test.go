@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRoles(t *testing.T) {
+	roles, err := ParseRoles(" editor = articles:read, articles:write ,sources:read ; analyst=articles:read,ai:ask,ai:ask")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"analyst", "editor"}, roles.Custom())
+	scopes, ok := roles.Scopes("editor")
+	assert.True(t, ok)
+	assert.Equal(t, []string{ScopeArticlesRead, ScopeArticlesWrite, ScopeSourcesRead}, scopes)
+	scopes, _ = roles.Scopes("analyst")
+	assert.Equal(t, []string{ScopeArticlesRead, ScopeAIAsk}, scopes, "duplicates collapse")
+
+	scopes, ok = roles.Scopes(RoleAdmin)
+	assert.True(t, ok)
+	assert.Equal(t, AllScopes, scopes)
+	scopes, ok = roles.Scopes(RoleViewer)
+	assert.True(t, ok)
+	assert.Equal(t, []string{ScopeSourcesRead}, scopes)
+	_, ok = roles.Scopes("owner")
+	assert.False(t, ok)
+}
+
+func TestParseRoles_Empty(t *testing.T) {
+	roles, err := ParseRoles("")
+	require.NoError(t, err)
+	assert.Empty(t, roles.Custom())
+}
+
+// TestParseRoles_Invalid: 不正な定義は起動を止める(fail-closed)。
+func TestParseRoles_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{"missing separator", "editor"},
+		{"uppercase name", "Editor=articles:read"},
+		{"redefines admin", "admin=articles:read"},
+		{"redefines viewer", "viewer=articles:read"},
+		{"duplicate role", "editor=articles:read;editor=sources:read"},
+		{"unknown scope", "editor=articles:delete"},
+		{"no scopes", "editor= , "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRoles(tt.spec)
+			assert.ErrorContains(t, err, "invalid role")
+		})
+	}
+}
+
+func TestEffectiveScopes(t *testing.T) {
+	role := []string{ScopeArticlesRead, ScopeArticlesWrite}
+
+	assert.Equal(t, role, effectiveScopes(role, nil, false), "token without scope claim")
+	assert.Equal(t, []string{ScopeArticlesRead}, effectiveScopes(role, []string{ScopeArticlesRead, ScopeSourcesWrite}, true),
+		"scopes removed from the role are dropped")
+	assert.Empty(t, effectiveScopes(role, nil, true))
+}
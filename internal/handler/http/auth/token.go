@@ -7,13 +7,16 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"catchup-feed/internal/handler/http/requestid"
 	authservice "catchup-feed/internal/service/auth"
+	refreshUC "catchup-feed/internal/usecase/refreshtoken"
 	viewerUC "catchup-feed/internal/usecase/viewer"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 type loginRequest struct {
@@ -23,6 +26,14 @@ type loginRequest struct {
 
 type tokenResponse struct {
 	Token string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	// RefreshToken is only set when refresh tokens are enabled. Browser
+	// clients use the HttpOnly cookie instead; the body copy is for
+	// non-browser clients (same reasoning as Token).
+	RefreshToken string `json:"refresh_token,omitempty" example:"cfr_..."`
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token" example:"cfr_..."`
 }
 
 // tokenTTL is the lifetime of an issued JWT.
@@ -39,12 +50,49 @@ type ViewerAuthenticator interface {
 	Authenticate(ctx context.Context, email, password string) error
 }
 
+// TokenAuditor records a successful token issuance in the audit log.
+// Implemented by usecase/audit.Service.
+type TokenAuditor interface {
+	RecordTokenIssued(ctx context.Context, subject, role string)
+}
+
+// RefreshTokens issues, rotates and revokes refresh tokens. Rotate fails
+// with usecase/refreshtoken.ErrInvalidToken / ErrTokenReused for tokens
+// that must not be exchanged (both 401); any other error is an
+// infrastructure failure. Implemented by usecase/refreshtoken.Service.
+type RefreshTokens interface {
+	Issue(ctx context.Context, subject, role string) (*refreshUC.Issued, error)
+	Rotate(ctx context.Context, plaintext string) (*refreshUC.Issued, error)
+	Revoke(ctx context.Context, plaintext string) error
+}
+
+// signAccessToken signs the access JWT (sub / role / scope / jti / iat /
+// exp) with JWT_SECRET. scope is the role's space-delimited scopes
+// (AUTH_ROLES) at issuance; the middleware narrows it to the role's current
+// scopes. jti is a random ID the revocation denylist refers to.
+func signAccessToken(sub, role string, now time.Time) (string, error) {
+	scopes, _ := loadRoles().Scopes(role)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":   sub,
+		"role":  role,
+		"scope": strings.Join(scopes, " "),
+		"jti":   uuid.NewString(),
+		"iat":   now.Unix(),
+		"exp":   now.Add(tokenTTL).Unix(),
+	})
+	return token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+}
+
 // TokenHandler creates an HTTP handler that authenticates a user and issues
-// a JWT. Credentials are checked against the administrator first (C-7: env
-// + bcrypt); on mismatch they fall through to the viewers table (D-27 (2),
-// email + bcrypt; deactivated viewers are rejected). The issued token
-// carries sub/iat/exp plus the role claim (admin / viewer). viewers may be
-// nil to disable viewer login entirely (admin-only issuance).
+// a JWT. Credentials are checked against the administrator first (the
+// authService provider: role=admin users, or env + bcrypt under C-7); on
+// mismatch they fall through to the viewer accounts (D-27 (2), email +
+// bcrypt; deactivated viewers are rejected). Custom-role accounts
+// (AUTH_ROLES) authenticate through the same provider. The issued token
+// carries sub/iat/exp plus the role claim (admin / viewer / custom role)
+// and the role's scopes in the scope claim. viewers may be
+// nil to disable viewer login entirely (admin-only issuance). audit may be
+// nil to skip recording issued tokens.
 //
 // Unlike the admin API handlers (respond.SafeError -> JSON
 // {"error": "..."}), this endpoint replies to failures with http.Error
@@ -54,12 +102,17 @@ type ViewerAuthenticator interface {
 //
 // @Summary      JWT トークン取得
 // @Description  メールアドレスとパスワードで認証し、JWT トークンを発行します。
-// @Description  まず管理者(環境変数+bcrypt)と照合し、不一致なら viewers テーブルの
-// @Description  アクティブな閲覧専用アカウントと照合します(D-27。無効化済み viewer は拒否)。
-// @Description  発行する JWT には role クレーム(admin / viewer)が入ります。
+// @Description  まず管理者(users テーブルの role=admin)と照合し、不一致なら
+// @Description  アクティブな閲覧専用アカウント(role=viewer)と照合します(D-27。無効化済み viewer は拒否)。
+// @Description  カスタムロール(AUTH_ROLES)のアカウントも users テーブルで照合します。
+// @Description  発行する JWT には role クレーム(admin / viewer / カスタムロール)と、ロールのスコープを空白区切りで並べた scope クレームが入ります。
 // @Description  JSON body の token(dev の Bearer フォールバック用に後方互換で維持)に加え、
 // @Description  同じ JWT を HttpOnly / Secure / SameSite=Strict の cookie
 // @Description  (catchup_feed_auth_token)で Set-Cookie します(D-22)。
+// @Description  併せてリフレッシュトークン(30日、1回限り)を cookie(catchup_feed_refresh_token、Path=/auth)と
+// @Description  body の refresh_token で返します。JWT 失効後は /auth/refresh で再発行できます。
+// @Description  MFA(TOTP)を有効にした admin には JWT の代わりに mfa_required / mfa_token を返します。
+// @Description  /auth/token/mfa に認証アプリのコードとともに送るとログインが完了します。
 // @Tags         auth
 // @Accept       json
 // @Produce      json
@@ -71,7 +124,24 @@ type ViewerAuthenticator interface {
 // @Failure      429 {string} string "Too many requests - rate limit exceeded"
 // @Failure      500 {string} string "トークン生成失敗"
 // @Router       /auth/token [post]
-func TokenHandler(authService *authservice.AuthService, viewers ViewerAuthenticator) http.HandlerFunc {
+func TokenHandler(authService *authservice.AuthService, viewers ViewerAuthenticator, audit TokenAuditor) http.HandlerFunc {
+	return TokenHandlerWithRefresh(authService, viewers, audit, nil)
+}
+
+// TokenHandlerWithRefresh is TokenHandler that additionally issues a
+// refresh token (a new family per login) in the catchup_feed_refresh_token
+// cookie and the refresh_token body field. refresh may be nil to issue
+// access tokens only.
+func TokenHandlerWithRefresh(authService *authservice.AuthService, viewers ViewerAuthenticator, audit TokenAuditor, refresh RefreshTokens) http.HandlerFunc {
+	return TokenHandlerWithMFA(authService, viewers, audit, refresh, nil)
+}
+
+// TokenHandlerWithMFA is TokenHandlerWithRefresh with TOTP multi-factor
+// authentication for admins: when the admin has MFA enabled, a correct
+// password does not issue tokens but an mfa_token challenge, which
+// MFATokenHandler exchanges for the session together with a code. mfa may
+// be nil to disable the second step.
+func TokenHandlerWithMFA(authService *authservice.AuthService, viewers ViewerAuthenticator, audit TokenAuditor, refresh RefreshTokens, mfa MFAVerifier) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 
@@ -97,8 +167,11 @@ func TokenHandler(authService *authservice.AuthService, viewers ViewerAuthentica
 		// 管理者を先に照合し、不一致なら viewer にフォールバック(D-27 (2))。
 		// 失敗レスポンスはどちらの照合で落ちたかを区別しない(401 固定)。
 		// ログのみ、資格情報不一致とインフラ障害(DB エラー等)を区別する。
-		role := RoleAdmin
-		if err := authService.ValidateCredentials(r.Context(), creds); err != nil {
+		role, err := authService.Authenticate(r.Context(), creds)
+		if role == "" {
+			role = RoleAdmin
+		}
+		if err != nil {
 			viewerErr := err
 			if viewers != nil {
 				viewerErr = viewers.Authenticate(r.Context(), req.Email, req.Password)
@@ -120,17 +193,159 @@ func TokenHandler(authService *authservice.AuthService, viewers ViewerAuthentica
 			role = RoleViewer
 		}
 
-		secret := []byte(os.Getenv("JWT_SECRET"))
+		if role == RoleAdmin && mfa != nil {
+			required, err := mfa.Required(r.Context(), req.Email)
+			if err != nil {
+				logger.Error("authentication failed",
+					slog.String("reason", "mfa_lookup_failed"),
+					slog.String("error", err.Error()),
+					slog.Int64("duration_ms", time.Since(start).Milliseconds()))
+				http.Error(w, "token generation failed", http.StatusInternalServerError)
+				return
+			}
+			if required {
+				writeMFAChallenge(w, logger, start, req.Email, role)
+				return
+			}
+		}
 
-		now := time.Now()
-		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-			"sub":  req.Email,
-			"role": role,
-			"iat":  now.Unix(),
-			"exp":  now.Add(tokenTTL).Unix(),
-		})
+		issueSession(w, r, logger, start, req.Email, role, refresh, audit)
+	}
+}
+
+// issueSession completes a successful login: it signs the access JWT, issues
+// a refresh token (a new family) when refresh is non-nil, records the
+// issuance and writes both cookies and the JSON body. Shared by the
+// password (TokenHandler) and OIDC (OIDCTokenHandler) logins.
+func issueSession(w http.ResponseWriter, r *http.Request, logger *slog.Logger, start time.Time, sub, role string, refresh RefreshTokens, audit TokenAuditor) {
+	signed, err := signAccessToken(sub, role, time.Now())
+	if err != nil {
+		logger.Error("token generation failed",
+			slog.String("error", err.Error()),
+			slog.Int64("duration_ms", time.Since(start).Milliseconds()))
+		http.Error(w, "token generation failed", http.StatusInternalServerError)
+		return
+	}
 
-		signed, err := token.SignedString(secret)
+	resp := tokenResponse{Token: signed}
+	if refresh != nil {
+		issued, err := refresh.Issue(r.Context(), sub, role)
+		if err != nil {
+			logger.Error("refresh token generation failed",
+				slog.String("error", err.Error()),
+				slog.Int64("duration_ms", time.Since(start).Milliseconds()))
+			http.Error(w, "token generation failed", http.StatusInternalServerError)
+			return
+		}
+		resp.RefreshToken = issued.Token
+		http.SetCookie(w, newRefreshCookie(issued.Token, time.Until(issued.ExpiresAt)))
+	}
+
+	logger.Info("authentication successful",
+		slog.String("user_email", sub),
+		slog.String("role", role),
+		slog.Int64("duration_ms", time.Since(start).Milliseconds()))
+	if audit != nil {
+		audit.RecordTokenIssued(r.Context(), sub, role)
+	}
+
+	// Issue the JWT as an HttpOnly cookie so the browser never exposes it
+	// to JavaScript (mitigates XSS token theft, D-22). SetCookie must run
+	// before WriteHeader (JSON encode below writes the header).
+	http.SetCookie(w, newAuthCookie(signed, tokenTTL))
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		logger.Error("failed to encode token response",
+			slog.String("error", err.Error()))
+	}
+}
+
+// RefreshHandler exchanges a refresh token for a new access token and a
+// rotated refresh token. The token is read from the refresh cookie, or
+// from the JSON body for non-browser clients. Each refresh token works
+// once: presenting a rotated token again revokes its whole family (reuse
+// detection), so a stolen token is only useful until either party
+// refreshes. A viewer is re-validated like on every API request (D-27
+// (4)); a deactivated viewer gets 401 and its new token is revoked.
+// viewers may be nil to skip that check; audit may be nil to skip
+// recording issued tokens.
+//
+// Failures reply with http.Error (text/plain), like TokenHandler.
+//
+// @Summary      JWT トークン再発行(リフレッシュ)
+// @Description  リフレッシュトークンを新しい JWT と新しいリフレッシュトークンに交換します(ローテーション)。
+// @Description  リフレッシュトークンは cookie(catchup_feed_refresh_token、Path=/auth)から読み、
+// @Description  cookie がなければ JSON body の refresh_token を使います。各トークンは1回限りで、
+// @Description  使用済みトークンが再提示されると漏洩とみなし、そのログイン系列のトークンをすべて失効させます。
+// @Description  無効化済み viewer は 401 になります。
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body refreshRequest false "リフレッシュトークン(cookie がない場合)"
+// @Success      200 {object} tokenResponse "JWT とローテーション後のリフレッシュトークン(併せて両方の cookie を Set-Cookie)"
+// @Header       200 {string} Set-Cookie "catchup_feed_auth_token=<jwt> / catchup_feed_refresh_token=<token>; HttpOnly; Secure; SameSite=Strict"
+// @Failure      401 {string} string "リフレッシュトークンが無効・期限切れ・再利用"
+// @Failure      429 {string} string "Too many requests - rate limit exceeded"
+// @Failure      500 {string} string "トークン生成失敗"
+// @Router       /auth/refresh [post]
+func RefreshHandler(refresh RefreshTokens, viewers ViewerVerifier, audit TokenAuditor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		logger := slog.With(slog.String("request_id", requestid.FromContext(r.Context())))
+
+		plaintext := ""
+		if c, err := r.Cookie(refreshCookieName); err == nil {
+			plaintext = c.Value
+		}
+		if plaintext == "" {
+			var req refreshRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err == nil {
+				plaintext = req.RefreshToken
+			}
+		}
+
+		unauthorized := func(reason string) {
+			logger.Warn("token refresh failed",
+				slog.String("reason", reason),
+				slog.Int64("duration_ms", time.Since(start).Milliseconds()))
+			http.SetCookie(w, expiredRefreshCookie())
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+		}
+
+		issued, err := refresh.Rotate(r.Context(), plaintext)
+		switch {
+		case errors.Is(err, refreshUC.ErrTokenReused):
+			unauthorized("refresh_token_reused")
+			return
+		case errors.Is(err, refreshUC.ErrInvalidToken):
+			unauthorized("invalid_refresh_token")
+			return
+		case err != nil:
+			logger.Error("token refresh failed",
+				slog.String("error", err.Error()),
+				slog.Int64("duration_ms", time.Since(start).Milliseconds()))
+			http.Error(w, "token generation failed", http.StatusInternalServerError)
+			return
+		}
+
+		if issued.Role == RoleViewer && viewers != nil {
+			active, err := viewers.IsActiveViewer(r.Context(), issued.Subject)
+			if err != nil || !active {
+				if revokeErr := refresh.Revoke(r.Context(), issued.Token); revokeErr != nil {
+					logger.Error("refresh token revoke failed", slog.String("error", revokeErr.Error()))
+				}
+				if err != nil {
+					logger.Error("token refresh failed",
+						slog.String("reason", "viewer_lookup_failed"),
+						slog.String("error", err.Error()))
+				}
+				unauthorized("viewer_inactive")
+				return
+			}
+		}
+
+		signed, err := signAccessToken(issued.Subject, issued.Role, time.Now())
 		if err != nil {
 			logger.Error("token generation failed",
 				slog.String("error", err.Error()),
@@ -139,18 +354,19 @@ func TokenHandler(authService *authservice.AuthService, viewers ViewerAuthentica
 			return
 		}
 
-		logger.Info("authentication successful",
-			slog.String("user_email", req.Email),
-			slog.String("role", role),
+		logger.Info("token refresh successful",
+			slog.String("user_email", issued.Subject),
+			slog.String("role", issued.Role),
 			slog.Int64("duration_ms", time.Since(start).Milliseconds()))
+		if audit != nil {
+			audit.RecordTokenIssued(r.Context(), issued.Subject, issued.Role)
+		}
 
-		// Issue the JWT as an HttpOnly cookie so the browser never exposes it
-		// to JavaScript (mitigates XSS token theft, D-22). SetCookie must run
-		// before WriteHeader (JSON encode below writes the header).
 		http.SetCookie(w, newAuthCookie(signed, tokenTTL))
+		http.SetCookie(w, newRefreshCookie(issued.Token, time.Until(issued.ExpiresAt)))
 
 		w.Header().Set("Content-Type", "application/json")
-		if err := json.NewEncoder(w).Encode(tokenResponse{Token: signed}); err != nil {
+		if err := json.NewEncoder(w).Encode(tokenResponse{Token: signed, RefreshToken: issued.Token}); err != nil {
 			logger.Error("failed to encode token response",
 				slog.String("error", err.Error()))
 		}
@@ -166,6 +382,7 @@ func TokenHandler(authService *authservice.AuthService, viewers ViewerAuthentica
 // @Summary      ログアウト(cookie 失効)
 // @Description  HttpOnly の認証 cookie(catchup_feed_auth_token)を Max-Age=0 で失効させます。
 // @Description  HttpOnly cookie は JS から削除できないため backend で失効させます(D-22)。
+// @Description  リフレッシュトークンの cookie があれば、そのログイン系列のトークンも失効させます。
 // @Description  認証不要・冪等。
 // @Tags         auth
 // @Produce      json
@@ -178,3 +395,11 @@ func LogoutHandler() http.HandlerFunc {
 		w.WriteHeader(http.StatusNoContent)
 	}
 }
+
+// LogoutHandlerWithRefresh is LogoutHandler that also revokes the refresh
+// token family of the presented refresh cookie and clears that cookie. A
+// revocation failure is logged but still answers 204: the cookies are
+// cleared either way, and the token expires on its own.
+func LogoutHandlerWithRefresh(refresh RefreshTokens) http.HandlerFunc {
+	return LogoutHandlerWithRevocation(refresh, nil)
+}
@@ -0,0 +1,259 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	refreshUC "catchup-feed/internal/usecase/refreshtoken"
+)
+
+// stubRefreshTokens is a canned RefreshTokens: live maps a token to its
+// subject/role; Rotate consumes it and issues "<token>+". Tokens in reused
+// fail with ErrTokenReused.
+type stubRefreshTokens struct {
+	live    map[string][2]string
+	reused  map[string]bool
+	revoked []string
+	err     error
+}
+
+func newStubRefreshTokens() *stubRefreshTokens {
+	return &stubRefreshTokens{live: map[string][2]string{}, reused: map[string]bool{}}
+}
+
+func (s *stubRefreshTokens) issue(token, subject, role string) *refreshUC.Issued {
+	s.live[token] = [2]string{subject, role}
+	return &refreshUC.Issued{Token: token, Subject: subject, Role: role, ExpiresAt: time.Now().Add(time.Hour)}
+}
+
+func (s *stubRefreshTokens) Issue(_ context.Context, subject, role string) (*refreshUC.Issued, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.issue("cfr_login", subject, role), nil
+}
+
+func (s *stubRefreshTokens) Rotate(_ context.Context, plaintext string) (*refreshUC.Issued, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.reused[plaintext] {
+		return nil, refreshUC.ErrTokenReused
+	}
+	id, ok := s.live[plaintext]
+	if !ok {
+		return nil, refreshUC.ErrInvalidToken
+	}
+	delete(s.live, plaintext)
+	s.reused[plaintext] = true
+	return s.issue(plaintext+"+", id[0], id[1]), nil
+}
+
+func (s *stubRefreshTokens) Revoke(_ context.Context, plaintext string) error {
+	s.revoked = append(s.revoked, plaintext)
+	delete(s.live, plaintext)
+	return nil
+}
+
+func findCookie(rec *httptest.ResponseRecorder, name string) *http.Cookie {
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func TestTokenHandlerWithRefresh_IssuesRefreshToken(t *testing.T) {
+	authService := newTestAuthService(t)
+	refresh := newStubRefreshTokens()
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/token",
+		strings.NewReader(`{"email":"`+testAdminUser+`","password":"`+testPassword+`"}`))
+	rec := httptest.NewRecorder()
+	TokenHandlerWithRefresh(authService, nil, nil, refresh).ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var body tokenResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.NotEmpty(t, body.Token)
+	assert.Equal(t, "cfr_login", body.RefreshToken)
+
+	c := findCookie(rec, refreshCookieName)
+	require.NotNil(t, c)
+	assert.Equal(t, "cfr_login", c.Value)
+	// リフレッシュ cookie は /auth 配下にしか送られない。
+	assert.Equal(t, refreshCookiePath, c.Path)
+	assert.True(t, c.HttpOnly)
+	assert.True(t, c.Secure)
+	assert.Equal(t, http.SameSiteStrictMode, c.SameSite)
+	assert.Equal(t, [2]string{testAdminUser, RoleAdmin}, refresh.live["cfr_login"])
+}
+
+func TestTokenHandler_NoRefreshToken(t *testing.T) {
+	authService := newTestAuthService(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/token",
+		strings.NewReader(`{"email":"`+testAdminUser+`","password":"`+testPassword+`"}`))
+	rec := httptest.NewRecorder()
+	TokenHandler(authService, nil, nil).ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	assert.NotContains(t, rec.Body.String(), "refresh_token")
+	assert.Nil(t, findCookie(rec, refreshCookieName))
+}
+
+func TestRefreshHandler(t *testing.T) {
+	const viewerEmail = "friend@example.com"
+
+	tests := []struct {
+		name       string
+		seed       func(s *stubRefreshTokens)
+		cookie     string
+		body       string
+		viewers    *stubViewerVerifier
+		wantCode   int
+		wantRole   string
+		wantRotate string
+	}{
+		{
+			name:       "cookie",
+			seed:       func(s *stubRefreshTokens) { s.issue("cfr_a", testAdminUser, RoleAdmin) },
+			cookie:     "cfr_a",
+			wantCode:   http.StatusOK,
+			wantRole:   RoleAdmin,
+			wantRotate: "cfr_a+",
+		},
+		{
+			name:       "json body",
+			seed:       func(s *stubRefreshTokens) { s.issue("cfr_a", testAdminUser, RoleAdmin) },
+			body:       `{"refresh_token":"cfr_a"}`,
+			wantCode:   http.StatusOK,
+			wantRole:   RoleAdmin,
+			wantRotate: "cfr_a+",
+		},
+		{
+			name:       "active viewer",
+			seed:       func(s *stubRefreshTokens) { s.issue("cfr_v", viewerEmail, RoleViewer) },
+			cookie:     "cfr_v",
+			viewers:    &stubViewerVerifier{active: map[string]bool{viewerEmail: true}},
+			wantCode:   http.StatusOK,
+			wantRole:   RoleViewer,
+			wantRotate: "cfr_v+",
+		},
+		{
+			name:     "deactivated viewer",
+			seed:     func(s *stubRefreshTokens) { s.issue("cfr_v", viewerEmail, RoleViewer) },
+			cookie:   "cfr_v",
+			viewers:  &stubViewerVerifier{active: map[string]bool{}},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "reused token",
+			seed:     func(s *stubRefreshTokens) { s.reused["cfr_old"] = true },
+			cookie:   "cfr_old",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "unknown token",
+			seed:     func(*stubRefreshTokens) {},
+			cookie:   "cfr_unknown",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "no token",
+			seed:     func(*stubRefreshTokens) {},
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("JWT_SECRET", testJWTSecret)
+			refresh := newStubRefreshTokens()
+			tt.seed(refresh)
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/refresh", strings.NewReader(tt.body))
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: refreshCookieName, Value: tt.cookie})
+			}
+			var viewers ViewerVerifier
+			if tt.viewers != nil {
+				viewers = tt.viewers
+			}
+			rec := httptest.NewRecorder()
+			RefreshHandler(refresh, viewers, nil).ServeHTTP(rec, req)
+			require.Equal(t, tt.wantCode, rec.Code)
+
+			c := findCookie(rec, refreshCookieName)
+			require.NotNil(t, c)
+			if tt.wantCode != http.StatusOK {
+				// 失敗時はリフレッシュ cookie を消す。
+				assert.Equal(t, -1, c.MaxAge)
+				assert.Nil(t, findCookie(rec, authCookieName))
+				return
+			}
+
+			var body tokenResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantRotate, body.RefreshToken)
+			assert.Equal(t, tt.wantRotate, c.Value)
+
+			tok, err := jwt.Parse(body.Token, func(*jwt.Token) (interface{}, error) {
+				return []byte(testJWTSecret), nil
+			})
+			require.NoError(t, err)
+			claims, ok := tok.Claims.(jwt.MapClaims)
+			require.True(t, ok)
+			assert.Equal(t, tt.wantRole, claims["role"])
+			require.NotNil(t, findCookie(rec, authCookieName))
+		})
+	}
+}
+
+func TestRefreshHandler_DeactivatedViewerRevokesNewToken(t *testing.T) {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	refresh := newStubRefreshTokens()
+	refresh.issue("cfr_v", "friend@example.com", RoleViewer)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+	req.AddCookie(&http.Cookie{Name: refreshCookieName, Value: "cfr_v"})
+	rec := httptest.NewRecorder()
+	RefreshHandler(refresh, &stubViewerVerifier{active: map[string]bool{}}, nil).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, []string{"cfr_v+"}, refresh.revoked)
+}
+
+func TestLogoutHandlerWithRefresh(t *testing.T) {
+	refresh := newStubRefreshTokens()
+	refresh.issue("cfr_a", testAdminUser, RoleAdmin)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
+	req.AddCookie(&http.Cookie{Name: refreshCookieName, Value: "cfr_a"})
+	rec := httptest.NewRecorder()
+	LogoutHandlerWithRefresh(refresh).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, []string{"cfr_a"}, refresh.revoked)
+	for _, name := range []string{authCookieName, refreshCookieName} {
+		c := findCookie(rec, name)
+		require.NotNil(t, c, name)
+		assert.Equal(t, -1, c.MaxAge, name)
+	}
+
+	// cookie なしでも冪等に 204。
+	rec = httptest.NewRecorder()
+	LogoutHandlerWithRefresh(refresh).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/auth/logout", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Len(t, refresh.revoked, 1)
+}
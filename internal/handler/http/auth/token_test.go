@@ -104,7 +104,7 @@ func TestTokenHandler(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			handler := TokenHandler(newTestAuthService(t), viewers)
+			handler := TokenHandler(newTestAuthService(t), viewers, nil)
 
 			req := httptest.NewRequest(http.MethodPost, "/auth/token", strings.NewReader(tt.body))
 			rec := httptest.NewRecorder()
@@ -122,7 +122,7 @@ func TestTokenHandler(t *testing.T) {
 // TestTokenHandler_NilViewerAuthenticator verifies admin-only issuance when
 // no viewer store is wired: viewer-style credentials are rejected.
 func TestTokenHandler_NilViewerAuthenticator(t *testing.T) {
-	handler := TokenHandler(newTestAuthService(t), nil)
+	handler := TokenHandler(newTestAuthService(t), nil, nil)
 
 	body := `{"email":"friend@example.com","password":"viewer-password-1"}`
 	req := httptest.NewRequest(http.MethodPost, "/auth/token", strings.NewReader(body))
@@ -139,7 +139,7 @@ func TestTokenHandler_NilViewerAuthenticator(t *testing.T) {
 // issues no token. (ログ側は reason=viewer_lookup_failed で区別される。)
 func TestTokenHandler_ViewerLookupFailure(t *testing.T) {
 	viewers := &stubViewerAuthenticator{err: errors.New("db down")}
-	handler := TokenHandler(newTestAuthService(t), viewers)
+	handler := TokenHandler(newTestAuthService(t), viewers, nil)
 
 	body := `{"email":"friend@example.com","password":"viewer-password-1"}`
 	req := httptest.NewRequest(http.MethodPost, "/auth/token", strings.NewReader(body))
@@ -154,7 +154,7 @@ func TestTokenHandler_ViewerLookupFailure(t *testing.T) {
 // TestTokenHandler_IssuedClaims verifies that the issued admin JWT carries
 // sub/iat/exp plus role=admin (D-27) and passes the admin-only middleware.
 func TestTokenHandler_IssuedClaims(t *testing.T) {
-	handler := TokenHandler(newTestAuthService(t), nil)
+	handler := TokenHandler(newTestAuthService(t), nil, nil)
 
 	body := `{"email":"` + testAdminUser + `","password":"` + testPassword + `"}`
 	req := httptest.NewRequest(http.MethodPost, "/auth/token", strings.NewReader(body))
@@ -195,3 +195,30 @@ func TestTokenHandler_IssuedClaims(t *testing.T) {
 	middleware.ServeHTTP(protectedRec, protected)
 	assert.Equal(t, http.StatusOK, protectedRec.Code)
 }
+
+type stubTokenAuditor struct {
+	subject, role string
+	calls         int
+}
+
+func (s *stubTokenAuditor) RecordTokenIssued(_ context.Context, subject, role string) {
+	s.subject, s.role = subject, role
+	s.calls++
+}
+
+// TestTokenHandler_AuditsIssuance verifies that only successful issuance is
+// recorded, with the subject as the actor.
+func TestTokenHandler_AuditsIssuance(t *testing.T) {
+	auditor := &stubTokenAuditor{}
+	handler := TokenHandler(newTestAuthService(t), nil, auditor)
+
+	for _, password := range []string{"wrong-password", testPassword} {
+		body := `{"email":"` + testAdminUser + `","password":"` + password + `"}`
+		req := httptest.NewRequest(http.MethodPost, "/auth/token", strings.NewReader(body))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Equal(t, 1, auditor.calls)
+	assert.Equal(t, testAdminUser, auditor.subject)
+	assert.Equal(t, RoleAdmin, auditor.role)
+}
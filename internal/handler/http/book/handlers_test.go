@@ -77,10 +77,20 @@ func (f *fakeJobs) Enqueue(_ context.Context, kind string, payload json.RawMessa
 
 func (f *fakeJobs) ClaimNext(context.Context, ...string) (*entity.Job, error) { panic("not used") }
 func (f *fakeJobs) MarkDone(context.Context, int64) error                     { panic("not used") }
+func (f *fakeJobs) Get(context.Context, int64) (*entity.Job, error)           { panic("not used") }
 func (f *fakeJobs) MarkFailed(context.Context, int64, string, *time.Time) error {
 	panic("not used")
 }
-func (f *fakeJobs) RequeueRunning(context.Context, ...string) (int64, error) { panic("not used") }
+func (f *fakeJobs) EnqueueUnique(context.Context, string, string, json.RawMessage, time.Time) (int64, bool, error) {
+	panic("not used")
+}
+func (f *fakeJobs) RequeueRunning(context.Context, time.Time, ...string) (int64, error) {
+	panic("not used")
+}
+
+func (f *fakeJobs) ListActive(context.Context, ...string) ([]*entity.Job, error) {
+	panic("not used")
+}
 
 func newService(t *testing.T) (*bookUC.Service, *fakeRepo, *fakeJobs) {
 	t.Helper()
@@ -0,0 +1,156 @@
+package crawl
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/handler/http/respond"
+	crawlUC "catchup-feed/internal/usecase/crawl"
+)
+
+type ControlStatusHandler struct{ Svc *crawlUC.Service }
+
+// ServeHTTP クロール制御の状態取得
+// @Summary      クロール制御の状態取得
+// @Description  定期クロールの停止状態(理由・操作者・日時)、定期クロールから外しているソース、
+// @Description  実行待ち・実行中のクロール / 要約掃き取りジョブを返します。停止後にキューが空になったかの確認に使います。admin 専用
+// @Tags         crawl
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} ControlDTO "クロール制御の状態"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /crawl/control [get]
+func (h ControlStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	status, err := h.Svc.ControlStatus(r.Context())
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toControlDTO(status))
+}
+
+type PauseHandler struct{ Svc *crawlUC.Service }
+
+// ServeHTTP 定期クロールの停止
+// @Summary      定期クロールの停止
+// @Description  メンテナンスのため、worker の cron による定期クロール(ソース個別スケジュールを含む)と要約の掃き取りを停止します。
+// @Description  すべての worker が次の cron から従います。登録済みのジョブと即時クロールは止まりません。
+// @Description  reason は任意(500文字まで)。再開は POST /crawl/resume。admin 専用
+// @Tags         crawl
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body PauseRequest false "停止理由"
+// @Success      200 {object} ControlDTO "停止後の状態"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - 入力が不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /crawl/pause [post]
+func (h PauseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req PauseRequest
+	// 本文は任意: 空なら理由なしで停止する
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respond.SafeError(w, http.StatusBadRequest, errInvalidBody)
+		return
+	}
+	if err := h.Svc.Pause(r.Context(), req.Reason, auth.SubjectFromContext(r.Context())); err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respondControl(w, r, h.Svc)
+}
+
+type ResumeHandler struct{ Svc *crawlUC.Service }
+
+// ServeHTTP 定期クロールの再開
+// @Summary      定期クロールの再開
+// @Description  POST /crawl/pause で停止した定期クロールを再開します。各 worker の次の cron から登録されます。
+// @Description  停止していなければ何もしません。admin 専用
+// @Tags         crawl
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} ControlDTO "再開後の状態"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /crawl/resume [post]
+func (h ResumeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := h.Svc.Resume(r.Context(), auth.SubjectFromContext(r.Context())); err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respondControl(w, r, h.Svc)
+}
+
+type SkipSourceHandler struct{ Svc *crawlUC.Service }
+
+// ServeHTTP ソースを定期クロールから外す
+// @Summary      ソースを定期クロールから外す
+// @Description  指定ソースを cron・ソース個別スケジュールの定期クロールから外します(ソース自体は有効のまま)。
+// @Description  POST /sources/{id}/crawl による即時クロールはできます。外し済みなら何もしません。admin 専用
+// @Tags         crawl
+// @Security     BearerAuth
+// @Param        id path int true "ソースID"
+// @Success      204 "外した"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - ID が不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      404 {object} respond.ErrorResponse "ソースが見つからない"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /sources/{id}/crawl-skip [put]
+func (h SkipSourceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.Svc.SkipSource(r.Context(), id); err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type UnskipSourceHandler struct{ Svc *crawlUC.Service }
+
+// ServeHTTP ソースを定期クロールに戻す
+// @Summary      ソースを定期クロールに戻す
+// @Description  PUT /sources/{id}/crawl-skip で外したソースを定期クロールに戻します。admin 専用
+// @Tags         crawl
+// @Security     BearerAuth
+// @Param        id path int true "ソースID"
+// @Success      204 "戻した"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - ID が不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      404 {object} respond.ErrorResponse "外されていない"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /sources/{id}/crawl-skip [delete]
+func (h UnskipSourceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.Svc.UnskipSource(r.Context(), id); err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// respondControl answers a pause / resume with the resulting state.
+func respondControl(w http.ResponseWriter, r *http.Request, svc *crawlUC.Service) {
+	status, err := svc.ControlStatus(r.Context())
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toControlDTO(status))
+}
@@ -0,0 +1,180 @@
+// Package crawl provides the on-demand crawl HTTP handlers: trigger a crawl
+// of one source or of every source, executed by the worker through the
+// jobs queue, poll the job status, browse the crawl history, and pause the
+// scheduled crawls for maintenance (C-21 flat paths: /crawl,
+// /crawl/jobs/{id}, /crawl/control, /crawl/pause, /crawl/resume,
+// /sources/{id}/crawl, /sources/{id}/crawl-skip, /crawls, /crawls/{id}).
+package crawl
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/pathutil"
+	crawlUC "catchup-feed/internal/usecase/crawl"
+)
+
+// errInvalidBody is returned for a request body that is not valid JSON.
+var errInvalidBody = errors.New("invalid request body")
+
+// JobDTO is the status of one crawl job. source_id is omitted for a crawl
+// of every source. status moves pending → running → done / failed.
+type JobDTO struct {
+	ID        int64     `json:"id" example:"42"`
+	SourceID  int64     `json:"source_id,omitempty" example:"1"`
+	Status    string    `json:"status" example:"pending"`
+	Attempts  int       `json:"attempts" example:"0"`
+	LastError *string   `json:"last_error"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func toJobDTO(j *entity.Job) JobDTO {
+	var payload entity.CrawlPayload
+	_ = json.Unmarshal(j.Payload, &payload)
+	return JobDTO{
+		ID:        j.ID,
+		SourceID:  payload.SourceID,
+		Status:    j.Status,
+		Attempts:  j.Attempts,
+		LastError: j.LastError,
+		CreatedAt: j.CreatedAt,
+	}
+}
+
+// PauseRequest is the optional body of POST /crawl/pause.
+type PauseRequest struct {
+	Reason string `json:"reason" example:"DB maintenance"`
+}
+
+// ActiveJobDTO is a crawl or summary sweep job still pending or running.
+type ActiveJobDTO struct {
+	JobDTO
+	Kind            string `json:"kind" example:"crawl"`
+	DefaultSchedule bool   `json:"default_schedule" example:"true"`
+}
+
+// ControlDTO is the maintenance state of the scheduled crawls. updated_at
+// is null when the crawls have never been paused.
+type ControlDTO struct {
+	Paused         bool           `json:"paused" example:"true"`
+	Reason         string         `json:"reason" example:"DB maintenance"`
+	UpdatedBy      string         `json:"updated_by" example:"admin"`
+	UpdatedAt      *time.Time     `json:"updated_at"`
+	SkippedSources []int64        `json:"skipped_sources"`
+	ActiveJobs     []ActiveJobDTO `json:"active_jobs"`
+}
+
+func toControlDTO(s *crawlUC.ControlStatus) ControlDTO {
+	out := ControlDTO{
+		Paused:         s.Control.Paused,
+		Reason:         s.Control.Reason,
+		UpdatedBy:      s.Control.UpdatedBy,
+		UpdatedAt:      s.Control.UpdatedAt,
+		SkippedSources: s.Control.SkippedSources,
+		ActiveJobs:     make([]ActiveJobDTO, 0, len(s.ActiveJobs)),
+	}
+	if out.SkippedSources == nil {
+		out.SkippedSources = []int64{}
+	}
+	for _, j := range s.ActiveJobs {
+		var payload entity.CrawlPayload
+		_ = json.Unmarshal(j.Payload, &payload)
+		out.ActiveJobs = append(out.ActiveJobs, ActiveJobDTO{
+			JobDTO:          toJobDTO(j),
+			Kind:            j.Kind,
+			DefaultSchedule: payload.DefaultSchedule,
+		})
+	}
+	return out
+}
+
+// RunDTO is one crawl run of the history. finished_at is null while the
+// run is in progress (or when the worker stopped before finishing it);
+// error is the reason of a failed run.
+type RunDTO struct {
+	ID                 int64      `json:"id" example:"42"`
+	Status             string     `json:"status" example:"succeeded"`
+	StartedAt          time.Time  `json:"started_at"`
+	FinishedAt         *time.Time `json:"finished_at"`
+	Sources            int        `json:"sources" example:"12"`
+	FetchFailedSources int        `json:"fetch_failed_sources" example:"1"`
+	FeedItems          int64      `json:"feed_items" example:"240"`
+	Inserted           int64      `json:"inserted" example:"8"`
+	Duplicated         int64      `json:"duplicated" example:"232"`
+	SummarizeErrors    int64      `json:"summarize_errors" example:"0"`
+	Error              *string    `json:"error"`
+}
+
+// RunSourceDTO is the outcome of one source within a run. http_status is
+// 0 when no response was received.
+type RunSourceDTO struct {
+	SourceID        int64  `json:"source_id" example:"1"`
+	Kind            string `json:"kind" example:"rss"`
+	HTTPStatus      int    `json:"http_status" example:"200"`
+	FetchError      string `json:"fetch_error,omitempty"`
+	FeedItems       int64  `json:"feed_items" example:"20"`
+	Inserted        int64  `json:"inserted" example:"2"`
+	Duplicated      int64  `json:"duplicated" example:"18"`
+	SummarizeErrors int64  `json:"summarize_errors" example:"0"`
+	TimedOut        bool   `json:"timed_out"`
+	NotModified     bool   `json:"not_modified"`
+	Locked          bool   `json:"locked"`
+	DurationMS      int64  `json:"duration_ms" example:"1530"`
+}
+
+// RunDetailDTO is a crawl run with its per-source stats, in the order the
+// sources were started.
+type RunDetailDTO struct {
+	RunDTO
+	SourceStats []RunSourceDTO `json:"source_stats"`
+}
+
+func toRunDTO(r *entity.CrawlRun) RunDTO {
+	return RunDTO{
+		ID:                 r.ID,
+		Status:             r.Status,
+		StartedAt:          r.StartedAt,
+		FinishedAt:         r.FinishedAt,
+		Sources:            r.SourceCount,
+		FetchFailedSources: r.FetchFailedSources,
+		FeedItems:          r.FeedItems,
+		Inserted:           r.Inserted,
+		Duplicated:         r.Duplicated,
+		SummarizeErrors:    r.SummarizeErrors,
+		Error:              r.Error,
+	}
+}
+
+func toRunDetailDTO(r *entity.CrawlRun) RunDetailDTO {
+	out := RunDetailDTO{RunDTO: toRunDTO(r), SourceStats: make([]RunSourceDTO, 0, len(r.Sources))}
+	for _, s := range r.Sources {
+		out.SourceStats = append(out.SourceStats, RunSourceDTO{
+			SourceID:        s.SourceID,
+			Kind:            s.Kind,
+			HTTPStatus:      s.HTTPStatus,
+			FetchError:      s.FetchError,
+			FeedItems:       s.FeedItems,
+			Inserted:        s.Inserted,
+			Duplicated:      s.Duplicated,
+			SummarizeErrors: s.SummarizeErrors,
+			TimedOut:        s.TimedOut,
+			NotModified:     s.NotModified,
+			Locked:          s.Locked,
+			DurationMS:      s.DurationMS,
+		})
+	}
+	return out
+}
+
+// pathID extracts the positive integer {id} path value.
+func pathID(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, pathutil.ErrInvalidID
+	}
+	return id, nil
+}
@@ -0,0 +1,168 @@
+package crawl
+
+import (
+	"net/http"
+
+	"catchup-feed/internal/common/pagination"
+	"catchup-feed/internal/handler/http/respond"
+	crawlUC "catchup-feed/internal/usecase/crawl"
+)
+
+type TriggerSourceHandler struct{ Svc *crawlUC.Service }
+
+// ServeHTTP ソースの即時クロール
+// @Summary      ソースの即時クロール
+// @Description  指定ソースのクロールをジョブとして登録し、次の cron を待たずに worker で実行します。
+// @Description  返されたジョブ ID で GET /crawl/jobs/{id} から進捗を確認できます。
+// @Description  無効化されたソースは 409 を返します。sources:write スコープが必要です
+// @Tags         sources
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "ソースID"
+// @Success      202 {object} JobDTO "登録されたクロールジョブ"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - ID が不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - スコープ不足"
+// @Failure      404 {object} respond.ErrorResponse "ソースが見つからない"
+// @Failure      409 {object} respond.ErrorResponse "ソースが無効化されている"
+// @Failure      429 {object} respond.ErrorResponse "Too many requests"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /sources/{id}/crawl [post]
+func (h TriggerSourceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	job, err := h.Svc.TriggerSource(r.Context(), id)
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusAccepted, toJobDTO(job))
+}
+
+type TriggerAllHandler struct{ Svc *crawlUC.Service }
+
+// ServeHTTP 全ソースの即時クロール
+// @Summary      全ソースの即時クロール
+// @Description  有効な全ソースのクロールをジョブとして登録し、次の cron を待たずに worker で実行します。
+// @Description  返されたジョブ ID で GET /crawl/jobs/{id} から進捗を確認できます。sources:write スコープが必要です
+// @Tags         crawl
+// @Security     BearerAuth
+// @Produce      json
+// @Success      202 {object} JobDTO "登録されたクロールジョブ"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - スコープ不足"
+// @Failure      429 {object} respond.ErrorResponse "Too many requests"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /crawl [post]
+func (h TriggerAllHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	job, err := h.Svc.TriggerAll(r.Context())
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusAccepted, toJobDTO(job))
+}
+
+type StatusHandler struct{ Svc *crawlUC.Service }
+
+// ServeHTTP クロールジョブの状態取得
+// @Summary      クロールジョブの状態取得
+// @Description  即時クロールジョブの状態(pending / running / done / failed)、試行回数、直近のエラーを返します。
+// @Description  sources:read スコープが必要です
+// @Tags         crawl
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "ジョブID"
+// @Success      200 {object} JobDTO "クロールジョブ"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - ID が不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - スコープ不足"
+// @Failure      404 {object} respond.ErrorResponse "ジョブが見つからない"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /crawl/jobs/{id} [get]
+func (h StatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	job, err := h.Svc.Status(r.Context(), id)
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toJobDTO(job))
+}
+
+type ListRunsHandler struct {
+	Svc           *crawlUC.Service
+	PaginationCfg pagination.Config
+}
+
+// ServeHTTP クロール履歴一覧取得
+// @Summary      クロール履歴一覧取得
+// @Description  cron・ソース個別スケジュール・即時クロールの実行履歴を新しい順に取得します。
+// @Description  各実行の開始/終了時刻、状態(running / succeeded / failed)、件数の集計、中断理由を含みます。
+// @Description  ソースごとの内訳は GET /crawls/{id} で取得します。sources:read スコープが必要です
+// @Tags         crawl
+// @Security     BearerAuth
+// @Produce      json
+// @Param        page query int false "ページ番号(1-indexed、デフォルト: 1)"
+// @Param        limit query int false "1ページあたりの件数(デフォルト: 20、最大: 100。上限は呼び出し元の区分ごとに PAGINATION_MAX_LIMIT_* で変更可)"
+// @Success      200 {object} pagination.Response[RunDTO] "クロール履歴(新しい順)"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid query parameter"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - スコープ不足"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /crawls [get]
+func (h ListRunsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params, err := pagination.ParseQueryParams(r, h.PaginationCfg)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	result, err := h.Svc.ListRuns(r.Context(), params)
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	out := make([]RunDTO, 0, len(result.Data))
+	for _, run := range result.Data {
+		out = append(out, toRunDTO(run))
+	}
+	respond.JSON(w, http.StatusOK, pagination.NewResponse(out, result.Pagination))
+}
+
+type GetRunHandler struct{ Svc *crawlUC.Service }
+
+// ServeHTTP クロール履歴の詳細取得
+// @Summary      クロール履歴の詳細取得
+// @Description  1回のクロール実行の集計と、ソースごとの内訳(HTTP ステータス、取得エラー、件数、タイムアウト、304)を返します。
+// @Description  sources:read スコープが必要です
+// @Tags         crawl
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "クロール実行ID"
+// @Success      200 {object} RunDetailDTO "クロール実行"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - ID が不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - スコープ不足"
+// @Failure      404 {object} respond.ErrorResponse "クロール実行が見つからない"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /crawls/{id} [get]
+func (h GetRunHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	run, err := h.Svc.GetRun(r.Context(), id)
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toRunDetailDTO(run))
+}
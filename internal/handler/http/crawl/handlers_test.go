@@ -0,0 +1,350 @@
+package crawl_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/common/pagination"
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/handler/http/crawl"
+	"catchup-feed/internal/repository"
+	crawlUC "catchup-feed/internal/usecase/crawl"
+)
+
+/* ───────── モック実装 ───────── */
+
+type stubJobs struct {
+	repository.JobRepository
+	jobs       map[int64]*entity.Job
+	enqueueErr error
+}
+
+func (s *stubJobs) Enqueue(_ context.Context, kind string, payload json.RawMessage, _ time.Time) (int64, error) {
+	if s.enqueueErr != nil {
+		return 0, s.enqueueErr
+	}
+	id := int64(len(s.jobs) + 1)
+	s.jobs[id] = &entity.Job{ID: id, Kind: kind, Payload: payload, Status: entity.JobStatusPending, CreatedAt: time.Now()}
+	return id, nil
+}
+
+func (s *stubJobs) Get(_ context.Context, id int64) (*entity.Job, error) {
+	return s.jobs[id], nil
+}
+
+func (s *stubJobs) ListActive(context.Context, ...string) ([]*entity.Job, error) {
+	var out []*entity.Job
+	for _, j := range s.jobs {
+		if j.Status == entity.JobStatusPending || j.Status == entity.JobStatusRunning {
+			out = append(out, j)
+		}
+	}
+	return out, nil
+}
+
+type stubControl struct {
+	control entity.CrawlControl
+	skipped map[int64]bool
+}
+
+func (s *stubControl) Get(context.Context) (*entity.CrawlControl, error) {
+	c := s.control
+	for id := range s.skipped {
+		c.SkippedSources = append(c.SkippedSources, id)
+	}
+	return &c, nil
+}
+
+func (s *stubControl) SetPaused(_ context.Context, paused bool, reason, updatedBy string) error {
+	s.control.Paused, s.control.Reason, s.control.UpdatedBy = paused, reason, updatedBy
+	return nil
+}
+
+func (s *stubControl) SkipSource(_ context.Context, sourceID int64) error {
+	s.skipped[sourceID] = true
+	return nil
+}
+
+func (s *stubControl) UnskipSource(_ context.Context, sourceID int64) (bool, error) {
+	removed := s.skipped[sourceID]
+	delete(s.skipped, sourceID)
+	return removed, nil
+}
+
+type stubSources struct {
+	repository.SourceRepository
+	sources map[int64]*entity.Source
+}
+
+func (s *stubSources) Get(_ context.Context, id int64) (*entity.Source, error) {
+	return s.sources[id], nil
+}
+
+type stubRuns struct {
+	repository.CrawlRunRepository
+	runs []*entity.CrawlRun
+	err  error
+}
+
+func (s *stubRuns) List(_ context.Context, offset, limit int) ([]*entity.CrawlRun, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	end := min(offset+limit, len(s.runs))
+	if offset >= end {
+		return nil, nil
+	}
+	return s.runs[offset:end], nil
+}
+
+func (s *stubRuns) Count(_ context.Context) (int64, error) {
+	return int64(len(s.runs)), s.err
+}
+
+func (s *stubRuns) Get(_ context.Context, id int64) (*entity.CrawlRun, error) {
+	for _, r := range s.runs {
+		if r.ID == id {
+			return r, nil
+		}
+	}
+	return nil, s.err
+}
+
+func newMux() (*http.ServeMux, *stubJobs) {
+	mux, jobs, _ := newMuxWithRuns()
+	return mux, jobs
+}
+
+func newMuxWithRuns() (*http.ServeMux, *stubJobs, *stubRuns) {
+	jobs := &stubJobs{jobs: map[int64]*entity.Job{}}
+	runs := &stubRuns{}
+	svc := &crawlUC.Service{
+		Jobs:    jobs,
+		Runs:    runs,
+		Control: &stubControl{skipped: map[int64]bool{}},
+		Sources: &stubSources{sources: map[int64]*entity.Source{
+			1: {ID: 1, Active: true},
+			2: {ID: 2, Active: false},
+		}},
+	}
+	mux := http.NewServeMux()
+	// 認可・レート制限なしに Register と同じパターンで直接張る。
+	mux.Handle("POST /sources/{id}/crawl", crawl.TriggerSourceHandler{Svc: svc})
+	mux.Handle("POST /crawl", crawl.TriggerAllHandler{Svc: svc})
+	mux.Handle("GET /crawl/jobs/{id}", crawl.StatusHandler{Svc: svc})
+	mux.Handle("GET /crawls", crawl.ListRunsHandler{Svc: svc, PaginationCfg: pagination.DefaultConfig()})
+	mux.Handle("GET /crawls/{id}", crawl.GetRunHandler{Svc: svc})
+	mux.Handle("GET /crawl/control", crawl.ControlStatusHandler{Svc: svc})
+	mux.Handle("POST /crawl/pause", crawl.PauseHandler{Svc: svc})
+	mux.Handle("POST /crawl/resume", crawl.ResumeHandler{Svc: svc})
+	mux.Handle("PUT /sources/{id}/crawl-skip", crawl.SkipSourceHandler{Svc: svc})
+	mux.Handle("DELETE /sources/{id}/crawl-skip", crawl.UnskipSourceHandler{Svc: svc})
+	return mux, jobs, runs
+}
+
+func serve(mux *http.ServeMux, method, path string) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, httptest.NewRequest(method, path, nil))
+	return rr
+}
+
+/* ───────── POST /sources/{id}/crawl ───────── */
+
+func TestTriggerSourceHandler(t *testing.T) {
+	mux, _ := newMux()
+
+	rr := serve(mux, http.MethodPost, "/sources/1/crawl")
+	require.Equal(t, http.StatusAccepted, rr.Code)
+	var got crawl.JobDTO
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	assert.Equal(t, int64(1), got.ID)
+	assert.Equal(t, int64(1), got.SourceID)
+	assert.Equal(t, entity.JobStatusPending, got.Status)
+}
+
+func TestTriggerSourceHandler_Errors(t *testing.T) {
+	mux, _ := newMux()
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{"invalid id", "/sources/abc/crawl", http.StatusBadRequest},
+		{"zero id", "/sources/0/crawl", http.StatusBadRequest},
+		{"unknown source", "/sources/99/crawl", http.StatusNotFound},
+		{"inactive source", "/sources/2/crawl", http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, serve(mux, http.MethodPost, tt.path).Code)
+		})
+	}
+}
+
+/* ───────── POST /crawl ───────── */
+
+func TestTriggerAllHandler(t *testing.T) {
+	mux, jobs := newMux()
+
+	rr := serve(mux, http.MethodPost, "/crawl")
+	require.Equal(t, http.StatusAccepted, rr.Code)
+	assert.NotContains(t, rr.Body.String(), "source_id")
+
+	jobs.enqueueErr = errors.New("db down")
+	rr = serve(mux, http.MethodPost, "/crawl")
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.NotContains(t, rr.Body.String(), "db down")
+}
+
+/* ───────── GET /crawl/jobs/{id} ───────── */
+
+func TestStatusHandler(t *testing.T) {
+	mux, jobs := newMux()
+	require.Equal(t, http.StatusAccepted, serve(mux, http.MethodPost, "/sources/1/crawl").Code)
+	lastErr := "fetch failed"
+	jobs.jobs[1].Status = entity.JobStatusFailed
+	jobs.jobs[1].Attempts = 3
+	jobs.jobs[1].LastError = &lastErr
+
+	rr := serve(mux, http.MethodGet, "/crawl/jobs/1")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var got crawl.JobDTO
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	assert.Equal(t, entity.JobStatusFailed, got.Status)
+	assert.Equal(t, 3, got.Attempts)
+	require.NotNil(t, got.LastError)
+	assert.Equal(t, lastErr, *got.LastError)
+
+	assert.Equal(t, http.StatusNotFound, serve(mux, http.MethodGet, "/crawl/jobs/99").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(mux, http.MethodGet, "/crawl/jobs/x").Code)
+}
+
+/* ───────── GET /crawls ───────── */
+
+func TestListRunsHandler(t *testing.T) {
+	mux, _, runs := newMuxWithRuns()
+	started := time.Date(2026, 10, 1, 5, 0, 0, 0, time.UTC)
+	finished := started.Add(2 * time.Minute)
+	abort := "process feed items: db down"
+	runs.runs = []*entity.CrawlRun{
+		{ID: 3, Status: entity.CrawlRunStatusRunning, StartedAt: started.Add(time.Hour), SourceCount: 4},
+		{ID: 2, Status: entity.CrawlRunStatusFailed, StartedAt: started, FinishedAt: &finished, SourceCount: 4, Error: &abort},
+		{ID: 1, Status: entity.CrawlRunStatusSucceeded, StartedAt: started, FinishedAt: &finished, SourceCount: 4, Inserted: 5,
+			Sources: []entity.CrawlRunSource{{SourceID: 1, HTTPStatus: 200}}},
+	}
+
+	rr := serve(mux, http.MethodGet, "/crawls?page=1&limit=2")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var got pagination.Response[crawl.RunDTO]
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	require.Len(t, got.Data, 2)
+	assert.Equal(t, int64(3), got.Data[0].ID)
+	assert.Nil(t, got.Data[0].FinishedAt, "a running crawl has no finished_at")
+	require.NotNil(t, got.Data[1].Error)
+	assert.Equal(t, abort, *got.Data[1].Error)
+	assert.Equal(t, int64(3), got.Pagination.Total)
+	assert.Equal(t, 2, got.Pagination.TotalPages)
+	assert.NotContains(t, rr.Body.String(), "source_stats", "the list leaves the breakdown to GET /crawls/{id}")
+
+	assert.Equal(t, http.StatusBadRequest, serve(mux, http.MethodGet, "/crawls?page=0").Code)
+
+	runs.err = errors.New("db down")
+	rr = serve(mux, http.MethodGet, "/crawls")
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.NotContains(t, rr.Body.String(), "db down")
+}
+
+/* ───────── GET /crawls/{id} ───────── */
+
+func TestGetRunHandler(t *testing.T) {
+	mux, _, runs := newMuxWithRuns()
+	started := time.Date(2026, 10, 1, 5, 0, 0, 0, time.UTC)
+	runs.runs = []*entity.CrawlRun{{
+		ID: 1, Status: entity.CrawlRunStatusSucceeded, StartedAt: started, SourceCount: 2, FetchFailedSources: 1,
+		Sources: []entity.CrawlRunSource{
+			{SourceID: 1, Kind: "rss", HTTPStatus: 304, NotModified: true, DurationMS: 80},
+			{SourceID: 2, Kind: "rss", HTTPStatus: 404, FetchError: "feed returned 404", DurationMS: 40},
+		},
+	}}
+
+	rr := serve(mux, http.MethodGet, "/crawls/1")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var got crawl.RunDetailDTO
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	assert.Equal(t, 2, got.Sources)
+	assert.Equal(t, 1, got.FetchFailedSources)
+	require.Len(t, got.SourceStats, 2)
+	assert.True(t, got.SourceStats[0].NotModified)
+	assert.Equal(t, "feed returned 404", got.SourceStats[1].FetchError)
+
+	assert.Equal(t, http.StatusNotFound, serve(mux, http.MethodGet, "/crawls/99").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(mux, http.MethodGet, "/crawls/x").Code)
+}
+
+/* ───────── クロール制御 ───────── */
+
+func TestPauseResumeHandlers(t *testing.T) {
+	mux, jobs := newMux()
+	jobs.jobs[1] = &entity.Job{ID: 1, Kind: entity.JobKindCrawl, Payload: []byte(`{"default_schedule":true}`), Status: entity.JobStatusRunning}
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/crawl/pause", strings.NewReader(`{"reason":"DB maintenance"}`))
+	mux.ServeHTTP(rr, req.WithContext(auth.WithIdentity(req.Context(), "admin", auth.RoleAdmin)))
+	require.Equal(t, http.StatusOK, rr.Code)
+	var got crawl.ControlDTO
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	assert.True(t, got.Paused)
+	assert.Equal(t, "DB maintenance", got.Reason)
+	assert.Equal(t, "admin", got.UpdatedBy)
+	require.Len(t, got.ActiveJobs, 1)
+	assert.True(t, got.ActiveJobs[0].DefaultSchedule)
+	assert.Equal(t, entity.JobKindCrawl, got.ActiveJobs[0].Kind)
+
+	// 本文なしでも停止できる
+	assert.Equal(t, http.StatusOK, serve(mux, http.MethodPost, "/crawl/pause").Code)
+
+	rr = httptest.NewRecorder()
+	mux.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/crawl/pause", strings.NewReader(`{`)))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = httptest.NewRecorder()
+	mux.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/crawl/pause",
+		strings.NewReader(`{"reason":"`+strings.Repeat("x", crawlUC.MaxPauseReasonLength+1)+`"}`)))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = serve(mux, http.MethodPost, "/crawl/resume")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	assert.False(t, got.Paused)
+	assert.Empty(t, got.Reason)
+}
+
+func TestSkipSourceHandlers(t *testing.T) {
+	mux, _ := newMux()
+
+	assert.Equal(t, http.StatusNoContent, serve(mux, http.MethodPut, "/sources/1/crawl-skip").Code)
+	assert.Equal(t, http.StatusNoContent, serve(mux, http.MethodPut, "/sources/1/crawl-skip").Code, "idempotent")
+	assert.Equal(t, http.StatusNotFound, serve(mux, http.MethodPut, "/sources/99/crawl-skip").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(mux, http.MethodPut, "/sources/x/crawl-skip").Code)
+
+	rr := serve(mux, http.MethodGet, "/crawl/control")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var got crawl.ControlDTO
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	assert.Equal(t, []int64{1}, got.SkippedSources)
+	assert.NotNil(t, got.ActiveJobs, "active_jobs is an empty array, not null")
+
+	assert.Equal(t, http.StatusNoContent, serve(mux, http.MethodDelete, "/sources/1/crawl-skip").Code)
+	assert.Equal(t, http.StatusNotFound, serve(mux, http.MethodDelete, "/sources/1/crawl-skip").Code)
+}
@@ -0,0 +1,31 @@
+package crawl
+
+import (
+	"net/http"
+
+	"catchup-feed/internal/common/pagination"
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/handler/http/middleware"
+	crawlUC "catchup-feed/internal/usecase/crawl"
+)
+
+// Register registers the on-demand crawl and crawl history routes (C-21
+// flat paths). Triggers require the sources:write scope and share the
+// search rate limit — each one makes the worker fetch external feeds; the
+// status and history routes require sources:read.
+func Register(mux *http.ServeMux, svc *crawlUC.Service, paginationCfg pagination.Config, rateLimiter *middleware.RateLimiter) {
+	read := auth.RequireScope(auth.ScopeSourcesRead)
+	write := auth.RequireScope(auth.ScopeSourcesWrite)
+
+	mux.Handle("POST /sources/{id}/crawl", write(rateLimiter.Middleware(TriggerSourceHandler{svc})))
+	mux.Handle("POST /crawl", write(rateLimiter.Middleware(TriggerAllHandler{svc})))
+	mux.Handle("GET /crawl/jobs/{id}", read(StatusHandler{svc}))
+	mux.Handle("GET /crawls", read(ListRunsHandler{Svc: svc, PaginationCfg: paginationCfg}))
+	mux.Handle("GET /crawls/{id}", read(GetRunHandler{svc}))
+
+	mux.Handle("GET /crawl/control", auth.Authz(ControlStatusHandler{svc}))
+	mux.Handle("POST /crawl/pause", auth.Authz(PauseHandler{svc}))
+	mux.Handle("POST /crawl/resume", auth.Authz(ResumeHandler{svc}))
+	mux.Handle("PUT /sources/{id}/crawl-skip", auth.Authz(SkipSourceHandler{svc}))
+	mux.Handle("DELETE /sources/{id}/crawl-skip", auth.Authz(UnskipSourceHandler{svc}))
+}
@@ -0,0 +1,28 @@
+package crawl
+
+import (
+	"errors"
+	"net/http"
+
+	"catchup-feed/internal/handler/http/respond"
+	crawlUC "catchup-feed/internal/usecase/crawl"
+)
+
+// respondUsecaseError maps use case sentinel errors to HTTP statuses:
+// not-found → 404, invalid input → 400, inactive source → 409, anything
+// else → sanitized 500.
+func respondUsecaseError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, crawlUC.ErrSourceNotFound),
+		errors.Is(err, crawlUC.ErrJobNotFound),
+		errors.Is(err, crawlUC.ErrRunNotFound),
+		errors.Is(err, crawlUC.ErrSkippedSourceNotFound):
+		respond.SafeError(w, http.StatusNotFound, err)
+	case errors.Is(err, crawlUC.ErrInvalidPauseReason):
+		respond.SafeError(w, http.StatusBadRequest, err)
+	case errors.Is(err, crawlUC.ErrSourceInactive):
+		respond.SafeError(w, http.StatusConflict, err)
+	default:
+		respond.SafeError(w, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,22 @@
+package dashboard
+
+import (
+	"log/slog"
+	"net/http"
+
+	dashUC "catchup-feed/internal/usecase/dashboard"
+)
+
+// Register registers GET /ws, the dashboard's live event push. The route
+// is not wrapped in RequireScope: a client needs articles:read or
+// sources:read and receives only the event types its scopes cover, and
+// article events of its subscribed sources when subscription-scoped.
+func Register(mux *http.ServeMux, hub *dashUC.Hub, checkOrigin func(origin string) bool, maxConnections int, subscriptions SubscriptionLookup, logger *slog.Logger) {
+	mux.Handle("GET /ws", WSHandler{
+		Hub:            hub,
+		CheckOrigin:    checkOrigin,
+		MaxConnections: maxConnections,
+		Subscriptions:  subscriptions,
+		Logger:         logger,
+	})
+}
@@ -0,0 +1,370 @@
+package dashboard
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/handler/http/respond"
+	dashUC "catchup-feed/internal/usecase/dashboard"
+)
+
+const (
+	// wsPingInterval is how often the server pings; a client that sends
+	// nothing (not even the pong) for wsReadTimeout is dropped.
+	wsPingInterval = 30 * time.Second
+	wsReadTimeout  = 2 * wsPingInterval
+	// wsWriteTimeout bounds one frame write to a slow client.
+	wsWriteTimeout = 10 * time.Second
+	// wsMaxMessageSize caps a client message (subscribe requests only).
+	wsMaxMessageSize = 4096
+)
+
+// eventScopes is the scope each event type requires: articles are
+// articles:read, crawl and source health sources:read (like GET /crawls
+// and GET /sources/health).
+var eventScopes = map[string]string{
+	entity.DashboardEventArticleCreated: auth.ScopeArticlesRead,
+	entity.DashboardEventCrawlCompleted: auth.ScopeSourcesRead,
+	entity.DashboardEventSourceHealth:   auth.ScopeSourcesRead,
+}
+
+var (
+	errUnknownEventType = errors.New("unknown event type")
+	errEventForbidden   = errors.New("event type not permitted")
+)
+
+// WSHandler upgrades GET /ws to a WebSocket that pushes dashboard events.
+type WSHandler struct {
+	Hub *dashUC.Hub
+	// CheckOrigin reports whether a browser Origin may connect (the CORS
+	// allowlist). The dashboard authenticates with its cookie, which the
+	// browser attaches to a cross-site handshake too, so without this
+	// check any site could read the events (cross-site WebSocket
+	// hijacking). Requests without an Origin header (non-browser clients)
+	// are not checked. nil rejects every browser Origin.
+	CheckOrigin func(origin string) bool
+	// MaxConnections limits open connections (0 = unlimited).
+	MaxConnections int
+	// Subscriptions scopes article.created for subscription-scoped
+	// callers (auth.SubscriptionScoped) to their sources; nil reports no
+	// subscriptions.
+	Subscriptions SubscriptionLookup
+	Logger        *slog.Logger
+}
+
+// SubscriptionLookup reports which sources a login subject subscribes to
+// (implemented by the subscription use case).
+type SubscriptionLookup interface {
+	SubscribedSourceIDs(ctx context.Context, subject string) (map[int64]bool, error)
+}
+
+// ServeHTTP ダッシュボード更新 WebSocket
+// @Summary      ダッシュボード更新(WebSocket)
+// @Description  WebSocket でダッシュボードのイベントを配信します。メッセージは {"type","source_id","data"} の JSON テキストです。
+// @Description  type は article.created(data は {"type","article_id","source_id"}、articles:read が必要)、crawl.completed(クロール実行の集計、sources:read が必要)、source.health(ソースの初回クロールと ok / failing の切り替わり、sources:read が必要)です。
+// @Description  クエリ types / source_ids(カンマ区切り)で受け取るイベントを絞り込めます。省略時は権限のあるすべての種類・すべてのソースです。接続後も {"action":"subscribe","types":[...],"source_ids":[...]} を送ると絞り込みを置き換えられ、{"type":"subscribed"} または {"type":"error"} が返ります。
+// @Description  接続中のイベントのみ届きます(再接続までの間のイベントは再送されません)。サーバーは 30 秒ごとに ping を送ります。ブラウザからの接続は CORS の許可オリジンに限ります。
+// @Description  admin 以外のアカウントには article.created は接続時点で購読中のソースの記事のみ届きます(購読の変更は再接続後に反映)。
+// @Tags         dashboard
+// @Security     BearerAuth
+// @Param        types       query  string  false  "受け取るイベント種別(カンマ区切り)"  example(article.created,source.health)
+// @Param        source_ids  query  string  false  "受け取るソース ID(カンマ区切り、crawl.completed には適用されない)"  example(1,2)
+// @Success      101 {string} string "Switching Protocols"
+// @Failure      400 {object} respond.ErrorResponse "Invalid filter or not a WebSocket handshake"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - origin not allowed or event type not permitted"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Failure      503 {object} respond.ErrorResponse "Service unavailable - too many connections or not configured"
+// @Router       /ws [get]
+func (h WSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.Hub == nil {
+		respond.SafeError(w, http.StatusServiceUnavailable, errors.New("event stream not configured"))
+		return
+	}
+	if origin := r.Header.Get("Origin"); origin != "" && (h.CheckOrigin == nil || !h.CheckOrigin(origin)) {
+		respond.SafeError(w, http.StatusForbidden, errors.New("origin not allowed"))
+		return
+	}
+	permitted := permittedEvents(auth.ScopesFromContext(r.Context()))
+	if len(permitted) == 0 {
+		respond.SafeError(w, http.StatusForbidden, errors.New("forbidden"))
+		return
+	}
+	filter, err := filterFromQuery(r, permitted)
+	if err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, errEventForbidden) {
+			code = http.StatusForbidden
+		}
+		respond.SafeError(w, code, err)
+		return
+	}
+	// Subscription-scoped callers only hear about the articles of their
+	// sources, as of the time they connect.
+	var sources map[int64]bool
+	if auth.SubscriptionScoped(r.Context()) {
+		sources = map[int64]bool{}
+		if h.Subscriptions != nil {
+			if sources, err = h.Subscriptions.SubscribedSourceIDs(r.Context(), auth.SubjectFromContext(r.Context())); err != nil {
+				respond.SafeError(w, http.StatusInternalServerError, err)
+				return
+			}
+		}
+	}
+	if h.MaxConnections > 0 && h.Hub.Stats().Connections >= h.MaxConnections {
+		respond.SafeError(w, http.StatusServiceUnavailable, errors.New("too many connections"))
+		return
+	}
+
+	conn, rw, _, err := ws.HTTPUpgrader{Timeout: wsWriteTimeout}.Upgrade(r, w)
+	if err != nil {
+		// The upgrader has already answered the handshake.
+		h.logger().Debug("websocket upgrade failed", slog.Any("error", err))
+		return
+	}
+	defer func() { _ = conn.Close() }()
+	// The hijacked connection keeps the server's request deadlines
+	// (HTTP_READ_TIMEOUT / HTTP_WRITE_TIMEOUT); the loops below set their own.
+	_ = conn.SetDeadline(time.Time{})
+
+	sub := h.Hub.Subscribe(filter)
+	defer sub.Cancel()
+	c := &wsConn{conn: conn}
+	if err := c.sendJSON(controlMessage{Type: "subscribed", Data: filter}); err != nil {
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.readLoop(c, rw.Reader, sub, permitted)
+	}()
+
+	ping := time.NewTicker(wsPingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-r.Context().Done():
+			_ = c.sendClose(ws.StatusGoingAway, "server shutting down")
+			return
+		case <-ping.C:
+			if err := c.send(ws.OpPing, nil); err != nil {
+				return
+			}
+		case ev, ok := <-sub.Events():
+			if !ok {
+				_ = c.sendClose(ws.StatusGoingAway, "server shutting down")
+				return
+			}
+			if sources != nil && ev.Type == entity.DashboardEventArticleCreated && !sources[ev.SourceID] {
+				continue
+			}
+			if err := c.sendJSON(ev); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (h WSHandler) logger() *slog.Logger {
+	if h.Logger == nil {
+		return slog.Default()
+	}
+	return h.Logger
+}
+
+// subscribeRequest is the client message replacing the connection's
+// filter.
+type subscribeRequest struct {
+	Action    string   `json:"action"`
+	Types     []string `json:"types"`
+	SourceIDs []int64  `json:"source_ids"`
+}
+
+// controlMessage is a server reply to the client (subscribed / error).
+type controlMessage struct {
+	Type string `json:"type"`
+	Data any    `json:"data"`
+}
+
+// readLoop answers control frames and applies subscribe requests until
+// the client closes, goes silent or breaks the protocol.
+func (h WSHandler) readLoop(c *wsConn, src *bufio.Reader, sub *dashUC.Subscription, permitted []string) {
+	var control bytes.Buffer
+	handleControl := func(hdr ws.Header, r io.Reader) error {
+		control.Reset()
+		err := wsutil.ControlFrameHandler(&control, ws.StateServerSide)(hdr, r)
+		if control.Len() > 0 {
+			if _, werr := c.Write(control.Bytes()); werr != nil && err == nil {
+				err = werr
+			}
+		}
+		return err
+	}
+	rd := &wsutil.Reader{
+		Source:         src,
+		State:          ws.StateServerSide,
+		CheckUTF8:      true,
+		MaxFrameSize:   wsMaxMessageSize,
+		OnIntermediate: handleControl,
+	}
+	for {
+		_ = c.conn.SetReadDeadline(time.Now().Add(wsReadTimeout))
+		hdr, err := rd.NextFrame()
+		if err != nil {
+			return
+		}
+		if hdr.OpCode.IsControl() {
+			if err := handleControl(hdr, rd); err != nil {
+				return
+			}
+			continue
+		}
+		data, err := io.ReadAll(io.LimitReader(rd, wsMaxMessageSize+1))
+		if err != nil || len(data) > wsMaxMessageSize {
+			_ = c.sendClose(ws.StatusMessageTooBig, "message too big")
+			return
+		}
+		if hdr.OpCode != ws.OpText {
+			continue
+		}
+		if err := c.sendJSON(applySubscribe(data, sub, permitted)); err != nil {
+			return
+		}
+	}
+}
+
+// applySubscribe replaces the filter from a subscribe request and returns
+// the reply. An invalid request keeps the current filter.
+func applySubscribe(data []byte, sub *dashUC.Subscription, permitted []string) controlMessage {
+	var req subscribeRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return errorMessage(errors.New("invalid message"))
+	}
+	if req.Action != "subscribe" {
+		return errorMessage(fmt.Errorf("unknown action %q", req.Action))
+	}
+	filter, err := newFilter(req.Types, req.SourceIDs, permitted)
+	if err != nil {
+		return errorMessage(err)
+	}
+	sub.SetFilter(filter)
+	return controlMessage{Type: "subscribed", Data: filter}
+}
+
+func errorMessage(err error) controlMessage {
+	return controlMessage{Type: "error", Data: respond.ErrorResponse{Error: err.Error()}}
+}
+
+// permittedEvents returns the event types scopes allow, in
+// entity.DashboardEvents order.
+func permittedEvents(scopes []string) []string {
+	var types []string
+	for _, t := range entity.DashboardEvents {
+		if slices.Contains(scopes, eventScopes[t]) {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// filterFromQuery reads the types / source_ids query parameters.
+func filterFromQuery(r *http.Request, permitted []string) (dashUC.Filter, error) {
+	q := r.URL.Query()
+	var types []string
+	for _, t := range strings.Split(q.Get("types"), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, t)
+		}
+	}
+	var sourceIDs []int64
+	for _, s := range strings.Split(q.Get("source_ids"), ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || id <= 0 {
+			return dashUC.Filter{}, fmt.Errorf("invalid source_ids: %q", s)
+		}
+		sourceIDs = append(sourceIDs, id)
+	}
+	return newFilter(types, sourceIDs, permitted)
+}
+
+// newFilter validates a requested filter. No types means every permitted
+// type; the filter always lists its types explicitly so a connection
+// never receives an event its scopes do not cover.
+func newFilter(types []string, sourceIDs []int64, permitted []string) (dashUC.Filter, error) {
+	if len(types) == 0 {
+		types = permitted
+	}
+	for _, t := range types {
+		if _, ok := eventScopes[t]; !ok {
+			return dashUC.Filter{}, fmt.Errorf("%w: %q", errUnknownEventType, t)
+		}
+		if !slices.Contains(permitted, t) {
+			return dashUC.Filter{}, fmt.Errorf("%w: %q", errEventForbidden, t)
+		}
+	}
+	for _, id := range sourceIDs {
+		if id <= 0 {
+			return dashUC.Filter{}, fmt.Errorf("invalid source_ids: %d", id)
+		}
+	}
+	return dashUC.Filter{Types: slices.Clone(types), SourceIDs: sourceIDs}, nil
+}
+
+// wsConn serializes frame writes from the event loop and the read loop
+// (control frame replies). Every frame goes out in a single Write.
+type wsConn struct {
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func (c *wsConn) Write(frame []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_ = c.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return c.conn.Write(frame)
+}
+
+func (c *wsConn) send(op ws.OpCode, payload []byte) error {
+	var frame bytes.Buffer
+	if err := ws.WriteFrame(&frame, ws.NewFrame(op, true, payload)); err != nil {
+		return err
+	}
+	_, err := c.Write(frame.Bytes())
+	return err
+}
+
+func (c *wsConn) sendJSON(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.send(ws.OpText, data)
+}
+
+func (c *wsConn) sendClose(code ws.StatusCode, reason string) error {
+	return c.send(ws.OpClose, ws.NewCloseFrameBody(code, reason))
+}
@@ -0,0 +1,291 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/auth"
+	dashUC "catchup-feed/internal/usecase/dashboard"
+)
+
+const (
+	testJWTSecret = "test-secret-key-at-least-32-characters-long"
+	testAdminUser = "admin@example.com"
+)
+
+// newTestServer serves WSHandler behind the admin Authz middleware.
+func newTestServer(t *testing.T, h WSHandler) *httptest.Server {
+	t.Helper()
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	t.Setenv(auth.EnvAdminUser, testAdminUser)
+	srv := httptest.NewServer(auth.Authz(h))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// adminToken signs an admin JWT; scope narrows it when non-empty.
+func adminToken(t *testing.T, scope string) string {
+	t.Helper()
+	claims := jwt.MapClaims{
+		"sub":  testAdminUser,
+		"role": auth.RoleAdmin,
+		"iat":  time.Now().Unix(),
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	}
+	if scope != "" {
+		claims["scope"] = scope
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testJWTSecret))
+	require.NoError(t, err)
+	return signed
+}
+
+func dial(t *testing.T, srv *httptest.Server, query string, header http.Header) (net.Conn, error) {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws" + query
+	conn, br, _, err := ws.Dialer{Header: ws.HandshakeHeaderHTTP(header), Timeout: 2 * time.Second}.Dial(context.Background(), url)
+	if err != nil {
+		return nil, err
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+	if br != nil {
+		// Frames that arrived with the handshake response
+		return bufferedConn{Conn: conn, r: io.MultiReader(br, conn)}, nil
+	}
+	return conn, nil
+}
+
+type bufferedConn struct {
+	net.Conn
+	r io.Reader
+}
+
+func (c bufferedConn) Read(p []byte) (int, error) { return c.r.Read(p) }
+
+func bearer(token string) http.Header {
+	return http.Header{"Authorization": {"Bearer " + token}}
+}
+
+// readMessage reads the next text message as {"type","source_id","data"}.
+func readMessage(t *testing.T, conn net.Conn) (string, int64, json.RawMessage) {
+	t.Helper()
+	data, err := wsutil.ReadServerText(conn)
+	require.NoError(t, err)
+	var msg struct {
+		Type     string          `json:"type"`
+		SourceID int64           `json:"source_id"`
+		Data     json.RawMessage `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(data, &msg))
+	return msg.Type, msg.SourceID, msg.Data
+}
+
+func event(typ string, sourceID int64) entity.DashboardEvent {
+	return entity.DashboardEvent{Type: typ, SourceID: sourceID, Data: json.RawMessage(`{"n":1}`)}
+}
+
+/* ───────── handshake ───────── */
+
+func TestWSHandler_Handshake(t *testing.T) {
+	hub := dashUC.NewHub()
+	srv := newTestServer(t, WSHandler{
+		Hub:         hub,
+		CheckOrigin: func(origin string) bool { return origin == "https://app.example.com" },
+	})
+
+	tests := []struct {
+		name    string
+		query   string
+		header  http.Header
+		wantErr int // 0 = upgraded
+	}{
+		{"no token", "", http.Header{}, http.StatusUnauthorized},
+		{"admin", "", bearer(adminToken(t, "")), 0},
+		{"allowed origin", "", http.Header{"Authorization": {"Bearer " + adminToken(t, "")}, "Origin": {"https://app.example.com"}}, 0},
+		{"foreign origin", "", http.Header{"Authorization": {"Bearer " + adminToken(t, "")}, "Origin": {"https://evil.example.com"}}, http.StatusForbidden},
+		{"unknown type", "?types=article.deleted", bearer(adminToken(t, "")), http.StatusBadRequest},
+		{"invalid source id", "?source_ids=abc", bearer(adminToken(t, "")), http.StatusBadRequest},
+		{"type outside scopes", "?types=article.created", bearer(adminToken(t, auth.ScopeSourcesRead)), http.StatusForbidden},
+		{"no event scope", "", bearer(adminToken(t, auth.ScopeAIAsk)), http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := dial(t, srv, tt.query, tt.header)
+			if tt.wantErr == 0 {
+				require.NoError(t, err)
+				return
+			}
+			var status ws.StatusError
+			require.ErrorAs(t, err, &status)
+			assert.Equal(t, tt.wantErr, int(status))
+		})
+	}
+}
+
+func TestWSHandler_MaxConnections(t *testing.T) {
+	hub := dashUC.NewHub()
+	srv := newTestServer(t, WSHandler{Hub: hub, MaxConnections: 1})
+
+	conn, err := dial(t, srv, "", bearer(adminToken(t, "")))
+	require.NoError(t, err)
+	readMessage(t, conn) // subscribed
+
+	_, err = dial(t, srv, "", bearer(adminToken(t, "")))
+	var status ws.StatusError
+	require.ErrorAs(t, err, &status)
+	assert.Equal(t, http.StatusServiceUnavailable, int(status))
+}
+
+/* ───────── events ───────── */
+
+func TestWSHandler_DeliversFilteredEvents(t *testing.T) {
+	hub := dashUC.NewHub()
+	srv := newTestServer(t, WSHandler{Hub: hub})
+
+	conn, err := dial(t, srv, "?types=article.created,source.health&source_ids=3", bearer(adminToken(t, "")))
+	require.NoError(t, err)
+	typ, _, data := readMessage(t, conn)
+	assert.Equal(t, "subscribed", typ)
+	assert.JSONEq(t, `{"types":["article.created","source.health"],"source_ids":[3]}`, string(data))
+
+	hub.Publish(event(entity.DashboardEventArticleCreated, 4)) // other source
+	hub.Publish(event(entity.DashboardEventCrawlCompleted, 0)) // type not subscribed
+	hub.Publish(event(entity.DashboardEventSourceHealth, 3))
+
+	typ, sourceID, data := readMessage(t, conn)
+	assert.Equal(t, entity.DashboardEventSourceHealth, typ)
+	assert.Equal(t, int64(3), sourceID)
+	assert.JSONEq(t, `{"n":1}`, string(data))
+	assert.Equal(t, 1, hub.Stats().Connections)
+}
+
+// Scopes limit the default filter: a sources:read token never receives
+// article.created.
+func TestWSHandler_DefaultFilterFollowsScopes(t *testing.T) {
+	hub := dashUC.NewHub()
+	srv := newTestServer(t, WSHandler{Hub: hub})
+
+	conn, err := dial(t, srv, "", bearer(adminToken(t, auth.ScopeSourcesRead)))
+	require.NoError(t, err)
+	_, _, data := readMessage(t, conn)
+	assert.JSONEq(t, `{"types":["crawl.completed","source.health"]}`, string(data))
+
+	hub.Publish(event(entity.DashboardEventArticleCreated, 1))
+	hub.Publish(event(entity.DashboardEventCrawlCompleted, 0))
+	typ, _, _ := readMessage(t, conn)
+	assert.Equal(t, entity.DashboardEventCrawlCompleted, typ)
+}
+
+func TestWSHandler_SubscribeMessage(t *testing.T) {
+	hub := dashUC.NewHub()
+	srv := newTestServer(t, WSHandler{Hub: hub})
+
+	conn, err := dial(t, srv, "", bearer(adminToken(t, "")))
+	require.NoError(t, err)
+	readMessage(t, conn) // subscribed
+
+	require.NoError(t, wsutil.WriteClientText(conn, []byte(`{"action":"subscribe","types":["bogus"]}`)))
+	typ, _, data := readMessage(t, conn)
+	assert.Equal(t, "error", typ)
+	assert.Contains(t, string(data), "unknown event type")
+
+	require.NoError(t, wsutil.WriteClientText(conn, []byte(`{"action":"subscribe","types":["crawl.completed"]}`)))
+	typ, _, data = readMessage(t, conn)
+	assert.Equal(t, "subscribed", typ)
+	assert.JSONEq(t, `{"types":["crawl.completed"]}`, string(data))
+
+	hub.Publish(event(entity.DashboardEventArticleCreated, 1))
+	hub.Publish(event(entity.DashboardEventCrawlCompleted, 0))
+	typ, _, _ = readMessage(t, conn)
+	assert.Equal(t, entity.DashboardEventCrawlCompleted, typ)
+}
+
+// stubSubscriptions subscribes every subject to source 3.
+type stubSubscriptions struct{}
+
+func (stubSubscriptions) SubscribedSourceIDs(context.Context, string) (map[int64]bool, error) {
+	return map[int64]bool{3: true}, nil
+}
+
+// An account other than admin receives article.created of its subscribed
+// sources only; other event types are not scoped.
+func TestWSHandler_SubscriptionScope(t *testing.T) {
+	hub := dashUC.NewHub()
+	h := WSHandler{Hub: hub, Subscriptions: stubSubscriptions{}}
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	t.Setenv(auth.EnvAdminUser, testAdminUser)
+	srv := httptest.NewServer(auth.Authz(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), "alice@example.com", "editor")))
+	})))
+	t.Cleanup(srv.Close)
+
+	conn, err := dial(t, srv, "", bearer(adminToken(t, "")))
+	require.NoError(t, err)
+	readMessage(t, conn) // subscribed
+
+	hub.Publish(event(entity.DashboardEventArticleCreated, 4))
+	hub.Publish(event(entity.DashboardEventSourceHealth, 4))
+	hub.Publish(event(entity.DashboardEventArticleCreated, 3))
+
+	typ, sourceID, _ := readMessage(t, conn)
+	assert.Equal(t, entity.DashboardEventSourceHealth, typ)
+	assert.Equal(t, int64(4), sourceID)
+	typ, sourceID, _ = readMessage(t, conn)
+	assert.Equal(t, entity.DashboardEventArticleCreated, typ)
+	assert.Equal(t, int64(3), sourceID, "source 4 is not subscribed")
+}
+
+// Ping frames from the client are answered while events flow.
+func TestWSHandler_AnswersPing(t *testing.T) {
+	hub := dashUC.NewHub()
+	srv := newTestServer(t, WSHandler{Hub: hub})
+
+	conn, err := dial(t, srv, "", bearer(adminToken(t, "")))
+	require.NoError(t, err)
+	readMessage(t, conn) // subscribed
+
+	require.NoError(t, wsutil.WriteClientMessage(conn, ws.OpPing, []byte("hi")))
+	msgs, err := wsutil.ReadServerMessage(conn, nil)
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, ws.OpPong, msgs[0].OpCode)
+	assert.Equal(t, "hi", string(msgs[0].Payload))
+}
+
+// Closing the hub (server shutdown) closes the connections.
+func TestWSHandler_HubCloseEndsConnection(t *testing.T) {
+	hub := dashUC.NewHub()
+	srv := newTestServer(t, WSHandler{Hub: hub})
+
+	conn, err := dial(t, srv, "", bearer(adminToken(t, "")))
+	require.NoError(t, err)
+	readMessage(t, conn) // subscribed
+
+	hub.Close()
+	_, err = wsutil.ReadServerText(conn)
+	var closed wsutil.ClosedError
+	require.ErrorAs(t, err, &closed)
+	assert.Equal(t, ws.StatusGoingAway, closed.Code)
+	require.Eventually(t, func() bool { return hub.Stats().Connections == 0 }, time.Second, 10*time.Millisecond)
+}
+
+func TestWSHandler_NoHub(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WSHandler{}.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
@@ -0,0 +1,122 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"catchup-feed/internal/notify"
+)
+
+// ErrorReport is one server-side failure handed to an ErrorReporter:
+// either a recovered panic (Panic / Stack set) or a handler that answered
+// with a 5xx status.
+type ErrorReport struct {
+	RequestID string
+	Method    string
+	Path      string // redacted (pathutil.RedactPath)
+	Status    int
+	Panic     any    // nil for plain 5xx responses
+	Stack     string // empty for plain 5xx responses
+	Time      time.Time
+}
+
+// ErrorReporter ships server failures somewhere a human will see them.
+// Report is called on the request goroutine after the response has been
+// written, so implementations must not block on the network.
+type ErrorReporter interface {
+	Report(ctx context.Context, rep ErrorReport)
+}
+
+// NotifyReporter reports failures to the admin notification channels
+// (§7 / D-7: Discord / Slack) instead of a hosted error tracker — one
+// admin, one Pi, and the channels are already there (設計原則1: 右サイズ).
+//
+// Reports are throttled per "METHOD path status" key: a DB outage turns
+// every request into a 500, and the channel must not receive one message
+// per request. The first failure of a key is sent immediately; repeats
+// within Interval are counted and the count is attached to the next
+// message sent for that key.
+type NotifyReporter struct {
+	destinations []notify.Destination
+	interval     time.Duration
+	timeout      time.Duration
+	logger       *slog.Logger
+
+	mu      sync.Mutex
+	last    map[string]time.Time
+	dropped map[string]int
+}
+
+// NewNotifyReporter builds a reporter over destinations. interval is the
+// per-key throttle window.
+func NewNotifyReporter(destinations []notify.Destination, interval time.Duration, logger *slog.Logger) *NotifyReporter {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &NotifyReporter{
+		destinations: destinations,
+		interval:     interval,
+		timeout:      15 * time.Second,
+		logger:       logger,
+		last:         map[string]time.Time{},
+		dropped:      map[string]int{},
+	}
+}
+
+// Report sends rep asynchronously unless its key was reported within the
+// throttle window.
+func (n *NotifyReporter) Report(ctx context.Context, rep ErrorReport) {
+	key := fmt.Sprintf("%s %s %d", rep.Method, rep.Path, rep.Status)
+
+	n.mu.Lock()
+	if last, ok := n.last[key]; ok && rep.Time.Sub(last) < n.interval {
+		n.dropped[key]++
+		n.mu.Unlock()
+		return
+	}
+	suppressed := n.dropped[key]
+	n.last[key] = rep.Time
+	delete(n.dropped, key)
+	n.mu.Unlock()
+
+	msg := formatErrorReport(rep, suppressed)
+	// The request context is cancelled once the handler returns; keep its
+	// values (request_id for logging) but not its deadline.
+	sendCtx := context.WithoutCancel(ctx)
+	go func() {
+		sendCtx, cancel := context.WithTimeout(sendCtx, n.timeout)
+		defer cancel()
+		for _, d := range n.destinations {
+			if err := d.Notify(sendCtx, msg); err != nil {
+				n.logger.WarnContext(sendCtx, "error report delivery failed",
+					slog.String("channel", d.Name()),
+					slog.Any("error", err))
+			}
+		}
+	}()
+}
+
+func formatErrorReport(rep ErrorReport, suppressed int) notify.Message {
+	var subject string
+	if rep.Panic != nil {
+		subject = fmt.Sprintf("[server] panic: %s %s", rep.Method, rep.Path)
+	} else {
+		subject = fmt.Sprintf("[server] %d: %s %s", rep.Status, rep.Method, rep.Path)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "request_id: %s\n", rep.RequestID)
+	fmt.Fprintf(&b, "time: %s\n", rep.Time.Format(time.RFC3339))
+	fmt.Fprintf(&b, "status: %d\n", rep.Status)
+	if suppressed > 0 {
+		fmt.Fprintf(&b, "suppressed since last report: %d\n", suppressed)
+	}
+	if rep.Panic != nil {
+		fmt.Fprintf(&b, "panic: %v\n\n%s", rep.Panic, rep.Stack)
+	}
+	return notify.Message{Kind: notify.MessageKindError, Subject: subject, Body: b.String()}
+}
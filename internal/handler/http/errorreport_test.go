@@ -0,0 +1,142 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"catchup-feed/internal/handler/http/requestid"
+	"catchup-feed/internal/notify"
+)
+
+type stubReporter struct{ reports []ErrorReport }
+
+func (s *stubReporter) Report(_ context.Context, rep ErrorReport) {
+	s.reports = append(s.reports, rep)
+}
+
+func TestRecoverWithReporter(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantReport bool
+		wantStatus int
+		wantPanic  bool
+	}{
+		{
+			name:       "panic is reported with stack",
+			handler:    func(http.ResponseWriter, *http.Request) { panic("boom") },
+			wantReport: true,
+			wantStatus: http.StatusInternalServerError,
+			wantPanic:  true,
+		},
+		{
+			name: "5xx response is reported",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			wantReport: true,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name: "4xx response is not reported",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+		},
+		{
+			name:    "implicit 200 is not reported",
+			handler: func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write([]byte("ok")) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter := &stubReporter{}
+			handler := RecoverWithReporter(slog.Default(), reporter)(tt.handler)
+
+			req := httptest.NewRequest(http.MethodGet, "/feeds/secret-token/feed.xml", nil)
+			req = req.WithContext(requestid.WithRequestID(req.Context(), "req-1"))
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if !tt.wantReport {
+				if len(reporter.reports) != 0 {
+					t.Fatalf("want no report, got %+v", reporter.reports)
+				}
+				return
+			}
+			if len(reporter.reports) != 1 {
+				t.Fatalf("want 1 report, got %d", len(reporter.reports))
+			}
+			rep := reporter.reports[0]
+			if rep.RequestID != "req-1" || rep.Status != tt.wantStatus {
+				t.Errorf("report = %+v", rep)
+			}
+			if strings.Contains(rep.Path, "secret-token") {
+				t.Errorf("feed token must be redacted, got %q", rep.Path)
+			}
+			if tt.wantPanic && (rep.Panic == nil || rep.Stack == "") {
+				t.Errorf("panic report must carry value and stack: %+v", rep)
+			}
+		})
+	}
+}
+
+type stubDestination struct {
+	mu   sync.Mutex
+	msgs []notify.Message
+	done chan struct{}
+}
+
+func (s *stubDestination) Name() string { return "stub" }
+
+func (s *stubDestination) Notify(_ context.Context, msg notify.Message) error {
+	s.mu.Lock()
+	s.msgs = append(s.msgs, msg)
+	s.mu.Unlock()
+	s.done <- struct{}{}
+	return errors.New("delivery failures are only logged")
+}
+
+func TestNotifyReporter_Throttle(t *testing.T) {
+	dest := &stubDestination{done: make(chan struct{}, 4)}
+	reporter := NewNotifyReporter([]notify.Destination{dest}, time.Minute, nil)
+
+	base := time.Date(2026, 7, 4, 12, 0, 0, 0, time.UTC)
+	rep := ErrorReport{RequestID: "r", Method: "GET", Path: "/articles", Status: 500}
+	for _, offset := range []time.Duration{0, 10 * time.Second, 20 * time.Second, 2 * time.Minute} {
+		rep.Time = base.Add(offset)
+		reporter.Report(context.Background(), rep)
+	}
+	// 別キー(ステータス違い)は独立して送られる
+	rep.Status, rep.Time = 502, base.Add(30*time.Second)
+	reporter.Report(context.Background(), rep)
+
+	for range 3 {
+		select {
+		case <-dest.done:
+		case <-time.After(time.Second):
+			t.Fatal("report not delivered")
+		}
+	}
+	dest.mu.Lock()
+	defer dest.mu.Unlock()
+	if len(dest.msgs) != 3 {
+		t.Fatalf("want 3 messages, got %d", len(dest.msgs))
+	}
+	var sawSuppressed bool
+	for _, m := range dest.msgs {
+		if strings.Contains(m.Body, "suppressed since last report: 2") {
+			sawSuppressed = true
+		}
+	}
+	if !sawSuppressed {
+		t.Errorf("the message after the window must carry the suppressed count: %+v", dest.msgs)
+	}
+}
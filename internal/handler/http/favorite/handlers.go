@@ -0,0 +1,101 @@
+// Package favorite provides the per-user favorite (star) HTTP handlers
+// (/articles/{id}/favorite), following the flat-path convention (C-21).
+// Starred articles are listed with GET /articles?favorites=true and every
+// article response carries a favorited flag (article package).
+package favorite
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/handler/http/pathutil"
+	"catchup-feed/internal/handler/http/respond"
+	favoriteUC "catchup-feed/internal/usecase/favorite"
+)
+
+// Register registers the favorite routes. Starring only touches the
+// caller's own state, so both routes need just articles:read
+// (auth.RequireScope; admins hold every scope).
+func Register(mux *http.ServeMux, svc *favoriteUC.Service) {
+	read := auth.RequireScope(auth.ScopeArticlesRead)
+
+	mux.Handle("POST /articles/{id}/favorite", read(AddHandler{svc}))
+	mux.Handle("DELETE /articles/{id}/favorite", read(RemoveHandler{svc}))
+}
+
+type AddHandler struct{ Svc *favoriteUC.Service }
+
+// ServeHTTP 記事をお気に入りに追加
+// @Summary      記事をお気に入りに追加
+// @Description  呼び出し元ユーザーのお気に入りに記事を追加します。追加済みでも成功します(冪等)。
+// @Tags         favorites
+// @Security     BearerAuth
+// @Param        id path int true "記事 ID"
+// @Success      204 "追加した"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid ID"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - ユーザーアカウントが必要"
+// @Failure      404 {object} respond.ErrorResponse "Not found - 記事が存在しない"
+// @Router       /articles/{id}/favorite [post]
+func (h AddHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.Svc.Add(r.Context(), auth.SubjectFromContext(r.Context()), id); err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type RemoveHandler struct{ Svc *favoriteUC.Service }
+
+// ServeHTTP 記事をお気に入りから削除
+// @Summary      記事をお気に入りから削除
+// @Description  呼び出し元ユーザーのお気に入りから記事を外します。お気に入りでない記事に対しても成功します(冪等)。
+// @Tags         favorites
+// @Security     BearerAuth
+// @Param        id path int true "記事 ID"
+// @Success      204 "削除した"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid ID"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - ユーザーアカウントが必要"
+// @Router       /articles/{id}/favorite [delete]
+func (h RemoveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.Svc.Remove(r.Context(), auth.SubjectFromContext(r.Context()), id); err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// respondUsecaseError maps use case errors to HTTP statuses: caller
+// without a user account → 403, unknown article → 404, anything else →
+// sanitized 500.
+func respondUsecaseError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, favoriteUC.ErrAccountNotFound):
+		respond.SafeError(w, http.StatusForbidden, err)
+	case errors.Is(err, favoriteUC.ErrArticleNotFound):
+		respond.SafeError(w, http.StatusNotFound, err)
+	default:
+		respond.SafeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+func pathID(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, pathutil.ErrInvalidID
+	}
+	return id, nil
+}
@@ -0,0 +1,97 @@
+package favorite_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/handler/http/favorite"
+	"catchup-feed/internal/repository"
+	favoriteUC "catchup-feed/internal/usecase/favorite"
+)
+
+/* ───────── モック実装 ───────── */
+
+type stubUserRepo struct {
+	repository.UserRepository
+}
+
+func (stubUserRepo) GetActiveByEmail(_ context.Context, email string) (*entity.User, error) {
+	if email == "alice@example.com" {
+		return &entity.User{ID: 7, Email: email}, nil
+	}
+	return nil, nil
+}
+
+type stubFavorites struct {
+	starred map[int64]bool
+}
+
+func (s *stubFavorites) Add(_ context.Context, _, articleID int64) (bool, error) {
+	if articleID >= 100 { // 100 以上は存在しない記事
+		return false, nil
+	}
+	s.starred[articleID] = true
+	return true, nil
+}
+
+func (s *stubFavorites) Remove(_ context.Context, _, articleID int64) error {
+	delete(s.starred, articleID)
+	return nil
+}
+
+func (s *stubFavorites) FavoritedIDs(context.Context, int64, []int64) (map[int64]bool, error) {
+	return s.starred, nil
+}
+
+/* ───────── テストケース ───────── */
+
+func TestRegister_NoRouteConflicts(t *testing.T) {
+	mux := http.NewServeMux()
+	// article パッケージの前方一致ルートと共存できること(登録時に panic しない)。
+	mux.Handle("POST   /articles", http.NotFoundHandler())
+	mux.Handle("DELETE /articles/", http.NotFoundHandler())
+	assert.NotPanics(t, func() {
+		favorite.Register(mux, &favoriteUC.Service{Users: stubUserRepo{}, Favorites: &stubFavorites{}})
+	})
+}
+
+func TestFavoriteHandlers(t *testing.T) {
+	favs := &stubFavorites{starred: map[int64]bool{}}
+	svc := &favoriteUC.Service{Users: stubUserRepo{}, Favorites: favs}
+	// スコープ判定は auth パッケージでテスト済みのため、ハンドラを直接登録する。
+	mux := http.NewServeMux()
+	mux.Handle("POST /articles/{id}/favorite", favorite.AddHandler{Svc: svc})
+	mux.Handle("DELETE /articles/{id}/favorite", favorite.RemoveHandler{Svc: svc})
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		subject  string
+		wantCode int
+	}{
+		{"add", http.MethodPost, "/articles/5/favorite", "alice@example.com", http.StatusNoContent},
+		{"add again", http.MethodPost, "/articles/5/favorite", "alice@example.com", http.StatusNoContent},
+		{"unknown article", http.MethodPost, "/articles/500/favorite", "alice@example.com", http.StatusNotFound},
+		{"invalid id", http.MethodPost, "/articles/0/favorite", "alice@example.com", http.StatusBadRequest},
+		{"api key identity", http.MethodPost, "/articles/5/favorite", "apikey:ci", http.StatusForbidden},
+		{"remove", http.MethodDelete, "/articles/5/favorite", "alice@example.com", http.StatusNoContent},
+		{"remove again", http.MethodDelete, "/articles/5/favorite", "alice@example.com", http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			req = req.WithContext(auth.WithIdentity(req.Context(), tt.subject, auth.RoleViewer))
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+			assert.Equal(t, tt.wantCode, rr.Code, rr.Body.String())
+		})
+	}
+	assert.Empty(t, favs.starred)
+}
@@ -8,16 +8,24 @@ import (
 	"database/sql"
 	"encoding/json"
 	"log"
+	"maps"
 	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"catchup-feed/internal/pkg/buildinfo"
 )
 
 // HealthResponse represents the JSON response for health check endpoints.
 type HealthResponse struct {
-	Status    string                 `json:"status"`    // "healthy" or "unhealthy"
-	Timestamp string                 `json:"timestamp"` // ISO 8601 format
-	Checks    map[string]CheckStatus `json:"checks"`    // Status of each check item
-	Version   string                 `json:"version"`   // Application version
+	Status    string                 `json:"status"`          // "healthy" or "unhealthy"
+	Timestamp string                 `json:"timestamp"`       // ISO 8601 format
+	Checks    map[string]CheckStatus `json:"checks"`          // Status of each check item
+	Version   string                 `json:"version"`         // Application version
+	Build     *buildinfo.Info        `json:"build,omitempty"` // Build metadata (same as GET /version)
 }
 
 // CheckStatus represents the status of a single health check.
@@ -33,16 +41,51 @@ type CSPHealthInfo struct {
 	ReportOnly bool `json:"report_only"` // Whether CSP is in report-only mode
 }
 
+// defaultProbeTimeout bounds one dependency check when its
+// DependencyProbe.Timeout is zero.
+const defaultProbeTimeout = 2 * time.Second
+
+// DependencyProbe is an external dependency HealthHandler checks besides
+// the database, e.g. the AI summarizer or a notification webhook.
+// Dependencies degrade gracefully (§8 縮退許容), so a failing probe marks
+// its own check unhealthy and the overall status "degraded" (still 200).
+type DependencyProbe struct {
+	Name string
+	// Timeout bounds one Check; 0 means defaultProbeTimeout.
+	Timeout time.Duration
+	Check   func(ctx context.Context) error
+}
+
 // HealthHandler handles health check endpoint requests.
 // It performs database connectivity checks and returns detailed health status.
 // It also reports CSP status for operational monitoring.
+//
+// The database and every probe report latency_ms and, once they have
+// succeeded, last_success in their check details.
 type HealthHandler struct {
 	DB      *sql.DB
 	Version string
+	// Build, when set, is reported as the response's build metadata.
+	Build *buildinfo.Info
 
 	// CSP status (optional)
 	CSPEnabled    bool // Whether CSP is enabled
 	CSPReportOnly bool // Whether CSP is in report-only mode
+
+	// WebSocketStats, when set, reports the GET /ws connection counters
+	// (informational: the check is always healthy).
+	WebSocketStats func() any
+
+	// CacheStats, when set, reports the read cache backend and its
+	// hit / miss counters (informational like WebSocketStats).
+	CacheStats func() any
+
+	// Probes are checked concurrently on every request, each under its
+	// own timeout.
+	Probes []DependencyProbe
+
+	mu          sync.Mutex
+	lastSuccess map[string]time.Time
 }
 
 // ServeHTTP performs health checks and returns the application health status.
@@ -70,16 +113,44 @@ func (h *HealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		allHealthy = false
 	}
 
+	// 外部依存(AI・通知 webhook)。失敗しても縮退運転なので degraded。
+	degraded := false
+	for name, check := range h.checkProbes(ctx) {
+		checks[name] = check
+		if check.Status == "unhealthy" {
+			degraded = true
+		}
+	}
+
 	// CSPチェック
 	if h.CSPEnabled {
 		cspCheck := h.checkCSP()
 		checks["csp"] = cspCheck
 	}
 
+	// WebSocket 接続数
+	if h.WebSocketStats != nil {
+		checks["websocket"] = CheckStatus{
+			Status:  "healthy",
+			Details: map[string]interface{}{"stats": h.WebSocketStats()},
+		}
+	}
+
+	// 読み取りキャッシュのヒット率
+	if h.CacheStats != nil {
+		checks["cache"] = CheckStatus{
+			Status:  "healthy",
+			Details: map[string]interface{}{"stats": h.CacheStats()},
+		}
+	}
+
 	// 全体のステータス決定
 	// "degraded" is a warning state, not a failure - system is still operational
 	status := "healthy"
 	statusCode := http.StatusOK
+	if degraded {
+		status = "degraded"
+	}
 	if !allHealthy {
 		status = "unhealthy"
 		statusCode = http.StatusServiceUnavailable
@@ -91,6 +162,7 @@ func (h *HealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 		Checks:    checks,
 		Version:   h.Version,
+		Build:     h.Build,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -101,13 +173,72 @@ func (h *HealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// checkProbes runs the dependency probes concurrently and returns their
+// checks keyed by probe name.
+func (h *HealthHandler) checkProbes(ctx context.Context) map[string]CheckStatus {
+	results := make([]CheckStatus, len(h.Probes))
+	var wg sync.WaitGroup
+	for i, probe := range h.Probes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = h.runProbe(ctx, probe)
+		}()
+	}
+	wg.Wait()
+
+	checks := make(map[string]CheckStatus, len(h.Probes))
+	for i, probe := range h.Probes {
+		checks[probe.Name] = results[i]
+	}
+	return checks
+}
+
+func (h *HealthHandler) runProbe(ctx context.Context, probe DependencyProbe) CheckStatus {
+	timeout := probe.Timeout
+	if timeout <= 0 {
+		timeout = defaultProbeTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := probe.Check(ctx)
+	details := h.timing(probe.Name, start, err == nil)
+	if err != nil {
+		return CheckStatus{Status: "unhealthy", Message: err.Error(), Details: details}
+	}
+	return CheckStatus{Status: "healthy", Details: details}
+}
+
+// timing returns the latency_ms / last_success details of a check that
+// started at start, recording the success time when ok.
+func (h *HealthHandler) timing(name string, start time.Time, ok bool) map[string]interface{} {
+	details := map[string]interface{}{"latency_ms": time.Since(start).Milliseconds()}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if ok {
+		if h.lastSuccess == nil {
+			h.lastSuccess = make(map[string]time.Time)
+		}
+		h.lastSuccess[name] = start
+	}
+	if at, seen := h.lastSuccess[name]; seen {
+		details["last_success"] = at.UTC().Format(time.RFC3339)
+	}
+	return details
+}
+
 // checkDatabase checks database connectivity and returns connection pool statistics.
 func (h *HealthHandler) checkDatabase(ctx context.Context) CheckStatus {
 	// Ping database
+	start := time.Now()
 	if err := h.DB.PingContext(ctx); err != nil {
 		return CheckStatus{
 			Status:  "unhealthy",
 			Message: err.Error(),
+			Details: h.timing("database", start, false),
 		}
 	}
 
@@ -124,6 +255,7 @@ func (h *HealthHandler) checkDatabase(ctx context.Context) CheckStatus {
 		"max_idle_time_closed": stats.MaxIdleTimeClosed,
 		"max_lifetime_closed":  stats.MaxLifetimeClosed,
 	}
+	maps.Copy(details, h.timing("database", start, true))
 
 	// Check connection pool utilization
 	// Guard against zero division when MaxOpenConnections is 0 (unlimited/unconfigured)
@@ -168,15 +300,85 @@ func (h *HealthHandler) checkCSP() CheckStatus {
 	}
 }
 
+// Startup steps the server reports on /startup.
+const (
+	StartupMigrations  = "migrations"   // database schema applied
+	StartupCacheWarmup = "cache_warmup" // read cache pre-filled
+)
+
+// StartupState tracks the one-time steps of a server start. Its zero
+// value has no steps and is complete.
+type StartupState struct {
+	mu      sync.Mutex
+	pending []string
+}
+
+// NewStartupState returns a state waiting for steps.
+func NewStartupState(steps ...string) *StartupState {
+	return &StartupState{pending: slices.Clone(steps)}
+}
+
+// Done marks step finished. Unknown or repeated steps are ignored.
+func (s *StartupState) Done(step string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending = slices.DeleteFunc(s.pending, func(p string) bool { return p == step })
+}
+
+// Pending returns the steps not finished yet, in declaration order.
+func (s *StartupState) Pending() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.pending)
+}
+
+// StartupHandler handles Kubernetes startup probe requests. It answers 503
+// with the pending steps until every startup step is done, then 200 for
+// good: Kubernetes holds off the liveness and readiness probes until the
+// startup probe succeeds, so a long migration does not get the pod killed.
+type StartupHandler struct {
+	State *StartupState
+}
+
+// ServeHTTP returns 200 "started" once startup is complete, or 503
+// "starting: <pending steps>".
+func (h *StartupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if pending := h.State.Pending(); len(pending) > 0 {
+		http.Error(w, "starting: "+strings.Join(pending, ", "), http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("started")); err != nil {
+		log.Printf("startup: failed to write response: %v", err)
+	}
+}
+
 // ReadyHandler handles Kubernetes readiness probe requests.
 // It checks if the database connection is established and ready to accept traffic.
 type ReadyHandler struct {
 	DB *sql.DB
+	// Draining is set once shutdown has begun (internal/pkg/shutdown), so
+	// load balancers stop routing here while in-flight requests finish.
+	Draining *atomic.Bool
+	// Startup, when set, keeps the server not ready until every startup
+	// step is done.
+	Startup *StartupState
 }
 
 // ServeHTTP performs readiness checks and returns 200 OK if ready,
-// or 503 Service Unavailable if the database is not ready.
+// or 503 Service Unavailable if the server is still starting, draining,
+// or the database is not ready.
 func (h *ReadyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.Startup != nil && len(h.Startup.Pending()) > 0 {
+		http.Error(w, "starting", http.StatusServiceUnavailable)
+		return
+	}
+	if h.Draining != nil && h.Draining.Load() {
+		http.Error(w, "shutting down", http.StatusServiceUnavailable)
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
 	defer cancel()
 
@@ -1,16 +1,21 @@
 package http
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/pkg/buildinfo"
 )
 
 func TestHealthHandler_ServeHTTP(t *testing.T) {
@@ -289,6 +294,131 @@ func TestHealthHandler_CacheControl(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestHealthHandler_WebSocketStats(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mock.ExpectPing()
+
+	handler := &HealthHandler{
+		DB:             db,
+		Version:        "test-version",
+		WebSocketStats: func() any { return map[string]int{"connections": 2} },
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	var response HealthResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	check, ok := response.Checks["websocket"]
+	require.True(t, ok)
+	assert.Equal(t, "healthy", check.Status)
+	assert.Equal(t, map[string]interface{}{"connections": float64(2)}, check.Details["stats"])
+}
+
+func TestHealthHandler_CacheStats(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mock.ExpectPing()
+
+	handler := &HealthHandler{
+		DB:         db,
+		Version:    "test-version",
+		CacheStats: func() any { return map[string]any{"backend": "memory", "hits": 3} },
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	var response HealthResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	check, ok := response.Checks["cache"]
+	require.True(t, ok)
+	assert.Equal(t, "healthy", check.Status)
+	assert.Equal(t, map[string]interface{}{"backend": "memory", "hits": float64(3)}, check.Details["stats"])
+}
+
+func TestHealthHandler_Build(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mock.ExpectPing()
+
+	handler := &HealthHandler{
+		DB:      db,
+		Version: "v1.2.3",
+		Build:   &buildinfo.Info{Version: "v1.2.3", GitCommit: "0123abc", GoVersion: "go1.25.1"},
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	var response HealthResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	require.NotNil(t, response.Build)
+	assert.Equal(t, "0123abc", response.Build.GitCommit)
+	assert.Equal(t, "go1.25.1", response.Build.GoVersion)
+}
+
+func TestHealthHandler_Probes(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	failing := false
+	handler := &HealthHandler{
+		DB:      db,
+		Version: "test-version",
+		Probes: []DependencyProbe{
+			{Name: "ai", Check: func(context.Context) error {
+				if failing {
+					return errors.New("connection refused")
+				}
+				return nil
+			}},
+			{Name: "notify_slack", Timeout: 10 * time.Millisecond, Check: func(ctx context.Context) error {
+				<-ctx.Done() // 応答しない webhook
+				return ctx.Err()
+			}},
+		},
+	}
+
+	get := func() HealthResponse {
+		mock.ExpectPing()
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		// 外部依存の失敗は縮退運転(200)。
+		require.Equal(t, http.StatusOK, rec.Code)
+		var response HealthResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+		return response
+	}
+
+	response := get()
+	assert.Equal(t, "degraded", response.Status)
+	assert.Equal(t, "healthy", response.Checks["ai"].Status)
+	assert.Contains(t, response.Checks["ai"].Details, "latency_ms")
+	lastSuccess := response.Checks["ai"].Details["last_success"]
+	assert.NotEmpty(t, lastSuccess)
+	assert.Equal(t, "unhealthy", response.Checks["notify_slack"].Status)
+	assert.Contains(t, response.Checks["notify_slack"].Message, "deadline exceeded")
+	assert.NotContains(t, response.Checks["notify_slack"].Details, "last_success")
+	assert.Contains(t, response.Checks["database"].Details, "latency_ms")
+	assert.Contains(t, response.Checks["database"].Details, "last_success")
+
+	// 失敗しても最後に成功した時刻は残る。
+	failing = true
+	response = get()
+	assert.Equal(t, "unhealthy", response.Checks["ai"].Status)
+	assert.Equal(t, lastSuccess, response.Checks["ai"].Details["last_success"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestReadyHandler_ServeHTTP(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -352,6 +482,69 @@ func TestReadyHandler_NoDatabaseConfigured(t *testing.T) {
 	assert.Contains(t, rec.Body.String(), "database not configured")
 }
 
+// While draining, /ready fails without touching the database.
+func TestReadyHandler_Draining(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	draining := &atomic.Bool{}
+	draining.Store(true)
+	handler := &ReadyHandler{DB: db, Draining: draining}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), "shutting down")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReadyHandler_Starting(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	startup := NewStartupState(StartupMigrations, StartupCacheWarmup)
+	handler := &ReadyHandler{DB: db, Startup: startup}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), "starting")
+
+	startup.Done(StartupMigrations)
+	startup.Done(StartupCacheWarmup)
+	mock.ExpectPing()
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStartupHandler_ServeHTTP(t *testing.T) {
+	startup := NewStartupState(StartupMigrations, StartupCacheWarmup)
+	handler := &StartupHandler{State: startup}
+	get := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/startup", nil))
+		return rec
+	}
+
+	rec := get()
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), "starting: migrations, cache_warmup")
+
+	startup.Done(StartupMigrations)
+	startup.Done(StartupMigrations) // 重複は無視
+	rec = get()
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), "starting: cache_warmup")
+
+	startup.Done(StartupCacheWarmup)
+	rec = get()
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "started", rec.Body.String())
+}
+
 func TestReadyHandler_Timeout(t *testing.T) {
 	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
 	require.NoError(t, err)
@@ -0,0 +1,100 @@
+// Package loglevel provides the runtime log level API: the administrator
+// can switch the server's slog level (debug / info / warn / error) while
+// chasing an incident, without a restart. All routes are admin-only.
+package loglevel
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/handler/http/respond"
+	"catchup-feed/internal/pkg/logging"
+)
+
+// DTO is the current level, in the same names ParseLevel accepts.
+type DTO struct {
+	Level string `json:"level" example:"info"`
+}
+
+// UpdateRequest is the PUT /log-level body.
+type UpdateRequest struct {
+	Level string `json:"level" example:"debug"`
+}
+
+func current() DTO {
+	return DTO{Level: strings.ToLower(logging.Level().String())}
+}
+
+type GetHandler struct{}
+
+// ServeHTTP ログレベル取得
+// @Summary      ログレベル取得
+// @Description  server プロセスの現在のログレベル(debug / info / warn / error)を返します。admin 専用
+// @Tags         log-level
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} DTO "現在のログレベル"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Router       /log-level [get]
+func (GetHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	respond.JSON(w, http.StatusOK, current())
+}
+
+type UpdateHandler struct {
+	Logger *slog.Logger
+}
+
+// ServeHTTP ログレベル変更
+// @Summary      ログレベル変更
+// @Description  server プロセスのログレベルを再起動なしで切り替えます。変更はプロセス再起動で
+// @Description  LOG_LEVEL の値に戻ります。admin 専用
+// @Tags         log-level
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        level body UpdateRequest true "新しいログレベル(debug / info / warn / error)"
+// @Success      200 {object} DTO "変更後のログレベル"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - level が不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Router       /log-level [put]
+func (h UpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req UpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	level, err := logging.ParseLevel(req.Level)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	previous := logging.Level()
+	logging.SetLevel(level)
+	// Logged at Warn so the change itself is visible at any level.
+	h.logger().WarnContext(r.Context(), "log level changed",
+		slog.String("from", strings.ToLower(previous.String())),
+		slog.String("to", strings.ToLower(level.String())))
+
+	respond.JSON(w, http.StatusOK, current())
+}
+
+func (h UpdateHandler) logger() *slog.Logger {
+	if h.Logger != nil {
+		return h.Logger
+	}
+	return slog.Default()
+}
+
+// Register registers the log level routes (C-21 flat paths). Both are
+// wrapped in auth.Authz: changing the level is an operator action, and
+// the outer viewer allowlist independently keeps viewers out.
+func Register(mux *http.ServeMux, logger *slog.Logger) {
+	mux.Handle("GET /log-level", auth.Authz(GetHandler{}))
+	mux.Handle("PUT /log-level", auth.Authz(UpdateHandler{Logger: logger}))
+}
@@ -0,0 +1,69 @@
+package loglevel_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/handler/http/loglevel"
+	"catchup-feed/internal/pkg/logging"
+)
+
+func newMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	// 認可ミドルウェアなしで Register と同じパターンを張る。
+	mux.Handle("GET /log-level", loglevel.GetHandler{})
+	mux.Handle("PUT /log-level", loglevel.UpdateHandler{})
+	return mux
+}
+
+func TestUpdateHandler(t *testing.T) {
+	prev := logging.Level()
+	t.Cleanup(func() { logging.SetLevel(prev) })
+
+	tests := []struct {
+		name      string
+		body      string
+		wantCode  int
+		wantLevel slog.Level
+	}{
+		{name: "switch to debug", body: `{"level":"debug"}`, wantCode: http.StatusOK, wantLevel: slog.LevelDebug},
+		{name: "switch to warn (case-insensitive)", body: `{"level":"WARN"}`, wantCode: http.StatusOK, wantLevel: slog.LevelWarn},
+		{name: "unknown level", body: `{"level":"trace"}`, wantCode: http.StatusBadRequest, wantLevel: slog.LevelInfo},
+		{name: "invalid json", body: `{not json`, wantCode: http.StatusBadRequest, wantLevel: slog.LevelInfo},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logging.SetLevel(slog.LevelInfo)
+
+			rec := httptest.NewRecorder()
+			newMux().ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/log-level", strings.NewReader(tt.body)))
+			require.Equal(t, tt.wantCode, rec.Code)
+			assert.Equal(t, tt.wantLevel, logging.Level())
+
+			if tt.wantCode == http.StatusOK {
+				var got loglevel.DTO
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+				assert.Equal(t, strings.ToLower(tt.wantLevel.String()), got.Level)
+			}
+		})
+	}
+}
+
+func TestGetHandler(t *testing.T) {
+	prev := logging.Level()
+	t.Cleanup(func() { logging.SetLevel(prev) })
+	logging.SetLevel(slog.LevelError)
+
+	rec := httptest.NewRecorder()
+	newMux().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/log-level", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"level":"error"}`, rec.Body.String())
+}
@@ -0,0 +1,151 @@
+package mfa
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/handler/http/respond"
+	mfaUC "catchup-feed/internal/usecase/mfa"
+)
+
+// StatusResponse is the GET /auth/mfa body.
+type StatusResponse struct {
+	Enabled bool `json:"enabled" example:"true"`
+}
+
+// EnrollResponse carries a new TOTP secret. It is shown once: the secret
+// for manual entry and the otpauth:// URI the client renders as a QR code.
+type EnrollResponse struct {
+	Secret string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	URI    string `json:"otpauth_uri" example:"otpauth://totp/catchup-feed:admin@example.com?secret=...&issuer=catchup-feed"`
+}
+
+// CodeRequest carries a 6-digit code from the authenticator app.
+type CodeRequest struct {
+	Code string `json:"code" example:"123456"`
+}
+
+// respondUsecaseError maps use case sentinel errors to HTTP statuses:
+// non-admin → 403, missing account / enrollment → 404, already enabled →
+// 409, wrong code → 400, anything else → sanitized 500.
+func respondUsecaseError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, mfaUC.ErrAdminOnly):
+		respond.SafeError(w, http.StatusForbidden, err)
+	case errors.Is(err, mfaUC.ErrAccountNotFound),
+		errors.Is(err, mfaUC.ErrNotEnrolled):
+		respond.SafeError(w, http.StatusNotFound, err)
+	case errors.Is(err, mfaUC.ErrAlreadyEnabled):
+		respond.SafeError(w, http.StatusConflict, err)
+	case errors.Is(err, mfaUC.ErrInvalidCode):
+		respond.SafeError(w, http.StatusBadRequest, err)
+	default:
+		respond.SafeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+type StatusHandler struct{ Svc *mfaUC.Service }
+
+// ServeHTTP MFA 状態取得
+// @Summary      MFA 状態取得
+// @Description  ログイン中の admin の多要素認証(TOTP)が有効かを返します。admin 専用
+// @Tags         auth
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} StatusResponse "MFA の有効・無効"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Router       /auth/mfa [get]
+func (h StatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	enabled, err := h.Svc.Required(r.Context(), auth.SubjectFromContext(r.Context()))
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, StatusResponse{Enabled: enabled})
+}
+
+type EnrollHandler struct{ Svc *mfaUC.Service }
+
+// ServeHTTP MFA 登録開始
+// @Summary      MFA 登録開始
+// @Description  TOTP の秘密鍵を生成し、認証アプリ用の otpauth:// URI(QR コード化して表示する)とともに返します。
+// @Description  この時点では未有効で、/auth/mfa/confirm に最初のコードを送ると有効になります。
+// @Description  有効化済みの場合は先に無効化が必要です(409)。admin 専用
+// @Tags         auth
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} EnrollResponse "秘密鍵と provisioning URI(この応答でのみ表示)"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      409 {object} respond.ErrorResponse "Conflict - MFA は有効化済み"
+// @Router       /auth/mfa/enroll [post]
+func (h EnrollHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	enrollment, err := h.Svc.Enroll(r.Context(), auth.SubjectFromContext(r.Context()))
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	respond.JSON(w, http.StatusOK, EnrollResponse{Secret: enrollment.Secret, URI: enrollment.URI})
+}
+
+type ConfirmHandler struct{ Svc *mfaUC.Service }
+
+// ServeHTTP MFA 有効化
+// @Summary      MFA 有効化
+// @Description  認証アプリが表示する6桁コードで登録を確認し、MFA を有効にします。
+// @Description  以降 /auth/token はパスワードに加えて /auth/token/mfa でのコード入力を求めます。admin 専用
+// @Tags         auth
+// @Security     BearerAuth
+// @Accept       json
+// @Param        request body CodeRequest true "6桁コード"
+// @Success      204 "有効化成功"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - コードが不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      404 {object} respond.ErrorResponse "Not found - 登録が開始されていない"
+// @Failure      409 {object} respond.ErrorResponse "Conflict - MFA は有効化済み"
+// @Router       /auth/mfa/confirm [post]
+func (h ConfirmHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req CodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.Svc.Confirm(r.Context(), auth.SubjectFromContext(r.Context()), req.Code); err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type DisableHandler struct{ Svc *mfaUC.Service }
+
+// ServeHTTP MFA 無効化
+// @Summary      MFA 無効化
+// @Description  現在の6桁コードを確認して MFA を無効にします(セッションの乗っ取りだけでは外せない)。admin 専用
+// @Tags         auth
+// @Security     BearerAuth
+// @Accept       json
+// @Param        request body CodeRequest true "6桁コード"
+// @Success      204 "無効化成功"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - コードが不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      404 {object} respond.ErrorResponse "Not found - MFA は無効"
+// @Router       /auth/mfa/disable [post]
+func (h DisableHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req CodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.Svc.Disable(r.Context(), auth.SubjectFromContext(r.Context()), req.Code); err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,132 @@
+package mfa_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/handler/http/mfa"
+	mfaUC "catchup-feed/internal/usecase/mfa"
+)
+
+/* ───────── モック実装 ───────── */
+
+type stubUserRepo struct {
+	users []*entity.User
+}
+
+func (s *stubUserRepo) Create(context.Context, *entity.User) error { return nil }
+func (s *stubUserRepo) Get(context.Context, int64) (*entity.User, error) {
+	return nil, nil
+}
+func (s *stubUserRepo) List(context.Context) ([]*entity.User, error) { return s.users, nil }
+func (s *stubUserRepo) Update(context.Context, *entity.User) error   { return nil }
+func (s *stubUserRepo) Deactivate(context.Context, int64, time.Time) error {
+	return nil
+}
+func (s *stubUserRepo) Reactivate(context.Context, int64) error { return nil }
+func (s *stubUserRepo) Delete(context.Context, int64) error     { return nil }
+func (s *stubUserRepo) CountActiveAdmins(context.Context) (int, error) {
+	return 1, nil
+}
+
+func (s *stubUserRepo) GetActiveByEmail(_ context.Context, email string) (*entity.User, error) {
+	for _, u := range s.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+type stubMFARepo struct {
+	rows map[int64]*entity.UserMFA
+}
+
+func (s *stubMFARepo) Get(_ context.Context, userID int64) (*entity.UserMFA, error) {
+	return s.rows[userID], nil
+}
+
+func (s *stubMFARepo) SavePending(_ context.Context, userID int64, secret string) (bool, error) {
+	if m, ok := s.rows[userID]; ok && m.IsEnabled() {
+		return false, nil
+	}
+	s.rows[userID] = &entity.UserMFA{UserID: userID, Secret: secret}
+	return true, nil
+}
+
+func (s *stubMFARepo) Enable(_ context.Context, userID, step int64, t time.Time) error {
+	m := s.rows[userID]
+	m.EnabledAt, m.LastStep = &t, step
+	return nil
+}
+
+func (s *stubMFARepo) UseStep(_ context.Context, userID, step int64) (bool, error) {
+	m := s.rows[userID]
+	if m.LastStep >= step {
+		return false, nil
+	}
+	m.LastStep = step
+	return true, nil
+}
+
+func (s *stubMFARepo) Delete(_ context.Context, userID int64) error {
+	delete(s.rows, userID)
+	return nil
+}
+
+/* ───────── テストケース ───────── */
+
+func serve(h http.Handler, method, path, sub, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req = req.WithContext(auth.WithIdentity(req.Context(), sub, auth.RoleAdmin))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestEnrollmentFlow(t *testing.T) {
+	const admin = "admin@example.com"
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc := &mfaUC.Service{
+		Users: &stubUserRepo{users: []*entity.User{
+			{ID: 1, Email: admin, Role: auth.RoleAdmin},
+			{ID: 2, Email: "friend@example.com", Role: auth.RoleViewer},
+		}},
+		MFA: &stubMFARepo{rows: map[int64]*entity.UserMFA{}},
+		Now: func() time.Time { return now },
+	}
+
+	rec := serve(mfa.EnrollHandler{Svc: svc}, http.MethodPost, "/auth/mfa/enroll", admin, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+	var enrollment mfa.EnrollResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &enrollment))
+	assert.True(t, strings.HasPrefix(enrollment.URI, "otpauth://totp/"))
+
+	rec = serve(mfa.ConfirmHandler{Svc: svc}, http.MethodPost, "/auth/mfa/confirm", admin, `{"code":"000000"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	code, err := entity.TOTPCode(enrollment.Secret, entity.TOTPStep(now))
+	require.NoError(t, err)
+	rec = serve(mfa.ConfirmHandler{Svc: svc}, http.MethodPost, "/auth/mfa/confirm", admin, `{"code":"`+code+`"}`)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = serve(mfa.StatusHandler{Svc: svc}, http.MethodGet, "/auth/mfa", admin, "")
+	assert.JSONEq(t, `{"enabled":true}`, rec.Body.String())
+
+	rec = serve(mfa.EnrollHandler{Svc: svc}, http.MethodPost, "/auth/mfa/enroll", admin, "")
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = serve(mfa.EnrollHandler{Svc: svc}, http.MethodPost, "/auth/mfa/enroll", "friend@example.com", "")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
@@ -0,0 +1,22 @@
+// Package mfa provides the TOTP enrollment HTTP handlers for the
+// authenticated admin (C-21 flat paths under /auth/mfa). The second login
+// step itself (POST /auth/token/mfa) is public and lives in the auth
+// package next to /auth/token.
+package mfa
+
+import (
+	"net/http"
+
+	"catchup-feed/internal/handler/http/auth"
+	mfaUC "catchup-feed/internal/usecase/mfa"
+)
+
+// Register registers the MFA enrollment routes. Every route is wrapped in
+// auth.Authz: MFA is offered to admins only, and each admin manages only
+// their own enrollment (the JWT subject).
+func Register(mux *http.ServeMux, svc *mfaUC.Service) {
+	mux.Handle("GET /auth/mfa", auth.Authz(StatusHandler{svc}))
+	mux.Handle("POST /auth/mfa/enroll", auth.Authz(EnrollHandler{svc}))
+	mux.Handle("POST /auth/mfa/confirm", auth.Authz(ConfirmHandler{svc}))
+	mux.Handle("POST /auth/mfa/disable", auth.Authz(DisableHandler{svc}))
+}
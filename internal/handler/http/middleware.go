@@ -26,15 +26,12 @@ func Logging(logger *slog.Logger) func(http.Handler) http.Handler {
 			// Process request
 			next.ServeHTTP(wrapped, r)
 
-			// Extract request ID
-			reqID := requestid.FromContext(r.Context())
-
 			// Calculate processing duration
 			duration := time.Since(start)
 
-			// Log request completion with structured fields
-			logger.Info("request completed",
-				slog.String("request_id", reqID),
+			// Log request completion with structured fields.
+			// request_id comes from the context (logging.ContextHandler).
+			logger.InfoContext(r.Context(), "request completed",
 				slog.String("method", r.Method),
 				slog.String("path", pathutil.RedactPath(r.URL.Path)),
 				slog.String("query", r.URL.RawQuery),
@@ -52,13 +49,24 @@ func Logging(logger *slog.Logger) func(http.Handler) http.Handler {
 // Recover returns middleware that catches panics and logs them with structured logging.
 // It prevents the server from crashing and returns a 500 Internal Server Error response.
 func Recover(logger *slog.Logger) func(http.Handler) http.Handler {
+	return RecoverWithReporter(logger, nil)
+}
+
+// RecoverWithReporter is Recover plus an ErrorReporter: recovered panics
+// and handler responses with a 5xx status are also handed to reporter
+// with the request_id and, for panics, the stack trace. A nil reporter
+// behaves exactly like Recover.
+func RecoverWithReporter(logger *slog.Logger, reporter ErrorReporter) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// 5xx を検出するためにステータスを記録する(reporter 未設定時は素通し)
+			var wrapped *responsewriter.ResponseWriter
+			if reporter != nil {
+				wrapped = responsewriter.Wrap(w)
+				w = wrapped
+			}
 			defer func() {
 				if rec := recover(); rec != nil {
-					// リクエストID を取得
-					reqID := requestid.FromContext(r.Context())
-
 					// スタックトレースを取得
 					stack := string(debug.Stack())
 
@@ -69,14 +77,21 @@ func Recover(logger *slog.Logger) func(http.Handler) http.Handler {
 						fmt.Errorf("internal error"),
 					)
 
-					// 構造化ログで記録
-					logger.Error("panic recovered",
-						slog.String("request_id", reqID),
+					// 構造化ログで記録(request_id は context 経由で付与される)
+					logger.ErrorContext(r.Context(), "panic recovered",
 						slog.String("method", r.Method),
 						slog.String("path", pathutil.RedactPath(r.URL.Path)),
 						slog.Any("panic", rec),
 						slog.String("stack", stack),
 					)
+
+					if reporter != nil {
+						reporter.Report(r.Context(), newErrorReport(r, http.StatusInternalServerError, rec, stack))
+					}
+					return
+				}
+				if wrapped != nil && wrapped.StatusCode() >= http.StatusInternalServerError {
+					reporter.Report(r.Context(), newErrorReport(r, wrapped.StatusCode(), nil, ""))
 				}
 			}()
 			next.ServeHTTP(w, r)
@@ -84,6 +99,18 @@ func Recover(logger *slog.Logger) func(http.Handler) http.Handler {
 	}
 }
 
+func newErrorReport(r *http.Request, status int, panicValue any, stack string) ErrorReport {
+	return ErrorReport{
+		RequestID: requestid.FromContext(r.Context()),
+		Method:    r.Method,
+		Path:      pathutil.RedactPath(r.URL.Path),
+		Status:    status,
+		Panic:     panicValue,
+		Stack:     stack,
+		Time:      time.Now(),
+	}
+}
+
 // LimitRequestBody returns middleware that limits the size of request bodies to prevent DoS attacks.
 func LimitRequestBody(maxBytes int64) func(http.Handler) http.Handler {
 	return LimitRequestBodyPerRoute(maxBytes, nil)
@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"catchup-feed/internal/handler/http/pathutil"
+	"catchup-feed/internal/handler/http/respond"
+)
+
+// Double-submit CSRF cookie and header. The cookie is readable by the
+// frontend's JavaScript (not HttpOnly) so it can echo the value in the
+// header; a cross-site page can make the browser send the cookie but
+// cannot read it.
+const (
+	CSRFCookieName = "catchup_feed_csrf_token"
+	CSRFHeaderName = "X-CSRF-Token"
+)
+
+// DefaultCSRFExemptPaths are the token issuance endpoints. They are
+// called before the client holds a session (or to replace a dead one), so
+// there is no session to ride on and no CSRF token to echo yet.
+var DefaultCSRFExemptPaths = []string{
+	"/auth/token",
+	"/auth/token/mfa",
+	"/auth/oidc",
+	"/auth/refresh",
+	"/auth/logout",
+	"/auth/revoke",
+}
+
+var errCSRFTokenMismatch = errors.New("forbidden: missing or invalid CSRF token")
+
+// CSRFConfig configures the CSRF middleware.
+type CSRFConfig struct {
+	// SessionCookie is the cookie that authenticates browser requests (the
+	// auth cookie). Only unsafe requests carrying it are checked: Bearer
+	// and API key clients send nothing a browser attaches on its own.
+	SessionCookie string
+
+	// ExemptPaths are never checked, in addition to
+	// DefaultCSRFExemptPaths. A trailing '/' matches the subtree.
+	ExemptPaths []string
+
+	// CookieDomain is the Domain attribute of the CSRF cookie (empty
+	// omits it, scoping the cookie to the response host).
+	CookieDomain string
+
+	// Logger records rejected requests; nil means slog.Default().
+	Logger *slog.Logger
+}
+
+// CSRF returns a double-submit cookie middleware. Every response to a
+// client without a CSRF cookie sets one (catchup_feed_csrf_token); POST /
+// PUT / PATCH / DELETE requests that carry the session cookie must send
+// the same value in X-CSRF-Token, or they are rejected with 403.
+//
+// It sits after CORS (preflights never reach it) and before the routes, so
+// a rejected request does no work.
+func CSRF(cfg CSRFConfig) func(http.Handler) http.Handler {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	exempt := append(append([]string(nil), DefaultCSRFExemptPaths...), cfg.ExemptPaths...)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := ""
+			if c, err := r.Cookie(CSRFCookieName); err == nil {
+				token = c.Value
+			}
+			if token == "" {
+				token = rand.Text()
+				http.SetCookie(w, newCSRFCookie(token, cfg.CookieDomain))
+			}
+
+			if csrfSafeMethod(r.Method) || !hasCookie(r, cfg.SessionCookie) || csrfExempt(exempt, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			header := r.Header.Get(CSRFHeaderName)
+			if header == "" || subtle.ConstantTimeCompare([]byte(header), []byte(token)) != 1 {
+				logger.Warn("CSRF token rejected",
+					slog.String("method", r.Method),
+					slog.String("path", pathutil.RedactPath(r.URL.Path)),
+					slog.Bool("header_present", header != ""))
+				respond.SafeError(w, http.StatusForbidden, errCSRFTokenMismatch)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// newCSRFCookie builds the CSRF cookie. Secure / SameSite=Strict like the
+// auth cookie, but not HttpOnly: the frontend must read it.
+func newCSRFCookie(value, domain string) *http.Cookie {
+	return &http.Cookie{
+		Name:     CSRFCookieName,
+		Value:    value,
+		Path:     "/",
+		Domain:   domain,
+		HttpOnly: false, // #nosec G124 -- read by the frontend for the double submit
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+	}
+}
+
+func csrfSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+func hasCookie(r *http.Request, name string) bool {
+	c, err := r.Cookie(name)
+	return err == nil && c.Value != ""
+}
+
+// csrfExempt matches path against the exempt list: exact (or with a
+// trailing slash), or by prefix for entries ending in '/'.
+func csrfExempt(exempt []string, path string) bool {
+	for _, p := range exempt {
+		if strings.HasSuffix(p, "/") {
+			if strings.HasPrefix(path, p) {
+				return true
+			}
+			continue
+		}
+		if path == p || path == p+"/" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testSessionCookie = "session"
+
+func newCSRFHandler(cfg CSRFConfig) http.Handler {
+	cfg.SessionCookie = testSessionCookie
+	return CSRF(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+}
+
+// csrfRequest builds a request with the given session / CSRF cookies and
+// X-CSRF-Token header (empty values are omitted).
+func csrfRequest(method, path, session, cookie, header string) *http.Request {
+	req := httptest.NewRequest(method, path, nil)
+	if session != "" {
+		req.AddCookie(&http.Cookie{Name: testSessionCookie, Value: session})
+	}
+	if cookie != "" {
+		req.AddCookie(&http.Cookie{Name: CSRFCookieName, Value: cookie})
+	}
+	if header != "" {
+		req.Header.Set(CSRFHeaderName, header)
+	}
+	return req
+}
+
+// TestCSRF_Check covers which requests must echo the CSRF cookie
+func TestCSRF_Check(t *testing.T) {
+	handler := newCSRFHandler(CSRFConfig{ExemptPaths: []string{"/hooks/"}})
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		want int
+	}{
+		{"safe method", csrfRequest(http.MethodGet, "/articles", "jwt", "", ""), http.StatusNoContent},
+		{"matching token", csrfRequest(http.MethodPost, "/sources", "jwt", "tok", "tok"), http.StatusNoContent},
+		{"missing header", csrfRequest(http.MethodPost, "/sources", "jwt", "tok", ""), http.StatusForbidden},
+		{"mismatched header", csrfRequest(http.MethodDelete, "/sources/1", "jwt", "tok", "other"), http.StatusForbidden},
+		{"no CSRF cookie", csrfRequest(http.MethodPut, "/sources/1", "jwt", "", "guess"), http.StatusForbidden},
+		{"bearer client (no session cookie)", csrfRequest(http.MethodPost, "/sources", "", "", ""), http.StatusNoContent},
+		{"token issuance exempt", csrfRequest(http.MethodPost, "/auth/token", "jwt", "", ""), http.StatusNoContent},
+		{"refresh exempt", csrfRequest(http.MethodPost, "/auth/refresh/", "jwt", "", ""), http.StatusNoContent},
+		{"configured prefix exempt", csrfRequest(http.MethodPost, "/hooks/github", "jwt", "", ""), http.StatusNoContent},
+		{"exempt path is exact", csrfRequest(http.MethodPost, "/auth/tokens", "jwt", "tok", ""), http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, tt.req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+// TestCSRF_IssuesCookie tests that a client without a CSRF cookie gets one
+func TestCSRF_IssuesCookie(t *testing.T) {
+	handler := newCSRFHandler(CSRFConfig{CookieDomain: "example.com"})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, csrfRequest(http.MethodGet, "/articles", "", "", ""))
+
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != CSRFCookieName {
+		t.Fatalf("cookies = %v, want one %s", cookies, CSRFCookieName)
+	}
+	c := cookies[0]
+	if len(c.Value) < 26 || c.HttpOnly || !c.Secure || c.SameSite != http.SameSiteStrictMode || c.Path != "/" || c.Domain != "example.com" {
+		t.Errorf("cookie = %+v, want a readable Secure SameSite=Strict token for example.com", c)
+	}
+
+	// The issued token passes the check on the next request
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, csrfRequest(http.MethodPost, "/sources", "jwt", c.Value, c.Value))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if len(rec.Result().Cookies()) != 0 {
+		t.Error("expected no new cookie when the client already has one")
+	}
+}
@@ -1,11 +1,15 @@
 package middleware
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"catchup-feed/internal/domain/entity"
 	"catchup-feed/internal/handler/http/pathutil"
 )
 
@@ -27,6 +31,43 @@ type RateLimiter struct {
 
 	// requests stores request timestamps for each IP address
 	requests map[string][]time.Time
+
+	// denials counts rejected requests per minute for each IP address, for
+	// the admin inspection API (TopDenied)
+	denials map[string]denialCounter
+
+	// store, when set, replaces the in-process requests map so that several
+	// server instances share one budget per client (RATE_LIMIT_STORE).
+	store RateLimitStore
+
+	// scope namespaces this limiter's keys inside a shared store
+	// (e.g. "auth", "search", "feed").
+	scope string
+
+	// headers selects the quota headers written on each response
+	// (RATE_LIMIT_HEADERS); the zero value writes none.
+	headers RateLimitHeaderMode
+}
+
+// RateLimitStore is a shared sliding-window backend for RateLimiter.
+// The default (nil store) keeps the windows in process memory, which is
+// exact for the single-instance Pi deployment; a shared store is only
+// needed when more than one server instance answers the same clients.
+type RateLimitStore interface {
+	// Allow records one request for key and reports whether it is within
+	// limit requests per window. A denied request does not count against
+	// the window; it is recorded as a denial for TopDenied.
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, error)
+	// Cleanup removes expired entries.
+	Cleanup(ctx context.Context) error
+	// Count returns key's allowed requests inside window without
+	// recording one.
+	Count(ctx context.Context, key string, window time.Duration) (int, error)
+	// Reset forgets key's requests and denials, lifting its limit at once.
+	Reset(ctx context.Context, key string) error
+	// TopDenied returns up to n keys starting with prefix that were denied
+	// inside window, most denials first.
+	TopDenied(ctx context.Context, prefix string, window time.Duration, n int) ([]entity.RateLimitDenial, error)
 }
 
 // NewRateLimiter creates a new RateLimiter with the specified parameters.
@@ -50,9 +91,24 @@ func NewRateLimiter(limit int, window time.Duration, ipExtractor IPExtractor) *R
 		window:      window,
 		ipExtractor: ipExtractor,
 		requests:    make(map[string][]time.Time),
+		denials:     make(map[string]denialCounter),
 	}
 }
 
+// NewRateLimiterWithStore creates a RateLimiter whose windows live in store
+// under keys "<scope>:<ip>". A nil store falls back to the in-memory
+// windows of NewRateLimiter.
+//
+// If the store fails (e.g. the database is unreachable) the request is
+// allowed and a warning is logged: a rate limiter outage must not take
+// the API down with it.
+func NewRateLimiterWithStore(scope string, limit int, window time.Duration, ipExtractor IPExtractor, store RateLimitStore) *RateLimiter {
+	rl := NewRateLimiter(limit, window, ipExtractor)
+	rl.scope = scope
+	rl.store = store
+	return rl
+}
+
 // Middleware returns an HTTP middleware handler that enforces rate limiting.
 // It extracts the client IP using the configured IPExtractor and checks if
 // the request count is within the allowed limit for the time window.
@@ -88,7 +144,9 @@ func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 		}
 
 		// Check rate limit for this IP
-		if !rl.allow(ip) {
+		q := rl.check(r.Context(), ip)
+		rl.writeHeaders(w, q)
+		if !q.allowed {
 			// The path is redacted: this limiter fronts the public feed
 			// routes, so the exceeded path may embed a plaintext feed
 			// token (D-5) — precisely during invalid-token hammering.
@@ -107,6 +165,34 @@ func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 	})
 }
 
+// check dispatches to the shared store when configured, otherwise to the
+// in-memory window. Store errors fail open (reported as a full quota).
+//
+// The store only answers allowed/denied, so with quota headers enabled the
+// remaining count costs one extra Count call, and reset is reported as the
+// whole window — an upper bound, which is the safe side for clients.
+func (rl *RateLimiter) check(ctx context.Context, ip string) quota {
+	if rl.store == nil {
+		return rl.allowQuota(ip)
+	}
+	key := rl.scope + ":" + ip
+	allowed, err := rl.store.Allow(ctx, key, rl.limit, rl.window)
+	if err != nil {
+		slog.Warn("rate limiter: store unavailable, allowing request",
+			slog.String("scope", rl.scope),
+			slog.String("error", err.Error()),
+		)
+		return quota{allowed: true, remaining: rl.limit, reset: rl.window}
+	}
+	q := quota{allowed: allowed, reset: rl.window}
+	if rl.headers != "" && rl.headers != RateLimitHeadersNone {
+		if count, err := rl.store.Count(ctx, key, rl.window); err == nil {
+			q.remaining = max(rl.limit-count, 0)
+		}
+	}
+	return q
+}
+
 // allow checks if a request from the given IP is allowed based on the rate limit.
 // It implements a sliding window algorithm:
 // 1. Remove timestamps older than the time window
@@ -115,6 +201,12 @@ func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 //
 // This method is thread-safe using read-write locks for performance.
 func (rl *RateLimiter) allow(ip string) bool {
+	return rl.allowQuota(ip).allowed
+}
+
+// allowQuota is allow, also reporting the remaining requests and the time
+// until the oldest request in the window expires.
+func (rl *RateLimiter) allowQuota(ip string) quota {
 	now := time.Now()
 	cutoff := now.Add(-rl.window)
 
@@ -136,14 +228,23 @@ func (rl *RateLimiter) allow(ip string) bool {
 	if len(validTimestamps) >= rl.limit {
 		// Update the map with cleaned timestamps (don't add new request)
 		rl.requests[ip] = validTimestamps
-		return false
+		rl.denials[ip] = rl.denials[ip].add(now, rl.window)
+		q := quota{reset: rl.window}
+		if len(validTimestamps) > 0 {
+			q.reset = validTimestamps[0].Add(rl.window).Sub(now)
+		}
+		return q
 	}
 
 	// Add current request timestamp
 	validTimestamps = append(validTimestamps, now)
 	rl.requests[ip] = validTimestamps
 
-	return true
+	return quota{
+		allowed:   true,
+		remaining: rl.limit - len(validTimestamps),
+		reset:     validTimestamps[0].Add(rl.window).Sub(now),
+	}
 }
 
 // CleanupExpired removes all expired timestamps from all IPs.
@@ -160,6 +261,18 @@ func (rl *RateLimiter) allow(ip string) bool {
 //	    }
 //	}()
 func (rl *RateLimiter) CleanupExpired() {
+	if rl.store != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := rl.store.Cleanup(ctx); err != nil {
+			slog.Warn("rate limiter: store cleanup failed",
+				slog.String("scope", rl.scope),
+				slog.String("error", err.Error()),
+			)
+		}
+		return
+	}
+
 	now := time.Now()
 	cutoff := now.Add(-rl.window)
 
@@ -183,8 +296,142 @@ func (rl *RateLimiter) CleanupExpired() {
 			rl.requests[ip] = validTimestamps
 		}
 	}
+	for ip, counter := range rl.denials {
+		if live := counter.since(now, rl.window); len(live) == 0 {
+			delete(rl.denials, ip)
+		} else {
+			rl.denials[ip] = live
+		}
+	}
 
 	slog.Debug("rate limiter: cleanup completed",
 		slog.Int("active_ips", len(rl.requests)),
 	)
 }
+
+// Scope returns the limiter's scope ("" for NewRateLimiter limiters).
+func (rl *RateLimiter) Scope() string { return rl.scope }
+
+// Limit returns the maximum number of requests per IP within Window.
+func (rl *RateLimiter) Limit() int { return rl.limit }
+
+// Window returns the sliding window length.
+func (rl *RateLimiter) Window() time.Duration { return rl.window }
+
+// Count returns the requests ip made inside the current window, without
+// recording one.
+func (rl *RateLimiter) Count(ctx context.Context, ip string) (int, error) {
+	if rl.store != nil {
+		return rl.store.Count(ctx, rl.scope+":"+ip, rl.window)
+	}
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+	return len(after(rl.requests[ip], time.Now().Add(-rl.window))), nil
+}
+
+// Reset clears ip's window so its next request is allowed.
+func (rl *RateLimiter) Reset(ctx context.Context, ip string) error {
+	if rl.store != nil {
+		return rl.store.Reset(ctx, rl.scope+":"+ip)
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	delete(rl.requests, ip)
+	delete(rl.denials, ip)
+	return nil
+}
+
+// TopDenied returns up to n IPs denied inside the current window, most
+// denials first. As with the shared stores, denials are counted per
+// minute, so the window is rounded down to the minute.
+func (rl *RateLimiter) TopDenied(ctx context.Context, n int) ([]entity.RateLimitDenial, error) {
+	if rl.store != nil {
+		prefix := rl.scope + ":"
+		top, err := rl.store.TopDenied(ctx, prefix, rl.window, n)
+		if err != nil {
+			return nil, err
+		}
+		for i := range top {
+			top[i].Key = strings.TrimPrefix(top[i].Key, prefix)
+		}
+		return top, nil
+	}
+	now := time.Now()
+	rl.mu.RLock()
+	top := make([]entity.RateLimitDenial, 0, len(rl.denials))
+	for ip, counter := range rl.denials {
+		if denied := counter.total(now, rl.window); denied > 0 {
+			top = append(top, entity.RateLimitDenial{Key: ip, Denied: denied})
+		}
+	}
+	rl.mu.RUnlock()
+	return rankDenied(top, n), nil
+}
+
+// after returns the timestamps later than cutoff.
+func after(timestamps []time.Time, cutoff time.Time) []time.Time {
+	var valid []time.Time
+	for _, ts := range timestamps {
+		if ts.After(cutoff) {
+			valid = append(valid, ts)
+		}
+	}
+	return valid
+}
+
+// denialCounter counts denials per minute, oldest first, like the
+// postgres and redis stores: a flood of rejected requests costs one bucket
+// per minute of the window instead of one entry per request.
+type denialCounter []denialBucket
+
+type denialBucket struct {
+	minute int64 // Unix time / 60
+	count  int
+}
+
+// add records a denial at now and drops the minutes before the window.
+func (c denialCounter) add(now time.Time, window time.Duration) denialCounter {
+	c = c.since(now, window)
+	minute := now.Unix() / 60
+	if n := len(c); n > 0 && c[n-1].minute == minute {
+		c[n-1].count++
+		return c
+	}
+	return append(c, denialBucket{minute: minute, count: 1})
+}
+
+// since returns the buckets from the minute the window starts in.
+func (c denialCounter) since(now time.Time, window time.Duration) denialCounter {
+	oldest := now.Add(-window).Unix() / 60
+	i := 0
+	for i < len(c) && c[i].minute < oldest {
+		i++
+	}
+	if i == len(c) {
+		return nil
+	}
+	return c[i:]
+}
+
+// total sums the denials from the minute the window starts in.
+func (c denialCounter) total(now time.Time, window time.Duration) int {
+	n := 0
+	for _, b := range c.since(now, window) {
+		n += b.count
+	}
+	return n
+}
+
+// rankDenied sorts by denials (then key, for a stable order) and keeps n.
+func rankDenied(keys []entity.RateLimitDenial, n int) []entity.RateLimitDenial {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Denied != keys[j].Denied {
+			return keys[i].Denied > keys[j].Denied
+		}
+		return keys[i].Key < keys[j].Key
+	})
+	if n >= 0 && len(keys) > n {
+		keys = keys[:n]
+	}
+	return keys
+}
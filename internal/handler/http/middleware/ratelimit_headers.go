@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimitHeaderMode selects which quota headers RateLimiter writes on
+// each limited response.
+type RateLimitHeaderMode string
+
+const (
+	// RateLimitHeadersNone writes no quota headers (the zero value, so
+	// limiters built directly keep their plain responses).
+	RateLimitHeadersNone RateLimitHeaderMode = "none"
+	// RateLimitHeadersLegacy writes the de facto X-RateLimit-Limit /
+	// -Remaining / -Reset headers (Reset is a Unix timestamp).
+	RateLimitHeadersLegacy RateLimitHeaderMode = "legacy"
+	// RateLimitHeadersDraft writes the IETF draft RateLimit-Limit /
+	// -Remaining / -Reset headers (Reset is delta seconds) plus
+	// RateLimit-Policy ("<limit>;w=<window seconds>").
+	RateLimitHeadersDraft RateLimitHeaderMode = "draft"
+	// RateLimitHeadersBoth writes both sets, for clients mid-migration.
+	RateLimitHeadersBoth RateLimitHeaderMode = "both"
+)
+
+// LoadRateLimitHeaderMode reads RATE_LIMIT_HEADERS (default "both"). Like
+// LoadRoutePolicies an unknown value is an error that prevents startup.
+func LoadRateLimitHeaderMode() (RateLimitHeaderMode, error) {
+	return ParseRateLimitHeaderMode(os.Getenv("RATE_LIMIT_HEADERS"))
+}
+
+// ParseRateLimitHeaderMode parses a RATE_LIMIT_HEADERS value. Empty means
+// RateLimitHeadersBoth.
+func ParseRateLimitHeaderMode(s string) (RateLimitHeaderMode, error) {
+	switch mode := RateLimitHeaderMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case "":
+		return RateLimitHeadersBoth, nil
+	case RateLimitHeadersNone, RateLimitHeadersLegacy, RateLimitHeadersDraft, RateLimitHeadersBoth:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid RATE_LIMIT_HEADERS %q: must be none, legacy, draft or both", s)
+	}
+}
+
+// SetHeaderMode selects the quota headers this limiter writes. It must be
+// called before the limiter serves requests.
+func (rl *RateLimiter) SetHeaderMode(mode RateLimitHeaderMode) {
+	rl.headers = mode
+}
+
+// quota is the outcome of one rate limit check, as reported to clients.
+type quota struct {
+	allowed   bool
+	remaining int
+	// reset is how long until the window frees at least one request.
+	reset time.Duration
+}
+
+// writeHeaders writes the quota headers selected by rl.headers. On a
+// denied request Retry-After is added so well-behaved clients back off
+// for exactly as long as needed.
+func (rl *RateLimiter) writeHeaders(w http.ResponseWriter, q quota) {
+	if rl.headers == "" || rl.headers == RateLimitHeadersNone {
+		return
+	}
+	h := w.Header()
+	limit := strconv.Itoa(rl.limit)
+	remaining := strconv.Itoa(q.remaining)
+	resetSecs := int64(math.Ceil(q.reset.Seconds()))
+
+	if rl.headers == RateLimitHeadersLegacy || rl.headers == RateLimitHeadersBoth {
+		h.Set("X-RateLimit-Limit", limit)
+		h.Set("X-RateLimit-Remaining", remaining)
+		h.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Unix()+resetSecs, 10))
+	}
+	if rl.headers == RateLimitHeadersDraft || rl.headers == RateLimitHeadersBoth {
+		h.Set("RateLimit-Limit", limit)
+		h.Set("RateLimit-Remaining", remaining)
+		h.Set("RateLimit-Reset", strconv.FormatInt(resetSecs, 10))
+		h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", rl.limit, int64(rl.window.Seconds())))
+	}
+	if !q.allowed {
+		h.Set("Retry-After", strconv.FormatInt(resetSecs, 10))
+	}
+}
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RoutePolicy is a rate limit declared for one route pattern.
+//
+// Pattern uses the net/http ServeMux syntax the routes themselves are
+// registered with ("POST /articles", "GET /articles/{id}"), so a policy
+// matches exactly the requests its route would serve.
+type RoutePolicy struct {
+	Pattern string
+	Limit   int
+	Window  time.Duration
+}
+
+// LoadRoutePolicies reads per-route rate limits from RATE_LIMIT_ROUTES.
+//
+// Format: comma-separated "<METHOD> <path>=<limit>/<window>" entries, e.g.
+//
+//	RATE_LIMIT_ROUTES="POST /articles=10/1m, GET /articles=120/1m"
+//
+// An unset variable yields no policies. Like LoadTrustedProxyConfig this
+// fails closed: a malformed entry is an error that prevents startup.
+func LoadRoutePolicies() ([]RoutePolicy, error) {
+	return ParseRoutePolicies(os.Getenv("RATE_LIMIT_ROUTES"))
+}
+
+// ParseRoutePolicies parses the RATE_LIMIT_ROUTES format.
+func ParseRoutePolicies(spec string) ([]RoutePolicy, error) {
+	var policies []RoutePolicy
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		eq := strings.LastIndex(entry, "=")
+		if eq < 0 {
+			return nil, fmt.Errorf("invalid rate limit route %q: must be \"<METHOD> <path>=<limit>/<window>\"", entry)
+		}
+		pattern := strings.TrimSpace(entry[:eq])
+		limitStr, windowStr, ok := strings.Cut(strings.TrimSpace(entry[eq+1:]), "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit route %q: limit must be \"<limit>/<window>\" (e.g. 10/1m)", entry)
+		}
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			return nil, fmt.Errorf("invalid rate limit route %q: limit must be a positive integer", entry)
+		}
+		window, err := time.ParseDuration(windowStr)
+		if err != nil || window <= 0 {
+			return nil, fmt.Errorf("invalid rate limit route %q: window must be a positive duration", entry)
+		}
+		if method, path, ok := strings.Cut(pattern, " "); !ok || method == "" || !strings.HasPrefix(strings.TrimSpace(path), "/") {
+			return nil, fmt.Errorf("invalid rate limit route %q: pattern must be \"<METHOD> <path>\"", entry)
+		}
+		policies = append(policies, RoutePolicy{Pattern: pattern, Limit: limit, Window: window})
+	}
+	return policies, nil
+}
+
+// RouteRateLimiter applies a per-IP RateLimiter chosen by the request's
+// route. Requests matching no policy pass through untouched, so the
+// fixed per-endpoint limiters (auth / search / feed) keep working
+// alongside it.
+type RouteRateLimiter struct {
+	// matcher resolves a request to its policy pattern with the same
+	// precedence rules as the real router.
+	matcher  *http.ServeMux
+	limiters map[string]*RateLimiter
+}
+
+// NewRouteRateLimiter builds the limiters for policies. Each policy has its
+// own window, keyed "route:<pattern>:<ip>" in a shared store. Invalid or
+// duplicate patterns are reported as an error instead of the ServeMux
+// panic.
+func NewRouteRateLimiter(policies []RoutePolicy, ipExtractor IPExtractor, store RateLimitStore) (rrl *RouteRateLimiter, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			rrl, err = nil, fmt.Errorf("invalid rate limit route pattern: %v", r)
+		}
+	}()
+
+	rrl = &RouteRateLimiter{
+		matcher:  http.NewServeMux(),
+		limiters: make(map[string]*RateLimiter, len(policies)),
+	}
+	for _, p := range policies {
+		rrl.matcher.Handle(p.Pattern, http.NotFoundHandler())
+		rrl.limiters[p.Pattern] = NewRateLimiterWithStore("route:"+p.Pattern, p.Limit, p.Window, ipExtractor, store)
+	}
+	return rrl, nil
+}
+
+// Limiters returns the underlying limiters, ordered by pattern, for
+// periodic CleanupExpired and the admin inspection API.
+func (rrl *RouteRateLimiter) Limiters() []*RateLimiter {
+	out := make([]*RateLimiter, 0, len(rrl.limiters))
+	for _, l := range rrl.limiters {
+		out = append(out, l)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].scope < out[j].scope })
+	return out
+}
+
+// Middleware enforces the policy matching each request.
+func (rrl *RouteRateLimiter) Middleware(next http.Handler) http.Handler {
+	if len(rrl.limiters) == 0 {
+		return next
+	}
+	limited := make(map[string]http.Handler, len(rrl.limiters))
+	for pattern, l := range rrl.limiters {
+		limited[pattern] = l.Middleware(next)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, pattern := rrl.matcher.Handler(r); pattern != "" {
+			if h, ok := limited[pattern]; ok {
+				h.ServeHTTP(w, r)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestParseRoutePolicies tests the RATE_LIMIT_ROUTES format
+func TestParseRoutePolicies(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    []RoutePolicy
+		wantErr bool
+	}{
+		{name: "empty", spec: "", want: nil},
+		{
+			name: "multiple entries with spaces",
+			spec: "POST /articles=10/1m, GET /articles/{id}=120/30s",
+			want: []RoutePolicy{
+				{Pattern: "POST /articles", Limit: 10, Window: time.Minute},
+				{Pattern: "GET /articles/{id}", Limit: 120, Window: 30 * time.Second},
+			},
+		},
+		{name: "missing limit", spec: "POST /articles", wantErr: true},
+		{name: "missing window", spec: "POST /articles=10", wantErr: true},
+		{name: "zero limit", spec: "POST /articles=0/1m", wantErr: true},
+		{name: "invalid window", spec: "POST /articles=10/soon", wantErr: true},
+		{name: "missing method", spec: "/articles=10/1m", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRoutePolicies(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d policies, got %d", len(tt.want), len(got))
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("policy %d: expected %+v, got %+v", i, tt.want[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+// TestRouteRateLimiter_ResolvesPolicyByMethodAndPattern tests that each
+// route gets its own budget and unmatched routes are not limited
+func TestRouteRateLimiter_ResolvesPolicyByMethodAndPattern(t *testing.T) {
+	rrl, err := NewRouteRateLimiter([]RoutePolicy{
+		{Pattern: "POST /articles", Limit: 1, Window: time.Minute},
+		{Pattern: "GET /articles/{id}", Limit: 2, Window: time.Minute},
+	}, &mockIPExtractor{ip: "192.168.1.1"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	handler := rrl.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	requests := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{"POST", "/articles", http.StatusOK},
+		{"POST", "/articles", http.StatusTooManyRequests},
+		// GET /articles has no policy
+		{"GET", "/articles", http.StatusOK},
+		{"GET", "/articles", http.StatusOK},
+		// {id} routes share one budget per IP
+		{"GET", "/articles/1", http.StatusOK},
+		{"GET", "/articles/2", http.StatusOK},
+		{"GET", "/articles/3", http.StatusTooManyRequests},
+	}
+
+	for i, req := range requests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(req.method, req.path, nil))
+		if rec.Code != req.want {
+			t.Errorf("request %d (%s %s): expected status %d, got %d", i+1, req.method, req.path, req.want, rec.Code)
+		}
+	}
+
+	if got := len(rrl.Limiters()); got != 2 {
+		t.Errorf("expected 2 limiters, got %d", got)
+	}
+}
+
+// TestNewRouteRateLimiter_DuplicatePattern tests that a conflicting pattern
+// is an error rather than a panic
+func TestNewRouteRateLimiter_DuplicatePattern(t *testing.T) {
+	_, err := NewRouteRateLimiter([]RoutePolicy{
+		{Pattern: "POST /articles", Limit: 1, Window: time.Minute},
+		{Pattern: "POST /articles", Limit: 5, Window: time.Minute},
+	}, &mockIPExtractor{ip: "192.168.1.1"}, nil)
+	if err == nil {
+		t.Fatal("expected error for duplicate pattern")
+	}
+}
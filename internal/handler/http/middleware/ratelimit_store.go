@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// MemoryRateLimitStore is an in-process RateLimitStore. RateLimiter does
+// not need it (a nil store already keeps its windows in memory); it exists
+// for callers with per-key limits, such as API key quotas, when
+// RATE_LIMIT_STORE=memory.
+type MemoryRateLimitStore struct {
+	mu   sync.Mutex
+	hits map[string]memoryWindow
+}
+
+type memoryWindow struct {
+	timestamps []time.Time
+	denials    denialCounter
+	window     time.Duration
+}
+
+// NewMemoryRateLimitStore creates an empty store.
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{hits: make(map[string]memoryWindow)}
+}
+
+// Allow implements RateLimitStore with the same sliding window as
+// RateLimiter.allow.
+func (s *MemoryRateLimitStore) Allow(_ context.Context, key string, limit int, window time.Duration) (bool, error) {
+	now := time.Now()
+	cutoff := now.Add(-window)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w := s.hits[key]
+	w.window = window
+	w.timestamps = after(w.timestamps, cutoff)
+	if len(w.timestamps) >= limit {
+		w.denials = w.denials.add(now, window)
+		s.hits[key] = w
+		return false, nil
+	}
+	w.timestamps = append(w.timestamps, now)
+	s.hits[key] = w
+	return true, nil
+}
+
+// Cleanup implements RateLimitStore by dropping keys with no request or
+// denial inside their window.
+func (s *MemoryRateLimitStore) Cleanup(_ context.Context) error {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, w := range s.hits {
+		cutoff := now.Add(-w.window)
+		w.timestamps = after(w.timestamps, cutoff)
+		w.denials = w.denials.since(now, w.window)
+		if len(w.timestamps) == 0 && len(w.denials) == 0 {
+			delete(s.hits, key)
+		} else {
+			s.hits[key] = w
+		}
+	}
+	return nil
+}
+
+// Count implements RateLimitStore.
+func (s *MemoryRateLimitStore) Count(_ context.Context, key string, window time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(after(s.hits[key].timestamps, time.Now().Add(-window))), nil
+}
+
+// Reset implements RateLimitStore.
+func (s *MemoryRateLimitStore) Reset(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.hits, key)
+	return nil
+}
+
+// TopDenied implements RateLimitStore. Denials are counted per minute,
+// so the window is rounded down to the minute.
+func (s *MemoryRateLimitStore) TopDenied(_ context.Context, prefix string, window time.Duration, n int) ([]entity.RateLimitDenial, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	top := make([]entity.RateLimitDenial, 0)
+	for key, w := range s.hits {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if denied := w.denials.total(now, window); denied > 0 {
+			top = append(top, entity.RateLimitDenial{Key: key, Denied: denied})
+		}
+	}
+	s.mu.Unlock()
+	return rankDenied(top, n), nil
+}
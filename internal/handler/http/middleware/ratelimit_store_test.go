@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestMemoryRateLimitStore_Allow tests per-key limits with independent windows
+func TestMemoryRateLimitStore_Allow(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := store.Allow(ctx, "a", 2, time.Minute); !ok {
+			t.Fatalf("request %d for key a should be allowed", i+1)
+		}
+	}
+	if ok, _ := store.Allow(ctx, "a", 2, time.Minute); ok {
+		t.Error("3rd request for key a should be denied")
+	}
+	if ok, _ := store.Allow(ctx, "b", 2, time.Minute); !ok {
+		t.Error("key b must not share key a's window")
+	}
+}
+
+// TestMemoryRateLimitStore_Cleanup tests that expired keys are dropped
+func TestMemoryRateLimitStore_Cleanup(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	ctx := context.Background()
+
+	_, _ = store.Allow(ctx, "short", 5, 10*time.Millisecond)
+	_, _ = store.Allow(ctx, "long", 5, time.Hour)
+	time.Sleep(20 * time.Millisecond)
+
+	if err := store.Cleanup(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := store.hits["short"]; ok {
+		t.Error("expired key should be removed")
+	}
+	if _, ok := store.hits["long"]; !ok {
+		t.Error("active key should be kept")
+	}
+}
+
+// TestMemoryRateLimitStore_Inspection tests Count / Reset / TopDenied
+func TestMemoryRateLimitStore_Inspection(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	ctx := context.Background()
+
+	for range 4 {
+		_, _ = store.Allow(ctx, "auth:a", 1, time.Minute)
+	}
+	_, _ = store.Allow(ctx, "auth:b", 1, time.Minute)
+	_, _ = store.Allow(ctx, "auth:b", 1, time.Minute)
+	for range 5 {
+		_, _ = store.Allow(ctx, "search:a", 1, time.Minute)
+	}
+
+	if count, _ := store.Count(ctx, "auth:a", time.Minute); count != 1 {
+		t.Errorf("Count() = %d, want 1", count)
+	}
+	top, _ := store.TopDenied(ctx, "auth:", time.Minute, 10)
+	if len(top) != 2 || top[0].Key != "auth:a" || top[0].Denied != 3 || top[1].Key != "auth:b" {
+		t.Errorf("TopDenied() = %v", top)
+	}
+
+	if err := store.Reset(ctx, "auth:a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok, _ := store.Allow(ctx, "auth:a", 1, time.Minute); !ok {
+		t.Error("request after Reset should be allowed")
+	}
+}
+
+// TestMemoryRateLimitStore_DenialsAreBounded floods one key: the denials
+// are per-minute counters, not one entry per rejected request.
+func TestMemoryRateLimitStore_DenialsAreBounded(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	ctx := context.Background()
+	for range 10000 {
+		_, _ = store.Allow(ctx, "auth:10.0.0.1", 1, time.Minute)
+	}
+
+	store.mu.Lock()
+	buckets := len(store.hits["auth:10.0.0.1"].denials)
+	store.mu.Unlock()
+	if buckets > 2 {
+		t.Errorf("stored %d denial buckets for one minute of denials, want at most 2", buckets)
+	}
+	top, err := store.TopDenied(ctx, "auth:", time.Minute, 1)
+	if err != nil || len(top) != 1 || top[0].Denied != 9999 {
+		t.Errorf("TopDenied() = %v, %v; want 9999 denials", top, err)
+	}
+}
@@ -1,13 +1,19 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/netip"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
+
+	"catchup-feed/internal/domain/entity"
 )
 
 // mockIPExtractor is a mock implementation of IPExtractor for testing
@@ -473,3 +479,358 @@ func TestRateLimiter_InvalidRemoteAddrFallback(t *testing.T) {
 			http.StatusInternalServerError, rec.Code)
 	}
 }
+
+// fakeRateLimitStore is a scriptable RateLimitStore recording the keys it sees.
+type fakeRateLimitStore struct {
+	mu       sync.Mutex
+	counts   map[string]int
+	denied   map[string]int
+	err      error
+	cleanups int
+}
+
+func (f *fakeRateLimitStore) Allow(_ context.Context, key string, limit int, _ time.Duration) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return false, f.err
+	}
+	if f.counts[key] >= limit {
+		if f.denied != nil {
+			f.denied[key]++
+		}
+		return false, nil
+	}
+	f.counts[key]++
+	return true, nil
+}
+
+func (f *fakeRateLimitStore) Cleanup(_ context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cleanups++
+	return f.err
+}
+
+func (f *fakeRateLimitStore) Count(_ context.Context, key string, _ time.Duration) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.counts[key], f.err
+}
+
+func (f *fakeRateLimitStore) Reset(_ context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.counts, key)
+	delete(f.denied, key)
+	return f.err
+}
+
+func (f *fakeRateLimitStore) TopDenied(_ context.Context, prefix string, _ time.Duration, n int) ([]entity.RateLimitDenial, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var top []entity.RateLimitDenial
+	for key, denied := range f.denied {
+		if strings.HasPrefix(key, prefix) {
+			top = append(top, entity.RateLimitDenial{Key: key, Denied: denied})
+		}
+	}
+	return rankDenied(top, n), f.err
+}
+
+// TestRateLimiter_WithStore tests that a shared store replaces the in-memory
+// window and that limiters sharing a store are scoped apart.
+func TestRateLimiter_WithStore(t *testing.T) {
+	store := &fakeRateLimitStore{counts: map[string]int{}}
+	extractor := &mockIPExtractor{ip: "192.168.1.1"}
+	auth := NewRateLimiterWithStore("auth", 2, time.Minute, extractor, store)
+	search := NewRateLimiterWithStore("search", 2, time.Minute, extractor, store)
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	authHandler := auth.Middleware(ok)
+	searchHandler := search.Middleware(ok)
+
+	codes := make([]int, 0, 4)
+	for _, h := range []http.Handler{authHandler, authHandler, authHandler, searchHandler} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", "/test", nil))
+		codes = append(codes, rec.Code)
+	}
+
+	want := []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests, http.StatusOK}
+	for i := range want {
+		if codes[i] != want[i] {
+			t.Errorf("request %d: expected status %d, got %d", i+1, want[i], codes[i])
+		}
+	}
+	if store.counts["auth:192.168.1.1"] != 2 || store.counts["search:192.168.1.1"] != 1 {
+		t.Errorf("unexpected store keys: %v", store.counts)
+	}
+	if len(auth.requests) != 0 {
+		t.Errorf("in-memory window must stay unused with a store, got %d entries", len(auth.requests))
+	}
+
+	auth.CleanupExpired()
+	if store.cleanups != 1 {
+		t.Errorf("expected CleanupExpired to clean the store once, got %d", store.cleanups)
+	}
+}
+
+// TestRateLimiter_StoreErrorFailsOpen tests that a store outage lets requests through
+func TestRateLimiter_StoreErrorFailsOpen(t *testing.T) {
+	store := &fakeRateLimitStore{counts: map[string]int{}, err: errors.New("connection refused")}
+	limiter := NewRateLimiterWithStore("auth", 1, time.Minute, &mockIPExtractor{ip: "192.168.1.1"}, store)
+
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for i := 0; i < 3; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", "/test", nil))
+		if rec.Code != http.StatusOK {
+			t.Errorf("Request %d: expected status %d, got %d", i+1, http.StatusOK, rec.Code)
+		}
+	}
+}
+
+// TestRateLimiter_DenialsAreBounded floods one IP: the denials are kept
+// as per-minute counters, so the stored state does not grow with each
+// rejected request.
+func TestRateLimiter_DenialsAreBounded(t *testing.T) {
+	limiter := NewRateLimiter(1, time.Minute, &mockIPExtractor{})
+	for range 10000 {
+		limiter.allow("10.0.0.1")
+	}
+
+	limiter.mu.RLock()
+	buckets := len(limiter.denials["10.0.0.1"])
+	limiter.mu.RUnlock()
+	if buckets > 2 {
+		t.Errorf("stored %d denial buckets for one minute of denials, want at most 2", buckets)
+	}
+	top, _ := limiter.TopDenied(context.Background(), 1)
+	if len(top) != 1 || top[0].Denied != 9999 {
+		t.Errorf("TopDenied() = %v, want 9999 denials", top)
+	}
+}
+
+// TestDenialCounter tests the per-minute buckets across minutes and the
+// window's start.
+func TestDenialCounter(t *testing.T) {
+	start := time.Date(2026, 10, 15, 7, 30, 10, 0, time.UTC)
+	var c denialCounter
+	for i := range 3 * 60 {
+		c = c.add(start.Add(time.Duration(i)*time.Second), time.Minute)
+	}
+	now := start.Add(3*time.Minute - time.Second) // 07:33:09
+	if len(c) > 2 {
+		t.Errorf("kept %d buckets for a 1m window, want at most 2", len(c))
+	}
+	// The window starts at 07:32:09, rounded down to 07:32: 60 + 10 denials.
+	if got := c.total(now, time.Minute); got != 70 {
+		t.Errorf("total() = %d, want 70", got)
+	}
+	if got := c.since(now.Add(5*time.Minute), time.Minute); got != nil {
+		t.Errorf("since() after the window = %v, want nil", got)
+	}
+}
+
+// TestRateLimiter_Inspection tests Count / Reset / TopDenied on the
+// in-memory window used by the admin rate limit API.
+func TestRateLimiter_Inspection(t *testing.T) {
+	ctx := context.Background()
+	limiter := NewRateLimiter(2, time.Minute, &mockIPExtractor{})
+
+	for range 5 {
+		limiter.allow("10.0.0.1")
+	}
+	limiter.allow("10.0.0.2")
+	limiter.allow("10.0.0.2")
+	limiter.allow("10.0.0.2")
+	limiter.allow("10.0.0.3")
+
+	count, err := limiter.Count(ctx, "10.0.0.1")
+	if err != nil || count != 2 {
+		t.Errorf("Count() = %d, %v; want 2, nil", count, err)
+	}
+
+	top, err := limiter.TopDenied(ctx, 10)
+	if err != nil {
+		t.Fatalf("TopDenied() error = %v", err)
+	}
+	want := []entity.RateLimitDenial{{Key: "10.0.0.1", Denied: 3}, {Key: "10.0.0.2", Denied: 1}}
+	if fmt.Sprint(top) != fmt.Sprint(want) {
+		t.Errorf("TopDenied() = %v, want %v", top, want)
+	}
+	if top, _ := limiter.TopDenied(ctx, 1); len(top) != 1 {
+		t.Errorf("TopDenied(1) returned %d entries, want 1", len(top))
+	}
+
+	if err := limiter.Reset(ctx, "10.0.0.1"); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if !limiter.allow("10.0.0.1") {
+		t.Error("request after Reset was denied")
+	}
+	if top, _ := limiter.TopDenied(ctx, 10); len(top) != 1 || top[0].Key != "10.0.0.2" {
+		t.Errorf("TopDenied() after Reset = %v", top)
+	}
+}
+
+// TestRateLimiter_InspectionWithStore tests that inspection goes through
+// the store with the limiter's scope and strips it from returned keys.
+func TestRateLimiter_InspectionWithStore(t *testing.T) {
+	ctx := context.Background()
+	store := &fakeRateLimitStore{counts: map[string]int{}, denied: map[string]int{}}
+	auth := NewRateLimiterWithStore("auth", 1, time.Minute, &mockIPExtractor{}, store)
+	search := NewRateLimiterWithStore("search", 1, time.Minute, &mockIPExtractor{}, store)
+
+	for range 3 {
+		_, _ = store.Allow(ctx, "auth:10.0.0.1", 1, time.Minute)
+		_, _ = store.Allow(ctx, "search:10.0.0.9", 1, time.Minute)
+	}
+
+	if count, err := auth.Count(ctx, "10.0.0.1"); err != nil || count != 1 {
+		t.Errorf("Count() = %d, %v; want 1, nil", count, err)
+	}
+	top, err := auth.TopDenied(ctx, 10)
+	if err != nil {
+		t.Fatalf("TopDenied() error = %v", err)
+	}
+	if len(top) != 1 || top[0] != (entity.RateLimitDenial{Key: "10.0.0.1", Denied: 2}) {
+		t.Errorf("TopDenied() = %v", top)
+	}
+
+	if err := auth.Reset(ctx, "10.0.0.1"); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if _, ok := store.counts["auth:10.0.0.1"]; ok {
+		t.Error("Reset did not clear the scoped key")
+	}
+	if count, _ := search.Count(ctx, "10.0.0.9"); count != 1 {
+		t.Errorf("Reset leaked into another scope: search count = %d", count)
+	}
+}
+
+// TestRateLimiter_Headers tests the quota headers of each RATE_LIMIT_HEADERS mode
+func TestRateLimiter_Headers(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		mode       RateLimitHeaderMode
+		wantLegacy bool
+		wantDraft  bool
+	}{
+		{name: "zero value writes none", mode: ""},
+		{name: "none", mode: RateLimitHeadersNone},
+		{name: "legacy", mode: RateLimitHeadersLegacy, wantLegacy: true},
+		{name: "draft", mode: RateLimitHeadersDraft, wantDraft: true},
+		{name: "both", mode: RateLimitHeadersBoth, wantLegacy: true, wantDraft: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewRateLimiter(2, time.Minute, &mockIPExtractor{ip: "192.168.1.1"})
+			limiter.SetHeaderMode(tt.mode)
+			handler := limiter.Middleware(ok)
+
+			var recs []*httptest.ResponseRecorder
+			for range 3 {
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+				recs = append(recs, rec)
+			}
+
+			first, denied := recs[0].Header(), recs[2].Header()
+			if tt.wantLegacy {
+				if got := first.Get("X-RateLimit-Limit"); got != "2" {
+					t.Errorf("X-RateLimit-Limit = %q, want 2", got)
+				}
+				if got := first.Get("X-RateLimit-Remaining"); got != "1" {
+					t.Errorf("X-RateLimit-Remaining = %q, want 1", got)
+				}
+				reset, err := strconv.ParseInt(first.Get("X-RateLimit-Reset"), 10, 64)
+				if err != nil || reset < time.Now().Unix() {
+					t.Errorf("X-RateLimit-Reset = %q, want a future Unix timestamp", first.Get("X-RateLimit-Reset"))
+				}
+			} else if first.Get("X-RateLimit-Limit") != "" {
+				t.Error("unexpected X-RateLimit-* headers")
+			}
+
+			if tt.wantDraft {
+				if got := first.Get("RateLimit-Limit"); got != "2" {
+					t.Errorf("RateLimit-Limit = %q, want 2", got)
+				}
+				if got := denied.Get("RateLimit-Remaining"); got != "0" {
+					t.Errorf("RateLimit-Remaining on 429 = %q, want 0", got)
+				}
+				if got := first.Get("RateLimit-Reset"); got != "60" {
+					t.Errorf("RateLimit-Reset = %q, want 60", got)
+				}
+				if got := first.Get("RateLimit-Policy"); got != "2;w=60" {
+					t.Errorf("RateLimit-Policy = %q, want 2;w=60", got)
+				}
+			} else if first.Get("RateLimit-Limit") != "" {
+				t.Error("unexpected RateLimit-* headers")
+			}
+
+			wantRetry := tt.wantLegacy || tt.wantDraft
+			if got := denied.Get("Retry-After"); (got != "") != wantRetry {
+				t.Errorf("Retry-After on 429 = %q, want present=%v", got, wantRetry)
+			}
+			if first.Get("Retry-After") != "" {
+				t.Error("Retry-After must only be set on 429")
+			}
+		})
+	}
+}
+
+// TestRateLimiter_HeadersWithStore tests that the store path reports the
+// remaining count and the whole window as reset
+func TestRateLimiter_HeadersWithStore(t *testing.T) {
+	store := &fakeRateLimitStore{counts: map[string]int{}}
+	limiter := NewRateLimiterWithStore("auth", 3, time.Minute, &mockIPExtractor{ip: "192.168.1.1"}, store)
+	limiter.SetHeaderMode(RateLimitHeadersDraft)
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+	if got := rec.Header().Get("RateLimit-Remaining"); got != "2" {
+		t.Errorf("RateLimit-Remaining = %q, want 2", got)
+	}
+	if got := rec.Header().Get("RateLimit-Reset"); got != "60" {
+		t.Errorf("RateLimit-Reset = %q, want 60", got)
+	}
+}
+
+// TestParseRateLimitHeaderMode tests RATE_LIMIT_HEADERS parsing
+func TestParseRateLimitHeaderMode(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    RateLimitHeaderMode
+		wantErr bool
+	}{
+		{in: "", want: RateLimitHeadersBoth},
+		{in: "none", want: RateLimitHeadersNone},
+		{in: " Legacy ", want: RateLimitHeadersLegacy},
+		{in: "draft", want: RateLimitHeadersDraft},
+		{in: "both", want: RateLimitHeadersBoth},
+		{in: "ietf", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRateLimitHeaderMode(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRateLimitHeaderMode(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseRateLimitHeaderMode(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
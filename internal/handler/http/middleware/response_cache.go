@@ -0,0 +1,324 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"catchup-feed/internal/handler/http/respond"
+)
+
+// DefaultResponseCachePaths are the GET endpoints whose responses depend
+// only on the caller's role, never on who the caller is. The article list,
+// search and detail are not among them: they mark the caller's favorites.
+// Nor is /feed.xml, which follows the caller's source subscriptions.
+var DefaultResponseCachePaths = []string{
+	"/sources",
+	"/sources/search",
+	"/sources/health",
+	"/sources/export.opml",
+	"/tags",
+	"/crawls",
+}
+
+// DefaultResponseCacheTTL is how long a cached response is served.
+const DefaultResponseCacheTTL = 10 * time.Second
+
+// maxCachedResponseBytes bounds the body of a cacheable response; larger
+// responses are served but not stored.
+const maxCachedResponseBytes = 1 << 20
+
+// responseCacheNamespace is the store namespace whose generation
+// Invalidate bumps.
+const responseCacheNamespace = "http"
+
+// ResponseCacheHeader reports HIT or MISS on responses to cacheable
+// requests.
+const ResponseCacheHeader = "X-Cache"
+
+// ResponseCacheStore keeps the cached responses. It has the method set of
+// cache.Store (internal/infra/cache), so the read cache's backend can be
+// shared.
+type ResponseCacheStore interface {
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Generation(ctx context.Context, namespace string) (int64, error)
+	Invalidate(ctx context.Context, namespace string) error
+}
+
+// ResponseCacheConfig configures the HTTP response cache.
+type ResponseCacheConfig struct {
+	// Store holds the responses; required.
+	Store ResponseCacheStore
+
+	// Role returns the role of the authenticated caller (auth.RoleFromContext).
+	// Responses are shared per role; requests without one are not cached.
+	// Scopes derive from the role, so a response one caller was allowed to
+	// see is allowed for every caller with the same role.
+	Role func(ctx context.Context) string
+
+	// Paths are the cacheable GET paths (exact match, a trailing slash
+	// tolerated); nil means DefaultResponseCachePaths.
+	Paths []string
+
+	// TTL is the lifetime of a cached response; 0 means
+	// DefaultResponseCacheTTL.
+	TTL time.Duration
+
+	// Logger records store failures; nil means slog.Default().
+	Logger *slog.Logger
+}
+
+// ResponseCacheStats are the response cache counters (GET /health).
+type ResponseCacheStats struct {
+	Hits          int64 `json:"hits"`
+	Misses        int64 `json:"misses"`
+	Errors        int64 `json:"errors"`
+	Invalidations int64 `json:"invalidations"`
+}
+
+// ResponseCache caches whole 200 responses of the cacheable GET endpoints,
+// keyed by path, query, Accept and role. Every successful unsafe request
+// through it invalidates all entries, as does Invalidate (called for the
+// worker's NOTIFYs), so a mutation is visible on the next request rather
+// than after the TTL.
+type ResponseCache struct {
+	store  ResponseCacheStore
+	role   func(ctx context.Context) string
+	paths  map[string]struct{}
+	ttl    time.Duration
+	logger *slog.Logger
+
+	hits          atomic.Int64
+	misses        atomic.Int64
+	errors        atomic.Int64
+	invalidations atomic.Int64
+}
+
+// NewResponseCache creates a response cache.
+func NewResponseCache(cfg ResponseCacheConfig) *ResponseCache {
+	paths := cfg.Paths
+	if paths == nil {
+		paths = DefaultResponseCachePaths
+	}
+	c := &ResponseCache{
+		store:  cfg.Store,
+		role:   cfg.Role,
+		paths:  make(map[string]struct{}, len(paths)),
+		ttl:    cfg.TTL,
+		logger: cfg.Logger,
+	}
+	for _, p := range paths {
+		c.paths[p] = struct{}{}
+	}
+	if c.ttl <= 0 {
+		c.ttl = DefaultResponseCacheTTL
+	}
+	if c.logger == nil {
+		c.logger = slog.Default()
+	}
+	return c
+}
+
+// cachedResponse is the stored form of a response.
+type cachedResponse struct {
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+}
+
+// Middleware serves cacheable requests from the cache and invalidates it
+// after successful mutations. It belongs inside the authentication
+// middleware (it needs the role) and outside the routes. A nil
+// *ResponseCache returns next unchanged.
+func (c *ResponseCache) Middleware(next http.Handler) http.Handler {
+	if c == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !csrfSafeMethod(r.Method) {
+			sw := &responseCacheStatusWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(sw, r)
+			if sw.status < http.StatusBadRequest {
+				c.Invalidate(r.Context())
+			}
+			return
+		}
+
+		key, ok := c.key(r)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if cached, ok := c.lookup(r.Context(), key); ok {
+			c.hits.Add(1)
+			c.write(w, r, cached, "HIT")
+			return
+		}
+		c.misses.Add(1)
+
+		// Render the full response even for a conditional request, so it
+		// can be stored; If-None-Match is answered from the copy below.
+		inner := r.Clone(r.Context())
+		inner.Header.Del("If-None-Match")
+		rec := &responseCacheRecorder{header: make(http.Header), status: http.StatusOK}
+		next.ServeHTTP(rec, inner)
+
+		resp := cachedResponse{Header: rec.header, Body: rec.body.Bytes()}
+		if rec.status != http.StatusOK || rec.header.Get("Set-Cookie") != "" {
+			copyHeader(w.Header(), rec.header)
+			w.WriteHeader(rec.status)
+			_, _ = w.Write(resp.Body)
+			return
+		}
+		if len(resp.Body) <= maxCachedResponseBytes {
+			c.save(r.Context(), key, resp)
+		}
+		c.write(w, r, resp, "MISS")
+	})
+}
+
+// Invalidate drops every cached response (no-op on nil).
+func (c *ResponseCache) Invalidate(ctx context.Context) {
+	if c == nil {
+		return
+	}
+	c.invalidations.Add(1)
+	if err := c.store.Invalidate(ctx, responseCacheNamespace); err != nil {
+		c.errors.Add(1)
+		c.logger.WarnContext(ctx, "response cache invalidation failed", slog.Any("error", err))
+	}
+}
+
+// Stats returns the current counters.
+func (c *ResponseCache) Stats() ResponseCacheStats {
+	return ResponseCacheStats{
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Errors:        c.errors.Load(),
+		Invalidations: c.invalidations.Load(),
+	}
+}
+
+// key returns the store key of a cacheable request. The query is
+// re-encoded so parameter order does not split entries.
+func (c *ResponseCache) key(r *http.Request) (string, bool) {
+	if r.Method != http.MethodGet {
+		return "", false
+	}
+	path := r.URL.Path
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	if _, ok := c.paths[path]; !ok {
+		return "", false
+	}
+	role := ""
+	if c.role != nil {
+		role = c.role(r.Context())
+	}
+	if role == "" {
+		return "", false
+	}
+	gen, err := c.store.Generation(r.Context(), responseCacheNamespace)
+	if err != nil {
+		c.storeFailed(r.Context(), err)
+		return "", false
+	}
+	return responseCacheNamespace + ":" + strconv.FormatInt(gen, 10) + ":" + role + ":" +
+		r.Header.Get("Accept") + ":" + path + "?" + r.URL.Query().Encode(), true
+}
+
+func (c *ResponseCache) lookup(ctx context.Context, key string) (cachedResponse, bool) {
+	data, ok, err := c.store.Get(ctx, key)
+	if err != nil {
+		c.storeFailed(ctx, err)
+		return cachedResponse{}, false
+	}
+	var resp cachedResponse
+	if !ok || json.Unmarshal(data, &resp) != nil {
+		return cachedResponse{}, false
+	}
+	return resp, true
+}
+
+func (c *ResponseCache) save(ctx context.Context, key string, resp cachedResponse) {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return
+	}
+	if err := c.store.Set(ctx, key, data, c.ttl); err != nil {
+		c.storeFailed(ctx, err)
+	}
+}
+
+func (c *ResponseCache) storeFailed(ctx context.Context, err error) {
+	c.errors.Add(1)
+	c.logger.DebugContext(ctx, "response cache store failed, serving uncached", slog.Any("error", err))
+}
+
+// write sends a 200 response (or 304 when If-None-Match names its ETag).
+func (c *ResponseCache) write(w http.ResponseWriter, r *http.Request, resp cachedResponse, status string) {
+	copyHeader(w.Header(), resp.Header)
+	w.Header().Set(ResponseCacheHeader, status)
+	if etag := resp.Header.Get("ETag"); etag != "" && respond.ETagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(resp.Body)
+}
+
+func copyHeader(dst, src http.Header) {
+	for k, v := range src {
+		dst[k] = v
+	}
+}
+
+// responseCacheRecorder buffers the handler's response.
+type responseCacheRecorder struct {
+	header      http.Header
+	body        bytes.Buffer
+	status      int
+	wroteHeader bool
+}
+
+func (r *responseCacheRecorder) Header() http.Header { return r.header }
+
+func (r *responseCacheRecorder) WriteHeader(code int) {
+	if !r.wroteHeader {
+		r.status, r.wroteHeader = code, true
+	}
+}
+
+func (r *responseCacheRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	return r.body.Write(b)
+}
+
+// responseCacheStatusWriter records the status of a mutation.
+type responseCacheStatusWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *responseCacheStatusWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.status, w.wroteHeader = code, true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseCacheStatusWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (w *responseCacheStatusWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
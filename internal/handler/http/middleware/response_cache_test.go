@@ -0,0 +1,207 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mapResponseStore is a ResponseCacheStore without expiry.
+type mapResponseStore struct {
+	mu   sync.Mutex
+	data map[string][]byte
+	gens map[string]int64
+}
+
+func newMapResponseStore() *mapResponseStore {
+	return &mapResponseStore{data: map[string][]byte{}, gens: map[string]int64{}}
+}
+
+func (s *mapResponseStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.data[key]
+	return v, ok, nil
+}
+
+func (s *mapResponseStore) Set(_ context.Context, key string, value []byte, _ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = value
+	return nil
+}
+
+func (s *mapResponseStore) Generation(_ context.Context, ns string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.gens[ns], nil
+}
+
+func (s *mapResponseStore) Invalidate(_ context.Context, ns string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gens[ns]++
+	return nil
+}
+
+type roleKey struct{}
+
+// newResponseCacheHandler returns a cached handler that counts the GET
+// requests reaching it; its body is the count.
+func newResponseCacheHandler(t *testing.T) (*ResponseCache, http.Handler, *int) {
+	t.Helper()
+	calls := 0
+	rc := NewResponseCache(ResponseCacheConfig{
+		Store: newMapResponseStore(),
+		Role: func(ctx context.Context) string {
+			role, _ := ctx.Value(roleKey{}).(string)
+			return role
+		},
+	})
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /sources", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `W/"v`+strconv.Itoa(calls)+`"`)
+		_, _ = w.Write([]byte(strconv.Itoa(calls)))
+	})
+	mux.HandleFunc("GET /articles", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(strconv.Itoa(calls)))
+	})
+	mux.HandleFunc("POST /sources", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("DELETE /sources/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	return rc, rc.Middleware(mux), &calls
+}
+
+func serveAs(h http.Handler, role, method, target string, header ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	if role != "" {
+		req = req.WithContext(context.WithValue(req.Context(), roleKey{}, role))
+	}
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+/* ───────── hits and keys ───────── */
+
+func TestResponseCache_ServesRepeatedRequests(t *testing.T) {
+	rc, h, calls := newResponseCacheHandler(t)
+
+	first := serveAs(h, "admin", http.MethodGet, "/sources?b=2&a=1")
+	second := serveAs(h, "admin", http.MethodGet, "/sources?a=1&b=2")
+
+	if *calls != 1 {
+		t.Fatalf("handler calls = %d, want 1", *calls)
+	}
+	if first.Header().Get(ResponseCacheHeader) != "MISS" || second.Header().Get(ResponseCacheHeader) != "HIT" {
+		t.Errorf("X-Cache = %q, %q, want MISS, HIT", first.Header().Get(ResponseCacheHeader), second.Header().Get(ResponseCacheHeader))
+	}
+	if second.Body.String() != "1" || second.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("hit = %q (%s), want the first response", second.Body.String(), second.Header().Get("Content-Type"))
+	}
+	if stats := rc.Stats(); stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("stats = %+v, want 1 hit / 1 miss", stats)
+	}
+}
+
+func TestResponseCache_KeyedByRoleAndQuery(t *testing.T) {
+	_, h, calls := newResponseCacheHandler(t)
+
+	serveAs(h, "admin", http.MethodGet, "/sources")
+	serveAs(h, "viewer", http.MethodGet, "/sources")
+	serveAs(h, "admin", http.MethodGet, "/sources?active=true")
+	serveAs(h, "admin", http.MethodGet, "/sources", "Accept", "text/csv")
+
+	if *calls != 4 {
+		t.Errorf("handler calls = %d, want 4 (one per role / query / Accept)", *calls)
+	}
+}
+
+func TestResponseCache_Bypass(t *testing.T) {
+	_, h, calls := newResponseCacheHandler(t)
+
+	for range 2 {
+		if rec := serveAs(h, "admin", http.MethodGet, "/articles"); rec.Header().Get(ResponseCacheHeader) != "" {
+			t.Error("unlisted path should not be cached")
+		}
+		serveAs(h, "", http.MethodGet, "/sources") // no role
+	}
+	if *calls != 4 {
+		t.Errorf("handler calls = %d, want 4", *calls)
+	}
+}
+
+func TestResponseCache_ConditionalRequest(t *testing.T) {
+	_, h, calls := newResponseCacheHandler(t)
+
+	// A conditional miss still stores the full response
+	rec := serveAs(h, "admin", http.MethodGet, "/sources", "If-None-Match", `W/"v1"`)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("status = %d, want 304", rec.Code)
+	}
+	rec = serveAs(h, "admin", http.MethodGet, "/sources")
+	if rec.Code != http.StatusOK || rec.Body.String() != "1" || rec.Header().Get(ResponseCacheHeader) != "HIT" {
+		t.Errorf("got %d %q, want the cached 200", rec.Code, rec.Body.String())
+	}
+	rec = serveAs(h, "admin", http.MethodGet, "/sources", "If-None-Match", `"v1"`)
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("status = %d, want 304 without body", rec.Code)
+	}
+	if *calls != 1 {
+		t.Errorf("handler calls = %d, want 1", *calls)
+	}
+}
+
+/* ───────── invalidation ───────── */
+
+func TestResponseCache_MutationInvalidates(t *testing.T) {
+	rc, h, calls := newResponseCacheHandler(t)
+
+	serveAs(h, "admin", http.MethodGet, "/sources")
+	// A failed mutation changes nothing
+	serveAs(h, "admin", http.MethodDelete, "/sources/9")
+	serveAs(h, "admin", http.MethodGet, "/sources")
+	if *calls != 1 {
+		t.Fatalf("handler calls = %d, want 1 after a failed mutation", *calls)
+	}
+
+	if rec := serveAs(h, "admin", http.MethodPost, "/sources"); rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201", rec.Code)
+	}
+	serveAs(h, "viewer", http.MethodGet, "/sources")
+	rec := serveAs(h, "admin", http.MethodGet, "/sources")
+	if rec.Body.String() != "3" || rec.Header().Get(ResponseCacheHeader) != "MISS" {
+		t.Errorf("got %q (%s), want a fresh response", rec.Body.String(), rec.Header().Get(ResponseCacheHeader))
+	}
+
+	rc.Invalidate(context.Background())
+	serveAs(h, "admin", http.MethodGet, "/sources")
+	if *calls != 4 {
+		t.Errorf("handler calls = %d, want 4", *calls)
+	}
+	if stats := rc.Stats(); stats.Invalidations != 2 {
+		t.Errorf("invalidations = %d, want 2", stats.Invalidations)
+	}
+}
+
+func TestResponseCache_Nil(t *testing.T) {
+	var rc *ResponseCache
+	next := http.NotFoundHandler()
+	rc.Invalidate(context.Background())
+	if h := rc.Middleware(next); h == nil {
+		t.Error("nil cache should return next")
+	}
+}
@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/hmac"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/pathutil"
+	"catchup-feed/internal/handler/http/respond"
+)
+
+// Inbound webhook headers. They default to the ones our own outgoing
+// deliveries carry (jobs.WebhookTimestampHeader / WebhookSignatureHeader),
+// so the signing scheme is the same in both directions.
+const (
+	DefaultWebhookTimestampHeader = "X-Catchup-Timestamp"
+	DefaultWebhookSignatureHeader = "X-Catchup-Signature"
+)
+
+// DefaultWebhookTolerance is how far a delivery's timestamp may be from
+// now, in either direction, before it is rejected as stale (replay window).
+const DefaultWebhookTolerance = 5 * time.Minute
+
+// webhookSignaturePrefix prefixes the hex HMAC in the signature header.
+const webhookSignaturePrefix = "sha256="
+
+var (
+	errWebhookSignatureRequired = errors.New("webhook signature and timestamp are required")
+	errWebhookTimestamp         = errors.New("invalid webhook timestamp: missing, malformed or outside the allowed window")
+	errWebhookSignature         = errors.New("invalid webhook signature")
+)
+
+// WebhookSignatureConfig configures the inbound webhook verification.
+type WebhookSignatureConfig struct {
+	// Secrets maps an endpoint path to its shared secrets. Any one of them
+	// may sign a delivery, so a secret can be rotated without downtime.
+	// Paths not listed here pass through unchecked.
+	Secrets map[string][]string
+
+	// TimestampHeader / SignatureHeader name the headers; empty means
+	// DefaultWebhookTimestampHeader / DefaultWebhookSignatureHeader.
+	TimestampHeader string
+	SignatureHeader string
+
+	// Tolerance is the accepted clock difference; 0 means
+	// DefaultWebhookTolerance.
+	Tolerance time.Duration
+
+	// Now returns the current time; nil means time.Now.
+	Now func() time.Time
+
+	// Logger records rejected deliveries; nil means slog.Default().
+	Logger *slog.Logger
+}
+
+// WebhookSignature returns a middleware that verifies inbound webhook
+// deliveries to the endpoints in cfg.Secrets. A delivery must carry a
+// Unix timestamp within Tolerance of now and the signature
+// "sha256=<hex HMAC-SHA256(secret, "<timestamp>.<body>")>"
+// (entity.SignWebhookPayload); otherwise it is rejected with 401 before
+// the handler runs. The body is buffered for the check and handed to the
+// handler unchanged, so the middleware belongs inside the body limit.
+func WebhookSignature(cfg WebhookSignatureConfig) func(http.Handler) http.Handler {
+	timestampHeader := cmp.Or(cfg.TimestampHeader, DefaultWebhookTimestampHeader)
+	signatureHeader := cmp.Or(cfg.SignatureHeader, DefaultWebhookSignatureHeader)
+	tolerance := cfg.Tolerance
+	if tolerance <= 0 {
+		tolerance = DefaultWebhookTolerance
+	}
+	now := cfg.Now
+	if now == nil {
+		now = time.Now
+	}
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			secrets, ok := cfg.Secrets[r.URL.Path]
+			if !ok {
+				secrets, ok = cfg.Secrets[strings.TrimSuffix(r.URL.Path, "/")]
+			}
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			reject := func(err error) {
+				logger.Warn("webhook delivery rejected",
+					slog.String("path", pathutil.RedactPath(r.URL.Path)),
+					slog.String("reason", err.Error()))
+				respond.SafeError(w, http.StatusUnauthorized, err)
+			}
+
+			rawTimestamp := r.Header.Get(timestampHeader)
+			signature := r.Header.Get(signatureHeader)
+			if rawTimestamp == "" || signature == "" {
+				reject(errWebhookSignatureRequired)
+				return
+			}
+			timestamp, err := strconv.ParseInt(rawTimestamp, 10, 64)
+			if err != nil || now().Sub(time.Unix(timestamp, 0)).Abs() > tolerance {
+				reject(errWebhookTimestamp)
+				return
+			}
+			got, err := decodeWebhookSignature(signature)
+			if err != nil {
+				reject(errWebhookSignature)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				var maxBytes *http.MaxBytesError
+				if errors.As(err, &maxBytes) {
+					respond.SafeError(w, http.StatusRequestEntityTooLarge, errors.New("request body too long"))
+					return
+				}
+				respond.SafeError(w, http.StatusBadRequest, errors.New("invalid request body"))
+				return
+			}
+			if !webhookSignatureValid(secrets, timestamp, body, got) {
+				reject(errWebhookSignature)
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// decodeWebhookSignature strips the sha256= prefix and returns the hex
+// digest in lowercase.
+func decodeWebhookSignature(header string) (string, error) {
+	digest, ok := strings.CutPrefix(strings.TrimSpace(header), webhookSignaturePrefix)
+	if !ok || digest == "" {
+		return "", errWebhookSignature
+	}
+	return strings.ToLower(digest), nil
+}
+
+// webhookSignatureValid reports whether any secret produced got, comparing
+// in constant time.
+func webhookSignatureValid(secrets []string, timestamp int64, body []byte, got string) bool {
+	valid := false
+	for _, secret := range secrets {
+		want := entity.SignWebhookPayload(secret, timestamp, body)
+		if hmac.Equal([]byte(want), []byte(got)) {
+			valid = true
+		}
+	}
+	return valid
+}
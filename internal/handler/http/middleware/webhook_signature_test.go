@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+const testWebhookSecret = "test-webhook-secret"
+
+var testWebhookNow = time.Unix(1_760_000_000, 0)
+
+// newWebhookHandler returns the verified handler and a pointer to the body
+// the handler received.
+func newWebhookHandler(cfg WebhookSignatureConfig) (http.Handler, *string) {
+	if cfg.Secrets == nil {
+		cfg.Secrets = map[string][]string{"/hooks/websub": {testWebhookSecret}}
+	}
+	cfg.Now = func() time.Time { return testWebhookNow }
+	received := new(string)
+	return WebhookSignature(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*received = string(body)
+		w.WriteHeader(http.StatusNoContent)
+	})), received
+}
+
+func signedWebhookRequest(path, body, secret string, timestamp time.Time) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	ts := timestamp.Unix()
+	req.Header.Set(DefaultWebhookTimestampHeader, strconv.FormatInt(ts, 10))
+	req.Header.Set(DefaultWebhookSignatureHeader, "sha256="+entity.SignWebhookPayload(secret, ts, []byte(body)))
+	return req
+}
+
+// TestWebhookSignature_Valid tests that a signed delivery reaches the
+// handler with its body intact
+func TestWebhookSignature_Valid(t *testing.T) {
+	handler, received := newWebhookHandler(WebhookSignatureConfig{})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedWebhookRequest("/hooks/websub", `{"feed":"x"}`, testWebhookSecret, testWebhookNow.Add(-time.Minute)))
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d (body %s)", rec.Code, http.StatusNoContent, rec.Body)
+	}
+	if *received != `{"feed":"x"}` {
+		t.Errorf("handler body = %q, want the original body", *received)
+	}
+}
+
+// TestWebhookSignature_Rejects tests unsigned, stale and forged deliveries
+func TestWebhookSignature_Rejects(t *testing.T) {
+	handler, received := newWebhookHandler(WebhookSignatureConfig{})
+
+	tampered := signedWebhookRequest("/hooks/websub", `{"feed":"x"}`, testWebhookSecret, testWebhookNow)
+	tampered.Body = io.NopCloser(strings.NewReader(`{"feed":"y"}`))
+	unsigned := httptest.NewRequest(http.MethodPost, "/hooks/websub", strings.NewReader("{}"))
+	noPrefix := signedWebhookRequest("/hooks/websub", "{}", testWebhookSecret, testWebhookNow)
+	noPrefix.Header.Set(DefaultWebhookSignatureHeader, strings.TrimPrefix(noPrefix.Header.Get(DefaultWebhookSignatureHeader), "sha256="))
+	badTimestamp := signedWebhookRequest("/hooks/websub", "{}", testWebhookSecret, testWebhookNow)
+	badTimestamp.Header.Set(DefaultWebhookTimestampHeader, "yesterday")
+
+	tests := []struct {
+		name string
+		req  *http.Request
+	}{
+		{"unsigned", unsigned},
+		{"stale", signedWebhookRequest("/hooks/websub", "{}", testWebhookSecret, testWebhookNow.Add(-6*time.Minute))},
+		{"from the future", signedWebhookRequest("/hooks/websub", "{}", testWebhookSecret, testWebhookNow.Add(6*time.Minute))},
+		{"malformed timestamp", badTimestamp},
+		{"wrong secret", signedWebhookRequest("/hooks/websub/", "{}", "other-secret", testWebhookNow)},
+		{"tampered body", tampered},
+		{"missing sha256= prefix", noPrefix},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			*received = ""
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, tt.req)
+			if rec.Code != http.StatusUnauthorized {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+			}
+			if *received != "" {
+				t.Error("handler ran for a rejected delivery")
+			}
+		})
+	}
+}
+
+// TestWebhookSignature_Options tests custom headers, tolerance and
+// unconfigured paths
+func TestWebhookSignature_Options(t *testing.T) {
+	handler, _ := newWebhookHandler(WebhookSignatureConfig{
+		TimestampHeader: "X-Hub-Timestamp",
+		SignatureHeader: "X-Hub-Signature-256",
+		Tolerance:       time.Hour,
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/hooks/websub", strings.NewReader("{}"))
+	ts := testWebhookNow.Add(-30 * time.Minute).Unix()
+	req.Header.Set("X-Hub-Timestamp", strconv.FormatInt(ts, 10))
+	req.Header.Set("X-Hub-Signature-256", "sha256="+entity.SignWebhookPayload(testWebhookSecret, ts, []byte("{}")))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("custom headers: status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/articles", strings.NewReader("{}")))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("unconfigured path: status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+}
+
+// TestWebhookSignature_BodyTooLarge tests that the body limit still applies
+func TestWebhookSignature_BodyTooLarge(t *testing.T) {
+	handler, _ := newWebhookHandler(WebhookSignatureConfig{})
+	limited := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, 4)
+		handler.ServeHTTP(w, r)
+	})
+
+	rec := httptest.NewRecorder()
+	limited.ServeHTTP(rec, signedWebhookRequest("/hooks/websub", `{"feed":"x"}`, testWebhookSecret, testWebhookNow))
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusRequestEntityTooLarge)
+	}
+}
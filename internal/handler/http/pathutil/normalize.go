@@ -19,11 +19,20 @@ var pathPatterns = []*PathPattern{
 	{Pattern: regexp.MustCompile(`^/articles/\d+$`), Template: "/articles/:id"},
 	{Pattern: regexp.MustCompile(`^/articles/\d+/comments$`), Template: "/articles/:id/comments"},
 	{Pattern: regexp.MustCompile(`^/articles/\d+/related$`), Template: "/articles/:id/related"},
+	{Pattern: regexp.MustCompile(`^/articles/\d+/content$`), Template: "/articles/:id/content"},
+	{Pattern: regexp.MustCompile(`^/articles/\d+/duplicates$`), Template: "/articles/:id/duplicates"},
+	{Pattern: regexp.MustCompile(`^/articles/\d+/summarize$`), Template: "/articles/:id/summarize"},
 
 	// Source routes with IDs
 	{Pattern: regexp.MustCompile(`^/sources/\d+$`), Template: "/sources/:id"},
 	{Pattern: regexp.MustCompile(`^/sources/\d+/articles$`), Template: "/sources/:id/articles"},
 	{Pattern: regexp.MustCompile(`^/sources/\d+/stats$`), Template: "/sources/:id/stats"},
+	{Pattern: regexp.MustCompile(`^/sources/\d+/health$`), Template: "/sources/:id/health"},
+	{Pattern: regexp.MustCompile(`^/sources/\d+/crawl$`), Template: "/sources/:id/crawl"},
+
+	// Crawl job and crawl history routes with IDs
+	{Pattern: regexp.MustCompile(`^/crawl/jobs/\d+$`), Template: "/crawl/jobs/:id"},
+	{Pattern: regexp.MustCompile(`^/crawls/\d+$`), Template: "/crawls/:id"},
 
 	// User routes with IDs (if applicable in the future)
 	{Pattern: regexp.MustCompile(`^/users/\d+$`), Template: "/users/:id"},
@@ -68,11 +68,46 @@ func TestNormalizePath(t *testing.T) {
 			path:     "/sources/123/articles",
 			expected: "/sources/:id/articles",
 		},
+		{
+			name:     "article content",
+			path:     "/articles/321/content",
+			expected: "/articles/:id/content",
+		},
+		{
+			name:     "article duplicates",
+			path:     "/articles/321/duplicates",
+			expected: "/articles/:id/duplicates",
+		},
+		{
+			name:     "article summarize",
+			path:     "/articles/321/summarize",
+			expected: "/articles/:id/summarize",
+		},
 		{
 			name:     "source stats",
 			path:     "/sources/456/stats",
 			expected: "/sources/:id/stats",
 		},
+		{
+			name:     "source health",
+			path:     "/sources/789/health",
+			expected: "/sources/:id/health",
+		},
+		{
+			name:     "source crawl trigger",
+			path:     "/sources/12/crawl",
+			expected: "/sources/:id/crawl",
+		},
+		{
+			name:     "crawl job status",
+			path:     "/crawl/jobs/34",
+			expected: "/crawl/jobs/:id",
+		},
+		{
+			name:     "crawl run",
+			path:     "/crawls/56",
+			expected: "/crawls/:id",
+		},
 
 		// User routes with IDs (should be normalized)
 		{
@@ -0,0 +1,200 @@
+// Package ratelimit provides the admin-only inspection API over the HTTP
+// rate limiters (auth / search / feed and the RATE_LIMIT_ROUTES policies):
+// which clients are being limited, where one client stands in its window,
+// and a reset for a client that was limited by mistake.
+package ratelimit
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/handler/http/middleware"
+	"catchup-feed/internal/handler/http/respond"
+)
+
+const (
+	defaultTop = 10
+	maxTop     = 100
+)
+
+var (
+	errScopeRequired = errors.New("scope is required")
+	errKeyRequired   = errors.New("key is required")
+	errScopeNotFound = errors.New("rate limit scope not found")
+	errInvalidTop    = errors.New("invalid top: must be between 1 and 100")
+)
+
+// Limiters is the set of limiters the API inspects, looked up by scope.
+type Limiters []*middleware.RateLimiter
+
+func (ls Limiters) find(scope string) *middleware.RateLimiter {
+	for _, l := range ls {
+		if l.Scope() == scope {
+			return l
+		}
+	}
+	return nil
+}
+
+// ScopeDTO is one limiter with its most-limited clients.
+type ScopeDTO struct {
+	Scope         string                   `json:"scope"`
+	Limit         int                      `json:"limit"`
+	WindowSeconds int64                    `json:"window_seconds"`
+	TopDenied     []entity.RateLimitDenial `json:"top_denied"`
+}
+
+// KeyDTO is one client's position in a limiter's current window.
+type KeyDTO struct {
+	Scope         string `json:"scope"`
+	Key           string `json:"key"`
+	Count         int    `json:"count"`
+	Remaining     int    `json:"remaining"`
+	Limit         int    `json:"limit"`
+	WindowSeconds int64  `json:"window_seconds"`
+}
+
+type ListHandler struct{ Limiters Limiters }
+
+// ServeHTTP レート制限状況一覧取得
+// @Summary      レート制限状況一覧取得
+// @Description  各レートリミッタ(scope: auth / search / feed / route:<pattern>)の上限・窓と、
+// @Description  現在の窓で拒否回数の多いクライアント(キーはクライアント IP)を取得します。admin 専用
+// @Tags         rate-limits
+// @Security     BearerAuth
+// @Produce      json
+// @Param        top query int false "scope ごとに返す上位件数(デフォルト: 10、最大: 100)"
+// @Success      200 {array} ScopeDTO "レートリミッタ一覧"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid query parameter"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /rate-limits [get]
+func (h ListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	top := defaultTop
+	if raw := r.URL.Query().Get("top"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > maxTop {
+			respond.SafeError(w, http.StatusBadRequest, errInvalidTop)
+			return
+		}
+		top = n
+	}
+
+	out := make([]ScopeDTO, 0, len(h.Limiters))
+	for _, l := range h.Limiters {
+		denied, err := l.TopDenied(r.Context(), top)
+		if err != nil {
+			respond.SafeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if denied == nil {
+			denied = []entity.RateLimitDenial{}
+		}
+		out = append(out, ScopeDTO{
+			Scope:         l.Scope(),
+			Limit:         l.Limit(),
+			WindowSeconds: int64(l.Window().Seconds()),
+			TopDenied:     denied,
+		})
+	}
+	respond.JSON(w, http.StatusOK, out)
+}
+
+type StatusHandler struct{ Limiters Limiters }
+
+// ServeHTTP クライアント別レート制限状況取得
+// @Summary      クライアント別レート制限状況取得
+// @Description  指定 scope の現在の窓における、クライアント(key = IP)のリクエスト数と残り回数を取得します。admin 専用
+// @Tags         rate-limits
+// @Security     BearerAuth
+// @Produce      json
+// @Param        scope query string true "レートリミッタの scope(auth / search / feed / route:<pattern>)"
+// @Param        key query string true "クライアント IP"
+// @Success      200 {object} KeyDTO "クライアントのレート制限状況"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - scope / key 未指定"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      404 {object} respond.ErrorResponse "scope が存在しない"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /rate-limits/keys [get]
+func (h StatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	l, key, ok := h.Limiters.resolve(w, r)
+	if !ok {
+		return
+	}
+	count, err := l.Count(r.Context(), key)
+	if err != nil {
+		respond.SafeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, KeyDTO{
+		Scope:         l.Scope(),
+		Key:           key,
+		Count:         count,
+		Remaining:     max(l.Limit()-count, 0),
+		Limit:         l.Limit(),
+		WindowSeconds: int64(l.Window().Seconds()),
+	})
+}
+
+type ResetHandler struct{ Limiters Limiters }
+
+// ServeHTTP クライアント別レート制限リセット
+// @Summary      クライアント別レート制限リセット
+// @Description  指定 scope におけるクライアント(key = IP)の窓をリセットし、即座にリクエストを許可します。admin 専用
+// @Tags         rate-limits
+// @Security     BearerAuth
+// @Param        scope query string true "レートリミッタの scope(auth / search / feed / route:<pattern>)"
+// @Param        key query string true "クライアント IP"
+// @Success      204 "リセット成功"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - scope / key 未指定"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      404 {object} respond.ErrorResponse "scope が存在しない"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /rate-limits/keys [delete]
+func (h ResetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	l, key, ok := h.Limiters.resolve(w, r)
+	if !ok {
+		return
+	}
+	if err := l.Reset(r.Context(), key); err != nil {
+		respond.SafeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// resolve reads the scope / key query parameters, writing the 400 / 404
+// response itself when they do not name a limiter and client.
+func (ls Limiters) resolve(w http.ResponseWriter, r *http.Request) (*middleware.RateLimiter, string, bool) {
+	q := r.URL.Query()
+	scope, key := q.Get("scope"), q.Get("key")
+	switch {
+	case scope == "":
+		respond.SafeError(w, http.StatusBadRequest, errScopeRequired)
+		return nil, "", false
+	case key == "":
+		respond.SafeError(w, http.StatusBadRequest, errKeyRequired)
+		return nil, "", false
+	}
+	l := ls.find(scope)
+	if l == nil {
+		respond.SafeError(w, http.StatusNotFound, errScopeNotFound)
+		return nil, "", false
+	}
+	return l, key, true
+}
+
+// Register registers the rate limit inspection routes (C-21 flat paths).
+// They are admin-only: viewers are not on the allowlist and auth.Authz
+// keeps them protected even without the outer Authz.
+func Register(mux *http.ServeMux, limiters Limiters) {
+	mux.Handle("GET /rate-limits", auth.Authz(ListHandler{limiters}))
+	mux.Handle("GET /rate-limits/keys", auth.Authz(StatusHandler{limiters}))
+	mux.Handle("DELETE /rate-limits/keys", auth.Authz(ResetHandler{limiters}))
+}
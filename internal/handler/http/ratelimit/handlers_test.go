@@ -0,0 +1,116 @@
+package ratelimit_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/handler/http/middleware"
+	hratelimit "catchup-feed/internal/handler/http/ratelimit"
+)
+
+type fixedIP string
+
+func (ip fixedIP) ExtractIP(*http.Request) (string, error) { return string(ip), nil }
+
+// newMux wires the handlers without auth (auth.Authz is covered by the
+// auth package) over one in-memory "auth" limiter that 10.0.0.1 has
+// exceeded (3 denials) and 10.0.0.2 has used once.
+func newMux(t *testing.T) *http.ServeMux {
+	t.Helper()
+	store := middleware.NewMemoryRateLimitStore()
+	limiter := middleware.NewRateLimiterWithStore("auth", 2, time.Minute, fixedIP("10.0.0.1"), store)
+	ok := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	for range 5 {
+		ok.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+	other := middleware.NewRateLimiterWithStore("auth", 2, time.Minute, fixedIP("10.0.0.2"), store)
+	other.Middleware(http.NotFoundHandler()).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	limiters := hratelimit.Limiters{limiter}
+	mux := http.NewServeMux()
+	mux.Handle("GET /rate-limits", hratelimit.ListHandler{Limiters: limiters})
+	mux.Handle("GET /rate-limits/keys", hratelimit.StatusHandler{Limiters: limiters})
+	mux.Handle("DELETE /rate-limits/keys", hratelimit.ResetHandler{Limiters: limiters})
+	return mux
+}
+
+func do(mux *http.ServeMux, method, target string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+	return rec
+}
+
+func TestListHandler(t *testing.T) {
+	mux := newMux(t)
+
+	rec := do(mux, http.MethodGet, "/rate-limits")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var got []hratelimit.ScopeDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Len(t, got, 1)
+	assert.Equal(t, "auth", got[0].Scope)
+	assert.Equal(t, 2, got[0].Limit)
+	assert.Equal(t, int64(60), got[0].WindowSeconds)
+	require.Len(t, got[0].TopDenied, 1)
+	assert.Equal(t, "10.0.0.1", got[0].TopDenied[0].Key)
+	assert.Equal(t, 3, got[0].TopDenied[0].Denied)
+
+	assert.Equal(t, http.StatusBadRequest, do(mux, http.MethodGet, "/rate-limits?top=0").Code)
+	assert.Equal(t, http.StatusBadRequest, do(mux, http.MethodGet, "/rate-limits?top=abc").Code)
+}
+
+func TestStatusHandler(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		wantCode      int
+		wantCount     int
+		wantRemaining int
+	}{
+		{name: "limited client", query: "?scope=auth&key=10.0.0.1", wantCode: http.StatusOK, wantCount: 2, wantRemaining: 0},
+		{name: "partially used", query: "?scope=auth&key=10.0.0.2", wantCode: http.StatusOK, wantCount: 1, wantRemaining: 1},
+		{name: "unseen client", query: "?scope=auth&key=10.0.0.9", wantCode: http.StatusOK, wantCount: 0, wantRemaining: 2},
+		{name: "unknown scope", query: "?scope=nope&key=10.0.0.1", wantCode: http.StatusNotFound},
+		{name: "missing scope", query: "?key=10.0.0.1", wantCode: http.StatusBadRequest},
+		{name: "missing key", query: "?scope=auth", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := newMux(t)
+			rec := do(mux, http.MethodGet, "/rate-limits/keys"+tt.query)
+			require.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var got hratelimit.KeyDTO
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			assert.Equal(t, tt.wantCount, got.Count)
+			assert.Equal(t, tt.wantRemaining, got.Remaining)
+		})
+	}
+}
+
+func TestResetHandler(t *testing.T) {
+	mux := newMux(t)
+
+	rec := do(mux, http.MethodDelete, "/rate-limits/keys?scope=auth&key=10.0.0.1")
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = do(mux, http.MethodGet, "/rate-limits/keys?scope=auth&key=10.0.0.1")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var got hratelimit.KeyDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, 0, got.Count)
+	assert.Equal(t, 2, got.Remaining)
+
+	assert.Equal(t, http.StatusNotFound, do(mux, http.MethodDelete, "/rate-limits/keys?scope=nope&key=10.0.0.1").Code)
+	assert.Equal(t, http.StatusBadRequest, do(mux, http.MethodDelete, "/rate-limits/keys?scope=auth").Code)
+}
@@ -0,0 +1,117 @@
+// Package readstate provides the per-user read/unread HTTP handlers:
+// single-article marks (/articles/{id}/read), bulk marks (/articles/read,
+// /articles/unread), unread counts per source (/articles/unread-counts) and
+// cross-device delta sync (/articles/read-state), following the flat-path
+// convention (C-21).
+package readstate
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"catchup-feed/internal/handler/http/pathutil"
+	"catchup-feed/internal/repository"
+	readstateUC "catchup-feed/internal/usecase/readstate"
+)
+
+// BulkRequest is the POST /articles/read and /articles/unread body.
+type BulkRequest struct {
+	ArticleIDs []int64 `json:"article_ids" example:"1,2,3"`
+}
+
+// BulkResponse reports how many articles changed state.
+type BulkResponse struct {
+	Updated int64 `json:"updated" example:"3"`
+}
+
+// SourceUnreadDTO is the unread count of one source.
+type SourceUnreadDTO struct {
+	SourceID   int64  `json:"source_id" example:"1"`
+	SourceName string `json:"source_name" example:"Go Blog"`
+	Unread     int64  `json:"unread" example:"5"`
+}
+
+// UnreadCountsResponse is the GET /articles/unread-counts body.
+type UnreadCountsResponse struct {
+	Total   int64             `json:"total" example:"12"`
+	Sources []SourceUnreadDTO `json:"sources"`
+}
+
+func toUnreadCountsResponse(counts []repository.SourceUnreadCount) UnreadCountsResponse {
+	resp := UnreadCountsResponse{Sources: make([]SourceUnreadDTO, 0, len(counts))}
+	for _, c := range counts {
+		resp.Total += c.Unread
+		resp.Sources = append(resp.Sources, SourceUnreadDTO{SourceID: c.SourceID, SourceName: c.SourceName, Unread: c.Unread})
+	}
+	return resp
+}
+
+// ChangeDTO is one read-state change: the article became read (read=true)
+// or unread at changed_at.
+type ChangeDTO struct {
+	ArticleID int64     `json:"article_id" example:"1"`
+	Read      bool      `json:"read" example:"true"`
+	ChangedAt time.Time `json:"changed_at" example:"2026-10-01T09:00:00Z"`
+}
+
+// SyncRequest is the POST /articles/read-state/sync body: the changes made
+// on the device since its last sync and the synced_at of that sync.
+type SyncRequest struct {
+	LastSyncedAt *time.Time  `json:"last_synced_at" example:"2026-10-01T08:00:00Z"`
+	Changes      []ChangeDTO `json:"changes"`
+}
+
+// SyncResponse is the GET /articles/read-state and POST
+// /articles/read-state/sync body. Clients store synced_at and send it as
+// the next since / last_synced_at.
+type SyncResponse struct {
+	Changes  []ChangeDTO `json:"changes"`
+	Applied  int64       `json:"applied" example:"2"`
+	SyncedAt time.Time   `json:"synced_at" example:"2026-10-01T09:00:00Z"`
+}
+
+var errInvalidSince = errors.New("invalid since: must be an RFC3339 timestamp")
+
+// parseSince reads the optional since query parameter; absent means the
+// zero time (the full state).
+func parseSince(r *http.Request) (time.Time, error) {
+	raw := r.URL.Query().Get("since")
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	since, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, errInvalidSince
+	}
+	return since, nil
+}
+
+func fromChangeDTOs(dtos []ChangeDTO) []repository.ReadStateChange {
+	changes := make([]repository.ReadStateChange, 0, len(dtos))
+	for _, c := range dtos {
+		changes = append(changes, repository.ReadStateChange{ArticleID: c.ArticleID, Read: c.Read, ChangedAt: c.ChangedAt})
+	}
+	return changes
+}
+
+func toSyncResponse(result *readstateUC.SyncResult) SyncResponse {
+	resp := SyncResponse{
+		Changes:  make([]ChangeDTO, 0, len(result.Changes)),
+		Applied:  result.Applied,
+		SyncedAt: result.SyncedAt,
+	}
+	for _, c := range result.Changes {
+		resp.Changes = append(resp.Changes, ChangeDTO{ArticleID: c.ArticleID, Read: c.Read, ChangedAt: c.ChangedAt})
+	}
+	return resp
+}
+
+func pathID(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, pathutil.ErrInvalidID
+	}
+	return id, nil
+}
@@ -0,0 +1,200 @@
+package readstate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/handler/http/respond"
+	readstateUC "catchup-feed/internal/usecase/readstate"
+)
+
+type MarkReadHandler struct{ Svc *readstateUC.Service }
+
+// ServeHTTP 記事を既読にする
+// @Summary      記事を既読にする
+// @Description  呼び出し元ユーザーについて記事を既読にします。既読済みの場合は既読日時を更新します。
+// @Tags         read-state
+// @Security     BearerAuth
+// @Param        id path int true "記事 ID"
+// @Success      204 "既読にした"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid ID"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - ユーザーアカウントが必要"
+// @Failure      404 {object} respond.ErrorResponse "Not found - 記事が存在しない"
+// @Router       /articles/{id}/read [put]
+func (h MarkReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.Svc.MarkArticleRead(r.Context(), auth.SubjectFromContext(r.Context()), id); err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type MarkUnreadHandler struct{ Svc *readstateUC.Service }
+
+// ServeHTTP 記事を未読に戻す
+// @Summary      記事を未読に戻す
+// @Description  呼び出し元ユーザーについて記事の既読を解除します。未読の記事に対しても成功します(冪等)。
+// @Tags         read-state
+// @Security     BearerAuth
+// @Param        id path int true "記事 ID"
+// @Success      204 "未読に戻した"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid ID"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - ユーザーアカウントが必要"
+// @Router       /articles/{id}/read [delete]
+func (h MarkUnreadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if _, err := h.Svc.MarkUnread(r.Context(), auth.SubjectFromContext(r.Context()), []int64{id}); err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type BulkMarkReadHandler struct{ Svc *readstateUC.Service }
+
+// ServeHTTP 記事を一括で既読にする
+// @Summary      記事を一括で既読にする
+// @Description  指定した記事(最大500件)を既読にします。存在しない ID は無視し、既読にした件数を返します。
+// @Tags         read-state
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body BulkRequest true "記事 ID のリスト"
+// @Success      200 {object} BulkResponse "既読にした件数"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - ID リストが不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - ユーザーアカウントが必要"
+// @Router       /articles/read [post]
+func (h BulkMarkReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveBulk(w, r, h.Svc.MarkRead)
+}
+
+type BulkMarkUnreadHandler struct{ Svc *readstateUC.Service }
+
+// ServeHTTP 記事を一括で未読に戻す
+// @Summary      記事を一括で未読に戻す
+// @Description  指定した記事(最大500件)の既読を解除し、既読だった件数を返します。
+// @Tags         read-state
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body BulkRequest true "記事 ID のリスト"
+// @Success      200 {object} BulkResponse "未読に戻した件数"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - ID リストが不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - ユーザーアカウントが必要"
+// @Router       /articles/unread [post]
+func (h BulkMarkUnreadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveBulk(w, r, h.Svc.MarkUnread)
+}
+
+// serveBulk decodes a BulkRequest and applies mark to the caller's state.
+func serveBulk(w http.ResponseWriter, r *http.Request, mark func(ctx context.Context, subject string, ids []int64) (int64, error)) {
+	var req BulkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	n, err := mark(r.Context(), auth.SubjectFromContext(r.Context()), req.ArticleIDs)
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, BulkResponse{Updated: n})
+}
+
+type UnreadCountsHandler struct{ Svc *readstateUC.Service }
+
+// ServeHTTP ソースごとの未読件数取得
+// @Summary      ソースごとの未読件数取得
+// @Description  呼び出し元ユーザーの未読記事数を有効なソースごとに返します。
+// @Tags         read-state
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} UnreadCountsResponse "未読件数"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - ユーザーアカウントが必要"
+// @Router       /articles/unread-counts [get]
+func (h UnreadCountsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	counts, err := h.Svc.UnreadCounts(r.Context(), auth.SubjectFromContext(r.Context()))
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toUnreadCountsResponse(counts))
+}
+
+type ReadStateHandler struct{ Svc *readstateUC.Service }
+
+// ServeHTTP 既読状態の差分取得
+// @Summary      既読状態の差分取得
+// @Description  呼び出し元ユーザーの既読・未読の変更のうち since 以降のものを古い順に返します。since を省略すると全記事の既読状態を返します。次回は応答の synced_at を since に指定します。
+// @Tags         read-state
+// @Security     BearerAuth
+// @Produce      json
+// @Param        since query string false "前回の synced_at (RFC3339)"
+// @Success      200 {object} SyncResponse "since 以降の変更"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - since が不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - ユーザーアカウントが必要"
+// @Router       /articles/read-state [get]
+func (h ReadStateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	since, err := parseSince(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	result, err := h.Svc.Sync(r.Context(), auth.SubjectFromContext(r.Context()), since, nil)
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toSyncResponse(result))
+}
+
+type SyncHandler struct{ Svc *readstateUC.Service }
+
+// ServeHTTP 既読状態の同期
+// @Summary      既読状態の同期
+// @Description  端末でオフライン中に行った既読・未読の変更(最大1000件)を取り込み、last_synced_at 以降の変更を返します。同じ記事の変更は changed_at が新しい方が勝ちます(未来の時刻はサーバー時刻に丸めます)。存在しない記事の変更は無視します。applied は取り込まれた変更の件数です。
+// @Tags         read-state
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body SyncRequest true "端末側の変更と前回の synced_at"
+// @Success      200 {object} SyncResponse "last_synced_at 以降の変更"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - 変更リストが不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - ユーザーアカウントが必要"
+// @Router       /articles/read-state/sync [post]
+func (h SyncHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req SyncRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var since time.Time
+	if req.LastSyncedAt != nil {
+		since = *req.LastSyncedAt
+	}
+	result, err := h.Svc.Sync(r.Context(), auth.SubjectFromContext(r.Context()), since, fromChangeDTOs(req.Changes))
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toSyncResponse(result))
+}
@@ -0,0 +1,223 @@
+package readstate_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/handler/http/readstate"
+	"catchup-feed/internal/repository"
+	readstateUC "catchup-feed/internal/usecase/readstate"
+)
+
+/* ───────── モック実装 ───────── */
+
+type stubUserRepo struct {
+	repository.UserRepository
+}
+
+func (stubUserRepo) GetActiveByEmail(_ context.Context, email string) (*entity.User, error) {
+	if email == "alice@example.com" {
+		return &entity.User{ID: 7, Email: email}, nil
+	}
+	return nil, nil
+}
+
+type stubStates struct {
+	read   map[int64]bool
+	merged []repository.ReadStateChange
+}
+
+func (s *stubStates) MarkRead(_ context.Context, _ int64, ids []int64, _ time.Time) (int64, error) {
+	var n int64
+	for _, id := range ids {
+		if id < 100 { // 100 以上は存在しない記事
+			s.read[id] = true
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *stubStates) MarkUnread(_ context.Context, _ int64, ids []int64, _ time.Time) (int64, error) {
+	var n int64
+	for _, id := range ids {
+		if s.read[id] {
+			delete(s.read, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *stubStates) UnreadCounts(context.Context, int64) ([]repository.SourceUnreadCount, error) {
+	return []repository.SourceUnreadCount{
+		{SourceID: 1, SourceName: "Go Blog", Unread: 3},
+		{SourceID: 2, SourceName: "Rust Blog", Unread: 2},
+	}, nil
+}
+
+func (s *stubStates) Changes(_ context.Context, _ int64, since time.Time) ([]repository.ReadStateChange, error) {
+	var out []repository.ReadStateChange
+	for _, c := range s.merged {
+		if !c.ChangedAt.Before(since) {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (s *stubStates) Merge(_ context.Context, _ int64, changes []repository.ReadStateChange) (int64, error) {
+	s.merged = append(s.merged, changes...)
+	return int64(len(changes)), nil
+}
+
+func newMux() (*http.ServeMux, *stubStates) {
+	states := &stubStates{read: map[int64]bool{}}
+	svc := &readstateUC.Service{Users: stubUserRepo{}, States: states}
+	// スコープ判定は auth パッケージでテスト済みのため、ハンドラを直接登録する。
+	mux := http.NewServeMux()
+	mux.Handle("PUT /articles/{id}/read", readstate.MarkReadHandler{Svc: svc})
+	mux.Handle("DELETE /articles/{id}/read", readstate.MarkUnreadHandler{Svc: svc})
+	mux.Handle("POST /articles/read", readstate.BulkMarkReadHandler{Svc: svc})
+	mux.Handle("POST /articles/unread", readstate.BulkMarkUnreadHandler{Svc: svc})
+	mux.Handle("GET /articles/unread-counts", readstate.UnreadCountsHandler{Svc: svc})
+	mux.Handle("GET /articles/read-state", readstate.ReadStateHandler{Svc: svc})
+	mux.Handle("POST /articles/read-state/sync", readstate.SyncHandler{Svc: svc})
+	return mux, states
+}
+
+func serve(mux *http.ServeMux, method, target, body, subject string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req = req.WithContext(auth.WithIdentity(req.Context(), subject, auth.RoleViewer))
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, req)
+	return rr
+}
+
+/* ───────── テストケース ───────── */
+
+func TestRegister_NoRouteConflicts(t *testing.T) {
+	mux := http.NewServeMux()
+	// article / tag パッケージのルートと共存できること(登録時に panic しない)。
+	mux.Handle("GET    /articles/", http.NotFoundHandler())
+	mux.Handle("PUT    /articles/", http.NotFoundHandler())
+	mux.Handle("DELETE /articles/", http.NotFoundHandler())
+	mux.Handle("POST   /articles", http.NotFoundHandler())
+	mux.Handle("PUT /articles/{id}/tags", http.NotFoundHandler())
+	assert.NotPanics(t, func() {
+		readstate.Register(mux, &readstateUC.Service{Users: stubUserRepo{}, States: &stubStates{}})
+	})
+}
+
+func TestMarkReadHandlers(t *testing.T) {
+	mux, states := newMux()
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		subject  string
+		wantCode int
+	}{
+		{"mark read", http.MethodPut, "/articles/5/read", "alice@example.com", http.StatusNoContent},
+		{"unknown article", http.MethodPut, "/articles/500/read", "alice@example.com", http.StatusNotFound},
+		{"invalid id", http.MethodPut, "/articles/abc/read", "alice@example.com", http.StatusBadRequest},
+		{"api key identity", http.MethodPut, "/articles/5/read", "apikey:ci", http.StatusForbidden},
+		{"mark unread", http.MethodDelete, "/articles/5/read", "alice@example.com", http.StatusNoContent},
+		{"mark unread again", http.MethodDelete, "/articles/5/read", "alice@example.com", http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := serve(mux, tt.method, tt.target, "", tt.subject)
+			assert.Equal(t, tt.wantCode, rr.Code, rr.Body.String())
+		})
+	}
+	assert.Empty(t, states.read)
+}
+
+func TestBulkHandlers(t *testing.T) {
+	mux, states := newMux()
+
+	rr := serve(mux, http.MethodPost, "/articles/read", `{"article_ids":[1,2,3,100]}`, "alice@example.com")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var got readstate.BulkResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&got))
+	assert.Equal(t, int64(3), got.Updated)
+	assert.Len(t, states.read, 3)
+
+	rr = serve(mux, http.MethodPost, "/articles/unread", `{"article_ids":[2,4]}`, "alice@example.com")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&got))
+	assert.Equal(t, int64(1), got.Updated)
+
+	for _, body := range []string{`{"article_ids":[]}`, `{"article_ids":[0]}`, `{`} {
+		rr = serve(mux, http.MethodPost, "/articles/read", body, "alice@example.com")
+		assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+	}
+}
+
+func TestUnreadCountsHandler(t *testing.T) {
+	mux, _ := newMux()
+
+	rr := serve(mux, http.MethodGet, "/articles/unread-counts", "", "alice@example.com")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var got readstate.UnreadCountsResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&got))
+	assert.Equal(t, int64(5), got.Total)
+	require.Len(t, got.Sources, 2)
+	assert.Equal(t, "Go Blog", got.Sources[0].SourceName)
+}
+
+func TestSyncHandlers(t *testing.T) {
+	mux, states := newMux()
+
+	body := `{"last_synced_at":"2026-10-01T09:00:00Z","changes":[
+		{"article_id":1,"read":true,"changed_at":"2026-10-01T08:00:00Z"},
+		{"article_id":2,"read":false,"changed_at":"2026-10-01T10:00:00Z"}]}`
+	rr := serve(mux, http.MethodPost, "/articles/read-state/sync", body, "alice@example.com")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var got readstate.SyncResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&got))
+	assert.Equal(t, int64(2), got.Applied)
+	require.Len(t, got.Changes, 1, "only changes at or after last_synced_at")
+	assert.Equal(t, int64(2), got.Changes[0].ArticleID)
+	assert.False(t, got.Changes[0].Read)
+	assert.Len(t, states.merged, 2)
+
+	// since なしは全状態。
+	rr = serve(mux, http.MethodGet, "/articles/read-state", "", "alice@example.com")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&got))
+	assert.Len(t, got.Changes, 2)
+	assert.Zero(t, got.Applied)
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		body     string
+		subject  string
+		wantCode int
+	}{
+		{"invalid since", http.MethodGet, "/articles/read-state?since=yesterday", "", "alice@example.com", http.StatusBadRequest},
+		{"missing changed_at", http.MethodPost, "/articles/read-state/sync", `{"changes":[{"article_id":1,"read":true}]}`, "alice@example.com", http.StatusBadRequest},
+		{"malformed body", http.MethodPost, "/articles/read-state/sync", `{`, "alice@example.com", http.StatusBadRequest},
+		{"api key identity", http.MethodGet, "/articles/read-state", "", "apikey:ci", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := serve(mux, tt.method, tt.target, tt.body, tt.subject)
+			assert.Equal(t, tt.wantCode, rr.Code, rr.Body.String())
+		})
+	}
+}
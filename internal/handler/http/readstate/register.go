@@ -0,0 +1,23 @@
+package readstate
+
+import (
+	"net/http"
+
+	"catchup-feed/internal/handler/http/auth"
+	readstateUC "catchup-feed/internal/usecase/readstate"
+)
+
+// Register registers the read state routes (C-21 flat paths). Marking
+// articles read only touches the caller's own state, so every route needs
+// just articles:read (auth.RequireScope; admins hold every scope).
+func Register(mux *http.ServeMux, svc *readstateUC.Service) {
+	read := auth.RequireScope(auth.ScopeArticlesRead)
+
+	mux.Handle("PUT /articles/{id}/read", read(MarkReadHandler{svc}))
+	mux.Handle("DELETE /articles/{id}/read", read(MarkUnreadHandler{svc}))
+	mux.Handle("POST /articles/read", read(BulkMarkReadHandler{svc}))
+	mux.Handle("POST /articles/unread", read(BulkMarkUnreadHandler{svc}))
+	mux.Handle("GET /articles/unread-counts", read(UnreadCountsHandler{svc}))
+	mux.Handle("GET /articles/read-state", read(ReadStateHandler{svc}))
+	mux.Handle("POST /articles/read-state/sync", read(SyncHandler{svc}))
+}
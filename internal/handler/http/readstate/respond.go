@@ -0,0 +1,25 @@
+package readstate
+
+import (
+	"errors"
+	"net/http"
+
+	"catchup-feed/internal/handler/http/respond"
+	readstateUC "catchup-feed/internal/usecase/readstate"
+)
+
+// respondUsecaseError maps use case errors to HTTP statuses: caller
+// without a user account → 403, unknown article → 404, bad ID or change
+// list → 400, anything else → sanitized 500.
+func respondUsecaseError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, readstateUC.ErrAccountNotFound):
+		respond.SafeError(w, http.StatusForbidden, err)
+	case errors.Is(err, readstateUC.ErrArticleNotFound):
+		respond.SafeError(w, http.StatusNotFound, err)
+	case errors.Is(err, readstateUC.ErrInvalidArticleIDs), errors.Is(err, readstateUC.ErrInvalidChanges):
+		respond.SafeError(w, http.StatusBadRequest, err)
+	default:
+		respond.SafeError(w, http.StatusInternalServerError, err)
+	}
+}
@@ -4,9 +4,12 @@ package requestid
 
 import (
 	"context"
+	"log/slog"
 	"net/http"
 
 	"github.com/google/uuid"
+
+	"catchup-feed/internal/pkg/logging"
 )
 
 // contextKey is a custom type for context keys to avoid collisions.
@@ -28,8 +31,11 @@ func FromContext(ctx context.Context) string {
 	return ""
 }
 
-// WithRequestID adds a request ID to the context.
+// WithRequestID adds a request ID to the context. The ID is also attached
+// as a log attribute, so every *Context log call made while serving the
+// request carries request_id without a manual field.
 func WithRequestID(ctx context.Context, id string) context.Context {
+	ctx = logging.WithAttrs(ctx, slog.String("request_id", id))
 	return context.WithValue(ctx, RequestIDKey, id)
 }
 
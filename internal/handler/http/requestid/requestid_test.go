@@ -1,7 +1,10 @@
 package requestid
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -9,6 +12,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/pkg/logging"
 )
 
 func TestFromContext(t *testing.T) {
@@ -53,6 +58,20 @@ func TestWithRequestID(t *testing.T) {
 	assert.Equal(t, requestID, storedID)
 }
 
+// TestWithRequestID_LogAttr: the ID must reach *Context log records without
+// handlers adding it manually.
+func TestWithRequestID_LogAttr(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, slog.LevelInfo)
+
+	ctx := WithRequestID(context.Background(), "req-789")
+	logger.InfoContext(ctx, "request completed")
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, "req-789", got["request_id"])
+}
+
 func TestMiddleware_WithExistingRequestID(t *testing.T) {
 	existingID := "existing-request-id-456"
 	var capturedID string
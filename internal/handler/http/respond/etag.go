@@ -0,0 +1,61 @@
+package respond
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// JSONWithETag writes v like JSON with a weak ETag derived from the encoded
+// body, and answers 304 Not Modified without a body when the request's
+// If-None-Match already names that ETag.
+//
+// The tag hashes the response itself rather than row timestamps: article
+// responses join data that changes without touching the article row
+// (summaries, source names, the caller's favorites), so only the body
+// says whether a polling client's copy is stale. The query still runs;
+// what is saved is the transfer.
+func JSONWithETag(w http.ResponseWriter, r *http.Request, code int, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		slog.Default().Error("failed to encode JSON response",
+			slog.Int("status_code", code),
+			slog.Any("error", err))
+		JSON(w, http.StatusInternalServerError, map[string]string{"error": "internal server error"})
+		return
+	}
+	body = append(body, '\n') // same bytes as JSON (json.Encoder)
+
+	sum := sha256.Sum256(body)
+	etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	// Responses depend on the caller (favorites, scopes): never share them.
+	w.Header().Set("Cache-Control", "private, no-cache")
+
+	if code == http.StatusOK && ETagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+}
+
+// ETagMatches reports whether an If-None-Match header lists etag, using
+// the weak comparison RFC 9110 prescribes for If-None-Match.
+func ETagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for candidate := range strings.SplitSeq(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
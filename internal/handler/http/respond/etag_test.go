@@ -0,0 +1,50 @@
+package respond
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestJSONWithETag(t *testing.T) {
+	body := map[string]string{"message": "success"}
+
+	rr := httptest.NewRecorder()
+	JSONWithETag(rr, httptest.NewRequest(http.MethodGet, "/", nil), http.StatusOK, body)
+	etag := rr.Header().Get("ETag")
+	if rr.Code != http.StatusOK || rr.Body.String() != "{\"message\":\"success\"}\n" {
+		t.Fatalf("status = %d, body = %q", rr.Code, rr.Body.String())
+	}
+	if len(etag) < 4 || etag[:3] != `W/"` {
+		t.Fatalf("ETag = %q, want a weak tag", etag)
+	}
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		want        int
+	}{
+		{"same tag", etag, http.StatusNotModified},
+		{"strong form of the tag", etag[2:], http.StatusNotModified},
+		{"tag in a list", `W/"other", ` + etag, http.StatusNotModified},
+		{"wildcard", "*", http.StatusNotModified},
+		{"other tag", `W/"other"`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			rr := httptest.NewRecorder()
+			JSONWithETag(rr, req, http.StatusOK, body)
+			if rr.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rr.Code, tt.want)
+			}
+			if rr.Header().Get("ETag") != etag {
+				t.Fatalf("ETag = %q, want %q", rr.Header().Get("ETag"), etag)
+			}
+			if tt.want == http.StatusNotModified && rr.Body.Len() != 0 {
+				t.Fatalf("304 with body %q", rr.Body.String())
+			}
+		})
+	}
+}
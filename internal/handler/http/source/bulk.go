@@ -0,0 +1,57 @@
+package source
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/respond"
+	srcUC "catchup-feed/internal/usecase/source"
+)
+
+type BulkUpdateHandler struct{ Svc srcUC.Service }
+
+// ServeHTTP ソース一括更新
+// @Summary      ソース一括更新
+// @Description  指定したソース（最大500件）に同じ変更を1トランザクションで適用し、ID ごとの結果（updated / not_found）を返します。
+// @Description  季節もののソースをまとめて有効・無効にする用途を想定しています。変更できるのは active / category / lang /
+// @Description  crawl_schedule / retention_days / render_js で、意味は PUT /sources/{id} と同じです（省略・空文字は変更なし）。
+// @Description  存在しない ID はエラーにせず not_found として報告します。不正な値があればどのソースも更新しません。
+// @Tags         sources
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body BulkUpdateRequest true "更新するソース ID のリストと変更内容"
+// @Success      200 {object} BulkUpdateResponse "ID ごとの更新結果"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - ids が空・501件以上・正でない、変更内容がない、値が不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required - missing or invalid JWT token"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - sources:write が必要"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /sources/bulk [patch]
+func (h BulkUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req BulkUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	updated, err := h.Svc.BulkUpdate(r.Context(), srcUC.BulkUpdateInput{
+		IDs:           req.IDs,
+		Active:        req.Active,
+		Category:      req.Category,
+		Lang:          req.Lang,
+		CrawlSchedule: req.CrawlSchedule,
+		RetentionDays: req.RetentionDays,
+		RenderJS:      req.RenderJS,
+	})
+	if err != nil {
+		code := http.StatusInternalServerError
+		var verr *entity.ValidationError
+		if errors.Is(err, srcUC.ErrInvalidSourceIDs) || errors.Is(err, srcUC.ErrNoBulkChanges) || errors.As(err, &verr) {
+			code = http.StatusBadRequest
+		}
+		respond.SafeError(w, code, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toBulkUpdateResponse(req.IDs, updated))
+}
@@ -13,7 +13,11 @@ type CreateHandler struct{ Svc srcUC.Service }
 
 // ServeHTTP ソース作成
 // @Summary      ソース作成
-// @Description  新しいソースを作成します
+// @Description  新しいソースを作成します。crawl_schedule(cron 式 "*/30 * * * *" または間隔 "2h"、
+// @Description  最短 5 分)を指定すると worker はそのソースだけ独自のスケジュールでクロールします。
+// @Description  省略時は全体の CRON_SCHEDULE に従います。retention_days(14〜3650)を指定すると
+// @Description  そのソースの記事だけ全体の RETENTION_DAYS と異なる日数で保持します。
+// @Description  render_js を true にすると記事本文をヘッドレスブラウザで描画して取得します(worker の HEADLESS_ENABLED が必要)
 // @Tags         sources
 // @Security     BearerAuth
 // @Accept       json
@@ -37,6 +41,9 @@ func (h CreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	err := h.Svc.Create(r.Context(), srcUC.CreateInput{
 		Name: req.Name, FeedURL: req.FeedURL,
 		Category: req.Category, Lang: req.Lang, Kind: req.Kind,
+		CrawlSchedule: req.CrawlSchedule,
+		RetentionDays: req.RetentionDays,
+		RenderJS:      req.RenderJS,
 	})
 	if err != nil {
 		respond.SafeError(w, http.StatusBadRequest, err)
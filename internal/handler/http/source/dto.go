@@ -1,20 +1,32 @@
 package source
 
-import "time"
+import (
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+)
 
 // DTO mirrors the §4 sources schema (+ Phase 2 kind). Category drives the
 // radio script corner assignment; Lang defaults to 'en'; Kind is the
-// content pipeline selector (rss | youtube | podcast).
+// content pipeline selector (rss | youtube | podcast). CrawlSchedule is
+// null when the source follows the worker's global CRON_SCHEDULE, and
+// RetentionDays when it follows the global RETENTION_DAYS. RenderJS marks
+// sources whose article pages are rendered with the headless browser.
+// GroupID is the source group (folder), null when ungrouped.
 type DTO struct {
-	ID        int64     `json:"id"`
-	Name      string    `json:"name"`
-	FeedURL   string    `json:"feed_url"`
-	URL       string    `json:"url"` // Mapped from FeedURL for frontend compatibility
-	Category  string    `json:"category"`
-	Lang      string    `json:"lang"`
-	Kind      string    `json:"kind" example:"rss" enums:"rss,youtube,podcast"`
-	Active    bool      `json:"active"`
-	CreatedAt time.Time `json:"created_at"`
+	ID            int64     `json:"id"`
+	Name          string    `json:"name"`
+	FeedURL       string    `json:"feed_url"`
+	URL           string    `json:"url"` // Mapped from FeedURL for frontend compatibility
+	Category      string    `json:"category"`
+	Lang          string    `json:"lang"`
+	Kind          string    `json:"kind" example:"rss" enums:"rss,youtube,podcast"`
+	Active        bool      `json:"active"`
+	CrawlSchedule *string   `json:"crawl_schedule" example:"*/30 * * * *"`
+	RetentionDays *int      `json:"retention_days" example:"90"`
+	RenderJS      bool      `json:"render_js"`
+	GroupID       *int64    `json:"group_id" example:"1"`
+	CreatedAt     time.Time `json:"created_at"`
 }
 
 // CreateRequest is the POST /sources body. name / feedURL / category are
@@ -25,31 +37,96 @@ type CreateRequest struct {
 	Category string `json:"category" example:"go"`
 	Lang     string `json:"lang,omitempty" example:"en"`
 	Kind     string `json:"kind,omitempty" example:"rss" enums:"rss,youtube,podcast"`
+	// CrawlSchedule is a 5-field cron expression or an interval ("30m");
+	// empty follows the global CRON_SCHEDULE.
+	CrawlSchedule string `json:"crawl_schedule,omitempty" example:"*/30 * * * *"`
+	// RetentionDays keeps this source's articles for that many days
+	// (14-3650); 0 follows the global RETENTION_DAYS.
+	RetentionDays int `json:"retention_days,omitempty" example:"90"`
+	// RenderJS fetches the article pages with the headless browser, for
+	// sites that only render their content in JavaScript.
+	RenderJS bool `json:"render_js,omitempty" example:"false"`
 }
 
 // UpdateRequest is the PUT /sources/{id} body. Empty strings keep the
-// current value; active is optional (null = unchanged).
+// current value; active is optional (null = unchanged). crawl_schedule is
+// also optional: null keeps it, "" clears it (back to CRON_SCHEDULE), and
+// so is retention_days: null keeps it, 0 clears it (back to RETENTION_DAYS).
+// render_js is optional like active.
 type UpdateRequest struct {
-	Name     string `json:"name,omitempty" example:"Go Blog"`
-	FeedURL  string `json:"feedURL,omitempty" example:"https://go.dev/blog/feed.atom"`
-	Category string `json:"category,omitempty" example:"go"`
-	Lang     string `json:"lang,omitempty" example:"en"`
-	Kind     string `json:"kind,omitempty" example:"rss" enums:"rss,youtube,podcast"`
-	Active   *bool  `json:"active,omitempty" example:"true"`
+	Name          string  `json:"name,omitempty" example:"Go Blog"`
+	FeedURL       string  `json:"feedURL,omitempty" example:"https://go.dev/blog/feed.atom"`
+	Category      string  `json:"category,omitempty" example:"go"`
+	Lang          string  `json:"lang,omitempty" example:"en"`
+	Kind          string  `json:"kind,omitempty" example:"rss" enums:"rss,youtube,podcast"`
+	Active        *bool   `json:"active,omitempty" example:"true"`
+	CrawlSchedule *string `json:"crawl_schedule,omitempty" example:"2h"`
+	RetentionDays *int    `json:"retention_days,omitempty" example:"30"`
+	RenderJS      *bool   `json:"render_js,omitempty" example:"true"`
 }
 
-// fromEntityFields builds a DTO from the source entity fields shared by
-// list and search responses.
-func toDTO(id int64, name, feedURL, category, lang, kind string, active bool, createdAt time.Time) DTO {
+// toDTO builds the DTO shared by list and search responses.
+func toDTO(e *entity.Source) DTO {
 	return DTO{
-		ID:        id,
-		Name:      name,
-		FeedURL:   feedURL,
-		URL:       feedURL, // Map FeedURL to URL for frontend compatibility
-		Category:  category,
-		Lang:      lang,
-		Kind:      kind,
-		Active:    active,
-		CreatedAt: createdAt,
+		ID:            e.ID,
+		Name:          e.Name,
+		FeedURL:       e.FeedURL,
+		URL:           e.FeedURL, // Map FeedURL to URL for frontend compatibility
+		Category:      e.Category,
+		Lang:          e.Lang,
+		Kind:          e.Kind,
+		Active:        e.Active,
+		CrawlSchedule: e.CrawlSchedule,
+		RetentionDays: e.RetentionDays,
+		RenderJS:      e.RenderJS,
+		GroupID:       e.GroupID,
+		CreatedAt:     e.CreatedAt,
+	}
+}
+
+// BulkUpdateRequest is the PATCH /sources/bulk body: the change, with the
+// same optional fields as UpdateRequest, applied to every source in ids.
+// name / feedURL / kind are per source and cannot be bulk-updated.
+type BulkUpdateRequest struct {
+	IDs           []int64 `json:"ids" example:"1,2,3"`
+	Active        *bool   `json:"active,omitempty" example:"false"`
+	Category      string  `json:"category,omitempty" example:"go"`
+	Lang          string  `json:"lang,omitempty" example:"en"`
+	CrawlSchedule *string `json:"crawl_schedule,omitempty" example:"2h"`
+	RetentionDays *int    `json:"retention_days,omitempty" example:"30"`
+	RenderJS      *bool   `json:"render_js,omitempty" example:"true"`
+}
+
+// BulkResult is the outcome for one requested source ID: "updated" or
+// "not_found".
+type BulkResult struct {
+	ID     int64  `json:"id" example:"1"`
+	Result string `json:"result" example:"updated"`
+}
+
+// BulkUpdateResponse reports the per-ID outcome, in request order.
+type BulkUpdateResponse struct {
+	Updated int          `json:"updated" example:"2"`
+	Results []BulkResult `json:"results"`
+}
+
+func toBulkUpdateResponse(requested, updated []int64) BulkUpdateResponse {
+	done := make(map[int64]bool, len(updated))
+	for _, id := range updated {
+		done[id] = true
+	}
+	resp := BulkUpdateResponse{Updated: len(updated), Results: make([]BulkResult, 0, len(requested))}
+	seen := make(map[int64]bool, len(requested))
+	for _, id := range requested {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result := "not_found"
+		if done[id] {
+			result = "updated"
+		}
+		resp.Results = append(resp.Results, BulkResult{ID: id, Result: result})
 	}
+	return resp
 }
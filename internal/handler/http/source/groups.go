@@ -0,0 +1,289 @@
+package source
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/pathutil"
+	"catchup-feed/internal/handler/http/respond"
+	srcUC "catchup-feed/internal/usecase/source"
+)
+
+// GroupDTO is one source group (folder). crawl_schedule is null when the
+// members without their own schedule follow the global CRON_SCHEDULE;
+// crawl_paused leaves the members out of the scheduled crawls; notify
+// false leaves their articles out of the notification digest.
+type GroupDTO struct {
+	ID            int64     `json:"id" example:"1"`
+	Name          string    `json:"name" example:"Go"`
+	CrawlSchedule *string   `json:"crawl_schedule" example:"2h"`
+	CrawlPaused   bool      `json:"crawl_paused" example:"false"`
+	Notify        bool      `json:"notify" example:"true"`
+	SourceIDs     []int64   `json:"source_ids"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func toGroupDTO(g *entity.SourceGroup) GroupDTO {
+	sourceIDs := g.SourceIDs
+	if sourceIDs == nil {
+		sourceIDs = []int64{}
+	}
+	return GroupDTO{
+		ID:            g.ID,
+		Name:          g.Name,
+		CrawlSchedule: g.CrawlSchedule,
+		CrawlPaused:   g.CrawlPaused,
+		Notify:        g.Notify,
+		SourceIDs:     sourceIDs,
+		CreatedAt:     g.CreatedAt,
+		UpdatedAt:     g.UpdatedAt,
+	}
+}
+
+// GroupRequest is the POST /source-groups and PUT /source-groups/{id}
+// body. crawl_schedule "" follows CRON_SCHEDULE; notify defaults to true.
+type GroupRequest struct {
+	Name          string `json:"name" example:"Go"`
+	CrawlSchedule string `json:"crawl_schedule,omitempty" example:"2h"`
+	CrawlPaused   bool   `json:"crawl_paused,omitempty" example:"false"`
+	Notify        *bool  `json:"notify,omitempty" example:"true"`
+}
+
+// GroupSourcesRequest is the PUT /source-groups/{id}/sources body: the
+// complete member list.
+type GroupSourcesRequest struct {
+	SourceIDs []int64 `json:"source_ids" example:"1,2,3"`
+}
+
+// GroupSourcesResponse lists the members after PUT
+// /source-groups/{id}/sources; unknown source IDs are not included.
+type GroupSourcesResponse struct {
+	SourceIDs []int64 `json:"source_ids" example:"1,2"`
+}
+
+func groupID(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, pathutil.ErrInvalidID
+	}
+	return id, nil
+}
+
+// respondGroupError maps group use case errors to HTTP statuses: unknown
+// group → 404, name collision → 409, validation → 400, anything else →
+// sanitized 500.
+func respondGroupError(w http.ResponseWriter, err error) {
+	var verr *entity.ValidationError
+	switch {
+	case errors.Is(err, srcUC.ErrSourceGroupNotFound):
+		respond.SafeError(w, http.StatusNotFound, err)
+	case errors.Is(err, srcUC.ErrSourceGroupNameTaken):
+		respond.SafeError(w, http.StatusConflict, err)
+	case errors.Is(err, srcUC.ErrInvalidGroupSourceIDs),
+		errors.As(err, &verr):
+		respond.SafeError(w, http.StatusBadRequest, err)
+	default:
+		respond.SafeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+type GroupListHandler struct{ Svc srcUC.Service }
+
+// ServeHTTP ソースグループ一覧取得
+// @Summary      ソースグループ一覧取得
+// @Description  ソースグループ(フォルダ)を名前順に返します。source_ids は所属ソースの ID です。
+// @Tags         source-groups
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {array} GroupDTO "ソースグループ一覧"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - sources:read が必要"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /source-groups [get]
+func (h GroupListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	groups, err := h.Svc.ListGroups(r.Context())
+	if err != nil {
+		respondGroupError(w, err)
+		return
+	}
+	out := make([]GroupDTO, 0, len(groups))
+	for _, g := range groups {
+		out = append(out, toGroupDTO(g))
+	}
+	respond.JSON(w, http.StatusOK, out)
+}
+
+type GroupGetHandler struct{ Svc srcUC.Service }
+
+// ServeHTTP ソースグループ取得
+// @Summary      ソースグループ取得
+// @Description  ソースグループを1件返します。
+// @Tags         source-groups
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "グループ ID"
+// @Success      200 {object} GroupDTO "ソースグループ"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid ID"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - sources:read が必要"
+// @Failure      404 {object} respond.ErrorResponse "Not found - グループが存在しない"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /source-groups/{id} [get]
+func (h GroupGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := groupID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	group, err := h.Svc.GetGroup(r.Context(), id)
+	if err != nil {
+		respondGroupError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toGroupDTO(group))
+}
+
+type GroupCreateHandler struct{ Svc srcUC.Service }
+
+// ServeHTTP ソースグループ作成
+// @Summary      ソースグループ作成
+// @Description  空のソースグループ(フォルダ)を作成します。所属ソースは PUT /source-groups/{id}/sources で設定します。
+// @Description  設定は所属ソース全体に効きます: crawl_schedule は自分のスケジュールを持たないソースのクロールスケジュール
+// @Description  (cron 式または間隔、空 = CRON_SCHEDULE)、crawl_paused = true で定期クロールから外し、notify = false で
+// @Description  ダイジェスト通知に載せません(省略時 true)。name は一意(最大100文字)。
+// @Tags         source-groups
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        group body GroupRequest true "グループ(name 必須)"
+// @Success      201 {object} GroupDTO "作成されたグループ"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - 入力が不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - sources:write が必要"
+// @Failure      409 {object} respond.ErrorResponse "Conflict - 同名のグループが存在"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /source-groups [post]
+func (h GroupCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req GroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	created, err := h.Svc.CreateGroup(r.Context(), srcUC.GroupInput(req))
+	if err != nil {
+		respondGroupError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusCreated, toGroupDTO(created))
+}
+
+type GroupUpdateHandler struct{ Svc srcUC.Service }
+
+// ServeHTTP ソースグループ更新
+// @Summary      ソースグループ更新
+// @Description  ソースグループの name / crawl_schedule / crawl_paused / notify を置き換えます(notify 省略時は true)。
+// @Description  所属ソースは変わりません。
+// @Tags         source-groups
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "グループ ID"
+// @Param        group body GroupRequest true "グループ(name 必須)"
+// @Success      200 {object} GroupDTO "更新後のグループ"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - 入力が不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - sources:write が必要"
+// @Failure      404 {object} respond.ErrorResponse "Not found - グループが存在しない"
+// @Failure      409 {object} respond.ErrorResponse "Conflict - 同名のグループが存在"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /source-groups/{id} [put]
+func (h GroupUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := groupID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var req GroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	updated, err := h.Svc.UpdateGroup(r.Context(), id, srcUC.GroupInput(req))
+	if err != nil {
+		respondGroupError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toGroupDTO(updated))
+}
+
+type GroupDeleteHandler struct{ Svc srcUC.Service }
+
+// ServeHTTP ソースグループ削除
+// @Summary      ソースグループ削除
+// @Description  ソースグループを削除します。ソース自体は削除されず、グループなしに戻ります。
+// @Tags         source-groups
+// @Security     BearerAuth
+// @Param        id path int true "グループ ID"
+// @Success      204 "削除成功"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid ID"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - sources:write が必要"
+// @Failure      404 {object} respond.ErrorResponse "Not found - グループが存在しない"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /source-groups/{id} [delete]
+func (h GroupDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := groupID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.Svc.DeleteGroup(r.Context(), id); err != nil {
+		respondGroupError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type GroupSourcesHandler struct{ Svc srcUC.Service }
+
+// ServeHTTP ソースグループの所属ソース設定
+// @Summary      ソースグループの所属ソース設定
+// @Description  ソースグループの所属ソースを source_ids で置き換えます(最大500件、空配列でグループを空にする)。
+// @Description  ソースは1つのグループにしか属せないため、他のグループに属していたソースはこのグループへ移ります。
+// @Description  存在しないソース ID は無視され、応答の source_ids に含まれません。
+// @Tags         source-groups
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "グループ ID"
+// @Param        body body GroupSourcesRequest true "所属させるソース ID の一覧"
+// @Success      200 {object} GroupSourcesResponse "設定後の所属ソース"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid ID、source_ids が501件以上・正でない"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - sources:write が必要"
+// @Failure      404 {object} respond.ErrorResponse "Not found - グループが存在しない"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /source-groups/{id}/sources [put]
+func (h GroupSourcesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := groupID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var req GroupSourcesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	members, err := h.Svc.SetGroupSources(r.Context(), id, req.SourceIDs)
+	if err != nil {
+		respondGroupError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, GroupSourcesResponse{SourceIDs: members})
+}
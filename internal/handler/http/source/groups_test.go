@@ -0,0 +1,179 @@
+package source_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/middleware"
+	"catchup-feed/internal/handler/http/source"
+	"catchup-feed/internal/repository"
+	srcUC "catchup-feed/internal/usecase/source"
+)
+
+/* ───────── Source Group Handler テスト ───────── */
+
+// stubGroups keeps groups in memory; sources 1-3 exist.
+type stubGroups struct {
+	repository.SourceGroupRepository
+	groups  map[int64]*entity.SourceGroup
+	members map[int64]int64 // source ID → group ID
+	nextID  int64
+}
+
+func (s *stubGroups) withMembers(g *entity.SourceGroup) *entity.SourceGroup {
+	copied := *g
+	copied.SourceIDs = []int64{}
+	for sourceID, groupID := range s.members {
+		if groupID == g.ID {
+			copied.SourceIDs = append(copied.SourceIDs, sourceID)
+		}
+	}
+	slices.Sort(copied.SourceIDs)
+	return &copied
+}
+
+func (s *stubGroups) List(context.Context) ([]*entity.SourceGroup, error) {
+	out := []*entity.SourceGroup{}
+	for _, g := range s.groups {
+		out = append(out, s.withMembers(g))
+	}
+	return out, nil
+}
+
+func (s *stubGroups) Get(_ context.Context, id int64) (*entity.SourceGroup, error) {
+	if g, ok := s.groups[id]; ok {
+		return s.withMembers(g), nil
+	}
+	return nil, nil
+}
+
+func (s *stubGroups) Create(_ context.Context, group *entity.SourceGroup) error {
+	for _, g := range s.groups {
+		if g.Name == group.Name {
+			return repository.ErrDuplicateSourceGroupName
+		}
+	}
+	s.nextID++
+	group.ID, group.CreatedAt, group.UpdatedAt = s.nextID, time.Now(), time.Now()
+	s.groups[group.ID] = group
+	return nil
+}
+
+func (s *stubGroups) Update(_ context.Context, group *entity.SourceGroup) error {
+	s.groups[group.ID] = group
+	return nil
+}
+
+func (s *stubGroups) Delete(_ context.Context, id int64) error {
+	delete(s.groups, id)
+	for sourceID, groupID := range s.members {
+		if groupID == id {
+			delete(s.members, sourceID)
+		}
+	}
+	return nil
+}
+
+func (s *stubGroups) SetSources(_ context.Context, groupID int64, sourceIDs []int64) ([]int64, error) {
+	for sourceID, g := range s.members {
+		if g == groupID {
+			delete(s.members, sourceID)
+		}
+	}
+	members := []int64{}
+	for _, id := range sourceIDs {
+		if id <= 3 {
+			s.members[id] = groupID
+			members = append(members, id)
+		}
+	}
+	slices.Sort(members)
+	return members, nil
+}
+
+func TestRegister_NoRouteConflicts(t *testing.T) {
+	assert.NotPanics(t, func() {
+		source.Register(http.NewServeMux(), srcUC.Service{Repo: &stubCreateRepo{}}, middleware.NewRateLimiter(100, time.Minute, nil))
+	})
+}
+
+func TestGroupHandlers(t *testing.T) {
+	groups := &stubGroups{groups: map[int64]*entity.SourceGroup{}, members: map[int64]int64{}}
+	svc := srcUC.Service{Repo: &stubCreateRepo{}, Groups: groups}
+	// スコープ判定は auth パッケージでテスト済みのため、ハンドラを直接登録する。
+	mux := http.NewServeMux()
+	mux.Handle("GET /source-groups", source.GroupListHandler{Svc: svc})
+	mux.Handle("GET /source-groups/{id}", source.GroupGetHandler{Svc: svc})
+	mux.Handle("POST /source-groups", source.GroupCreateHandler{Svc: svc})
+	mux.Handle("PUT /source-groups/{id}", source.GroupUpdateHandler{Svc: svc})
+	mux.Handle("DELETE /source-groups/{id}", source.GroupDeleteHandler{Svc: svc})
+	mux.Handle("PUT /source-groups/{id}/sources", source.GroupSourcesHandler{Svc: svc})
+
+	serve := func(method, target, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return rr
+	}
+
+	rr := serve(http.MethodPost, "/source-groups", `{"name":" Go ","crawl_schedule":"2h"}`)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var created source.GroupDTO
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+	assert.Equal(t, "Go", created.Name)
+	require.NotNil(t, created.CrawlSchedule)
+	assert.Equal(t, "2h", *created.CrawlSchedule)
+	assert.True(t, created.Notify, "notify defaults to true")
+	assert.Equal(t, []int64{}, created.SourceIDs)
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		body     string
+		wantCode int
+	}{
+		{"missing name", http.MethodPost, "/source-groups", `{"name":" "}`, http.StatusBadRequest},
+		{"invalid crawl schedule", http.MethodPost, "/source-groups", `{"name":"x","crawl_schedule":"sometimes"}`, http.StatusBadRequest},
+		{"malformed body", http.MethodPost, "/source-groups", `{`, http.StatusBadRequest},
+		{"duplicate name", http.MethodPost, "/source-groups", `{"name":"Go"}`, http.StatusConflict},
+		{"get", http.MethodGet, "/source-groups/1", "", http.StatusOK},
+		{"unknown group", http.MethodGet, "/source-groups/9", "", http.StatusNotFound},
+		{"invalid id", http.MethodGet, "/source-groups/0", "", http.StatusBadRequest},
+		{"mute notifications", http.MethodPut, "/source-groups/1", `{"name":"Go","crawl_paused":true,"notify":false}`, http.StatusOK},
+		{"update unknown group", http.MethodPut, "/source-groups/9", `{"name":"x"}`, http.StatusNotFound},
+		{"set members", http.MethodPut, "/source-groups/1/sources", `{"source_ids":[3,1,1,42]}`, http.StatusOK},
+		{"non-positive member", http.MethodPut, "/source-groups/1/sources", `{"source_ids":[0]}`, http.StatusBadRequest},
+		{"members of unknown group", http.MethodPut, "/source-groups/9/sources", `{"source_ids":[1]}`, http.StatusNotFound},
+		{"delete unknown group", http.MethodDelete, "/source-groups/9", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := serve(tt.method, tt.target, tt.body)
+			assert.Equal(t, tt.wantCode, rr.Code, rr.Body.String())
+		})
+	}
+
+	rr = serve(http.MethodGet, "/source-groups", "")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var got []source.GroupDTO
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	require.Len(t, got, 1)
+	assert.Nil(t, got[0].CrawlSchedule, "PUT replaces the settings")
+	assert.True(t, got[0].CrawlPaused)
+	assert.False(t, got[0].Notify)
+	assert.Equal(t, []int64{1, 3}, got[0].SourceIDs, "unknown sources are left out")
+
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodDelete, "/source-groups/1", "").Code)
+	assert.Empty(t, groups.groups)
+	assert.Empty(t, groups.members, "members become ungrouped")
+}
@@ -1,8 +1,11 @@
 package source_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -12,6 +15,7 @@ import (
 	"catchup-feed/internal/domain/entity"
 	"catchup-feed/internal/handler/http/source"
 	"catchup-feed/internal/repository"
+	"catchup-feed/internal/usecase/fetch"
 	srcUC "catchup-feed/internal/usecase/source"
 )
 
@@ -50,6 +54,10 @@ func (s *stubCreateRepo) Delete(_ context.Context, _ int64) error {
 	return nil
 }
 
+func (s *stubCreateRepo) UpdateFeedValidators(_ context.Context, _ int64, _, _ string) error {
+	return nil
+}
+
 func TestCreateHandler_Success(t *testing.T) {
 	stub := &stubCreateRepo{}
 	handler := source.CreateHandler{Svc: srcUC.Service{Repo: stub}}
@@ -228,6 +236,10 @@ func (s *stubUpdateRepo) Delete(_ context.Context, _ int64) error {
 	return nil
 }
 
+func (s *stubUpdateRepo) UpdateFeedValidators(_ context.Context, _ int64, _, _ string) error {
+	return nil
+}
+
 func TestUpdateHandler_Success(t *testing.T) {
 	stub := &stubUpdateRepo{
 		source: &entity.Source{
@@ -330,6 +342,55 @@ func TestUpdateHandler_NotFound(t *testing.T) {
 	}
 }
 
+/* ───────── Bulk Update Handler テスト ───────── */
+
+func TestBulkUpdateHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		updateErr error
+		wantCode  int
+	}{
+		{"updates existing, reports missing", `{"ids":[1,2,1],"active":false}`, nil, http.StatusOK},
+		{"empty ids", `{"ids":[],"active":false}`, nil, http.StatusBadRequest},
+		{"no changes", `{"ids":[1]}`, nil, http.StatusBadRequest},
+		{"invalid crawl schedule", `{"ids":[1],"crawl_schedule":"sometimes"}`, nil, http.StatusBadRequest},
+		{"malformed body", `{`, nil, http.StatusBadRequest},
+		{"repository failure", `{"ids":[1],"active":false}`, errors.New("db down"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubUpdateRepo{
+				source:    &entity.Source{ID: 1, Name: "Go Blog", FeedURL: "https://go.dev/blog/feed.atom", Active: true},
+				updateErr: tt.updateErr,
+			}
+			handler := source.BulkUpdateHandler{Svc: srcUC.Service{Repo: stub}}
+
+			req := httptest.NewRequest(http.MethodPatch, "/sources/bulk", strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantCode {
+				t.Fatalf("status code = %d, want %d: %s", rr.Code, tt.wantCode, rr.Body.String())
+			}
+			if rr.Code != http.StatusOK {
+				return
+			}
+			var resp source.BulkUpdateResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			want := []source.BulkResult{{ID: 1, Result: "updated"}, {ID: 2, Result: "not_found"}}
+			if resp.Updated != 1 || len(resp.Results) != len(want) || resp.Results[0] != want[0] || resp.Results[1] != want[1] {
+				t.Errorf("response = %+v, want updated 1 and %+v", resp, want)
+			}
+			if stub.source.Active {
+				t.Errorf("source 1 must be deactivated")
+			}
+		})
+	}
+}
+
 /* ───────── Delete Handler テスト ───────── */
 
 type stubDeleteRepo struct {
@@ -347,6 +408,10 @@ func (s *stubDeleteRepo) Delete(_ context.Context, id int64) error {
 	return nil
 }
 
+func (s *stubDeleteRepo) UpdateFeedValidators(_ context.Context, _ int64, _, _ string) error {
+	return nil
+}
+
 // 以下は未使用だが、インターフェース満たすために実装
 func (s *stubDeleteRepo) Get(_ context.Context, _ int64) (*entity.Source, error) {
 	return nil, nil
@@ -445,6 +510,10 @@ func (s *stubSearchRepo) Delete(_ context.Context, _ int64) error {
 	return nil
 }
 
+func (s *stubSearchRepo) UpdateFeedValidators(_ context.Context, _ int64, _, _ string) error {
+	return nil
+}
+
 func TestSearchHandler_Success(t *testing.T) {
 	now := time.Now()
 	stub := &stubSearchRepo{
@@ -907,3 +976,314 @@ func TestSearchHandler_BackwardCompatibility(t *testing.T) {
 		t.Errorf("Name = %q, want %q", result[0].Name, "GitHub Blog")
 	}
 }
+
+/* ───────── Import Handler テスト ───────── */
+
+type stubImportRepo struct {
+	stubCreateRepo
+	existing []*entity.Source
+	created  []*entity.Source
+}
+
+func (s *stubImportRepo) List(_ context.Context) ([]*entity.Source, error) {
+	return s.existing, nil
+}
+
+func (s *stubImportRepo) Create(_ context.Context, src *entity.Source) error {
+	s.created = append(s.created, src)
+	return nil
+}
+
+const testOPML = `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+  <head><title>Subscriptions</title></head>
+  <body>
+    <outline text="Go">
+      <outline text="Go Blog" type="rss" xmlUrl="https://go.dev/blog/feed.atom" htmlUrl="https://go.dev/blog"/>
+      <outline text="Existing" type="rss" xmlUrl="HTTPS://Example.com/feed/"/>
+    </outline>
+    <outline title="Loose Feed" text="loose" type="rss" xmlUrl="https://loose.example.com/rss" category="/News/World,/Other"/>
+    <outline text="No Category" type="rss" xmlUrl="https://plain.example.com/rss"/>
+    <outline text="Broken" type="rss" xmlUrl="ftp://broken.example.com/rss"/>
+  </body>
+</opml>`
+
+func TestImportHandler_Success(t *testing.T) {
+	stub := &stubImportRepo{existing: []*entity.Source{{ID: 1, FeedURL: "https://example.com/feed"}}}
+	handler := source.ImportHandler{Svc: srcUC.Service{Repo: stub}}
+
+	req := httptest.NewRequest(http.MethodPost, "/sources/import?category=misc", strings.NewReader(testOPML))
+	req.Header.Set("Content-Type", "text/xml")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d: %s", rr.Code, http.StatusOK, rr.Body.String())
+	}
+	var resp source.ImportResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Created != 3 || resp.Duplicate != 1 || resp.Invalid != 1 || resp.Failed != 0 {
+		t.Errorf("counts = %+v, want created=3 duplicate=1 invalid=1", resp)
+	}
+
+	wantCategory := map[string]string{
+		"Go Blog":     "Go",
+		"Loose Feed":  "News/World",
+		"No Category": "misc",
+	}
+	if len(stub.created) != len(wantCategory) {
+		t.Fatalf("created = %d, want %d", len(stub.created), len(wantCategory))
+	}
+	for _, src := range stub.created {
+		if got := src.Category; got != wantCategory[src.Name] {
+			t.Errorf("%s: Category = %q, want %q", src.Name, got, wantCategory[src.Name])
+		}
+	}
+	if resp.Results[1].Status != srcUC.ImportStatusDuplicate {
+		t.Errorf("Results[1].Status = %q, want duplicate", resp.Results[1].Status)
+	}
+}
+
+func TestImportHandler_Multipart(t *testing.T) {
+	stub := &stubImportRepo{}
+	handler := source.ImportHandler{Svc: srcUC.Service{Repo: stub}}
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	fw, err := mw.CreateFormFile("file", "feeds.opml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = fw.Write([]byte(testOPML))
+	_ = mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/sources/import", &buf)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d: %s", rr.Code, http.StatusOK, rr.Body.String())
+	}
+	for _, src := range stub.created {
+		if src.Name == "No Category" && src.Category != "imported" {
+			t.Errorf("Category = %q, want %q", src.Category, "imported")
+		}
+	}
+}
+
+func TestImportHandler_InvalidOPML(t *testing.T) {
+	for name, body := range map[string]string{
+		"not xml":                "name,url\nGo,https://go.dev/blog/feed.atom",
+		"not opml":               `<rss version="2.0"><channel/></rss>`,
+		"multipart without file": "",
+	} {
+		t.Run(name, func(t *testing.T) {
+			handler := source.ImportHandler{Svc: srcUC.Service{Repo: &stubImportRepo{}}}
+			req := httptest.NewRequest(http.MethodPost, "/sources/import", strings.NewReader(body))
+			if body == "" {
+				req.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != http.StatusBadRequest {
+				t.Fatalf("status code = %d, want %d", rr.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+/* ───────── Export Handler テスト ───────── */
+
+type stubExportRepo struct {
+	stubCreateRepo
+	active []*entity.Source
+}
+
+func (s *stubExportRepo) ListActive(_ context.Context) ([]*entity.Source, error) {
+	return s.active, nil
+}
+
+func TestExportHandler_RoundTrip(t *testing.T) {
+	stub := &stubExportRepo{active: []*entity.Source{
+		{ID: 1, Name: "Go Blog", FeedURL: "https://go.dev/blog/feed.atom", Category: "go", Lang: "en", Active: true},
+		{ID: 2, Name: "Zenn & Qiita", FeedURL: "https://zenn.dev/feed?a=1&b=2", Category: "dev", Lang: "ja", Active: true},
+		{ID: 3, Name: "Awesome Go", FeedURL: "https://awesome-go.com/rss", Category: "go", Lang: "en", Active: true},
+	}}
+	handler := source.ExportHandler{Svc: srcUC.Service{Repo: stub}}
+
+	req := httptest.NewRequest(http.MethodGet, "/sources/export.opml", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status code = %d, want %d", rr.Code, http.StatusOK)
+	}
+	if ct := rr.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/x-opml") {
+		t.Errorf("Content-Type = %q, want text/x-opml", ct)
+	}
+	body := rr.Body.String()
+	if strings.Index(body, `text="dev"`) > strings.Index(body, `text="go"`) {
+		t.Errorf("categories are not sorted:\n%s", body)
+	}
+	if strings.Index(body, "Awesome Go") > strings.Index(body, "Go Blog") {
+		t.Errorf("sources are not sorted by name:\n%s", body)
+	}
+
+	// 書き出した OPML はそのまま取り込める。
+	importRepo := &stubImportRepo{}
+	importHandler := source.ImportHandler{Svc: srcUC.Service{Repo: importRepo}}
+	req = httptest.NewRequest(http.MethodPost, "/sources/import", strings.NewReader(body))
+	rr = httptest.NewRecorder()
+	importHandler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("import status code = %d, want %d: %s", rr.Code, http.StatusOK, rr.Body.String())
+	}
+	if len(importRepo.created) != len(stub.active) {
+		t.Fatalf("created = %d, want %d", len(importRepo.created), len(stub.active))
+	}
+	for _, got := range importRepo.created {
+		var want *entity.Source
+		for _, s := range stub.active {
+			if s.FeedURL == got.FeedURL {
+				want = s
+			}
+		}
+		if want == nil || got.Name != want.Name || got.Category != want.Category || got.Lang != want.Lang {
+			t.Errorf("round trip: got %+v, want %+v", got, want)
+		}
+	}
+}
+
+/* ───────── Health Handler テスト ───────── */
+
+type stubHealthRepo struct {
+	health *entity.SourceHealth
+}
+
+func (s *stubHealthRepo) RecordFetch(_ context.Context, _ int64, _ time.Time, _ int, _ string) error {
+	return nil
+}
+func (s *stubHealthRepo) Get(_ context.Context, id int64) (*entity.SourceHealth, error) {
+	if s.health != nil && s.health.SourceID == id {
+		return s.health, nil
+	}
+	return nil, nil
+}
+func (s *stubHealthRepo) List(_ context.Context) ([]*entity.SourceHealth, error) {
+	if s.health == nil {
+		return nil, nil
+	}
+	return []*entity.SourceHealth{s.health}, nil
+}
+
+func TestHealthHandler(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	crawled := now.Add(-time.Hour)
+	httpStatus := 503
+	lastErr := "feed returned 503"
+	svc := srcUC.Service{
+		Repo: &stubUpdateRepo{source: &entity.Source{ID: 1, Name: "Go Blog", FeedURL: "https://go.dev/blog/feed.atom", Active: true}},
+		HealthRepo: &stubHealthRepo{health: &entity.SourceHealth{
+			SourceID: 1, LastCrawledAt: &crawled, LastHTTPStatus: &httpStatus, ConsecutiveFailures: 3, LastError: &lastErr,
+		}},
+		Now: func() time.Time { return now },
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /sources/{id}/health", source.HealthHandler{Svc: svc})
+
+	tests := []struct {
+		name     string
+		path     string
+		wantCode int
+	}{
+		{"found", "/sources/1/health", http.StatusOK},
+		{"not found", "/sources/2/health", http.StatusNotFound},
+		{"invalid id", "/sources/abc/health", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d; body=%s", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var got source.HealthDTO
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if got.Status != entity.SourceHealthFailing || got.ConsecutiveFailures != 3 || got.Name != "Go Blog" {
+				t.Errorf("got %+v", got)
+			}
+			if got.LastHTTPStatus == nil || *got.LastHTTPStatus != 503 {
+				t.Errorf("LastHTTPStatus = %v, want 503", got.LastHTTPStatus)
+			}
+		})
+	}
+}
+
+func TestHealthListHandler_InvalidStatus(t *testing.T) {
+	handler := source.HealthListHandler{Svc: srcUC.Service{Repo: &stubUpdateRepo{}, HealthRepo: &stubHealthRepo{}}}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sources/health?status=broken", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", rec.Code)
+	}
+}
+
+/* ───────── Validate Handler テスト ───────── */
+
+type stubPreviewer struct {
+	preview *fetch.FeedPreview
+	err     error
+}
+
+func (s stubPreviewer) Preview(context.Context, string, int) (*fetch.FeedPreview, error) {
+	return s.preview, s.err
+}
+
+func TestValidateHandler(t *testing.T) {
+	preview := &fetch.FeedPreview{
+		Type: "atom", Title: "The Go Blog", SiteURL: "https://go.dev/blog", ItemCount: 12,
+		Items: []fetch.FeedItem{{Title: "Go 1.26", URL: "https://go.dev/blog/go1.26"}},
+	}
+	tests := []struct {
+		name     string
+		body     string
+		err      error
+		wantCode int
+	}{
+		{"ok", `{"feedURL":"https://go.dev/blog/feed.atom"}`, nil, http.StatusOK},
+		{"missing url", `{}`, nil, http.StatusBadRequest},
+		{"bad scheme", `{"feedURL":"file:///etc/passwd"}`, nil, http.StatusBadRequest},
+		{"not a feed", `{"feedURL":"https://go.dev/"}`, fetch.ErrInvalidFeedFormat, http.StatusUnprocessableEntity},
+		{"unreachable", `{"feedURL":"https://go.dev/feed"}`, &fetch.FeedStatusError{StatusCode: 503}, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := source.ValidateHandler{Svc: srcUC.Service{Previewer: stubPreviewer{preview: preview, err: tt.err}}}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/sources/validate", strings.NewReader(tt.body)))
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d; body=%s", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var got source.ValidateResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if got.Type != "atom" || got.Title != "The Go Blog" || got.ItemCount != 12 || len(got.Items) != 1 {
+				t.Errorf("got %+v", got)
+			}
+		})
+	}
+}
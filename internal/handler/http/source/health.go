@@ -0,0 +1,123 @@
+package source
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/pathutil"
+	"catchup-feed/internal/handler/http/respond"
+	srcUC "catchup-feed/internal/usecase/source"
+)
+
+// HealthDTO is the crawl health of one source. status is ok (last fetch
+// succeeded recently), failing (the latest fetch failed), stale (no
+// successful fetch for DefaultStaleAfter, or two crawl_schedule periods)
+// or never_crawled. last_http_status is null when the latest fetch got no
+// response (DNS / TLS / timeout).
+type HealthDTO struct {
+	SourceID            int64      `json:"source_id"`
+	Name                string     `json:"name"`
+	FeedURL             string     `json:"feed_url"`
+	Active              bool       `json:"active"`
+	Status              string     `json:"status" example:"failing" enums:"ok,failing,stale,never_crawled"`
+	LastCrawledAt       *time.Time `json:"last_crawled_at"`
+	LastSuccessAt       *time.Time `json:"last_success_at"`
+	LastHTTPStatus      *int       `json:"last_http_status" example:"404"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastError           *string    `json:"last_error"`
+}
+
+func toHealthDTO(report srcUC.HealthReport) HealthDTO {
+	dto := HealthDTO{
+		SourceID: report.Source.ID,
+		Name:     report.Source.Name,
+		FeedURL:  report.Source.FeedURL,
+		Active:   report.Source.Active,
+		Status:   report.Status,
+	}
+	if h := report.Health; h != nil {
+		dto.LastCrawledAt = h.LastCrawledAt
+		dto.LastSuccessAt = h.LastSuccessAt
+		dto.LastHTTPStatus = h.LastHTTPStatus
+		dto.ConsecutiveFailures = h.ConsecutiveFailures
+		dto.LastError = h.LastError
+	}
+	return dto
+}
+
+var errInvalidHealthStatus = errors.New("invalid status: must be one of ok, failing, stale, never_crawled")
+
+type HealthHandler struct{ Svc srcUC.Service }
+
+// ServeHTTP ソースのクロール状態
+// @Summary      ソースのクロール状態
+// @Description  worker が記録した直近のフィード取得結果(最終クロール日時・最終成功日時・HTTP ステータス・
+// @Description  連続失敗回数・最後のエラー)と、そこから判定した status を返します。
+// @Tags         sources
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "ソースID"
+// @Success      200 {object} HealthDTO "クロール状態"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid ID"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - sources:read が必要"
+// @Failure      404 {object} respond.ErrorResponse "Not found - source not found"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /sources/{id}/health [get]
+func (h HealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		respond.SafeError(w, http.StatusBadRequest, pathutil.ErrInvalidID)
+		return
+	}
+	report, err := h.Svc.Health(r.Context(), id)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, srcUC.ErrSourceNotFound) {
+			code = http.StatusNotFound
+		}
+		respond.SafeError(w, code, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toHealthDTO(*report))
+}
+
+type HealthListHandler struct{ Svc srcUC.Service }
+
+// ServeHTTP ソースのクロール状態一覧
+// @Summary      ソースのクロール状態一覧
+// @Description  全ソース(非アクティブ含む)のクロール状態をソースID順に返します。
+// @Description  status を指定するとその状態のソースだけに絞り込みます(例: status=failing で故障中のフィード)。
+// @Tags         sources
+// @Security     BearerAuth
+// @Produce      json
+// @Param        status query string false "状態で絞り込み" Enums(ok, failing, stale, never_crawled)
+// @Success      200 {array} HealthDTO "クロール状態一覧"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid status"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - sources:read が必要"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /sources/health [get]
+func (h HealthListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", entity.SourceHealthOK, entity.SourceHealthFailing,
+		entity.SourceHealthStale, entity.SourceHealthNeverCrawled:
+	default:
+		respond.SafeError(w, http.StatusBadRequest, errInvalidHealthStatus)
+		return
+	}
+	reports, err := h.Svc.ListHealth(r.Context(), status)
+	if err != nil {
+		respond.SafeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	out := make([]HealthDTO, 0, len(reports))
+	for _, report := range reports {
+		out = append(out, toHealthDTO(report))
+	}
+	respond.JSON(w, http.StatusOK, out)
+}
@@ -0,0 +1,116 @@
+package source
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"catchup-feed/internal/handler/http/respond"
+	srcUC "catchup-feed/internal/usecase/source"
+)
+
+// defaultImportCategory is the category of feeds outside any OPML folder
+// when the request does not name one.
+const defaultImportCategory = "imported"
+
+// ImportResultDTO reports one imported outline.
+type ImportResultDTO struct {
+	Name     string `json:"name" example:"Go Blog"`
+	FeedURL  string `json:"feed_url" example:"https://go.dev/blog/feed.atom"`
+	Category string `json:"category" example:"go"`
+	Status   string `json:"status" example:"created" enums:"created,duplicate,invalid,failed"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ImportResponse is the POST /sources/import body: per-status counts and
+// one result per feed outline, in document order.
+type ImportResponse struct {
+	Created   int               `json:"created"`
+	Duplicate int               `json:"duplicate"`
+	Invalid   int               `json:"invalid"`
+	Failed    int               `json:"failed"`
+	Results   []ImportResultDTO `json:"results"`
+}
+
+type ImportHandler struct{ Svc srcUC.Service }
+
+// ServeHTTP OPML インポート
+// @Summary      OPML インポート
+// @Description  他の RSS リーダーから書き出した OPML を読み込み、フィード(xmlUrl を持つ outline)ごとにソースを作成します。
+// @Description  OPML はリクエスト本文そのもの、または multipart/form-data の file フィールドで送ります。
+// @Description  フォルダ(xmlUrl のない outline)名がカテゴリになり、フォルダ外のフィードは category 属性、
+// @Description  それもなければクエリの category(既定 imported)になります。
+// @Description  フィード URL が登録済み(またはファイル内で重複)のものはスキップします。
+// @Description  エントリごとに独立して作成し、結果(created / duplicate / invalid / failed)を返します。
+// @Tags         sources
+// @Security     BearerAuth
+// @Accept       xml
+// @Accept       mpfd
+// @Produce      json
+// @Param        category query string false "フォルダ外のフィードのカテゴリ(既定 imported)"
+// @Param        kind query string false "全フィードの kind(rss / youtube / podcast、既定 rss)"
+// @Success      200 {object} ImportResponse "エントリごとの結果"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - OPML が不正・エントリ数超過"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - sources:write が必要"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /sources/import [post]
+func (h ImportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body io.Reader = r.Body
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			respond.SafeError(w, http.StatusBadRequest, errors.New("file is required"))
+			return
+		}
+		defer func() { _ = file.Close() }()
+		body = file
+	}
+
+	var doc opmlDocument
+	if err := xml.NewDecoder(body).Decode(&doc); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, errors.New("OPML is invalid: must be a UTF-8 OPML document"))
+		return
+	}
+
+	category := firstNonEmpty(r.URL.Query().Get("category"), defaultImportCategory)
+	kind := r.URL.Query().Get("kind")
+	feeds := doc.feeds(category)
+	entries := make([]srcUC.ImportEntry, 0, len(feeds))
+	for _, f := range feeds {
+		entries = append(entries, srcUC.ImportEntry{
+			Name: f.Name, FeedURL: f.FeedURL, Category: f.Category, Lang: f.Lang, Kind: kind,
+		})
+	}
+
+	results, err := h.Svc.Import(r.Context(), entries)
+	if errors.Is(err, srcUC.ErrTooManyImportEntries) {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err != nil {
+		respond.SafeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := ImportResponse{Results: make([]ImportResultDTO, 0, len(results))}
+	for _, res := range results {
+		switch res.Status {
+		case srcUC.ImportStatusCreated:
+			resp.Created++
+		case srcUC.ImportStatusDuplicate:
+			resp.Duplicate++
+		case srcUC.ImportStatusInvalid:
+			resp.Invalid++
+		default:
+			resp.Failed++
+		}
+		resp.Results = append(resp.Results, ImportResultDTO{
+			Name: res.Name, FeedURL: res.FeedURL, Category: res.Category,
+			Status: res.Status, Error: res.Error,
+		})
+	}
+	respond.JSON(w, http.StatusOK, resp)
+}
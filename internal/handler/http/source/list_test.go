@@ -61,6 +61,10 @@ func (s *stubSourceRepo) Delete(_ context.Context, _ int64) error {
 	return nil
 }
 
+func (s *stubSourceRepo) UpdateFeedValidators(_ context.Context, _ int64, _, _ string) error {
+	return nil
+}
+
 /* ───────── テストケース ───────── */
 
 func TestListHandler_Success(t *testing.T) {
@@ -0,0 +1,84 @@
+package source
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// opmlDocument is the subset of OPML 2.0 (http://opml.org/spec2.opml) RSS
+// readers exchange subscription lists with: feeds are outlines carrying an
+// xmlUrl, folders are outlines without one that nest feeds.
+type opmlDocument struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    opmlHead `xml:"head"`
+	Body    opmlBody `xml:"body"`
+}
+
+type opmlHead struct {
+	Title       string `xml:"title,omitempty"`
+	DateCreated string `xml:"dateCreated,omitempty"`
+}
+
+type opmlBody struct {
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr,omitempty"`
+	Type     string        `xml:"type,attr,omitempty"`
+	XMLURL   string        `xml:"xmlUrl,attr,omitempty"`
+	HTMLURL  string        `xml:"htmlUrl,attr,omitempty"`
+	Category string        `xml:"category,attr,omitempty"`
+	Language string        `xml:"language,attr,omitempty"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// opmlFeed is a feed outline with the folder it was found in.
+type opmlFeed struct {
+	Name     string
+	FeedURL  string
+	Category string
+	Lang     string
+}
+
+// feeds flattens the outline tree. A feed's category is its innermost
+// folder; outside any folder it falls back to the first entry of the OPML
+// category attribute ("/Tech/Go,/News" → "Tech/Go"), then to
+// defaultCategory.
+func (d *opmlDocument) feeds(defaultCategory string) []opmlFeed {
+	var out []opmlFeed
+	var walk func(outlines []opmlOutline, folder string)
+	walk = func(outlines []opmlOutline, folder string) {
+		for _, o := range outlines {
+			name := firstNonEmpty(o.Title, o.Text)
+			if o.XMLURL == "" {
+				walk(o.Outlines, firstNonEmpty(name, folder))
+				continue
+			}
+			category := folder
+			if category == "" {
+				first, _, _ := strings.Cut(o.Category, ",")
+				category = strings.Trim(strings.TrimSpace(first), "/")
+			}
+			out = append(out, opmlFeed{
+				Name:     firstNonEmpty(name, o.XMLURL),
+				FeedURL:  strings.TrimSpace(o.XMLURL),
+				Category: firstNonEmpty(category, defaultCategory),
+				Lang:     strings.TrimSpace(o.Language),
+			})
+		}
+	}
+	walk(d.Body.Outlines, "")
+	return out
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
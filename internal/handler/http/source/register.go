@@ -9,15 +9,43 @@ import (
 )
 
 // Register registers all source-related HTTP handlers with the given mux.
-// It sets up routes for listing, searching, creating, updating, and deleting sources.
-// Protected routes (create, update, delete) require authentication via the auth middleware.
+// It sets up routes for listing, searching, creating, updating (one by one
+// or in bulk), and deleting sources,
+// for OPML import/export, for the per-source crawl health, for checking
+// a feed URL before it is registered and for the source groups (folders,
+// under the top-level /source-groups: /sources/groups/{id} would overlap
+// /sources/{id}/health).
+// Read routes require the sources:read scope and write routes sources:write
+// (auth.RequireScope; admins hold every scope).
 // Search endpoints are protected by rate limiting to prevent DoS attacks.
 func Register(mux *http.ServeMux, svc srcUC.Service, searchRateLimiter *middleware.RateLimiter) {
-	mux.Handle("GET    /sources", ListHandler{svc})
+	read := auth.RequireScope(auth.ScopeSourcesRead)
+	write := auth.RequireScope(auth.ScopeSourcesWrite)
+
+	mux.Handle("GET    /sources", read(ListHandler{svc}))
 	// Search endpoint with rate limiting (100 req/min per IP)
-	mux.Handle("GET    /sources/search", searchRateLimiter.Middleware(SearchHandler{svc}))
+	mux.Handle("GET    /sources/search", read(searchRateLimiter.Middleware(SearchHandler{svc})))
+	// OPML export of active sources (backup / move to other readers).
+	mux.Handle("GET    /sources/export.opml", read(ExportHandler{svc}))
+	// Crawl health recorded by the worker (stale / broken feeds).
+	mux.Handle("GET    /sources/health", read(HealthListHandler{svc}))
+	mux.Handle("GET    /sources/{id}/health", read(HealthHandler{svc}))
+
+	mux.Handle("POST   /sources", write(CreateHandler{svc}))
+	// OPML import from other RSS readers (per-entry result report).
+	mux.Handle("POST   /sources/import", write(ImportHandler{svc}))
+	// Feed check before creation. It fetches a user-supplied URL, so it
+	// shares the search rate limit.
+	mux.Handle("POST   /sources/validate", write(searchRateLimiter.Middleware(ValidateHandler{svc})))
+	// Same change to many sources in one transaction (seasonal on / off).
+	mux.Handle("PATCH  /sources/bulk", write(BulkUpdateHandler{svc}))
+	mux.Handle("PUT    /sources/", write(UpdateHandler{svc}))
+	mux.Handle("DELETE /sources/", write(DeleteHandler{svc}))
 
-	mux.Handle("POST   /sources", auth.Authz(CreateHandler{svc}))
-	mux.Handle("PUT    /sources/", auth.Authz(UpdateHandler{svc}))
-	mux.Handle("DELETE /sources/", auth.Authz(DeleteHandler{svc}))
+	mux.Handle("GET    /source-groups", read(GroupListHandler{svc}))
+	mux.Handle("GET    /source-groups/{id}", read(GroupGetHandler{svc}))
+	mux.Handle("POST   /source-groups", write(GroupCreateHandler{svc}))
+	mux.Handle("PUT    /source-groups/{id}", write(GroupUpdateHandler{svc}))
+	mux.Handle("DELETE /source-groups/{id}", write(GroupDeleteHandler{svc}))
+	mux.Handle("PUT    /source-groups/{id}/sources", write(GroupSourcesHandler{svc}))
 }
@@ -14,7 +14,9 @@ type UpdateHandler struct{ Svc srcUC.Service }
 
 // ServeHTTP ソース更新
 // @Summary      ソース更新
-// @Description  既存のソースを更新します
+// @Description  既存のソースを更新します。crawl_schedule は省略(null)で変更なし、
+// @Description  空文字で解除(全体の CRON_SCHEDULE に戻す)。retention_days も省略(null)で変更なし、
+// @Description  0 で解除(全体の RETENTION_DAYS に戻す)。render_js も省略(null)で変更なし
 // @Tags         sources
 // @Security     BearerAuth
 // @Accept       json
@@ -42,7 +44,9 @@ func (h UpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	err = h.Svc.Update(r.Context(), srcUC.UpdateInput{
 		ID: id, Name: req.Name, FeedURL: req.FeedURL,
 		Category: req.Category, Lang: req.Lang, Kind: req.Kind,
-		Active: req.Active,
+		Active: req.Active, CrawlSchedule: req.CrawlSchedule,
+		RetentionDays: req.RetentionDays,
+		RenderJS:      req.RenderJS,
 	})
 	if err != nil {
 		code := http.StatusBadRequest
@@ -0,0 +1,98 @@
+package source
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/respond"
+	srcUC "catchup-feed/internal/usecase/source"
+)
+
+// ValidateRequest is the POST /sources/validate body.
+type ValidateRequest struct {
+	FeedURL string `json:"feedURL" example:"https://go.dev/blog/feed.atom"`
+}
+
+// ValidateItemDTO is one sample entry of a validated feed.
+type ValidateItemDTO struct {
+	Title       string    `json:"title"`
+	URL         string    `json:"url"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// ValidateResponse describes a feed as the crawler would see it. type is
+// the detected format; items holds at most srcUC.PreviewItemLimit entries
+// while item_count counts every entry in the feed.
+type ValidateResponse struct {
+	FeedURL     string            `json:"feed_url"`
+	Type        string            `json:"type" example:"atom" enums:"rss,atom,json"`
+	Title       string            `json:"title" example:"The Go Blog"`
+	Description string            `json:"description"`
+	SiteURL     string            `json:"site_url" example:"https://go.dev/blog"`
+	ItemCount   int               `json:"item_count"`
+	Items       []ValidateItemDTO `json:"items"`
+}
+
+type ValidateHandler struct{ Svc srcUC.Service }
+
+// ServeHTTP フィード URL の事前検証
+// @Summary      フィード URL の事前検証
+// @Description  指定された URL を実際に取得・解析し、フィード形式(rss / atom / json)・タイトル・
+// @Description  先頭数件の記事を返します。ソースは作成しません。登録前にフィードかどうかを確かめる用途です。
+// @Description  プライベートアドレスへの URL(リダイレクト先を含む)は拒否します
+// @Tags         sources
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body ValidateRequest true "検証するフィード URL"
+// @Success      200 {object} ValidateResponse "フィードの内容"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid URL"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - sources:write が必要"
+// @Failure      422 {object} respond.ErrorResponse "取得できない、またはフィードではない"
+// @Failure      429 {object} respond.ErrorResponse "Too many requests"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /sources/validate [post]
+func (h ValidateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req ValidateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.FeedURL == "" {
+		respond.SafeError(w, http.StatusBadRequest, errors.New("feedURL required"))
+		return
+	}
+
+	preview, err := h.Svc.ValidateFeed(r.Context(), req.FeedURL)
+	if err != nil {
+		var verr *entity.ValidationError
+		switch {
+		case errors.As(err, &verr):
+			respond.SafeError(w, http.StatusBadRequest, err)
+		case errors.Is(err, srcUC.ErrFeedUnreachable), errors.Is(err, srcUC.ErrNotAFeed):
+			// どちらもユースケースが組み立てたメッセージなのでそのまま返す
+			respond.Error(w, http.StatusUnprocessableEntity, err)
+		default:
+			respond.SafeError(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	out := ValidateResponse{
+		FeedURL:     req.FeedURL,
+		Type:        preview.Type,
+		Title:       preview.Title,
+		Description: preview.Description,
+		SiteURL:     preview.SiteURL,
+		ItemCount:   preview.ItemCount,
+		Items:       make([]ValidateItemDTO, 0, len(preview.Items)),
+	}
+	for _, item := range preview.Items {
+		out.Items = append(out.Items, ValidateItemDTO{Title: item.Title, URL: item.URL, PublishedAt: item.PublishedAt})
+	}
+	respond.JSON(w, http.StatusOK, out)
+}
@@ -0,0 +1,137 @@
+// Package stats provides the admin dashboard statistics handler
+// (GET /stats). The route is admin-only.
+package stats
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/handler/http/respond"
+	statsUC "catchup-feed/internal/usecase/stats"
+)
+
+// SourceDTO is one source's article counts.
+type SourceDTO struct {
+	SourceID      int64      `json:"source_id"`
+	SourceName    string     `json:"source_name"`
+	Active        bool       `json:"active"`
+	Total         int64      `json:"total"`
+	Recent        int64      `json:"recent"`          // 集計期間(days)内にクロールした記事数
+	LastCrawledAt *time.Time `json:"last_crawled_at"` // null = 記事なし
+}
+
+// BucketDTO is the article count of one day or week (JST).
+type BucketDTO struct {
+	Day   string `json:"day"` // 期間の初日 (YYYY-MM-DD)。週は月曜始まり
+	Count int64  `json:"count"`
+}
+
+// CrawlDTO aggregates the crawl history of the window. Durations are in
+// seconds over finished runs (null = 完了した実行なし).
+type CrawlDTO struct {
+	Runs            int64    `json:"runs"`
+	Succeeded       int64    `json:"succeeded"`
+	Failed          int64    `json:"failed"`
+	Running         int64    `json:"running"`
+	Inserted        int64    `json:"inserted"`
+	SummarizeErrors int64    `json:"summarize_errors"`
+	AvgDurationSec  *float64 `json:"avg_duration_sec"`
+	P95DurationSec  *float64 `json:"p95_duration_sec"`
+	MaxDurationSec  *float64 `json:"max_duration_sec"`
+}
+
+// DTO is the GET /stats response.
+type DTO struct {
+	Since              time.Time   `json:"since"`
+	Days               int         `json:"days"`
+	Weeks              int         `json:"weeks"`
+	Sources            []SourceDTO `json:"sources"`
+	ArticlesPerDay     []BucketDTO `json:"articles_per_day"`
+	ArticlesPerWeek    []BucketDTO `json:"articles_per_week"`
+	Crawls             CrawlDTO    `json:"crawls"`
+	SummarizeErrorRate *float64    `json:"summarize_error_rate"` // null = 期間内の追加記事なし
+}
+
+func toBucketDTOs(buckets []entity.ArticleCountBucket) []BucketDTO {
+	out := make([]BucketDTO, 0, len(buckets))
+	for _, b := range buckets {
+		out = append(out, BucketDTO(b))
+	}
+	return out
+}
+
+func toDTO(s *statsUC.Stats) DTO {
+	sources := make([]SourceDTO, 0, len(s.Sources))
+	for _, src := range s.Sources {
+		sources = append(sources, SourceDTO(*src))
+	}
+	return DTO{
+		Since:              s.Since,
+		Days:               s.Days,
+		Weeks:              s.Weeks,
+		Sources:            sources,
+		ArticlesPerDay:     toBucketDTOs(s.PerDay),
+		ArticlesPerWeek:    toBucketDTOs(s.PerWeek),
+		Crawls:             CrawlDTO(s.Crawls),
+		SummarizeErrorRate: s.SummarizeErrorRate,
+	}
+}
+
+type Handler struct{ Svc *statsUC.Service }
+
+// ServeHTTP ダッシュボード統計取得
+// @Summary      ダッシュボード統計取得
+// @Description  ソースごとの記事数、日別・週別(月曜始まり、JST)の記事数、クロール履歴から集計した実行数・所要時間(平均・p95・最大)と要約エラー率を返します。集計期間は今日を含む直近 days 日(週別は weeks 週)です
+// @Tags         stats
+// @Security     BearerAuth
+// @Produce      json
+// @Param        days query int false "集計日数(デフォルト30、最大366)"
+// @Param        weeks query int false "週別の週数(デフォルト12、最大104)"
+// @Success      200 {object} DTO "統計"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid query parameter"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /stats [get]
+func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	days, err := parseWindow(q.Get("days"), "days", statsUC.MaxDays)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	weeks, err := parseWindow(q.Get("weeks"), "weeks", statsUC.MaxWeeks)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	stats, err := h.Svc.Get(r.Context(), days, weeks)
+	if err != nil {
+		respond.SafeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toDTO(stats))
+}
+
+// parseWindow parses an optional positive count up to maxValue; 0 when
+// absent (usecase default).
+func parseWindow(raw, name string, maxValue int) (int, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 || n > maxValue {
+		return 0, fmt.Errorf("invalid %s: must be between 1 and %d", name, maxValue)
+	}
+	return n, nil
+}
+
+// Register registers GET /stats. The explicit auth.Authz wrap keeps it
+// admin-only even if the mux is ever mounted without the outer Authz.
+func Register(mux *http.ServeMux, svc *statsUC.Service) {
+	mux.Handle("GET /stats", auth.Authz(Handler{svc}))
+}
@@ -0,0 +1,75 @@
+package stats_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	hstats "catchup-feed/internal/handler/http/stats"
+	statsUC "catchup-feed/internal/usecase/stats"
+)
+
+type stubStatsRepo struct{ err error }
+
+func (s stubStatsRepo) ArticleCountsBySource(context.Context, time.Time) ([]*entity.SourceArticleStats, error) {
+	return []*entity.SourceArticleStats{{SourceID: 1, SourceName: "Go Blog", Active: true, Total: 10, Recent: 3}}, s.err
+}
+
+func (s stubStatsRepo) ArticleCountsByPeriod(_ context.Context, from, _ time.Time, _ int) ([]entity.ArticleCountBucket, error) {
+	return []entity.ArticleCountBucket{{Day: from.Format(time.DateOnly), Count: 4}}, nil
+}
+
+func (s stubStatsRepo) CrawlRunStats(context.Context, time.Time) (*entity.CrawlRunStats, error) {
+	avg := 12.5
+	return &entity.CrawlRunStats{Runs: 2, Succeeded: 2, Inserted: 10, SummarizeErrors: 1, AvgDurationSec: &avg}, nil
+}
+
+func newHandler(err error) hstats.Handler {
+	return hstats.Handler{Svc: &statsUC.Service{
+		Repo: stubStatsRepo{err: err},
+		Now:  func() time.Time { return time.Date(2026, 10, 15, 3, 0, 0, 0, time.UTC) },
+	}}
+}
+
+func TestHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newHandler(nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats?days=7&weeks=4", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var got hstats.DTO
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	assert.Equal(t, 7, got.Days)
+	assert.Equal(t, 4, got.Weeks)
+	require.Len(t, got.Sources, 1)
+	assert.Equal(t, "Go Blog", got.Sources[0].SourceName)
+	assert.Nil(t, got.Sources[0].LastCrawledAt)
+	assert.Equal(t, []hstats.BucketDTO{{Day: "2026-10-09", Count: 4}}, got.ArticlesPerDay)
+	assert.Equal(t, []hstats.BucketDTO{{Day: "2026-09-21", Count: 4}}, got.ArticlesPerWeek)
+	assert.Equal(t, int64(2), got.Crawls.Runs)
+	require.NotNil(t, got.Crawls.AvgDurationSec)
+	assert.Nil(t, got.Crawls.P95DurationSec)
+	require.NotNil(t, got.SummarizeErrorRate)
+	assert.InDelta(t, 0.1, *got.SummarizeErrorRate, 1e-9)
+}
+
+func TestHandler_InvalidQuery(t *testing.T) {
+	for _, target := range []string{"/stats?days=0", "/stats?days=367", "/stats?weeks=abc", "/stats?weeks=105"} {
+		rec := httptest.NewRecorder()
+		newHandler(nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+	}
+}
+
+func TestHandler_Error(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newHandler(errors.New("db down")).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
@@ -0,0 +1,154 @@
+// Package subscription provides the per-user source subscription HTTP
+// handlers (/sources/{id}/subscription, GET /sources/subscriptions),
+// following the flat-path convention (C-21). The article list, search and
+// event stream of accounts other than admins only cover the subscribed
+// sources (article package).
+package subscription
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/handler/http/pathutil"
+	"catchup-feed/internal/handler/http/respond"
+	subscriptionUC "catchup-feed/internal/usecase/subscription"
+)
+
+// Register registers the subscription routes. Subscribing only touches
+// the caller's own state, so every route needs just sources:read
+// (auth.RequireScope; admins hold every scope).
+func Register(mux *http.ServeMux, svc *subscriptionUC.Service) {
+	read := auth.RequireScope(auth.ScopeSourcesRead)
+
+	mux.Handle("GET /sources/subscriptions", read(ListHandler{svc}))
+	mux.Handle("POST /sources/{id}/subscription", read(SubscribeHandler{svc}))
+	mux.Handle("DELETE /sources/{id}/subscription", read(UnsubscribeHandler{svc}))
+}
+
+// SourceDTO is one subscribed source.
+type SourceDTO struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	FeedURL   string    `json:"feed_url"`
+	Category  string    `json:"category"`
+	Kind      string    `json:"kind" example:"rss" enums:"rss,youtube,podcast"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func toSourceDTO(s *entity.Source) SourceDTO {
+	return SourceDTO{
+		ID:        s.ID,
+		Name:      s.Name,
+		FeedURL:   s.FeedURL,
+		Category:  s.Category,
+		Kind:      s.Kind,
+		Active:    s.Active,
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+type ListHandler struct{ Svc *subscriptionUC.Service }
+
+// ServeHTTP 購読中のソース一覧取得
+// @Summary      購読中のソース一覧取得
+// @Description  呼び出し元ユーザーが購読しているソースを返します。admin 以外のアカウントの記事一覧・検索・記事イベントはこれらのソースに絞られます。
+// @Tags         subscriptions
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {array} SourceDTO "購読中のソース"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - ユーザーアカウントが必要"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /sources/subscriptions [get]
+func (h ListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	sources, err := h.Svc.List(r.Context(), auth.SubjectFromContext(r.Context()))
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	out := make([]SourceDTO, 0, len(sources))
+	for _, s := range sources {
+		out = append(out, toSourceDTO(s))
+	}
+	respond.JSON(w, http.StatusOK, out)
+}
+
+type SubscribeHandler struct{ Svc *subscriptionUC.Service }
+
+// ServeHTTP ソースを購読
+// @Summary      ソースを購読
+// @Description  呼び出し元ユーザーの購読にソースを追加します。購読済みでも成功します(冪等)。
+// @Tags         subscriptions
+// @Security     BearerAuth
+// @Param        id path int true "ソース ID"
+// @Success      204 "購読した"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid ID"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - ユーザーアカウントが必要"
+// @Failure      404 {object} respond.ErrorResponse "Not found - ソースが存在しない"
+// @Router       /sources/{id}/subscription [post]
+func (h SubscribeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.Svc.Subscribe(r.Context(), auth.SubjectFromContext(r.Context()), id); err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type UnsubscribeHandler struct{ Svc *subscriptionUC.Service }
+
+// ServeHTTP ソースの購読を解除
+// @Summary      ソースの購読を解除
+// @Description  呼び出し元ユーザーの購読からソースを外します。購読していないソースに対しても成功します(冪等)。
+// @Tags         subscriptions
+// @Security     BearerAuth
+// @Param        id path int true "ソース ID"
+// @Success      204 "解除した"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid ID"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - ユーザーアカウントが必要"
+// @Router       /sources/{id}/subscription [delete]
+func (h UnsubscribeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.Svc.Unsubscribe(r.Context(), auth.SubjectFromContext(r.Context()), id); err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// respondUsecaseError maps use case errors to HTTP statuses: caller
+// without a user account → 403, unknown source → 404, anything else →
+// sanitized 500.
+func respondUsecaseError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, subscriptionUC.ErrAccountNotFound):
+		respond.SafeError(w, http.StatusForbidden, err)
+	case errors.Is(err, subscriptionUC.ErrSourceNotFound):
+		respond.SafeError(w, http.StatusNotFound, err)
+	default:
+		respond.SafeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+func pathID(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, pathutil.ErrInvalidID
+	}
+	return id, nil
+}
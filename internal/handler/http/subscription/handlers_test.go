@@ -0,0 +1,125 @@
+package subscription_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/auth"
+	"catchup-feed/internal/handler/http/subscription"
+	"catchup-feed/internal/repository"
+	subscriptionUC "catchup-feed/internal/usecase/subscription"
+)
+
+/* ───────── モック実装 ───────── */
+
+type stubUserRepo struct {
+	repository.UserRepository
+}
+
+func (stubUserRepo) GetActiveByEmail(_ context.Context, email string) (*entity.User, error) {
+	if email == "alice@example.com" {
+		return &entity.User{ID: 7, Email: email}, nil
+	}
+	return nil, nil
+}
+
+type stubSubscriptions struct {
+	followed map[int64]bool
+}
+
+func (s *stubSubscriptions) Subscribe(_ context.Context, _, sourceID int64) (bool, error) {
+	if sourceID >= 100 { // 100 以上は存在しないソース
+		return false, nil
+	}
+	s.followed[sourceID] = true
+	return true, nil
+}
+
+func (s *stubSubscriptions) Unsubscribe(_ context.Context, _, sourceID int64) error {
+	delete(s.followed, sourceID)
+	return nil
+}
+
+func (s *stubSubscriptions) ListSources(context.Context, int64) ([]*entity.Source, error) {
+	out := []*entity.Source{}
+	for id := range s.followed {
+		out = append(out, &entity.Source{ID: id, Name: "Go Blog", Kind: "rss", Active: true})
+	}
+	return out, nil
+}
+
+func (s *stubSubscriptions) SourceIDs(context.Context, int64) (map[int64]bool, error) {
+	return s.followed, nil
+}
+
+/* ───────── テストケース ───────── */
+
+func TestRegister_NoRouteConflicts(t *testing.T) {
+	mux := http.NewServeMux()
+	// source パッケージの前方一致ルートと共存できること(登録時に panic しない)。
+	mux.Handle("GET    /sources/{id}/health", http.NotFoundHandler())
+	mux.Handle("POST   /sources/import", http.NotFoundHandler())
+	mux.Handle("PUT    /sources/", http.NotFoundHandler())
+	mux.Handle("DELETE /sources/", http.NotFoundHandler())
+	assert.NotPanics(t, func() {
+		subscription.Register(mux, &subscriptionUC.Service{Users: stubUserRepo{}, Subscriptions: &stubSubscriptions{}})
+	})
+}
+
+func TestSubscriptionHandlers(t *testing.T) {
+	subs := &stubSubscriptions{followed: map[int64]bool{}}
+	svc := &subscriptionUC.Service{Users: stubUserRepo{}, Subscriptions: subs}
+	// スコープ判定は auth パッケージでテスト済みのため、ハンドラを直接登録する。
+	mux := http.NewServeMux()
+	mux.Handle("GET /sources/subscriptions", subscription.ListHandler{Svc: svc})
+	mux.Handle("POST /sources/{id}/subscription", subscription.SubscribeHandler{Svc: svc})
+	mux.Handle("DELETE /sources/{id}/subscription", subscription.UnsubscribeHandler{Svc: svc})
+
+	serve := func(method, target, subject string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		req = req.WithContext(auth.WithIdentity(req.Context(), subject, "editor"))
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		subject  string
+		wantCode int
+	}{
+		{"subscribe", http.MethodPost, "/sources/3/subscription", "alice@example.com", http.StatusNoContent},
+		{"subscribe again", http.MethodPost, "/sources/3/subscription", "alice@example.com", http.StatusNoContent},
+		{"unknown source", http.MethodPost, "/sources/300/subscription", "alice@example.com", http.StatusNotFound},
+		{"invalid id", http.MethodPost, "/sources/0/subscription", "alice@example.com", http.StatusBadRequest},
+		{"api key identity", http.MethodPost, "/sources/3/subscription", "apikey:ci", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := serve(tt.method, tt.target, tt.subject)
+			assert.Equal(t, tt.wantCode, rr.Code, rr.Body.String())
+		})
+	}
+
+	rr := serve(http.MethodGet, "/sources/subscriptions", "alice@example.com")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var got []subscription.SourceDTO
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	require.Len(t, got, 1)
+	assert.Equal(t, int64(3), got[0].ID)
+
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodGet, "/sources/subscriptions", "apikey:ci").Code)
+
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodDelete, "/sources/3/subscription", "alice@example.com").Code)
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodDelete, "/sources/3/subscription", "alice@example.com").Code)
+	assert.Empty(t, subs.followed)
+}
@@ -0,0 +1,135 @@
+package tag
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"catchup-feed/internal/handler/http/respond"
+	tagUC "catchup-feed/internal/usecase/tag"
+)
+
+type ArticleTagsHandler struct{ Svc *tagUC.Service }
+
+// ServeHTTP 記事のタグ取得
+// @Summary      記事のタグ取得
+// @Description  記事に付与されているタグを名前順で取得します。
+// @Tags         tags
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "記事 ID"
+// @Success      200 {array} DTO "記事のタグ"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid ID"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - articles:read が必要"
+// @Failure      404 {object} respond.ErrorResponse "Not found - 記事が存在しない"
+// @Router       /articles/{id}/tags [get]
+func (h ArticleTagsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	tags, err := h.Svc.ArticleTags(r.Context(), id)
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toDTOs(tags))
+}
+
+type SetArticleTagsHandler struct{ Svc *tagUC.Service }
+
+// ServeHTTP 記事のタグ設定
+// @Summary      記事のタグ設定
+// @Description  記事のタグを指定したリストで置き換えます(最大20個)。未登録の名前はタグを自動作成し、
+// @Description  空配列ですべて外します。名前は正規化(小文字・空白1つ)し、重複はまとめます。
+// @Tags         tags
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "記事 ID"
+// @Param        tags body ArticleTagsRequest true "タグ名のリスト"
+// @Success      200 {array} DTO "設定後の記事のタグ"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - タグ名が不正・個数超過"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - articles:write が必要"
+// @Failure      404 {object} respond.ErrorResponse "Not found - 記事が存在しない"
+// @Router       /articles/{id}/tags [put]
+func (h SetArticleTagsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var req ArticleTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	tags, err := h.Svc.SetArticleTags(r.Context(), id, req.Tags)
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toDTOs(tags))
+}
+
+type BulkArticleTagsHandler struct{ Svc *tagUC.Service }
+
+// ServeHTTP 記事タグの一括更新
+// @Summary      記事タグの一括更新
+// @Description  指定した記事（最大500件）すべてに対し、remove のタグを外してから add のタグを付与します（1トランザクション）。
+// @Description  未登録の名前はタグを自動作成します。存在しない記事 ID は not_found として報告します。
+// @Tags         tags
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body BulkArticleTagsRequest true "対象の記事 ID と追加・削除するタグ名"
+// @Success      200 {object} BulkArticleTagsResponse "ID ごとの更新結果"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - article_ids・タグ名が不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - articles:write が必要"
+// @Router       /articles/bulk-tags [post]
+func (h BulkArticleTagsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req BulkArticleTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	updated, err := h.Svc.UpdateArticlesTags(r.Context(), req.ArticleIDs, req.Add, req.Remove)
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toBulkArticleTagsResponse(req.ArticleIDs, updated))
+}
+
+type SuggestHandler struct{ Svc *tagUC.Service }
+
+// ServeHTTP 記事のタグ候補取得
+// @Summary      記事のタグ候補取得
+// @Description  記事にまだ付いていないタグの候補を返します(最大5件)。先頭はソース(フィード)の
+// @Description  カテゴリ、続いて同じソースの他の記事でよく使われているタグです。付与は PUT /articles/{id}/tags で行います。
+// @Tags         tags
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "記事 ID"
+// @Success      200 {object} SuggestionsResponse "タグ候補"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid ID"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - articles:read が必要"
+// @Failure      404 {object} respond.ErrorResponse "Not found - 記事が存在しない"
+// @Router       /articles/{id}/tags/suggestions [get]
+func (h SuggestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	names, err := h.Svc.Suggest(r.Context(), id)
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, SuggestionsResponse{Suggestions: names})
+}
@@ -0,0 +1,103 @@
+// Package tag provides the article tag HTTP handlers: CRUD over the tag
+// vocabulary (/tags, /tags/{id}) and per-article assignment and
+// suggestions (/articles/{id}/tags, /articles/{id}/tags/suggestions) and
+// bulk tag changes (/articles/bulk-tags),
+// following the flat-path convention (C-21).
+package tag
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/pathutil"
+)
+
+// DTO mirrors the tags schema plus the number of tagged articles.
+type DTO struct {
+	ID           int64     `json:"id" example:"1"`
+	Name         string    `json:"name" example:"go"`
+	ArticleCount int64     `json:"article_count" example:"12"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func toDTO(t *entity.Tag) DTO {
+	return DTO{ID: t.ID, Name: t.Name, ArticleCount: t.ArticleCount, CreatedAt: t.CreatedAt}
+}
+
+func toDTOs(tags []*entity.Tag) []DTO {
+	out := make([]DTO, 0, len(tags))
+	for _, t := range tags {
+		out = append(out, toDTO(t))
+	}
+	return out
+}
+
+// TagRequest is the POST /tags and PUT /tags/{id} body. The name is
+// normalized server-side (trimmed, lowercased, single spaces).
+type TagRequest struct {
+	Name string `json:"name" example:"Go"`
+}
+
+// ArticleTagsRequest is the PUT /articles/{id}/tags body: the complete
+// tag list of the article. Unknown names create new tags; [] clears.
+type ArticleTagsRequest struct {
+	Tags []string `json:"tags" example:"go,release"`
+}
+
+// BulkArticleTagsRequest is the POST /articles/bulk-tags body: tags to add
+// to and remove from every listed article. Removals apply first.
+type BulkArticleTagsRequest struct {
+	ArticleIDs []int64  `json:"article_ids" example:"1,2,3"`
+	Add        []string `json:"add,omitempty" example:"go"`
+	Remove     []string `json:"remove,omitempty" example:"draft"`
+}
+
+// BulkResult is the outcome for one requested article ID: "updated" or
+// "not_found".
+type BulkResult struct {
+	ID     int64  `json:"id" example:"1"`
+	Result string `json:"result" example:"updated"`
+}
+
+// BulkArticleTagsResponse reports the per-ID outcome, in request order.
+type BulkArticleTagsResponse struct {
+	Updated int          `json:"updated" example:"2"`
+	Results []BulkResult `json:"results"`
+}
+
+func toBulkArticleTagsResponse(requested, updated []int64) BulkArticleTagsResponse {
+	done := make(map[int64]bool, len(updated))
+	for _, id := range updated {
+		done[id] = true
+	}
+	resp := BulkArticleTagsResponse{Updated: len(updated), Results: make([]BulkResult, 0, len(requested))}
+	seen := make(map[int64]bool, len(requested))
+	for _, id := range requested {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result := "not_found"
+		if done[id] {
+			result = "updated"
+		}
+		resp.Results = append(resp.Results, BulkResult{ID: id, Result: result})
+	}
+	return resp
+}
+
+// SuggestionsResponse is the GET /articles/{id}/tags/suggestions body.
+type SuggestionsResponse struct {
+	Suggestions []string `json:"suggestions" example:"go,release"`
+}
+
+// pathID extracts the positive integer {id} path value.
+func pathID(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, pathutil.ErrInvalidID
+	}
+	return id, nil
+}
@@ -0,0 +1,236 @@
+package tag_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/tag"
+	"catchup-feed/internal/repository"
+	tagUC "catchup-feed/internal/usecase/tag"
+)
+
+/* ───────── モック実装 ───────── */
+
+type stubTagRepo struct {
+	tags     []*entity.Tag
+	assigned map[int64][]string
+	top      []string
+	added    []string
+	removed  []string
+}
+
+func (s *stubTagRepo) find(match func(*entity.Tag) bool) *entity.Tag {
+	for _, t := range s.tags {
+		if match(t) {
+			cp := *t
+			return &cp
+		}
+	}
+	return nil
+}
+
+func (s *stubTagRepo) List(context.Context) ([]*entity.Tag, error) { return s.tags, nil }
+func (s *stubTagRepo) Get(_ context.Context, id int64) (*entity.Tag, error) {
+	return s.find(func(t *entity.Tag) bool { return t.ID == id }), nil
+}
+func (s *stubTagRepo) Create(_ context.Context, t *entity.Tag) error {
+	if s.find(func(o *entity.Tag) bool { return o.Name == t.Name }) != nil {
+		return repository.ErrDuplicateTagName
+	}
+	t.ID = int64(len(s.tags) + 1)
+	s.tags = append(s.tags, t)
+	return nil
+}
+func (s *stubTagRepo) Update(context.Context, *entity.Tag) error { return nil }
+func (s *stubTagRepo) Delete(context.Context, int64) error       { return nil }
+func (s *stubTagRepo) ListByArticle(_ context.Context, articleID int64) ([]*entity.Tag, error) {
+	out := []*entity.Tag{}
+	for _, name := range s.assigned[articleID] {
+		out = append(out, &entity.Tag{Name: name})
+	}
+	return out, nil
+}
+func (s *stubTagRepo) SetArticleTags(ctx context.Context, articleID int64, names []string) ([]*entity.Tag, error) {
+	s.assigned[articleID] = names
+	return s.ListByArticle(ctx, articleID)
+}
+func (s *stubTagRepo) UpdateArticlesTags(_ context.Context, articleIDs []int64, add, remove []string) ([]int64, error) {
+	s.added, s.removed = add, remove
+	updated := []int64{}
+	for _, id := range articleIDs {
+		if id == 1 { // stubArticleRepo と同じく記事 1 だけが存在する
+			updated = append(updated, id)
+		}
+	}
+	return updated, nil
+}
+func (s *stubTagRepo) ListTopBySource(context.Context, int64, int) ([]string, error) {
+	return s.top, nil
+}
+
+type stubArticleRepo struct {
+	repository.ArticleRepository
+}
+
+func (stubArticleRepo) Get(_ context.Context, id int64) (*entity.Article, error) {
+	if id != 1 {
+		return nil, nil
+	}
+	return &entity.Article{ID: 1, SourceID: 10}, nil
+}
+
+type stubSourceRepo struct {
+	repository.SourceRepository
+}
+
+func (stubSourceRepo) Get(_ context.Context, id int64) (*entity.Source, error) {
+	return &entity.Source{ID: id, Category: "Tech"}, nil
+}
+
+func newService() (*tagUC.Service, *stubTagRepo) {
+	repo := &stubTagRepo{
+		tags:     []*entity.Tag{{ID: 1, Name: "go", ArticleCount: 2}},
+		assigned: map[int64][]string{},
+		top:      []string{"go", "release"},
+	}
+	return &tagUC.Service{Tags: repo, Articles: stubArticleRepo{}, Sources: stubSourceRepo{}}, repo
+}
+
+/* ───────── テストケース ───────── */
+
+func TestRegister_NoRouteConflicts(t *testing.T) {
+	svc, _ := newService()
+	mux := http.NewServeMux()
+	// article パッケージの前方一致ルートと共存できること(登録時に panic しない)。
+	mux.Handle("GET    /articles/", http.NotFoundHandler())
+	mux.Handle("PUT    /articles/", http.NotFoundHandler())
+	assert.NotPanics(t, func() { tag.Register(mux, svc) })
+}
+
+func TestListHandler(t *testing.T) {
+	svc, _ := newService()
+	rr := httptest.NewRecorder()
+	tag.ListHandler{Svc: svc}.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/tags", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var got []tag.DTO
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&got))
+	require.Len(t, got, 1)
+	assert.Equal(t, int64(2), got[0].ArticleCount)
+}
+
+func TestCreateHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantCode int
+	}{
+		{"created", `{"name":" Release "}`, http.StatusCreated},
+		{"duplicate", `{"name":"Go"}`, http.StatusConflict},
+		{"empty name", `{"name":"  "}`, http.StatusBadRequest},
+		{"malformed json", `{`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newService()
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/tags", strings.NewReader(tt.body))
+			tag.CreateHandler{Svc: svc}.ServeHTTP(rr, req)
+			assert.Equal(t, tt.wantCode, rr.Code, rr.Body.String())
+		})
+	}
+}
+
+func TestUpdateHandler_NotFound(t *testing.T) {
+	svc, _ := newService()
+	req := httptest.NewRequest(http.MethodPut, "/tags/9", strings.NewReader(`{"name":"x"}`))
+	req.SetPathValue("id", "9")
+	rr := httptest.NewRecorder()
+	tag.UpdateHandler{Svc: svc}.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestSetArticleTagsHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		body     string
+		wantCode int
+	}{
+		{"replaced", "1", `{"tags":["Go","go","Release"]}`, http.StatusOK},
+		{"cleared", "1", `{"tags":[]}`, http.StatusOK},
+		{"article not found", "2", `{"tags":["go"]}`, http.StatusNotFound},
+		{"invalid id", "abc", `{"tags":["go"]}`, http.StatusBadRequest},
+		{"invalid tag", "1", `{"tags":["a,b"]}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo := newService()
+			req := httptest.NewRequest(http.MethodPut, "/articles/"+tt.id+"/tags", strings.NewReader(tt.body))
+			req.SetPathValue("id", tt.id)
+			rr := httptest.NewRecorder()
+			tag.SetArticleTagsHandler{Svc: svc}.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantCode, rr.Code, rr.Body.String())
+			if tt.name == "replaced" {
+				assert.Equal(t, []string{"go", "release"}, repo.assigned[1])
+			}
+		})
+	}
+}
+
+func TestSuggestHandler(t *testing.T) {
+	svc, repo := newService()
+	repo.assigned[1] = []string{"go"}
+
+	req := httptest.NewRequest(http.MethodGet, "/articles/1/tags/suggestions", nil)
+	req.SetPathValue("id", "1")
+	rr := httptest.NewRecorder()
+	tag.SuggestHandler{Svc: svc}.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var got tag.SuggestionsResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&got))
+	assert.Equal(t, []string{"tech", "release"}, got.Suggestions)
+}
+
+func TestBulkArticleTagsHandler(t *testing.T) {
+	svc, repo := newService()
+	req := httptest.NewRequest(http.MethodPost, "/articles/bulk-tags",
+		strings.NewReader(`{"article_ids":[1,2,1],"add":["Go"],"remove":["Draft"]}`))
+	rr := httptest.NewRecorder()
+	tag.BulkArticleTagsHandler{Svc: svc}.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var got tag.BulkArticleTagsResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&got))
+	assert.Equal(t, 1, got.Updated)
+	assert.Equal(t, []tag.BulkResult{{ID: 1, Result: "updated"}, {ID: 2, Result: "not_found"}}, got.Results)
+	assert.Equal(t, []string{"go"}, repo.added)
+	assert.Equal(t, []string{"draft"}, repo.removed)
+}
+
+func TestBulkArticleTagsHandler_BadRequest(t *testing.T) {
+	for name, body := range map[string]string{
+		"no ids":      `{"article_ids":[],"add":["go"]}`,
+		"no changes":  `{"article_ids":[1]}`,
+		"invalid tag": `{"article_ids":[1],"add":["a,b"]}`,
+		"malformed":   `{"article_ids":`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			svc, _ := newService()
+			rr := httptest.NewRecorder()
+			tag.BulkArticleTagsHandler{Svc: svc}.ServeHTTP(rr,
+				httptest.NewRequest(http.MethodPost, "/articles/bulk-tags", strings.NewReader(body)))
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		})
+	}
+}
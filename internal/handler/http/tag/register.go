@@ -0,0 +1,26 @@
+package tag
+
+import (
+	"net/http"
+
+	"catchup-feed/internal/handler/http/auth"
+	tagUC "catchup-feed/internal/usecase/tag"
+)
+
+// Register registers the tag routes (C-21 flat paths). Tags are article
+// metadata, so they share the article scopes: reads require articles:read
+// and writes articles:write (auth.RequireScope; admins hold every scope).
+func Register(mux *http.ServeMux, svc *tagUC.Service) {
+	read := auth.RequireScope(auth.ScopeArticlesRead)
+	write := auth.RequireScope(auth.ScopeArticlesWrite)
+
+	mux.Handle("GET /tags", read(ListHandler{svc}))
+	mux.Handle("POST /tags", write(CreateHandler{svc}))
+	mux.Handle("PUT /tags/{id}", write(UpdateHandler{svc}))
+	mux.Handle("DELETE /tags/{id}", write(DeleteHandler{svc}))
+
+	mux.Handle("GET /articles/{id}/tags", read(ArticleTagsHandler{svc}))
+	mux.Handle("PUT /articles/{id}/tags", write(SetArticleTagsHandler{svc}))
+	mux.Handle("GET /articles/{id}/tags/suggestions", read(SuggestHandler{svc}))
+	mux.Handle("POST /articles/bulk-tags", write(BulkArticleTagsHandler{svc}))
+}
@@ -0,0 +1,32 @@
+package tag
+
+import (
+	"errors"
+	"net/http"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/respond"
+	tagUC "catchup-feed/internal/usecase/tag"
+)
+
+// respondUsecaseError maps use case errors to HTTP statuses: not-found →
+// 404, name collision → 409, validation → 400, anything else → sanitized
+// 500.
+func respondUsecaseError(w http.ResponseWriter, err error) {
+	var verr *entity.ValidationError
+	switch {
+	case errors.Is(err, tagUC.ErrTagNotFound),
+		errors.Is(err, tagUC.ErrArticleNotFound):
+		respond.SafeError(w, http.StatusNotFound, err)
+	case errors.Is(err, tagUC.ErrTagNameTaken):
+		respond.SafeError(w, http.StatusConflict, err)
+	case errors.Is(err, tagUC.ErrInvalidID),
+		errors.Is(err, tagUC.ErrTooManyTags),
+		errors.Is(err, tagUC.ErrInvalidArticleIDs),
+		errors.Is(err, tagUC.ErrNoTagChanges),
+		errors.As(err, &verr):
+		respond.SafeError(w, http.StatusBadRequest, err)
+	default:
+		respond.SafeError(w, http.StatusInternalServerError, err)
+	}
+}
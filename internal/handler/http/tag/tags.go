@@ -0,0 +1,125 @@
+package tag
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"catchup-feed/internal/handler/http/respond"
+	tagUC "catchup-feed/internal/usecase/tag"
+)
+
+type ListHandler struct{ Svc *tagUC.Service }
+
+// ServeHTTP タグ一覧取得
+// @Summary      タグ一覧取得
+// @Description  すべてのタグを名前順で取得します。article_count は付与されている記事数です。
+// @Tags         tags
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {array} DTO "タグ一覧"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - articles:read が必要"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /tags [get]
+func (h ListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	list, err := h.Svc.List(r.Context())
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toDTOs(list))
+}
+
+type CreateHandler struct{ Svc *tagUC.Service }
+
+// ServeHTTP タグ作成
+// @Summary      タグ作成
+// @Description  タグを作成します。名前は前後の空白を除き小文字化して保存します(最大50文字、カンマ不可)。
+// @Tags         tags
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        tag body TagRequest true "タグ名"
+// @Success      201 {object} DTO "作成されたタグ"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - 名前が不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - articles:write が必要"
+// @Failure      409 {object} respond.ErrorResponse "Conflict - 同名のタグが存在"
+// @Router       /tags [post]
+func (h CreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req TagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	created, err := h.Svc.Create(r.Context(), req.Name)
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusCreated, toDTO(created))
+}
+
+type UpdateHandler struct{ Svc *tagUC.Service }
+
+// ServeHTTP タグ名変更
+// @Summary      タグ名変更
+// @Description  タグの名前を変更します。記事への付与はそのまま引き継がれます。
+// @Tags         tags
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "タグ ID"
+// @Param        tag body TagRequest true "新しいタグ名"
+// @Success      200 {object} DTO "変更後のタグ"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - 入力が不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - articles:write が必要"
+// @Failure      404 {object} respond.ErrorResponse "Not found - タグが存在しない"
+// @Failure      409 {object} respond.ErrorResponse "Conflict - 同名のタグが存在"
+// @Router       /tags/{id} [put]
+func (h UpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var req TagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	updated, err := h.Svc.Rename(r.Context(), id, req.Name)
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toDTO(updated))
+}
+
+type DeleteHandler struct{ Svc *tagUC.Service }
+
+// ServeHTTP タグ削除
+// @Summary      タグ削除
+// @Description  タグを削除します。記事への付与もすべて外れます(記事自体は残ります)。
+// @Tags         tags
+// @Security     BearerAuth
+// @Param        id path int true "タグ ID"
+// @Success      204 "No Content"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid ID"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - articles:write が必要"
+// @Failure      404 {object} respond.ErrorResponse "Not found - タグが存在しない"
+// @Router       /tags/{id} [delete]
+func (h DeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.Svc.Delete(r.Context(), id); err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
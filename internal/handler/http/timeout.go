@@ -2,13 +2,16 @@ package http
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"sync"
 	"time"
 )
 
 // Timeout returns middleware that enforces request timeouts.
-// If a request takes longer than the specified duration, it returns 504 Gateway Timeout.
+// If a request takes longer than the specified duration, it returns 504 Gateway Timeout
+// with an application/problem+json body (RFC 9457).
 // The context is properly canceled to allow downstream handlers to cleanup.
 //
 // Note: This implementation uses a mutex to prevent race conditions when writing
@@ -17,70 +20,165 @@ import (
 func Timeout(duration time.Duration) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Create context with timeout
-			ctx, cancel := context.WithTimeout(r.Context(), duration)
-			defer cancel()
-
-			// Replace request context with timeout context
-			r = r.WithContext(ctx)
-
-			// Channel to signal completion and mutex to prevent concurrent writes
-			done := make(chan struct{})
-			var mu sync.Mutex
-			timedOut := false
-
-			// Wrap response writer to check for timeout before writing
-			wrappedWriter := &timeoutResponseWriter{
-				ResponseWriter: w,
-				mu:             &mu,
-				timedOut:       &timedOut,
-			}
-
-			// Execute handler in goroutine
-			go func() {
-				next.ServeHTTP(wrappedWriter, r)
-				close(done)
-			}()
+			serveWithTimeout(next, w, r, duration)
+		})
+	}
+}
 
-			// Wait for either completion or timeout
-			select {
-			case <-done:
-				// Request completed successfully
-				return
-			case <-ctx.Done():
-				// Timeout occurred - acquire lock and write timeout response
-				mu.Lock()
-				timedOut = true
-				if !wrappedWriter.written {
-					w.Header().Set("Content-Type", "application/json")
-					w.WriteHeader(http.StatusGatewayTimeout)
-					_, _ = w.Write([]byte(`{"error":"request timeout"}`))
+// TimeoutPerRoute is Timeout with per-route overrides, keyed by
+// http.ServeMux patterns ("GET /articles/export",
+// "POST /sources/{id}/crawl"); the most specific matching pattern wins,
+// as in routing. A zero or negative duration (default or override)
+// disables the timeout — for streams (SSE, export), large uploads and
+// file downloads that legitimately outlive any fixed deadline.
+func TimeoutPerRoute(defaultTimeout time.Duration, overrides map[string]time.Duration) (func(http.Handler) http.Handler, error) {
+	routes := http.NewServeMux()
+	for pattern, d := range overrides {
+		if err := registerTimeoutRoute(routes, pattern, d); err != nil {
+			return nil, err
+		}
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			d := defaultTimeout
+			if h, pattern := routes.Handler(r); pattern != "" {
+				if route, ok := h.(timeoutRoute); ok {
+					d = time.Duration(route)
 				}
-				mu.Unlock()
 			}
+			if d <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			serveWithTimeout(next, w, r, d)
 		})
+	}, nil
+}
+
+// timeoutRoute carries an override's duration through the lookup mux.
+type timeoutRoute time.Duration
+
+func (timeoutRoute) ServeHTTP(http.ResponseWriter, *http.Request) {}
+
+// registerTimeoutRoute turns ServeMux's panic on an invalid or
+// conflicting pattern into an error.
+func registerTimeoutRoute(routes *http.ServeMux, pattern string, d time.Duration) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("invalid timeout route %q: %v", pattern, p)
+		}
+	}()
+	routes.Handle(pattern, timeoutRoute(d))
+	return nil
+}
+
+func serveWithTimeout(next http.Handler, w http.ResponseWriter, r *http.Request, duration time.Duration) {
+	// Create context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), duration)
+	defer cancel()
+
+	// Replace request context with timeout context
+	r = r.WithContext(ctx)
+
+	// Channel to signal completion and mutex to prevent concurrent writes
+	done := make(chan struct{})
+	panicked := make(chan any, 1)
+	var mu sync.Mutex
+	timedOut := false
+
+	// Wrap response writer to check for timeout before writing. The
+	// handler gets its own header map so that the timeout response below
+	// never races with a handler still setting headers.
+	wrappedWriter := &timeoutResponseWriter{
+		ResponseWriter: w,
+		header:         make(http.Header),
+		mu:             &mu,
+		timedOut:       &timedOut,
 	}
+
+	// Execute handler in goroutine
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				panicked <- p
+			}
+		}()
+		next.ServeHTTP(wrappedWriter, r)
+		close(done)
+	}()
+
+	// Wait for either completion or timeout
+	select {
+	case <-done:
+		// Request completed successfully
+		return
+	case p := <-panicked:
+		// Re-raise on the serving goroutine so the recovery middleware sees it
+		panic(p)
+	case <-ctx.Done():
+		// Timeout occurred - acquire lock and write timeout response
+		mu.Lock()
+		timedOut = true
+		if !wrappedWriter.written {
+			writeTimeoutProblem(w, duration)
+		}
+		mu.Unlock()
+	}
+}
+
+// timeoutProblem is the RFC 9457 problem details body of a 504.
+type timeoutProblem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail"`
+}
+
+func writeTimeoutProblem(w http.ResponseWriter, duration time.Duration) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(http.StatusGatewayTimeout)
+	_ = json.NewEncoder(w).Encode(timeoutProblem{
+		Type:   "about:blank",
+		Title:  http.StatusText(http.StatusGatewayTimeout),
+		Status: http.StatusGatewayTimeout,
+		Detail: fmt.Sprintf("request timeout: not completed within %s", duration),
+	})
 }
 
 // timeoutResponseWriter wraps http.ResponseWriter to prevent writes after timeout
 type timeoutResponseWriter struct {
 	http.ResponseWriter
+	header   http.Header
 	mu       *sync.Mutex
 	timedOut *bool
 	written  bool
 }
 
+// Header returns the handler's header map, copied to the response when
+// the header is written.
+func (w *timeoutResponseWriter) Header() http.Header {
+	return w.header
+}
+
 // WriteHeader writes the status code if timeout hasn't occurred
 func (w *timeoutResponseWriter) WriteHeader(statusCode int) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
 	if !*w.timedOut && !w.written {
-		w.written = true
-		w.ResponseWriter.WriteHeader(statusCode)
+		w.writeHeaderLocked(statusCode)
 	}
 }
 
+func (w *timeoutResponseWriter) writeHeaderLocked(statusCode int) {
+	w.written = true
+	dst := w.ResponseWriter.Header()
+	for k, v := range w.header {
+		dst[k] = v
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
 // Write writes data if timeout hasn't occurred
 func (w *timeoutResponseWriter) Write(data []byte) (int, error) {
 	w.mu.Lock()
@@ -91,9 +189,29 @@ func (w *timeoutResponseWriter) Write(data []byte) (int, error) {
 	}
 
 	if !w.written {
-		w.written = true
-		w.ResponseWriter.WriteHeader(http.StatusOK)
+		w.writeHeaderLocked(http.StatusOK)
 	}
 
 	return w.ResponseWriter.Write(data)
 }
+
+// Flush lets streaming handlers flush through the timeout writer.
+func (w *timeoutResponseWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if *w.timedOut {
+		return
+	}
+	if !w.written {
+		w.writeHeaderLocked(http.StatusOK)
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (w *timeoutResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
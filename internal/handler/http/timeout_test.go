@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -69,8 +70,8 @@ func TestTimeout_Timeout(t *testing.T) {
 
 	// Verify content type
 	contentType := rec.Header().Get("Content-Type")
-	if contentType != "application/json" {
-		t.Errorf("expected Content-Type application/json, got '%s'", contentType)
+	if contentType != "application/problem+json" {
+		t.Errorf("expected Content-Type application/problem+json, got '%s'", contentType)
 	}
 }
 
@@ -326,3 +327,96 @@ func TestTimeout_MultipleWrites(t *testing.T) {
 		t.Errorf("expected combined body, got '%s'", rec.Body.String())
 	}
 }
+
+func TestTimeout_ProblemBody(t *testing.T) {
+	handler := Timeout(10 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Handler", "set before timeout") // must not leak into the 504
+		<-r.Context().Done()
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	var problem map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("body is not JSON: %v (%q)", err, rec.Body.String())
+	}
+	if problem["status"] != float64(http.StatusGatewayTimeout) || problem["title"] != "Gateway Timeout" || problem["type"] != "about:blank" {
+		t.Errorf("unexpected problem body: %v", problem)
+	}
+	if rec.Header().Get("X-Handler") != "" {
+		t.Error("handler header leaked into the timeout response")
+	}
+}
+
+// A panic in the handler goroutine reaches the caller instead of
+// crashing the process, so the recovery middleware can handle it.
+func TestTimeout_PanicPropagates(t *testing.T) {
+	handler := Timeout(time.Second)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+
+	defer func() {
+		if p := recover(); p != "boom" {
+			t.Errorf("recovered %v, want boom", p)
+		}
+	}()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/test", nil))
+}
+
+func TestTimeout_FlushStreams(t *testing.T) {
+	handler := Timeout(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: 1\n\n"))
+		if err := http.NewResponseController(w).Flush(); err != nil {
+			t.Errorf("Flush: %v", err)
+		}
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events", nil))
+
+	if !rec.Flushed || rec.Header().Get("Content-Type") != "text/event-stream" {
+		t.Errorf("flushed = %v, Content-Type = %q", rec.Flushed, rec.Header().Get("Content-Type"))
+	}
+}
+
+func TestTimeoutPerRoute(t *testing.T) {
+	mw, err := TimeoutPerRoute(20*time.Millisecond, map[string]time.Duration{
+		"GET /articles/export":     0,
+		"POST /sources/{id}/crawl": time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slow := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(50 * time.Millisecond):
+			w.WriteHeader(http.StatusOK)
+		case <-r.Context().Done():
+		}
+	}))
+
+	tests := []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodGet, "/articles", http.StatusGatewayTimeout},
+		{http.MethodGet, "/articles/export", http.StatusOK},
+		{http.MethodPost, "/sources/7/crawl", http.StatusOK},
+		{http.MethodGet, "/sources/7/crawl", http.StatusGatewayTimeout},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		slow.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+		if rec.Code != tt.want {
+			t.Errorf("%s %s: status %d, want %d", tt.method, tt.path, rec.Code, tt.want)
+		}
+	}
+}
+
+func TestTimeoutPerRoute_InvalidPattern(t *testing.T) {
+	if _, err := TimeoutPerRoute(time.Second, map[string]time.Duration{"GET /a/{": time.Second}); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+}
@@ -0,0 +1,73 @@
+// Package user provides the account management HTTP handlers: admin-only
+// CRUD over the users table (administrators and viewers), following the
+// flat-path convention (C-21: /users, /users/{id}, /users/{id}/active).
+package user
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/pathutil"
+)
+
+// DTO mirrors the users schema. PasswordHash never leaves the
+// persistence/usecase layers; Active is derived from DeactivatedAt (same
+// shape as the viewer DTO plus role).
+type DTO struct {
+	ID            int64      `json:"id"`
+	Name          string     `json:"name"`
+	Email         string     `json:"email"`
+	Role          string     `json:"role"`
+	Active        bool       `json:"active"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	DeactivatedAt *time.Time `json:"deactivated_at"`
+}
+
+func toDTO(u *entity.User) DTO {
+	return DTO{
+		ID:            u.ID,
+		Name:          u.Name,
+		Email:         u.Email,
+		Role:          u.Role,
+		Active:        u.IsActive(),
+		CreatedAt:     u.CreatedAt,
+		UpdatedAt:     u.UpdatedAt,
+		DeactivatedAt: u.DeactivatedAt,
+	}
+}
+
+// CreateRequest is the POST /users body. All fields required; the password
+// is bcrypt-hashed server-side.
+type CreateRequest struct {
+	Name     string `json:"name" example:"Alice"`
+	Email    string `json:"email" example:"alice@example.com"`
+	Role     string `json:"role" example:"viewer" enums:"admin,viewer"`
+	Password string `json:"password" example:"correct-horse-battery"`
+}
+
+// UpdateRequest is the PUT /users/{id} body. name / email / role are full
+// replacements; password is optional — omitted (null) keeps the current
+// password, present re-sets it.
+type UpdateRequest struct {
+	Name     string  `json:"name" example:"Alice"`
+	Email    string  `json:"email" example:"alice@example.com"`
+	Role     string  `json:"role" example:"viewer" enums:"admin,viewer"`
+	Password *string `json:"password,omitempty" example:"new-password-123"`
+}
+
+// ActiveRequest is the PUT /users/{id}/active body.
+type ActiveRequest struct {
+	Active bool `json:"active" example:"false"`
+}
+
+// pathID extracts the positive integer {id} path value.
+func pathID(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, pathutil.ErrInvalidID
+	}
+	return id, nil
+}
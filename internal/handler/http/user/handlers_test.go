@@ -0,0 +1,273 @@
+package user_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/user"
+	"catchup-feed/internal/repository"
+	userUC "catchup-feed/internal/usecase/user"
+)
+
+/* ───────── モック実装 ───────── */
+
+type stubUserRepo struct {
+	users     map[int64]*entity.User
+	createErr error
+}
+
+func newStubUserRepo(users ...*entity.User) *stubUserRepo {
+	s := &stubUserRepo{users: map[int64]*entity.User{}}
+	for _, u := range users {
+		s.users[u.ID] = u
+	}
+	return s
+}
+
+func (s *stubUserRepo) Create(_ context.Context, u *entity.User) error {
+	if s.createErr != nil {
+		return s.createErr
+	}
+	u.ID = int64(len(s.users) + 1)
+	u.CreatedAt, u.UpdatedAt = time.Now(), time.Now()
+	s.users[u.ID] = u
+	return nil
+}
+
+func (s *stubUserRepo) Get(_ context.Context, id int64) (*entity.User, error) {
+	return s.users[id], nil
+}
+
+func (s *stubUserRepo) List(_ context.Context) ([]*entity.User, error) {
+	out := make([]*entity.User, 0, len(s.users))
+	for id := int64(1); id <= int64(len(s.users))+10; id++ {
+		if u, ok := s.users[id]; ok {
+			out = append(out, u)
+		}
+	}
+	return out, nil
+}
+
+func (s *stubUserRepo) Update(_ context.Context, u *entity.User) error {
+	s.users[u.ID] = u
+	return nil
+}
+
+func (s *stubUserRepo) Deactivate(_ context.Context, id int64, t time.Time) error {
+	if u, ok := s.users[id]; ok && u.DeactivatedAt == nil {
+		at := t
+		u.DeactivatedAt = &at
+	}
+	return nil
+}
+
+func (s *stubUserRepo) Reactivate(_ context.Context, id int64) error {
+	if u, ok := s.users[id]; ok {
+		u.DeactivatedAt = nil
+	}
+	return nil
+}
+
+func (s *stubUserRepo) Delete(_ context.Context, id int64) error {
+	delete(s.users, id)
+	return nil
+}
+
+func (s *stubUserRepo) GetActiveByEmail(_ context.Context, email string) (*entity.User, error) {
+	for _, u := range s.users {
+		if u.Email == email && u.DeactivatedAt == nil {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *stubUserRepo) CountActiveAdmins(_ context.Context) (int, error) {
+	n := 0
+	for _, u := range s.users {
+		if u.Role == userUC.RoleAdmin && u.DeactivatedAt == nil {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func newMux(repo repository.UserRepository) *http.ServeMux {
+	svc := &userUC.Service{Users: repo}
+	mux := http.NewServeMux()
+	// Register と同じパターンで、認可ミドルウェアなしに直接張る。
+	mux.Handle("GET /users", user.ListHandler{Svc: svc})
+	mux.Handle("POST /users", user.CreateHandler{Svc: svc})
+	mux.Handle("PUT /users/{id}", user.UpdateHandler{Svc: svc})
+	mux.Handle("PUT /users/{id}/active", user.SetActiveHandler{Svc: svc})
+	mux.Handle("DELETE /users/{id}", user.DeleteHandler{Svc: svc})
+	return mux
+}
+
+func do(mux *http.ServeMux, method, path, body string) *httptest.ResponseRecorder {
+	var req *http.Request
+	if body == "" {
+		req = httptest.NewRequest(method, path, nil)
+	} else {
+		req = httptest.NewRequest(method, path, strings.NewReader(body))
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+func rootAdmin() *entity.User {
+	return &entity.User{ID: 1, Name: "Root", Email: "root@example.com", Role: userUC.RoleAdmin, PasswordHash: "h"}
+}
+
+/* ───────── テストケース ───────── */
+
+func TestListHandler(t *testing.T) {
+	repo := newStubUserRepo(
+		rootAdmin(),
+		&entity.User{ID: 2, Name: "Alice", Email: "alice@example.com", Role: userUC.RoleViewer, PasswordHash: "h"},
+	)
+
+	rec := do(newMux(repo), http.MethodGet, "/users", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var got []user.DTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Len(t, got, 2)
+	assert.Equal(t, "admin", got[0].Role)
+	assert.Equal(t, "viewer", got[1].Role)
+	// password_hash がレスポンスに漏れないこと。
+	assert.NotContains(t, rec.Body.String(), "password")
+}
+
+func TestCreateHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		createErr error
+		wantCode  int
+	}{
+		{
+			name:     "valid admin",
+			body:     `{"name":"Ops","email":"ops@example.com","role":"admin","password":"password-123"}`,
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:     "invalid role",
+			body:     `{"name":"Ops","email":"ops@example.com","role":"owner","password":"password-123"}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "short password",
+			body:     `{"name":"Ops","email":"ops@example.com","role":"admin","password":"short"}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:      "duplicate email",
+			body:      `{"name":"Ops","email":"root@example.com","role":"admin","password":"password-123"}`,
+			createErr: repository.ErrDuplicateUserEmail,
+			wantCode:  http.StatusConflict,
+		},
+		{
+			name:     "invalid json",
+			body:     `{not json`,
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newStubUserRepo()
+			repo.createErr = tt.createErr
+			rec := do(newMux(repo), http.MethodPost, "/users", tt.body)
+			assert.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantCode == http.StatusCreated {
+				var got user.DTO
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+				assert.Equal(t, "admin", got.Role)
+				assert.True(t, got.Active)
+				assert.NotContains(t, rec.Body.String(), "password")
+			}
+		})
+	}
+}
+
+// TestLastAdminConflict: 最後のアクティブ admin の降格・無効化・削除は 409。
+func TestLastAdminConflict(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{name: "demote", method: http.MethodPut, path: "/users/1", body: `{"name":"Root","email":"root@example.com","role":"viewer"}`},
+		{name: "deactivate", method: http.MethodPut, path: "/users/1/active", body: `{"active":false}`},
+		{name: "delete", method: http.MethodDelete, path: "/users/1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newStubUserRepo(rootAdmin())
+			rec := do(newMux(repo), tt.method, tt.path, tt.body)
+			assert.Equal(t, http.StatusConflict, rec.Code)
+			assert.Contains(t, rec.Body.String(), "last active admin")
+			assert.True(t, repo.users[1].IsActive())
+		})
+	}
+}
+
+func TestUpdateHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		body     string
+		wantCode int
+	}{
+		{
+			name:     "promote viewer to admin",
+			path:     "/users/2",
+			body:     `{"name":"Alice","email":"alice@example.com","role":"admin"}`,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "not found",
+			path:     "/users/99",
+			body:     `{"name":"Alice","email":"alice@example.com","role":"viewer"}`,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "invalid id",
+			path:     "/users/abc",
+			body:     `{"name":"Alice","email":"alice@example.com","role":"viewer"}`,
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newStubUserRepo(rootAdmin(),
+				&entity.User{ID: 2, Name: "Alice", Email: "alice@example.com", Role: userUC.RoleViewer, PasswordHash: "h"})
+			rec := do(newMux(repo), http.MethodPut, tt.path, tt.body)
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
+
+func TestDeleteHandler(t *testing.T) {
+	repo := newStubUserRepo(rootAdmin(),
+		&entity.User{ID: 2, Name: "Alice", Email: "alice@example.com", Role: userUC.RoleViewer, PasswordHash: "h"})
+	mux := newMux(repo)
+
+	assert.Equal(t, http.StatusNoContent, do(mux, http.MethodDelete, "/users/2", "").Code)
+	assert.Equal(t, http.StatusNotFound, do(mux, http.MethodDelete, "/users/2", "").Code)
+	assert.Len(t, repo.users, 1)
+}
@@ -0,0 +1,19 @@
+package user
+
+import (
+	"net/http"
+
+	"catchup-feed/internal/handler/http/auth"
+	userUC "catchup-feed/internal/usecase/user"
+)
+
+// Register registers the user-management routes (C-21 flat paths). Every
+// route is wrapped in auth.Authz: account management is admin-only, and
+// viewers are additionally kept out by the outer middleware's allowlist.
+func Register(mux *http.ServeMux, svc *userUC.Service) {
+	mux.Handle("GET /users", auth.Authz(ListHandler{svc}))
+	mux.Handle("POST /users", auth.Authz(CreateHandler{svc}))
+	mux.Handle("PUT /users/{id}", auth.Authz(UpdateHandler{svc}))
+	mux.Handle("PUT /users/{id}/active", auth.Authz(SetActiveHandler{svc}))
+	mux.Handle("DELETE /users/{id}", auth.Authz(DeleteHandler{svc}))
+}
@@ -0,0 +1,30 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"catchup-feed/internal/handler/http/respond"
+	userUC "catchup-feed/internal/usecase/user"
+)
+
+// respondUsecaseError maps use case sentinel errors to HTTP statuses:
+// not-found → 404, email collision / last admin → 409, validation → 400,
+// anything else → sanitized 500.
+func respondUsecaseError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, userUC.ErrUserNotFound):
+		respond.SafeError(w, http.StatusNotFound, err)
+	case errors.Is(err, userUC.ErrEmailTaken),
+		errors.Is(err, userUC.ErrLastAdmin):
+		respond.SafeError(w, http.StatusConflict, err)
+	case errors.Is(err, userUC.ErrNameRequired),
+		errors.Is(err, userUC.ErrInvalidEmail),
+		errors.Is(err, userUC.ErrInvalidRole),
+		errors.Is(err, userUC.ErrPasswordTooShort),
+		errors.Is(err, userUC.ErrPasswordTooLong):
+		respond.SafeError(w, http.StatusBadRequest, err)
+	default:
+		respond.SafeError(w, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,183 @@
+package user
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"catchup-feed/internal/handler/http/respond"
+	userUC "catchup-feed/internal/usecase/user"
+)
+
+type ListHandler struct{ Svc *userUC.Service }
+
+// ServeHTTP ユーザー一覧取得
+// @Summary      ユーザー一覧取得
+// @Description  ダッシュボードのアカウント(admin / viewer)をアクティブ・非アクティブ含めて
+// @Description  すべて取得します。role で権限、active / deactivated_at で有効・無効を判別できます。admin 専用
+// @Tags         users
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {array} DTO "ユーザー一覧"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /users [get]
+func (h ListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	list, err := h.Svc.List(r.Context())
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	out := make([]DTO, 0, len(list))
+	for _, u := range list {
+		out = append(out, toDTO(u))
+	}
+	respond.JSON(w, http.StatusOK, out)
+}
+
+type CreateHandler struct{ Svc *userUC.Service }
+
+// ServeHTTP ユーザー登録
+// @Summary      ユーザー登録
+// @Description  admin または viewer のアカウントを作成します。パスワードはサーバー側で
+// @Description  bcrypt ハッシュのみ保存されます。admin 専用
+// @Tags         users
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        user body CreateRequest true "ユーザー情報(name / email / role / password すべて必須)"
+// @Success      201 {object} DTO "作成されたユーザー"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - name/email/role/password が不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      409 {object} respond.ErrorResponse "Conflict - email が既に登録済み"
+// @Router       /users [post]
+func (h CreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req CreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	created, err := h.Svc.Create(r.Context(), userUC.CreateInput{
+		Name:     req.Name,
+		Email:    req.Email,
+		Role:     req.Role,
+		Password: req.Password,
+	})
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusCreated, toDTO(created))
+}
+
+type UpdateHandler struct{ Svc *userUC.Service }
+
+// ServeHTTP ユーザー更新
+// @Summary      ユーザー更新
+// @Description  ユーザーの name / email / role を更新します。password は任意で、指定した場合のみ
+// @Description  再設定されます。最後のアクティブな admin は降格できません(409)。admin 専用
+// @Tags         users
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "ユーザー ID"
+// @Param        user body UpdateRequest true "更新するユーザー情報"
+// @Success      200 {object} DTO "更新後のユーザー"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - 入力が不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      404 {object} respond.ErrorResponse "Not found - ユーザーが存在しない"
+// @Failure      409 {object} respond.ErrorResponse "Conflict - email が登録済み / 最後の admin"
+// @Router       /users/{id} [put]
+func (h UpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var req UpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	updated, err := h.Svc.Update(r.Context(), id, userUC.UpdateInput{
+		Name:     req.Name,
+		Email:    req.Email,
+		Role:     req.Role,
+		Password: req.Password,
+	})
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toDTO(updated))
+}
+
+type SetActiveHandler struct{ Svc *userUC.Service }
+
+// ServeHTTP ユーザー有効/無効切替
+// @Summary      ユーザー有効/無効切替
+// @Description  ユーザーの有効/無効を切り替えます(論理無効化 = deactivated_at の set/clear)。
+// @Description  無効化はリクエスト時の DB 再検証により即時反映されます。最後のアクティブな
+// @Description  admin は無効化できません(409)。冪等。admin 専用
+// @Tags         users
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "ユーザー ID"
+// @Param        active body ActiveRequest true "{active: true|false}"
+// @Success      200 {object} DTO "切替後のユーザー"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - 入力が不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      404 {object} respond.ErrorResponse "Not found - ユーザーが存在しない"
+// @Failure      409 {object} respond.ErrorResponse "Conflict - 最後の admin"
+// @Router       /users/{id}/active [put]
+func (h SetActiveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var req ActiveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	updated, err := h.Svc.SetActive(r.Context(), id, req.Active)
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusOK, toDTO(updated))
+}
+
+type DeleteHandler struct{ Svc *userUC.Service }
+
+// ServeHTTP ユーザー削除(物理削除)
+// @Summary      ユーザー削除(物理削除)
+// @Description  ユーザーを物理削除します。削除後、そのユーザーの既存 JWT はリクエスト時の
+// @Description  DB 再検証で即座に拒否されます。最後のアクティブな admin は削除できません(409)。admin 専用
+// @Tags         users
+// @Security     BearerAuth
+// @Param        id path int true "ユーザー ID"
+// @Success      204 "No Content"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid ID"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      404 {object} respond.ErrorResponse "Not found - ユーザーが存在しない"
+// @Failure      409 {object} respond.ErrorResponse "Conflict - 最後の admin"
+// @Router       /users/{id} [delete]
+func (h DeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.Svc.Delete(r.Context(), id); err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,21 @@
+package http
+
+import (
+	"net/http"
+
+	"catchup-feed/internal/handler/http/respond"
+	"catchup-feed/internal/pkg/buildinfo"
+)
+
+// VersionHandler handles GET /version: the version, git commit, build date
+// and Go runtime of the running binary. Like /health it needs no
+// authentication, so deploy tooling can check what is rolled out.
+type VersionHandler struct {
+	Info buildinfo.Info
+}
+
+// ServeHTTP returns Info as JSON.
+func (h *VersionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	respond.JSON(w, http.StatusOK, h.Info)
+}
@@ -0,0 +1,39 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/pkg/buildinfo"
+)
+
+func TestVersionHandler_ServeHTTP(t *testing.T) {
+	info := buildinfo.Info{
+		Version:   "v1.2.3",
+		GitCommit: "0123abc",
+		BuildDate: "2026-10-01T09:00:00Z",
+		GoVersion: "go1.25.1",
+		OS:        "linux",
+		Arch:      "arm64",
+	}
+	rr := httptest.NewRecorder()
+	(&VersionHandler{Info: info}).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	var got map[string]string
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&got))
+	assert.Equal(t, map[string]string{
+		"version":    "v1.2.3",
+		"git_commit": "0123abc",
+		"build_date": "2026-10-01T09:00:00Z",
+		"go_version": "go1.25.1",
+		"os":         "linux",
+		"arch":       "arm64",
+	}, got)
+}
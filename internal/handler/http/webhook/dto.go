@@ -0,0 +1,98 @@
+// Package webhook provides the outbound webhook management HTTP handlers:
+// admin-only register / list / delete of webhooks and their delivery
+// history, following the flat-path convention (C-21: /webhooks,
+// /webhooks/{id}).
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/pathutil"
+)
+
+// DTO mirrors the webhooks schema without the secret, which is write-only.
+type DTO struct {
+	ID        int64     `json:"id" example:"1"`
+	URL       string    `json:"url" example:"https://hooks.example.com/catchup"`
+	Events    []string  `json:"events" example:"article.created,crawl.completed"`
+	Route     RouteDTO  `json:"route"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RouteDTO narrows which articles an article.created webhook receives.
+// Empty lists mean "no condition"; an all-empty route receives everything.
+type RouteDTO struct {
+	SourceIDs  []int64  `json:"source_ids,omitempty" example:"1,2"`
+	Categories []string `json:"categories,omitempty" example:"security"`
+	Keywords   []string `json:"keywords,omitempty" example:"Go,CVE"`
+	Fallback   bool     `json:"fallback,omitempty" example:"false"`
+}
+
+func toDTO(w *entity.Webhook) DTO {
+	return DTO{ID: w.ID, URL: w.URL, Events: w.Events, Route: RouteDTO(w.Route), CreatedAt: w.CreatedAt}
+}
+
+// Request is the POST /webhooks body.
+type Request struct {
+	URL    string   `json:"url" example:"https://hooks.example.com/catchup"`
+	Secret string   `json:"secret" example:"a-long-random-signing-secret"`
+	Events []string `json:"events" example:"article.created"`
+	Route  RouteDTO `json:"route"`
+}
+
+// DeliveryDTO is one webhook_deliveries row. payload is the exact body
+// that was (or will be) sent.
+type DeliveryDTO struct {
+	ID             int64           `json:"id" example:"10"`
+	Event          string          `json:"event" example:"article.created"`
+	Status         string          `json:"status" example:"delivered"`
+	Attempts       int             `json:"attempts" example:"1"`
+	ResponseStatus *int            `json:"response_status" example:"200"`
+	LastError      *string         `json:"last_error"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+}
+
+func toDeliveryDTO(d *entity.WebhookDelivery) DeliveryDTO {
+	return DeliveryDTO{
+		ID:             d.ID,
+		Event:          d.Event,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		LastError:      d.LastError,
+		Payload:        d.Payload,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+}
+
+var errInvalidLimit = errors.New("invalid limit: must be a positive integer")
+
+// pathID extracts the positive integer {id} path value.
+func pathID(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, pathutil.ErrInvalidID
+	}
+	return id, nil
+}
+
+// queryLimit reads the optional limit query parameter (0 = default).
+func queryLimit(r *http.Request) (int, error) {
+	raw := r.URL.Query().Get("limit")
+	if raw == "" {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit <= 0 {
+		return 0, errInvalidLimit
+	}
+	return limit, nil
+}
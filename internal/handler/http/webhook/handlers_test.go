@@ -0,0 +1,175 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/webhook"
+	webhookUC "catchup-feed/internal/usecase/webhook"
+)
+
+/* ───────── モック実装 ───────── */
+
+type stubWebhookRepo struct {
+	webhooks   map[int64]*entity.Webhook
+	deliveries []*entity.WebhookDelivery
+}
+
+func (s *stubWebhookRepo) Create(_ context.Context, w *entity.Webhook) error {
+	w.ID = int64(len(s.webhooks) + 1)
+	w.CreatedAt = time.Now()
+	s.webhooks[w.ID] = w
+	return nil
+}
+
+func (s *stubWebhookRepo) Get(_ context.Context, id int64) (*entity.Webhook, error) {
+	return s.webhooks[id], nil
+}
+
+func (s *stubWebhookRepo) List(_ context.Context) ([]*entity.Webhook, error) {
+	out := make([]*entity.Webhook, 0, len(s.webhooks))
+	for id := int64(1); id <= int64(len(s.webhooks)); id++ {
+		if w, ok := s.webhooks[id]; ok {
+			out = append(out, w)
+		}
+	}
+	return out, nil
+}
+
+func (s *stubWebhookRepo) Delete(_ context.Context, id int64) error {
+	delete(s.webhooks, id)
+	return nil
+}
+
+func (s *stubWebhookRepo) CreateDeliveries(_ context.Context, _ string, _ json.RawMessage) (int64, error) {
+	return 0, nil
+}
+
+func (s *stubWebhookRepo) CreateDeliveriesTo(_ context.Context, _ []int64, _ string, _ json.RawMessage) (int64, error) {
+	return 0, nil
+}
+
+func (s *stubWebhookRepo) GetDelivery(_ context.Context, _ int64) (*entity.WebhookDelivery, error) {
+	return nil, nil
+}
+
+func (s *stubWebhookRepo) UpdateDelivery(_ context.Context, _ *entity.WebhookDelivery) error {
+	return nil
+}
+
+func (s *stubWebhookRepo) ListDeliveries(_ context.Context, _ int64, _ int) ([]*entity.WebhookDelivery, error) {
+	return s.deliveries, nil
+}
+
+func newMux() (*http.ServeMux, *stubWebhookRepo) {
+	repo := &stubWebhookRepo{webhooks: map[int64]*entity.Webhook{}}
+	svc := &webhookUC.Service{Webhooks: repo}
+	mux := http.NewServeMux()
+	// 認可ミドルウェアなしに Register と同じパターンで直接張る。
+	mux.Handle("GET /webhooks", webhook.ListHandler{Svc: svc})
+	mux.Handle("POST /webhooks", webhook.CreateHandler{Svc: svc})
+	mux.Handle("DELETE /webhooks/{id}", webhook.DeleteHandler{Svc: svc})
+	mux.Handle("GET /webhooks/{id}/deliveries", webhook.DeliveriesHandler{Svc: svc})
+	return mux, repo
+}
+
+func do(mux *http.ServeMux, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+/* ───────── テストケース ───────── */
+
+func TestCreateHandler(t *testing.T) {
+	mux, repo := newMux()
+
+	rec := do(mux, http.MethodPost, "/webhooks",
+		`{"url":"https://hooks.example.com/catchup","secret":"0123456789abcdef","events":["article.created"]}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.NotContains(t, rec.Body.String(), "0123456789abcdef", "secret must never be echoed")
+
+	var got webhook.DTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, int64(1), got.ID)
+	assert.Equal(t, []string{"article.created"}, got.Events)
+	assert.Equal(t, "0123456789abcdef", repo.webhooks[1].Secret)
+
+	rec = do(mux, http.MethodGet, "/webhooks", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "0123456789abcdef")
+}
+
+func TestCreateHandler_Route(t *testing.T) {
+	mux, repo := newMux()
+
+	rec := do(mux, http.MethodPost, "/webhooks",
+		`{"url":"https://hooks.example.com/go","secret":"0123456789abcdef","events":["article.created"],"route":{"source_ids":[2],"keywords":[" Go "]}}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	var got webhook.DTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, webhook.RouteDTO{SourceIDs: []int64{2}, Keywords: []string{"Go"}}, got.Route)
+	assert.Equal(t, entity.WebhookRoute{SourceIDs: []int64{2}, Keywords: []string{"Go"}}, repo.webhooks[1].Route)
+}
+
+func TestCreateHandler_BadRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"malformed json", `{`},
+		{"invalid url", `{"url":"not a url","secret":"0123456789abcdef","events":["article.created"]}`},
+		{"short secret", `{"url":"https://hooks.example.com","secret":"x","events":["article.created"]}`},
+		{"unknown event", `{"url":"https://hooks.example.com","secret":"0123456789abcdef","events":["nope"]}`},
+		{"fallback with conditions", `{"url":"https://hooks.example.com","secret":"0123456789abcdef","events":["article.created"],"route":{"fallback":true,"keywords":["go"]}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, _ := newMux()
+			rec := do(mux, http.MethodPost, "/webhooks", tt.body)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestDeleteHandler(t *testing.T) {
+	mux, repo := newMux()
+	repo.webhooks[1] = &entity.Webhook{ID: 1, URL: "https://hooks.example.com"}
+
+	assert.Equal(t, http.StatusNoContent, do(mux, http.MethodDelete, "/webhooks/1", "").Code)
+	assert.Empty(t, repo.webhooks)
+	assert.Equal(t, http.StatusNotFound, do(mux, http.MethodDelete, "/webhooks/1", "").Code)
+	assert.Equal(t, http.StatusBadRequest, do(mux, http.MethodDelete, "/webhooks/abc", "").Code)
+}
+
+func TestDeliveriesHandler(t *testing.T) {
+	mux, repo := newMux()
+	repo.webhooks[1] = &entity.Webhook{ID: 1, URL: "https://hooks.example.com"}
+	status := 200
+	repo.deliveries = []*entity.WebhookDelivery{{
+		ID: 9, WebhookID: 1, Event: "article.created", Payload: json.RawMessage(`{"event":"article.created"}`),
+		Status: entity.WebhookDeliveryDelivered, Attempts: 1, ResponseStatus: &status, CreatedAt: time.Now(),
+	}}
+
+	rec := do(mux, http.MethodGet, "/webhooks/1/deliveries?limit=10", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var got []map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Len(t, got, 1)
+	assert.Equal(t, "delivered", got[0]["status"])
+	assert.Equal(t, map[string]any{"event": "article.created"}, got[0]["payload"])
+
+	assert.Equal(t, http.StatusBadRequest, do(mux, http.MethodGet, "/webhooks/1/deliveries?limit=x", "").Code)
+	assert.Equal(t, http.StatusNotFound, do(mux, http.MethodGet, "/webhooks/2/deliveries", "").Code)
+}
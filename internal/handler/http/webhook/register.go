@@ -0,0 +1,17 @@
+package webhook
+
+import (
+	"net/http"
+
+	"catchup-feed/internal/handler/http/auth"
+	webhookUC "catchup-feed/internal/usecase/webhook"
+)
+
+// Register registers the webhook management routes (C-21 flat paths).
+// Every route is wrapped in auth.Authz: webhooks are admin-only.
+func Register(mux *http.ServeMux, svc *webhookUC.Service) {
+	mux.Handle("GET /webhooks", auth.Authz(ListHandler{svc}))
+	mux.Handle("POST /webhooks", auth.Authz(CreateHandler{svc}))
+	mux.Handle("DELETE /webhooks/{id}", auth.Authz(DeleteHandler{svc}))
+	mux.Handle("GET /webhooks/{id}/deliveries", auth.Authz(DeliveriesHandler{svc}))
+}
@@ -0,0 +1,25 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+
+	"catchup-feed/internal/handler/http/respond"
+	webhookUC "catchup-feed/internal/usecase/webhook"
+)
+
+// respondUsecaseError maps use case sentinel errors to HTTP statuses:
+// not-found → 404, validation → 400, anything else → sanitized 500.
+func respondUsecaseError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, webhookUC.ErrWebhookNotFound):
+		respond.SafeError(w, http.StatusNotFound, err)
+	case errors.Is(err, webhookUC.ErrInvalidURL),
+		errors.Is(err, webhookUC.ErrInvalidSecret),
+		errors.Is(err, webhookUC.ErrInvalidEvents),
+		errors.Is(err, webhookUC.ErrInvalidRoute):
+		respond.SafeError(w, http.StatusBadRequest, err)
+	default:
+		respond.SafeError(w, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,149 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/handler/http/respond"
+	webhookUC "catchup-feed/internal/usecase/webhook"
+)
+
+type ListHandler struct{ Svc *webhookUC.Service }
+
+// ServeHTTP Webhook 一覧取得
+// @Summary      Webhook 一覧取得
+// @Description  登録済みの Webhook をすべて取得します。署名用の secret は返しません。admin 専用
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {array} DTO "Webhook 一覧"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /webhooks [get]
+func (h ListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	list, err := h.Svc.List(r.Context())
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	out := make([]DTO, 0, len(list))
+	for _, webhook := range list {
+		out = append(out, toDTO(webhook))
+	}
+	respond.JSON(w, http.StatusOK, out)
+}
+
+type CreateHandler struct{ Svc *webhookUC.Service }
+
+// ServeHTTP Webhook 登録
+// @Summary      Webhook 登録
+// @Description  イベント発生時に JSON を POST する Webhook を登録します。events は article.created /
+// @Description  crawl.completed から1つ以上。リクエストには X-Catchup-Event / X-Catchup-Delivery /
+// @Description  X-Catchup-Timestamp と、HMAC-SHA256(secret, "<timestamp>.<body>") の16進を
+// @Description  X-Catchup-Signature: sha256=... として付けます。2xx 以外・通信失敗は再試行し
+// @Description  (4xx は 408 / 429 を除き再試行しない)、結果は配信履歴で確認できます。
+// @Description  secret は16文字以上で、レスポンスには含まれません。
+// @Description  route を指定すると article.created を絞り込めます: source_ids / categories (ソースのカテゴリ) /
+// @Description  keywords (タイトル・要約の部分一致、大文字小文字を区別しない) のいずれかに一致した記事だけを送ります。
+// @Description  fallback: true の Webhook は、条件付き Webhook のどれにも一致しなかった記事を受け取ります。
+// @Description  route なしの Webhook はすべての記事を受け取ります。admin 専用
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        webhook body Request true "Webhook 情報(url / secret / events 必須)"
+// @Success      201 {object} DTO "登録された Webhook"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - 入力が不正"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /webhooks [post]
+func (h CreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	created, err := h.Svc.Create(r.Context(), webhookUC.CreateInput{
+		URL:    req.URL,
+		Secret: req.Secret,
+		Events: req.Events,
+		Route:  entity.WebhookRoute(req.Route),
+	})
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	respond.JSON(w, http.StatusCreated, toDTO(created))
+}
+
+type DeleteHandler struct{ Svc *webhookUC.Service }
+
+// ServeHTTP Webhook 削除
+// @Summary      Webhook 削除
+// @Description  Webhook を配信履歴ごと削除します。未送信の配信は破棄されます。admin 専用
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Param        id path int true "Webhook ID"
+// @Success      204 "No Content"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid ID"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      404 {object} respond.ErrorResponse "Not found - Webhook が存在しない"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /webhooks/{id} [delete]
+func (h DeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.Svc.Delete(r.Context(), id); err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type DeliveriesHandler struct{ Svc *webhookUC.Service }
+
+// ServeHTTP Webhook 配信履歴
+// @Summary      Webhook 配信履歴
+// @Description  Webhook の配信を新しい順に返します。status は pending(未送信・再試行待ち)/
+// @Description  delivered / failed(再試行の上限到達または再試行しない応答)。admin 専用
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Webhook ID"
+// @Param        limit query int false "件数(既定 50、最大 200)"
+// @Success      200 {array} DeliveryDTO "配信履歴"
+// @Failure      400 {object} respond.ErrorResponse "Bad request - invalid ID / limit"
+// @Failure      401 {object} respond.ErrorResponse "Authentication required"
+// @Failure      403 {object} respond.ErrorResponse "Forbidden - admin 専用"
+// @Failure      404 {object} respond.ErrorResponse "Not found - Webhook が存在しない"
+// @Failure      500 {object} respond.ErrorResponse "サーバーエラー"
+// @Router       /webhooks/{id}/deliveries [get]
+func (h DeliveriesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	limit, err := queryLimit(r)
+	if err != nil {
+		respond.SafeError(w, http.StatusBadRequest, err)
+		return
+	}
+	deliveries, err := h.Svc.ListDeliveries(r.Context(), id, limit)
+	if err != nil {
+		respondUsecaseError(w, err)
+		return
+	}
+	out := make([]DeliveryDTO, 0, len(deliveries))
+	for _, d := range deliveries {
+		out = append(out, toDeliveryDTO(d))
+	}
+	respond.JSON(w, http.StatusOK, out)
+}
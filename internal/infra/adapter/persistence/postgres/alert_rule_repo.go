@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+const alertRuleColumns = "id, user_id, name, query, enabled, hit_count, last_hit_at, created_at, updated_at"
+
+// AlertRuleRepo persists keyword alert rules (alert_rules).
+type AlertRuleRepo struct{ db *sql.DB }
+
+func NewAlertRuleRepo(db *sql.DB) repository.AlertRuleRepository {
+	return &AlertRuleRepo{db: db}
+}
+
+// mapAlertRuleErr converts a unique_violation on (user_id, name) into the
+// repository sentinel so the use case can answer 409 instead of 500.
+func mapAlertRuleErr(op string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		return fmt.Errorf("%s: %w", op, repository.ErrDuplicateAlertRuleName)
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
+
+func scanAlertRule(s scanner) (*entity.AlertRule, error) {
+	var r entity.AlertRule
+	if err := s.Scan(
+		&r.ID, &r.UserID, &r.Name, &r.Query, &r.Enabled,
+		&r.HitCount, &r.LastHitAt, &r.CreatedAt, &r.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (repo *AlertRuleRepo) queryRules(ctx context.Context, op, query string, args ...any) ([]*entity.AlertRule, error) {
+	rows, err := conn(ctx, repo.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	rules := make([]*entity.AlertRule, 0)
+	for rows.Next() {
+		rule, err := scanAlertRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return rules, nil
+}
+
+// List returns the user's rules, oldest first.
+func (repo *AlertRuleRepo) List(ctx context.Context, userID int64) ([]*entity.AlertRule, error) {
+	query := `
+SELECT ` + alertRuleColumns + `
+FROM alert_rules
+WHERE user_id = $1
+ORDER BY id ASC`
+	return repo.queryRules(ctx, "List", query, userID)
+}
+
+// ListEnabled returns every enabled rule, oldest first. It runs inside the
+// crawl's unit of work when there is one (conn).
+func (repo *AlertRuleRepo) ListEnabled(ctx context.Context) ([]*entity.AlertRule, error) {
+	query := `
+SELECT ` + alertRuleColumns + `
+FROM alert_rules
+WHERE enabled
+ORDER BY id ASC`
+	return repo.queryRules(ctx, "ListEnabled", query)
+}
+
+// Get returns the rule by ID, or nil when not found.
+func (repo *AlertRuleRepo) Get(ctx context.Context, id int64) (*entity.AlertRule, error) {
+	query := `
+SELECT ` + alertRuleColumns + `
+FROM alert_rules
+WHERE id = $1
+LIMIT 1`
+	rule, err := scanAlertRule(repo.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Get: %w", err)
+	}
+	return rule, nil
+}
+
+// Create inserts the rule and sets rule.ID / HitCount / CreatedAt /
+// UpdatedAt.
+func (repo *AlertRuleRepo) Create(ctx context.Context, rule *entity.AlertRule) error {
+	const query = `
+INSERT INTO alert_rules (user_id, name, query, enabled)
+VALUES ($1, $2, $3, $4)
+RETURNING id, hit_count, created_at, updated_at`
+	if err := repo.db.QueryRowContext(ctx, query,
+		rule.UserID, rule.Name, rule.Query, rule.Enabled,
+	).Scan(&rule.ID, &rule.HitCount, &rule.CreatedAt, &rule.UpdatedAt); err != nil {
+		return mapAlertRuleErr("Create", err)
+	}
+	return nil
+}
+
+// Update saves name, query and enabled and sets rule.UpdatedAt. The hit
+// counters are left alone.
+func (repo *AlertRuleRepo) Update(ctx context.Context, rule *entity.AlertRule) error {
+	const query = `
+UPDATE alert_rules SET
+       name       = $2,
+       query      = $3,
+       enabled    = $4,
+       updated_at = now()
+WHERE id = $1
+RETURNING updated_at`
+	err := repo.db.QueryRowContext(ctx, query, rule.ID, rule.Name, rule.Query, rule.Enabled).Scan(&rule.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil // deleted meanwhile; Delete is idempotent too
+	}
+	if err != nil {
+		return mapAlertRuleErr("Update", err)
+	}
+	return nil
+}
+
+// Delete removes the rule.
+func (repo *AlertRuleRepo) Delete(ctx context.Context, id int64) error {
+	if _, err := repo.db.ExecContext(ctx, `DELETE FROM alert_rules WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("Delete: %w", err)
+	}
+	return nil
+}
+
+// RecordHits counts the hits and queues their notify_alert jobs in a
+// single statement, like WebhookRepo.CreateDeliveriesTo: a hit is never
+// counted without its notification (or the other way round). A rule
+// disabled since it was matched is skipped.
+func (repo *AlertRuleRepo) RecordHits(ctx context.Context, ruleIDs []int64, article entity.WebhookArticleData) (int64, error) {
+	if len(ruleIDs) == 0 {
+		return 0, nil
+	}
+	payload, err := json.Marshal(article)
+	if err != nil {
+		return 0, fmt.Errorf("RecordHits: article: %w", err)
+	}
+	in, args := idPlaceholders(ruleIDs, 3)
+	// #nosec G201 -- in contains only generated $N placeholders.
+	query := fmt.Sprintf(`
+WITH r AS (
+    UPDATE alert_rules SET
+           hit_count   = hit_count + 1,
+           last_hit_at = now()
+    WHERE enabled
+      AND id IN (%s)
+    RETURNING id, name
+)
+INSERT INTO jobs (kind, payload)
+SELECT $1, jsonb_build_object('rule_id', r.id, 'rule_name', r.name, 'article', $2::jsonb)
+FROM r`, in)
+	res, err := conn(ctx, repo.db).ExecContext(ctx, query, append([]any{entity.JobKindNotifyAlert, json.RawMessage(payload)}, args...)...)
+	if err != nil {
+		return 0, fmt.Errorf("RecordHits: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("RecordHits: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,118 @@
+package postgres_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+	"catchup-feed/internal/repository"
+)
+
+var alertRuleCols = []string{"id", "user_id", "name", "query", "enabled", "hit_count", "last_hit_at", "created_at", "updated_at"}
+
+func newAlertRuleRepo(t *testing.T) (repository.AlertRuleRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return pg.NewAlertRuleRepo(db), mock, func() { _ = db.Close() }
+}
+
+func TestAlertRuleRepo_Create(t *testing.T) {
+	repo, mock, closeFn := newAlertRuleRepo(t)
+	defer closeFn()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO alert_rules")).
+		WithArgs(int64(7), "Go", "go -beta", true).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "hit_count", "created_at", "updated_at"}).AddRow(int64(3), int64(0), now, now))
+	rule := &entity.AlertRule{UserID: 7, Name: "Go", Query: "go -beta", Enabled: true}
+	require.NoError(t, repo.Create(context.Background(), rule))
+	assert.Equal(t, int64(3), rule.ID)
+
+	// 同じユーザーの同名ルールは sentinel に変換
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO alert_rules")).
+		WillReturnError(&pgconn.PgError{Code: "23505"})
+	err := repo.Create(context.Background(), &entity.AlertRule{UserID: 7, Name: "Go", Query: "go"})
+	assert.True(t, errors.Is(err, repository.ErrDuplicateAlertRuleName))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAlertRuleRepo_List(t *testing.T) {
+	repo, mock, closeFn := newAlertRuleRepo(t)
+	defer closeFn()
+
+	now := time.Now()
+	mock.ExpectQuery(`FROM alert_rules\s+WHERE user_id = \$1`).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows(alertRuleCols).
+			AddRow(int64(1), int64(7), "Go", "go", true, int64(4), now, now, now).
+			AddRow(int64(2), int64(7), "Rust", "rust", false, int64(0), nil, now, now))
+
+	rules, err := repo.List(context.Background(), 7)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, int64(4), rules[0].HitCount)
+	require.NotNil(t, rules[0].LastHitAt)
+	assert.Nil(t, rules[1].LastHitAt)
+	assert.False(t, rules[1].Enabled)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAlertRuleRepo_Get_NotFound(t *testing.T) {
+	repo, mock, closeFn := newAlertRuleRepo(t)
+	defer closeFn()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM alert_rules")).
+		WithArgs(int64(9)).
+		WillReturnRows(sqlmock.NewRows(alertRuleCols))
+	rule, err := repo.Get(context.Background(), 9)
+	require.NoError(t, err)
+	assert.Nil(t, rule)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAlertRuleRepo_Update(t *testing.T) {
+	repo, mock, closeFn := newAlertRuleRepo(t)
+	defer closeFn()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE alert_rules SET")).
+		WithArgs(int64(3), "Go", "go OR golang", false).
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(now))
+	rule := &entity.AlertRule{ID: 3, Name: "Go", Query: "go OR golang"}
+	require.NoError(t, repo.Update(context.Background(), rule))
+	assert.Equal(t, now, rule.UpdatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAlertRuleRepo_RecordHits(t *testing.T) {
+	repo, mock, closeFn := newAlertRuleRepo(t)
+	defer closeFn()
+
+	article := entity.WebhookArticleData{ID: 10, SourceID: 2, Title: "Go 1.23", URL: "https://example.com/go"}
+	payload, err := json.Marshal(article)
+	require.NoError(t, err)
+	mock.ExpectExec(`hit_count\s+= hit_count \+ 1.*WHERE enabled\s+AND id IN \(\$3, \$4\).*INSERT INTO jobs`).
+		WithArgs(entity.JobKindNotifyAlert, json.RawMessage(payload), int64(1), int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	n, err := repo.RecordHits(context.Background(), []int64{1, 3}, article)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	// 一致なしはクエリを発行しない
+	n, err = repo.RecordHits(context.Background(), nil, article)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+const apiKeyColumns = "id, name, role, key_hash, key_prefix, rate_limit, daily_quota, created_at, last_used_at, revoked_at"
+
+// APIKeyRepo persists service-to-service API keys (api_keys table).
+type APIKeyRepo struct{ db *sql.DB }
+
+func NewAPIKeyRepo(db *sql.DB) repository.APIKeyRepository {
+	return &APIKeyRepo{db: db}
+}
+
+func scanAPIKey(s scanner) (*entity.APIKey, error) {
+	var key entity.APIKey
+	var quota sql.NullInt64
+	if err := s.Scan(
+		&key.ID, &key.Name, &key.Role, &key.KeyHash, &key.KeyPrefix,
+		&key.RateLimit, &quota, &key.CreatedAt, &key.LastUsedAt, &key.RevokedAt,
+	); err != nil {
+		return nil, err
+	}
+	if quota.Valid {
+		q := int(quota.Int64)
+		key.DailyQuota = &q
+	}
+	return &key, nil
+}
+
+// Create inserts the key and sets key.ID / CreatedAt.
+func (repo *APIKeyRepo) Create(ctx context.Context, key *entity.APIKey) error {
+	const query = `
+INSERT INTO api_keys (name, role, key_hash, key_prefix, rate_limit, daily_quota)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, created_at`
+	err := repo.db.QueryRowContext(ctx, query,
+		key.Name, key.Role, key.KeyHash, key.KeyPrefix, key.RateLimit, key.DailyQuota,
+	).Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("Create: %w", err)
+	}
+	return nil
+}
+
+// Get returns the key by ID, or nil when not found.
+func (repo *APIKeyRepo) Get(ctx context.Context, id int64) (*entity.APIKey, error) {
+	query := `
+SELECT ` + apiKeyColumns + `
+FROM api_keys
+WHERE id = $1
+LIMIT 1`
+	key, err := scanAPIKey(repo.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Get: %w", err)
+	}
+	return key, nil
+}
+
+// List returns all keys (revoked included), oldest first.
+func (repo *APIKeyRepo) List(ctx context.Context) ([]*entity.APIKey, error) {
+	query := `
+SELECT ` + apiKeyColumns + `
+FROM api_keys
+ORDER BY id ASC`
+	rows, err := repo.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("List: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	keys := make([]*entity.APIKey, 0, 10)
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("List: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// Update rewrites name / role / rate_limit / daily_quota.
+func (repo *APIKeyRepo) Update(ctx context.Context, key *entity.APIKey) error {
+	const query = `
+UPDATE api_keys SET
+       name        = $1,
+       role        = $2,
+       rate_limit  = $3,
+       daily_quota = $4
+WHERE id = $5`
+	res, err := repo.db.ExecContext(ctx, query,
+		key.Name, key.Role, key.RateLimit, key.DailyQuota, key.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("Update: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("Update: no rows affected")
+	}
+	return nil
+}
+
+// Revoke marks the key revoked as of t (idempotent: an already revoked key
+// keeps its original timestamp).
+func (repo *APIKeyRepo) Revoke(ctx context.Context, id int64, t time.Time) error {
+	const query = `
+UPDATE api_keys SET revoked_at = $1
+WHERE id = $2 AND revoked_at IS NULL`
+	if _, err := repo.db.ExecContext(ctx, query, t, id); err != nil {
+		return fmt.Errorf("Revoke: %w", err)
+	}
+	return nil
+}
+
+// GetActiveByHash returns the non-revoked key with the given hash, or nil.
+func (repo *APIKeyRepo) GetActiveByHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	query := `
+SELECT ` + apiKeyColumns + `
+FROM api_keys
+WHERE key_hash = $1 AND revoked_at IS NULL
+LIMIT 1`
+	key, err := scanAPIKey(repo.db.QueryRowContext(ctx, query, keyHash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetActiveByHash: %w", err)
+	}
+	return key, nil
+}
+
+// TouchLastUsed sets last_used_at to t.
+func (repo *APIKeyRepo) TouchLastUsed(ctx context.Context, id int64, t time.Time) error {
+	const query = `UPDATE api_keys SET last_used_at = $1 WHERE id = $2`
+	if _, err := repo.db.ExecContext(ctx, query, t, id); err != nil {
+		return fmt.Errorf("TouchLastUsed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package postgres_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+	"catchup-feed/internal/repository"
+)
+
+var apiKeyCols = []string{"id", "name", "role", "key_hash", "key_prefix", "rate_limit", "daily_quota", "created_at", "last_used_at", "revoked_at"}
+
+func newAPIKeyRepo(t *testing.T) (repository.APIKeyRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return pg.NewAPIKeyRepo(db), mock, func() { _ = db.Close() }
+}
+
+func TestAPIKeyRepo_Create(t *testing.T) {
+	repo, mock, closeFn := newAPIKeyRepo(t)
+	defer closeFn()
+
+	quota := 1000
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO api_keys")).
+		WithArgs("ingest", "viewer", "hash", "cfk_abcdefgh", 30, &quota).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(int64(4), now))
+
+	key := &entity.APIKey{Name: "ingest", Role: "viewer", KeyHash: "hash", KeyPrefix: "cfk_abcdefgh", RateLimit: 30, DailyQuota: &quota}
+	require.NoError(t, repo.Create(context.Background(), key))
+	assert.Equal(t, int64(4), key.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyRepo_GetActiveByHash(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		rows      *sqlmock.Rows
+		wantNil   bool
+		wantQuota *int
+	}{
+		{
+			name: "unlimited quota",
+			rows: sqlmock.NewRows(apiKeyCols).
+				AddRow(int64(1), "ingest", "admin", "hash", "cfk_abcdefgh", 60, nil, now, nil, nil),
+		},
+		{
+			name: "with daily quota",
+			rows: sqlmock.NewRows(apiKeyCols).
+				AddRow(int64(1), "ingest", "admin", "hash", "cfk_abcdefgh", 60, int64(500), now, now, nil),
+			wantQuota: func() *int { q := 500; return &q }(),
+		},
+		{
+			name:    "unknown or revoked returns nil, nil",
+			rows:    sqlmock.NewRows(apiKeyCols),
+			wantNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, closeFn := newAPIKeyRepo(t)
+			defer closeFn()
+
+			mock.ExpectQuery(regexp.QuoteMeta("key_hash = $1 AND revoked_at IS NULL")).
+				WithArgs("hash").
+				WillReturnRows(tt.rows)
+
+			got, err := repo.GetActiveByHash(context.Background(), "hash")
+			require.NoError(t, err)
+			if tt.wantNil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, tt.wantQuota, got.DailyQuota)
+		})
+	}
+}
+
+func TestAPIKeyRepo_Revoke(t *testing.T) {
+	repo, mock, closeFn := newAPIKeyRepo(t)
+	defer closeFn()
+
+	at := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("revoked_at IS NULL")).
+		WithArgs(at, int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.Revoke(context.Background(), 1, at))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// ArticleContentRepo persists fetched article pages (article_contents table).
+type ArticleContentRepo struct{ db *sql.DB }
+
+func NewArticleContentRepo(db *sql.DB) repository.ArticleContentRepository {
+	return &ArticleContentRepo{db: db}
+}
+
+func (repo *ArticleContentRepo) Save(ctx context.Context, content *entity.ArticleContent) error {
+	if content.FetchedAt.IsZero() {
+		content.FetchedAt = time.Now()
+	}
+	const query = `
+INSERT INTO article_contents (article_id, url, html, text, fetched_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (article_id) DO UPDATE SET
+       url        = EXCLUDED.url,
+       html       = EXCLUDED.html,
+       text       = EXCLUDED.text,
+       fetched_at = EXCLUDED.fetched_at`
+	if _, err := repo.db.ExecContext(ctx, query,
+		content.ArticleID, content.URL, content.HTML, content.Text, content.FetchedAt,
+	); err != nil {
+		return fmt.Errorf("Save: %w", err)
+	}
+	return nil
+}
+
+func (repo *ArticleContentRepo) Get(ctx context.Context, articleID int64) (*entity.ArticleContent, error) {
+	const query = `
+SELECT article_id, url, html, text, fetched_at
+FROM article_contents
+WHERE article_id = $1`
+	var c entity.ArticleContent
+	err := repo.db.QueryRowContext(ctx, query, articleID).Scan(
+		&c.ArticleID, &c.URL, &c.HTML, &c.Text, &c.FetchedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Get: %w", err)
+	}
+	return &c, nil
+}
@@ -0,0 +1,76 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+	"catchup-feed/internal/repository"
+)
+
+func newArticleContentRepo(t *testing.T) (repository.ArticleContentRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return pg.NewArticleContentRepo(db), mock, func() { _ = db.Close() }
+}
+
+func TestArticleContentRepo_Save(t *testing.T) {
+	repo, mock, closeFn := newArticleContentRepo(t)
+	defer closeFn()
+
+	at := time.Date(2026, 10, 1, 5, 30, 0, 0, time.UTC)
+	mock.ExpectExec(`INSERT INTO article_contents .*ON CONFLICT \(article_id\) DO UPDATE SET`).
+		WithArgs(int64(3), "https://example.com/post", "<html>…</html>", "本文", at).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.Save(context.Background(), &entity.ArticleContent{
+		ArticleID: 3, URL: "https://example.com/post", HTML: "<html>…</html>", Text: "本文", FetchedAt: at,
+	})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleContentRepo_Get(t *testing.T) {
+	at := time.Date(2026, 10, 1, 5, 30, 0, 0, time.UTC)
+	cols := []string{"article_id", "url", "html", "text", "fetched_at"}
+
+	t.Run("found", func(t *testing.T) {
+		repo, mock, closeFn := newArticleContentRepo(t)
+		defer closeFn()
+		mock.ExpectQuery("FROM article_contents").
+			WithArgs(int64(3)).
+			WillReturnRows(sqlmock.NewRows(cols).AddRow(int64(3), "https://example.com/post", "<p>x</p>", "x", at))
+
+		got, err := repo.Get(context.Background(), 3)
+		require.NoError(t, err)
+		assert.Equal(t, &entity.ArticleContent{ArticleID: 3, URL: "https://example.com/post", HTML: "<p>x</p>", Text: "x", FetchedAt: at}, got)
+	})
+
+	t.Run("missing returns nil", func(t *testing.T) {
+		repo, mock, closeFn := newArticleContentRepo(t)
+		defer closeFn()
+		mock.ExpectQuery("FROM article_contents").WillReturnError(sql.ErrNoRows)
+
+		got, err := repo.Get(context.Background(), 4)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		repo, mock, closeFn := newArticleContentRepo(t)
+		defer closeFn()
+		mock.ExpectQuery("FROM article_contents").WillReturnError(errors.New("boom"))
+
+		_, err := repo.Get(context.Background(), 4)
+		assert.Error(t, err)
+	})
+}
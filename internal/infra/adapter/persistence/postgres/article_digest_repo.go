@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"catchup-feed/internal/repository"
+)
+
+// ArticleDigestRepo selects the articles of the notification digest.
+type ArticleDigestRepo struct {
+	db *sql.DB
+}
+
+func NewArticleDigestRepo(db *sql.DB) repository.ArticleDigestRepository {
+	return &ArticleDigestRepo{db: db}
+}
+
+// digestNotifyCondition leaves out the articles of sources in a source
+// group with notify turned off.
+const digestNotifyCondition = `NOT EXISTS (
+      SELECT 1 FROM sources gs
+      INNER JOIN source_groups g ON g.id = gs.group_id
+      WHERE gs.id = a.source_id AND NOT g.notify)`
+
+func (repo *ArticleDigestRepo) ListStoredBetween(ctx context.Context, from, to time.Time, limit int) ([]repository.ArticleWithSource, int64, error) {
+	var total int64
+	err := repo.db.QueryRowContext(ctx, `
+SELECT COUNT(*)
+FROM articles a
+WHERE a.crawled_at >= $1 AND a.crawled_at < $2
+  AND `+digestNotifyCondition+`
+  AND a.duplicate_of IS NULL`, from, to).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ListStoredBetween: %w", err)
+	}
+	if total == 0 {
+		return nil, 0, nil
+	}
+
+	query := `
+SELECT ` + articleColumns + `, s.name AS source_name
+` + articleFrom + `
+INNER JOIN sources s ON a.source_id = s.id
+WHERE a.crawled_at >= $1 AND a.crawled_at < $2
+  AND ` + digestNotifyCondition + `
+  AND a.duplicate_of IS NULL
+ORDER BY a.crawled_at DESC, a.id DESC
+LIMIT $3`
+	articles, err := queryArticlesWithSource(ctx, repo.db, "ListStoredBetween", query, limit, from, to, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return articles, total, nil
+}
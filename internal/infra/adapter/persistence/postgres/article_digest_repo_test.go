@@ -0,0 +1,69 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+)
+
+func TestArticleDigestRepo_ListStoredBetween(t *testing.T) {
+	from := time.Date(2026, 10, 14, 8, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	t.Run("articles", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(`SELECT COUNT\(\*\)(.|\n)+NOT g.notify`).
+			WithArgs(from, to).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(3)))
+		now := time.Now()
+		mock.ExpectQuery(`a.duplicate_of IS NULL\s+ORDER BY a.crawled_at DESC, a.id DESC\s+LIMIT \$3`).
+			WithArgs(from, to, 2).
+			WillReturnRows(sqlmock.NewRows(append(articleCols, "source_name")).
+				AddRow(int64(9), int64(1), "newest", "https://a/9", "", "s", now, now, "", "Go Blog").
+				AddRow(int64(8), int64(2), "older", "https://b/8", "", "s", now, now, "", "Mirror"))
+
+		got, total, err := pg.NewArticleDigestRepo(db).ListStoredBetween(context.Background(), from, to, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		require.Len(t, got, 2)
+		assert.Equal(t, int64(9), got[0].Article.ID)
+		assert.Equal(t, "Mirror", got[1].SourceName)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("none skips the list query", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(`SELECT COUNT\(\*\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(0)))
+
+		got, total, err := pg.NewArticleDigestRepo(db).ListStoredBetween(context.Background(), from, to, 20)
+		require.NoError(t, err)
+		assert.Zero(t, total)
+		assert.Empty(t, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(`SELECT COUNT\(\*\)`).WillReturnError(errors.New("boom"))
+
+		_, _, err = pg.NewArticleDigestRepo(db).ListStoredBetween(context.Background(), from, to, 20)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"catchup-feed/internal/repository"
+)
+
+// ArticleExportRepo reads the rows of the scheduled article export and
+// keeps the log of its runs (article_exports).
+type ArticleExportRepo struct{ db *sql.DB }
+
+func NewArticleExportRepo(db *sql.DB) repository.ArticleExportRepository {
+	return &ArticleExportRepo{db: db}
+}
+
+func (repo *ArticleExportRepo) LastExportedUntil(ctx context.Context) (time.Time, error) {
+	var until time.Time
+	err := repo.db.QueryRowContext(ctx,
+		`SELECT window_to FROM article_exports ORDER BY id DESC LIMIT 1`).Scan(&until)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("LastExportedUntil: %w", err)
+	}
+	return until, nil
+}
+
+// ListChanged pages by article ID, so a window of any size is read in
+// bounded batches.
+func (repo *ArticleExportRepo) ListChanged(ctx context.Context, from, to time.Time, afterID int64, limit int) ([]repository.ExportedArticle, error) {
+	const query = `
+SELECT a.id, a.source_id, s.name, a.title, a.url, a.published_at, a.crawled_at,
+       sm.body, sm.provider, sm.created_at
+FROM articles a
+INNER JOIN sources s ON s.id = a.source_id
+LEFT JOIN summaries sm ON sm.article_id = a.id
+WHERE a.id > $3
+  AND ((a.crawled_at >= $1 AND a.crawled_at < $2)
+    OR (sm.created_at >= $1 AND sm.created_at < $2))
+ORDER BY a.id ASC
+LIMIT $4`
+	rows, err := repo.db.QueryContext(ctx, query, from, to, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ListChanged: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := make([]repository.ExportedArticle, 0, limit)
+	for rows.Next() {
+		var a repository.ExportedArticle
+		if err := rows.Scan(&a.ID, &a.SourceID, &a.SourceName, &a.Title, &a.URL, &a.PublishedAt, &a.CrawledAt,
+			&a.Summary, &a.SummaryProvider, &a.SummarizedAt); err != nil {
+			return nil, fmt.Errorf("ListChanged: Scan: %w", err)
+		}
+		out = append(out, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ListChanged: %w", err)
+	}
+	return out, nil
+}
+
+func (repo *ArticleExportRepo) Record(ctx context.Context, export *repository.ArticleExport) error {
+	keys, err := json.Marshal(export.ObjectKeys)
+	if err != nil {
+		return fmt.Errorf("Record: object keys: %w", err)
+	}
+	const query = `
+INSERT INTO article_exports (window_from, window_to, object_keys, row_count)
+VALUES ($1, $2, $3, $4)
+RETURNING id, created_at`
+	if err := repo.db.QueryRowContext(ctx, query,
+		export.From, export.To, json.RawMessage(keys), export.Rows,
+	).Scan(&export.ID, &export.CreatedAt); err != nil {
+		return fmt.Errorf("Record: %w", err)
+	}
+	return nil
+}
+
+func (repo *ArticleExportRepo) ListExpired(ctx context.Context, before time.Time) ([]repository.ArticleExport, error) {
+	const query = `
+SELECT id, window_from, window_to, object_keys, row_count, created_at
+FROM article_exports
+WHERE created_at < $1
+  AND id < (SELECT max(id) FROM article_exports)
+ORDER BY id ASC`
+	rows, err := repo.db.QueryContext(ctx, query, before)
+	if err != nil {
+		return nil, fmt.Errorf("ListExpired: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []repository.ArticleExport
+	for rows.Next() {
+		var (
+			e    repository.ArticleExport
+			keys []byte
+		)
+		if err := rows.Scan(&e.ID, &e.From, &e.To, &keys, &e.Rows, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("ListExpired: Scan: %w", err)
+		}
+		if err := json.Unmarshal(keys, &e.ObjectKeys); err != nil {
+			return nil, fmt.Errorf("ListExpired: decode object keys: %w", err)
+		}
+		out = append(out, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ListExpired: %w", err)
+	}
+	return out, nil
+}
+
+func (repo *ArticleExportRepo) Delete(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	in, args := idPlaceholders(ids, 1)
+	// #nosec G201 -- in contains only generated $N placeholders.
+	if _, err := repo.db.ExecContext(ctx, fmt.Sprintf(`DELETE FROM article_exports WHERE id IN (%s)`, in), args...); err != nil {
+		return fmt.Errorf("Delete: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package postgres_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+	"catchup-feed/internal/repository"
+)
+
+func TestArticleExportRepo_LastExportedUntil(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	repo := pg.NewArticleExportRepo(db)
+
+	until := time.Date(2026, 10, 15, 7, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT window_to FROM article_exports ORDER BY id DESC LIMIT 1")).
+		WillReturnRows(sqlmock.NewRows([]string{"window_to"}).AddRow(until))
+	got, err := repo.LastExportedUntil(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, until, got)
+
+	// 初回は zero time(全件が対象)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT window_to FROM article_exports")).
+		WillReturnRows(sqlmock.NewRows([]string{"window_to"}))
+	got, err = repo.LastExportedUntil(context.Background())
+	require.NoError(t, err)
+	assert.True(t, got.IsZero())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleExportRepo_ListChanged(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	from := time.Date(2026, 10, 14, 7, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	crawled := from.Add(time.Hour)
+	mock.ExpectQuery(`LEFT JOIN summaries sm(.|\n)+OR \(sm.created_at >= \$1 AND sm.created_at < \$2\)\)\s+ORDER BY a.id ASC\s+LIMIT \$4`).
+		WithArgs(from, to, int64(10), 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "source_id", "name", "title", "url", "published_at", "crawled_at", "body", "provider", "created_at"}).
+			AddRow(int64(11), int64(1), "Go Blog", "Go 1.26", "https://go.dev/blog/go1.26", nil, crawled, "要約", "gemini", crawled).
+			AddRow(int64(12), int64(2), "Podcast", "Episode 1", "https://p/1", crawled, crawled, nil, nil, nil))
+
+	got, err := pg.NewArticleExportRepo(db).ListChanged(context.Background(), from, to, 10, 2)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Nil(t, got[0].PublishedAt)
+	require.NotNil(t, got[0].Summary)
+	assert.Equal(t, "要約", *got[0].Summary)
+	assert.Equal(t, "gemini", *got[0].SummaryProvider)
+	assert.Nil(t, got[1].Summary, "not summarized yet")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleExportRepo_RecordAndExpire(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	repo := pg.NewArticleExportRepo(db)
+
+	from := time.Date(2026, 10, 14, 7, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO article_exports")).
+		WithArgs(from, to, []byte(`["p/a-0001.ndjson.gz"]`), 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(int64(5), now))
+	export := &repository.ArticleExport{From: from, To: to, ObjectKeys: []string{"p/a-0001.ndjson.gz"}, Rows: 3}
+	require.NoError(t, repo.Record(context.Background(), export))
+	assert.Equal(t, int64(5), export.ID)
+
+	before := now.AddDate(0, 0, -30)
+	mock.ExpectQuery(`WHERE created_at < \$1\s+AND id < \(SELECT max\(id\) FROM article_exports\)`).
+		WithArgs(before).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "window_from", "window_to", "object_keys", "row_count", "created_at"}).
+			AddRow(int64(1), from, to, []byte(`["p/a-0001.ndjson.gz","p/a-0002.ndjson.gz"]`), 60000, before).
+			AddRow(int64(2), to, to, []byte(`[]`), 0, before))
+	expired, err := repo.ListExpired(context.Background(), before)
+	require.NoError(t, err)
+	require.Len(t, expired, 2)
+	assert.Equal(t, []string{"p/a-0001.ndjson.gz", "p/a-0002.ndjson.gz"}, expired[0].ObjectKeys)
+	assert.Empty(t, expired[1].ObjectKeys)
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM article_exports WHERE id IN ($1, $2)")).
+		WithArgs(int64(1), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	require.NoError(t, repo.Delete(context.Background(), []int64{1, 2}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -11,40 +11,86 @@ import (
 
 // ArticleQueryBuilder builds WHERE clauses for article search in PostgreSQL.
 // This builder is shared between COUNT and SELECT queries to eliminate duplication.
-// It uses PostgreSQL-specific features like ILIKE and numbered placeholders ($1, $2, etc.).
-type ArticleQueryBuilder struct{}
+// Keywords use full-text search (tsvector @@ tsquery) with an ILIKE fallback backed
+// by pg_trgm indexes, and numbered placeholders ($1, $2, etc.).
+type ArticleQueryBuilder struct {
+	// tsConfig is the text search configuration keywords are parsed with.
+	// Only search.IsTextSearchConfig names are accepted, because it is
+	// interpolated into the SQL as a literal.
+	tsConfig string
+}
 
-// NewArticleQueryBuilder creates a new query builder instance.
+// NewArticleQueryBuilder creates a new query builder instance using the
+// default text search configuration.
 func NewArticleQueryBuilder() *ArticleQueryBuilder {
-	return &ArticleQueryBuilder{}
+	return NewArticleQueryBuilderWithConfig(search.DefaultTextSearchConfig)
+}
+
+// NewArticleQueryBuilderWithConfig creates a query builder parsing keywords
+// with tsConfig. Unknown configurations fall back to the default.
+func NewArticleQueryBuilderWithConfig(tsConfig string) *ArticleQueryBuilder {
+	if !search.IsTextSearchConfig(tsConfig) {
+		tsConfig = search.DefaultTextSearchConfig
+	}
+	return &ArticleQueryBuilder{tsConfig: tsConfig}
+}
+
+// documents returns the tsvector expressions of the title and the summary
+// body. The stored tsv columns are built with the default configuration
+// (migration); any other configuration computes the vectors on the fly,
+// which matches correctly but cannot use the GIN indexes.
+func (qb *ArticleQueryBuilder) documents(tableAlias string) (title, body string) {
+	if qb.tsConfig == search.DefaultTextSearchConfig {
+		if tableAlias != "" {
+			return tableAlias + ".tsv", "sm.tsv"
+		}
+		// summaries has a tsv column too, so qualify it even without alias
+		return "articles.tsv", "sm.tsv"
+	}
+	titleCol := "title"
+	if tableAlias != "" {
+		titleCol = tableAlias + ".title"
+	}
+	return fmt.Sprintf("to_tsvector('%s', %s)", qb.tsConfig, titleCol),
+		fmt.Sprintf("to_tsvector('%s', sm.body)", qb.tsConfig)
+}
+
+// tsquery returns the tsquery expression for the keyword at placeholder n.
+func (qb *ArticleQueryBuilder) tsquery(n int) string {
+	return fmt.Sprintf("plainto_tsquery('%s', $%d)", qb.tsConfig, n)
 }
 
 // BuildWhereClause builds WHERE clause and arguments for article search.
-// It supports multi-keyword AND logic and optional filters (source_id, date range).
+// It supports multi-keyword AND logic and optional filters (source_id, date range, tag, unread, favorites, summarized).
 // Returns empty string if no conditions are provided.
-// PostgreSQL-specific: Uses ILIKE for case-insensitive search and $N placeholders.
+//
+// Each keyword takes two placeholders, the ILIKE pattern ($n) and the raw
+// keyword for plainto_tsquery ($n+1), and matches when either the
+// full-text search or the ILIKE fallback does. The fallback keeps
+// substring matches working where the parser finds no word boundaries
+// (日本語の要約) and for partial words; pg_trgm indexes keep it fast.
+// Keyword placeholders always come first (see BuildOrderBy).
 func (qb *ArticleQueryBuilder) BuildWhereClause(keywords []string, filters repository.ArticleSearchFilters, tableAlias string) (clause string, args []interface{}) {
 	var conditions []string
 	paramIndex := 1
 
 	// Add keyword conditions (multi-keyword AND logic)
-	// Each keyword searches in both title and summary using ILIKE (case-insensitive).
 	// The summary body lives in the summaries table (§4), so keyword queries
 	// require "LEFT JOIN summaries sm ON sm.article_id = a.id" (articleFrom).
+	titleDoc, bodyDoc := qb.documents(tableAlias)
 	for _, keyword := range keywords {
-		// Escape special characters for ILIKE
-		escapedKeyword := search.EscapeILIKE(keyword)
-
 		// Build condition with table alias if provided
 		titleCol := "title"
 		if tableAlias != "" {
 			titleCol = tableAlias + ".title"
 		}
-		summaryCol := "sm.body"
+		query := qb.tsquery(paramIndex + 1)
 
-		conditions = append(conditions, fmt.Sprintf("(%s ILIKE $%d OR %s ILIKE $%d)", titleCol, paramIndex, summaryCol, paramIndex))
-		args = append(args, escapedKeyword)
-		paramIndex++
+		conditions = append(conditions, fmt.Sprintf("(%s @@ %s OR %s @@ %s OR %s ILIKE $%d OR sm.body ILIKE $%d)",
+			titleDoc, query, bodyDoc, query, titleCol, paramIndex, paramIndex))
+		// Escape special characters for ILIKE
+		args = append(args, search.EscapeILIKE(keyword), keyword)
+		paramIndex += 2
 	}
 
 	// Add source ID filter
@@ -60,6 +106,19 @@ func (qb *ArticleQueryBuilder) BuildWhereClause(keywords []string, filters repos
 		paramIndex++
 	}
 
+	// Add source group filter (articles of the group's member sources)
+	if filters.GroupID != nil {
+		col := "articles.source_id"
+		if tableAlias != "" {
+			col = tableAlias + ".source_id"
+		}
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM sources gs WHERE gs.id = %s AND gs.group_id = $%d)",
+			col, paramIndex))
+		args = append(args, *filters.GroupID)
+		paramIndex++
+	}
+
 	// Add date range filters
 	if filters.From != nil {
 		var col string
@@ -81,6 +140,77 @@ func (qb *ArticleQueryBuilder) BuildWhereClause(keywords []string, filters repos
 		}
 		conditions = append(conditions, fmt.Sprintf("%s <= $%d", col, paramIndex))
 		args = append(args, *filters.To)
+		paramIndex++
+	}
+
+	// Add tag filter (tag names are stored normalized, so exact match)
+	if filters.Tag != nil {
+		col := "articles.id"
+		if tableAlias != "" {
+			col = tableAlias + ".id"
+		}
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM article_tags at INNER JOIN tags t ON t.id = at.tag_id WHERE at.article_id = %s AND t.name = $%d)",
+			col, paramIndex))
+		args = append(args, *filters.Tag)
+		paramIndex++
+	}
+
+	// Add unread filter (no read mark of the requesting user)
+	if filters.UnreadFor != nil {
+		col := "articles.id"
+		if tableAlias != "" {
+			col = tableAlias + ".id"
+		}
+		conditions = append(conditions, fmt.Sprintf(
+			"NOT EXISTS (SELECT 1 FROM article_read_state rs INNER JOIN users u ON u.id = rs.user_id WHERE rs.article_id = %s AND rs.unread_at IS NULL AND u.email = $%d)",
+			col, paramIndex))
+		args = append(args, *filters.UnreadFor)
+		paramIndex++
+	}
+
+	// Add favorites filter (starred by the requesting user)
+	if filters.FavoritesOf != nil {
+		col := "articles.id"
+		if tableAlias != "" {
+			col = tableAlias + ".id"
+		}
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM article_favorites f INNER JOIN users u ON u.id = f.user_id WHERE f.article_id = %s AND u.email = $%d)",
+			col, paramIndex))
+		args = append(args, *filters.FavoritesOf)
+		paramIndex++
+	}
+
+	// Add subscription filter (sources the requesting user subscribes to)
+	if filters.SubscribedBy != nil {
+		col := "articles.source_id"
+		if tableAlias != "" {
+			col = tableAlias + ".source_id"
+		}
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM source_subscriptions ss INNER JOIN users u ON u.id = ss.user_id WHERE ss.source_id = %s AND u.email = $%d)",
+			col, paramIndex))
+		args = append(args, *filters.SubscribedBy)
+	}
+
+	// Add summarized filter (the summary row exists and is not empty)
+	if filters.Summarized {
+		col := "articles.id"
+		if tableAlias != "" {
+			col = tableAlias + ".id"
+		}
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM summaries su WHERE su.article_id = %s AND su.body <> '')", col))
+	}
+
+	// Collapse near-duplicate groups to their canonical article
+	if filters.CollapseDuplicates {
+		col := "duplicate_of"
+		if tableAlias != "" {
+			col = tableAlias + ".duplicate_of"
+		}
+		conditions = append(conditions, col+" IS NULL")
 	}
 
 	// Return empty if no conditions
@@ -91,3 +221,74 @@ func (qb *ArticleQueryBuilder) BuildWhereClause(keywords []string, filters repos
 	// Join all conditions with AND
 	return "WHERE " + strings.Join(conditions, " AND "), args
 }
+
+// BuildOrderBy builds the ORDER BY clause for a search built by
+// BuildWhereClause with the same keywords. With keywords, results are
+// ranked by ts_rank over the title (weighted A) and the summary body,
+// newest first among equal ranks; ILIKE-only matches rank 0. It reuses the
+// keyword placeholders of BuildWhereClause and adds no arguments.
+//
+// A non-zero sort overrides that default. Fields come from a fixed switch,
+// never from the request, and unknown fields keep the default order;
+// relevance without keywords orders by published_at instead.
+func (qb *ArticleQueryBuilder) BuildOrderBy(keywords []string, sort repository.ArticleSort, tableAlias string) string {
+	col := func(name string) string {
+		if tableAlias != "" {
+			return tableAlias + "." + name
+		}
+		return name
+	}
+	direction := "DESC"
+	if sort.Ascending {
+		direction = "ASC"
+	}
+
+	switch sort.Field {
+	case repository.ArticleSortPublishedAt:
+		return fmt.Sprintf("ORDER BY %s %s NULLS LAST, %s %s", col("published_at"), direction, col("id"), direction)
+	case repository.ArticleSortCreatedAt:
+		return fmt.Sprintf("ORDER BY %s %s, %s %s", col("crawled_at"), direction, col("id"), direction)
+	case repository.ArticleSortTitle:
+		return fmt.Sprintf("ORDER BY %s %s, %s %s", col("title"), direction, col("id"), direction)
+	case repository.ArticleSortRelevance:
+		if len(keywords) == 0 {
+			return fmt.Sprintf("ORDER BY %s %s NULLS LAST, %s %s", col("published_at"), direction, col("id"), direction)
+		}
+		return fmt.Sprintf("ORDER BY %s %s, %s DESC, %s DESC", qb.rank(keywords, tableAlias), direction, col("published_at"), col("id"))
+	}
+
+	if len(keywords) == 0 {
+		return "ORDER BY " + col("published_at") + " DESC"
+	}
+	return fmt.Sprintf("ORDER BY %s DESC, %s DESC", qb.rank(keywords, tableAlias), col("published_at"))
+}
+
+// rank returns the ts_rank expression of the keyword search.
+func (qb *ArticleQueryBuilder) rank(keywords []string, tableAlias string) string {
+	queries := make([]string, len(keywords))
+	for i := range keywords {
+		queries[i] = qb.tsquery(2*i + 2)
+	}
+	titleDoc, bodyDoc := qb.documents(tableAlias)
+	return fmt.Sprintf("ts_rank(setweight(%s, 'A') || COALESCE(%s, ''::tsvector), %s)",
+		titleDoc, bodyDoc, strings.Join(queries, " && "))
+}
+
+// BuildKeysetCondition builds the condition selecting the rows after the
+// cursor in (published_at DESC NULLS LAST, id DESC) order, numbering its
+// placeholders from paramIndex. Rows without published_at sort last, so a
+// dated cursor is followed by older rows, same-time rows with a smaller
+// ID, and every undated row.
+func (qb *ArticleQueryBuilder) BuildKeysetCondition(after repository.ArticleCursor, tableAlias string, paramIndex int) (condition string, args []interface{}) {
+	publishedCol, idCol := "published_at", "id"
+	if tableAlias != "" {
+		publishedCol, idCol = tableAlias+".published_at", tableAlias+".id"
+	}
+	if after.PublishedAt == nil {
+		return fmt.Sprintf("(%s IS NULL AND %s < $%d)", publishedCol, idCol, paramIndex),
+			[]interface{}{after.ID}
+	}
+	return fmt.Sprintf("(%[1]s < $%[3]d OR (%[1]s = $%[3]d AND %[2]s < $%[4]d) OR %[1]s IS NULL)",
+			publishedCol, idCol, paramIndex, paramIndex+1),
+		[]interface{}{*after.PublishedAt, after.ID}
+}
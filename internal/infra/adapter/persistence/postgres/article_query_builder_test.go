@@ -1,6 +1,7 @@
 package postgres_test
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -10,6 +11,13 @@ import (
 
 /* ──────────────────────────── BuildWhereClause Tests ──────────────────────────── */
 
+// keywordCondition is the expected condition of one keyword: full-text
+// search on the stored tsv columns or the ILIKE fallback.
+func keywordCondition(tsvTable, titleCol string, pattern, raw int) string {
+	return fmt.Sprintf("(%[1]s.tsv @@ plainto_tsquery('simple', $%[4]d) OR sm.tsv @@ plainto_tsquery('simple', $%[4]d) OR %[2]s ILIKE $%[3]d OR sm.body ILIKE $%[3]d)",
+		tsvTable, titleCol, pattern, raw)
+}
+
 func TestArticleQueryBuilder_BuildWhereClause_NoConditions(t *testing.T) {
 	builder := postgres.NewArticleQueryBuilder()
 	clause, args := builder.BuildWhereClause([]string{}, repository.ArticleSearchFilters{}, "")
@@ -26,12 +34,15 @@ func TestArticleQueryBuilder_BuildWhereClause_SingleKeyword(t *testing.T) {
 	builder := postgres.NewArticleQueryBuilder()
 	clause, args := builder.BuildWhereClause([]string{"Go"}, repository.ArticleSearchFilters{}, "")
 
-	expectedClause := "WHERE (title ILIKE $1 OR sm.body ILIKE $1)"
+	expectedClause := "WHERE " + keywordCondition("articles", "title", 1, 2)
 	if clause != expectedClause {
 		t.Errorf("clause = %q, want %q", clause, expectedClause)
 	}
-	if len(args) != 1 {
-		t.Fatalf("len(args) = %d, want 1", len(args))
+	if len(args) != 2 {
+		t.Fatalf("len(args) = %d, want 2", len(args))
+	}
+	if args[1] != "Go" {
+		t.Errorf("args[1] = %q, want %q", args[1], "Go")
 	}
 	if args[0] != "%Go%" {
 		t.Errorf("args[0] = %q, want %q", args[0], "%Go%")
@@ -42,15 +53,15 @@ func TestArticleQueryBuilder_BuildWhereClause_MultipleKeywords(t *testing.T) {
 	builder := postgres.NewArticleQueryBuilder()
 	clause, args := builder.BuildWhereClause([]string{"Go", "release"}, repository.ArticleSearchFilters{}, "")
 
-	expectedClause := "WHERE (title ILIKE $1 OR sm.body ILIKE $1) AND (title ILIKE $2 OR sm.body ILIKE $2)"
+	expectedClause := "WHERE " + keywordCondition("articles", "title", 1, 2) + " AND " + keywordCondition("articles", "title", 3, 4)
 	if clause != expectedClause {
 		t.Errorf("clause = %q, want %q", clause, expectedClause)
 	}
-	if len(args) != 2 {
-		t.Fatalf("len(args) = %d, want 2", len(args))
+	if len(args) != 4 {
+		t.Fatalf("len(args) = %d, want 4", len(args))
 	}
-	if args[0] != "%Go%" || args[1] != "%release%" {
-		t.Errorf("args = %v, want [%%Go%%, %%release%%]", args)
+	if args[0] != "%Go%" || args[1] != "Go" || args[2] != "%release%" || args[3] != "release" {
+		t.Errorf("args = %v, want [%%Go%% Go %%release%% release]", args)
 	}
 }
 
@@ -58,12 +69,12 @@ func TestArticleQueryBuilder_BuildWhereClause_WithTableAlias(t *testing.T) {
 	builder := postgres.NewArticleQueryBuilder()
 	clause, args := builder.BuildWhereClause([]string{"Go"}, repository.ArticleSearchFilters{}, "a")
 
-	expectedClause := "WHERE (a.title ILIKE $1 OR sm.body ILIKE $1)"
+	expectedClause := "WHERE " + keywordCondition("a", "a.title", 1, 2)
 	if clause != expectedClause {
 		t.Errorf("clause = %q, want %q", clause, expectedClause)
 	}
-	if len(args) != 1 {
-		t.Fatalf("len(args) = %d, want 1", len(args))
+	if len(args) != 2 {
+		t.Fatalf("len(args) = %d, want 2", len(args))
 	}
 }
 
@@ -73,15 +84,33 @@ func TestArticleQueryBuilder_BuildWhereClause_WithSourceIDFilter(t *testing.T) {
 	filters := repository.ArticleSearchFilters{SourceID: &sourceID}
 	clause, args := builder.BuildWhereClause([]string{"Go"}, filters, "")
 
-	expectedClause := "WHERE (title ILIKE $1 OR sm.body ILIKE $1) AND source_id = $2"
+	expectedClause := "WHERE " + keywordCondition("articles", "title", 1, 2) + " AND source_id = $3"
 	if clause != expectedClause {
 		t.Errorf("clause = %q, want %q", clause, expectedClause)
 	}
-	if len(args) != 2 {
-		t.Fatalf("len(args) = %d, want 2", len(args))
+	if len(args) != 3 {
+		t.Fatalf("len(args) = %d, want 3", len(args))
+	}
+	if args[2] != int64(2) {
+		t.Errorf("args[2] = %v, want 2", args[2])
+	}
+}
+
+func TestArticleQueryBuilder_BuildWhereClause_WithGroupFilter(t *testing.T) {
+	builder := postgres.NewArticleQueryBuilder()
+	sourceID, groupID := int64(2), int64(5)
+	tag := "go"
+	filters := repository.ArticleSearchFilters{SourceID: &sourceID, GroupID: &groupID, Tag: &tag}
+	clause, args := builder.BuildWhereClause(nil, filters, "a")
+
+	expectedClause := "WHERE a.source_id = $1" +
+		" AND EXISTS (SELECT 1 FROM sources gs WHERE gs.id = a.source_id AND gs.group_id = $2)" +
+		" AND EXISTS (SELECT 1 FROM article_tags at INNER JOIN tags t ON t.id = at.tag_id WHERE at.article_id = a.id AND t.name = $3)"
+	if clause != expectedClause {
+		t.Errorf("clause = %q, want %q", clause, expectedClause)
 	}
-	if args[1] != int64(2) {
-		t.Errorf("args[1] = %v, want 2", args[1])
+	if len(args) != 3 || args[1] != groupID {
+		t.Errorf("args = %v, want [2 5 go]", args)
 	}
 }
 
@@ -92,12 +121,12 @@ func TestArticleQueryBuilder_BuildWhereClause_WithDateFilters(t *testing.T) {
 	filters := repository.ArticleSearchFilters{From: &from, To: &to}
 	clause, args := builder.BuildWhereClause([]string{"Go"}, filters, "")
 
-	expectedClause := "WHERE (title ILIKE $1 OR sm.body ILIKE $1) AND published_at >= $2 AND published_at <= $3"
+	expectedClause := "WHERE " + keywordCondition("articles", "title", 1, 2) + " AND published_at >= $3 AND published_at <= $4"
 	if clause != expectedClause {
 		t.Errorf("clause = %q, want %q", clause, expectedClause)
 	}
-	if len(args) != 3 {
-		t.Fatalf("len(args) = %d, want 3", len(args))
+	if len(args) != 4 {
+		t.Fatalf("len(args) = %d, want 4", len(args))
 	}
 }
 
@@ -113,12 +142,13 @@ func TestArticleQueryBuilder_BuildWhereClause_WithAllFilters(t *testing.T) {
 	}
 	clause, args := builder.BuildWhereClause([]string{"Go", "release"}, filters, "a")
 
-	expectedClause := "WHERE (a.title ILIKE $1 OR sm.body ILIKE $1) AND (a.title ILIKE $2 OR sm.body ILIKE $2) AND a.source_id = $3 AND a.published_at >= $4 AND a.published_at <= $5"
+	expectedClause := "WHERE " + keywordCondition("a", "a.title", 1, 2) + " AND " + keywordCondition("a", "a.title", 3, 4) +
+		" AND a.source_id = $5 AND a.published_at >= $6 AND a.published_at <= $7"
 	if clause != expectedClause {
 		t.Errorf("clause = %q, want %q", clause, expectedClause)
 	}
-	if len(args) != 5 {
-		t.Fatalf("len(args) = %d, want 5", len(args))
+	if len(args) != 7 {
+		t.Fatalf("len(args) = %d, want 7", len(args))
 	}
 }
 
@@ -141,18 +171,22 @@ func TestArticleQueryBuilder_BuildWhereClause_SpecialCharactersEscaped(t *testin
 	builder := postgres.NewArticleQueryBuilder()
 	_, args := builder.BuildWhereClause([]string{"100%", "my_var", "path\\file"}, repository.ArticleSearchFilters{}, "")
 
-	if len(args) != 3 {
-		t.Fatalf("len(args) = %d, want 3", len(args))
+	if len(args) != 6 {
+		t.Fatalf("len(args) = %d, want 6", len(args))
 	}
-	// EscapeILIKE should escape special characters
+	// EscapeILIKE should escape special characters of the ILIKE patterns
 	if args[0] != "%100\\%%" {
 		t.Errorf("args[0] = %q, want %%100\\%%%%", args[0])
 	}
-	if args[1] != "%my\\_var%" {
-		t.Errorf("args[1] = %q, want %%my\\_var%%", args[1])
+	if args[2] != "%my\\_var%" {
+		t.Errorf("args[2] = %q, want %%my\\_var%%", args[2])
+	}
+	if args[4] != "%path\\\\file%" {
+		t.Errorf("args[4] = %q, want %%path\\\\file%%", args[4])
 	}
-	if args[2] != "%path\\\\file%" {
-		t.Errorf("args[2] = %q, want %%path\\\\file%%", args[2])
+	// The tsquery keywords are passed as-is (plainto_tsquery ignores punctuation)
+	if args[1] != "100%" || args[3] != "my_var" || args[5] != "path\\file" {
+		t.Errorf("tsquery args = [%v %v %v], want raw keywords", args[1], args[3], args[5])
 	}
 }
 
@@ -185,3 +219,228 @@ func TestArticleQueryBuilder_BuildWhereClause_OnlyToFilter(t *testing.T) {
 		t.Fatalf("len(args) = %d, want 1", len(args))
 	}
 }
+
+func TestArticleQueryBuilder_BuildWhereClause_WithTagFilter(t *testing.T) {
+	builder := postgres.NewArticleQueryBuilder()
+	sourceID := int64(3)
+	to := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	tag := "go"
+	filters := repository.ArticleSearchFilters{SourceID: &sourceID, To: &to, Tag: &tag}
+	clause, args := builder.BuildWhereClause([]string{"release"}, filters, "a")
+
+	expectedClause := "WHERE " + keywordCondition("a", "a.title", 1, 2) + " AND a.source_id = $3 AND a.published_at <= $4" +
+		" AND EXISTS (SELECT 1 FROM article_tags at INNER JOIN tags t ON t.id = at.tag_id WHERE at.article_id = a.id AND t.name = $5)"
+	if clause != expectedClause {
+		t.Errorf("clause = %q, want %q", clause, expectedClause)
+	}
+	if len(args) != 5 {
+		t.Fatalf("len(args) = %d, want 5", len(args))
+	}
+	if args[4] != "go" {
+		t.Errorf("args[4] = %v, want %q", args[4], "go")
+	}
+}
+
+func TestArticleQueryBuilder_BuildWhereClause_WithUnreadFilter(t *testing.T) {
+	builder := postgres.NewArticleQueryBuilder()
+	tag := "go"
+	subject := "alice@example.com"
+	filters := repository.ArticleSearchFilters{Tag: &tag, UnreadFor: &subject}
+	clause, args := builder.BuildWhereClause(nil, filters, "a")
+
+	expectedClause := "WHERE EXISTS (SELECT 1 FROM article_tags at INNER JOIN tags t ON t.id = at.tag_id WHERE at.article_id = a.id AND t.name = $1)" +
+		" AND NOT EXISTS (SELECT 1 FROM article_read_state rs INNER JOIN users u ON u.id = rs.user_id WHERE rs.article_id = a.id AND rs.unread_at IS NULL AND u.email = $2)"
+	if clause != expectedClause {
+		t.Errorf("clause = %q, want %q", clause, expectedClause)
+	}
+	if len(args) != 2 || args[1] != subject {
+		t.Errorf("args = %v, want [go %s]", args, subject)
+	}
+}
+
+func TestArticleQueryBuilder_BuildWhereClause_WithFavoritesFilter(t *testing.T) {
+	builder := postgres.NewArticleQueryBuilder()
+	subject := "alice@example.com"
+	filters := repository.ArticleSearchFilters{UnreadFor: &subject, FavoritesOf: &subject}
+	clause, args := builder.BuildWhereClause(nil, filters, "")
+
+	expectedClause := "WHERE NOT EXISTS (SELECT 1 FROM article_read_state rs INNER JOIN users u ON u.id = rs.user_id WHERE rs.article_id = articles.id AND rs.unread_at IS NULL AND u.email = $1)" +
+		" AND EXISTS (SELECT 1 FROM article_favorites f INNER JOIN users u ON u.id = f.user_id WHERE f.article_id = articles.id AND u.email = $2)"
+	if clause != expectedClause {
+		t.Errorf("clause = %q, want %q", clause, expectedClause)
+	}
+	if len(args) != 2 {
+		t.Errorf("args = %v, want 2 args", args)
+	}
+}
+
+func TestArticleQueryBuilder_BuildWhereClause_WithSubscribedByFilter(t *testing.T) {
+	builder := postgres.NewArticleQueryBuilder()
+	subject := "alice@example.com"
+	filters := repository.ArticleSearchFilters{FavoritesOf: &subject, SubscribedBy: &subject}
+	clause, args := builder.BuildWhereClause(nil, filters, "a")
+
+	expectedClause := "WHERE EXISTS (SELECT 1 FROM article_favorites f INNER JOIN users u ON u.id = f.user_id WHERE f.article_id = a.id AND u.email = $1)" +
+		" AND EXISTS (SELECT 1 FROM source_subscriptions ss INNER JOIN users u ON u.id = ss.user_id WHERE ss.source_id = a.source_id AND u.email = $2)"
+	if clause != expectedClause {
+		t.Errorf("clause = %q, want %q", clause, expectedClause)
+	}
+	if len(args) != 2 || args[1] != subject {
+		t.Errorf("args = %v, want [%s %s]", args, subject, subject)
+	}
+}
+
+func TestArticleQueryBuilder_BuildWhereClause_WithSummarizedFilter(t *testing.T) {
+	builder := postgres.NewArticleQueryBuilder()
+	sourceID := int64(3)
+	filters := repository.ArticleSearchFilters{SourceID: &sourceID, Summarized: true}
+	clause, args := builder.BuildWhereClause(nil, filters, "a")
+
+	expectedClause := "WHERE a.source_id = $1" +
+		" AND EXISTS (SELECT 1 FROM summaries su WHERE su.article_id = a.id AND su.body <> '')"
+	if clause != expectedClause {
+		t.Errorf("clause = %q, want %q", clause, expectedClause)
+	}
+	if len(args) != 1 {
+		t.Errorf("args = %v, want only the source ID", args)
+	}
+}
+
+func TestArticleQueryBuilder_BuildWhereClause_CollapseDuplicates(t *testing.T) {
+	builder := postgres.NewArticleQueryBuilder()
+	filters := repository.ArticleSearchFilters{Summarized: true, CollapseDuplicates: true}
+	clause, args := builder.BuildWhereClause(nil, filters, "a")
+
+	expectedClause := "WHERE EXISTS (SELECT 1 FROM summaries su WHERE su.article_id = a.id AND su.body <> '')" +
+		" AND a.duplicate_of IS NULL"
+	if clause != expectedClause {
+		t.Errorf("clause = %q, want %q", clause, expectedClause)
+	}
+	if len(args) != 0 {
+		t.Errorf("args = %v, want none", args)
+	}
+}
+
+func TestArticleQueryBuilder_BuildWhereClause_LanguageConfig(t *testing.T) {
+	builder := postgres.NewArticleQueryBuilderWithConfig("english")
+	clause, _ := builder.BuildWhereClause([]string{"running"}, repository.ArticleSearchFilters{}, "a")
+
+	// Non-default configurations compute the vectors on the fly.
+	expectedClause := "WHERE (to_tsvector('english', a.title) @@ plainto_tsquery('english', $2)" +
+		" OR to_tsvector('english', sm.body) @@ plainto_tsquery('english', $2) OR a.title ILIKE $1 OR sm.body ILIKE $1)"
+	if clause != expectedClause {
+		t.Errorf("clause = %q, want %q", clause, expectedClause)
+	}
+
+	// Unknown configurations fall back to the default (never reach the SQL).
+	clause, _ = postgres.NewArticleQueryBuilderWithConfig("x'); DROP TABLE articles; --").
+		BuildWhereClause([]string{"go"}, repository.ArticleSearchFilters{}, "a")
+	if clause != "WHERE "+keywordCondition("a", "a.title", 1, 2) {
+		t.Errorf("clause = %q, want the default configuration", clause)
+	}
+}
+
+/* ──────────────────────────── BuildOrderBy Tests ──────────────────────────── */
+
+func TestArticleQueryBuilder_BuildOrderBy(t *testing.T) {
+	builder := postgres.NewArticleQueryBuilder()
+
+	tests := []struct {
+		name     string
+		keywords []string
+		sort     repository.ArticleSort
+		alias    string
+		want     string
+	}{
+		{"no keywords", nil, repository.ArticleSort{}, "a", "ORDER BY a.published_at DESC"},
+		{"no keywords without alias", nil, repository.ArticleSort{}, "", "ORDER BY published_at DESC"},
+		{
+			"single keyword",
+			[]string{"go"},
+			repository.ArticleSort{},
+			"a",
+			"ORDER BY ts_rank(setweight(a.tsv, 'A') || COALESCE(sm.tsv, ''::tsvector), plainto_tsquery('simple', $2)) DESC, a.published_at DESC",
+		},
+		{
+			"keywords reuse the tsquery placeholders of BuildWhereClause",
+			[]string{"go", "release"},
+			repository.ArticleSort{},
+			"a",
+			"ORDER BY ts_rank(setweight(a.tsv, 'A') || COALESCE(sm.tsv, ''::tsvector), plainto_tsquery('simple', $2) && plainto_tsquery('simple', $4)) DESC, a.published_at DESC",
+		},
+		{
+			"published_at ascending",
+			[]string{"go"},
+			repository.ArticleSort{Field: repository.ArticleSortPublishedAt, Ascending: true},
+			"a",
+			"ORDER BY a.published_at ASC NULLS LAST, a.id ASC",
+		},
+		{
+			"created_at orders by crawled_at",
+			nil,
+			repository.ArticleSort{Field: repository.ArticleSortCreatedAt},
+			"a",
+			"ORDER BY a.crawled_at DESC, a.id DESC",
+		},
+		{
+			"title without alias",
+			nil,
+			repository.ArticleSort{Field: repository.ArticleSortTitle, Ascending: true},
+			"",
+			"ORDER BY title ASC, id ASC",
+		},
+		{
+			"relevance ascending",
+			[]string{"go"},
+			repository.ArticleSort{Field: repository.ArticleSortRelevance, Ascending: true},
+			"a",
+			"ORDER BY ts_rank(setweight(a.tsv, 'A') || COALESCE(sm.tsv, ''::tsvector), plainto_tsquery('simple', $2)) ASC, a.published_at DESC, a.id DESC",
+		},
+		{
+			"relevance without keywords falls back to published_at",
+			nil,
+			repository.ArticleSort{Field: repository.ArticleSortRelevance},
+			"a",
+			"ORDER BY a.published_at DESC NULLS LAST, a.id DESC",
+		},
+		{
+			"unknown field keeps the default order",
+			nil,
+			repository.ArticleSort{Field: "url; DROP TABLE articles"},
+			"a",
+			"ORDER BY a.published_at DESC",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := builder.BuildOrderBy(tt.keywords, tt.sort, tt.alias); got != tt.want {
+				t.Errorf("BuildOrderBy() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+/* ──────────────────────────── BuildKeysetCondition Tests ──────────────────────────── */
+
+func TestArticleQueryBuilder_BuildKeysetCondition(t *testing.T) {
+	builder := postgres.NewArticleQueryBuilder()
+	published := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	clause, args := builder.BuildKeysetCondition(repository.ArticleCursor{PublishedAt: &published, ID: 42}, "a", 3)
+	want := "(a.published_at < $3 OR (a.published_at = $3 AND a.id < $4) OR a.published_at IS NULL)"
+	if clause != want {
+		t.Errorf("clause = %q, want %q", clause, want)
+	}
+	if len(args) != 2 || args[0] != published || args[1] != int64(42) {
+		t.Errorf("args = %v, want [%v 42]", args, published)
+	}
+
+	// Undated cursor: only the undated tail with smaller IDs remains.
+	clause, args = builder.BuildKeysetCondition(repository.ArticleCursor{ID: 7}, "", 1)
+	if want := "(published_at IS NULL AND id < $1)"; clause != want {
+		t.Errorf("clause = %q, want %q", clause, want)
+	}
+	if len(args) != 1 || args[0] != int64(7) {
+		t.Errorf("args = %v, want [7]", args)
+	}
+}
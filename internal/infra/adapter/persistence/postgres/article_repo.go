@@ -4,10 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
+
 	"catchup-feed/internal/domain/entity"
 	"catchup-feed/internal/pkg/search"
 	"catchup-feed/internal/repository"
@@ -18,23 +22,48 @@ import (
 // Every read query uses the same "articles a LEFT JOIN summaries sm" shape.
 const (
 	articleColumns = `a.id, a.source_id, a.title, a.url, COALESCE(a.content, '') AS content,
-       COALESCE(sm.body, '') AS summary, a.published_at, a.crawled_at`
+       COALESCE(sm.body, '') AS summary, a.published_at, a.crawled_at, COALESCE(a.image_url, '') AS image_url`
 	articleFrom = `FROM articles a
 LEFT JOIN summaries sm ON sm.article_id = a.id`
 )
 
 type ArticleRepo struct {
 	db           *sql.DB
+	replica      ReadDB // nil = every query on db
 	queryBuilder *ArticleQueryBuilder
 }
 
 func NewArticleRepo(db *sql.DB) repository.ArticleRepository {
+	return NewArticleRepoWithTextSearchConfig(db, search.DefaultTextSearchConfig)
+}
+
+// NewArticleRepoWithTextSearchConfig returns an article repository whose
+// keyword search parses keywords with tsConfig (SEARCH_LANGUAGE). Unknown
+// configurations fall back to search.DefaultTextSearchConfig.
+func NewArticleRepoWithTextSearchConfig(db *sql.DB, tsConfig string) repository.ArticleRepository {
+	return NewArticleRepoWithReplica(db, nil, tsConfig)
+}
+
+// NewArticleRepoWithReplica is NewArticleRepoWithTextSearchConfig with the
+// list, search and count queries sent to replica.Reader(). Get and the
+// writes stay on db, so an article just created or edited reads back
+// immediately despite replication lag.
+func NewArticleRepoWithReplica(db *sql.DB, replica ReadDB, tsConfig string) repository.ArticleRepository {
 	return &ArticleRepo{
 		db:           db,
-		queryBuilder: NewArticleQueryBuilder(),
+		replica:      replica,
+		queryBuilder: NewArticleQueryBuilderWithConfig(tsConfig),
 	}
 }
 
+// reader returns the pool for read-only list, search and count queries.
+func (repo *ArticleRepo) reader() *sql.DB {
+	if repo.replica != nil {
+		return repo.replica.Reader()
+	}
+	return repo.db
+}
+
 func scanArticle(s scanner, extra ...any) (*entity.Article, error) {
 	var (
 		article     entity.Article
@@ -43,6 +72,7 @@ func scanArticle(s scanner, extra ...any) (*entity.Article, error) {
 	dest := []any{
 		&article.ID, &article.SourceID, &article.Title, &article.URL,
 		&article.Content, &article.Summary, &publishedAt, &article.CrawledAt,
+		&article.ImageURL,
 	}
 	dest = append(dest, extra...)
 	if err := s.Scan(dest...); err != nil {
@@ -52,8 +82,8 @@ func scanArticle(s scanner, extra ...any) (*entity.Article, error) {
 	return &article, nil
 }
 
-func (repo *ArticleRepo) queryArticles(ctx context.Context, op, query string, args ...any) ([]*entity.Article, error) {
-	rows, err := repo.db.QueryContext(ctx, query, args...)
+func queryArticles(ctx context.Context, db *sql.DB, op, query string, args ...any) ([]*entity.Article, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -70,8 +100,8 @@ func (repo *ArticleRepo) queryArticles(ctx context.Context, op, query string, ar
 	return articles, rows.Err()
 }
 
-func (repo *ArticleRepo) queryArticlesWithSource(ctx context.Context, op, query string, capacity int, args ...any) ([]repository.ArticleWithSource, error) {
-	rows, err := repo.db.QueryContext(ctx, query, args...)
+func queryArticlesWithSource(ctx context.Context, db *sql.DB, op, query string, capacity int, args ...any) ([]repository.ArticleWithSource, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -97,7 +127,7 @@ func (repo *ArticleRepo) List(ctx context.Context) ([]*entity.Article, error) {
 SELECT ` + articleColumns + `
 ` + articleFrom + `
 ORDER BY a.published_at DESC`
-	return repo.queryArticles(ctx, "List", query)
+	return queryArticles(ctx, repo.reader(), "List", query)
 }
 
 func (repo *ArticleRepo) ListWithSource(ctx context.Context) ([]repository.ArticleWithSource, error) {
@@ -106,7 +136,7 @@ SELECT ` + articleColumns + `, s.name AS source_name
 ` + articleFrom + `
 INNER JOIN sources s ON a.source_id = s.id
 ORDER BY a.published_at DESC`
-	return repo.queryArticlesWithSource(ctx, "ListWithSource", query, 100)
+	return queryArticlesWithSource(ctx, repo.reader(), "ListWithSource", query, 100)
 }
 
 // ListWithSourcePaginated retrieves paginated articles with source names.
@@ -118,14 +148,14 @@ SELECT ` + articleColumns + `, s.name AS source_name
 INNER JOIN sources s ON a.source_id = s.id
 ORDER BY a.published_at DESC
 LIMIT $1 OFFSET $2`
-	return repo.queryArticlesWithSource(ctx, "ListWithSourcePaginated", query, limit, limit, offset)
+	return queryArticlesWithSource(ctx, repo.reader(), "ListWithSourcePaginated", query, limit, limit, offset)
 }
 
 // CountArticles returns the total number of articles in the database.
 func (repo *ArticleRepo) CountArticles(ctx context.Context) (int64, error) {
 	const query = `SELECT COUNT(*) FROM articles`
 	var count int64
-	err := repo.db.QueryRowContext(ctx, query).Scan(&count)
+	err := repo.reader().QueryRowContext(ctx, query).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("CountArticles: %w", err)
 	}
@@ -173,13 +203,15 @@ SELECT ` + articleColumns + `
 WHERE a.title ILIKE $1
     OR sm.body ILIKE $1
 ORDER BY a.published_at DESC`
-	return repo.queryArticles(ctx, "Search", query, "%"+keyword+"%")
+	return queryArticles(ctx, repo.reader(), "Search", query, "%"+keyword+"%")
 }
 
 func (repo *ArticleRepo) SearchWithFilters(ctx context.Context, keywords []string, filters repository.ArticleSearchFilters) ([]*entity.Article, error) {
 	// Check if there are any search criteria (keywords or filters)
 	hasKeywords := len(keywords) > 0
-	hasFilters := filters.SourceID != nil || filters.From != nil || filters.To != nil
+	hasFilters := filters.SourceID != nil || filters.GroupID != nil || filters.From != nil || filters.To != nil ||
+		filters.Tag != nil || filters.UnreadFor != nil || filters.FavoritesOf != nil || filters.SubscribedBy != nil ||
+		filters.Summarized || filters.CollapseDuplicates || filters.Sort != (repository.ArticleSort{})
 
 	// No keywords and no filters -> return empty result
 	if !hasKeywords && !hasFilters {
@@ -193,14 +225,16 @@ func (repo *ArticleRepo) SearchWithFilters(ctx context.Context, keywords []strin
 	// Build WHERE clause using QueryBuilder
 	whereClause, args := repo.queryBuilder.BuildWhereClause(keywords, filters, "a")
 
-	// #nosec G201 -- whereClause is generated by QueryBuilder using parameterized placeholders ($1, $2, etc.)
+	orderBy := repo.queryBuilder.BuildOrderBy(keywords, filters.Sort, "a")
+
+	// #nosec G201 -- whereClause and orderBy are generated by QueryBuilder using parameterized placeholders ($1, $2, etc.)
 	query := fmt.Sprintf(`
 SELECT %s
 %s
 %s
-ORDER BY a.published_at DESC`, articleColumns, articleFrom, whereClause)
+%s`, articleColumns, articleFrom, whereClause, orderBy)
 
-	return repo.queryArticles(ctx, "SearchWithFilters", query, args...)
+	return queryArticles(ctx, repo.reader(), "SearchWithFilters", query, args...)
 }
 
 // CountArticlesWithFilters returns the total number of articles matching the search criteria.
@@ -208,7 +242,9 @@ ORDER BY a.published_at DESC`, articleColumns, articleFrom, whereClause)
 func (repo *ArticleRepo) CountArticlesWithFilters(ctx context.Context, keywords []string, filters repository.ArticleSearchFilters) (int64, error) {
 	// Check if there are any search criteria (keywords or filters)
 	hasKeywords := len(keywords) > 0
-	hasFilters := filters.SourceID != nil || filters.From != nil || filters.To != nil
+	hasFilters := filters.SourceID != nil || filters.GroupID != nil || filters.From != nil || filters.To != nil ||
+		filters.Tag != nil || filters.UnreadFor != nil || filters.FavoritesOf != nil || filters.SubscribedBy != nil ||
+		filters.Summarized || filters.CollapseDuplicates || filters.Sort != (repository.ArticleSort{})
 
 	// No keywords and no filters -> return 0
 	if !hasKeywords && !hasFilters {
@@ -226,7 +262,7 @@ func (repo *ArticleRepo) CountArticlesWithFilters(ctx context.Context, keywords
 	query := "SELECT COUNT(*) " + articleFrom + " " + whereClause
 
 	var count int64
-	err := repo.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	err := repo.reader().QueryRowContext(ctx, query, args...).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("CountArticlesWithFilters: %w", err)
 	}
@@ -239,7 +275,9 @@ func (repo *ArticleRepo) CountArticlesWithFilters(ctx context.Context, keywords
 func (repo *ArticleRepo) SearchWithFiltersPaginated(ctx context.Context, keywords []string, filters repository.ArticleSearchFilters, offset, limit int) ([]repository.ArticleWithSource, error) {
 	// Check if there are any search criteria (keywords or filters)
 	hasKeywords := len(keywords) > 0
-	hasFilters := filters.SourceID != nil || filters.From != nil || filters.To != nil
+	hasFilters := filters.SourceID != nil || filters.GroupID != nil || filters.From != nil || filters.To != nil ||
+		filters.Tag != nil || filters.UnreadFor != nil || filters.FavoritesOf != nil || filters.SubscribedBy != nil ||
+		filters.Summarized || filters.CollapseDuplicates || filters.Sort != (repository.ArticleSort{})
 
 	// No keywords and no filters -> return empty result
 	if !hasKeywords && !hasFilters {
@@ -259,67 +297,166 @@ func (repo *ArticleRepo) SearchWithFiltersPaginated(ctx context.Context, keyword
 	// Add LIMIT and OFFSET to args
 	args = append(args, limit, offset)
 
-	// #nosec G201 -- whereClause is generated by QueryBuilder using parameterized placeholders ($1, $2, etc.)
+	// Keyword searches are ordered by relevance (ts_rank), then recency,
+	// unless the caller asked for another order
+	orderBy := repo.queryBuilder.BuildOrderBy(keywords, filters.Sort, "a")
+
+	// #nosec G201 -- whereClause and orderBy are generated by QueryBuilder using parameterized placeholders ($1, $2, etc.)
 	// paramIndex values are integers computed from len(args), not user input.
 	query := fmt.Sprintf(`
 SELECT %s, s.name AS source_name
 %s
 INNER JOIN sources s ON a.source_id = s.id
 %s
-ORDER BY a.published_at DESC
-LIMIT $%d OFFSET $%d`, articleColumns, articleFrom, whereClause, paramIndex, paramIndex+1)
+%s
+LIMIT $%d OFFSET $%d`, articleColumns, articleFrom, whereClause, orderBy, paramIndex, paramIndex+1)
+
+	return queryArticlesWithSource(ctx, repo.reader(), "SearchWithFiltersPaginated", query, limit, args...)
+}
+
+// ListWithSourceAfter returns the keyset page after the cursor. Unlike
+// the OFFSET queries its cost does not grow with the page depth: the
+// cursor condition is served by idx_articles_published_at_id. The keyset
+// fixes the order, so filters.Sort is ignored.
+func (repo *ArticleRepo) ListWithSourceAfter(ctx context.Context, keywords []string, filters repository.ArticleSearchFilters, after *repository.ArticleCursor, limit int) ([]repository.ArticleWithSource, error) {
+	// Apply search timeout to prevent long-running queries
+	ctx, cancel := context.WithTimeout(ctx, search.DefaultSearchTimeout)
+	defer cancel()
+
+	whereClause, args := repo.queryBuilder.BuildWhereClause(keywords, filters, "a")
+	if after != nil {
+		condition, cursorArgs := repo.queryBuilder.BuildKeysetCondition(*after, "a", len(args)+1)
+		if whereClause == "" {
+			whereClause = "WHERE " + condition
+		} else {
+			whereClause += " AND " + condition
+		}
+		args = append(args, cursorArgs...)
+	}
+	paramIndex := len(args) + 1
+	args = append(args, limit)
+
+	// #nosec G201 -- whereClause is generated by QueryBuilder using parameterized placeholders ($1, $2, etc.)
+	// paramIndex is an integer computed from len(args), not user input.
+	query := fmt.Sprintf(`
+SELECT %s, s.name AS source_name
+%s
+INNER JOIN sources s ON a.source_id = s.id
+%s
+ORDER BY a.published_at DESC NULLS LAST, a.id DESC
+LIMIT $%d`, articleColumns, articleFrom, whereClause, paramIndex)
+
+	return queryArticlesWithSource(ctx, repo.reader(), "ListWithSourceAfter", query, limit, args...)
+}
+
+// exportColumns is articleColumns without the extracted full text, which
+// exports (like every article response) leave out; Content scans as "".
+const exportColumns = `a.id, a.source_id, a.title, a.url, '' AS content,
+       COALESCE(sm.body, '') AS summary, a.published_at, a.crawled_at, COALESCE(a.image_url, '') AS image_url`
+
+// ForEachWithSource runs the search without LIMIT and hands each row to fn
+// as it is scanned. Article.Content is left empty. No search timeout
+// applies: exports are expected to outlast it, and the request context
+// still cancels the query.
+func (repo *ArticleRepo) ForEachWithSource(ctx context.Context, keywords []string, filters repository.ArticleSearchFilters, fn func(repository.ArticleWithSource) error) error {
+	whereClause, args := repo.queryBuilder.BuildWhereClause(keywords, filters, "a")
+	orderBy := repo.queryBuilder.BuildOrderBy(keywords, filters.Sort, "a")
+
+	// #nosec G201 -- whereClause and orderBy are generated by QueryBuilder using parameterized placeholders ($1, $2, etc.)
+	query := fmt.Sprintf(`
+SELECT %s, s.name AS source_name
+%s
+INNER JOIN sources s ON a.source_id = s.id
+%s
+%s`, exportColumns, articleFrom, whereClause, orderBy)
+
+	rows, err := repo.reader().QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("ForEachWithSource: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
 
-	return repo.queryArticlesWithSource(ctx, "SearchWithFiltersPaginated", query, limit, args...)
+	for rows.Next() {
+		var sourceName string
+		article, err := scanArticle(rows, &sourceName)
+		if err != nil {
+			return fmt.Errorf("ForEachWithSource: Scan: %w", err)
+		}
+		if err := fn(repository.ArticleWithSource{Article: article, SourceName: sourceName}); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("ForEachWithSource: %w", err)
+	}
+	return nil
 }
 
 // Create inserts the article and sets article.ID (RETURNING id), which the
 // crawl pipeline needs for the summaries.article_id foreign key.
 // article.Summary is ignored: summaries live in their own table.
 func (repo *ArticleRepo) Create(ctx context.Context, article *entity.Article) error {
-	if article.CrawledAt.IsZero() {
-		article.CrawledAt = time.Now()
-	}
-	err := repo.db.QueryRowContext(ctx, insertArticleSQL,
-		article.SourceID, article.Title, article.URL,
-		nullString(article.Content), nullTime(article.PublishedAt), article.CrawledAt,
-	).Scan(&article.ID)
-	if err != nil {
-		return fmt.Errorf("Create: %w", err)
+	if err := insertArticle(ctx, conn(ctx, repo.db), article); err != nil {
+		return mapArticleInsertErr("Create", err)
 	}
 	return nil
 }
 
 // insertArticleSQL inserts one article row and returns its id. Shared by
-// Create and CreateWithSummary.
+// the Create methods through insertArticle.
 const insertArticleSQL = `
 INSERT INTO articles
-	   (source_id, title, url, content, published_at, crawled_at)
-VALUES ($1, $2, $3, $4, $5, $6)
+	   (source_id, title, url, content, published_at, crawled_at, content_hash, simhash, duplicate_of, image_url)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 RETURNING id`
 
+// insertArticle fills the insert-time defaults (crawled_at, content_hash)
+// and inserts the article, setting article.ID.
+func insertArticle(ctx context.Context, q interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}, article *entity.Article) error {
+	if article.CrawledAt.IsZero() {
+		article.CrawledAt = time.Now()
+	}
+	if article.ContentHash == "" {
+		article.ContentHash = entity.ArticleContentHash(article.URL, article.Title, article.Content)
+	}
+	return q.QueryRowContext(ctx, insertArticleSQL,
+		article.SourceID, article.Title, article.URL,
+		nullString(article.Content), nullTime(article.PublishedAt), article.CrawledAt,
+		article.ContentHash, nullSimHash(article.SimHash), article.DuplicateOf,
+		nullString(article.ImageURL),
+	).Scan(&article.ID)
+}
+
+// mapArticleInsertErr converts a unique_violation on the content hash index
+// into repository.ErrDuplicateContentHash (a URL collision stays a plain
+// error: the crawl checks URLs before inserting).
+func mapArticleInsertErr(op string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation && pgErr.ConstraintName == "idx_articles_content_hash" {
+		return fmt.Errorf("%s: %w", op, repository.ErrDuplicateContentHash)
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
+
 // CreateWithSummary inserts the article and its summary atomically (same
 // pattern as EpisodeRepo.Create). A summary insert failure rolls the
 // article back, keeping the invariant "every stored article has a summary":
 // the URL then stays unknown and the next hourly crawl retries it (§8).
 func (repo *ArticleRepo) CreateWithSummary(ctx context.Context, article *entity.Article, summary *entity.Summary) error {
-	if article.CrawledAt.IsZero() {
-		article.CrawledAt = time.Now()
-	}
 	if summary.Provider == "" {
 		summary.Provider = entity.SummaryProviderUnknown
 	}
 
-	tx, err := repo.db.BeginTx(ctx, nil)
+	tx, err := beginTx(ctx, repo.db)
 	if err != nil {
 		return fmt.Errorf("CreateWithSummary: begin: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	if err := tx.QueryRowContext(ctx, insertArticleSQL,
-		article.SourceID, article.Title, article.URL,
-		nullString(article.Content), nullTime(article.PublishedAt), article.CrawledAt,
-	).Scan(&article.ID); err != nil {
-		return fmt.Errorf("CreateWithSummary: article: %w", err)
+	if err := insertArticle(ctx, tx, article); err != nil {
+		return mapArticleInsertErr("CreateWithSummary: article", err)
 	}
 
 	summary.ArticleID = article.ID
@@ -338,6 +475,139 @@ VALUES ($1, $2, $3)`
 	return nil
 }
 
+// createBatchChunk bounds the rows of one multi-row INSERT: 10 parameters
+// per article stays far below PostgreSQL's 65535 bind parameter limit.
+const createBatchChunk = 500
+
+// CreateBatch inserts the articles and their summaries with one multi-row
+// INSERT per table (and chunk) in one transaction. ON CONFLICT DO NOTHING
+// skips articles whose URL or content hash is already stored — including
+// ones a concurrent crawl just inserted — and RETURNING id, url hands the
+// new IDs back by URL (articles.url is unique).
+func (repo *ArticleRepo) CreateBatch(ctx context.Context, items []repository.NewArticle) (int, error) {
+	if len(items) == 0 {
+		return 0, nil
+	}
+	tx, err := beginTx(ctx, repo.db)
+	if err != nil {
+		return 0, fmt.Errorf("CreateBatch: begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	inserted := 0
+	for chunk := range slices.Chunk(items, createBatchChunk) {
+		n, err := insertArticleChunk(ctx, tx, chunk)
+		if err != nil {
+			return 0, fmt.Errorf("CreateBatch: articles: %w", err)
+		}
+		inserted += n
+		if err := insertSummaryChunk(ctx, tx, chunk); err != nil {
+			return 0, fmt.Errorf("CreateBatch: summaries: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("CreateBatch: commit: %w", err)
+	}
+	return inserted, nil
+}
+
+// insertArticleChunk inserts the chunk's articles and sets the IDs of the
+// inserted ones. Returns how many were inserted.
+func insertArticleChunk(ctx context.Context, q executor, items []repository.NewArticle) (int, error) {
+	var sb strings.Builder
+	sb.WriteString(`
+INSERT INTO articles
+       (source_id, title, url, content, published_at, crawled_at, content_hash, simhash, duplicate_of, image_url)
+VALUES `)
+	args := make([]any, 0, len(items)*10)
+	byURL := make(map[string][]*entity.Article, len(items))
+	for i, item := range items {
+		article := item.Article
+		article.ID = 0
+		if article.CrawledAt.IsZero() {
+			article.CrawledAt = time.Now()
+		}
+		if article.ContentHash == "" {
+			article.ContentHash = entity.ArticleContentHash(article.URL, article.Title, article.Content)
+		}
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		n := len(args)
+		fmt.Fprintf(&sb, "($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+			n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8, n+9, n+10)
+		args = append(args,
+			article.SourceID, article.Title, article.URL,
+			nullString(article.Content), nullTime(article.PublishedAt), article.CrawledAt,
+			article.ContentHash, nullSimHash(article.SimHash), article.DuplicateOf,
+			nullString(article.ImageURL),
+		)
+		byURL[article.URL] = append(byURL[article.URL], article)
+	}
+	sb.WriteString(`
+ON CONFLICT DO NOTHING
+RETURNING id, url`)
+
+	// #nosec G202 -- the builder contains only generated $N placeholders.
+	rows, err := q.QueryContext(ctx, sb.String(), args...)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = rows.Close() }()
+	inserted := 0
+	for rows.Next() {
+		var id int64
+		var url string
+		if err := rows.Scan(&id, &url); err != nil {
+			return 0, err
+		}
+		// A URL repeated within the batch is inserted once: the first
+		// article with that URL gets the row.
+		if pending := byURL[url]; len(pending) > 0 {
+			pending[0].ID = id
+			byURL[url] = pending[1:]
+			inserted++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	return inserted, nil
+}
+
+// insertSummaryChunk inserts the summaries of the chunk's inserted
+// articles (ID set by insertArticleChunk).
+func insertSummaryChunk(ctx context.Context, q executor, items []repository.NewArticle) error {
+	var sb strings.Builder
+	sb.WriteString(`
+INSERT INTO summaries (article_id, body, provider)
+VALUES `)
+	args := make([]any, 0, len(items)*3)
+	for _, item := range items {
+		if item.Article.ID == 0 {
+			continue
+		}
+		summary := item.Summary
+		summary.ArticleID = item.Article.ID
+		if summary.Provider == "" {
+			summary.Provider = entity.SummaryProviderUnknown
+		}
+		if len(args) > 0 {
+			sb.WriteString(", ")
+		}
+		n := len(args)
+		fmt.Fprintf(&sb, "($%d, $%d, $%d)", n+1, n+2, n+3)
+		args = append(args, summary.ArticleID, summary.Body, summary.Provider)
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	// #nosec G202 -- the builder contains only generated $N placeholders.
+	_, err := q.ExecContext(ctx, sb.String(), args...)
+	return err
+}
+
 // CreateWithTranscribeJob inserts the article (content NULL — the Mac
 // transcribe worker fills it later, Phase 2 §5) and its kind='transcribe'
 // job atomically, mirroring CreateWithSummary: either both rows land or
@@ -345,21 +615,14 @@ VALUES ($1, $2, $3)`
 // the URL then stays unknown and the next hourly crawl retries (§8 縮退許容).
 // The payload contract is entity.TranscribePayload.
 func (repo *ArticleRepo) CreateWithTranscribeJob(ctx context.Context, article *entity.Article, mediaURL, sourceKind string) error {
-	if article.CrawledAt.IsZero() {
-		article.CrawledAt = time.Now()
-	}
-
-	tx, err := repo.db.BeginTx(ctx, nil)
+	tx, err := beginTx(ctx, repo.db)
 	if err != nil {
 		return fmt.Errorf("CreateWithTranscribeJob: begin: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	if err := tx.QueryRowContext(ctx, insertArticleSQL,
-		article.SourceID, article.Title, article.URL,
-		nullString(article.Content), nullTime(article.PublishedAt), article.CrawledAt,
-	).Scan(&article.ID); err != nil {
-		return fmt.Errorf("CreateWithTranscribeJob: article: %w", err)
+	if err := insertArticle(ctx, tx, article); err != nil {
+		return mapArticleInsertErr("CreateWithTranscribeJob: article", err)
 	}
 
 	payload, err := json.Marshal(entity.TranscribePayload{
@@ -396,7 +659,7 @@ WHERE a.content IS NOT NULL AND a.content <> ''
   AND sm.article_id IS NULL
 ORDER BY a.id
 LIMIT $1`
-	return repo.queryArticles(ctx, "ListUnsummarized", query, limit)
+	return queryArticles(ctx, repo.db, "ListUnsummarized", query, limit)
 }
 
 func (repo *ArticleRepo) Update(ctx context.Context, article *entity.Article) error {
@@ -408,7 +671,7 @@ UPDATE articles SET
        content      = $4,
        published_at = $5
 WHERE id = $6`
-	res, err := repo.db.ExecContext(ctx, query,
+	res, err := conn(ctx, repo.db).ExecContext(ctx, query,
 		article.SourceID, article.Title, article.URL,
 		nullString(article.Content), nullTime(article.PublishedAt), article.ID,
 	)
@@ -426,7 +689,7 @@ WHERE id = $6`
 // Articles referenced by episode segments fail with an FK error on
 // purpose: segment scripts are Phase 3 assets and must keep their source.
 func (repo *ArticleRepo) Delete(ctx context.Context, id int64) error {
-	tx, err := repo.db.BeginTx(ctx, nil)
+	tx, err := beginTx(ctx, repo.db)
 	if err != nil {
 		return fmt.Errorf("Delete: begin: %w", err)
 	}
@@ -448,6 +711,49 @@ func (repo *ArticleRepo) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// DeleteBatch deletes the articles and their summaries in one transaction.
+// IDs of missing articles are skipped, not treated as errors.
+func (repo *ArticleRepo) DeleteBatch(ctx context.Context, ids []int64) ([]int64, error) {
+	if len(ids) == 0 {
+		return []int64{}, nil
+	}
+	tx, err := beginTx(ctx, repo.db)
+	if err != nil {
+		return nil, fmt.Errorf("DeleteBatch: begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	in, args := idPlaceholders(ids, 1)
+	// #nosec G201 -- in contains only generated $N placeholders.
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM summaries WHERE article_id IN (%s)`, in), args...); err != nil {
+		return nil, fmt.Errorf("DeleteBatch: summaries: %w", err)
+	}
+	// #nosec G201 -- in contains only generated $N placeholders.
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`DELETE FROM articles WHERE id IN (%s) RETURNING id`, in), args...)
+	if err != nil {
+		return nil, fmt.Errorf("DeleteBatch: %w", err)
+	}
+	deleted := make([]int64, 0, len(ids))
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("DeleteBatch: Scan: %w", err)
+		}
+		deleted = append(deleted, id)
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return nil, fmt.Errorf("DeleteBatch: %w", err)
+	}
+	_ = rows.Close()
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("DeleteBatch: commit: %w", err)
+	}
+	return deleted, nil
+}
+
 func (repo *ArticleRepo) ExistsByURL(ctx context.Context, url string) (bool, error) {
 	const query = `SELECT EXISTS (SELECT 1 FROM articles WHERE url = $1)`
 	var existsFlag bool
@@ -501,11 +807,57 @@ func (repo *ArticleRepo) ExistsByURLBatch(ctx context.Context, urls []string) (m
 	return result, nil
 }
 
+// ExistsByContentHashBatch reports which content hashes are already stored,
+// in one query like ExistsByURLBatch.
+func (repo *ArticleRepo) ExistsByContentHashBatch(ctx context.Context, hashes []string) (map[string]bool, error) {
+	result := make(map[string]bool)
+	if len(hashes) == 0 {
+		return result, nil
+	}
+
+	placeholders := make([]string, len(hashes))
+	args := make([]any, len(hashes))
+	for i, hash := range hashes {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = hash
+	}
+
+	// #nosec G201 -- placeholders are programmatically generated ($1, $2, etc.), not from user input
+	query := fmt.Sprintf(
+		`SELECT content_hash FROM articles WHERE content_hash IN (%s)`,
+		strings.Join(placeholders, ", "),
+	)
+
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ExistsByContentHashBatch: QueryContext: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, fmt.Errorf("ExistsByContentHashBatch: Scan: %w", err)
+		}
+		result[hash] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ExistsByContentHashBatch: rows.Err: %w", err)
+	}
+	return result, nil
+}
+
 // nullString maps "" to SQL NULL (articles.content is nullable in §4).
 func nullString(s string) sql.NullString {
 	return sql.NullString{String: s, Valid: s != ""}
 }
 
+// nullSimHash maps "no fingerprint" (0) to SQL NULL and stores the
+// fingerprint bits as a signed bigint.
+func nullSimHash(h uint64) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(h), Valid: h != 0} // #nosec G115 -- bit pattern, not a quantity
+}
+
 // nullTime maps the zero time to SQL NULL (articles.published_at is
 // nullable in §4: feeds without dates stay NULL instead of year 1).
 func nullTime(t time.Time) sql.NullTime {
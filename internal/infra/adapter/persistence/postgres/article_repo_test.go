@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -27,13 +28,13 @@ type driverValue = driver.Value
 // summaries.body join).
 var articleCols = []string{
 	"id", "source_id", "title", "url", "content",
-	"summary", "published_at", "crawled_at",
+	"summary", "published_at", "crawled_at", "image_url",
 }
 
 func artRow(a *entity.Article) *sqlmock.Rows {
 	return sqlmock.NewRows(articleCols).AddRow(
 		a.ID, a.SourceID, a.Title, a.URL, a.Content,
-		a.Summary, a.PublishedAt, a.CrawledAt,
+		a.Summary, a.PublishedAt, a.CrawledAt, a.ImageURL,
 	)
 }
 
@@ -62,12 +63,13 @@ func TestArticleRepo_Get(t *testing.T) {
 				ID: 1, SourceID: 2, Title: "Go 1.26 released",
 				URL: "https://example.com", Content: "full text",
 				Summary: "日本語要約", PublishedAt: now, CrawledAt: now,
+				ImageURL: "https://example.com/og.png",
 			},
 		},
 		{
 			name: "NULL published_at maps to zero time",
 			rows: sqlmock.NewRows(articleCols).
-				AddRow(int64(1), int64(2), "t", "https://u", "", "", nil, now),
+				AddRow(int64(1), int64(2), "t", "https://u", "", "", nil, now, ""),
 			want: &entity.Article{
 				ID: 1, SourceID: 2, Title: "t", URL: "https://u", CrawledAt: now,
 			},
@@ -130,13 +132,46 @@ func TestArticleRepo_List(t *testing.T) {
 	assert.Equal(t, "s", got[0].Summary, "summary joined from summaries.body")
 }
 
+// fixedReader is a ReadDB that always reads from one pool.
+type fixedReader struct{ db *sql.DB }
+
+func (f fixedReader) Reader() *sql.DB { return f.db }
+
+func TestArticleRepo_Replica(t *testing.T) {
+	primary, primaryMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = primary.Close() }()
+	replica, replicaMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = replica.Close() }()
+	repo := pg.NewArticleRepoWithReplica(primary, fixedReader{replica}, "")
+
+	now := time.Now()
+	row := &entity.Article{ID: 1, SourceID: 2, Title: "x", URL: "y", PublishedAt: now, CrawledAt: now}
+	// 一覧・件数はレプリカ、単一行の取得は primary(作成直後の読み戻し)。
+	replicaMock.ExpectQuery("FROM articles a").WillReturnRows(artRow(row))
+	replicaMock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM articles")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+	primaryMock.ExpectQuery("FROM articles a").WithArgs(int64(1)).WillReturnRows(artRow(row))
+
+	_, err = repo.List(context.Background())
+	require.NoError(t, err)
+	_, err = repo.CountArticles(context.Background())
+	require.NoError(t, err)
+	_, err = repo.Get(context.Background(), 1)
+	require.NoError(t, err)
+
+	assert.NoError(t, replicaMock.ExpectationsWereMet())
+	assert.NoError(t, primaryMock.ExpectationsWereMet())
+}
+
 func TestArticleRepo_List_ScanError(t *testing.T) {
 	repo, mock, closeFn := newArticleRepo(t)
 	defer closeFn()
 
 	mock.ExpectQuery("FROM articles a").
 		WillReturnRows(sqlmock.NewRows(articleCols).
-			AddRow("not-an-int", int64(2), "t", "u", "", "", time.Now(), time.Now()))
+			AddRow("not-an-int", int64(2), "t", "u", "", "", time.Now(), time.Now(), ""))
 
 	_, err := repo.List(context.Background())
 	assert.Error(t, err)
@@ -173,8 +208,14 @@ func TestArticleRepo_SearchWithFilters(t *testing.T) {
 		{
 			name:      "single keyword searches title and sm.body",
 			keywords:  []string{"go"},
-			wantQuery: `(a.title ILIKE $1 OR sm.body ILIKE $1)`,
-			wantArgs:  []driverValue{"%go%"},
+			wantQuery: `OR a.title ILIKE $1 OR sm.body ILIKE $1)`,
+			wantArgs:  []driverValue{"%go%", "go"},
+		},
+		{
+			name:      "keywords are full-text searched and ranked",
+			keywords:  []string{"go"},
+			wantQuery: `ORDER BY ts_rank(setweight(a.tsv, 'A') || COALESCE(sm.tsv, ''::tsvector), plainto_tsquery('simple', $2)) DESC`,
+			wantArgs:  []driverValue{"%go%", "go"},
 		},
 		{
 			name:      "source id filter",
@@ -186,8 +227,8 @@ func TestArticleRepo_SearchWithFilters(t *testing.T) {
 			name:      "keyword and date range",
 			keywords:  []string{"go"},
 			filters:   repository.ArticleSearchFilters{From: &now},
-			wantQuery: `a.published_at >= $2`,
-			wantArgs:  []driverValue{"%go%", now},
+			wantQuery: `a.published_at >= $3`,
+			wantArgs:  []driverValue{"%go%", "go", now},
 		},
 		{
 			name:    "no keywords and no filters returns empty without querying",
@@ -222,7 +263,7 @@ func TestArticleRepo_CountArticlesWithFilters_JoinsSummaries(t *testing.T) {
 
 	// COUNT must use the same summaries join because keywords search sm.body.
 	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM articles a\nLEFT JOIN summaries sm ON sm.article_id = a.id")).
-		WithArgs("%go%").
+		WithArgs("%go%", "go").
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(3)))
 
 	got, err := repo.CountArticlesWithFilters(context.Background(), []string{"go"}, repository.ArticleSearchFilters{})
@@ -249,7 +290,7 @@ func TestArticleRepo_ListWithSourcePaginated(t *testing.T) {
 
 	now := time.Now()
 	rows := sqlmock.NewRows(append(articleCols, "source_name")).
-		AddRow(int64(1), int64(2), "t", "https://u", "c", "s", now, now, "Go Blog")
+		AddRow(int64(1), int64(2), "t", "https://u", "c", "s", now, now, "", "Go Blog")
 
 	mock.ExpectQuery("LIMIT \\$1 OFFSET \\$2").
 		WithArgs(10, 20).
@@ -266,8 +307,8 @@ func TestArticleRepo_SearchWithFiltersPaginated_WithKeywords(t *testing.T) {
 	repo, mock, closeFn := newArticleRepo(t)
 	defer closeFn()
 
-	mock.ExpectQuery(regexp.QuoteMeta("LIMIT $2 OFFSET $3")).
-		WithArgs("%go%", 10, 0).
+	mock.ExpectQuery(regexp.QuoteMeta("DESC, a.published_at DESC\nLIMIT $3 OFFSET $4")).
+		WithArgs("%go%", "go", 10, 0).
 		WillReturnRows(sqlmock.NewRows(append(articleCols, "source_name")))
 
 	got, err := repo.SearchWithFiltersPaginated(context.Background(), []string{"go"}, repository.ArticleSearchFilters{}, 0, 10)
@@ -276,6 +317,130 @@ func TestArticleRepo_SearchWithFiltersPaginated_WithKeywords(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+// A sort alone is enough to list (and count) every article in that order.
+func TestArticleRepo_SearchWithFiltersPaginated_SortOnly(t *testing.T) {
+	repo, mock, closeFn := newArticleRepo(t)
+	defer closeFn()
+
+	filters := repository.ArticleSearchFilters{
+		Sort: repository.ArticleSort{Field: repository.ArticleSortTitle, Ascending: true},
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("INNER JOIN sources s ON a.source_id = s.id\n\nORDER BY a.title ASC, a.id ASC\nLIMIT $1 OFFSET $2")).
+		WithArgs(10, 20).
+		WillReturnRows(sqlmock.NewRows(append(articleCols, "source_name")))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM articles a")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	got, err := repo.SearchWithFiltersPaginated(context.Background(), nil, filters, 20, 10)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	count, err := repo.CountArticlesWithFilters(context.Background(), nil, filters)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleRepo_ForEachWithSource(t *testing.T) {
+	repo, mock, closeFn := newArticleRepo(t)
+	defer closeFn()
+
+	now := time.Now()
+	sourceID := int64(2)
+	rows := sqlmock.NewRows(append(articleCols, "source_name")).
+		AddRow(int64(1), int64(2), "t1", "https://u/1", "", "s", now, now, "", "Go Blog").
+		AddRow(int64(2), int64(2), "t2", "https://u/2", "", "s", nil, now, "", "Go Blog")
+
+	// 全文 (content) は読み込まず、LIMIT なしで検索順に流す
+	mock.ExpectQuery(regexp.QuoteMeta("'' AS content") + "(?s).*" + regexp.QuoteMeta("WHERE a.source_id = $1\nORDER BY a.published_at DESC") + "$").
+		WithArgs(sourceID).
+		WillReturnRows(rows)
+
+	var got []int64
+	err := repo.ForEachWithSource(context.Background(), nil, repository.ArticleSearchFilters{SourceID: &sourceID},
+		func(a repository.ArticleWithSource) error {
+			got = append(got, a.Article.ID)
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleRepo_ForEachWithSource_StopsOnCallbackError(t *testing.T) {
+	repo, mock, closeFn := newArticleRepo(t)
+	defer closeFn()
+
+	now := time.Now()
+	rows := sqlmock.NewRows(append(articleCols, "source_name")).
+		AddRow(int64(1), int64(2), "t1", "https://u/1", "", "s", now, now, "", "Go Blog").
+		AddRow(int64(2), int64(2), "t2", "https://u/2", "", "s", now, now, "", "Go Blog")
+	mock.ExpectQuery(regexp.QuoteMeta("ORDER BY a.published_at DESC")).WillReturnRows(rows)
+
+	stop := errors.New("client gone")
+	calls := 0
+	err := repo.ForEachWithSource(context.Background(), nil, repository.ArticleSearchFilters{},
+		func(repository.ArticleWithSource) error {
+			calls++
+			return stop
+		})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
+
+func TestArticleRepo_ListWithSourceAfter(t *testing.T) {
+	published := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	sourceID := int64(7)
+
+	tests := []struct {
+		name      string
+		keywords  []string
+		filters   repository.ArticleSearchFilters
+		after     *repository.ArticleCursor
+		wantQuery string
+		wantArgs  []driverValue
+	}{
+		{
+			name:      "first page lists everything in keyset order",
+			wantQuery: "INNER JOIN sources s ON a.source_id = s.id\n\nORDER BY a.published_at DESC NULLS LAST, a.id DESC\nLIMIT $1",
+			wantArgs:  []driverValue{20},
+		},
+		{
+			name:      "cursor without filters",
+			after:     &repository.ArticleCursor{PublishedAt: &published, ID: 42},
+			wantQuery: "WHERE (a.published_at < $1 OR (a.published_at = $1 AND a.id < $2) OR a.published_at IS NULL)",
+			wantArgs:  []driverValue{published, int64(42), 20},
+		},
+		{
+			name:      "cursor after keywords and filters",
+			keywords:  []string{"go"},
+			filters:   repository.ArticleSearchFilters{SourceID: &sourceID},
+			after:     &repository.ArticleCursor{ID: 5},
+			wantQuery: "AND a.source_id = $3 AND (a.published_at IS NULL AND a.id < $4)",
+			wantArgs:  []driverValue{"%go%", "go", sourceID, int64(5), 20},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, closeFn := newArticleRepo(t)
+			defer closeFn()
+
+			args := make([]driver.Value, len(tt.wantArgs))
+			copy(args, tt.wantArgs)
+			mock.ExpectQuery(regexp.QuoteMeta(tt.wantQuery)).
+				WithArgs(args...).
+				WillReturnRows(sqlmock.NewRows(append(articleCols, "source_name")))
+
+			got, err := repo.ListWithSourceAfter(context.Background(), tt.keywords, tt.filters, tt.after, 20)
+			require.NoError(t, err)
+			assert.Empty(t, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestArticleRepo_CountArticles(t *testing.T) {
 	repo, mock, closeFn := newArticleRepo(t)
 	defer closeFn()
@@ -298,23 +463,27 @@ func TestArticleRepo_Create(t *testing.T) {
 		article     *entity.Article
 		wantContent driverValue // nil = SQL NULL
 		wantPubAt   driverValue
+		wantImage   driverValue
 	}{
 		{
 			name: "full article",
 			article: &entity.Article{
 				SourceID: 2, Title: "title", URL: "https://u",
 				Content: "full text", PublishedAt: now, CrawledAt: now,
+				ImageURL: "https://u/og.png",
 			},
 			wantContent: "full text",
 			wantPubAt:   now,
+			wantImage:   "https://u/og.png",
 		},
 		{
-			name: "empty content and zero published_at stored as NULL",
+			name: "empty content, zero published_at and no image stored as NULL",
 			article: &entity.Article{
 				SourceID: 2, Title: "title", URL: "https://u", CrawledAt: now,
 			},
 			wantContent: nil,
 			wantPubAt:   nil,
+			wantImage:   nil,
 		},
 	}
 
@@ -325,7 +494,8 @@ func TestArticleRepo_Create(t *testing.T) {
 
 			mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO articles")).
 				WithArgs(int64(2), "title", "https://u",
-					tt.wantContent, tt.wantPubAt, now).
+					tt.wantContent, tt.wantPubAt, now,
+					entity.ArticleContentHash("https://u", "title", tt.article.Content), nil, nil, tt.wantImage).
 				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(99)))
 
 			err := repo.Create(context.Background(), tt.article)
@@ -344,7 +514,8 @@ func TestArticleRepo_CreateWithSummary(t *testing.T) {
 	now := time.Now()
 	mock.ExpectBegin()
 	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO articles")).
-		WithArgs(int64(2), "title", "https://u", "full text", now, now).
+		WithArgs(int64(2), "title", "https://u", "full text", now, now,
+			entity.ArticleContentHash("https://u", "title", "full text"), nil, nil, nil).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(99)))
 	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO summaries")).
 		WithArgs(int64(99), "日本語要約", "gemini").
@@ -363,6 +534,71 @@ func TestArticleRepo_CreateWithSummary(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestArticleRepo_CreateBatch(t *testing.T) {
+	repo, mock, closeFn := newArticleRepo(t)
+	defer closeFn()
+
+	now := time.Now()
+	items := []repository.NewArticle{
+		{Article: &entity.Article{SourceID: 1, Title: "a", URL: "https://a", CrawledAt: now}, Summary: &entity.Summary{Body: "要約a", Provider: "gemini"}},
+		{Article: &entity.Article{SourceID: 1, Title: "b", URL: "https://b", CrawledAt: now}, Summary: &entity.Summary{Body: "要約b"}},
+		{Article: &entity.Article{SourceID: 1, Title: "c", URL: "https://c", CrawledAt: now}, Summary: &entity.Summary{Body: "要約c", Provider: "groq"}},
+	}
+
+	mock.ExpectBegin()
+	// https://b は既に保存済み(ON CONFLICT DO NOTHING で返らない)
+	mock.ExpectQuery(`INSERT INTO articles .* VALUES \(\$1, .*\), \(\$11, .*\), \(\$21, .*\$30\)\s+ON CONFLICT DO NOTHING\s+RETURNING id, url`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "url"}).AddRow(int64(10), "https://a").AddRow(int64(11), "https://c"))
+	mock.ExpectExec(`INSERT INTO summaries \(article_id, body, provider\)\s+VALUES \(\$1, \$2, \$3\), \(\$4, \$5, \$6\)$`).
+		WithArgs(int64(10), "要約a", "gemini", int64(11), "要約c", "groq").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	n, err := repo.CreateBatch(context.Background(), items)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, int64(10), items[0].Article.ID)
+	assert.Zero(t, items[1].Article.ID, "skipped article keeps ID 0")
+	assert.Equal(t, int64(11), items[2].Article.ID)
+	assert.Equal(t, int64(11), items[2].Summary.ArticleID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleRepo_CreateBatch_SummaryErrorRollsBack(t *testing.T) {
+	repo, mock, closeFn := newArticleRepo(t)
+	defer closeFn()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO articles")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "url"}).AddRow(int64(10), "https://a"))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO summaries")).
+		WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	_, err := repo.CreateBatch(context.Background(), []repository.NewArticle{
+		{Article: &entity.Article{SourceID: 1, Title: "a", URL: "https://a", CrawledAt: time.Now()}, Summary: &entity.Summary{Body: "要約"}},
+	})
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleRepo_CreateBatch_AllSkipped(t *testing.T) {
+	repo, mock, closeFn := newArticleRepo(t)
+	defer closeFn()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO articles")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "url"}))
+	mock.ExpectCommit() // summaries の INSERT は発行しない
+
+	n, err := repo.CreateBatch(context.Background(), []repository.NewArticle{
+		{Article: &entity.Article{SourceID: 1, Title: "a", URL: "https://a", CrawledAt: time.Now()}, Summary: &entity.Summary{Body: "要約"}},
+	})
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 // TestArticleRepo_CreateWithSummary_SummaryErrorRollsBack pins the §8
 // invariant: a summary insert failure must roll the article back, so no
 // article row can exist without its summary (the URL stays unknown and the
@@ -419,7 +655,7 @@ func TestArticleRepo_CreateWithTranscribeJob(t *testing.T) {
 	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO articles")).
 		WithArgs(int64(2), "Ep 1", "https://example.com/ep1",
 			nil, // content is stored as NULL until transcribed
-			now, now).
+			now, now, "feed-hash", nil, nil, nil).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(42)))
 	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO jobs")).
 		WithArgs(entity.JobKindTranscribe,
@@ -427,9 +663,10 @@ func TestArticleRepo_CreateWithTranscribeJob(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
+	// 呼び出し側(クロール)が設定したハッシュはそのまま保存する
 	art := &entity.Article{
 		SourceID: 2, Title: "Ep 1", URL: "https://example.com/ep1",
-		PublishedAt: now, CrawledAt: now,
+		PublishedAt: now, CrawledAt: now, ContentHash: "feed-hash",
 	}
 	require.NoError(t, repo.CreateWithTranscribeJob(context.Background(),
 		art, "https://cdn.example.com/ep1.mp3", entity.SourceKindPodcast))
@@ -437,6 +674,22 @@ func TestArticleRepo_CreateWithTranscribeJob(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestArticleRepo_CreateWithSummary_DuplicateContentHash(t *testing.T) {
+	repo, mock, closeFn := newArticleRepo(t)
+	defer closeFn()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO articles")).
+		WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "idx_articles_content_hash"})
+	mock.ExpectRollback()
+
+	err := repo.CreateWithSummary(context.Background(),
+		&entity.Article{SourceID: 1, Title: "t", URL: "https://u?utm_source=x"},
+		&entity.Summary{Body: "要約"})
+	assert.ErrorIs(t, err, repository.ErrDuplicateContentHash)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 // TestArticleRepo_CreateWithTranscribeJob_JobErrorRollsBack: a job insert
 // failure must roll the article back — otherwise a content-less article
 // would exist with no transcribe job to ever fill it (stuck row).
@@ -541,6 +794,42 @@ func TestArticleRepo_Delete_NotFound(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestArticleRepo_DeleteBatch(t *testing.T) {
+	repo, mock, closeFn := newArticleRepo(t)
+	defer closeFn()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM summaries WHERE article_id IN ($1, $2, $3)")).
+		WithArgs(int64(1), int64(2), int64(99)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("DELETE FROM articles WHERE id IN ($1, $2, $3) RETURNING id")).
+		WithArgs(int64(1), int64(2), int64(99)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)).AddRow(int64(2)))
+	mock.ExpectCommit()
+
+	deleted, err := repo.DeleteBatch(context.Background(), []int64{1, 2, 99})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestArticleRepo_DeleteBatch_RollsBack: 途中で失敗したら要約の削除も取り消す。
+func TestArticleRepo_DeleteBatch_RollsBack(t *testing.T) {
+	repo, mock, closeFn := newArticleRepo(t)
+	defer closeFn()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM summaries")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("DELETE FROM articles")).
+		WillReturnError(errors.New("fk violation"))
+	mock.ExpectRollback()
+
+	_, err := repo.DeleteBatch(context.Background(), []int64{1})
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 /* ─────────────────────────── Exists ─────────────────────────── */
 
 func TestArticleRepo_ExistsByURL(t *testing.T) {
@@ -605,6 +894,24 @@ func TestArticleRepo_ExistsByURLBatch(t *testing.T) {
 	}
 }
 
+func TestArticleRepo_ExistsByContentHashBatch(t *testing.T) {
+	repo, mock, closeFn := newArticleRepo(t)
+	defer closeFn()
+
+	mock.ExpectQuery("SELECT content_hash FROM articles WHERE content_hash IN").
+		WithArgs("h1", "h2").
+		WillReturnRows(sqlmock.NewRows([]string{"content_hash"}).AddRow("h2"))
+
+	got, err := repo.ExistsByContentHashBatch(context.Background(), []string{"h1", "h2"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"h2": true}, got)
+
+	got, err = repo.ExistsByContentHashBatch(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 /* ─────────────────────────── GetWithSource ─────────────────────────── */
 
 func TestArticleRepo_GetWithSource(t *testing.T) {
@@ -613,7 +920,7 @@ func TestArticleRepo_GetWithSource(t *testing.T) {
 
 	now := time.Now()
 	rows := sqlmock.NewRows(append(articleCols, "source_name")).
-		AddRow(int64(1), int64(2), "t", "https://u", "c", "s", now, now, "Go Blog")
+		AddRow(int64(1), int64(2), "t", "https://u", "c", "s", now, now, "", "Go Blog")
 
 	mock.ExpectQuery("INNER JOIN sources s ON a.source_id = s.id").
 		WithArgs(int64(1)).
@@ -655,8 +962,8 @@ func TestArticleRepo_ListUnsummarized(t *testing.T) {
 		{
 			name: "returns content-filled articles without summaries",
 			rows: sqlmock.NewRows(articleCols).
-				AddRow(int64(1), int64(2), "transcribed", "https://u1", "transcript text", "", now, now).
-				AddRow(int64(3), int64(2), "another", "https://u2", "more text", "", nil, now),
+				AddRow(int64(1), int64(2), "transcribed", "https://u1", "transcript text", "", now, now, "").
+				AddRow(int64(3), int64(2), "another", "https://u2", "more text", "", nil, now, ""),
 			wantLen: 2,
 		},
 		{
@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"catchup-feed/internal/repository"
+)
+
+// retentionProtected excludes articles that must outlive the retention
+// window: a user's favorites, and articles referenced by radio segments or
+// learning items (both keep a plain foreign key, so deleting would fail).
+const retentionProtected = `
+  AND NOT EXISTS (SELECT 1 FROM article_favorites f WHERE f.article_id = a.id)
+  AND NOT EXISTS (SELECT 1 FROM segments g WHERE g.article_id = a.id)
+  AND NOT EXISTS (SELECT 1 FROM learning_items l WHERE l.article_id = a.id)`
+
+// ArticleRetentionRepo finds and purges expired articles.
+type ArticleRetentionRepo struct{ db *sql.DB }
+
+func NewArticleRetentionRepo(db *sql.DB) repository.ArticleRetentionRepository {
+	return &ArticleRetentionRepo{db: db}
+}
+
+// ListExpired applies each source's retention_days, falling back to
+// defaultDays, in a single query.
+func (repo *ArticleRetentionRepo) ListExpired(ctx context.Context, now time.Time, defaultDays, limit int) ([]repository.ExpiredArticle, error) {
+	query := `
+SELECT a.id, a.source_id, COALESCE(a.published_at, a.crawled_at)
+FROM articles a
+JOIN sources s ON s.id = a.source_id
+WHERE COALESCE(s.retention_days, $1::int) > 0
+  AND COALESCE(a.published_at, a.crawled_at) < $2::timestamptz - make_interval(days => COALESCE(s.retention_days, $1::int))` +
+		retentionProtected + `
+ORDER BY COALESCE(a.published_at, a.crawled_at), a.id
+LIMIT $3`
+	rows, err := repo.db.QueryContext(ctx, query, defaultDays, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ListExpired: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []repository.ExpiredArticle
+	for rows.Next() {
+		var a repository.ExpiredArticle
+		if err := rows.Scan(&a.ID, &a.SourceID, &a.PublishedAt); err != nil {
+			return nil, fmt.Errorf("ListExpired: Scan: %w", err)
+		}
+		out = append(out, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ListExpired: %w", err)
+	}
+	return out, nil
+}
+
+// Purge locks the still-unprotected articles first: FOR UPDATE conflicts
+// with the key-share lock a concurrent favorite INSERT takes, so nothing
+// can be favorited between the check and the DELETE.
+func (repo *ArticleRetentionRepo) Purge(ctx context.Context, ids []int64, archive bool) ([]int64, error) {
+	if len(ids) == 0 {
+		return []int64{}, nil
+	}
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Purge: begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	in, args := idPlaceholders(ids, 1)
+	// #nosec G201 -- in contains only generated $N placeholders.
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+SELECT a.id FROM articles a
+WHERE a.id IN (%s)`+retentionProtected+`
+ORDER BY a.id
+FOR UPDATE`, in), args...)
+	if err != nil {
+		return nil, fmt.Errorf("Purge: lock: %w", err)
+	}
+	locked := make([]int64, 0, len(ids))
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("Purge: Scan: %w", err)
+		}
+		locked = append(locked, id)
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return nil, fmt.Errorf("Purge: lock: %w", err)
+	}
+	_ = rows.Close()
+	if len(locked) == 0 {
+		return locked, nil
+	}
+
+	in, args = idPlaceholders(locked, 1)
+	if archive {
+		// #nosec G201 -- in contains only generated $N placeholders.
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`
+INSERT INTO articles_archive (id, source_id, url, title, content, summary, published_at, crawled_at)
+SELECT a.id, a.source_id, a.url, a.title, a.content, s.body, a.published_at, a.crawled_at
+FROM articles a
+LEFT JOIN summaries s ON s.article_id = a.id
+WHERE a.id IN (%s)
+ON CONFLICT (id) DO NOTHING`, in), args...); err != nil {
+			return nil, fmt.Errorf("Purge: archive: %w", err)
+		}
+	}
+	// #nosec G201 -- in contains only generated $N placeholders.
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM summaries WHERE article_id IN (%s)`, in), args...); err != nil {
+		return nil, fmt.Errorf("Purge: summaries: %w", err)
+	}
+	// #nosec G201 -- in contains only generated $N placeholders.
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM articles WHERE id IN (%s)`, in), args...); err != nil {
+		return nil, fmt.Errorf("Purge: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("Purge: commit: %w", err)
+	}
+	return locked, nil
+}
@@ -0,0 +1,123 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+	"catchup-feed/internal/repository"
+)
+
+func newArticleRetentionRepo(t *testing.T) (repository.ArticleRetentionRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return pg.NewArticleRetentionRepo(db), mock, func() { _ = db.Close() }
+}
+
+func TestArticleRetentionRepo_ListExpired(t *testing.T) {
+	repo, mock, closeFn := newArticleRetentionRepo(t)
+	defer closeFn()
+
+	now := time.Date(2026, 10, 15, 6, 0, 0, 0, time.UTC)
+	old := now.AddDate(0, 0, -120)
+	mock.ExpectQuery(`COALESCE\(s\.retention_days, \$1::int\).*NOT EXISTS \(SELECT 1 FROM article_favorites.*segments.*learning_items.*LIMIT \$3`).
+		WithArgs(90, now, 500).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "source_id", "published_at"}).
+			AddRow(int64(7), int64(2), old))
+
+	got, err := repo.ListExpired(context.Background(), now, 90, 500)
+	require.NoError(t, err)
+	assert.Equal(t, []repository.ExpiredArticle{{ID: 7, SourceID: 2, PublishedAt: old}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleRetentionRepo_ListExpired_Error(t *testing.T) {
+	repo, mock, closeFn := newArticleRetentionRepo(t)
+	defer closeFn()
+
+	mock.ExpectQuery("FROM articles").WillReturnError(errors.New("db down"))
+
+	_, err := repo.ListExpired(context.Background(), time.Now(), 90, 500)
+	assert.Error(t, err)
+}
+
+func TestArticleRetentionRepo_Purge(t *testing.T) {
+	t.Run("archive then delete only the still-unprotected rows", func(t *testing.T) {
+		repo, mock, closeFn := newArticleRetentionRepo(t)
+		defer closeFn()
+
+		mock.ExpectBegin()
+		// 3 はロック取得までの間にお気に入りされた
+		mock.ExpectQuery(`SELECT a.id FROM articles a.*article_favorites.*FOR UPDATE`).
+			WithArgs(int64(1), int64(2), int64(3)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)).AddRow(int64(2)))
+		mock.ExpectExec(`INSERT INTO articles_archive .*LEFT JOIN summaries`).
+			WithArgs(int64(1), int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(`DELETE FROM summaries`).
+			WithArgs(int64(1), int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(`DELETE FROM articles`).
+			WithArgs(int64(1), int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		got, err := repo.Purge(context.Background(), []int64{1, 2, 3}, true)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 2}, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("delete mode skips the archive copy", func(t *testing.T) {
+		repo, mock, closeFn := newArticleRetentionRepo(t)
+		defer closeFn()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`FOR UPDATE`).
+			WithArgs(int64(5)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(5)))
+		mock.ExpectExec(`DELETE FROM summaries`).WithArgs(int64(5)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM articles`).WithArgs(int64(5)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		got, err := repo.Purge(context.Background(), []int64{5}, false)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{5}, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("everything protected commits nothing", func(t *testing.T) {
+		repo, mock, closeFn := newArticleRetentionRepo(t)
+		defer closeFn()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`FOR UPDATE`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		got, err := repo.Purge(context.Background(), []int64{9}, true)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("delete error rolls back", func(t *testing.T) {
+		repo, mock, closeFn := newArticleRetentionRepo(t)
+		defer closeFn()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`FOR UPDATE`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(5)))
+		mock.ExpectExec(`DELETE FROM summaries`).WillReturnError(errors.New("db down"))
+		mock.ExpectRollback()
+
+		_, err := repo.Purge(context.Background(), []int64{5}, false)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"catchup-feed/internal/repository"
+)
+
+// ArticleSimilarityRepo finds near-duplicate articles by SimHash
+// (articles.simhash / duplicate_of). simhash holds the 64 fingerprint bits
+// as a signed bigint; bit_count of the XOR is the Hamming distance.
+type ArticleSimilarityRepo struct {
+	db *sql.DB
+}
+
+func NewArticleSimilarityRepo(db *sql.DB) repository.ArticleSimilarityRepository {
+	return &ArticleSimilarityRepo{db: db}
+}
+
+// FindNearDuplicate scans the recent fingerprinted articles
+// (idx_articles_simhash_crawled_at); the window keeps the scan small.
+func (repo *ArticleSimilarityRepo) FindNearDuplicate(ctx context.Context, simHash uint64, maxDistance int, since time.Time, excludeSourceID int64) (int64, error) {
+	const query = `
+SELECT COALESCE(duplicate_of, id)
+FROM articles
+WHERE simhash IS NOT NULL
+  AND crawled_at >= $2
+  AND source_id <> $3
+  AND bit_count((simhash # $1)::bit(64)) <= $4
+ORDER BY bit_count((simhash # $1)::bit(64)), id
+LIMIT 1`
+	var id int64
+	err := repo.db.QueryRowContext(ctx, query,
+		nullSimHash(simHash), since, excludeSourceID, maxDistance,
+	).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("FindNearDuplicate: %w", err)
+	}
+	return id, nil
+}
+
+func (repo *ArticleSimilarityRepo) ListGroup(ctx context.Context, articleID int64) ([]repository.ArticleWithSource, error) {
+	query := `
+WITH g AS (SELECT COALESCE(duplicate_of, id) AS root FROM articles WHERE id = $1)
+SELECT ` + articleColumns + `, s.name AS source_name
+` + articleFrom + `
+INNER JOIN sources s ON a.source_id = s.id
+CROSS JOIN g
+WHERE a.id = g.root OR a.duplicate_of = g.root
+ORDER BY a.id <> g.root, a.id`
+	return queryArticlesWithSource(ctx, repo.db, "ListGroup", query, 4, articleID)
+}
@@ -0,0 +1,79 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+	"catchup-feed/internal/repository"
+)
+
+func newArticleSimilarityRepo(t *testing.T) (repository.ArticleSimilarityRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return pg.NewArticleSimilarityRepo(db), mock, func() { _ = db.Close() }
+}
+
+func TestArticleSimilarityRepo_FindNearDuplicate(t *testing.T) {
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("found", func(t *testing.T) {
+		repo, mock, closeFn := newArticleSimilarityRepo(t)
+		defer closeFn()
+		// The high bit set: stored as a negative bigint.
+		mock.ExpectQuery(`bit_count\(\(simhash # \$1\)::bit\(64\)\) <= \$4`).
+			WithArgs(int64(-0x7fffffffffffff00), since, int64(2), 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(42)))
+
+		got, err := repo.FindNearDuplicate(context.Background(), 0x8000000000000100, 10, since, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("none returns 0", func(t *testing.T) {
+		repo, mock, closeFn := newArticleSimilarityRepo(t)
+		defer closeFn()
+		mock.ExpectQuery("FROM articles").WillReturnError(sql.ErrNoRows)
+
+		got, err := repo.FindNearDuplicate(context.Background(), 1, 10, since, 2)
+		require.NoError(t, err)
+		assert.Zero(t, got)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		repo, mock, closeFn := newArticleSimilarityRepo(t)
+		defer closeFn()
+		mock.ExpectQuery("FROM articles").WillReturnError(errors.New("boom"))
+
+		_, err := repo.FindNearDuplicate(context.Background(), 1, 10, since, 2)
+		assert.Error(t, err)
+	})
+}
+
+func TestArticleSimilarityRepo_ListGroup(t *testing.T) {
+	repo, mock, closeFn := newArticleSimilarityRepo(t)
+	defer closeFn()
+
+	now := time.Now()
+	mock.ExpectQuery(`WHERE a.id = g.root OR a.duplicate_of = g.root`).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows(append(articleCols, "source_name")).
+			AddRow(int64(5), int64(1), "original", "https://a", "c", "", now, now, "", "Source A").
+			AddRow(int64(7), int64(2), "copy", "https://b", "c", "", now, now, "", "Source B"))
+
+	got, err := repo.ListGroup(context.Background(), 7)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, int64(5), got[0].Article.ID)
+	assert.Equal(t, "Source B", got[1].SourceName)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+const auditLogColumns = "id, actor, action, resource_type, resource_id, request_id, ip, before, after, created_at"
+
+// auditLogWhere applies AuditLogFilter with the "$n IS NULL OR ..." idiom
+// (as feed_access_logs does), keeping the query static. Args $1..$6 come
+// from auditLogFilterArgs.
+const auditLogWhere = `
+WHERE ($1::text IS NULL OR actor = $1)
+  AND ($2::text IS NULL OR action = $2)
+  AND ($3::text IS NULL OR resource_type = $3)
+  AND ($4::bigint IS NULL OR resource_id = $4)
+  AND ($5::timestamptz IS NULL OR created_at >= $5)
+  AND ($6::timestamptz IS NULL OR created_at < $6)`
+
+// AuditLogRepo persists the admin audit trail (audit_logs table).
+type AuditLogRepo struct{ db *sql.DB }
+
+func NewAuditLogRepo(db *sql.DB) repository.AuditLogRepository {
+	return &AuditLogRepo{db: db}
+}
+
+func auditLogFilterArgs(f repository.AuditLogFilter) []any {
+	return []any{
+		nullString(f.Actor), nullString(f.Action), nullString(f.ResourceType),
+		f.ResourceID, f.From, f.To,
+	}
+}
+
+// nullJSON maps an empty snapshot to SQL NULL (create has no before,
+// delete has no after).
+func nullJSON(b []byte) any {
+	if len(b) == 0 {
+		return nil
+	}
+	return string(b)
+}
+
+// Insert appends the entry and sets entry.ID / CreatedAt.
+func (repo *AuditLogRepo) Insert(ctx context.Context, entry *entity.AuditLog) error {
+	const query = `
+INSERT INTO audit_logs (actor, action, resource_type, resource_id, request_id, ip, before, after)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, created_at`
+	err := repo.db.QueryRowContext(ctx, query,
+		entry.Actor, entry.Action, entry.ResourceType, entry.ResourceID,
+		nullString(entry.RequestID), nullString(entry.IP),
+		nullJSON(entry.Before), nullJSON(entry.After),
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("Insert: %w", err)
+	}
+	return nil
+}
+
+// List returns matching entries, newest first.
+func (repo *AuditLogRepo) List(ctx context.Context, filter repository.AuditLogFilter, offset, limit int) ([]*entity.AuditLog, error) {
+	query := `
+SELECT ` + auditLogColumns + `
+FROM audit_logs` + auditLogWhere + `
+ORDER BY id DESC
+LIMIT $7 OFFSET $8`
+	args := append(auditLogFilterArgs(filter), limit, offset)
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("List: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	entries := make([]*entity.AuditLog, 0, limit)
+	for rows.Next() {
+		var (
+			e             entity.AuditLog
+			requestID, ip sql.NullString
+			before, after []byte
+		)
+		if err := rows.Scan(
+			&e.ID, &e.Actor, &e.Action, &e.ResourceType, &e.ResourceID,
+			&requestID, &ip, &before, &after, &e.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("List: %w", err)
+		}
+		e.RequestID, e.IP = requestID.String, ip.String
+		e.Before, e.After = before, after
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
+
+// Count returns the number of matching entries.
+func (repo *AuditLogRepo) Count(ctx context.Context, filter repository.AuditLogFilter) (int64, error) {
+	query := `SELECT COUNT(*) FROM audit_logs` + auditLogWhere
+	var n int64
+	if err := repo.db.QueryRowContext(ctx, query, auditLogFilterArgs(filter)...).Scan(&n); err != nil {
+		return 0, fmt.Errorf("Count: %w", err)
+	}
+	return n, nil
+}
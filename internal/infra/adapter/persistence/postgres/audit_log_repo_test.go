@@ -0,0 +1,81 @@
+package postgres_test
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+	"catchup-feed/internal/repository"
+)
+
+var auditLogCols = []string{"id", "actor", "action", "resource_type", "resource_id", "request_id", "ip", "before", "after", "created_at"}
+
+func newAuditLogRepo(t *testing.T) (repository.AuditLogRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return pg.NewAuditLogRepo(db), mock, func() { _ = db.Close() }
+}
+
+func TestAuditLogRepo_Insert(t *testing.T) {
+	repo, mock, closeFn := newAuditLogRepo(t)
+	defer closeFn()
+
+	id := int64(5)
+	now := time.Now()
+	after := json.RawMessage(`{"name":"Go Blog"}`)
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO audit_logs")).
+		WithArgs("admin", entity.AuditActionCreate, entity.AuditResourceSource, &id,
+			"req-1", "203.0.113.7", nil, `{"name":"Go Blog"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(int64(1), now))
+
+	entry := &entity.AuditLog{
+		Actor: "admin", Action: entity.AuditActionCreate, ResourceType: entity.AuditResourceSource,
+		ResourceID: &id, RequestID: "req-1", IP: "203.0.113.7", After: after,
+	}
+	require.NoError(t, repo.Insert(context.Background(), entry))
+	assert.Equal(t, int64(1), entry.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditLogRepo_List(t *testing.T) {
+	repo, mock, closeFn := newAuditLogRepo(t)
+	defer closeFn()
+
+	now := time.Now()
+	id := int64(5)
+	mock.ExpectQuery(regexp.QuoteMeta("FROM audit_logs")).
+		WithArgs(nil, "delete", nil, nil, nil, nil, 20, 40).
+		WillReturnRows(sqlmock.NewRows(auditLogCols).
+			AddRow(int64(2), "admin", "delete", "article", id, nil, nil, []byte(`{"title":"x"}`), nil, now))
+
+	got, err := repo.List(context.Background(), repository.AuditLogFilter{Action: "delete"}, 40, 20)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "", got[0].RequestID)
+	assert.JSONEq(t, `{"title":"x"}`, string(got[0].Before))
+	assert.Nil(t, got[0].After)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditLogRepo_Count(t *testing.T) {
+	repo, mock, closeFn := newAuditLogRepo(t)
+	defer closeFn()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM audit_logs")).
+		WithArgs("admin", nil, nil, nil, nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(3)))
+
+	n, err := repo.Count(context.Background(), repository.AuditLogFilter{Actor: "admin"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
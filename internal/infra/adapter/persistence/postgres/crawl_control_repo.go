@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// CrawlControlRepo persists the crawl maintenance controls (crawl_control,
+// a single row with id = 1, and crawl_skipped_sources).
+type CrawlControlRepo struct{ db *sql.DB }
+
+func NewCrawlControlRepo(db *sql.DB) repository.CrawlControlRepository {
+	return &CrawlControlRepo{db: db}
+}
+
+// Get reads the pause row and the skipped sources. A missing pause row
+// (never paused) reads as not paused.
+func (repo *CrawlControlRepo) Get(ctx context.Context) (*entity.CrawlControl, error) {
+	var c entity.CrawlControl
+	err := repo.db.QueryRowContext(ctx,
+		`SELECT paused, reason, updated_by, updated_at FROM crawl_control WHERE id = 1`,
+	).Scan(&c.Paused, &c.Reason, &c.UpdatedBy, &c.UpdatedAt)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("Get: %w", err)
+	}
+
+	rows, err := repo.db.QueryContext(ctx, `SELECT source_id FROM crawl_skipped_sources ORDER BY source_id`)
+	if err != nil {
+		return nil, fmt.Errorf("Get: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	c.SkippedSources = []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("Get: %w", err)
+		}
+		c.SkippedSources = append(c.SkippedSources, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Get: %w", err)
+	}
+	return &c, nil
+}
+
+// SetPaused upserts the single pause row.
+func (repo *CrawlControlRepo) SetPaused(ctx context.Context, paused bool, reason, updatedBy string) error {
+	const query = `
+INSERT INTO crawl_control (id, paused, reason, updated_by, updated_at)
+VALUES (1, $1, $2, $3, now())
+ON CONFLICT (id) DO UPDATE SET
+       paused     = EXCLUDED.paused,
+       reason     = EXCLUDED.reason,
+       updated_by = EXCLUDED.updated_by,
+       updated_at = EXCLUDED.updated_at`
+	if _, err := repo.db.ExecContext(ctx, query, paused, reason, updatedBy); err != nil {
+		return fmt.Errorf("SetPaused: %w", err)
+	}
+	return nil
+}
+
+// SkipSource inserts the source's row; an existing row is kept as is.
+func (repo *CrawlControlRepo) SkipSource(ctx context.Context, sourceID int64) error {
+	const query = `
+INSERT INTO crawl_skipped_sources (source_id)
+VALUES ($1)
+ON CONFLICT (source_id) DO NOTHING`
+	if _, err := repo.db.ExecContext(ctx, query, sourceID); err != nil {
+		return fmt.Errorf("SkipSource: %w", err)
+	}
+	return nil
+}
+
+// UnskipSource deletes the source's row.
+func (repo *CrawlControlRepo) UnskipSource(ctx context.Context, sourceID int64) (bool, error) {
+	res, err := repo.db.ExecContext(ctx, `DELETE FROM crawl_skipped_sources WHERE source_id = $1`, sourceID)
+	if err != nil {
+		return false, fmt.Errorf("UnskipSource: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("UnskipSource: %w", err)
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,107 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+	"catchup-feed/internal/repository"
+)
+
+func newCrawlControlRepo(t *testing.T) (repository.CrawlControlRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return pg.NewCrawlControlRepo(db), mock, func() { _ = db.Close() }
+}
+
+func TestCrawlControlRepo_Get(t *testing.T) {
+	repo, mock, closeFn := newCrawlControlRepo(t)
+	defer closeFn()
+
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("FROM crawl_control WHERE id = 1")).
+		WillReturnRows(sqlmock.NewRows([]string{"paused", "reason", "updated_by", "updated_at"}).
+			AddRow(true, "DB maintenance", "admin@example.com", at))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT source_id FROM crawl_skipped_sources ORDER BY source_id")).
+		WillReturnRows(sqlmock.NewRows([]string{"source_id"}).AddRow(int64(3)).AddRow(int64(7)))
+
+	got, err := repo.Get(context.Background())
+	require.NoError(t, err)
+	assert.True(t, got.Paused)
+	assert.Equal(t, "DB maintenance", got.Reason)
+	assert.Equal(t, "admin@example.com", got.UpdatedBy)
+	require.NotNil(t, got.UpdatedAt)
+	assert.Equal(t, at, *got.UpdatedAt)
+	assert.Equal(t, []int64{3, 7}, got.SkippedSources)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCrawlControlRepo_Get_NeverPaused(t *testing.T) {
+	repo, mock, closeFn := newCrawlControlRepo(t)
+	defer closeFn()
+
+	// 行が無い = 一度も停止していない
+	mock.ExpectQuery(regexp.QuoteMeta("FROM crawl_control WHERE id = 1")).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(regexp.QuoteMeta("FROM crawl_skipped_sources")).
+		WillReturnRows(sqlmock.NewRows([]string{"source_id"}))
+
+	got, err := repo.Get(context.Background())
+	require.NoError(t, err)
+	assert.False(t, got.Paused)
+	assert.Nil(t, got.UpdatedAt)
+	assert.Empty(t, got.SkippedSources)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCrawlControlRepo_SetPaused(t *testing.T) {
+	repo, mock, closeFn := newCrawlControlRepo(t)
+	defer closeFn()
+
+	mock.ExpectExec(`INSERT INTO crawl_control .*ON CONFLICT \(id\) DO UPDATE SET`).
+		WithArgs(true, "DB maintenance", "admin@example.com").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.SetPaused(context.Background(), true, "DB maintenance", "admin@example.com"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCrawlControlRepo_SkipSource(t *testing.T) {
+	repo, mock, closeFn := newCrawlControlRepo(t)
+	defer closeFn()
+
+	mock.ExpectExec(`INSERT INTO crawl_skipped_sources .*ON CONFLICT \(source_id\) DO NOTHING`).
+		WithArgs(int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	require.NoError(t, repo.SkipSource(context.Background(), 3))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCrawlControlRepo_UnskipSource(t *testing.T) {
+	repo, mock, closeFn := newCrawlControlRepo(t)
+	defer closeFn()
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM crawl_skipped_sources WHERE source_id = $1")).
+		WithArgs(int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM crawl_skipped_sources WHERE source_id = $1")).
+		WithArgs(int64(4)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	removed, err := repo.UnskipSource(context.Background(), 3)
+	require.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = repo.UnskipSource(context.Background(), 4)
+	require.NoError(t, err)
+	assert.False(t, removed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+const crawlRunColumns = "id, status, started_at, finished_at, sources, fetch_failed_sources, feed_items, inserted, duplicated, summarize_errors, source_stats, error"
+
+// CrawlRunRepo persists the crawl history (crawl_runs table).
+type CrawlRunRepo struct{ db *sql.DB }
+
+func NewCrawlRunRepo(db *sql.DB) repository.CrawlRunRepository {
+	return &CrawlRunRepo{db: db}
+}
+
+func scanCrawlRun(s scanner) (*entity.CrawlRun, error) {
+	var (
+		r           entity.CrawlRun
+		sourceStats []byte
+	)
+	if err := s.Scan(
+		&r.ID, &r.Status, &r.StartedAt, &r.FinishedAt,
+		&r.SourceCount, &r.FetchFailedSources, &r.FeedItems, &r.Inserted,
+		&r.Duplicated, &r.SummarizeErrors, &sourceStats, &r.Error,
+	); err != nil {
+		return nil, err
+	}
+	if len(sourceStats) > 0 {
+		if err := json.Unmarshal(sourceStats, &r.Sources); err != nil {
+			return nil, fmt.Errorf("decode source_stats: %w", err)
+		}
+	}
+	return &r, nil
+}
+
+// Start inserts the running row and sets run.ID.
+func (repo *CrawlRunRepo) Start(ctx context.Context, run *entity.CrawlRun) error {
+	const query = `
+INSERT INTO crawl_runs (status, started_at, sources)
+VALUES ($1, $2, $3)
+RETURNING id`
+	if err := repo.db.QueryRowContext(ctx, query,
+		entity.CrawlRunStatusRunning, run.StartedAt, run.SourceCount,
+	).Scan(&run.ID); err != nil {
+		return fmt.Errorf("Start: %w", err)
+	}
+	return nil
+}
+
+// Finish writes the outcome of the run.
+func (repo *CrawlRunRepo) Finish(ctx context.Context, run *entity.CrawlRun) error {
+	sources := run.Sources
+	if sources == nil {
+		sources = []entity.CrawlRunSource{}
+	}
+	sourceStats, err := json.Marshal(sources)
+	if err != nil {
+		return fmt.Errorf("Finish: encode source_stats: %w", err)
+	}
+	const query = `
+UPDATE crawl_runs
+SET status = $1, finished_at = $2, sources = $3, fetch_failed_sources = $4,
+    feed_items = $5, inserted = $6, duplicated = $7, summarize_errors = $8,
+    source_stats = $9, error = $10
+WHERE id = $11`
+	if _, err := repo.db.ExecContext(ctx, query,
+		run.Status, run.FinishedAt, run.SourceCount, run.FetchFailedSources,
+		run.FeedItems, run.Inserted, run.Duplicated, run.SummarizeErrors,
+		string(sourceStats), run.Error, run.ID,
+	); err != nil {
+		return fmt.Errorf("Finish: %w", err)
+	}
+	return nil
+}
+
+func (repo *CrawlRunRepo) Get(ctx context.Context, id int64) (*entity.CrawlRun, error) {
+	query := `
+SELECT ` + crawlRunColumns + `
+FROM crawl_runs
+WHERE id = $1`
+	r, err := scanCrawlRun(repo.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Get: %w", err)
+	}
+	return r, nil
+}
+
+// List returns runs newest first.
+func (repo *CrawlRunRepo) List(ctx context.Context, offset, limit int) ([]*entity.CrawlRun, error) {
+	query := `
+SELECT ` + crawlRunColumns + `
+FROM crawl_runs
+ORDER BY id DESC
+LIMIT $1 OFFSET $2`
+	rows, err := repo.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("List: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	runs := make([]*entity.CrawlRun, 0, limit)
+	for rows.Next() {
+		r, err := scanCrawlRun(rows)
+		if err != nil {
+			return nil, fmt.Errorf("List: %w", err)
+		}
+		runs = append(runs, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("List: %w", err)
+	}
+	return runs, nil
+}
+
+// Count returns the number of runs.
+func (repo *CrawlRunRepo) Count(ctx context.Context) (int64, error) {
+	var n int64
+	if err := repo.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM crawl_runs`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("Count: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,160 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+	"catchup-feed/internal/repository"
+)
+
+var crawlRunCols = []string{
+	"id", "status", "started_at", "finished_at", "sources", "fetch_failed_sources",
+	"feed_items", "inserted", "duplicated", "summarize_errors", "source_stats", "error",
+}
+
+func newCrawlRunRepo(t *testing.T) (repository.CrawlRunRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return pg.NewCrawlRunRepo(db), mock, func() { _ = db.Close() }
+}
+
+func TestCrawlRunRepo_Start(t *testing.T) {
+	repo, mock, closeFn := newCrawlRunRepo(t)
+	defer closeFn()
+
+	started := time.Date(2026, 10, 1, 5, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO crawl_runs (status, started_at, sources)")).
+		WithArgs("running", started, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(11)))
+
+	run := &entity.CrawlRun{StartedAt: started, SourceCount: 3}
+	require.NoError(t, repo.Start(context.Background(), run))
+	assert.Equal(t, int64(11), run.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCrawlRunRepo_Finish(t *testing.T) {
+	repo, mock, closeFn := newCrawlRunRepo(t)
+	defer closeFn()
+
+	finished := time.Date(2026, 10, 1, 5, 3, 0, 0, time.UTC)
+	errMsg := "summarize: all providers failed"
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE crawl_runs")).
+		WithArgs("failed", &finished, 2, 1, int64(5), int64(3), int64(2), int64(1),
+			`[{"source_id":1,"kind":"rss","http_status":200,"feed_items":5,"inserted":3,"duplicated":2,"summarize_errors":1,"duration_ms":1200},`+
+				`{"source_id":2,"kind":"rss","http_status":404,"fetch_error":"feed returned 404","feed_items":0,"inserted":0,"duplicated":0,"summarize_errors":0,"duration_ms":80}]`,
+			&errMsg, int64(11)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.Finish(context.Background(), &entity.CrawlRun{
+		ID: 11, Status: entity.CrawlRunStatusFailed, FinishedAt: &finished,
+		SourceCount: 2, FetchFailedSources: 1, FeedItems: 5, Inserted: 3, Duplicated: 2, SummarizeErrors: 1,
+		Sources: []entity.CrawlRunSource{
+			{SourceID: 1, Kind: "rss", HTTPStatus: 200, FeedItems: 5, Inserted: 3, Duplicated: 2, SummarizeErrors: 1, DurationMS: 1200},
+			{SourceID: 2, Kind: "rss", HTTPStatus: 404, FetchError: "feed returned 404", DurationMS: 80},
+		},
+		Error: &errMsg,
+	})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCrawlRunRepo_Finish_NoSourcesStoresEmptyArray(t *testing.T) {
+	repo, mock, closeFn := newCrawlRunRepo(t)
+	defer closeFn()
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE crawl_runs")).
+		WithArgs("succeeded", sqlmock.AnyArg(), 0, 0, int64(0), int64(0), int64(0), int64(0), "[]", nil, int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	finished := time.Now()
+	require.NoError(t, repo.Finish(context.Background(), &entity.CrawlRun{
+		ID: 3, Status: entity.CrawlRunStatusSucceeded, FinishedAt: &finished,
+	}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCrawlRunRepo_Get(t *testing.T) {
+	started := time.Date(2026, 10, 1, 5, 0, 0, 0, time.UTC)
+	finished := started.Add(3 * time.Minute)
+
+	t.Run("found", func(t *testing.T) {
+		repo, mock, closeFn := newCrawlRunRepo(t)
+		defer closeFn()
+
+		mock.ExpectQuery(regexp.QuoteMeta("FROM crawl_runs")).
+			WithArgs(int64(11)).
+			WillReturnRows(sqlmock.NewRows(crawlRunCols).AddRow(
+				int64(11), "succeeded", started, finished, 1, 0, int64(5), int64(3), int64(2), int64(0),
+				[]byte(`[{"source_id":1,"kind":"rss","http_status":304,"not_modified":true,"duration_ms":90}]`), nil,
+			))
+
+		got, err := repo.Get(context.Background(), 11)
+		require.NoError(t, err)
+		assert.Equal(t, &entity.CrawlRun{
+			ID: 11, Status: "succeeded", StartedAt: started, FinishedAt: &finished,
+			SourceCount: 1, FeedItems: 5, Inserted: 3, Duplicated: 2,
+			Sources: []entity.CrawlRunSource{{SourceID: 1, Kind: "rss", HTTPStatus: 304, NotModified: true, DurationMS: 90}},
+		}, got)
+	})
+
+	t.Run("not found returns nil, nil", func(t *testing.T) {
+		repo, mock, closeFn := newCrawlRunRepo(t)
+		defer closeFn()
+
+		mock.ExpectQuery(regexp.QuoteMeta("FROM crawl_runs")).
+			WillReturnRows(sqlmock.NewRows(crawlRunCols))
+
+		got, err := repo.Get(context.Background(), 99)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		repo, mock, closeFn := newCrawlRunRepo(t)
+		defer closeFn()
+
+		mock.ExpectQuery(regexp.QuoteMeta("FROM crawl_runs")).
+			WillReturnError(errors.New("db down"))
+
+		_, err := repo.Get(context.Background(), 1)
+		assert.Error(t, err)
+	})
+}
+
+func TestCrawlRunRepo_ListAndCount(t *testing.T) {
+	repo, mock, closeFn := newCrawlRunRepo(t)
+	defer closeFn()
+
+	started := time.Date(2026, 10, 1, 5, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("ORDER BY id DESC\nLIMIT $1 OFFSET $2")).
+		WithArgs(20, 40).
+		WillReturnRows(sqlmock.NewRows(crawlRunCols).
+			AddRow(int64(2), "running", started, nil, 4, 0, int64(0), int64(0), int64(0), int64(0), []byte(`[]`), nil).
+			AddRow(int64(1), "failed", started, started, 4, 4, int64(0), int64(0), int64(0), int64(0), []byte(`[]`), "db down"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM crawl_runs")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(42)))
+
+	runs, err := repo.List(context.Background(), 40, 20)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, int64(2), runs[0].ID)
+	assert.Nil(t, runs[0].FinishedAt)
+	require.NotNil(t, runs[1].Error)
+	assert.Equal(t, "db down", *runs[1].Error)
+
+	n, err := repo.Count(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
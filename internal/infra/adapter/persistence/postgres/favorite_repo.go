@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"catchup-feed/internal/repository"
+)
+
+// FavoriteRepo persists per-user favorites (article_favorites table).
+type FavoriteRepo struct{ db *sql.DB }
+
+func NewFavoriteRepo(db *sql.DB) repository.FavoriteRepository {
+	return &FavoriteRepo{db: db}
+}
+
+// Add inserts the favorite. Selecting from articles turns an unknown
+// article into "no row" instead of a foreign key error; an existing
+// favorite keeps its created_at.
+func (repo *FavoriteRepo) Add(ctx context.Context, userID, articleID int64) (bool, error) {
+	const query = `
+WITH ins AS (
+    INSERT INTO article_favorites (user_id, article_id)
+    SELECT $1, a.id FROM articles a WHERE a.id = $2
+    ON CONFLICT (user_id, article_id) DO NOTHING
+)
+SELECT EXISTS (SELECT 1 FROM articles WHERE id = $2)`
+	var exists bool
+	if err := repo.db.QueryRowContext(ctx, query, userID, articleID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("Add: %w", err)
+	}
+	return exists, nil
+}
+
+// Remove deletes the favorite if any.
+func (repo *FavoriteRepo) Remove(ctx context.Context, userID, articleID int64) error {
+	const query = `DELETE FROM article_favorites WHERE user_id = $1 AND article_id = $2`
+	if _, err := repo.db.ExecContext(ctx, query, userID, articleID); err != nil {
+		return fmt.Errorf("Remove: %w", err)
+	}
+	return nil
+}
+
+// FavoritedIDs looks up the user's favorites among articleIDs.
+func (repo *FavoriteRepo) FavoritedIDs(ctx context.Context, userID int64, articleIDs []int64) (map[int64]bool, error) {
+	favorited := make(map[int64]bool)
+	if len(articleIDs) == 0 {
+		return favorited, nil
+	}
+	in, idArgs := idPlaceholders(articleIDs, 2)
+	// #nosec G201 -- in contains only generated $N placeholders.
+	query := fmt.Sprintf(`SELECT article_id FROM article_favorites WHERE user_id = $1 AND article_id IN (%s)`, in)
+	rows, err := repo.db.QueryContext(ctx, query, append([]any{userID}, idArgs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("FavoritedIDs: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("FavoritedIDs: %w", err)
+		}
+		favorited[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("FavoritedIDs: %w", err)
+	}
+	return favorited, nil
+}
@@ -0,0 +1,73 @@
+package postgres_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+	"catchup-feed/internal/repository"
+)
+
+func newFavoriteRepo(t *testing.T) (repository.FavoriteRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return pg.NewFavoriteRepo(db), mock, func() { _ = db.Close() }
+}
+
+func TestFavoriteRepo_Add(t *testing.T) {
+	repo, mock, closeFn := newFavoriteRepo(t)
+	defer closeFn()
+
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO article_favorites")).
+		WithArgs(int64(1), int64(10)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO article_favorites")).
+		WithArgs(int64(1), int64(99)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	ok, err := repo.Add(context.Background(), 1, 10)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = repo.Add(context.Background(), 1, 99)
+	require.NoError(t, err)
+	assert.False(t, ok, "unknown article")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFavoriteRepo_Remove(t *testing.T) {
+	repo, mock, closeFn := newFavoriteRepo(t)
+	defer closeFn()
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM article_favorites WHERE user_id = $1 AND article_id = $2")).
+		WithArgs(int64(1), int64(10)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	require.NoError(t, repo.Remove(context.Background(), 1, 10))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFavoriteRepo_FavoritedIDs(t *testing.T) {
+	repo, mock, closeFn := newFavoriteRepo(t)
+	defer closeFn()
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE user_id = $1 AND article_id IN ($2, $3, $4)")).
+		WithArgs(int64(1), int64(10), int64(11), int64(12)).
+		WillReturnRows(sqlmock.NewRows([]string{"article_id"}).AddRow(int64(11)))
+
+	got, err := repo.FavoritedIDs(context.Background(), 1, []int64{10, 11, 12})
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]bool{11: true}, got)
+
+	// 空リストは DB に行かない。
+	got, err = repo.FavoritedIDs(context.Background(), 1, nil)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -35,15 +35,45 @@ INSERT INTO jobs (kind, payload, run_after)
 VALUES ($1, $2, $3)
 RETURNING id`
 	var id int64
-	err := repo.db.QueryRowContext(ctx, query, kind, []byte(payload), runAfter).Scan(&id)
+	err := conn(ctx, repo.db).QueryRowContext(ctx, query, kind, []byte(payload), runAfter).Scan(&id)
 	if err != nil {
 		return 0, fmt.Errorf("Enqueue: %w", err)
 	}
 	return id, nil
 }
 
+// EnqueueUnique inserts a pending job unless a job holds the same
+// dedupe_key, whatever its status: the scheduled keys carry their tick, so
+// a tick one replica has already run must not run again on another. The
+// NOT EXISTS sees finished jobs (idx_jobs_dedupe_key_lookup); the partial
+// unique index idx_jobs_dedupe_key makes concurrent INSERTs atomic across
+// replicas: the losing one hits ON CONFLICT DO NOTHING and returns no row.
+func (repo *JobRepo) EnqueueUnique(ctx context.Context, kind, dedupeKey string, payload json.RawMessage, runAfter time.Time) (int64, bool, error) {
+	if len(payload) == 0 {
+		payload = json.RawMessage(`{}`)
+	}
+	if runAfter.IsZero() {
+		runAfter = time.Now()
+	}
+	const query = `
+INSERT INTO jobs (kind, payload, run_after, dedupe_key)
+SELECT $1::text, $2::jsonb, $3::timestamptz, $4::text
+WHERE NOT EXISTS (SELECT 1 FROM jobs WHERE dedupe_key = $4)
+ON CONFLICT DO NOTHING
+RETURNING id`
+	var id int64
+	err := repo.db.QueryRowContext(ctx, query, kind, []byte(payload), runAfter, dedupeKey).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("EnqueueUnique: %w", err)
+	}
+	return id, true, nil
+}
+
 // ClaimNext atomically claims the oldest runnable pending job: it marks the
-// row running and increments attempts. FOR UPDATE SKIP LOCKED keeps
+// row running, stamps claimed_at and increments attempts. FOR UPDATE SKIP LOCKED keeps
 // concurrent consumers from double-claiming. Returns nil when nothing is
 // runnable.
 func (repo *JobRepo) ClaimNext(ctx context.Context, kinds ...string) (*entity.Job, error) {
@@ -63,8 +93,9 @@ func (repo *JobRepo) ClaimNext(ctx context.Context, kinds ...string) (*entity.Jo
 	// #nosec G201 -- kindFilter contains only generated placeholders ($1, $2, ...).
 	query := fmt.Sprintf(`
 UPDATE jobs SET
-       status   = 'running',
-       attempts = attempts + 1
+       status     = 'running',
+       attempts   = attempts + 1,
+       claimed_at = now()
 WHERE id = (
     SELECT id FROM jobs
     WHERE status = 'pending' AND run_after <= now()%s
@@ -92,6 +123,79 @@ RETURNING id, kind, payload, status, attempts, last_error, run_after, created_at
 	return &job, nil
 }
 
+// Get returns a job by ID, or nil when it does not exist.
+func (repo *JobRepo) Get(ctx context.Context, id int64) (*entity.Job, error) {
+	const query = `
+SELECT id, kind, payload, status, attempts, last_error, run_after, created_at
+FROM jobs
+WHERE id = $1`
+	var (
+		job     entity.Job
+		payload []byte
+	)
+	err := repo.db.QueryRowContext(ctx, query, id).Scan(
+		&job.ID, &job.Kind, &payload, &job.Status, &job.Attempts,
+		&job.LastError, &job.RunAfter, &job.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Get: %w", err)
+	}
+	job.Payload = json.RawMessage(payload)
+	return &job, nil
+}
+
+// ListActive returns the pending and running jobs of the given kinds (all
+// kinds when none are given), oldest first.
+func (repo *JobRepo) ListActive(ctx context.Context, kinds ...string) ([]*entity.Job, error) {
+	var (
+		kindFilter string
+		args       []any
+	)
+	if len(kinds) > 0 {
+		placeholders := make([]string, len(kinds))
+		for i, kind := range kinds {
+			placeholders[i] = fmt.Sprintf("$%d", i+1)
+			args = append(args, kind)
+		}
+		kindFilter = fmt.Sprintf(" AND kind IN (%s)", strings.Join(placeholders, ", "))
+	}
+
+	// #nosec G201 -- kindFilter contains only generated placeholders ($1, $2, ...).
+	query := fmt.Sprintf(`
+SELECT id, kind, payload, status, attempts, last_error, run_after, created_at
+FROM jobs
+WHERE status IN ('pending', 'running')%s
+ORDER BY created_at ASC, id ASC`, kindFilter)
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ListActive: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	jobs := make([]*entity.Job, 0)
+	for rows.Next() {
+		var (
+			job     entity.Job
+			payload []byte
+		)
+		if err := rows.Scan(
+			&job.ID, &job.Kind, &payload, &job.Status, &job.Attempts,
+			&job.LastError, &job.RunAfter, &job.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("ListActive: %w", err)
+		}
+		job.Payload = json.RawMessage(payload)
+		jobs = append(jobs, &job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ListActive: %w", err)
+	}
+	return jobs, nil
+}
+
 // MarkDone finishes a claimed job successfully.
 func (repo *JobRepo) MarkDone(ctx context.Context, id int64) error {
 	const query = `UPDATE jobs SET status = 'done' WHERE id = $1`
@@ -105,28 +209,31 @@ func (repo *JobRepo) MarkDone(ctx context.Context, id int64) error {
 	return nil
 }
 
-// RequeueRunning flips running jobs of the given kinds back to pending
-// (stale-job sweep, see repository.JobRepository). The kind restriction is
-// load-bearing: other consumers' running jobs (e.g. the Mac transcribe
-// worker's) are mid-execution, not orphans, and must not be requeued. No
-// kinds sweeps nothing. last_error records the sweep so the dashboard of a
+// RequeueRunning flips running jobs of the given kinds claimed before
+// claimedBefore back to pending (stale-job sweep, see
+// repository.JobRepository). The kind restriction is load-bearing: other
+// consumers' running jobs (e.g. the Mac transcribe worker's) are
+// mid-execution, not orphans, and must not be requeued; the cutoff does the
+// same for this consumer's own kinds running on another replica. No kinds
+// sweeps nothing. last_error records the sweep so the dashboard of a
 // crash-looping job tells the story.
-func (repo *JobRepo) RequeueRunning(ctx context.Context, kinds ...string) (int64, error) {
+func (repo *JobRepo) RequeueRunning(ctx context.Context, claimedBefore time.Time, kinds ...string) (int64, error) {
 	if len(kinds) == 0 {
 		return 0, nil
 	}
 	placeholders := make([]string, len(kinds))
-	args := make([]any, len(kinds))
+	args := make([]any, 0, len(kinds)+1)
+	args = append(args, claimedBefore)
 	for i, kind := range kinds {
-		placeholders[i] = fmt.Sprintf("$%d", i+1)
-		args[i] = kind
+		placeholders[i] = fmt.Sprintf("$%d", i+2)
+		args = append(args, kind)
 	}
-	// #nosec G201 -- the interpolated fragment contains only generated placeholders ($1, $2, ...).
+	// #nosec G201 -- the interpolated fragment contains only generated placeholders ($2, $3, ...).
 	query := fmt.Sprintf(`
 UPDATE jobs SET
        status     = 'pending',
        last_error = 'requeued: claimed by a worker that did not finish (stale running sweep)'
-WHERE status = 'running' AND kind IN (%s)`, strings.Join(placeholders, ", "))
+WHERE status = 'running' AND (claimed_at IS NULL OR claimed_at < $1) AND kind IN (%s)`, strings.Join(placeholders, ", "))
 	res, err := repo.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return 0, fmt.Errorf("RequeueRunning: %w", err)
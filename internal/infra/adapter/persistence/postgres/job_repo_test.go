@@ -71,6 +71,51 @@ func TestJobRepo_Enqueue(t *testing.T) {
 	}
 }
 
+/* ─────────────────────────── EnqueueUnique ─────────────────────────── */
+
+func TestJobRepo_EnqueueUnique(t *testing.T) {
+	t.Run("inserts when no job holds the key", func(t *testing.T) {
+		repo, mock, closeFn := newJobRepo(t)
+		defer closeFn()
+
+		mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO jobs (kind, payload, run_after, dedupe_key)")).
+			WithArgs(entity.JobKindCrawl, []byte(`{"source_id":3}`), sqlmock.AnyArg(), "crawl:source:3").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(9)))
+
+		id, enqueued, err := repo.EnqueueUnique(context.Background(), entity.JobKindCrawl, "crawl:source:3",
+			json.RawMessage(`{"source_id":3}`), time.Time{})
+		require.NoError(t, err)
+		assert.True(t, enqueued)
+		assert.Equal(t, int64(9), id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("held key inserts nothing", func(t *testing.T) {
+		repo, mock, closeFn := newJobRepo(t)
+		defer closeFn()
+
+		// Held by a finished job (NOT EXISTS) or a live one (ON CONFLICT).
+		mock.ExpectQuery(regexp.QuoteMeta("WHERE NOT EXISTS (SELECT 1 FROM jobs WHERE dedupe_key = $4)\nON CONFLICT DO NOTHING")).
+			WithArgs(entity.JobKindResummarize, []byte(`{}`), sqlmock.AnyArg(), entity.JobKindResummarize).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		id, enqueued, err := repo.EnqueueUnique(context.Background(), entity.JobKindResummarize, entity.JobKindResummarize, nil, time.Time{})
+		require.NoError(t, err)
+		assert.False(t, enqueued)
+		assert.Zero(t, id)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		repo, mock, closeFn := newJobRepo(t)
+		defer closeFn()
+
+		mock.ExpectQuery("INSERT INTO jobs").WillReturnError(errors.New("connection refused"))
+
+		_, _, err := repo.EnqueueUnique(context.Background(), entity.JobKindCrawl, "crawl:default", nil, time.Time{})
+		assert.ErrorContains(t, err, "EnqueueUnique")
+	})
+}
+
 /* ─────────────────────────── ClaimNext ─────────────────────────── */
 
 func TestJobRepo_ClaimNext(t *testing.T) {
@@ -104,6 +149,14 @@ func TestJobRepo_ClaimNext(t *testing.T) {
 			wantQuery: `kind IN ($1, $2)`,
 			wantArgs:  []any{entity.JobKindNotifyEpisode, entity.JobKindRegenerateFeed},
 		},
+		{
+			name: "stamps claimed_at for the stale sweep",
+			rows: sqlmock.NewRows(jobCols).AddRow(
+				int64(5), entity.JobKindCrawl, []byte(`{}`),
+				entity.JobStatusRunning, 1, nil, now, now,
+			),
+			wantQuery: "claimed_at = now()",
+		},
 		{
 			name:      "no runnable job returns nil, nil",
 			rows:      sqlmock.NewRows(jobCols),
@@ -155,6 +208,66 @@ func TestJobRepo_ClaimNext(t *testing.T) {
 	}
 }
 
+/* ─────────────────────────── Get ─────────────────────────── */
+
+func TestJobRepo_Get(t *testing.T) {
+	created := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	cols := []string{"id", "kind", "payload", "status", "attempts", "last_error", "run_after", "created_at"}
+
+	t.Run("found", func(t *testing.T) {
+		repo, mock, closeFn := newJobRepo(t)
+		defer closeFn()
+		lastErr := "boom"
+		mock.ExpectQuery(regexp.QuoteMeta("FROM jobs\nWHERE id = $1")).
+			WithArgs(int64(4)).
+			WillReturnRows(sqlmock.NewRows(cols).
+				AddRow(int64(4), "crawl", []byte(`{"source_id":2}`), "failed", 3, lastErr, created, created))
+
+		job, err := repo.Get(context.Background(), 4)
+		require.NoError(t, err)
+		require.NotNil(t, job)
+		assert.Equal(t, "crawl", job.Kind)
+		assert.Equal(t, entity.JobStatusFailed, job.Status)
+		assert.JSONEq(t, `{"source_id":2}`, string(job.Payload))
+		require.NotNil(t, job.LastError)
+		assert.Equal(t, lastErr, *job.LastError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("missing returns nil", func(t *testing.T) {
+		repo, mock, closeFn := newJobRepo(t)
+		defer closeFn()
+		mock.ExpectQuery("FROM jobs").WillReturnRows(sqlmock.NewRows(cols))
+
+		job, err := repo.Get(context.Background(), 99)
+		require.NoError(t, err)
+		assert.Nil(t, job)
+	})
+}
+
+/* ─────────────────────────── ListActive ─────────────────────────── */
+
+func TestJobRepo_ListActive(t *testing.T) {
+	repo, mock, closeFn := newJobRepo(t)
+	defer closeFn()
+	created := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	cols := []string{"id", "kind", "payload", "status", "attempts", "last_error", "run_after", "created_at"}
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE status IN ('pending', 'running') AND kind IN ($1, $2)")).
+		WithArgs("crawl", "resummarize").
+		WillReturnRows(sqlmock.NewRows(cols).
+			AddRow(int64(4), "crawl", []byte(`{"default_schedule":true}`), "running", 1, nil, created, created).
+			AddRow(int64(5), "resummarize", []byte(`{}`), "pending", 0, nil, created, created))
+
+	jobs, err := repo.ListActive(context.Background(), "crawl", "resummarize")
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, entity.JobStatusRunning, jobs[0].Status)
+	assert.JSONEq(t, `{"default_schedule":true}`, string(jobs[0].Payload))
+	assert.Equal(t, "resummarize", jobs[1].Kind)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 /* ─────────────────────────── MarkDone / MarkFailed ─────────────────────────── */
 
 func TestJobRepo_MarkDone(t *testing.T) {
@@ -219,6 +332,7 @@ func TestJobRepo_MarkFailed(t *testing.T) {
 /* ─────────────────────── RequeueRunning ─────────────────────── */
 
 func TestJobRepo_RequeueRunning(t *testing.T) {
+	cutoff := time.Date(2026, 7, 4, 4, 0, 0, 0, time.UTC)
 	tests := []struct {
 		name      string
 		kinds     []string
@@ -230,15 +344,15 @@ func TestJobRepo_RequeueRunning(t *testing.T) {
 			name:      "requeues orphaned running jobs of own kinds only",
 			kinds:     []string{entity.JobKindNotifyEpisode, entity.JobKindRegenerateFeed},
 			rows:      2,
-			wantQuery: `WHERE status = 'running' AND kind IN ($1, $2)`,
-			wantArgs:  []driverValue{entity.JobKindNotifyEpisode, entity.JobKindRegenerateFeed},
+			wantQuery: `WHERE status = 'running' AND (claimed_at IS NULL OR claimed_at < $1) AND kind IN ($2, $3)`,
+			wantArgs:  []driverValue{cutoff, entity.JobKindNotifyEpisode, entity.JobKindRegenerateFeed},
 		},
 		{
 			name:      "no matching running jobs is a no-op",
 			kinds:     []string{entity.JobKindCleanupOldMedia},
 			rows:      0,
-			wantQuery: `WHERE status = 'running' AND kind IN ($1)`,
-			wantArgs:  []driverValue{entity.JobKindCleanupOldMedia},
+			wantQuery: `WHERE status = 'running' AND (claimed_at IS NULL OR claimed_at < $1) AND kind IN ($2)`,
+			wantArgs:  []driverValue{cutoff, entity.JobKindCleanupOldMedia},
 		},
 	}
 	for _, tt := range tests {
@@ -250,7 +364,7 @@ func TestJobRepo_RequeueRunning(t *testing.T) {
 				WithArgs(tt.wantArgs...).
 				WillReturnResult(sqlmock.NewResult(0, tt.rows))
 
-			n, err := repo.RequeueRunning(context.Background(), tt.kinds...)
+			n, err := repo.RequeueRunning(context.Background(), cutoff, tt.kinds...)
 			require.NoError(t, err)
 			assert.Equal(t, tt.rows, n)
 			assert.NoError(t, mock.ExpectationsWereMet())
@@ -265,7 +379,7 @@ func TestJobRepo_RequeueRunning_NoKinds(t *testing.T) {
 	repo, mock, closeFn := newJobRepo(t)
 	defer closeFn()
 
-	n, err := repo.RequeueRunning(context.Background())
+	n, err := repo.RequeueRunning(context.Background(), time.Now())
 	require.NoError(t, err)
 	assert.Zero(t, n)
 	assert.NoError(t, mock.ExpectationsWereMet(), "no query expected for an empty kind set")
@@ -275,10 +389,10 @@ func TestJobRepo_RequeueRunning_Error(t *testing.T) {
 	repo, mock, closeFn := newJobRepo(t)
 	defer closeFn()
 
-	mock.ExpectExec(regexp.QuoteMeta("WHERE status = 'running' AND kind IN ($1)")).
-		WithArgs(entity.JobKindRegenerateFeed).
+	mock.ExpectExec(regexp.QuoteMeta("kind IN ($2)")).
+		WithArgs(sqlmock.AnyArg(), entity.JobKindRegenerateFeed).
 		WillReturnError(errors.New("connection refused"))
 
-	_, err := repo.RequeueRunning(context.Background(), entity.JobKindRegenerateFeed)
+	_, err := repo.RequeueRunning(context.Background(), time.Now(), entity.JobKindRegenerateFeed)
 	assert.ErrorContains(t, err, "RequeueRunning")
 }
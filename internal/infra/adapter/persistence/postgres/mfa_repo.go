@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// MFARepo persists TOTP enrollments (user_mfa table).
+type MFARepo struct{ db *sql.DB }
+
+func NewMFARepo(db *sql.DB) repository.MFARepository {
+	return &MFARepo{db: db}
+}
+
+// Get returns the user's enrollment, or nil when there is none.
+func (repo *MFARepo) Get(ctx context.Context, userID int64) (*entity.UserMFA, error) {
+	const query = `
+SELECT user_id, secret, enabled_at, last_step, created_at
+FROM user_mfa
+WHERE user_id = $1`
+	var m entity.UserMFA
+	err := repo.db.QueryRowContext(ctx, query, userID).Scan(
+		&m.UserID, &m.Secret, &m.EnabledAt, &m.LastStep, &m.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Get: %w", err)
+	}
+	return &m, nil
+}
+
+// SavePending upserts a pending enrollment. The WHERE clause keeps an
+// enabled enrollment from being overwritten, even by a concurrent request.
+func (repo *MFARepo) SavePending(ctx context.Context, userID int64, secret string) (bool, error) {
+	const query = `
+INSERT INTO user_mfa (user_id, secret)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret, last_step = 0, created_at = now()
+WHERE user_mfa.enabled_at IS NULL`
+	res, err := repo.db.ExecContext(ctx, query, userID, secret)
+	if err != nil {
+		return false, fmt.Errorf("SavePending: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("SavePending: %w", err)
+	}
+	return n > 0, nil
+}
+
+// Enable activates the pending enrollment.
+func (repo *MFARepo) Enable(ctx context.Context, userID int64, step int64, t time.Time) error {
+	const query = `
+UPDATE user_mfa
+SET enabled_at = $3, last_step = $2
+WHERE user_id = $1 AND enabled_at IS NULL`
+	if _, err := repo.db.ExecContext(ctx, query, userID, step, t); err != nil {
+		return fmt.Errorf("Enable: %w", err)
+	}
+	return nil
+}
+
+// UseStep advances last_step atomically, so two requests racing with the
+// same code cannot both succeed.
+func (repo *MFARepo) UseStep(ctx context.Context, userID int64, step int64) (bool, error) {
+	res, err := repo.db.ExecContext(ctx,
+		`UPDATE user_mfa SET last_step = $2 WHERE user_id = $1 AND last_step < $2`, userID, step)
+	if err != nil {
+		return false, fmt.Errorf("UseStep: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("UseStep: %w", err)
+	}
+	return n > 0, nil
+}
+
+// Delete removes the enrollment.
+func (repo *MFARepo) Delete(ctx context.Context, userID int64) error {
+	if _, err := repo.db.ExecContext(ctx, `DELETE FROM user_mfa WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("Delete: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+	"catchup-feed/internal/repository"
+)
+
+func newMFARepo(t *testing.T) (repository.MFARepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return pg.NewMFARepo(db), mock, func() { _ = db.Close() }
+}
+
+func TestMFARepo_Get(t *testing.T) {
+	repo, mock, closeFn := newMFARepo(t)
+	defer closeFn()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("FROM user_mfa")).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "secret", "enabled_at", "last_step", "created_at"}).
+			AddRow(int64(1), "JBSWY3DPEHPK3PXP", now, int64(42), now))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM user_mfa")).
+		WithArgs(int64(2)).
+		WillReturnError(sql.ErrNoRows)
+
+	got, err := repo.Get(context.Background(), 1)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.True(t, got.IsEnabled())
+	assert.Equal(t, int64(42), got.LastStep)
+
+	got, err = repo.Get(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMFARepo_SavePending(t *testing.T) {
+	repo, mock, closeFn := newMFARepo(t)
+	defer closeFn()
+
+	mock.ExpectExec(regexp.QuoteMeta("WHERE user_mfa.enabled_at IS NULL")).
+		WithArgs(int64(1), "SECRET").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// 有効化済みの登録は上書きしない。
+	mock.ExpectExec(regexp.QuoteMeta("WHERE user_mfa.enabled_at IS NULL")).
+		WithArgs(int64(1), "OTHER").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	saved, err := repo.SavePending(context.Background(), 1, "SECRET")
+	require.NoError(t, err)
+	assert.True(t, saved)
+	saved, err = repo.SavePending(context.Background(), 1, "OTHER")
+	require.NoError(t, err)
+	assert.False(t, saved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMFARepo_UseStep(t *testing.T) {
+	repo, mock, closeFn := newMFARepo(t)
+	defer closeFn()
+
+	q := regexp.QuoteMeta("UPDATE user_mfa SET last_step = $2 WHERE user_id = $1 AND last_step < $2")
+	mock.ExpectExec(q).WithArgs(int64(1), int64(100)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(q).WithArgs(int64(1), int64(100)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	ok, err := repo.UseStep(context.Background(), 1, 100)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = repo.UseStep(context.Background(), 1, 100)
+	require.NoError(t, err)
+	assert.False(t, ok, "replayed step")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
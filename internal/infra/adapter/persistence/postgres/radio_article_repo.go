@@ -26,6 +26,9 @@ func NewRadioArticleRepo(db *sql.DB) repository.RadioArticleRepository {
 // published_at is the previous run's selection timestamp, so consecutive
 // windows may overlap) and keeps manual -since re-runs from double-airing
 // old articles (§6-6 冪等性).
+//
+// Near-duplicates (duplicate_of set: the same story from another source)
+// are left out so a story airs once, under its canonical article.
 func (repo *RadioArticleRepo) ListSummarizedSince(ctx context.Context, since time.Time, limit int) ([]repository.RadioArticle, error) {
 	const query = `
 SELECT a.id, a.title, a.url, s.category, s.name, sm.body,
@@ -34,6 +37,7 @@ FROM articles a
 JOIN summaries sm ON sm.article_id = a.id
 JOIN sources s ON s.id = a.source_id
 WHERE sm.created_at > $1
+  AND a.duplicate_of IS NULL
   AND NOT EXISTS (SELECT 1 FROM segments sg WHERE sg.article_id = a.id)
 ORDER BY COALESCE(a.published_at, a.crawled_at) ASC, a.id ASC
 LIMIT $2`
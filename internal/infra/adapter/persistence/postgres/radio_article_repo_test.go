@@ -76,6 +76,22 @@ func TestRadioArticleRepo_ListSummarizedSince_ExcludesBroadcastArticles(t *testi
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+// TestRadioArticleRepo_ListSummarizedSince_ExcludesNearDuplicates: the
+// copies of a story from other sources are not aired again.
+func TestRadioArticleRepo_ListSummarizedSince_ExcludesNearDuplicates(t *testing.T) {
+	repo, mock, closeFn := newRadioArticleRepo(t)
+	defer closeFn()
+
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("AND a.duplicate_of IS NULL")).
+		WithArgs(since, 50).
+		WillReturnRows(sqlmock.NewRows(radioArticleCols))
+
+	_, err := repo.ListSummarizedSince(context.Background(), since, 50)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestRadioArticleRepo_ListSummarizedSince_Empty(t *testing.T) {
 	repo, mock, closeFn := newRadioArticleRepo(t)
 	defer closeFn()
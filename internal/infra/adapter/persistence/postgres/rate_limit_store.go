@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// RateLimitStore keeps rate limiter windows in the rate_limit_hits table so
+// that every server instance pointed at the same database shares one budget
+// per client (RATE_LIMIT_STORE=postgres). It implements
+// middleware.RateLimitStore.
+//
+// Timestamps come from the database clock (now()), so instances with
+// skewed clocks still agree on the window.
+type RateLimitStore struct{ db *sql.DB }
+
+func NewRateLimitStore(db *sql.DB) *RateLimitStore {
+	return &RateLimitStore{db: db}
+}
+
+// Allow counts key's hits inside the sliding window and records a new one
+// when below limit. A transaction-scoped advisory lock on the key
+// serializes concurrent requests from the same client, so two instances
+// cannot both admit the limit-th request. A key already at its limit is
+// denied by a plain count first, without the lock, so a flood costs one
+// read and one counter upsert per request.
+func (s *RateLimitStore) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, error) {
+	count, err := s.Count(ctx, key, window)
+	if err != nil {
+		return false, fmt.Errorf("Allow: %w", err)
+	}
+	if count >= limit {
+		return false, s.recordDenial(ctx, key, window)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("Allow: begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, key); err != nil {
+		return false, fmt.Errorf("Allow: lock: %w", err)
+	}
+	secs := window.Seconds()
+	if err := tx.QueryRowContext(ctx, rateLimitCountQuery, key, secs).Scan(&count); err != nil {
+		return false, fmt.Errorf("Allow: count: %w", err)
+	}
+	if count >= limit {
+		// another request took the last slot meanwhile
+		if err := tx.Commit(); err != nil {
+			return false, fmt.Errorf("Allow: commit: %w", err)
+		}
+		return false, s.recordDenial(ctx, key, window)
+	}
+
+	const insertQuery = `
+INSERT INTO rate_limit_hits (key, expires_at)
+VALUES ($1, now() + make_interval(secs => $2))`
+	if _, err := tx.ExecContext(ctx, insertQuery, key, secs); err != nil {
+		return false, fmt.Errorf("Allow: insert: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("Allow: commit: %w", err)
+	}
+	return true, nil
+}
+
+// recordDenial adds one to key's denial counter of the current minute
+// (rate_limit_denials), which TopDenied sums.
+func (s *RateLimitStore) recordDenial(ctx context.Context, key string, window time.Duration) error {
+	const query = `
+INSERT INTO rate_limit_denials (key, bucket, denied, expires_at)
+VALUES ($1, date_trunc('minute', now()), 1, now() + make_interval(secs => $2))
+ON CONFLICT (key, bucket) DO UPDATE
+SET denied = rate_limit_denials.denied + 1, expires_at = EXCLUDED.expires_at`
+	if _, err := s.db.ExecContext(ctx, query, key, window.Seconds()); err != nil {
+		return fmt.Errorf("Allow: record denial: %w", err)
+	}
+	return nil
+}
+
+const rateLimitCountQuery = `
+SELECT count(*) FROM rate_limit_hits
+WHERE key = $1 AND hit_at > now() - make_interval(secs => $2)`
+
+// Count returns key's allowed hits inside the window.
+func (s *RateLimitStore) Count(ctx context.Context, key string, window time.Duration) (int, error) {
+	var count int
+	if err := s.db.QueryRowContext(ctx, rateLimitCountQuery, key, window.Seconds()).Scan(&count); err != nil {
+		return 0, fmt.Errorf("Count: %w", err)
+	}
+	return count, nil
+}
+
+// Reset deletes every hit and denial count of key.
+func (s *RateLimitStore) Reset(ctx context.Context, key string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM rate_limit_hits WHERE key = $1`, key); err != nil {
+		return fmt.Errorf("Reset: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM rate_limit_denials WHERE key = $1`, key); err != nil {
+		return fmt.Errorf("Reset: %w", err)
+	}
+	return nil
+}
+
+// TopDenied ranks keys under prefix by denials inside the window. The
+// counters are per minute, so the window is rounded down to the minute.
+// prefix is matched with starts_with rather than LIKE so that "%" or "_"
+// in a route pattern scope is taken literally.
+func (s *RateLimitStore) TopDenied(ctx context.Context, prefix string, window time.Duration, n int) ([]entity.RateLimitDenial, error) {
+	const query = `
+SELECT key, sum(denied) AS denied FROM rate_limit_denials
+WHERE starts_with(key, $1) AND bucket >= date_trunc('minute', now() - make_interval(secs => $2))
+GROUP BY key
+ORDER BY denied DESC, key
+LIMIT $3`
+	rows, err := s.db.QueryContext(ctx, query, prefix, window.Seconds(), n)
+	if err != nil {
+		return nil, fmt.Errorf("TopDenied: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	top := make([]entity.RateLimitDenial, 0)
+	for rows.Next() {
+		var k entity.RateLimitDenial
+		if err := rows.Scan(&k.Key, &k.Denied); err != nil {
+			return nil, fmt.Errorf("TopDenied: scan: %w", err)
+		}
+		top = append(top, k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("TopDenied: rows: %w", err)
+	}
+	return top, nil
+}
+
+// Cleanup deletes hits and denial counters whose window has passed.
+func (s *RateLimitStore) Cleanup(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM rate_limit_hits WHERE expires_at < now()`); err != nil {
+		return fmt.Errorf("Cleanup: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM rate_limit_denials WHERE expires_at < now()`); err != nil {
+		return fmt.Errorf("Cleanup: %w", err)
+	}
+	return nil
+}
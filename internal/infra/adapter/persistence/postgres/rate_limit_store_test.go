@@ -0,0 +1,162 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+)
+
+func TestRateLimitStore_Allow(t *testing.T) {
+	const key = "auth:192.0.2.1"
+	expectCount := func(mock sqlmock.Sqlmock, n int) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM rate_limit_hits")).
+			WithArgs(key, float64(60)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(n))
+	}
+	expectDenial := func(mock sqlmock.Sqlmock) {
+		mock.ExpectExec(regexp.QuoteMeta("ON CONFLICT (key, bucket) DO UPDATE")).
+			WithArgs(key, float64(60)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	t.Run("below limit records the hit", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		expectCount(mock, 4)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("pg_advisory_xact_lock")).
+			WithArgs(key).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		expectCount(mock, 4)
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO rate_limit_hits")).
+			WithArgs(key, float64(60)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		got, err := pg.NewRateLimitStore(db).Allow(context.Background(), key, 5, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// 上限に達したキーはロックもトランザクションも取らず、分単位のカウンタを増やすだけ。
+	t.Run("at limit only counts the denial", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		expectCount(mock, 5)
+		expectDenial(mock)
+
+		got, err := pg.NewRateLimitStore(db).Allow(context.Background(), key, 5, time.Minute)
+		require.NoError(t, err)
+		assert.False(t, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("losing the race for the last slot is a denial", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		expectCount(mock, 4)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("pg_advisory_xact_lock")).
+			WithArgs(key).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		expectCount(mock, 5)
+		mock.ExpectCommit()
+		expectDenial(mock)
+
+		got, err := pg.NewRateLimitStore(db).Allow(context.Background(), key, 5, time.Minute)
+		require.NoError(t, err)
+		assert.False(t, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRateLimitStore_Allow_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM rate_limit_hits")).
+		WillReturnError(errors.New("connection refused"))
+
+	_, err = pg.NewRateLimitStore(db).Allow(context.Background(), "auth:192.0.2.1", 5, time.Minute)
+	assert.Error(t, err)
+}
+
+func TestRateLimitStore_Cleanup(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM rate_limit_hits WHERE expires_at < now()")).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM rate_limit_denials WHERE expires_at < now()")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, pg.NewRateLimitStore(db).Cleanup(context.Background()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRateLimitStore_Count(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM rate_limit_hits")).
+		WithArgs("auth:192.0.2.1", float64(60)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	got, err := pg.NewRateLimitStore(db).Count(context.Background(), "auth:192.0.2.1", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 3, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRateLimitStore_Reset(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM rate_limit_hits WHERE key = $1")).
+		WithArgs("auth:192.0.2.1").
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM rate_limit_denials WHERE key = $1")).
+		WithArgs("auth:192.0.2.1").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	require.NoError(t, pg.NewRateLimitStore(db).Reset(context.Background(), "auth:192.0.2.1"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRateLimitStore_TopDenied(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT key, sum(denied) AS denied FROM rate_limit_denials")).
+		WithArgs("auth:", float64(60), 2).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "count"}).
+			AddRow("auth:192.0.2.1", 7).
+			AddRow("auth:192.0.2.2", 1))
+
+	got, err := pg.NewRateLimitStore(db).TopDenied(context.Background(), "auth:", time.Minute, 2)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "auth:192.0.2.1", got[0].Key)
+	assert.Equal(t, 7, got[0].Denied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
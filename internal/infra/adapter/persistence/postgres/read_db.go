@@ -0,0 +1,9 @@
+package postgres
+
+import "database/sql"
+
+// ReadDB picks the pool for read-only list, search and count queries: a
+// read replica while it answers, the primary otherwise (*db.Replica).
+type ReadDB interface {
+	Reader() *sql.DB
+}
@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"catchup-feed/internal/repository"
+)
+
+// ReadStateRepo persists per-user read marks (article_read_state table).
+type ReadStateRepo struct{ db *sql.DB }
+
+func NewReadStateRepo(db *sql.DB) repository.ReadStateRepository {
+	return &ReadStateRepo{db: db}
+}
+
+// idPlaceholders returns "$first, $first+1, ..." for ids and the matching
+// args, like ExistsByURLBatch's IN list.
+func idPlaceholders(ids []int64, first int) (string, []any) {
+	placeholders := make([]string, len(ids))
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		placeholders[i] = fmt.Sprintf("$%d", first+i)
+		args[i] = id
+	}
+	return strings.Join(placeholders, ", "), args
+}
+
+// MarkRead upserts read marks, replacing unread marks. Selecting from
+// articles skips unknown IDs instead of failing the whole batch on the
+// foreign key.
+func (repo *ReadStateRepo) MarkRead(ctx context.Context, userID int64, articleIDs []int64, at time.Time) (int64, error) {
+	if len(articleIDs) == 0 {
+		return 0, nil
+	}
+	in, idArgs := idPlaceholders(articleIDs, 3)
+	// #nosec G201 -- in contains only generated $N placeholders.
+	query := fmt.Sprintf(`
+INSERT INTO article_read_state (user_id, article_id, read_at)
+SELECT $1, a.id, $2
+FROM articles a
+WHERE a.id IN (%s)
+ON CONFLICT (user_id, article_id) DO UPDATE SET read_at = EXCLUDED.read_at, unread_at = NULL`, in)
+	res, err := repo.db.ExecContext(ctx, query, append([]any{userID, at}, idArgs...)...)
+	if err != nil {
+		return 0, fmt.Errorf("MarkRead: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("MarkRead: %w", err)
+	}
+	return n, nil
+}
+
+// MarkUnread sets unread_at on the read marks. The rows stay, so the
+// change reaches other devices through Changes; articles without a row
+// are unread already.
+func (repo *ReadStateRepo) MarkUnread(ctx context.Context, userID int64, articleIDs []int64, at time.Time) (int64, error) {
+	if len(articleIDs) == 0 {
+		return 0, nil
+	}
+	in, idArgs := idPlaceholders(articleIDs, 3)
+	// #nosec G201 -- in contains only generated $N placeholders.
+	query := fmt.Sprintf(`
+UPDATE article_read_state SET unread_at = $2
+WHERE user_id = $1 AND unread_at IS NULL AND article_id IN (%s)`, in)
+	res, err := repo.db.ExecContext(ctx, query, append([]any{userID, at}, idArgs...)...)
+	if err != nil {
+		return 0, fmt.Errorf("MarkUnread: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("MarkUnread: %w", err)
+	}
+	return n, nil
+}
+
+// Changes reads the rows changed since: a row's change time is its
+// unread_at, or read_at for a read mark.
+func (repo *ReadStateRepo) Changes(ctx context.Context, userID int64, since time.Time) ([]repository.ReadStateChange, error) {
+	const query = `
+SELECT article_id, unread_at IS NULL, COALESCE(unread_at, read_at) AS changed_at
+FROM article_read_state
+WHERE user_id = $1 AND COALESCE(unread_at, read_at) >= $2
+ORDER BY changed_at ASC, article_id ASC`
+	rows, err := repo.db.QueryContext(ctx, query, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("Changes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	changes := make([]repository.ReadStateChange, 0)
+	for rows.Next() {
+		var c repository.ReadStateChange
+		if err := rows.Scan(&c.ArticleID, &c.Read, &c.ChangedAt); err != nil {
+			return nil, fmt.Errorf("Changes: %w", err)
+		}
+		changes = append(changes, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Changes: %w", err)
+	}
+	return changes, nil
+}
+
+// Merge upserts every change in one statement. The ON CONFLICT ... WHERE
+// keeps a stored change that is as new or newer; an unread mark for an
+// article never read is stored too (read_at = unread_at), so it can
+// override an older read from another device. Changes must name distinct
+// articles (one row cannot be updated twice in one statement).
+func (repo *ReadStateRepo) Merge(ctx context.Context, userID int64, changes []repository.ReadStateChange) (int64, error) {
+	if len(changes) == 0 {
+		return 0, nil
+	}
+	values := make([]string, len(changes))
+	args := make([]any, 0, 1+3*len(changes))
+	args = append(args, userID)
+	for i, c := range changes {
+		n := 2 + 3*i
+		values[i] = fmt.Sprintf("($%d::bigint, $%d::boolean, $%d::timestamptz)", n, n+1, n+2)
+		args = append(args, c.ArticleID, c.Read, c.ChangedAt)
+	}
+	// #nosec G201 -- values contains only generated $N placeholders.
+	query := fmt.Sprintf(`
+INSERT INTO article_read_state AS rs (user_id, article_id, read_at, unread_at)
+SELECT $1, a.id, v.changed_at, CASE WHEN v.read THEN NULL ELSE v.changed_at END
+FROM (VALUES %s) AS v(article_id, read, changed_at)
+INNER JOIN articles a ON a.id = v.article_id
+ON CONFLICT (user_id, article_id) DO UPDATE SET
+    read_at   = CASE WHEN EXCLUDED.unread_at IS NULL THEN EXCLUDED.read_at ELSE rs.read_at END,
+    unread_at = EXCLUDED.unread_at
+WHERE COALESCE(EXCLUDED.unread_at, EXCLUDED.read_at) > COALESCE(rs.unread_at, rs.read_at)`, strings.Join(values, ", "))
+	res, err := repo.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("Merge: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("Merge: %w", err)
+	}
+	return n, nil
+}
+
+// UnreadCounts counts, per active source, the articles without a read mark
+// of the user (no row, or an unread mark).
+func (repo *ReadStateRepo) UnreadCounts(ctx context.Context, userID int64) ([]repository.SourceUnreadCount, error) {
+	const query = `
+SELECT s.id, s.name, count(a.id)
+FROM sources s
+LEFT JOIN articles a ON a.source_id = s.id
+    AND NOT EXISTS (
+        SELECT 1 FROM article_read_state rs
+        WHERE rs.article_id = a.id AND rs.user_id = $1 AND rs.unread_at IS NULL)
+WHERE s.active
+GROUP BY s.id, s.name
+ORDER BY s.name`
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("UnreadCounts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	counts := make([]repository.SourceUnreadCount, 0)
+	for rows.Next() {
+		var c repository.SourceUnreadCount
+		if err := rows.Scan(&c.SourceID, &c.SourceName, &c.Unread); err != nil {
+			return nil, fmt.Errorf("UnreadCounts: %w", err)
+		}
+		counts = append(counts, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("UnreadCounts: %w", err)
+	}
+	return counts, nil
+}
@@ -0,0 +1,123 @@
+package postgres_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+	"catchup-feed/internal/repository"
+)
+
+func newReadStateRepo(t *testing.T) (repository.ReadStateRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return pg.NewReadStateRepo(db), mock, func() { _ = db.Close() }
+}
+
+func TestReadStateRepo_MarkRead(t *testing.T) {
+	repo, mock, closeFn := newReadStateRepo(t)
+	defer closeFn()
+
+	at := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("WHERE a.id IN ($3, $4, $5)")).
+		WithArgs(int64(1), at, int64(10), int64(11), int64(99)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	n, err := repo.MarkRead(context.Background(), 1, []int64{10, 11, 99}, at)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n, "unknown article IDs are skipped")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReadStateRepo_MarkUnread(t *testing.T) {
+	repo, mock, closeFn := newReadStateRepo(t)
+	defer closeFn()
+
+	at := time.Now()
+	// 行は消さずに未読マーク(unread_at)にする。
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE article_read_state SET unread_at = $2\nWHERE user_id = $1 AND unread_at IS NULL AND article_id IN ($3)")).
+		WithArgs(int64(1), at, int64(10)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	n, err := repo.MarkUnread(context.Background(), 1, []int64{10}, at)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	// 空リストは DB に行かない。
+	n, err = repo.MarkUnread(context.Background(), 1, nil, at)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReadStateRepo_UnreadCounts(t *testing.T) {
+	repo, mock, closeFn := newReadStateRepo(t)
+	defer closeFn()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM sources s")).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "count"}).
+			AddRow(int64(2), "Go Blog", int64(5)).
+			AddRow(int64(3), "Zenn", int64(0)))
+
+	got, err := repo.UnreadCounts(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []repository.SourceUnreadCount{
+		{SourceID: 2, SourceName: "Go Blog", Unread: 5},
+		{SourceID: 3, SourceName: "Zenn", Unread: 0},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReadStateRepo_Changes(t *testing.T) {
+	repo, mock, closeFn := newReadStateRepo(t)
+	defer closeFn()
+
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	readAt := since.Add(time.Hour)
+	unreadAt := since.Add(2 * time.Hour)
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE user_id = $1 AND COALESCE(unread_at, read_at) >= $2")).
+		WithArgs(int64(1), since).
+		WillReturnRows(sqlmock.NewRows([]string{"article_id", "read", "changed_at"}).
+			AddRow(int64(10), true, readAt).
+			AddRow(int64(11), false, unreadAt))
+
+	got, err := repo.Changes(context.Background(), 1, since)
+	require.NoError(t, err)
+	assert.Equal(t, []repository.ReadStateChange{
+		{ArticleID: 10, Read: true, ChangedAt: readAt},
+		{ArticleID: 11, Read: false, ChangedAt: unreadAt},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReadStateRepo_Merge(t *testing.T) {
+	repo, mock, closeFn := newReadStateRepo(t)
+	defer closeFn()
+
+	t1 := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Minute)
+	mock.ExpectExec(regexp.QuoteMeta("FROM (VALUES ($2::bigint, $3::boolean, $4::timestamptz), ($5::bigint, $6::boolean, $7::timestamptz))")).
+		WithArgs(int64(1), int64(10), true, t1, int64(11), false, t2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	n, err := repo.Merge(context.Background(), 1, []repository.ReadStateChange{
+		{ArticleID: 10, Read: true, ChangedAt: t1},
+		{ArticleID: 11, Read: false, ChangedAt: t2},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n, "older changes are ignored")
+
+	// 空リストは DB に行かない。
+	n, err = repo.Merge(context.Background(), 1, nil)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
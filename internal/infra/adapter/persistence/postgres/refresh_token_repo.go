@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+const refreshTokenColumns = "id, family_id, subject, role, token_hash, expires_at, created_at, used_at, revoked_at"
+
+// RefreshTokenRepo persists refresh tokens (refresh_tokens table).
+type RefreshTokenRepo struct{ db *sql.DB }
+
+func NewRefreshTokenRepo(db *sql.DB) repository.RefreshTokenRepository {
+	return &RefreshTokenRepo{db: db}
+}
+
+// Create inserts the token and sets token.ID / CreatedAt.
+func (repo *RefreshTokenRepo) Create(ctx context.Context, token *entity.RefreshToken) error {
+	const query = `
+INSERT INTO refresh_tokens (family_id, subject, role, token_hash, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, created_at`
+	err := repo.db.QueryRowContext(ctx, query,
+		token.FamilyID, token.Subject, token.Role, token.TokenHash, token.ExpiresAt,
+	).Scan(&token.ID, &token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("Create: %w", err)
+	}
+	return nil
+}
+
+// GetByHash returns the token with the given hash, or nil.
+func (repo *RefreshTokenRepo) GetByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	query := `
+SELECT ` + refreshTokenColumns + `
+FROM refresh_tokens
+WHERE token_hash = $1
+LIMIT 1`
+	var t entity.RefreshToken
+	err := repo.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&t.ID, &t.FamilyID, &t.Subject, &t.Role, &t.TokenHash,
+		&t.ExpiresAt, &t.CreatedAt, &t.UsedAt, &t.RevokedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetByHash: %w", err)
+	}
+	return &t, nil
+}
+
+// MarkUsed consumes the token; the WHERE guard makes it a compare-and-set.
+func (repo *RefreshTokenRepo) MarkUsed(ctx context.Context, id int64, t time.Time) (bool, error) {
+	const query = `
+UPDATE refresh_tokens SET used_at = $1
+WHERE id = $2 AND used_at IS NULL AND revoked_at IS NULL`
+	res, err := repo.db.ExecContext(ctx, query, t, id)
+	if err != nil {
+		return false, fmt.Errorf("MarkUsed: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("MarkUsed: %w", err)
+	}
+	return n == 1, nil
+}
+
+// RevokeFamily revokes the family's live tokens (idempotent: already
+// revoked tokens keep their original timestamp).
+func (repo *RefreshTokenRepo) RevokeFamily(ctx context.Context, familyID string, t time.Time) error {
+	const query = `
+UPDATE refresh_tokens SET revoked_at = $1
+WHERE family_id = $2 AND revoked_at IS NULL`
+	if _, err := repo.db.ExecContext(ctx, query, t, familyID); err != nil {
+		return fmt.Errorf("RevokeFamily: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired deletes tokens that expired before t.
+func (repo *RefreshTokenRepo) DeleteExpired(ctx context.Context, t time.Time) (int64, error) {
+	res, err := repo.db.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE expires_at < $1`, t)
+	if err != nil {
+		return 0, fmt.Errorf("DeleteExpired: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("DeleteExpired: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,143 @@
+package postgres_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+	"catchup-feed/internal/repository"
+)
+
+var refreshTokenCols = []string{"id", "family_id", "subject", "role", "token_hash", "expires_at", "created_at", "used_at", "revoked_at"}
+
+func newRefreshTokenRepo(t *testing.T) (repository.RefreshTokenRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return pg.NewRefreshTokenRepo(db), mock, func() { _ = db.Close() }
+}
+
+func TestRefreshTokenRepo_Create(t *testing.T) {
+	repo, mock, closeFn := newRefreshTokenRepo(t)
+	defer closeFn()
+
+	now := time.Now()
+	exp := now.Add(time.Hour)
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO refresh_tokens")).
+		WithArgs("fam", "admin@example.com", "admin", "hash", exp).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(int64(3), now))
+
+	token := &entity.RefreshToken{FamilyID: "fam", Subject: "admin@example.com", Role: "admin", TokenHash: "hash", ExpiresAt: exp}
+	require.NoError(t, repo.Create(context.Background(), token))
+	assert.Equal(t, int64(3), token.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRefreshTokenRepo_GetByHash(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		rows     *sqlmock.Rows
+		wantNil  bool
+		wantUsed bool
+	}{
+		{
+			name: "live token",
+			rows: sqlmock.NewRows(refreshTokenCols).
+				AddRow(int64(1), "fam", "admin@example.com", "admin", "hash", now.Add(time.Hour), now, nil, nil),
+		},
+		{
+			name: "used token",
+			rows: sqlmock.NewRows(refreshTokenCols).
+				AddRow(int64(1), "fam", "admin@example.com", "admin", "hash", now.Add(time.Hour), now, now, nil),
+			wantUsed: true,
+		},
+		{name: "not found", rows: sqlmock.NewRows(refreshTokenCols), wantNil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, closeFn := newRefreshTokenRepo(t)
+			defer closeFn()
+
+			mock.ExpectQuery(regexp.QuoteMeta("WHERE token_hash = $1")).
+				WithArgs("hash").
+				WillReturnRows(tt.rows)
+
+			got, err := repo.GetByHash(context.Background(), "hash")
+			require.NoError(t, err)
+			if tt.wantNil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, "fam", got.FamilyID)
+			assert.Equal(t, tt.wantUsed, got.UsedAt != nil)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRefreshTokenRepo_MarkUsed(t *testing.T) {
+	tests := []struct {
+		name     string
+		affected int64
+		want     bool
+	}{
+		{name: "consumed", affected: 1, want: true},
+		{name: "already used or revoked", affected: 0, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, closeFn := newRefreshTokenRepo(t)
+			defer closeFn()
+
+			now := time.Now()
+			mock.ExpectExec(regexp.QuoteMeta("WHERE id = $2 AND used_at IS NULL AND revoked_at IS NULL")).
+				WithArgs(now, int64(1)).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			got, err := repo.MarkUsed(context.Background(), 1, now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRefreshTokenRepo_RevokeFamily(t *testing.T) {
+	repo, mock, closeFn := newRefreshTokenRepo(t)
+	defer closeFn()
+
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("WHERE family_id = $2 AND revoked_at IS NULL")).
+		WithArgs(now, "fam").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	require.NoError(t, repo.RevokeFamily(context.Background(), "fam", now))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRefreshTokenRepo_DeleteExpired(t *testing.T) {
+	repo, mock, closeFn := newRefreshTokenRepo(t)
+	defer closeFn()
+
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM refresh_tokens WHERE expires_at < $1")).
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+
+	n, err := repo.DeleteExpired(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"catchup-feed/internal/repository"
+)
+
+// RevokedTokenRepo persists the access-token denylist (revoked_tokens
+// table).
+type RevokedTokenRepo struct{ db *sql.DB }
+
+func NewRevokedTokenRepo(db *sql.DB) repository.RevokedTokenRepository {
+	return &RevokedTokenRepo{db: db}
+}
+
+// Add denylists jti; the first revocation's timestamp is kept.
+func (repo *RevokedTokenRepo) Add(ctx context.Context, jti, subject string, expiresAt time.Time) error {
+	const query = `
+INSERT INTO revoked_tokens (jti, subject, expires_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (jti) DO NOTHING`
+	if _, err := repo.db.ExecContext(ctx, query, jti, subject, expiresAt); err != nil {
+		return fmt.Errorf("Add: %w", err)
+	}
+	return nil
+}
+
+// Exists reports whether jti is denylisted.
+func (repo *RevokedTokenRepo) Exists(ctx context.Context, jti string) (bool, error) {
+	var exists bool
+	err := repo.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1)`, jti,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("Exists: %w", err)
+	}
+	return exists, nil
+}
+
+// DeleteExpired deletes entries whose token expired before t.
+func (repo *RevokedTokenRepo) DeleteExpired(ctx context.Context, t time.Time) (int64, error) {
+	res, err := repo.db.ExecContext(ctx, `DELETE FROM revoked_tokens WHERE expires_at < $1`, t)
+	if err != nil {
+		return 0, fmt.Errorf("DeleteExpired: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("DeleteExpired: %w", err)
+	}
+	return n, nil
+}
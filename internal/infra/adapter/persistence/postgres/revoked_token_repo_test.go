@@ -0,0 +1,64 @@
+package postgres_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+	"catchup-feed/internal/repository"
+)
+
+func newRevokedTokenRepo(t *testing.T) (repository.RevokedTokenRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return pg.NewRevokedTokenRepo(db), mock, func() { _ = db.Close() }
+}
+
+func TestRevokedTokenRepo_Add(t *testing.T) {
+	repo, mock, closeFn := newRevokedTokenRepo(t)
+	defer closeFn()
+
+	exp := time.Now().Add(time.Hour)
+	mock.ExpectExec(regexp.QuoteMeta("ON CONFLICT (jti) DO NOTHING")).
+		WithArgs("jti-1", "admin@example.com", exp).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.Add(context.Background(), "jti-1", "admin@example.com", exp))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRevokedTokenRepo_Exists(t *testing.T) {
+	repo, mock, closeFn := newRevokedTokenRepo(t)
+	defer closeFn()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1)")).
+		WithArgs("jti-1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	got, err := repo.Exists(context.Background(), "jti-1")
+	require.NoError(t, err)
+	assert.True(t, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRevokedTokenRepo_DeleteExpired(t *testing.T) {
+	repo, mock, closeFn := newRevokedTokenRepo(t)
+	defer closeFn()
+
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM revoked_tokens WHERE expires_at < $1")).
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+
+	n, err := repo.DeleteExpired(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,227 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+const sourceGroupColumns = "id, name, crawl_schedule, crawl_paused, notify, created_at, updated_at"
+
+// SourceGroupRepo persists source groups (source_groups) and their
+// membership (sources.group_id).
+type SourceGroupRepo struct{ db *sql.DB }
+
+func NewSourceGroupRepo(db *sql.DB) repository.SourceGroupRepository {
+	return &SourceGroupRepo{db: db}
+}
+
+// mapSourceGroupErr converts a unique_violation on name into the
+// repository sentinel so the use case can answer 409 instead of 500.
+func mapSourceGroupErr(op string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		return fmt.Errorf("%s: %w", op, repository.ErrDuplicateSourceGroupName)
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
+
+func scanSourceGroup(s scanner) (*entity.SourceGroup, error) {
+	g := entity.SourceGroup{SourceIDs: []int64{}}
+	if err := s.Scan(
+		&g.ID, &g.Name, &g.CrawlSchedule, &g.CrawlPaused, &g.Notify, &g.CreatedAt, &g.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+// List returns every group ordered by name. The members are read with a
+// second query over sources.group_id (idx_sources_group_id).
+func (repo *SourceGroupRepo) List(ctx context.Context) ([]*entity.SourceGroup, error) {
+	rows, err := repo.db.QueryContext(ctx, `
+SELECT `+sourceGroupColumns+`
+FROM source_groups
+ORDER BY name ASC, id ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("List: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	groups := make([]*entity.SourceGroup, 0)
+	byID := make(map[int64]*entity.SourceGroup)
+	for rows.Next() {
+		g, err := scanSourceGroup(rows)
+		if err != nil {
+			return nil, fmt.Errorf("List: %w", err)
+		}
+		groups = append(groups, g)
+		byID[g.ID] = g
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("List: %w", err)
+	}
+	if len(groups) == 0 {
+		return groups, nil
+	}
+
+	members, err := repo.db.QueryContext(ctx,
+		`SELECT id, group_id FROM sources WHERE group_id IS NOT NULL ORDER BY id ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("List: members: %w", err)
+	}
+	defer func() { _ = members.Close() }()
+	for members.Next() {
+		var sourceID, groupID int64
+		if err := members.Scan(&sourceID, &groupID); err != nil {
+			return nil, fmt.Errorf("List: members: %w", err)
+		}
+		if g, ok := byID[groupID]; ok {
+			g.SourceIDs = append(g.SourceIDs, sourceID)
+		}
+	}
+	if err := members.Err(); err != nil {
+		return nil, fmt.Errorf("List: members: %w", err)
+	}
+	return groups, nil
+}
+
+// Get returns the group with its members, or nil when not found.
+func (repo *SourceGroupRepo) Get(ctx context.Context, id int64) (*entity.SourceGroup, error) {
+	g, err := scanSourceGroup(repo.db.QueryRowContext(ctx, `
+SELECT `+sourceGroupColumns+`
+FROM source_groups
+WHERE id = $1
+LIMIT 1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Get: %w", err)
+	}
+
+	rows, err := repo.db.QueryContext(ctx, `SELECT id FROM sources WHERE group_id = $1 ORDER BY id ASC`, id)
+	if err != nil {
+		return nil, fmt.Errorf("Get: members: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var sourceID int64
+		if err := rows.Scan(&sourceID); err != nil {
+			return nil, fmt.Errorf("Get: members: %w", err)
+		}
+		g.SourceIDs = append(g.SourceIDs, sourceID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Get: members: %w", err)
+	}
+	return g, nil
+}
+
+// Create inserts the group and sets group.ID / CreatedAt / UpdatedAt.
+func (repo *SourceGroupRepo) Create(ctx context.Context, group *entity.SourceGroup) error {
+	const query = `
+INSERT INTO source_groups (name, crawl_schedule, crawl_paused, notify)
+VALUES ($1, $2, $3, $4)
+RETURNING id, created_at, updated_at`
+	if err := repo.db.QueryRowContext(ctx, query,
+		group.Name, group.CrawlSchedule, group.CrawlPaused, group.Notify,
+	).Scan(&group.ID, &group.CreatedAt, &group.UpdatedAt); err != nil {
+		return mapSourceGroupErr("Create", err)
+	}
+	return nil
+}
+
+// Update saves the name and settings and sets group.UpdatedAt.
+func (repo *SourceGroupRepo) Update(ctx context.Context, group *entity.SourceGroup) error {
+	const query = `
+UPDATE source_groups SET
+       name           = $2,
+       crawl_schedule = $3,
+       crawl_paused   = $4,
+       notify         = $5,
+       updated_at     = now()
+WHERE id = $1
+RETURNING updated_at`
+	err := repo.db.QueryRowContext(ctx, query,
+		group.ID, group.Name, group.CrawlSchedule, group.CrawlPaused, group.Notify,
+	).Scan(&group.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil // deleted meanwhile; Delete is idempotent too
+	}
+	if err != nil {
+		return mapSourceGroupErr("Update", err)
+	}
+	return nil
+}
+
+// Delete removes the group. sources.group_id is ON DELETE SET NULL, so the
+// members become ungrouped.
+func (repo *SourceGroupRepo) Delete(ctx context.Context, id int64) error {
+	if _, err := repo.db.ExecContext(ctx, `DELETE FROM source_groups WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("Delete: %w", err)
+	}
+	return nil
+}
+
+// SetSources replaces the group's members in one transaction: the former
+// members not listed are released first, then the listed sources are
+// moved in.
+func (repo *SourceGroupRepo) SetSources(ctx context.Context, groupID int64, sourceIDs []int64) ([]int64, error) {
+	tx, err := beginTx(ctx, repo.db)
+	if err != nil {
+		return nil, fmt.Errorf("SetSources: begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if len(sourceIDs) == 0 {
+		if _, err := tx.ExecContext(ctx, `UPDATE sources SET group_id = NULL WHERE group_id = $1`, groupID); err != nil {
+			return nil, fmt.Errorf("SetSources: release: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, fmt.Errorf("SetSources: commit: %w", err)
+		}
+		return []int64{}, nil
+	}
+
+	in, args := idPlaceholders(sourceIDs, 2)
+	args = append([]any{groupID}, args...)
+	// #nosec G201 -- in contains only generated $N placeholders.
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(
+		`UPDATE sources SET group_id = NULL WHERE group_id = $1 AND id NOT IN (%s)`, in), args...); err != nil {
+		return nil, fmt.Errorf("SetSources: release: %w", err)
+	}
+	// #nosec G201 -- in contains only generated $N placeholders.
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(
+		`UPDATE sources SET group_id = $1 WHERE id IN (%s) RETURNING id`, in), args...)
+	if err != nil {
+		return nil, fmt.Errorf("SetSources: %w", err)
+	}
+	members := make([]int64, 0, len(sourceIDs))
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("SetSources: Scan: %w", err)
+		}
+		members = append(members, id)
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return nil, fmt.Errorf("SetSources: %w", err)
+	}
+	_ = rows.Close()
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("SetSources: commit: %w", err)
+	}
+	slices.Sort(members)
+	return members, nil
+}
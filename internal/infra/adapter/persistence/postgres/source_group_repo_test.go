@@ -0,0 +1,154 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+	"catchup-feed/internal/repository"
+)
+
+var sourceGroupCols = []string{"id", "name", "crawl_schedule", "crawl_paused", "notify", "created_at", "updated_at"}
+
+func newSourceGroupRepo(t *testing.T) (repository.SourceGroupRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return pg.NewSourceGroupRepo(db), mock, func() { _ = db.Close() }
+}
+
+func TestSourceGroupRepo_Create(t *testing.T) {
+	repo, mock, closeFn := newSourceGroupRepo(t)
+	defer closeFn()
+
+	now := time.Now()
+	schedule := "2h"
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO source_groups")).
+		WithArgs("Go", &schedule, false, true).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(int64(3), now, now))
+	group := &entity.SourceGroup{Name: "Go", CrawlSchedule: &schedule, Notify: true}
+	require.NoError(t, repo.Create(context.Background(), group))
+	assert.Equal(t, int64(3), group.ID)
+
+	// 同名のグループは sentinel に変換
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO source_groups")).
+		WillReturnError(&pgconn.PgError{Code: "23505"})
+	err := repo.Create(context.Background(), &entity.SourceGroup{Name: "Go"})
+	assert.True(t, errors.Is(err, repository.ErrDuplicateSourceGroupName))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceGroupRepo_List(t *testing.T) {
+	repo, mock, closeFn := newSourceGroupRepo(t)
+	defer closeFn()
+
+	now := time.Now()
+	mock.ExpectQuery(`FROM source_groups\s+ORDER BY name ASC`).
+		WillReturnRows(sqlmock.NewRows(sourceGroupCols).
+			AddRow(int64(1), "Go", "2h", false, true, now, now).
+			AddRow(int64(2), "Seasonal", nil, true, false, now, now))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, group_id FROM sources WHERE group_id IS NOT NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "group_id"}).
+			AddRow(int64(4), int64(1)).
+			AddRow(int64(5), int64(2)).
+			AddRow(int64(6), int64(1)))
+
+	groups, err := repo.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	require.NotNil(t, groups[0].CrawlSchedule)
+	assert.Equal(t, "2h", *groups[0].CrawlSchedule)
+	assert.Equal(t, []int64{4, 6}, groups[0].SourceIDs)
+	assert.Nil(t, groups[1].CrawlSchedule)
+	assert.True(t, groups[1].CrawlPaused)
+	assert.False(t, groups[1].Notify)
+	assert.Equal(t, []int64{5}, groups[1].SourceIDs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceGroupRepo_Get(t *testing.T) {
+	repo, mock, closeFn := newSourceGroupRepo(t)
+	defer closeFn()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("FROM source_groups")).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(sourceGroupCols).AddRow(int64(1), "Go", nil, false, true, now, now))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM sources WHERE group_id = $1")).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	group, err := repo.Get(context.Background(), 1)
+	require.NoError(t, err)
+	require.NotNil(t, group)
+	assert.Equal(t, []int64{}, group.SourceIDs)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM source_groups")).
+		WithArgs(int64(9)).
+		WillReturnRows(sqlmock.NewRows(sourceGroupCols))
+	group, err = repo.Get(context.Background(), 9)
+	require.NoError(t, err)
+	assert.Nil(t, group)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceGroupRepo_SetSources(t *testing.T) {
+	t.Run("replaces the members", func(t *testing.T) {
+		repo, mock, closeFn := newSourceGroupRepo(t)
+		defer closeFn()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE sources SET group_id = NULL WHERE group_id = $1 AND id NOT IN ($2, $3, $4)")).
+			WithArgs(int64(1), int64(7), int64(3), int64(99)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectQuery(regexp.QuoteMeta("UPDATE sources SET group_id = $1 WHERE id IN ($2, $3, $4) RETURNING id")).
+			WithArgs(int64(1), int64(7), int64(3), int64(99)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(7)).AddRow(int64(3)))
+		mock.ExpectCommit()
+
+		members, err := repo.SetSources(context.Background(), 1, []int64{7, 3, 99})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3, 7}, members)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("empty list empties the group", func(t *testing.T) {
+		repo, mock, closeFn := newSourceGroupRepo(t)
+		defer closeFn()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE sources SET group_id = NULL WHERE group_id = $1")).
+			WithArgs(int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		members, err := repo.SetSources(context.Background(), 1, nil)
+		require.NoError(t, err)
+		assert.Empty(t, members)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("error rolls back", func(t *testing.T) {
+		repo, mock, closeFn := newSourceGroupRepo(t)
+		defer closeFn()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE sources SET group_id = NULL")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta("UPDATE sources SET group_id = $1")).
+			WillReturnError(errors.New("boom"))
+		mock.ExpectRollback()
+
+		_, err := repo.SetSources(context.Background(), 1, []int64{3})
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+const sourceHealthColumns = "source_id, last_crawled_at, last_success_at, last_http_status, consecutive_failures, last_error"
+
+// SourceHealthRepo persists per-source crawl health (source_health table).
+type SourceHealthRepo struct{ db *sql.DB }
+
+func NewSourceHealthRepo(db *sql.DB) repository.SourceHealthRepository {
+	return &SourceHealthRepo{db: db}
+}
+
+func scanSourceHealth(s scanner) (*entity.SourceHealth, error) {
+	var h entity.SourceHealth
+	var status sql.NullInt64
+	if err := s.Scan(
+		&h.SourceID, &h.LastCrawledAt, &h.LastSuccessAt, &status,
+		&h.ConsecutiveFailures, &h.LastError,
+	); err != nil {
+		return nil, err
+	}
+	if status.Valid {
+		code := int(status.Int64)
+		h.LastHTTPStatus = &code
+	}
+	return &h, nil
+}
+
+// RecordFetch upserts the source's row in one statement: the failure
+// streak is incremented from the stored value, so concurrent crawls of
+// different sources never read-modify-write each other's rows.
+func (repo *SourceHealthRepo) RecordFetch(ctx context.Context, sourceID int64, at time.Time, httpStatus int, fetchErr string) error {
+	var status sql.NullInt64
+	if httpStatus != 0 {
+		status = sql.NullInt64{Int64: int64(httpStatus), Valid: true}
+	}
+	var lastError sql.NullString
+	if fetchErr != "" {
+		lastError = sql.NullString{String: fetchErr, Valid: true}
+	}
+	const query = `
+INSERT INTO source_health (source_id, last_crawled_at, last_success_at, last_http_status, consecutive_failures, last_error)
+VALUES ($1, $2::timestamptz, CASE WHEN $4::text IS NULL THEN $2::timestamptz END, $3::int,
+        CASE WHEN $4::text IS NULL THEN 0 ELSE 1 END, $4::text)
+ON CONFLICT (source_id) DO UPDATE SET
+       last_crawled_at      = EXCLUDED.last_crawled_at,
+       last_success_at      = COALESCE(EXCLUDED.last_success_at, source_health.last_success_at),
+       last_http_status     = EXCLUDED.last_http_status,
+       consecutive_failures = CASE WHEN EXCLUDED.last_error IS NULL THEN 0
+                                   ELSE source_health.consecutive_failures + 1 END,
+       last_error           = EXCLUDED.last_error`
+	if _, err := repo.db.ExecContext(ctx, query, sourceID, at, status, lastError); err != nil {
+		return fmt.Errorf("RecordFetch: %w", err)
+	}
+	return nil
+}
+
+func (repo *SourceHealthRepo) Get(ctx context.Context, sourceID int64) (*entity.SourceHealth, error) {
+	query := `
+SELECT ` + sourceHealthColumns + `
+FROM source_health
+WHERE source_id = $1`
+	h, err := scanSourceHealth(repo.db.QueryRowContext(ctx, query, sourceID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Get: %w", err)
+	}
+	return h, nil
+}
+
+func (repo *SourceHealthRepo) List(ctx context.Context) ([]*entity.SourceHealth, error) {
+	query := `
+SELECT ` + sourceHealthColumns + `
+FROM source_health
+ORDER BY source_id ASC`
+	rows, err := repo.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("List: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []*entity.SourceHealth
+	for rows.Next() {
+		h, err := scanSourceHealth(rows)
+		if err != nil {
+			return nil, fmt.Errorf("List: %w", err)
+		}
+		out = append(out, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("List: %w", err)
+	}
+	return out, nil
+}
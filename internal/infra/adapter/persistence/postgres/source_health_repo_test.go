@@ -0,0 +1,94 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+	"catchup-feed/internal/repository"
+)
+
+var sourceHealthCols = []string{"source_id", "last_crawled_at", "last_success_at", "last_http_status", "consecutive_failures", "last_error"}
+
+func newSourceHealthRepo(t *testing.T) (repository.SourceHealthRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return pg.NewSourceHealthRepo(db), mock, func() { _ = db.Close() }
+}
+
+func TestSourceHealthRepo_RecordFetch(t *testing.T) {
+	at := time.Date(2026, 10, 1, 5, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		httpStatus int
+		fetchErr   string
+		wantStatus any
+		wantErr    any
+	}{
+		{"success", 200, "", sql.NullInt64{Int64: 200, Valid: true}, sql.NullString{}},
+		{"http failure", 404, "feed returned 404", sql.NullInt64{Int64: 404, Valid: true}, sql.NullString{String: "feed returned 404", Valid: true}},
+		{"no response", 0, "dial tcp: timeout", sql.NullInt64{}, sql.NullString{String: "dial tcp: timeout", Valid: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, closeFn := newSourceHealthRepo(t)
+			defer closeFn()
+
+			// 連続失敗数は保存値から1文で数える(読んでから書かない)
+			mock.ExpectExec(`INSERT INTO source_health .*ON CONFLICT \(source_id\) DO UPDATE SET.*source_health\.consecutive_failures \+ 1`).
+				WithArgs(int64(7), at, tt.wantStatus, tt.wantErr).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			require.NoError(t, repo.RecordFetch(context.Background(), 7, at, tt.httpStatus, tt.fetchErr))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSourceHealthRepo_GetAndList(t *testing.T) {
+	repo, mock, closeFn := newSourceHealthRepo(t)
+	defer closeFn()
+
+	at := time.Date(2026, 10, 1, 5, 30, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("FROM source_health")).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows(sourceHealthCols).
+			AddRow(int64(7), at, nil, nil, 3, "dial tcp: timeout"))
+
+	h, err := repo.Get(context.Background(), 7)
+	require.NoError(t, err)
+	require.NotNil(t, h)
+	assert.Nil(t, h.LastHTTPStatus)
+	assert.Nil(t, h.LastSuccessAt)
+	assert.Equal(t, 3, h.ConsecutiveFailures)
+	require.NotNil(t, h.LastError)
+	assert.Equal(t, "dial tcp: timeout", *h.LastError)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM source_health")).
+		WithArgs(int64(8)).
+		WillReturnRows(sqlmock.NewRows(sourceHealthCols))
+	h, err = repo.Get(context.Background(), 8)
+	require.NoError(t, err)
+	assert.Nil(t, h)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM source_health")).
+		WillReturnRows(sqlmock.NewRows(sourceHealthCols).
+			AddRow(int64(1), at, at, int64(200), 0, nil).
+			AddRow(int64(2), at, nil, int64(500), 1, "feed returned 500"))
+	list, err := repo.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.NotNil(t, list[0].LastHTTPStatus)
+	assert.Equal(t, 200, *list[0].LastHTTPStatus)
+	assert.Nil(t, list[0].LastError)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
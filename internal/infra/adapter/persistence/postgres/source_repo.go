@@ -12,14 +12,31 @@ import (
 )
 
 // sourceColumns is the §4 sources column list used by every SELECT.
-const sourceColumns = "id, name, feed_url, category, lang, kind, active, created_at"
+const sourceColumns = "id, name, feed_url, category, lang, kind, active, crawl_schedule, retention_days, render_js, etag, last_modified, created_at, group_id"
 
-type SourceRepo struct{ db *sql.DB }
+type SourceRepo struct {
+	db      *sql.DB
+	replica ReadDB // nil = every query on db
+}
 
 func NewSourceRepo(db *sql.DB) repository.SourceRepository {
 	return &SourceRepo{db: db}
 }
 
+// NewSourceRepoWithReplica sends List, Search and SearchWithFilters to
+// replica.Reader(). Get, ListActive (the crawl) and the writes stay on db.
+func NewSourceRepoWithReplica(db *sql.DB, replica ReadDB) repository.SourceRepository {
+	return &SourceRepo{db: db, replica: replica}
+}
+
+// reader returns the pool for read-only list and search queries.
+func (repo *SourceRepo) reader() *sql.DB {
+	if repo.replica != nil {
+		return repo.replica.Reader()
+	}
+	return repo.db
+}
+
 // scanner abstracts *sql.Row / *sql.Rows for shared scan helpers.
 type scanner interface {
 	Scan(dest ...any) error
@@ -27,17 +44,21 @@ type scanner interface {
 
 func scanSource(s scanner) (*entity.Source, error) {
 	var source entity.Source
+	var etag, lastModified sql.NullString
 	if err := s.Scan(
 		&source.ID, &source.Name, &source.FeedURL, &source.Category,
-		&source.Lang, &source.Kind, &source.Active, &source.CreatedAt,
+		&source.Lang, &source.Kind, &source.Active, &source.CrawlSchedule, &source.RetentionDays,
+		&source.RenderJS, &etag, &lastModified, &source.CreatedAt, &source.GroupID,
 	); err != nil {
 		return nil, err
 	}
+	source.ETag = etag.String
+	source.LastModified = lastModified.String
 	return &source, nil
 }
 
-func (repo *SourceRepo) querySources(ctx context.Context, op, query string, args ...any) ([]*entity.Source, error) {
-	rows, err := repo.db.QueryContext(ctx, query, args...)
+func querySources(ctx context.Context, db *sql.DB, op, query string, args ...any) ([]*entity.Source, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -60,7 +81,7 @@ SELECT ` + sourceColumns + `
 FROM sources
 WHERE id = $1
 LIMIT 1`
-	source, err := scanSource(repo.db.QueryRowContext(ctx, query, id))
+	source, err := scanSource(conn(ctx, repo.db).QueryRowContext(ctx, query, id))
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -75,7 +96,7 @@ func (repo *SourceRepo) List(ctx context.Context) ([]*entity.Source, error) {
 SELECT ` + sourceColumns + `
 FROM sources
 ORDER BY id ASC`
-	return repo.querySources(ctx, "List", query)
+	return querySources(ctx, repo.reader(), "List", query)
 }
 
 func (repo *SourceRepo) ListActive(ctx context.Context) ([]*entity.Source, error) {
@@ -84,7 +105,7 @@ SELECT ` + sourceColumns + `
 FROM sources
 WHERE active = TRUE
 ORDER BY id ASC`
-	return repo.querySources(ctx, "ListActive", query)
+	return querySources(ctx, repo.db, "ListActive", query)
 }
 
 func (repo *SourceRepo) Search(ctx context.Context, kw string) ([]*entity.Source, error) {
@@ -94,7 +115,7 @@ FROM sources
 WHERE name     ILIKE $1
 OR feed_url ILIKE $1
 ORDER BY id ASC`
-	return repo.querySources(ctx, "Search", query, "%"+kw+"%")
+	return querySources(ctx, repo.reader(), "Search", query, "%"+kw+"%")
 }
 
 // SearchWithFilters searches sources with multi-keyword AND logic and optional filters
@@ -145,7 +166,7 @@ FROM sources`
 	}
 	query += "\nORDER BY id ASC"
 
-	return repo.querySources(ctx, "SearchWithFilters", query, args...)
+	return querySources(ctx, repo.reader(), "SearchWithFilters", query, args...)
 }
 
 func (repo *SourceRepo) Create(ctx context.Context, source *entity.Source) error {
@@ -156,11 +177,12 @@ func (repo *SourceRepo) Create(ctx context.Context, source *entity.Source) error
 		source.Kind = entity.DefaultSourceKind
 	}
 	const query = `
-INSERT INTO sources (name, feed_url, category, lang, kind, active)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO sources (name, feed_url, category, lang, kind, active, crawl_schedule, retention_days, render_js)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING id, created_at`
 	err := repo.db.QueryRowContext(ctx, query,
 		source.Name, source.FeedURL, source.Category, source.Lang, source.Kind, source.Active,
+		source.CrawlSchedule, source.RetentionDays, source.RenderJS,
 	).Scan(&source.ID, &source.CreatedAt)
 	if err != nil {
 		return fmt.Errorf("Create: %w", err)
@@ -182,11 +204,18 @@ UPDATE sources SET
        category = $3,
        lang     = $4,
        kind     = $5,
-       active   = $6
-WHERE id = $7`
-	res, err := repo.db.ExecContext(ctx, query,
+       active   = $6,
+       crawl_schedule = $7,
+       retention_days = $8,
+       render_js      = $9,
+       -- 別のフィードに向いた検証子は使えない(304 で取りこぼす)
+       etag           = CASE WHEN feed_url = $2 THEN etag END,
+       last_modified  = CASE WHEN feed_url = $2 THEN last_modified END
+WHERE id = $10`
+	res, err := conn(ctx, repo.db).ExecContext(ctx, query,
 		source.Name, source.FeedURL, source.Category,
-		source.Lang, source.Kind, source.Active, source.ID,
+		source.Lang, source.Kind, source.Active, source.CrawlSchedule, source.RetentionDays,
+		source.RenderJS, source.ID,
 	)
 	if err != nil {
 		return fmt.Errorf("Update: %w", err)
@@ -208,3 +237,16 @@ func (repo *SourceRepo) Delete(ctx context.Context, id int64) error {
 	}
 	return nil
 }
+
+// UpdateFeedValidators stores the cache validators of the last feed
+// response. It touches only those two columns, so it never overwrites an
+// edit made through the API while the crawl was running.
+func (repo *SourceRepo) UpdateFeedValidators(ctx context.Context, id int64, etag, lastModified string) error {
+	const query = `
+UPDATE sources SET etag = NULLIF($1, ''), last_modified = NULLIF($2, '')
+WHERE id = $3`
+	if _, err := repo.db.ExecContext(ctx, query, etag, lastModified, id); err != nil {
+		return fmt.Errorf("UpdateFeedValidators: %w", err)
+	}
+	return nil
+}
@@ -19,14 +19,36 @@ import (
 
 /* ─────────────────────────── ヘルパ ─────────────────────────── */
 
-// sourceCols is the §4 sources column list (+ Phase 2 kind).
+// sourceCols is the §4 sources column list (+ Phase 2 kind, crawl_schedule,
+// retention_days, render_js, the conditional GET validators and the source
+// group).
 var sourceCols = []string{
-	"id", "name", "feed_url", "category", "lang", "kind", "active", "created_at",
+	"id", "name", "feed_url", "category", "lang", "kind", "active", "crawl_schedule", "retention_days", "render_js", "etag", "last_modified", "created_at", "group_id",
 }
 
 func srcRow(s *entity.Source) *sqlmock.Rows {
+	var crawlSchedule any // NULL
+	if s.CrawlSchedule != nil {
+		crawlSchedule = *s.CrawlSchedule
+	}
+	var retentionDays any // NULL
+	if s.RetentionDays != nil {
+		retentionDays = int64(*s.RetentionDays)
+	}
+	var etag, lastModified any // NULL
+	if s.ETag != "" {
+		etag = s.ETag
+	}
+	if s.LastModified != "" {
+		lastModified = s.LastModified
+	}
+	var groupID any // NULL
+	if s.GroupID != nil {
+		groupID = *s.GroupID
+	}
 	return sqlmock.NewRows(sourceCols).AddRow(
-		s.ID, s.Name, s.FeedURL, s.Category, s.Lang, s.Kind, s.Active, s.CreatedAt,
+		s.ID, s.Name, s.FeedURL, s.Category, s.Lang, s.Kind, s.Active, crawlSchedule, retentionDays,
+		s.RenderJS, etag, lastModified, s.CreatedAt, groupID,
 	)
 }
 
@@ -41,6 +63,8 @@ func newSourceRepo(t *testing.T) (repository.SourceRepository, sqlmock.Sqlmock,
 
 func TestSourceRepo_Get(t *testing.T) {
 	now := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	everyHalfHour := "*/30 * * * *"
+	ninetyDays := 90
 
 	tests := []struct {
 		name    string
@@ -57,6 +81,42 @@ func TestSourceRepo_Get(t *testing.T) {
 				Category: "dev", Lang: "en", Kind: "rss", Active: true, CreatedAt: now,
 			},
 		},
+		{
+			name: "found with crawl schedule",
+			want: &entity.Source{
+				ID: 1, Name: "Golang Weekly",
+				FeedURL:  "https://example.com/feed.xml",
+				Category: "dev", Lang: "en", Kind: "rss", Active: true,
+				CrawlSchedule: &everyHalfHour, CreatedAt: now,
+			},
+		},
+		{
+			name: "found with retention days",
+			want: &entity.Source{
+				ID: 1, Name: "Golang Weekly",
+				FeedURL:  "https://example.com/feed.xml",
+				Category: "dev", Lang: "en", Kind: "rss", Active: true,
+				RetentionDays: &ninetyDays, CreatedAt: now,
+			},
+		},
+		{
+			name: "found with render_js",
+			want: &entity.Source{
+				ID: 1, Name: "Golang Weekly",
+				FeedURL:  "https://example.com/feed.xml",
+				Category: "dev", Lang: "en", Kind: "rss", Active: true,
+				RenderJS: true, CreatedAt: now,
+			},
+		},
+		{
+			name: "found with feed validators",
+			want: &entity.Source{
+				ID: 1, Name: "Golang Weekly",
+				FeedURL:  "https://example.com/feed.xml",
+				Category: "dev", Lang: "en", Kind: "rss", Active: true,
+				ETag: `"abc"`, LastModified: "Mon, 01 Jan 2024 00:00:00 GMT", CreatedAt: now,
+			},
+		},
 		{
 			name: "not found returns nil, nil",
 			rows: sqlmock.NewRows(sourceCols),
@@ -135,7 +195,7 @@ func TestSourceRepo_List_ScanError(t *testing.T) {
 
 	mock.ExpectQuery("FROM sources").
 		WillReturnRows(sqlmock.NewRows(sourceCols).
-			AddRow("not-an-int", "n", "u", "dev", "en", "rss", true, time.Now()))
+			AddRow("not-an-int", "n", "u", "dev", "en", "rss", true, nil, nil, false, nil, nil, time.Now(), nil))
 
 	_, err := repo.List(context.Background())
 	assert.Error(t, err)
@@ -269,7 +329,7 @@ func TestSourceRepo_Create(t *testing.T) {
 
 			now := time.Now()
 			mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO sources")).
-				WithArgs(tt.source.Name, tt.source.FeedURL, tt.source.Category, tt.wantLang, tt.wantKind, true).
+				WithArgs(tt.source.Name, tt.source.FeedURL, tt.source.Category, tt.wantLang, tt.wantKind, true, tt.source.CrawlSchedule, tt.source.RetentionDays, tt.source.RenderJS).
 				WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(int64(5), now))
 
 			err := repo.Create(context.Background(), tt.source)
@@ -299,13 +359,16 @@ func TestSourceRepo_Update(t *testing.T) {
 	repo, mock, closeFn := newSourceRepo(t)
 	defer closeFn()
 
+	schedule := "*/30 * * * *"
+	retention := 30
 	mock.ExpectExec("UPDATE sources").
-		WithArgs("new", "https://u", "ai", "en", "youtube", false, int64(1)).
+		WithArgs("new", "https://u", "ai", "en", "youtube", false, &schedule, &retention, true, int64(1)).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	err := repo.Update(context.Background(), &entity.Source{
 		ID: 1, Name: "new", FeedURL: "https://u",
 		Category: "ai", Lang: "en", Kind: "youtube", Active: false,
+		CrawlSchedule: &schedule, RetentionDays: &retention, RenderJS: true,
 	})
 	require.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -324,6 +387,30 @@ func TestSourceRepo_Update_NoRowsAffected(t *testing.T) {
 	assert.Error(t, err)
 }
 
+/* ─────────────────────────── UpdateFeedValidators ─────────────────────────── */
+
+func TestSourceRepo_UpdateFeedValidators(t *testing.T) {
+	repo, mock, closeFn := newSourceRepo(t)
+	defer closeFn()
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE sources SET etag = NULLIF($1, ''), last_modified = NULLIF($2, '')")).
+		WithArgs(`"v1"`, "", int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.UpdateFeedValidators(context.Background(), 1, `"v1"`, ""))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceRepo_UpdateFeedValidators_DatabaseError(t *testing.T) {
+	repo, mock, closeFn := newSourceRepo(t)
+	defer closeFn()
+
+	mock.ExpectExec("UPDATE sources SET etag").
+		WillReturnError(errors.New("db down"))
+
+	assert.Error(t, repo.UpdateFeedValidators(context.Background(), 1, "", ""))
+}
+
 func TestSourceRepo_Delete(t *testing.T) {
 	repo, mock, closeFn := newSourceRepo(t)
 	defer closeFn()
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// SourceSubscriptionRepo persists per-user source subscriptions
+// (source_subscriptions table).
+type SourceSubscriptionRepo struct{ db *sql.DB }
+
+func NewSourceSubscriptionRepo(db *sql.DB) repository.SourceSubscriptionRepository {
+	return &SourceSubscriptionRepo{db: db}
+}
+
+// Subscribe inserts the subscription. Like FavoriteRepo.Add, selecting
+// from sources turns an unknown source into "no row" instead of a foreign
+// key error; an existing subscription keeps its created_at.
+func (repo *SourceSubscriptionRepo) Subscribe(ctx context.Context, userID, sourceID int64) (bool, error) {
+	const query = `
+WITH ins AS (
+    INSERT INTO source_subscriptions (user_id, source_id)
+    SELECT $1, s.id FROM sources s WHERE s.id = $2
+    ON CONFLICT (user_id, source_id) DO NOTHING
+)
+SELECT EXISTS (SELECT 1 FROM sources WHERE id = $2)`
+	var exists bool
+	if err := repo.db.QueryRowContext(ctx, query, userID, sourceID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("Subscribe: %w", err)
+	}
+	return exists, nil
+}
+
+// Unsubscribe deletes the subscription if any.
+func (repo *SourceSubscriptionRepo) Unsubscribe(ctx context.Context, userID, sourceID int64) error {
+	const query = `DELETE FROM source_subscriptions WHERE user_id = $1 AND source_id = $2`
+	if _, err := repo.db.ExecContext(ctx, query, userID, sourceID); err != nil {
+		return fmt.Errorf("Unsubscribe: %w", err)
+	}
+	return nil
+}
+
+func (repo *SourceSubscriptionRepo) ListSources(ctx context.Context, userID int64) ([]*entity.Source, error) {
+	query := `
+SELECT ` + sourceColumns + `
+FROM sources
+WHERE id IN (SELECT source_id FROM source_subscriptions WHERE user_id = $1)
+ORDER BY id ASC`
+	return querySources(ctx, repo.db, "ListSources", query, userID)
+}
+
+func (repo *SourceSubscriptionRepo) SourceIDs(ctx context.Context, userID int64) (map[int64]bool, error) {
+	const query = `SELECT source_id FROM source_subscriptions WHERE user_id = $1`
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("SourceIDs: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	ids := make(map[int64]bool)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("SourceIDs: %w", err)
+		}
+		ids[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("SourceIDs: %w", err)
+	}
+	return ids, nil
+}
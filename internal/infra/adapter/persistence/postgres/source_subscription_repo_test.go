@@ -0,0 +1,87 @@
+package postgres_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+	"catchup-feed/internal/repository"
+)
+
+func newSourceSubscriptionRepo(t *testing.T) (repository.SourceSubscriptionRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return pg.NewSourceSubscriptionRepo(db), mock, func() { _ = db.Close() }
+}
+
+func TestSourceSubscriptionRepo_Subscribe(t *testing.T) {
+	repo, mock, closeFn := newSourceSubscriptionRepo(t)
+	defer closeFn()
+
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO source_subscriptions")).
+		WithArgs(int64(1), int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO source_subscriptions")).
+		WithArgs(int64(1), int64(99)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	ok, err := repo.Subscribe(context.Background(), 1, 3)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = repo.Subscribe(context.Background(), 1, 99)
+	require.NoError(t, err)
+	assert.False(t, ok, "unknown source")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceSubscriptionRepo_Unsubscribe(t *testing.T) {
+	repo, mock, closeFn := newSourceSubscriptionRepo(t)
+	defer closeFn()
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM source_subscriptions WHERE user_id = $1 AND source_id = $2")).
+		WithArgs(int64(1), int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	require.NoError(t, repo.Unsubscribe(context.Background(), 1, 3))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceSubscriptionRepo_ListSources(t *testing.T) {
+	repo, mock, closeFn := newSourceSubscriptionRepo(t)
+	defer closeFn()
+
+	src := &entity.Source{ID: 3, Name: "Go Blog", FeedURL: "https://go.dev/blog/feed.atom", Kind: "rss", Active: true,
+		CreatedAt: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)}
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE id IN (SELECT source_id FROM source_subscriptions WHERE user_id = $1)")).
+		WithArgs(int64(1)).
+		WillReturnRows(srcRow(src))
+
+	got, err := repo.ListSources(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "Go Blog", got[0].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSourceSubscriptionRepo_SourceIDs(t *testing.T) {
+	repo, mock, closeFn := newSourceSubscriptionRepo(t)
+	defer closeFn()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT source_id FROM source_subscriptions WHERE user_id = $1")).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"source_id"}).AddRow(int64(3)).AddRow(int64(5)))
+
+	got, err := repo.SourceIDs(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]bool{3: true, 5: true}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
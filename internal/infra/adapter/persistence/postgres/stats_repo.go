@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// StatsRepo computes the dashboard aggregates in one SQL pass each
+// (single-user scale, no metrics stack — 設計原則 1). All queries are
+// read-only and go to replica.Reader().
+type StatsRepo struct{ replica ReadDB }
+
+func NewStatsRepo(replica ReadDB) repository.StatsRepository {
+	return &StatsRepo{replica: replica}
+}
+
+// ArticleCountsBySource LEFT JOINs so sources without articles appear
+// with zero counts.
+func (repo *StatsRepo) ArticleCountsBySource(ctx context.Context, since time.Time) ([]*entity.SourceArticleStats, error) {
+	const query = `
+SELECT s.id, s.name, s.active,
+       COUNT(a.id) AS total,
+       COUNT(a.id) FILTER (WHERE a.crawled_at >= $1) AS recent,
+       MAX(a.crawled_at) AS last_crawled_at
+FROM sources s
+LEFT JOIN articles a ON a.source_id = s.id
+GROUP BY s.id, s.name, s.active
+ORDER BY s.id ASC`
+	rows, err := repo.replica.Reader().QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("ArticleCountsBySource: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	stats := make([]*entity.SourceArticleStats, 0, 20)
+	for rows.Next() {
+		var s entity.SourceArticleStats
+		if err := rows.Scan(&s.SourceID, &s.SourceName, &s.Active, &s.Total, &s.Recent, &s.LastCrawledAt); err != nil {
+			return nil, fmt.Errorf("ArticleCountsBySource: %w", err)
+		}
+		stats = append(stats, &s)
+	}
+	return stats, rows.Err()
+}
+
+// ArticleCountsByPeriod buckets on JST days: each bucket start is a
+// JST-wall-clock midnight, reinterpreted as a timestamptz with AT TIME
+// ZONE 'Asia/Tokyo' (same convention as LearningRepo). from and to are
+// taken as calendar days in their own location; the usecase passes JST.
+func (repo *StatsRepo) ArticleCountsByPeriod(ctx context.Context, from, to time.Time, stepDays int) ([]entity.ArticleCountBucket, error) {
+	const query = `
+SELECT to_char(b.day, 'YYYY-MM-DD'), COUNT(a.id)
+FROM generate_series($1::date::timestamp, $2::date::timestamp, make_interval(days => $3::int)) AS b(day)
+LEFT JOIN articles a
+       ON a.crawled_at >= b.day AT TIME ZONE 'Asia/Tokyo'
+      AND a.crawled_at <  (b.day + make_interval(days => $3::int)) AT TIME ZONE 'Asia/Tokyo'
+GROUP BY b.day
+ORDER BY b.day ASC`
+	rows, err := repo.replica.Reader().QueryContext(ctx, query,
+		from.Format(time.DateOnly), to.Format(time.DateOnly), stepDays)
+	if err != nil {
+		return nil, fmt.Errorf("ArticleCountsByPeriod: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	buckets := make([]entity.ArticleCountBucket, 0, 32)
+	for rows.Next() {
+		var b entity.ArticleCountBucket
+		if err := rows.Scan(&b.Day, &b.Count); err != nil {
+			return nil, fmt.Errorf("ArticleCountsByPeriod: %w", err)
+		}
+		buckets = append(buckets, b)
+	}
+	return buckets, rows.Err()
+}
+
+// CrawlRunStats counts runs by status and derives the durations of the
+// finished ones (finished_at - started_at, in seconds).
+func (repo *StatsRepo) CrawlRunStats(ctx context.Context, since time.Time) (*entity.CrawlRunStats, error) {
+	const query = `
+SELECT COUNT(*),
+       COUNT(*) FILTER (WHERE status = 'succeeded'),
+       COUNT(*) FILTER (WHERE status = 'failed'),
+       COUNT(*) FILTER (WHERE finished_at IS NULL),
+       COALESCE(SUM(inserted), 0),
+       COALESCE(SUM(summarize_errors), 0),
+       AVG(d.sec),
+       percentile_cont(0.95) WITHIN GROUP (ORDER BY d.sec),
+       MAX(d.sec)
+FROM crawl_runs r
+CROSS JOIN LATERAL (SELECT EXTRACT(EPOCH FROM r.finished_at - r.started_at)::float8 AS sec) d
+WHERE r.started_at >= $1`
+	var s entity.CrawlRunStats
+	err := repo.replica.Reader().QueryRowContext(ctx, query, since).Scan(
+		&s.Runs, &s.Succeeded, &s.Failed, &s.Running,
+		&s.Inserted, &s.SummarizeErrors,
+		&s.AvgDurationSec, &s.P95DurationSec, &s.MaxDurationSec,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("CrawlRunStats: %w", err)
+	}
+	return &s, nil
+}
@@ -0,0 +1,80 @@
+package postgres_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+)
+
+func TestStatsRepo_ArticleCountsBySource(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	last := since.Add(36 * time.Hour)
+	mock.ExpectQuery(regexp.QuoteMeta("LEFT JOIN articles a ON a.source_id = s.id")).
+		WithArgs(since).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "active", "total", "recent", "last_crawled_at"}).
+			AddRow(int64(1), "Go Blog", true, int64(10), int64(3), last).
+			AddRow(int64(2), "新規ソース", true, int64(0), int64(0), nil))
+
+	got, err := pg.NewStatsRepo(fixedReader{db}).ArticleCountsBySource(context.Background(), since)
+	require.NoError(t, err)
+	require.Len(t, got, 2, "sources without articles still appear")
+	assert.Equal(t, int64(10), got[0].Total)
+	assert.Equal(t, int64(3), got[0].Recent)
+	require.NotNil(t, got[0].LastCrawledAt)
+	assert.Nil(t, got[1].LastCrawledAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsRepo_ArticleCountsByPeriod(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	jst := time.FixedZone("JST", 9*60*60)
+	from := time.Date(2026, 10, 5, 0, 0, 0, 0, jst)
+	to := time.Date(2026, 10, 12, 0, 0, 0, 0, jst)
+	mock.ExpectQuery(regexp.QuoteMeta("generate_series($1::date::timestamp, $2::date::timestamp")).
+		WithArgs("2026-10-05", "2026-10-12", 7).
+		WillReturnRows(sqlmock.NewRows([]string{"day", "count"}).
+			AddRow("2026-10-05", int64(12)).
+			AddRow("2026-10-12", int64(0)))
+
+	got, err := pg.NewStatsRepo(fixedReader{db}).ArticleCountsByPeriod(context.Background(), from, to, 7)
+	require.NoError(t, err)
+	assert.Equal(t, []entity.ArticleCountBucket{{Day: "2026-10-05", Count: 12}, {Day: "2026-10-12", Count: 0}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsRepo_CrawlRunStats(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("percentile_cont(0.95)")).
+		WithArgs(since).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"runs", "succeeded", "failed", "running", "inserted", "summarize_errors", "avg", "p95", "max",
+		}).AddRow(int64(24), int64(22), int64(1), int64(1), int64(120), int64(6), 41.5, 88.0, 95.2))
+
+	got, err := pg.NewStatsRepo(fixedReader{db}).CrawlRunStats(context.Background(), since)
+	require.NoError(t, err)
+	assert.Equal(t, int64(24), got.Runs)
+	assert.Equal(t, int64(1), got.Running)
+	assert.Equal(t, int64(6), got.SummarizeErrors)
+	require.NotNil(t, got.P95DurationSec)
+	assert.InDelta(t, 88.0, *got.P95DurationSec, 1e-9)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,266 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// TagRepo persists tags and article_tags.
+type TagRepo struct{ db *sql.DB }
+
+func NewTagRepo(db *sql.DB) repository.TagRepository {
+	return &TagRepo{db: db}
+}
+
+// mapTagErr converts a unique_violation on tags.name into the repository
+// sentinel so the use case can answer 409 instead of 500.
+func mapTagErr(op string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		return fmt.Errorf("%s: %w", op, repository.ErrDuplicateTagName)
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
+
+func (repo *TagRepo) queryTags(ctx context.Context, op, query string, args ...any) ([]*entity.Tag, error) {
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	tags := make([]*entity.Tag, 0)
+	for rows.Next() {
+		var t entity.Tag
+		if err := rows.Scan(&t.ID, &t.Name, &t.CreatedAt, &t.ArticleCount); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		tags = append(tags, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return tags, nil
+}
+
+// List returns every tag with its article count.
+func (repo *TagRepo) List(ctx context.Context) ([]*entity.Tag, error) {
+	const query = `
+SELECT t.id, t.name, t.created_at, count(at.article_id)
+FROM tags t
+LEFT JOIN article_tags at ON at.tag_id = t.id
+GROUP BY t.id
+ORDER BY t.name`
+	return repo.queryTags(ctx, "List", query)
+}
+
+// Get returns the tag, or nil when there is none.
+func (repo *TagRepo) Get(ctx context.Context, id int64) (*entity.Tag, error) {
+	const query = `
+SELECT t.id, t.name, t.created_at,
+       (SELECT count(*) FROM article_tags at WHERE at.tag_id = t.id)
+FROM tags t
+WHERE t.id = $1`
+	var t entity.Tag
+	err := repo.db.QueryRowContext(ctx, query, id).Scan(&t.ID, &t.Name, &t.CreatedAt, &t.ArticleCount)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Get: %w", err)
+	}
+	return &t, nil
+}
+
+// Create inserts the tag and sets tag.ID / CreatedAt.
+func (repo *TagRepo) Create(ctx context.Context, tag *entity.Tag) error {
+	err := repo.db.QueryRowContext(ctx,
+		`INSERT INTO tags (name) VALUES ($1) RETURNING id, created_at`, tag.Name,
+	).Scan(&tag.ID, &tag.CreatedAt)
+	if err != nil {
+		return mapTagErr("Create", err)
+	}
+	return nil
+}
+
+// Update renames the tag.
+func (repo *TagRepo) Update(ctx context.Context, tag *entity.Tag) error {
+	if _, err := repo.db.ExecContext(ctx,
+		`UPDATE tags SET name = $2 WHERE id = $1`, tag.ID, tag.Name); err != nil {
+		return mapTagErr("Update", err)
+	}
+	return nil
+}
+
+// Delete removes the tag; article_tags rows go with it (ON DELETE CASCADE).
+func (repo *TagRepo) Delete(ctx context.Context, id int64) error {
+	if _, err := repo.db.ExecContext(ctx, `DELETE FROM tags WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("Delete: %w", err)
+	}
+	return nil
+}
+
+// ListByArticle returns the article's tags ordered by name.
+func (repo *TagRepo) ListByArticle(ctx context.Context, articleID int64) ([]*entity.Tag, error) {
+	const query = `
+SELECT t.id, t.name, t.created_at,
+       (SELECT count(*) FROM article_tags c WHERE c.tag_id = t.id)
+FROM tags t
+INNER JOIN article_tags at ON at.tag_id = t.id
+WHERE at.article_id = $1
+ORDER BY t.name`
+	return repo.queryTags(ctx, "ListByArticle", query, articleID)
+}
+
+// SetArticleTags replaces the article's assignments. Missing tags are
+// upserted (the no-op DO UPDATE makes RETURNING yield existing rows too),
+// so concurrent requests naming the same new tag do not collide.
+func (repo *TagRepo) SetArticleTags(ctx context.Context, articleID int64, names []string) ([]*entity.Tag, error) {
+	tx, err := beginTx(ctx, repo.db)
+	if err != nil {
+		return nil, fmt.Errorf("SetArticleTags: begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM article_tags WHERE article_id = $1`, articleID); err != nil {
+		return nil, fmt.Errorf("SetArticleTags: clear: %w", err)
+	}
+
+	const upsertTag = `
+INSERT INTO tags (name) VALUES ($1)
+ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+RETURNING id, created_at`
+	tags := make([]*entity.Tag, 0, len(names))
+	for _, name := range names {
+		t := &entity.Tag{Name: name}
+		if err := tx.QueryRowContext(ctx, upsertTag, name).Scan(&t.ID, &t.CreatedAt); err != nil {
+			return nil, fmt.Errorf("SetArticleTags: tag: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO article_tags (article_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+			articleID, t.ID); err != nil {
+			return nil, fmt.Errorf("SetArticleTags: assign: %w", err)
+		}
+		tags = append(tags, t)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("SetArticleTags: commit: %w", err)
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags, nil
+}
+
+// UpdateArticlesTags applies one tag change to many articles in a single
+// transaction. Missing article IDs are skipped: the INSERT selects from
+// articles instead of failing on the foreign key.
+func (repo *TagRepo) UpdateArticlesTags(ctx context.Context, articleIDs []int64, add, remove []string) ([]int64, error) {
+	if len(articleIDs) == 0 {
+		return []int64{}, nil
+	}
+	tx, err := beginTx(ctx, repo.db)
+	if err != nil {
+		return nil, fmt.Errorf("UpdateArticlesTags: begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	in, idArgs := idPlaceholders(articleIDs, 1)
+	// #nosec G201 -- in contains only generated $N placeholders.
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`SELECT id FROM articles WHERE id IN (%s) ORDER BY id`, in), idArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("UpdateArticlesTags: articles: %w", err)
+	}
+	existing := make([]int64, 0, len(articleIDs))
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("UpdateArticlesTags: Scan: %w", err)
+		}
+		existing = append(existing, id)
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return nil, fmt.Errorf("UpdateArticlesTags: articles: %w", err)
+	}
+	_ = rows.Close()
+	if len(existing) == 0 {
+		return existing, nil
+	}
+
+	in, idArgs = idPlaceholders(existing, 2)
+	for _, name := range remove {
+		// #nosec G201 -- in contains only generated $N placeholders.
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`
+DELETE FROM article_tags at
+USING tags t
+WHERE t.id = at.tag_id AND t.name = $1 AND at.article_id IN (%s)`, in),
+			append([]any{name}, idArgs...)...); err != nil {
+			return nil, fmt.Errorf("UpdateArticlesTags: remove: %w", err)
+		}
+	}
+
+	const upsertTag = `
+INSERT INTO tags (name) VALUES ($1)
+ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+RETURNING id`
+	for _, name := range add {
+		var tagID int64
+		if err := tx.QueryRowContext(ctx, upsertTag, name).Scan(&tagID); err != nil {
+			return nil, fmt.Errorf("UpdateArticlesTags: tag: %w", err)
+		}
+		// #nosec G201 -- in contains only generated $N placeholders.
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`
+INSERT INTO article_tags (article_id, tag_id)
+SELECT a.id, $1 FROM articles a WHERE a.id IN (%s)
+ON CONFLICT DO NOTHING`, in),
+			append([]any{tagID}, idArgs...)...); err != nil {
+			return nil, fmt.Errorf("UpdateArticlesTags: assign: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("UpdateArticlesTags: commit: %w", err)
+	}
+	return existing, nil
+}
+
+// ListTopBySource ranks tags by how many of the source's articles carry
+// them.
+func (repo *TagRepo) ListTopBySource(ctx context.Context, sourceID int64, limit int) ([]string, error) {
+	const query = `
+SELECT t.name
+FROM article_tags at
+INNER JOIN tags t ON t.id = at.tag_id
+INNER JOIN articles a ON a.id = at.article_id
+WHERE a.source_id = $1
+GROUP BY t.name
+ORDER BY count(*) DESC, t.name
+LIMIT $2`
+	rows, err := repo.db.QueryContext(ctx, query, sourceID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ListTopBySource: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("ListTopBySource: %w", err)
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ListTopBySource: %w", err)
+	}
+	return names, nil
+}
@@ -0,0 +1,155 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+	"catchup-feed/internal/repository"
+)
+
+func newTagRepo(t *testing.T) (repository.TagRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return pg.NewTagRepo(db), mock, func() { _ = db.Close() }
+}
+
+func TestTagRepo_List(t *testing.T) {
+	repo, mock, closeFn := newTagRepo(t)
+	defer closeFn()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("LEFT JOIN article_tags at ON at.tag_id = t.id")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "count"}).
+			AddRow(int64(1), "go", now, int64(3)).
+			AddRow(int64(2), "postgres", now, int64(0)))
+
+	got, err := repo.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "go", got[0].Name)
+	assert.Equal(t, int64(3), got[0].ArticleCount)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTagRepo_Create_Duplicate(t *testing.T) {
+	repo, mock, closeFn := newTagRepo(t)
+	defer closeFn()
+
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO tags (name)")).
+		WithArgs("go").
+		WillReturnError(&pgconn.PgError{Code: "23505"})
+
+	err := repo.Create(context.Background(), &entity.Tag{Name: "go"})
+	assert.ErrorIs(t, err, repository.ErrDuplicateTagName)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTagRepo_SetArticleTags(t *testing.T) {
+	repo, mock, closeFn := newTagRepo(t)
+	defer closeFn()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM article_tags WHERE article_id = $1")).
+		WithArgs(int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	for i, name := range []string{"postgres", "go"} {
+		mock.ExpectQuery(regexp.QuoteMeta("ON CONFLICT (name) DO UPDATE")).
+			WithArgs(name).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(int64(i+1), now))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO article_tags")).
+			WithArgs(int64(7), int64(i+1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+
+	got, err := repo.SetArticleTags(context.Background(), 7, []string{"postgres", "go"})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "go", got[0].Name, "sorted by name")
+	assert.Equal(t, int64(2), got[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestTagRepo_SetArticleTags_RollsBack: 途中で失敗したら既存の付与も残す。
+func TestTagRepo_SetArticleTags_RollsBack(t *testing.T) {
+	repo, mock, closeFn := newTagRepo(t)
+	defer closeFn()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM article_tags")).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO tags")).
+		WillReturnError(errors.New("db down"))
+	mock.ExpectRollback()
+
+	_, err := repo.SetArticleTags(context.Background(), 7, []string{"go"})
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTagRepo_UpdateArticlesTags(t *testing.T) {
+	repo, mock, closeFn := newTagRepo(t)
+	defer closeFn()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM articles WHERE id IN ($1, $2, $3)")).
+		WithArgs(int64(1), int64(2), int64(99)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)).AddRow(int64(2)))
+	mock.ExpectExec(regexp.QuoteMeta("t.name = $1 AND at.article_id IN ($2, $3)")).
+		WithArgs("draft", int64(1), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("ON CONFLICT (name) DO UPDATE")).
+		WithArgs("go").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(5)))
+	mock.ExpectExec(regexp.QuoteMeta("SELECT a.id, $1 FROM articles a WHERE a.id IN ($2, $3)")).
+		WithArgs(int64(5), int64(1), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	got, err := repo.UpdateArticlesTags(context.Background(), []int64{1, 2, 99}, []string{"go"}, []string{"draft"})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestTagRepo_UpdateArticlesTags_NoArticles: 対象記事がなければ何も変更しない。
+func TestTagRepo_UpdateArticlesTags_NoArticles(t *testing.T) {
+	repo, mock, closeFn := newTagRepo(t)
+	defer closeFn()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM articles")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+
+	got, err := repo.UpdateArticlesTags(context.Background(), []int64{99}, []string{"go"}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTagRepo_ListTopBySource(t *testing.T) {
+	repo, mock, closeFn := newTagRepo(t)
+	defer closeFn()
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE a.source_id = $1")).
+		WithArgs(int64(3), 5).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("go").AddRow("release"))
+
+	got, err := repo.ListTopBySource(context.Background(), 3, 5)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go", "release"}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
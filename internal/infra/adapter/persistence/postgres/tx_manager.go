@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"catchup-feed/internal/repository"
+)
+
+// txKey carries the *sql.Tx of TxManager.WithinTx through the context.
+type txKey struct{}
+
+// executor is what both *sql.DB and *sql.Tx offer; repository methods that
+// take part in a unit of work run their statements on conn(ctx, db).
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// TxManager implements repository.TxManager on the primary pool.
+type TxManager struct{ db *sql.DB }
+
+func NewTxManager(db *sql.DB) repository.TxManager {
+	return &TxManager{db: db}
+}
+
+// WithinTx runs fn in a transaction carried by ctx. A WithinTx inside fn
+// joins the running transaction, so use cases compose.
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("WithinTx: begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("WithinTx: commit: %w", err)
+	}
+	return nil
+}
+
+// conn returns the transaction of a surrounding WithinTx, or db.
+func conn(ctx context.Context, db *sql.DB) executor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// scopedTx is a transaction opened by a repository method. Joined to a
+// surrounding WithinTx, Commit and Rollback are no-ops: the outer unit of
+// work decides, and a failed statement aborts it anyway.
+type scopedTx struct {
+	*sql.Tx
+	owned bool
+}
+
+// beginTx opens a transaction for a multi-statement repository method, or
+// joins the one carried by ctx.
+func beginTx(ctx context.Context, db *sql.DB) (*scopedTx, error) {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return &scopedTx{Tx: tx}, nil
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &scopedTx{Tx: tx, owned: true}, nil
+}
+
+func (t *scopedTx) Commit() error {
+	if !t.owned {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+func (t *scopedTx) Rollback() error {
+	if !t.owned {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
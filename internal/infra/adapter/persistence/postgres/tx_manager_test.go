@@ -0,0 +1,119 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+)
+
+/* ───────── 記事 + 配信を 1 トランザクションで ───────── */
+
+func TestTxManager_WithinTx_Commit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	articles, webhooks := pg.NewArticleRepo(db), pg.NewWebhookRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO articles")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(7)))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO webhook_deliveries")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = pg.NewTxManager(db).WithinTx(context.Background(), func(ctx context.Context) error {
+		art := &entity.Article{SourceID: 1, Title: "t", URL: "https://u", CrawledAt: time.Now()}
+		if err := articles.Create(ctx, art); err != nil {
+			return err
+		}
+		_, err := webhooks.CreateDeliveries(ctx, entity.WebhookEventArticleCreated, []byte(`{}`))
+		return err
+	})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTxManager_WithinTx_RollbackOnError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	articles, webhooks := pg.NewArticleRepo(db), pg.NewWebhookRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO articles")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(7)))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO webhook_deliveries")).
+		WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	err = pg.NewTxManager(db).WithinTx(context.Background(), func(ctx context.Context) error {
+		art := &entity.Article{SourceID: 1, Title: "t", URL: "https://u", CrawledAt: time.Now()}
+		if err := articles.Create(ctx, art); err != nil {
+			return err
+		}
+		_, err := webhooks.CreateDeliveries(ctx, entity.WebhookEventArticleCreated, []byte(`{}`))
+		return err
+	})
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestTxManager_WithinTx_JoinsRepositoryTx pins the join: a repository
+// method that opens its own transaction runs inside the outer one, which
+// alone commits.
+func TestTxManager_WithinTx_JoinsRepositoryTx(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	articles := pg.NewArticleRepo(db)
+	tm := pg.NewTxManager(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO articles")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(7)))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO summaries")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO jobs")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	mock.ExpectCommit()
+
+	err = tm.WithinTx(context.Background(), func(ctx context.Context) error {
+		art := &entity.Article{SourceID: 1, Title: "t", URL: "https://u", CrawledAt: time.Now()}
+		if err := articles.CreateWithSummary(ctx, art, &entity.Summary{Body: "要約"}); err != nil {
+			return err
+		}
+		// ネストした WithinTx も同じトランザクションに入る。
+		return tm.WithinTx(ctx, func(ctx context.Context) error {
+			_, err := pg.NewJobRepo(db).Enqueue(ctx, entity.JobKindDeliverWebhook, nil, time.Time{})
+			return err
+		})
+	})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTxManager_WithinTx_BeginError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mock.ExpectBegin().WillReturnError(errors.New("too many connections"))
+
+	called := false
+	err = pg.NewTxManager(db).WithinTx(context.Background(), func(context.Context) error {
+		called = true
+		return nil
+	})
+	assert.Error(t, err)
+	assert.False(t, called)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
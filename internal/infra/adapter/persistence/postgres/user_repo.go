@@ -0,0 +1,186 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+const userColumns = "id, name, email, role, password_hash, created_at, updated_at, deactivated_at"
+
+// UserRepo persists dashboard accounts of every role (users table).
+type UserRepo struct{ db *sql.DB }
+
+func NewUserRepo(db *sql.DB) repository.UserRepository {
+	return &UserRepo{db: db}
+}
+
+func scanUser(s scanner) (*entity.User, error) {
+	var user entity.User
+	if err := s.Scan(
+		&user.ID, &user.Name, &user.Email, &user.Role, &user.PasswordHash,
+		&user.CreatedAt, &user.UpdatedAt, &user.DeactivatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// mapUserErr converts a unique_violation on users.email into the
+// repository sentinel so the use case can answer 409 instead of 500.
+func mapUserErr(op string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		return fmt.Errorf("%s: %w", op, repository.ErrDuplicateUserEmail)
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
+
+// Create inserts the user and sets user.ID / CreatedAt / UpdatedAt.
+func (repo *UserRepo) Create(ctx context.Context, user *entity.User) error {
+	const query = `
+INSERT INTO users (name, email, role, password_hash)
+VALUES ($1, $2, $3, $4)
+RETURNING id, created_at, updated_at`
+	err := repo.db.QueryRowContext(ctx, query,
+		user.Name, user.Email, user.Role, user.PasswordHash,
+	).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+	if err != nil {
+		return mapUserErr("Create", err)
+	}
+	return nil
+}
+
+// Get returns the user, or nil when not found.
+func (repo *UserRepo) Get(ctx context.Context, id int64) (*entity.User, error) {
+	query := `
+SELECT ` + userColumns + `
+FROM users
+WHERE id = $1
+LIMIT 1`
+	user, err := scanUser(repo.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Get: %w", err)
+	}
+	return user, nil
+}
+
+// List returns all users (every role, active and deactivated), oldest first.
+func (repo *UserRepo) List(ctx context.Context) ([]*entity.User, error) {
+	query := `
+SELECT ` + userColumns + `
+FROM users
+ORDER BY id ASC`
+	rows, err := repo.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("List: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	users := make([]*entity.User, 0, 10)
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("List: %w", err)
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+// Update rewrites name / email / role / password_hash and bumps updated_at.
+func (repo *UserRepo) Update(ctx context.Context, user *entity.User) error {
+	const query = `
+UPDATE users SET
+       name          = $1,
+       email         = $2,
+       role          = $3,
+       password_hash = $4,
+       updated_at    = now()
+WHERE id = $5`
+	res, err := repo.db.ExecContext(ctx, query,
+		user.Name, user.Email, user.Role, user.PasswordHash, user.ID,
+	)
+	if err != nil {
+		return mapUserErr("Update", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("Update: no rows affected")
+	}
+	return nil
+}
+
+// Deactivate marks the user inactive as of t (idempotent).
+func (repo *UserRepo) Deactivate(ctx context.Context, id int64, t time.Time) error {
+	const query = `
+UPDATE users SET deactivated_at = $1, updated_at = now()
+WHERE id = $2 AND deactivated_at IS NULL`
+	if _, err := repo.db.ExecContext(ctx, query, t, id); err != nil {
+		return fmt.Errorf("Deactivate: %w", err)
+	}
+	return nil
+}
+
+// Reactivate clears deactivated_at (idempotent).
+func (repo *UserRepo) Reactivate(ctx context.Context, id int64) error {
+	const query = `
+UPDATE users SET deactivated_at = NULL, updated_at = now()
+WHERE id = $1 AND deactivated_at IS NOT NULL`
+	if _, err := repo.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("Reactivate: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the user row physically.
+func (repo *UserRepo) Delete(ctx context.Context, id int64) error {
+	const query = `DELETE FROM users WHERE id = $1`
+	res, err := repo.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("Delete: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("Delete: no rows affected")
+	}
+	return nil
+}
+
+// GetActiveByEmail returns the active user with the given email, or nil.
+func (repo *UserRepo) GetActiveByEmail(ctx context.Context, email string) (*entity.User, error) {
+	query := `
+SELECT ` + userColumns + `
+FROM users
+WHERE email = $1 AND deactivated_at IS NULL
+LIMIT 1`
+	user, err := scanUser(repo.db.QueryRowContext(ctx, query, email))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetActiveByEmail: %w", err)
+	}
+	return user, nil
+}
+
+// CountActiveAdmins returns the number of active role=admin users.
+func (repo *UserRepo) CountActiveAdmins(ctx context.Context) (int, error) {
+	const query = `
+SELECT COUNT(*)
+FROM users
+WHERE role = 'admin' AND deactivated_at IS NULL`
+	var n int
+	if err := repo.db.QueryRowContext(ctx, query).Scan(&n); err != nil {
+		return 0, fmt.Errorf("CountActiveAdmins: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,84 @@
+package postgres_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+	"catchup-feed/internal/repository"
+)
+
+var userCols = []string{"id", "name", "email", "role", "password_hash", "created_at", "updated_at", "deactivated_at"}
+
+func newUserRepo(t *testing.T) (repository.UserRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return pg.NewUserRepo(db), mock, func() { _ = db.Close() }
+}
+
+func TestUserRepo_Create(t *testing.T) {
+	repo, mock, closeFn := newUserRepo(t)
+	defer closeFn()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO users (name, email, role, password_hash)")).
+		WithArgs("Root", "root@example.com", "admin", "hash").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(int64(3), now, now))
+
+	user := &entity.User{Name: "Root", Email: "root@example.com", Role: "admin", PasswordHash: "hash"}
+	require.NoError(t, repo.Create(context.Background(), user))
+	assert.Equal(t, int64(3), user.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepo_Create_DuplicateEmail(t *testing.T) {
+	repo, mock, closeFn := newUserRepo(t)
+	defer closeFn()
+
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO users")).
+		WillReturnError(&pgconn.PgError{Code: "23505"})
+
+	err := repo.Create(context.Background(), &entity.User{Name: "Root", Email: "root@example.com", Role: "admin", PasswordHash: "hash"})
+	assert.ErrorIs(t, err, repository.ErrDuplicateUserEmail)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepo_GetActiveByEmail(t *testing.T) {
+	repo, mock, closeFn := newUserRepo(t)
+	defer closeFn()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE email = $1 AND deactivated_at IS NULL")).
+		WithArgs("root@example.com").
+		WillReturnRows(sqlmock.NewRows(userCols).
+			AddRow(int64(1), "Root", "root@example.com", "admin", "hash", now, now, nil))
+
+	got, err := repo.GetActiveByEmail(context.Background(), "root@example.com")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "admin", got.Role)
+	assert.True(t, got.IsActive())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepo_CountActiveAdmins(t *testing.T) {
+	repo, mock, closeFn := newUserRepo(t)
+	defer closeFn()
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE role = 'admin' AND deactivated_at IS NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	n, err := repo.CountActiveAdmins(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -18,7 +18,9 @@ const viewerColumns = "id, name, email, password_hash, created_at, updated_at, d
 // pgUniqueViolation is the PostgreSQL error code for unique_violation.
 const pgUniqueViolation = "23505"
 
-// ViewerRepo persists read-only dashboard accounts (viewers table, D-27).
+// ViewerRepo persists read-only dashboard accounts (D-27): the role=viewer
+// rows of the users table. Every statement is scoped by role so the viewer
+// API can never read or modify an administrator.
 type ViewerRepo struct{ db *sql.DB }
 
 func NewViewerRepo(db *sql.DB) repository.ViewerRepository {
@@ -36,7 +38,7 @@ func scanViewer(s scanner) (*entity.Viewer, error) {
 	return &viewer, nil
 }
 
-// mapViewerErr converts a unique_violation on viewers.email into the
+// mapViewerErr converts a unique_violation on users.email into the
 // repository sentinel so the use case can answer 409 instead of 500.
 func mapViewerErr(op string, err error) error {
 	var pgErr *pgconn.PgError
@@ -49,8 +51,8 @@ func mapViewerErr(op string, err error) error {
 // Create inserts the viewer and sets viewer.ID / CreatedAt / UpdatedAt.
 func (repo *ViewerRepo) Create(ctx context.Context, viewer *entity.Viewer) error {
 	const query = `
-INSERT INTO viewers (name, email, password_hash)
-VALUES ($1, $2, $3)
+INSERT INTO users (name, email, role, password_hash)
+VALUES ($1, $2, 'viewer', $3)
 RETURNING id, created_at, updated_at`
 	err := repo.db.QueryRowContext(ctx, query,
 		viewer.Name, viewer.Email, viewer.PasswordHash,
@@ -65,8 +67,8 @@ RETURNING id, created_at, updated_at`
 func (repo *ViewerRepo) Get(ctx context.Context, id int64) (*entity.Viewer, error) {
 	query := `
 SELECT ` + viewerColumns + `
-FROM viewers
-WHERE id = $1
+FROM users
+WHERE id = $1 AND role = 'viewer'
 LIMIT 1`
 	viewer, err := scanViewer(repo.db.QueryRowContext(ctx, query, id))
 	if errors.Is(err, sql.ErrNoRows) {
@@ -82,7 +84,8 @@ LIMIT 1`
 func (repo *ViewerRepo) List(ctx context.Context) ([]*entity.Viewer, error) {
 	query := `
 SELECT ` + viewerColumns + `
-FROM viewers
+FROM users
+WHERE role = 'viewer'
 ORDER BY id ASC`
 	rows, err := repo.db.QueryContext(ctx, query)
 	if err != nil {
@@ -104,12 +107,12 @@ ORDER BY id ASC`
 // Update rewrites name / email / password_hash and bumps updated_at.
 func (repo *ViewerRepo) Update(ctx context.Context, viewer *entity.Viewer) error {
 	const query = `
-UPDATE viewers SET
+UPDATE users SET
        name          = $1,
        email         = $2,
        password_hash = $3,
        updated_at    = now()
-WHERE id = $4`
+WHERE id = $4 AND role = 'viewer'`
 	res, err := repo.db.ExecContext(ctx, query,
 		viewer.Name, viewer.Email, viewer.PasswordHash, viewer.ID,
 	)
@@ -125,8 +128,8 @@ WHERE id = $4`
 // Deactivate marks the viewer inactive as of t (idempotent).
 func (repo *ViewerRepo) Deactivate(ctx context.Context, id int64, t time.Time) error {
 	const query = `
-UPDATE viewers SET deactivated_at = $1, updated_at = now()
-WHERE id = $2 AND deactivated_at IS NULL`
+UPDATE users SET deactivated_at = $1, updated_at = now()
+WHERE id = $2 AND role = 'viewer' AND deactivated_at IS NULL`
 	if _, err := repo.db.ExecContext(ctx, query, t, id); err != nil {
 		return fmt.Errorf("Deactivate: %w", err)
 	}
@@ -136,8 +139,8 @@ WHERE id = $2 AND deactivated_at IS NULL`
 // Reactivate clears deactivated_at (idempotent).
 func (repo *ViewerRepo) Reactivate(ctx context.Context, id int64) error {
 	const query = `
-UPDATE viewers SET deactivated_at = NULL, updated_at = now()
-WHERE id = $1 AND deactivated_at IS NOT NULL`
+UPDATE users SET deactivated_at = NULL, updated_at = now()
+WHERE id = $1 AND role = 'viewer' AND deactivated_at IS NOT NULL`
 	if _, err := repo.db.ExecContext(ctx, query, id); err != nil {
 		return fmt.Errorf("Reactivate: %w", err)
 	}
@@ -146,7 +149,7 @@ WHERE id = $1 AND deactivated_at IS NOT NULL`
 
 // Delete removes the viewer row physically.
 func (repo *ViewerRepo) Delete(ctx context.Context, id int64) error {
-	const query = `DELETE FROM viewers WHERE id = $1`
+	const query = `DELETE FROM users WHERE id = $1 AND role = 'viewer'`
 	res, err := repo.db.ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("Delete: %w", err)
@@ -161,8 +164,8 @@ func (repo *ViewerRepo) Delete(ctx context.Context, id int64) error {
 func (repo *ViewerRepo) GetActiveByEmail(ctx context.Context, email string) (*entity.Viewer, error) {
 	query := `
 SELECT ` + viewerColumns + `
-FROM viewers
-WHERE email = $1 AND deactivated_at IS NULL
+FROM users
+WHERE email = $1 AND role = 'viewer' AND deactivated_at IS NULL
 LIMIT 1`
 	viewer, err := scanViewer(repo.db.QueryRowContext(ctx, query, email))
 	if errors.Is(err, sql.ErrNoRows) {
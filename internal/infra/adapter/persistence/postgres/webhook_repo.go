@@ -0,0 +1,242 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+const (
+	webhookColumns         = "id, url, secret, events, route, created_at"
+	webhookDeliveryColumns = "id, webhook_id, event, payload, status, attempts, response_status, last_error, created_at, delivered_at"
+)
+
+// WebhookRepo persists outbound webhooks (webhooks, webhook_deliveries).
+type WebhookRepo struct{ db *sql.DB }
+
+func NewWebhookRepo(db *sql.DB) repository.WebhookRepository {
+	return &WebhookRepo{db: db}
+}
+
+func scanWebhook(s scanner) (*entity.Webhook, error) {
+	var w entity.Webhook
+	var events, route []byte
+	if err := s.Scan(&w.ID, &w.URL, &w.Secret, &events, &route, &w.CreatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(events, &w.Events); err != nil {
+		return nil, fmt.Errorf("decode events: %w", err)
+	}
+	if err := json.Unmarshal(route, &w.Route); err != nil {
+		return nil, fmt.Errorf("decode route: %w", err)
+	}
+	return &w, nil
+}
+
+func scanWebhookDelivery(s scanner) (*entity.WebhookDelivery, error) {
+	var d entity.WebhookDelivery
+	var payload []byte
+	if err := s.Scan(
+		&d.ID, &d.WebhookID, &d.Event, &payload, &d.Status, &d.Attempts,
+		&d.ResponseStatus, &d.LastError, &d.CreatedAt, &d.DeliveredAt,
+	); err != nil {
+		return nil, err
+	}
+	d.Payload = json.RawMessage(payload)
+	return &d, nil
+}
+
+// Create inserts the webhook and sets webhook.ID / CreatedAt.
+func (repo *WebhookRepo) Create(ctx context.Context, webhook *entity.Webhook) error {
+	events, err := json.Marshal(webhook.Events)
+	if err != nil {
+		return fmt.Errorf("Create: events: %w", err)
+	}
+	route, err := json.Marshal(webhook.Route)
+	if err != nil {
+		return fmt.Errorf("Create: route: %w", err)
+	}
+	const query = `
+INSERT INTO webhooks (url, secret, events, route)
+VALUES ($1, $2, $3, $4)
+RETURNING id, created_at`
+	if err := repo.db.QueryRowContext(ctx, query,
+		webhook.URL, webhook.Secret, json.RawMessage(events), json.RawMessage(route),
+	).Scan(&webhook.ID, &webhook.CreatedAt); err != nil {
+		return fmt.Errorf("Create: %w", err)
+	}
+	return nil
+}
+
+// Get returns the webhook by ID, or nil when not found.
+func (repo *WebhookRepo) Get(ctx context.Context, id int64) (*entity.Webhook, error) {
+	query := `
+SELECT ` + webhookColumns + `
+FROM webhooks
+WHERE id = $1
+LIMIT 1`
+	webhook, err := scanWebhook(repo.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Get: %w", err)
+	}
+	return webhook, nil
+}
+
+// List returns all webhooks, oldest first.
+func (repo *WebhookRepo) List(ctx context.Context) ([]*entity.Webhook, error) {
+	query := `
+SELECT ` + webhookColumns + `
+FROM webhooks
+ORDER BY id ASC`
+	rows, err := conn(ctx, repo.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("List: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	webhooks := make([]*entity.Webhook, 0, 10)
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("List: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+// Delete removes the webhook; webhook_deliveries cascade.
+func (repo *WebhookRepo) Delete(ctx context.Context, id int64) error {
+	if _, err := repo.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("Delete: %w", err)
+	}
+	return nil
+}
+
+// CreateDeliveries fans the event out in a single statement: the CTE
+// inserts one delivery per subscribed webhook and the outer INSERT queues
+// a deliver_webhook job for each, so a delivery never exists without its
+// job (or the other way round).
+func (repo *WebhookRepo) CreateDeliveries(ctx context.Context, event string, payload json.RawMessage) (int64, error) {
+	const query = `
+WITH d AS (
+    INSERT INTO webhook_deliveries (webhook_id, event, payload)
+    SELECT id, $1, $2
+    FROM webhooks
+    WHERE events @> jsonb_build_array($1::text)
+    RETURNING id
+)
+INSERT INTO jobs (kind, payload)
+SELECT $3, jsonb_build_object('delivery_id', d.id)
+FROM d`
+	res, err := conn(ctx, repo.db).ExecContext(ctx, query, event, payload, entity.JobKindDeliverWebhook)
+	if err != nil {
+		return 0, fmt.Errorf("CreateDeliveries: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("CreateDeliveries: %w", err)
+	}
+	return n, nil
+}
+
+// CreateDeliveriesTo is CreateDeliveries over the listed webhooks. The
+// subscription is checked again so a webhook changed since it was routed
+// never receives an event it no longer subscribes to.
+func (repo *WebhookRepo) CreateDeliveriesTo(ctx context.Context, webhookIDs []int64, event string, payload json.RawMessage) (int64, error) {
+	if len(webhookIDs) == 0 {
+		return 0, nil
+	}
+	in, args := idPlaceholders(webhookIDs, 4)
+	// #nosec G201 -- in contains only generated $N placeholders.
+	query := fmt.Sprintf(`
+WITH d AS (
+    INSERT INTO webhook_deliveries (webhook_id, event, payload)
+    SELECT id, $1, $2
+    FROM webhooks
+    WHERE events @> jsonb_build_array($1::text)
+      AND id IN (%s)
+    RETURNING id
+)
+INSERT INTO jobs (kind, payload)
+SELECT $3, jsonb_build_object('delivery_id', d.id)
+FROM d`, in)
+	res, err := conn(ctx, repo.db).ExecContext(ctx, query, append([]any{event, payload, entity.JobKindDeliverWebhook}, args...)...)
+	if err != nil {
+		return 0, fmt.Errorf("CreateDeliveriesTo: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("CreateDeliveriesTo: %w", err)
+	}
+	return n, nil
+}
+
+// GetDelivery returns the delivery by ID, or nil when not found.
+func (repo *WebhookRepo) GetDelivery(ctx context.Context, id int64) (*entity.WebhookDelivery, error) {
+	query := `
+SELECT ` + webhookDeliveryColumns + `
+FROM webhook_deliveries
+WHERE id = $1
+LIMIT 1`
+	delivery, err := scanWebhookDelivery(repo.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetDelivery: %w", err)
+	}
+	return delivery, nil
+}
+
+// UpdateDelivery records the outcome of a delivery attempt.
+func (repo *WebhookRepo) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	const query = `
+UPDATE webhook_deliveries SET
+       status          = $1,
+       attempts        = $2,
+       response_status = $3,
+       last_error      = $4,
+       delivered_at    = $5
+WHERE id = $6`
+	if _, err := repo.db.ExecContext(ctx, query,
+		delivery.Status, delivery.Attempts, delivery.ResponseStatus,
+		delivery.LastError, delivery.DeliveredAt, delivery.ID,
+	); err != nil {
+		return fmt.Errorf("UpdateDelivery: %w", err)
+	}
+	return nil
+}
+
+// ListDeliveries returns the webhook's latest deliveries, newest first.
+func (repo *WebhookRepo) ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]*entity.WebhookDelivery, error) {
+	query := `
+SELECT ` + webhookDeliveryColumns + `
+FROM webhook_deliveries
+WHERE webhook_id = $1
+ORDER BY id DESC
+LIMIT $2`
+	rows, err := repo.db.QueryContext(ctx, query, webhookID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ListDeliveries: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	deliveries := make([]*entity.WebhookDelivery, 0, limit)
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ListDeliveries: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
@@ -0,0 +1,135 @@
+package postgres_test
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	pg "catchup-feed/internal/infra/adapter/persistence/postgres"
+	"catchup-feed/internal/repository"
+)
+
+var (
+	webhookCols         = []string{"id", "url", "secret", "events", "route", "created_at"}
+	webhookDeliveryCols = []string{"id", "webhook_id", "event", "payload", "status", "attempts", "response_status", "last_error", "created_at", "delivered_at"}
+)
+
+func newWebhookRepo(t *testing.T) (repository.WebhookRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return pg.NewWebhookRepo(db), mock, func() { _ = db.Close() }
+}
+
+func TestWebhookRepo_Create(t *testing.T) {
+	repo, mock, closeFn := newWebhookRepo(t)
+	defer closeFn()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO webhooks")).
+		WithArgs("https://hooks.example.com", "0123456789abcdef", json.RawMessage(`["article.created"]`), json.RawMessage(`{"keywords":["go"]}`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(int64(3), now))
+
+	webhook := &entity.Webhook{URL: "https://hooks.example.com", Secret: "0123456789abcdef", Events: []string{entity.WebhookEventArticleCreated},
+		Route: entity.WebhookRoute{Keywords: []string{"go"}}}
+	require.NoError(t, repo.Create(context.Background(), webhook))
+	assert.Equal(t, int64(3), webhook.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepo_Get(t *testing.T) {
+	repo, mock, closeFn := newWebhookRepo(t)
+	defer closeFn()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM webhooks")).
+		WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows(webhookCols).
+			AddRow(int64(3), "https://hooks.example.com", "secret", []byte(`["article.created","crawl.completed"]`), []byte(`{"source_ids":[1,2]}`), time.Now()))
+
+	webhook, err := repo.Get(context.Background(), 3)
+	require.NoError(t, err)
+	require.NotNil(t, webhook)
+	assert.Equal(t, []string{"article.created", "crawl.completed"}, webhook.Events)
+	assert.Equal(t, []int64{1, 2}, webhook.Route.SourceIDs)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM webhooks")).
+		WithArgs(int64(4)).
+		WillReturnRows(sqlmock.NewRows(webhookCols))
+	webhook, err = repo.Get(context.Background(), 4)
+	require.NoError(t, err)
+	assert.Nil(t, webhook)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepo_CreateDeliveries(t *testing.T) {
+	repo, mock, closeFn := newWebhookRepo(t)
+	defer closeFn()
+
+	payload := json.RawMessage(`{"event":"crawl.completed"}`)
+	// 配信行とジョブを1文(CTE)で作る
+	mock.ExpectExec(`WITH d AS \(\s*INSERT INTO webhook_deliveries .*events @> jsonb_build_array\(\$1::text\).*INSERT INTO jobs`).
+		WithArgs("crawl.completed", payload, entity.JobKindDeliverWebhook).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	n, err := repo.CreateDeliveries(context.Background(), "crawl.completed", payload)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepo_CreateDeliveriesTo(t *testing.T) {
+	repo, mock, closeFn := newWebhookRepo(t)
+	defer closeFn()
+
+	payload := json.RawMessage(`{"event":"article.created"}`)
+	mock.ExpectExec(`events @> jsonb_build_array\(\$1::text\)\s+AND id IN \(\$4, \$5\).*INSERT INTO jobs`).
+		WithArgs("article.created", payload, entity.JobKindDeliverWebhook, int64(1), int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	n, err := repo.CreateDeliveriesTo(context.Background(), []int64{1, 3}, "article.created", payload)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	// 宛先なしはクエリを発行しない
+	n, err = repo.CreateDeliveriesTo(context.Background(), nil, "article.created", payload)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepo_Deliveries(t *testing.T) {
+	repo, mock, closeFn := newWebhookRepo(t)
+	defer closeFn()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("FROM webhook_deliveries")).
+		WithArgs(int64(3), 50).
+		WillReturnRows(sqlmock.NewRows(webhookDeliveryCols).
+			AddRow(int64(11), int64(3), "article.created", []byte(`{}`), "delivered", 1, int64(200), nil, now, now).
+			AddRow(int64(10), int64(3), "article.created", []byte(`{}`), "pending", 1, nil, "timeout", now, nil))
+
+	deliveries, err := repo.ListDeliveries(context.Background(), 3, 50)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	require.NotNil(t, deliveries[0].ResponseStatus)
+	assert.Equal(t, 200, *deliveries[0].ResponseStatus)
+	assert.Nil(t, deliveries[1].ResponseStatus)
+	require.NotNil(t, deliveries[1].LastError)
+	assert.Equal(t, "timeout", *deliveries[1].LastError)
+
+	status := 503
+	msg := "webhook answered 503"
+	delivery := &entity.WebhookDelivery{ID: 10, Status: entity.WebhookDeliveryFailed, Attempts: 3, ResponseStatus: &status, LastError: &msg}
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE webhook_deliveries SET")).
+		WithArgs("failed", 3, &status, &msg, nil, int64(10)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, repo.UpdateDelivery(context.Background(), delivery))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,233 @@
+// Package cache is the read-through cache in front of the hot read
+// repositories (article list / get, source list / get). Entries live for a
+// short TTL and writes through the cached repositories invalidate their
+// namespace, so the cache only ever adds up to a TTL of staleness for
+// writes made elsewhere (the worker's crawls).
+//
+// Invalidation is by generation: every key embeds its namespace's current
+// generation and Invalidate bumps it, so stale entries are never read
+// again and simply age out. The Store is in-memory (LRU) or Redis, shared
+// by several server instances.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	pkgconfig "catchup-feed/pkg/config"
+)
+
+// Namespaces of the cached repositories.
+const (
+	NamespaceArticles = "articles"
+	NamespaceSources  = "sources"
+	NamespaceStats    = "stats"
+)
+
+// Backends selectable with CACHE_BACKEND.
+const (
+	BackendNone   = "none"
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+)
+
+// Store keeps the encoded entries and the namespace generations.
+type Store interface {
+	// Get returns the value of key; ok is false on a miss.
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	// Set stores value under key for ttl.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Generation returns the current generation of namespace (0 until the
+	// first Invalidate).
+	Generation(ctx context.Context, namespace string) (int64, error)
+	// Invalidate bumps the generation of namespace.
+	Invalidate(ctx context.Context, namespace string) error
+}
+
+// Stats are the cache counters (reported by GET /health).
+type Stats struct {
+	Backend       string `json:"backend"`
+	Hits          int64  `json:"hits"`
+	Misses        int64  `json:"misses"`
+	Errors        int64  `json:"errors"` // store failures, answered from the database
+	Invalidations int64  `json:"invalidations"`
+	Entries       int    `json:"entries,omitempty"` // memory backend only
+}
+
+// Cache is a Store with a TTL and hit / miss counters. A nil *Cache is
+// valid and caches nothing.
+type Cache struct {
+	store   Store
+	backend string
+	ttl     time.Duration
+	logger  *slog.Logger
+
+	hits          atomic.Int64
+	misses        atomic.Int64
+	errors        atomic.Int64
+	invalidations atomic.Int64
+}
+
+// New returns a cache over store; backend is only reported in Stats.
+func New(store Store, backend string, ttl time.Duration, logger *slog.Logger) *Cache {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Cache{store: store, backend: backend, ttl: ttl, logger: logger}
+}
+
+// Config is the cache configuration.
+//
+// Environment variables:
+//   - CACHE_BACKEND: none (default) | memory | redis
+//   - CACHE_TTL: entry lifetime (default 30s)
+//   - CACHE_MAX_ENTRIES: memory backend capacity (default 10000)
+//   - REDIS_URL: redis backend address, redis[s]://[user:password@]host:port[/db][?pool_size=N]
+type Config struct {
+	Backend    string
+	TTL        time.Duration
+	MaxEntries int
+	RedisURL   string
+}
+
+// LoadConfigFromEnv reads the cache configuration.
+func LoadConfigFromEnv() (Config, error) {
+	cfg := Config{
+		Backend:    pkgconfig.GetEnvString("CACHE_BACKEND", BackendNone),
+		TTL:        pkgconfig.GetEnvDuration("CACHE_TTL", 30*time.Second),
+		MaxEntries: pkgconfig.GetEnvInt("CACHE_MAX_ENTRIES", 10000),
+		RedisURL:   pkgconfig.GetEnvString("REDIS_URL", ""),
+	}
+	switch cfg.Backend {
+	case BackendNone:
+	case BackendMemory:
+		if cfg.MaxEntries <= 0 {
+			return Config{}, fmt.Errorf("CACHE_MAX_ENTRIES: must be positive, got %d", cfg.MaxEntries)
+		}
+	case BackendRedis:
+		if cfg.RedisURL == "" {
+			return Config{}, fmt.Errorf("REDIS_URL is required with CACHE_BACKEND=redis")
+		}
+	default:
+		return Config{}, fmt.Errorf("CACHE_BACKEND: unknown backend %q (want none, memory or redis)", cfg.Backend)
+	}
+	if cfg.Backend != BackendNone && cfg.TTL <= 0 {
+		return Config{}, fmt.Errorf("CACHE_TTL: must be positive, got %s", cfg.TTL)
+	}
+	return cfg, nil
+}
+
+// Open builds the configured cache; nil for CACHE_BACKEND=none.
+func Open(cfg Config, logger *slog.Logger) (*Cache, error) {
+	switch cfg.Backend {
+	case BackendMemory:
+		return New(NewMemoryStore(cfg.MaxEntries), cfg.Backend, cfg.TTL, logger), nil
+	case BackendRedis:
+		store, err := NewRedisStore(cfg.RedisURL)
+		if err != nil {
+			return nil, err
+		}
+		return New(store, cfg.Backend, cfg.TTL, logger), nil
+	default:
+		return nil, nil
+	}
+}
+
+// Stats returns the current counters.
+func (c *Cache) Stats() Stats {
+	if c == nil {
+		return Stats{Backend: BackendNone}
+	}
+	stats := Stats{
+		Backend:       c.backend,
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Errors:        c.errors.Load(),
+		Invalidations: c.invalidations.Load(),
+	}
+	if m, ok := c.store.(*MemoryStore); ok {
+		stats.Entries = m.Len()
+	}
+	return stats
+}
+
+// Store returns the backing store, shared with the HTTP response cache;
+// nil when the cache is disabled.
+func (c *Cache) Store() Store {
+	if c == nil {
+		return nil
+	}
+	return c.store
+}
+
+// Invalidate drops every entry of namespace. A store failure is logged:
+// the entries then expire with their TTL.
+func (c *Cache) Invalidate(ctx context.Context, namespace string) {
+	if c == nil {
+		return
+	}
+	c.invalidations.Add(1)
+	if err := c.store.Invalidate(ctx, namespace); err != nil {
+		c.errors.Add(1)
+		c.logger.WarnContext(ctx, "cache invalidation failed",
+			slog.String("namespace", namespace), slog.Any("error", err))
+	}
+}
+
+// Close releases the store's connections, if it holds any.
+func (c *Cache) Close() error {
+	if c == nil {
+		return nil
+	}
+	if closer, ok := c.store.(interface{ Close() error }); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// load returns the cached value of key in namespace, or calls fetch and
+// caches its result. The cache is best-effort: a store failure is counted
+// and the value comes from fetch. Errors from fetch are not cached.
+func load[T any](ctx context.Context, c *Cache, namespace, key string, fetch func() (T, error)) (T, error) {
+	if c == nil {
+		return fetch()
+	}
+	gen, err := c.store.Generation(ctx, namespace)
+	if err != nil {
+		c.storeFailed(ctx, err)
+		return fetch()
+	}
+	fullKey := namespace + ":" + strconv.FormatInt(gen, 10) + ":" + key
+
+	if data, ok, err := c.store.Get(ctx, fullKey); err != nil {
+		c.storeFailed(ctx, err)
+	} else if ok {
+		var v T
+		if err := json.Unmarshal(data, &v); err == nil {
+			c.hits.Add(1)
+			return v, nil
+		}
+	}
+	c.misses.Add(1)
+
+	v, err := fetch()
+	if err != nil {
+		return v, err
+	}
+	if data, err := json.Marshal(v); err == nil {
+		if err := c.store.Set(ctx, fullKey, data, c.ttl); err != nil {
+			c.storeFailed(ctx, err)
+		}
+	}
+	return v, nil
+}
+
+func (c *Cache) storeFailed(ctx context.Context, err error) {
+	c.errors.Add(1)
+	c.logger.DebugContext(ctx, "cache store failed, reading through", slog.Any("error", err))
+}
@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore is an in-process LRU Store holding at most maxEntries
+// entries. Generations are process-local, so with several server
+// instances each one only sees its own invalidations (use Redis there).
+type MemoryStore struct {
+	mu          sync.Mutex
+	maxEntries  int
+	ll          *list.List // front = most recently used
+	items       map[string]*list.Element
+	generations map[string]int64
+	now         func() time.Time
+}
+
+type memoryEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemoryStore returns an LRU store; maxEntries <= 0 means 10000.
+func NewMemoryStore(maxEntries int) *MemoryStore {
+	if maxEntries <= 0 {
+		maxEntries = 10000
+	}
+	return &MemoryStore{
+		maxEntries:  maxEntries,
+		ll:          list.New(),
+		items:       make(map[string]*list.Element),
+		generations: make(map[string]int64),
+		now:         time.Now,
+	}
+}
+
+// Get implements Store.
+func (m *MemoryStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := el.Value.(*memoryEntry)
+	if !m.now().Before(entry.expiresAt) {
+		m.remove(el)
+		return nil, false, nil
+	}
+	m.ll.MoveToFront(el)
+	return entry.value, true, nil
+}
+
+// Set implements Store, evicting the least recently used entry when full.
+func (m *MemoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expiresAt := m.now().Add(ttl)
+	if el, ok := m.items[key]; ok {
+		entry := el.Value.(*memoryEntry)
+		entry.value, entry.expiresAt = value, expiresAt
+		m.ll.MoveToFront(el)
+		return nil
+	}
+	m.items[key] = m.ll.PushFront(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+	for m.ll.Len() > m.maxEntries {
+		m.remove(m.ll.Back())
+	}
+	return nil
+}
+
+// Generation implements Store.
+func (m *MemoryStore) Generation(_ context.Context, namespace string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.generations[namespace], nil
+}
+
+// Invalidate implements Store. The namespace's old entries are unreachable
+// from now on and leave through LRU eviction or expiry.
+func (m *MemoryStore) Invalidate(_ context.Context, namespace string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.generations[namespace]++
+	return nil
+}
+
+// Len returns the number of entries held, expired ones included.
+func (m *MemoryStore) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ll.Len()
+}
+
+func (m *MemoryStore) remove(el *list.Element) {
+	m.ll.Remove(el)
+	delete(m.items, el.Value.(*memoryEntry).key)
+}
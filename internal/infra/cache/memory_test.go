@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryStore(2)
+
+	require.NoError(t, m.Set(ctx, "a", []byte("1"), time.Minute))
+	require.NoError(t, m.Set(ctx, "b", []byte("2"), time.Minute))
+	// Reading "a" makes "b" the least recently used.
+	_, ok, _ := m.Get(ctx, "a")
+	require.True(t, ok)
+	require.NoError(t, m.Set(ctx, "c", []byte("3"), time.Minute))
+
+	_, ok, _ = m.Get(ctx, "b")
+	assert.False(t, ok, "b should have been evicted")
+	v, ok, _ := m.Get(ctx, "a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), v)
+	assert.Equal(t, 2, m.Len())
+}
+
+func TestMemoryStore_Expiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1_700_000_000, 0)
+	m := NewMemoryStore(10)
+	m.now = func() time.Time { return now }
+
+	require.NoError(t, m.Set(ctx, "k", []byte("v"), 30*time.Second))
+	_, ok, _ := m.Get(ctx, "k")
+	assert.True(t, ok)
+
+	now = now.Add(30 * time.Second)
+	_, ok, _ = m.Get(ctx, "k")
+	assert.False(t, ok)
+	assert.Equal(t, 0, m.Len(), "expired entry is dropped on read")
+}
+
+func TestMemoryStore_Generation(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryStore(10)
+
+	gen, err := m.Generation(ctx, NamespaceArticles)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), gen)
+
+	require.NoError(t, m.Invalidate(ctx, NamespaceArticles))
+	gen, _ = m.Generation(ctx, NamespaceArticles)
+	assert.Equal(t, int64(1), gen)
+	gen, _ = m.Generation(ctx, NamespaceSources)
+	assert.Equal(t, int64(0), gen, "namespaces are independent")
+}
+
+func TestLoadConfigFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    Config
+		wantErr bool
+	}{
+		{name: "default is disabled", want: Config{Backend: BackendNone, TTL: 30 * time.Second, MaxEntries: 10000}},
+		{
+			name: "memory",
+			env:  map[string]string{"CACHE_BACKEND": "memory", "CACHE_TTL": "5s", "CACHE_MAX_ENTRIES": "100"},
+			want: Config{Backend: BackendMemory, TTL: 5 * time.Second, MaxEntries: 100},
+		},
+		{
+			name: "redis",
+			env:  map[string]string{"CACHE_BACKEND": "redis", "REDIS_URL": "redis://localhost:6379/1"},
+			want: Config{Backend: BackendRedis, TTL: 30 * time.Second, MaxEntries: 10000, RedisURL: "redis://localhost:6379/1"},
+		},
+		{name: "redis without url", env: map[string]string{"CACHE_BACKEND": "redis"}, wantErr: true},
+		{name: "unknown backend", env: map[string]string{"CACHE_BACKEND": "memcached"}, wantErr: true},
+		{name: "bad max entries", env: map[string]string{"CACHE_BACKEND": "memory", "CACHE_MAX_ENTRIES": "-1"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, k := range []string{"CACHE_BACKEND", "CACHE_TTL", "CACHE_MAX_ENTRIES", "REDIS_URL"} {
+				t.Setenv(k, tt.env[k])
+			}
+			got, err := LoadConfigFromEnv()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"catchup-feed/internal/infra/redis"
+)
+
+// redisKeyPrefix namespaces our keys in a Redis shared with other users.
+const redisKeyPrefix = "catchup-feed:cache:"
+
+// RedisStore is a Store on Redis, shared by every server instance so an
+// invalidation on one is seen by all.
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore returns a store for REDIS_URL (see redis.New). Connections
+// are dialled lazily, so an unreachable Redis does not stop the server:
+// reads fall through to the database until it is back.
+func NewRedisStore(rawURL string) (*RedisStore, error) {
+	client, err := redis.New(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return &RedisStore{client: client}, nil
+}
+
+// Get implements Store.
+func (s *RedisStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	v, err := s.client.Do(ctx, "GET", redisKeyPrefix+key)
+	if errors.Is(err, redis.ErrNil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	b, ok := v.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("redis: unexpected GET reply %T", v)
+	}
+	return b, true, nil
+}
+
+// Set implements Store.
+func (s *RedisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := s.client.Do(ctx, "SET", redisKeyPrefix+key, string(value), "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	return err
+}
+
+// Generation implements Store.
+func (s *RedisStore) Generation(ctx context.Context, namespace string) (int64, error) {
+	v, err := s.client.Do(ctx, "GET", redisKeyPrefix+"gen:"+namespace)
+	if errors.Is(err, redis.ErrNil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	b, ok := v.([]byte)
+	if !ok {
+		return 0, fmt.Errorf("redis: unexpected GET reply %T", v)
+	}
+	return strconv.ParseInt(string(b), 10, 64)
+}
+
+// Invalidate implements Store.
+func (s *RedisStore) Invalidate(ctx context.Context, namespace string) error {
+	_, err := s.client.Do(ctx, "INCR", redisKeyPrefix+"gen:"+namespace)
+	return err
+}
+
+// Close closes the pooled connections; the store is unusable afterwards.
+func (s *RedisStore) Close() error {
+	return s.client.Close()
+}
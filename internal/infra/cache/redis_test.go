@@ -0,0 +1,217 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRedis serves GET / SET / INCR / AUTH / SELECT from a map, enough to
+// exercise RedisStore without a Redis server.
+type fakeRedis struct {
+	ln       net.Listener
+	password string
+
+	mu       sync.Mutex
+	data     map[string]string
+	commands []string
+}
+
+func newFakeRedis(t *testing.T, password string) *fakeRedis {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	f := &fakeRedis{ln: ln, password: password, data: map[string]string{}}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	r := bufio.NewReader(conn)
+	authed := f.password == ""
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		f.mu.Lock()
+		f.commands = append(f.commands, strings.Join(args, " "))
+		reply := "-ERR unknown command\r\n"
+		switch cmd := strings.ToUpper(args[0]); {
+		case cmd == "AUTH":
+			if args[len(args)-1] == f.password {
+				authed = true
+				reply = "+OK\r\n"
+			} else {
+				reply = "-WRONGPASS invalid password\r\n"
+			}
+		case !authed:
+			reply = "-NOAUTH Authentication required.\r\n"
+		case cmd == "SELECT":
+			reply = "+OK\r\n"
+		case cmd == "GET":
+			if v, ok := f.data[args[1]]; ok {
+				reply = fmt.Sprintf("$%d\r\n%s\r\n", len(v), v)
+			} else {
+				reply = "$-1\r\n"
+			}
+		case cmd == "SET":
+			f.data[args[1]] = args[2]
+			reply = "+OK\r\n"
+		case cmd == "INCR":
+			n, _ := strconv.ParseInt(f.data[args[1]], 10, 64)
+			f.data[args[1]] = strconv.FormatInt(n+1, 10)
+			reply = ":" + f.data[args[1]] + "\r\n"
+		}
+		f.mu.Unlock()
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line)[1:])
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		if _, err := r.ReadString('\n'); err != nil { // $<len>
+			return nil, err
+		}
+		arg, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		args[i] = strings.TrimSuffix(arg, "\r\n")
+	}
+	return args, nil
+}
+
+func TestRedisStore_RoundTrip(t *testing.T) {
+	f := newFakeRedis(t, "s3cret")
+	s, err := NewRedisStore("redis://:s3cret@" + f.ln.Addr().String() + "/2")
+	require.NoError(t, err)
+	defer func() { _ = s.Close() }()
+	ctx := context.Background()
+
+	_, ok, err := s.Get(ctx, "articles:0:count")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, s.Set(ctx, "articles:0:count", []byte("42"), 30*time.Second))
+	v, ok, err := s.Get(ctx, "articles:0:count")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("42"), v)
+
+	gen, err := s.Generation(ctx, NamespaceArticles)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), gen)
+	require.NoError(t, s.Invalidate(ctx, NamespaceArticles))
+	gen, err = s.Generation(ctx, NamespaceArticles)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), gen)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	assert.Equal(t, []string{
+		"AUTH s3cret",
+		"SELECT 2",
+		"GET catchup-feed:cache:articles:0:count",
+		"SET catchup-feed:cache:articles:0:count 42 PX 30000",
+		"GET catchup-feed:cache:articles:0:count",
+		"GET catchup-feed:cache:gen:articles",
+		"INCR catchup-feed:cache:gen:articles",
+		"GET catchup-feed:cache:gen:articles",
+	}, f.commands, "one pooled connection, authenticated once")
+}
+
+func TestRedisStore_AuthFailure(t *testing.T) {
+	f := newFakeRedis(t, "s3cret")
+	s, err := NewRedisStore("redis://:wrong@" + f.ln.Addr().String())
+	require.NoError(t, err)
+
+	_, _, err = s.Get(context.Background(), "k")
+	assert.ErrorContains(t, err, "auth")
+}
+
+func TestRedisStore_Unreachable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	s, err := NewRedisStore("redis://" + addr)
+	require.NoError(t, err)
+	_, _, err = s.Get(context.Background(), "k")
+	assert.Error(t, err)
+}
+
+func TestNewRedisStore_InvalidURL(t *testing.T) {
+	for _, raw := range []string{"localhost:6379", "http://localhost", "redis://", "redis://localhost/x"} {
+		_, err := NewRedisStore(raw)
+		assert.Error(t, err, raw)
+	}
+
+	s, err := NewRedisStore("redis://localhost")
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:6379", s.client.Addr())
+}
+
+// TestRedisStore_RealRedis runs the store against TEST_REDIS_URL (see
+// internal/infra/redis for a docker one-liner); skipped when unset.
+func TestRedisStore_RealRedis(t *testing.T) {
+	raw := os.Getenv("TEST_REDIS_URL")
+	if raw == "" {
+		t.Skip("TEST_REDIS_URL not set; skipping real-redis test")
+	}
+	s, err := NewRedisStore(raw)
+	require.NoError(t, err)
+	defer func() { _ = s.Close() }()
+	ctx := context.Background()
+	ns := "test-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	key := ns + ":count"
+
+	_, ok, err := s.Get(ctx, key)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	require.NoError(t, s.Set(ctx, key, []byte("42"), time.Second))
+	v, ok, err := s.Get(ctx, key)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("42"), v)
+
+	require.NoError(t, s.Invalidate(ctx, ns))
+	require.NoError(t, s.Invalidate(ctx, ns))
+	gen, err := s.Generation(ctx, ns)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), gen)
+	_, _ = s.client.Do(ctx, "DEL", redisKeyPrefix+"gen:"+ns, redisKeyPrefix+key)
+}
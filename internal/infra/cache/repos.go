@@ -0,0 +1,213 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// ArticleRepo caches the article list / get reads of an ArticleRepository.
+// Every write made through it invalidates the article and stats
+// namespaces; the other methods pass straight through.
+type ArticleRepo struct {
+	repository.ArticleRepository
+	cache *Cache
+}
+
+// NewArticleRepo wraps inner; with a nil cache it returns inner itself.
+func NewArticleRepo(inner repository.ArticleRepository, c *Cache) repository.ArticleRepository {
+	if c == nil {
+		return inner
+	}
+	return &ArticleRepo{ArticleRepository: inner, cache: c}
+}
+
+// articleWithSourceName is GetWithSource's result as one cacheable value.
+type articleWithSourceName struct {
+	Article    *entity.Article
+	SourceName string
+}
+
+func (r *ArticleRepo) ListWithSourcePaginated(ctx context.Context, offset, limit int) ([]repository.ArticleWithSource, error) {
+	key := "page:" + strconv.Itoa(offset) + ":" + strconv.Itoa(limit)
+	return load(ctx, r.cache, NamespaceArticles, key, func() ([]repository.ArticleWithSource, error) {
+		return r.ArticleRepository.ListWithSourcePaginated(ctx, offset, limit)
+	})
+}
+
+func (r *ArticleRepo) CountArticles(ctx context.Context) (int64, error) {
+	return load(ctx, r.cache, NamespaceArticles, "count", func() (int64, error) {
+		return r.ArticleRepository.CountArticles(ctx)
+	})
+}
+
+func (r *ArticleRepo) Get(ctx context.Context, id int64) (*entity.Article, error) {
+	return load(ctx, r.cache, NamespaceArticles, "get:"+strconv.FormatInt(id, 10), func() (*entity.Article, error) {
+		return r.ArticleRepository.Get(ctx, id)
+	})
+}
+
+func (r *ArticleRepo) GetWithSource(ctx context.Context, id int64) (*entity.Article, string, error) {
+	v, err := load(ctx, r.cache, NamespaceArticles, "getws:"+strconv.FormatInt(id, 10), func() (articleWithSourceName, error) {
+		article, sourceName, err := r.ArticleRepository.GetWithSource(ctx, id)
+		return articleWithSourceName{Article: article, SourceName: sourceName}, err
+	})
+	return v.Article, v.SourceName, err
+}
+
+func (r *ArticleRepo) Create(ctx context.Context, article *entity.Article) error {
+	defer r.invalidate(ctx)
+	return r.ArticleRepository.Create(ctx, article)
+}
+
+func (r *ArticleRepo) CreateWithSummary(ctx context.Context, article *entity.Article, summary *entity.Summary) error {
+	defer r.invalidate(ctx)
+	return r.ArticleRepository.CreateWithSummary(ctx, article, summary)
+}
+
+func (r *ArticleRepo) CreateBatch(ctx context.Context, items []repository.NewArticle) (int, error) {
+	defer r.invalidate(ctx)
+	return r.ArticleRepository.CreateBatch(ctx, items)
+}
+
+func (r *ArticleRepo) CreateWithTranscribeJob(ctx context.Context, article *entity.Article, mediaURL, sourceKind string) error {
+	defer r.invalidate(ctx)
+	return r.ArticleRepository.CreateWithTranscribeJob(ctx, article, mediaURL, sourceKind)
+}
+
+func (r *ArticleRepo) Update(ctx context.Context, article *entity.Article) error {
+	defer r.invalidate(ctx)
+	return r.ArticleRepository.Update(ctx, article)
+}
+
+func (r *ArticleRepo) Delete(ctx context.Context, id int64) error {
+	defer r.invalidate(ctx)
+	return r.ArticleRepository.Delete(ctx, id)
+}
+
+func (r *ArticleRepo) DeleteBatch(ctx context.Context, ids []int64) ([]int64, error) {
+	defer r.invalidate(ctx)
+	return r.ArticleRepository.DeleteBatch(ctx, ids)
+}
+
+func (r *ArticleRepo) invalidate(ctx context.Context) {
+	r.cache.Invalidate(ctx, NamespaceArticles)
+	r.cache.Invalidate(ctx, NamespaceStats)
+}
+
+// SourceRepo caches the source list / get reads of a SourceRepository.
+// Writes invalidate the articles too: article lists carry the source name.
+type SourceRepo struct {
+	repository.SourceRepository
+	cache *Cache
+}
+
+// NewSourceRepo wraps inner; with a nil cache it returns inner itself.
+func NewSourceRepo(inner repository.SourceRepository, c *Cache) repository.SourceRepository {
+	if c == nil {
+		return inner
+	}
+	return &SourceRepo{SourceRepository: inner, cache: c}
+}
+
+func (r *SourceRepo) List(ctx context.Context) ([]*entity.Source, error) {
+	return load(ctx, r.cache, NamespaceSources, "list", func() ([]*entity.Source, error) {
+		return r.SourceRepository.List(ctx)
+	})
+}
+
+func (r *SourceRepo) Get(ctx context.Context, id int64) (*entity.Source, error) {
+	return load(ctx, r.cache, NamespaceSources, "get:"+strconv.FormatInt(id, 10), func() (*entity.Source, error) {
+		return r.SourceRepository.Get(ctx, id)
+	})
+}
+
+func (r *SourceRepo) Create(ctx context.Context, source *entity.Source) error {
+	defer r.invalidate(ctx)
+	return r.SourceRepository.Create(ctx, source)
+}
+
+func (r *SourceRepo) Update(ctx context.Context, source *entity.Source) error {
+	defer r.invalidate(ctx)
+	return r.SourceRepository.Update(ctx, source)
+}
+
+func (r *SourceRepo) Delete(ctx context.Context, id int64) error {
+	defer r.invalidate(ctx)
+	return r.SourceRepository.Delete(ctx, id)
+}
+
+func (r *SourceRepo) UpdateFeedValidators(ctx context.Context, id int64, etag, lastModified string) error {
+	defer r.cache.Invalidate(ctx, NamespaceSources)
+	return r.SourceRepository.UpdateFeedValidators(ctx, id, etag, lastModified)
+}
+
+func (r *SourceRepo) invalidate(ctx context.Context) {
+	r.cache.Invalidate(ctx, NamespaceSources)
+	r.cache.Invalidate(ctx, NamespaceArticles)
+	r.cache.Invalidate(ctx, NamespaceStats)
+}
+
+// SourceGroupRepo invalidates the cached sources when the group
+// membership changes: SetSources and Delete rewrite sources.group_id. The
+// groups themselves are not cached.
+type SourceGroupRepo struct {
+	repository.SourceGroupRepository
+	cache *Cache
+}
+
+// NewSourceGroupRepo wraps inner; with a nil cache it returns inner itself.
+func NewSourceGroupRepo(inner repository.SourceGroupRepository, c *Cache) repository.SourceGroupRepository {
+	if c == nil {
+		return inner
+	}
+	return &SourceGroupRepo{SourceGroupRepository: inner, cache: c}
+}
+
+func (r *SourceGroupRepo) Delete(ctx context.Context, id int64) error {
+	defer r.cache.Invalidate(ctx, NamespaceSources)
+	return r.SourceGroupRepository.Delete(ctx, id)
+}
+
+func (r *SourceGroupRepo) SetSources(ctx context.Context, groupID int64, sourceIDs []int64) ([]int64, error) {
+	defer r.cache.Invalidate(ctx, NamespaceSources)
+	return r.SourceGroupRepository.SetSources(ctx, groupID, sourceIDs)
+}
+
+// StatsRepo caches the dashboard aggregates. The windows start on day
+// boundaries, so the keys repeat within a day; the stats namespace is
+// invalidated by article / source writes and the worker's NOTIFYs.
+type StatsRepo struct {
+	inner repository.StatsRepository
+	cache *Cache
+}
+
+// NewStatsRepo wraps inner; with a nil cache it returns inner itself.
+func NewStatsRepo(inner repository.StatsRepository, c *Cache) repository.StatsRepository {
+	if c == nil {
+		return inner
+	}
+	return &StatsRepo{inner: inner, cache: c}
+}
+
+func (r *StatsRepo) ArticleCountsBySource(ctx context.Context, since time.Time) ([]*entity.SourceArticleStats, error) {
+	return load(ctx, r.cache, NamespaceStats, "sources:"+strconv.FormatInt(since.Unix(), 10), func() ([]*entity.SourceArticleStats, error) {
+		return r.inner.ArticleCountsBySource(ctx, since)
+	})
+}
+
+func (r *StatsRepo) ArticleCountsByPeriod(ctx context.Context, from, to time.Time, stepDays int) ([]entity.ArticleCountBucket, error) {
+	key := "period:" + from.Format(time.DateOnly) + ":" + to.Format(time.DateOnly) + ":" + strconv.Itoa(stepDays)
+	return load(ctx, r.cache, NamespaceStats, key, func() ([]entity.ArticleCountBucket, error) {
+		return r.inner.ArticleCountsByPeriod(ctx, from, to, stepDays)
+	})
+}
+
+func (r *StatsRepo) CrawlRunStats(ctx context.Context, since time.Time) (*entity.CrawlRunStats, error) {
+	return load(ctx, r.cache, NamespaceStats, "crawls:"+strconv.FormatInt(since.Unix(), 10), func() (*entity.CrawlRunStats, error) {
+		return r.inner.CrawlRunStats(ctx, since)
+	})
+}
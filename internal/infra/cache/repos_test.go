@@ -0,0 +1,260 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/* ───────── stubs ───────── */
+
+// stubArticleRepo counts the reads that reach the database.
+type stubArticleRepo struct {
+	repository.ArticleRepository
+	calls   int
+	total   int64
+	getErr  error
+	deleted []int64
+}
+
+func (s *stubArticleRepo) ListWithSourcePaginated(_ context.Context, offset, limit int) ([]repository.ArticleWithSource, error) {
+	s.calls++
+	return []repository.ArticleWithSource{
+		{Article: &entity.Article{ID: int64(offset + 1), Title: "Go 1.26"}, SourceName: "Go Blog"},
+	}, nil
+}
+
+func (s *stubArticleRepo) CountArticles(context.Context) (int64, error) {
+	s.calls++
+	return s.total, nil
+}
+
+func (s *stubArticleRepo) GetWithSource(_ context.Context, id int64) (*entity.Article, string, error) {
+	s.calls++
+	if s.getErr != nil {
+		return nil, "", s.getErr
+	}
+	return &entity.Article{ID: id, Title: "Go 1.26"}, "Go Blog", nil
+}
+
+func (s *stubArticleRepo) Delete(_ context.Context, id int64) error {
+	s.deleted = append(s.deleted, id)
+	return nil
+}
+
+type stubSourceRepo struct {
+	repository.SourceRepository
+	calls int
+}
+
+func (s *stubSourceRepo) List(context.Context) ([]*entity.Source, error) {
+	s.calls++
+	return []*entity.Source{{ID: 1, Name: "Go Blog"}}, nil
+}
+
+func (s *stubSourceRepo) Update(context.Context, *entity.Source) error { return nil }
+
+func newMemoryCache() *Cache {
+	return New(NewMemoryStore(100), BackendMemory, time.Minute, slog.New(slog.DiscardHandler))
+}
+
+/* ───────── ArticleRepo ───────── */
+
+func TestArticleRepo_CachesReads(t *testing.T) {
+	ctx := context.Background()
+	stub := &stubArticleRepo{total: 7}
+	c := newMemoryCache()
+	repo := NewArticleRepo(stub, c)
+
+	for range 2 {
+		items, err := repo.ListWithSourcePaginated(ctx, 0, 20)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, "Go Blog", items[0].SourceName)
+		n, err := repo.CountArticles(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), n)
+	}
+	assert.Equal(t, 2, stub.calls, "second round served from the cache")
+
+	// Pages are cached separately.
+	items, err := repo.ListWithSourcePaginated(ctx, 20, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(21), items[0].Article.ID)
+	assert.Equal(t, 3, stub.calls)
+
+	stats := c.Stats()
+	assert.Equal(t, int64(2), stats.Hits)
+	assert.Equal(t, int64(3), stats.Misses)
+	assert.Equal(t, 3, stats.Entries)
+}
+
+func TestArticleRepo_ReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	repo := NewArticleRepo(&stubArticleRepo{}, newMemoryCache())
+
+	a, _, err := repo.GetWithSource(ctx, 1)
+	require.NoError(t, err)
+	a.Title = "mutated by a caller"
+
+	b, sourceName, err := repo.GetWithSource(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Go 1.26", b.Title)
+	assert.Equal(t, "Go Blog", sourceName)
+}
+
+func TestArticleRepo_WriteInvalidates(t *testing.T) {
+	ctx := context.Background()
+	stub := &stubArticleRepo{}
+	c := newMemoryCache()
+	repo := NewArticleRepo(stub, c)
+
+	_, _, _ = repo.GetWithSource(ctx, 1)
+	require.NoError(t, repo.Delete(ctx, 1))
+	_, _, _ = repo.GetWithSource(ctx, 1)
+
+	assert.Equal(t, []int64{1}, stub.deleted)
+	assert.Equal(t, 2, stub.calls, "read after delete goes to the database")
+	assert.Equal(t, int64(2), c.Stats().Invalidations, "articles and stats")
+}
+
+func TestArticleRepo_ErrorsAreNotCached(t *testing.T) {
+	ctx := context.Background()
+	stub := &stubArticleRepo{getErr: errors.New("connection refused")}
+	repo := NewArticleRepo(stub, newMemoryCache())
+
+	_, _, err := repo.GetWithSource(ctx, 1)
+	assert.Error(t, err)
+	stub.getErr = nil
+	a, _, err := repo.GetWithSource(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), a.ID)
+	assert.Equal(t, 2, stub.calls)
+}
+
+func TestNewArticleRepo_NilCache(t *testing.T) {
+	stub := &stubArticleRepo{}
+	assert.Same(t, repository.ArticleRepository(stub), NewArticleRepo(stub, nil))
+}
+
+/* ───────── SourceRepo ───────── */
+
+func TestSourceRepo_UpdateInvalidatesArticlesToo(t *testing.T) {
+	ctx := context.Background()
+	articles := &stubArticleRepo{}
+	sources := &stubSourceRepo{}
+	c := newMemoryCache()
+	articleRepo := NewArticleRepo(articles, c)
+	sourceRepo := NewSourceRepo(sources, c)
+
+	_, _ = sourceRepo.List(ctx)
+	_, _ = articleRepo.ListWithSourcePaginated(ctx, 0, 20)
+	require.NoError(t, sourceRepo.Update(ctx, &entity.Source{ID: 1, Name: "The Go Blog"}))
+	_, _ = sourceRepo.List(ctx)
+	_, _ = articleRepo.ListWithSourcePaginated(ctx, 0, 20)
+
+	assert.Equal(t, 2, sources.calls)
+	assert.Equal(t, 2, articles.calls, "article lists carry the source name")
+}
+
+type stubSourceGroupRepo struct {
+	repository.SourceGroupRepository
+}
+
+func (stubSourceGroupRepo) SetSources(_ context.Context, _ int64, ids []int64) ([]int64, error) {
+	return ids, nil
+}
+
+func TestSourceGroupRepo_SetSourcesInvalidatesSources(t *testing.T) {
+	ctx := context.Background()
+	sources := &stubSourceRepo{}
+	c := newMemoryCache()
+	sourceRepo := NewSourceRepo(sources, c)
+	groupRepo := NewSourceGroupRepo(stubSourceGroupRepo{}, c)
+
+	_, _ = sourceRepo.List(ctx)
+	_, err := groupRepo.SetSources(ctx, 1, []int64{1})
+	require.NoError(t, err)
+	_, _ = sourceRepo.List(ctx)
+
+	assert.Equal(t, 2, sources.calls, "sources carry their group")
+}
+
+/* ───────── StatsRepo ───────── */
+
+type stubStatsRepo struct {
+	repository.StatsRepository
+	calls int
+}
+
+func (s *stubStatsRepo) CrawlRunStats(context.Context, time.Time) (*entity.CrawlRunStats, error) {
+	s.calls++
+	return &entity.CrawlRunStats{Runs: 24, Inserted: 120}, nil
+}
+
+func TestStatsRepo_InvalidatedByArticleWrites(t *testing.T) {
+	ctx := context.Background()
+	stub := &stubStatsRepo{}
+	c := newMemoryCache()
+	statsRepo := NewStatsRepo(stub, c)
+	articleRepo := NewArticleRepo(&stubArticleRepo{}, c)
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	for range 2 {
+		got, err := statsRepo.CrawlRunStats(ctx, since)
+		require.NoError(t, err)
+		assert.Equal(t, int64(24), got.Runs)
+	}
+	assert.Equal(t, 1, stub.calls)
+
+	require.NoError(t, articleRepo.Delete(ctx, 1))
+	_, _ = statsRepo.CrawlRunStats(ctx, since)
+	assert.Equal(t, 2, stub.calls)
+}
+
+/* ───────── store failures ───────── */
+
+// failingStore fails every operation, like an unreachable Redis.
+type failingStore struct{}
+
+var errStoreDown = errors.New("store down")
+
+func (failingStore) Get(context.Context, string) ([]byte, bool, error) {
+	return nil, false, errStoreDown
+}
+func (failingStore) Set(context.Context, string, []byte, time.Duration) error {
+	return errStoreDown
+}
+func (failingStore) Generation(context.Context, string) (int64, error) { return 0, errStoreDown }
+func (failingStore) Invalidate(context.Context, string) error          { return errStoreDown }
+
+func TestArticleRepo_StoreFailureReadsThrough(t *testing.T) {
+	ctx := context.Background()
+	stub := &stubArticleRepo{total: 3}
+	c := New(failingStore{}, BackendRedis, time.Minute, slog.New(slog.DiscardHandler))
+	repo := NewArticleRepo(stub, c)
+
+	n, err := repo.CountArticles(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	require.NoError(t, repo.Delete(ctx, 1))
+
+	stats := c.Stats()
+	assert.Equal(t, int64(3), stats.Errors, "generation read + two invalidations")
+	assert.Equal(t, int64(0), stats.Hits)
+}
+
+func TestCache_NilIsDisabled(t *testing.T) {
+	var c *Cache
+	c.Invalidate(context.Background(), NamespaceArticles)
+	assert.Equal(t, Stats{Backend: BackendNone}, c.Stats())
+	assert.NoError(t, c.Close())
+}
@@ -0,0 +1,510 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// BackupFormat and BackupVersion identify a dump written by Backup. Restore
+// rejects any other format or version.
+const (
+	BackupFormat  = "catchup-feed-backup"
+	BackupVersion = 1
+)
+
+// restoreBatchSize is how many rows one INSERT of Restore carries.
+const restoreBatchSize = 500
+
+// ErrRestoreTargetNotEmpty is returned by Restore when a table it would
+// fill already has rows and RestoreOptions.Replace is not set.
+var ErrRestoreTargetNotEmpty = errors.New("restore target is not empty")
+
+// backupTable is one table of the dump. The order of backupTables is the
+// foreign-key order: a table comes after every table it references, so
+// Restore inserts in this order.
+type backupTable struct {
+	name    string
+	orderBy string
+	// content marks the bulky article text left out by
+	// BackupOptions.WithoutContent: the whole table when contentColumns
+	// is empty, otherwise just those columns.
+	content        bool
+	contentColumns []string
+}
+
+// backupTables is what a dump holds: configuration (sources, groups, tags,
+// webhooks), accounts and their credentials, and the articles with their
+// summaries and per-user state. Operational tables that rebuild themselves
+// or only matter to the running instance (jobs, audit_logs, crawl_runs,
+// source_health, deliveries, rate limits, tokens, exports) and the radio /
+// learning data are not included; pg_dump remains the full backup.
+var backupTables = []backupTable{
+	{name: "source_groups", orderBy: "id"},
+	{name: "sources", orderBy: "id"},
+	{name: "tags", orderBy: "id"},
+	{name: "users", orderBy: "id"},
+	{name: "user_mfa", orderBy: "user_id"},
+	{name: "api_keys", orderBy: "id"},
+	{name: "subscribers", orderBy: "id"},
+	{name: "feed_tokens", orderBy: "id"},
+	{name: "webhooks", orderBy: "id"},
+	{name: "alert_rules", orderBy: "id"},
+	{name: "source_subscriptions", orderBy: "user_id, source_id"},
+	{name: "articles", orderBy: "id", content: true, contentColumns: []string{"content"}},
+	{name: "summaries", orderBy: "article_id"},
+	{name: "article_contents", orderBy: "article_id", content: true},
+	{name: "article_tags", orderBy: "article_id, tag_id"},
+	{name: "article_read_state", orderBy: "user_id, article_id"},
+	{name: "article_favorites", orderBy: "user_id, article_id"},
+}
+
+// BackupOptions controls Backup.
+type BackupOptions struct {
+	// WithoutContent leaves out articles.content and article_contents, the
+	// bulk of a dump. Restored articles then have no stored full text.
+	WithoutContent bool
+	Now            func() time.Time // nil = time.Now
+}
+
+// RestoreOptions controls Restore.
+type RestoreOptions struct {
+	// Replace empties every dumped table before restoring, together with
+	// the tables outside the dump that reference them (radio segments,
+	// learning items, webhook deliveries, ...), which RestoreResult.Cleared
+	// reports. Without it Restore refuses a database where any dumped table
+	// has rows.
+	Replace bool
+}
+
+// RestoreResult is what Restore changed.
+type RestoreResult struct {
+	// Restored is the rows inserted per dumped table.
+	Restored []TableCount
+	// Cleared is the rows Replace removed from tables that are not in the
+	// dump but reference a dumped table; they are not restored.
+	Cleared []TableCount
+}
+
+// TableCount is the number of rows Backup wrote or Restore inserted for
+// one table.
+type TableCount struct {
+	Table string
+	Rows  int
+}
+
+// backupLine is one line of a dump. A dump is JSON lines: a header
+// (Format, Version, CreatedAt, WithoutContent), then for each table a
+// section line (Table, Columns) followed by one Row line per row. Rows
+// are the to_jsonb of the table row, so they restore into a newer schema
+// too: dropped columns are ignored and added ones take their default.
+type backupLine struct {
+	Format         string          `json:"format,omitempty"`
+	Version        int             `json:"version,omitempty"`
+	CreatedAt      *time.Time      `json:"created_at,omitempty"`
+	WithoutContent bool            `json:"without_content,omitempty"`
+	Table          string          `json:"table,omitempty"`
+	Columns        []string        `json:"columns,omitempty"`
+	Row            json.RawMessage `json:"row,omitempty"`
+}
+
+// Backup writes a dump of backupTables to w. The tables are read in one
+// repeatable-read transaction, so the dump is a consistent snapshot.
+func Backup(ctx context.Context, database *sql.DB, w io.Writer, opts BackupOptions) ([]TableCount, error) {
+	tx, err := database.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("backup: begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+	createdAt := now().UTC()
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(backupLine{
+		Format: BackupFormat, Version: BackupVersion, CreatedAt: &createdAt, WithoutContent: opts.WithoutContent,
+	}); err != nil {
+		return nil, fmt.Errorf("backup: write header: %w", err)
+	}
+
+	var counts []TableCount
+	for _, t := range backupTables {
+		if opts.WithoutContent && t.content && len(t.contentColumns) == 0 {
+			continue
+		}
+		columns, err := tableColumns(ctx, tx, t.name)
+		if err != nil {
+			return nil, fmt.Errorf("backup: %s: %w", t.name, err)
+		}
+		var omit []string
+		if opts.WithoutContent {
+			omit = t.contentColumns
+		}
+		columns = without(columns, omit)
+		if err := enc.Encode(backupLine{Table: t.name, Columns: columns}); err != nil {
+			return nil, fmt.Errorf("backup: %s: %w", t.name, err)
+		}
+		n, err := backupRows(ctx, tx, enc, t, columns)
+		if err != nil {
+			return nil, fmt.Errorf("backup: %s: %w", t.name, err)
+		}
+		counts = append(counts, TableCount{Table: t.name, Rows: n})
+	}
+	return counts, nil
+}
+
+// backupRows writes the rows of t, restricted to columns, as Row lines.
+func backupRows(ctx context.Context, tx *sql.Tx, enc *json.Encoder, t backupTable, columns []string) (int, error) {
+	if len(columns) == 0 {
+		return 0, errors.New("table has no columns")
+	}
+	// #nosec G201 -- table, columns and order come from backupTables and
+	// information_schema, never from input.
+	query := fmt.Sprintf(`SELECT to_jsonb(r) FROM (SELECT %s FROM %s ORDER BY %s) r`,
+		strings.Join(columns, ", "), t.name, t.orderBy)
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	n := 0
+	for rows.Next() {
+		var row []byte
+		if err := rows.Scan(&row); err != nil {
+			return n, fmt.Errorf("scan: %w", err)
+		}
+		if err := enc.Encode(backupLine{Row: row}); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, rows.Err()
+}
+
+// Restore reads a dump written by Backup and inserts its rows in one
+// transaction: a dump that fails part way, replace included, leaves the
+// database untouched.
+// Only the columns present in both the dump and the current schema are
+// inserted, and the ID sequences are moved past the restored IDs. The
+// articles_notify trigger is disabled meanwhile, so a restore does not
+// announce every article as new to the server's listeners.
+func Restore(ctx context.Context, database *sql.DB, r io.Reader, opts RestoreOptions) (*RestoreResult, error) {
+	dec := json.NewDecoder(r)
+	var header backupLine
+	if err := dec.Decode(&header); err != nil {
+		return nil, fmt.Errorf("restore: read header: %w", err)
+	}
+	if header.Format != BackupFormat {
+		return nil, fmt.Errorf("restore: not a %s dump", BackupFormat)
+	}
+	if header.Version != BackupVersion {
+		return nil, fmt.Errorf("restore: unsupported dump version %d (want %d)", header.Version, BackupVersion)
+	}
+
+	tx, err := database.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("restore: begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	cleared, err := prepareRestoreTarget(ctx, tx, opts.Replace)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, `ALTER TABLE articles DISABLE TRIGGER articles_notify`); err != nil {
+		return nil, fmt.Errorf("restore: disable articles_notify: %w", err)
+	}
+
+	var (
+		done    []*restoreSection
+		current *restoreSection
+	)
+	finish := func() error {
+		if current == nil {
+			return nil
+		}
+		if err := current.flush(ctx, tx); err != nil {
+			return fmt.Errorf("restore: %s: %w", current.table, err)
+		}
+		done = append(done, current)
+		return nil
+	}
+	for {
+		var line backupLine
+		err := dec.Decode(&line)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("restore: read dump: %w", err)
+		}
+		switch {
+		case line.Table != "":
+			if err := finish(); err != nil {
+				return nil, err
+			}
+			current, err = newRestoreSection(ctx, tx, line.Table, line.Columns)
+			if err != nil {
+				return nil, err
+			}
+		case len(line.Row) > 0:
+			if current == nil {
+				return nil, errors.New("restore: row before any table")
+			}
+			if err := current.add(ctx, tx, line.Row); err != nil {
+				return nil, fmt.Errorf("restore: %s: %w", current.table, err)
+			}
+		}
+	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
+
+	res := &RestoreResult{Restored: make([]TableCount, 0, len(done)), Cleared: cleared}
+	for _, s := range done {
+		if err := s.resetSequence(ctx, tx); err != nil {
+			return nil, fmt.Errorf("restore: %s: %w", s.table, err)
+		}
+		res.Restored = append(res.Restored, TableCount{Table: s.table, Rows: s.rows})
+	}
+	if _, err := tx.ExecContext(ctx, `ALTER TABLE articles ENABLE TRIGGER articles_notify`); err != nil {
+		return nil, fmt.Errorf("restore: enable articles_notify: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("restore: commit: %w", err)
+	}
+	return res, nil
+}
+
+// restoreDependentsQuery lists the tables outside the dump ($1, comma
+// separated) whose foreign keys reach a dumped table, directly or through
+// each other.
+const restoreDependentsQuery = `
+WITH RECURSIVE dumped AS (
+	SELECT unnest(string_to_array($1, ',')::regclass[]) AS oid
+), deps(oid) AS (
+	SELECT c.conrelid FROM pg_constraint c JOIN dumped d ON c.confrelid = d.oid
+	WHERE c.contype = 'f'
+	UNION
+	SELECT c.conrelid FROM pg_constraint c JOIN deps d ON c.confrelid = d.oid
+	WHERE c.contype = 'f'
+)
+SELECT oid::regclass::text FROM deps
+WHERE oid NOT IN (SELECT oid FROM dumped)
+ORDER BY 1`
+
+// prepareRestoreTarget empties the dumped tables (replace) or checks that
+// they are empty. A migrated database already holds the seeded sources, so
+// restoring into a fresh environment needs replace too.
+//
+// Replace truncates the dumped tables and every table that references
+// them in one statement. A DELETE would fail on references without ON
+// DELETE CASCADE (segments, learning_items) and silently cascade or null
+// out the others; instead the dependents are named in the TRUNCATE, and
+// their row counts are returned, so nothing outside the dump is lost
+// unreported. Without CASCADE, a dependent the query missed fails the
+// restore rather than being emptied.
+func prepareRestoreTarget(ctx context.Context, tx *sql.Tx, replace bool) ([]TableCount, error) {
+	if !replace {
+		for _, t := range backupTables {
+			var exists bool
+			// #nosec G202 -- t.name comes from backupTables.
+			if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM `+t.name+`)`).Scan(&exists); err != nil {
+				return nil, fmt.Errorf("restore: check %s: %w", t.name, err)
+			}
+			if exists {
+				return nil, fmt.Errorf("%w: %s has rows", ErrRestoreTargetNotEmpty, t.name)
+			}
+		}
+		return nil, nil
+	}
+
+	names := make([]string, len(backupTables))
+	for i, t := range backupTables {
+		names[i] = t.name
+	}
+	rows, err := tx.QueryContext(ctx, restoreDependentsQuery, strings.Join(names, ","))
+	if err != nil {
+		return nil, fmt.Errorf("restore: find dependent tables: %w", err)
+	}
+	var dependents []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("restore: find dependent tables: %w", err)
+		}
+		dependents = append(dependents, name)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("restore: find dependent tables: %w", err)
+	}
+
+	cleared := make([]TableCount, 0, len(dependents))
+	for _, name := range dependents {
+		var n int
+		// #nosec G202 -- name is a regclass quoted by postgres.
+		if err := tx.QueryRowContext(ctx, `SELECT count(*) FROM `+name).Scan(&n); err != nil {
+			return nil, fmt.Errorf("restore: count %s: %w", name, err)
+		}
+		cleared = append(cleared, TableCount{Table: name, Rows: n})
+	}
+	// #nosec G202 -- the names come from backupTables and pg_constraint.
+	if _, err := tx.ExecContext(ctx, `TRUNCATE `+strings.Join(append(names, dependents...), ", ")); err != nil {
+		return nil, fmt.Errorf("restore: clear tables: %w", err)
+	}
+	return cleared, nil
+}
+
+// restoreSection buffers the rows of one table and inserts them in
+// batches.
+type restoreSection struct {
+	table   string
+	columns []string
+	batch   []json.RawMessage
+	rows    int
+}
+
+// newRestoreSection accepts only the tables of backupTables and keeps the
+// dumped columns that the table still has.
+func newRestoreSection(ctx context.Context, tx *sql.Tx, table string, dumped []string) (*restoreSection, error) {
+	known := false
+	for _, t := range backupTables {
+		if t.name == table {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return nil, fmt.Errorf("restore: unexpected table %q in dump", table)
+	}
+	current, err := tableColumns(ctx, tx, table)
+	if err != nil {
+		return nil, fmt.Errorf("restore: %s: %w", table, err)
+	}
+	have := make(map[string]bool, len(current))
+	for _, c := range current {
+		have[c] = true
+	}
+	var columns []string
+	for _, c := range dumped {
+		if have[c] {
+			columns = append(columns, c)
+		}
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("restore: %s: no dumped column exists in the table", table)
+	}
+	return &restoreSection{table: table, columns: columns}, nil
+}
+
+func (s *restoreSection) add(ctx context.Context, tx *sql.Tx, row json.RawMessage) error {
+	s.batch = append(s.batch, row)
+	if len(s.batch) < restoreBatchSize {
+		return nil
+	}
+	return s.flush(ctx, tx)
+}
+
+func (s *restoreSection) flush(ctx context.Context, tx *sql.Tx) error {
+	if len(s.batch) == 0 {
+		return nil
+	}
+	// the rows are JSON values already; join them into one array
+	var rows strings.Builder
+	rows.WriteByte('[')
+	for i, row := range s.batch {
+		if i > 0 {
+			rows.WriteByte(',')
+		}
+		rows.Write(row)
+	}
+	rows.WriteByte(']')
+	cols := strings.Join(s.columns, ", ")
+	// #nosec G201 -- table and columns are checked against backupTables and
+	// information_schema.
+	query := fmt.Sprintf(`INSERT INTO %s (%s) SELECT %s FROM jsonb_populate_recordset(NULL::%s, $1::jsonb)`,
+		s.table, cols, cols, s.table)
+	if _, err := tx.ExecContext(ctx, query, rows.String()); err != nil {
+		return err
+	}
+	s.rows += len(s.batch)
+	s.batch = s.batch[:0]
+	return nil
+}
+
+// resetSequence moves the id sequence of the table past its largest id,
+// so rows created after the restore do not collide. Tables without a
+// serial id column are left alone.
+func (s *restoreSection) resetSequence(ctx context.Context, tx *sql.Tx) error {
+	hasID := false
+	for _, c := range s.columns {
+		if c == "id" {
+			hasID = true
+			break
+		}
+	}
+	if !hasID {
+		return nil
+	}
+	// #nosec G201 -- table comes from backupTables.
+	query := fmt.Sprintf(`SELECT setval(pg_get_serial_sequence($1, 'id'), COALESCE(max(id), 0) + 1, false) FROM %s`, s.table)
+	if _, err := tx.ExecContext(ctx, query, s.table); err != nil {
+		return fmt.Errorf("reset sequence: %w", err)
+	}
+	return nil
+}
+
+// tableColumns returns the stored (non-generated) columns of table in
+// definition order. Generated columns such as articles.tsv are derived on
+// insert and cannot be written.
+func tableColumns(ctx context.Context, tx *sql.Tx, table string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, `
+SELECT column_name FROM information_schema.columns
+WHERE table_schema = current_schema() AND table_name = $1 AND is_generated = 'NEVER'
+ORDER BY ordinal_position`, table)
+	if err != nil {
+		return nil, fmt.Errorf("list columns: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var columns []string
+	for rows.Next() {
+		var c string
+		if err := rows.Scan(&c); err != nil {
+			return nil, fmt.Errorf("list columns: %w", err)
+		}
+		columns = append(columns, c)
+	}
+	return columns, rows.Err()
+}
+
+func without(columns, omit []string) []string {
+	if len(omit) == 0 {
+		return columns
+	}
+	out := make([]string, 0, len(columns))
+	for _, c := range columns {
+		keep := true
+		for _, o := range omit {
+			if c == o {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			out = append(out, c)
+		}
+	}
+	return out
+}
@@ -0,0 +1,115 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRestoreReplace_RealPostgres round-trips a dump into a database whose
+// restored tables are referenced from outside the dump, both without ON
+// DELETE CASCADE (segments, learning_items, feed_access_logs) and with it
+// (webhook_deliveries). Replace must clear those dependents and say so
+// instead of failing or cascading silently.
+//
+// It empties the dumped tables of TEST_DATABASE_URL, so point it at a
+// scratch database.
+func TestRestoreReplace_RealPostgres(t *testing.T) {
+	conn := openTestDB(t)
+	require.NoError(t, MigrateUp(conn))
+	ctx := context.Background()
+	nano := time.Now().UnixNano()
+
+	var srcID, articleID, episodeID, subscriberID, tokenID, webhookID int64
+	require.NoError(t, conn.QueryRow(
+		`INSERT INTO sources (name, feed_url, category, active) VALUES ('restore-test', $1, 'dev', false) RETURNING id`,
+		fmt.Sprintf("https://restore.example.com/%d.rss", nano)).Scan(&srcID))
+	require.NoError(t, conn.QueryRow(
+		`INSERT INTO articles (source_id, url, title) VALUES ($1, $2, 'restore test article') RETURNING id`,
+		srcID, fmt.Sprintf("https://restore.example.com/%d/a", nano)).Scan(&articleID))
+	require.NoError(t, conn.QueryRow(
+		`INSERT INTO episodes (feed_kind, title, show_notes, audio_path, audio_bytes, duration_sec)
+		 VALUES ('private', 'restore test ep', '', $1, 1, 1) RETURNING id`,
+		fmt.Sprintf("/data/episodes/restore-%d.mp3", nano)).Scan(&episodeID))
+	t.Cleanup(func() {
+		_, _ = conn.Exec(`DELETE FROM feed_access_logs WHERE episode_id = $1`, episodeID)
+		_, _ = conn.Exec(`DELETE FROM segments WHERE episode_id = $1`, episodeID)
+		_, _ = conn.Exec(`DELETE FROM episodes WHERE id = $1`, episodeID)
+	})
+	_, err := conn.Exec(`INSERT INTO segments (episode_id, position, kind, article_id, script) VALUES ($1, 0, 'news', $2, 'x')`,
+		episodeID, articleID)
+	require.NoError(t, err)
+	_, err = conn.Exec(`INSERT INTO learning_items (kind, article_id, concept, question, answer, provider, due_on)
+		VALUES ('article', $1, 'c', 'q', 'a', 'gemini', current_date)`, articleID)
+	require.NoError(t, err)
+	require.NoError(t, conn.QueryRow(`INSERT INTO subscribers (name) VALUES ('restore-test') RETURNING id`).Scan(&subscriberID))
+	require.NoError(t, conn.QueryRow(`INSERT INTO feed_tokens (subscriber_id, token_hash) VALUES ($1, $2) RETURNING id`,
+		subscriberID, fmt.Sprintf("restore-%d", nano)).Scan(&tokenID))
+	_, err = conn.Exec(`INSERT INTO feed_access_logs (token_id, episode_id) VALUES ($1, $2)`, tokenID, episodeID)
+	require.NoError(t, err)
+	require.NoError(t, conn.QueryRow(`INSERT INTO webhooks (url, secret, events) VALUES ('https://hooks.example.com', 's', '[]') RETURNING id`).
+		Scan(&webhookID))
+	_, err = conn.Exec(`INSERT INTO webhook_deliveries (webhook_id, event, payload) VALUES ($1, 'article.created', '{}')`, webhookID)
+	require.NoError(t, err)
+
+	var dump bytes.Buffer
+	backedUp, err := Backup(ctx, conn, &dump, BackupOptions{})
+	require.NoError(t, err)
+
+	// Written after the backup: Replace must drop it.
+	var lateID int64
+	require.NoError(t, conn.QueryRow(
+		`INSERT INTO articles (source_id, url, title) VALUES ($1, $2, 'after backup') RETURNING id`,
+		srcID, fmt.Sprintf("https://restore.example.com/%d/late", nano)).Scan(&lateID))
+
+	// A failing restore leaves everything in place, dependents included.
+	broken := dump.String() + `{"table":"jobs","columns":["id"]}` + "\n"
+	_, err = Restore(ctx, conn, strings.NewReader(broken), RestoreOptions{Replace: true})
+	require.Error(t, err)
+	assert.Equal(t, 1, countRows(t, conn, `SELECT count(*) FROM segments WHERE episode_id = $1`, episodeID))
+	assert.Equal(t, 1, countRows(t, conn, `SELECT count(*) FROM articles WHERE id = $1`, lateID))
+
+	res, err := Restore(ctx, conn, bytes.NewReader(dump.Bytes()), RestoreOptions{Replace: true})
+	require.NoError(t, err)
+	assert.Equal(t, backedUp, res.Restored)
+	cleared := map[string]int{}
+	for _, c := range res.Cleared {
+		cleared[c.Table] = c.Rows
+	}
+	for _, table := range []string{"segments", "learning_items", "feed_access_logs", "webhook_deliveries"} {
+		assert.GreaterOrEqual(t, cleared[table], 1, "%s is reported as cleared", table)
+		assert.Equal(t, 0, countRows(t, conn, `SELECT count(*) FROM `+table), table)
+	}
+	for _, c := range res.Cleared {
+		for _, bt := range backupTables {
+			assert.NotEqual(t, bt.name, c.Table, "dumped tables are restored, not reported as cleared")
+		}
+	}
+	assert.NotContains(t, cleared, "episodes", "tables the dump does not reach are kept")
+	assert.Equal(t, 1, countRows(t, conn, `SELECT count(*) FROM episodes WHERE id = $1`, episodeID))
+
+	assert.Equal(t, 1, countRows(t, conn, `SELECT count(*) FROM articles WHERE id = $1`, articleID))
+	assert.Equal(t, 0, countRows(t, conn, `SELECT count(*) FROM articles WHERE id = $1`, lateID))
+	assert.Equal(t, 1, countRows(t, conn, `SELECT count(*) FROM feed_tokens WHERE id = $1`, tokenID))
+
+	// The sequences moved past the restored IDs.
+	var nextID int64
+	require.NoError(t, conn.QueryRow(
+		`INSERT INTO articles (source_id, url, title) VALUES ($1, $2, 'after restore') RETURNING id`,
+		srcID, fmt.Sprintf("https://restore.example.com/%d/next", nano)).Scan(&nextID))
+	assert.Greater(t, nextID, articleID)
+}
+
+func countRows(t *testing.T, conn *sql.DB, query string, args ...any) int {
+	t.Helper()
+	var n int
+	require.NoError(t, conn.QueryRow(query, args...).Scan(&n))
+	return n
+}
@@ -0,0 +1,224 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const columnsQuery = "SELECT column_name FROM information_schema.columns"
+
+// Every dumped table must be one MigrateUp creates.
+func TestBackupTables_AreMigratedTables(t *testing.T) {
+	for _, bt := range backupTables {
+		assert.Contains(t, wantTables, bt.name)
+	}
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	ctx := context.Background()
+	columns := func(table string) []string {
+		switch table {
+		case "sources":
+			return []string{"id", "name"}
+		case "articles":
+			return []string{"id", "source_id", "title", "content"}
+		case "user_mfa":
+			return []string{"user_id", "secret"}
+		}
+		return []string{"id"}
+	}
+
+	// ===== backup(本文なし)=====
+	src, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = src.Close() }()
+
+	mock.ExpectBegin()
+	for _, bt := range backupTables {
+		if bt.name == "article_contents" {
+			continue
+		}
+		cols := sqlmock.NewRows([]string{"column_name"})
+		for _, c := range columns(bt.name) {
+			cols.AddRow(c)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(columnsQuery)).WithArgs(bt.name).WillReturnRows(cols)
+		rows := sqlmock.NewRows([]string{"to_jsonb"})
+		switch bt.name {
+		case "sources":
+			rows.AddRow([]byte(`{"id": 3, "name": "Go Blog"}`))
+		case "articles":
+			rows.AddRow([]byte(`{"id": 7, "source_id": 3, "title": "Go 1.26 & more"}`))
+		}
+		want := "FROM (SELECT " + strings.Join(without(columns(bt.name), bt.contentColumns), ", ") +
+			" FROM " + bt.name + " ORDER BY " + bt.orderBy + ") r"
+		mock.ExpectQuery(regexp.QuoteMeta(want)).WillReturnRows(rows)
+	}
+	mock.ExpectRollback()
+
+	var dump bytes.Buffer
+	created := time.Date(2026, 10, 15, 3, 0, 0, 0, time.UTC)
+	counts, err := Backup(ctx, src, &dump, BackupOptions{WithoutContent: true, Now: func() time.Time { return created }})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	assert.Len(t, counts, len(backupTables)-1)
+	assert.Contains(t, counts, TableCount{Table: "articles", Rows: 1})
+
+	lines := strings.Split(strings.TrimSpace(dump.String()), "\n")
+	assert.Equal(t, `{"format":"catchup-feed-backup","version":1,"created_at":"2026-10-15T03:00:00Z","without_content":true}`, lines[0])
+	assert.Contains(t, dump.String(), `{"table":"articles","columns":["id","source_id","title"]}`+"\n"+
+		`{"row":{"id":7,"source_id":3,"title":"Go 1.26 & more"}}`)
+	assert.NotContains(t, dump.String(), "article_contents")
+
+	// ===== restore(新しいスキーマに image_url が増えている)=====
+	dst, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = dst.Close() }()
+
+	mock.ExpectBegin()
+	for _, bt := range backupTables {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM " + bt.name + ")")).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	}
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE articles DISABLE TRIGGER articles_notify")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	for _, bt := range backupTables {
+		if bt.name == "article_contents" {
+			continue
+		}
+		current := columns(bt.name)
+		if bt.name == "articles" {
+			current = []string{"id", "source_id", "title", "content", "image_url"}
+		}
+		cols := sqlmock.NewRows([]string{"column_name"})
+		for _, c := range current {
+			cols.AddRow(c)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(columnsQuery)).WithArgs(bt.name).WillReturnRows(cols)
+		switch bt.name {
+		case "sources":
+			mock.ExpectExec(regexp.QuoteMeta("INSERT INTO sources (id, name) SELECT id, name FROM jsonb_populate_recordset(NULL::sources, $1::jsonb)")).
+				WithArgs(`[{"id":3,"name":"Go Blog"}]`).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		case "articles":
+			mock.ExpectExec(regexp.QuoteMeta("INSERT INTO articles (id, source_id, title) SELECT id, source_id, title FROM jsonb_populate_recordset")).
+				WithArgs(`[{"id":7,"source_id":3,"title":"Go 1.26 & more"}]`).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+	}
+	for _, bt := range backupTables {
+		if bt.name == "article_contents" || bt.name == "user_mfa" {
+			continue // 出力に無い / id 列が無い
+		}
+		mock.ExpectExec(regexp.QuoteMeta("SELECT setval(pg_get_serial_sequence($1, 'id'), COALESCE(max(id), 0) + 1, false) FROM " + bt.name)).
+			WithArgs(bt.name).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE articles ENABLE TRIGGER articles_notify")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	res, err := Restore(ctx, dst, &dump, RestoreOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, counts, res.Restored)
+	assert.Empty(t, res.Cleared)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRestore_RefusesNonEmptyTarget(t *testing.T) {
+	database, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = database.Close() }()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM source_groups)")).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM sources)")).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	dump := `{"format":"catchup-feed-backup","version":1}` + "\n"
+	_, err = Restore(context.Background(), database, strings.NewReader(dump), RestoreOptions{})
+	assert.ErrorIs(t, err, ErrRestoreTargetNotEmpty)
+	assert.Contains(t, err.Error(), "sources has rows")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// A dump is checked before the transaction starts.
+func TestRestore_RejectsInvalidDump(t *testing.T) {
+	tests := map[string]string{
+		"not json":      "hello",
+		"other format":  `{"format":"pg_dump","version":1}`,
+		"newer version": `{"format":"catchup-feed-backup","version":2}`,
+	}
+	for name, dump := range tests {
+		t.Run(name, func(t *testing.T) {
+			database, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = database.Close() }()
+
+			_, err = Restore(context.Background(), database, strings.NewReader(dump), RestoreOptions{Replace: true})
+			assert.Error(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRestore_RejectsUnknownTable(t *testing.T) {
+	database, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = database.Close() }()
+
+	mock.ExpectBegin()
+	expectReplace(mock)
+	mock.ExpectExec("ALTER TABLE articles DISABLE TRIGGER").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	dump := `{"format":"catchup-feed-backup","version":1}` + "\n" + `{"table":"jobs","columns":["id"]}` + "\n"
+	_, err = Restore(context.Background(), database, strings.NewReader(dump), RestoreOptions{Replace: true})
+	assert.EqualError(t, err, `restore: unexpected table "jobs" in dump`)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// expectReplace expects Replace to find segments referencing a dumped
+// table and truncate it with the dumped tables.
+func expectReplace(mock sqlmock.Sqlmock) {
+	names := make([]string, len(backupTables))
+	for i, t := range backupTables {
+		names[i] = t.name
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("WITH RECURSIVE dumped")).
+		WithArgs(strings.Join(names, ",")).
+		WillReturnRows(sqlmock.NewRows([]string{"oid"}).AddRow("segments"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM segments")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+	mock.ExpectExec(regexp.QuoteMeta("TRUNCATE " + strings.Join(names, ", ") + ", segments")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func TestRestore_ReplaceReportsClearedDependents(t *testing.T) {
+	database, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = database.Close() }()
+
+	mock.ExpectBegin()
+	expectReplace(mock)
+	mock.ExpectExec("ALTER TABLE articles DISABLE TRIGGER").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE articles ENABLE TRIGGER").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	dump := `{"format":"catchup-feed-backup","version":1}` + "\n"
+	res, err := Restore(context.Background(), database, strings.NewReader(dump), RestoreOptions{Replace: true})
+	require.NoError(t, err)
+	assert.Empty(t, res.Restored)
+	assert.Equal(t, []TableCount{{Table: "segments", Rows: 4}}, res.Cleared)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
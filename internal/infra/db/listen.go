@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Reconnect backoff of Listen.
+const (
+	listenMinBackoff = time.Second
+	listenMaxBackoff = 30 * time.Second
+)
+
+// Listen LISTENs on channel over a dedicated connection (outside the pool:
+// a listening connection is held for the process lifetime) and calls fn
+// with every notification payload until ctx is cancelled. fn runs on the
+// listening goroutine and must not block. A lost connection is reopened
+// with backoff; notifications sent in between are gone (PostgreSQL keeps
+// none for absent listeners), so consumers treat the stream as a hint
+// and the tables stay the source of truth (§8 縮退).
+func Listen(ctx context.Context, dsn, channel string, fn func(payload string), logger *slog.Logger) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	backoff := listenMinBackoff
+	for {
+		start := time.Now()
+		err := listenOnce(ctx, dsn, channel, fn, logger)
+		if ctx.Err() != nil {
+			return
+		}
+		// A connection that lived a while resets the backoff.
+		if time.Since(start) > listenMaxBackoff {
+			backoff = listenMinBackoff
+		}
+		logger.Warn("database listener disconnected, reconnecting",
+			slog.String("channel", channel),
+			slog.Duration("backoff", backoff),
+			slog.Any("error", err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, listenMaxBackoff)
+	}
+}
+
+// listenOnce connects, LISTENs and delivers notifications until the
+// connection fails or ctx ends.
+func listenOnce(ctx context.Context, dsn, channel string, fn func(payload string), logger *slog.Logger) error {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = conn.Close(closeCtx)
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	logger.Info("database listener started", slog.String("channel", channel))
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		fn(n.Payload)
+	}
+}
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// TestListen_ArticleInsert_RealPostgres pins the articles_notify trigger
+// end to end: an inserted article reaches a Listen callback as an
+// entity.ArticleEvent. Skipped unless TEST_DATABASE_URL is set.
+func TestListen_ArticleInsert_RealPostgres(t *testing.T) {
+	conn := openTestDB(t)
+	require.NoError(t, MigrateUp(conn))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	payloads := make(chan string, 1)
+	go Listen(ctx, os.Getenv("TEST_DATABASE_URL"), entity.ArticleEventChannel, func(p string) { payloads <- p }, nil)
+	// LISTEN が張られるまで待つ
+	time.Sleep(500 * time.Millisecond)
+
+	var sourceID, articleID int64
+	require.NoError(t, conn.QueryRow(
+		`INSERT INTO sources (name, feed_url, category) VALUES ('listen', 'https://example.com/listen-'||md5(random()::text), 'tech') RETURNING id`,
+	).Scan(&sourceID))
+	t.Cleanup(func() {
+		_, _ = conn.Exec(`DELETE FROM articles WHERE source_id = $1`, sourceID)
+		_, _ = conn.Exec(`DELETE FROM sources WHERE id = $1`, sourceID)
+	})
+	require.NoError(t, conn.QueryRow(
+		`INSERT INTO articles (source_id, title, url, crawled_at) VALUES ($1, 't', 'https://example.com/a-'||md5(random()::text), now()) RETURNING id`,
+		sourceID,
+	).Scan(&articleID))
+
+	select {
+	case p := <-payloads:
+		var ev entity.ArticleEvent
+		require.NoError(t, json.Unmarshal([]byte(p), &ev))
+		assert.Equal(t, entity.ArticleEvent{Type: entity.WebhookEventArticleCreated, ArticleID: articleID, SourceID: sourceID}, ev)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification received")
+	}
+}
+
+// TestListen_SourceHealth_RealPostgres pins the source_health_notify
+// trigger: the first crawl and an ok -> failing flip notify, a repeated
+// failure does not. Skipped unless TEST_DATABASE_URL is set.
+func TestListen_SourceHealth_RealPostgres(t *testing.T) {
+	conn := openTestDB(t)
+	require.NoError(t, MigrateUp(conn))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	payloads := make(chan string, 4)
+	go Listen(ctx, os.Getenv("TEST_DATABASE_URL"), entity.DashboardEventChannel, func(p string) { payloads <- p }, nil)
+	// LISTEN が張られるまで待つ
+	time.Sleep(500 * time.Millisecond)
+
+	var sourceID int64
+	require.NoError(t, conn.QueryRow(
+		`INSERT INTO sources (name, feed_url, category) VALUES ('listen', 'https://example.com/listen-'||md5(random()::text), 'tech') RETURNING id`,
+	).Scan(&sourceID))
+	t.Cleanup(func() {
+		_, _ = conn.Exec(`DELETE FROM sources WHERE id = $1`, sourceID)
+	})
+	record := func(failures int) {
+		_, err := conn.Exec(`
+INSERT INTO source_health (source_id, last_crawled_at, consecutive_failures) VALUES ($1, now(), $2)
+ON CONFLICT (source_id) DO UPDATE SET consecutive_failures = EXCLUDED.consecutive_failures`, sourceID, failures)
+		require.NoError(t, err)
+	}
+	next := func() map[string]any {
+		t.Helper()
+		select {
+		case p := <-payloads:
+			var ev entity.DashboardEvent
+			require.NoError(t, json.Unmarshal([]byte(p), &ev))
+			assert.Equal(t, entity.DashboardEventSourceHealth, ev.Type)
+			assert.Equal(t, sourceID, ev.SourceID)
+			var data map[string]any
+			require.NoError(t, json.Unmarshal(ev.Data, &data))
+			return data
+		case <-time.After(5 * time.Second):
+			t.Fatal("no notification received")
+			return nil
+		}
+	}
+
+	record(0)
+	assert.Equal(t, entity.SourceHealthOK, next()["status"])
+	record(0) // unchanged: silent
+	record(1)
+	assert.Equal(t, entity.SourceHealthFailing, next()["status"])
+	record(2) // still failing: silent
+	select {
+	case p := <-payloads:
+		t.Fatalf("unexpected notification: %s", p)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
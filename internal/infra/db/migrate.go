@@ -26,6 +26,12 @@ var seedSourcesSQL string
 // with a half-applied schema.
 const createVectorExtension = `CREATE EXTENSION IF NOT EXISTS vector`
 
+// createTrgmExtension enables pg_trgm for the trigram indexes behind the
+// article keyword search fallback (ILIKE on title / summary body). pg_trgm
+// ships with PostgreSQL's contrib modules, which every official image
+// (pgvector/pgvector:pg18 included) contains.
+const createTrgmExtension = `CREATE EXTENSION IF NOT EXISTS pg_trgm`
+
 // createTableStatements is the §4 schema, one statement per table, in
 // dependency order.
 var createTableStatements = []string{
@@ -40,6 +46,11 @@ var createTableStatements = []string{
                   CONSTRAINT sources_kind_check
                   CHECK (kind IN ('rss', 'youtube', 'podcast')),  -- Phase 2 §4
     active        boolean NOT NULL DEFAULT true,
+    crawl_schedule text,                    -- NULL = worker の CRON_SCHEDULE に従う
+    retention_days int,                     -- NULL = worker の RETENTION_DAYS に従う
+    render_js     boolean NOT NULL DEFAULT false, -- 本文取得にヘッドレスブラウザを使う
+    etag          text,                     -- 前回のフィード応答の ETag(条件付き GET)
+    last_modified text,                     -- 前回のフィード応答の Last-Modified
     created_at    timestamptz NOT NULL DEFAULT now()
 )`,
 	`CREATE TABLE IF NOT EXISTS articles (
@@ -86,14 +97,18 @@ var createTableStatements = []string{
     created_at     timestamptz NOT NULL DEFAULT now(),
     deactivated_at timestamptz              -- NULL = アクティブ
 )`,
-	// viewers: 友人向け閲覧専用アカウント(D-27)。subscribers(ポッドキャスト
-	// の視聴コントロール)とは別エンティティ — こちらは Web ダッシュボードへの
+	// users: ダッシュボードのアカウント。admin と viewer(友人向け閲覧専用、
+	// D-27)を role で区別する。subscribers(ポッドキャストの視聴
+	// コントロール)とは別エンティティ — こちらは Web ダッシュボードへの
 	// アクセスコントロール。無効化は論理(deactivated_at)、削除は物理。
-	// CREATE TABLE IF NOT EXISTS なので既存 DB への追加マイグレーションとして安全。
-	`CREATE TABLE IF NOT EXISTS viewers (
+	// admin は以前は環境変数のみ(C-7)だったが、現在の環境変数は最初の
+	// admin を作るブートストラップ専用。旧 viewers テーブルの行は
+	// alterTableStatements で移す。
+	`CREATE TABLE IF NOT EXISTS users (
     id             bigserial PRIMARY KEY,
     name           text NOT NULL,
     email          text NOT NULL UNIQUE,
+    role           text NOT NULL,            -- admin / viewer / AUTH_ROLES のカスタムロール
     password_hash  text NOT NULL,            -- bcrypt(admin が作成時に設定)
     created_at     timestamptz NOT NULL DEFAULT now(),
     updated_at     timestamptz NOT NULL DEFAULT now(),
@@ -122,7 +137,9 @@ var createTableStatements = []string{
     attempts      int NOT NULL DEFAULT 0,
     last_error    text,
     run_after     timestamptz NOT NULL DEFAULT now(),
-    created_at    timestamptz NOT NULL DEFAULT now()
+    created_at    timestamptz NOT NULL DEFAULT now(),
+    claimed_at    timestamptz,              -- 直近の claim 時刻(stale sweep の基準)
+    dedupe_key    text                      -- 同一キーの pending/running は1件まで
 )`,
 	// ===== 書籍 RAG(Phase 2 §6)=====
 	// Go コードからのアクセスは Phase 2 では発生しない(書き込み・検索は
@@ -172,6 +189,270 @@ var createTableStatements = []string{
   result      text,                           -- 'good' | 'fuzzy' | 'forgot' | NULL = 未採点
   graded_at   timestamptz,                    -- 採点時刻(自動解決時は NULL のまま result='auto' — §6)
   UNIQUE (item_id, asked_on)                  -- 同日 rev 再実行(radio の冪等仕様)の冪等キー
+)`,
+	// ===== 監査ログ(管理操作の記録)=====
+	// sources / articles の作成・更新・削除と JWT 発行を1行ずつ残す。
+	// resource_id に FK は張らない: 削除された行の記録こそ残したい。
+	// before / after は変更前後のスナップショット(作成は before が NULL、
+	// 削除は after が NULL)。
+	`CREATE TABLE IF NOT EXISTS audit_logs (
+    id            bigserial PRIMARY KEY,
+    actor         text NOT NULL,            -- JWT の sub(管理者ユーザー名 / viewer email)
+    action        text NOT NULL,            -- 'create' | 'update' | 'delete' | 'issue'
+    resource_type text NOT NULL,            -- 'source' | 'article' | 'auth_token'
+    resource_id   bigint,
+    request_id    text,
+    ip            text,
+    before        jsonb,
+    after         jsonb,
+    created_at    timestamptz NOT NULL DEFAULT now()
+)`,
+	// ===== API キー(サービス間アクセス用、X-API-Key ヘッダ)=====
+	// feed_tokens と同じく平文は保存せず SHA-256 hex のみ。rate_limit /
+	// daily_quota はキー単位の上限(1分 / 24時間のスライディングウィンドウ)。
+	`CREATE TABLE IF NOT EXISTS api_keys (
+    id            bigserial PRIMARY KEY,
+    name          text NOT NULL,
+    role          text NOT NULL,            -- admin / viewer / AUTH_ROLES のカスタムロール
+    key_hash      text NOT NULL UNIQUE,     -- SHA-256 hex
+    key_prefix    text NOT NULL,            -- 一覧表示用の先頭12文字
+    rate_limit    int  NOT NULL DEFAULT 60, -- リクエスト/分
+    daily_quota   int,                      -- リクエスト/24時間(NULL = 無制限)
+    created_at    timestamptz NOT NULL DEFAULT now(),
+    last_used_at  timestamptz,
+    revoked_at    timestamptz               -- NULL = 有効
+)`,
+	// ===== リフレッシュトークン(/auth/refresh、ローテーション+再利用検知)=====
+	// 平文は保存せず SHA-256 hex のみ。family_id はログイン1回ごとの系列で、
+	// 使用済みトークンの再提示を検知したら系列ごと revoked_at を立てる。
+	`CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id            bigserial PRIMARY KEY,
+    family_id     text NOT NULL,
+    subject       text NOT NULL,
+    role          text NOT NULL,
+    token_hash    text NOT NULL UNIQUE,     -- SHA-256 hex
+    expires_at    timestamptz NOT NULL,
+    created_at    timestamptz NOT NULL DEFAULT now(),
+    used_at       timestamptz,              -- ローテーション済み
+    revoked_at    timestamptz               -- NULL = 有効
+)`,
+	// ===== 失効済みアクセストークン(jti の拒否リスト、/auth/revoke・ログアウト)=====
+	// JWT は自己完結で失効できないため、jti を期限まで保持して middleware が
+	// 照合する。expires_at(= JWT の exp)を過ぎた行は定期クリーンアップで削除。
+	`CREATE TABLE IF NOT EXISTS revoked_tokens (
+    jti           text PRIMARY KEY,
+    subject       text NOT NULL,
+    expires_at    timestamptz NOT NULL,
+    revoked_at    timestamptz NOT NULL DEFAULT now()
+)`,
+	// ===== TOTP 多要素認証(admin の任意設定)=====
+	// secret はコード検証に平文が必要なためハッシュ化できない。enabled_at が
+	// NULL の行は登録途中(確認コード未入力)。last_step は最後に受理した
+	// コードの時間ステップで、同じコードの再利用を防ぐ。
+	`CREATE TABLE IF NOT EXISTS user_mfa (
+    user_id       bigint PRIMARY KEY REFERENCES users ON DELETE CASCADE,
+    secret        text NOT NULL,
+    enabled_at    timestamptz,              -- NULL = 登録途中
+    last_step     bigint NOT NULL DEFAULT 0,
+    created_at    timestamptz NOT NULL DEFAULT now()
+)`,
+	// ===== タグ(記事の自由ラベル)=====
+	// name は正規化済み(小文字・空白1つ、entity.NormalizeTagName)で一意。
+	`CREATE TABLE IF NOT EXISTS tags (
+    id            bigserial PRIMARY KEY,
+    name          text NOT NULL UNIQUE,
+    created_at    timestamptz NOT NULL DEFAULT now()
+)`,
+	// 記事とタグの多対多。記事・タグのどちらを消しても付与は消える。
+	`CREATE TABLE IF NOT EXISTS article_tags (
+    article_id    bigint NOT NULL REFERENCES articles ON DELETE CASCADE,
+    tag_id        bigint NOT NULL REFERENCES tags ON DELETE CASCADE,
+    created_at    timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (article_id, tag_id)
+)`,
+	// ===== 既読管理(ユーザーごと)=====
+	// unread_at が NULL の行がある記事が既読、行がないか unread_at のある
+	// 記事は未読。未読に戻すと行は消さずに unread_at を記録する(同期 API
+	// が他の端末へ変更を渡すため、alterTableStatements で追加)。
+	`CREATE TABLE IF NOT EXISTS article_read_state (
+    user_id       bigint NOT NULL REFERENCES users ON DELETE CASCADE,
+    article_id    bigint NOT NULL REFERENCES articles ON DELETE CASCADE,
+    read_at       timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, article_id)
+)`,
+	// ===== お気に入り(ユーザーごと)=====
+	// 行がある記事がお気に入り。解除すると行を消す。
+	`CREATE TABLE IF NOT EXISTS article_favorites (
+    user_id       bigint NOT NULL REFERENCES users ON DELETE CASCADE,
+    article_id    bigint NOT NULL REFERENCES articles ON DELETE CASCADE,
+    created_at    timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, article_id)
+)`,
+	// ===== ソース購読(ユーザーごと)=====
+	// 行があるソースを購読中。admin 以外のアカウントの記事一覧・検索・
+	// イベントは購読中のソースに絞られる。解除すると行を消す。
+	`CREATE TABLE IF NOT EXISTS source_subscriptions (
+    user_id       bigint NOT NULL REFERENCES users ON DELETE CASCADE,
+    source_id     bigint NOT NULL REFERENCES sources ON DELETE CASCADE,
+    created_at    timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, source_id)
+)`,
+	// ===== レート制限(RATE_LIMIT_STORE=postgres のときのみ使用)=====
+	// 1リクエスト = 1行のスライディングウィンドウ。key は "<scope>:<ip>"。
+	// expires_at を過ぎた行はサーバーの定期クリーンアップが削除する。
+	`CREATE TABLE IF NOT EXISTS rate_limit_hits (
+    key           text NOT NULL,
+    hit_at        timestamptz NOT NULL DEFAULT now(),
+    expires_at    timestamptz NOT NULL
+)`,
+	// 拒否の集計(管理 API の TopDenied 用)。拒否1件ごとに行を足すと
+	// フラッド中に書き込みが増えるため、キーと分単位の bucket ごとに1行の
+	// カウンタを upsert する。
+	`CREATE TABLE IF NOT EXISTS rate_limit_denials (
+    key           text NOT NULL,
+    bucket        timestamptz NOT NULL,     -- 拒否時刻を分単位に切り捨てたもの
+    denied        int NOT NULL DEFAULT 0,
+    expires_at    timestamptz NOT NULL,
+    PRIMARY KEY (key, bucket)
+)`,
+	// ===== Webhook(新着記事・クロール完了の外部通知)=====
+	// secret は署名(HMAC-SHA256)に平文が必要なためハッシュ化できない。
+	// events は購読するイベント名の JSON 配列。
+	`CREATE TABLE IF NOT EXISTS webhooks (
+    id            bigserial PRIMARY KEY,
+    url           text NOT NULL,
+    secret        text NOT NULL,
+    events        jsonb NOT NULL,           -- ["article.created", "crawl.completed"]
+    created_at    timestamptz NOT NULL DEFAULT now()
+)`,
+	// 配信1件 = 1行。送信は deliver_webhook ジョブが行い、再試行はジョブの
+	// attempts 上限に従う。payload は再試行でも同じバイト列を署名・送信する。
+	`CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id              bigserial PRIMARY KEY,
+    webhook_id      bigint NOT NULL REFERENCES webhooks ON DELETE CASCADE,
+    event           text NOT NULL,
+    payload         jsonb NOT NULL,
+    status          text NOT NULL DEFAULT 'pending',  -- pending|delivered|failed
+    attempts        int NOT NULL DEFAULT 0,
+    response_status int,                    -- 最後の試行の HTTP ステータス(NULL = 応答なし)
+    last_error      text,
+    created_at      timestamptz NOT NULL DEFAULT now(),
+    delivered_at    timestamptz
+)`,
+	// ソースごとのクロール結果(worker がフィード取得のたびに upsert)。
+	// 停止・故障したフィードをログを読まずに見つけるための状態で、
+	// 履歴は持たない。
+	`CREATE TABLE IF NOT EXISTS source_health (
+    source_id            bigint PRIMARY KEY REFERENCES sources ON DELETE CASCADE,
+    last_crawled_at      timestamptz NOT NULL,
+    last_success_at      timestamptz,
+    last_http_status     int,               -- NULL = 応答なし(DNS / TLS / タイムアウト)
+    consecutive_failures int NOT NULL DEFAULT 0,
+    last_error           text
+)`,
+	// 記事の元ページ(ContentFetcher が取得した生 HTML と readability 抽出
+	// テキスト)。フロントエンドや AI 処理が元サイトを取り直さずに済む
+	// ように保存する。フィード本文が短い記事だけが対象。
+	`CREATE TABLE IF NOT EXISTS article_contents (
+    article_id  bigint PRIMARY KEY REFERENCES articles ON DELETE CASCADE,
+    url         text NOT NULL,              -- リダイレクト後の最終 URL
+    html        text NOT NULL,
+    text        text NOT NULL,
+    fetched_at  timestamptz NOT NULL DEFAULT now()
+)`,
+	// 保持期間を過ぎてアーカイブされた記事(retention ジョブ、
+	// RETENTION_MODE=archive)。要約本文も一緒に残す。ソースを消しても
+	// 残るように source_id に外部キーは張らない。
+	`CREATE TABLE IF NOT EXISTS articles_archive (
+    id            bigint PRIMARY KEY,       -- 元の articles.id
+    source_id     bigint NOT NULL,
+    url           text NOT NULL,
+    title         text NOT NULL,
+    content       text,
+    summary       text,
+    published_at  timestamptz,
+    crawled_at    timestamptz NOT NULL,
+    archived_at   timestamptz NOT NULL DEFAULT now()
+)`,
+	// クロール実行1回 = 1行(cron・ソース個別スケジュール・即時クロール・
+	// crawl-once 共通)。開始時に running で作り、終了時に集計を書き込む。
+	// finished_at が NULL のまま残った行は worker が途中で落ちた実行。
+	// source_stats はソースごとの集計(HTTP ステータス・取得エラー等)の
+	// JSON 配列。ソースを消しても履歴は残すので外部キーは張らない。
+	`CREATE TABLE IF NOT EXISTS crawl_runs (
+    id                   bigserial PRIMARY KEY,
+    status               text NOT NULL DEFAULT 'running',  -- running|succeeded|failed
+    started_at           timestamptz NOT NULL,
+    finished_at          timestamptz,
+    sources              int NOT NULL DEFAULT 0,
+    fetch_failed_sources int NOT NULL DEFAULT 0,
+    feed_items           int NOT NULL DEFAULT 0,
+    inserted             int NOT NULL DEFAULT 0,
+    duplicated           int NOT NULL DEFAULT 0,
+    summarize_errors     int NOT NULL DEFAULT 0,
+    source_stats         jsonb NOT NULL DEFAULT '[]',
+    error                text
+)`,
+	// ===== クロール制御(メンテナンス用、管理 API から操作)=====
+	// 1行だけのテーブル(id = 1)。paused の間 worker の cron は定期
+	// クロールと要約の掃き取りを登録しない(即時クロールは止めない)。
+	// 行が無い = 停止していない。
+	`CREATE TABLE IF NOT EXISTS crawl_control (
+    id            smallint PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    paused        boolean NOT NULL DEFAULT false,
+    reason        text NOT NULL DEFAULT '',
+    updated_by    text NOT NULL DEFAULT '',
+    updated_at    timestamptz NOT NULL DEFAULT now()
+)`,
+	// 定期クロールから外すソース。行があるソースは cron・ソース個別
+	// スケジュールのクロール対象にならない。外すのをやめると行を消す。
+	`CREATE TABLE IF NOT EXISTS crawl_skipped_sources (
+    source_id     bigint PRIMARY KEY REFERENCES sources ON DELETE CASCADE,
+    created_at    timestamptz NOT NULL DEFAULT now()
+)`,
+	// ===== キーワードアラート(ユーザーごと)=====
+	// query はキーワードの論理式(AND / OR / NOT・括弧・"フレーズ")。クロールで
+	// 入った記事のタイトル・要約が一致すると hit_count を増やし、notify_alert
+	// ジョブで管理者の通知先へ送る。名前はユーザー内で一意。
+	`CREATE TABLE IF NOT EXISTS alert_rules (
+    id            bigserial PRIMARY KEY,
+    user_id       bigint NOT NULL REFERENCES users ON DELETE CASCADE,
+    name          text NOT NULL,
+    query         text NOT NULL,
+    enabled       boolean NOT NULL DEFAULT true,
+    hit_count     bigint NOT NULL DEFAULT 0,
+    last_hit_at   timestamptz,
+    created_at    timestamptz NOT NULL DEFAULT now(),
+    updated_at    timestamptz NOT NULL DEFAULT now(),
+    UNIQUE (user_id, name)
+)`,
+	// ===== ソースグループ(フォルダ)=====
+	// RSS リーダーのフォルダに相当。ソースは sources.group_id で高々1グループに
+	// 属する。crawl_schedule はスケジュールを持たないメンバーのクロール
+	// スケジュール(NULL = worker の CRON_SCHEDULE)、crawl_paused はメンバー全員を
+	// 定期クロールから外す。notify = false のグループの記事はダイジェスト通知に
+	// 載せない。
+	`CREATE TABLE IF NOT EXISTS source_groups (
+    id            bigserial PRIMARY KEY,
+    name          text NOT NULL UNIQUE,
+    crawl_schedule text,
+    crawl_paused  boolean NOT NULL DEFAULT false,
+    notify        boolean NOT NULL DEFAULT true,
+    created_at    timestamptz NOT NULL DEFAULT now(),
+    updated_at    timestamptz NOT NULL DEFAULT now()
+)`,
+	// ===== 記事エクスポート(オブジェクトストレージ)=====
+	// worker の export_articles ジョブが書き出した NDJSON の記録。1行 = 1回の
+	// 実行で、[window_from, window_to) に取得または要約された記事を object_keys
+	// に書き出した。最新行の window_to が次回の開始点、古い行は
+	// EXPORT_RETENTION_DAYS でオブジェクトごと削除する。
+	`CREATE TABLE IF NOT EXISTS article_exports (
+    id            bigserial PRIMARY KEY,
+    window_from   timestamptz NOT NULL,
+    window_to     timestamptz NOT NULL,
+    object_keys   jsonb NOT NULL,           -- ["catchup-feed/articles/dt=2026-10-15/...ndjson.gz"]
+    row_count     int NOT NULL,
+    created_at    timestamptz NOT NULL DEFAULT now()
 )`,
 }
 
@@ -187,6 +468,22 @@ var createTableStatements = []string{
 //     added via a DO block because PostgreSQL has no ADD CONSTRAINT IF NOT
 //     EXISTS; duplicate_object makes the re-run a no-op (fresh databases
 //     already get the constraint inline from CREATE TABLE).
+//   - sources.crawl_schedule: per-source cron expression or interval that
+//     replaces the worker's global CRON_SCHEDULE for that source. Nullable
+//     with no default, so existing rows keep following the global schedule.
+//   - sources.retention_days: per-source article retention window in days
+//     that replaces the worker's RETENTION_DAYS for that source. Nullable
+//     like crawl_schedule.
+//   - sources.render_js: fetch this source's article pages with the
+//     headless browser (HEADLESS_ENABLED) instead of a plain GET. NOT NULL
+//     DEFAULT false, so existing rows keep the readability fetcher.
+//   - sources.etag / sources.last_modified: cache validators of the last
+//     feed response, sent back as If-None-Match / If-Modified-Since so an
+//     unchanged feed answers 304. Nullable: NULL sends an unconditional GET.
+//   - sources.group_id: the source group (folder) the source belongs to.
+//     source_groups is created after sources, so fresh databases get the
+//     column through this ALTER too. ON DELETE SET NULL leaves the members
+//     of a deleted group ungrouped; existing rows stay NULL.
 //   - books.review_cursor / books.review_status (Phase 3 §7.3): book_review
 //     progress lives on the books row (専用テーブルは過剰). The canonical
 //     books CREATE TABLE is owned by catchup-feed-ai (Phase 2 §6), so the
@@ -196,6 +493,69 @@ var createTableStatements = []string{
 //     "active は同時に最大1冊" exclusivity is a cross-row invariant that a
 //     column CHECK cannot express, so it is enforced in the application
 //     layer (設計書 §7.3, 管理 API の activate が担う).
+//   - rate_limit_hits.denied: rejected requests used to be kept as rows
+//     too. Denials are now counted in rate_limit_denials, so the DO block
+//     deletes the old denied rows and drops the column. Once the column is
+//     gone the block is a no-op.
+//   - viewers → users: accounts moved into the role-aware users table. The
+//     DO block copies the legacy viewers rows (role='viewer', timestamps
+//     and deactivation preserved) and drops the old table in a single
+//     statement, so a failed copy leaves viewers untouched. Once viewers is
+//     gone the block is a no-op.
+//   - users.role / refresh_tokens.role / api_keys.role: the admin|viewer
+//     CHECK is dropped because custom roles (AUTH_ROLES) are configuration,
+//     not schema. The user and API key use cases validate the role against
+//     the configured set instead.
+//   - articles.tsv / summaries.tsv: full-text search vectors, generated
+//     (STORED) from the title and the summary body so they never go stale.
+//     Always built with the 'simple' configuration — a generated column
+//     needs a fixed one; SEARCH_LANGUAGE other than simple is applied at
+//     query time instead (see postgres.ArticleQueryBuilder). Adding a
+//     stored generated column rewrites the table once; fresh and existing
+//     databases both get the columns through these ALTERs.
+//   - articles.content_hash: entity.ArticleContentHash (normalized URL +
+//     title + feed content) set on insert, so the crawl can skip an article
+//     republished under a different tracking query string. Rows stored
+//     before the column existed stay NULL and are only deduplicated by URL.
+//   - articles.simhash / articles.duplicate_of: near-duplicate grouping.
+//     simhash is entity.ArticleSimHash (title + stored content; NULL when
+//     the text is too short to fingerprint). duplicate_of points a
+//     near-duplicate from another source at the canonical (first stored)
+//     article of its group; ON DELETE SET NULL makes the copy standalone
+//     again when the canonical one is purged. Rows stored before the
+//     columns existed stay NULL and are never grouped.
+//   - articles.image_url: the thumbnail (og:image / twitter:image) of the
+//     page fetched for content enhancement, absolute http(s) only. NULL
+//     when no page was fetched or it declares no image; existing rows stay
+//     NULL.
+//   - jobs.claimed_at / jobs.dedupe_key: the queue is shared by several
+//     worker replicas. claimed_at (set by ClaimNext) lets a consumer requeue
+//     only running rows older than its job timeout — a fresh one is live on
+//     another replica. dedupe_key lets every replica's cron enqueue the same
+//     tick without duplicates (idx_jobs_dedupe_key). The Mac worker claims
+//     without setting claimed_at; the Pi never sweeps its kinds anyway.
+//   - webhooks.route: entity.WebhookRoute as JSON (source_ids / categories /
+//     keywords / fallback), evaluated by the webhook use case when an
+//     article.created event is published. '{}' is the pre-existing
+//     behavior: every article.
+//   - article_read_state.unread_at: marking an article unread keeps the
+//     row as a timestamped unread mark instead of deleting it, so the
+//     read-state sync API can hand the change to other devices and merge
+//     offline changes last-writer-wins. NULL = read (at read_at); existing
+//     rows are all read marks and need no backfill.
+//   - notify_article_event / articles_notify: NOTIFY article_events
+//     (entity.ArticleEvent as JSON) for every inserted article. NOTIFY is
+//     transactional, so listeners (the server's GET /articles/events) only
+//     hear about committed rows, and a rolled-back crawl insert stays
+//     silent. Without a listener the notification is dropped.
+//   - notify_crawl_completed / crawl_runs_notify and notify_source_health /
+//     source_health_notify: NOTIFY dashboard_events (entity.DashboardEvent
+//     as JSON) for GET /ws when a crawl run finishes (finished_at goes from
+//     NULL to set, whatever its status) and when a source is first crawled
+//     or flips between ok and failing. Recording an unchanged health state
+//     stays silent, so a routine crawl does not send one event per source.
+//     last_error is cut to 500 characters to stay far below the 8000-byte
+//     NOTIFY payload limit.
 var alterTableStatements = []string{
 	`ALTER TABLE sources ADD COLUMN IF NOT EXISTS kind text NOT NULL DEFAULT 'rss'`,
 	`DO $$
@@ -205,25 +565,177 @@ BEGIN
 EXCEPTION
     WHEN duplicate_object THEN NULL;
 END $$`,
+	`ALTER TABLE sources ADD COLUMN IF NOT EXISTS crawl_schedule text`,
+	`ALTER TABLE sources ADD COLUMN IF NOT EXISTS retention_days int`,
+	`ALTER TABLE sources ADD COLUMN IF NOT EXISTS render_js boolean NOT NULL DEFAULT false`,
+	`ALTER TABLE sources ADD COLUMN IF NOT EXISTS etag text`,
+	`ALTER TABLE sources ADD COLUMN IF NOT EXISTS last_modified text`,
+	`ALTER TABLE sources ADD COLUMN IF NOT EXISTS group_id bigint REFERENCES source_groups ON DELETE SET NULL`,
 	`ALTER TABLE books ADD COLUMN IF NOT EXISTS review_cursor int NOT NULL DEFAULT 0`,
 	`ALTER TABLE books ADD COLUMN IF NOT EXISTS review_status text NOT NULL DEFAULT 'idle'`,
+	`DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns
+               WHERE table_schema = current_schema() AND table_name = 'rate_limit_hits' AND column_name = 'denied') THEN
+        DELETE FROM rate_limit_hits WHERE denied;
+        ALTER TABLE rate_limit_hits DROP COLUMN denied;
+    END IF;
+END $$`,
+	`DO $$
+BEGIN
+    IF to_regclass('viewers') IS NOT NULL THEN
+        INSERT INTO users (name, email, role, password_hash, created_at, updated_at, deactivated_at)
+        SELECT name, lower(email), 'viewer', password_hash, created_at, updated_at, deactivated_at
+        FROM viewers
+        ON CONFLICT (email) DO NOTHING;
+        DROP TABLE viewers;
+    END IF;
+END $$`,
+	`ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check`,
+	`ALTER TABLE refresh_tokens DROP CONSTRAINT IF EXISTS refresh_tokens_role_check`,
+	`ALTER TABLE api_keys DROP CONSTRAINT IF EXISTS api_keys_role_check`,
+	`ALTER TABLE articles ADD COLUMN IF NOT EXISTS tsv tsvector
+    GENERATED ALWAYS AS (to_tsvector('simple', title)) STORED`,
+	`ALTER TABLE summaries ADD COLUMN IF NOT EXISTS tsv tsvector
+    GENERATED ALWAYS AS (to_tsvector('simple', body)) STORED`,
+	`ALTER TABLE articles ADD COLUMN IF NOT EXISTS content_hash text`,
+	`ALTER TABLE articles ADD COLUMN IF NOT EXISTS simhash bigint`,
+	`ALTER TABLE articles ADD COLUMN IF NOT EXISTS duplicate_of bigint REFERENCES articles ON DELETE SET NULL`,
+	`ALTER TABLE articles ADD COLUMN IF NOT EXISTS image_url text`,
+	`ALTER TABLE jobs ADD COLUMN IF NOT EXISTS claimed_at timestamptz`,
+	`ALTER TABLE jobs ADD COLUMN IF NOT EXISTS dedupe_key text`,
+	`ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS route jsonb NOT NULL DEFAULT '{}'`,
+	`ALTER TABLE article_read_state ADD COLUMN IF NOT EXISTS unread_at timestamptz`,
+	`CREATE OR REPLACE FUNCTION notify_article_event() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    PERFORM pg_notify('article_events', json_build_object(
+        'type', 'article.created',
+        'article_id', NEW.id,
+        'source_id', NEW.source_id
+    )::text);
+    RETURN NULL;
+END $$`,
+	`CREATE OR REPLACE TRIGGER articles_notify
+    AFTER INSERT ON articles
+    FOR EACH ROW EXECUTE FUNCTION notify_article_event()`,
+	`CREATE OR REPLACE FUNCTION notify_crawl_completed() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    PERFORM pg_notify('dashboard_events', json_build_object(
+        'type', 'crawl.completed',
+        'data', json_build_object(
+            'crawl_run_id', NEW.id,
+            'status', NEW.status,
+            'sources', NEW.sources,
+            'fetch_failed_sources', NEW.fetch_failed_sources,
+            'feed_items', NEW.feed_items,
+            'inserted', NEW.inserted,
+            'duplicated', NEW.duplicated,
+            'summarize_errors', NEW.summarize_errors,
+            'started_at', NEW.started_at,
+            'finished_at', NEW.finished_at
+        )
+    )::text);
+    RETURN NULL;
+END $$`,
+	`CREATE OR REPLACE TRIGGER crawl_runs_notify
+    AFTER UPDATE OF finished_at ON crawl_runs
+    FOR EACH ROW WHEN (OLD.finished_at IS NULL AND NEW.finished_at IS NOT NULL)
+    EXECUTE FUNCTION notify_crawl_completed()`,
+	`CREATE OR REPLACE FUNCTION notify_source_health() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND (OLD.consecutive_failures > 0) = (NEW.consecutive_failures > 0) THEN
+        RETURN NULL;
+    END IF;
+    PERFORM pg_notify('dashboard_events', json_build_object(
+        'type', 'source.health',
+        'source_id', NEW.source_id,
+        'data', json_build_object(
+            'source_id', NEW.source_id,
+            'status', CASE WHEN NEW.consecutive_failures > 0 THEN 'failing' ELSE 'ok' END,
+            'consecutive_failures', NEW.consecutive_failures,
+            'last_http_status', NEW.last_http_status,
+            'last_error', left(NEW.last_error, 500),
+            'last_crawled_at', NEW.last_crawled_at
+        )
+    )::text);
+    RETURN NULL;
+END $$`,
+	`CREATE OR REPLACE TRIGGER source_health_notify
+    AFTER INSERT OR UPDATE ON source_health
+    FOR EACH ROW EXECUTE FUNCTION notify_source_health()`,
 }
 
 // createIndexStatements are implementation-need indexes beyond §4 (which
 // only specifies constraints). Kept deliberately small — single-user scale:
 //   - idx_articles_published_at: every article listing / radio article
 //     selection orders by published_at DESC.
+//   - idx_articles_published_at_id: keyset (cursor) pagination order
+//     (published_at DESC NULLS LAST, id DESC); the id tie-breaker makes the
+//     cursor condition an index range scan at any depth.
 //   - idx_articles_source_id: FK join sources<->articles used by all
 //     "with source" queries.
 //   - idx_jobs_pending: partial index backing the ClaimNext polling query
 //     (WHERE status='pending' AND run_after <= now()).
 //   - idx_feed_access_logs_token_id: per-friend access aggregation on the
 //     only table expected to grow unbounded.
+//   - idx_audit_logs_created_at: GET /audit-logs lists newest first.
+//   - idx_audit_logs_resource: "history of this source/article" lookups.
+//   - idx_rate_limit_hits_key: per-key window count on every limited
+//     request when RATE_LIMIT_STORE=postgres.
+//   - idx_refresh_tokens_family_id: family-wide revocation on logout /
+//     reuse detection.
+//   - idx_revoked_tokens_expires_at: periodic deletion of denylist entries
+//     whose token has expired anyway.
+//   - idx_article_tags_tag_id: "articles with this tag" filters and per-tag
+//     counts (the primary key only covers article_id-first lookups).
+//   - idx_articles_tsv / idx_summaries_tsv: GIN indexes for the full-text
+//     keyword search (tsv @@ tsquery).
+//   - idx_articles_title_trgm / idx_summaries_body_trgm: pg_trgm GIN
+//     indexes for the ILIKE fallback, which catches what the 'simple'
+//     parser cannot split into words (日本語の要約など).
+//   - idx_webhook_deliveries_webhook_id: per-webhook delivery history,
+//     newest first (also serves the ON DELETE CASCADE).
+//   - idx_articles_content_hash: UNIQUE, the content-hash dedupe of the
+//     crawl (NULLs of pre-existing rows do not collide).
+//   - idx_articles_simhash_crawled_at: the recent fingerprinted articles
+//     the crawl compares a new article against.
+//   - idx_articles_duplicate_of: the members of a near-duplicate group
+//     (also serves the ON DELETE SET NULL).
+//   - idx_jobs_dedupe_key: partial UNIQUE over live (pending / running)
+//     jobs, backing EnqueueUnique's ON CONFLICT DO NOTHING for replicas
+//     enqueueing the same tick at once.
+//   - idx_jobs_dedupe_key_lookup: every keyed job, finished ones included,
+//     for EnqueueUnique's NOT EXISTS: a tick a replica has already run is
+//     not enqueued again. Not UNIQUE — keys from before the tick was part
+//     of them repeat.
+//   - idx_sources_group_id: the members of a source group, for the group
+//     article filter (also serves the ON DELETE SET NULL).
 var createIndexStatements = []string{
 	`CREATE INDEX IF NOT EXISTS idx_articles_published_at ON articles (published_at DESC)`,
+	`CREATE INDEX IF NOT EXISTS idx_articles_published_at_id ON articles (published_at DESC NULLS LAST, id DESC)`,
 	`CREATE INDEX IF NOT EXISTS idx_articles_source_id ON articles (source_id)`,
 	`CREATE INDEX IF NOT EXISTS idx_jobs_pending ON jobs (run_after) WHERE status = 'pending'`,
 	`CREATE INDEX IF NOT EXISTS idx_feed_access_logs_token_id ON feed_access_logs (token_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs (created_at DESC)`,
+	`CREATE INDEX IF NOT EXISTS idx_audit_logs_resource ON audit_logs (resource_type, resource_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_rate_limit_hits_key ON rate_limit_hits (key, hit_at)`,
+	`CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens (family_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires_at ON revoked_tokens (expires_at)`,
+	`CREATE INDEX IF NOT EXISTS idx_article_tags_tag_id ON article_tags (tag_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_articles_tsv ON articles USING gin (tsv)`,
+	`CREATE INDEX IF NOT EXISTS idx_summaries_tsv ON summaries USING gin (tsv)`,
+	`CREATE INDEX IF NOT EXISTS idx_articles_title_trgm ON articles USING gin (title gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_summaries_body_trgm ON summaries USING gin (body gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries (webhook_id, id DESC)`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_articles_content_hash ON articles (content_hash)`,
+	`CREATE INDEX IF NOT EXISTS idx_articles_simhash_crawled_at ON articles (crawled_at) WHERE simhash IS NOT NULL`,
+	`CREATE INDEX IF NOT EXISTS idx_articles_duplicate_of ON articles (duplicate_of) WHERE duplicate_of IS NOT NULL`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_jobs_dedupe_key ON jobs (dedupe_key) WHERE status IN ('pending', 'running')`,
+	`CREATE INDEX IF NOT EXISTS idx_jobs_dedupe_key_lookup ON jobs (dedupe_key) WHERE dedupe_key IS NOT NULL`,
+	`CREATE INDEX IF NOT EXISTS idx_sources_group_id ON sources (group_id) WHERE group_id IS NOT NULL`,
 }
 
 // MigrateUp applies the pulse schema (Phase 1 §4 + Phase 2 §4/§6 + Phase 3
@@ -234,6 +746,9 @@ func MigrateUp(db *sql.DB) error {
 		return fmt.Errorf(
 			"enable pgvector extension (U-24): book_chunks.embedding requires a pgvector-enabled PostgreSQL image such as pgvector/pgvector:pg18: %w", err)
 	}
+	if _, err := db.Exec(createTrgmExtension); err != nil {
+		return fmt.Errorf("enable pg_trgm extension (article search indexes): %w", err)
+	}
 	for _, stmt := range createTableStatements {
 		if _, err := db.Exec(stmt); err != nil {
 			return err
@@ -364,7 +364,7 @@ func TestRequeueRunning_KindScoped_RealPostgres(t *testing.T) {
 		entity.JobKindRegenerateFeed, entity.JobKindNotifyEpisode,
 		entity.JobKindNotifyError, entity.JobKindCleanupOldMedia,
 	}
-	n, err := jobs.RequeueRunning(context.Background(), piWorkerKinds...)
+	n, err := jobs.RequeueRunning(context.Background(), time.Now(), piWorkerKinds...)
 	require.NoError(t, err)
 	assert.GreaterOrEqual(t, n, int64(1))
 
@@ -379,9 +379,49 @@ func TestRequeueRunning_KindScoped_RealPostgres(t *testing.T) {
 		"the Mac worker's job must not gain attempts from the Pi sweep")
 
 	// The Mac worker's own sweep (kind 'transcribe') does requeue it.
-	n, err = jobs.RequeueRunning(context.Background(), entity.JobKindTranscribe)
+	n, err = jobs.RequeueRunning(context.Background(), time.Now(), entity.JobKindTranscribe)
 	require.NoError(t, err)
 	assert.GreaterOrEqual(t, n, int64(1))
 	status, _ = jobStatus(transcribeID)
 	assert.Equal(t, entity.JobStatusPending, status)
 }
+
+// TestEnqueueUnique_FinishedTick_RealPostgres covers a replica that fires
+// a scheduled tick after another replica's job for it has already run:
+// the key names the tick, and a finished job still holds it, so the
+// digest is not sent twice. The next tick's key is free.
+func TestEnqueueUnique_FinishedTick_RealPostgres(t *testing.T) {
+	conn := openTestDB(t)
+	require.NoError(t, MigrateUp(conn))
+	ctx := context.Background()
+	jobs := pgRepo.NewJobRepo(conn)
+
+	tick := time.Date(2026, 7, 4, 5, 30, 0, 0, time.UTC)
+	key := func(at time.Time) string { return "notify_digest:" + at.Format(time.RFC3339) }
+	cleanup := func() {
+		_, _ = conn.Exec(`DELETE FROM jobs WHERE dedupe_key IN ($1, $2)`, key(tick), key(tick.Add(time.Hour)))
+	}
+	cleanup()
+	t.Cleanup(cleanup)
+
+	id, enqueued, err := jobs.EnqueueUnique(ctx, entity.JobKindNotifyDigest, key(tick), nil, time.Time{})
+	require.NoError(t, err)
+	require.True(t, enqueued)
+
+	// Another replica fires the same tick while the job is pending.
+	_, enqueued, err = jobs.EnqueueUnique(ctx, entity.JobKindNotifyDigest, key(tick), nil, time.Time{})
+	require.NoError(t, err)
+	assert.False(t, enqueued, "a pending job holds the tick")
+
+	require.NoError(t, jobs.MarkDone(ctx, id))
+
+	// And a late replica fires it after the job has completed.
+	_, enqueued, err = jobs.EnqueueUnique(ctx, entity.JobKindNotifyDigest, key(tick), nil, time.Time{})
+	require.NoError(t, err)
+	assert.False(t, enqueued, "a completed job still holds the tick")
+	assert.Equal(t, 1, countRows(t, conn, `SELECT count(*) FROM jobs WHERE dedupe_key = $1`, key(tick)))
+
+	_, enqueued, err = jobs.EnqueueUnique(ctx, entity.JobKindNotifyDigest, key(tick.Add(time.Hour)), nil, time.Time{})
+	require.NoError(t, err)
+	assert.True(t, enqueued, "the next tick is enqueued")
+}
@@ -10,20 +10,39 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-// §4 (+ Phase 2 §6 books + Phase 3 §4 learning) tables in dependency order
+// §4 (+ users + Phase 2 §6 books + Phase 3 §4 learning + audit_logs + api_keys + refresh_tokens + rate_limit_hits + webhooks) tables in dependency order
 // — MigrateUp must create exactly these.
 var wantTables = []string{
 	"sources", "articles", "summaries",
 	"episodes", "segments",
-	"subscribers", "viewers", "feed_tokens", "feed_access_logs",
+	"subscribers", "users", "feed_tokens", "feed_access_logs",
 	"jobs",
 	"books", "book_chunks",
 	"learning_items", "review_logs",
+	"audit_logs",
+	"api_keys",
+	"refresh_tokens",
+	"revoked_tokens",
+	"user_mfa",
+	"tags", "article_tags",
+	"article_read_state", "article_favorites",
+	"source_subscriptions",
+	"rate_limit_hits", "rate_limit_denials",
+	"webhooks", "webhook_deliveries",
+	"source_health",
+	"article_contents", "articles_archive",
+	"crawl_runs",
+	"crawl_control", "crawl_skipped_sources",
+	"alert_rules",
+	"source_groups",
+	"article_exports",
 }
 
 func expectFullMigration(mock sqlmock.Sqlmock) {
 	mock.ExpectExec("CREATE EXTENSION IF NOT EXISTS vector").
 		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE EXTENSION IF NOT EXISTS pg_trgm").
+		WillReturnResult(sqlmock.NewResult(0, 0))
 	for _, table := range wantTables {
 		mock.ExpectExec("CREATE TABLE IF NOT EXISTS " + table + " ").
 			WillReturnResult(sqlmock.NewResult(0, 0))
@@ -33,13 +52,84 @@ func expectFullMigration(mock sqlmock.Sqlmock) {
 		WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectExec("sources_kind_check").
 		WillReturnResult(sqlmock.NewResult(0, 0))
+	// ソース個別のクロールスケジュール(NULL = CRON_SCHEDULE に従う)。
+	mock.ExpectExec("ALTER TABLE sources ADD COLUMN IF NOT EXISTS crawl_schedule").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	// ソース個別の記事保持日数(NULL = RETENTION_DAYS に従う)。
+	mock.ExpectExec("ALTER TABLE sources ADD COLUMN IF NOT EXISTS retention_days").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	// JS 描画が必要なソースのヘッドレスブラウザ取得フラグ(既定 false)。
+	mock.ExpectExec("ALTER TABLE sources ADD COLUMN IF NOT EXISTS render_js").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	// 条件付き GET 用のフィード検証子(NULL = 無条件 GET)。
+	mock.ExpectExec("ALTER TABLE sources ADD COLUMN IF NOT EXISTS etag").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE sources ADD COLUMN IF NOT EXISTS last_modified").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	// ソースグループ(フォルダ)への所属(NULL = グループなし)。
+	mock.ExpectExec("ALTER TABLE sources ADD COLUMN IF NOT EXISTS group_id").
+		WillReturnResult(sqlmock.NewResult(0, 0))
 	// Phase 3 upgrade path: books の book_review 進捗2カラム(§7.3)。
 	mock.ExpectExec("ALTER TABLE books ADD COLUMN IF NOT EXISTS review_cursor").
 		WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectExec("ALTER TABLE books ADD COLUMN IF NOT EXISTS review_status").
 		WillReturnResult(sqlmock.NewResult(0, 0))
+	// rate_limit_hits.denied の旧拒否行を消して列を外す(拒否は rate_limit_denials へ)。
+	mock.ExpectExec("ALTER TABLE rate_limit_hits DROP COLUMN denied").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	// 旧 viewers テーブルを users(role='viewer')へ移して削除する。
+	mock.ExpectExec("INSERT INTO users .* FROM viewers").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	// カスタムロール(AUTH_ROLES)を入れられるよう role の CHECK を外す。
+	mock.ExpectExec("ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE refresh_tokens DROP CONSTRAINT IF EXISTS refresh_tokens_role_check").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE api_keys DROP CONSTRAINT IF EXISTS api_keys_role_check").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	// 全文検索用の生成列(タイトル・要約)。
+	mock.ExpectExec("ALTER TABLE articles ADD COLUMN IF NOT EXISTS tsv").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE summaries ADD COLUMN IF NOT EXISTS tsv").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	// 内容ハッシュによる重複排除(既存行は NULL のまま)。
+	mock.ExpectExec("ALTER TABLE articles ADD COLUMN IF NOT EXISTS content_hash").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	// SimHash による近似重複のグループ化(既存行は NULL のまま)。
+	mock.ExpectExec("ALTER TABLE articles ADD COLUMN IF NOT EXISTS simhash").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE articles ADD COLUMN IF NOT EXISTS duplicate_of").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	// 記事のサムネイル(og:image)。既存行は NULL のまま。
+	mock.ExpectExec("ALTER TABLE articles ADD COLUMN IF NOT EXISTS image_url").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	// 複数 worker 向けのジョブのリース時刻と重複排除キー。
+	mock.ExpectExec("ALTER TABLE jobs ADD COLUMN IF NOT EXISTS claimed_at").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE jobs ADD COLUMN IF NOT EXISTS dedupe_key").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	// Webhook の配信ルール(既存行は '{}' = 全記事)。
+	mock.ExpectExec("ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS route").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	// 未読マーク(既読状態の同期)。
+	mock.ExpectExec("ALTER TABLE article_read_state ADD COLUMN IF NOT EXISTS unread_at").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	// 記事 INSERT の NOTIFY(GET /articles/events)。
+	mock.ExpectExec("CREATE OR REPLACE FUNCTION notify_article_event").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE OR REPLACE TRIGGER articles_notify").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	// クロール完了・ソース状態変化の NOTIFY(GET /ws)。
+	mock.ExpectExec("CREATE OR REPLACE FUNCTION notify_crawl_completed").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE OR REPLACE TRIGGER crawl_runs_notify").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE OR REPLACE FUNCTION notify_source_health").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE OR REPLACE TRIGGER source_health_notify").
+		WillReturnResult(sqlmock.NewResult(0, 0))
 	for range createIndexStatements {
-		mock.ExpectExec("CREATE INDEX IF NOT EXISTS").
+		mock.ExpectExec("CREATE (UNIQUE )?INDEX IF NOT EXISTS").
 			WillReturnResult(sqlmock.NewResult(0, 0))
 	}
 	mock.ExpectExec("INSERT INTO sources").
@@ -94,6 +184,25 @@ func TestMigrateUp_ExtensionError(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+// TestMigrateUp_TrgmExtensionError: pg_trgm を有効化できなければ検索
+// インデックスを作れないため、テーブル作成前に中断する。
+func TestMigrateUp_TrgmExtensionError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mock.ExpectExec("CREATE EXTENSION IF NOT EXISTS vector").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE EXTENSION IF NOT EXISTS pg_trgm").
+		WillReturnError(sql.ErrConnDone)
+
+	err = MigrateUp(db)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, sql.ErrConnDone)
+	assert.Contains(t, err.Error(), "pg_trgm")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestMigrateUp_TableError(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -101,6 +210,8 @@ func TestMigrateUp_TableError(t *testing.T) {
 
 	mock.ExpectExec("CREATE EXTENSION IF NOT EXISTS vector").
 		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE EXTENSION IF NOT EXISTS pg_trgm").
+		WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectExec("CREATE TABLE IF NOT EXISTS sources").
 		WillReturnError(sql.ErrConnDone)
 
@@ -117,6 +228,8 @@ func TestMigrateUp_IndexError(t *testing.T) {
 
 	mock.ExpectExec("CREATE EXTENSION IF NOT EXISTS vector").
 		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE EXTENSION IF NOT EXISTS pg_trgm").
+		WillReturnResult(sqlmock.NewResult(0, 0))
 	for range wantTables {
 		mock.ExpectExec("CREATE TABLE IF NOT EXISTS").
 			WillReturnResult(sqlmock.NewResult(0, 0))
@@ -139,6 +252,8 @@ func TestMigrateUp_AlterError(t *testing.T) {
 
 	mock.ExpectExec("CREATE EXTENSION IF NOT EXISTS vector").
 		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE EXTENSION IF NOT EXISTS pg_trgm").
+		WillReturnResult(sqlmock.NewResult(0, 0))
 	for range wantTables {
 		mock.ExpectExec("CREATE TABLE IF NOT EXISTS").
 			WillReturnResult(sqlmock.NewResult(0, 0))
@@ -157,6 +272,8 @@ func TestMigrateUp_SeedError(t *testing.T) {
 
 	mock.ExpectExec("CREATE EXTENSION IF NOT EXISTS vector").
 		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE EXTENSION IF NOT EXISTS pg_trgm").
+		WillReturnResult(sqlmock.NewResult(0, 0))
 	for range wantTables {
 		mock.ExpectExec("CREATE TABLE IF NOT EXISTS").
 			WillReturnResult(sqlmock.NewResult(0, 0))
@@ -166,7 +283,7 @@ func TestMigrateUp_SeedError(t *testing.T) {
 			WillReturnResult(sqlmock.NewResult(0, 0))
 	}
 	for range createIndexStatements {
-		mock.ExpectExec("CREATE INDEX IF NOT EXISTS").
+		mock.ExpectExec("CREATE (UNIQUE )?INDEX IF NOT EXISTS").
 			WillReturnResult(sqlmock.NewResult(0, 0))
 	}
 	mock.ExpectExec("INSERT INTO sources").
@@ -190,9 +307,9 @@ func TestSchema_MatchesDesignDoc(t *testing.T) {
 		{"segments unique per (episode_id, position)", "UNIQUE (episode_id, position)"},
 		{"feed_tokens stores only the hash, unique (D-5)", "token_hash    text NOT NULL UNIQUE"},
 		{"subscribers deactivate instead of delete (C-8)", "deactivated_at timestamptz"},
-		// D-27 — viewer 閲覧専用アカウント。
-		{"viewers.email is the unique login identifier (D-27)", "email          text NOT NULL UNIQUE"},
-		{"viewers store only the bcrypt hash (D-27)", "password_hash  text NOT NULL"},
+		// D-27 — admin / viewer アカウント。
+		{"users.email is the unique login identifier (D-27)", "email          text NOT NULL UNIQUE"},
+		{"users store only the bcrypt hash (D-27)", "password_hash  text NOT NULL"},
 		{"jobs default to pending (C-4 DB queue)", "status        text NOT NULL DEFAULT 'pending'"},
 		{"jobs carry a jsonb payload", "payload       jsonb NOT NULL DEFAULT '{}'"},
 		{"episodes store the mp3 path, not the blob (C-10)", "audio_path    text NOT NULL"},
@@ -211,6 +328,8 @@ func TestSchema_MatchesDesignDoc(t *testing.T) {
 		{"review_logs reference items with NOT NULL FK", "item_id     bigint NOT NULL REFERENCES learning_items"},
 		{"review_logs.asked_on is a date (JST 放送日)", "asked_on    date NOT NULL"},
 		{"review_logs unique per (item_id, asked_on) — 同日 rev 冪等キー", "UNIQUE (item_id, asked_on)"},
+		{"audit_logs keep the actor of every mutation", "actor         text NOT NULL"},
+		{"audit_logs store before/after snapshots as jsonb", "before        jsonb"},
 	}
 
 	for _, tt := range tests {
@@ -3,33 +3,60 @@ package db
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log"
 	"log/slog"
+	"math"
 	"os"
 	"strconv"
+	"sync"
 	"time"
 
-	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// Pool implementations (DB_POOL).
+const (
+	// PoolStdlib is database/sql's own pool over the pgx stdlib driver.
+	PoolStdlib = "stdlib"
+	// PoolPgxpool keeps the connections in a pgxpool.Pool; database/sql
+	// only borrows them (stdlib.OpenDBFromPool), so the repositories are
+	// unchanged while the pool gains min conns, health checks and its
+	// own statistics.
+	PoolPgxpool = "pgxpool"
 )
 
 // ConnectionConfig holds database connection pool configuration.
 type ConnectionConfig struct {
+	Pool            string // PoolStdlib | PoolPgxpool
 	MaxOpenConns    int
 	MaxIdleConns    int
 	ConnMaxLifetime time.Duration
 	ConnMaxIdleTime time.Duration
+
+	// MinConns and HealthCheckPeriod apply to PoolPgxpool only.
+	MinConns          int
+	HealthCheckPeriod time.Duration
 }
 
 // DefaultConnectionConfig returns the default connection pool configuration.
 func DefaultConnectionConfig() ConnectionConfig {
 	return ConnectionConfig{
-		MaxOpenConns:    25,               // Maximum number of open connections
-		MaxIdleConns:    10,               // Maximum number of idle connections
-		ConnMaxLifetime: 1 * time.Hour,    // Maximum lifetime of a connection
-		ConnMaxIdleTime: 30 * time.Minute, // Maximum idle time of a connection
+		Pool:              PoolStdlib,
+		MaxOpenConns:      25,               // Maximum number of open connections
+		MaxIdleConns:      10,               // Maximum number of idle connections
+		ConnMaxLifetime:   1 * time.Hour,    // Maximum lifetime of a connection
+		ConnMaxIdleTime:   30 * time.Minute, // Maximum idle time of a connection
+		MinConns:          0,                // Connections pgxpool keeps open even when idle
+		HealthCheckPeriod: 1 * time.Minute,  // How often pgxpool checks idle connections
 	}
 }
 
+// pgxPools maps a *sql.DB opened with PoolPgxpool to its pool, for
+// PgxPoolStatsOf.
+var pgxPools sync.Map // *sql.DB → *pgxpool.Pool
+
 // Open creates and configures a new database connection pool.
 // It reads DATABASE_URL from environment and applies connection pool settings.
 func Open() *sql.DB {
@@ -38,19 +65,27 @@ func Open() *sql.DB {
 		log.Fatal("DATABASE_URL not set")
 	}
 
-	db, err := sql.Open("pgx", dsn)
-	if err != nil {
-		log.Fatal(err)
-	}
-
 	// Apply connection pool configuration
 	cfg := getConnectionConfigFromEnv()
-	db.SetMaxOpenConns(cfg.MaxOpenConns)
-	db.SetMaxIdleConns(cfg.MaxIdleConns)
-	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
-	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	var db *sql.DB
+	if cfg.Pool == PoolPgxpool {
+		var err error
+		if db, err = openPgxPool(dsn, cfg); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		var err error
+		if db, err = sql.Open("pgx", dsn); err != nil {
+			log.Fatal(err)
+		}
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+		db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+		db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	}
 
 	slog.Info("database connection pool configured",
+		slog.String("pool", cfg.Pool),
 		slog.Int("max_open_conns", cfg.MaxOpenConns),
 		slog.Int("max_idle_conns", cfg.MaxIdleConns),
 		slog.Duration("conn_max_lifetime", cfg.ConnMaxLifetime),
@@ -67,6 +102,39 @@ func Open() *sql.DB {
 	return db
 }
 
+// openPgxPool opens a pgxpool.Pool and wraps it in a *sql.DB. The pool
+// owns the connections: database/sql keeps none idle (OpenDBFromPool sets
+// MaxIdleConns to 0), and its MaxOpenConns mirrors the pool's MaxConns so
+// /health's utilization stays meaningful. Closing the *sql.DB leaves the
+// pool open; processes close it only by exiting.
+func openPgxPool(dsn string, cfg ConnectionConfig) (*sql.DB, error) {
+	poolCfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("parse DATABASE_URL for pgxpool: %w", err)
+	}
+	poolCfg.MaxConns = clampInt32(cfg.MaxOpenConns)
+	poolCfg.MinConns = min(clampInt32(cfg.MinConns), poolCfg.MaxConns)
+	poolCfg.MaxConnLifetime = cfg.ConnMaxLifetime
+	poolCfg.MaxConnIdleTime = cfg.ConnMaxIdleTime
+	poolCfg.HealthCheckPeriod = cfg.HealthCheckPeriod
+
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolCfg)
+	if err != nil {
+		return nil, fmt.Errorf("open pgxpool: %w", err)
+	}
+	db := stdlib.OpenDBFromPool(pool)
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	pgxPools.Store(db, pool)
+	return db, nil
+}
+
+func clampInt32(n int) int32 {
+	if n > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int32(n) // #nosec G115 -- bounded above; config values are positive
+}
+
 // getConnectionConfigFromEnv reads connection pool configuration from environment variables.
 // Falls back to default values if not set.
 func getConnectionConfigFromEnv() ConnectionConfig {
@@ -96,5 +164,25 @@ func getConnectionConfigFromEnv() ConnectionConfig {
 		}
 	}
 
+	switch pool := os.Getenv("DB_POOL"); pool {
+	case "", PoolStdlib:
+	case PoolPgxpool:
+		cfg.Pool = PoolPgxpool
+	default:
+		slog.Warn("invalid DB_POOL, using stdlib", slog.String("value", pool))
+	}
+
+	if minConns := os.Getenv("DB_MIN_CONNS"); minConns != "" {
+		if val, err := strconv.Atoi(minConns); err == nil && val >= 0 {
+			cfg.MinConns = val
+		}
+	}
+
+	if period := os.Getenv("DB_HEALTH_CHECK_PERIOD"); period != "" {
+		if val, err := time.ParseDuration(period); err == nil && val > 0 {
+			cfg.HealthCheckPeriod = val
+		}
+	}
+
 	return cfg
 }
@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"catchup-feed/pkg/config"
+)
+
+// replicaPingTimeout bounds one replica health check.
+const replicaPingTimeout = 2 * time.Second
+
+// Replica routes read-only queries to a read replica
+// (DATABASE_REPLICA_URL) while it answers pings, and to the primary
+// otherwise. Without a replica configured every read goes to the primary,
+// so callers never branch on the setup. Only list / search / count
+// queries are routed (postgres.ReadDB): they tolerate replication lag,
+// single-row reads and writes do not.
+type Replica struct {
+	primary *sql.DB
+	replica *sql.DB // nil = not configured
+	healthy atomic.Bool
+	logger  *slog.Logger
+}
+
+// NewReplica wraps primary and an already opened replica pool (nil = no
+// replica). The replica starts healthy; Watch corrects that on its first
+// check.
+func NewReplica(primary, replica *sql.DB, logger *slog.Logger) *Replica {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	r := &Replica{primary: primary, replica: replica, logger: logger}
+	r.healthy.Store(replica != nil)
+	return r
+}
+
+// OpenReplica opens DATABASE_REPLICA_URL with the primary's pool settings
+// (always the stdlib pool). An unset URL yields a Replica that reads from
+// the primary; an unreachable replica is logged and starts unhealthy
+// instead of stopping the server — reads fall back to the primary (§8
+// 縮退).
+func OpenReplica(primary *sql.DB, logger *slog.Logger) *Replica {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	dsn := os.Getenv("DATABASE_REPLICA_URL")
+	if dsn == "" {
+		return NewReplica(primary, nil, logger)
+	}
+	replica, err := sql.Open("pgx", dsn)
+	if err != nil {
+		logger.Warn("invalid DATABASE_REPLICA_URL, reading from the primary", slog.Any("error", err))
+		return NewReplica(primary, nil, logger)
+	}
+	cfg := getConnectionConfigFromEnv()
+	replica.SetMaxOpenConns(cfg.MaxOpenConns)
+	replica.SetMaxIdleConns(cfg.MaxIdleConns)
+	replica.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	replica.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	r := NewReplica(primary, replica, logger)
+	r.check(context.Background())
+	logger.Info("database read replica configured", slog.Bool("healthy", r.Healthy()))
+	return r
+}
+
+// Reader returns the pool for read-only queries.
+func (r *Replica) Reader() *sql.DB {
+	if r.replica != nil && r.healthy.Load() {
+		return r.replica
+	}
+	return r.primary
+}
+
+// Enabled reports whether a replica is configured.
+func (r *Replica) Enabled() bool { return r.replica != nil }
+
+// Healthy reports whether reads currently go to the replica.
+func (r *Replica) Healthy() bool { return r.replica != nil && r.healthy.Load() }
+
+// ReplicaCheckIntervalFromEnv reads DB_REPLICA_CHECK_INTERVAL (default
+// 10s): how quickly reads leave a failed replica and return to a
+// recovered one.
+func ReplicaCheckIntervalFromEnv() time.Duration {
+	return config.GetEnvDuration("DB_REPLICA_CHECK_INTERVAL", 10*time.Second)
+}
+
+// Watch pings the replica every interval until ctx is cancelled, moving
+// reads to the primary while it fails and back once it answers. Reads
+// already running on a replica that just failed return their error; the
+// window is one interval.
+func (r *Replica) Watch(ctx context.Context, interval time.Duration) {
+	if r.replica == nil || interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.check(ctx)
+		}
+	}
+}
+
+// check pings the replica and logs transitions only.
+func (r *Replica) check(ctx context.Context) {
+	pingCtx, cancel := context.WithTimeout(ctx, replicaPingTimeout)
+	defer cancel()
+	err := r.replica.PingContext(pingCtx)
+	healthy := err == nil
+	if r.healthy.Swap(healthy) == healthy {
+		return
+	}
+	if healthy {
+		r.logger.Info("database read replica recovered, reading from the replica")
+	} else {
+		r.logger.Warn("database read replica unavailable, reading from the primary", slog.Any("error", err))
+	}
+}
+
+// Close closes the replica pool; the primary is the caller's.
+func (r *Replica) Close() error {
+	if r.replica == nil {
+		return nil
+	}
+	return r.replica.Close()
+}
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPingMock(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	database, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+	return database, mock
+}
+
+func TestReplica_WithoutReplicaReadsPrimary(t *testing.T) {
+	primary, _ := newPingMock(t)
+	r := NewReplica(primary, nil, slog.New(slog.DiscardHandler))
+
+	assert.Same(t, primary, r.Reader())
+	assert.False(t, r.Enabled())
+	assert.False(t, r.Healthy())
+	// Watch returns immediately without a replica.
+	r.Watch(context.Background(), 1)
+	assert.NoError(t, r.Close())
+}
+
+func TestReplica_FallsBackAndRecovers(t *testing.T) {
+	primary, _ := newPingMock(t)
+	replica, mock := newPingMock(t)
+	r := NewReplica(primary, replica, slog.New(slog.DiscardHandler))
+	assert.Same(t, replica, r.Reader())
+
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	r.check(context.Background())
+	assert.Same(t, primary, r.Reader(), "a failed ping moves reads to the primary")
+	assert.False(t, r.Healthy())
+
+	mock.ExpectPing()
+	r.check(context.Background())
+	assert.Same(t, replica, r.Reader(), "reads return once the replica answers")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOpenReplica_Unset(t *testing.T) {
+	t.Setenv("DATABASE_REPLICA_URL", "")
+	primary, _ := newPingMock(t)
+
+	r := OpenReplica(primary, slog.New(slog.DiscardHandler))
+	assert.Same(t, primary, r.Reader())
+}
@@ -0,0 +1,139 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"catchup-feed/pkg/config"
+)
+
+// StatsSource is the part of *sql.DB the pool sampler reads.
+type StatsSource interface {
+	Stats() sql.DBStats
+}
+
+// PgxPoolStats is the pgxpool side of a pool opened with DB_POOL=pgxpool.
+// The counters are cumulative since the pool was opened.
+type PgxPoolStats struct {
+	MaxConns          int32
+	TotalConns        int32
+	IdleConns         int32
+	AcquiredConns     int32
+	ConstructingConns int32
+
+	AcquireCount         int64
+	AcquireDuration      time.Duration
+	EmptyAcquireCount    int64 // acquires that had to wait for a new or released connection
+	CanceledAcquireCount int64
+	NewConnsCount        int64
+}
+
+// PgxPoolStatsOf returns the pgxpool statistics behind db; ok is false
+// for the default stdlib pool.
+func PgxPoolStatsOf(db *sql.DB) (PgxPoolStats, bool) {
+	v, ok := pgxPools.Load(db)
+	if !ok {
+		return PgxPoolStats{}, false
+	}
+	s := v.(*pgxpool.Pool).Stat()
+	return PgxPoolStats{
+		MaxConns:             s.MaxConns(),
+		TotalConns:           s.TotalConns(),
+		IdleConns:            s.IdleConns(),
+		AcquiredConns:        s.AcquiredConns(),
+		ConstructingConns:    s.ConstructingConns(),
+		AcquireCount:         s.AcquireCount(),
+		AcquireDuration:      s.AcquireDuration(),
+		EmptyAcquireCount:    s.EmptyAcquireCount(),
+		CanceledAcquireCount: s.CanceledAcquireCount(),
+		NewConnsCount:        s.NewConnsCount(),
+	}, true
+}
+
+// StatsIntervalFromEnv reads DB_STATS_INTERVAL (default 5m). Zero or a
+// negative value disables sampling.
+func StatsIntervalFromEnv() time.Duration {
+	return config.GetEnvDuration("DB_STATS_INTERVAL", 5*time.Minute)
+}
+
+// SampleStats logs the connection pool statistics every interval until
+// ctx is cancelled. There is no metrics backend on the Pi (設計原則1:
+// 右サイズ), so the samples are structured log records: the gauges
+// (open / in_use / idle) as of the tick, and the wait counters as deltas
+// over the interval. A window in which callers had to wait for a
+// connection is logged at Warn — that is the signal to raise
+// DB_MAX_OPEN_CONNS. /health reports the same numbers on demand. With
+// DB_POOL=pgxpool a second record carries the pgxpool statistics.
+func SampleStats(ctx context.Context, src StatsSource, interval time.Duration, logger *slog.Logger) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	db, _ := src.(*sql.DB)
+	prev := src.Stats()
+	prevPgx, hasPgx := PgxPoolStatsOf(db)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cur := src.Stats()
+			logStats(ctx, logger, prev, cur)
+			prev = cur
+			if hasPgx {
+				curPgx, _ := PgxPoolStatsOf(db)
+				logPgxStats(ctx, logger, prevPgx, curPgx)
+				prevPgx = curPgx
+			}
+		}
+	}
+}
+
+func logStats(ctx context.Context, logger *slog.Logger, prev, cur sql.DBStats) {
+	waits := cur.WaitCount - prev.WaitCount
+	level := slog.LevelInfo
+	msg := "db pool stats"
+	if waits > 0 {
+		level = slog.LevelWarn
+		msg = "db pool stats: callers waited for a connection"
+	}
+	logger.LogAttrs(ctx, level, msg,
+		slog.Int("max_open_connections", cur.MaxOpenConnections),
+		slog.Int("open_connections", cur.OpenConnections),
+		slog.Int("in_use", cur.InUse),
+		slog.Int("idle", cur.Idle),
+		slog.Int64("wait_count", waits),
+		slog.Int64("wait_duration_ms", (cur.WaitDuration-prev.WaitDuration).Milliseconds()),
+	)
+}
+
+// logPgxStats logs the pgxpool gauges and the counters as deltas. A
+// canceled acquire means a caller gave up waiting for a connection, the
+// same signal as wait_count in logStats.
+func logPgxStats(ctx context.Context, logger *slog.Logger, prev, cur PgxPoolStats) {
+	canceled := cur.CanceledAcquireCount - prev.CanceledAcquireCount
+	level := slog.LevelInfo
+	msg := "db pgxpool stats"
+	if canceled > 0 {
+		level = slog.LevelWarn
+		msg = "db pgxpool stats: callers gave up waiting for a connection"
+	}
+	logger.LogAttrs(ctx, level, msg,
+		slog.Int("max_conns", int(cur.MaxConns)),
+		slog.Int("total_conns", int(cur.TotalConns)),
+		slog.Int("idle_conns", int(cur.IdleConns)),
+		slog.Int("acquired_conns", int(cur.AcquiredConns)),
+		slog.Int("constructing_conns", int(cur.ConstructingConns)),
+		slog.Int64("acquire_count", cur.AcquireCount-prev.AcquireCount),
+		slog.Int64("empty_acquire_count", cur.EmptyAcquireCount-prev.EmptyAcquireCount),
+		slog.Int64("canceled_acquire_count", canceled),
+		slog.Int64("acquire_duration_ms", (cur.AcquireDuration-prev.AcquireDuration).Milliseconds()),
+		slog.Int64("new_conns", cur.NewConnsCount-prev.NewConnsCount),
+	)
+}
@@ -0,0 +1,151 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubStats struct {
+	mu    sync.Mutex
+	stats []sql.DBStats
+}
+
+func (s *stubStats) Stats() sql.DBStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cur := s.stats[0]
+	if len(s.stats) > 1 {
+		s.stats = s.stats[1:]
+	}
+	return cur
+}
+
+func TestLogStats(t *testing.T) {
+	tests := []struct {
+		name      string
+		prev, cur sql.DBStats
+		wantLevel string
+		wantWaits float64
+	}{
+		{
+			name:      "no contention logs at info",
+			prev:      sql.DBStats{WaitCount: 3},
+			cur:       sql.DBStats{MaxOpenConnections: 25, OpenConnections: 4, InUse: 1, Idle: 3, WaitCount: 3},
+			wantLevel: "INFO",
+			wantWaits: 0,
+		},
+		{
+			name:      "waits in the window log at warn as deltas",
+			prev:      sql.DBStats{WaitCount: 3, WaitDuration: time.Second},
+			cur:       sql.DBStats{MaxOpenConnections: 25, OpenConnections: 25, InUse: 25, WaitCount: 10, WaitDuration: 3 * time.Second},
+			wantLevel: "WARN",
+			wantWaits: 7,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+			logStats(context.Background(), logger, tt.prev, tt.cur)
+
+			var got map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+			assert.Equal(t, tt.wantLevel, got["level"])
+			assert.Equal(t, tt.wantWaits, got["wait_count"])
+			assert.Equal(t, float64(tt.cur.InUse), got["in_use"])
+			if tt.wantWaits > 0 {
+				assert.Equal(t, float64(2000), got["wait_duration_ms"])
+			}
+		})
+	}
+}
+
+func TestLogPgxStats(t *testing.T) {
+	prev := PgxPoolStats{AcquireCount: 10, CanceledAcquireCount: 1, AcquireDuration: time.Second}
+	tests := []struct {
+		name         string
+		cur          PgxPoolStats
+		wantLevel    string
+		wantAcquires float64
+	}{
+		{"quiet window", PgxPoolStats{MaxConns: 25, TotalConns: 3, AcquireCount: 14, CanceledAcquireCount: 1, AcquireDuration: time.Second}, "INFO", 4},
+		{"canceled acquires warn", PgxPoolStats{MaxConns: 25, TotalConns: 25, AcquireCount: 40, CanceledAcquireCount: 3, AcquireDuration: 4 * time.Second}, "WARN", 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+			logPgxStats(context.Background(), logger, prev, tt.cur)
+
+			var got map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+			assert.Equal(t, tt.wantLevel, got["level"])
+			assert.Equal(t, tt.wantAcquires, got["acquire_count"])
+			assert.Equal(t, float64(tt.cur.TotalConns), got["total_conns"])
+		})
+	}
+}
+
+func TestPgxPoolStatsOf_StdlibPool(t *testing.T) {
+	_, ok := PgxPoolStatsOf(&sql.DB{})
+	assert.False(t, ok)
+	_, ok = PgxPoolStatsOf(nil)
+	assert.False(t, ok)
+}
+
+func TestSampleStats_StopsOnCancel(t *testing.T) {
+	var buf syncBuffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	src := &stubStats{stats: []sql.DBStats{{}, {OpenConnections: 1}}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		SampleStats(ctx, src, 10*time.Millisecond, logger)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool { return strings.Contains(buf.String(), "db pool stats") },
+		time.Second, 5*time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("SampleStats did not return after cancel")
+	}
+}
+
+func TestSampleStats_Disabled(t *testing.T) {
+	// interval <= 0 returns immediately without touching the pool.
+	SampleStats(context.Background(), nil, 0, slog.Default())
+}
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
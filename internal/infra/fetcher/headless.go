@@ -0,0 +1,300 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	cdpfetch "github.com/chromedp/cdproto/fetch"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+
+	"catchup-feed/internal/usecase/fetch"
+)
+
+// renderSettleDelay is how long a page may keep rendering after the load
+// event before its DOM is read. Most client-side rendered articles fill in
+// on the first XHR round trip.
+const renderSettleDelay = 500 * time.Millisecond
+
+// blockedResourceTypes are never loaded by the headless browser: the
+// article text does not depend on them and they are most of a page's
+// weight.
+var blockedResourceTypes = map[network.ResourceType]bool{
+	network.ResourceTypeImage:       true,
+	network.ResourceTypeMedia:       true,
+	network.ResourceTypeFont:        true,
+	network.ResourceTypeStylesheet:  true,
+	network.ResourceTypeTextTrack:   true,
+	network.ResourceTypeManifest:    true,
+	network.ResourceTypePing:        true,
+	network.ResourceTypeWebSocket:   true,
+	network.ResourceTypeEventSource: true,
+}
+
+// HeadlessConfig configures the headless browser fallback used for
+// sources with render_js set.
+type HeadlessConfig struct {
+	// Enabled turns the fallback on (HEADLESS_ENABLED). Default: false —
+	// it needs a Chrome / Chromium binary on the worker host.
+	Enabled bool
+
+	// Timeout bounds one page render, from opening the tab to reading
+	// the DOM (HEADLESS_TIMEOUT). Waiting for a free browser slot does not
+	// count against it. Default: 20s
+	Timeout time.Duration
+
+	// Concurrency caps the pages rendered at once across the whole crawl
+	// (HEADLESS_CONCURRENCY). Each tab costs tens of MB on the Pi.
+	// Default: 2
+	Concurrency int
+
+	// ExecPath is the Chrome / Chromium binary (HEADLESS_CHROME_PATH).
+	// Empty looks up the usual names in PATH.
+	ExecPath string
+}
+
+// DefaultHeadlessConfig returns the defaults of the headless fallback.
+func DefaultHeadlessConfig() HeadlessConfig {
+	return HeadlessConfig{
+		Enabled:     false,
+		Timeout:     20 * time.Second,
+		Concurrency: 2,
+	}
+}
+
+// Validate checks the headless settings.
+func (c *HeadlessConfig) Validate() error {
+	if c.Timeout <= 0 {
+		return fmt.Errorf("headless timeout must be positive, got %v", c.Timeout)
+	}
+	if c.Concurrency < 1 || c.Concurrency > 10 {
+		return fmt.Errorf("headless concurrency must be between 1 and 10, got %d", c.Concurrency)
+	}
+	return nil
+}
+
+// LoadHeadlessConfigFromEnv loads the headless fallback settings.
+//
+// Environment variables:
+//   - HEADLESS_ENABLED: "true" or "false" (default: false)
+//   - HEADLESS_TIMEOUT: duration string, e.g., "20s" (default: 20s)
+//   - HEADLESS_CONCURRENCY: integer, 1-10 (default: 2)
+//   - HEADLESS_CHROME_PATH: browser binary (default: looked up in PATH)
+func LoadHeadlessConfigFromEnv() (HeadlessConfig, error) {
+	cfg := DefaultHeadlessConfig()
+
+	if val := os.Getenv("HEADLESS_ENABLED"); val != "" {
+		cfg.Enabled = val == "true"
+	}
+	if val := os.Getenv("HEADLESS_TIMEOUT"); val != "" {
+		parsed, err := time.ParseDuration(val)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid HEADLESS_TIMEOUT: %v (expected format: '20s', '1m')", err)
+		}
+		cfg.Timeout = parsed
+	}
+	if val := os.Getenv("HEADLESS_CONCURRENCY"); val != "" {
+		parsed, err := strconv.Atoi(val)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid HEADLESS_CONCURRENCY: %v", err)
+		}
+		cfg.Concurrency = parsed
+	}
+	cfg.ExecPath = os.Getenv("HEADLESS_CHROME_PATH")
+
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("headless config validation failed: %w", err)
+	}
+	return cfg, nil
+}
+
+// HeadlessFetcher implements fetch.ContentFetcher (and fetch.PageFetcher)
+// by rendering the article page in a headless Chrome, for sites that only
+// build their content in JavaScript. The rendered DOM goes through the
+// same Readability extraction as ReadabilityFetcher.
+//
+// Safeguards:
+//   - every request of the page (navigation, redirects, scripts, XHR) is
+//     intercepted and SSRF-validated; images, media, fonts and stylesheets
+//     are not loaded at all
+//   - one render is bounded by HeadlessConfig.Timeout
+//   - at most HeadlessConfig.Concurrency pages render at once
+//   - the rendered HTML is capped at ContentFetchConfig.MaxBodySize
+//
+// The browser is started on the first fetch and shared by all tabs; it is
+// restarted on the next fetch if it dies. Close stops it.
+//
+// Thread safety: HeadlessFetcher is safe for concurrent use.
+type HeadlessFetcher struct {
+	config  HeadlessConfig
+	content ContentFetchConfig
+	sem     chan struct{}
+
+	allocCtx    context.Context
+	allocCancel context.CancelFunc
+
+	mu            sync.Mutex
+	browserCtx    context.Context
+	browserCancel context.CancelFunc
+}
+
+// NewHeadlessFetcher creates the fetcher. content supplies the SSRF and
+// size limits shared with ReadabilityFetcher. No browser is started until
+// the first fetch.
+func NewHeadlessFetcher(config HeadlessConfig, content ContentFetchConfig) *HeadlessFetcher {
+	opts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.UserAgent(UserAgent),
+		chromedp.Flag("blink-settings", "imagesEnabled=false"),
+		chromedp.Flag("mute-audio", true),
+	)
+	if config.ExecPath != "" {
+		opts = append(opts, chromedp.ExecPath(config.ExecPath))
+	}
+	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), opts...)
+	concurrency := config.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &HeadlessFetcher{
+		config:      config,
+		content:     content,
+		sem:         make(chan struct{}, concurrency),
+		allocCtx:    allocCtx,
+		allocCancel: allocCancel,
+	}
+}
+
+// FetchContent renders the page and returns its extracted text.
+func (f *HeadlessFetcher) FetchContent(ctx context.Context, urlStr string) (string, error) {
+	page, err := f.FetchPage(ctx, urlStr)
+	if err != nil {
+		return "", err
+	}
+	return page.Text, nil
+}
+
+// FetchPage renders the page and returns the final URL, the rendered HTML
+// and the extracted text (fetch.PageFetcher).
+func (f *HeadlessFetcher) FetchPage(ctx context.Context, urlStr string) (*fetch.FetchedPage, error) {
+	if err := validateURL(urlStr, f.content.DenyPrivateIPs); err != nil {
+		return nil, err
+	}
+
+	select {
+	case f.sem <- struct{}{}:
+		defer func() { <-f.sem }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	browserCtx, err := f.browser()
+	if err != nil {
+		return nil, err
+	}
+	tabCtx, cancelTab := chromedp.NewContext(browserCtx)
+	defer cancelTab()
+	// chromedp contexts derive from the browser, not from ctx: tie the
+	// tab to the caller so a cancelled crawl closes it.
+	stop := context.AfterFunc(ctx, cancelTab)
+	defer stop()
+	runCtx, cancel := context.WithTimeout(tabCtx, f.config.Timeout)
+	defer cancel()
+
+	chromedp.ListenTarget(runCtx, func(ev any) {
+		if e, ok := ev.(*cdpfetch.EventRequestPaused); ok {
+			go f.interceptRequest(runCtx, e)
+		}
+	})
+
+	var (
+		location string
+		html     string
+	)
+	err = chromedp.Run(runCtx,
+		cdpfetch.Enable(),
+		chromedp.Navigate(urlStr),
+		chromedp.Sleep(renderSettleDelay),
+		chromedp.Location(&location),
+		chromedp.OuterHTML("html", &html, chromedp.ByQuery),
+	)
+	if err != nil {
+		if errors.Is(runCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+			return nil, fmt.Errorf("%w: render exceeded %v", fetch.ErrTimeout, f.config.Timeout)
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("headless render failed: %w", err)
+	}
+
+	if int64(len(html)) > f.content.MaxBodySize {
+		return nil, fmt.Errorf("%w: rendered page size %d bytes exceeds limit %d bytes",
+			fetch.ErrBodyTooLarge, len(html), f.content.MaxBodySize)
+	}
+	finalURL, _ := url.Parse(location)
+	return extractPage(finalURL, urlStr, []byte(html))
+}
+
+// interceptRequest lets a paused request through, or fails it when it is
+// a blocked resource type or its URL does not pass the SSRF check
+// (redirect hops included — each one is paused again).
+func (f *HeadlessFetcher) interceptRequest(ctx context.Context, e *cdpfetch.EventRequestPaused) {
+	c := chromedp.FromContext(ctx)
+	if c == nil || c.Target == nil {
+		return
+	}
+	execCtx := cdp.WithExecutor(ctx, c.Target)
+	if blockRequest(e.ResourceType, e.Request.URL, f.content.DenyPrivateIPs) {
+		_ = cdpfetch.FailRequest(e.RequestID, network.ErrorReasonBlockedByClient).Do(execCtx)
+		return
+	}
+	_ = cdpfetch.ContinueRequest(e.RequestID).Do(execCtx)
+}
+
+// blockRequest reports whether the headless browser must not load a
+// request of resourceType to reqURL.
+func blockRequest(resourceType network.ResourceType, reqURL string, denyPrivateIPs bool) bool {
+	if blockedResourceTypes[resourceType] {
+		return true
+	}
+	return validateURL(reqURL, denyPrivateIPs) != nil
+}
+
+// browser returns the shared browser context, starting the browser if it
+// is not running.
+func (f *HeadlessFetcher) browser() (context.Context, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.browserCtx != nil && f.browserCtx.Err() == nil {
+		return f.browserCtx, nil
+	}
+	if f.allocCtx.Err() != nil {
+		return nil, errors.New("headless fetcher closed")
+	}
+	ctx, cancel := chromedp.NewContext(f.allocCtx)
+	if err := chromedp.Run(ctx); err != nil {
+		cancel()
+		return nil, fmt.Errorf("start headless browser: %w", err)
+	}
+	slog.Info("headless browser started")
+	f.browserCtx, f.browserCancel = ctx, cancel
+	return ctx, nil
+}
+
+// Close stops the browser. Fetches after Close fail.
+func (f *HeadlessFetcher) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.browserCancel != nil {
+		f.browserCancel()
+	}
+	f.allocCancel()
+}
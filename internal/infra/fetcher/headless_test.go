@@ -0,0 +1,102 @@
+package fetcher_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"catchup-feed/internal/infra/fetcher"
+	"catchup-feed/internal/usecase/fetch"
+)
+
+// ───────────────────────────────────────────────────────────────
+// Headless browser fallback (no browser needed: every case fails before
+// Chrome would start)
+// ───────────────────────────────────────────────────────────────
+
+func TestLoadHeadlessConfigFromEnv_Defaults(t *testing.T) {
+	cfg, err := fetcher.LoadHeadlessConfigFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Enabled {
+		t.Error("expected Enabled=false by default (needs a browser binary)")
+	}
+	if cfg.Timeout != 20*time.Second {
+		t.Errorf("expected Timeout=20s, got %v", cfg.Timeout)
+	}
+	if cfg.Concurrency != 2 {
+		t.Errorf("expected Concurrency=2, got %d", cfg.Concurrency)
+	}
+}
+
+func TestLoadHeadlessConfigFromEnv_Overrides(t *testing.T) {
+	t.Setenv("HEADLESS_ENABLED", "true")
+	t.Setenv("HEADLESS_TIMEOUT", "45s")
+	t.Setenv("HEADLESS_CONCURRENCY", "4")
+	t.Setenv("HEADLESS_CHROME_PATH", "/usr/bin/chromium")
+
+	cfg, err := fetcher.LoadHeadlessConfigFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := fetcher.HeadlessConfig{
+		Enabled: true, Timeout: 45 * time.Second, Concurrency: 4, ExecPath: "/usr/bin/chromium",
+	}
+	if cfg != want {
+		t.Errorf("got %+v, want %+v", cfg, want)
+	}
+}
+
+func TestLoadHeadlessConfigFromEnv_Invalid(t *testing.T) {
+	tests := []struct {
+		name, key, value string
+	}{
+		{"unparsable timeout", "HEADLESS_TIMEOUT", "soon"},
+		{"zero timeout", "HEADLESS_TIMEOUT", "0s"},
+		{"unparsable concurrency", "HEADLESS_CONCURRENCY", "many"},
+		{"zero concurrency", "HEADLESS_CONCURRENCY", "0"},
+		{"too much concurrency", "HEADLESS_CONCURRENCY", "11"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(tt.key, tt.value)
+			if _, err := fetcher.LoadHeadlessConfigFromEnv(); err == nil {
+				t.Errorf("%s=%q: expected an error", tt.key, tt.value)
+			}
+		})
+	}
+}
+
+func TestHeadlessFetcher_RejectsUnsafeURLs(t *testing.T) {
+	f := fetcher.NewHeadlessFetcher(fetcher.DefaultHeadlessConfig(), fetcher.DefaultConfig())
+	defer f.Close()
+
+	tests := []struct {
+		url  string
+		want error
+	}{
+		{"http://127.0.0.1/admin", fetch.ErrPrivateIP},
+		{"http://192.168.1.10/", fetch.ErrPrivateIP},
+		{"file:///etc/passwd", fetch.ErrInvalidURL},
+		{"javascript:alert(1)", fetch.ErrInvalidURL},
+	}
+	for _, tt := range tests {
+		_, err := f.FetchPage(context.Background(), tt.url)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("FetchPage(%q) error = %v, want %v", tt.url, err, tt.want)
+		}
+	}
+}
+
+func TestHeadlessFetcher_ClosedFetcherFails(t *testing.T) {
+	f := fetcher.NewHeadlessFetcher(fetcher.DefaultHeadlessConfig(), fetcher.DefaultConfig())
+	f.Close()
+
+	// A public IP literal passes the SSRF check without DNS.
+	_, err := f.FetchContent(context.Background(), "https://93.184.216.34/article")
+	if err == nil {
+		t.Fatal("expected an error after Close")
+	}
+}
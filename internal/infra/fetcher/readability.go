@@ -113,6 +113,20 @@ func (f *ReadabilityFetcher) FetchContent(ctx context.Context, urlStr string) (s
 	}
 
 	// Step 2: Execute fetch
+	page, err := f.doFetch(ctx, urlStr)
+	if err != nil {
+		return "", err
+	}
+	return page.Text, nil
+}
+
+// FetchPage fetches the article like FetchContent but also returns the raw
+// HTML and the final URL after redirects. This method implements the
+// optional fetch.PageFetcher interface (article_contents storage).
+func (f *ReadabilityFetcher) FetchPage(ctx context.Context, urlStr string) (*fetch.FetchedPage, error) {
+	if err := validateURL(urlStr, f.config.DenyPrivateIPs); err != nil {
+		return nil, err
+	}
 	return f.doFetch(ctx, urlStr)
 }
 
@@ -123,16 +137,16 @@ func (f *ReadabilityFetcher) FetchContent(ctx context.Context, urlStr string) (s
 //  2. Execute HTTP request
 //  3. Read response body with size limiting
 //  4. Extract article content using Readability
-//  5. Return clean text
+//  5. Return the page with its clean text
 //
 // Parameters:
 //   - ctx: Context for cancellation and timeout
 //   - urlStr: Article URL to fetch
 //
 // Returns:
-//   - string: Extracted article content (plain text)
+//   - *fetch.FetchedPage: Final URL, raw HTML and extracted text
 //   - error: Error if fetching or extraction fails
-func (f *ReadabilityFetcher) doFetch(ctx context.Context, urlStr string) (string, error) {
+func (f *ReadabilityFetcher) doFetch(ctx context.Context, urlStr string) (*fetch.FetchedPage, error) {
 	// Apply per-request timeout from config
 	reqCtx, cancel := context.WithTimeout(ctx, f.config.Timeout)
 	defer cancel()
@@ -140,7 +154,7 @@ func (f *ReadabilityFetcher) doFetch(ctx context.Context, urlStr string) (string
 	// Create HTTP request
 	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, urlStr, nil)
 	if err != nil {
-		return "", fmt.Errorf("%w: failed to create request: %v", fetch.ErrInvalidURL, err)
+		return nil, fmt.Errorf("%w: failed to create request: %v", fetch.ErrInvalidURL, err)
 	}
 
 	// Identify ourselves with the crawler-wide User-Agent (shared with the
@@ -152,13 +166,13 @@ func (f *ReadabilityFetcher) doFetch(ctx context.Context, urlStr string) (string
 	if err != nil {
 		// Check if error is timeout
 		if reqCtx.Err() == context.DeadlineExceeded {
-			return "", fmt.Errorf("%w: request exceeded %v", fetch.ErrTimeout, f.config.Timeout)
+			return nil, fmt.Errorf("%w: request exceeded %v", fetch.ErrTimeout, f.config.Timeout)
 		}
 		// Check if error is due to redirect validation
 		if urlErr, ok := err.(*url.Error); ok && urlErr.Err != nil {
-			return "", urlErr.Err
+			return nil, urlErr.Err
 		}
-		return "", fmt.Errorf("HTTP request failed: %w", err)
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
 	defer func() {
 		_ = resp.Body.Close()
@@ -166,7 +180,7 @@ func (f *ReadabilityFetcher) doFetch(ctx context.Context, urlStr string) (string
 
 	// Check HTTP status code
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
 	}
 
 	// Read response body with size limit
@@ -174,22 +188,32 @@ func (f *ReadabilityFetcher) doFetch(ctx context.Context, urlStr string) (string
 	limitedReader := io.LimitReader(resp.Body, f.config.MaxBodySize+1)
 	htmlBytes, err := io.ReadAll(limitedReader)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	// Check if response exceeded size limit
 	if int64(len(htmlBytes)) > f.config.MaxBodySize {
-		return "", fmt.Errorf("%w: response size %d bytes exceeds limit %d bytes",
+		return nil, fmt.Errorf("%w: response size %d bytes exceeds limit %d bytes",
 			fetch.ErrBodyTooLarge, len(htmlBytes), f.config.MaxBodySize)
 	}
 
 	// Parse the final URL (may have changed due to redirects)
-	parsedURL, err := url.Parse(urlStr)
-	if err != nil {
-		parsedURL = nil // Readability can work without URL
+	var finalURL *url.URL
+	if resp.Request != nil {
+		finalURL = resp.Request.URL
 	}
-	if resp.Request != nil && resp.Request.URL != nil {
-		parsedURL = resp.Request.URL
+	return extractPage(finalURL, urlStr, htmlBytes)
+}
+
+// extractPage runs Readability over a fetched page. finalURL is the URL
+// after redirects (nil = urlStr); it resolves relative links. Shared by
+// the plain HTTP and the headless fetchers.
+func extractPage(finalURL *url.URL, urlStr string, htmlBytes []byte) (*fetch.FetchedPage, error) {
+	parsedURL := finalURL
+	if parsedURL == nil {
+		if u, err := url.Parse(urlStr); err == nil {
+			parsedURL = u // Readability can work without URL
+		}
 	}
 
 	// Extract article content using Readability
@@ -197,7 +221,7 @@ func (f *ReadabilityFetcher) doFetch(ctx context.Context, urlStr string) (string
 	htmlReader := io.NopCloser(bytes.NewReader(htmlBytes))
 	article, err := readability.FromReader(htmlReader, parsedURL)
 	if err != nil {
-		return "", fmt.Errorf("%w: %v", fetch.ErrReadabilityFailed, err)
+		return nil, fmt.Errorf("%w: %v", fetch.ErrReadabilityFailed, err)
 	}
 
 	// Return clean article text
@@ -205,13 +229,23 @@ func (f *ReadabilityFetcher) doFetch(ctx context.Context, urlStr string) (string
 	if article.TextContent == "" {
 		// Fallback to Content if TextContent is empty
 		if article.Content == "" {
-			return "", fmt.Errorf("%w: no readable content found", fetch.ErrReadabilityFailed)
+			return nil, fmt.Errorf("%w: no readable content found", fetch.ErrReadabilityFailed)
 		}
 		slog.Debug("using article Content instead of TextContent",
 			slog.String("url", urlStr),
 			slog.Int("content_length", len(article.Content)))
-		return article.Content, nil
+		return newFetchedPage(parsedURL, urlStr, htmlBytes, article.Content, article.Image), nil
 	}
 
-	return article.TextContent, nil
+	return newFetchedPage(parsedURL, urlStr, htmlBytes, article.TextContent, article.Image), nil
+}
+
+// newFetchedPage builds the fetched page, preferring the final URL after
+// redirects over the requested one. image is the page's declared
+// thumbnail, kept only when valid (see thumbnailURL).
+func newFetchedPage(finalURL *url.URL, urlStr string, html []byte, text, image string) *fetch.FetchedPage {
+	if finalURL != nil {
+		urlStr = finalURL.String()
+	}
+	return &fetch.FetchedPage{URL: urlStr, HTML: string(html), Text: text, ImageURL: thumbnailURL(finalURL, image)}
 }
@@ -0,0 +1,37 @@
+package fetcher
+
+import (
+	"net/url"
+	"strings"
+)
+
+// maxImageURLLength bounds a stored thumbnail URL; longer ones are most
+// likely tracking-laden or inlined data and are dropped.
+const maxImageURLLength = 2048
+
+// thumbnailURL validates the page's declared image (og:image, image or
+// twitter:image, as picked by Readability) and resolves it against the
+// page URL. Only absolute http(s) URLs without credentials are kept — a
+// data: URI or a relative path the client cannot resolve is worse than no
+// thumbnail. Returns "" for anything else.
+func thumbnailURL(base *url.URL, raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || len(raw) > maxImageURLLength {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User != nil {
+		return ""
+	}
+	s := u.String()
+	if len(s) > maxImageURLLength {
+		return ""
+	}
+	return s
+}
@@ -49,6 +49,13 @@ func SSRFCheckRedirect(maxRedirects int, denyPrivateIPs bool) func(req *http.Req
 	}
 }
 
+// ValidateURL runs the entry-point SSRF validation (validateURL) for callers
+// outside this package that fetch a user-supplied URL directly, such as the
+// feed preview of POST /sources/validate.
+func ValidateURL(urlStr string, denyPrivateIPs bool) error {
+	return validateURL(urlStr, denyPrivateIPs)
+}
+
 // validateURL validates a URL for security before making an HTTP request.
 // This function prevents Server-Side Request Forgery (SSRF) attacks by:
 //   - Checking URL scheme (only http/https allowed)
@@ -0,0 +1,178 @@
+// Package objectstore writes objects to S3-compatible storage: Amazon S3,
+// Google Cloud Storage through its XML API with HMAC keys, MinIO and
+// Cloudflare R2. Like the secrets package it is a plain net/http client
+// signed with AWS Signature Version 4; no vendor SDK.
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"catchup-feed/internal/infra/secrets"
+)
+
+// Environment variables. The credentials are the AWS ones
+// (AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY / AWS_SESSION_TOKEN); for GCS
+// they hold an HMAC key of the service account.
+const (
+	EnvBucket = "EXPORT_BUCKET"
+	// EnvEndpoint selects another S3-compatible service, e.g.
+	// https://storage.googleapis.com (GCS) or http://minio:9000. Objects
+	// are then addressed path-style (endpoint/bucket/key).
+	EnvEndpoint = "EXPORT_S3_ENDPOINT"
+	// EnvRegion defaults to AWS_REGION / AWS_DEFAULT_REGION; GCS and R2
+	// accept "auto".
+	EnvRegion  = "EXPORT_S3_REGION"
+	EnvTimeout = "EXPORT_S3_TIMEOUT"
+)
+
+const (
+	s3Service = "s3"
+
+	// defaultTimeout bounds each request, upload included.
+	defaultTimeout = 60 * time.Second
+
+	// maxErrorBytes caps the error body kept in an error message.
+	maxErrorBytes = 4 << 10
+)
+
+// ErrNotConfigured indicates a bucket without a region or credentials.
+var ErrNotConfigured = errors.New("object store not configured")
+
+// S3 puts and deletes objects in one bucket.
+type S3 struct {
+	Bucket string
+	Region string
+	// Endpoint is empty for Amazon S3 (virtual-hosted
+	// https://<bucket>.s3.<region>.amazonaws.com); any other endpoint is
+	// addressed path-style.
+	Endpoint    string
+	Credentials secrets.AWSCredentials
+	HTTPClient  *http.Client
+	now         func() time.Time
+}
+
+// NewS3FromEnv returns the bucket configured by EXPORT_BUCKET, or nil when
+// it is unset.
+func NewS3FromEnv() (*S3, error) {
+	bucket := os.Getenv(EnvBucket)
+	if bucket == "" {
+		return nil, nil
+	}
+	region := os.Getenv(EnvRegion)
+	if region == "" {
+		region = os.Getenv(secrets.EnvAWSRegion)
+	}
+	if region == "" {
+		region = os.Getenv(secrets.EnvAWSDefaultRegion)
+	}
+	s := &S3{
+		Bucket:   bucket,
+		Region:   region,
+		Endpoint: os.Getenv(EnvEndpoint),
+		Credentials: secrets.AWSCredentials{
+			AccessKeyID:     os.Getenv(secrets.EnvAWSAccessKeyID),
+			SecretAccessKey: os.Getenv(secrets.EnvAWSSecretKey),
+			SessionToken:    os.Getenv(secrets.EnvAWSSessionToken),
+		},
+	}
+	if s.Region == "" || s.Credentials.AccessKeyID == "" || s.Credentials.SecretAccessKey == "" {
+		return nil, fmt.Errorf("%w: %s (or %s), %s and %s are required with %s",
+			ErrNotConfigured, EnvRegion, secrets.EnvAWSRegion, secrets.EnvAWSAccessKeyID, secrets.EnvAWSSecretKey, EnvBucket)
+	}
+	if s.Endpoint != "" {
+		u, err := url.Parse(s.Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("%s must be an http(s) URL", EnvEndpoint)
+		}
+	}
+	timeout := defaultTimeout
+	if raw := os.Getenv(EnvTimeout); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("%s must be a positive duration", EnvTimeout)
+		}
+		timeout = d
+	}
+	s.HTTPClient = &http.Client{Timeout: timeout}
+	return s, nil
+}
+
+// Put uploads body as key, replacing any object of that name.
+func (s *S3) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	req, err := s.newRequest(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return s.do(req, body, http.StatusOK)
+}
+
+// Delete removes key. Deleting a missing object succeeds, as S3 does.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	return s.do(req, nil, http.StatusNoContent, http.StatusOK, http.StatusNotFound)
+}
+
+func (s *S3) newRequest(ctx context.Context, method, key string, body []byte) (*http.Request, error) {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return nil, fmt.Errorf("s3: invalid object key %q", key)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.objectURL(key), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("s3: build request: %w", err)
+	}
+	return req, nil
+}
+
+// objectURL escapes each key segment the way S3 builds the canonical URI
+// it verifies the signature against (everything but A-Z a-z 0-9 - . _ ~,
+// so "dt=" partitions become dt%3D) and keeps the slashes.
+func (s *S3) objectURL(key string) string {
+	segments := strings.Split(key, "/")
+	for i, seg := range segments {
+		segments[i] = strings.ReplaceAll(url.QueryEscape(seg), "+", "%20")
+	}
+	path := strings.Join(segments, "/")
+	if s.Endpoint == "" {
+		return "https://" + s.Bucket + ".s3." + s.Region + ".amazonaws.com/" + path
+	}
+	return strings.TrimRight(s.Endpoint, "/") + "/" + url.PathEscape(s.Bucket) + "/" + path
+}
+
+func (s *S3) do(req *http.Request, body []byte, okStatus ...int) error {
+	payloadHash := sha256.Sum256(body)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+	secrets.SignV4(req, body, s.Credentials, s.Region, s3Service, now().UTC())
+
+	resp, err := s.HTTPClient.Do(req) // #nosec G107 -- endpoint is AWS or operator configuration
+	if err != nil {
+		return fmt.Errorf("s3: %s: %w", req.Method, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	for _, status := range okStatus {
+		if resp.StatusCode == status {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			return nil
+		}
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBytes))
+	return fmt.Errorf("s3: %s %s: status %d: %s", req.Method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(msg)))
+}
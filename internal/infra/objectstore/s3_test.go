@@ -0,0 +1,99 @@
+package objectstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/infra/secrets"
+)
+
+func newTestS3(t *testing.T, handler http.HandlerFunc) *S3 {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return &S3{
+		Bucket:      "analytics",
+		Region:      "auto",
+		Endpoint:    srv.URL,
+		Credentials: secrets.AWSCredentials{AccessKeyID: "AKID", SecretAccessKey: "secret"},
+		HTTPClient:  srv.Client(),
+		now:         func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) },
+	}
+}
+
+func TestS3_Put(t *testing.T) {
+	s := newTestS3(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/analytics/catchup/articles/dt%3D2026-01-01/a%201.ndjson", r.URL.EscapedPath())
+		assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+		// sha256("{}\n")
+		assert.Equal(t, "ca3d163bab055381827226140568f3bef7eaac187cebd76878e0b63e9e442356", r.Header.Get("X-Amz-Content-Sha256"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"),
+			"AWS4-HMAC-SHA256 Credential=AKID/20260101/auto/s3/aws4_request, "))
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "{}\n", string(body))
+	})
+	require.NoError(t, s.Put(context.Background(), "catchup/articles/dt=2026-01-01/a 1.ndjson", []byte("{}\n"), "application/x-ndjson"))
+}
+
+func TestS3_Errors(t *testing.T) {
+	s := newTestS3(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = fmt.Fprint(w, `<Error><Code>AccessDenied</Code></Error>`)
+	})
+	err := s.Put(context.Background(), "x.ndjson", nil, "application/x-ndjson")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 403")
+	assert.Contains(t, err.Error(), "AccessDenied")
+
+	assert.NoError(t, s.Delete(context.Background(), "x.ndjson"), "a missing object is already deleted")
+	assert.Error(t, s.Put(context.Background(), "/x", nil, ""), "absolute key")
+}
+
+func TestS3_ObjectURL(t *testing.T) {
+	s := &S3{Bucket: "b", Region: "ap-northeast-1"}
+	assert.Equal(t, "https://b.s3.ap-northeast-1.amazonaws.com/p/k.ndjson.gz", s.objectURL("p/k.ndjson.gz"))
+	s.Endpoint = "https://storage.googleapis.com/"
+	assert.Equal(t, "https://storage.googleapis.com/b/p/k.ndjson.gz", s.objectURL("p/k.ndjson.gz"))
+}
+
+func TestNewS3FromEnv(t *testing.T) {
+	t.Setenv(EnvBucket, "")
+	s, err := NewS3FromEnv()
+	require.NoError(t, err)
+	assert.Nil(t, s, "export disabled without a bucket")
+
+	t.Setenv(EnvBucket, "analytics")
+	t.Setenv(EnvRegion, "")
+	t.Setenv(secrets.EnvAWSRegion, "ap-northeast-1")
+	t.Setenv(secrets.EnvAWSAccessKeyID, "")
+	t.Setenv(secrets.EnvAWSSecretKey, "")
+	_, err = NewS3FromEnv()
+	assert.ErrorIs(t, err, ErrNotConfigured)
+
+	t.Setenv(secrets.EnvAWSAccessKeyID, "AKID")
+	t.Setenv(secrets.EnvAWSSecretKey, "secret")
+	t.Setenv(EnvEndpoint, "storage.googleapis.com")
+	_, err = NewS3FromEnv()
+	assert.Error(t, err, "endpoint without scheme")
+
+	t.Setenv(EnvEndpoint, "")
+	t.Setenv(EnvTimeout, "2m")
+	s, err = NewS3FromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, "ap-northeast-1", s.Region)
+	assert.Equal(t, 2*time.Minute, s.HTTPClient.Timeout)
+}
@@ -0,0 +1,341 @@
+// Package oidc verifies ID tokens issued by an external OpenID Connect
+// provider (Google, Microsoft Entra ID, Keycloak, ...). The provider is
+// located through its discovery document and tokens are checked against
+// the signing keys it publishes (JWKS). Like the summarizer providers this
+// is a plain net/http client; no vendor SDK.
+//
+// Only ID-token verification lives here. Which role a verified identity
+// gets (claim-to-role mapping) is auth policy and belongs to the HTTP auth
+// layer.
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"catchup-feed/pkg/config"
+)
+
+// Environment variables. OIDC login is disabled while EnvIssuer is unset.
+const (
+	EnvIssuer   = "OIDC_ISSUER"
+	EnvClientID = "OIDC_CLIENT_ID"
+	EnvTimeout  = "OIDC_TIMEOUT"
+)
+
+const (
+	// defaultTimeout bounds each discovery / JWKS request.
+	defaultTimeout = 10 * time.Second
+
+	// keysTTL is how long fetched signing keys are trusted before the JWKS
+	// is fetched again. Providers rotate keys with overlap, so an hour is
+	// well within their publication window.
+	keysTTL = 1 * time.Hour
+
+	// minRefreshInterval throttles refetches triggered by an unknown key
+	// ID, so forged tokens with random kids cannot hammer the provider.
+	minRefreshInterval = 1 * time.Minute
+
+	// clockSkew tolerates small clock differences with the provider.
+	clockSkew = 1 * time.Minute
+
+	// maxDocumentBytes caps discovery / JWKS response bodies.
+	maxDocumentBytes = 1 << 20
+)
+
+// signingMethods are the asymmetric algorithms accepted for ID tokens.
+// HS* is excluded on purpose: the provider's "secret" would be the public
+// client ID.
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384"}
+
+var (
+	// ErrInvalidToken indicates an ID token that failed verification
+	// (signature, issuer, audience, expiry, unknown key).
+	ErrInvalidToken = errors.New("invalid id token")
+
+	// ErrProviderUnavailable indicates the discovery document or JWKS
+	// could not be fetched or parsed.
+	ErrProviderUnavailable = errors.New("oidc provider unavailable")
+)
+
+// Config identifies the provider and this application's registration.
+type Config struct {
+	// Issuer is the provider's issuer URL, e.g. https://accounts.google.com.
+	// It must match the "iss" claim (and the discovery document) exactly,
+	// including any trailing slash.
+	Issuer string
+	// ClientID is this application's OAuth client ID, the expected "aud".
+	ClientID string
+	// Timeout bounds each request to the provider.
+	Timeout time.Duration
+}
+
+// LoadConfig reads the OIDC settings. enabled is false when OIDC_ISSUER is
+// unset. A set issuer without a client ID, or an issuer that is not an
+// https URL (http is tolerated for localhost), is an error that prevents
+// startup.
+func LoadConfig() (cfg Config, enabled bool, err error) {
+	cfg = Config{
+		Issuer:   strings.TrimSpace(os.Getenv(EnvIssuer)),
+		ClientID: strings.TrimSpace(os.Getenv(EnvClientID)),
+		Timeout:  config.GetEnvDuration(EnvTimeout, defaultTimeout),
+	}
+	if cfg.Issuer == "" {
+		return cfg, false, nil
+	}
+	u, err := url.Parse(cfg.Issuer)
+	if err != nil || u.Host == "" {
+		return cfg, false, fmt.Errorf("%s is invalid: must be an absolute URL", EnvIssuer)
+	}
+	if u.Scheme != "https" && (u.Scheme != "http" || !isLoopback(u.Hostname())) {
+		return cfg, false, fmt.Errorf("%s is invalid: must use https", EnvIssuer)
+	}
+	if cfg.ClientID == "" {
+		return cfg, false, fmt.Errorf("%s is required when %s is set", EnvClientID, EnvIssuer)
+	}
+	return cfg, true, nil
+}
+
+func isLoopback(host string) bool {
+	return host == "localhost" || host == "127.0.0.1" || host == "::1"
+}
+
+// Verifier checks ID tokens against the provider's published keys. Keys
+// are fetched lazily on first use and cached; an unknown key ID triggers a
+// (throttled) refetch so provider key rotation needs no restart. Safe for
+// concurrent use.
+type Verifier struct {
+	cfg    Config
+	client *http.Client
+	now    func() time.Time
+
+	mu        sync.Mutex
+	jwksURI   string
+	keys      map[string]any
+	fetchedAt time.Time
+}
+
+// NewVerifier creates a Verifier. client may be nil to use a client with
+// cfg.Timeout.
+func NewVerifier(cfg Config, client *http.Client) *Verifier {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if client == nil {
+		client = &http.Client{Timeout: cfg.Timeout}
+	}
+	return &Verifier{cfg: cfg, client: client, now: time.Now}
+}
+
+// Verify validates rawIDToken — signature, issuer, audience (and azp when
+// several audiences are present), expiry and issued-at — and returns its
+// claims. Verification failures wrap ErrInvalidToken; provider outages wrap
+// ErrProviderUnavailable.
+func (v *Verifier) Verify(ctx context.Context, rawIDToken string) (map[string]any, error) {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithIssuer(v.cfg.Issuer),
+		jwt.WithAudience(v.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+		jwt.WithTimeFunc(v.now),
+	)
+	var keyErr error
+	token, err := parser.Parse(rawIDToken, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, err := v.key(ctx, kid)
+		keyErr = err
+		return key, err
+	})
+	if err != nil {
+		if errors.Is(keyErr, ErrProviderUnavailable) {
+			return nil, keyErr
+		}
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	// OIDC Core §3.1.3.7: with several audiences, azp must be this client.
+	if aud, _ := claims.GetAudience(); len(aud) > 1 {
+		if azp, _ := claims["azp"].(string); azp != v.cfg.ClientID {
+			return nil, fmt.Errorf("%w: azp does not match the client", ErrInvalidToken)
+		}
+	}
+	return claims, nil
+}
+
+// key returns the public key for kid, refreshing the JWKS when the cache
+// is stale or the kid is unknown.
+func (v *Verifier) key(ctx context.Context, kid string) (any, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := v.now()
+	if key, ok := v.lookup(kid); ok && now.Sub(v.fetchedAt) < keysTTL {
+		return key, nil
+	}
+	if v.keys == nil || now.Sub(v.fetchedAt) >= minRefreshInterval {
+		if err := v.refresh(ctx); err != nil {
+			// Keep serving cached keys through a provider outage.
+			if key, ok := v.lookup(kid); ok {
+				return key, nil
+			}
+			return nil, err
+		}
+		v.fetchedAt = now
+	}
+	if key, ok := v.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// lookup finds kid in the cache. A token without kid matches when the
+// provider publishes exactly one key.
+func (v *Verifier) lookup(kid string) (any, bool) {
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, true
+		}
+	}
+	key, ok := v.keys[kid]
+	return key, ok
+}
+
+// refresh resolves the JWKS URI through discovery (once) and reloads the
+// key set. Called with v.mu held.
+func (v *Verifier) refresh(ctx context.Context) error {
+	if v.jwksURI == "" {
+		var doc struct {
+			Issuer  string `json:"issuer"`
+			JWKSURI string `json:"jwks_uri"`
+		}
+		if err := v.getJSON(ctx, strings.TrimSuffix(v.cfg.Issuer, "/")+"/.well-known/openid-configuration", &doc); err != nil {
+			return err
+		}
+		if doc.Issuer != v.cfg.Issuer || doc.JWKSURI == "" {
+			return fmt.Errorf("%w: discovery document does not match the issuer", ErrProviderUnavailable)
+		}
+		v.jwksURI = doc.JWKSURI
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := v.getJSON(ctx, v.jwksURI, &set); err != nil {
+		return err
+	}
+	keys := make(map[string]any, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		if key, err := k.publicKey(); err == nil {
+			keys[k.Kid] = key
+		}
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("%w: no usable signing keys", ErrProviderUnavailable)
+	}
+	v.keys = keys
+	return nil
+}
+
+func (v *Verifier) getJSON(ctx context.Context, rawURL string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrProviderUnavailable, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrProviderUnavailable, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: GET %s: status %d", ErrProviderUnavailable, rawURL, resp.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxDocumentBytes)).Decode(out); err != nil {
+		return fmt.Errorf("%w: decode %s: %w", ErrProviderUnavailable, rawURL, err)
+	}
+	return nil
+}
+
+// jsonWebKey is the subset of RFC 7517 needed for RSA and EC signature
+// keys.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("rsa exponent out of range")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, fmt.Errorf("invalid key parameter")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,205 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testClientID = "catchup-feed-client"
+
+// fakeProvider serves a discovery document and a JWKS with one RSA key.
+type fakeProvider struct {
+	server    *httptest.Server
+	key       *rsa.PrivateKey
+	kid       string
+	jwksCalls atomic.Int32
+}
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	p := &fakeProvider{key: key, kid: "key-1"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   p.server.URL,
+			"jwks_uri": p.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		p.jwksCalls.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": p.kid,
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+		}}})
+	})
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+func (p *fakeProvider) claims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":   p.server.URL,
+		"aud":   testClientID,
+		"sub":   "10769150350006150715113082367",
+		"email": "alice@example.com",
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func (p *fakeProvider) sign(t *testing.T, claims jwt.MapClaims, kid string) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(p.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func (p *fakeProvider) verifier() *Verifier {
+	return NewVerifier(Config{Issuer: p.server.URL, ClientID: testClientID}, p.server.Client())
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	p := newFakeProvider(t)
+	v := p.verifier()
+
+	claims, err := v.Verify(context.Background(), p.sign(t, p.claims(), p.kid))
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", claims["email"])
+
+	// 2回目はキャッシュした鍵を使う。
+	_, err = v.Verify(context.Background(), p.sign(t, p.claims(), p.kid))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), p.jwksCalls.Load())
+}
+
+func TestVerifier_Rejects(t *testing.T) {
+	p := newFakeProvider(t)
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	with := func(mutate func(jwt.MapClaims)) jwt.MapClaims {
+		c := p.claims()
+		mutate(c)
+		return c
+	}
+
+	tests := []struct {
+		name  string
+		token func() string
+	}{
+		{"wrong audience", func() string {
+			return p.sign(t, with(func(c jwt.MapClaims) { c["aud"] = "someone-else" }), p.kid)
+		}},
+		{"wrong issuer", func() string {
+			return p.sign(t, with(func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }), p.kid)
+		}},
+		{"expired", func() string {
+			return p.sign(t, with(func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }), p.kid)
+		}},
+		{"missing exp", func() string {
+			return p.sign(t, with(func(c jwt.MapClaims) { delete(c, "exp") }), p.kid)
+		}},
+		{"several audiences without matching azp", func() string {
+			return p.sign(t, with(func(c jwt.MapClaims) { c["aud"] = []string{testClientID, "other"} }), p.kid)
+		}},
+		{"signed by another key", func() string {
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, p.claims())
+			token.Header["kid"] = p.kid
+			signed, err := token.SignedString(other)
+			require.NoError(t, err)
+			return signed
+		}},
+		{"unknown kid", func() string { return p.sign(t, p.claims(), "key-2") }},
+		{"HS256 with the client ID as secret", func() string {
+			signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, p.claims()).SignedString([]byte(testClientID))
+			require.NoError(t, err)
+			return signed
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.verifier().Verify(context.Background(), tt.token())
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
+
+// TestVerifier_UnknownKidRefetchIsThrottled: ランダムな kid の偽造トークンで
+// JWKS を叩かせない。
+func TestVerifier_UnknownKidRefetchIsThrottled(t *testing.T) {
+	p := newFakeProvider(t)
+	v := p.verifier()
+
+	_, err := v.Verify(context.Background(), p.sign(t, p.claims(), p.kid))
+	require.NoError(t, err)
+	for range 5 {
+		_, err = v.Verify(context.Background(), p.sign(t, p.claims(), "forged"))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	}
+	assert.Equal(t, int32(1), p.jwksCalls.Load())
+}
+
+func TestVerifier_ProviderUnavailable(t *testing.T) {
+	p := newFakeProvider(t)
+	token := p.sign(t, p.claims(), p.kid)
+	v := p.verifier()
+	p.server.Close()
+
+	_, err := v.Verify(context.Background(), token)
+	assert.ErrorIs(t, err, ErrProviderUnavailable)
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		issuer      string
+		clientID    string
+		wantEnabled bool
+		wantErr     bool
+	}{
+		{name: "disabled when issuer unset"},
+		{name: "https issuer", issuer: "https://accounts.google.com", clientID: "c", wantEnabled: true},
+		{name: "http on localhost for development", issuer: "http://localhost:8081/realms/dev", clientID: "c", wantEnabled: true},
+		{name: "plain http", issuer: "http://idp.example.com", clientID: "c", wantErr: true},
+		{name: "missing client id", issuer: "https://accounts.google.com", wantErr: true},
+		{name: "not a URL", issuer: "accounts.google.com", clientID: "c", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvIssuer, tt.issuer)
+			t.Setenv(EnvClientID, tt.clientID)
+
+			_, enabled, err := LoadConfig()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantEnabled, enabled)
+		})
+	}
+}
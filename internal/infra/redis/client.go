@@ -0,0 +1,339 @@
+// Package redis is the small Redis client shared by the response cache
+// (internal/infra/cache) and the rate limiter store. It speaks RESP2 over
+// a bounded connection pool, with TLS (rediss://), ACL users and
+// pipelining; the commands themselves are up to the callers.
+package redis
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPoolSize caps the open connections per client unless the URL
+	// sets ?pool_size=.
+	DefaultPoolSize = 10
+
+	dialTimeout = 2 * time.Second
+	ioTimeout   = 500 * time.Millisecond
+	// maxIdleTime drops pooled connections a server-side timeout or a
+	// NAT in between may already have closed.
+	maxIdleTime = 5 * time.Minute
+)
+
+// ErrNil is the nil reply (a missing key, an empty array).
+var ErrNil = errors.New("redis: nil")
+
+// ErrClosed is returned once Close has been called.
+var ErrClosed = errors.New("redis: client closed")
+
+// Error is an error reply (-ERR ...); the connection stays usable.
+type Error string
+
+func (e Error) Error() string { return "redis: " + string(e) }
+
+// Client is a pool of connections to one Redis server. It is safe for
+// concurrent use; at most PoolSize commands are in flight at once and
+// further callers wait for a free connection (or their context).
+type Client struct {
+	addr     string
+	username string
+	password string
+	db       int
+	tls      *tls.Config
+
+	slots chan struct{}
+
+	mu     sync.Mutex
+	idle   []*conn
+	closed bool
+}
+
+type conn struct {
+	nc       net.Conn
+	r        *bufio.Reader
+	lastUsed time.Time
+}
+
+// New returns a client for
+// redis[s]://[user:password@]host[:port][/db][?pool_size=N]. rediss://
+// dials TLS with the system roots. Connections are dialled lazily, so an
+// unreachable Redis does not fail New.
+func New(rawURL string) (*Client, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") || u.Hostname() == "" {
+		return nil, errors.New("REDIS_URL: want redis[s]://[user:password@]host[:port][/db]")
+	}
+	c := &Client{addr: u.Host}
+	if u.Port() == "" {
+		c.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.Scheme == "rediss" {
+		c.tls = &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}
+	}
+	if u.User != nil {
+		c.username = u.User.Username()
+		c.password, _ = u.User.Password()
+	}
+	if db := strings.TrimPrefix(u.Path, "/"); db != "" {
+		if c.db, err = strconv.Atoi(db); err != nil || c.db < 0 {
+			return nil, fmt.Errorf("REDIS_URL: invalid database %q", db)
+		}
+	}
+	poolSize := DefaultPoolSize
+	if v := u.Query().Get("pool_size"); v != "" {
+		if poolSize, err = strconv.Atoi(v); err != nil || poolSize <= 0 {
+			return nil, fmt.Errorf("REDIS_URL: invalid pool_size %q", v)
+		}
+	}
+	c.slots = make(chan struct{}, poolSize)
+	return c, nil
+}
+
+// Addr returns the host:port the client dials.
+func (c *Client) Addr() string { return c.addr }
+
+// PoolSize returns the maximum number of open connections.
+func (c *Client) PoolSize() int { return cap(c.slots) }
+
+// Do runs one command. A nil reply is ErrNil and an error reply is an
+// Error; other replies are string (status), int64, []byte (bulk) or []any
+// (array).
+func (c *Client) Do(ctx context.Context, args ...string) (any, error) {
+	replies, err := c.Pipeline(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	if err, ok := replies[0].(error); ok {
+		return nil, err
+	}
+	return replies[0], nil
+}
+
+// Pipeline writes the commands in one go and reads their replies in
+// order, so N commands cost one round trip. Per-command failures (Error,
+// ErrNil) are returned in place of the reply; the error result is only for
+// the connection itself.
+func (c *Client) Pipeline(ctx context.Context, cmds ...[]string) ([]any, error) {
+	cn, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(ioTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = cn.nc.SetDeadline(deadline)
+
+	replies, err := cn.roundTrip(cmds...)
+	if err != nil {
+		// The stream is out of step with the replies; never reuse it.
+		_ = cn.nc.Close()
+		<-c.slots
+		return nil, err
+	}
+	c.put(cn)
+	return replies, nil
+}
+
+// Close closes the idle connections; connections in use are closed when
+// they are returned. The client is unusable afterwards.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	for _, cn := range c.idle {
+		_ = cn.nc.Close()
+	}
+	c.idle = nil
+	return nil
+}
+
+// get takes a pool slot, then an idle connection or a new one.
+func (c *Client) get(ctx context.Context) (*conn, error) {
+	select {
+	case c.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("redis: waiting for a connection: %w", ctx.Err())
+	}
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		<-c.slots
+		return nil, ErrClosed
+	}
+	for len(c.idle) > 0 {
+		cn := c.idle[len(c.idle)-1]
+		c.idle = c.idle[:len(c.idle)-1]
+		if time.Since(cn.lastUsed) < maxIdleTime {
+			c.mu.Unlock()
+			return cn, nil
+		}
+		_ = cn.nc.Close()
+	}
+	c.mu.Unlock()
+
+	cn, err := c.dial(ctx)
+	if err != nil {
+		<-c.slots
+		return nil, err
+	}
+	return cn, nil
+}
+
+func (c *Client) put(cn *conn) {
+	c.mu.Lock()
+	if c.closed {
+		_ = cn.nc.Close()
+	} else {
+		cn.lastUsed = time.Now()
+		c.idle = append(c.idle, cn)
+	}
+	c.mu.Unlock()
+	<-c.slots
+}
+
+func (c *Client) dial(ctx context.Context) (*conn, error) {
+	d := net.Dialer{Timeout: dialTimeout}
+	var nc net.Conn
+	var err error
+	if c.tls != nil {
+		td := tls.Dialer{NetDialer: &d, Config: c.tls}
+		nc, err = td.DialContext(ctx, "tcp", c.addr)
+	} else {
+		nc, err = d.DialContext(ctx, "tcp", c.addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("redis: dial: %w", err)
+	}
+	cn := &conn{nc: nc, r: bufio.NewReader(nc)}
+	_ = nc.SetDeadline(time.Now().Add(ioTimeout))
+
+	var setup [][]string
+	if c.password != "" {
+		auth := []string{"AUTH", c.password}
+		if c.username != "" {
+			auth = []string{"AUTH", c.username, c.password}
+		}
+		setup = append(setup, auth)
+	}
+	if c.db != 0 {
+		setup = append(setup, []string{"SELECT", strconv.Itoa(c.db)})
+	}
+	if len(setup) == 0 {
+		return cn, nil
+	}
+	replies, err := cn.roundTrip(setup...)
+	if err == nil {
+		for i, r := range replies {
+			if rerr, ok := r.(error); ok {
+				err = fmt.Errorf("%s: %w", strings.ToLower(setup[i][0]), rerr)
+				break
+			}
+		}
+	}
+	if err != nil {
+		_ = nc.Close()
+		return nil, fmt.Errorf("redis: %w", err)
+	}
+	return cn, nil
+}
+
+// roundTrip writes the commands as RESP arrays of bulk strings and reads
+// one reply per command.
+func (cn *conn) roundTrip(cmds ...[]string) ([]any, error) {
+	var b strings.Builder
+	for _, args := range cmds {
+		fmt.Fprintf(&b, "*%d\r\n", len(args))
+		for _, a := range args {
+			fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(a), a)
+		}
+	}
+	if _, err := io.WriteString(cn.nc, b.String()); err != nil {
+		return nil, err
+	}
+	replies := make([]any, len(cmds))
+	for i := range replies {
+		v, err := cn.readReply()
+		var rerr Error
+		switch {
+		case errors.Is(err, ErrNil), errors.As(err, &rerr):
+			replies[i] = err
+		case err != nil:
+			return nil, err
+		default:
+			replies[i] = v
+		}
+	}
+	return replies, nil
+}
+
+// readReply reads one RESP2 reply. An error reply inside an array (EXEC,
+// EVAL) becomes an Error element rather than failing the whole reply.
+func (cn *conn) readReply() (any, error) {
+	line, err := cn.r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("redis: empty reply")
+	}
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, Error(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: bad bulk length %q", line)
+		}
+		if n < 0 {
+			return nil, ErrNil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(cn.r, buf); err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: bad array length %q", line)
+		}
+		if n < 0 {
+			return nil, ErrNil
+		}
+		elems := make([]any, n)
+		for i := range elems {
+			v, err := cn.readReply()
+			var rerr Error
+			switch {
+			case errors.Is(err, ErrNil):
+				elems[i] = nil
+			case errors.As(err, &rerr):
+				elems[i] = rerr
+			case err != nil:
+				return nil, err
+			default:
+				elems[i] = v
+			}
+		}
+		return elems, nil
+	default:
+		return nil, fmt.Errorf("redis: unsupported reply %q", line)
+	}
+}
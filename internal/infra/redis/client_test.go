@@ -0,0 +1,305 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServer answers every command with reply(args), counting the
+// connections open at once.
+type fakeServer struct {
+	ln    net.Listener
+	reply func(args []string) string
+
+	open, maxOpen atomic.Int32
+}
+
+func newFakeServer(t *testing.T, reply func(args []string) string) *fakeServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	f := &fakeServer{ln: ln, reply: reply}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+func (f *fakeServer) serve(conn net.Conn) {
+	n := f.open.Add(1)
+	for {
+		m := f.maxOpen.Load()
+		if n <= m || f.maxOpen.CompareAndSwap(m, n) {
+			break
+		}
+	}
+	defer f.open.Add(-1)
+	defer func() { _ = conn.Close() }()
+	r := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		if _, err := io.WriteString(conn, f.reply(args)); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line)[1:])
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		head, err := r.ReadString('\n') // $<len>
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(head)[1:])
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func TestNew(t *testing.T) {
+	for _, raw := range []string{
+		"localhost:6379", "http://localhost", "redis://", "redis://localhost/x",
+		"redis://localhost?pool_size=0", "redis://localhost?pool_size=x",
+	} {
+		_, err := New(raw)
+		assert.Error(t, err, raw)
+	}
+
+	c, err := New("redis://localhost")
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:6379", c.Addr())
+	assert.Equal(t, DefaultPoolSize, c.PoolSize())
+	assert.Nil(t, c.tls)
+
+	c, err = New("rediss://app:pw@cache.example.com:6380/3?pool_size=4")
+	require.NoError(t, err)
+	assert.Equal(t, "cache.example.com:6380", c.Addr())
+	assert.Equal(t, 4, c.PoolSize())
+	assert.Equal(t, "app", c.username)
+	assert.Equal(t, "pw", c.password)
+	assert.Equal(t, 3, c.db)
+	require.NotNil(t, c.tls)
+	assert.Equal(t, "cache.example.com", c.tls.ServerName)
+}
+
+func TestClient_Replies(t *testing.T) {
+	f := newFakeServer(t, func(args []string) string {
+		switch args[0] {
+		case "PING":
+			return "+PONG\r\n"
+		case "INCR":
+			return ":7\r\n"
+		case "GET":
+			return "$-1\r\n"
+		case "TIME":
+			return "*2\r\n$10\r\n1760500000\r\n$6\r\n123456\r\n"
+		}
+		return "-ERR unknown command '" + args[0] + "'\r\n"
+	})
+	c, err := New("redis://" + f.ln.Addr().String())
+	require.NoError(t, err)
+	defer func() { _ = c.Close() }()
+	ctx := context.Background()
+
+	v, err := c.Do(ctx, "PING")
+	require.NoError(t, err)
+	assert.Equal(t, "PONG", v)
+
+	_, err = c.Do(ctx, "GET", "missing")
+	assert.ErrorIs(t, err, ErrNil)
+
+	_, err = c.Do(ctx, "NOPE")
+	var rerr Error
+	require.ErrorAs(t, err, &rerr)
+	assert.Contains(t, rerr.Error(), "unknown command")
+
+	replies, err := c.Pipeline(ctx, []string{"INCR", "k"}, []string{"NOPE"}, []string{"GET", "k"}, []string{"TIME"})
+	require.NoError(t, err)
+	require.Len(t, replies, 4)
+	assert.Equal(t, int64(7), replies[0])
+	assert.IsType(t, Error(""), replies[1])
+	assert.Equal(t, ErrNil, replies[2])
+	assert.Equal(t, []any{[]byte("1760500000"), []byte("123456")}, replies[3])
+
+	assert.Equal(t, int32(1), f.maxOpen.Load(), "error replies keep the connection")
+}
+
+func TestClient_PoolIsBounded(t *testing.T) {
+	f := newFakeServer(t, func([]string) string {
+		time.Sleep(20 * time.Millisecond)
+		return "+PONG\r\n"
+	})
+	c, err := New("redis://" + f.ln.Addr().String() + "?pool_size=3")
+	require.NoError(t, err)
+	defer func() { _ = c.Close() }()
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_, err := c.Do(ctx, "PING")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, f.maxOpen.Load(), int32(3))
+}
+
+func TestClient_WaitHonoursContext(t *testing.T) {
+	release := make(chan struct{})
+	f := newFakeServer(t, func([]string) string {
+		<-release
+		return "+PONG\r\n"
+	})
+	c, err := New("redis://" + f.ln.Addr().String() + "?pool_size=1")
+	require.NoError(t, err)
+	defer func() { _ = c.Close() }()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = c.Do(context.Background(), "PING")
+	}()
+	require.Eventually(t, func() bool { return f.open.Load() == 1 }, time.Second, 5*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = c.Do(ctx, "PING")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	<-done
+}
+
+func TestClient_AuthAndSelect(t *testing.T) {
+	var mu sync.Mutex
+	var commands []string
+	f := newFakeServer(t, func(args []string) string {
+		mu.Lock()
+		commands = append(commands, strings.Join(args, " "))
+		mu.Unlock()
+		if args[0] == "AUTH" && args[2] != "pw" {
+			return "-WRONGPASS invalid username-password pair\r\n"
+		}
+		return "+OK\r\n"
+	})
+
+	c, err := New("redis://app:pw@" + f.ln.Addr().String() + "/2")
+	require.NoError(t, err)
+	_, err = c.Do(context.Background(), "PING")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AUTH app pw", "SELECT 2", "PING"}, commands)
+
+	c, err = New("redis://app:wrong@" + f.ln.Addr().String())
+	require.NoError(t, err)
+	_, err = c.Do(context.Background(), "PING")
+	assert.ErrorContains(t, err, "auth")
+}
+
+func TestClient_Closed(t *testing.T) {
+	c, err := New("redis://127.0.0.1:1")
+	require.NoError(t, err)
+	require.NoError(t, c.Close())
+	_, err = c.Do(context.Background(), "PING")
+	assert.ErrorIs(t, err, ErrClosed)
+}
+
+// openTestClient connects to TEST_REDIS_URL or skips the test, e.g.:
+//
+//	docker run -d --rm -p 56379:6379 redis:7
+//	TEST_REDIS_URL='redis://localhost:56379/15' go test ./internal/infra/redis/ -v
+//
+// The tests only touch keys they create and delete them afterwards.
+func openTestClient(t *testing.T) *Client {
+	t.Helper()
+	raw := os.Getenv("TEST_REDIS_URL")
+	if raw == "" {
+		t.Skip("TEST_REDIS_URL not set; skipping real-redis test")
+	}
+	c, err := New(raw)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	_, err = c.Do(context.Background(), "PING")
+	require.NoError(t, err)
+	return c
+}
+
+func TestClient_RealRedis(t *testing.T) {
+	c := openTestClient(t)
+	ctx := context.Background()
+	key := "catchup-feed:test:client:" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	t.Cleanup(func() { _, _ = c.Do(context.Background(), "DEL", key) })
+
+	_, err := c.Do(ctx, "GET", key)
+	assert.ErrorIs(t, err, ErrNil)
+
+	replies, err := c.Pipeline(ctx,
+		[]string{"SET", key, "a b\r\nc", "PX", "10000"},
+		[]string{"GET", key},
+		[]string{"INCR", key},
+		[]string{"PTTL", key},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "OK", replies[0])
+	assert.Equal(t, []byte("a b\r\nc"), replies[1], "bulk strings are binary safe")
+	assert.IsType(t, Error(""), replies[2], "INCR on a non-integer is an error reply")
+	ttl, ok := replies[3].(int64)
+	require.True(t, ok)
+	assert.Greater(t, ttl, int64(0))
+
+	v, err := c.Do(ctx, "EVAL", "return {1, redis.call('GET', KEYS[1]), false}", "1", key)
+	require.NoError(t, err)
+	assert.Equal(t, []any{int64(1), []byte("a b\r\nc"), nil}, v)
+
+	var wg sync.WaitGroup
+	for range 4 * c.PoolSize() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Do(ctx, "PING")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,224 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// rateLimitPrefix namespaces the rate limiter keys in a shared Redis.
+const rateLimitPrefix = "catchup-feed:ratelimit:"
+
+// RateLimitStore keeps rate limiter windows in Redis so that every server
+// instance pointed at it shares one budget per client
+// (RATE_LIMIT_STORE=redis). It implements middleware.RateLimitStore.
+//
+// Layout, under rateLimitPrefix:
+//   - hits:<key>: sorted set of allowed requests scored by time (ms), the
+//     sliding window; expires with the window
+//   - denied:<key>: hash of per-minute denial counters
+//   - denied: sorted set of keys with denials, scored by when their
+//     counters expire, so TopDenied need not scan the keyspace
+//
+// Allow, Count and Cleanup run as Lua scripts on the Redis clock (TIME),
+// atomically and agreeing across instances with skewed clocks, like the
+// postgres store's now().
+type RateLimitStore struct{ client *Client }
+
+// NewRateLimitStore returns a store on client.
+func NewRateLimitStore(client *Client) *RateLimitStore {
+	return &RateLimitStore{client: client}
+}
+
+// allowScript: KEYS = hits, denied, index; ARGV = limit, window ms, a
+// unique hit id, key. Returns 1 when allowed. A denial only bumps the
+// minute's counter, so a flood costs no memory per request; the hash
+// drops stale minutes whenever a new one starts.
+const allowScript = `
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local limit, window = tonumber(ARGV[1]), tonumber(ARGV[2])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', string.format('%d', now - window))
+if redis.call('ZCARD', KEYS[1]) < limit then
+	redis.call('ZADD', KEYS[1], string.format('%d', now), ARGV[3])
+	redis.call('PEXPIRE', KEYS[1], window)
+	return 1
+end
+if redis.call('HINCRBY', KEYS[2], string.format('%d', math.floor(now / 60000)), 1) == 1 then
+	local oldest = math.floor((now - window) / 60000)
+	for _, f in ipairs(redis.call('HKEYS', KEYS[2])) do
+		if tonumber(f) < oldest then
+			redis.call('HDEL', KEYS[2], f)
+		end
+	end
+end
+local expires = string.format('%d', now + window + 60000)
+redis.call('PEXPIREAT', KEYS[2], expires)
+redis.call('ZADD', KEYS[3], expires, ARGV[4])
+return 0`
+
+// countScript: KEYS = hits; ARGV = window ms.
+const countScript = `
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+return redis.call('ZCOUNT', KEYS[1], '(' .. string.format('%d', now - tonumber(ARGV[1])), '+inf')`
+
+// cleanupScript: KEYS = index. Hits and counters expire on their own;
+// only the index keeps members of expired counters.
+const cleanupScript = `
+local t = redis.call('TIME')
+return redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', t[1] .. '000')`
+
+func hitsKey(key string) string   { return rateLimitPrefix + "hits:" + key }
+func deniedKey(key string) string { return rateLimitPrefix + "denied:" + key }
+
+const deniedIndexKey = rateLimitPrefix + "denied"
+
+// Allow implements middleware.RateLimitStore.
+func (s *RateLimitStore) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, error) {
+	v, err := s.client.Do(ctx, "EVAL", allowScript, "3", hitsKey(key), deniedKey(key), deniedIndexKey,
+		strconv.Itoa(limit), strconv.FormatInt(window.Milliseconds(), 10), uuid.NewString(), key)
+	if err != nil {
+		return false, fmt.Errorf("Allow: %w", err)
+	}
+	return v == int64(1), nil
+}
+
+// Count implements middleware.RateLimitStore.
+func (s *RateLimitStore) Count(ctx context.Context, key string, window time.Duration) (int, error) {
+	v, err := s.client.Do(ctx, "EVAL", countScript, "1", hitsKey(key), strconv.FormatInt(window.Milliseconds(), 10))
+	if err != nil {
+		return 0, fmt.Errorf("Count: %w", err)
+	}
+	n, ok := v.(int64)
+	if !ok {
+		return 0, fmt.Errorf("Count: unexpected reply %T", v)
+	}
+	return int(n), nil
+}
+
+// Reset deletes key's window and denial counters.
+func (s *RateLimitStore) Reset(ctx context.Context, key string) error {
+	replies, err := s.client.Pipeline(ctx,
+		[]string{"DEL", hitsKey(key), deniedKey(key)},
+		[]string{"ZREM", deniedIndexKey, key},
+	)
+	if err != nil {
+		return fmt.Errorf("Reset: %w", err)
+	}
+	for _, r := range replies {
+		if err, ok := r.(error); ok {
+			return fmt.Errorf("Reset: %w", err)
+		}
+	}
+	return nil
+}
+
+// Cleanup drops index entries whose denial counters have expired.
+func (s *RateLimitStore) Cleanup(ctx context.Context) error {
+	if _, err := s.client.Do(ctx, "EVAL", cleanupScript, "1", deniedIndexKey); err != nil {
+		return fmt.Errorf("Cleanup: %w", err)
+	}
+	return nil
+}
+
+// topDeniedBatch bounds one TopDenied pipeline.
+const topDeniedBatch = 500
+
+// TopDenied ranks keys under prefix by denials inside the window. As with
+// the postgres store, the counters are per minute, so the window is
+// rounded down to the minute.
+func (s *RateLimitStore) TopDenied(ctx context.Context, prefix string, window time.Duration, n int) ([]entity.RateLimitDenial, error) {
+	now, err := s.serverTime(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("TopDenied: %w", err)
+	}
+	v, err := s.client.Do(ctx, "ZRANGEBYSCORE", deniedIndexKey, strconv.FormatInt(now.UnixMilli(), 10), "+inf")
+	if err != nil {
+		return nil, fmt.Errorf("TopDenied: %w", err)
+	}
+	members, _ := v.([]any)
+	var keys []string
+	for _, m := range members {
+		if k, ok := m.([]byte); ok && strings.HasPrefix(string(k), prefix) {
+			keys = append(keys, string(k))
+		}
+	}
+
+	oldest := now.Add(-window).Unix() / 60
+	top := make([]entity.RateLimitDenial, 0)
+	for start := 0; start < len(keys); start += topDeniedBatch {
+		batch := keys[start:min(start+topDeniedBatch, len(keys))]
+		cmds := make([][]string, len(batch))
+		for i, k := range batch {
+			cmds[i] = []string{"HGETALL", deniedKey(k)}
+		}
+		replies, err := s.client.Pipeline(ctx, cmds...)
+		if err != nil {
+			return nil, fmt.Errorf("TopDenied: %w", err)
+		}
+		for i, r := range replies {
+			if denied := sumDenied(r, oldest); denied > 0 {
+				top = append(top, entity.RateLimitDenial{Key: batch[i], Denied: denied})
+			}
+		}
+	}
+
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Denied != top[j].Denied {
+			return top[i].Denied > top[j].Denied
+		}
+		return top[i].Key < top[j].Key
+	})
+	if n >= 0 && len(top) > n {
+		top = top[:n]
+	}
+	return top, nil
+}
+
+// sumDenied adds the counters of an HGETALL reply (minute, count, ...)
+// from minute oldest on.
+func sumDenied(reply any, oldest int64) int {
+	fields, _ := reply.([]any)
+	total := 0
+	for i := 0; i+1 < len(fields); i += 2 {
+		f, _ := fields[i].([]byte)
+		c, _ := fields[i+1].([]byte)
+		minute, err := strconv.ParseInt(string(f), 10, 64)
+		if err != nil || minute < oldest {
+			continue
+		}
+		if n, err := strconv.Atoi(string(c)); err == nil {
+			total += n
+		}
+	}
+	return total
+}
+
+// serverTime returns the Redis clock (TIME).
+func (s *RateLimitStore) serverTime(ctx context.Context) (time.Time, error) {
+	v, err := s.client.Do(ctx, "TIME")
+	if err != nil {
+		return time.Time{}, err
+	}
+	parts, _ := v.([]any)
+	if len(parts) != 2 {
+		return time.Time{}, errors.New("unexpected TIME reply")
+	}
+	secStr, _ := parts[0].([]byte)
+	usecStr, _ := parts[1].([]byte)
+	sec, err1 := strconv.ParseInt(string(secStr), 10, 64)
+	usec, err2 := strconv.ParseInt(string(usecStr), 10, 64)
+	if err1 != nil || err2 != nil {
+		return time.Time{}, errors.New("unexpected TIME reply")
+	}
+	return time.Unix(sec, usec*1000), nil
+}
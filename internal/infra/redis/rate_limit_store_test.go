@@ -0,0 +1,201 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+func bulk(s string) string { return "$" + strconv.Itoa(len(s)) + "\r\n" + s + "\r\n" }
+
+func TestRateLimitStore_AllowAndCount(t *testing.T) {
+	var mu sync.Mutex
+	var evals [][]string
+	f := newFakeServer(t, func(args []string) string {
+		mu.Lock()
+		defer mu.Unlock()
+		evals = append(evals, args)
+		if len(evals) == 1 {
+			return ":1\r\n"
+		}
+		if args[3] == hitsKey("auth:192.0.2.1") && len(args) == 5 {
+			return ":3\r\n"
+		}
+		return ":0\r\n"
+	})
+	c, err := New("redis://" + f.ln.Addr().String())
+	require.NoError(t, err)
+	s := NewRateLimitStore(c)
+	ctx := context.Background()
+
+	ok, err := s.Allow(ctx, "auth:192.0.2.1", 5, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = s.Allow(ctx, "auth:192.0.2.1", 5, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	n, err := s.Count(ctx, "auth:192.0.2.1", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	mu.Lock()
+	defer mu.Unlock()
+	first := evals[0]
+	assert.Equal(t, []string{"EVAL", allowScript, "3",
+		"catchup-feed:ratelimit:hits:auth:192.0.2.1",
+		"catchup-feed:ratelimit:denied:auth:192.0.2.1",
+		"catchup-feed:ratelimit:denied",
+		"5", "60000"}, first[:8])
+	assert.Equal(t, "auth:192.0.2.1", first[9])
+	assert.NotEqual(t, first[8], evals[1][8], "each hit is a distinct member")
+}
+
+func TestRateLimitStore_TopDenied(t *testing.T) {
+	const now = 1760500000 // seconds; minute 29341666 starts at 1760499960
+	minute := func(offset int64) string { return strconv.FormatInt(now/60+offset, 10) }
+	f := newFakeServer(t, func(args []string) string {
+		switch args[0] {
+		case "TIME":
+			return "*2\r\n" + bulk(strconv.Itoa(now)) + bulk("250000")
+		case "ZRANGEBYSCORE":
+			if args[2] != "1760500000250" {
+				return "-ERR unexpected min " + args[2] + "\r\n"
+			}
+			return "*3\r\n" + bulk("auth:a") + bulk("search:b") + bulk("auth:c")
+		case "HGETALL":
+			switch strings.TrimPrefix(args[1], rateLimitPrefix+"denied:") {
+			case "auth:a":
+				return "*4\r\n" + bulk(minute(0)) + bulk("2") + bulk(minute(-5)) + bulk("40")
+			case "auth:c":
+				return "*4\r\n" + bulk(minute(0)) + bulk("3") + bulk(minute(-1)) + bulk("4")
+			}
+			return "*0\r\n"
+		}
+		return "-ERR unexpected " + args[0] + "\r\n"
+	})
+	c, err := New("redis://" + f.ln.Addr().String())
+	require.NoError(t, err)
+	s := NewRateLimitStore(c)
+
+	top, err := s.TopDenied(context.Background(), "auth:", time.Minute, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []entity.RateLimitDenial{{Key: "auth:c", Denied: 7}, {Key: "auth:a", Denied: 2}}, top,
+		"counters older than the window's first minute are ignored")
+
+	top, err = s.TopDenied(context.Background(), "auth:", time.Hour, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []entity.RateLimitDenial{{Key: "auth:a", Denied: 42}}, top)
+}
+
+func TestRateLimitStore_Reset(t *testing.T) {
+	var mu sync.Mutex
+	var commands []string
+	f := newFakeServer(t, func(args []string) string {
+		mu.Lock()
+		commands = append(commands, strings.Join(args, " "))
+		mu.Unlock()
+		return ":1\r\n"
+	})
+	c, err := New("redis://" + f.ln.Addr().String())
+	require.NoError(t, err)
+
+	require.NoError(t, NewRateLimitStore(c).Reset(context.Background(), "feed:198.51.100.7"))
+	assert.Equal(t, []string{
+		"DEL catchup-feed:ratelimit:hits:feed:198.51.100.7 catchup-feed:ratelimit:denied:feed:198.51.100.7",
+		"ZREM catchup-feed:ratelimit:denied feed:198.51.100.7",
+	}, commands)
+}
+
+func TestRateLimitStore_Unreachable(t *testing.T) {
+	c, err := New("redis://127.0.0.1:1")
+	require.NoError(t, err)
+	_, err = NewRateLimitStore(c).Allow(context.Background(), "k", 1, time.Minute)
+	assert.Error(t, err, "the limiter lets the request through on a store error")
+}
+
+// TestRateLimitStore_RealRedis runs the Lua scripts on TEST_REDIS_URL.
+func TestRateLimitStore_RealRedis(t *testing.T) {
+	c := openTestClient(t)
+	s := NewRateLimitStore(c)
+	ctx := context.Background()
+	scope := "test" + strconv.FormatInt(time.Now().UnixNano(), 36) + ":"
+	a, b := scope+"192.0.2.1", scope+"192.0.2.2"
+	t.Cleanup(func() {
+		_ = s.Reset(context.Background(), a)
+		_ = s.Reset(context.Background(), b)
+	})
+
+	for i := range 5 {
+		ok, err := s.Allow(ctx, a, 3, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, i < 3, ok, "request %d", i)
+	}
+	ok, err := s.Allow(ctx, b, 3, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok, "keys have separate windows")
+
+	n, err := s.Count(ctx, a, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n, "denied requests do not count against the window")
+
+	top, err := s.TopDenied(ctx, scope, time.Minute, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []entity.RateLimitDenial{{Key: a, Denied: 2}}, top)
+
+	require.NoError(t, s.Cleanup(ctx))
+	top, err = s.TopDenied(ctx, scope, time.Minute, 10)
+	require.NoError(t, err)
+	assert.Len(t, top, 1, "Cleanup keeps live counters")
+
+	require.NoError(t, s.Reset(ctx, a))
+	ok, err = s.Allow(ctx, a, 3, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok, "Reset lifts the limit at once")
+	top, err = s.TopDenied(ctx, scope, time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, top)
+
+	// The window slides: hits older than it no longer count.
+	for range 2 {
+		ok, err := s.Allow(ctx, b, 2, 200*time.Millisecond)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+	ok, err = s.Allow(ctx, b, 2, 200*time.Millisecond)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	time.Sleep(250 * time.Millisecond)
+	ok, err = s.Allow(ctx, b, 2, 200*time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// Concurrent requests never admit more than the limit.
+	c2 := scope + "burst"
+	t.Cleanup(func() { _ = s.Reset(context.Background(), c2) })
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allowed := 0
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := s.Allow(ctx, c2, 10, time.Minute)
+			assert.NoError(t, err)
+			if ok {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 10, allowed)
+}
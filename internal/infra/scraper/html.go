@@ -0,0 +1,344 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/andybalholm/cascadia"
+	"golang.org/x/net/html"
+	"gopkg.in/yaml.v3"
+
+	"catchup-feed/internal/infra/fetcher"
+	"catchup-feed/internal/usecase/fetch"
+)
+
+// maxHTMLPageSize caps the listing page read by an HTML scraper.
+const maxHTMLPageSize = 10 << 20
+
+// defaultDateLayouts are tried in order when a definition sets no
+// date_layout.
+var defaultDateLayouts = []string{
+	time.RFC3339,
+	time.RFC1123Z,
+	time.RFC1123,
+	"2006-01-02",
+	"2006/01/02",
+	"2006.01.02",
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"2 January 2006",
+	"2006年1月2日",
+}
+
+// HTMLScraperConfig is the YAML file (SCRAPER_CONFIG) declaring the sites
+// without a usable feed that are scraped from their listing page instead.
+//
+//	scrapers:
+//	  - name: example-blog
+//	    url_pattern: '^https://example\.com/blog/?$'
+//	    item: article.post
+//	    title: h2
+//	    link: h2 a          # href; empty = the item element itself
+//	    date: time          # datetime attribute, else the text
+//	    date_layout: "2006-01-02"
+//	    content: .excerpt   # optional; empty leaves it to content enhancement
+type HTMLScraperConfig struct {
+	Scrapers []HTMLScraperDef `yaml:"scrapers"`
+}
+
+// HTMLScraperDef declares one scraped site. A source whose feed URL
+// matches URLPattern is fetched as an HTML page: every Item element
+// becomes a feed item, with the other selectors evaluated inside it.
+type HTMLScraperDef struct {
+	Name       string `yaml:"name"`
+	URLPattern string `yaml:"url_pattern"`
+	Item       string `yaml:"item"`
+	Title      string `yaml:"title"`
+	Link       string `yaml:"link"`
+	Date       string `yaml:"date"`
+	DateLayout string `yaml:"date_layout"`
+	Content    string `yaml:"content"`
+}
+
+// htmlScraper is a compiled HTMLScraperDef.
+type htmlScraper struct {
+	name       string
+	pattern    *regexp.Regexp
+	item       cascadia.Matcher
+	title      cascadia.Matcher
+	link       cascadia.Matcher // nil = the item element
+	date       cascadia.Matcher // nil = no date
+	dateLayout string
+	content    cascadia.Matcher // nil = no content
+}
+
+// LoadHTMLScrapers reads and validates the scraper definitions at path.
+// The path comes from the SCRAPER_CONFIG environment variable.
+func LoadHTMLScrapers(path string) ([]HTMLScraperDef, error) {
+	// #nosec G304 -- path is provided by trusted source (environment), not user input
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scraper config: %w", err)
+	}
+	var cfg HTMLScraperConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse scraper config: %w", err)
+	}
+	for _, def := range cfg.Scrapers {
+		if _, err := compileHTMLScraper(def); err != nil {
+			return nil, err
+		}
+	}
+	return cfg.Scrapers, nil
+}
+
+func compileHTMLScraper(def HTMLScraperDef) (*htmlScraper, error) {
+	if def.Name == "" {
+		return nil, errors.New("scraper: name is required")
+	}
+	if def.URLPattern == "" || def.Item == "" || def.Title == "" {
+		return nil, fmt.Errorf("scraper %q: url_pattern, item and title are required", def.Name)
+	}
+	s := &htmlScraper{name: def.Name, dateLayout: def.DateLayout}
+	var err error
+	if s.pattern, err = regexp.Compile(def.URLPattern); err != nil {
+		return nil, fmt.Errorf("scraper %q: invalid url_pattern: %w", def.Name, err)
+	}
+	selectors := []struct {
+		field string
+		expr  string
+		dst   *cascadia.Matcher
+	}{
+		{"item", def.Item, &s.item},
+		{"title", def.Title, &s.title},
+		{"link", def.Link, &s.link},
+		{"date", def.Date, &s.date},
+		{"content", def.Content, &s.content},
+	}
+	for _, sel := range selectors {
+		if sel.expr == "" {
+			continue
+		}
+		if *sel.dst, err = cascadia.ParseGroup(sel.expr); err != nil {
+			return nil, fmt.Errorf("scraper %q: invalid %s selector: %w", def.Name, sel.field, err)
+		}
+	}
+	return s, nil
+}
+
+// HTMLFetcher is the crawl's FeedFetcher when scraper definitions are
+// configured: feed URLs matching a definition are scraped from HTML, every
+// other URL goes to the RSS fetcher unchanged. Both paths send the same
+// conditional GET validators.
+type HTMLFetcher struct {
+	client   *http.Client
+	rss      *RSSFetcher
+	scrapers []*htmlScraper
+}
+
+// NewHTMLFetcher compiles defs (first match wins) in front of rss. client
+// should be the RSS fetcher's client (SSRF redirect hook included).
+func NewHTMLFetcher(client *http.Client, rss *RSSFetcher, defs []HTMLScraperDef) (*HTMLFetcher, error) {
+	f := &HTMLFetcher{client: client, rss: rss}
+	for _, def := range defs {
+		s, err := compileHTMLScraper(def)
+		if err != nil {
+			return nil, err
+		}
+		f.scrapers = append(f.scrapers, s)
+	}
+	return f, nil
+}
+
+// Fetch scrapes or parses feedURL.
+func (f *HTMLFetcher) Fetch(ctx context.Context, feedURL string) ([]fetch.FeedItem, error) {
+	resp, err := f.FetchConditional(ctx, feedURL, fetch.FeedValidators{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// FetchConditional is Fetch with HTTP conditional GET (see
+// RSSFetcher.FetchConditional).
+func (f *HTMLFetcher) FetchConditional(ctx context.Context, feedURL string, prev fetch.FeedValidators) (*fetch.FeedResponse, error) {
+	s := f.match(feedURL)
+	if s == nil {
+		return f.rss.FetchConditional(ctx, feedURL, prev)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", fetcher.UserAgent)
+	if prev.ETag != "" {
+		req.Header.Set("If-None-Match", prev.ETag)
+	}
+	if prev.LastModified != "" {
+		req.Header.Set("If-Modified-Since", prev.LastModified)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return &fetch.FeedResponse{NotModified: true, Validators: prev}, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &fetch.FeedStatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	doc, err := html.Parse(io.LimitReader(resp.Body, maxHTMLPageSize))
+	if err != nil {
+		return nil, fmt.Errorf("%w: scraper %q: %v", fetch.ErrInvalidFeedFormat, s.name, err)
+	}
+	base := resp.Request.URL // after redirects
+	return &fetch.FeedResponse{
+		Items: s.scrape(doc, base),
+		Validators: fetch.FeedValidators{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+		},
+	}, nil
+}
+
+func (f *HTMLFetcher) match(feedURL string) *htmlScraper {
+	for _, s := range f.scrapers {
+		if s.pattern.MatchString(feedURL) {
+			return s
+		}
+	}
+	return nil
+}
+
+// scrape extracts the feed items of a listing page. Items without a
+// title or a link are dropped; an unparsable date falls back to now, like
+// an RSS item without pubDate (toFeedItems).
+func (s *htmlScraper) scrape(doc *html.Node, base *url.URL) []fetch.FeedItem {
+	nodes := cascadia.QueryAll(doc, s.item)
+	items := make([]fetch.FeedItem, 0, len(nodes))
+	for _, n := range nodes {
+		title := nodeText(cascadia.Query(n, s.title))
+		link := s.itemLink(n, base)
+		if title == "" || link == "" {
+			continue
+		}
+		item := fetch.FeedItem{Title: title, URL: link, PublishedAt: time.Now()}
+		if s.date != nil {
+			if at, ok := s.parseDate(cascadia.Query(n, s.date)); ok {
+				item.PublishedAt = at
+			}
+		}
+		if s.content != nil {
+			item.Content = nodeText(cascadia.Query(n, s.content))
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// itemLink resolves the href of the link element (or of the item itself)
+// against the page URL.
+func (s *htmlScraper) itemLink(item *html.Node, base *url.URL) string {
+	n := item
+	if s.link != nil {
+		n = cascadia.Query(item, s.link)
+	}
+	href := strings.TrimSpace(nodeAttr(n, "href"))
+	if href == "" {
+		return ""
+	}
+	u, err := base.Parse(href)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+	return u.String()
+}
+
+// parseDate reads the datetime attribute (<time>) or the text of n.
+func (s *htmlScraper) parseDate(n *html.Node) (time.Time, bool) {
+	value := strings.TrimSpace(nodeAttr(n, "datetime"))
+	if value == "" {
+		value = nodeText(n)
+	}
+	if value == "" {
+		return time.Time{}, false
+	}
+	layouts := defaultDateLayouts
+	if s.dateLayout != "" {
+		layouts = []string{s.dateLayout}
+	}
+	for _, layout := range layouts {
+		if at, err := time.Parse(layout, value); err == nil {
+			return at, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func nodeAttr(n *html.Node, key string) string {
+	if n == nil {
+		return ""
+	}
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// nodeText returns the whitespace-collapsed text content of n.
+func nodeText(n *html.Node) string {
+	if n == nil {
+		return ""
+	}
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+			b.WriteByte(' ')
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// NewFeedFetcherFromEnv returns the crawl's FeedFetcher: the RSS fetcher,
+// fronted by an HTMLFetcher when SCRAPER_CONFIG names a scraper
+// definition file. An unreadable or invalid file is an error — silently
+// crawling the configured sites as RSS would only fail on every run.
+func NewFeedFetcherFromEnv(client *http.Client, logger *slog.Logger) (fetch.FeedFetcher, error) {
+	rss := NewRSSFetcher(client)
+	path := os.Getenv("SCRAPER_CONFIG")
+	if path == "" {
+		return rss, nil
+	}
+	defs, err := LoadHTMLScrapers(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := NewHTMLFetcher(client, rss, defs)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("HTML scrapers loaded", slog.String("path", path), slog.Int("scrapers", len(defs)))
+	return f, nil
+}
@@ -0,0 +1,200 @@
+package scraper_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/infra/scraper"
+	"catchup-feed/internal/usecase/fetch"
+)
+
+const listingPage = `<!doctype html>
+<html><body>
+  <article class="post">
+    <h2><a href="/blog/first">First  post</a></h2>
+    <time datetime="2025-03-14T09:00:00Z">March 14</time>
+    <p class="excerpt">Hello <b>world</b></p>
+  </article>
+  <article class="post">
+    <h2><a href="https://other.example.com/second">Second post</a></h2>
+    <span class="date">2025-03-10</span>
+  </article>
+  <article class="post">
+    <h2>No link</h2>
+  </article>
+  <article class="post">
+    <h2><a href="javascript:alert(1)">Script link</a></h2>
+  </article>
+</body></html>`
+
+func blogDef(pattern string) scraper.HTMLScraperDef {
+	return scraper.HTMLScraperDef{
+		Name:       "example-blog",
+		URLPattern: pattern,
+		Item:       "article.post",
+		Title:      "h2",
+		Link:       "h2 a",
+		Date:       "time, .date",
+		Content:    ".excerpt",
+	}
+}
+
+func TestHTMLFetcher_FetchScrapesMatchingURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(listingPage))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	f, err := scraper.NewHTMLFetcher(client, scraper.NewRSSFetcher(client),
+		[]scraper.HTMLScraperDef{blogDef("^" + regexp.QuoteMeta(server.URL) + "/blog$")})
+	require.NoError(t, err)
+
+	resp, err := f.FetchConditional(context.Background(), server.URL+"/blog", fetch.FeedValidators{})
+	require.NoError(t, err)
+	assert.Equal(t, `"v1"`, resp.Validators.ETag)
+
+	require.Len(t, resp.Items, 2, "items without a usable http(s) link are dropped")
+	first := resp.Items[0]
+	assert.Equal(t, "First post", first.Title)
+	assert.Equal(t, server.URL+"/blog/first", first.URL, "relative links resolve against the page")
+	assert.Equal(t, time.Date(2025, 3, 14, 9, 0, 0, 0, time.UTC), first.PublishedAt)
+	assert.Equal(t, "Hello world", first.Content)
+
+	second := resp.Items[1]
+	assert.Equal(t, "https://other.example.com/second", second.URL)
+	assert.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), second.PublishedAt)
+	assert.Empty(t, second.Content)
+}
+
+func TestHTMLFetcher_NonMatchingURLUsesRSS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>t</title>
+<item><title>Feed item</title><link>https://example.com/a</link></item></channel></rss>`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	f, err := scraper.NewHTMLFetcher(client, scraper.NewRSSFetcher(client),
+		[]scraper.HTMLScraperDef{blogDef(`^https://blog\.example\.com/`)})
+	require.NoError(t, err)
+
+	items, err := f.Fetch(context.Background(), server.URL+"/feed.xml")
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Feed item", items[0].Title)
+}
+
+func TestHTMLFetcher_StatusAndNotModified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	f, err := scraper.NewHTMLFetcher(client, scraper.NewRSSFetcher(client),
+		[]scraper.HTMLScraperDef{blogDef("^" + regexp.QuoteMeta(server.URL))})
+	require.NoError(t, err)
+
+	resp, err := f.FetchConditional(context.Background(), server.URL, fetch.FeedValidators{ETag: `"v1"`})
+	require.NoError(t, err)
+	assert.True(t, resp.NotModified)
+
+	_, err = f.Fetch(context.Background(), server.URL)
+	var statusErr *fetch.FeedStatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusForbidden, statusErr.StatusCode)
+}
+
+func TestLoadHTMLScrapers(t *testing.T) {
+	write := func(t *testing.T, content string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "scrapers.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		defs, err := scraper.LoadHTMLScrapers(write(t, `
+scrapers:
+  - name: example-blog
+    url_pattern: '^https://example\.com/blog/?$'
+    item: article.post
+    title: h2
+    link: h2 a
+    date: time
+    date_layout: "2006-01-02"
+`))
+		require.NoError(t, err)
+		require.Len(t, defs, 1)
+		assert.Equal(t, "example-blog", defs[0].Name)
+		assert.Equal(t, "2006-01-02", defs[0].DateLayout)
+	})
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"missing name", "scrapers:\n  - url_pattern: x\n    item: a\n    title: b\n", "name is required"},
+		{"missing item", "scrapers:\n  - name: s\n    url_pattern: x\n    title: b\n", "are required"},
+		{"bad pattern", "scrapers:\n  - name: s\n    url_pattern: '('\n    item: a\n    title: b\n", "invalid url_pattern"},
+		{"bad selector", "scrapers:\n  - name: s\n    url_pattern: x\n    item: 'a[['\n    title: b\n", "invalid item selector"},
+		{"bad yaml", "scrapers: [", "failed to parse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := scraper.LoadHTMLScrapers(write(t, tt.content))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := scraper.LoadHTMLScrapers(filepath.Join(t.TempDir(), "none.yaml"))
+		assert.ErrorContains(t, err, "failed to read")
+	})
+}
+
+func TestNewFeedFetcherFromEnv(t *testing.T) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	logger := slog.New(slog.DiscardHandler)
+
+	t.Run("unset: plain RSS fetcher", func(t *testing.T) {
+		t.Setenv("SCRAPER_CONFIG", "")
+		f, err := scraper.NewFeedFetcherFromEnv(client, logger)
+		require.NoError(t, err)
+		assert.IsType(t, &scraper.RSSFetcher{}, f)
+	})
+
+	t.Run("set: HTML fetcher", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "scrapers.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("scrapers:\n  - name: s\n    url_pattern: x\n    item: a\n    title: b\n"), 0o600))
+		t.Setenv("SCRAPER_CONFIG", path)
+		f, err := scraper.NewFeedFetcherFromEnv(client, logger)
+		require.NoError(t, err)
+		assert.IsType(t, &scraper.HTMLFetcher{}, f)
+	})
+
+	t.Run("invalid file is an error", func(t *testing.T) {
+		t.Setenv("SCRAPER_CONFIG", filepath.Join(t.TempDir(), "missing.yaml"))
+		_, err := scraper.NewFeedFetcherFromEnv(client, logger)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,67 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"catchup-feed/internal/infra/fetcher"
+	"catchup-feed/internal/usecase/fetch"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// FeedPreviewer fetches and parses a feed URL supplied by an API user so it
+// can be checked before a source is created. Unlike the worker's crawl, the
+// URL comes straight from the request, so the entry point is validated for
+// SSRF (fetcher.ValidateURL) as well as every redirect hop (the client's
+// fetcher.SSRFCheckRedirect hook).
+type FeedPreviewer struct {
+	client         *http.Client
+	denyPrivateIPs bool
+}
+
+// NewFeedPreviewer creates a FeedPreviewer. client should carry the same
+// SSRF redirect hook as the worker's feed-fetch client.
+func NewFeedPreviewer(client *http.Client, denyPrivateIPs bool) *FeedPreviewer {
+	return &FeedPreviewer{client: client, denyPrivateIPs: denyPrivateIPs}
+}
+
+// Preview fetches feedURL and returns its format, metadata and up to
+// maxItems entries. A response that is not RSS, Atom or JSON Feed wraps
+// fetch.ErrInvalidFeedFormat; a non-2xx response is a *fetch.FeedStatusError.
+func (p *FeedPreviewer) Preview(ctx context.Context, feedURL string, maxItems int) (*fetch.FeedPreview, error) {
+	if err := fetcher.ValidateURL(feedURL, p.denyPrivateIPs); err != nil {
+		return nil, err
+	}
+
+	fp := gofeed.NewParser()
+	fp.UserAgent = fetcher.UserAgent
+	fp.Client = p.client
+
+	feed, err := fp.ParseURLWithContext(feedURL, ctx)
+	if err != nil {
+		var httpErr gofeed.HTTPError
+		if errors.As(err, &httpErr) {
+			return nil, &fetch.FeedStatusError{StatusCode: httpErr.StatusCode, Status: httpErr.Status}
+		}
+		if errors.Is(err, gofeed.ErrFeedTypeNotDetected) {
+			return nil, fmt.Errorf("%w: %v", fetch.ErrInvalidFeedFormat, err)
+		}
+		return nil, err
+	}
+
+	feedItems := feed.Items
+	if maxItems >= 0 && len(feedItems) > maxItems {
+		feedItems = feedItems[:maxItems]
+	}
+	return &fetch.FeedPreview{
+		Type:        feed.FeedType,
+		Title:       feed.Title,
+		Description: feed.Description,
+		SiteURL:     feed.Link,
+		ItemCount:   len(feed.Items),
+		Items:       toFeedItems(feedItems),
+	}, nil
+}
@@ -0,0 +1,81 @@
+package scraper_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"catchup-feed/internal/infra/scraper"
+	"catchup-feed/internal/usecase/fetch"
+)
+
+const previewAtom = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>The Go Blog</title>
+  <link href="https://go.dev/blog"/>
+  <entry><title>One</title><link href="https://go.dev/blog/one"/><updated>2026-01-03T00:00:00Z</updated></entry>
+  <entry><title>Two</title><link href="https://go.dev/blog/two"/><updated>2026-01-02T00:00:00Z</updated></entry>
+  <entry><title>Three</title><link href="https://go.dev/blog/three"/><updated>2026-01-01T00:00:00Z</updated></entry>
+</feed>`
+
+func TestFeedPreviewer_Preview(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/feed":
+			w.Header().Set("Content-Type", "application/atom+xml")
+			_, _ = w.Write([]byte(previewAtom))
+		case "/page":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte("<!doctype html><html><body>blog</body></html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	// httptest はループバックなのでプライベート IP 拒否は切っておく
+	previewer := scraper.NewFeedPreviewer(&http.Client{Timeout: 5 * time.Second}, false)
+
+	t.Run("atom feed", func(t *testing.T) {
+		preview, err := previewer.Preview(context.Background(), server.URL+"/feed", 2)
+		if err != nil {
+			t.Fatalf("Preview() error = %v", err)
+		}
+		if preview.Type != "atom" || preview.Title != "The Go Blog" || preview.SiteURL != "https://go.dev/blog" {
+			t.Errorf("preview = %+v", preview)
+		}
+		if preview.ItemCount != 3 || len(preview.Items) != 2 {
+			t.Fatalf("ItemCount = %d, len(Items) = %d, want 3 and 2", preview.ItemCount, len(preview.Items))
+		}
+		if preview.Items[0].URL != "https://go.dev/blog/one" {
+			t.Errorf("Items[0].URL = %q", preview.Items[0].URL)
+		}
+	})
+
+	t.Run("html page is not a feed", func(t *testing.T) {
+		_, err := previewer.Preview(context.Background(), server.URL+"/page", 2)
+		if !errors.Is(err, fetch.ErrInvalidFeedFormat) {
+			t.Fatalf("err = %v, want ErrInvalidFeedFormat", err)
+		}
+	})
+
+	t.Run("non-2xx keeps the status", func(t *testing.T) {
+		_, err := previewer.Preview(context.Background(), server.URL+"/missing", 2)
+		var statusErr *fetch.FeedStatusError
+		if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+			t.Fatalf("err = %v, want FeedStatusError 404", err)
+		}
+	})
+}
+
+func TestFeedPreviewer_Preview_PrivateIPBlocked(t *testing.T) {
+	previewer := scraper.NewFeedPreviewer(&http.Client{Timeout: 5 * time.Second}, true)
+
+	_, err := previewer.Preview(context.Background(), "http://127.0.0.1:6379/feed", 5)
+	if !errors.Is(err, fetch.ErrPrivateIP) {
+		t.Fatalf("err = %v, want ErrPrivateIP", err)
+	}
+}
@@ -1,5 +1,6 @@
 // Package scraper provides implementations for fetching RSS/Atom feeds.
-// It uses the gofeed library to parse feed content.
+// It uses the gofeed library to parse feed content, and CSS-selector
+// scrapers declared in SCRAPER_CONFIG for sites without a feed.
 package scraper
 
 import (
@@ -30,22 +31,68 @@ func NewRSSFetcher(client *http.Client) *RSSFetcher {
 // Failures are returned as-is; the hourly cron simply retries on the next run.
 // Returns a slice of FeedItem containing the parsed feed entries.
 func (f *RSSFetcher) Fetch(ctx context.Context, feedURL string) ([]fetch.FeedItem, error) {
-	return f.doFetch(ctx, feedURL)
+	resp, err := f.doFetch(ctx, feedURL, fetch.FeedValidators{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// FetchConditional is Fetch with HTTP conditional GET: prev is sent as
+// If-None-Match / If-Modified-Since, and a 304 answer returns NotModified
+// without reading a body. The response validators (ETag / Last-Modified
+// headers) are returned for the caller to store.
+func (f *RSSFetcher) FetchConditional(ctx context.Context, feedURL string, prev fetch.FeedValidators) (*fetch.FeedResponse, error) {
+	return f.doFetch(ctx, feedURL, prev)
 }
 
 // doFetch performs the actual feed fetch without retry or circuit breaker.
-func (f *RSSFetcher) doFetch(ctx context.Context, feedURL string) ([]fetch.FeedItem, error) {
-	fp := gofeed.NewParser()
-	fp.UserAgent = fetcher.UserAgent
-	fp.Client = f.client
+// The request mirrors gofeed's ParseURLWithContext, plus the validators.
+func (f *RSSFetcher) doFetch(ctx context.Context, feedURL string, prev fetch.FeedValidators) (*fetch.FeedResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", fetcher.UserAgent)
+	if prev.ETag != "" {
+		req.Header.Set("If-None-Match", prev.ETag)
+	}
+	if prev.LastModified != "" {
+		req.Header.Set("If-Modified-Since", prev.LastModified)
+	}
 
-	feed, err := fp.ParseURLWithContext(feedURL, ctx)
+	resp, err := f.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
+	defer func() { _ = resp.Body.Close() }()
 
-	items := make([]fetch.FeedItem, 0, len(feed.Items))
-	for _, it := range feed.Items {
+	if resp.StatusCode == http.StatusNotModified {
+		return &fetch.FeedResponse{NotModified: true, Validators: prev}, nil
+	}
+	// 非 2xx はステータスを残す(ソース単位のクロール統計用)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &fetch.FeedStatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	feed, err := gofeed.NewParser().Parse(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fetch.FeedResponse{
+		Items: toFeedItems(feed.Items),
+		Validators: fetch.FeedValidators{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+		},
+	}, nil
+}
+
+// toFeedItems converts parsed gofeed entries to FeedItems.
+func toFeedItems(feedItems []*gofeed.Item) []fetch.FeedItem {
+	items := make([]fetch.FeedItem, 0, len(feedItems))
+	for _, it := range feedItems {
 		pubAt := time.Now()
 		if it.PublishedParsed != nil {
 			pubAt = *it.PublishedParsed
@@ -65,8 +112,7 @@ func (f *RSSFetcher) doFetch(ctx context.Context, feedURL string) ([]fetch.FeedI
 			EnclosureURL: enclosureURL(it.Enclosures),
 		})
 	}
-
-	return items, nil
+	return items
 }
 
 // enclosureURL picks the media URL from the item enclosures (Phase 2 §5.2:
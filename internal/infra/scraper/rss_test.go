@@ -2,6 +2,7 @@ package scraper_test
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -9,6 +10,7 @@ import (
 
 	"catchup-feed/internal/infra/fetcher"
 	"catchup-feed/internal/infra/scraper"
+	"catchup-feed/internal/usecase/fetch"
 )
 
 func TestRSSFetcher_Fetch_Success(t *testing.T) {
@@ -338,3 +340,100 @@ func TestRSSFetcher_Fetch_SendsSharedUserAgent(t *testing.T) {
 		t.Errorf("User-Agent = %q, want %q", ua, fetcher.UserAgent)
 	}
 }
+
+func TestRSSFetcher_Fetch_HTTPStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "gone", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	fetcher := scraper.NewRSSFetcher(&http.Client{Timeout: 10 * time.Second})
+
+	_, err := fetcher.Fetch(context.Background(), server.URL)
+	var statusErr *fetch.FeedStatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("Fetch() error = %v, want *fetch.FeedStatusError", err)
+	}
+	if statusErr.StatusCode != http.StatusNotFound {
+		t.Errorf("StatusCode = %d, want %d", statusErr.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestRSSFetcher_FetchConditional(t *testing.T) {
+	const rss = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0"><channel><title>T</title>
+<item><title>Article 1</title><link>https://example.com/article1</link></item>
+</channel></rss>`
+	const etag = `"v1"`
+	const lastModified = "Mon, 01 Jan 2024 00:00:00 GMT"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", lastModified)
+		_, _ = w.Write([]byte(rss))
+	}))
+	defer server.Close()
+
+	fetcher := scraper.NewRSSFetcher(&http.Client{Timeout: 10 * time.Second})
+
+	// 初回は無条件 GET。検証子を受け取る
+	resp, err := fetcher.FetchConditional(context.Background(), server.URL, fetch.FeedValidators{})
+	if err != nil {
+		t.Fatalf("FetchConditional() error = %v", err)
+	}
+	if resp.NotModified || len(resp.Items) != 1 {
+		t.Fatalf("resp = %+v, want 1 item", resp)
+	}
+	want := fetch.FeedValidators{ETag: etag, LastModified: lastModified}
+	if resp.Validators != want {
+		t.Errorf("Validators = %+v, want %+v", resp.Validators, want)
+	}
+
+	// 2回目は If-None-Match を送り、304 で本文を読まない
+	resp, err = fetcher.FetchConditional(context.Background(), server.URL, want)
+	if err != nil {
+		t.Fatalf("FetchConditional() error = %v", err)
+	}
+	if !resp.NotModified || len(resp.Items) != 0 {
+		t.Fatalf("resp = %+v, want NotModified", resp)
+	}
+	if resp.Validators != want {
+		t.Errorf("Validators = %+v, want the ones sent", resp.Validators)
+	}
+}
+
+func TestRSSFetcher_FetchConditional_SendsValidators(t *testing.T) {
+	var gotETag, gotSince string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotETag = r.Header.Get("If-None-Match")
+		gotSince = r.Header.Get("If-Modified-Since")
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer server.Close()
+
+	fetcher := scraper.NewRSSFetcher(&http.Client{Timeout: 10 * time.Second})
+
+	_, err := fetcher.FetchConditional(context.Background(), server.URL,
+		fetch.FeedValidators{ETag: `W/"abc"`, LastModified: "Tue, 02 Jan 2024 00:00:00 GMT"})
+	if err != nil {
+		t.Fatalf("FetchConditional() error = %v", err)
+	}
+	if gotETag != `W/"abc"` {
+		t.Errorf("If-None-Match = %q", gotETag)
+	}
+	if gotSince != "Tue, 02 Jan 2024 00:00:00 GMT" {
+		t.Errorf("If-Modified-Since = %q", gotSince)
+	}
+
+	// 検証子がなければ条件ヘッダーは送らない
+	if _, err := fetcher.Fetch(context.Background(), server.URL); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if gotETag != "" || gotSince != "" {
+		t.Errorf("unconditional fetch sent If-None-Match=%q If-Modified-Since=%q", gotETag, gotSince)
+	}
+}
@@ -0,0 +1,210 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// AWS environment variables (the names the AWS CLI / SDKs use). Only
+// static or session credentials from the environment are supported; an
+// instance / task role needs them exported first (e.g. aws-vault,
+// `aws configure export-credentials`).
+const (
+	EnvAWSRegion        = "AWS_REGION"
+	EnvAWSDefaultRegion = "AWS_DEFAULT_REGION"
+	EnvAWSAccessKeyID   = "AWS_ACCESS_KEY_ID"
+	EnvAWSSecretKey     = "AWS_SECRET_ACCESS_KEY"
+	EnvAWSSessionToken  = "AWS_SESSION_TOKEN"
+	// EnvAWSEndpoint overrides the service endpoint (LocalStack, VPC endpoint).
+	EnvAWSEndpoint = "AWS_ENDPOINT_URL_SECRETS_MANAGER"
+)
+
+const awsService = "secretsmanager"
+
+// AWSCredentials are the keys a request is signed with.
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// AWS reads secrets with the Secrets Manager GetSecretValue API.
+type AWS struct {
+	Region      string
+	Endpoint    string // default https://secretsmanager.<region>.amazonaws.com
+	Credentials AWSCredentials
+	HTTPClient  *http.Client
+	now         func() time.Time
+}
+
+// NewAWSFromEnv reads the region, credentials and optional endpoint.
+func NewAWSFromEnv(timeout time.Duration) *AWS {
+	region := os.Getenv(EnvAWSRegion)
+	if region == "" {
+		region = os.Getenv(EnvAWSDefaultRegion)
+	}
+	return &AWS{
+		Region:   region,
+		Endpoint: os.Getenv(EnvAWSEndpoint),
+		Credentials: AWSCredentials{
+			AccessKeyID:     os.Getenv(EnvAWSAccessKeyID),
+			SecretAccessKey: os.Getenv(EnvAWSSecretKey),
+			SessionToken:    os.Getenv(EnvAWSSessionToken),
+		},
+		HTTPClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Fetch reads the current version of secretID. A JSON object secret is
+// returned field by field; any other SecretString under the "" field.
+func (a *AWS) Fetch(ctx context.Context, secretID string) (map[string]string, error) {
+	if a.Region == "" || a.Credentials.AccessKeyID == "" || a.Credentials.SecretAccessKey == "" {
+		return nil, fmt.Errorf("%w: %s, %s and %s are required",
+			ErrNotConfigured, EnvAWSRegion, EnvAWSAccessKeyID, EnvAWSSecretKey)
+	}
+	endpoint := a.Endpoint
+	if endpoint == "" {
+		endpoint = "https://" + awsService + "." + a.Region + ".amazonaws.com"
+	}
+	body, err := json.Marshal(map[string]string{"SecretId": secretID})
+	if err != nil {
+		return nil, fmt.Errorf("awssm: encode request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(endpoint, "/")+"/", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("awssm: build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	now := time.Now
+	if a.now != nil {
+		now = a.now
+	}
+	SignV4(req, body, a.Credentials, a.Region, awsService, now().UTC())
+
+	resp, err := a.HTTPClient.Do(req) // #nosec G107 -- endpoint is AWS or operator configuration
+	if err != nil {
+		return nil, fmt.Errorf("awssm: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("awssm: read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("awssm: status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var out struct {
+		SecretString *string `json:"SecretString"`
+	}
+	if err := json.Unmarshal(respBody, &out); err != nil {
+		return nil, fmt.Errorf("awssm: decode response: %w", err)
+	}
+	if out.SecretString == nil {
+		return nil, fmt.Errorf("awssm: binary secrets are not supported")
+	}
+	fields := map[string]string{"": *out.SecretString}
+	var object map[string]any
+	if json.Unmarshal([]byte(*out.SecretString), &object) == nil {
+		for k, v := range object {
+			if s, ok := v.(string); ok {
+				fields[k] = s
+			}
+		}
+	}
+	return fields, nil
+}
+
+// SignV4 adds AWS Signature Version 4 headers to req. Every header already
+// set on req is signed, plus Host and X-Amz-Date. The objectstore
+// package's S3 client signs with it too.
+func SignV4(req *http.Request, body []byte, creds AWSCredentials, region, service string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.Join(v, ",")
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	slices.Sort(names)
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + strings.TrimSpace(headers[k]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+creds.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func canonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	var parts []string
+	for _, k := range keys {
+		values := slices.Clone(q[k])
+		slices.Sort(values)
+		for _, v := range values {
+			parts = append(parts, awsEscape(k)+"="+awsEscape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func awsEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,273 @@
+// Package secrets resolves environment variables that reference a secret
+// in HashiCorp Vault or AWS Secrets Manager, so JWT_SECRET, API keys and
+// webhook URLs need not be stored in plaintext. Like the oidc and
+// summarizer packages these are plain net/http clients; no vendor SDK.
+//
+// A reference replaces the value of any environment variable:
+//
+//	JWT_SECRET=vault://secret/catchup#jwt_secret      (KV v2: mount "secret", path "catchup")
+//	GEMINI_API_KEY=awssm://catchup/prod#GEMINI_API_KEY (JSON secret, one key)
+//	SLACK_WEBHOOK_URL=awssm://catchup/slack-webhook    (plain string secret)
+//
+// ResolveEnv runs once at startup, after CONFIG_FILE and before anything
+// reads the variables, and overwrites each reference with the secret
+// value. Every consumer keeps calling os.Getenv unchanged.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"catchup-feed/pkg/config"
+)
+
+// Environment variables.
+const (
+	// EnvRefreshInterval re-reads referenced secrets periodically (0 = never).
+	// Values read per request (JWT_SECRET) follow a rotation immediately;
+	// values read at startup (API keys, notify webhooks) need a restart.
+	EnvRefreshInterval = "SECRETS_REFRESH_INTERVAL"
+	EnvTimeout         = "SECRETS_TIMEOUT"
+)
+
+const (
+	schemeVault = "vault://"
+	schemeAWS   = "awssm://"
+
+	// defaultTimeout bounds each secret fetch.
+	defaultTimeout = 10 * time.Second
+
+	// maxResponseBytes caps secret store response bodies.
+	maxResponseBytes = 1 << 20
+)
+
+// ErrNotConfigured indicates a reference to a store whose address or
+// credentials are not set.
+var ErrNotConfigured = errors.New("secret store not configured")
+
+// Ref is a parsed secret reference.
+type Ref struct {
+	Scheme string // "vault" or "awssm"
+	Path   string // Vault "<mount>/<path>" or AWS secret ID / ARN
+	Field  string // key inside the secret; "" for the whole AWS SecretString
+}
+
+func (r Ref) String() string {
+	s := r.Scheme + "://" + r.Path
+	if r.Field != "" {
+		s += "#" + r.Field
+	}
+	return s
+}
+
+// ParseRef parses value as a secret reference. ok is false for ordinary
+// values.
+func ParseRef(value string) (ref Ref, ok bool, err error) {
+	var rest string
+	switch {
+	case strings.HasPrefix(value, schemeVault):
+		ref.Scheme, rest = "vault", strings.TrimPrefix(value, schemeVault)
+	case strings.HasPrefix(value, schemeAWS):
+		ref.Scheme, rest = "awssm", strings.TrimPrefix(value, schemeAWS)
+	default:
+		return Ref{}, false, nil
+	}
+	// AWS ARNs contain ':' and '/', so only '#' separates the field.
+	if i := strings.LastIndex(rest, "#"); i >= 0 {
+		rest, ref.Field = rest[:i], rest[i+1:]
+	}
+	ref.Path = strings.Trim(rest, "/")
+	switch {
+	case ref.Path == "":
+		return Ref{}, true, fmt.Errorf("%s: missing secret path", value)
+	case ref.Scheme == "vault" && !strings.Contains(ref.Path, "/"):
+		return Ref{}, true, fmt.Errorf("%s: want vault://<mount>/<path>#<field>", value)
+	case ref.Scheme == "vault" && ref.Field == "":
+		return Ref{}, true, fmt.Errorf("%s: missing #field", value)
+	}
+	return ref, true, nil
+}
+
+// Store fetches one secret as a set of fields. A plain string secret is
+// returned under the "" field.
+type Store interface {
+	Fetch(ctx context.Context, path string) (map[string]string, error)
+}
+
+// Resolver remembers which environment variables were references, so
+// Refresh can re-read them after ResolveEnv replaced their values.
+type Resolver struct {
+	Stores   map[string]Store // by Ref.Scheme
+	Interval time.Duration
+	Logger   *slog.Logger
+
+	mu   sync.Mutex
+	refs map[string]Ref // env key -> reference
+}
+
+// NewResolverFromEnv builds a resolver for the stores configured in the
+// environment. A store without configuration is still registered and
+// reports ErrNotConfigured when a reference needs it.
+func NewResolverFromEnv(logger *slog.Logger) *Resolver {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	timeout := config.GetEnvDuration(EnvTimeout, defaultTimeout)
+	return &Resolver{
+		Stores: map[string]Store{
+			"vault": NewVaultFromEnv(timeout),
+			"awssm": NewAWSFromEnv(timeout),
+		},
+		Interval: config.GetEnvDuration(EnvRefreshInterval, 0),
+		Logger:   logger,
+	}
+}
+
+// ResolveEnv builds a resolver from the environment and replaces every
+// secret reference in it. An error (bad reference, unreachable store,
+// missing field) is meant to stop the process: starting with a literal
+// "vault://..." as the JWT secret would be worse than not starting.
+func ResolveEnv(ctx context.Context, logger *slog.Logger) (*Resolver, error) {
+	r := NewResolverFromEnv(logger)
+	if err := r.Resolve(ctx, os.Environ()); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Resolve replaces the references among environ ("KEY=value" pairs) with
+// their secret values via os.Setenv. Each secret is fetched once even if
+// several variables point into it.
+func (r *Resolver) Resolve(ctx context.Context, environ []string) error {
+	refs := make(map[string]Ref)
+	var errs []error
+	for _, kv := range environ {
+		key, value, _ := strings.Cut(kv, "=")
+		ref, ok, err := ParseRef(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+			continue
+		}
+		if ok {
+			refs[key] = ref
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if len(refs) == 0 {
+		return nil
+	}
+
+	values, err := r.fetchAll(ctx, refs)
+	if err != nil {
+		return err
+	}
+	for key, value := range values {
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("set %s: %w", key, err)
+		}
+	}
+
+	r.mu.Lock()
+	r.refs = refs
+	r.mu.Unlock()
+	r.Logger.Info("secrets resolved", slog.Any("keys", sortedKeys(refs)))
+	return nil
+}
+
+// fetchAll resolves refs to values, all or nothing.
+func (r *Resolver) fetchAll(ctx context.Context, refs map[string]Ref) (map[string]string, error) {
+	type secretID struct{ scheme, path string }
+	fetched := make(map[secretID]map[string]string)
+	values := make(map[string]string, len(refs))
+	var errs []error
+	for _, key := range sortedKeys(refs) {
+		ref := refs[key]
+		id := secretID{ref.Scheme, ref.Path}
+		fields, ok := fetched[id]
+		if !ok {
+			store := r.Stores[ref.Scheme]
+			if store == nil {
+				errs = append(errs, fmt.Errorf("%s: %w: %s", key, ErrNotConfigured, ref.Scheme))
+				continue
+			}
+			var err error
+			if fields, err = store.Fetch(ctx, ref.Path); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %s: %w", key, ref, err))
+				continue
+			}
+			fetched[id] = fields
+		}
+		value, ok := fields[ref.Field]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %s: field not found", key, ref))
+			continue
+		}
+		values[key] = value
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return values, nil
+}
+
+// Refresh re-reads the resolved secrets every Interval until ctx is done.
+// It returns at once when there is nothing to refresh. A failed refresh
+// keeps the previous values and is retried on the next tick.
+func (r *Resolver) Refresh(ctx context.Context) {
+	if r == nil || r.Interval <= 0 {
+		return
+	}
+	r.mu.Lock()
+	refs := r.refs
+	r.mu.Unlock()
+	if len(refs) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.refreshOnce(ctx, refs)
+		}
+	}
+}
+
+func (r *Resolver) refreshOnce(ctx context.Context, refs map[string]Ref) {
+	values, err := r.fetchAll(ctx, refs)
+	if err != nil {
+		r.Logger.Warn("secrets refresh failed, keeping previous values", slog.Any("error", err))
+		return
+	}
+	for _, key := range sortedKeys(values) {
+		if os.Getenv(key) == values[key] {
+			continue
+		}
+		if err := os.Setenv(key, values[key]); err != nil {
+			r.Logger.Warn("secrets refresh: set failed", slog.String("key", key), slog.Any("error", err))
+			continue
+		}
+		r.Logger.Info("secret rotated", slog.String("key", key))
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,213 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	secrets map[string]map[string]string
+	calls   int
+}
+
+func (f *fakeStore) Fetch(_ context.Context, path string) (map[string]string, error) {
+	f.calls++
+	s, ok := f.secrets[path]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return s, nil
+}
+
+func newTestResolver(stores map[string]Store) *Resolver {
+	return &Resolver{Stores: stores, Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+}
+
+/* ───────── ParseRef ───────── */
+
+func TestParseRef(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Ref
+		ok      bool
+		wantErr bool
+	}{
+		{"plain", Ref{}, false, false},
+		{"https://hooks.slack.com/services/x", Ref{}, false, false},
+		{"vault://secret/catchup#jwt_secret", Ref{Scheme: "vault", Path: "secret/catchup", Field: "jwt_secret"}, true, false},
+		{"awssm://catchup/prod#GEMINI_API_KEY", Ref{Scheme: "awssm", Path: "catchup/prod", Field: "GEMINI_API_KEY"}, true, false},
+		{"awssm://arn:aws:secretsmanager:ap-northeast-1:123:secret:x-AbCd", Ref{Scheme: "awssm", Path: "arn:aws:secretsmanager:ap-northeast-1:123:secret:x-AbCd"}, true, false},
+		{"vault://secret/catchup", Ref{}, true, true},
+		{"vault://catchup#key", Ref{}, true, true},
+		{"awssm://", Ref{}, true, true},
+	}
+	for _, tt := range tests {
+		got, ok, err := ParseRef(tt.value)
+		assert.Equal(t, tt.ok, ok, tt.value)
+		assert.Equal(t, tt.wantErr, err != nil, "%s: %v", tt.value, err)
+		assert.Equal(t, tt.want, got, tt.value)
+	}
+}
+
+/* ───────── Resolve / Refresh ───────── */
+
+func TestResolver_Resolve_ReplacesReferences(t *testing.T) {
+	t.Setenv("SECRETS_TEST_A", "")
+	t.Setenv("SECRETS_TEST_B", "")
+	store := &fakeStore{secrets: map[string]map[string]string{
+		"secret/app": {"a": "value-a", "b": "value-b"},
+	}}
+	r := newTestResolver(map[string]Store{"vault": store})
+
+	err := r.Resolve(context.Background(), []string{
+		"SECRETS_TEST_A=vault://secret/app#a",
+		"SECRETS_TEST_B=vault://secret/app#b",
+		"SECRETS_TEST_PLAIN=unchanged",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "value-a", os.Getenv("SECRETS_TEST_A"))
+	assert.Equal(t, "value-b", os.Getenv("SECRETS_TEST_B"))
+	assert.Equal(t, 1, store.calls, "one fetch per secret")
+}
+
+func TestResolver_Resolve_FailsWithoutPartialApply(t *testing.T) {
+	t.Setenv("SECRETS_TEST_A", "vault://secret/app#a")
+	t.Setenv("SECRETS_TEST_B", "vault://secret/app#missing")
+	r := newTestResolver(map[string]Store{"vault": &fakeStore{secrets: map[string]map[string]string{
+		"secret/app": {"a": "value-a"},
+	}}})
+
+	err := r.Resolve(context.Background(), []string{
+		"SECRETS_TEST_A=vault://secret/app#a",
+		"SECRETS_TEST_B=vault://secret/app#missing",
+		"SECRETS_TEST_C=awssm://unknown-store",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SECRETS_TEST_B")
+	assert.Contains(t, err.Error(), "SECRETS_TEST_C")
+	assert.Equal(t, "vault://secret/app#a", os.Getenv("SECRETS_TEST_A"))
+}
+
+func TestResolver_RefreshRotatesAndKeepsValuesOnFailure(t *testing.T) {
+	t.Setenv("SECRETS_TEST_A", "")
+	store := &fakeStore{secrets: map[string]map[string]string{"secret/app": {"a": "v1"}}}
+	r := newTestResolver(map[string]Store{"vault": store})
+	require.NoError(t, r.Resolve(context.Background(), []string{"SECRETS_TEST_A=vault://secret/app#a"}))
+
+	store.secrets["secret/app"] = map[string]string{"a": "v2"}
+	r.refreshOnce(context.Background(), r.refs)
+	assert.Equal(t, "v2", os.Getenv("SECRETS_TEST_A"))
+
+	delete(store.secrets, "secret/app")
+	r.refreshOnce(context.Background(), r.refs)
+	assert.Equal(t, "v2", os.Getenv("SECRETS_TEST_A"))
+}
+
+func TestResolver_Refresh_NoopWithoutInterval(t *testing.T) {
+	done := make(chan struct{})
+	go func() {
+		(*Resolver)(nil).Refresh(context.Background())
+		newTestResolver(nil).Refresh(context.Background())
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Refresh blocked without an interval")
+	}
+}
+
+/* ───────── Vault ───────── */
+
+func TestVault_Fetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/secret/data/catchup/prod", r.URL.Path)
+		assert.Equal(t, "tok", r.Header.Get("X-Vault-Token"))
+		assert.Equal(t, "team", r.Header.Get("X-Vault-Namespace"))
+		_, _ = fmt.Fprint(w, `{"data":{"data":{"jwt_secret":"s3cret"},"metadata":{"version":3}}}`)
+	}))
+	defer srv.Close()
+
+	v := &Vault{Addr: srv.URL, Token: "tok", Namespace: "team", HTTPClient: srv.Client()}
+	fields, err := v.Fetch(context.Background(), "secret/catchup/prod")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"jwt_secret": "s3cret"}, fields)
+}
+
+func TestVault_Fetch_Errors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = fmt.Fprint(w, `{"errors":["permission denied"]}`)
+	}))
+	defer srv.Close()
+
+	_, err := (&Vault{Addr: srv.URL, Token: "tok", HTTPClient: srv.Client()}).Fetch(context.Background(), "secret/x")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+
+	_, err = (&Vault{}).Fetch(context.Background(), "secret/x")
+	assert.ErrorIs(t, err, ErrNotConfigured)
+}
+
+/* ───────── AWS Secrets Manager ───────── */
+
+func TestAWS_Fetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"SecretId":"catchup/prod"}`, string(body))
+		assert.Equal(t, "secretsmanager.GetSecretValue", r.Header.Get("X-Amz-Target"))
+		assert.Equal(t, "session", r.Header.Get("X-Amz-Security-Token"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"),
+			"AWS4-HMAC-SHA256 Credential=AKID/20260101/ap-northeast-1/secretsmanager/aws4_request, "))
+		_, _ = fmt.Fprint(w, `{"Name":"catchup/prod","SecretString":"{\"GEMINI_API_KEY\":\"k\",\"n\":1}"}`)
+	}))
+	defer srv.Close()
+
+	a := &AWS{
+		Region:      "ap-northeast-1",
+		Endpoint:    srv.URL,
+		Credentials: AWSCredentials{AccessKeyID: "AKID", SecretAccessKey: "secret", SessionToken: "session"},
+		HTTPClient:  srv.Client(),
+		now:         func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) },
+	}
+	fields, err := a.Fetch(context.Background(), "catchup/prod")
+	require.NoError(t, err)
+	assert.Equal(t, "k", fields["GEMINI_API_KEY"])
+	assert.Equal(t, `{"GEMINI_API_KEY":"k","n":1}`, fields[""])
+	assert.NotContains(t, fields, "n", "non-string fields are not exposed")
+
+	_, err = (&AWS{}).Fetch(context.Background(), "x")
+	assert.ErrorIs(t, err, ErrNotConfigured)
+}
+
+// The example request from the AWS Signature Version 4 documentation
+// (IAM ListUsers) pins the signing algorithm.
+func TestSignV4_DocumentationExample(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	SignV4(req, nil, AWSCredentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}, "us-east-1", "iam", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	assert.Equal(t,
+		"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, "+
+			"SignedHeaders=content-type;host;x-amz-date, "+
+			"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7",
+		req.Header.Get("Authorization"))
+}
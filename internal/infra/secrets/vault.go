@@ -0,0 +1,86 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Vault environment variables (the names the vault CLI uses).
+const (
+	EnvVaultAddr      = "VAULT_ADDR"
+	EnvVaultToken     = "VAULT_TOKEN"
+	EnvVaultNamespace = "VAULT_NAMESPACE"
+)
+
+// Vault reads secrets from a KV version 2 secrets engine with a token.
+type Vault struct {
+	Addr       string
+	Token      string
+	Namespace  string // Vault Enterprise / HCP namespace; optional
+	HTTPClient *http.Client
+}
+
+// NewVaultFromEnv reads VAULT_ADDR / VAULT_TOKEN / VAULT_NAMESPACE.
+func NewVaultFromEnv(timeout time.Duration) *Vault {
+	return &Vault{
+		Addr:       strings.TrimRight(os.Getenv(EnvVaultAddr), "/"),
+		Token:      os.Getenv(EnvVaultToken),
+		Namespace:  os.Getenv(EnvVaultNamespace),
+		HTTPClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Fetch reads "<mount>/<path>" as GET /v1/<mount>/data/<path> and returns
+// the latest version's fields.
+func (v *Vault) Fetch(ctx context.Context, path string) (map[string]string, error) {
+	if v.Addr == "" || v.Token == "" {
+		return nil, fmt.Errorf("%w: %s and %s are required", ErrNotConfigured, EnvVaultAddr, EnvVaultToken)
+	}
+	mount, secretPath, _ := strings.Cut(path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.Addr+"/v1/"+mount+"/data/"+secretPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("vault: build request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", v.Token)
+	if v.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.Namespace)
+	}
+
+	resp, err := v.HTTPClient.Do(req) // #nosec G107 -- VAULT_ADDR is operator configuration
+	if err != nil {
+		return nil, fmt.Errorf("vault: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("vault: read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		// The body lists Vault's errors; it never echoes the secret.
+		return nil, fmt.Errorf("vault: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var payload struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("vault: decode response: %w", err)
+	}
+	fields := make(map[string]string, len(payload.Data.Data))
+	for k, val := range payload.Data.Data {
+		s, ok := val.(string)
+		if !ok {
+			return nil, fmt.Errorf("vault: field %q is not a string", k)
+		}
+		fields[k] = s
+	}
+	return fields, nil
+}
@@ -0,0 +1,286 @@
+package summarizer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// maxBatchSize caps SUMMARIZER_BATCH_SIZE: with batchMaxItemChars per
+	// article a full batch stays around maxInputChars of article text.
+	maxBatchSize = 10
+
+	// batchMaxItemChars is the "short article" cut-off: longer articles
+	// are summarized on their own, as before.
+	batchMaxItemChars = 1000
+
+	// defaultBatchWait is how long the first queued article waits for
+	// others before its batch is sent anyway.
+	defaultBatchWait = 2 * time.Second
+)
+
+// BatchConfig configures Batcher.
+type BatchConfig struct {
+	// Size is the maximum number of articles per request
+	// (SUMMARIZER_BATCH_SIZE). Below 2 batching is disabled.
+	Size int
+
+	// Wait is how long a partial batch waits for more articles
+	// (SUMMARIZER_BATCH_WAIT).
+	Wait time.Duration
+
+	// CharacterLimit is the per-article summary length requested.
+	CharacterLimit int
+}
+
+// Enabled reports whether the config batches at all.
+func (c BatchConfig) Enabled() bool { return c.Size >= 2 }
+
+// LoadBatchConfig loads the batching settings from environment variables.
+// Invalid values disable batching (or fall back to the default wait) with
+// a warning (fail-open, like LoadOptions).
+//
+// Environment variables:
+//   - SUMMARIZER_BATCH_SIZE: articles per request, 2-10 (default 0: disabled)
+//   - SUMMARIZER_BATCH_WAIT: max wait for a partial batch as a Go duration (default 2s)
+func LoadBatchConfig(opts Options) BatchConfig {
+	cfg := BatchConfig{Wait: defaultBatchWait, CharacterLimit: opts.withDefaults().CharacterLimit}
+
+	if v := os.Getenv("SUMMARIZER_BATCH_SIZE"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 0 || parsed > maxBatchSize {
+			slog.Warn("Invalid SUMMARIZER_BATCH_SIZE, batching disabled",
+				slog.String("value", v),
+				slog.Int("max", maxBatchSize))
+		} else {
+			cfg.Size = parsed
+		}
+	}
+
+	if v := os.Getenv("SUMMARIZER_BATCH_WAIT"); v != "" {
+		parsed, err := time.ParseDuration(v)
+		if err != nil || parsed <= 0 {
+			slog.Warn("Invalid SUMMARIZER_BATCH_WAIT, using default",
+				slog.String("value", v),
+				slog.Duration("default", defaultBatchWait))
+		} else {
+			cfg.Wait = parsed
+		}
+	}
+
+	return cfg
+}
+
+// batchBackend is the part of Chain the Batcher needs.
+type batchBackend interface {
+	SummarizeWithProvider(ctx context.Context, text string) (string, string, error)
+	Generate(ctx context.Context, prompt string) (string, string, error)
+}
+
+// Batcher groups short articles summarized concurrently (the crawl runs
+// several summaries in parallel) into one structured Generate request
+// through the chain, cutting round-trips and per-request prompt overhead.
+// The model answers a JSON array with one summary per article; an article
+// whose summary is missing from the answer — or every article of a batch
+// whose request or parse failed — is summarized on its own as before, so
+// batching never loses a summary the single path would have produced.
+//
+// A batch is sent once Size articles are queued or Wait after the first
+// one, whichever comes first. Articles longer than batchMaxItemChars and
+// batches of one skip the batch prompt entirely.
+type Batcher struct {
+	backend batchBackend
+	config  BatchConfig
+	logger  *slog.Logger
+
+	mu      sync.Mutex
+	pending *pendingBatch
+}
+
+type pendingBatch struct {
+	items []*batchItem
+	timer *time.Timer
+}
+
+type batchItem struct {
+	ctx  context.Context
+	text string
+	// done receives exactly one result; buffered so the batch never blocks
+	// on a caller that gave up.
+	done chan batchResult
+}
+
+type batchResult struct {
+	summary  string
+	provider string
+	ok       bool
+}
+
+// NewBatcher wraps chain with batching. The config should be Enabled;
+// otherwise every article is summarized on its own.
+func NewBatcher(chain *Chain, config BatchConfig, logger *slog.Logger) *Batcher {
+	return newBatcher(chain, config, logger)
+}
+
+func newBatcher(backend batchBackend, config BatchConfig, logger *slog.Logger) *Batcher {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if config.Wait <= 0 {
+		config.Wait = defaultBatchWait
+	}
+	if config.CharacterLimit == 0 {
+		config.CharacterLimit = defaultCharLimit
+	}
+	return &Batcher{backend: backend, config: config, logger: logger}
+}
+
+// Summarize implements the fetch usecase Summarizer interface.
+func (b *Batcher) Summarize(ctx context.Context, articleText string) (string, error) {
+	summary, _, err := b.SummarizeWithProvider(ctx, articleText)
+	return summary, err
+}
+
+// SummarizeWithProvider queues a short article for the next batch and
+// waits for its summary; long articles go straight to the chain.
+func (b *Batcher) SummarizeWithProvider(ctx context.Context, articleText string) (string, string, error) {
+	if !b.config.Enabled() || utf8.RuneCountInString(articleText) > batchMaxItemChars {
+		return b.backend.SummarizeWithProvider(ctx, articleText)
+	}
+
+	item := &batchItem{ctx: ctx, text: articleText, done: make(chan batchResult, 1)}
+	b.enqueue(item)
+
+	select {
+	case res := <-item.done:
+		if res.ok {
+			return res.summary, res.provider, nil
+		}
+		return b.backend.SummarizeWithProvider(ctx, articleText)
+	case <-ctx.Done():
+		return "", "", ctx.Err()
+	}
+}
+
+// enqueue adds item to the pending batch and sends the batch when full.
+// The first item arms the wait timer; the timer only flushes the batch it
+// was armed for, so a batch sent for being full never cuts the next one
+// short.
+func (b *Batcher) enqueue(item *batchItem) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.pending == nil {
+		batch := &pendingBatch{}
+		batch.timer = time.AfterFunc(b.config.Wait, func() { b.flush(batch) })
+		b.pending = batch
+	}
+	b.pending.items = append(b.pending.items, item)
+	if len(b.pending.items) >= b.config.Size {
+		batch := b.pending
+		b.pending = nil
+		batch.timer.Stop()
+		go b.run(batch.items)
+	}
+}
+
+// flush sends batch if it is still the pending one.
+func (b *Batcher) flush(batch *pendingBatch) {
+	b.mu.Lock()
+	if b.pending != batch {
+		b.mu.Unlock()
+		return
+	}
+	b.pending = nil
+	b.mu.Unlock()
+	b.run(batch.items)
+}
+
+// run sends one batch request and hands each article its summary. It runs
+// on a context detached from the first caller's cancellation (one caller
+// giving up must not fail the others) but keeping its values (the crawl's
+// UsageMeter); the providers' own timeouts bound the request.
+func (b *Batcher) run(items []*batchItem) {
+	if len(items) == 1 {
+		items[0].done <- batchResult{}
+		return
+	}
+
+	ctx := context.WithoutCancel(items[0].ctx)
+	start := time.Now()
+	out, provider, err := b.backend.Generate(ctx, buildBatchPrompt(b.config.CharacterLimit, items))
+	if err != nil {
+		b.logger.WarnContext(ctx, "batch summarization failed, summarizing articles one by one",
+			slog.Int("items", len(items)),
+			slog.String("error", err.Error()))
+		for _, item := range items {
+			item.done <- batchResult{}
+		}
+		return
+	}
+
+	summaries := parseBatchSummaries(out, len(items))
+	missing := 0
+	for i, item := range items {
+		summary := summaries[i]
+		if summary == "" {
+			missing++
+			item.done <- batchResult{}
+			continue
+		}
+		item.done <- batchResult{summary: summary, provider: provider, ok: true}
+	}
+	b.logger.InfoContext(ctx, "batch summarization completed",
+		slog.String("provider", provider),
+		slog.Int("items", len(items)),
+		slog.Int("missing", missing),
+		slog.Duration("duration", time.Since(start)))
+}
+
+// buildBatchPrompt constructs the Japanese multi-article prompt. Articles
+// are numbered from 1 and the answer is a JSON array keyed by that
+// number. Only public article text is embedded (C-12).
+func buildBatchPrompt(charLimit int, items []*batchItem) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "以下の%d件の記事をそれぞれ日本語で%d文字以内で要約してください。\n", len(items), charLimit)
+	sb.WriteString(`出力は JSON 配列のみとし、各記事につき {"id": 記事番号, "summary": "要約"} の要素を1つ含めてください。` + "\n")
+	for i, item := range items {
+		fmt.Fprintf(&sb, "\n[記事%d]\n%s\n", i+1, item.text)
+	}
+	return sb.String()
+}
+
+// parseBatchSummaries extracts the per-article summaries from a batch
+// answer, indexed like the prompt's articles (id-1). Text around the JSON
+// array (a ```json fence, a preamble) is ignored; unknown or repeated ids
+// and empty summaries are dropped, leaving "" for the caller to summarize
+// on its own. An unparsable answer yields all "".
+func parseBatchSummaries(out string, n int) []string {
+	summaries := make([]string, n)
+	start, end := strings.Index(out, "["), strings.LastIndex(out, "]")
+	if start < 0 || end < start {
+		return summaries
+	}
+	var entries []struct {
+		ID      int    `json:"id"`
+		Summary string `json:"summary"`
+	}
+	if err := json.Unmarshal([]byte(out[start:end+1]), &entries); err != nil {
+		return summaries
+	}
+	for _, e := range entries {
+		if e.ID < 1 || e.ID > n || summaries[e.ID-1] != "" {
+			continue
+		}
+		summaries[e.ID-1] = strings.TrimSpace(e.Summary)
+	}
+	return summaries
+}
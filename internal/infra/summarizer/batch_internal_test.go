@@ -0,0 +1,203 @@
+package summarizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBatchBackend answers batch prompts with a scripted function and
+// single summaries with "single:<text>".
+type fakeBatchBackend struct {
+	mu       sync.Mutex
+	prompts  []string
+	singles  []string
+	generate func(prompt string) (string, error)
+}
+
+func (f *fakeBatchBackend) SummarizeWithProvider(_ context.Context, text string) (string, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.singles = append(f.singles, text)
+	return "single:" + text, "groq", nil
+}
+
+func (f *fakeBatchBackend) Generate(_ context.Context, prompt string) (string, string, error) {
+	f.mu.Lock()
+	f.prompts = append(f.prompts, prompt)
+	f.mu.Unlock()
+	out, err := f.generate(prompt)
+	return out, "gemini", err
+}
+
+// summarizeAll runs one Summarize per text concurrently, like the crawl.
+func summarizeAll(t *testing.T, b *Batcher, texts []string) (summaries, providers []string) {
+	t.Helper()
+	summaries, providers = make([]string, len(texts)), make([]string, len(texts))
+	var wg sync.WaitGroup
+	for i, text := range texts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s, p, err := b.SummarizeWithProvider(context.Background(), text)
+			assert.NoError(t, err)
+			summaries[i], providers[i] = s, p
+		}()
+	}
+	wg.Wait()
+	return summaries, providers
+}
+
+// echoBatch answers every [記事N] of the prompt with "batch:N".
+func echoBatch(prompt string) (string, error) {
+	n := strings.Count(prompt, "[記事")
+	entries := make([]string, n)
+	for i := range n {
+		entries[i] = fmt.Sprintf(`{"id": %d, "summary": "batch:%d"}`, i+1, i+1)
+	}
+	return "```json\n[" + strings.Join(entries, ",") + "]\n```", nil
+}
+
+func TestBatcher_FullBatchIsOneRequest(t *testing.T) {
+	backend := &fakeBatchBackend{generate: echoBatch}
+	b := newBatcher(backend, BatchConfig{Size: 3, Wait: time.Minute, CharacterLimit: 300}, nil)
+
+	summaries, providers := summarizeAll(t, b, []string{"a", "b", "c"})
+
+	require.Len(t, backend.prompts, 1, "a full batch must not wait for the timer")
+	assert.Contains(t, backend.prompts[0], "以下の3件の記事をそれぞれ日本語で300文字以内で要約してください")
+	assert.Empty(t, backend.singles)
+	assert.ElementsMatch(t, []string{"batch:1", "batch:2", "batch:3"}, summaries)
+	assert.Equal(t, []string{"gemini", "gemini", "gemini"}, providers)
+}
+
+func TestBatcher_PartialBatchSentAfterWait(t *testing.T) {
+	backend := &fakeBatchBackend{generate: echoBatch}
+	b := newBatcher(backend, BatchConfig{Size: 5, Wait: 20 * time.Millisecond}, nil)
+
+	summaries, _ := summarizeAll(t, b, []string{"a", "b"})
+
+	require.Len(t, backend.prompts, 1)
+	assert.ElementsMatch(t, []string{"batch:1", "batch:2"}, summaries)
+}
+
+func TestBatcher_SingleItemSkipsBatchPrompt(t *testing.T) {
+	backend := &fakeBatchBackend{generate: echoBatch}
+	b := newBatcher(backend, BatchConfig{Size: 5, Wait: 10 * time.Millisecond}, nil)
+
+	summary, provider, err := b.SummarizeWithProvider(context.Background(), "alone")
+	require.NoError(t, err)
+
+	assert.Equal(t, "single:alone", summary)
+	assert.Equal(t, "groq", provider)
+	assert.Empty(t, backend.prompts)
+}
+
+func TestBatcher_LongArticleBypassesBatch(t *testing.T) {
+	backend := &fakeBatchBackend{generate: echoBatch}
+	b := newBatcher(backend, BatchConfig{Size: 2, Wait: time.Minute}, nil)
+
+	long := strings.Repeat("あ", batchMaxItemChars+1)
+	summary, _, err := b.SummarizeWithProvider(context.Background(), long)
+	require.NoError(t, err)
+
+	assert.Equal(t, "single:"+long, summary)
+	assert.Empty(t, backend.prompts)
+}
+
+func TestBatcher_FallsBackPerArticle(t *testing.T) {
+	tests := []struct {
+		name     string
+		generate func(string) (string, error)
+		want     []string
+	}{
+		{
+			name:     "request error",
+			generate: func(string) (string, error) { return "", errors.New("all generate providers failed") },
+			want:     []string{"single:a", "single:b"},
+		},
+		{
+			name:     "unparsable answer",
+			generate: func(string) (string, error) { return "記事1: ...", nil },
+			want:     []string{"single:a", "single:b"},
+		},
+		{
+			name: "missing and unknown ids",
+			generate: func(string) (string, error) {
+				return `[{"id": 2, "summary": "batch:2"}, {"id": 9, "summary": "stray"}, {"id": 1, "summary": " "}]`, nil
+			},
+			want: []string{"single:a", "batch:2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &fakeBatchBackend{generate: tt.generate}
+			b := newBatcher(backend, BatchConfig{Size: 2, Wait: time.Minute}, nil)
+
+			// Queue "a" first so it is article 1 of the prompt.
+			var summaries [2]string
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				summaries[0], _, _ = b.SummarizeWithProvider(context.Background(), "a")
+			}()
+			require.Eventually(t, func() bool {
+				b.mu.Lock()
+				defer b.mu.Unlock()
+				return b.pending != nil
+			}, time.Second, time.Millisecond)
+			summaries[1], _, _ = b.SummarizeWithProvider(context.Background(), "b")
+			wg.Wait()
+
+			assert.Equal(t, tt.want, summaries[:])
+		})
+	}
+}
+
+func TestBatcher_CallerCanceledWhileWaiting(t *testing.T) {
+	backend := &fakeBatchBackend{generate: echoBatch}
+	b := newBatcher(backend, BatchConfig{Size: 5, Wait: time.Minute}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, _, err := b.SummarizeWithProvider(ctx, "a")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestLoadBatchConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     string
+		wait     string
+		wantSize int
+		wantWait time.Duration
+	}{
+		{"defaults", "", "", 0, defaultBatchWait},
+		{"valid", "5", "500ms", 5, 500 * time.Millisecond},
+		{"size at max", "10", "", 10, defaultBatchWait},
+		{"size above max disables", "11", "", 0, defaultBatchWait},
+		{"non-numeric size disables", "many", "", 0, defaultBatchWait},
+		{"invalid wait falls back", "5", "soon", 5, defaultBatchWait},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SUMMARIZER_BATCH_SIZE", tt.size)
+			t.Setenv("SUMMARIZER_BATCH_WAIT", tt.wait)
+
+			cfg := LoadBatchConfig(Options{CharacterLimit: 400})
+
+			assert.Equal(t, tt.wantSize, cfg.Size)
+			assert.Equal(t, tt.wantWait, cfg.Wait)
+			assert.Equal(t, 400, cfg.CharacterLimit)
+			assert.Equal(t, tt.wantSize >= 2, cfg.Enabled())
+		})
+	}
+}
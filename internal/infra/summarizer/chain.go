@@ -48,6 +48,8 @@ type Chain struct {
 	// retryWaited accumulates the nanoseconds already spent waiting on 429
 	// hints in this process (D-26: 累積待機は retryWaitBudget で打ち切り).
 	retryWaited atomic.Int64
+	// usage accumulates token usage over the process lifetime.
+	usage *UsageMeter
 	// budgetWarned makes the budget-exhaustion warning fire only once per
 	// process: the long-lived worker would otherwise repeat it on every 429
 	// for the rest of its life, but with zero occurrences the "retries have
@@ -69,7 +71,7 @@ func NewChain(providers ...Provider) (*Chain, error) {
 	if len(providers) == 0 {
 		return nil, ErrNoProviders
 	}
-	return &Chain{providers: providers, logger: slog.Default(), sleep: sleepContext}, nil
+	return &Chain{providers: providers, logger: slog.Default(), sleep: sleepContext, usage: NewUsageMeter()}, nil
 }
 
 // sleepContext blocks for d or until ctx is canceled, whichever comes first.
@@ -166,6 +168,12 @@ func (c *Chain) ProviderNames() []string {
 	return names
 }
 
+// Usage returns the token usage of every call made through the chain since
+// the process started, per provider and model.
+func (c *Chain) Usage() map[UsageKey]Usage {
+	return c.usage.Snapshot()
+}
+
 // Summarize implements the fetch usecase Summarizer interface.
 // The winning provider is logged; callers that need to persist it
 // (summaries.provider) should use SummarizeWithProvider.
@@ -178,7 +186,7 @@ func (c *Chain) Summarize(ctx context.Context, articleText string) (string, erro
 // together with the name of the provider that produced it (for
 // summaries.provider / fallback observability, §8).
 func (c *Chain) SummarizeWithProvider(ctx context.Context, articleText string) (string, string, error) {
-	return c.fallback(ctx, "summarize", func(p Provider) (string, error) {
+	return c.fallback(ctx, "summarize", func(ctx context.Context, p Provider) (string, error) {
 		return p.Summarize(ctx, articleText)
 	})
 }
@@ -190,7 +198,7 @@ func (c *Chain) SummarizeWithProvider(ctx context.Context, articleText string) (
 // retry, no circuit breaker (C-3); only public-article-derived text may be
 // embedded in the prompt (C-12).
 func (c *Chain) Generate(ctx context.Context, prompt string) (string, string, error) {
-	return c.fallback(ctx, "generate", func(p Provider) (string, error) {
+	return c.fallback(ctx, "generate", func(ctx context.Context, p Provider) (string, error) {
 		return p.Generate(ctx, prompt)
 	})
 }
@@ -199,19 +207,31 @@ func (c *Chain) Generate(ctx context.Context, prompt string) (string, string, er
 // successful output with the provider name. A provider gets at most two
 // attempts: the second only after a hinted 429 within the retry-wait budget
 // (D-26 (2)); everything else falls straight through to the next provider.
-func (c *Chain) fallback(ctx context.Context, op string, call func(Provider) (string, error)) (string, string, error) {
+// Token usage reported by the provider is added to the chain's totals and
+// to the ctx's UsageMeter, if any.
+func (c *Chain) fallback(ctx context.Context, op string, call func(context.Context, Provider) (string, error)) (string, string, error) {
 	var errs []error
 
 	for _, p := range c.providers {
 		for attempt := 0; ; attempt++ {
 			start := time.Now()
-			out, err := call(p)
+			callCtx, cu := withCallUsage(ctx)
+			out, err := call(callCtx, p)
 			duration := time.Since(start)
+			if cu.reported {
+				c.usage.Add(cu.key, cu.usage)
+				if m := UsageMeterFromContext(ctx); m != nil {
+					m.Add(cu.key, cu.usage)
+				}
+			}
 
 			if err == nil {
 				c.logger.InfoContext(ctx, op+" completed",
 					slog.String("provider", p.Name()),
+					slog.String("model", cu.key.Model),
 					slog.Int("output_length", text.CountRunes(out)),
+					slog.Int64("prompt_tokens", cu.usage.PromptTokens),
+					slog.Int64("completion_tokens", cu.usage.CompletionTokens),
 					slog.Duration("duration", duration))
 				return out, p.Name(), nil
 			}
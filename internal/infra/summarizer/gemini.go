@@ -118,6 +118,10 @@ type geminiResponse struct {
 			Parts []geminiPart `json:"parts"`
 		} `json:"content"`
 	} `json:"candidates"`
+	UsageMetadata struct {
+		PromptTokenCount     int64 `json:"promptTokenCount"`
+		CandidatesTokenCount int64 `json:"candidatesTokenCount"`
+	} `json:"usageMetadata"`
 }
 
 // Summarize implements Provider using the generateContent endpoint.
@@ -152,6 +156,10 @@ func (g *Gemini) generate(ctx context.Context, parts []geminiPart) (string, erro
 	if err := postJSON(ctx, g.client, ProviderGemini, url, headers, reqBody, &resp); err != nil {
 		return "", err
 	}
+	reportUsage(ctx, ProviderGemini, g.config.Model, Usage{
+		PromptTokens:     resp.UsageMetadata.PromptTokenCount,
+		CompletionTokens: resp.UsageMetadata.CandidatesTokenCount,
+	})
 
 	if len(resp.Candidates) == 0 || len(resp.Candidates[0].Content.Parts) == 0 {
 		return "", fmt.Errorf("%s: api returned no candidates", ProviderGemini)
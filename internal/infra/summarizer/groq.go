@@ -95,6 +95,10 @@ type groqResponse struct {
 			Content string `json:"content"`
 		} `json:"message"`
 	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int64 `json:"prompt_tokens"`
+		CompletionTokens int64 `json:"completion_tokens"`
+	} `json:"usage"`
 }
 
 // Summarize implements Provider using the chat/completions endpoint.
@@ -120,6 +124,10 @@ func (g *Groq) Generate(ctx context.Context, prompt string) (string, error) {
 	if err := postJSON(ctx, g.client, ProviderGroq, url, headers, reqBody, &resp); err != nil {
 		return "", err
 	}
+	reportUsage(ctx, ProviderGroq, g.config.Model, Usage{
+		PromptTokens:     resp.Usage.PromptTokens,
+		CompletionTokens: resp.Usage.CompletionTokens,
+	})
 
 	if len(resp.Choices) == 0 {
 		return "", fmt.Errorf("%s: api returned no choices", ProviderGroq)
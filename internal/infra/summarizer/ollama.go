@@ -87,7 +87,9 @@ type ollamaRequest struct {
 
 // ollamaResponse is the minimal /api/generate response body.
 type ollamaResponse struct {
-	Response string `json:"response"`
+	Response        string `json:"response"`
+	PromptEvalCount int64  `json:"prompt_eval_count"`
+	EvalCount       int64  `json:"eval_count"`
 }
 
 // Summarize implements Provider using the /api/generate endpoint.
@@ -113,6 +115,10 @@ func (o *Ollama) Generate(ctx context.Context, prompt string) (string, error) {
 	if err := postJSON(ctx, o.client, ProviderOllama, url, nil, reqBody, &resp); err != nil {
 		return "", err
 	}
+	reportUsage(ctx, ProviderOllama, o.config.Model, Usage{
+		PromptTokens:     resp.PromptEvalCount,
+		CompletionTokens: resp.EvalCount,
+	})
 
 	out := strings.TrimSpace(resp.Response)
 	if out == "" {
@@ -120,3 +126,22 @@ func (o *Ollama) Generate(ctx context.Context, prompt string) (string, error) {
 	}
 	return out, nil
 }
+
+// Probe checks the Ollama API answers (GET /api/tags, the model list)
+// without generating anything, for the server's GET /health.
+func (o *Ollama) Probe(ctx context.Context) error {
+	url := strings.TrimSuffix(o.config.Host, "/") + "/api/tags"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("%s: build request: %w", ProviderOllama, err)
+	}
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: request failed: %w", ProviderOllama, err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s: api error status %d", ProviderOllama, resp.StatusCode)
+	}
+	return nil
+}
@@ -118,6 +118,26 @@ func TestOllama_Summarize_ConnectionRefused(t *testing.T) {
 	assert.Contains(t, err.Error(), "request failed")
 }
 
+func TestOllama_Probe(t *testing.T) {
+	var gotPath string
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	o := newOllama(t, srv.URL, summarizer.Options{CharacterLimit: 900, Timeout: time.Second})
+
+	require.NoError(t, o.Probe(context.Background()))
+	assert.Equal(t, "/api/tags", gotPath)
+
+	status = http.StatusServiceUnavailable
+	err := o.Probe(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "503")
+}
+
 func TestLoadOllamaConfig(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -0,0 +1,138 @@
+package summarizer
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+)
+
+// Usage is the token count reported by a provider for generation calls.
+// Providers report what their API returns (Gemini usageMetadata, Groq's
+// OpenAI-compatible usage, Ollama prompt_eval_count / eval_count); a
+// provider whose response omits the counts simply reports nothing.
+type Usage struct {
+	PromptTokens     int64
+	CompletionTokens int64
+}
+
+// UsageKey identifies one provider / model pair.
+type UsageKey struct {
+	Provider string
+	Model    string
+}
+
+// UsageMeter accumulates token usage per provider and model. It is safe
+// for concurrent use: one crawl summarizes articles in parallel.
+//
+// There is no per-token cost accounting: every provider in the chain runs
+// on a free tier or locally (ゼロ円運用), so the number worth watching is
+// tokens against the daily quota, not money.
+type UsageMeter struct {
+	mu    sync.Mutex
+	byKey map[UsageKey]Usage
+}
+
+// NewUsageMeter returns an empty meter.
+func NewUsageMeter() *UsageMeter {
+	return &UsageMeter{byKey: map[UsageKey]Usage{}}
+}
+
+// Add records one call's usage.
+func (m *UsageMeter) Add(key UsageKey, u Usage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cur := m.byKey[key]
+	cur.PromptTokens += u.PromptTokens
+	cur.CompletionTokens += u.CompletionTokens
+	m.byKey[key] = cur
+}
+
+// Snapshot returns a copy of the per provider / model totals.
+func (m *UsageMeter) Snapshot() map[UsageKey]Usage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make(map[UsageKey]Usage, len(m.byKey))
+	for k, v := range m.byKey {
+		out[k] = v
+	}
+	return out
+}
+
+// Total returns the usage summed over every provider and model.
+func (m *UsageMeter) Total() Usage {
+	var total Usage
+	for _, u := range m.Snapshot() {
+		total.PromptTokens += u.PromptTokens
+		total.CompletionTokens += u.CompletionTokens
+	}
+	return total
+}
+
+// LogValue renders the meter as one group per "provider/model", so the
+// crawl summary line carries the breakdown, e.g.
+// token_usage.gemini/gemini-2.5-flash.prompt_tokens=1234.
+func (m *UsageMeter) LogValue() slog.Value {
+	snap := m.Snapshot()
+	keys := make([]UsageKey, 0, len(snap))
+	for k := range snap {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Provider != keys[j].Provider {
+			return keys[i].Provider < keys[j].Provider
+		}
+		return keys[i].Model < keys[j].Model
+	})
+	attrs := make([]slog.Attr, 0, len(keys))
+	for _, k := range keys {
+		u := snap[k]
+		attrs = append(attrs, slog.Group(k.Provider+"/"+k.Model,
+			slog.Int64("prompt_tokens", u.PromptTokens),
+			slog.Int64("completion_tokens", u.CompletionTokens)))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+type meterKey struct{}
+
+// WithUsageMeter returns a context whose summarization calls (through
+// Chain) also add their token usage to m. The worker attaches one meter
+// per crawl run to report the crawl's token consumption.
+func WithUsageMeter(ctx context.Context, m *UsageMeter) context.Context {
+	return context.WithValue(ctx, meterKey{}, m)
+}
+
+// UsageMeterFromContext returns the meter attached by WithUsageMeter, or nil.
+func UsageMeterFromContext(ctx context.Context) *UsageMeter {
+	m, _ := ctx.Value(meterKey{}).(*UsageMeter)
+	return m
+}
+
+// callUsage is the per-attempt sink a provider reports into. The chain
+// creates one per provider attempt, so the reported usage belongs to
+// exactly that call.
+type callUsage struct {
+	key      UsageKey
+	usage    Usage
+	reported bool
+}
+
+type callUsageKey struct{}
+
+func withCallUsage(ctx context.Context) (context.Context, *callUsage) {
+	cu := &callUsage{}
+	return context.WithValue(ctx, callUsageKey{}, cu), cu
+}
+
+// reportUsage records the token counts of one provider response. Called
+// by providers after a decoded response; a no-op outside the chain.
+func reportUsage(ctx context.Context, provider, model string, u Usage) {
+	cu, ok := ctx.Value(callUsageKey{}).(*callUsage)
+	if !ok {
+		return
+	}
+	cu.key = UsageKey{Provider: provider, Model: model}
+	cu.usage = u
+	cu.reported = true
+}
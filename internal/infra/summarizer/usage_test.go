@@ -0,0 +1,100 @@
+package summarizer_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/infra/summarizer"
+)
+
+// TestChain_RecordsTokenUsage: the usage reported by the provider that
+// answered lands in both the chain totals and the ctx meter.
+func TestChain_RecordsTokenUsage(t *testing.T) {
+	geminiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"error":{"message":"internal"}}`, http.StatusInternalServerError)
+	}))
+	defer geminiSrv.Close()
+
+	groqSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"content":"要約。"}}],` +
+			`"usage":{"prompt_tokens":120,"completion_tokens":30}}`))
+	}))
+	defer groqSrv.Close()
+
+	opts := summarizer.Options{CharacterLimit: 900, Timeout: 5 * time.Second}
+	chain, err := summarizer.NewChain(
+		summarizer.NewGemini(summarizer.GeminiConfig{APIKey: "k", Model: "gemini-2.5-flash", BaseURL: geminiSrv.URL, Options: opts}),
+		summarizer.NewGroq(summarizer.GroqConfig{APIKey: "k", Model: "llama-3.3-70b-versatile", BaseURL: groqSrv.URL, Options: opts}),
+	)
+	require.NoError(t, err)
+
+	meter := summarizer.NewUsageMeter()
+	ctx := summarizer.WithUsageMeter(context.Background(), meter)
+	for range 2 {
+		_, _, err := chain.SummarizeWithProvider(ctx, "public article")
+		require.NoError(t, err)
+	}
+
+	key := summarizer.UsageKey{Provider: summarizer.ProviderGroq, Model: "llama-3.3-70b-versatile"}
+	want := summarizer.Usage{PromptTokens: 240, CompletionTokens: 60}
+	assert.Equal(t, map[summarizer.UsageKey]summarizer.Usage{key: want}, meter.Snapshot(),
+		"the failed Gemini attempt must not be counted")
+	assert.Equal(t, want, meter.Total())
+	assert.Equal(t, want, chain.Usage()[key])
+}
+
+func TestProviders_ParseTokenUsage(t *testing.T) {
+	opts := summarizer.Options{CharacterLimit: 900, Timeout: 5 * time.Second}
+
+	tests := []struct {
+		name     string
+		body     string
+		provider func(url string) summarizer.Provider
+		want     summarizer.UsageKey
+	}{
+		{
+			name: "gemini usageMetadata",
+			body: `{"candidates":[{"content":{"parts":[{"text":"要約。"}]}}],` +
+				`"usageMetadata":{"promptTokenCount":10,"candidatesTokenCount":5}}`,
+			provider: func(url string) summarizer.Provider {
+				return summarizer.NewGemini(summarizer.GeminiConfig{APIKey: "k", Model: "gemini-2.5-flash", BaseURL: url, Options: opts})
+			},
+			want: summarizer.UsageKey{Provider: summarizer.ProviderGemini, Model: "gemini-2.5-flash"},
+		},
+		{
+			name: "ollama eval counts",
+			body: `{"response":"要約。","done":true,"prompt_eval_count":10,"eval_count":5}`,
+			provider: func(url string) summarizer.Provider {
+				return summarizer.NewOllama(summarizer.OllamaConfig{Host: url, Model: "qwen2.5:7b", Options: opts})
+			},
+			want: summarizer.UsageKey{Provider: summarizer.ProviderOllama, Model: "qwen2.5:7b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			chain, err := summarizer.NewChain(tt.provider(srv.URL))
+			require.NoError(t, err)
+			meter := summarizer.NewUsageMeter()
+			_, _, err = chain.Generate(summarizer.WithUsageMeter(context.Background(), meter), "prompt")
+			require.NoError(t, err)
+
+			assert.Equal(t, summarizer.Usage{PromptTokens: 10, CompletionTokens: 5}, meter.Snapshot()[tt.want])
+		})
+	}
+}
+
+func TestUsageMeterFromContext_Absent(t *testing.T) {
+	assert.Nil(t, summarizer.UsageMeterFromContext(context.Background()))
+}
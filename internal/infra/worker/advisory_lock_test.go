@@ -0,0 +1,84 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdvisoryLocker_TryLockSource(t *testing.T) {
+	lockQuery := regexp.QuoteMeta("SELECT pg_try_advisory_lock($1, $2)")
+	unlockQuery := regexp.QuoteMeta("SELECT pg_advisory_unlock($1, $2)")
+
+	t.Run("acquired: unlock releases the lock", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(lockQuery).
+			WithArgs(sourceLockClass, int32(7)).
+			WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(true))
+		mock.ExpectExec(unlockQuery).
+			WithArgs(sourceLockClass, int32(7)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		locker := NewAdvisoryLocker(db, slog.New(slog.DiscardHandler))
+		unlock, acquired, err := locker.TryLockSource(context.Background(), 7)
+		require.NoError(t, err)
+		require.True(t, acquired)
+		unlock()
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("held elsewhere: not acquired", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(lockQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(false))
+
+		locker := NewAdvisoryLocker(db, slog.New(slog.DiscardHandler))
+		unlock, acquired, err := locker.TryLockSource(context.Background(), 7)
+		require.NoError(t, err)
+		assert.False(t, acquired)
+		assert.Nil(t, unlock)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(lockQuery).WillReturnError(errors.New("db down"))
+
+		locker := NewAdvisoryLocker(db, slog.New(slog.DiscardHandler))
+		_, acquired, err := locker.TryLockSource(context.Background(), 7)
+		assert.ErrorContains(t, err, "TryLockSource")
+		assert.False(t, acquired)
+	})
+
+	t.Run("failed unlock does not panic", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(lockQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(true))
+		mock.ExpectExec(unlockQuery).WillReturnError(errors.New("connection reset"))
+
+		locker := NewAdvisoryLocker(db, slog.New(slog.DiscardHandler))
+		unlock, acquired, err := locker.TryLockSource(context.Background(), 7)
+		require.NoError(t, err)
+		require.True(t, acquired)
+		assert.NotPanics(t, unlock)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
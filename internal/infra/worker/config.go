@@ -1,12 +1,17 @@
 package worker
 
 import (
+	"catchup-feed/internal/domain/entity"
 	"catchup-feed/internal/pkg/config"
 	"fmt"
 	"log/slog"
 	"time"
 )
 
+// maxCrawlConcurrency bounds CRAWL_CONCURRENCY: beyond it the database
+// pool and the summarizer providers, not the sources, become the limit.
+const maxCrawlConcurrency = 32
+
 // WorkerConfig holds the configuration for the worker component.
 // This configuration controls the cron schedule, timezone, notification settings,
 // and other operational parameters for the worker service.
@@ -54,6 +59,26 @@ type WorkerConfig struct {
 	// Default: 30 minutes
 	CrawlTimeout time.Duration
 
+	// CrawlConcurrency is how many sources one crawl processes at once.
+	// Range: 1-32 (0 = sequential, the zero value)
+	// Default: 4
+	CrawlConcurrency int
+
+	// CrawlSourceTimeout bounds the processing of a single source within a
+	// crawl; a source that runs out of time is skipped and the crawl goes
+	// on. LoadConfigFromEnv caps it at CrawlTimeout (a longer value would
+	// never fire: the crawl's own deadline comes first).
+	// Range: 10s-1h (0 = no per-source timeout)
+	// Default: 5 minutes
+	CrawlSourceTimeout time.Duration
+
+	// NearDuplicateMaxDistance is the SimHash Hamming distance up to which
+	// a new article is marked as a near-duplicate of another source's
+	// article (articles.duplicate_of).
+	// Range: 1-20 (0 = detection disabled)
+	// Default: 10 (entity.DefaultSimHashMaxDistance)
+	NearDuplicateMaxDistance int
+
 	// HealthPort is the port number for the health check HTTP server.
 	// Range: 1024-65535 (avoid privileged ports)
 	// Default: 9091
@@ -75,10 +100,14 @@ type WorkerConfig struct {
 //	config.CronSchedule = "0 */6 * * *"  // Customize to run every 6 hours
 func DefaultConfig() WorkerConfig {
 	return WorkerConfig{
-		CronSchedule: "30 5 * * *",     // Every day at 5:30 AM
-		Timezone:     "Asia/Tokyo",     // JST
-		CrawlTimeout: 30 * time.Minute, // 30 minutes
-		HealthPort:   9091,             // Standard Prometheus exporter port
+		CronSchedule:       "30 5 * * *",     // Every day at 5:30 AM
+		Timezone:           "Asia/Tokyo",     // JST
+		CrawlTimeout:       30 * time.Minute, // 30 minutes
+		CrawlConcurrency:   4,                // 4 sources at once
+		CrawlSourceTimeout: 5 * time.Minute,  // 5 minutes per source
+		HealthPort:         9091,             // Standard Prometheus exporter port
+
+		NearDuplicateMaxDistance: entity.DefaultSimHashMaxDistance,
 	}
 }
 
@@ -90,6 +119,9 @@ func DefaultConfig() WorkerConfig {
 //   - CronSchedule: Must be a valid cron expression (validated by robfig/cron parser)
 //   - Timezone: Must be a valid IANA timezone name (validated by time.LoadLocation)
 //   - CrawlTimeout: Must be positive (> 0)
+//   - CrawlConcurrency: Must be between 0 and 32
+//   - CrawlSourceTimeout: Must not be negative (0 = disabled)
+//   - NearDuplicateMaxDistance: Must be between 0 and 20 (0 = disabled)
 //   - HealthPort: Must be between 1024 and 65535 (avoid privileged ports)
 //
 // Returns:
@@ -124,6 +156,21 @@ func (c *WorkerConfig) Validate() error {
 		errors = append(errors, fmt.Errorf("crawl timeout: %w", err))
 	}
 
+	// Validate CrawlConcurrency (range: 0-32, 0 = sequential)
+	if err := config.ValidateIntRange(c.CrawlConcurrency, 0, maxCrawlConcurrency); err != nil {
+		errors = append(errors, fmt.Errorf("crawl concurrency: %w", err))
+	}
+
+	// Validate CrawlSourceTimeout (0 = disabled)
+	if c.CrawlSourceTimeout < 0 {
+		errors = append(errors, fmt.Errorf("crawl source timeout: must not be negative, got %v", c.CrawlSourceTimeout))
+	}
+
+	// Validate NearDuplicateMaxDistance (range: 0-20, 0 = disabled)
+	if err := config.ValidateIntRange(c.NearDuplicateMaxDistance, 0, entity.MaxSimHashDistance); err != nil {
+		errors = append(errors, fmt.Errorf("near duplicate max distance: %w", err))
+	}
+
 	// Validate HealthPort (range: 1024-65535)
 	if err := config.ValidateIntRange(c.HealthPort, 1024, 65535); err != nil {
 		errors = append(errors, fmt.Errorf("health port: %w", err))
@@ -151,6 +198,11 @@ func (c *WorkerConfig) Validate() error {
 //   - CRON_SCHEDULE: Cron expression (default: "30 5 * * *")
 //   - WORKER_TIMEZONE: IANA timezone name (default: "Asia/Tokyo")
 //   - CRAWL_TIMEOUT: Duration string, e.g., "30m" (default: 30 minutes)
+//   - CRAWL_CONCURRENCY: Integer 1-32 (default: 4)
+//   - CRAWL_SOURCE_TIMEOUT: Duration string 10s-1h or "0" to disable
+//     (default: 5 minutes, capped at CRAWL_TIMEOUT)
+//   - NEAR_DUPLICATE_MAX_DISTANCE: Integer 0-20, 0 disables near-duplicate
+//     detection (default: 10)
 //   - WORKER_HEALTH_PORT: Integer 1024-65535 (default: 9091)
 //
 // Parameters:
@@ -217,6 +269,58 @@ func LoadConfigFromEnv(logger *slog.Logger) (*WorkerConfig, error) {
 		}
 	}
 
+	// Load CrawlConcurrency
+	result = config.LoadEnvInt("CRAWL_CONCURRENCY", cfg.CrawlConcurrency, func(v int) error {
+		return config.ValidateIntRange(v, 1, maxCrawlConcurrency)
+	})
+	cfg.CrawlConcurrency = result.Value.(int)
+	if result.FallbackApplied {
+		fallbackApplied = true
+		for _, warning := range result.Warnings {
+			logger.Warn("Configuration fallback applied",
+				slog.String("field", "CrawlConcurrency"),
+				slog.String("warning", warning))
+		}
+	}
+
+	// Load CrawlSourceTimeout (0 disables, otherwise 10s-1h)
+	result = config.LoadEnvDuration("CRAWL_SOURCE_TIMEOUT", cfg.CrawlSourceTimeout, func(d time.Duration) error {
+		if d == 0 {
+			return nil
+		}
+		return config.ValidateDuration(d, 10*time.Second, 1*time.Hour)
+	})
+	cfg.CrawlSourceTimeout = result.Value.(time.Duration)
+	if result.FallbackApplied {
+		fallbackApplied = true
+		for _, warning := range result.Warnings {
+			logger.Warn("Configuration fallback applied",
+				slog.String("field", "CrawlSourceTimeout"),
+				slog.String("warning", warning))
+		}
+	}
+	// A source may not outlive the crawl it belongs to.
+	if cfg.CrawlSourceTimeout > cfg.CrawlTimeout {
+		logger.Warn("CRAWL_SOURCE_TIMEOUT exceeds CRAWL_TIMEOUT, capping it",
+			slog.Duration("crawl_source_timeout", cfg.CrawlSourceTimeout),
+			slog.Duration("crawl_timeout", cfg.CrawlTimeout))
+		cfg.CrawlSourceTimeout = cfg.CrawlTimeout
+	}
+
+	// Load NearDuplicateMaxDistance (0 disables)
+	result = config.LoadEnvInt("NEAR_DUPLICATE_MAX_DISTANCE", cfg.NearDuplicateMaxDistance, func(v int) error {
+		return config.ValidateIntRange(v, 0, entity.MaxSimHashDistance)
+	})
+	cfg.NearDuplicateMaxDistance = result.Value.(int)
+	if result.FallbackApplied {
+		fallbackApplied = true
+		for _, warning := range result.Warnings {
+			logger.Warn("Configuration fallback applied",
+				slog.String("field", "NearDuplicateMaxDistance"),
+				slog.String("warning", warning))
+		}
+	}
+
 	// Load HealthPort
 	result = config.LoadEnvInt("WORKER_HEALTH_PORT", cfg.HealthPort, func(v int) error {
 		return config.ValidateIntRange(v, 1024, 65535)
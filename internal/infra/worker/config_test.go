@@ -25,6 +25,14 @@ func TestDefaultConfig(t *testing.T) {
 		t.Errorf("Expected CrawlTimeout 30m, got %v", config.CrawlTimeout)
 	}
 
+	if config.CrawlConcurrency != 4 {
+		t.Errorf("Expected CrawlConcurrency 4, got %d", config.CrawlConcurrency)
+	}
+
+	if config.CrawlSourceTimeout != 5*time.Minute {
+		t.Errorf("Expected CrawlSourceTimeout 5m, got %v", config.CrawlSourceTimeout)
+	}
+
 	if config.HealthPort != 9091 {
 		t.Errorf("Expected HealthPort 9091, got %d", config.HealthPort)
 	}
@@ -609,3 +617,145 @@ func TestLoadConfigFromEnv_PartiallyValid(t *testing.T) {
 		t.Errorf("Expected 2 warnings, got %d", warningCount)
 	}
 }
+
+func TestWorkerConfig_Validate_CrawlConcurrency(t *testing.T) {
+	tests := []struct {
+		name        string
+		concurrency int
+		wantErr     bool
+	}{
+		{"Zero (sequential)", 0, false},
+		{"Minimum", 1, false},
+		{"Maximum", 32, false},
+		{"Negative", -1, true},
+		{"Too high", 33, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.CrawlConcurrency = tt.concurrency
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWorkerConfig_Validate_CrawlSourceTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		wantErr bool
+	}{
+		{"Zero (disabled)", 0, false},
+		{"Positive", 5 * time.Minute, false},
+		{"Negative", -1 * time.Second, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.CrawlSourceTimeout = tt.timeout
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFromEnv_CrawlConcurrency(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		want     int
+		fallback bool
+	}{
+		{"Valid", "8", 8, false},
+		{"Zero", "0", 4, true},
+		{"Too high", "64", 4, true},
+		{"Invalid format", "many", 4, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CRAWL_CONCURRENCY", tt.value)
+
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+			config, _ := LoadConfigFromEnv(logger)
+			if config.CrawlConcurrency != tt.want {
+				t.Errorf("Expected CrawlConcurrency %d, got %d", tt.want, config.CrawlConcurrency)
+			}
+			if got := strings.Contains(buf.String(), "Configuration fallback applied"); got != tt.fallback {
+				t.Errorf("Expected fallback warning %v, logs: %s", tt.fallback, buf.String())
+			}
+		})
+	}
+}
+
+func TestLoadConfigFromEnv_CrawlSourceTimeout(t *testing.T) {
+	tests := []struct {
+		name         string
+		value        string
+		crawlTimeout string
+		want         time.Duration
+	}{
+		{"Valid", "2m", "", 2 * time.Minute},
+		{"Disabled", "0", "", 0},
+		{"Too short", "1s", "", 5 * time.Minute},
+		{"Invalid format", "soon", "", 5 * time.Minute},
+		{"Capped at CrawlTimeout", "1h", "10m", 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CRAWL_SOURCE_TIMEOUT", tt.value)
+			t.Setenv("CRAWL_TIMEOUT", tt.crawlTimeout)
+
+			logger := slog.New(slog.NewJSONHandler(&bytes.Buffer{}, nil))
+
+			config, _ := LoadConfigFromEnv(logger)
+			if config.CrawlSourceTimeout != tt.want {
+				t.Errorf("Expected CrawlSourceTimeout %v, got %v", tt.want, config.CrawlSourceTimeout)
+			}
+			if err := config.Validate(); err != nil {
+				t.Errorf("Loaded config must be valid: %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFromEnv_NearDuplicateMaxDistance(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		want     int
+		fallback bool
+	}{
+		{"Default", "", 10, false},
+		{"Valid", "6", 6, false},
+		{"Disabled", "0", 0, false},
+		{"Too high", "32", 10, true},
+		{"Negative", "-1", 10, true},
+		{"Invalid format", "close", 10, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NEAR_DUPLICATE_MAX_DISTANCE", tt.value)
+
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+			config, _ := LoadConfigFromEnv(logger)
+			if config.NearDuplicateMaxDistance != tt.want {
+				t.Errorf("Expected NearDuplicateMaxDistance %d, got %d", tt.want, config.NearDuplicateMaxDistance)
+			}
+			if got := strings.Contains(buf.String(), "Configuration fallback applied"); got != tt.fallback {
+				t.Errorf("Expected fallback warning %v, logs: %s", tt.fallback, buf.String())
+			}
+		})
+	}
+}
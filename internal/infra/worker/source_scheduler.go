@@ -0,0 +1,141 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/robfig/cron/v3"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/pkg/schedule"
+	"catchup-feed/internal/repository"
+)
+
+// SourceScheduler keeps one cron entry per active source that carries its
+// own crawl_schedule, or whose source group carries one (Groups). Sources
+// are edited through the API server, so the worker cannot learn about
+// changes directly: Sync re-reads the sources and adds, replaces or removes
+// entries to match. Sources without an override are not scheduled here —
+// the global CRON_SCHEDULE crawls them.
+//
+// Example usage:
+//
+//	scheduler := NewSourceScheduler(c, sourceRepo, func(id int64) { ... }, logger)
+//	if err := scheduler.Sync(ctx); err != nil { ... }
+//	_, _ = c.AddFunc("@every 1m", func() { _ = scheduler.Sync(ctx) })
+type SourceScheduler struct {
+	// Groups, when non-nil, applies the source group settings: members
+	// without their own crawl_schedule are scheduled on their group's, and
+	// members of a paused group are not scheduled at all.
+	Groups repository.SourceGroupRepository
+
+	cron    *cron.Cron
+	sources repository.SourceRepository
+	crawl   func(sourceID int64)
+	logger  *slog.Logger
+
+	mu      sync.Mutex
+	entries map[int64]scheduledSource
+}
+
+// scheduledSource is the cron entry of one source and the spec it was
+// created from (a changed spec replaces the entry). entryID 0 marks a spec
+// that failed to parse, remembered so it is logged once, not every sync.
+type scheduledSource struct {
+	spec    string
+	entryID cron.EntryID
+}
+
+// NewSourceScheduler creates a scheduler that adds its entries to c.
+// crawl starts one crawl of the given source (the worker enqueues a
+// deduplicated 'crawl' job); it is wrapped by c's chain like any other
+// job.
+func NewSourceScheduler(c *cron.Cron, sources repository.SourceRepository, crawl func(sourceID int64), logger *slog.Logger) *SourceScheduler {
+	return &SourceScheduler{
+		cron:    c,
+		sources: sources,
+		crawl:   crawl,
+		logger:  logger,
+		entries: make(map[int64]scheduledSource),
+	}
+}
+
+// Sync brings the cron entries in line with the active sources'
+// crawl_schedule (or their group's). A stored schedule that no longer parses is logged and
+// left unscheduled rather than failing the whole sync (the API validates
+// schedules, so this only happens after a manual database edit).
+func (s *SourceScheduler) Sync(ctx context.Context) error {
+	srcs, err := s.sources.ListActive(ctx)
+	if err != nil {
+		return fmt.Errorf("list active sources: %w", err)
+	}
+	var groups entity.SourceGroups
+	if s.Groups != nil {
+		list, err := s.Groups.List(ctx)
+		if err != nil {
+			return fmt.Errorf("list source groups: %w", err)
+		}
+		groups = entity.NewSourceGroups(list)
+	}
+	want := make(map[int64]string, len(srcs))
+	for _, src := range srcs {
+		if groups.CrawlPaused(src) {
+			continue
+		}
+		if spec := groups.CrawlSchedule(src); spec != nil {
+			want[src.ID] = *spec
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, entry := range s.entries {
+		if spec, ok := want[id]; ok && spec == entry.spec {
+			continue
+		}
+		if entry.entryID != 0 {
+			s.cron.Remove(entry.entryID)
+		}
+		delete(s.entries, id)
+		s.logger.Info("source crawl schedule removed",
+			slog.Int64("source_id", id),
+			slog.String("schedule", entry.spec))
+	}
+
+	for id, spec := range want {
+		if _, ok := s.entries[id]; ok {
+			continue
+		}
+		sched, err := schedule.Parse(spec)
+		if err != nil {
+			s.logger.Error("invalid source crawl schedule, source is not crawled",
+				slog.Int64("source_id", id),
+				slog.String("schedule", spec),
+				slog.Any("error", err))
+			s.entries[id] = scheduledSource{spec: spec}
+			continue
+		}
+		entryID := s.cron.Schedule(sched, cron.FuncJob(func() { s.crawl(id) }))
+		s.entries[id] = scheduledSource{spec: spec, entryID: entryID}
+		s.logger.Info("source crawl schedule added",
+			slog.Int64("source_id", id),
+			slog.String("schedule", spec))
+	}
+	return nil
+}
+
+// Scheduled returns the number of sources currently on their own schedule.
+func (s *SourceScheduler) Scheduled() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, entry := range s.entries {
+		if entry.entryID != 0 {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,176 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/robfig/cron/v3"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// stubSourceRepo serves ListActive only.
+type stubSourceRepo struct {
+	sources []*entity.Source
+	err     error
+}
+
+func (s *stubSourceRepo) ListActive(context.Context) ([]*entity.Source, error) {
+	return s.sources, s.err
+}
+func (s *stubSourceRepo) Get(context.Context, int64) (*entity.Source, error) { return nil, nil }
+func (s *stubSourceRepo) List(context.Context) ([]*entity.Source, error)     { return nil, nil }
+func (s *stubSourceRepo) Search(context.Context, string) ([]*entity.Source, error) {
+	return nil, nil
+}
+func (s *stubSourceRepo) SearchWithFilters(context.Context, []string, repository.SourceSearchFilters) ([]*entity.Source, error) {
+	return nil, nil
+}
+func (s *stubSourceRepo) Create(context.Context, *entity.Source) error { return nil }
+func (s *stubSourceRepo) Update(context.Context, *entity.Source) error { return nil }
+func (s *stubSourceRepo) Delete(context.Context, int64) error          { return nil }
+func (s *stubSourceRepo) UpdateFeedValidators(context.Context, int64, string, string) error {
+	return nil
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestSourceScheduler_Sync(t *testing.T) {
+	c := cron.New()
+	repo := &stubSourceRepo{sources: []*entity.Source{
+		{ID: 1, Active: true},
+		{ID: 2, Active: true, CrawlSchedule: strPtr("*/30 * * * *")},
+		{ID: 3, Active: true, CrawlSchedule: strPtr("2h")},
+	}}
+	var crawled []int64
+	scheduler := NewSourceScheduler(c, repo, func(id int64) { crawled = append(crawled, id) }, slog.New(slog.DiscardHandler))
+
+	if err := scheduler.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if got := scheduler.Scheduled(); got != 2 {
+		t.Fatalf("Scheduled() = %d, want 2", got)
+	}
+	if got := len(c.Entries()); got != 2 {
+		t.Fatalf("cron entries = %d, want 2", got)
+	}
+	entry2 := scheduler.entries[2].entryID
+
+	// Running an entry crawls exactly its source.
+	c.Entry(entry2).Job.Run()
+	if len(crawled) != 1 || crawled[0] != 2 {
+		t.Errorf("crawled = %v, want [2]", crawled)
+	}
+
+	// Unchanged source keeps its entry; changed spec replaces it; removed
+	// override and vanished sources drop theirs.
+	repo.sources = []*entity.Source{
+		{ID: 1, Active: true, CrawlSchedule: strPtr("45m")},
+		{ID: 2, Active: true, CrawlSchedule: strPtr("*/30 * * * *")},
+	}
+	if err := scheduler.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if got := scheduler.entries[2].entryID; got != entry2 {
+		t.Errorf("unchanged source got a new entry: %d, want %d", got, entry2)
+	}
+	if _, ok := scheduler.entries[3]; ok {
+		t.Error("source 3 should have been unscheduled")
+	}
+	if _, ok := scheduler.entries[1]; !ok {
+		t.Error("source 1 should have been scheduled")
+	}
+	if got := len(c.Entries()); got != 2 {
+		t.Errorf("cron entries = %d, want 2", got)
+	}
+
+	repo.sources = nil
+	if err := scheduler.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if got := len(c.Entries()); got != 0 {
+		t.Errorf("cron entries = %d, want 0", got)
+	}
+}
+
+func TestSourceScheduler_Sync_InvalidSpec(t *testing.T) {
+	c := cron.New()
+	repo := &stubSourceRepo{sources: []*entity.Source{
+		{ID: 1, Active: true, CrawlSchedule: strPtr("* * * * *")}, // under MinInterval
+	}}
+	scheduler := NewSourceScheduler(c, repo, func(int64) {}, slog.New(slog.DiscardHandler))
+
+	if err := scheduler.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if got := scheduler.Scheduled(); got != 0 {
+		t.Errorf("Scheduled() = %d, want 0", got)
+	}
+
+	// Fixing the spec schedules the source on the next sync.
+	repo.sources[0].CrawlSchedule = strPtr("10m")
+	if err := scheduler.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if got := scheduler.Scheduled(); got != 1 {
+		t.Errorf("Scheduled() = %d, want 1", got)
+	}
+}
+
+func TestSourceScheduler_Sync_ListError(t *testing.T) {
+	repo := &stubSourceRepo{err: errors.New("db down")}
+	scheduler := NewSourceScheduler(cron.New(), repo, func(int64) {}, slog.New(slog.DiscardHandler))
+	if err := scheduler.Sync(context.Background()); err == nil {
+		t.Error("Sync() error = nil, want error")
+	}
+}
+
+type stubSourceGroupRepo struct {
+	repository.SourceGroupRepository
+	groups []*entity.SourceGroup
+}
+
+func (s *stubSourceGroupRepo) List(context.Context) ([]*entity.SourceGroup, error) {
+	return s.groups, nil
+}
+
+func TestSourceScheduler_Sync_Groups(t *testing.T) {
+	c := cron.New()
+	goGroup, seasonal := int64(1), int64(2)
+	repo := &stubSourceRepo{sources: []*entity.Source{
+		{ID: 1, Active: true, GroupID: &goGroup},
+		{ID: 2, Active: true, GroupID: &goGroup, CrawlSchedule: strPtr("30m")},
+		{ID: 3, Active: true, GroupID: &seasonal, CrawlSchedule: strPtr("2h")},
+	}}
+	groups := &stubSourceGroupRepo{groups: []*entity.SourceGroup{
+		{ID: goGroup, Name: "Go", CrawlSchedule: strPtr("3h")},
+		{ID: seasonal, Name: "Seasonal", CrawlPaused: true},
+	}}
+	scheduler := NewSourceScheduler(c, repo, func(int64) {}, slog.New(slog.DiscardHandler))
+	scheduler.Groups = groups
+
+	if err := scheduler.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if got := scheduler.entries[1].spec; got != "3h" {
+		t.Errorf("source 1 spec = %q, want the group's 3h", got)
+	}
+	if got := scheduler.entries[2].spec; got != "30m" {
+		t.Errorf("source 2 spec = %q, want its own 30m", got)
+	}
+	if _, ok := scheduler.entries[3]; ok {
+		t.Error("a member of a paused group should not be scheduled")
+	}
+
+	// Resuming the group schedules its member again.
+	groups.groups[1].CrawlPaused = false
+	if err := scheduler.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if got := scheduler.Scheduled(); got != 3 {
+		t.Errorf("Scheduled() = %d, want 3", got)
+	}
+}
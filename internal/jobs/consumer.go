@@ -3,7 +3,9 @@
 // repository's SKIP LOCKED claim, dispatches to per-kind handlers, and
 // records success / retry / terminal failure. Deliberately not a worker
 // framework: one poll loop, one map of handlers, a fixed retry ceiling
-// (§7: attempts 上限 3).
+// (§7: attempts 上限 3). Several worker replicas may run the same
+// consumer: the claim never hands a job to two of them, and the stale
+// sweep only reclaims rows older than a job timeout.
 package jobs
 
 import (
@@ -33,6 +35,13 @@ const (
 	// DefaultMaxAttempts is the §7 retry ceiling: a job is executed at
 	// most this many times before failing terminally.
 	DefaultMaxAttempts = 3
+	// DefaultStaleSweepInterval is how often a running consumer looks for
+	// jobs orphaned by a replica that died mid-execution.
+	DefaultStaleSweepInterval = 5 * time.Minute
+	// staleGrace is added to the job timeout for the default StaleAfter:
+	// a live handler is canceled at the timeout, then still needs to
+	// record its outcome.
+	staleGrace = time.Minute
 )
 
 // Handler executes one job kind. Returning nil marks the job done; an
@@ -82,8 +91,15 @@ type Consumer struct {
 	// RetryDelay maps the attempt count (1-based, as recorded by the
 	// claim) to the backoff before the next try. nil = linear minutes.
 	RetryDelay func(attempts int) time.Duration
-	Logger     *slog.Logger
-	Now        func() time.Time // nil = time.Now
+	// StaleAfter is how long a job may stay running before the sweep
+	// treats it as orphaned. 0 = JobTimeout plus a minute of grace; it
+	// must exceed JobTimeout or live jobs get executed twice.
+	StaleAfter time.Duration
+	// StaleSweepInterval is how often Run repeats the stale sweep.
+	// 0 = DefaultStaleSweepInterval.
+	StaleSweepInterval time.Duration
+	Logger             *slog.Logger
+	Now                func() time.Time // nil = time.Now
 }
 
 func (c *Consumer) logger() *slog.Logger {
@@ -121,6 +137,20 @@ func (c *Consumer) maxAttempts() int {
 	return DefaultMaxAttempts
 }
 
+func (c *Consumer) staleAfter() time.Duration {
+	if c.StaleAfter > 0 {
+		return c.StaleAfter
+	}
+	return c.jobTimeout() + staleGrace
+}
+
+func (c *Consumer) staleSweepInterval() time.Duration {
+	if c.StaleSweepInterval > 0 {
+		return c.StaleSweepInterval
+	}
+	return DefaultStaleSweepInterval
+}
+
 func (c *Consumer) retryDelay(attempts int) time.Duration {
 	if c.RetryDelay != nil {
 		return c.RetryDelay(attempts)
@@ -141,10 +171,12 @@ func (c *Consumer) kinds() []string {
 // including other consumers' pending jobs (e.g. 'transcribe' for the Mac
 // worker) — only to fail them terminally with "no handler registered".
 //
-// It first sweeps stale 'running' rows of its own kinds back to pending: a
-// running job of a kind this consumer handles can only be the orphan of a
-// crashed predecessor (§4 持ち越し課題). Other consumers' kinds are
-// deliberately left alone — their running rows are live, not stale.
+// At startup and then every StaleSweepInterval it sweeps stale 'running'
+// rows of its own kinds back to pending: a job of a kind this consumer
+// handles that has been running longer than StaleAfter can only be the
+// orphan of a crashed worker (§4 持ち越し課題) — a live one would have hit
+// its timeout. Younger running rows may be live on another replica and are
+// left alone, as are other consumers' kinds.
 // Beyond the no-handlers guard it always returns ctx.Err().
 func (c *Consumer) Run(ctx context.Context) error {
 	logger := c.logger()
@@ -153,21 +185,20 @@ func (c *Consumer) Run(ctx context.Context) error {
 		return errors.New("jobs: consumer has no registered handlers; refusing to run (empty kinds would claim every job kind)")
 	}
 
-	requeued, err := c.Jobs.RequeueRunning(ctx, c.kinds()...)
-	if err != nil {
-		// Non-fatal: the jobs themselves are still claimable next start.
-		logger.Error("jobs: stale running sweep failed", slog.Any("error", err))
-	} else if requeued > 0 {
-		logger.Warn("jobs: requeued stale running jobs from a previous worker",
-			slog.Int64("count", requeued))
-	}
+	c.sweepStale(ctx)
+	nextSweep := c.now().Add(c.staleSweepInterval())
 
 	logger.Info("jobs: consumer started",
 		slog.Any("kinds", c.kinds()),
 		slog.Duration("poll_interval", c.pollInterval()),
+		slog.Duration("stale_after", c.staleAfter()),
 		slog.Int("max_attempts", c.maxAttempts()))
 
 	for {
+		if !c.now().Before(nextSweep) {
+			c.sweepStale(ctx)
+			nextSweep = c.now().Add(c.staleSweepInterval())
+		}
 		claimed, err := c.consumeOne(ctx)
 		if err != nil {
 			if ctx.Err() != nil {
@@ -186,6 +217,23 @@ func (c *Consumer) Run(ctx context.Context) error {
 	}
 }
 
+// sweepStale requeues this consumer's running jobs claimed more than
+// StaleAfter ago.
+func (c *Consumer) sweepStale(ctx context.Context) {
+	requeued, err := c.Jobs.RequeueRunning(ctx, c.now().Add(-c.staleAfter()), c.kinds()...)
+	if err != nil {
+		// Non-fatal: the next sweep retries.
+		if ctx.Err() == nil {
+			c.logger().Error("jobs: stale running sweep failed", slog.Any("error", err))
+		}
+		return
+	}
+	if requeued > 0 {
+		c.logger().Warn("jobs: requeued stale running jobs from a crashed worker",
+			slog.Int64("count", requeued))
+	}
+}
+
 // consumeOne claims and executes at most one job. It reports whether a job
 // was claimed (to keep draining without sleeping).
 func (c *Consumer) consumeOne(ctx context.Context) (bool, error) {
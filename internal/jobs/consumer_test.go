@@ -23,6 +23,9 @@ type fakeJobQueue struct {
 	jobs     []*entity.Job
 	nextID   int64
 	claimErr error
+	// claimedAt mirrors jobs.claimed_at. Rows added as running without an
+	// entry count as claimed long ago, like rows predating the column.
+	claimedAt map[int64]time.Time
 }
 
 func (q *fakeJobQueue) add(kind string, status string, attempts int, payload string) *entity.Job {
@@ -60,6 +63,25 @@ func (q *fakeJobQueue) Enqueue(_ context.Context, kind string, payload json.RawM
 	return job.ID, nil
 }
 
+func (q *fakeJobQueue) EnqueueUnique(_ context.Context, kind, dedupeKey string, payload json.RawMessage, _ time.Time) (int64, bool, error) {
+	job := q.add(kind, entity.JobStatusPending, 0, string(payload))
+	return job.ID, true, nil
+}
+
+// claimAt marks a running job as claimed at t (e.g. by another replica).
+func (q *fakeJobQueue) claimAt(id int64, t time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.claimedAt == nil {
+		q.claimedAt = map[int64]time.Time{}
+	}
+	q.claimedAt[id] = t
+}
+
+func (q *fakeJobQueue) Get(_ context.Context, id int64) (*entity.Job, error) {
+	return q.get(id), nil
+}
+
 func (q *fakeJobQueue) ClaimNext(_ context.Context, kinds ...string) (*entity.Job, error) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
@@ -75,6 +97,10 @@ func (q *fakeJobQueue) ClaimNext(_ context.Context, kinds ...string) (*entity.Jo
 		}
 		job.Status = entity.JobStatusRunning
 		job.Attempts++
+		if q.claimedAt == nil {
+			q.claimedAt = map[int64]time.Time{}
+		}
+		q.claimedAt[job.ID] = time.Now()
 		copied := *job
 		return &copied, nil
 	}
@@ -112,12 +138,15 @@ func (q *fakeJobQueue) MarkFailed(_ context.Context, id int64, lastError string,
 	return errors.New("not found")
 }
 
-func (q *fakeJobQueue) RequeueRunning(_ context.Context, kinds ...string) (int64, error) {
+func (q *fakeJobQueue) RequeueRunning(_ context.Context, claimedBefore time.Time, kinds ...string) (int64, error) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 	var n int64
 	for _, job := range q.jobs {
-		if job.Status == entity.JobStatusRunning && slices.Contains(kinds, job.Kind) {
+		if job.Status != entity.JobStatusRunning || !slices.Contains(kinds, job.Kind) {
+			continue
+		}
+		if at, ok := q.claimedAt[job.ID]; !ok || at.Before(claimedBefore) {
 			job.Status = entity.JobStatusPending
 			n++
 		}
@@ -125,6 +154,10 @@ func (q *fakeJobQueue) RequeueRunning(_ context.Context, kinds ...string) (int64
 	return n, nil
 }
 
+func (q *fakeJobQueue) ListActive(context.Context, ...string) ([]*entity.Job, error) {
+	panic("not used")
+}
+
 // runUntil runs the consumer until check(queue) is true or the timeout hits.
 func runUntil(t *testing.T, consumer *jobs.Consumer, queue *fakeJobQueue, check func() bool) {
 	t.Helper()
@@ -317,6 +350,35 @@ func TestConsumer_Run(t *testing.T) {
 			"another consumer's running job must not gain attempts")
 	})
 
+	t.Run("a recent claim by another replica is left running", func(t *testing.T) {
+		queue := &fakeJobQueue{}
+		live := queue.add("ok", entity.JobStatusRunning, 1, `{}`)
+		queue.claimAt(live.ID, time.Now()) // mid-execution on another replica
+		next := queue.add("ok", entity.JobStatusPending, 0, `{}`)
+
+		consumer := newTestConsumer(queue, map[string]jobs.Handler{
+			"ok": jobs.HandlerFunc(func(_ context.Context, _ *entity.Job) error { return nil }),
+		})
+		runUntil(t, consumer, queue, func() bool { return queue.get(next.ID).Status == entity.JobStatusDone })
+		got := queue.get(live.ID)
+		assert.Equal(t, entity.JobStatusRunning, got.Status, "a live claim must not be requeued")
+		assert.Equal(t, 1, got.Attempts)
+	})
+
+	t.Run("jobs orphaned while running are requeued by the periodic sweep", func(t *testing.T) {
+		queue := &fakeJobQueue{}
+		orphan := queue.add("ok", entity.JobStatusRunning, 1, `{}`)
+		queue.claimAt(orphan.ID, time.Now()) // still live at startup
+
+		consumer := newTestConsumer(queue, map[string]jobs.Handler{
+			"ok": jobs.HandlerFunc(func(_ context.Context, _ *entity.Job) error { return nil }),
+		})
+		consumer.StaleAfter = 20 * time.Millisecond
+		consumer.StaleSweepInterval = 10 * time.Millisecond
+		runUntil(t, consumer, queue, func() bool { return queue.get(orphan.ID).Status == entity.JobStatusDone })
+		assert.Equal(t, 2, queue.get(orphan.ID).Attempts)
+	})
+
 	t.Run("unregistered kinds are never claimed", func(t *testing.T) {
 		queue := &fakeJobQueue{}
 		future := queue.add("future_kind", entity.JobStatusPending, 0, `{}`)
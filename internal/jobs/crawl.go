@@ -0,0 +1,87 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	fetchUC "catchup-feed/internal/usecase/fetch"
+)
+
+// Crawler is the slice of the fetch service the crawl job needs.
+// Satisfied by *fetch.Service.
+type Crawler interface {
+	CrawlSource(ctx context.Context, id int64) (*fetchUC.CrawlStats, error)
+	CrawlAllSources(ctx context.Context) (*fetchUC.CrawlStats, error)
+	CrawlDefaultScheduleSources(ctx context.Context) (*fetchUC.CrawlStats, error)
+}
+
+// CrawlHandler handles 'crawl': the worker cron's scheduled crawls and the
+// on-demand ones enqueued through the API (POST /crawl,
+// POST /sources/{id}/crawl). A retry is harmless — already stored articles
+// are skipped.
+type CrawlHandler struct {
+	Crawler Crawler
+	Logger  *slog.Logger
+}
+
+// Handle crawls the source in the payload, or every active source when
+// source_id is absent — minus those on their own crawl_schedule when
+// default_schedule is set.
+func (h *CrawlHandler) Handle(ctx context.Context, job *entity.Job) error {
+	var payload entity.CrawlPayload
+	if len(job.Payload) > 0 {
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return Permanent(fmt.Errorf("crawl: invalid payload: %w", err))
+		}
+	}
+	if payload.SourceID < 0 {
+		return Permanent(fmt.Errorf("crawl: invalid source_id %d", payload.SourceID))
+	}
+
+	logger := h.logger().With(
+		slog.Int64("job_id", job.ID),
+		slog.Int64("source_id", payload.SourceID),
+		slog.Bool("default_schedule", payload.DefaultSchedule))
+	start := time.Now()
+
+	var (
+		stats *fetchUC.CrawlStats
+		err   error
+	)
+	switch {
+	case payload.SourceID > 0:
+		stats, err = h.Crawler.CrawlSource(ctx, payload.SourceID)
+	case payload.DefaultSchedule:
+		stats, err = h.Crawler.CrawlDefaultScheduleSources(ctx)
+	default:
+		stats, err = h.Crawler.CrawlAllSources(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("crawl: %w", err)
+	}
+	logger.InfoContext(ctx, "crawl: crawl completed",
+		slog.Int("sources", stats.Sources),
+		slog.Int("fetch_failed_sources", stats.FetchFailedSources()),
+		slog.Int64("feed_items", stats.FeedItems),
+		slog.Int64("inserted", stats.Inserted),
+		slog.Int64("duplicated", stats.Duplicated),
+		slog.Int64("duplicated_by_hash", stats.DuplicatedByHash),
+		slog.Int64("near_duplicates", stats.NearDuplicates),
+		slog.Int64("filtered_junk", stats.FilteredJunk),
+		slog.Int64("summarize_errors", stats.SummarizeError),
+		slog.Int64("youtube_direct_attempts", stats.YouTubeDirectAttempts),
+		slog.Int64("youtube_direct_succeeded", stats.YouTubeDirectSucceeded),
+		slog.Duration("duration", time.Since(start)))
+	return nil
+}
+
+func (h *CrawlHandler) logger() *slog.Logger {
+	if h.Logger != nil {
+		return h.Logger
+	}
+	return slog.Default()
+}
@@ -0,0 +1,95 @@
+package jobs_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/jobs"
+	fetchUC "catchup-feed/internal/usecase/fetch"
+)
+
+// fakeCrawler はどのクロールが呼ばれたかを記録する。
+type fakeCrawler struct {
+	sourceIDs    []int64
+	allCalls     int
+	defaultCalls int
+	err          error
+}
+
+func (c *fakeCrawler) CrawlSource(_ context.Context, id int64) (*fetchUC.CrawlStats, error) {
+	c.sourceIDs = append(c.sourceIDs, id)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &fetchUC.CrawlStats{Sources: 1}, nil
+}
+
+func (c *fakeCrawler) CrawlAllSources(context.Context) (*fetchUC.CrawlStats, error) {
+	c.allCalls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &fetchUC.CrawlStats{Sources: 3}, nil
+}
+
+func (c *fakeCrawler) CrawlDefaultScheduleSources(context.Context) (*fetchUC.CrawlStats, error) {
+	c.defaultCalls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &fetchUC.CrawlStats{Sources: 2}, nil
+}
+
+func crawlJob(payload string) *entity.Job {
+	return &entity.Job{ID: 21, Kind: entity.JobKindCrawl, Payload: json.RawMessage(payload)}
+}
+
+func TestCrawlHandler_Handle(t *testing.T) {
+	t.Run("source_id crawls that source", func(t *testing.T) {
+		crawler := &fakeCrawler{}
+		h := &jobs.CrawlHandler{Crawler: crawler}
+		assert.NoError(t, h.Handle(context.Background(), crawlJob(`{"source_id":7}`)))
+		assert.Equal(t, []int64{7}, crawler.sourceIDs)
+		assert.Zero(t, crawler.allCalls)
+	})
+
+	t.Run("empty payload crawls every source", func(t *testing.T) {
+		crawler := &fakeCrawler{}
+		h := &jobs.CrawlHandler{Crawler: crawler}
+		assert.NoError(t, h.Handle(context.Background(), crawlJob(`{}`)))
+		assert.Equal(t, 1, crawler.allCalls)
+		assert.Empty(t, crawler.sourceIDs)
+	})
+
+	t.Run("default_schedule skips sources on their own schedule", func(t *testing.T) {
+		crawler := &fakeCrawler{}
+		h := &jobs.CrawlHandler{Crawler: crawler}
+		assert.NoError(t, h.Handle(context.Background(), crawlJob(`{"default_schedule":true}`)))
+		assert.Equal(t, 1, crawler.defaultCalls)
+		assert.Zero(t, crawler.allCalls)
+		assert.Empty(t, crawler.sourceIDs)
+	})
+
+	t.Run("invalid payload is permanent", func(t *testing.T) {
+		crawler := &fakeCrawler{}
+		h := &jobs.CrawlHandler{Crawler: crawler}
+		err := h.Handle(context.Background(), crawlJob(`{"source_id":"x"}`))
+		assert.True(t, jobs.IsPermanent(err))
+		err = h.Handle(context.Background(), crawlJob(`{"source_id":-1}`))
+		assert.True(t, jobs.IsPermanent(err))
+		assert.Zero(t, crawler.allCalls)
+		assert.Empty(t, crawler.sourceIDs)
+	})
+
+	t.Run("crawl error is retried", func(t *testing.T) {
+		h := &jobs.CrawlHandler{Crawler: &fakeCrawler{err: errors.New("db down")}}
+		err := h.Handle(context.Background(), crawlJob(`{"source_id":7}`))
+		assert.Error(t, err)
+		assert.False(t, jobs.IsPermanent(err))
+	})
+}
@@ -0,0 +1,186 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// Request headers of a webhook delivery. Receivers verify the signature
+// as HMAC-SHA256(secret, "<timestamp>.<body>") in hex
+// (entity.SignWebhookPayload).
+const (
+	WebhookEventHeader     = "X-Catchup-Event"
+	WebhookDeliveryHeader  = "X-Catchup-Delivery"
+	WebhookTimestampHeader = "X-Catchup-Timestamp"
+	WebhookSignatureHeader = "X-Catchup-Signature"
+)
+
+// DefaultWebhookTimeout bounds one delivery request.
+const DefaultWebhookTimeout = 10 * time.Second
+
+// webhookErrorBodyLimit is how much of a failed response body is kept in
+// last_error.
+const webhookErrorBodyLimit = 512
+
+// NewWebhookClient returns the delivery HTTP client. Redirects are not
+// followed: a webhook URL is registered as the final endpoint, and a 3xx
+// is recorded as a failed attempt instead of being chased to an address
+// that was never validated.
+func NewWebhookClient() *http.Client {
+	return &http.Client{
+		Timeout: DefaultWebhookTimeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// DeliverWebhookHandler handles 'deliver_webhook': one signed POST of a
+// webhook_deliveries row. Every attempt is recorded on the row. Network
+// errors, 5xx, 408 and 429 are retried by the consumer's policy; other
+// non-2xx answers fail the delivery at once — retrying cannot fix them.
+type DeliverWebhookHandler struct {
+	Webhooks repository.WebhookRepository
+	Client   *http.Client // nil = NewWebhookClient()
+	// MaxAttempts must match the consumer's so the row reads 'failed' on
+	// the last attempt (0 = DefaultMaxAttempts).
+	MaxAttempts int
+	Logger      *slog.Logger
+	Now         func() time.Time // nil = time.Now
+}
+
+// Handle sends the delivery referenced by the job payload.
+func (h *DeliverWebhookHandler) Handle(ctx context.Context, job *entity.Job) error {
+	var payload entity.DeliverWebhookPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return Permanent(fmt.Errorf("deliver_webhook: invalid payload: %w", err))
+	}
+	delivery, err := h.Webhooks.GetDelivery(ctx, payload.DeliveryID)
+	if err != nil {
+		return fmt.Errorf("deliver_webhook: %w", err)
+	}
+	if delivery == nil {
+		// The webhook was deleted (deliveries cascade).
+		return Permanent(fmt.Errorf("deliver_webhook: delivery %d not found", payload.DeliveryID))
+	}
+	webhook, err := h.Webhooks.Get(ctx, delivery.WebhookID)
+	if err != nil {
+		return fmt.Errorf("deliver_webhook: %w", err)
+	}
+	if webhook == nil {
+		return Permanent(fmt.Errorf("deliver_webhook: webhook %d not found", delivery.WebhookID))
+	}
+
+	status, sendErr := h.send(ctx, webhook, delivery)
+
+	delivery.Attempts = job.Attempts
+	delivery.ResponseStatus = nil
+	if status != 0 {
+		delivery.ResponseStatus = &status
+	}
+	logger := h.logger().With(
+		slog.Int64("delivery_id", delivery.ID),
+		slog.Int64("webhook_id", webhook.ID),
+		slog.String("event", delivery.Event),
+		slog.Int("attempts", job.Attempts))
+
+	if sendErr == nil {
+		now := h.now()
+		delivery.Status = entity.WebhookDeliveryDelivered
+		delivery.LastError = nil
+		delivery.DeliveredAt = &now
+		if err := h.Webhooks.UpdateDelivery(ctx, delivery); err != nil {
+			// Sent already; a retry would deliver twice.
+			logger.Error("jobs: webhook delivered but status update failed", slog.Any("error", err))
+		}
+		logger.Info("jobs: webhook delivered", slog.Int("status", status))
+		return nil
+	}
+
+	msg := sendErr.Error()
+	delivery.LastError = &msg
+	delivery.Status = entity.WebhookDeliveryPending
+	if IsPermanent(sendErr) || job.Attempts >= h.maxAttempts() {
+		delivery.Status = entity.WebhookDeliveryFailed
+	}
+	if err := h.Webhooks.UpdateDelivery(ctx, delivery); err != nil {
+		logger.Error("jobs: webhook delivery status update failed", slog.Any("error", err))
+	}
+	return sendErr
+}
+
+// send POSTs the stored payload and returns the response status (0 when
+// no response was received). A nil error means a 2xx answer.
+func (h *DeliverWebhookHandler) send(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, Permanent(fmt.Errorf("build request: %w", err))
+	}
+	timestamp := h.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "catchup-feed-webhook")
+	req.Header.Set(WebhookEventHeader, delivery.Event)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, "sha256="+entity.SignWebhookPayload(webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := h.client().Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("post webhook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		return resp.StatusCode, nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookErrorBodyLimit))
+	err = fmt.Errorf("webhook answered %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	if !retryableStatus(resp.StatusCode) {
+		err = Permanent(err)
+	}
+	return resp.StatusCode, err
+}
+
+// retryableStatus reports whether a non-2xx answer is worth retrying.
+func retryableStatus(code int) bool {
+	return code >= 500 || code == http.StatusRequestTimeout || code == http.StatusTooManyRequests
+}
+
+func (h *DeliverWebhookHandler) client() *http.Client {
+	if h.Client != nil {
+		return h.Client
+	}
+	return NewWebhookClient()
+}
+
+func (h *DeliverWebhookHandler) maxAttempts() int {
+	if h.MaxAttempts > 0 {
+		return h.MaxAttempts
+	}
+	return DefaultMaxAttempts
+}
+
+func (h *DeliverWebhookHandler) now() time.Time {
+	if h.Now != nil {
+		return h.Now()
+	}
+	return time.Now()
+}
+
+func (h *DeliverWebhookHandler) logger() *slog.Logger {
+	if h.Logger != nil {
+		return h.Logger
+	}
+	return slog.Default()
+}
@@ -0,0 +1,147 @@
+package jobs_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/jobs"
+)
+
+// fakeWebhookRepo serves one webhook and one delivery and records updates.
+type fakeWebhookRepo struct {
+	webhook  *entity.Webhook
+	delivery *entity.WebhookDelivery
+	updates  []entity.WebhookDelivery
+}
+
+func (f *fakeWebhookRepo) Create(context.Context, *entity.Webhook) error { return nil }
+func (f *fakeWebhookRepo) Get(_ context.Context, id int64) (*entity.Webhook, error) {
+	if f.webhook != nil && f.webhook.ID == id {
+		return f.webhook, nil
+	}
+	return nil, nil
+}
+func (f *fakeWebhookRepo) List(context.Context) ([]*entity.Webhook, error) { return nil, nil }
+func (f *fakeWebhookRepo) Delete(context.Context, int64) error             { return nil }
+func (f *fakeWebhookRepo) CreateDeliveries(context.Context, string, json.RawMessage) (int64, error) {
+	return 0, nil
+}
+func (f *fakeWebhookRepo) CreateDeliveriesTo(context.Context, []int64, string, json.RawMessage) (int64, error) {
+	return 0, nil
+}
+func (f *fakeWebhookRepo) GetDelivery(_ context.Context, id int64) (*entity.WebhookDelivery, error) {
+	if f.delivery != nil && f.delivery.ID == id {
+		return f.delivery, nil
+	}
+	return nil, nil
+}
+func (f *fakeWebhookRepo) UpdateDelivery(_ context.Context, d *entity.WebhookDelivery) error {
+	f.updates = append(f.updates, *d)
+	return nil
+}
+func (f *fakeWebhookRepo) ListDeliveries(context.Context, int64, int) ([]*entity.WebhookDelivery, error) {
+	return nil, nil
+}
+
+func TestDeliverWebhookHandler_Handle(t *testing.T) {
+	const secret = "0123456789abcdef"
+	now := time.Unix(1_790_000_000, 0)
+	body := json.RawMessage(`{"event":"article.created","data":{"id":1}}`)
+
+	setup := func(t *testing.T, status int) (*jobs.DeliverWebhookHandler, *fakeWebhookRepo, http.Header) {
+		t.Helper()
+		got := http.Header{}
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			for k, v := range r.Header {
+				got[k] = v
+			}
+			assert.JSONEq(t, string(body), string(b))
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte("nope"))
+		}))
+		t.Cleanup(srv.Close)
+
+		repo := &fakeWebhookRepo{
+			webhook:  &entity.Webhook{ID: 3, URL: srv.URL, Secret: secret},
+			delivery: &entity.WebhookDelivery{ID: 7, WebhookID: 3, Event: entity.WebhookEventArticleCreated, Payload: body},
+		}
+		handler := &jobs.DeliverWebhookHandler{
+			Webhooks: repo,
+			Logger:   slog.New(slog.DiscardHandler),
+			Now:      func() time.Time { return now },
+		}
+		return handler, repo, got
+	}
+	newJob := func(attempts int) *entity.Job {
+		return &entity.Job{ID: 1, Kind: entity.JobKindDeliverWebhook, Payload: json.RawMessage(`{"delivery_id":7}`), Attempts: attempts}
+	}
+
+	t.Run("2xx marks the delivery delivered and signs the body", func(t *testing.T) {
+		handler, repo, got := setup(t, http.StatusNoContent)
+		require.NoError(t, handler.Handle(context.Background(), newJob(1)))
+
+		assert.Equal(t, "article.created", got.Get(jobs.WebhookEventHeader))
+		assert.Equal(t, "7", got.Get(jobs.WebhookDeliveryHeader))
+		assert.Equal(t, "1790000000", got.Get(jobs.WebhookTimestampHeader))
+		assert.Equal(t, "sha256="+entity.SignWebhookPayload(secret, now.Unix(), body), got.Get(jobs.WebhookSignatureHeader))
+
+		require.Len(t, repo.updates, 1)
+		assert.Equal(t, entity.WebhookDeliveryDelivered, repo.updates[0].Status)
+		assert.Equal(t, http.StatusNoContent, *repo.updates[0].ResponseStatus)
+		assert.NotNil(t, repo.updates[0].DeliveredAt)
+		assert.Nil(t, repo.updates[0].LastError)
+	})
+
+	t.Run("5xx is retried and stays pending", func(t *testing.T) {
+		handler, repo, _ := setup(t, http.StatusBadGateway)
+		err := handler.Handle(context.Background(), newJob(1))
+		require.Error(t, err)
+		assert.False(t, jobs.IsPermanent(err))
+
+		require.Len(t, repo.updates, 1)
+		assert.Equal(t, entity.WebhookDeliveryPending, repo.updates[0].Status)
+		assert.Equal(t, 1, repo.updates[0].Attempts)
+		assert.Contains(t, *repo.updates[0].LastError, "502")
+	})
+
+	t.Run("5xx on the last attempt fails the delivery", func(t *testing.T) {
+		handler, repo, _ := setup(t, http.StatusServiceUnavailable)
+		require.Error(t, handler.Handle(context.Background(), newJob(jobs.DefaultMaxAttempts)))
+		require.Len(t, repo.updates, 1)
+		assert.Equal(t, entity.WebhookDeliveryFailed, repo.updates[0].Status)
+	})
+
+	t.Run("4xx is permanent", func(t *testing.T) {
+		handler, repo, _ := setup(t, http.StatusBadRequest)
+		err := handler.Handle(context.Background(), newJob(1))
+		assert.True(t, jobs.IsPermanent(err))
+		require.Len(t, repo.updates, 1)
+		assert.Equal(t, entity.WebhookDeliveryFailed, repo.updates[0].Status)
+	})
+
+	t.Run("429 is retried", func(t *testing.T) {
+		handler, _, _ := setup(t, http.StatusTooManyRequests)
+		err := handler.Handle(context.Background(), newJob(1))
+		require.Error(t, err)
+		assert.False(t, jobs.IsPermanent(err))
+	})
+
+	t.Run("missing delivery is dropped", func(t *testing.T) {
+		handler, repo, _ := setup(t, http.StatusOK)
+		repo.delivery = nil
+		err := handler.Handle(context.Background(), newJob(1))
+		assert.True(t, jobs.IsPermanent(err))
+		assert.Empty(t, repo.updates)
+	})
+}
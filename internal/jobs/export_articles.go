@@ -0,0 +1,307 @@
+package jobs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// DefaultExportMaxRowsPerObject caps the rows of one exported object; a
+// larger window is split into numbered parts.
+const DefaultExportMaxRowsPerObject = 10000
+
+// DefaultExportSafetyLag is how far before the cron tick a window ends by
+// default. It must exceed the longest transaction that stores or
+// summarizes articles: a row stamped before the window's end but committed
+// after the export read it would otherwise never be exported.
+const DefaultExportSafetyLag = 5 * time.Minute
+
+// exportPageSize is how many articles one ListChanged query reads.
+const exportPageSize = 500
+
+// ObjectStore is where export_articles writes. Satisfied by
+// *objectstore.S3.
+type ObjectStore interface {
+	Put(ctx context.Context, key string, body []byte, contentType string) error
+	Delete(ctx context.Context, key string) error
+}
+
+// ExportArticlesHandler handles 'export_articles': the articles stored or
+// summarized since the previous run are written to object storage as
+// NDJSON, one article per line, so analytics can read them without
+// database access. The window runs from the previous run's end to
+// SafetyLag before the job's creation (the cron tick), so late commits
+// land in the next window, and the object keys derive from the window's
+// end, so a retry overwrites the objects of a failed attempt instead of
+// leaving strays. The first run exports every article. Runs are recorded
+// in article_exports; with RetentionDays the objects of older runs are
+// deleted.
+type ExportArticlesHandler struct {
+	Articles repository.ArticleExportRepository
+	// Store is nil when EXPORT_BUCKET is unset; a leftover job then fails
+	// permanently.
+	Store ObjectStore
+	// Prefix is prepended to every key ("catchup-feed/" writes
+	// catchup-feed/articles/dt=2026-10-15/...).
+	Prefix string
+	// Gzip compresses each object (.ndjson.gz).
+	Gzip bool
+	// RetentionDays deletes the objects of runs older than that many days;
+	// 0 keeps them forever.
+	RetentionDays    int
+	MaxRowsPerObject int           // 0 = DefaultExportMaxRowsPerObject
+	SafetyLag        time.Duration // 0 = DefaultExportSafetyLag
+	Logger           *slog.Logger
+	Now              func() time.Time // nil = time.Now; used when the job has no created_at
+}
+
+// exportRow is one NDJSON line: the fields of GET /articles/export plus
+// the summary's provider and time. Missing values are null.
+type exportRow struct {
+	ID              int64      `json:"id"`
+	SourceID        int64      `json:"source_id"`
+	SourceName      string     `json:"source_name"`
+	Title           string     `json:"title"`
+	URL             string     `json:"url"`
+	Summary         *string    `json:"summary"`
+	SummaryProvider *string    `json:"summary_provider"`
+	PublishedAt     *time.Time `json:"published_at"`
+	CrawledAt       time.Time  `json:"crawled_at"`
+	SummarizedAt    *time.Time `json:"summarized_at"`
+}
+
+// Handle exports the window, records the run and then deletes expired
+// runs. A window already recorded (a retry after the record) only runs the
+// cleanup.
+func (h *ExportArticlesHandler) Handle(ctx context.Context, job *entity.Job) error {
+	logger := h.logger().With(slog.Int64("job_id", job.ID))
+	if h.Store == nil {
+		return Permanent(errors.New("export_articles: EXPORT_BUCKET is not set"))
+	}
+	until := job.CreatedAt
+	if until.IsZero() {
+		until = h.now()
+	}
+	until = until.Add(-h.safetyLag()).UTC()
+	from, err := h.Articles.LastExportedUntil(ctx)
+	if err != nil {
+		return fmt.Errorf("export_articles: %w", err)
+	}
+
+	if from.Before(until) {
+		start := time.Now()
+		export, err := h.export(ctx, from, until)
+		if err != nil {
+			return err
+		}
+		logger.Info("jobs: articles exported",
+			slog.Time("from", from),
+			slog.Time("until", until),
+			slog.Int("rows", export.Rows),
+			slog.Any("objects", export.ObjectKeys),
+			slog.Duration("duration", time.Since(start)))
+	} else {
+		logger.Info("jobs: export window already recorded, skipping", slog.Time("until", until))
+	}
+	h.deleteExpired(ctx, logger)
+	return nil
+}
+
+// export writes [from, until) in parts of MaxRowsPerObject rows and records
+// the run. A window without articles uploads nothing but is still
+// recorded, so the next run starts at until.
+func (h *ExportArticlesHandler) export(ctx context.Context, from, until time.Time) (*repository.ArticleExport, error) {
+	export := &repository.ArticleExport{From: from, To: until, ObjectKeys: []string{}}
+	part := h.newPart()
+	flush := func() error {
+		key := h.objectKey(until, len(export.ObjectKeys)+1)
+		body, err := part.close()
+		if err != nil {
+			return fmt.Errorf("export_articles: encode %s: %w", key, err)
+		}
+		if err := h.Store.Put(ctx, key, body, h.contentType()); err != nil {
+			return fmt.Errorf("export_articles: upload %s: %w", key, err)
+		}
+		export.ObjectKeys = append(export.ObjectKeys, key)
+		part = h.newPart()
+		return nil
+	}
+
+	var afterID int64
+	for {
+		page, err := h.Articles.ListChanged(ctx, from, until, afterID, exportPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("export_articles: %w", err)
+		}
+		for _, a := range page {
+			if err := part.write(exportRow{
+				ID:              a.ID,
+				SourceID:        a.SourceID,
+				SourceName:      a.SourceName,
+				Title:           a.Title,
+				URL:             a.URL,
+				Summary:         a.Summary,
+				SummaryProvider: a.SummaryProvider,
+				PublishedAt:     a.PublishedAt,
+				CrawledAt:       a.CrawledAt,
+				SummarizedAt:    a.SummarizedAt,
+			}); err != nil {
+				return nil, fmt.Errorf("export_articles: encode article %d: %w", a.ID, err)
+			}
+			export.Rows++
+			if part.rows == h.maxRowsPerObject() {
+				if err := flush(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if len(page) < exportPageSize {
+			break
+		}
+		afterID = page[len(page)-1].ID
+	}
+	if part.rows > 0 {
+		if err := flush(); err != nil {
+			return nil, err
+		}
+	}
+	if err := h.Articles.Record(ctx, export); err != nil {
+		return nil, fmt.Errorf("export_articles: %w", err)
+	}
+	return export, nil
+}
+
+// deleteExpired removes the objects and records of runs past
+// RetentionDays. Failures are only logged: the records stay, so the next
+// run tries again.
+func (h *ExportArticlesHandler) deleteExpired(ctx context.Context, logger *slog.Logger) {
+	if h.RetentionDays <= 0 {
+		return
+	}
+	expired, err := h.Articles.ListExpired(ctx, h.now().AddDate(0, 0, -h.RetentionDays))
+	if err != nil {
+		logger.Warn("jobs: failed to list expired exports", slog.Any("error", err))
+		return
+	}
+	var ids []int64
+	deleted := 0
+runs:
+	for _, run := range expired {
+		for _, key := range run.ObjectKeys {
+			if err := h.Store.Delete(ctx, key); err != nil {
+				logger.Warn("jobs: failed to delete expired export", slog.String("key", key), slog.Any("error", err))
+				continue runs
+			}
+			deleted++
+		}
+		ids = append(ids, run.ID)
+	}
+	if len(ids) == 0 {
+		return
+	}
+	if err := h.Articles.Delete(ctx, ids); err != nil {
+		logger.Warn("jobs: failed to delete expired export records", slog.Any("error", err))
+		return
+	}
+	logger.Info("jobs: expired exports deleted",
+		slog.Int("runs", len(ids)),
+		slog.Int("objects", deleted),
+		slog.Int("retention_days", h.RetentionDays))
+}
+
+// objectKey is <Prefix>articles/dt=<date>/articles-<until>-<part>.ndjson,
+// Hive-style partitioned by the UTC date of the window's end.
+func (h *ExportArticlesHandler) objectKey(until time.Time, part int) string {
+	prefix := strings.Trim(h.Prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	key := fmt.Sprintf("%sarticles/dt=%s/articles-%s-%04d.ndjson",
+		prefix, until.Format("2006-01-02"), until.Format("20060102T150405Z"), part)
+	if h.Gzip {
+		key += ".gz"
+	}
+	return key
+}
+
+func (h *ExportArticlesHandler) contentType() string {
+	if h.Gzip {
+		return "application/gzip"
+	}
+	return "application/x-ndjson"
+}
+
+// exportPart buffers one object.
+type exportPart struct {
+	buf  bytes.Buffer
+	gz   *gzip.Writer
+	enc  *json.Encoder
+	rows int
+}
+
+func (h *ExportArticlesHandler) newPart() *exportPart {
+	p := &exportPart{}
+	var w io.Writer = &p.buf
+	if h.Gzip {
+		p.gz = gzip.NewWriter(&p.buf)
+		w = p.gz
+	}
+	p.enc = json.NewEncoder(w)
+	p.enc.SetEscapeHTML(false)
+	return p
+}
+
+func (p *exportPart) write(row exportRow) error {
+	if err := p.enc.Encode(row); err != nil {
+		return err
+	}
+	p.rows++
+	return nil
+}
+
+func (p *exportPart) close() ([]byte, error) {
+	if p.gz != nil {
+		if err := p.gz.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return p.buf.Bytes(), nil
+}
+
+func (h *ExportArticlesHandler) maxRowsPerObject() int {
+	if h.MaxRowsPerObject > 0 {
+		return h.MaxRowsPerObject
+	}
+	return DefaultExportMaxRowsPerObject
+}
+
+func (h *ExportArticlesHandler) safetyLag() time.Duration {
+	if h.SafetyLag > 0 {
+		return h.SafetyLag
+	}
+	return DefaultExportSafetyLag
+}
+
+func (h *ExportArticlesHandler) now() time.Time {
+	if h.Now != nil {
+		return h.Now()
+	}
+	return time.Now()
+}
+
+func (h *ExportArticlesHandler) logger() *slog.Logger {
+	if h.Logger != nil {
+		return h.Logger
+	}
+	return slog.Default()
+}
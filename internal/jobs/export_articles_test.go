@@ -0,0 +1,186 @@
+package jobs_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/jobs"
+	"catchup-feed/internal/repository"
+)
+
+// fakeExportArticles serves articles 1..n, every one changed in the window.
+type fakeExportArticles struct {
+	n        int64
+	until    time.Time
+	recorded []*repository.ArticleExport
+	expired  []repository.ArticleExport
+	deleted  []int64
+}
+
+func (f *fakeExportArticles) LastExportedUntil(context.Context) (time.Time, error) {
+	return f.until, nil
+}
+
+func (f *fakeExportArticles) ListChanged(_ context.Context, _, _ time.Time, afterID int64, limit int) ([]repository.ExportedArticle, error) {
+	var out []repository.ExportedArticle
+	summary := "要約 & まとめ"
+	for id := afterID + 1; id <= f.n && len(out) < limit; id++ {
+		a := repository.ExportedArticle{ID: id, SourceID: 1, SourceName: "Go Blog", Title: "t", URL: "https://go.dev/?a=1&b=2"}
+		if id%2 == 0 {
+			a.Summary = &summary
+		}
+		out = append(out, a)
+	}
+	return out, nil
+}
+
+func (f *fakeExportArticles) Record(_ context.Context, export *repository.ArticleExport) error {
+	f.recorded = append(f.recorded, export)
+	f.until = export.To
+	return nil
+}
+
+func (f *fakeExportArticles) ListExpired(context.Context, time.Time) ([]repository.ArticleExport, error) {
+	return f.expired, nil
+}
+
+func (f *fakeExportArticles) Delete(_ context.Context, ids []int64) error {
+	f.deleted = append(f.deleted, ids...)
+	return nil
+}
+
+type fakeObjectStore struct {
+	objects map[string][]byte
+	deleted []string
+	putErr  error
+	delErr  map[string]error
+}
+
+func (f *fakeObjectStore) Put(_ context.Context, key string, body []byte, _ string) error {
+	if f.putErr != nil {
+		return f.putErr
+	}
+	f.objects[key] = body
+	return nil
+}
+
+func (f *fakeObjectStore) Delete(_ context.Context, key string) error {
+	if err := f.delErr[key]; err != nil {
+		return err
+	}
+	f.deleted = append(f.deleted, key)
+	return nil
+}
+
+func TestExportArticlesHandler_Handle(t *testing.T) {
+	tick := time.Date(2026, 10, 15, 7, 30, 0, 0, time.UTC)
+	job := &entity.Job{ID: 3, Kind: entity.JobKindExportArticles, CreatedAt: tick}
+	windowEnd := tick.Add(-jobs.DefaultExportSafetyLag)
+
+	t.Run("splits the window into parts and records the run", func(t *testing.T) {
+		articles := &fakeExportArticles{n: 1203}
+		store := &fakeObjectStore{objects: map[string][]byte{}}
+		handler := &jobs.ExportArticlesHandler{Articles: articles, Store: store, Prefix: "/catchup-feed/", Gzip: true, MaxRowsPerObject: 1000}
+
+		require.NoError(t, handler.Handle(context.Background(), job))
+		require.Len(t, articles.recorded, 1)
+		run := articles.recorded[0]
+		assert.True(t, run.From.IsZero(), "the first run exports everything")
+		assert.Equal(t, windowEnd, run.To, "the window ends SafetyLag before the tick")
+		assert.Equal(t, 1203, run.Rows)
+		assert.Equal(t, []string{
+			"catchup-feed/articles/dt=2026-10-15/articles-20261015T072500Z-0001.ndjson.gz",
+			"catchup-feed/articles/dt=2026-10-15/articles-20261015T072500Z-0002.ndjson.gz",
+		}, run.ObjectKeys)
+
+		zr, err := gzip.NewReader(bytes.NewReader(store.objects[run.ObjectKeys[1]]))
+		require.NoError(t, err)
+		raw, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+		require.Len(t, lines, 203)
+		assert.Contains(t, lines[0], `"url":"https://go.dev/?a=1&b=2"`, "no HTML escaping")
+		var row map[string]any
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &row))
+		assert.Equal(t, float64(1001), row["id"])
+		assert.Nil(t, row["summary"])
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &row))
+		assert.Equal(t, "要約 & まとめ", row["summary"])
+
+		// 同じ tick の再実行(記録後のリトライ)は何も書かない
+		store.objects = map[string][]byte{}
+		require.NoError(t, handler.Handle(context.Background(), job))
+		assert.Len(t, articles.recorded, 1)
+		assert.Empty(t, store.objects)
+	})
+
+	t.Run("an empty window is recorded without objects", func(t *testing.T) {
+		articles := &fakeExportArticles{until: tick.Add(-time.Hour)}
+		store := &fakeObjectStore{objects: map[string][]byte{}}
+		handler := &jobs.ExportArticlesHandler{Articles: articles, Store: store}
+
+		require.NoError(t, handler.Handle(context.Background(), job))
+		require.Len(t, articles.recorded, 1)
+		assert.Equal(t, tick.Add(-time.Hour), articles.recorded[0].From)
+		assert.Empty(t, articles.recorded[0].ObjectKeys)
+		assert.Empty(t, store.objects)
+	})
+
+	t.Run("a failed upload records nothing", func(t *testing.T) {
+		articles := &fakeExportArticles{n: 2}
+		store := &fakeObjectStore{objects: map[string][]byte{}, putErr: errors.New("status 503")}
+		handler := &jobs.ExportArticlesHandler{Articles: articles, Store: store}
+
+		err := handler.Handle(context.Background(), job)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "articles-20261015T072500Z-0001.ndjson")
+		assert.Empty(t, articles.recorded)
+	})
+
+	t.Run("deletes expired runs whose objects are gone", func(t *testing.T) {
+		articles := &fakeExportArticles{
+			until: windowEnd,
+			expired: []repository.ArticleExport{
+				{ID: 1, ObjectKeys: []string{"a-1", "a-2"}},
+				{ID: 2, ObjectKeys: []string{"b-1"}},
+				{ID: 3, ObjectKeys: []string{}},
+			},
+		}
+		store := &fakeObjectStore{delErr: map[string]error{"b-1": errors.New("status 500")}}
+		handler := &jobs.ExportArticlesHandler{Articles: articles, Store: store, RetentionDays: 30}
+
+		require.NoError(t, handler.Handle(context.Background(), job))
+		assert.Equal(t, []string{"a-1", "a-2"}, store.deleted)
+		assert.Equal(t, []int64{1, 3}, articles.deleted, "run 2 is retried next time")
+	})
+
+	t.Run("rows committed after the window's end wait for the next run", func(t *testing.T) {
+		articles := &fakeExportArticles{n: 1}
+		store := &fakeObjectStore{objects: map[string][]byte{}}
+		handler := &jobs.ExportArticlesHandler{Articles: articles, Store: store, SafetyLag: time.Minute}
+
+		require.NoError(t, handler.Handle(context.Background(), job))
+		require.NoError(t, handler.Handle(context.Background(), &entity.Job{ID: 4, Kind: entity.JobKindExportArticles, CreatedAt: tick.Add(24 * time.Hour)}))
+		require.Len(t, articles.recorded, 2)
+		assert.Equal(t, tick.Add(-time.Minute), articles.recorded[0].To)
+		assert.Equal(t, articles.recorded[0].To, articles.recorded[1].From, "windows are contiguous")
+		assert.Equal(t, tick.Add(24*time.Hour-time.Minute), articles.recorded[1].To)
+	})
+
+	t.Run("without a bucket the job fails permanently", func(t *testing.T) {
+		handler := &jobs.ExportArticlesHandler{Articles: &fakeExportArticles{}}
+		err := handler.Handle(context.Background(), job)
+		assert.True(t, jobs.IsPermanent(err))
+	})
+}
@@ -0,0 +1,57 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/notify"
+)
+
+// NotifyAlertHandler handles 'notify_alert': one keyword alert hit, sent
+// to every admin destination with the rule name in front of the article
+// title. Failures are joined and returned like notify_digest: a retry
+// re-sends to every channel.
+type NotifyAlertHandler struct {
+	Destinations []notify.Destination
+	Logger       *slog.Logger
+}
+
+// Handle sends the alert. A malformed payload is permanent.
+func (h *NotifyAlertHandler) Handle(ctx context.Context, job *entity.Job) error {
+	logger := h.logger().With(slog.Int64("job_id", job.ID))
+
+	var payload entity.NotifyAlertPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return Permanent(fmt.Errorf("notify_alert: invalid payload: %w", err))
+	}
+	msg := notify.Message{
+		Kind:     notify.MessageKindAlert,
+		Subject:  fmt.Sprintf("[%s] %s", payload.RuleName, payload.Article.Title),
+		Body:     payload.Article.Summary,
+		Link:     payload.Article.URL,
+		ImageURL: payload.Article.ImageURL,
+	}
+	var errs []error
+	for _, destination := range h.Destinations {
+		if err := destination.Notify(ctx, msg); err != nil {
+			errs = append(errs, fmt.Errorf("notify_alert: %s: %w", destination.Name(), err))
+			continue
+		}
+		logger.Info("jobs: keyword alert delivered",
+			slog.String("channel", destination.Name()),
+			slog.Int64("rule_id", payload.RuleID),
+			slog.Int64("article_id", payload.Article.ID))
+	}
+	return errors.Join(errs...)
+}
+
+func (h *NotifyAlertHandler) logger() *slog.Logger {
+	if h.Logger != nil {
+		return h.Logger
+	}
+	return slog.Default()
+}
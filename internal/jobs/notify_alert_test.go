@@ -0,0 +1,56 @@
+package jobs_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/jobs"
+	"catchup-feed/internal/notify"
+)
+
+func TestNotifyAlertHandler_Handle(t *testing.T) {
+	newJob := func(payload string) *entity.Job {
+		return &entity.Job{ID: 9, Kind: entity.JobKindNotifyAlert, Payload: json.RawMessage(payload)}
+	}
+	const payload = `{"rule_id":3,"rule_name":"Go","article":{"id":10,"title":"Go 1.23","url":"https://example.com/go","summary":"リリース","image_url":"https://example.com/og.png"}}`
+
+	t.Run("tags the article with the rule name", func(t *testing.T) {
+		discord := &fakeDestination{name: "discord"}
+		slack := &fakeDestination{name: "slack"}
+		handler := &jobs.NotifyAlertHandler{Destinations: []notify.Destination{discord, slack}, Logger: slog.New(slog.DiscardHandler)}
+		require.NoError(t, handler.Handle(context.Background(), newJob(payload)))
+
+		for _, destination := range []*fakeDestination{discord, slack} {
+			require.Len(t, destination.got, 1)
+			assert.Equal(t, notify.Message{
+				Kind:     notify.MessageKindAlert,
+				Subject:  "[Go] Go 1.23",
+				Body:     "リリース",
+				Link:     "https://example.com/go",
+				ImageURL: "https://example.com/og.png",
+			}, destination.got[0])
+		}
+	})
+
+	t.Run("delivery failure is returned for retry", func(t *testing.T) {
+		handler := &jobs.NotifyAlertHandler{
+			Destinations: []notify.Destination{&fakeDestination{name: "slack", err: errors.New("webhook down")}},
+			Logger:       slog.New(slog.DiscardHandler),
+		}
+		err := handler.Handle(context.Background(), newJob(payload))
+		require.Error(t, err)
+		assert.False(t, jobs.IsPermanent(err))
+	})
+
+	t.Run("malformed payload is permanent", func(t *testing.T) {
+		handler := &jobs.NotifyAlertHandler{Logger: slog.New(slog.DiscardHandler)}
+		assert.True(t, jobs.IsPermanent(handler.Handle(context.Background(), newJob(`not json`))))
+	})
+}
@@ -0,0 +1,207 @@
+package jobs
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/notify"
+	"catchup-feed/internal/repository"
+	artUC "catchup-feed/internal/usecase/article"
+)
+
+// Digest modes (DIGEST_MODE).
+const (
+	// DigestModeDaily covers the articles of the past 24 hours.
+	DigestModeDaily = "daily"
+	// DigestModeWeekly covers the articles of the past 7 days.
+	DigestModeWeekly = "weekly"
+)
+
+// DefaultDigestMaxItems caps the articles listed in one digest; the rest
+// are only counted. Discord and Slack truncate long messages anyway.
+const DefaultDigestMaxItems = 20
+
+// DefaultDigestMaxTopics caps the topics the digest opens with.
+const DefaultDigestMaxTopics = 5
+
+// DigestPeriod returns the window a digest mode covers, or 0 for an
+// unknown mode.
+func DigestPeriod(mode string) time.Duration {
+	switch mode {
+	case DigestModeDaily:
+		return 24 * time.Hour
+	case DigestModeWeekly:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+// NotifyDigestHandler handles 'notify_digest': one message per admin
+// destination listing the articles stored during the past period, grouped
+// by source, instead of nothing at all between radio episodes. The
+// articles of the period are first clustered into topics
+// (artUC.ClusterWithSource, the clusters of GET /articles/highlights), and
+// the digest opens with the largest ones — stories several articles
+// covered — each under its representative article. The window
+// ends at the job's creation (the cron tick), so a retry sends the same
+// articles. Failures are joined and returned like notify_episode: a retry
+// re-sends to every channel.
+type NotifyDigestHandler struct {
+	Articles     repository.ArticleDigestRepository
+	Destinations []notify.Destination
+	Mode         string // DigestModeDaily | DigestModeWeekly
+	MaxItems     int    // 0 = DefaultDigestMaxItems
+	MaxTopics    int    // 0 = DefaultDigestMaxTopics
+	// Location is the timezone of the date in the subject (the worker's
+	// WORKER_TIMEZONE); nil = time.Local.
+	Location *time.Location
+	Logger   *slog.Logger
+	Now      func() time.Time // nil = time.Now; used when the job has no created_at
+}
+
+// Handle builds and sends the digest. An empty period sends nothing.
+func (h *NotifyDigestHandler) Handle(ctx context.Context, job *entity.Job) error {
+	logger := h.logger().With(slog.Int64("job_id", job.ID))
+
+	period := DigestPeriod(h.Mode)
+	if period == 0 {
+		return Permanent(fmt.Errorf("notify_digest: unknown mode %q", h.Mode))
+	}
+	maxItems := h.MaxItems
+	if maxItems <= 0 {
+		maxItems = DefaultDigestMaxItems
+	}
+	maxTopics := h.MaxTopics
+	if maxTopics <= 0 {
+		maxTopics = DefaultDigestMaxTopics
+	}
+	to := job.CreatedAt
+	if to.IsZero() {
+		to = h.now()
+	}
+	from := to.Add(-period)
+
+	// Topics are clustered over more articles than are listed, like the
+	// highlights API.
+	clustered, total, err := h.Articles.ListStoredBetween(ctx, from, to, max(maxItems, artUC.MaxHighlightArticles))
+	if err != nil {
+		return fmt.Errorf("notify_digest: %w", err)
+	}
+	if total == 0 {
+		logger.Info("jobs: no new articles, digest skipped", slog.String("mode", h.Mode))
+		return nil
+	}
+	articles := clustered[:min(maxItems, len(clustered))]
+
+	msg := notify.Message{
+		Kind:    notify.MessageKindDigest,
+		Subject: h.subject(to, total),
+		Body:    digestTopics(artUC.ClusterWithSource(clustered), maxTopics) + digestBody(articles, total),
+	}
+	// The thumbnail is the newest listed article that has one.
+	for _, a := range articles {
+		if a.Article.ImageURL != "" {
+			msg.ImageURL = a.Article.ImageURL
+			break
+		}
+	}
+	var errs []error
+	for _, destination := range h.Destinations {
+		if err := destination.Notify(ctx, msg); err != nil {
+			errs = append(errs, fmt.Errorf("notify_digest: %s: %w", destination.Name(), err))
+			continue
+		}
+		logger.Info("jobs: digest delivered",
+			slog.String("channel", destination.Name()),
+			slog.String("mode", h.Mode),
+			slog.Int("listed", len(articles)),
+			slog.Int64("total", total))
+	}
+	return errors.Join(errs...)
+}
+
+func (h *NotifyDigestHandler) subject(at time.Time, total int64) string {
+	loc := h.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	label := "デイリー"
+	if h.Mode == DigestModeWeekly {
+		label = "ウィークリー"
+	}
+	return fmt.Sprintf("catchup-feed %sダイジェスト %s (%d 件)", label, at.In(loc).Format("2006-01-02"), total)
+}
+
+// digestTopics renders the topics covered by more than one article, up to
+// maxTopics, each as its representative article and the number of related
+// ones; "" when there are none.
+func digestTopics(topics []artUC.Topic, maxTopics int) string {
+	var sb strings.Builder
+	n := 0
+	for _, topic := range topics {
+		// topics come largest first, so the rest are single articles too
+		if n == maxTopics || len(topic.Articles) < 2 {
+			break
+		}
+		if n == 0 {
+			sb.WriteString("■ 主なトピック\n")
+		}
+		n++
+		rep := topic.Representative
+		fmt.Fprintf(&sb, "・%s (%s ほか %d 件)\n  %s\n", rep.Article.Title, rep.SourceName, len(topic.Articles)-1, rep.Article.URL)
+	}
+	if n > 0 {
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// digestBody lists the articles grouped by source (sources by name,
+// articles newest first) and counts those past the cap.
+func digestBody(articles []repository.ArticleWithSource, total int64) string {
+	groups := map[string][]*entity.Article{}
+	var sources []string
+	for _, a := range articles {
+		if _, ok := groups[a.SourceName]; !ok {
+			sources = append(sources, a.SourceName)
+		}
+		groups[a.SourceName] = append(groups[a.SourceName], a.Article)
+	}
+	slices.SortFunc(sources, cmp.Compare)
+
+	var sb strings.Builder
+	for i, source := range sources {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "■ %s (%d)\n", source, len(groups[source]))
+		for _, a := range groups[source] {
+			fmt.Fprintf(&sb, "・%s\n  %s\n", a.Title, a.URL)
+		}
+	}
+	if rest := total - int64(len(articles)); rest > 0 {
+		fmt.Fprintf(&sb, "\nほか %d 件\n", rest)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func (h *NotifyDigestHandler) now() time.Time {
+	if h.Now != nil {
+		return h.Now()
+	}
+	return time.Now()
+}
+
+func (h *NotifyDigestHandler) logger() *slog.Logger {
+	if h.Logger != nil {
+		return h.Logger
+	}
+	return slog.Default()
+}
@@ -0,0 +1,181 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/jobs"
+	"catchup-feed/internal/notify"
+	"catchup-feed/internal/repository"
+	artUC "catchup-feed/internal/usecase/article"
+)
+
+type fakeDigestArticles struct {
+	articles []repository.ArticleWithSource
+	total    int64
+	err      error
+
+	from, to time.Time
+	limit    int
+}
+
+func (f *fakeDigestArticles) ListStoredBetween(_ context.Context, from, to time.Time, limit int) ([]repository.ArticleWithSource, int64, error) {
+	f.from, f.to, f.limit = from, to, limit
+	return f.articles, f.total, f.err
+}
+
+func digestArticle(id int64, source, title string) repository.ArticleWithSource {
+	return repository.ArticleWithSource{
+		Article:    &entity.Article{ID: id, Title: title, URL: "https://example.com/" + title},
+		SourceName: source,
+	}
+}
+
+func TestNotifyDigestHandler_Handle(t *testing.T) {
+	tick := time.Date(2026, 10, 14, 23, 0, 0, 0, time.UTC) // 2026-10-15 08:00 JST
+	jst := time.FixedZone("JST", 9*60*60)
+	job := &entity.Job{ID: 7, Kind: entity.JobKindNotifyDigest, CreatedAt: tick}
+
+	t.Run("groups by source and counts articles past the cap", func(t *testing.T) {
+		articles := &fakeDigestArticles{
+			articles: []repository.ArticleWithSource{
+				digestArticle(3, "Zenn", "c"),
+				digestArticle(2, "Go Blog", "b"),
+				digestArticle(1, "Zenn", "a"),
+			},
+			total: 5,
+		}
+		discord := &fakeDestination{name: "discord"}
+		slack := &fakeDestination{name: "slack"}
+		handler := &jobs.NotifyDigestHandler{
+			Articles:     articles,
+			Destinations: []notify.Destination{discord, slack},
+			Mode:         jobs.DigestModeDaily,
+			MaxItems:     3,
+			Location:     jst,
+			Logger:       slog.New(slog.DiscardHandler),
+		}
+		require.NoError(t, handler.Handle(context.Background(), job))
+
+		assert.Equal(t, tick.Add(-24*time.Hour), articles.from)
+		assert.Equal(t, tick, articles.to, "the window ends at the cron tick so retries resend the same digest")
+		assert.Equal(t, artUC.MaxHighlightArticles, articles.limit, "topics are clustered over more articles than are listed")
+
+		for _, destination := range []*fakeDestination{discord, slack} {
+			require.Len(t, destination.got, 1)
+			assert.Equal(t, "catchup-feed デイリーダイジェスト 2026-10-15 (5 件)", destination.got[0].Subject)
+			assert.Equal(t, "■ Go Blog (1)\n"+
+				"・b\n  https://example.com/b\n"+
+				"\n■ Zenn (2)\n"+
+				"・c\n  https://example.com/c\n"+
+				"・a\n  https://example.com/a\n"+
+				"\nほか 2 件", destination.got[0].Body)
+		}
+	})
+
+	t.Run("weekly window", func(t *testing.T) {
+		articles := &fakeDigestArticles{articles: []repository.ArticleWithSource{digestArticle(1, "Zenn", "a")}, total: 1}
+		discord := &fakeDestination{name: "discord"}
+		handler := &jobs.NotifyDigestHandler{
+			Articles:     articles,
+			Destinations: []notify.Destination{discord},
+			Mode:         jobs.DigestModeWeekly,
+			Location:     jst,
+			Logger:       slog.New(slog.DiscardHandler),
+		}
+		require.NoError(t, handler.Handle(context.Background(), job))
+
+		assert.Equal(t, tick.Add(-7*24*time.Hour), articles.from)
+		require.Len(t, discord.got, 1)
+		assert.Contains(t, discord.got[0].Subject, "ウィークリーダイジェスト")
+		assert.NotContains(t, discord.got[0].Body, "ほか")
+	})
+
+	t.Run("opens with topics covered by several articles", func(t *testing.T) {
+		article := func(id int64, source, title, summary string) repository.ArticleWithSource {
+			a := digestArticle(id, source, title)
+			a.Article.URL = fmt.Sprintf("https://example.com/%d", id)
+			a.Article.Summary = summary
+			return a
+		}
+		discord := &fakeDestination{name: "discord"}
+		handler := &jobs.NotifyDigestHandler{
+			Articles: &fakeDigestArticles{
+				articles: []repository.ArticleWithSource{
+					article(5, "Go Blog", "Go 1.30 released", "The Go team shipped Go 1.30 with a faster garbage collector."),
+					article(4, "Zenn", "PostgreSQL 19 beta", "PostgreSQL 19 beta adds asynchronous I/O."),
+					article(3, "Zenn", "What's in Go 1.30", "A tour of the Go 1.30 release: garbage collector and iterators."),
+					article(2, "Qiita", "Upgrading to Go 1.30", "Notes from upgrading our services to the Go 1.30 release."),
+					article(1, "Qiita", "生成AIのセキュリティ対策", "生成AIを業務で使う際の対策。"),
+				},
+				total: 5,
+			},
+			Destinations: []notify.Destination{discord},
+			Mode:         jobs.DigestModeWeekly,
+			MaxItems:     2,
+			Logger:       slog.New(slog.DiscardHandler),
+		}
+		require.NoError(t, handler.Handle(context.Background(), job))
+		require.Len(t, discord.got, 1)
+		body := discord.got[0].Body
+		assert.Regexp(t, `^■ 主なトピック\n・[^\n]*Go 1\.30[^\n]* \([^)]+ ほか 2 件\)\n  https://example\.com/[235]\n\n■ `, body)
+		assert.NotContains(t, body, "PostgreSQL 19 beta (", "a single article is not a topic")
+		assert.Contains(t, body, "ほか 3 件", "only MaxItems articles are listed by source")
+	})
+
+	t.Run("thumbnail is the newest article with an image", func(t *testing.T) {
+		withImage := digestArticle(2, "Go Blog", "b")
+		withImage.Article.ImageURL = "https://example.com/b.png"
+		discord := &fakeDestination{name: "discord"}
+		handler := &jobs.NotifyDigestHandler{
+			Articles: &fakeDigestArticles{
+				articles: []repository.ArticleWithSource{digestArticle(3, "Zenn", "c"), withImage, digestArticle(1, "Zenn", "a")},
+				total:    3,
+			},
+			Destinations: []notify.Destination{discord},
+			Mode:         jobs.DigestModeDaily,
+			Logger:       slog.New(slog.DiscardHandler),
+		}
+		require.NoError(t, handler.Handle(context.Background(), job))
+		require.Len(t, discord.got, 1)
+		assert.Equal(t, "https://example.com/b.png", discord.got[0].ImageURL)
+	})
+
+	t.Run("no articles sends nothing", func(t *testing.T) {
+		discord := &fakeDestination{name: "discord"}
+		handler := &jobs.NotifyDigestHandler{
+			Articles:     &fakeDigestArticles{},
+			Destinations: []notify.Destination{discord},
+			Mode:         jobs.DigestModeDaily,
+			Logger:       slog.New(slog.DiscardHandler),
+		}
+		require.NoError(t, handler.Handle(context.Background(), job))
+		assert.Empty(t, discord.got)
+	})
+
+	t.Run("delivery failure is returned for retry", func(t *testing.T) {
+		handler := &jobs.NotifyDigestHandler{
+			Articles:     &fakeDigestArticles{articles: []repository.ArticleWithSource{digestArticle(1, "Zenn", "a")}, total: 1},
+			Destinations: []notify.Destination{&fakeDestination{name: "slack", err: errors.New("webhook down")}},
+			Mode:         jobs.DigestModeDaily,
+			Logger:       slog.New(slog.DiscardHandler),
+		}
+		err := handler.Handle(context.Background(), job)
+		require.Error(t, err)
+		assert.False(t, jobs.IsPermanent(err))
+	})
+
+	t.Run("unknown mode is permanent", func(t *testing.T) {
+		handler := &jobs.NotifyDigestHandler{Articles: &fakeDigestArticles{}, Mode: "hourly", Logger: slog.New(slog.DiscardHandler)}
+		err := handler.Handle(context.Background(), job)
+		assert.True(t, jobs.IsPermanent(err))
+	})
+}
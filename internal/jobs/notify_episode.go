@@ -89,6 +89,7 @@ func (h *NotifyEpisodeHandler) Handle(ctx context.Context, job *entity.Job) erro
 	}
 
 	msg := notify.Message{
+		Kind:    notify.MessageKindEpisode,
 		Subject: episode.Title,
 		Body:    episode.ShowNotes,
 	}
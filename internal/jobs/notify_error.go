@@ -49,6 +49,7 @@ func (h *NotifyErrorHandler) Handle(ctx context.Context, job *entity.Job) error
 		payload.Source = "unknown"
 	}
 	msg := notify.Message{
+		Kind:    notify.MessageKindError,
 		Subject: fmt.Sprintf("catchup-feed 障害: %s の実行が失敗しました", payload.Source),
 		Body:    payload.Message,
 	}
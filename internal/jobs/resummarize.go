@@ -0,0 +1,87 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"catchup-feed/internal/domain/entity"
+	fetchUC "catchup-feed/internal/usecase/fetch"
+)
+
+// Sweeper is the slice of the fetch service the resummarize job needs.
+// Satisfied by *fetch.Service.
+type Sweeper interface {
+	SweepUnsummarized(ctx context.Context) (*fetchUC.SweepStats, error)
+	SummarizeArticle(ctx context.Context, articleID int64) (*entity.Summary, error)
+}
+
+// ResummarizeHandler handles 'resummarize'. Without an article_id it is
+// the §5.2b summary sweep: summarize articles whose content was filled in
+// after insert (transcribe path). Articles whose summarization fails are
+// left in place for the next sweep; only a failed sweep itself (e.g. the
+// candidate query) is retried through the queue.
+//
+// With an article_id (POST /articles/{id}/summarize) that one article is
+// summarized again; a failed summarization is retried through the queue,
+// and an article that is gone or has no content fails the job for good.
+type ResummarizeHandler struct {
+	Sweeper Sweeper
+	Logger  *slog.Logger
+}
+
+// Handle runs one sweep, or re-summarizes the article of the payload.
+func (h *ResummarizeHandler) Handle(ctx context.Context, job *entity.Job) error {
+	var payload entity.ResummarizePayload
+	if len(job.Payload) > 0 {
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return Permanent(fmt.Errorf("resummarize: invalid payload: %w", err))
+		}
+	}
+	if payload.ArticleID < 0 {
+		return Permanent(fmt.Errorf("resummarize: invalid article_id %d", payload.ArticleID))
+	}
+	if payload.ArticleID > 0 {
+		return h.summarizeArticle(ctx, job, payload.ArticleID)
+	}
+
+	stats, err := h.Sweeper.SweepUnsummarized(ctx)
+	if err != nil {
+		return fmt.Errorf("resummarize: %w", err)
+	}
+	if stats.Candidates == 0 {
+		return nil // the common case: nothing transcribed since last cycle
+	}
+	h.logger().InfoContext(ctx, "resummarize: summary sweep completed",
+		slog.Int64("job_id", job.ID),
+		slog.Int("candidates", stats.Candidates),
+		slog.Int64("summarized", stats.Summarized),
+		slog.Int64("failed", stats.Failed),
+		slog.Bool("limit_hit", stats.LimitHit),
+		slog.Duration("duration", stats.Duration))
+	return nil
+}
+
+func (h *ResummarizeHandler) summarizeArticle(ctx context.Context, job *entity.Job, articleID int64) error {
+	sum, err := h.Sweeper.SummarizeArticle(ctx, articleID)
+	if errors.Is(err, fetchUC.ErrArticleNotFound) || errors.Is(err, fetchUC.ErrNoContent) {
+		return Permanent(fmt.Errorf("resummarize article %d: %w", articleID, err))
+	}
+	if err != nil {
+		return fmt.Errorf("resummarize article %d: %w", articleID, err)
+	}
+	h.logger().InfoContext(ctx, "resummarize: article summarized",
+		slog.Int64("job_id", job.ID),
+		slog.Int64("article_id", articleID),
+		slog.String("summary_provider", sum.Provider))
+	return nil
+}
+
+func (h *ResummarizeHandler) logger() *slog.Logger {
+	if h.Logger != nil {
+		return h.Logger
+	}
+	return slog.Default()
+}
@@ -0,0 +1,97 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/jobs"
+	fetchUC "catchup-feed/internal/usecase/fetch"
+)
+
+// fakeSweeper は SweepUnsummarized / SummarizeArticle の呼び出しを記録する。
+type fakeSweeper struct {
+	calls int
+	stats *fetchUC.SweepStats
+	err   error
+
+	articleIDs []int64
+	articleErr error
+}
+
+func (s *fakeSweeper) SummarizeArticle(_ context.Context, articleID int64) (*entity.Summary, error) {
+	s.articleIDs = append(s.articleIDs, articleID)
+	if s.articleErr != nil {
+		return nil, s.articleErr
+	}
+	return &entity.Summary{ArticleID: articleID, Body: "summary", Provider: "gemini"}, nil
+}
+
+func (s *fakeSweeper) SweepUnsummarized(context.Context) (*fetchUC.SweepStats, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.stats, nil
+}
+
+func TestResummarizeHandler_Handle(t *testing.T) {
+	job := &entity.Job{ID: 5, Kind: entity.JobKindResummarize}
+
+	t.Run("runs the sweep", func(t *testing.T) {
+		sweeper := &fakeSweeper{stats: &fetchUC.SweepStats{Candidates: 3, Summarized: 2, Failed: 1}}
+		h := &jobs.ResummarizeHandler{Sweeper: sweeper}
+		assert.NoError(t, h.Handle(context.Background(), job))
+		assert.Equal(t, 1, sweeper.calls)
+	})
+
+	t.Run("nothing to sweep", func(t *testing.T) {
+		h := &jobs.ResummarizeHandler{Sweeper: &fakeSweeper{stats: &fetchUC.SweepStats{}}}
+		assert.NoError(t, h.Handle(context.Background(), job))
+	})
+
+	t.Run("sweep error is retried", func(t *testing.T) {
+		h := &jobs.ResummarizeHandler{Sweeper: &fakeSweeper{err: errors.New("db down")}}
+		err := h.Handle(context.Background(), job)
+		assert.Error(t, err)
+		assert.False(t, jobs.IsPermanent(err))
+	})
+}
+
+func TestResummarizeHandler_Article(t *testing.T) {
+	job := &entity.Job{ID: 6, Kind: entity.JobKindResummarize, Payload: []byte(`{"article_id":42}`)}
+
+	t.Run("summarizes only that article", func(t *testing.T) {
+		sweeper := &fakeSweeper{}
+		h := &jobs.ResummarizeHandler{Sweeper: sweeper}
+		assert.NoError(t, h.Handle(context.Background(), job))
+		assert.Equal(t, []int64{42}, sweeper.articleIDs)
+		assert.Zero(t, sweeper.calls, "no sweep")
+	})
+
+	t.Run("summarizer failure is retried", func(t *testing.T) {
+		h := &jobs.ResummarizeHandler{Sweeper: &fakeSweeper{articleErr: fetchUC.ErrSummarizationFailed}}
+		err := h.Handle(context.Background(), job)
+		assert.Error(t, err)
+		assert.False(t, jobs.IsPermanent(err))
+	})
+
+	t.Run("missing article or content is permanent", func(t *testing.T) {
+		for _, cause := range []error{fetchUC.ErrArticleNotFound, fetchUC.ErrNoContent} {
+			h := &jobs.ResummarizeHandler{Sweeper: &fakeSweeper{articleErr: cause}}
+			err := h.Handle(context.Background(), job)
+			assert.True(t, jobs.IsPermanent(err), "%v", cause)
+		}
+	})
+
+	t.Run("invalid payload is permanent", func(t *testing.T) {
+		h := &jobs.ResummarizeHandler{Sweeper: &fakeSweeper{}}
+		for _, payload := range []string{`{`, `{"article_id":-1}`} {
+			bad := &entity.Job{ID: 7, Kind: entity.JobKindResummarize, Payload: []byte(payload)}
+			assert.True(t, jobs.IsPermanent(h.Handle(context.Background(), bad)), payload)
+		}
+	})
+}
@@ -0,0 +1,162 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// Retention modes (RETENTION_MODE).
+const (
+	// RetentionModeArchive moves expired articles to articles_archive.
+	RetentionModeArchive = "archive"
+	// RetentionModeDelete drops expired articles for good.
+	RetentionModeDelete = "delete"
+)
+
+// DefaultRetentionMaxPerRun bounds one purge_old_articles run; leftovers
+// wait for the next day's job so a first run against a large backlog does
+// not hold the database for long.
+const DefaultRetentionMaxPerRun = 5000
+
+// ArticleRetentionStore is the slice of the retention repository the job
+// needs. Satisfied by repository.ArticleRetentionRepository.
+type ArticleRetentionStore interface {
+	ListExpired(ctx context.Context, now time.Time, defaultDays, limit int) ([]repository.ExpiredArticle, error)
+	Purge(ctx context.Context, ids []int64, archive bool) ([]int64, error)
+}
+
+// RetentionStats is the outcome of one run. In a dry run Expired counts
+// what would have been purged and Purged stays 0.
+type RetentionStats struct {
+	Expired  int
+	Purged   int
+	BySource map[int64]int // purged (dry run: expired) per source
+	DryRun   bool
+	LimitHit bool
+}
+
+// RetentionHandler handles 'purge_old_articles': it archives or deletes
+// articles older than their source's retention_days, falling back to
+// DefaultDays. Favorited articles and articles used by radio segments or
+// learning items are never touched. Idempotent: a retry finds less to do.
+type RetentionHandler struct {
+	Articles ArticleRetentionStore
+	// DefaultDays is RETENTION_DAYS; 0 keeps the articles of sources
+	// without their own retention_days forever.
+	DefaultDays int
+	Mode        string // RetentionModeArchive (default) | RetentionModeDelete
+	// DryRun only counts and logs the articles a real run would purge.
+	DryRun    bool
+	MaxPerRun int // 0 = DefaultRetentionMaxPerRun
+	Logger    *slog.Logger
+	Now       func() time.Time // nil = time.Now
+}
+
+// Handle runs one retention pass and logs its RetentionStats.
+func (h *RetentionHandler) Handle(ctx context.Context, job *entity.Job) error {
+	logger := h.logger().With(slog.Int64("job_id", job.ID))
+	start := time.Now()
+
+	stats, err := h.Run(ctx)
+	if err != nil {
+		return err
+	}
+	logger.Info("retention: run completed",
+		slog.Bool("dry_run", stats.DryRun),
+		slog.String("mode", h.mode()),
+		slog.Int("default_days", h.DefaultDays),
+		slog.Int("expired", stats.Expired),
+		slog.Int("purged", stats.Purged),
+		slog.Any("by_source", stats.BySource),
+		slog.Bool("limit_hit", stats.LimitHit),
+		slog.Duration("duration", time.Since(start)))
+	return nil
+}
+
+// Run purges expired articles in purgeBatchLimit batches up to MaxPerRun.
+// A failed batch aborts the run; batches already committed stay purged.
+func (h *RetentionHandler) Run(ctx context.Context) (*RetentionStats, error) {
+	now := h.now()
+	stats := &RetentionStats{BySource: map[int64]int{}, DryRun: h.DryRun}
+
+	if h.DryRun {
+		expired, err := h.Articles.ListExpired(ctx, now, h.DefaultDays, h.maxPerRun())
+		if err != nil {
+			return nil, fmt.Errorf("retention: list expired articles: %w", err)
+		}
+		stats.Expired = len(expired)
+		stats.LimitHit = len(expired) == h.maxPerRun()
+		for _, a := range expired {
+			stats.BySource[a.SourceID]++
+		}
+		return stats, nil
+	}
+
+	archive := h.mode() == RetentionModeArchive
+	for stats.Purged < h.maxPerRun() {
+		limit := min(purgeBatchLimit, h.maxPerRun()-stats.Purged)
+		expired, err := h.Articles.ListExpired(ctx, now, h.DefaultDays, limit)
+		if err != nil {
+			return nil, fmt.Errorf("retention: list expired articles: %w", err)
+		}
+		if len(expired) == 0 {
+			break
+		}
+		stats.Expired += len(expired)
+
+		ids := make([]int64, len(expired))
+		sourceOf := make(map[int64]int64, len(expired))
+		for i, a := range expired {
+			ids[i] = a.ID
+			sourceOf[a.ID] = a.SourceID
+		}
+		purged, err := h.Articles.Purge(ctx, ids, archive)
+		if err != nil {
+			return nil, fmt.Errorf("retention: purge %d articles: %w", len(ids), err)
+		}
+		stats.Purged += len(purged)
+		for _, id := range purged {
+			stats.BySource[sourceOf[id]]++
+		}
+		// A short batch is the last one; an empty purge means everything
+		// left got protected meanwhile, and listing again would loop.
+		if len(expired) < limit || len(purged) == 0 {
+			break
+		}
+	}
+	stats.LimitHit = stats.Purged >= h.maxPerRun()
+	return stats, nil
+}
+
+func (h *RetentionHandler) mode() string {
+	if h.Mode == RetentionModeDelete {
+		return RetentionModeDelete
+	}
+	return RetentionModeArchive
+}
+
+func (h *RetentionHandler) maxPerRun() int {
+	if h.MaxPerRun > 0 {
+		return h.MaxPerRun
+	}
+	return DefaultRetentionMaxPerRun
+}
+
+func (h *RetentionHandler) logger() *slog.Logger {
+	if h.Logger != nil {
+		return h.Logger
+	}
+	return slog.Default()
+}
+
+func (h *RetentionHandler) now() time.Time {
+	if h.Now != nil {
+		return h.Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,159 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/jobs"
+	"catchup-feed/internal/repository"
+)
+
+// fakeRetentionStore は期限切れ記事の一覧と削除を模倣する。protected の
+// 記事は一覧には出るが Purge では残る(一覧後にお気に入りされた想定)。
+type fakeRetentionStore struct {
+	expired    []repository.ExpiredArticle
+	protected  map[int64]bool
+	purged     []int64
+	archived   []bool
+	listLimits []int
+	listErr    error
+	purgeErr   error
+}
+
+func (s *fakeRetentionStore) ListExpired(_ context.Context, _ time.Time, _ int, limit int) ([]repository.ExpiredArticle, error) {
+	s.listLimits = append(s.listLimits, limit)
+	if s.listErr != nil {
+		return nil, s.listErr
+	}
+	var out []repository.ExpiredArticle
+	for _, a := range s.expired {
+		if len(out) == limit {
+			break
+		}
+		if !s.isPurged(a.ID) {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (s *fakeRetentionStore) Purge(_ context.Context, ids []int64, archive bool) ([]int64, error) {
+	if s.purgeErr != nil {
+		return nil, s.purgeErr
+	}
+	s.archived = append(s.archived, archive)
+	out := []int64{}
+	for _, id := range ids {
+		if !s.protected[id] {
+			s.purged = append(s.purged, id)
+			out = append(out, id)
+		}
+	}
+	return out, nil
+}
+
+func (s *fakeRetentionStore) isPurged(id int64) bool {
+	for _, p := range s.purged {
+		if p == id {
+			return true
+		}
+	}
+	return false
+}
+
+func expiredArticles(n int, sourceID int64) []repository.ExpiredArticle {
+	out := make([]repository.ExpiredArticle, n)
+	for i := range out {
+		out[i] = repository.ExpiredArticle{ID: int64(i + 1), SourceID: sourceID}
+	}
+	return out
+}
+
+func retentionJob() *entity.Job {
+	return &entity.Job{ID: 11, Kind: entity.JobKindPurgeOldArticles}
+}
+
+func TestRetentionHandler_ArchivesByDefault(t *testing.T) {
+	store := &fakeRetentionStore{expired: []repository.ExpiredArticle{
+		{ID: 1, SourceID: 1}, {ID: 2, SourceID: 2}, {ID: 3, SourceID: 2},
+	}}
+	handler := &jobs.RetentionHandler{Articles: store, DefaultDays: 90}
+
+	stats, err := handler.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Purged)
+	assert.Equal(t, map[int64]int{1: 1, 2: 2}, stats.BySource)
+	assert.Equal(t, []bool{true}, store.archived)
+	assert.False(t, stats.LimitHit)
+
+	require.NoError(t, handler.Handle(context.Background(), retentionJob()))
+}
+
+func TestRetentionHandler_DeleteMode(t *testing.T) {
+	store := &fakeRetentionStore{expired: expiredArticles(2, 1)}
+	handler := &jobs.RetentionHandler{Articles: store, DefaultDays: 30, Mode: jobs.RetentionModeDelete}
+
+	_, err := handler.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []bool{false}, store.archived)
+	assert.Equal(t, []int64{1, 2}, store.purged)
+}
+
+func TestRetentionHandler_DryRunPurgesNothing(t *testing.T) {
+	store := &fakeRetentionStore{expired: expiredArticles(4, 3)}
+	handler := &jobs.RetentionHandler{Articles: store, DefaultDays: 30, DryRun: true}
+
+	stats, err := handler.Run(context.Background())
+	require.NoError(t, err)
+	assert.True(t, stats.DryRun)
+	assert.Equal(t, 4, stats.Expired)
+	assert.Equal(t, 0, stats.Purged)
+	assert.Equal(t, map[int64]int{3: 4}, stats.BySource)
+	assert.Empty(t, store.purged)
+	assert.Empty(t, store.archived)
+}
+
+func TestRetentionHandler_BatchesUpToMaxPerRun(t *testing.T) {
+	store := &fakeRetentionStore{expired: expiredArticles(1200, 1)}
+	handler := &jobs.RetentionHandler{Articles: store, DefaultDays: 30, MaxPerRun: 1100}
+
+	stats, err := handler.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1100, stats.Purged)
+	assert.True(t, stats.LimitHit)
+	assert.Equal(t, []int{500, 500, 100}, store.listLimits)
+}
+
+func TestRetentionHandler_StopsWhenEverythingGotProtected(t *testing.T) {
+	store := &fakeRetentionStore{
+		expired:   expiredArticles(500, 1),
+		protected: map[int64]bool{},
+	}
+	for i := int64(1); i <= 500; i++ {
+		store.protected[i] = true
+	}
+	handler := &jobs.RetentionHandler{Articles: store, DefaultDays: 30}
+
+	stats, err := handler.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.Purged)
+	assert.Len(t, store.listLimits, 1, "must not list the same protected batch again")
+}
+
+func TestRetentionHandler_Errors(t *testing.T) {
+	t.Run("list", func(t *testing.T) {
+		handler := &jobs.RetentionHandler{Articles: &fakeRetentionStore{listErr: errors.New("db down")}}
+		assert.Error(t, handler.Handle(context.Background(), retentionJob()))
+	})
+	t.Run("purge", func(t *testing.T) {
+		store := &fakeRetentionStore{expired: expiredArticles(1, 1), purgeErr: errors.New("db down")}
+		handler := &jobs.RetentionHandler{Articles: store}
+		assert.Error(t, handler.Handle(context.Background(), retentionJob()))
+	})
+}
@@ -22,18 +22,26 @@ func getenv(key string) string { return os.Getenv(key) }
 // keeps running (§8).
 //
 // Environment variables:
-//   - DISCORD_ENABLED / DISCORD_WEBHOOK_URL
-//   - SLACK_ENABLED   / SLACK_WEBHOOK_URL
+//   - DISCORD_ENABLED / DISCORD_WEBHOOK_URL / DISCORD_MESSAGE_TEMPLATE
+//   - SLACK_ENABLED   / SLACK_WEBHOOK_URL   / SLACK_MESSAGE_TEMPLATE
+//
+// The optional *_MESSAGE_TEMPLATE is a text/template over TemplateData
+// ({{.Kind}} {{.Title}} {{.Summary}} {{.Link}}) replacing the message
+// body; it is parsed and test-executed here, at startup.
 func LoadDestinationsFromEnv(logger *slog.Logger) []Destination {
 	if logger == nil {
 		logger = slog.Default()
 	}
 	var destinations []Destination
 	if u, ok := loadWebhook(logger, "discord", "DISCORD_ENABLED", "DISCORD_WEBHOOK_URL", "discord.com", "/api/webhooks/"); ok {
-		destinations = append(destinations, NewDiscord(u, webhookTimeout, logger))
+		discord := NewDiscord(u, webhookTimeout, logger)
+		discord.SetTemplate(loadTemplate(logger, "discord", "DISCORD_MESSAGE_TEMPLATE"))
+		destinations = append(destinations, discord)
 	}
 	if u, ok := loadWebhook(logger, "slack", "SLACK_ENABLED", "SLACK_WEBHOOK_URL", "hooks.slack.com", "/services/"); ok {
-		destinations = append(destinations, NewSlack(u, webhookTimeout))
+		slack := NewSlack(u, webhookTimeout)
+		slack.SetTemplate(loadTemplate(logger, "slack", "SLACK_MESSAGE_TEMPLATE"))
+		destinations = append(destinations, slack)
 	}
 	return destinations
 }
@@ -71,6 +79,7 @@ func loadWebhook(logger *slog.Logger, name, enabledKey, urlKey, wantHost, wantPa
 //   - SMTP_HOST / SMTP_PORT (default 587)
 //   - SMTP_USERNAME / SMTP_PASSWORD (e.g. Gmail address + app password)
 //   - SMTP_FROM (default SMTP_USERNAME)
+//   - SMTP_MESSAGE_TEMPLATE: optional mail body template ({{.Title}} {{.Summary}})
 func LoadSMTPFromEnv(logger *slog.Logger) *SMTPMailer {
 	if logger == nil {
 		logger = slog.Default()
@@ -104,5 +113,7 @@ func LoadSMTPFromEnv(logger *slog.Logger) *SMTPMailer {
 	}
 	logger.Info("notify: channel enabled", slog.String("channel", "email"),
 		slog.String("host", cfg.Host), slog.Int("port", cfg.Port))
-	return NewSMTPMailer(cfg)
+	mailer := NewSMTPMailer(cfg)
+	mailer.SetTemplate(loadTemplate(logger, "email", "SMTP_MESSAGE_TEMPLATE"))
+	return mailer
 }
@@ -152,3 +152,36 @@ func TestLoadSMTPFromEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadDestinationsFromEnv_Templates(t *testing.T) {
+	t.Setenv("DISCORD_ENABLED", "true")
+	t.Setenv("DISCORD_WEBHOOK_URL", "https://discord.com/api/webhooks/1/abc")
+	t.Setenv("DISCORD_MESSAGE_TEMPLATE", "{{.Title}}: {{.Summary}}")
+	t.Setenv("SLACK_ENABLED", "true")
+	t.Setenv("SLACK_WEBHOOK_URL", "https://hooks.slack.com/services/T/B/x")
+	t.Setenv("SLACK_MESSAGE_TEMPLATE", "{{.Nope}}")
+
+	destinations := LoadDestinationsFromEnv(discard())
+	require.Len(t, destinations, 2)
+
+	discord, ok := destinations[0].(*Discord)
+	require.True(t, ok)
+	require.NotNil(t, discord.body)
+	assert.Equal(t, "ep: notes", discord.body.Render(Message{Subject: "ep", Body: "notes"}))
+
+	slack, ok := destinations[1].(*Slack)
+	require.True(t, ok)
+	assert.Nil(t, slack.body, "an invalid template keeps the channel on the default format")
+}
+
+func TestLoadSMTPFromEnv_Template(t *testing.T) {
+	t.Setenv("SMTP_ENABLED", "true")
+	t.Setenv("SMTP_HOST", "smtp.example.com")
+	t.Setenv("SMTP_FROM", "pulse@example.com")
+	t.Setenv("SMTP_MESSAGE_TEMPLATE", "{{.Title}}{{\"\\n\\n\"}}{{.Summary}}")
+
+	mailer := LoadSMTPFromEnv(discard())
+	require.NotNil(t, mailer)
+	require.NotNil(t, mailer.body)
+	assert.Equal(t, "ep\n\nnotes", mailer.body.Render(Message{Subject: "ep", Body: "notes"}))
+}
@@ -34,6 +34,7 @@ type Discord struct {
 	webhookURL string
 	client     *http.Client
 	logger     *slog.Logger
+	body       *Template // nil = msg.Body as is
 }
 
 // NewDiscord builds a Discord destination. timeout bounds one webhook call.
@@ -50,12 +51,25 @@ func NewDiscord(webhookURL string, timeout time.Duration, logger *slog.Logger) *
 
 func (d *Discord) Name() string { return "discord" }
 
+// SetTemplate formats the embed description with t (DISCORD_MESSAGE_TEMPLATE).
+func (d *Discord) SetTemplate(t *Template) { d.body = t }
+
+// Probe implements Prober.
+func (d *Discord) Probe(ctx context.Context) error {
+	return probeWebhook(ctx, d.client, d.webhookURL)
+}
+
+type discordImage struct {
+	URL string `json:"url"`
+}
+
 type discordEmbed struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	URL         string `json:"url,omitempty"`
-	Color       int    `json:"color"`
-	Timestamp   string `json:"timestamp"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	URL         string        `json:"url,omitempty"`
+	Color       int           `json:"color"`
+	Timestamp   string        `json:"timestamp"`
+	Thumbnail   *discordImage `json:"thumbnail,omitempty"`
 }
 
 type discordPayload struct {
@@ -69,11 +83,14 @@ type discordPayload struct {
 func (d *Discord) Notify(ctx context.Context, msg Message) error {
 	payload := discordPayload{Embeds: []discordEmbed{{
 		Title:       truncate(msg.Subject, discordMaxTitle),
-		Description: truncate(msg.Body, discordMaxDescription),
+		Description: truncate(d.body.Render(msg), discordMaxDescription),
 		URL:         msg.Link,
 		Color:       discordBlue,
 		Timestamp:   time.Now().UTC().Format(time.RFC3339),
 	}}}
+	if msg.ImageURL != "" {
+		payload.Embeds[0].Thumbnail = &discordImage{URL: msg.ImageURL}
+	}
 	payloadJSON, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("discord: marshal payload: %w", err)
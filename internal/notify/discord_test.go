@@ -52,6 +52,11 @@ func TestDiscord_Notify(t *testing.T) {
 			msg:    notify.Message{Subject: "pulse 2026-07-05", Body: "show notes", Link: "http://pi/private/episodes/1.mp3"},
 			status: http.StatusNoContent,
 		},
+		{
+			name:   "image becomes the embed thumbnail",
+			msg:    notify.Message{Subject: "digest", Body: "list", ImageURL: "https://example.com/og.png"},
+			status: http.StatusNoContent,
+		},
 		{
 			name: "small attachment posts multipart with payload_json and file",
 			msg: notify.Message{
@@ -120,6 +125,9 @@ func TestDiscord_Notify(t *testing.T) {
 					Title       string `json:"title"`
 					Description string `json:"description"`
 					URL         string `json:"url"`
+					Thumbnail   *struct {
+						URL string `json:"url"`
+					} `json:"thumbnail"`
 				} `json:"embeds"`
 			}
 			require.NoError(t, json.Unmarshal(capture.body, &payload))
@@ -127,6 +135,12 @@ func TestDiscord_Notify(t *testing.T) {
 			assert.Equal(t, tt.msg.Subject, payload.Embeds[0].Title)
 			assert.Equal(t, tt.msg.Body, payload.Embeds[0].Description)
 			assert.Equal(t, tt.msg.Link, payload.Embeds[0].URL)
+			if tt.msg.ImageURL == "" {
+				assert.Nil(t, payload.Embeds[0].Thumbnail)
+			} else {
+				require.NotNil(t, payload.Embeds[0].Thumbnail)
+				assert.Equal(t, tt.msg.ImageURL, payload.Embeds[0].Thumbnail.URL)
+			}
 		})
 	}
 }
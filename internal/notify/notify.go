@@ -7,19 +7,29 @@
 // (§7: attempts 上限 3), and the notify_error path is best-effort.
 package notify
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
 
 // Message is one notification. §7's payload is タイトル+ショーノート+
 // エピソードURL; the same shape also carries error notices (§8), which is
 // why the interface takes a Message rather than an Episode — the episode
 // formatting happens in the jobs handler.
 type Message struct {
+	// Kind is MessageKindEpisode / Digest / Alert / Error; only message
+	// templates ({{.Kind}}) look at it.
+	Kind string
 	// Subject is the short line: episode title or error headline.
 	Subject string
 	// Body is the long text: show notes or error detail.
 	Body string
 	// Link is an optional URL shown with the message (episode URL).
 	Link string
+	// ImageURL is an optional thumbnail (an article's og:image): the
+	// Discord embed thumbnail and the Slack image accessory. Mail ignores it.
+	ImageURL string
 	// AttachmentPath / AttachmentBytes optionally reference a local mp3.
 	// Only Discord uses them (§7: mp3 が 10MB 未満なら Discord へ直接添付、
 	// 公開エピソードのみ — the caller enforces the public-only rule, the
@@ -38,3 +48,28 @@ type Destination interface {
 	// concern, not the destination's.
 	Notify(ctx context.Context, msg Message) error
 }
+
+// Prober is implemented by destinations that can check their endpoint is
+// reachable without delivering anything (the server's GET /health).
+type Prober interface {
+	Probe(ctx context.Context) error
+}
+
+// probeWebhook sends a HEAD request to a webhook URL. Webhooks only accept
+// POST, so any answer below 500 proves the service is reachable; nothing
+// is posted.
+func probeWebhook(ctx context.Context, client *http.Client, webhookURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, webhookURL, nil)
+	if err != nil {
+		return fmt.Errorf("build probe request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("probe webhook: %w", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("probe webhook: status %d", resp.StatusCode)
+	}
+	return nil
+}
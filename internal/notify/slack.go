@@ -14,6 +14,7 @@ import (
 const (
 	slackMaxSectionText = 3000
 	slackMaxFallback    = 150
+	slackMaxAltText     = 2000
 )
 
 // Slack posts messages to a Slack incoming webhook (admin channel, D-7).
@@ -21,6 +22,7 @@ const (
 type Slack struct {
 	webhookURL string
 	client     *http.Client
+	body       *Template // nil = msg.Body as is
 }
 
 // NewSlack builds a Slack destination. timeout bounds one webhook call.
@@ -33,14 +35,30 @@ func NewSlack(webhookURL string, timeout time.Duration) *Slack {
 
 func (s *Slack) Name() string { return "slack" }
 
+// SetTemplate formats the body section with t (SLACK_MESSAGE_TEMPLATE).
+func (s *Slack) SetTemplate(t *Template) { s.body = t }
+
+// Probe implements Prober.
+func (s *Slack) Probe(ctx context.Context) error {
+	return probeWebhook(ctx, s.client, s.webhookURL)
+}
+
 type slackText struct {
 	Type string `json:"type"`
 	Text string `json:"text"`
 }
 
+// slackImage is a section's image accessory.
+type slackImage struct {
+	Type     string `json:"type"`
+	ImageURL string `json:"image_url"`
+	AltText  string `json:"alt_text"`
+}
+
 type slackBlock struct {
-	Type string     `json:"type"`
-	Text *slackText `json:"text,omitempty"`
+	Type      string      `json:"type"`
+	Text      *slackText  `json:"text,omitempty"`
+	Accessory *slackImage `json:"accessory,omitempty"`
 }
 
 type slackPayload struct {
@@ -49,7 +67,8 @@ type slackPayload struct {
 }
 
 // Notify posts one Block Kit message: a bold subject (linked when the
-// message carries a URL) followed by the body.
+// message carries a URL, with the thumbnail beside it when there is one)
+// followed by the body.
 func (s *Slack) Notify(ctx context.Context, msg Message) error {
 	subject := "*" + msg.Subject + "*"
 	if msg.Link != "" {
@@ -61,9 +80,12 @@ func (s *Slack) Notify(ctx context.Context, msg Message) error {
 			{Type: "section", Text: &slackText{Type: "mrkdwn", Text: truncate(subject, slackMaxSectionText)}},
 		},
 	}
-	if msg.Body != "" {
+	if msg.ImageURL != "" {
+		payload.Blocks[0].Accessory = &slackImage{Type: "image", ImageURL: msg.ImageURL, AltText: truncate(msg.Subject, slackMaxAltText)}
+	}
+	if body := s.body.Render(msg); body != "" {
 		payload.Blocks = append(payload.Blocks,
-			slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: truncate(msg.Body, slackMaxSectionText)}})
+			slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: truncate(body, slackMaxSectionText)}})
 	}
 
 	body, err := json.Marshal(payload)
@@ -38,6 +38,13 @@ func TestSlack_Notify(t *testing.T) {
 			wantBlocks: 2,
 			wantFirst:  "*<http://pi/private/episodes/2.mp3|ep>*",
 		},
+		{
+			name:       "image rides the subject section as an accessory",
+			msg:        notify.Message{Subject: "digest", Body: "list", ImageURL: "https://example.com/og.png"},
+			status:     http.StatusOK,
+			wantBlocks: 2,
+			wantFirst:  "*digest*",
+		},
 		{
 			name:       "empty body sends the subject block only",
 			msg:        notify.Message{Subject: "障害"},
@@ -80,6 +87,11 @@ func TestSlack_Notify(t *testing.T) {
 						Type string `json:"type"`
 						Text string `json:"text"`
 					} `json:"text"`
+					Accessory *struct {
+						Type     string `json:"type"`
+						ImageURL string `json:"image_url"`
+						AltText  string `json:"alt_text"`
+					} `json:"accessory"`
 				} `json:"blocks"`
 			}
 			require.NoError(t, json.Unmarshal(body, &payload))
@@ -87,9 +99,46 @@ func TestSlack_Notify(t *testing.T) {
 			require.Len(t, payload.Blocks, tt.wantBlocks)
 			assert.Equal(t, "section", payload.Blocks[0].Type)
 			assert.Equal(t, tt.wantFirst, payload.Blocks[0].Text.Text)
+			if tt.msg.ImageURL == "" {
+				assert.Nil(t, payload.Blocks[0].Accessory)
+			} else {
+				require.NotNil(t, payload.Blocks[0].Accessory)
+				assert.Equal(t, "image", payload.Blocks[0].Accessory.Type)
+				assert.Equal(t, tt.msg.ImageURL, payload.Blocks[0].Accessory.ImageURL)
+				assert.Equal(t, tt.msg.Subject, payload.Blocks[0].Accessory.AltText)
+			}
 			if tt.wantBlocks == 2 {
 				assert.Equal(t, tt.msg.Body, payload.Blocks[1].Text.Text)
 			}
 		})
 	}
 }
+
+func TestSlack_Probe(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		// incoming webhook は POST 以外を 4xx で拒否するが、到達はできている。
+		{"reachable webhook rejects HEAD", http.StatusBadRequest, false},
+		{"service error", http.StatusServiceUnavailable, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodHead, r.Method, "the probe must not post")
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			var prober notify.Prober = notify.NewSlack(srv.URL, time.Second)
+			err := prober.Probe(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -32,7 +32,8 @@ type SMTPConfig struct {
 // message, text/plain UTF-8, no HTML, no queueing. Friends number in the
 // single digits and retries are the jobs queue's concern (§7).
 type SMTPMailer struct {
-	cfg SMTPConfig
+	cfg  SMTPConfig
+	body *Template // nil = body as is
 }
 
 // NewSMTPMailer builds a mailer for the given relay.
@@ -43,10 +44,14 @@ func NewSMTPMailer(cfg SMTPConfig) *SMTPMailer {
 	return &SMTPMailer{cfg: cfg}
 }
 
+// SetTemplate formats the mail body with t (SMTP_MESSAGE_TEMPLATE).
+func (m *SMTPMailer) SetTemplate(t *Template) { m.body = t }
+
 // Send delivers one message to one recipient. Friends are addressed
 // individually (never as a shared To list) so addresses are not leaked
 // between them.
 func (m *SMTPMailer) Send(ctx context.Context, to, subject, body string) error {
+	body = m.body.Render(Message{Subject: subject, Body: body})
 	msg, err := buildMail(m.cfg.From, to, subject, body, time.Now())
 	if err != nil {
 		return err
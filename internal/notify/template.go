@@ -0,0 +1,98 @@
+package notify
+
+import (
+	"log/slog"
+	"strings"
+	"text/template"
+)
+
+// Message kinds, exposed to templates as {{.Kind}} so one template can
+// format episodes, digests, keyword alerts and error notices differently.
+const (
+	MessageKindEpisode = "episode"
+	MessageKindDigest  = "digest"
+	MessageKindAlert   = "alert"
+	MessageKindError   = "error"
+)
+
+// TemplateData is what a message template sees.
+type TemplateData struct {
+	Kind    string // MessageKind*; empty for mail (always an episode)
+	Title   string // Message.Subject
+	Summary string // Message.Body: show notes, digest list or error detail
+	Link    string // Message.Link; empty when the message has none
+	Image   string // Message.ImageURL; empty when the message has none
+}
+
+// sampleTemplateData is executed once at load so a template referencing
+// an unknown field fails at startup instead of on the first notification.
+var sampleTemplateData = TemplateData{
+	Kind:    MessageKindEpisode,
+	Title:   "title",
+	Summary: "summary",
+	Link:    "https://example.com/episode.mp3",
+	Image:   "https://example.com/og.png",
+}
+
+// Template renders the text part of a message for one channel: the embed
+// description (Discord), the body section (Slack) or the mail body
+// (email). The subject line stays as is — it is the embed title, the
+// Slack fallback text and the mail Subject header, all length-limited.
+type Template struct {
+	tmpl *template.Template
+}
+
+// ParseTemplate parses and validates a message template.
+func ParseTemplate(name, text string) (*Template, error) {
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	t := &Template{tmpl: tmpl}
+	if _, err := t.execute(sampleTemplateData); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Render returns the templated text for msg. A nil template, or one that
+// fails on this particular message, yields msg.Body unchanged: a custom
+// format must never cost the notification itself.
+func (t *Template) Render(msg Message) string {
+	if t == nil {
+		return msg.Body
+	}
+	out, err := t.execute(TemplateData{Kind: msg.Kind, Title: msg.Subject, Summary: msg.Body, Link: msg.Link, Image: msg.ImageURL})
+	if err != nil {
+		slog.Warn("notify: message template failed, sending the default body",
+			slog.String("template", t.tmpl.Name()), slog.Any("error", err))
+		return msg.Body
+	}
+	return out
+}
+
+func (t *Template) execute(data TemplateData) (string, error) {
+	var sb strings.Builder
+	if err := t.tmpl.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// loadTemplate reads an optional *_MESSAGE_TEMPLATE variable. An invalid
+// template logs a warning and keeps the built-in format (fail-open, like
+// the rest of the channel configuration).
+func loadTemplate(logger *slog.Logger, channel, key string) *Template {
+	text := getenv(key)
+	if text == "" {
+		return nil
+	}
+	tmpl, err := ParseTemplate(channel, text)
+	if err != nil {
+		logger.Warn("notify: invalid message template, using the default format",
+			slog.String("channel", channel), slog.String("key", key), slog.Any("error", err))
+		return nil
+	}
+	logger.Info("notify: custom message template loaded", slog.String("channel", channel))
+	return tmpl
+}
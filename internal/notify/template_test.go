@@ -0,0 +1,108 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/notify"
+)
+
+func TestParseTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"all fields", `{{.Kind}} {{.Title}} {{.Summary}} {{.Link}}`, false},
+		{"conditional on kind", `{{if eq .Kind "digest"}}{{.Summary}}{{else}}{{.Title}}{{end}}`, false},
+		{"syntax error", `{{.Title`, true},
+		{"unknown field fails at startup", `{{.SourceName}}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := notify.ParseTemplate("test", tt.text)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTemplate_Render(t *testing.T) {
+	msg := notify.Message{Kind: notify.MessageKindEpisode, Subject: "ep 1", Body: "notes", Link: "http://pi/1.mp3"}
+
+	tmpl, err := notify.ParseTemplate("test", `[{{.Kind}}] {{.Title}}{{"\n"}}{{.Summary}}{{with .Link}}{{"\n"}}{{.}}{{end}}`)
+	require.NoError(t, err)
+	assert.Equal(t, "[episode] ep 1\nnotes\nhttp://pi/1.mp3", tmpl.Render(msg))
+
+	var none *notify.Template
+	assert.Equal(t, "notes", none.Render(msg), "no template keeps the body")
+
+	// A template that only fails on some messages falls back to the body.
+	failing, err := notify.ParseTemplate("test", `{{slice .Summary 0 6}}`)
+	require.NoError(t, err)
+	assert.Equal(t, "notes", failing.Render(msg))
+}
+
+func TestSlack_Notify_Template(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	tmpl, err := notify.ParseTemplate("slack", `:radio: {{.Summary}}`)
+	require.NoError(t, err)
+	destination := notify.NewSlack(server.URL, time.Second)
+	destination.SetTemplate(tmpl)
+	require.NoError(t, destination.Notify(context.Background(), notify.Message{Subject: "ep", Body: "notes"}))
+
+	var payload struct {
+		Text   string `json:"text"`
+		Blocks []struct {
+			Text struct {
+				Text string `json:"text"`
+			} `json:"text"`
+		} `json:"blocks"`
+	}
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "ep", payload.Text, "the subject is not templated")
+	require.Len(t, payload.Blocks, 2)
+	assert.Equal(t, ":radio: notes", payload.Blocks[1].Text.Text)
+}
+
+func TestDiscord_Notify_Template(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	tmpl, err := notify.ParseTemplate("discord", `{{.Summary}} ({{.Kind}})`)
+	require.NoError(t, err)
+	destination := notify.NewDiscord(server.URL, time.Second, nil)
+	destination.SetTemplate(tmpl)
+	require.NoError(t, destination.Notify(context.Background(),
+		notify.Message{Kind: notify.MessageKindDigest, Subject: "digest", Body: "■ Zenn (1)"}))
+
+	var payload struct {
+		Embeds []struct {
+			Title       string `json:"title"`
+			Description string `json:"description"`
+		} `json:"embeds"`
+	}
+	require.NoError(t, json.Unmarshal(body, &payload))
+	require.Len(t, payload.Embeds, 1)
+	assert.Equal(t, "digest", payload.Embeds[0].Title)
+	assert.Equal(t, "■ Zenn (1) (digest)", payload.Embeds[0].Description)
+}
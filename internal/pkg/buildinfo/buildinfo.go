@@ -0,0 +1,84 @@
+// Package buildinfo exposes the version, git commit and build date that
+// the release build embeds with -ldflags (see the Dockerfile):
+//
+//	-X catchup-feed/internal/pkg/buildinfo.Version=v1.2.3
+//	-X catchup-feed/internal/pkg/buildinfo.GitCommit=0123abc
+//	-X catchup-feed/internal/pkg/buildinfo.BuildDate=2026-10-01T09:00:00Z
+//
+// Local builds (go run, go test) embed nothing: Version falls back to the
+// VERSION environment variable (then "dev") and GitCommit to the VCS
+// revision the Go toolchain stamps into the binary, when there is one.
+package buildinfo
+
+import (
+	"os"
+	"runtime"
+	"runtime/debug"
+)
+
+// Set at link time with -ldflags "-X ...". Empty when not embedded.
+var (
+	Version   string
+	GitCommit string
+	BuildDate string
+)
+
+const unknown = "unknown"
+
+// Info is the build of the running binary.
+type Info struct {
+	Version   string `json:"version" example:"v1.2.3"`
+	GitCommit string `json:"git_commit" example:"0123abc"`
+	BuildDate string `json:"build_date" example:"2026-10-01T09:00:00Z"`
+	GoVersion string `json:"go_version" example:"go1.25.1"`
+	OS        string `json:"os" example:"linux"`
+	Arch      string `json:"arch" example:"arm64"`
+}
+
+// Get returns the build info, filling what the linker did not embed from
+// the environment and the Go runtime.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		GitCommit: GitCommit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+	if info.Version == "" {
+		info.Version = os.Getenv("VERSION")
+	}
+	if info.Version == "" {
+		info.Version = "dev"
+	}
+	if info.GitCommit == "" || info.BuildDate == "" {
+		revision, date := vcs()
+		if info.GitCommit == "" {
+			info.GitCommit = revision
+		}
+		if info.BuildDate == "" {
+			info.BuildDate = date
+		}
+	}
+	return info
+}
+
+// vcs returns the revision and commit time go build stamped into the
+// binary (-buildvcs), or "unknown" for each it did not.
+func vcs() (revision, date string) {
+	revision, date = unknown, unknown
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return revision, date
+	}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			revision = s.Value
+		case "vcs.time":
+			date = s.Value
+		}
+	}
+	return revision, date
+}
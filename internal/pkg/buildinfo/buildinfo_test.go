@@ -0,0 +1,38 @@
+package buildinfo
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet_Embedded(t *testing.T) {
+	defer func(v, c, d string) { Version, GitCommit, BuildDate = v, c, d }(Version, GitCommit, BuildDate)
+	Version, GitCommit, BuildDate = "v1.2.3", "0123abc", "2026-10-01T09:00:00Z"
+	t.Setenv("VERSION", "ignored")
+
+	assert.Equal(t, Info{
+		Version:   "v1.2.3",
+		GitCommit: "0123abc",
+		BuildDate: "2026-10-01T09:00:00Z",
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}, Get())
+}
+
+func TestGet_Fallbacks(t *testing.T) {
+	defer func(v, c, d string) { Version, GitCommit, BuildDate = v, c, d }(Version, GitCommit, BuildDate)
+	Version, GitCommit, BuildDate = "", "", ""
+
+	t.Setenv("VERSION", "v9.9.9")
+	info := Get()
+	assert.Equal(t, "v9.9.9", info.Version)
+	// テストバイナリには VCS 情報が載らないことがあるが、空にはならない。
+	assert.NotEmpty(t, info.GitCommit)
+	assert.NotEmpty(t, info.BuildDate)
+
+	t.Setenv("VERSION", "")
+	assert.Equal(t, "dev", Get().Version)
+}
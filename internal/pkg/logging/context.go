@@ -0,0 +1,72 @@
+// Package logging carries log correlation through context.Context. The
+// worker runs one crawl as a tree of work — crawl run → source → article
+// summarization — spread over errgroup goroutines and the summarizer chain.
+// Instead of a tracing SDK (no collector runs next to the Pi; 設計原則1:
+// 右サイズ), each level attaches its identifying attributes to ctx and the
+// ContextHandler stamps them onto every record logged with that ctx, so
+// one crawl can be followed end-to-end with a single crawl_id filter.
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+type attrsKey struct{}
+
+// WithAttrs returns a child context whose log records carry attrs in
+// addition to everything the parent already carries. The parent is never
+// mutated, so sibling goroutines (one per article) cannot see each
+// other's attributes.
+func WithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	if len(attrs) == 0 {
+		return ctx
+	}
+	parent := AttrsFromContext(ctx)
+	merged := make([]slog.Attr, 0, len(parent)+len(attrs))
+	merged = append(merged, parent...)
+	merged = append(merged, attrs...)
+	return context.WithValue(ctx, attrsKey{}, merged)
+}
+
+// AttrsFromContext returns the attributes attached by WithAttrs, outermost
+// first. The returned slice must not be modified.
+func AttrsFromContext(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+	attrs, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	return attrs
+}
+
+// ContextHandler wraps a slog.Handler and adds the context attributes to
+// each record. Only the *Context logging methods (InfoContext, ...) pass a
+// ctx through; plain Info calls log without correlation.
+type ContextHandler struct {
+	inner slog.Handler
+}
+
+// NewContextHandler wraps inner.
+func NewContextHandler(inner slog.Handler) *ContextHandler {
+	return &ContextHandler{inner: inner}
+}
+
+func (h *ContextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+func (h *ContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if attrs := AttrsFromContext(ctx); len(attrs) > 0 {
+		r = r.Clone()
+		r.AddAttrs(attrs...)
+	}
+	return h.inner.Handle(ctx, r)
+}
+
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{inner: h.inner.WithAttrs(attrs)}
+}
+
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{inner: h.inner.WithGroup(name)}
+}
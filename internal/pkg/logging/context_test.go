@@ -0,0 +1,66 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(NewContextHandler(slog.NewJSONHandler(buf, nil)))
+}
+
+func decode(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	return got
+}
+
+func TestContextHandler_AddsContextAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestLogger(&buf)
+
+	ctx := WithAttrs(context.Background(), slog.String("crawl_id", "c-1"))
+	ctx = WithAttrs(ctx, slog.Int64("source_id", 7))
+	logger.InfoContext(ctx, "source crawl completed", slog.Int("inserted", 2))
+
+	got := decode(t, &buf)
+	assert.Equal(t, "c-1", got["crawl_id"])
+	assert.Equal(t, float64(7), got["source_id"])
+	assert.Equal(t, float64(2), got["inserted"])
+}
+
+func TestContextHandler_NoContextAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestLogger(&buf)
+
+	logger.Info("plain")
+
+	got := decode(t, &buf)
+	assert.Equal(t, "plain", got["msg"])
+	assert.NotContains(t, got, "crawl_id")
+}
+
+// TestWithAttrs_SiblingsAreIsolated: per-article goroutines derive from
+// the same source ctx and must not leak attributes into each other.
+func TestWithAttrs_SiblingsAreIsolated(t *testing.T) {
+	parent := WithAttrs(context.Background(), slog.String("crawl_id", "c-1"))
+	a := WithAttrs(parent, slog.String("url", "https://a.example"))
+	b := WithAttrs(parent, slog.String("url", "https://b.example"))
+
+	assert.Len(t, AttrsFromContext(parent), 1)
+	assert.Equal(t, "https://a.example", AttrsFromContext(a)[1].Value.String())
+	assert.Equal(t, "https://b.example", AttrsFromContext(b)[1].Value.String())
+}
+
+func TestWithAttrs_Empty(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, ctx, WithAttrs(ctx))
+	assert.Nil(t, AttrsFromContext(ctx))
+}
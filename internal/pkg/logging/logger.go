@@ -0,0 +1,87 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"catchup-feed/pkg/config"
+)
+
+// level is the process-wide minimum level used by Init. It is a LevelVar
+// so the admin API can switch it at runtime (PUT /log-level) without a
+// restart; every logger derived from the Init logger follows the change.
+var level = new(slog.LevelVar)
+
+// New builds the JSON logger shared by the server and the worker: records
+// go to w through a ContextHandler, so request_id (HTTP) and crawl_id
+// (worker) attached to ctx appear without manual fields.
+func New(w io.Writer, level slog.Leveler) *slog.Logger {
+	return slog.New(NewContextHandler(slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level: level,
+	})))
+}
+
+// Init builds the process logger on stdout, starting at LevelFromEnv and
+// adjustable afterwards through SetLevel, and installs it as slog's
+// default so package-level slog calls share the same handler. Warn
+// sampling is added when SamplingFromEnv enables it.
+func Init() *slog.Logger {
+	level.Set(LevelFromEnv())
+	logger := New(os.Stdout, level)
+	if cfg := SamplingFromEnv(); cfg.Enabled() {
+		logger = slog.New(NewSamplingHandler(logger.Handler(), cfg, nil))
+	}
+	slog.SetDefault(logger)
+	return logger
+}
+
+// SamplingFromEnv reads the Warn sampling settings. Sampling is opt-in:
+// with the defaults every record is written, as before.
+//
+// Environment variables:
+//   - LOG_SAMPLING_BURST: Warn records per message and interval (default 0 = off)
+//   - LOG_SAMPLING_INTERVAL: sampling window (default 1m)
+func SamplingFromEnv() SamplingConfig {
+	return SamplingConfig{
+		Burst:    config.GetEnvInt("LOG_SAMPLING_BURST", 0),
+		Interval: config.GetEnvDuration("LOG_SAMPLING_INTERVAL", time.Minute),
+	}
+}
+
+// Level returns the current process-wide level.
+func Level() slog.Level { return level.Level() }
+
+// SetLevel switches the process-wide level at runtime.
+func SetLevel(l slog.Level) { level.Set(l) }
+
+// ParseLevel accepts the four operator-facing names: debug, info, warn
+// and error (case-insensitive). slog's own offsets ("info+2") are
+// deliberately not accepted.
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("invalid log level %q: must be one of debug, info, warn, error", s)
+	}
+}
+
+// LevelFromEnv reads LOG_LEVEL (debug / info / warn / error). Unset or
+// unknown values fall back to info so a typo never silences the logs.
+func LevelFromEnv() slog.Level {
+	l, err := ParseLevel(os.Getenv("LOG_LEVEL"))
+	if err != nil {
+		return slog.LevelInfo
+	}
+	return l
+}
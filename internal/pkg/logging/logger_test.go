@@ -0,0 +1,76 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    slog.Level
+		wantErr bool
+	}{
+		{in: "debug", want: slog.LevelDebug},
+		{in: "INFO", want: slog.LevelInfo},
+		{in: " warn ", want: slog.LevelWarn},
+		{in: "error", want: slog.LevelError},
+		{in: "warning", wantErr: true},
+		{in: "info+2", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseLevel(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLevelFromEnv(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "warn")
+	assert.Equal(t, slog.LevelWarn, LevelFromEnv())
+
+	t.Setenv("LOG_LEVEL", "verbose")
+	assert.Equal(t, slog.LevelInfo, LevelFromEnv(), "unknown values fall back to info")
+}
+
+// TestSetLevel_AppliesToExistingLogger: loggers built on the shared
+// LevelVar must follow runtime changes without being rebuilt.
+func TestSetLevel_AppliesToExistingLogger(t *testing.T) {
+	prev := Level()
+	t.Cleanup(func() { SetLevel(prev) })
+
+	var buf bytes.Buffer
+	logger := New(&buf, level)
+
+	SetLevel(slog.LevelInfo)
+	logger.Debug("hidden")
+	assert.Zero(t, buf.Len())
+
+	SetLevel(slog.LevelDebug)
+	logger.Debug("shown")
+	assert.Contains(t, buf.String(), "shown")
+	assert.Equal(t, slog.LevelDebug, Level())
+}
+
+func TestSamplingFromEnv(t *testing.T) {
+	t.Setenv("LOG_SAMPLING_BURST", "")
+	t.Setenv("LOG_SAMPLING_INTERVAL", "")
+	assert.False(t, SamplingFromEnv().Enabled(), "sampling is opt-in")
+
+	t.Setenv("LOG_SAMPLING_BURST", "10")
+	t.Setenv("LOG_SAMPLING_INTERVAL", "30s")
+	assert.Equal(t, SamplingConfig{Burst: 10, Interval: 30 * time.Second}, SamplingFromEnv())
+}
@@ -0,0 +1,128 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SamplingConfig bounds how many Warn records with the same message may
+// pass per interval. Burst <= 0 disables sampling.
+type SamplingConfig struct {
+	Burst    int
+	Interval time.Duration
+}
+
+// Enabled reports whether the configuration turns sampling on.
+func (c SamplingConfig) Enabled() bool {
+	return c.Burst > 0 && c.Interval > 0
+}
+
+// SamplingHandler drops repeated Warn records so one noisy path (rate
+// limit denials from a single client, every source failing during a
+// network outage) cannot flood stdout on the Pi. Records are keyed by
+// message: the first Burst per Interval pass, the rest are counted and
+// dropped, and the first record of the next window carries the number
+// dropped as "suppressed" so nothing disappears silently.
+//
+// Only Warn is sampled. Debug/Info volume is governed by the level, and
+// Error and above always pass — they are rare and each one matters.
+type SamplingHandler struct {
+	inner   slog.Handler
+	sampler *sampler
+}
+
+// NewSamplingHandler wraps inner. now may be nil (time.Now).
+func NewSamplingHandler(inner slog.Handler, cfg SamplingConfig, now func() time.Time) *SamplingHandler {
+	if now == nil {
+		now = time.Now
+	}
+	return &SamplingHandler{
+		inner: inner,
+		sampler: &sampler{
+			cfg:     cfg,
+			now:     now,
+			windows: make(map[string]*sampleWindow),
+		},
+	}
+}
+
+// Suppressed returns the total number of records dropped since start.
+func (h *SamplingHandler) Suppressed() int64 {
+	return h.sampler.suppressed.Load()
+}
+
+func (h *SamplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+func (h *SamplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level < slog.LevelWarn || r.Level >= slog.LevelError {
+		return h.inner.Handle(ctx, r)
+	}
+	ok, dropped := h.sampler.allow(r.Message)
+	if !ok {
+		return nil
+	}
+	if dropped > 0 {
+		r = r.Clone()
+		r.AddAttrs(slog.Int64("suppressed", dropped))
+	}
+	return h.inner.Handle(ctx, r)
+}
+
+// WithAttrs and WithGroup share the sampler: a message is one key no
+// matter which derived logger emits it.
+func (h *SamplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &SamplingHandler{inner: h.inner.WithAttrs(attrs), sampler: h.sampler}
+}
+
+func (h *SamplingHandler) WithGroup(name string) slog.Handler {
+	return &SamplingHandler{inner: h.inner.WithGroup(name), sampler: h.sampler}
+}
+
+type sampleWindow struct {
+	start   time.Time
+	count   int
+	dropped int64
+}
+
+type sampler struct {
+	cfg        SamplingConfig
+	now        func() time.Time
+	suppressed atomic.Int64
+
+	mu      sync.Mutex
+	windows map[string]*sampleWindow
+}
+
+// allow reports whether a record with key may pass, and on the first pass
+// of a new window how many records the previous window dropped. The map is
+// keyed by log message — a closed set of string literals — so it stays
+// small without eviction.
+func (s *sampler) allow(key string) (bool, int64) {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, ok := s.windows[key]
+	if !ok {
+		s.windows[key] = &sampleWindow{start: now, count: 1}
+		return true, 0
+	}
+	if now.Sub(w.start) >= s.cfg.Interval {
+		dropped := w.dropped
+		*w = sampleWindow{start: now, count: 1}
+		return true, dropped
+	}
+	if w.count < s.cfg.Burst {
+		w.count++
+		return true, 0
+	}
+	w.dropped++
+	s.suppressed.Add(1)
+	return false, 0
+}
@@ -0,0 +1,102 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func newSampled(buf *bytes.Buffer, burst int, interval time.Duration, clock *fakeClock) (*slog.Logger, *SamplingHandler) {
+	h := NewSamplingHandler(slog.NewJSONHandler(buf, nil), SamplingConfig{Burst: burst, Interval: interval}, clock.now)
+	return slog.New(h), h
+}
+
+func lines(buf *bytes.Buffer) []map[string]any {
+	var out []map[string]any
+	for _, l := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if l == "" {
+			continue
+		}
+		var m map[string]any
+		if err := json.Unmarshal([]byte(l), &m); err == nil {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func TestSamplingHandler_BurstThenSuppress(t *testing.T) {
+	var buf bytes.Buffer
+	clock := &fakeClock{t: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)}
+	logger, h := newSampled(&buf, 2, time.Minute, clock)
+
+	for range 5 {
+		logger.Warn("rate limit exceeded")
+	}
+	assert.Len(t, lines(&buf), 2)
+	assert.Equal(t, int64(3), h.Suppressed())
+
+	// Next window: the first record reports what the previous one dropped.
+	clock.t = clock.t.Add(time.Minute)
+	buf.Reset()
+	logger.Warn("rate limit exceeded")
+	got := lines(&buf)
+	require.Len(t, got, 1)
+	assert.Equal(t, float64(3), got[0]["suppressed"])
+}
+
+func TestSamplingHandler_KeysAreIndependent(t *testing.T) {
+	var buf bytes.Buffer
+	clock := &fakeClock{t: time.Now()}
+	logger, _ := newSampled(&buf, 1, time.Minute, clock)
+
+	logger.Warn("failed to fetch feed")
+	logger.Warn("failed to fetch feed")
+	logger.Warn("rate limit exceeded")
+
+	assert.Len(t, lines(&buf), 2)
+}
+
+func TestSamplingHandler_OnlyWarnIsSampled(t *testing.T) {
+	var buf bytes.Buffer
+	clock := &fakeClock{t: time.Now()}
+	logger, h := newSampled(&buf, 1, time.Minute, clock)
+
+	for range 3 {
+		logger.Info("article summarized")
+		logger.Error("crawl failed")
+	}
+
+	assert.Len(t, lines(&buf), 6)
+	assert.Zero(t, h.Suppressed())
+}
+
+// TestSamplingHandler_SharedAcrossWith: derived loggers count against the
+// same key.
+func TestSamplingHandler_SharedAcrossWith(t *testing.T) {
+	var buf bytes.Buffer
+	clock := &fakeClock{t: time.Now()}
+	logger, h := newSampled(&buf, 1, time.Minute, clock)
+
+	logger.With(slog.Int("source_id", 1)).Warn("failed to fetch feed")
+	logger.With(slog.Int("source_id", 2)).Warn("failed to fetch feed")
+
+	assert.Len(t, lines(&buf), 1)
+	assert.Equal(t, int64(1), h.Suppressed())
+}
+
+func TestSamplingConfig_Enabled(t *testing.T) {
+	assert.False(t, SamplingConfig{}.Enabled())
+	assert.False(t, SamplingConfig{Burst: 5}.Enabled())
+	assert.True(t, SamplingConfig{Burst: 5, Interval: time.Second}.Enabled())
+}
@@ -0,0 +1,73 @@
+// Package schedule parses per-source crawl schedules (sources.crawl_schedule).
+//
+// A schedule is either a standard 5-field cron expression ("*/30 * * * *"),
+// a robfig/cron descriptor ("@hourly", "@every 45m") or a bare Go duration
+// meaning "every d" ("30m", "2h"). Schedules firing more often than
+// MinInterval are rejected: every run fetches the feed and may call the
+// summarizer, so a typo like "* * * * *" must not hammer the source.
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// MinInterval is the shortest allowed gap between two crawls of a source.
+const MinInterval = 5 * time.Minute
+
+// parser accepts the same syntax as the worker's CRON_SCHEDULE plus
+// descriptors.
+var parser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// probeRuns bounds how many consecutive activations Parse inspects when
+// checking a cron expression against MinInterval.
+const probeRuns = 2000
+
+// Parse validates spec and returns its schedule.
+func Parse(spec string) (cron.Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, fmt.Errorf("invalid crawl schedule: cannot be empty")
+	}
+	if d, err := time.ParseDuration(spec); err == nil {
+		if d < MinInterval {
+			return nil, fmt.Errorf("invalid crawl schedule %q: interval must be at least %s", spec, MinInterval)
+		}
+		return cron.Every(d), nil
+	}
+	sched, err := parser.Parse(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid crawl schedule %q: %w", spec, err)
+	}
+	if every, ok := sched.(cron.ConstantDelaySchedule); ok {
+		if every.Delay < MinInterval {
+			return nil, fmt.Errorf("invalid crawl schedule %q: interval must be at least %s", spec, MinInterval)
+		}
+		return sched, nil
+	}
+	// Walk a week of activations: cron fields repeat at most weekly, so
+	// any gap shorter than MinInterval shows up within that window.
+	start := time.Date(2000, 1, 3, 0, 0, 0, 0, time.UTC) // a Monday
+	end := start.AddDate(0, 0, 8)
+	prev := sched.Next(start)
+	for i := 0; i < probeRuns && !prev.IsZero() && prev.Before(end); i++ {
+		next := sched.Next(prev)
+		if next.IsZero() {
+			break
+		}
+		if next.Sub(prev) < MinInterval {
+			return nil, fmt.Errorf("invalid crawl schedule %q: runs more often than every %s", spec, MinInterval)
+		}
+		prev = next
+	}
+	return sched, nil
+}
+
+// Validate reports whether spec is an acceptable crawl schedule.
+func Validate(spec string) error {
+	_, err := Parse(spec)
+	return err
+}
@@ -0,0 +1,57 @@
+package schedule_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/pkg/schedule"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr bool
+	}{
+		{"cron every 30 minutes", "*/30 * * * *", false},
+		{"cron daily", "30 5 * * *", false},
+		{"cron weekdays", "0 9 * * 1-5", false},
+		{"descriptor", "@hourly", false},
+		{"every descriptor", "@every 45m", false},
+		{"bare duration", "2h", false},
+		{"surrounding spaces", "  15m ", false},
+		{"empty", "", true},
+		{"garbage", "often", true},
+		{"six fields", "0 0 * * * *", true},
+		{"interval too short", "1m", true},
+		{"every descriptor too short", "@every 30s", true},
+		{"cron every minute", "* * * * *", true},
+		{"cron burst in one hour", "*/2 9 * * *", true},
+		{"cron burst on mondays", "0-4 3 * * 1", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := schedule.Parse(tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestParse_Next(t *testing.T) {
+	from := time.Date(2026, 10, 1, 10, 7, 0, 0, time.UTC)
+
+	sched, err := schedule.Parse("30m")
+	require.NoError(t, err)
+	assert.Equal(t, from.Add(30*time.Minute), sched.Next(from))
+
+	sched, err = schedule.Parse("*/20 * * * *")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 1, 10, 20, 0, 0, time.UTC), sched.Next(from))
+}
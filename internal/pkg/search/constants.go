@@ -16,4 +16,9 @@ const (
 	// DefaultSearchTimeout is the default timeout for search queries.
 	// This prevents long-running queries from blocking database connections.
 	DefaultSearchTimeout = 5 * time.Second
+
+	// DefaultTextSearchConfig is the PostgreSQL text search configuration
+	// the stored tsv columns are built with. "simple" only lowercases
+	// words (no stemming), which suits mixed Japanese / English content.
+	DefaultTextSearchConfig = "simple"
 )
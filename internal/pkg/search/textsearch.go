@@ -0,0 +1,27 @@
+package search
+
+// textSearchConfigs are the text search configurations bundled with
+// PostgreSQL (SELECT cfgname FROM pg_ts_config on a stock server).
+var textSearchConfigs = map[string]bool{
+	"simple": true, "arabic": true, "armenian": true, "basque": true,
+	"catalan": true, "danish": true, "dutch": true, "english": true,
+	"finnish": true, "french": true, "german": true, "greek": true,
+	"hindi": true, "hungarian": true, "indonesian": true, "irish": true,
+	"italian": true, "lithuanian": true, "nepali": true, "norwegian": true,
+	"portuguese": true, "romanian": true, "russian": true, "serbian": true,
+	"spanish": true, "swedish": true, "tamil": true, "turkish": true,
+	"yiddish": true,
+}
+
+// IsTextSearchConfig reports whether name is a built-in PostgreSQL text
+// search configuration. Query builders interpolate the configuration into
+// SQL as a literal, so only names passing this check may be used.
+//
+// Examples:
+//
+//	IsTextSearchConfig("english")       // true
+//	IsTextSearchConfig("English")       // false (names are lowercase)
+//	IsTextSearchConfig("x'); DROP --")  // false
+func IsTextSearchConfig(name string) bool {
+	return textSearchConfigs[name]
+}
@@ -0,0 +1,26 @@
+package search
+
+import "testing"
+
+func TestIsTextSearchConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "default", input: DefaultTextSearchConfig, want: true},
+		{name: "english", input: "english", want: true},
+		{name: "uppercase", input: "English", want: false},
+		{name: "empty", input: "", want: false},
+		{name: "unknown", input: "japanese", want: false},
+		{name: "injection", input: "simple'); DROP TABLE articles; --", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTextSearchConfig(tt.input); got != tt.want {
+				t.Errorf("IsTextSearchConfig(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Package shutdown is the drain sequence the server and the worker run on
+// SIGTERM, so a rolling restart behind a load balancer drops no request:
+//
+//  1. readiness flips to "not ready" (server /ready, worker /health/ready)
+//     while everything else keeps working;
+//  2. DrainPeriod passes, long enough for the load balancer / kubelet to
+//     see the failing probe and stop routing new traffic here;
+//  3. the process stops accepting work and waits for what is in flight,
+//     up to Timeout, after which the caller forces the rest closed.
+package shutdown
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"catchup-feed/pkg/config"
+)
+
+// Config bounds the drain sequence.
+//
+// Environment variables:
+//   - SHUTDOWN_DRAIN_PERIOD: step 2 (default 0 = stop at once, as before)
+//   - SHUTDOWN_TIMEOUT: step 3 hard deadline (default 10s)
+//
+// DrainPeriod + Timeout must fit in the orchestrator's grace period
+// (Kubernetes terminationGracePeriodSeconds, docker stop --time).
+type Config struct {
+	DrainPeriod time.Duration
+	Timeout     time.Duration
+}
+
+// LoadConfigFromEnv reads the drain settings (fail-open: invalid values
+// log a warning and fall back to the defaults).
+func LoadConfigFromEnv() Config {
+	cfg := Config{
+		DrainPeriod: config.GetEnvDuration("SHUTDOWN_DRAIN_PERIOD", 0),
+		Timeout:     config.GetEnvDuration("SHUTDOWN_TIMEOUT", 10*time.Second),
+	}
+	if cfg.DrainPeriod < 0 {
+		slog.Warn("negative SHUTDOWN_DRAIN_PERIOD, draining without delay", slog.Duration("value", cfg.DrainPeriod))
+		cfg.DrainPeriod = 0
+	}
+	if err := config.ValidatePositiveDuration(cfg.Timeout); err != nil {
+		slog.Warn("invalid SHUTDOWN_TIMEOUT, using default", slog.Any("error", err))
+		cfg.Timeout = 10 * time.Second
+	}
+	return cfg
+}
+
+// Drain runs the sequence: notReady, wait DrainPeriod, then stop with a
+// context that expires after Timeout. It returns stop's error; a
+// context.DeadlineExceeded means work was still in flight at the deadline.
+func (c Config) Drain(logger *slog.Logger, notReady func(), stop func(ctx context.Context) error) error {
+	notReady()
+	if c.DrainPeriod > 0 {
+		logger.Info("draining: readiness is failing, waiting before stopping",
+			slog.Duration("drain_period", c.DrainPeriod))
+		time.Sleep(c.DrainPeriod)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+	return stop(ctx)
+}
+
+// InFlight counts requests being served, for the shutdown logs.
+type InFlight struct {
+	n atomic.Int64
+}
+
+// Middleware counts the requests passing through next.
+func (f *InFlight) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.n.Add(1)
+		defer f.n.Add(-1)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Count returns the number of requests currently being served.
+func (f *InFlight) Count() int64 { return f.n.Load() }
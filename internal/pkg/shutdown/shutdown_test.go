@@ -0,0 +1,81 @@
+package shutdown_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/pkg/shutdown"
+)
+
+var discard = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func TestLoadConfigFromEnv(t *testing.T) {
+	t.Setenv("SHUTDOWN_DRAIN_PERIOD", "")
+	t.Setenv("SHUTDOWN_TIMEOUT", "")
+	assert.Equal(t, shutdown.Config{Timeout: 10 * time.Second}, shutdown.LoadConfigFromEnv())
+
+	t.Setenv("SHUTDOWN_DRAIN_PERIOD", "15s")
+	t.Setenv("SHUTDOWN_TIMEOUT", "25s")
+	assert.Equal(t, shutdown.Config{DrainPeriod: 15 * time.Second, Timeout: 25 * time.Second}, shutdown.LoadConfigFromEnv())
+
+	t.Setenv("SHUTDOWN_DRAIN_PERIOD", "-1s")
+	t.Setenv("SHUTDOWN_TIMEOUT", "0s")
+	assert.Equal(t, shutdown.Config{Timeout: 10 * time.Second}, shutdown.LoadConfigFromEnv())
+}
+
+// Readiness fails first, the drain period passes, and only then does stop
+// run, with the hard deadline on its context.
+func TestConfig_Drain_Order(t *testing.T) {
+	cfg := shutdown.Config{DrainPeriod: 50 * time.Millisecond, Timeout: time.Second}
+	var notReadyAt time.Time
+	var steps []string
+
+	err := cfg.Drain(discard,
+		func() {
+			notReadyAt = time.Now()
+			steps = append(steps, "not ready")
+		},
+		func(ctx context.Context) error {
+			steps = append(steps, "stop")
+			assert.GreaterOrEqual(t, time.Since(notReadyAt), cfg.DrainPeriod)
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			assert.WithinDuration(t, time.Now().Add(cfg.Timeout), deadline, 100*time.Millisecond)
+			return nil
+		})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"not ready", "stop"}, steps)
+}
+
+func TestConfig_Drain_DeadlineExceeded(t *testing.T) {
+	cfg := shutdown.Config{Timeout: 10 * time.Millisecond}
+
+	err := cfg.Drain(discard, func() {}, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestInFlight_Middleware(t *testing.T) {
+	var inFlight shutdown.InFlight
+	var during int64
+	h := inFlight.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		during = inFlight.Count()
+	}))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, int64(1), during)
+	assert.Equal(t, int64(0), inFlight.Count())
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// ErrDuplicateAlertRuleName is returned by Create / Update when the user
+// already has a rule with the name (alert_rules UNIQUE (user_id, name)).
+var ErrDuplicateAlertRuleName = errors.New("alert rule name already exists")
+
+// AlertRuleRepository persists keyword alert rules (alert_rules table).
+type AlertRuleRepository interface {
+	// List returns the user's rules, oldest first.
+	List(ctx context.Context, userID int64) ([]*entity.AlertRule, error)
+	// ListEnabled returns every user's enabled rules, oldest first.
+	ListEnabled(ctx context.Context) ([]*entity.AlertRule, error)
+	// Get returns the rule, or nil when it does not exist.
+	Get(ctx context.Context, id int64) (*entity.AlertRule, error)
+	// Create inserts the rule and sets rule.ID / HitCount / CreatedAt /
+	// UpdatedAt. Returns ErrDuplicateAlertRuleName on a name collision.
+	Create(ctx context.Context, rule *entity.AlertRule) error
+	// Update saves the rule's name, query and enabled flag and sets
+	// rule.UpdatedAt. Returns ErrDuplicateAlertRuleName on a name
+	// collision.
+	Update(ctx context.Context, rule *entity.AlertRule) error
+	// Delete removes the rule (idempotent).
+	Delete(ctx context.Context, id int64) error
+	// RecordHits counts a match of article on each listed rule that is
+	// still enabled and queues a notify_alert job
+	// (entity.NotifyAlertPayload) for it. Returns the number of hits
+	// recorded.
+	RecordHits(ctx context.Context, ruleIDs []int64, article entity.WebhookArticleData) (int64, error)
+}
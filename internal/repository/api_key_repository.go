@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// APIKeyRepository persists service-to-service API keys (api_keys table).
+// Only SHA-256 hex hashes are stored. Revocation is an update of
+// revoked_at; a revoked key is never reactivated — issue a new one.
+type APIKeyRepository interface {
+	// Create inserts the key and sets key.ID / CreatedAt.
+	Create(ctx context.Context, key *entity.APIKey) error
+	// Get returns the key by ID (revoked or not), or nil when not found.
+	Get(ctx context.Context, id int64) (*entity.APIKey, error)
+	// List returns all keys (revoked included), oldest first.
+	List(ctx context.Context) ([]*entity.APIKey, error)
+	// Update rewrites name / role / rate_limit / daily_quota.
+	Update(ctx context.Context, key *entity.APIKey) error
+	// Revoke marks the key revoked as of t (idempotent).
+	Revoke(ctx context.Context, id int64, t time.Time) error
+	// GetActiveByHash resolves a request key hash to a non-revoked key, or
+	// nil when no such key exists.
+	GetActiveByHash(ctx context.Context, keyHash string) (*entity.APIKey, error)
+	// TouchLastUsed sets last_used_at to t.
+	TouchLastUsed(ctx context.Context, id int64, t time.Time) error
+}
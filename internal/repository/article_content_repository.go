@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// ArticleContentRepository persists fetched article pages (article_contents
+// table, one row per article, removed with the article).
+type ArticleContentRepository interface {
+	// Save stores the page of content.ArticleID, replacing an earlier one.
+	// A zero FetchedAt is stored as now.
+	Save(ctx context.Context, content *entity.ArticleContent) error
+	// Get returns the stored page of an article, or nil when there is none.
+	Get(ctx context.Context, articleID int64) (*entity.ArticleContent, error)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// ArticleDigestRepository selects the articles of the notification digest
+// (notify_digest). Like RadioArticleRepository it is a single-purpose
+// query kept out of ArticleRepository.
+type ArticleDigestRepository interface {
+	// ListStoredBetween returns the articles stored (crawled_at) in
+	// [from, to), newest first, up to limit, together with the number of
+	// such articles. Near-duplicates (duplicate_of set) are left out, so a
+	// story appears once under its canonical article. Articles of sources
+	// in a source group with notify turned off are left out too.
+	ListStoredBetween(ctx context.Context, from, to time.Time, limit int) ([]ArticleWithSource, int64, error)
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// ExportedArticle is one row of the scheduled article export: the article,
+// its source and its summary when it has one.
+type ExportedArticle struct {
+	ID          int64
+	SourceID    int64
+	SourceName  string
+	Title       string
+	URL         string
+	PublishedAt *time.Time
+	CrawledAt   time.Time
+	Summary     *string
+	// SummaryProvider and SummarizedAt are set with Summary.
+	SummaryProvider *string
+	SummarizedAt    *time.Time
+}
+
+// ArticleExport is one recorded run of the export: the objects written for
+// the window [From, To).
+type ArticleExport struct {
+	ID         int64
+	From       time.Time
+	To         time.Time
+	ObjectKeys []string
+	Rows       int
+	CreatedAt  time.Time
+}
+
+// ArticleExportRepository backs the worker's export_articles job. Like
+// ArticleDigestRepository it is a single-purpose query kept out of
+// ArticleRepository.
+type ArticleExportRepository interface {
+	// LastExportedUntil returns To of the newest recorded run, or the
+	// zero time before the first one.
+	LastExportedUntil(ctx context.Context) (time.Time, error)
+	// ListChanged returns up to limit articles with ID > afterID, in ID
+	// order, that were stored (crawled_at) or summarized (summaries
+	// created_at) in [from, to). An article summarized after the run that
+	// exported it is therefore exported again, with its summary.
+	ListChanged(ctx context.Context, from, to time.Time, afterID int64, limit int) ([]ExportedArticle, error)
+	// Record saves a completed run and sets export.ID / CreatedAt.
+	Record(ctx context.Context, export *ArticleExport) error
+	// ListExpired returns the runs recorded before before, oldest first.
+	// The newest run is never returned: its To is where the next run
+	// starts.
+	ListExpired(ctx context.Context, before time.Time) ([]ArticleExport, error)
+	// Delete removes the given run records.
+	Delete(ctx context.Context, ids []int64) error
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"catchup-feed/internal/domain/entity"
@@ -13,13 +14,60 @@ type ArticleWithSource struct {
 	SourceName string
 }
 
+// NewArticle is one row of ArticleRepository.CreateBatch: a crawled
+// article and its summary.
+type NewArticle struct {
+	Article *entity.Article
+	Summary *entity.Summary
+}
+
 // ArticleSearchFilters contains optional filters for article search
 type ArticleSearchFilters struct {
-	SourceID *int64     // Optional: Filter by source ID
-	From     *time.Time // Optional: Filter articles published >= this date
-	To       *time.Time // Optional: Filter articles published <= this date
+	SourceID           *int64      // Optional: Filter by source ID
+	GroupID            *int64      // Optional: Filter by source group ID (articles of the group's member sources)
+	From               *time.Time  // Optional: Filter articles published >= this date
+	To                 *time.Time  // Optional: Filter articles published <= this date
+	Tag                *string     // Optional: Filter by tag name (normalized)
+	UnreadFor          *string     // Optional: Only articles this login subject (users.email) has not read
+	FavoritesOf        *string     // Optional: Only articles this login subject (users.email) has starred
+	SubscribedBy       *string     // Optional: Only articles of sources this login subject (users.email) subscribes to
+	Summarized         bool        // Optional: Only articles that have a non-empty summary
+	CollapseDuplicates bool        // Optional: Hide near-duplicates (duplicate_of set), keeping each group's canonical article
+	Sort               ArticleSort // Optional: Result order; the zero value keeps the default order
+}
+
+// ArticleSortField is a sort key accepted by ArticleSort.
+type ArticleSortField string
+
+const (
+	ArticleSortPublishedAt ArticleSortField = "published_at"
+	ArticleSortCreatedAt   ArticleSortField = "created_at" // when the article was stored (crawled_at)
+	ArticleSortTitle       ArticleSortField = "title"
+	ArticleSortRelevance   ArticleSortField = "relevance" // full-text rank; keyword searches only
+)
+
+// ArticleSort selects the order of search results. The zero value is the
+// default order: relevance for keyword searches, newest first otherwise.
+// Explicit orders break ties by article ID so paging is stable.
+type ArticleSort struct {
+	Field     ArticleSortField
+	Ascending bool
 }
 
+// ArticleCursor is a keyset pagination position: the published_at and ID
+// of the last article of the previous page. A nil PublishedAt means that
+// article has none (such articles sort after all dated ones).
+type ArticleCursor struct {
+	PublishedAt *time.Time
+	ID          int64
+}
+
+// ErrDuplicateContentHash is returned by the Create methods when another
+// article already has the same content_hash (entity.ArticleContentHash):
+// the same article under a different URL, typically another tracking
+// query string. The crawl counts it as DuplicatedByHash.
+var ErrDuplicateContentHash = errors.New("article with this content hash already exists")
+
 type ArticleRepository interface {
 	List(ctx context.Context) ([]*entity.Article, error)
 	// ListWithSource retrieves all articles with their source names.
@@ -50,6 +98,13 @@ type ArticleRepository interface {
 	// Returns articles matching the criteria with LIMIT and OFFSET applied.
 	// Includes source_name from JOIN with sources table.
 	SearchWithFiltersPaginated(ctx context.Context, keywords []string, filters ArticleSearchFilters, offset, limit int) ([]ArticleWithSource, error)
+	// ListWithSourceAfter is the keyset (cursor) counterpart of
+	// ListWithSourcePaginated / SearchWithFiltersPaginated: it returns up to
+	// limit articles matching keywords and filters (none = all articles)
+	// that come after the cursor (nil = from the start), ordered by
+	// published_at DESC NULLS LAST, id DESC. Keyword matches are not
+	// relevance-ranked in this mode.
+	ListWithSourceAfter(ctx context.Context, keywords []string, filters ArticleSearchFilters, after *ArticleCursor, limit int) ([]ArticleWithSource, error)
 	// Create inserts a new article row and sets article.ID from the
 	// database (needed for the summaries.article_id foreign key).
 	// article.Summary is read-only and ignored here; persist summaries
@@ -62,6 +117,13 @@ type ArticleRepository interface {
 	// permanently unsummarized articles). Sets article.ID and
 	// summary.ArticleID.
 	CreateWithSummary(ctx context.Context, article *entity.Article, summary *entity.Summary) error
+	// CreateBatch is CreateWithSummary for many articles with multi-row
+	// INSERTs (the crawl stores each source's new articles at once). An
+	// article whose URL or content hash is already stored is skipped (ON
+	// CONFLICT DO NOTHING) and keeps ID 0 — the others get article.ID and
+	// summary.ArticleID. All rows land in one transaction or none do.
+	// Returns the number of articles inserted.
+	CreateBatch(ctx context.Context, items []NewArticle) (int, error)
 	// CreateWithTranscribeJob inserts the article (content NULL) and a
 	// kind='transcribe' job in one transaction (Phase 2 §5: youtube /
 	// podcast の新着検知). Either both rows land or neither, so a
@@ -78,7 +140,18 @@ type ArticleRepository interface {
 	ListUnsummarized(ctx context.Context, limit int) ([]*entity.Article, error)
 	Update(ctx context.Context, article *entity.Article) error
 	Delete(ctx context.Context, id int64) error
+	// ForEachWithSource streams the articles matching keywords and filters
+	// (every article when both are empty) to fn in search order, one row at
+	// a time, so large result sets are never held in memory. Article.Content
+	// is not loaded. An error from fn stops the iteration and is returned.
+	ForEachWithSource(ctx context.Context, keywords []string, filters ArticleSearchFilters, fn func(ArticleWithSource) error) error
+	// DeleteBatch deletes the articles (and their summaries) in one
+	// transaction and returns the IDs that existed and were deleted.
+	DeleteBatch(ctx context.Context, ids []int64) ([]int64, error)
 	ExistsByURL(ctx context.Context, url string) (bool, error)
 	// ExistsByURLBatch はバッチでURL存在チェックを行い、N+1問題を解消する
 	ExistsByURLBatch(ctx context.Context, urls []string) (map[string]bool, error)
+	// ExistsByContentHashBatch reports which of the given content hashes
+	// are already stored (articles.content_hash), keyed like ExistsByURLBatch.
+	ExistsByContentHashBatch(ctx context.Context, hashes []string) (map[string]bool, error)
 }
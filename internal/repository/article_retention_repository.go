@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// ExpiredArticle is an article whose retention window has passed.
+type ExpiredArticle struct {
+	ID          int64
+	SourceID    int64
+	PublishedAt time.Time
+}
+
+// ArticleRetentionRepository finds and purges articles past their
+// retention window (the worker's purge_old_articles job).
+type ArticleRetentionRepository interface {
+	// ListExpired returns up to limit articles, oldest first, published
+	// more than their source's retention_days before now (defaultDays when
+	// the source has none; a window <= 0 keeps the articles forever).
+	// Articles without published_at age from crawled_at. Favorited
+	// articles and articles used by radio segments or learning items are
+	// never returned.
+	ListExpired(ctx context.Context, now time.Time, defaultDays, limit int) ([]ExpiredArticle, error)
+	// Purge deletes the given articles and their summaries in one
+	// transaction, re-checking the protections above under row locks.
+	// With archive, the articles (and summary bodies) are first copied to
+	// articles_archive. Returns the IDs actually purged.
+	Purge(ctx context.Context, ids []int64, archive bool) ([]int64, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// ArticleSimilarityRepository looks up near-duplicate articles by their
+// SimHash (articles.simhash / duplicate_of, entity.ArticleSimHash).
+type ArticleSimilarityRepository interface {
+	// FindNearDuplicate returns the canonical article (duplicate_of, or the
+	// article itself when it is not a duplicate) of the closest article
+	// crawled at or after since, from a source other than excludeSourceID,
+	// whose SimHash is at most maxDistance bits from simHash. Ties go to
+	// the oldest article. Returns 0 when there is none.
+	FindNearDuplicate(ctx context.Context, simHash uint64, maxDistance int, since time.Time, excludeSourceID int64) (int64, error)
+	// ListGroup returns the near-duplicate group of an article: its
+	// canonical article first, then the duplicates by ID. A standalone
+	// article is a group of one; an unknown ID returns an empty slice.
+	ListGroup(ctx context.Context, articleID int64) ([]ArticleWithSource, error)
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// AuditLogFilter narrows GET /audit-logs. Zero values mean "no filter";
+// From is inclusive, To exclusive.
+type AuditLogFilter struct {
+	Actor        string
+	Action       string
+	ResourceType string
+	ResourceID   *int64
+	From         *time.Time
+	To           *time.Time
+}
+
+// AuditLogRepository persists the admin audit trail (audit_logs table).
+// Rows are append-only: there is no update or delete.
+type AuditLogRepository interface {
+	// Insert appends the entry and sets entry.ID / CreatedAt.
+	Insert(ctx context.Context, entry *entity.AuditLog) error
+	// List returns matching entries, newest first.
+	List(ctx context.Context, filter AuditLogFilter, offset, limit int) ([]*entity.AuditLog, error)
+	// Count returns the number of matching entries (pagination total).
+	Count(ctx context.Context, filter AuditLogFilter) (int64, error)
+}
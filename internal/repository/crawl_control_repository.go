@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// CrawlControlRepository persists the crawl maintenance controls
+// (crawl_control and crawl_skipped_sources tables). The database is the
+// only channel between the API and the worker replicas (C-4), so a pause
+// set through the API reaches every replica on its next cron tick.
+type CrawlControlRepository interface {
+	// Get returns the current controls; an unset pause reads as not
+	// paused. SkippedSources is ordered by source ID.
+	Get(ctx context.Context) (*entity.CrawlControl, error)
+	// SetPaused pauses or resumes the scheduled crawls, recording why and
+	// by whom.
+	SetPaused(ctx context.Context, paused bool, reason, updatedBy string) error
+	// SkipSource leaves the source out of the scheduled crawls. Skipping
+	// an already skipped source is a no-op.
+	SkipSource(ctx context.Context, sourceID int64) error
+	// UnskipSource puts the source back into the scheduled crawls and
+	// reports whether it was skipped.
+	UnskipSource(ctx context.Context, sourceID int64) (bool, error)
+}
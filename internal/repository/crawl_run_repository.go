@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// CrawlRunRepository persists the crawl history (crawl_runs table, one
+// row per crawl run).
+type CrawlRunRepository interface {
+	// Start inserts a running row for run (StartedAt) and sets run.ID.
+	Start(ctx context.Context, run *entity.CrawlRun) error
+	// Finish stores the final status, totals, per-source stats and error
+	// of a started run.
+	Finish(ctx context.Context, run *entity.CrawlRun) error
+	// Get returns a run by ID, or nil when it does not exist.
+	Get(ctx context.Context, id int64) (*entity.CrawlRun, error)
+	// List returns runs newest first.
+	List(ctx context.Context, offset, limit int) ([]*entity.CrawlRun, error)
+	// Count returns the number of runs (pagination total).
+	Count(ctx context.Context) (int64, error)
+}
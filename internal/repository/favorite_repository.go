@@ -0,0 +1,16 @@
+package repository
+
+import "context"
+
+// FavoriteRepository persists per-user favorite (starred) articles
+// (article_favorites table).
+type FavoriteRepository interface {
+	// Add stars the article. It reports false when the article does not
+	// exist; starring an already starred article is a no-op that reports
+	// true.
+	Add(ctx context.Context, userID, articleID int64) (bool, error)
+	// Remove unstars the article. Unstarred articles are not an error.
+	Remove(ctx context.Context, userID, articleID int64) error
+	// FavoritedIDs returns which of articleIDs the user has starred.
+	FavoritedIDs(ctx context.Context, userID int64, articleIDs []int64) (map[int64]bool, error)
+}
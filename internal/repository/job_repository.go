@@ -28,12 +28,26 @@ type JobRepository interface {
 	// Enqueue inserts a pending job. A nil payload is stored as '{}'.
 	// runAfter schedules the earliest execution time (time.Time{} = now).
 	Enqueue(ctx context.Context, kind string, payload json.RawMessage, runAfter time.Time) (int64, error)
+	// EnqueueUnique is Enqueue unless a job with the same dedupeKey
+	// exists, whatever its status, in which case nothing is inserted and
+	// enqueued is false. Scheduled work (cron ticks) goes through it with
+	// the tick in the key, so every worker replica may enqueue the same
+	// tick and it runs once, even when one replica fires after the job
+	// has already finished.
+	EnqueueUnique(ctx context.Context, kind, dedupeKey string, payload json.RawMessage, runAfter time.Time) (id int64, enqueued bool, err error)
 	// ClaimNext atomically claims the oldest runnable pending job
-	// (run_after <= now), marks it running, and increments attempts.
+	// (run_after <= now), marks it running with claimed_at = now, and
+	// increments attempts.
 	// Uses SELECT ... FOR UPDATE SKIP LOCKED so concurrent consumers never
 	// double-claim. kinds optionally restricts the job kinds considered.
 	// Returns nil when no job is runnable.
 	ClaimNext(ctx context.Context, kinds ...string) (*entity.Job, error)
+	// Get returns a job by ID, or nil when it does not exist (status
+	// polling for jobs enqueued through the API).
+	Get(ctx context.Context, id int64) (*entity.Job, error)
+	// ListActive returns the pending and running jobs, oldest first
+	// (maintenance status). kinds optionally restricts the job kinds.
+	ListActive(ctx context.Context, kinds ...string) ([]*entity.Job, error)
 	// MarkDone finishes a claimed job successfully.
 	MarkDone(ctx context.Context, id int64) error
 	// MarkFailed records the error. With retryAt set the job goes back to
@@ -41,18 +55,20 @@ type JobRepository interface {
 	// terminally. Retry-count policy stays in the caller: it reads
 	// Job.Attempts (incremented by ClaimNext) and decides.
 	MarkFailed(ctx context.Context, id int64, lastError string, retryAt *time.Time) error
-	// RequeueRunning flips running jobs of the given kinds back to pending
-	// and returns how many rows it touched (the stale-job sweep, run at
-	// consumer startup). The jobs table has multiple consumers — the Pi
-	// worker (cmd/worker) and the Mac transcribe worker (Python, Phase 2) —
-	// so each consumer MUST sweep only the kinds it is registered to
-	// handle and never touch another consumer's 'running' rows: a row of
-	// my own kinds that is 'running' when I start can only be the orphan
-	// of my crashed predecessor, while a 'running' row of a foreign kind
-	// is very likely mid-execution on the other host. Sweeping it would
-	// cause double execution and double attempts-counting. Calling with no
-	// kinds sweeps nothing (never all). Attempts stay as incremented by
-	// the crashed claim, so a repeatedly crashing job still hits the retry
-	// ceiling.
-	RequeueRunning(ctx context.Context, kinds ...string) (int64, error)
+	// RequeueRunning flips running jobs of the given kinds claimed before
+	// claimedBefore back to pending and returns how many rows it touched
+	// (the stale-job sweep). The jobs table has multiple consumers — the
+	// Pi worker replicas (cmd/worker) and the Mac transcribe worker
+	// (Python, Phase 2) — so each consumer MUST sweep only the kinds it is
+	// registered to handle and never touch another consumer's 'running'
+	// rows: a 'running' row of a foreign kind is very likely
+	// mid-execution on the other host. Sweeping it would cause double
+	// execution and double attempts-counting. Within its own kinds the
+	// cutoff does the same job across replicas: a row claimed less than
+	// one job timeout ago may be live on another replica, an older one
+	// can only be an orphan (rows without claimed_at predate the column
+	// and count as old). Calling with no kinds sweeps nothing (never
+	// all). Attempts stay as incremented by the crashed claim, so a
+	// repeatedly crashing job still hits the retry ceiling.
+	RequeueRunning(ctx context.Context, claimedBefore time.Time, kinds ...string) (int64, error)
 }
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// MFARepository persists TOTP enrollments (user_mfa table), one per user.
+type MFARepository interface {
+	// Get returns the user's enrollment (pending or enabled), or nil.
+	Get(ctx context.Context, userID int64) (*entity.UserMFA, error)
+	// SavePending stores secret as the user's pending enrollment, replacing
+	// an earlier pending one. An enabled enrollment is left untouched and
+	// reported as false.
+	SavePending(ctx context.Context, userID int64, secret string) (bool, error)
+	// Enable activates the pending enrollment as of t, recording step as
+	// the last accepted code.
+	Enable(ctx context.Context, userID int64, step int64, t time.Time) error
+	// UseStep records step as the last accepted code when it is newer than
+	// the stored one, and reports whether it was. A false result means the
+	// code was already used (replay).
+	UseStep(ctx context.Context, userID int64, step int64) (bool, error)
+	// Delete removes the enrollment (idempotent).
+	Delete(ctx context.Context, userID int64) error
+}
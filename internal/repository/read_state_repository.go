@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// SourceUnreadCount is the number of articles of one source a user has not
+// read yet.
+type SourceUnreadCount struct {
+	SourceID   int64
+	SourceName string
+	Unread     int64
+}
+
+// ReadStateChange is the latest read-state change of one article for a
+// user: read or marked unread at ChangedAt.
+type ReadStateChange struct {
+	ArticleID int64
+	Read      bool
+	ChangedAt time.Time
+}
+
+// ReadStateRepository persists per-user read marks (article_read_state
+// table). An article without a row, or whose row is an unread mark, is
+// unread.
+type ReadStateRepository interface {
+	// MarkRead marks the articles read as of at and returns how many of
+	// articleIDs exist (IDs of missing articles are ignored).
+	MarkRead(ctx context.Context, userID int64, articleIDs []int64, at time.Time) (int64, error)
+	// MarkUnread turns the read marks into unread marks as of at and
+	// returns how many articles were read.
+	MarkUnread(ctx context.Context, userID int64, articleIDs []int64, at time.Time) (int64, error)
+	// Changes returns the user's read-state changes at or after since,
+	// oldest first.
+	Changes(ctx context.Context, userID int64, since time.Time) ([]ReadStateChange, error)
+	// Merge applies changes made elsewhere (one per article) last-writer-
+	// wins: a change older than the stored one is ignored. Changes of
+	// missing articles are ignored too. Returns how many were applied.
+	Merge(ctx context.Context, userID int64, changes []ReadStateChange) (int64, error)
+	// UnreadCounts returns the user's unread article count for every active
+	// source (zero included), ordered by source name.
+	UnreadCounts(ctx context.Context, userID int64) ([]SourceUnreadCount, error)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// RefreshTokenRepository persists refresh tokens (refresh_tokens table).
+// Only SHA-256 hex hashes are stored.
+type RefreshTokenRepository interface {
+	// Create inserts the token and sets token.ID / CreatedAt.
+	Create(ctx context.Context, token *entity.RefreshToken) error
+	// GetByHash returns the token with the given hash (used, revoked or
+	// expired included), or nil when not found.
+	GetByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	// MarkUsed consumes the token as of t. It reports false when the token
+	// was already used or revoked, so two concurrent refreshes with the
+	// same token cannot both succeed.
+	MarkUsed(ctx context.Context, id int64, t time.Time) (bool, error)
+	// RevokeFamily revokes every live token of the family as of t.
+	RevokeFamily(ctx context.Context, familyID string, t time.Time) error
+	// DeleteExpired deletes tokens that expired before t and returns the
+	// number deleted.
+	DeleteExpired(ctx context.Context, t time.Time) (int64, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// RevokedTokenRepository persists the access-token denylist
+// (revoked_tokens table), keyed by the JWT "jti" claim.
+type RevokedTokenRepository interface {
+	// Add denylists jti until expiresAt (the token's own exp). Adding an
+	// already revoked jti is a no-op.
+	Add(ctx context.Context, jti, subject string, expiresAt time.Time) error
+	// Exists reports whether jti is denylisted.
+	Exists(ctx context.Context, jti string) (bool, error)
+	// DeleteExpired deletes entries whose token expired before t and
+	// returns the number deleted.
+	DeleteExpired(ctx context.Context, t time.Time) (int64, error)
+}
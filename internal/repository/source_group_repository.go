@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// ErrDuplicateSourceGroupName is returned by Create / Update when another
+// group has the name (source_groups.name UNIQUE).
+var ErrDuplicateSourceGroupName = errors.New("source group name already exists")
+
+// SourceGroupRepository persists source groups (source_groups table) and
+// their membership (sources.group_id).
+type SourceGroupRepository interface {
+	// List returns every group with its SourceIDs, ordered by name.
+	List(ctx context.Context) ([]*entity.SourceGroup, error)
+	// Get returns the group with its SourceIDs, or nil when it does not
+	// exist.
+	Get(ctx context.Context, id int64) (*entity.SourceGroup, error)
+	// Create inserts the group and sets group.ID / CreatedAt / UpdatedAt.
+	// SourceIDs is ignored (see SetSources). Returns
+	// ErrDuplicateSourceGroupName on a name collision.
+	Create(ctx context.Context, group *entity.SourceGroup) error
+	// Update saves the group's name and settings and sets
+	// group.UpdatedAt. Returns ErrDuplicateSourceGroupName on a name
+	// collision.
+	Update(ctx context.Context, group *entity.SourceGroup) error
+	// Delete removes the group (idempotent); its members become
+	// ungrouped.
+	Delete(ctx context.Context, id int64) error
+	// SetSources makes sourceIDs the members of the group in one
+	// transaction: the listed sources move into it (leaving any other
+	// group) and the former members not listed become ungrouped. It
+	// returns the listed IDs that exist, ascending; unknown IDs are
+	// ignored.
+	SetSources(ctx context.Context, groupID int64, sourceIDs []int64) ([]int64, error)
+}
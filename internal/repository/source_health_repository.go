@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// SourceHealthRepository persists per-source crawl health (source_health
+// table, one row per source, removed with the source).
+type SourceHealthRepository interface {
+	// RecordFetch stores the outcome of one feed fetch at the given time.
+	// fetchErr == "" is a success: the failure streak and last error are
+	// reset and last_success_at advances. httpStatus 0 means no response
+	// and is stored as NULL.
+	RecordFetch(ctx context.Context, sourceID int64, at time.Time, httpStatus int, fetchErr string) error
+	// Get returns the health of a source, or nil when nothing has been
+	// recorded yet.
+	Get(ctx context.Context, sourceID int64) (*entity.SourceHealth, error)
+	// List returns every recorded health row ordered by source_id.
+	List(ctx context.Context) ([]*entity.SourceHealth, error)
+}
@@ -21,4 +21,9 @@ type SourceRepository interface {
 	Create(ctx context.Context, source *entity.Source) error
 	Update(ctx context.Context, source *entity.Source) error
 	Delete(ctx context.Context, id int64) error
+	// UpdateFeedValidators stores the cache validators (ETag /
+	// Last-Modified) of the source's last feed response. Empty strings are
+	// stored as NULL, making the next fetch unconditional. A missing
+	// source is not an error.
+	UpdateFeedValidators(ctx context.Context, id int64, etag, lastModified string) error
 }
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// SourceSubscriptionRepository persists per-user source subscriptions
+// (source_subscriptions table).
+type SourceSubscriptionRepository interface {
+	// Subscribe follows the source. It reports false when the source does
+	// not exist; subscribing again is a no-op that reports true.
+	Subscribe(ctx context.Context, userID, sourceID int64) (bool, error)
+	// Unsubscribe unfollows the source. Unfollowed sources are not an
+	// error.
+	Unsubscribe(ctx context.Context, userID, sourceID int64) error
+	// ListSources returns the sources the user subscribes to, ordered by
+	// source ID.
+	ListSources(ctx context.Context, userID int64) ([]*entity.Source, error)
+	// SourceIDs returns the IDs of the sources the user subscribes to.
+	SourceIDs(ctx context.Context, userID int64) (map[int64]bool, error)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// StatsRepository computes the admin dashboard aggregates (GET /stats) in
+// SQL over articles and crawl_runs. Every method is read-only.
+type StatsRepository interface {
+	// ArticleCountsBySource returns every source with its total article
+	// count and the count crawled at or after since.
+	ArticleCountsBySource(ctx context.Context, since time.Time) ([]*entity.SourceArticleStats, error)
+	// ArticleCountsByPeriod counts the articles crawled per bucket of
+	// stepDays days, from the JST day of from through the JST day of to
+	// (inclusive). Empty buckets are returned with a zero count.
+	ArticleCountsByPeriod(ctx context.Context, from, to time.Time, stepDays int) ([]entity.ArticleCountBucket, error)
+	// CrawlRunStats aggregates the crawl runs started at or after since.
+	CrawlRunStats(ctx context.Context, since time.Time) (*entity.CrawlRunStats, error)
+}
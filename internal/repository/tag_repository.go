@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// ErrDuplicateTagName is returned by Create / Update when the name is
+// already used by another tag (tags.name UNIQUE).
+var ErrDuplicateTagName = errors.New("tag name already exists")
+
+// TagRepository persists tags and their assignment to articles (tags /
+// article_tags tables). Names are expected to be normalized by the caller
+// (entity.NormalizeTagName).
+type TagRepository interface {
+	// List returns every tag ordered by name, with ArticleCount set.
+	List(ctx context.Context) ([]*entity.Tag, error)
+	// Get returns the tag, or nil when it does not exist.
+	Get(ctx context.Context, id int64) (*entity.Tag, error)
+	// Create inserts the tag and sets tag.ID / CreatedAt. Returns
+	// ErrDuplicateTagName on a name collision.
+	Create(ctx context.Context, tag *entity.Tag) error
+	// Update renames the tag. Returns ErrDuplicateTagName on a name
+	// collision.
+	Update(ctx context.Context, tag *entity.Tag) error
+	// Delete removes the tag and its assignments (idempotent).
+	Delete(ctx context.Context, id int64) error
+	// ListByArticle returns the article's tags ordered by name.
+	ListByArticle(ctx context.Context, articleID int64) ([]*entity.Tag, error)
+	// SetArticleTags replaces the article's tags with names in one
+	// transaction, creating tags that do not exist yet, and returns the
+	// resulting tags ordered by name.
+	SetArticleTags(ctx context.Context, articleID int64, names []string) ([]*entity.Tag, error)
+	// UpdateArticlesTags removes the remove tags from and then adds the add
+	// tags (creating missing ones) to every article in articleIDs, in one
+	// transaction. Returns the IDs of the articles that exist.
+	UpdateArticlesTags(ctx context.Context, articleIDs []int64, add, remove []string) ([]int64, error)
+	// ListTopBySource returns up to limit tag names most used on the
+	// source's articles, most used first.
+	ListTopBySource(ctx context.Context, sourceID int64, limit int) ([]string, error)
+}
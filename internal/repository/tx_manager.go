@@ -0,0 +1,16 @@
+package repository
+
+import "context"
+
+// TxManager runs a use case step as one unit of work: every repository call
+// made with the ctx passed to fn joins the same database transaction, which
+// commits when fn returns nil and rolls back otherwise. Repository methods
+// that open their own transaction (ArticleRepository.CreateWithSummary,
+// TagRepository.SetArticleTags, ...) join the outer one instead of nesting.
+//
+// Only writes that must land together belong in fn: a failed statement
+// aborts the whole PostgreSQL transaction, so best-effort side effects
+// (auditing) stay outside.
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// ErrDuplicateUserEmail is returned by Create / Update when the email
+// collides with another account (users.email UNIQUE, shared by admins and
+// viewers). The use case maps it to a client-facing conflict error.
+var ErrDuplicateUserEmail = errors.New("user email already exists")
+
+// UserRepository persists dashboard accounts of every role (users table).
+// ViewerRepository is the role=viewer view of the same table.
+type UserRepository interface {
+	// Create inserts the user and sets user.ID / CreatedAt / UpdatedAt.
+	// Returns ErrDuplicateUserEmail on an email collision.
+	Create(ctx context.Context, user *entity.User) error
+	// Get returns the user, or nil when not found.
+	Get(ctx context.Context, id int64) (*entity.User, error)
+	// List returns all users (every role, active and deactivated), oldest
+	// first.
+	List(ctx context.Context) ([]*entity.User, error)
+	// Update rewrites name / email / role / password_hash and bumps
+	// updated_at. Returns ErrDuplicateUserEmail on an email collision.
+	Update(ctx context.Context, user *entity.User) error
+	// Deactivate marks the user inactive as of t (idempotent).
+	Deactivate(ctx context.Context, id int64, t time.Time) error
+	// Reactivate clears deactivated_at (idempotent).
+	Reactivate(ctx context.Context, id int64) error
+	// Delete removes the user row physically.
+	Delete(ctx context.Context, id int64) error
+	// GetActiveByEmail returns the active user with the given email, or nil.
+	GetActiveByEmail(ctx context.Context, email string) (*entity.User, error)
+	// CountActiveAdmins returns the number of active role=admin users.
+	CountActiveAdmins(ctx context.Context) (int, error)
+}
@@ -9,12 +9,12 @@ import (
 )
 
 // ErrDuplicateViewerEmail is returned by Create / Update when the email
-// collides with another viewer (viewers.email UNIQUE). The use case maps it
+// collides with another account (users.email UNIQUE — admins included). The use case maps it
 // to a client-facing conflict error.
 var ErrDuplicateViewerEmail = errors.New("viewer email already exists")
 
-// ViewerRepository persists read-only dashboard accounts (viewers table,
-// D-27). Unlike subscribers, viewers support both logical deactivation
+// ViewerRepository persists read-only dashboard accounts (the role=viewer
+// rows of the users table, D-27). Unlike subscribers, viewers support both logical deactivation
 // (login/browse blocked immediately) and physical deletion.
 type ViewerRepository interface {
 	// Create inserts the viewer and sets viewer.ID / CreatedAt / UpdatedAt.
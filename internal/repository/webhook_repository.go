@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// WebhookRepository persists outbound webhook subscriptions (webhooks) and
+// their delivery log (webhook_deliveries). Deleting a webhook deletes its
+// deliveries; their pending jobs then find no row and fail permanently.
+type WebhookRepository interface {
+	// Create inserts the webhook and sets webhook.ID / CreatedAt.
+	Create(ctx context.Context, webhook *entity.Webhook) error
+	// Get returns the webhook by ID, or nil when not found.
+	Get(ctx context.Context, id int64) (*entity.Webhook, error)
+	// List returns all webhooks, oldest first.
+	List(ctx context.Context) ([]*entity.Webhook, error)
+	// Delete removes the webhook and its deliveries.
+	Delete(ctx context.Context, id int64) error
+	// CreateDeliveries records a pending delivery of payload for every
+	// webhook subscribed to event and enqueues one deliver_webhook job per
+	// delivery, atomically. Returns the number of deliveries created.
+	CreateDeliveries(ctx context.Context, event string, payload json.RawMessage) (int64, error)
+	// CreateDeliveriesTo is CreateDeliveries restricted to the given
+	// webhooks (the routed article.created fan-out). Unknown IDs are
+	// skipped.
+	CreateDeliveriesTo(ctx context.Context, webhookIDs []int64, event string, payload json.RawMessage) (int64, error)
+	// GetDelivery returns the delivery by ID, or nil when not found.
+	GetDelivery(ctx context.Context, id int64) (*entity.WebhookDelivery, error)
+	// UpdateDelivery records the outcome of an attempt: status, attempts,
+	// response_status, last_error and delivered_at.
+	UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+	// ListDeliveries returns the webhook's most recent deliveries, newest
+	// first, at most limit of them.
+	ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]*entity.WebhookDelivery, error)
+}
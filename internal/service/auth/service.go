@@ -1,8 +1,9 @@
 // Package auth provides framework-agnostic authentication business logic
 // for the administrator.
 //
-// 管理者は環境変数+bcrypt のまま(C-7。users テーブルなしの原則は admin
-// について維持)。閲覧専用アカウント(viewer, D-27)の照合はここではなく
+// 管理者の照合先は AuthProvider で差し替える: 本番は users テーブル
+// (UserAuthProvider。admin に加えカスタムロールも照合し、RoleProvider で
+// role を返す)、旧来の環境変数+bcrypt(C-7)は AdminAuthProvider。閲覧専用アカウント(viewer, D-27)の照合はここではなく
 // usecase/viewer が担い、HTTP 層(TokenHandler)が admin → viewer の順で
 // フォールバックする。
 package auth
@@ -42,3 +43,23 @@ func NewAuthService(provider AuthProvider) *AuthService {
 func (s *AuthService) ValidateCredentials(ctx context.Context, creds Credentials) error {
 	return s.provider.ValidateCredentials(ctx, creds)
 }
+
+// RoleProvider is implemented by providers that authenticate accounts of
+// more than one role (the users table: admins and custom roles). The
+// returned role becomes the token's role claim.
+type RoleProvider interface {
+	AuthenticateRole(ctx context.Context, creds Credentials) (string, error)
+}
+
+// Authenticate validates credentials and returns the account's role. For
+// providers that only know the administrator (no RoleProvider), the role
+// is "" and callers treat a success as the admin.
+func (s *AuthService) Authenticate(ctx context.Context, creds Credentials) (string, error) {
+	if rp, ok := s.provider.(RoleProvider); ok {
+		return rp.AuthenticateRole(ctx, creds)
+	}
+	if err := s.provider.ValidateCredentials(ctx, creds); err != nil {
+		return "", err
+	}
+	return "", nil
+}
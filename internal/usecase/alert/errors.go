@@ -0,0 +1,33 @@
+// Package alert provides per-user keyword alerts: a dashboard account
+// defines rules (a name and a boolean keyword query), and every article a
+// crawl stores is matched against the enabled rules; a hit is counted on
+// the rule and notified to the admin destinations tagged with the rule
+// name. The caller is identified by the login subject (users.email) the
+// auth middleware puts in the request context.
+package alert
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors. Messages contain respond.SafeError's safe words so they
+// reach the client verbatim. Name and query problems are returned as
+// *entity.ValidationError.
+var (
+	// ErrAccountNotFound indicates the caller has no active account, e.g.
+	// an API key identity: alert rules are kept per user.
+	ErrAccountNotFound = errors.New("account not found: alert rules require a user account")
+
+	// ErrRuleNotFound indicates the rule does not exist or belongs to
+	// another user.
+	ErrRuleNotFound = errors.New("alert rule not found")
+
+	// ErrRuleNameTaken indicates the caller already has a rule with the
+	// name.
+	ErrRuleNameTaken = errors.New("alert rule already exists")
+
+	// ErrTooManyRules indicates the caller already has MaxRulesPerUser
+	// rules.
+	ErrTooManyRules = fmt.Errorf("alert rules are invalid: must be at most %d per user", MaxRulesPerUser)
+)
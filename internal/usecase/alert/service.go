@@ -0,0 +1,206 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// MaxRulesPerUser caps the rules of one account; every rule is evaluated
+// against every stored article.
+const MaxRulesPerUser = 50
+
+// RuleInput carries the fields of POST /alerts and PUT /alerts/{id}.
+type RuleInput struct {
+	Name  string
+	Query string
+	// Enabled nil means true: a new or replaced rule alerts unless it is
+	// explicitly disabled.
+	Enabled *bool
+}
+
+// Service manages the caller's alert rules and matches stored articles
+// against every user's enabled rules (Publish).
+type Service struct {
+	Users  repository.UserRepository
+	Rules  repository.AlertRuleRepository
+	Logger *slog.Logger
+}
+
+func (s *Service) logger() *slog.Logger {
+	if s.Logger != nil {
+		return s.Logger
+	}
+	return slog.Default()
+}
+
+// userID resolves the subject to an active account, or
+// ErrAccountNotFound.
+func (s *Service) userID(ctx context.Context, subject string) (int64, error) {
+	user, err := s.Users.GetActiveByEmail(ctx, strings.ToLower(strings.TrimSpace(subject)))
+	if err != nil {
+		return 0, fmt.Errorf("get account: %w", err)
+	}
+	if user == nil {
+		return 0, ErrAccountNotFound
+	}
+	return user.ID, nil
+}
+
+// validate trims the input and checks the name and the query.
+func validate(in *RuleInput) error {
+	in.Name = strings.TrimSpace(in.Name)
+	in.Query = strings.TrimSpace(in.Query)
+	if err := entity.ValidateAlertRuleName(in.Name); err != nil {
+		return err
+	}
+	_, err := entity.ParseAlertQuery(in.Query)
+	return err
+}
+
+func enabled(in RuleInput) bool {
+	return in.Enabled == nil || *in.Enabled
+}
+
+// List returns the caller's rules, oldest first.
+func (s *Service) List(ctx context.Context, subject string) ([]*entity.AlertRule, error) {
+	userID, err := s.userID(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := s.Rules.List(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list alert rules: %w", err)
+	}
+	return rules, nil
+}
+
+// Get returns the caller's rule, or ErrRuleNotFound when it does not
+// exist or belongs to someone else.
+func (s *Service) Get(ctx context.Context, subject string, id int64) (*entity.AlertRule, error) {
+	userID, err := s.userID(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	return s.get(ctx, userID, id)
+}
+
+func (s *Service) get(ctx context.Context, userID, id int64) (*entity.AlertRule, error) {
+	rule, err := s.Rules.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get alert rule: %w", err)
+	}
+	if rule == nil || rule.UserID != userID {
+		return nil, ErrRuleNotFound
+	}
+	return rule, nil
+}
+
+// Create adds a rule for the caller.
+func (s *Service) Create(ctx context.Context, subject string, in RuleInput) (*entity.AlertRule, error) {
+	if err := validate(&in); err != nil {
+		return nil, err
+	}
+	userID, err := s.userID(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.Rules.List(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list alert rules: %w", err)
+	}
+	if len(existing) >= MaxRulesPerUser {
+		return nil, ErrTooManyRules
+	}
+	rule := &entity.AlertRule{UserID: userID, Name: in.Name, Query: in.Query, Enabled: enabled(in)}
+	if err := s.Rules.Create(ctx, rule); err != nil {
+		if errors.Is(err, repository.ErrDuplicateAlertRuleName) {
+			return nil, ErrRuleNameTaken
+		}
+		return nil, fmt.Errorf("create alert rule: %w", err)
+	}
+	return rule, nil
+}
+
+// Update replaces the rule's name, query and enabled flag. The hit
+// counters are kept.
+func (s *Service) Update(ctx context.Context, subject string, id int64, in RuleInput) (*entity.AlertRule, error) {
+	if err := validate(&in); err != nil {
+		return nil, err
+	}
+	userID, err := s.userID(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := s.get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	rule.Name, rule.Query, rule.Enabled = in.Name, in.Query, enabled(in)
+	if err := s.Rules.Update(ctx, rule); err != nil {
+		if errors.Is(err, repository.ErrDuplicateAlertRuleName) {
+			return nil, ErrRuleNameTaken
+		}
+		return nil, fmt.Errorf("update alert rule: %w", err)
+	}
+	return rule, nil
+}
+
+// Delete removes the caller's rule.
+func (s *Service) Delete(ctx context.Context, subject string, id int64) error {
+	userID, err := s.userID(ctx, subject)
+	if err != nil {
+		return err
+	}
+	if _, err := s.get(ctx, userID, id); err != nil {
+		return err
+	}
+	if err := s.Rules.Delete(ctx, id); err != nil {
+		return fmt.Errorf("delete alert rule: %w", err)
+	}
+	return nil
+}
+
+// Publish matches an article.created event against every enabled rule
+// and records the hits (hit count + notify_alert job). It implements the
+// crawl's EventPublisher next to the webhooks, so inside the crawl's unit
+// of work a hit commits with the article. Other events, and articles
+// grouped under an earlier near-duplicate (which already alerted), are
+// ignored. A stored rule whose query no longer parses is skipped.
+func (s *Service) Publish(ctx context.Context, event string, data any) error {
+	article, ok := data.(entity.WebhookArticleData)
+	if !ok || event != entity.WebhookEventArticleCreated || article.DuplicateOf != nil {
+		return nil
+	}
+	rules, err := s.Rules.ListEnabled(ctx)
+	if err != nil {
+		return fmt.Errorf("match alert rules: %w", err)
+	}
+	var hits []int64
+	for _, rule := range rules {
+		query, err := entity.ParseAlertQuery(rule.Query)
+		if err != nil {
+			s.logger().WarnContext(ctx, "alert rule query invalid, skipped",
+				slog.Int64("rule_id", rule.ID), slog.Any("error", err))
+			continue
+		}
+		if query.MatchArticle(article.Title, article.Summary) {
+			hits = append(hits, rule.ID)
+		}
+	}
+	n, err := s.Rules.RecordHits(ctx, hits, article)
+	if err != nil {
+		return fmt.Errorf("match alert rules: %w", err)
+	}
+	if n > 0 {
+		s.logger().DebugContext(ctx, "alert rules matched",
+			slog.Int64("article_id", article.ID),
+			slog.Int64("hits", n))
+	}
+	return nil
+}
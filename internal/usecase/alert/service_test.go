@@ -0,0 +1,215 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+/* ───────── モック実装 ───────── */
+
+// stubUserRepo implements only GetActiveByEmail: alice is user 7, bob 8.
+type stubUserRepo struct {
+	repository.UserRepository
+}
+
+func (stubUserRepo) GetActiveByEmail(_ context.Context, email string) (*entity.User, error) {
+	switch email {
+	case "alice@example.com":
+		return &entity.User{ID: 7, Email: email}, nil
+	case "bob@example.com":
+		return &entity.User{ID: 8, Email: email}, nil
+	}
+	return nil, nil
+}
+
+// stubRules keeps rules in memory and records RecordHits calls.
+type stubRules struct {
+	rules  []*entity.AlertRule
+	nextID int64
+
+	hitIDs  []int64
+	hitData entity.WebhookArticleData
+	listErr error
+}
+
+func (s *stubRules) List(_ context.Context, userID int64) ([]*entity.AlertRule, error) {
+	var out []*entity.AlertRule
+	for _, r := range s.rules {
+		if r.UserID == userID {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (s *stubRules) ListEnabled(context.Context) ([]*entity.AlertRule, error) {
+	if s.listErr != nil {
+		return nil, s.listErr
+	}
+	var out []*entity.AlertRule
+	for _, r := range s.rules {
+		if r.Enabled {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (s *stubRules) Get(_ context.Context, id int64) (*entity.AlertRule, error) {
+	for _, r := range s.rules {
+		if r.ID == id {
+			copied := *r
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *stubRules) nameTaken(rule *entity.AlertRule) bool {
+	for _, r := range s.rules {
+		if r.UserID == rule.UserID && r.Name == rule.Name && r.ID != rule.ID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *stubRules) Create(_ context.Context, rule *entity.AlertRule) error {
+	if s.nameTaken(rule) {
+		return repository.ErrDuplicateAlertRuleName
+	}
+	s.nextID++
+	rule.ID = s.nextID
+	s.rules = append(s.rules, rule)
+	return nil
+}
+
+func (s *stubRules) Update(_ context.Context, rule *entity.AlertRule) error {
+	if s.nameTaken(rule) {
+		return repository.ErrDuplicateAlertRuleName
+	}
+	for i, r := range s.rules {
+		if r.ID == rule.ID {
+			s.rules[i] = rule
+		}
+	}
+	return nil
+}
+
+func (s *stubRules) Delete(_ context.Context, id int64) error {
+	for i, r := range s.rules {
+		if r.ID == id {
+			s.rules = append(s.rules[:i], s.rules[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (s *stubRules) RecordHits(_ context.Context, ruleIDs []int64, article entity.WebhookArticleData) (int64, error) {
+	s.hitIDs, s.hitData = ruleIDs, article
+	return int64(len(ruleIDs)), nil
+}
+
+func newService() (*Service, *stubRules) {
+	rules := &stubRules{}
+	return &Service{Users: stubUserRepo{}, Rules: rules}, rules
+}
+
+/* ───────── テスト ───────── */
+
+func TestService_CRUD(t *testing.T) {
+	svc, _ := newService()
+	ctx := context.Background()
+
+	created, err := svc.Create(ctx, "Alice@Example.com", RuleInput{Name: " Go ", Query: " go -beta "})
+	require.NoError(t, err)
+	assert.Equal(t, "Go", created.Name)
+	assert.Equal(t, "go -beta", created.Query)
+	assert.True(t, created.Enabled, "enabled defaults to true")
+	assert.Equal(t, int64(7), created.UserID)
+
+	_, err = svc.Create(ctx, "alice@example.com", RuleInput{Name: "Go", Query: "golang"})
+	assert.ErrorIs(t, err, ErrRuleNameTaken)
+
+	disabled := false
+	updated, err := svc.Update(ctx, "alice@example.com", created.ID, RuleInput{Name: "Go", Query: "go OR golang", Enabled: &disabled})
+	require.NoError(t, err)
+	assert.False(t, updated.Enabled)
+	assert.Equal(t, "go OR golang", updated.Query)
+
+	rules, err := svc.List(ctx, "alice@example.com")
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+
+	// 他人のルールは存在しない扱い
+	_, err = svc.Get(ctx, "bob@example.com", created.ID)
+	assert.ErrorIs(t, err, ErrRuleNotFound)
+	assert.ErrorIs(t, svc.Delete(ctx, "bob@example.com", created.ID), ErrRuleNotFound)
+
+	require.NoError(t, svc.Delete(ctx, "alice@example.com", created.ID))
+	_, err = svc.Get(ctx, "alice@example.com", created.ID)
+	assert.ErrorIs(t, err, ErrRuleNotFound)
+}
+
+func TestService_Create_Invalid(t *testing.T) {
+	svc, rules := newService()
+	ctx := context.Background()
+
+	for _, in := range []RuleInput{
+		{Name: "", Query: "go"},
+		{Name: "Go", Query: ""},
+		{Name: "Go", Query: "go AND"},
+	} {
+		_, err := svc.Create(ctx, "alice@example.com", in)
+		var verr *entity.ValidationError
+		assert.True(t, errors.As(err, &verr), "input %+v: %v", in, err)
+	}
+
+	_, err := svc.Create(ctx, "apikey:ci", RuleInput{Name: "Go", Query: "go"})
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+
+	for i := range MaxRulesPerUser {
+		rules.rules = append(rules.rules, &entity.AlertRule{ID: int64(100 + i), UserID: 7})
+	}
+	_, err = svc.Create(ctx, "alice@example.com", RuleInput{Name: "Go", Query: "go"})
+	assert.ErrorIs(t, err, ErrTooManyRules)
+}
+
+func TestService_Publish(t *testing.T) {
+	svc, rules := newService()
+	rules.rules = []*entity.AlertRule{
+		{ID: 1, UserID: 7, Name: "Go", Query: "go -beta", Enabled: true},
+		{ID: 2, UserID: 8, Name: "Rust", Query: "rust", Enabled: true},
+		{ID: 3, UserID: 8, Name: "Go (off)", Query: "go", Enabled: false},
+		{ID: 4, UserID: 8, Name: "Broken", Query: "go AND", Enabled: true},
+		{ID: 5, UserID: 8, Name: "Release", Query: `"1.23" OR リリース`, Enabled: true},
+	}
+	article := entity.WebhookArticleData{ID: 10, Title: "Go 1.23", Summary: "リリースされました"}
+
+	require.NoError(t, svc.Publish(context.Background(), entity.WebhookEventArticleCreated, article))
+	assert.Equal(t, []int64{1, 5}, rules.hitIDs)
+	assert.Equal(t, article, rules.hitData)
+
+	t.Run("other events and near-duplicates are ignored", func(t *testing.T) {
+		rules.hitIDs = nil
+		require.NoError(t, svc.Publish(context.Background(), entity.WebhookEventCrawlCompleted, entity.WebhookCrawlData{}))
+		canonical := int64(3)
+		duplicate := article
+		duplicate.DuplicateOf = &canonical
+		require.NoError(t, svc.Publish(context.Background(), entity.WebhookEventArticleCreated, duplicate))
+		assert.Nil(t, rules.hitIDs)
+	})
+
+	t.Run("a failed rule lookup is returned", func(t *testing.T) {
+		rules.listErr = errors.New("db down")
+		assert.Error(t, svc.Publish(context.Background(), entity.WebhookEventArticleCreated, article))
+	})
+}
@@ -0,0 +1,37 @@
+// Package apikey provides the service-to-service API key use cases:
+// admin-managed key CRUD (issue / update / revoke) plus the per-request
+// authentication and per-key rate limit / daily quota the auth layer
+// delegates here.
+package apikey
+
+import "errors"
+
+// Sentinel errors. Messages contain respond.SafeError's safe words so they
+// reach the client verbatim.
+var (
+	// ErrKeyNotFound indicates the key does not exist.
+	ErrKeyNotFound = errors.New("api key not found")
+
+	// ErrNameRequired indicates a missing key name.
+	ErrNameRequired = errors.New("name is required")
+
+	// ErrInvalidRole indicates a role other than admin / viewer or a custom
+	// role of Service.CustomRoles.
+	ErrInvalidRole = errors.New("role is invalid: must be admin, viewer or a role defined in AUTH_ROLES")
+
+	// ErrInvalidRateLimit indicates a non-positive per-minute limit.
+	ErrInvalidRateLimit = errors.New("rate_limit is invalid: must be a positive integer")
+
+	// ErrInvalidQuota indicates a non-positive daily quota.
+	ErrInvalidQuota = errors.New("daily_quota is invalid: must be a positive integer or null")
+
+	// ErrInvalidKey is the generic authentication failure: unknown or
+	// revoked key. Deliberately indistinguishable.
+	ErrInvalidKey = errors.New("api key is invalid")
+
+	// ErrRateLimited indicates the key exceeded its per-minute limit.
+	ErrRateLimited = errors.New("api key rate limit exceeded")
+
+	// ErrQuotaExceeded indicates the key exceeded its 24-hour quota.
+	ErrQuotaExceeded = errors.New("api key daily quota exceeded")
+)
@@ -0,0 +1,219 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// Built-in roles an API key may carry, the same as the JWT role claim
+// (auth.RoleAdmin / auth.RoleViewer, D-27). Custom roles come from
+// Service.CustomRoles.
+const (
+	RoleAdmin  = "admin"
+	RoleViewer = "viewer"
+)
+
+// DefaultRateLimit is the per-minute limit of a key created without one.
+const DefaultRateLimit = 60
+
+// quotaWindow is the daily quota window (sliding, not calendar days).
+const quotaWindow = 24 * time.Hour
+
+// touchInterval throttles last_used_at writes: a busy key would otherwise
+// cost one UPDATE per request.
+const touchInterval = time.Minute
+
+// Limiter is the sliding-window counter behind the per-key limits. It is
+// satisfied by middleware.RateLimitStore implementations, so keys share
+// RATE_LIMIT_STORE with the IP limiters.
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, error)
+}
+
+// CreateInput carries the fields for POST /api-keys. RateLimit 0 means
+// DefaultRateLimit; DailyQuota nil means unlimited.
+type CreateInput struct {
+	Name       string
+	Role       string
+	RateLimit  int
+	DailyQuota *int
+}
+
+// UpdateInput carries the fields for PUT /api-keys/{id} (full replacement).
+type UpdateInput = CreateInput
+
+// Service provides the API key use cases.
+type Service struct {
+	Keys repository.APIKeyRepository
+	// Limits enforces rate_limit / daily_quota; nil disables both.
+	Limits Limiter
+	Logger *slog.Logger
+	// CustomRoles are the role names defined by AUTH_ROLES, accepted in
+	// addition to admin / viewer.
+	CustomRoles []string
+	// Now returns the current time; nil means time.Now.
+	Now func() time.Time
+}
+
+func (s *Service) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+func (s *Service) logger() *slog.Logger {
+	if s.Logger != nil {
+		return s.Logger
+	}
+	return slog.Default()
+}
+
+func (s *Service) validate(in *CreateInput) error {
+	in.Name = strings.TrimSpace(in.Name)
+	if in.Name == "" {
+		return ErrNameRequired
+	}
+	if in.Role != RoleAdmin && in.Role != RoleViewer && !slices.Contains(s.CustomRoles, in.Role) {
+		return ErrInvalidRole
+	}
+	if in.RateLimit == 0 {
+		in.RateLimit = DefaultRateLimit
+	}
+	if in.RateLimit < 0 {
+		return ErrInvalidRateLimit
+	}
+	if in.DailyQuota != nil && *in.DailyQuota <= 0 {
+		return ErrInvalidQuota
+	}
+	return nil
+}
+
+// List returns all keys, revoked included.
+func (s *Service) List(ctx context.Context) ([]*entity.APIKey, error) {
+	keys, err := s.Keys.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list api keys: %w", err)
+	}
+	return keys, nil
+}
+
+// Get returns the key or ErrKeyNotFound.
+func (s *Service) Get(ctx context.Context, id int64) (*entity.APIKey, error) {
+	key, err := s.Keys.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get api key: %w", err)
+	}
+	if key == nil {
+		return nil, ErrKeyNotFound
+	}
+	return key, nil
+}
+
+// Create issues a new key. The plaintext is returned once and never
+// stored.
+func (s *Service) Create(ctx context.Context, in CreateInput) (*entity.APIKey, string, error) {
+	if err := s.validate(&in); err != nil {
+		return nil, "", err
+	}
+	plaintext, hash, prefix, err := entity.GenerateAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+	key := &entity.APIKey{
+		Name:       in.Name,
+		Role:       in.Role,
+		KeyHash:    hash,
+		KeyPrefix:  prefix,
+		RateLimit:  in.RateLimit,
+		DailyQuota: in.DailyQuota,
+	}
+	if err := s.Keys.Create(ctx, key); err != nil {
+		return nil, "", fmt.Errorf("create api key: %w", err)
+	}
+	return key, plaintext, nil
+}
+
+// Update rewrites name / role / limits. The key itself never changes.
+func (s *Service) Update(ctx context.Context, id int64, in UpdateInput) (*entity.APIKey, error) {
+	if err := s.validate(&in); err != nil {
+		return nil, err
+	}
+	key, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	key.Name, key.Role = in.Name, in.Role
+	key.RateLimit, key.DailyQuota = in.RateLimit, in.DailyQuota
+	if err := s.Keys.Update(ctx, key); err != nil {
+		return nil, fmt.Errorf("update api key: %w", err)
+	}
+	return key, nil
+}
+
+// Revoke revokes the key (idempotent). It stops authenticating on the
+// next request.
+func (s *Service) Revoke(ctx context.Context, id int64) (*entity.APIKey, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.Keys.Revoke(ctx, id, s.now()); err != nil {
+		return nil, fmt.Errorf("revoke api key: %w", err)
+	}
+	return s.Get(ctx, id)
+}
+
+// AuthenticateAPIKey resolves an X-API-Key header value to its key and
+// consumes one request from the key's per-minute limit and daily quota.
+// Unknown and revoked keys fail with the same ErrInvalidKey. A limiter
+// failure lets the request through (same fail-open policy as the IP
+// limiters).
+func (s *Service) AuthenticateAPIKey(ctx context.Context, plaintext string) (*entity.APIKey, error) {
+	if plaintext == "" {
+		return nil, ErrInvalidKey
+	}
+	key, err := s.Keys.GetActiveByHash(ctx, entity.HashAPIKey(plaintext))
+	if err != nil {
+		return nil, fmt.Errorf("authenticate api key: %w", err)
+	}
+	if key == nil {
+		return nil, ErrInvalidKey
+	}
+
+	if s.Limits != nil {
+		id := strconv.FormatInt(key.ID, 10)
+		if !s.allow(ctx, "apikey:"+id+":minute", key.RateLimit, time.Minute) {
+			return nil, ErrRateLimited
+		}
+		if key.DailyQuota != nil && !s.allow(ctx, "apikey:"+id+":day", *key.DailyQuota, quotaWindow) {
+			return nil, ErrQuotaExceeded
+		}
+	}
+
+	now := s.now()
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= touchInterval {
+		if err := s.Keys.TouchLastUsed(ctx, key.ID, now); err != nil {
+			s.logger().WarnContext(ctx, "api key last_used_at update failed",
+				slog.Int64("api_key_id", key.ID), slog.Any("error", err))
+		}
+	}
+	return key, nil
+}
+
+func (s *Service) allow(ctx context.Context, key string, limit int, window time.Duration) bool {
+	ok, err := s.Limits.Allow(ctx, key, limit, window)
+	if err != nil {
+		s.logger().WarnContext(ctx, "api key limiter unavailable, allowing request",
+			slog.String("key", key), slog.Any("error", err))
+		return true
+	}
+	return ok
+}
@@ -0,0 +1,192 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+/* ───────── モック実装 ───────── */
+
+type stubKeyRepo struct {
+	keys    map[int64]*entity.APIKey
+	touched []int64
+}
+
+func newStubKeyRepo() *stubKeyRepo {
+	return &stubKeyRepo{keys: map[int64]*entity.APIKey{}}
+}
+
+func (s *stubKeyRepo) Create(_ context.Context, k *entity.APIKey) error {
+	k.ID = int64(len(s.keys) + 1)
+	k.CreatedAt = time.Now()
+	s.keys[k.ID] = k
+	return nil
+}
+
+func (s *stubKeyRepo) Get(_ context.Context, id int64) (*entity.APIKey, error) {
+	return s.keys[id], nil
+}
+
+func (s *stubKeyRepo) List(_ context.Context) ([]*entity.APIKey, error) {
+	out := make([]*entity.APIKey, 0, len(s.keys))
+	for _, k := range s.keys {
+		out = append(out, k)
+	}
+	return out, nil
+}
+
+func (s *stubKeyRepo) Update(_ context.Context, k *entity.APIKey) error {
+	s.keys[k.ID] = k
+	return nil
+}
+
+func (s *stubKeyRepo) Revoke(_ context.Context, id int64, t time.Time) error {
+	if k, ok := s.keys[id]; ok && k.RevokedAt == nil {
+		k.RevokedAt = &t
+	}
+	return nil
+}
+
+func (s *stubKeyRepo) GetActiveByHash(_ context.Context, hash string) (*entity.APIKey, error) {
+	for _, k := range s.keys {
+		if k.KeyHash == hash && k.RevokedAt == nil {
+			return k, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *stubKeyRepo) TouchLastUsed(_ context.Context, id int64, t time.Time) error {
+	s.touched = append(s.touched, id)
+	s.keys[id].LastUsedAt = &t
+	return nil
+}
+
+// countingLimiter allows up to limit hits per key, ignoring the window.
+type countingLimiter struct {
+	counts map[string]int
+	err    error
+}
+
+func (l *countingLimiter) Allow(_ context.Context, key string, limit int, _ time.Duration) (bool, error) {
+	if l.err != nil {
+		return false, l.err
+	}
+	if l.counts[key] >= limit {
+		return false, nil
+	}
+	l.counts[key]++
+	return true, nil
+}
+
+/* ───────── テストケース ───────── */
+
+func TestService_Create(t *testing.T) {
+	quota := 100
+	zero := 0
+	tests := []struct {
+		name    string
+		in      CreateInput
+		wantErr error
+	}{
+		{name: "defaults rate limit", in: CreateInput{Name: "bot", Role: RoleViewer}},
+		{name: "with quota", in: CreateInput{Name: "bot", Role: RoleAdmin, RateLimit: 10, DailyQuota: &quota}},
+		{name: "missing name", in: CreateInput{Name: " ", Role: RoleViewer}, wantErr: ErrNameRequired},
+		{name: "unknown role", in: CreateInput{Name: "bot", Role: "owner"}, wantErr: ErrInvalidRole},
+		{name: "custom role", in: CreateInput{Name: "bot", Role: "editor"}},
+		{name: "negative rate limit", in: CreateInput{Name: "bot", Role: RoleViewer, RateLimit: -1}, wantErr: ErrInvalidRateLimit},
+		{name: "zero quota", in: CreateInput{Name: "bot", Role: RoleViewer, DailyQuota: &zero}, wantErr: ErrInvalidQuota},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newStubKeyRepo()
+			svc := &Service{Keys: repo, CustomRoles: []string{"editor"}}
+
+			key, plaintext, err := svc.Create(context.Background(), tt.in)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(plaintext, key.KeyPrefix))
+			assert.Equal(t, entity.HashAPIKey(plaintext), key.KeyHash)
+			assert.NotContains(t, key.KeyHash, plaintext, "plaintext must not be stored")
+			if tt.in.RateLimit == 0 {
+				assert.Equal(t, DefaultRateLimit, key.RateLimit)
+			}
+		})
+	}
+}
+
+func TestService_AuthenticateAPIKey(t *testing.T) {
+	quota := 3
+	repo := newStubKeyRepo()
+	limiter := &countingLimiter{counts: map[string]int{}}
+	svc := &Service{Keys: repo, Limits: limiter}
+
+	_, plaintext, err := svc.Create(context.Background(), CreateInput{Name: "bot", Role: RoleViewer, RateLimit: 2, DailyQuota: &quota})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		_, err := svc.AuthenticateAPIKey(ctx, plaintext)
+		require.NoError(t, err)
+	}
+	_, err = svc.AuthenticateAPIKey(ctx, plaintext)
+	assert.ErrorIs(t, err, ErrRateLimited)
+
+	// Per-minute budget refilled, daily quota (3) still counts: 2 used.
+	delete(limiter.counts, "apikey:1:minute")
+	_, err = svc.AuthenticateAPIKey(ctx, plaintext)
+	require.NoError(t, err)
+	delete(limiter.counts, "apikey:1:minute")
+	_, err = svc.AuthenticateAPIKey(ctx, plaintext)
+	assert.ErrorIs(t, err, ErrQuotaExceeded)
+
+	// last_used_at is written once, not per request.
+	assert.Equal(t, []int64{1}, repo.touched)
+
+	_, err = svc.AuthenticateAPIKey(ctx, "cfk_unknown")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestService_AuthenticateAPIKey_Revoked(t *testing.T) {
+	repo := newStubKeyRepo()
+	svc := &Service{Keys: repo}
+	key, plaintext, err := svc.Create(context.Background(), CreateInput{Name: "bot", Role: RoleAdmin})
+	require.NoError(t, err)
+
+	_, err = svc.Revoke(context.Background(), key.ID)
+	require.NoError(t, err)
+
+	_, err = svc.AuthenticateAPIKey(context.Background(), plaintext)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestService_AuthenticateAPIKey_LimiterFailsOpen(t *testing.T) {
+	repo := newStubKeyRepo()
+	svc := &Service{Keys: repo, Limits: &countingLimiter{err: errors.New("db down")}}
+	_, plaintext, err := svc.Create(context.Background(), CreateInput{Name: "bot", Role: RoleAdmin})
+	require.NoError(t, err)
+
+	_, err = svc.AuthenticateAPIKey(context.Background(), plaintext)
+	assert.NoError(t, err)
+}
+
+func TestService_UpdateAndRevoke_NotFound(t *testing.T) {
+	svc := &Service{Keys: newStubKeyRepo()}
+
+	_, err := svc.Update(context.Background(), 9, UpdateInput{Name: "bot", Role: RoleAdmin})
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+	_, err = svc.Revoke(context.Background(), 9)
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+}
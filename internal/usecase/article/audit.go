@@ -0,0 +1,46 @@
+package article
+
+import (
+	"context"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/usecase/audit"
+)
+
+// auditSnapshot is the audit_logs before/after shape of an article. The
+// extracted full text is reduced to its length so audit rows stay small.
+type auditSnapshot struct {
+	SourceID      int64     `json:"source_id"`
+	Title         string    `json:"title"`
+	URL           string    `json:"url"`
+	ContentLength int       `json:"content_length"`
+	PublishedAt   time.Time `json:"published_at"`
+}
+
+func toAuditSnapshot(art *entity.Article) any {
+	if art == nil {
+		return nil
+	}
+	return auditSnapshot{
+		SourceID:      art.SourceID,
+		Title:         art.Title,
+		URL:           art.URL,
+		ContentLength: len(art.Content),
+		PublishedAt:   art.PublishedAt,
+	}
+}
+
+// record writes one audit entry when auditing is enabled (Audit != nil).
+func (s *Service) record(ctx context.Context, action string, id int64, before, after *entity.Article) {
+	if s.Audit == nil {
+		return
+	}
+	s.Audit.Record(ctx, audit.Entry{
+		Action:       action,
+		ResourceType: entity.AuditResourceArticle,
+		ResourceID:   &id,
+		Before:       toAuditSnapshot(before),
+		After:        toAuditSnapshot(after),
+	})
+}
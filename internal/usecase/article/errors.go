@@ -3,7 +3,13 @@
 // including validation and interaction with the article repository.
 package article
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxBulkArticles bounds one bulk request (POST /articles/bulk-delete).
+const MaxBulkArticles = 500
 
 // Sentinel errors for article use case operations.
 var (
@@ -12,6 +18,26 @@ var (
 	// an article that does not exist in the repository.
 	ErrArticleNotFound = errors.New("article not found")
 
+	// ErrArticleContentNotFound indicates that no fetched page is stored
+	// for the article (RSS content was used, or the fetch failed).
+	ErrArticleContentNotFound = errors.New("article content not found")
+
+	// ErrResummarizeUnavailable indicates that re-summarization cannot be
+	// queued (no job queue configured).
+	ErrResummarizeUnavailable = errors.New("re-summarization is not available")
+
+	// ErrHighlightsUnavailable indicates that no digest repository is
+	// configured for period highlights.
+	ErrHighlightsUnavailable = errors.New("period highlights are not available")
+
+	// ErrInvalidHighlightPeriod indicates a period other than daily or
+	// weekly.
+	ErrInvalidHighlightPeriod = errors.New("period is invalid: must be daily or weekly")
+
+	// ErrEventStreamUnavailable indicates that no article event stream is
+	// configured.
+	ErrEventStreamUnavailable = errors.New("article event stream is not available")
+
 	// ErrInvalidArticleID indicates that the provided article ID is invalid.
 	// Article IDs must be positive integers.
 	ErrInvalidArticleID = errors.New("invalid article ID")
@@ -19,4 +45,8 @@ var (
 	// ErrDuplicateArticle indicates that an article with the same URL already exists.
 	// This prevents duplicate articles from being created in the system.
 	ErrDuplicateArticle = errors.New("article with this URL already exists")
+
+	// ErrInvalidArticleIDs indicates an empty, oversized or non-positive
+	// ID list in a bulk request.
+	ErrInvalidArticleIDs = fmt.Errorf("article_ids are invalid: must be 1 to %d positive IDs", MaxBulkArticles)
 )
@@ -0,0 +1,93 @@
+package article
+
+import (
+	"encoding/json"
+	"log/slog"
+	"sync"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// eventBufferSize is how many events a subscriber may fall behind before
+// it starts missing them.
+const eventBufferSize = 16
+
+// EventBus fans article events out to in-process subscribers (the
+// GET /articles/events streams). It is fed by the database listener on
+// entity.ArticleEventChannel, so events from the worker's crawl arrive
+// without polling. Delivery is best-effort: a subscriber whose buffer is
+// full misses the event instead of stalling the others.
+type EventBus struct {
+	mu     sync.Mutex
+	subs   map[chan entity.ArticleEvent]struct{}
+	closed bool
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{subs: make(map[chan entity.ArticleEvent]struct{})}
+}
+
+// Subscribe registers a subscriber. cancel unregisters it and closes the
+// channel; it is safe to call more than once. After Close the channel is
+// returned already closed.
+func (b *EventBus) Subscribe() (events <-chan entity.ArticleEvent, cancel func()) {
+	ch := make(chan entity.ArticleEvent, eventBufferSize)
+	b.mu.Lock()
+	if b.closed {
+		close(ch)
+	} else {
+		b.subs[ch] = struct{}{}
+	}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if _, ok := b.subs[ch]; ok {
+				delete(b.subs, ch)
+				close(ch)
+			}
+		})
+	}
+}
+
+// Close ends every subscription, so open streams return during server
+// shutdown instead of holding it up until the deadline.
+func (b *EventBus) Close() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for ch := range b.subs {
+		delete(b.subs, ch)
+		close(ch)
+	}
+}
+
+// Publish hands ev to every subscriber without blocking.
+func (b *EventBus) Publish(ev entity.ArticleEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+// HandleNotification publishes a NOTIFY payload of
+// entity.ArticleEventChannel (the db.Listen callback). A malformed
+// payload is logged and dropped.
+func (b *EventBus) HandleNotification(payload string) {
+	var ev entity.ArticleEvent
+	if err := json.Unmarshal([]byte(payload), &ev); err != nil {
+		slog.Warn("article event: malformed notification", slog.Any("error", err))
+		return
+	}
+	b.Publish(ev)
+}
@@ -0,0 +1,95 @@
+package article_test
+
+import (
+	"errors"
+	"testing"
+
+	"catchup-feed/internal/domain/entity"
+	artUC "catchup-feed/internal/usecase/article"
+)
+
+/* ───────── EventBus ───────── */
+
+func TestEventBus_PublishToSubscribers(t *testing.T) {
+	bus := artUC.NewEventBus()
+	a, cancelA := bus.Subscribe()
+	defer cancelA()
+	b, cancelB := bus.Subscribe()
+	defer cancelB()
+
+	ev := entity.ArticleEvent{Type: entity.WebhookEventArticleCreated, ArticleID: 1, SourceID: 2}
+	bus.Publish(ev)
+
+	for _, ch := range []<-chan entity.ArticleEvent{a, b} {
+		if got := <-ch; got != ev {
+			t.Fatalf("got %+v, want %+v", got, ev)
+		}
+	}
+}
+
+func TestEventBus_CancelClosesAndUnsubscribes(t *testing.T) {
+	bus := artUC.NewEventBus()
+	ch, cancel := bus.Subscribe()
+	cancel()
+	cancel() // 2 回目も安全
+
+	if _, ok := <-ch; ok {
+		t.Fatal("channel not closed after cancel")
+	}
+	bus.Publish(entity.ArticleEvent{ArticleID: 1}) // closed channel へ送らない
+}
+
+// A subscriber that stops reading misses events instead of blocking
+// Publish (and with it the database listener).
+func TestEventBus_SlowSubscriberDoesNotBlock(t *testing.T) {
+	bus := artUC.NewEventBus()
+	_, cancel := bus.Subscribe()
+	defer cancel()
+
+	for i := range 1000 {
+		bus.Publish(entity.ArticleEvent{ArticleID: int64(i)})
+	}
+}
+
+func TestEventBus_CloseEndsSubscriptions(t *testing.T) {
+	bus := artUC.NewEventBus()
+	ch, cancel := bus.Subscribe()
+	bus.Close()
+	cancel() // Close 後の cancel も安全
+
+	if _, ok := <-ch; ok {
+		t.Fatal("channel not closed by Close")
+	}
+	late, cancelLate := bus.Subscribe()
+	defer cancelLate()
+	if _, ok := <-late; ok {
+		t.Fatal("subscription after Close not closed")
+	}
+}
+
+func TestEventBus_HandleNotification(t *testing.T) {
+	bus := artUC.NewEventBus()
+	ch, cancel := bus.Subscribe()
+	defer cancel()
+
+	bus.HandleNotification(`not json`)
+	bus.HandleNotification(`{"type":"article.created","article_id":7,"source_id":3}`)
+
+	got := <-ch
+	want := entity.ArticleEvent{Type: entity.WebhookEventArticleCreated, ArticleID: 7, SourceID: 3}
+	if got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	select {
+	case extra := <-ch:
+		t.Fatalf("malformed payload published: %+v", extra)
+	default:
+	}
+}
+
+func TestService_SubscribeEvents_Unavailable(t *testing.T) {
+	svc := artUC.Service{Repo: newStub()}
+	if _, _, err := svc.SubscribeEvents(); !errors.Is(err, artUC.ErrEventStreamUnavailable) {
+		t.Fatalf("want ErrEventStreamUnavailable, got %v", err)
+	}
+}
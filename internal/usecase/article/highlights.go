@@ -0,0 +1,117 @@
+package article
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// Highlight periods (GET /articles/highlights?period=).
+const (
+	HighlightPeriodDaily  = "daily"
+	HighlightPeriodWeekly = "weekly"
+)
+
+const (
+	// MaxHighlightArticles caps the articles clustered for one period; a
+	// busy week beyond it is represented by its newest articles.
+	MaxHighlightArticles = 500
+	// DefaultHighlightTopics and MaxHighlightTopics bound the topics
+	// returned.
+	DefaultHighlightTopics = 10
+	MaxHighlightTopics     = 50
+)
+
+// Highlights is the topic summary of a period: the articles stored in
+// [From, To) clustered by entity.ClusterArticles.
+type Highlights struct {
+	From, To time.Time
+	// Total is the number of articles stored in the period and Clustered
+	// how many of them (the newest MaxHighlightArticles) were clustered.
+	Total     int64
+	Clustered int
+	Topics    []Topic
+}
+
+// Topic is one cluster of Highlights, largest first.
+type Topic struct {
+	Terms          []string
+	Representative repository.ArticleWithSource
+	// Articles are the cluster's articles, newest first, including the
+	// representative.
+	Articles []repository.ArticleWithSource
+}
+
+// HighlightWindow returns the window a highlight period ending at now
+// covers. Returns ErrInvalidHighlightPeriod for an unknown period.
+func HighlightWindow(period string, now time.Time) (from, to time.Time, err error) {
+	switch period {
+	case HighlightPeriodDaily:
+		return now.Add(-24 * time.Hour), now, nil
+	case HighlightPeriodWeekly:
+		return now.Add(-7 * 24 * time.Hour), now, nil
+	}
+	return time.Time{}, time.Time{}, ErrInvalidHighlightPeriod
+}
+
+// Highlights clusters the articles stored in [from, to) into topics, so
+// a period reads as its distinct stories with a representative article
+// each instead of one long list. Near-duplicates are already folded into
+// their canonical article. maxTopics <= 0 = DefaultHighlightTopics, capped
+// at MaxHighlightTopics.
+// Returns ErrHighlightsUnavailable when no digest repository is configured.
+func (s *Service) Highlights(ctx context.Context, from, to time.Time, maxTopics int) (*Highlights, error) {
+	if s.Digest == nil {
+		return nil, ErrHighlightsUnavailable
+	}
+	if maxTopics <= 0 {
+		maxTopics = DefaultHighlightTopics
+	}
+	maxTopics = min(maxTopics, MaxHighlightTopics)
+
+	articles, total, err := s.Digest.ListStoredBetween(ctx, from, to, MaxHighlightArticles)
+	if err != nil {
+		return nil, fmt.Errorf("list period articles: %w", err)
+	}
+	out := &Highlights{From: from, To: to, Total: total, Clustered: len(articles), Topics: []Topic{}}
+	for _, cluster := range ClusterWithSource(articles) {
+		if len(out.Topics) == maxTopics {
+			break
+		}
+		out.Topics = append(out.Topics, cluster)
+	}
+	return out, nil
+}
+
+// ClusterWithSource runs entity.ClusterArticles (default threshold) over
+// articles listed with their source names, newest first, and keeps the
+// source names on the result.
+func ClusterWithSource(articles []repository.ArticleWithSource) []Topic {
+	plain := make([]*entity.Article, len(articles))
+	sources := make(map[*entity.Article]string, len(articles))
+	for i, a := range articles {
+		plain[i] = a.Article
+		sources[a.Article] = a.SourceName
+	}
+	withSource := func(a *entity.Article) repository.ArticleWithSource {
+		return repository.ArticleWithSource{Article: a, SourceName: sources[a]}
+	}
+
+	clusters := entity.ClusterArticles(plain, 0)
+	topics := make([]Topic, 0, len(clusters))
+	for _, c := range clusters {
+		topic := Topic{
+			Terms:          c.Terms,
+			Representative: withSource(c.Representative),
+			Articles:       make([]repository.ArticleWithSource, 0, len(c.Articles)),
+		}
+		for _, a := range c.Articles {
+			topic.Articles = append(topic.Articles, withSource(a))
+		}
+		topics = append(topics, topic)
+	}
+	return topics
+}
@@ -2,12 +2,15 @@ package article
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"catchup-feed/internal/common/pagination"
 	"catchup-feed/internal/domain/entity"
 	"catchup-feed/internal/repository"
+	"catchup-feed/internal/usecase/audit"
 )
 
 // CreateInput represents the input parameters for creating a new article.
@@ -36,6 +39,85 @@ type UpdateInput struct {
 // It handles business logic for article operations and delegates persistence to the repository.
 type Service struct {
 	Repo repository.ArticleRepository
+	// Audit records create / update / delete into audit_logs; nil
+	// disables auditing.
+	Audit audit.Recorder
+	// Favorites backs the favorited flag of article responses; nil
+	// reports no favorites.
+	Favorites FavoriteLookup
+	// Subscriptions scopes the article event stream of subscription-scoped
+	// callers to their sources; nil reports no subscriptions.
+	Subscriptions SubscriptionLookup
+	// Events receives article.created for articles created through the
+	// API (outbound webhooks); nil disables it.
+	Events EventPublisher
+	// Contents reads the pages the crawler stored in article_contents;
+	// nil reports no stored content.
+	Contents repository.ArticleContentRepository
+	// Similarity reads the SimHash near-duplicate groups
+	// (articles.duplicate_of); nil reports every article as standalone.
+	Similarity repository.ArticleSimilarityRepository
+	// Digest lists the articles stored in a period for Highlights; nil
+	// makes Highlights fail with ErrHighlightsUnavailable.
+	Digest repository.ArticleDigestRepository
+	// Groups names the source group of a group-filtered feed; nil leaves
+	// the name out.
+	Groups repository.SourceGroupRepository
+	// Jobs queues re-summarization for the worker, which holds the
+	// summarizer chain; nil makes Resummarize fail with
+	// ErrResummarizeUnavailable.
+	Jobs repository.JobRepository
+	// Tx makes Create a unit of work: the article and its article.created
+	// webhook deliveries commit together, so an event is never lost (nor
+	// sent for an article that was rolled back). nil keeps the
+	// best-effort publish after the insert.
+	Tx repository.TxManager
+	// Stream carries the article events of GET /articles/events; nil makes
+	// SubscribeEvents fail with ErrEventStreamUnavailable.
+	Stream *EventBus
+}
+
+// EventPublisher queues an outbound event (implemented by the webhook use
+// case).
+type EventPublisher interface {
+	Publish(ctx context.Context, event string, data any) error
+}
+
+// FavoriteLookup reports which articles a login subject has starred
+// (implemented by the favorite use case).
+type FavoriteLookup interface {
+	FavoritedIDs(ctx context.Context, subject string, articleIDs []int64) (map[int64]bool, error)
+}
+
+// FavoritedIDs returns which of articleIDs the subject has starred.
+func (s *Service) FavoritedIDs(ctx context.Context, subject string, articleIDs []int64) (map[int64]bool, error) {
+	if s.Favorites == nil || len(articleIDs) == 0 {
+		return map[int64]bool{}, nil
+	}
+	favorited, err := s.Favorites.FavoritedIDs(ctx, subject, articleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("favorited articles: %w", err)
+	}
+	return favorited, nil
+}
+
+// SubscriptionLookup reports which sources a login subject subscribes to
+// (implemented by the subscription use case).
+type SubscriptionLookup interface {
+	SubscribedSourceIDs(ctx context.Context, subject string) (map[int64]bool, error)
+}
+
+// SubscribedSourceIDs returns the IDs of the sources the subject
+// subscribes to.
+func (s *Service) SubscribedSourceIDs(ctx context.Context, subject string) (map[int64]bool, error) {
+	if s.Subscriptions == nil {
+		return map[int64]bool{}, nil
+	}
+	ids, err := s.Subscriptions.SubscribedSourceIDs(ctx, subject)
+	if err != nil {
+		return nil, fmt.Errorf("subscribed sources: %w", err)
+	}
+	return ids, nil
 }
 
 // PaginatedResult represents the result of a paginated query.
@@ -116,6 +198,82 @@ func (s *Service) Get(ctx context.Context, id int64) (*entity.Article, error) {
 	return article, nil
 }
 
+// Content returns the page the crawler fetched for the article (raw HTML
+// and extracted text).
+// Returns ErrInvalidArticleID if the ID is not positive.
+// Returns ErrArticleNotFound if the article does not exist and
+// ErrArticleContentNotFound if no page is stored for it.
+func (s *Service) Content(ctx context.Context, id int64) (*entity.ArticleContent, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	if s.Contents == nil {
+		return nil, ErrArticleContentNotFound
+	}
+
+	content, err := s.Contents.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get article content: %w", err)
+	}
+	if content == nil {
+		return nil, ErrArticleContentNotFound
+	}
+	return content, nil
+}
+
+// Duplicates returns the near-duplicate group of the article: the
+// canonical article first, then the copies other sources published. A
+// standalone article is a group of one.
+// Returns ErrInvalidArticleID if the ID is not positive.
+// Returns ErrArticleNotFound if the article does not exist.
+func (s *Service) Duplicates(ctx context.Context, id int64) ([]repository.ArticleWithSource, error) {
+	article, sourceName, err := s.GetWithSource(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if s.Similarity == nil {
+		return []repository.ArticleWithSource{{Article: article, SourceName: sourceName}}, nil
+	}
+
+	group, err := s.Similarity.ListGroup(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("list duplicate group: %w", err)
+	}
+	return group, nil
+}
+
+// Resummarize queues a resummarize job that makes the worker run the
+// summarizer chain on the article again and replace its summary, and
+// returns the pending job.
+// Returns ErrInvalidArticleID if the ID is not positive.
+// Returns ErrArticleNotFound if the article does not exist.
+// Returns ErrResummarizeUnavailable when no job queue is configured.
+func (s *Service) Resummarize(ctx context.Context, id int64) (*entity.Job, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	if s.Jobs == nil {
+		return nil, ErrResummarizeUnavailable
+	}
+
+	raw, err := json.Marshal(entity.ResummarizePayload{ArticleID: id})
+	if err != nil {
+		return nil, fmt.Errorf("marshal resummarize payload: %w", err)
+	}
+	jobID, err := s.Jobs.Enqueue(ctx, entity.JobKindResummarize, raw, time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("enqueue resummarize job: %w", err)
+	}
+	job, err := s.Jobs.Get(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("get job: %w", err)
+	}
+	if job == nil {
+		job = &entity.Job{ID: jobID, Kind: entity.JobKindResummarize, Payload: raw, Status: entity.JobStatusPending}
+	}
+	return job, nil
+}
+
 // GetWithSource retrieves a single article by its ID along with the source name.
 // Returns ErrInvalidArticleID if the ID is not positive.
 // Returns ErrArticleNotFound if the article does not exist.
@@ -213,6 +371,117 @@ func (s *Service) SearchWithFiltersPaginated(ctx context.Context, keywords []str
 	}, nil
 }
 
+// ListWithSourceAfter returns the page of articles following after (the
+// first page when nil) using keyset pagination on (published_at, id), so
+// deep pages cost the same as the first one. keywords and filters narrow the
+// listing like SearchWithFiltersPaginated, but results are ordered by recency
+// rather than relevance.
+// Pagination.NextCursor is set when more articles follow; Page is left 0.
+// If count query fails, it returns data with total=-1 for graceful degradation.
+func (s *Service) ListWithSourceAfter(ctx context.Context, keywords []string, filters repository.ArticleSearchFilters, after *pagination.Cursor, limit int) (*PaginatedResult, error) {
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+
+	var key *repository.ArticleCursor
+	if after != nil {
+		key = &repository.ArticleCursor{PublishedAt: after.Time, ID: after.ID}
+	}
+
+	var (
+		total int64
+		err   error
+	)
+	if len(keywords) > 0 || filters != (repository.ArticleSearchFilters{}) {
+		total, err = s.Repo.CountArticlesWithFilters(ctx, keywords, filters)
+	} else {
+		total, err = s.Repo.CountArticles(ctx)
+	}
+	if err != nil {
+		total = -1
+	}
+
+	// Fetch one extra row to learn whether another page follows
+	articles, err := s.Repo.ListWithSourceAfter(ctx, keywords, filters, key, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("list articles with source after cursor: %w", err)
+	}
+
+	var next string
+	if len(articles) > limit {
+		articles = articles[:limit]
+		last := articles[limit-1].Article
+		c := pagination.Cursor{ID: last.ID}
+		if !last.PublishedAt.IsZero() {
+			t := last.PublishedAt
+			c.Time = &t
+		}
+		next = c.Encode()
+	}
+
+	var totalPages int
+	if total >= 0 {
+		totalPages = pagination.CalculateTotalPages(total, limit)
+	}
+
+	return &PaginatedResult{
+		Data: articles,
+		Pagination: pagination.Metadata{
+			Total:      total,
+			Limit:      limit,
+			TotalPages: totalPages,
+			NextCursor: next,
+		},
+	}, nil
+}
+
+// Recent returns the newest summarized articles matching filters, at most
+// limit of them, for the outbound feed. Articles still waiting for their
+// summary are left out so feed readers never see an empty entry.
+func (s *Service) Recent(ctx context.Context, filters repository.ArticleSearchFilters, limit int) ([]repository.ArticleWithSource, error) {
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+	filters.Summarized = true
+	articles, err := s.Repo.ListWithSourceAfter(ctx, nil, filters, nil, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list recent articles: %w", err)
+	}
+	return articles, nil
+}
+
+// GroupName returns the name of source group id, or "" when the group
+// does not exist or Groups is nil.
+func (s *Service) GroupName(ctx context.Context, id int64) (string, error) {
+	if s.Groups == nil {
+		return "", nil
+	}
+	group, err := s.Groups.Get(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("get source group: %w", err)
+	}
+	if group == nil {
+		return "", nil
+	}
+	return group.Name, nil
+}
+
+// Export streams every article matching keywords and filters (all articles
+// when both are empty) to fn in search order without loading the result
+// set into memory. An error returned by fn stops the export and is
+// returned as is.
+func (s *Service) Export(ctx context.Context, keywords []string, filters repository.ArticleSearchFilters, fn func(repository.ArticleWithSource) error) error {
+	var fnErr error
+	err := s.Repo.ForEachWithSource(ctx, keywords, filters, func(a repository.ArticleWithSource) error {
+		fnErr = fn(a)
+		return fnErr
+	})
+	if err != nil && fnErr == nil {
+		return fmt.Errorf("export articles: %w", err)
+	}
+	return err
+}
+
 // Create creates a new article with the provided input.
 // It validates the input data including URL format before creating the article.
 // Returns a ValidationError if any input field is invalid.
@@ -241,12 +510,49 @@ func (s *Service) Create(ctx context.Context, in CreateInput) error {
 		CrawledAt:   time.Now(),
 	}
 
+	if s.Tx != nil && s.Events != nil {
+		err := s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+			if err := s.Repo.Create(ctx, art); err != nil {
+				return fmt.Errorf("create article: %w", err)
+			}
+			if err := s.Events.Publish(ctx, entity.WebhookEventArticleCreated, entity.NewWebhookArticleData(art)); err != nil {
+				return fmt.Errorf("publish %s: %w", entity.WebhookEventArticleCreated, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		s.record(ctx, entity.AuditActionCreate, art.ID, nil, art)
+		return nil
+	}
+
 	if err := s.Repo.Create(ctx, art); err != nil {
 		return fmt.Errorf("create article: %w", err)
 	}
+	s.record(ctx, entity.AuditActionCreate, art.ID, nil, art)
+	if s.Events != nil {
+		// Best-effort like auditing: the article exists either way.
+		if err := s.Events.Publish(ctx, entity.WebhookEventArticleCreated, entity.NewWebhookArticleData(art)); err != nil {
+			slog.WarnContext(ctx, "webhook event publish failed",
+				slog.String("event", entity.WebhookEventArticleCreated),
+				slog.Any("error", err))
+		}
+	}
 	return nil
 }
 
+// SubscribeEvents subscribes to the article event stream; cancel must be
+// called when the caller stops reading.
+// Returns ErrEventStreamUnavailable when no stream is configured.
+func (s *Service) SubscribeEvents() (<-chan entity.ArticleEvent, func(), error) {
+	if s.Stream == nil {
+		return nil, nil, ErrEventStreamUnavailable
+	}
+	events, cancel := s.Stream.Subscribe()
+	return events, cancel, nil
+}
+
 // Update modifies an existing article with the provided input.
 // Only non-nil fields in the input will be updated.
 // Returns ErrInvalidArticleID if the ID is not positive.
@@ -264,6 +570,7 @@ func (s *Service) Update(ctx context.Context, in UpdateInput) error {
 	if art == nil {
 		return ErrArticleNotFound
 	}
+	before := *art
 
 	if in.SourceID != nil {
 		if *in.SourceID <= 0 {
@@ -294,6 +601,7 @@ func (s *Service) Update(ctx context.Context, in UpdateInput) error {
 	if err := s.Repo.Update(ctx, art); err != nil {
 		return fmt.Errorf("update article: %w", err)
 	}
+	s.record(ctx, entity.AuditActionUpdate, art.ID, &before, art)
 	return nil
 }
 
@@ -305,8 +613,63 @@ func (s *Service) Delete(ctx context.Context, id int64) error {
 		return ErrInvalidArticleID
 	}
 
+	// 監査ログの before 用。監査無効時は余分なクエリを発行しない。
+	var before *entity.Article
+	if s.Audit != nil {
+		var err error
+		if before, err = s.Repo.Get(ctx, id); err != nil {
+			return fmt.Errorf("get article: %w", err)
+		}
+	}
+
 	if err := s.Repo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("delete article: %w", err)
 	}
+	s.record(ctx, entity.AuditActionDelete, id, before, nil)
 	return nil
 }
+
+// DeleteBatch deletes the articles in one transaction and returns the IDs
+// that existed and were deleted; missing IDs are not an error so a cleanup
+// can be retried. Duplicate IDs are collapsed.
+// Returns ErrInvalidArticleIDs for an empty, oversized (> MaxBulkArticles)
+// or non-positive ID list.
+func (s *Service) DeleteBatch(ctx context.Context, ids []int64) ([]int64, error) {
+	if len(ids) == 0 || len(ids) > MaxBulkArticles {
+		return nil, ErrInvalidArticleIDs
+	}
+	unique := make([]int64, 0, len(ids))
+	seen := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, ErrInvalidArticleIDs
+		}
+		if _, dup := seen[id]; dup {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+
+	// 監査ログの before 用。監査無効時は余分なクエリを発行しない。
+	var before map[int64]*entity.Article
+	if s.Audit != nil {
+		before = make(map[int64]*entity.Article, len(unique))
+		for _, id := range unique {
+			art, err := s.Repo.Get(ctx, id)
+			if err != nil {
+				return nil, fmt.Errorf("get article: %w", err)
+			}
+			before[id] = art
+		}
+	}
+
+	deleted, err := s.Repo.DeleteBatch(ctx, unique)
+	if err != nil {
+		return nil, fmt.Errorf("delete articles: %w", err)
+	}
+	for _, id := range deleted {
+		s.record(ctx, entity.AuditActionDelete, id, before[id], nil)
+	}
+	return deleted, nil
+}
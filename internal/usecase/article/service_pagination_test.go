@@ -19,6 +19,9 @@ type mockArticleRepo struct {
 	totalCount      int64
 	listErr         error
 	countErr        error
+	lastAfter       *repository.ArticleCursor
+	lastLimit       int
+	lastFilters     repository.ArticleSearchFilters
 }
 
 func (m *mockArticleRepo) ListWithSourcePaginated(_ context.Context, offset, limit int) ([]repository.ArticleWithSource, error) {
@@ -68,12 +71,22 @@ func (m *mockArticleRepo) Update(_ context.Context, _ *entity.Article) error {
 func (m *mockArticleRepo) Delete(_ context.Context, _ int64) error {
 	return nil
 }
+func (m *mockArticleRepo) DeleteBatch(_ context.Context, _ []int64) ([]int64, error) {
+	return nil, nil
+}
+func (m *mockArticleRepo) ForEachWithSource(_ context.Context, _ []string, _ repository.ArticleSearchFilters, _ func(repository.ArticleWithSource) error) error {
+	return nil
+}
 func (m *mockArticleRepo) ExistsByURL(_ context.Context, _ string) (bool, error) {
 	return false, nil
 }
 func (m *mockArticleRepo) ExistsByURLBatch(_ context.Context, _ []string) (map[string]bool, error) {
 	return nil, nil
 }
+
+func (m *mockArticleRepo) ExistsByContentHashBatch(_ context.Context, _ []string) (map[string]bool, error) {
+	return map[string]bool{}, nil
+}
 func (m *mockArticleRepo) GetWithSource(_ context.Context, _ int64) (*entity.Article, string, error) {
 	return nil, "", nil
 }
@@ -86,6 +99,29 @@ func (m *mockArticleRepo) CountArticlesWithFilters(_ context.Context, _ []string
 func (m *mockArticleRepo) SearchWithFiltersPaginated(_ context.Context, _ []string, _ repository.ArticleSearchFilters, _, _ int) ([]repository.ArticleWithSource, error) {
 	return nil, nil
 }
+func (m *mockArticleRepo) ListWithSourceAfter(_ context.Context, _ []string, filters repository.ArticleSearchFilters, after *repository.ArticleCursor, limit int) ([]repository.ArticleWithSource, error) {
+	m.lastAfter = after
+	m.lastFilters = filters
+	m.lastLimit = limit
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	// articlesWithSrc is assumed to be in listing order already
+	start := 0
+	if after != nil {
+		for i, a := range m.articlesWithSrc {
+			if a.Article.ID == after.ID {
+				start = i + 1
+				break
+			}
+		}
+	}
+	end := start + limit
+	if end > len(m.articlesWithSrc) {
+		end = len(m.articlesWithSrc)
+	}
+	return m.articlesWithSrc[start:end], nil
+}
 
 /* ───────── テストケース ───────── */
 
@@ -334,9 +370,145 @@ func TestService_ListWithSourcePaginated_OffsetCalculation(t *testing.T) {
 	}
 }
 
+func cursorTestArticles(now time.Time) []repository.ArticleWithSource {
+	return []repository.ArticleWithSource{
+		{Article: &entity.Article{ID: 3, Title: "c", PublishedAt: now}, SourceName: "S"},
+		{Article: &entity.Article{ID: 2, Title: "b", PublishedAt: now.Add(-time.Hour)}, SourceName: "S"},
+		{Article: &entity.Article{ID: 1, Title: "a"}, SourceName: "S"}, // published_at NULL
+	}
+}
+
+func TestService_ListWithSourceAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	mock := &mockArticleRepo{articlesWithSrc: cursorTestArticles(now), totalCount: 3}
+	svc := article.Service{Repo: mock}
+
+	// 1 ページ目: 次ページがあるので next_cursor が返る
+	first, err := svc.ListWithSourceAfter(context.Background(), nil, repository.ArticleSearchFilters{}, nil, 2)
+	if err != nil {
+		t.Fatalf("ListWithSourceAfter() error = %v", err)
+	}
+	if mock.lastAfter != nil || mock.lastLimit != 3 {
+		t.Errorf("repo called with after=%v limit=%d, want nil and limit+1=3", mock.lastAfter, mock.lastLimit)
+	}
+	if len(first.Data) != 2 || first.Data[1].Article.ID != 2 {
+		t.Fatalf("first page = %+v, want articles 3 and 2", first.Data)
+	}
+	if first.Pagination.Total != 3 || first.Pagination.TotalPages != 2 || first.Pagination.Page != 0 {
+		t.Errorf("Pagination = %+v, want total 3, total_pages 2, no page", first.Pagination)
+	}
+	c, err := pagination.DecodeCursor(first.Pagination.NextCursor)
+	if err != nil {
+		t.Fatalf("DecodeCursor(next_cursor) error = %v", err)
+	}
+	if c.ID != 2 || c.Time == nil || !c.Time.Equal(now.Add(-time.Hour)) {
+		t.Errorf("next cursor = %+v, want id 2 at the article's published_at", c)
+	}
+
+	// 2 ページ目: 最終ページなので next_cursor は空
+	second, err := svc.ListWithSourceAfter(context.Background(), nil, repository.ArticleSearchFilters{}, &c, 2)
+	if err != nil {
+		t.Fatalf("ListWithSourceAfter() error = %v", err)
+	}
+	if mock.lastAfter == nil || mock.lastAfter.ID != 2 || !mock.lastAfter.PublishedAt.Equal(*c.Time) {
+		t.Errorf("repo called with after=%+v, want the decoded cursor", mock.lastAfter)
+	}
+	if len(second.Data) != 1 || second.Data[0].Article.ID != 1 {
+		t.Fatalf("second page = %+v, want article 1", second.Data)
+	}
+	if second.Pagination.NextCursor != "" {
+		t.Errorf("NextCursor = %q, want empty on the last page", second.Pagination.NextCursor)
+	}
+}
+
+func TestService_ListWithSourceAfter_UndatedCursor(t *testing.T) {
+	t.Parallel()
+
+	// ページ末尾の記事に published_at がなければ、カーソルは時刻なしになる
+	mock := &mockArticleRepo{articlesWithSrc: []repository.ArticleWithSource{
+		{Article: &entity.Article{ID: 5, PublishedAt: time.Now()}},
+		{Article: &entity.Article{ID: 4}},
+		{Article: &entity.Article{ID: 2}},
+	}}
+	svc := article.Service{Repo: mock}
+
+	result, err := svc.ListWithSourceAfter(context.Background(), nil, repository.ArticleSearchFilters{}, nil, 2)
+	if err != nil {
+		t.Fatalf("ListWithSourceAfter() error = %v", err)
+	}
+	c, err := pagination.DecodeCursor(result.Pagination.NextCursor)
+	if err != nil {
+		t.Fatalf("DecodeCursor(next_cursor) error = %v", err)
+	}
+	if c.ID != 4 || c.Time != nil {
+		t.Errorf("next cursor = %+v, want id 4 without time", c)
+	}
+}
+
+func TestService_ListWithSourceAfter_CountError(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockArticleRepo{
+		articlesWithSrc: cursorTestArticles(time.Now()),
+		countErr:        errors.New("count error"),
+	}
+	svc := article.Service{Repo: mock}
+
+	result, err := svc.ListWithSourceAfter(context.Background(), nil, repository.ArticleSearchFilters{}, nil, 10)
+	if err != nil {
+		t.Fatalf("ListWithSourceAfter() error = %v, want graceful degradation", err)
+	}
+	if result.Pagination.Total != -1 || result.Pagination.TotalPages != 0 {
+		t.Errorf("Pagination = %+v, want total -1 and total_pages 0", result.Pagination)
+	}
+	if len(result.Data) != 3 {
+		t.Errorf("len(Data) = %d, want 3", len(result.Data))
+	}
+}
+
+func TestService_ListWithSourceAfter_ListError(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockArticleRepo{listErr: errors.New("list error")}
+	svc := article.Service{Repo: mock}
+
+	if _, err := svc.ListWithSourceAfter(context.Background(), nil, repository.ArticleSearchFilters{}, nil, 10); err == nil {
+		t.Fatal("ListWithSourceAfter() error = nil, want error")
+	}
+}
+
+func TestService_Recent(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockArticleRepo{articlesWithSrc: cursorTestArticles(time.Now())}
+	svc := article.Service{Repo: mock}
+	tag := "go"
+
+	got, err := svc.Recent(context.Background(), repository.ArticleSearchFilters{Tag: &tag}, 2)
+	if err != nil {
+		t.Fatalf("Recent() error = %v", err)
+	}
+	if len(got) != 2 || mock.lastLimit != 2 || mock.lastAfter != nil {
+		t.Errorf("len = %d, limit = %d, after = %v, want the first 2 articles", len(got), mock.lastLimit, mock.lastAfter)
+	}
+	if !mock.lastFilters.Summarized || mock.lastFilters.Tag == nil || *mock.lastFilters.Tag != tag {
+		t.Errorf("filters = %+v, want summarized articles tagged go", mock.lastFilters)
+	}
+
+	mock.listErr = errors.New("list error")
+	if _, err := svc.Recent(context.Background(), repository.ArticleSearchFilters{}, 2); err == nil {
+		t.Fatal("Recent() error = nil, want error")
+	}
+}
+
 func (s *mockArticleRepo) CreateWithSummary(_ context.Context, _ *entity.Article, _ *entity.Summary) error {
 	return nil
 }
+func (s *mockArticleRepo) CreateBatch(_ context.Context, _ []repository.NewArticle) (int, error) {
+	return 0, nil
+}
 func (s *mockArticleRepo) CreateWithTranscribeJob(_ context.Context, _ *entity.Article, _, _ string) error {
 	return nil
 }
@@ -2,7 +2,9 @@ package article_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"slices"
 	"testing"
 	"time"
 
@@ -73,6 +75,37 @@ func (s *stubRepo) Delete(_ context.Context, id int64) error {
 	delete(s.data, id)
 	return nil
 }
+func (s *stubRepo) DeleteBatch(_ context.Context, ids []int64) ([]int64, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	deleted := []int64{}
+	for _, id := range ids {
+		if _, ok := s.data[id]; ok {
+			delete(s.data, id)
+			deleted = append(deleted, id)
+		}
+	}
+	return deleted, nil
+}
+
+// ForEachWithSource streams the stored articles in ID order.
+func (s *stubRepo) ForEachWithSource(_ context.Context, _ []string, _ repository.ArticleSearchFilters, fn func(repository.ArticleWithSource) error) error {
+	if s.err != nil {
+		return s.err
+	}
+	ids := make([]int64, 0, len(s.data))
+	for id := range s.data {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		if err := fn(repository.ArticleWithSource{Article: s.data[id], SourceName: "Test Source"}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
 // ExistsByURL checks if any article exists with the given URL.
 func (s *stubRepo) ListUnsummarized(_ context.Context, _ int) ([]*entity.Article, error) {
@@ -107,6 +140,10 @@ func (s *stubRepo) ExistsByURLBatch(_ context.Context, urls []string) (map[strin
 	return result, nil
 }
 
+func (s *stubRepo) ExistsByContentHashBatch(_ context.Context, _ []string) (map[string]bool, error) {
+	return map[string]bool{}, nil
+}
+
 // GetWithSource retrieves an article by ID along with the source name.
 func (s *stubRepo) GetWithSource(_ context.Context, id int64) (*entity.Article, string, error) {
 	if s.err != nil {
@@ -200,6 +237,14 @@ func (s *stubRepo) SearchWithFiltersPaginated(_ context.Context, keywords []stri
 	return all[offset:end], nil
 }
 
+// ListWithSourceAfter returns no rows; keyset paging is covered with mockArticleRepo.
+func (s *stubRepo) ListWithSourceAfter(_ context.Context, _ []string, _ repository.ArticleSearchFilters, _ *repository.ArticleCursor, _ int) ([]repository.ArticleWithSource, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return nil, nil
+}
+
 /* ───────── 1. Create のバリデーション ───────── */
 
 func TestService_Create_validation(t *testing.T) {
@@ -229,6 +274,73 @@ func TestService_Create_success(t *testing.T) {
 	}
 }
 
+/* ───────── 2b. Create: トランザクション内で記事とイベントを書く ───────── */
+
+// stubTx は WithinTx の結果(commit / rollback)だけを記録する。
+type stubTx struct{ committed, rolledBack int }
+
+func (s *stubTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		s.rolledBack++
+		return err
+	}
+	s.committed++
+	return nil
+}
+
+type stubPublisher struct {
+	events []string
+	err    error
+}
+
+func (p *stubPublisher) Publish(_ context.Context, event string, _ any) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.events = append(p.events, event)
+	return nil
+}
+
+func TestService_Create_withinTx(t *testing.T) {
+	in := artUC.CreateInput{SourceID: 1, Title: "t", URL: "https://example.com/article"}
+
+	t.Run("commits article and event together", func(t *testing.T) {
+		tx, pub := &stubTx{}, &stubPublisher{}
+		svc := artUC.Service{Repo: newStub(), Events: pub, Tx: tx}
+		if err := svc.Create(context.Background(), in); err != nil {
+			t.Fatalf("Create err=%v", err)
+		}
+		if tx.committed != 1 || tx.rolledBack != 0 {
+			t.Fatalf("committed=%d rolledBack=%d, want 1/0", tx.committed, tx.rolledBack)
+		}
+		if !slices.Equal(pub.events, []string{entity.WebhookEventArticleCreated}) {
+			t.Fatalf("events = %v", pub.events)
+		}
+	})
+
+	t.Run("publish failure fails the create", func(t *testing.T) {
+		tx, pub := &stubTx{}, &stubPublisher{err: errors.New("db down")}
+		svc := artUC.Service{Repo: newStub(), Events: pub, Tx: tx}
+		if err := svc.Create(context.Background(), in); err == nil {
+			t.Fatal("want error, got nil")
+		}
+		if tx.rolledBack != 1 {
+			t.Fatalf("rolledBack=%d, want 1", tx.rolledBack)
+		}
+	})
+
+	t.Run("without Tx a publish failure is only logged", func(t *testing.T) {
+		stub := newStub()
+		svc := artUC.Service{Repo: stub, Events: &stubPublisher{err: errors.New("db down")}}
+		if err := svc.Create(context.Background(), in); err != nil {
+			t.Fatalf("Create err=%v", err)
+		}
+		if len(stub.data) != 1 {
+			t.Fatalf("want 1 article, got %d", len(stub.data))
+		}
+	})
+}
+
 /* ───────── 3. Update: not-found ───────── */
 
 func TestService_Update_notFound(t *testing.T) {
@@ -1362,9 +1474,236 @@ func TestService_SearchWithFiltersPaginated_PaginationCalculation(t *testing.T)
 	}
 }
 
+/* ───────── 16. DeleteBatch: 一括削除 ───────── */
+
+func TestService_DeleteBatch(t *testing.T) {
+	stub := newStub()
+	stub.data[1] = &entity.Article{ID: 1, Title: "one"}
+	stub.data[2] = &entity.Article{ID: 2, Title: "two"}
+	svc := artUC.Service{Repo: stub}
+
+	// 重複は 1 件にまとめ、存在しない ID はエラーにしない
+	deleted, err := svc.DeleteBatch(context.Background(), []int64{2, 99, 2, 1})
+	if err != nil {
+		t.Fatalf("DeleteBatch() error = %v", err)
+	}
+	if len(deleted) != 2 || deleted[0] != 2 || deleted[1] != 1 {
+		t.Errorf("deleted = %v, want [2 1]", deleted)
+	}
+	if len(stub.data) != 0 {
+		t.Errorf("remaining articles = %d, want 0", len(stub.data))
+	}
+}
+
+func TestService_DeleteBatch_invalidIDs(t *testing.T) {
+	tooMany := make([]int64, artUC.MaxBulkArticles+1)
+	for i := range tooMany {
+		tooMany[i] = int64(i + 1)
+	}
+	for name, ids := range map[string][]int64{
+		"empty":    nil,
+		"too many": tooMany,
+		"zero":     {1, 0},
+	} {
+		t.Run(name, func(t *testing.T) {
+			svc := artUC.Service{Repo: newStub()}
+			if _, err := svc.DeleteBatch(context.Background(), ids); !errors.Is(err, artUC.ErrInvalidArticleIDs) {
+				t.Errorf("DeleteBatch() error = %v, want ErrInvalidArticleIDs", err)
+			}
+		})
+	}
+}
+
+func TestService_DeleteBatch_repoError(t *testing.T) {
+	stub := newStub()
+	stub.err = errors.New("delete failed")
+	svc := artUC.Service{Repo: stub}
+
+	if _, err := svc.DeleteBatch(context.Background(), []int64{1}); err == nil {
+		t.Fatal("DeleteBatch() error = nil, want error")
+	}
+}
+
+/* ───────── 17. Export: ストリーミング書き出し ───────── */
+
+func TestService_Export(t *testing.T) {
+	stub := newStub()
+	stub.data[1] = &entity.Article{ID: 1, Title: "one"}
+	stub.data[2] = &entity.Article{ID: 2, Title: "two"}
+	svc := artUC.Service{Repo: stub}
+
+	var got []int64
+	err := svc.Export(context.Background(), nil, repository.ArticleSearchFilters{}, func(a repository.ArticleWithSource) error {
+		got = append(got, a.Article.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("exported = %v, want [1 2]", got)
+	}
+
+	// コールバックのエラーはラップせずそのまま返す
+	stop := errors.New("client gone")
+	err = svc.Export(context.Background(), nil, repository.ArticleSearchFilters{}, func(repository.ArticleWithSource) error {
+		return stop
+	})
+	if err != stop {
+		t.Errorf("Export() error = %v, want the callback error", err)
+	}
+
+	stub.err = errors.New("db down")
+	if err := svc.Export(context.Background(), nil, repository.ArticleSearchFilters{}, func(repository.ArticleWithSource) error {
+		return nil
+	}); err == nil || errors.Is(err, stop) {
+		t.Errorf("Export() error = %v, want wrapped repository error", err)
+	}
+}
+
 func (s *stubRepo) CreateWithSummary(_ context.Context, _ *entity.Article, _ *entity.Summary) error {
 	return nil
 }
+func (s *stubRepo) CreateBatch(_ context.Context, _ []repository.NewArticle) (int, error) {
+	return 0, nil
+}
 func (s *stubRepo) CreateWithTranscribeJob(_ context.Context, _ *entity.Article, _, _ string) error {
 	return nil
 }
+
+/* ───────── 18. Content: クロール時に取得したページ ───────── */
+
+type stubContentRepo struct {
+	data map[int64]*entity.ArticleContent
+	err  error
+}
+
+func (s *stubContentRepo) Save(_ context.Context, _ *entity.ArticleContent) error { return nil }
+func (s *stubContentRepo) Get(_ context.Context, id int64) (*entity.ArticleContent, error) {
+	return s.data[id], s.err
+}
+
+func TestService_Content(t *testing.T) {
+	stub := newStub()
+	stub.data[1] = &entity.Article{ID: 1, Title: "with page"}
+	stub.data[2] = &entity.Article{ID: 2, Title: "rss only"}
+	contents := &stubContentRepo{data: map[int64]*entity.ArticleContent{
+		1: {ArticleID: 1, URL: "https://example.com/1", HTML: "<p>x</p>", Text: "x"},
+	}}
+	svc := artUC.Service{Repo: stub, Contents: contents}
+
+	got, err := svc.Content(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Content() error = %v", err)
+	}
+	if got.HTML != "<p>x</p>" || got.Text != "x" {
+		t.Errorf("Content() = %+v", got)
+	}
+
+	tests := []struct {
+		name    string
+		svc     artUC.Service
+		id      int64
+		wantErr error
+	}{
+		{"invalid id", svc, 0, artUC.ErrInvalidArticleID},
+		{"article not found", svc, 99, artUC.ErrArticleNotFound},
+		{"no stored page", svc, 2, artUC.ErrArticleContentNotFound},
+		{"contents repo not configured", artUC.Service{Repo: stub}, 1, artUC.ErrArticleContentNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.svc.Content(context.Background(), tt.id); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Content() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("repository error", func(t *testing.T) {
+		failing := artUC.Service{Repo: stub, Contents: &stubContentRepo{err: errors.New("db down")}}
+		if _, err := failing.Content(context.Background(), 1); err == nil {
+			t.Error("Content() error = nil, want repository error")
+		}
+	})
+}
+
+/* ───────── 19. Resummarize: worker への再要約ジョブ登録 ───────── */
+
+type stubJobRepo struct {
+	kind    string
+	payload json.RawMessage
+	err     error
+}
+
+func (s *stubJobRepo) Enqueue(_ context.Context, kind string, payload json.RawMessage, _ time.Time) (int64, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	s.kind, s.payload = kind, payload
+	return 7, nil
+}
+func (s *stubJobRepo) Get(_ context.Context, id int64) (*entity.Job, error) {
+	if s.kind == "" {
+		return nil, nil
+	}
+	return &entity.Job{ID: id, Kind: s.kind, Payload: s.payload, Status: entity.JobStatusPending}, nil
+}
+func (s *stubJobRepo) EnqueueUnique(context.Context, string, string, json.RawMessage, time.Time) (int64, bool, error) {
+	panic("not used")
+}
+func (s *stubJobRepo) ClaimNext(context.Context, ...string) (*entity.Job, error) { panic("not used") }
+func (s *stubJobRepo) MarkDone(context.Context, int64) error                     { panic("not used") }
+func (s *stubJobRepo) MarkFailed(context.Context, int64, string, *time.Time) error {
+	panic("not used")
+}
+func (s *stubJobRepo) RequeueRunning(context.Context, time.Time, ...string) (int64, error) {
+	panic("not used")
+}
+
+func (s *stubJobRepo) ListActive(context.Context, ...string) ([]*entity.Job, error) {
+	panic("not used")
+}
+
+func TestService_Resummarize(t *testing.T) {
+	stub := newStub()
+	stub.data[3] = &entity.Article{ID: 3, Title: "needs a new summary"}
+	jobs := &stubJobRepo{}
+	svc := artUC.Service{Repo: stub, Jobs: jobs}
+
+	job, err := svc.Resummarize(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("Resummarize() error = %v", err)
+	}
+	if job.ID != 7 || job.Kind != entity.JobKindResummarize || job.Status != entity.JobStatusPending {
+		t.Errorf("Resummarize() job = %+v", job)
+	}
+	var payload entity.ResummarizePayload
+	if err := json.Unmarshal(jobs.payload, &payload); err != nil || payload.ArticleID != 3 {
+		t.Errorf("payload = %s, want article_id 3", jobs.payload)
+	}
+
+	tests := []struct {
+		name    string
+		svc     artUC.Service
+		id      int64
+		wantErr error
+	}{
+		{"invalid id", svc, 0, artUC.ErrInvalidArticleID},
+		{"article not found", svc, 99, artUC.ErrArticleNotFound},
+		{"jobs not configured", artUC.Service{Repo: stub}, 3, artUC.ErrResummarizeUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.svc.Resummarize(context.Background(), tt.id); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Resummarize() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("enqueue error", func(t *testing.T) {
+		failing := artUC.Service{Repo: stub, Jobs: &stubJobRepo{err: errors.New("db down")}}
+		if _, err := failing.Resummarize(context.Background(), 3); err == nil {
+			t.Error("Resummarize() error = nil, want enqueue error")
+		}
+	})
+}
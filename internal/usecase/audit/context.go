@@ -0,0 +1,26 @@
+package audit
+
+import "context"
+
+// Meta is the request context recorded with every entry. The HTTP layer
+// fills it (handler/http/audit.RequestContext) so use cases can record
+// mutations without knowing about requests, JWTs or proxies.
+type Meta struct {
+	Actor     string // JWT sub: admin user name or viewer email
+	RequestID string
+	IP        string
+}
+
+type metaKey struct{}
+
+// WithMeta returns a context carrying meta.
+func WithMeta(ctx context.Context, meta Meta) context.Context {
+	return context.WithValue(ctx, metaKey{}, meta)
+}
+
+// MetaFromContext returns the meta attached by WithMeta, or the zero Meta
+// outside an HTTP request (worker, tests).
+func MetaFromContext(ctx context.Context) Meta {
+	meta, _ := ctx.Value(metaKey{}).(Meta)
+	return meta
+}
@@ -0,0 +1,14 @@
+// Package audit provides the admin audit trail: source / article
+// mutations and JWT issuance are recorded into audit_logs with the actor,
+// request_id, client IP and before/after snapshots, and listed for the
+// administrator with filters and pagination.
+package audit
+
+import "errors"
+
+// Sentinel errors. Messages contain respond.SafeError's safe words so they
+// reach the client verbatim.
+var (
+	// ErrInvalidRange indicates from is not before to.
+	ErrInvalidRange = errors.New("invalid range: from must be before to")
+)
@@ -0,0 +1,135 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"catchup-feed/internal/common/pagination"
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// Entry is one mutation to record. Before / After are marshalled to JSON
+// as-is; nil means "no snapshot" (create has no before, delete no after).
+type Entry struct {
+	Action       string
+	ResourceType string
+	ResourceID   *int64
+	Before       any
+	After        any
+	// Actor overrides Meta.Actor. Token issuance has no authenticated
+	// request yet, so the issued subject is the actor.
+	Actor string
+}
+
+// Recorder records audit entries. Use cases depend on this interface and
+// treat a nil Recorder as "auditing disabled".
+type Recorder interface {
+	Record(ctx context.Context, e Entry)
+}
+
+// Service records and lists audit entries.
+type Service struct {
+	Repo   repository.AuditLogRepository
+	Logger *slog.Logger // nil = slog.Default()
+}
+
+// PaginatedResult is one page of audit entries.
+type PaginatedResult struct {
+	Data       []*entity.AuditLog
+	Pagination pagination.Metadata
+}
+
+func (s *Service) logger() *slog.Logger {
+	if s.Logger != nil {
+		return s.Logger
+	}
+	return slog.Default()
+}
+
+// Record writes the entry with the request meta from ctx. It is
+// best-effort: the mutation it describes has already been committed, so
+// failing the request would misreport the outcome. Failures are logged at
+// Error level instead.
+func (s *Service) Record(ctx context.Context, e Entry) {
+	meta := MetaFromContext(ctx)
+	entry := &entity.AuditLog{
+		Actor:        meta.Actor,
+		Action:       e.Action,
+		ResourceType: e.ResourceType,
+		ResourceID:   e.ResourceID,
+		RequestID:    meta.RequestID,
+		IP:           meta.IP,
+	}
+	if e.Actor != "" {
+		entry.Actor = e.Actor
+	}
+	if entry.Actor == "" {
+		entry.Actor = "unknown"
+	}
+
+	var err error
+	if entry.Before, err = snapshot(e.Before); err == nil {
+		entry.After, err = snapshot(e.After)
+	}
+	if err == nil {
+		err = s.Repo.Insert(ctx, entry)
+	}
+	if err != nil {
+		s.logger().ErrorContext(ctx, "audit: failed to record entry",
+			slog.String("action", e.Action),
+			slog.String("resource_type", e.ResourceType),
+			slog.Any("error", err))
+	}
+}
+
+// RecordTokenIssued records a successful POST /auth/token (implements
+// auth.TokenAuditor). The subject is the actor: the caller has no
+// identity before the token exists.
+func (s *Service) RecordTokenIssued(ctx context.Context, subject, role string) {
+	s.Record(ctx, Entry{
+		Action:       entity.AuditActionIssue,
+		ResourceType: entity.AuditResourceAuthToken,
+		After:        map[string]string{"sub": subject, "role": role},
+		Actor:        subject,
+	})
+}
+
+// List returns one page of entries matching filter, newest first.
+func (s *Service) List(ctx context.Context, filter repository.AuditLogFilter, params pagination.Params) (*PaginatedResult, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, ErrInvalidRange
+	}
+
+	total, err := s.Repo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("count audit logs: %w", err)
+	}
+	offset := pagination.CalculateOffset(params.Page, params.Limit)
+	entries, err := s.Repo.List(ctx, filter, offset, params.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("list audit logs: %w", err)
+	}
+	return &PaginatedResult{
+		Data: entries,
+		Pagination: pagination.Metadata{
+			Total:      total,
+			Page:       params.Page,
+			Limit:      params.Limit,
+			TotalPages: pagination.CalculateTotalPages(total, params.Limit),
+		},
+	}, nil
+}
+
+func snapshot(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshal snapshot: %w", err)
+	}
+	return b, nil
+}
@@ -0,0 +1,105 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/common/pagination"
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+	"catchup-feed/internal/usecase/audit"
+)
+
+type stubAuditRepo struct {
+	inserted  []*entity.AuditLog
+	insertErr error
+	total     int64
+	offset    int
+	limit     int
+}
+
+func (s *stubAuditRepo) Insert(_ context.Context, e *entity.AuditLog) error {
+	if s.insertErr != nil {
+		return s.insertErr
+	}
+	s.inserted = append(s.inserted, e)
+	return nil
+}
+
+func (s *stubAuditRepo) List(_ context.Context, _ repository.AuditLogFilter, offset, limit int) ([]*entity.AuditLog, error) {
+	s.offset, s.limit = offset, limit
+	return []*entity.AuditLog{{ID: 1}}, nil
+}
+
+func (s *stubAuditRepo) Count(context.Context, repository.AuditLogFilter) (int64, error) {
+	return s.total, nil
+}
+
+func TestService_Record(t *testing.T) {
+	repo := &stubAuditRepo{}
+	svc := &audit.Service{Repo: repo}
+	ctx := audit.WithMeta(context.Background(), audit.Meta{
+		Actor: "admin@example.com", RequestID: "req-1", IP: "192.0.2.1",
+	})
+
+	id := int64(3)
+	svc.Record(ctx, audit.Entry{
+		Action:       entity.AuditActionUpdate,
+		ResourceType: entity.AuditResourceSource,
+		ResourceID:   &id,
+		Before:       map[string]string{"name": "old"},
+		After:        map[string]string{"name": "new"},
+	})
+
+	require.Len(t, repo.inserted, 1)
+	got := repo.inserted[0]
+	assert.Equal(t, "admin@example.com", got.Actor)
+	assert.Equal(t, "req-1", got.RequestID)
+	assert.Equal(t, "192.0.2.1", got.IP)
+	assert.JSONEq(t, `{"name":"old"}`, string(got.Before))
+	assert.JSONEq(t, `{"name":"new"}`, string(got.After))
+}
+
+func TestService_Record_actor(t *testing.T) {
+	repo := &stubAuditRepo{}
+	svc := &audit.Service{Repo: repo}
+
+	// 認証前のトークン発行は発行先の sub が実行者、それ以外で不明なら "unknown"。
+	svc.RecordTokenIssued(context.Background(), "friend@example.com", "viewer")
+	svc.Record(context.Background(), audit.Entry{Action: entity.AuditActionCreate})
+
+	require.Len(t, repo.inserted, 2)
+	assert.Equal(t, "friend@example.com", repo.inserted[0].Actor)
+	assert.Nil(t, repo.inserted[0].Before)
+	assert.Equal(t, "unknown", repo.inserted[1].Actor)
+}
+
+// TestService_Record_insertFailure: the mutation has already been
+// committed, so a failed insert is logged and swallowed.
+func TestService_Record_insertFailure(t *testing.T) {
+	svc := &audit.Service{Repo: &stubAuditRepo{insertErr: errors.New("db down")}}
+	assert.NotPanics(t, func() {
+		svc.Record(context.Background(), audit.Entry{Action: entity.AuditActionDelete})
+	})
+}
+
+func TestService_List(t *testing.T) {
+	repo := &stubAuditRepo{total: 45}
+	svc := &audit.Service{Repo: repo}
+
+	result, err := svc.List(context.Background(), repository.AuditLogFilter{}, pagination.Params{Page: 3, Limit: 20})
+	require.NoError(t, err)
+	assert.Equal(t, 40, repo.offset)
+	assert.Equal(t, 20, repo.limit)
+	assert.Equal(t, 3, result.Pagination.TotalPages)
+
+	from := time.Date(2026, 7, 2, 0, 0, 0, 0, time.UTC)
+	to := from.Add(-time.Hour)
+	_, err = svc.List(context.Background(), repository.AuditLogFilter{From: &from, To: &to}, pagination.Params{Page: 1, Limit: 20})
+	assert.ErrorIs(t, err, audit.ErrInvalidRange)
+}
@@ -0,0 +1,35 @@
+// Package crawl provides the on-demand crawl use cases: the API enqueues a
+// 'crawl' job that the worker executes right away instead of waiting for
+// the next cron tick (C-4: the API never crawls in-process), and the job
+// status can be polled by ID. The history of past runs (crawl_runs) is
+// listed for operators.
+package crawl
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors. Messages contain respond.SafeError's safe words so they
+// reach the client verbatim.
+var (
+	// ErrSourceNotFound indicates the source to crawl does not exist.
+	ErrSourceNotFound = errors.New("source not found")
+
+	// ErrSourceInactive indicates the source is deactivated; the worker
+	// would skip it, so the request is refused up front.
+	ErrSourceInactive = errors.New("source is inactive and cannot be crawled")
+
+	// ErrJobNotFound indicates no crawl job has the given ID.
+	ErrJobNotFound = errors.New("crawl job not found")
+
+	// ErrRunNotFound indicates no crawl run has the given ID.
+	ErrRunNotFound = errors.New("crawl run not found")
+
+	// ErrSkippedSourceNotFound indicates the source to put back into the
+	// scheduled crawls is not skipped.
+	ErrSkippedSourceNotFound = errors.New("skipped source not found")
+
+	// ErrInvalidPauseReason indicates an oversized pause reason.
+	ErrInvalidPauseReason = fmt.Errorf("reason is invalid: must be at most %d characters", MaxPauseReasonLength)
+)
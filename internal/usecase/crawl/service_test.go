@@ -0,0 +1,262 @@
+package crawl_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/common/pagination"
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+	crawlUC "catchup-feed/internal/usecase/crawl"
+)
+
+/* ───────── モック実装 ───────── */
+
+type stubJobs struct {
+	repository.JobRepository
+	jobs       map[int64]*entity.Job
+	enqueueErr error
+}
+
+func newStubJobs() *stubJobs {
+	return &stubJobs{jobs: map[int64]*entity.Job{}}
+}
+
+func (s *stubJobs) Enqueue(_ context.Context, kind string, payload json.RawMessage, _ time.Time) (int64, error) {
+	if s.enqueueErr != nil {
+		return 0, s.enqueueErr
+	}
+	id := int64(len(s.jobs) + 1)
+	s.jobs[id] = &entity.Job{ID: id, Kind: kind, Payload: payload, Status: entity.JobStatusPending}
+	return id, nil
+}
+
+func (s *stubJobs) Get(_ context.Context, id int64) (*entity.Job, error) {
+	return s.jobs[id], nil
+}
+
+type stubSources struct {
+	repository.SourceRepository
+	sources map[int64]*entity.Source
+}
+
+func (s *stubSources) Get(_ context.Context, id int64) (*entity.Source, error) {
+	return s.sources[id], nil
+}
+
+func newService() (*crawlUC.Service, *stubJobs) {
+	jobs := newStubJobs()
+	return &crawlUC.Service{
+		Jobs: jobs,
+		Sources: &stubSources{sources: map[int64]*entity.Source{
+			1: {ID: 1, Active: true},
+			2: {ID: 2, Active: false},
+		}},
+	}, jobs
+}
+
+/* ───────── TriggerSource ───────── */
+
+func TestService_TriggerSource(t *testing.T) {
+	svc, jobs := newService()
+
+	job, err := svc.TriggerSource(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, entity.JobKindCrawl, job.Kind)
+	assert.Equal(t, entity.JobStatusPending, job.Status)
+	assert.JSONEq(t, `{"source_id":1}`, string(jobs.jobs[job.ID].Payload))
+}
+
+func TestService_TriggerSource_Errors(t *testing.T) {
+	svc, jobs := newService()
+
+	_, err := svc.TriggerSource(context.Background(), 99)
+	assert.ErrorIs(t, err, crawlUC.ErrSourceNotFound)
+
+	_, err = svc.TriggerSource(context.Background(), 2)
+	assert.ErrorIs(t, err, crawlUC.ErrSourceInactive)
+	assert.Empty(t, jobs.jobs, "no job for a refused source")
+
+	jobs.enqueueErr = errors.New("db down")
+	_, err = svc.TriggerSource(context.Background(), 1)
+	assert.Error(t, err)
+}
+
+/* ───────── TriggerAll ───────── */
+
+func TestService_TriggerAll(t *testing.T) {
+	svc, jobs := newService()
+
+	job, err := svc.TriggerAll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, entity.JobKindCrawl, job.Kind)
+	assert.JSONEq(t, `{}`, string(jobs.jobs[job.ID].Payload))
+}
+
+/* ───────── Status ───────── */
+
+func TestService_Status(t *testing.T) {
+	svc, jobs := newService()
+	job, err := svc.TriggerAll(context.Background())
+	require.NoError(t, err)
+	jobs.jobs[50] = &entity.Job{ID: 50, Kind: entity.JobKindDeliverWebhook}
+
+	got, err := svc.Status(context.Background(), job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, job.ID, got.ID)
+
+	_, err = svc.Status(context.Background(), 404)
+	assert.ErrorIs(t, err, crawlUC.ErrJobNotFound)
+
+	_, err = svc.Status(context.Background(), 50)
+	assert.ErrorIs(t, err, crawlUC.ErrJobNotFound, "other job kinds are hidden")
+}
+
+/* ───────── クロール履歴 ───────── */
+
+type stubRuns struct {
+	repository.CrawlRunRepository
+	runs      []*entity.CrawlRun
+	gotOffset int
+	gotLimit  int
+	err       error
+}
+
+func (s *stubRuns) List(_ context.Context, offset, limit int) ([]*entity.CrawlRun, error) {
+	s.gotOffset, s.gotLimit = offset, limit
+	return s.runs, s.err
+}
+
+func (s *stubRuns) Count(_ context.Context) (int64, error) {
+	return int64(len(s.runs)), s.err
+}
+
+func (s *stubRuns) Get(_ context.Context, id int64) (*entity.CrawlRun, error) {
+	for _, r := range s.runs {
+		if r.ID == id {
+			return r, s.err
+		}
+	}
+	return nil, s.err
+}
+
+func TestService_ListRuns(t *testing.T) {
+	runs := &stubRuns{runs: []*entity.CrawlRun{{ID: 3}, {ID: 2}, {ID: 1}}}
+	svc := &crawlUC.Service{Runs: runs}
+
+	got, err := svc.ListRuns(context.Background(), pagination.Params{Page: 2, Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 2, runs.gotOffset)
+	assert.Equal(t, 2, runs.gotLimit)
+	assert.Len(t, got.Data, 3)
+	assert.Equal(t, int64(3), got.Pagination.Total)
+	assert.Equal(t, 2, got.Pagination.TotalPages)
+
+	runs.err = errors.New("db down")
+	_, err = svc.ListRuns(context.Background(), pagination.Params{Page: 1, Limit: 20})
+	assert.Error(t, err)
+}
+
+func TestService_GetRun(t *testing.T) {
+	svc := &crawlUC.Service{Runs: &stubRuns{runs: []*entity.CrawlRun{{ID: 7, Status: entity.CrawlRunStatusSucceeded}}}}
+
+	run, err := svc.GetRun(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), run.ID)
+
+	_, err = svc.GetRun(context.Background(), 8)
+	assert.ErrorIs(t, err, crawlUC.ErrRunNotFound)
+}
+
+/* ───────── メンテナンス制御 ───────── */
+
+type stubControl struct {
+	control entity.CrawlControl
+	skipped map[int64]bool
+}
+
+func newStubControl() *stubControl {
+	return &stubControl{skipped: map[int64]bool{}}
+}
+
+func (s *stubControl) Get(context.Context) (*entity.CrawlControl, error) {
+	c := s.control
+	for id := range s.skipped {
+		c.SkippedSources = append(c.SkippedSources, id)
+	}
+	return &c, nil
+}
+
+func (s *stubControl) SetPaused(_ context.Context, paused bool, reason, updatedBy string) error {
+	s.control.Paused, s.control.Reason, s.control.UpdatedBy = paused, reason, updatedBy
+	return nil
+}
+
+func (s *stubControl) SkipSource(_ context.Context, sourceID int64) error {
+	s.skipped[sourceID] = true
+	return nil
+}
+
+func (s *stubControl) UnskipSource(_ context.Context, sourceID int64) (bool, error) {
+	removed := s.skipped[sourceID]
+	delete(s.skipped, sourceID)
+	return removed, nil
+}
+
+func (s *stubJobs) ListActive(_ context.Context, kinds ...string) ([]*entity.Job, error) {
+	var out []*entity.Job
+	for _, j := range s.jobs {
+		if (j.Status == entity.JobStatusPending || j.Status == entity.JobStatusRunning) && slices.Contains(kinds, j.Kind) {
+			out = append(out, j)
+		}
+	}
+	return out, nil
+}
+
+func TestService_PauseResume(t *testing.T) {
+	svc, jobs := newService()
+	control := newStubControl()
+	svc.Control = control
+	jobs.jobs[1] = &entity.Job{ID: 1, Kind: entity.JobKindCrawl, Status: entity.JobStatusRunning}
+	jobs.jobs[2] = &entity.Job{ID: 2, Kind: entity.JobKindCrawl, Status: entity.JobStatusDone}
+	jobs.jobs[3] = &entity.Job{ID: 3, Kind: entity.JobKindDeliverWebhook, Status: entity.JobStatusPending}
+
+	require.NoError(t, svc.Pause(context.Background(), "  DB maintenance ", "admin@example.com"))
+	status, err := svc.ControlStatus(context.Background())
+	require.NoError(t, err)
+	assert.True(t, status.Control.Paused)
+	assert.Equal(t, "DB maintenance", status.Control.Reason)
+	assert.Equal(t, "admin@example.com", status.Control.UpdatedBy)
+	require.Len(t, status.ActiveJobs, 1, "only pending / running crawl jobs")
+	assert.Equal(t, int64(1), status.ActiveJobs[0].ID)
+
+	require.NoError(t, svc.Resume(context.Background(), "admin@example.com"))
+	assert.False(t, control.control.Paused)
+	assert.Empty(t, control.control.Reason)
+
+	err = svc.Pause(context.Background(), strings.Repeat("あ", crawlUC.MaxPauseReasonLength+1), "admin@example.com")
+	assert.ErrorIs(t, err, crawlUC.ErrInvalidPauseReason)
+}
+
+func TestService_SkipSource(t *testing.T) {
+	svc, _ := newService()
+	control := newStubControl()
+	svc.Control = control
+
+	// 無効化されたソースも外せる(再有効化したときに備える)
+	require.NoError(t, svc.SkipSource(context.Background(), 2))
+	assert.True(t, control.skipped[2])
+
+	assert.ErrorIs(t, svc.SkipSource(context.Background(), 99), crawlUC.ErrSourceNotFound)
+
+	require.NoError(t, svc.UnskipSource(context.Background(), 2))
+	assert.ErrorIs(t, svc.UnskipSource(context.Background(), 2), crawlUC.ErrSkippedSourceNotFound)
+}
@@ -0,0 +1,188 @@
+// Package dashboard fans the live dashboard events (new articles,
+// finished crawls, source health changes) out to the GET /ws connections.
+package dashboard
+
+import (
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// eventBufferSize is how many events a connection may fall behind before
+// it starts missing them.
+const eventBufferSize = 32
+
+// Filter selects the events a connection receives. An empty Types or
+// SourceIDs does not narrow anything. SourceIDs applies to the events
+// about a single source; crawl.completed always passes it.
+type Filter struct {
+	Types     []string `json:"types"`
+	SourceIDs []int64  `json:"source_ids,omitempty"`
+}
+
+// Match reports whether ev passes the filter.
+func (f Filter) Match(ev entity.DashboardEvent) bool {
+	if len(f.Types) > 0 && !slices.Contains(f.Types, ev.Type) {
+		return false
+	}
+	if len(f.SourceIDs) > 0 && ev.SourceID != 0 && !slices.Contains(f.SourceIDs, ev.SourceID) {
+		return false
+	}
+	return true
+}
+
+// Stats are the hub's connection and delivery counters (reported by
+// GET /health).
+type Stats struct {
+	Connections      int   `json:"connections"`       // open now
+	TotalConnections int64 `json:"total_connections"` // since startup
+	Delivered        int64 `json:"delivered"`         // events handed to a connection
+	Dropped          int64 `json:"dropped"`           // events missed by a full buffer
+}
+
+// Hub fans dashboard events out to subscribers. Like article.EventBus it
+// is fed by the database listeners and best-effort: a subscriber whose
+// buffer is full misses the event instead of stalling the others.
+type Hub struct {
+	mu     sync.Mutex
+	subs   map[*Subscription]struct{}
+	closed bool
+
+	total     atomic.Int64
+	delivered atomic.Int64
+	dropped   atomic.Int64
+}
+
+func NewHub() *Hub {
+	return &Hub{subs: make(map[*Subscription]struct{})}
+}
+
+// Subscription is one connection's registration. Its filter can be
+// replaced while it is open.
+type Subscription struct {
+	hub    *Hub
+	events chan entity.DashboardEvent
+	filter Filter // guarded by hub.mu
+	once   sync.Once
+}
+
+// Subscribe registers a subscriber receiving the events that match
+// filter. After Close the events channel is returned already closed.
+func (h *Hub) Subscribe(filter Filter) *Subscription {
+	sub := &Subscription{hub: h, events: make(chan entity.DashboardEvent, eventBufferSize), filter: filter}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(sub.events)
+		return sub
+	}
+	h.subs[sub] = struct{}{}
+	h.total.Add(1)
+	return sub
+}
+
+// Events is closed by Cancel or when the hub closes.
+func (s *Subscription) Events() <-chan entity.DashboardEvent { return s.events }
+
+// Filter returns the current filter.
+func (s *Subscription) Filter() Filter {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	return s.filter
+}
+
+// SetFilter replaces the filter for the events published from now on.
+func (s *Subscription) SetFilter(filter Filter) {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.filter = filter
+}
+
+// Cancel unregisters the subscriber and closes its channel; it is safe to
+// call more than once.
+func (s *Subscription) Cancel() {
+	s.once.Do(func() {
+		s.hub.mu.Lock()
+		defer s.hub.mu.Unlock()
+		if _, ok := s.hub.subs[s]; ok {
+			delete(s.hub.subs, s)
+			close(s.events)
+		}
+	})
+}
+
+// Close ends every subscription, so open connections return during server
+// shutdown instead of holding it up until the deadline.
+func (h *Hub) Close() {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for sub := range h.subs {
+		delete(h.subs, sub)
+		close(sub.events)
+	}
+}
+
+// Publish hands ev to every matching subscriber without blocking.
+func (h *Hub) Publish(ev entity.DashboardEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subs {
+		if !sub.filter.Match(ev) {
+			continue
+		}
+		select {
+		case sub.events <- ev:
+			h.delivered.Add(1)
+		default:
+			h.dropped.Add(1)
+		}
+	}
+}
+
+// Stats returns the current counters.
+func (h *Hub) Stats() Stats {
+	h.mu.Lock()
+	conns := len(h.subs)
+	h.mu.Unlock()
+	return Stats{
+		Connections:      conns,
+		TotalConnections: h.total.Load(),
+		Delivered:        h.delivered.Load(),
+		Dropped:          h.dropped.Load(),
+	}
+}
+
+// HandleNotification publishes a NOTIFY payload of
+// entity.DashboardEventChannel (the db.Listen callback). A malformed
+// payload is logged and dropped.
+func (h *Hub) HandleNotification(payload string) {
+	var ev entity.DashboardEvent
+	if err := json.Unmarshal([]byte(payload), &ev); err != nil || ev.Type == "" {
+		slog.Warn("dashboard event: malformed notification", slog.Any("error", err))
+		return
+	}
+	h.Publish(ev)
+}
+
+// HandleArticleNotification publishes a NOTIFY payload of
+// entity.ArticleEventChannel as article.created.
+func (h *Hub) HandleArticleNotification(payload string) {
+	var ev entity.ArticleEvent
+	if err := json.Unmarshal([]byte(payload), &ev); err != nil {
+		slog.Warn("dashboard event: malformed article notification", slog.Any("error", err))
+		return
+	}
+	dev, err := entity.NewArticleDashboardEvent(ev)
+	if err != nil {
+		return
+	}
+	h.Publish(dev)
+}
@@ -0,0 +1,132 @@
+package dashboard_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/usecase/dashboard"
+)
+
+func event(typ string, sourceID int64) entity.DashboardEvent {
+	return entity.DashboardEvent{Type: typ, SourceID: sourceID, Data: json.RawMessage(`{}`)}
+}
+
+/* ───────── Filter ───────── */
+
+func TestFilter_Match(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter dashboard.Filter
+		ev     entity.DashboardEvent
+		want   bool
+	}{
+		{"empty filter passes all", dashboard.Filter{}, event(entity.DashboardEventSourceHealth, 3), true},
+		{"type listed", dashboard.Filter{Types: []string{entity.DashboardEventArticleCreated}}, event(entity.DashboardEventArticleCreated, 1), true},
+		{"type not listed", dashboard.Filter{Types: []string{entity.DashboardEventArticleCreated}}, event(entity.DashboardEventCrawlCompleted, 0), false},
+		{"source listed", dashboard.Filter{SourceIDs: []int64{1, 2}}, event(entity.DashboardEventArticleCreated, 2), true},
+		{"source not listed", dashboard.Filter{SourceIDs: []int64{1, 2}}, event(entity.DashboardEventArticleCreated, 3), false},
+		{"crawl.completed has no source", dashboard.Filter{SourceIDs: []int64{1}}, event(entity.DashboardEventCrawlCompleted, 0), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Match(tt.ev))
+		})
+	}
+}
+
+/* ───────── Hub ───────── */
+
+func TestHub_PublishFiltersPerSubscriber(t *testing.T) {
+	hub := dashboard.NewHub()
+	all := hub.Subscribe(dashboard.Filter{})
+	defer all.Cancel()
+	crawls := hub.Subscribe(dashboard.Filter{Types: []string{entity.DashboardEventCrawlCompleted}})
+	defer crawls.Cancel()
+
+	hub.Publish(event(entity.DashboardEventArticleCreated, 1))
+	hub.Publish(event(entity.DashboardEventCrawlCompleted, 0))
+
+	assert.Equal(t, entity.DashboardEventArticleCreated, (<-all.Events()).Type)
+	assert.Equal(t, entity.DashboardEventCrawlCompleted, (<-all.Events()).Type)
+	assert.Equal(t, entity.DashboardEventCrawlCompleted, (<-crawls.Events()).Type)
+	assert.Empty(t, crawls.Events())
+	assert.Equal(t, int64(3), hub.Stats().Delivered)
+}
+
+func TestHub_SetFilter(t *testing.T) {
+	hub := dashboard.NewHub()
+	sub := hub.Subscribe(dashboard.Filter{SourceIDs: []int64{1}})
+	defer sub.Cancel()
+
+	sub.SetFilter(dashboard.Filter{SourceIDs: []int64{2}})
+	hub.Publish(event(entity.DashboardEventArticleCreated, 1))
+	hub.Publish(event(entity.DashboardEventArticleCreated, 2))
+
+	assert.Equal(t, int64(2), (<-sub.Events()).SourceID)
+	assert.Empty(t, sub.Events())
+	assert.Equal(t, []int64{2}, sub.Filter().SourceIDs)
+}
+
+func TestHub_FullBufferDrops(t *testing.T) {
+	hub := dashboard.NewHub()
+	sub := hub.Subscribe(dashboard.Filter{})
+	defer sub.Cancel()
+
+	for range 100 {
+		hub.Publish(event(entity.DashboardEventArticleCreated, 1))
+	}
+
+	stats := hub.Stats()
+	assert.Equal(t, int64(100), stats.Delivered+stats.Dropped)
+	assert.Positive(t, stats.Dropped)
+}
+
+func TestHub_StatsAndCancel(t *testing.T) {
+	hub := dashboard.NewHub()
+	a := hub.Subscribe(dashboard.Filter{})
+	b := hub.Subscribe(dashboard.Filter{})
+	assert.Equal(t, dashboard.Stats{Connections: 2, TotalConnections: 2}, hub.Stats())
+
+	a.Cancel()
+	a.Cancel() // idempotent
+	_, ok := <-a.Events()
+	assert.False(t, ok)
+	assert.Equal(t, 1, hub.Stats().Connections)
+
+	hub.Close()
+	_, ok = <-b.Events()
+	assert.False(t, ok)
+	b.Cancel() // safe after Close
+	assert.Equal(t, dashboard.Stats{TotalConnections: 2}, hub.Stats())
+
+	late := hub.Subscribe(dashboard.Filter{})
+	_, ok = <-late.Events()
+	assert.False(t, ok, "subscribing after Close returns a closed channel")
+}
+
+func TestHub_HandleNotifications(t *testing.T) {
+	hub := dashboard.NewHub()
+	sub := hub.Subscribe(dashboard.Filter{})
+	defer sub.Cancel()
+
+	hub.HandleArticleNotification(`{"type":"article.created","article_id":7,"source_id":3}`)
+	hub.HandleNotification(`{"type":"source.health","source_id":3,"data":{"status":"failing"}}`)
+	hub.HandleNotification(`not json`)
+	hub.HandleNotification(`{"data":{}}`)
+
+	ev := <-sub.Events()
+	assert.Equal(t, entity.DashboardEventArticleCreated, ev.Type)
+	assert.Equal(t, int64(3), ev.SourceID)
+	var art entity.ArticleEvent
+	require.NoError(t, json.Unmarshal(ev.Data, &art))
+	assert.Equal(t, int64(7), art.ArticleID)
+
+	ev = <-sub.Events()
+	assert.Equal(t, entity.DashboardEventSourceHealth, ev.Type)
+	assert.JSONEq(t, `{"status":"failing"}`, string(ev.Data))
+	assert.Empty(t, sub.Events())
+}
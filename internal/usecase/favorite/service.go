@@ -0,0 +1,100 @@
+// Package favorite provides per-user favorite (starred) articles.
+// Favorites belong to dashboard accounts (users table); the caller is
+// identified by the login subject (users.email) the auth middleware puts
+// in the request context.
+package favorite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"catchup-feed/internal/repository"
+)
+
+// Sentinel errors. Messages contain respond.SafeError's safe words so they
+// reach the client verbatim.
+var (
+	// ErrAccountNotFound indicates the caller has no active account, e.g.
+	// an API key identity: favorites are kept per user.
+	ErrAccountNotFound = errors.New("account not found: favorites require a user account")
+
+	// ErrArticleNotFound indicates the article to star does not exist.
+	ErrArticleNotFound = errors.New("article not found")
+)
+
+// Service stars and unstars articles for the calling user.
+type Service struct {
+	Users     repository.UserRepository
+	Favorites repository.FavoriteRepository
+}
+
+// userID resolves the subject to an active account. ok is false when
+// there is none.
+func (s *Service) userID(ctx context.Context, subject string) (id int64, ok bool, err error) {
+	user, err := s.Users.GetActiveByEmail(ctx, strings.ToLower(strings.TrimSpace(subject)))
+	if err != nil {
+		return 0, false, fmt.Errorf("get account: %w", err)
+	}
+	if user == nil {
+		return 0, false, nil
+	}
+	return user.ID, true, nil
+}
+
+// Add stars the article. Starring it again is a no-op.
+func (s *Service) Add(ctx context.Context, subject string, articleID int64) error {
+	userID, ok, err := s.userID(ctx, subject)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrAccountNotFound
+	}
+	exists, err := s.Favorites.Add(ctx, userID, articleID)
+	if err != nil {
+		return fmt.Errorf("add favorite: %w", err)
+	}
+	if !exists {
+		return ErrArticleNotFound
+	}
+	return nil
+}
+
+// Remove unstars the article. Unstarred articles are not an error
+// (idempotent).
+func (s *Service) Remove(ctx context.Context, subject string, articleID int64) error {
+	userID, ok, err := s.userID(ctx, subject)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrAccountNotFound
+	}
+	if err := s.Favorites.Remove(ctx, userID, articleID); err != nil {
+		return fmt.Errorf("remove favorite: %w", err)
+	}
+	return nil
+}
+
+// FavoritedIDs returns which of articleIDs the caller has starred. A
+// caller without an account has no favorites, so the result is empty
+// rather than an error; it backs the favorited flag of article listings.
+func (s *Service) FavoritedIDs(ctx context.Context, subject string, articleIDs []int64) (map[int64]bool, error) {
+	if len(articleIDs) == 0 {
+		return map[int64]bool{}, nil
+	}
+	userID, ok, err := s.userID(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return map[int64]bool{}, nil
+	}
+	favorited, err := s.Favorites.FavoritedIDs(ctx, userID, articleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("favorited ids: %w", err)
+	}
+	return favorited, nil
+}
@@ -0,0 +1,96 @@
+package favorite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+/* ───────── モック実装 ───────── */
+
+// stubUserRepo implements only GetActiveByEmail.
+type stubUserRepo struct {
+	repository.UserRepository
+}
+
+func (stubUserRepo) GetActiveByEmail(_ context.Context, email string) (*entity.User, error) {
+	if email == "alice@example.com" {
+		return &entity.User{ID: 7, Email: email}, nil
+	}
+	return nil, nil
+}
+
+// stubFavorites keeps favorites of existing articles (ID < 100) in memory.
+type stubFavorites struct {
+	starred map[int64]map[int64]bool // user → article
+}
+
+func (s *stubFavorites) Add(_ context.Context, userID, articleID int64) (bool, error) {
+	if articleID >= 100 {
+		return false, nil
+	}
+	if s.starred[userID] == nil {
+		s.starred[userID] = map[int64]bool{}
+	}
+	s.starred[userID][articleID] = true
+	return true, nil
+}
+
+func (s *stubFavorites) Remove(_ context.Context, userID, articleID int64) error {
+	delete(s.starred[userID], articleID)
+	return nil
+}
+
+func (s *stubFavorites) FavoritedIDs(_ context.Context, userID int64, ids []int64) (map[int64]bool, error) {
+	out := map[int64]bool{}
+	for _, id := range ids {
+		if s.starred[userID][id] {
+			out[id] = true
+		}
+	}
+	return out, nil
+}
+
+func newService() (*Service, *stubFavorites) {
+	favs := &stubFavorites{starred: map[int64]map[int64]bool{}}
+	return &Service{Users: stubUserRepo{}, Favorites: favs}, favs
+}
+
+/* ───────── テスト ───────── */
+
+func TestService_AddAndRemove(t *testing.T) {
+	svc, favs := newService()
+	ctx := context.Background()
+
+	require.NoError(t, svc.Add(ctx, " Alice@Example.com ", 10))
+	require.NoError(t, svc.Add(ctx, "alice@example.com", 10), "starring twice is a no-op")
+	assert.Equal(t, map[int64]bool{10: true}, favs.starred[7])
+
+	assert.ErrorIs(t, svc.Add(ctx, "alice@example.com", 100), ErrArticleNotFound)
+	assert.ErrorIs(t, svc.Add(ctx, "apikey:ci", 10), ErrAccountNotFound)
+
+	require.NoError(t, svc.Remove(ctx, "alice@example.com", 10))
+	require.NoError(t, svc.Remove(ctx, "alice@example.com", 10), "unstarring twice is a no-op")
+	assert.Empty(t, favs.starred[7])
+	assert.ErrorIs(t, svc.Remove(ctx, "apikey:ci", 10), ErrAccountNotFound)
+}
+
+func TestService_FavoritedIDs(t *testing.T) {
+	svc, _ := newService()
+	ctx := context.Background()
+	require.NoError(t, svc.Add(ctx, "alice@example.com", 11))
+
+	got, err := svc.FavoritedIDs(ctx, "alice@example.com", []int64{10, 11})
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]bool{11: true}, got)
+
+	// アカウントのない呼び出し元(API キー)はお気に入りなし。
+	got, err = svc.FavoritedIDs(ctx, "apikey:ci", []int64{10, 11})
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
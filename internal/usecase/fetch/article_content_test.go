@@ -0,0 +1,128 @@
+package fetch_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	fetchUC "catchup-feed/internal/usecase/fetch"
+)
+
+// stubPageFetcher は FetchPage を実装する ContentFetcher。
+type stubPageFetcher struct {
+	page *fetchUC.FetchedPage
+	err  error
+}
+
+func (s *stubPageFetcher) FetchContent(_ context.Context, _ string) (string, error) {
+	return "", errors.New("FetchContent must not be called when FetchPage is available")
+}
+
+func (s *stubPageFetcher) FetchPage(_ context.Context, _ string) (*fetchUC.FetchedPage, error) {
+	return s.page, s.err
+}
+
+// stubContentRepo は保存された ArticleContent を記録する。
+type stubContentRepo struct {
+	mu      sync.Mutex
+	saved   []*entity.ArticleContent
+	saveErr error
+}
+
+func (s *stubContentRepo) Save(_ context.Context, c *entity.ArticleContent) error {
+	if s.saveErr != nil {
+		return s.saveErr
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.saved = append(s.saved, c)
+	return nil
+}
+
+func (s *stubContentRepo) Get(_ context.Context, _ int64) (*entity.ArticleContent, error) {
+	return nil, nil
+}
+
+func newContentService(t *testing.T, cf fetchUC.ContentFetcher, repo *stubContentRepo) (*fetchUC.Service, *stubArticleRepo) {
+	t.Helper()
+	artRepo := &stubArticleRepo{}
+	svc := fetchUC.NewService(
+		&stubSourceRepo{sources: []*entity.Source{
+			{ID: 1, FeedURL: "https://example.com/feed", Kind: entity.SourceKindRSS, Active: true},
+		}},
+		artRepo,
+		&stubSummarizer{result: "summary"},
+		&stubFeedFetcher{items: []fetchUC.FeedItem{
+			{Title: "Article", URL: "https://example.com/a", Content: "short", PublishedAt: time.Now()},
+		}},
+		cf,
+		fetchUC.ContentFetchConfig{Parallelism: 10, Threshold: 1500},
+	)
+	svc.ContentRepo = repo
+	return &svc, artRepo
+}
+
+/* ───────── 取得したページの保存 ───────── */
+
+func TestService_CrawlAllSources_StoresFetchedPage(t *testing.T) {
+	page := &fetchUC.FetchedPage{
+		URL:      "https://example.com/a/final",
+		HTML:     "<html><body><p>full</p></body></html>",
+		Text:     strings.Repeat("full article text ", 20),
+		ImageURL: "https://example.com/og.png",
+	}
+	repo := &stubContentRepo{}
+	svc, artRepo := newContentService(t, &stubPageFetcher{page: page}, repo)
+
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), stats.Inserted)
+
+	require.Len(t, repo.saved, 1)
+	got := repo.saved[0]
+	assert.Equal(t, artRepo.articles[0].ID, got.ArticleID)
+	assert.Equal(t, page.URL, got.URL)
+	assert.Equal(t, page.HTML, got.HTML)
+	assert.Equal(t, page.Text, got.Text)
+	// 要約には抽出テキストが使われる
+	assert.Equal(t, page.Text, artRepo.articles[0].Content)
+	// ページのサムネイルは記事に付く
+	assert.Equal(t, page.ImageURL, artRepo.articles[0].ImageURL)
+}
+
+func TestService_CrawlAllSources_FetchFailureStoresNothing(t *testing.T) {
+	repo := &stubContentRepo{}
+	svc, artRepo := newContentService(t, &stubPageFetcher{err: errors.New("boom")}, repo)
+
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), stats.Inserted)
+	assert.Empty(t, repo.saved)
+	assert.Equal(t, "short", artRepo.articles[0].Content)
+}
+
+func TestService_CrawlAllSources_PlainContentFetcherStoresNothing(t *testing.T) {
+	repo := &stubContentRepo{}
+	svc, _ := newContentService(t, &mockContentFetcher{content: strings.Repeat("text ", 100)}, repo)
+
+	_, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, repo.saved)
+}
+
+func TestService_CrawlAllSources_ContentSaveErrorDoesNotFailCrawl(t *testing.T) {
+	page := &fetchUC.FetchedPage{URL: "https://example.com/a", HTML: "<p>x</p>", Text: strings.Repeat("x ", 100)}
+	repo := &stubContentRepo{saveErr: errors.New("db down")}
+	svc, _ := newContentService(t, &stubPageFetcher{page: page}, repo)
+
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), stats.Inserted)
+}
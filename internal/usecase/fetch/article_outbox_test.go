@@ -0,0 +1,120 @@
+package fetch_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	fetchUC "catchup-feed/internal/usecase/fetch"
+)
+
+// stubTxManager は WithinTx の結果(commit / rollback)だけを記録する。
+type stubTxManager struct {
+	mu                  sync.Mutex
+	committed, rollback int
+}
+
+func (m *stubTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := fn(ctx)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err != nil {
+		m.rollback++
+		return err
+	}
+	m.committed++
+	return nil
+}
+
+// stubEventPublisher は article.created だけ失敗させられる。
+type stubEventPublisher struct {
+	mu         sync.Mutex
+	events     []string
+	articleErr error
+}
+
+func (p *stubEventPublisher) Publish(_ context.Context, event string, _ any) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if event == entity.WebhookEventArticleCreated && p.articleErr != nil {
+		return p.articleErr
+	}
+	p.events = append(p.events, event)
+	return nil
+}
+
+func newOutboxService(articles *stubArticleRepo, pub *stubEventPublisher) fetchUC.Service {
+	srcRepo := &stubSourceRepo{
+		sources: []*entity.Source{{ID: 1, FeedURL: "https://example.com/feed", Kind: entity.SourceKindRSS, Active: true}},
+	}
+	fetcher := &orderRecordingFetcher{
+		feeds: map[string][]fetchUC.FeedItem{
+			"https://example.com/feed": {{Title: "A", URL: "https://example.com/a", Content: "c", PublishedAt: time.Now()}},
+		},
+	}
+	svc := fetchUC.NewService(
+		srcRepo, articles, &stubSummarizer{}, fetcher, nil,
+		fetchUC.ContentFetchConfig{Parallelism: 10, Threshold: 1500},
+	)
+	svc.Events = pub
+	return svc
+}
+
+func TestService_Crawl_PublishesArticleCreatedWithinTx(t *testing.T) {
+	articles, pub, tx := &stubArticleRepo{}, &stubEventPublisher{}, &stubTxManager{}
+	svc := newOutboxService(articles, pub)
+	svc.Tx = tx
+
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), stats.Inserted)
+	assert.Equal(t, 1, tx.committed, "article + event commit once")
+	assert.Equal(t, []string{entity.WebhookEventArticleCreated, entity.WebhookEventCrawlCompleted}, pub.events)
+}
+
+// A failed article.created publish rolls the article back: the URL stays
+// unknown and the next crawl retries the article and its event together.
+func TestService_Crawl_PublishFailureRollsBackArticle(t *testing.T) {
+	articles := &stubArticleRepo{}
+	pub := &stubEventPublisher{articleErr: errors.New("db down")}
+	tx := &stubTxManager{}
+	svc := newOutboxService(articles, pub)
+	svc.Tx = tx
+
+	stats, _ := svc.CrawlAllSources(context.Background())
+
+	assert.Equal(t, 1, tx.rollback)
+	assert.Equal(t, 0, tx.committed)
+	assert.Equal(t, int64(0), stats.Inserted)
+}
+
+func TestService_Crawl_WithoutTxPublishIsBestEffort(t *testing.T) {
+	articles := &stubArticleRepo{}
+	pub := &stubEventPublisher{articleErr: errors.New("db down")}
+	svc := newOutboxService(articles, pub)
+
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), stats.Inserted, "the article is kept, the event is lost")
+}
+
+func TestEventPublishers_StopsAtFirstFailure(t *testing.T) {
+	first, failing, last := &stubEventPublisher{}, &stubEventPublisher{articleErr: errors.New("db down")}, &stubEventPublisher{}
+	pubs := fetchUC.EventPublishers{first, failing, last}
+
+	require.NoError(t, pubs.Publish(context.Background(), entity.WebhookEventCrawlCompleted, nil))
+	assert.Equal(t, []string{entity.WebhookEventCrawlCompleted}, last.events)
+
+	err := pubs.Publish(context.Background(), entity.WebhookEventArticleCreated, nil)
+	require.Error(t, err)
+	assert.Equal(t, []string{entity.WebhookEventCrawlCompleted, entity.WebhookEventArticleCreated}, first.events)
+	assert.Equal(t, []string{entity.WebhookEventCrawlCompleted}, last.events, "publishers after the failure are not called")
+}
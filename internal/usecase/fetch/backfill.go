@@ -0,0 +1,257 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/pkg/logging"
+)
+
+// Backfill defaults, overridable through BackfillOptions.
+const (
+	// DefaultBackfillMaxPages bounds the archive walk of one backfill.
+	DefaultBackfillMaxPages = 50
+	// DefaultBackfillBatchSize is how many new articles are summarized
+	// between two pauses. Backfills run against the same free-tier
+	// summarizer quota as the hourly crawl (§8), so they trickle.
+	DefaultBackfillBatchSize = 10
+	// DefaultBackfillDelay is the pause between archive pages and between
+	// batches.
+	DefaultBackfillDelay = 2 * time.Second
+)
+
+// BackfillPagePlaceholder is replaced by the 1-based page number in
+// BackfillOptions.PageURL.
+const BackfillPagePlaceholder = "{page}"
+
+// ErrBackfillSourceNotFound is returned by BackfillSource for an unknown
+// source ID.
+var ErrBackfillSourceNotFound = errors.New("backfill: source not found")
+
+// BackfillOptions configures one BackfillSource run. A zero MaxPages or
+// BatchSize falls back to the package default, a zero Delay disables the
+// pauses, and a zero From / To leaves that end of the range open.
+type BackfillOptions struct {
+	// From and To bound published_at (inclusive). Items without a
+	// published_at cannot be placed in the range and are skipped.
+	From time.Time
+	To   time.Time
+	// PageURL is the archive page template, e.g.
+	// "https://example.com/feed?paged={page}". Empty = only the source's
+	// feed URL, which for many feeds already carries the full history.
+	PageURL   string
+	MaxPages  int
+	BatchSize int
+	Delay     time.Duration
+}
+
+// BackfillStats reports one BackfillSource run. The embedded CrawlStats
+// counts the in-range items like a crawl does.
+type BackfillStats struct {
+	Pages      int   // archive pages fetched
+	OutOfRange int64 // items outside [From, To] or without published_at
+	CrawlStats
+}
+
+// BackfillSource ingests the back catalog of one source between
+// opts.From and opts.To — the items the crawl deliberately drops as older
+// than BackfillCutoff (D-15b). It walks the feed (or the archive pages of
+// opts.PageURL) newest first and stops at MaxPages, at a missing page
+// (404 / 410), at a page with no item it has not seen yet (servers that
+// ignore the page parameter), or once a whole page predates From. New
+// in-range items go through the regular pipeline (dedupe, summarize or
+// transcribe enqueue) in batches of BatchSize with Delay between batches
+// and pages. Already stored articles are skipped, so a backfill can be
+// re-run over the same range.
+//
+// Like the crawl, only database errors and a dead ctx abort it; the
+// stats gathered so far are returned along with the error.
+func (s *Service) BackfillSource(ctx context.Context, sourceID int64, opts BackfillOptions) (*BackfillStats, error) {
+	if !opts.From.IsZero() && !opts.To.IsZero() && opts.From.After(opts.To) {
+		return nil, errors.New("backfill: from must be before to")
+	}
+	if opts.PageURL != "" && !strings.Contains(opts.PageURL, BackfillPagePlaceholder) {
+		return nil, fmt.Errorf("backfill: page URL must contain %s", BackfillPagePlaceholder)
+	}
+	maxPages := opts.MaxPages
+	if maxPages <= 0 {
+		maxPages = DefaultBackfillMaxPages
+	}
+	if opts.PageURL == "" {
+		maxPages = 1
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBackfillBatchSize
+	}
+
+	src, err := s.SourceRepo.Get(ctx, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("get source: %w", err)
+	}
+	if src == nil {
+		return nil, ErrBackfillSourceNotFound
+	}
+
+	ctx = logging.WithAttrs(ctx,
+		slog.Int64("source_id", src.ID),
+		slog.String("source_kind", src.Kind),
+		slog.Bool("backfill", true))
+	logger := slog.Default()
+	start := time.Now()
+	stats := &BackfillStats{}
+	stats.Sources = 1
+	run := s.newCrawlRun()
+	seen := make(map[string]bool)
+	firstBatch := true
+
+	for page := 1; page <= maxPages; page++ {
+		pageURL := src.FeedURL
+		if opts.PageURL != "" {
+			pageURL = strings.ReplaceAll(opts.PageURL, BackfillPagePlaceholder, strconv.Itoa(page))
+		}
+		if page > 1 {
+			if err := sleepContext(ctx, opts.Delay); err != nil {
+				return s.finishBackfill(stats, start), err
+			}
+		}
+
+		items, err := s.FeedFetcher.Fetch(ctx, pageURL)
+		if err != nil {
+			if page > 1 && isEndOfArchive(err) {
+				logger.InfoContext(ctx, "backfill: archive ends", slog.Int("page", page))
+				break
+			}
+			return s.finishBackfill(stats, start), fmt.Errorf("fetch page %d: %w", page, err)
+		}
+		stats.Pages++
+
+		var (
+			inRange     []FeedItem
+			unseen      int
+			beforeRange int
+		)
+		for _, item := range items {
+			key := articleURLForItem(src, item)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			unseen++
+			switch {
+			case item.PublishedAt.IsZero():
+				stats.OutOfRange++
+			case !opts.From.IsZero() && item.PublishedAt.Before(opts.From):
+				stats.OutOfRange++
+				beforeRange++
+			case !opts.To.IsZero() && item.PublishedAt.After(opts.To):
+				stats.OutOfRange++
+			default:
+				inRange = append(inRange, item)
+			}
+		}
+		stats.FeedItems += int64(unseen - len(inRange))
+		logger.InfoContext(ctx, "backfill: page fetched",
+			slog.Int("page", page),
+			slog.Int("items", len(items)),
+			slog.Int("unseen", unseen),
+			slog.Int("in_range", len(inRange)))
+		if unseen == 0 {
+			break
+		}
+
+		for batchStart := 0; batchStart < len(inRange); batchStart += batchSize {
+			if !firstBatch {
+				if err := sleepContext(ctx, opts.Delay); err != nil {
+					return s.finishBackfill(stats, start), err
+				}
+			}
+			firstBatch = false
+			batch := inRange[batchStart:min(batchStart+batchSize, len(inRange))]
+			if err := s.backfillBatch(ctx, src, batch, &stats.CrawlStats, run); err != nil {
+				return s.finishBackfill(stats, start), err
+			}
+		}
+
+		if beforeRange == unseen {
+			break // newest first: every later page is older still
+		}
+	}
+
+	s.finishBackfill(stats, start)
+	logger.InfoContext(ctx, "backfill completed",
+		slog.Int("pages", stats.Pages),
+		slog.Int64("feed_items", stats.FeedItems),
+		slog.Int64("out_of_range", stats.OutOfRange),
+		slog.Int64("inserted", stats.Inserted),
+		slog.Int64("duplicated", stats.Duplicated),
+		slog.Int64("duplicated_by_hash", stats.DuplicatedByHash),
+		slog.Int64("near_duplicates", stats.NearDuplicates),
+		slog.Int64("filtered_junk", stats.FilteredJunk),
+		slog.Int64("summarize_errors", stats.SummarizeError),
+		slog.Int64("transcribe_enqueued", stats.TranscribeEnqueued),
+		slog.Duration("duration", stats.Duration))
+	return stats, nil
+}
+
+// backfillBatch dedupes and ingests one batch through the kind's regular
+// path (see processSingleSource).
+func (s *Service) backfillBatch(ctx context.Context, src *entity.Source, items []FeedItem, stats *CrawlStats, run *crawlRun) error {
+	urls := make([]string, 0, len(items))
+	for _, item := range items {
+		urls = append(urls, articleURLForItem(src, item))
+	}
+	existsMap, err := s.ArticleRepo.ExistsByURLBatch(ctx, urls)
+	if err != nil {
+		return fmt.Errorf("batch check URLs: %w", err)
+	}
+	items, err = s.dropHashDuplicates(ctx, src, items, existsMap, stats)
+	if err != nil {
+		return fmt.Errorf("batch check content hashes: %w", err)
+	}
+	switch src.Kind {
+	case entity.SourceKindYouTube, entity.SourceKindPodcast:
+		if err := s.enqueueTranscribeItems(ctx, src, items, existsMap, stats, run); err != nil {
+			return fmt.Errorf("enqueue transcribe items: %w", err)
+		}
+	default:
+		if err := s.processFeedItems(ctx, src, items, existsMap, stats, run); err != nil {
+			return fmt.Errorf("process feed items: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) finishBackfill(stats *BackfillStats, start time.Time) *BackfillStats {
+	stats.Duration = time.Since(start)
+	return stats
+}
+
+// isEndOfArchive reports whether a page fetch failed because the page
+// does not exist — the usual way paginated archives end.
+func isEndOfArchive(err error) bool {
+	status := httpStatusOf(err)
+	return status == http.StatusNotFound || status == http.StatusGone
+}
+
+// sleepContext waits d, or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
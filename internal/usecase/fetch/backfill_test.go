@@ -0,0 +1,149 @@
+package fetch_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	fetchUC "catchup-feed/internal/usecase/fetch"
+)
+
+func day(d int) time.Time {
+	return time.Date(2025, 3, d, 12, 0, 0, 0, time.UTC)
+}
+
+func feedItem(slug string, published time.Time) fetchUC.FeedItem {
+	return fetchUC.FeedItem{Title: slug, URL: "https://example.com/" + slug, Content: "c", PublishedAt: published}
+}
+
+func newBackfillService(feeds map[string][]fetchUC.FeedItem, artRepo *stubArticleRepo) (fetchUC.Service, *orderRecordingFetcher) {
+	srcRepo := &stubSourceRepo{
+		sources: []*entity.Source{{ID: 4, FeedURL: "https://example.com/feed", Kind: entity.SourceKindRSS, Active: true}},
+	}
+	fetcher := &orderRecordingFetcher{feeds: feeds}
+	svc := fetchUC.NewService(
+		srcRepo, artRepo, &stubSummarizer{result: "summary"}, fetcher, nil,
+		fetchUC.ContentFetchConfig{Parallelism: 10, Threshold: 1500},
+	)
+	return svc, fetcher
+}
+
+/* ───────── バックフィル ───────── */
+
+func TestService_BackfillSource_FeedHistoryWithinRange(t *testing.T) {
+	artRepo := &stubArticleRepo{existsMap: map[string]bool{"https://example.com/stored": true}}
+	svc, fetcher := newBackfillService(map[string][]fetchUC.FeedItem{
+		"https://example.com/feed": {
+			feedItem("too-new", day(28)),
+			feedItem("stored", day(15)),
+			feedItem("a", day(14)),
+			feedItem("b", day(10)),
+			feedItem("undated", time.Time{}),
+			feedItem("too-old", day(1)),
+		},
+	}, artRepo)
+
+	stats, err := svc.BackfillSource(context.Background(), 4, fetchUC.BackfillOptions{From: day(5), To: day(20)})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"https://example.com/feed"}, fetcher.order, "without a page URL only the feed is read")
+	assert.Equal(t, 1, stats.Pages)
+	assert.Equal(t, int64(3), stats.OutOfRange)
+	assert.Equal(t, int64(6), stats.FeedItems)
+	assert.Equal(t, int64(2), stats.Inserted)
+	assert.Equal(t, int64(1), stats.Duplicated)
+	require.Len(t, artRepo.articles, 2)
+}
+
+func TestService_BackfillSource_WalksArchivePages(t *testing.T) {
+	pages := map[string][]fetchUC.FeedItem{
+		"https://example.com/feed?paged=1": {feedItem("p1", day(20))},
+		"https://example.com/feed?paged=2": {feedItem("p2", day(12))},
+		"https://example.com/feed?paged=3": {feedItem("p3", day(3)), feedItem("p3b", day(2))},
+		"https://example.com/feed?paged=4": {feedItem("p4", day(1))},
+	}
+
+	t.Run("stops once a page predates from", func(t *testing.T) {
+		svc, fetcher := newBackfillService(pages, &stubArticleRepo{})
+		stats, err := svc.BackfillSource(context.Background(), 4, fetchUC.BackfillOptions{
+			From: day(10), PageURL: "https://example.com/feed?paged={page}",
+		})
+		require.NoError(t, err)
+		assert.Len(t, fetcher.order, 3, "page 4 is never fetched")
+		assert.Equal(t, 3, stats.Pages)
+		assert.Equal(t, int64(2), stats.Inserted)
+	})
+
+	t.Run("a missing page ends the archive", func(t *testing.T) {
+		svc, fetcher := newBackfillService(map[string][]fetchUC.FeedItem{
+			"https://example.com/feed?paged=1": {feedItem("p1", day(20))},
+		}, &stubArticleRepo{})
+		fetcher.errs = map[string]error{
+			"https://example.com/feed?paged=2": &fetchUC.FeedStatusError{StatusCode: 404},
+		}
+		stats, err := svc.BackfillSource(context.Background(), 4, fetchUC.BackfillOptions{
+			PageURL: "https://example.com/feed?paged={page}",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, stats.Pages)
+		assert.Equal(t, int64(1), stats.Inserted)
+	})
+
+	t.Run("a server ignoring the page parameter ends the walk", func(t *testing.T) {
+		same := []fetchUC.FeedItem{feedItem("a", day(20)), feedItem("b", day(19))}
+		svc, fetcher := newBackfillService(map[string][]fetchUC.FeedItem{
+			"https://example.com/feed?paged=1": same,
+			"https://example.com/feed?paged=2": same,
+			"https://example.com/feed?paged=3": same,
+		}, &stubArticleRepo{})
+		stats, err := svc.BackfillSource(context.Background(), 4, fetchUC.BackfillOptions{
+			PageURL: "https://example.com/feed?paged={page}",
+		})
+		require.NoError(t, err)
+		assert.Len(t, fetcher.order, 2)
+		assert.Equal(t, int64(2), stats.Inserted)
+	})
+
+	t.Run("max pages", func(t *testing.T) {
+		svc, fetcher := newBackfillService(pages, &stubArticleRepo{})
+		_, err := svc.BackfillSource(context.Background(), 4, fetchUC.BackfillOptions{
+			PageURL: "https://example.com/feed?paged={page}", MaxPages: 2,
+		})
+		require.NoError(t, err)
+		assert.Len(t, fetcher.order, 2)
+	})
+}
+
+func TestService_BackfillSource_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		sourceID int64
+		opts     fetchUC.BackfillOptions
+		artRepo  *stubArticleRepo
+		wantErr  string
+	}{
+		{name: "unknown source", sourceID: 99, wantErr: "source not found"},
+		{name: "from after to", sourceID: 4, opts: fetchUC.BackfillOptions{From: day(10), To: day(1)}, wantErr: "from must be before to"},
+		{name: "page URL without placeholder", sourceID: 4, opts: fetchUC.BackfillOptions{PageURL: "https://example.com/feed"}, wantErr: "{page}"},
+		{name: "first page fails", sourceID: 4, opts: fetchUC.BackfillOptions{PageURL: "https://example.com/missing?p={page}"}, wantErr: "fetch page 1"},
+		{name: "database error", sourceID: 4, artRepo: &stubArticleRepo{existsErr: errors.New("db down")}, wantErr: "db down"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artRepo := tt.artRepo
+			if artRepo == nil {
+				artRepo = &stubArticleRepo{}
+			}
+			svc, _ := newBackfillService(map[string][]fetchUC.FeedItem{
+				"https://example.com/feed": {feedItem("a", day(10))},
+			}, artRepo)
+			_, err := svc.BackfillSource(context.Background(), tt.sourceID, tt.opts)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
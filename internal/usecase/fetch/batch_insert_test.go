@@ -0,0 +1,67 @@
+package fetch_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	fetchUC "catchup-feed/internal/usecase/fetch"
+)
+
+func newBatchService(articles *stubArticleRepo, items []fetchUC.FeedItem) fetchUC.Service {
+	srcRepo := &stubSourceRepo{
+		sources: []*entity.Source{{ID: 1, FeedURL: "https://example.com/feed", Kind: entity.SourceKindRSS, Active: true}},
+	}
+	return fetchUC.NewService(
+		srcRepo, articles, &stubSummarizer{}, &stubFeedFetcher{items: items}, nil,
+		fetchUC.ContentFetchConfig{Parallelism: 10, Threshold: 1500},
+	)
+}
+
+// The new articles of a source are stored with one CreateBatch, each with
+// its summary.
+func TestService_Crawl_StoresSourceArticlesInOneBatch(t *testing.T) {
+	now := time.Now()
+	articles := &stubArticleRepo{}
+	svc := newBatchService(articles, []fetchUC.FeedItem{
+		{Title: "A", URL: "https://example.com/a", Content: "a", PublishedAt: now},
+		{Title: "B", URL: "https://example.com/b", Content: "b", PublishedAt: now},
+		{Title: "C", URL: "https://example.com/c", Content: "c", PublishedAt: now},
+	})
+
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, articles.batchCalls)
+	assert.Equal(t, int64(3), stats.Inserted)
+	require.Len(t, articles.articles, 3)
+	for _, a := range articles.articles {
+		sum := articles.summaries[a.ID]
+		require.NotNil(t, sum, "summary stored with article %d", a.ID)
+		assert.Equal(t, a.ID, sum.ArticleID)
+	}
+}
+
+// An article the batch skips (stored concurrently by another crawl) is
+// counted as a hash duplicate and announces no article.created.
+func TestService_Crawl_BatchConflictCountsAsDuplicate(t *testing.T) {
+	now := time.Now()
+	articles := &stubArticleRepo{batchConflicts: map[string]bool{"https://example.com/b": true}}
+	pub := &stubEventPublisher{}
+	svc := newBatchService(articles, []fetchUC.FeedItem{
+		{Title: "A", URL: "https://example.com/a", Content: "a", PublishedAt: now},
+		{Title: "B", URL: "https://example.com/b", Content: "b", PublishedAt: now},
+	})
+	svc.Events = pub
+
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), stats.Inserted)
+	assert.Equal(t, int64(1), stats.DuplicatedByHash)
+	assert.Equal(t, []string{entity.WebhookEventArticleCreated, entity.WebhookEventCrawlCompleted}, pub.events)
+}
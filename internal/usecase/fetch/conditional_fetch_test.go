@@ -0,0 +1,121 @@
+package fetch_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	fetchUC "catchup-feed/internal/usecase/fetch"
+)
+
+// conditionalFetcher は ConditionalFeedFetcher のモック。受け取った検証子を記録し、
+// notModified なら 304 を、そうでなければ items と next を返す。
+type conditionalFetcher struct {
+	items       []fetchUC.FeedItem
+	next        fetchUC.FeedValidators
+	notModified bool
+
+	got        []fetchUC.FeedValidators
+	plainCalls int
+}
+
+func (f *conditionalFetcher) Fetch(_ context.Context, _ string) ([]fetchUC.FeedItem, error) {
+	f.plainCalls++
+	return f.items, nil
+}
+
+func (f *conditionalFetcher) FetchConditional(_ context.Context, _ string, prev fetchUC.FeedValidators) (*fetchUC.FeedResponse, error) {
+	f.got = append(f.got, prev)
+	if f.notModified {
+		return &fetchUC.FeedResponse{NotModified: true, Validators: prev}, nil
+	}
+	return &fetchUC.FeedResponse{Items: f.items, Validators: f.next}, nil
+}
+
+func newConditionalService(src *entity.Source, fetcher fetchUC.FeedFetcher, summarizer fetchUC.Summarizer) (fetchUC.Service, *stubSourceRepo, *stubArticleRepo) {
+	srcRepo := &stubSourceRepo{sources: []*entity.Source{src}}
+	artRepo := &stubArticleRepo{}
+	svc := fetchUC.NewService(
+		srcRepo, artRepo, summarizer, fetcher, nil,
+		fetchUC.ContentFetchConfig{Parallelism: 10, Threshold: 1500},
+	)
+	return svc, srcRepo, artRepo
+}
+
+/* ───────── 条件付き GET ───────── */
+
+func TestService_CrawlAllSources_NotModifiedSkipsSource(t *testing.T) {
+	src := &entity.Source{ID: 1, FeedURL: "https://example.com/feed", Kind: entity.SourceKindRSS, Active: true,
+		ETag: `"v1"`, LastModified: "Mon, 01 Jan 2024 00:00:00 GMT"}
+	fetcher := &conditionalFetcher{notModified: true}
+	svc, srcRepo, artRepo := newConditionalService(src, fetcher, &stubSummarizer{})
+	health := &stubHealthRepo{}
+	svc.HealthRepo = health
+
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []fetchUC.FeedValidators{{ETag: `"v1"`, LastModified: "Mon, 01 Jan 2024 00:00:00 GMT"}}, fetcher.got,
+		"stored validators are sent back")
+	assert.Zero(t, fetcher.plainCalls)
+	assert.Equal(t, int64(1), stats.NotModified)
+	assert.Zero(t, stats.FeedItems)
+	assert.Empty(t, artRepo.articles)
+	require.Len(t, stats.PerSource, 1)
+	assert.True(t, stats.PerSource[0].NotModified)
+	assert.Equal(t, 304, stats.PerSource[0].HTTPStatus)
+	assert.Zero(t, stats.FetchFailedSources(), "304 is not a fetch failure")
+	assert.Equal(t, []healthRecord{{SourceID: 1, HTTPStatus: 304}}, health.records)
+	assert.Empty(t, srcRepo.validators, "unchanged validators are not rewritten")
+}
+
+func TestService_CrawlAllSources_StoresNewValidators(t *testing.T) {
+	src := &entity.Source{ID: 1, FeedURL: "https://example.com/feed", Kind: entity.SourceKindRSS, Active: true}
+	fetcher := &conditionalFetcher{
+		items: []fetchUC.FeedItem{{Title: "A", URL: "https://example.com/a", Content: "c", PublishedAt: time.Now()}},
+		next:  fetchUC.FeedValidators{ETag: `"v2"`, LastModified: "Tue, 02 Jan 2024 00:00:00 GMT"},
+	}
+	svc, srcRepo, artRepo := newConditionalService(src, fetcher, &stubSummarizer{result: "summary"})
+
+	_, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []fetchUC.FeedValidators{{}}, fetcher.got, "first crawl is unconditional")
+	assert.Len(t, artRepo.articles, 1)
+	assert.Equal(t, map[int64]fetchUC.FeedValidators{1: fetcher.next}, srcRepo.validators)
+}
+
+func TestService_CrawlAllSources_SummarizeErrorClearsValidators(t *testing.T) {
+	src := &entity.Source{ID: 1, FeedURL: "https://example.com/feed", Kind: entity.SourceKindRSS, Active: true, ETag: `"v1"`}
+	fetcher := &conditionalFetcher{
+		items: []fetchUC.FeedItem{{Title: "A", URL: "https://example.com/a", Content: "c", PublishedAt: time.Now()}},
+		next:  fetchUC.FeedValidators{ETag: `"v2"`},
+	}
+	svc, srcRepo, _ := newConditionalService(src, fetcher, &stubSummarizer{err: errors.New("llm down")})
+
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), stats.SummarizeError)
+
+	// 次回は無条件 GET にして、要約に失敗した記事を拾い直す
+	assert.Equal(t, map[int64]fetchUC.FeedValidators{1: {}}, srcRepo.validators)
+}
+
+func TestService_CrawlAllSources_PlainFetcherKeepsValidators(t *testing.T) {
+	src := &entity.Source{ID: 1, FeedURL: "https://example.com/ok", Kind: entity.SourceKindRSS, Active: true}
+	fetcher := &orderRecordingFetcher{
+		feeds: map[string][]fetchUC.FeedItem{
+			"https://example.com/ok": {{Title: "A", URL: "https://example.com/a", Content: "c", PublishedAt: time.Now()}},
+		},
+	}
+	svc, srcRepo, _ := newConditionalService(src, fetcher, &stubSummarizer{result: "summary"})
+
+	_, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, srcRepo.validators)
+}
@@ -55,6 +55,24 @@ type ContentFetcher interface {
 	FetchContent(ctx context.Context, url string) (string, error)
 }
 
+// FetchedPage is a fetched article page: the final URL after redirects, the
+// raw HTML and the text extracted from it. ImageURL is the thumbnail the
+// page declares (og:image / twitter:image) as an absolute http(s) URL, or
+// empty.
+type FetchedPage struct {
+	URL      string
+	HTML     string
+	Text     string
+	ImageURL string
+}
+
+// PageFetcher is optionally implemented by ContentFetchers that can return
+// the raw page along with the extracted text. The crawl uses it to store
+// the full page in article_contents; other fetchers only enhance content.
+type PageFetcher interface {
+	FetchPage(ctx context.Context, url string) (*FetchedPage, error)
+}
+
 // Sentinel errors for content fetching operations.
 // These errors allow callers to distinguish between different failure modes
 // and implement appropriate fallback strategies.
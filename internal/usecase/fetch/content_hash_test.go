@@ -0,0 +1,49 @@
+package fetch_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	fetchUC "catchup-feed/internal/usecase/fetch"
+)
+
+func TestService_CrawlAllSources_SkipsContentHashDuplicates(t *testing.T) {
+	now := time.Now()
+	known := fetchUC.FeedItem{Title: "Known", URL: "https://example.com/known?utm_source=rss", Content: "body", PublishedAt: now}
+	fresh := fetchUC.FeedItem{Title: "Fresh", URL: "https://example.com/fresh", Content: "new body", PublishedAt: now}
+	// 同じフィード内で追跡パラメータ違いの同一記事
+	freshAgain := fetchUC.FeedItem{Title: "Fresh", URL: "https://example.com/fresh?fbclid=abc", Content: "new body", PublishedAt: now}
+
+	srcRepo := &stubSourceRepo{sources: []*entity.Source{
+		{ID: 1, FeedURL: "https://example.com/feed", Kind: entity.SourceKindRSS, Active: true},
+	}}
+	fetcher := &orderRecordingFetcher{feeds: map[string][]fetchUC.FeedItem{
+		"https://example.com/feed": {known, fresh, freshAgain},
+	}}
+	artRepo := &stubArticleRepo{hashExists: map[string]bool{
+		entity.ArticleContentHash("https://example.com/known", "Known", "body"): true,
+	}}
+	svc := fetchUC.NewService(
+		srcRepo, artRepo, &stubSummarizer{}, fetcher, nil,
+		fetchUC.ContentFetchConfig{Parallelism: 10, Threshold: 1500},
+	)
+
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3), stats.FeedItems)
+	assert.Equal(t, int64(1), stats.Inserted)
+	assert.Equal(t, int64(0), stats.Duplicated)
+	assert.Equal(t, int64(2), stats.DuplicatedByHash)
+	require.Len(t, stats.PerSource, 1)
+	assert.Equal(t, int64(2), stats.PerSource[0].DuplicatedByHash)
+
+	require.Len(t, artRepo.articles, 1)
+	assert.Equal(t, "https://example.com/fresh", artRepo.articles[0].URL)
+	assert.Equal(t, entity.ArticleContentHash(fresh.URL, fresh.Title, fresh.Content), artRepo.articles[0].ContentHash)
+}
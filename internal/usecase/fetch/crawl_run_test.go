@@ -0,0 +1,125 @@
+package fetch_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	fetchUC "catchup-feed/internal/usecase/fetch"
+)
+
+// stubCrawlRunRepo は Start / Finish を記録する。
+type stubCrawlRunRepo struct {
+	started  []entity.CrawlRun
+	finished []entity.CrawlRun
+	startErr error
+}
+
+func (s *stubCrawlRunRepo) Start(_ context.Context, run *entity.CrawlRun) error {
+	if s.startErr != nil {
+		return s.startErr
+	}
+	run.ID = int64(len(s.started) + 1)
+	s.started = append(s.started, *run)
+	return nil
+}
+
+func (s *stubCrawlRunRepo) Finish(_ context.Context, run *entity.CrawlRun) error {
+	s.finished = append(s.finished, *run)
+	return nil
+}
+
+func (s *stubCrawlRunRepo) Get(_ context.Context, _ int64) (*entity.CrawlRun, error) {
+	return nil, nil
+}
+
+func (s *stubCrawlRunRepo) List(_ context.Context, _, _ int) ([]*entity.CrawlRun, error) {
+	return nil, nil
+}
+
+func (s *stubCrawlRunRepo) Count(_ context.Context) (int64, error) {
+	return 0, nil
+}
+
+func newRunRecordingService(artRepo *stubArticleRepo) (fetchUC.Service, *stubCrawlRunRepo) {
+	srcRepo := &stubSourceRepo{
+		sources: []*entity.Source{
+			{ID: 1, FeedURL: "https://example.com/ok", Kind: entity.SourceKindRSS, Active: true},
+			{ID: 2, FeedURL: "https://example.com/broken", Kind: entity.SourceKindRSS, Active: true},
+		},
+	}
+	fetcher := &orderRecordingFetcher{
+		feeds: map[string][]fetchUC.FeedItem{
+			"https://example.com/ok": {
+				{Title: "A", URL: "https://example.com/a", Content: "c", PublishedAt: time.Now()},
+				{Title: "B", URL: "https://example.com/b", Content: "c", PublishedAt: time.Now()},
+			},
+		},
+	}
+	svc := fetchUC.NewService(
+		srcRepo, artRepo, &stubSummarizer{result: "summary"}, fetcher, nil,
+		fetchUC.ContentFetchConfig{Parallelism: 10, Threshold: 1500},
+	)
+	runs := &stubCrawlRunRepo{}
+	svc.RunRepo = runs
+	return svc, runs
+}
+
+/* ───────── クロール履歴 ───────── */
+
+func TestService_CrawlAllSources_RecordsCrawlRun(t *testing.T) {
+	svc, runs := newRunRecordingService(&stubArticleRepo{})
+
+	_, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, runs.started, 1)
+	assert.Equal(t, entity.CrawlRunStatusRunning, runs.started[0].Status)
+	assert.Equal(t, 2, runs.started[0].SourceCount)
+
+	require.Len(t, runs.finished, 1)
+	run := runs.finished[0]
+	assert.Equal(t, int64(1), run.ID)
+	assert.Equal(t, entity.CrawlRunStatusSucceeded, run.Status)
+	require.NotNil(t, run.FinishedAt)
+	assert.False(t, run.FinishedAt.Before(run.StartedAt))
+	assert.Nil(t, run.Error)
+	assert.Equal(t, 1, run.FetchFailedSources)
+	assert.Equal(t, int64(2), run.FeedItems)
+	assert.Equal(t, int64(2), run.Inserted)
+
+	require.Len(t, run.Sources, 2)
+	assert.Equal(t, int64(1), run.Sources[0].SourceID)
+	assert.Equal(t, 200, run.Sources[0].HTTPStatus)
+	assert.Equal(t, int64(2), run.Sources[0].Inserted)
+	assert.Equal(t, int64(2), run.Sources[1].SourceID)
+	assert.Equal(t, "unknown feed URL", run.Sources[1].FetchError)
+}
+
+func TestService_CrawlAllSources_RecordsFailedCrawlRun(t *testing.T) {
+	svc, runs := newRunRecordingService(&stubArticleRepo{createErr: errors.New("db down")})
+
+	_, err := svc.CrawlAllSources(context.Background())
+	require.Error(t, err)
+
+	require.Len(t, runs.finished, 1)
+	run := runs.finished[0]
+	assert.Equal(t, entity.CrawlRunStatusFailed, run.Status)
+	require.NotNil(t, run.Error)
+	assert.Contains(t, *run.Error, "db down")
+}
+
+func TestService_CrawlAllSources_RunRecordStartFailureDoesNotAbort(t *testing.T) {
+	svc, runs := newRunRecordingService(&stubArticleRepo{})
+	runs.startErr = errors.New("db down")
+
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err, "recording is best-effort")
+	assert.Equal(t, int64(2), stats.Inserted)
+	assert.Empty(t, runs.finished)
+}
@@ -0,0 +1,151 @@
+package fetch_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+	fetchUC "catchup-feed/internal/usecase/fetch"
+)
+
+// newScheduleTestService は個別スケジュールあり/なしのソースを混在させる。
+func newScheduleTestService() (*fetchUC.Service, *stubSourceRepo, *orderRecordingFetcher) {
+	now := time.Now()
+	halfHourly := "*/30 * * * *"
+	srcRepo := &stubSourceRepo{
+		sources: []*entity.Source{
+			{ID: 1, FeedURL: "https://example.com/rss1", Kind: entity.SourceKindRSS, Active: true},
+			{ID: 2, FeedURL: "https://example.com/rss2", Kind: entity.SourceKindRSS, Active: true, CrawlSchedule: &halfHourly},
+		},
+	}
+	fetcher := &orderRecordingFetcher{
+		feeds: map[string][]fetchUC.FeedItem{
+			"https://example.com/rss1": {{Title: "R1", URL: "https://example.com/r1", Content: "c1", PublishedAt: now}},
+			"https://example.com/rss2": {{Title: "R2", URL: "https://example.com/r2", Content: "c2", PublishedAt: now}},
+			"https://example.com/rss3": {{Title: "R3", URL: "https://example.com/r3", Content: "c3", PublishedAt: now}},
+			"https://example.com/rss4": {{Title: "R4", URL: "https://example.com/r4", Content: "c4", PublishedAt: now}},
+		},
+	}
+	svc := fetchUC.NewService(
+		srcRepo, &stubArticleRepo{}, &stubSummarizer{}, fetcher, nil,
+		fetchUC.ContentFetchConfig{Parallelism: 10, Threshold: 1500},
+	)
+	return &svc, srcRepo, fetcher
+}
+
+func TestService_CrawlDefaultScheduleSources_SkipsOverrides(t *testing.T) {
+	svc, _, fetcher := newScheduleTestService()
+
+	stats, err := svc.CrawlDefaultScheduleSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/rss1"}, fetcher.order,
+		"sources with their own crawl_schedule are left to their own cron entry")
+	assert.Equal(t, 1, stats.Sources)
+}
+
+type stubCrawlControl struct {
+	repository.CrawlControlRepository
+	control *entity.CrawlControl
+	err     error
+}
+
+func (s *stubCrawlControl) Get(context.Context) (*entity.CrawlControl, error) {
+	return s.control, s.err
+}
+
+func TestService_CrawlDefaultScheduleSources_SkipsControlledSources(t *testing.T) {
+	svc, srcRepo, fetcher := newScheduleTestService()
+	srcRepo.sources = append(srcRepo.sources,
+		&entity.Source{ID: 3, FeedURL: "https://example.com/rss3", Kind: entity.SourceKindRSS, Active: true})
+	svc.Control = &stubCrawlControl{control: &entity.CrawlControl{SkippedSources: []int64{1}}}
+
+	_, err := svc.CrawlDefaultScheduleSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/rss3"}, fetcher.order, "skipped sources are left out")
+
+	// 制御を読めなければ何も外さない
+	fetcher.order = nil
+	svc.Control = &stubCrawlControl{err: errors.New("db down")}
+	_, err = svc.CrawlDefaultScheduleSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/rss1", "https://example.com/rss3"}, fetcher.order)
+}
+
+type stubSourceGroups struct {
+	repository.SourceGroupRepository
+	groups []*entity.SourceGroup
+	err    error
+}
+
+func (s *stubSourceGroups) List(context.Context) ([]*entity.SourceGroup, error) {
+	return s.groups, s.err
+}
+
+func TestService_CrawlDefaultScheduleSources_AppliesGroupSettings(t *testing.T) {
+	svc, srcRepo, fetcher := newScheduleTestService()
+	twoHourly, seasonal := int64(1), int64(2)
+	groupSchedule := "2h"
+	srcRepo.sources = append(srcRepo.sources,
+		&entity.Source{ID: 3, FeedURL: "https://example.com/rss3", Kind: entity.SourceKindRSS, Active: true, GroupID: &twoHourly},
+		&entity.Source{ID: 4, FeedURL: "https://example.com/rss4", Kind: entity.SourceKindRSS, Active: true, GroupID: &seasonal})
+	srcRepo.sources[0].GroupID = &seasonal
+	svc.Groups = &stubSourceGroups{groups: []*entity.SourceGroup{
+		{ID: twoHourly, Name: "Go", CrawlSchedule: &groupSchedule},
+		{ID: seasonal, Name: "Seasonal"},
+	}}
+
+	_, err := svc.CrawlDefaultScheduleSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/rss1", "https://example.com/rss4"}, fetcher.order,
+		"members on the group schedule are left to their own cron entry")
+
+	fetcher.order = nil
+	svc.Groups = &stubSourceGroups{groups: []*entity.SourceGroup{{ID: seasonal, Name: "Seasonal", CrawlPaused: true}}}
+	_, err = svc.CrawlDefaultScheduleSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/rss3"}, fetcher.order, "paused groups are left out")
+
+	// グループを読めなければグループ設定を無視する
+	fetcher.order = nil
+	svc.Groups = &stubSourceGroups{err: errors.New("db down")}
+	_, err = svc.CrawlDefaultScheduleSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/rss1", "https://example.com/rss3", "https://example.com/rss4"}, fetcher.order)
+}
+
+func TestService_CrawlAllSources_IncludesOverrides(t *testing.T) {
+	svc, _, fetcher := newScheduleTestService()
+
+	_, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/rss1", "https://example.com/rss2"}, fetcher.order)
+}
+
+func TestService_CrawlSource(t *testing.T) {
+	t.Run("crawls only the given source", func(t *testing.T) {
+		svc, _, fetcher := newScheduleTestService()
+		stats, err := svc.CrawlSource(context.Background(), 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"https://example.com/rss2"}, fetcher.order)
+		assert.Equal(t, 1, stats.Sources)
+		assert.Equal(t, int64(1), stats.Inserted)
+	})
+
+	t.Run("inactive or deleted source is a no-op", func(t *testing.T) {
+		svc, srcRepo, fetcher := newScheduleTestService()
+		srcRepo.sources = append(srcRepo.sources,
+			&entity.Source{ID: 3, FeedURL: "https://example.com/rss3", Kind: entity.SourceKindRSS, Active: false})
+		for _, id := range []int64{3, 99} {
+			stats, err := svc.CrawlSource(context.Background(), id)
+			require.NoError(t, err)
+			assert.Equal(t, 0, stats.Sources)
+		}
+		assert.Empty(t, fetcher.order)
+	})
+}
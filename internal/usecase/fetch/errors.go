@@ -3,7 +3,10 @@
 // and storing articles in the repository.
 package fetch
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Sentinel errors for fetch use case operations.
 var (
@@ -19,3 +22,16 @@ var (
 	// This can occur due to API errors, rate limits, or invalid content.
 	ErrSummarizationFailed = errors.New("failed to summarize article content")
 )
+
+// FeedStatusError is returned by FeedFetcher implementations when the feed
+// server answered with a non-2xx HTTP status. The crawl records the status
+// per source (SourceStats.HTTPStatus) so a feed that started returning
+// 403/404/5xx stands out from plain network failures.
+type FeedStatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *FeedStatusError) Error() string {
+	return fmt.Sprintf("feed fetch: unexpected HTTP status %d", e.StatusCode)
+}
@@ -0,0 +1,98 @@
+package fetch_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	fetchUC "catchup-feed/internal/usecase/fetch"
+)
+
+func newHeadlessService(renderJS bool, plain, headless fetchUC.ContentFetcher) (fetchUC.Service, *stubArticleRepo) {
+	artRepo := &stubArticleRepo{}
+	svc := fetchUC.NewService(
+		&stubSourceRepo{sources: []*entity.Source{
+			{ID: 1, FeedURL: "https://example.com/feed", Kind: entity.SourceKindRSS, Active: true, RenderJS: renderJS},
+		}},
+		artRepo,
+		&stubSummarizer{result: "summary"},
+		&stubFeedFetcher{items: []fetchUC.FeedItem{
+			{Title: "Article", URL: "https://example.com/a", Content: "short", PublishedAt: time.Now()},
+		}},
+		plain,
+		fetchUC.ContentFetchConfig{Parallelism: 10, Threshold: 1500},
+	)
+	svc.HeadlessFetcher = headless
+	return svc, artRepo
+}
+
+/* ───────── ヘッドレスブラウザ取得(render_js) ───────── */
+
+func TestService_RenderJSSourceUsesHeadlessFetcher(t *testing.T) {
+	plain := &mockContentFetcher{content: strings.Repeat("plain ", 100)}
+	headless := &mockContentFetcher{content: strings.Repeat("rendered ", 100)}
+	svc, artRepo := newHeadlessService(true, plain, headless)
+
+	_, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+
+	assert.True(t, headless.called)
+	assert.False(t, plain.called)
+	require.Len(t, artRepo.articles, 1)
+	assert.Equal(t, headless.content, artRepo.articles[0].Content)
+}
+
+func TestService_PlainSourceIgnoresHeadlessFetcher(t *testing.T) {
+	plain := &mockContentFetcher{content: strings.Repeat("plain ", 100)}
+	headless := &mockContentFetcher{content: strings.Repeat("rendered ", 100)}
+	svc, artRepo := newHeadlessService(false, plain, headless)
+
+	_, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+
+	assert.False(t, headless.called)
+	require.Len(t, artRepo.articles, 1)
+	assert.Equal(t, plain.content, artRepo.articles[0].Content)
+}
+
+func TestService_RenderJSWithoutHeadlessFetcherUsesContentFetcher(t *testing.T) {
+	plain := &mockContentFetcher{content: strings.Repeat("plain ", 100)}
+	svc, artRepo := newHeadlessService(true, plain, nil)
+
+	_, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, artRepo.articles, 1)
+	assert.Equal(t, plain.content, artRepo.articles[0].Content)
+}
+
+func TestService_RenderJSEnhancesEvenWithoutContentFetcher(t *testing.T) {
+	headless := &mockContentFetcher{content: strings.Repeat("rendered ", 100)}
+	svc, artRepo := newHeadlessService(true, nil, headless)
+
+	_, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, artRepo.articles, 1)
+	assert.Equal(t, headless.content, artRepo.articles[0].Content)
+}
+
+func TestService_HeadlessFailureFallsBackToRSS(t *testing.T) {
+	plain := &mockContentFetcher{content: strings.Repeat("plain ", 100)}
+	headless := &mockContentFetcher{err: errors.New("render timed out")}
+	svc, artRepo := newHeadlessService(true, plain, headless)
+
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), stats.Inserted)
+
+	require.Len(t, artRepo.articles, 1)
+	assert.Equal(t, "short", artRepo.articles[0].Content)
+	assert.False(t, plain.called, "a failed render is not retried with a plain GET")
+}
@@ -0,0 +1,129 @@
+package fetch_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+	fetchUC "catchup-feed/internal/usecase/fetch"
+)
+
+type stubSimilarityRepo struct {
+	canonical int64
+	err       error
+
+	calls       int
+	simHash     uint64
+	maxDistance int
+	since       time.Time
+	excludeSrc  int64
+}
+
+func (s *stubSimilarityRepo) FindNearDuplicate(_ context.Context, simHash uint64, maxDistance int, since time.Time, excludeSourceID int64) (int64, error) {
+	s.calls++
+	s.simHash, s.maxDistance, s.since, s.excludeSrc = simHash, maxDistance, since, excludeSourceID
+	return s.canonical, s.err
+}
+
+func (s *stubSimilarityRepo) ListGroup(context.Context, int64) ([]repository.ArticleWithSource, error) {
+	return nil, nil
+}
+
+func newNearDuplicateService(content string, similar *stubSimilarityRepo, maxDistance int) (fetchUC.Service, *stubArticleRepo) {
+	artRepo := &stubArticleRepo{}
+	svc := fetchUC.NewService(
+		&stubSourceRepo{sources: []*entity.Source{
+			{ID: 3, FeedURL: "https://example.com/feed", Kind: entity.SourceKindRSS, Active: true},
+		}},
+		artRepo,
+		&stubSummarizer{result: "summary"},
+		&stubFeedFetcher{items: []fetchUC.FeedItem{
+			{Title: "Article", URL: "https://example.com/a", Content: content, PublishedAt: time.Now()},
+		}},
+		nil,
+		fetchUC.ContentFetchConfig{Parallelism: 10, Threshold: 1500},
+	)
+	svc.NearDuplicates = similar
+	svc.NearDuplicateMaxDistance = maxDistance
+	return svc, artRepo
+}
+
+/* ───────── SimHash 近似重複 ───────── */
+
+var nearDuplicateContent = strings.Repeat("the same wire story republished by many outlets ", 5)
+
+func TestService_MarksNearDuplicate(t *testing.T) {
+	similar := &stubSimilarityRepo{canonical: 42}
+	svc, artRepo := newNearDuplicateService(nearDuplicateContent, similar, 8)
+
+	start := time.Now()
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, artRepo.articles, 1)
+	art := artRepo.articles[0]
+	assert.Equal(t, entity.ArticleSimHash("Article", nearDuplicateContent), art.SimHash)
+	require.NotNil(t, art.DuplicateOf)
+	assert.Equal(t, int64(42), *art.DuplicateOf)
+	assert.Equal(t, int64(1), stats.NearDuplicates)
+	assert.Equal(t, int64(1), stats.Inserted, "a near-duplicate is still stored")
+
+	assert.Equal(t, art.SimHash, similar.simHash)
+	assert.Equal(t, 8, similar.maxDistance)
+	assert.Equal(t, int64(3), similar.excludeSrc, "only other sources are compared")
+	assert.WithinDuration(t, start.Add(-fetchUC.NearDuplicateWindow), similar.since, time.Minute)
+}
+
+func TestService_NearDuplicateNoMatch(t *testing.T) {
+	svc, artRepo := newNearDuplicateService(nearDuplicateContent, &stubSimilarityRepo{}, 8)
+
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, artRepo.articles, 1)
+	assert.NotZero(t, artRepo.articles[0].SimHash)
+	assert.Nil(t, artRepo.articles[0].DuplicateOf)
+	assert.Zero(t, stats.NearDuplicates)
+}
+
+func TestService_NearDuplicateLookupFailureStoresStandalone(t *testing.T) {
+	svc, artRepo := newNearDuplicateService(nearDuplicateContent, &stubSimilarityRepo{err: errors.New("boom")}, 8)
+
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, artRepo.articles, 1)
+	assert.Nil(t, artRepo.articles[0].DuplicateOf)
+	assert.Equal(t, int64(1), stats.Inserted)
+}
+
+func TestService_NearDuplicateSkipped(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		maxDistance int
+	}{
+		{"detection disabled", nearDuplicateContent, 0},
+		{"too short to fingerprint", "short", 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			similar := &stubSimilarityRepo{canonical: 42}
+			svc, artRepo := newNearDuplicateService(tt.content, similar, tt.maxDistance)
+
+			_, err := svc.CrawlAllSources(context.Background())
+			require.NoError(t, err)
+
+			assert.Zero(t, similar.calls)
+			require.Len(t, artRepo.articles, 1)
+			assert.Nil(t, artRepo.articles[0].DuplicateOf)
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package fetch
+
+import (
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	pkgconfig "catchup-feed/pkg/config"
+)
+
+// Quality filter actions (QUALITY_FILTER_ACTION).
+const (
+	// QualityActionDrop skips a junk item: it is not stored, so the next
+	// crawl sees it again (one more content fetch, never a summarization).
+	QualityActionDrop = "drop"
+	// QualityActionMark stores a junk item without summarizing it: the
+	// summaries row holds a note naming the reason (provider
+	// entity.SummaryProviderFiltered), so the article is visible, fetched
+	// once, left alone by SweepUnsummarized, and can still be summarized
+	// on demand (POST /articles/{id}/summarize).
+	QualityActionMark = "mark"
+)
+
+// Reasons a QualityFilter rejects an item.
+const (
+	QualityReasonTooShort       = "too_short"
+	QualityReasonBlockedDomain  = "blocked_domain"
+	QualityReasonBlockedKeyword = "blocked_keyword"
+	QualityReasonAd             = "ad"
+)
+
+// qualityReasonLabels are the reasons as written into the note of a
+// marked article.
+var qualityReasonLabels = map[string]string{
+	QualityReasonTooShort:       "本文が短すぎる",
+	QualityReasonBlockedDomain:  "ブロック対象のドメイン",
+	QualityReasonBlockedKeyword: "ブロック対象のキーワード",
+	QualityReasonAd:             "広告・PR 記事の可能性",
+}
+
+// QualityFilter is the crawl's junk-item stage: it runs on every new
+// RSS / scraped item after content enhancement and before summarization,
+// so spam, ads and stub pages never cost an LLM request. youtube / podcast
+// items have no content at that point and are not filtered. The zero value
+// rejects nothing.
+type QualityFilter struct {
+	// MinContentLength is the minimum length, in characters of text with
+	// HTML tags removed, of the content that would be summarized; 0 = no
+	// minimum.
+	MinContentLength int
+	// BlockedDomains rejects items whose URL host is one of these domains
+	// or a subdomain of one (lower-case, no scheme).
+	BlockedDomains []string
+	// BlockedKeywords rejects items whose title or content contains one of
+	// these (case-insensitive).
+	BlockedKeywords []string
+	// AdDetection enables the ad heuristics: a sponsored / PR marker in the
+	// title, a sponsorship disclosure in the content, or content that is
+	// mostly links.
+	AdDetection bool
+	// Action is QualityActionDrop (default) or QualityActionMark.
+	Action string
+}
+
+// LoadQualityFilterFromEnv reads the quality filter of the crawl
+// processes (worker, crawl-once): QUALITY_MIN_CONTENT_LENGTH,
+// QUALITY_BLOCKED_DOMAINS, QUALITY_BLOCKED_KEYWORDS (comma-separated),
+// QUALITY_AD_DETECTION and QUALITY_FILTER_ACTION. Invalid values fall back
+// to the defaults with a warning. It returns nil (no filtering) when no
+// rule is set.
+func LoadQualityFilterFromEnv(logger *slog.Logger) *QualityFilter {
+	filter := &QualityFilter{
+		MinContentLength: pkgconfig.GetEnvInt("QUALITY_MIN_CONTENT_LENGTH", 0),
+		BlockedDomains:   pkgconfig.GetEnvStringList("QUALITY_BLOCKED_DOMAINS", nil),
+		BlockedKeywords:  pkgconfig.GetEnvStringList("QUALITY_BLOCKED_KEYWORDS", nil),
+		AdDetection:      pkgconfig.GetEnvBool("QUALITY_AD_DETECTION", false),
+		Action:           pkgconfig.GetEnvString("QUALITY_FILTER_ACTION", QualityActionDrop),
+	}
+	if filter.MinContentLength < 0 {
+		logger.Warn("invalid QUALITY_MIN_CONTENT_LENGTH, no minimum",
+			slog.Int("value", filter.MinContentLength))
+		filter.MinContentLength = 0
+	}
+	if filter.Action != QualityActionDrop && filter.Action != QualityActionMark {
+		logger.Warn("invalid QUALITY_FILTER_ACTION, using drop", slog.String("action", filter.Action))
+		filter.Action = QualityActionDrop
+	}
+	if !filter.Enabled() {
+		return nil
+	}
+	logger.Info("quality filter enabled",
+		slog.Int("min_content_length", filter.MinContentLength),
+		slog.Int("blocked_domains", len(filter.BlockedDomains)),
+		slog.Int("blocked_keywords", len(filter.BlockedKeywords)),
+		slog.Bool("ad_detection", filter.AdDetection),
+		slog.String("action", filter.Action))
+	return filter
+}
+
+// Enabled reports whether f has any rule; a nil filter has none.
+func (f *QualityFilter) Enabled() bool {
+	return f != nil && (f.MinContentLength > 0 || len(f.BlockedDomains) > 0 || len(f.BlockedKeywords) > 0 || f.AdDetection)
+}
+
+// Marks reports whether junk items are stored marked instead of dropped.
+func (f *QualityFilter) Marks() bool {
+	return f.Action == QualityActionMark
+}
+
+// Check returns the reason item (with content, the text that would be
+// summarized) is junk, or "" when it passes. Rules are checked cheapest
+// first: domain, keywords, ad heuristics, length.
+func (f *QualityFilter) Check(item FeedItem, content string) string {
+	if !f.Enabled() {
+		return ""
+	}
+	if len(f.BlockedDomains) > 0 && blockedHost(item.URL, f.BlockedDomains) {
+		return QualityReasonBlockedDomain
+	}
+	if len(f.BlockedKeywords) > 0 {
+		title, body := strings.ToLower(item.Title), strings.ToLower(content)
+		for _, kw := range f.BlockedKeywords {
+			kw = strings.ToLower(kw)
+			if strings.Contains(title, kw) || strings.Contains(body, kw) {
+				return QualityReasonBlockedKeyword
+			}
+		}
+	}
+	if f.AdDetection && looksLikeAd(item.Title, content) {
+		return QualityReasonAd
+	}
+	if f.MinContentLength > 0 && utf8.RuneCountInString(strings.TrimSpace(htmlTagPattern.ReplaceAllString(content, ""))) < f.MinContentLength {
+		return QualityReasonTooShort
+	}
+	return ""
+}
+
+// qualityNote is the summary body stored for a marked article.
+func qualityNote(reason string) string {
+	label, ok := qualityReasonLabels[reason]
+	if !ok {
+		label = reason
+	}
+	return fmt.Sprintf("低品質の可能性があるため要約していません(理由: %s)", label)
+}
+
+func blockedHost(rawURL string, domains []string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, d := range domains {
+		d = strings.ToLower(strings.TrimPrefix(d, "."))
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+	linkPattern    = regexp.MustCompile(`https?://\S+|<a\s[^>]*>`)
+	// adTitlePattern matches the sponsored / PR markers Japanese and
+	// English media put in ad titles.
+	adTitlePattern = regexp.MustCompile(`(?i)[\[【(（]\s*(pr|ad|sponsored|広告|提供|タイアップ)\s*[\]】)）]|^(pr|ad|sponsored)\s*[:：]|\bsponsored\b`)
+	// adDisclosures are the sponsorship disclosures of promotional content.
+	adDisclosures = []string{
+		"sponsored content", "sponsored post", "paid partnership",
+		"プロモーションを含みます", "アフィリエイト広告を利用しています", "提供記事", "タイアップ記事",
+	}
+)
+
+// adLinkRatio is the share of the content's characters inside links from
+// which it counts as a link farm rather than an article.
+const adLinkRatio = 0.5
+
+func looksLikeAd(title, content string) bool {
+	if adTitlePattern.MatchString(title) {
+		return true
+	}
+	lower := strings.ToLower(content)
+	for _, d := range adDisclosures {
+		if strings.Contains(lower, d) {
+			return true
+		}
+	}
+	links := linkPattern.FindAllString(content, -1)
+	if len(links) < 5 {
+		return false
+	}
+	linked := 0
+	for _, l := range links {
+		linked += len(l)
+	}
+	return float64(linked) >= adLinkRatio*float64(len(content))
+}
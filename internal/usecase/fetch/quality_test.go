@@ -0,0 +1,146 @@
+package fetch_test
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	fetchUC "catchup-feed/internal/usecase/fetch"
+)
+
+/* ───────── 品質フィルタ ───────── */
+
+// countingSummarizer counts the summarizations, the cost the filter saves.
+type countingSummarizer struct{ calls atomic.Int64 }
+
+func (s *countingSummarizer) Summarize(_ context.Context, _ string) (string, error) {
+	s.calls.Add(1)
+	return "summary", nil
+}
+
+var qualityArticleContent = strings.Repeat("A real article about Go generics and iterators. ", 10)
+
+func newQualityService(filter *fetchUC.QualityFilter) (fetchUC.Service, *stubArticleRepo, *countingSummarizer, *stubEventPublisher) {
+	artRepo := &stubArticleRepo{}
+	sum := &countingSummarizer{}
+	pub := &stubEventPublisher{}
+	svc := fetchUC.NewService(
+		&stubSourceRepo{sources: []*entity.Source{
+			{ID: 1, FeedURL: "https://example.com/feed", Kind: entity.SourceKindRSS, Active: true},
+		}},
+		artRepo,
+		sum,
+		&stubFeedFetcher{items: []fetchUC.FeedItem{
+			{Title: "Go iterators", URL: "https://blog.example.com/go", Content: qualityArticleContent, PublishedAt: time.Now()},
+			{Title: "Stub", URL: "https://blog.example.com/stub", Content: "Read more...", PublishedAt: time.Now()},
+			{Title: "【PR】最新スマホが今だけ半額", URL: "https://blog.example.com/pr", Content: qualityArticleContent, PublishedAt: time.Now()},
+			{Title: "Cheap pills", URL: "https://spam.example.net/pills", Content: qualityArticleContent, PublishedAt: time.Now()},
+		}},
+		nil,
+		fetchUC.ContentFetchConfig{Parallelism: 10, Threshold: 1500},
+	)
+	svc.Events = pub
+	svc.Quality = filter
+	return svc, artRepo, sum, pub
+}
+
+func TestService_QualityFilterDropsJunkBeforeSummarization(t *testing.T) {
+	svc, artRepo, sum, _ := newQualityService(&fetchUC.QualityFilter{
+		MinContentLength: 100,
+		BlockedDomains:   []string{"example.net"},
+		AdDetection:      true,
+	})
+
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, artRepo.articles, 1)
+	assert.Equal(t, "https://blog.example.com/go", artRepo.articles[0].URL)
+	assert.Equal(t, int64(1), sum.calls.Load(), "junk is never summarized")
+	assert.Equal(t, int64(3), stats.FilteredJunk)
+	assert.Equal(t, int64(4), stats.FeedItems)
+	assert.Equal(t, int64(1), stats.Inserted)
+}
+
+func TestService_QualityFilterMarksJunk(t *testing.T) {
+	svc, artRepo, sum, pub := newQualityService(&fetchUC.QualityFilter{
+		BlockedDomains: []string{"example.net"},
+		Action:         fetchUC.QualityActionMark,
+	})
+
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, artRepo.articles, 4, "marked junk is stored")
+	assert.Equal(t, int64(3), sum.calls.Load())
+	assert.Equal(t, int64(1), stats.FilteredJunk)
+	assert.Equal(t, int64(4), stats.Inserted)
+
+	var marked *entity.Summary
+	for _, a := range artRepo.articles {
+		if a.URL == "https://spam.example.net/pills" {
+			marked = artRepo.summaries[a.ID]
+		}
+	}
+	require.NotNil(t, marked)
+	assert.Equal(t, entity.SummaryProviderFiltered, marked.Provider)
+	assert.Contains(t, marked.Body, "ブロック対象のドメイン")
+
+	created := 0
+	for _, e := range pub.events {
+		if e == entity.WebhookEventArticleCreated {
+			created++
+		}
+	}
+	assert.Equal(t, 3, created, "marked junk publishes no article.created")
+}
+
+func TestService_QualityFilterDisabled(t *testing.T) {
+	svc, artRepo, sum, _ := newQualityService(&fetchUC.QualityFilter{})
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, artRepo.articles, 4)
+	assert.Equal(t, int64(4), sum.calls.Load())
+	assert.Zero(t, stats.FilteredJunk)
+}
+
+func TestQualityFilter_Check(t *testing.T) {
+	filter := &fetchUC.QualityFilter{
+		MinContentLength: 20,
+		BlockedDomains:   []string{"spam.example"},
+		BlockedKeywords:  []string{"Casino"},
+		AdDetection:      true,
+	}
+	longText := strings.Repeat("本文です。", 10)
+	links := strings.Repeat(`<a href="https://shop.example/item">https://shop.example/item</a> `, 6)
+
+	tests := []struct {
+		name string
+		item fetchUC.FeedItem
+		text string
+		want string
+	}{
+		{"passes", fetchUC.FeedItem{Title: "Go 1.30", URL: "https://go.dev/blog"}, longText, ""},
+		{"subdomain of a blocked domain", fetchUC.FeedItem{Title: "x", URL: "https://www.spam.example/a"}, longText, fetchUC.QualityReasonBlockedDomain},
+		{"lookalike domain passes", fetchUC.FeedItem{Title: "x", URL: "https://notspam.example/a"}, longText, ""},
+		{"keyword in the title", fetchUC.FeedItem{Title: "Best online casino", URL: "https://a.example"}, longText, fetchUC.QualityReasonBlockedKeyword},
+		{"PR title", fetchUC.FeedItem{Title: "[PR] 新製品", URL: "https://a.example"}, longText, fetchUC.QualityReasonAd},
+		{"sponsorship disclosure", fetchUC.FeedItem{Title: "x", URL: "https://a.example"}, longText + "この記事はプロモーションを含みます", fetchUC.QualityReasonAd},
+		{"link farm", fetchUC.FeedItem{Title: "x", URL: "https://a.example"}, links, fetchUC.QualityReasonAd},
+		{"tags do not count towards the length", fetchUC.FeedItem{Title: "x", URL: "https://a.example"}, "<p><strong>short</strong></p>", fetchUC.QualityReasonTooShort},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, filter.Check(tt.item, tt.text))
+		})
+	}
+
+	var disabled *fetchUC.QualityFilter
+	assert.Empty(t, disabled.Check(fetchUC.FeedItem{Title: "[PR] x"}, ""), "a nil filter rejects nothing")
+}
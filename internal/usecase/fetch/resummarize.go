@@ -0,0 +1,61 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/pkg/logging"
+)
+
+var (
+	// ErrArticleNotFound is returned by SummarizeArticle for an unknown
+	// (e.g. deleted since the request) article.
+	ErrArticleNotFound = errors.New("article not found")
+
+	// ErrNoContent is returned by SummarizeArticle for an article with no
+	// stored content: there is nothing to summarize.
+	ErrNoContent = errors.New("article has no content to summarize")
+)
+
+// SummarizeArticle re-runs the summarizer chain on one stored article and
+// replaces its summary (POST /articles/{id}/summarize): after a prompt
+// change, or for an article whose summary is missing or poor. Unlike
+// SweepUnsummarized a failed summarization is returned, so the job that
+// asked for it can be retried. Requires SummaryRepo to be set.
+func (s *Service) SummarizeArticle(ctx context.Context, articleID int64) (*entity.Summary, error) {
+	if s.SummaryRepo == nil {
+		return nil, errors.New("summarize article: SummaryRepo is not configured")
+	}
+	art, err := s.ArticleRepo.Get(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("get article: %w", err)
+	}
+	if art == nil {
+		return nil, ErrArticleNotFound
+	}
+	if strings.TrimSpace(art.Content) == "" {
+		return nil, ErrNoContent
+	}
+
+	ctx = logging.WithAttrs(ctx,
+		slog.Int64("article_id", art.ID),
+		slog.String("url", art.URL))
+	body, provider, err := s.summarize(ctx, art.Content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSummarizationFailed, err)
+	}
+	if provider == "" {
+		provider = entity.SummaryProviderUnknown
+	}
+	sum := &entity.Summary{ArticleID: art.ID, Body: body, Provider: provider}
+	if err := s.SummaryRepo.Upsert(ctx, sum); err != nil {
+		return nil, fmt.Errorf("upsert summary: %w", err)
+	}
+	slog.InfoContext(ctx, "article re-summarized",
+		slog.String("summary_provider", provider))
+	return sum, nil
+}
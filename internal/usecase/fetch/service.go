@@ -2,13 +2,18 @@ package fetch
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"slices"
 	"sort"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/pkg/logging"
 	"catchup-feed/internal/repository"
 
 	"golang.org/x/sync/errgroup"
@@ -24,8 +29,9 @@ const (
 	// the overflow takes the transcribe queue exactly like a stage-1
 	// failure. Right-sized against U-20 (2-3 channels total, typically ≤1
 	// new video/day each) with the hourly cron giving 24 cycles/day of
-	// headroom, and against the crawl budget (attempts are sequential,
-	// each bounded by the describer's own request timeout).
+	// headroom, and against the crawl budget (attempts are sequential
+	// within a source, each bounded by the describer's own request
+	// timeout). The budget is shared by concurrently crawled sources.
 	YouTubeDirectMaxPerCycle = 3
 
 	// BackfillCutoff bounds how far back feed items of ANY kind are
@@ -48,6 +54,30 @@ type FeedFetcher interface {
 	Fetch(ctx context.Context, url string) ([]FeedItem, error)
 }
 
+// FeedValidators are the HTTP cache validators of a feed response. Sent
+// back as If-None-Match / If-Modified-Since, they let an unchanged feed
+// answer 304 Not Modified. The zero value makes an unconditional request.
+type FeedValidators struct {
+	ETag         string
+	LastModified string
+}
+
+// FeedResponse is the outcome of a conditional feed fetch. With
+// NotModified set, Items is empty and Validators echoes the ones sent.
+type FeedResponse struct {
+	Items       []FeedItem
+	NotModified bool
+	Validators  FeedValidators
+}
+
+// ConditionalFeedFetcher is optionally implemented by FeedFetchers that
+// support conditional GET (RSSFetcher). The crawl persists the returned
+// validators per source (sources.etag / sources.last_modified) and skips
+// parsing and deduplication on 304.
+type ConditionalFeedFetcher interface {
+	FetchConditional(ctx context.Context, url string, prev FeedValidators) (*FeedResponse, error)
+}
+
 // ContentFetchConfig holds configuration for content fetching behavior.
 // This is passed to the Service to control parallelism and threshold settings.
 type ContentFetchConfig struct {
@@ -66,6 +96,19 @@ type FeedItem struct {
 	EnclosureURL string
 }
 
+// FeedPreview is a fetched and parsed feed that is not stored: the result
+// of checking a feed URL before a source is created for it. Type is the
+// detected format ("rss", "atom" or "json"); Items holds the first entries
+// in feed order, up to the caller's limit, and ItemCount counts them all.
+type FeedPreview struct {
+	Type        string
+	Title       string
+	Description string
+	SiteURL     string
+	ItemCount   int
+	Items       []FeedItem
+}
+
 // Service provides feed crawling and article fetching use cases.
 // It orchestrates the process of fetching feeds, summarizing content, and storing articles.
 //
@@ -83,7 +126,7 @@ type Service struct {
 	// SummaryRepo is required only by SweepUnsummarized (Phase 2 §5.2b):
 	// the sweep upserts summaries for articles whose content arrived
 	// after insert (transcripts). Not part of NewService because the
-	// crawl path persists summaries atomically via CreateWithSummary.
+	// crawl path persists summaries atomically via CreateBatch.
 	SummaryRepo repository.SummaryRepository
 
 	// VideoDescriber, when non-nil, enables the §5.1 stage-1 attempt for
@@ -92,6 +135,129 @@ type Service struct {
 	// video is enqueued for the Mac worker. Optional like SummaryRepo:
 	// not part of NewService.
 	VideoDescriber VideoDescriber
+
+	// Events, when non-nil, receives article.created for every inserted
+	// article and crawl.completed at the end of CrawlAllSources (outbound
+	// webhooks). Publishing is best-effort: failures are logged and never
+	// affect the crawl. Optional like SummaryRepo: not part of NewService.
+	Events EventPublisher
+
+	// Tx, when non-nil, writes article.created in the same transaction as
+	// the article (webhook_deliveries + deliver_webhook jobs are the
+	// outbox, dispatched and retried by the worker): an inserted article
+	// always gets its event, and a failed publish rolls the article back
+	// so the next crawl retries both. nil keeps the best-effort publish
+	// after the insert.
+	Tx repository.TxManager
+
+	// HealthRepo, when non-nil, records the outcome of every feed fetch
+	// (source_health: last crawl, HTTP status, failure streak, last
+	// error). Best-effort like Events: a failed write is logged only.
+	HealthRepo repository.SourceHealthRepository
+
+	// ContentRepo, when non-nil, stores the page fetched for content
+	// enhancement (raw HTML + extracted text) in article_contents. Only
+	// pages fetched through a PageFetcher are stored. Best-effort like
+	// HealthRepo: a failed write is logged only.
+	ContentRepo repository.ArticleContentRepository
+
+	// RunRepo, when non-nil, records every CrawlSources run in crawl_runs
+	// (start / end, totals, per-source stats, the abort error) for
+	// GET /crawls. Best-effort like HealthRepo: a failed write is logged
+	// only.
+	RunRepo repository.CrawlRunRepository
+
+	// Control, when non-nil, leaves the sources an admin skipped through
+	// the crawl controls out of CrawlDefaultScheduleSources (the worker
+	// cron filters the per-source schedules itself). A failed read is
+	// logged and nothing is skipped.
+	Control repository.CrawlControlRepository
+
+	// Groups, when non-nil, applies the source group settings to
+	// CrawlDefaultScheduleSources: members of a paused group are not
+	// crawled, and members on their group's crawl_schedule are left to the
+	// worker's source scheduler. A failed read is logged and every source
+	// follows its own settings.
+	Groups repository.SourceGroupRepository
+
+	// SourceConcurrency is how many sources one crawl processes at once
+	// (CRAWL_CONCURRENCY); 0 or 1 keeps them sequential. Content fetches
+	// and summarizations stay bounded across all sources of a run, so
+	// raising it does not multiply the load on the summarizer providers.
+	SourceConcurrency int
+
+	// SourceTimeout bounds the processing of one source
+	// (CRAWL_SOURCE_TIMEOUT); 0 leaves only the caller's deadline. A
+	// source that runs out of time is recorded (SourceStats.TimedOut) and
+	// the run goes on with the others.
+	SourceTimeout time.Duration
+
+	// Locker, when non-nil, keeps two worker replicas from crawling the
+	// same source at once: a source locked by another crawl is skipped
+	// (SourceStats.Locked). A failed lock attempt is logged and the source
+	// is crawled anyway.
+	Locker SourceLocker
+
+	// HeadlessFetcher, when non-nil, replaces ContentFetcher for sources
+	// with RenderJS set: their article pages are rendered in a headless
+	// browser first, for sites that only build their content in
+	// JavaScript (HEADLESS_ENABLED). A failed render falls back to the RSS
+	// content like any failed fetch. nil fetches those sources through
+	// ContentFetcher like every other source.
+	HeadlessFetcher ContentFetcher
+
+	// NearDuplicates, when non-nil and NearDuplicateMaxDistance > 0, marks
+	// every new RSS / scraped article whose SimHash is within
+	// NearDuplicateMaxDistance bits of another source's article from the
+	// last NearDuplicateWindow as its duplicate (articles.duplicate_of), so
+	// listings and notifications can collapse the group. The article is
+	// still stored and summarized. A failed lookup is logged and the
+	// article is stored as standalone.
+	NearDuplicates repository.ArticleSimilarityRepository
+
+	// NearDuplicateMaxDistance is the SimHash Hamming distance threshold
+	// (NEAR_DUPLICATE_MAX_DISTANCE, 0 disables the detection).
+	NearDuplicateMaxDistance int
+
+	// Quality, when non-nil, rejects junk items (too short, blocked domain
+	// or keyword, ads) between content enhancement and summarization, and
+	// drops or marks them (QualityFilter.Action). Rejected items are
+	// counted in CrawlStats.FilteredJunk.
+	Quality *QualityFilter
+}
+
+// NearDuplicateWindow is how far back a new article is compared with the
+// stored ones: the same story is republished within days, and the window
+// keeps the lookup to the recent rows.
+const NearDuplicateWindow = 7 * 24 * time.Hour
+
+// SourceLocker serializes the crawl of a source across processes
+// (implemented with PostgreSQL advisory locks by worker.AdvisoryLocker).
+// acquired is false when another holder has the lock; unlock is non-nil
+// only when it was acquired.
+type SourceLocker interface {
+	TryLockSource(ctx context.Context, sourceID int64) (unlock func(), acquired bool, err error)
+}
+
+// EventPublisher queues an outbound event (implemented by the webhook use
+// case).
+type EventPublisher interface {
+	Publish(ctx context.Context, event string, data any) error
+}
+
+// EventPublishers hands every event to each publisher in turn (webhooks,
+// keyword alerts) and stops at the first failure, which inside the
+// crawl's unit of work rolls back what the others queued.
+type EventPublishers []EventPublisher
+
+// Publish implements EventPublisher.
+func (p EventPublishers) Publish(ctx context.Context, event string, data any) error {
+	for _, publisher := range p {
+		if err := publisher.Publish(ctx, event, data); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // VideoDescriber is the §5.1 stage-1 backend (Gemini に動画 URL を直接入力):
@@ -124,7 +290,7 @@ type ProviderSummarizer interface {
 // Parameters:
 //   - sourceRepo: Repository for managing feed sources
 //   - articleRepo: Repository for managing articles (articles + summaries
-//     are persisted atomically via CreateWithSummary)
+//     are persisted atomically via CreateBatch)
 //   - summarizer: AI service for text summarization
 //   - feedFetcher: Service for fetching RSS/Atom feeds
 //   - contentFetcher: Service for fetching full article content (can be nil to disable)
@@ -165,18 +331,123 @@ func NewService(
 // §5.1 stage-1 tries this cycle (capped at YouTubeDirectMaxPerCycle) and
 // YouTubeDirectSucceeded the ones persisted with a summary and no
 // transcribe job (also counted in Inserted, not in TranscribeEnqueued).
+// Duplicated counts items whose URL is already stored; DuplicatedByHash
+// counts the ones with a new URL but a known content hash
+// (entity.ArticleContentHash — the same article under another tracking
+// query string). The two do not overlap. NearDuplicates counts inserted
+// articles marked as a near-duplicate of another source's article
+// (SimHash, also counted in Inserted). FilteredJunk counts the items the
+// quality filter rejected; marked ones are also counted in Inserted.
 type CrawlStats struct {
 	Sources                int
 	FeedItems              int64
 	Inserted               int64
 	Duplicated             int64
+	DuplicatedByHash       int64
+	NearDuplicates         int64
+	FilteredJunk           int64
 	SummarizeError         int64
 	TranscribeEnqueued     int64
 	SkippedNoMedia         int64
 	SkippedBackfill        int64
 	YouTubeDirectAttempts  int64
 	YouTubeDirectSucceeded int64
+	NotModified            int64 // sources whose feed answered 304
+	SkippedLocked          int64 // sources skipped because another crawl held their lock
 	Duration               time.Duration
+
+	// PerSource holds one entry per processed source, in processing
+	// order, so a single misbehaving feed can be told apart from the
+	// aggregate counts above.
+	PerSource []SourceStats
+}
+
+// FetchFailedSources counts the sources whose feed could not be fetched.
+func (c *CrawlStats) FetchFailedSources() int {
+	n := 0
+	for _, ps := range c.PerSource {
+		if ps.FetchError != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// SourceStats is the crawl outcome of one source. HTTPStatus is the feed
+// response status: 200 on success, the server's status for a non-2xx
+// answer (FeedStatusError), 0 when no response was received (DNS, TLS,
+// timeout, parse failure). FetchError is empty on success.
+type SourceStats struct {
+	SourceID         int64
+	Kind             string
+	HTTPStatus       int
+	FetchDuration    time.Duration
+	FetchError       string
+	FeedItems        int64
+	Inserted         int64
+	Duplicated       int64
+	DuplicatedByHash int64
+	SummarizeErrors  int64
+	Duration         time.Duration
+	// TimedOut reports that the source hit SourceTimeout; the articles
+	// stored before that are kept and counted.
+	TimedOut bool
+	// NotModified reports a 304 answer to a conditional GET: the feed is
+	// unchanged since the last crawl and was not parsed again.
+	NotModified bool
+	// Locked reports that another crawl (usually on another worker
+	// replica) was processing the source, so this run skipped it.
+	Locked bool
+}
+
+// add folds the counters of one source's run into c. Sources and
+// Duration belong to the whole run and are left alone.
+func (c *CrawlStats) add(o *CrawlStats) {
+	c.FeedItems += o.FeedItems
+	c.Inserted += o.Inserted
+	c.Duplicated += o.Duplicated
+	c.DuplicatedByHash += o.DuplicatedByHash
+	c.NearDuplicates += o.NearDuplicates
+	c.FilteredJunk += o.FilteredJunk
+	c.SummarizeError += o.SummarizeError
+	c.TranscribeEnqueued += o.TranscribeEnqueued
+	c.SkippedNoMedia += o.SkippedNoMedia
+	c.SkippedBackfill += o.SkippedBackfill
+	c.YouTubeDirectAttempts += o.YouTubeDirectAttempts
+	c.YouTubeDirectSucceeded += o.YouTubeDirectSucceeded
+	c.NotModified += o.NotModified
+	c.SkippedLocked += o.SkippedLocked
+}
+
+// crawlRun is the state the sources of one CrawlSources run share: the
+// article-level semaphores (two-tier parallelism, see processFeedItems)
+// and the §5.1 stage-1 budget (YouTubeDirectMaxPerCycle is per cycle, not
+// per source).
+type crawlRun struct {
+	contentSem            chan struct{}
+	summarySem            chan struct{}
+	youtubeDirectAttempts atomic.Int64
+}
+
+func (s *Service) newCrawlRun() *crawlRun {
+	return &crawlRun{
+		contentSem: make(chan struct{}, s.contentConfig.Parallelism),
+		summarySem: make(chan struct{}, summarizerParallelism),
+	}
+}
+
+// reserveYouTubeDirect takes one stage-1 attempt from the cycle's budget.
+// It reports false once YouTubeDirectMaxPerCycle attempts are taken.
+func (r *crawlRun) reserveYouTubeDirect() bool {
+	for {
+		n := r.youtubeDirectAttempts.Load()
+		if n >= YouTubeDirectMaxPerCycle {
+			return false
+		}
+		if r.youtubeDirectAttempts.CompareAndSwap(n, n+1) {
+			return true
+		}
+	}
 }
 
 // CrawlAllSources fetches and processes articles from all active sources.
@@ -187,15 +458,68 @@ type CrawlStats struct {
 // 4. Stores new articles in the repository
 // Returns crawl statistics including counts of processed, inserted, and duplicated articles.
 func (s *Service) CrawlAllSources(ctx context.Context) (*CrawlStats, error) {
-	logger := slog.Default()
-	startAll := time.Now()
-	stats := &CrawlStats{}
+	srcs, err := s.SourceRepo.ListActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list active sources: %w", err)
+	}
+	return s.CrawlSources(ctx, srcs)
+}
 
+// CrawlDefaultScheduleSources crawls the active sources without their own
+// or their group's crawl_schedule: the ones the worker's global
+// CRON_SCHEDULE is responsible for. Sources with an override are crawled
+// by CrawlSource on their own schedule instead; sources skipped through
+// Control or in a paused group (Groups) are not crawled.
+func (s *Service) CrawlDefaultScheduleSources(ctx context.Context) (*CrawlStats, error) {
 	srcs, err := s.SourceRepo.ListActive(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("list active sources: %w", err)
 	}
-	stats.Sources = len(srcs)
+	var control *entity.CrawlControl
+	if s.Control != nil {
+		if control, err = s.Control.Get(ctx); err != nil {
+			slog.Default().Warn("failed to read crawl controls, crawling every source", slog.Any("error", err))
+		}
+	}
+	var groups entity.SourceGroups
+	if s.Groups != nil {
+		list, err := s.Groups.List(ctx)
+		if err != nil {
+			slog.Default().Warn("failed to read source groups, ignoring group settings", slog.Any("error", err))
+		}
+		groups = entity.NewSourceGroups(list)
+	}
+	defaults := make([]*entity.Source, 0, len(srcs))
+	for _, src := range srcs {
+		if groups.CrawlSchedule(src) == nil && !groups.CrawlPaused(src) && !control.Skips(src.ID) {
+			defaults = append(defaults, src)
+		}
+	}
+	return s.CrawlSources(ctx, defaults)
+}
+
+// CrawlSource crawls one source by ID (per-source crawl_schedule). The
+// source is re-read so a run always uses the current feed URL and kind; a
+// deleted or deactivated source yields empty stats.
+func (s *Service) CrawlSource(ctx context.Context, id int64) (*CrawlStats, error) {
+	src, err := s.SourceRepo.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get source: %w", err)
+	}
+	if src == nil || !src.Active {
+		return &CrawlStats{}, nil
+	}
+	return s.CrawlSources(ctx, []*entity.Source{src})
+}
+
+// CrawlSources runs the crawl pipeline over srcs (see CrawlAllSources) and
+// publishes crawl.completed for the run. Up to SourceConcurrency sources
+// are processed at once; a critical error of one source stops the sources
+// not yet started and is returned with the stats gathered so far.
+func (s *Service) CrawlSources(ctx context.Context, srcs []*entity.Source) (*CrawlStats, error) {
+	logger := slog.Default()
+	startAll := time.Now()
+	stats := &CrawlStats{Sources: len(srcs)}
 
 	// transcribe kind (youtube/podcast) を rss より先に処理する(安定ソート:
 	// 同 kind 内は ListActive の返す id 順を維持)。transcribe 経路は
@@ -206,34 +530,130 @@ func (s *Service) CrawlAllSources(ctx context.Context) (*CrawlStats, error) {
 	// id 順のままだと末尾の youtube/podcast ソースが要約詰まりの人質になって
 	// 毎サイクル未到達になる(本番障害: id 305〜309 に一度も到達せず)。
 	// 先行させれば、クオータ枯渇日でも新着検知と enqueue は必ず成立する。
+	srcs = slices.Clone(srcs) // 呼び出し元のスライスは並べ替えない
 	sort.SliceStable(srcs, func(i, j int) bool {
 		return isTranscribeKind(srcs[i]) && !isTranscribeKind(srcs[j])
 	})
 
-	for _, src := range srcs {
-		if err := s.processSingleSource(ctx, src, stats); err != nil {
-			return stats, err
+	// 並列時も取り出し順(transcribe 先行)は保たれる: 空いた枠から
+	// srcs の順に着手する。PerSource も着手順に並べる。
+	record := s.startRunRecord(ctx, startAll, len(srcs))
+	run := s.newCrawlRun()
+	perSource := make([]*SourceStats, len(srcs))
+	var mu sync.Mutex
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(s.sourceConcurrency())
+	for i, src := range srcs {
+		if egCtx.Err() != nil {
+			break // 中断済み: 未着手のソースは fetch 失敗として記録しない
+		}
+		eg.Go(func() error {
+			if egCtx.Err() != nil {
+				return nil
+			}
+			srcStats, err := s.crawlOneSource(egCtx, src, run)
+			mu.Lock()
+			defer mu.Unlock()
+			stats.add(srcStats)
+			perSource[i] = &srcStats.PerSource[0]
+			return err
+		})
+	}
+	err := eg.Wait()
+	for _, ps := range perSource {
+		if ps != nil {
+			stats.PerSource = append(stats.PerSource, *ps)
 		}
 	}
-
 	stats.Duration = time.Since(startAll)
-	logger.Info("all sources crawl completed",
+	s.finishRunRecord(ctx, record, stats, err)
+	if err != nil {
+		return stats, err
+	}
+
+	s.publish(ctx, entity.WebhookEventCrawlCompleted, entity.WebhookCrawlData{
+		Sources:            stats.Sources,
+		FetchFailedSources: stats.FetchFailedSources(),
+		FeedItems:          stats.FeedItems,
+		Inserted:           stats.Inserted,
+		Duplicated:         stats.Duplicated,
+		DuplicatedByHash:   stats.DuplicatedByHash,
+		NearDuplicates:     stats.NearDuplicates,
+		FilteredJunk:       stats.FilteredJunk,
+		SummarizeErrors:    stats.SummarizeError,
+		DurationMS:         stats.Duration.Milliseconds(),
+	})
+	logger.InfoContext(ctx, "all sources crawl completed",
 		slog.Int("sources", stats.Sources),
+		slog.Int("fetch_failed_sources", stats.FetchFailedSources()),
 		slog.Int64("feed_items", stats.FeedItems),
 		slog.Int64("inserted", stats.Inserted),
 		slog.Int64("duplicated", stats.Duplicated),
+		slog.Int64("duplicated_by_hash", stats.DuplicatedByHash),
+		slog.Int64("near_duplicates", stats.NearDuplicates),
 		slog.Int64("summarize_errors", stats.SummarizeError),
 		slog.Int64("transcribe_enqueued", stats.TranscribeEnqueued),
 		slog.Int64("skipped_no_media", stats.SkippedNoMedia),
 		slog.Int64("skipped_backfill", stats.SkippedBackfill),
 		slog.Int64("youtube_direct_attempts", stats.YouTubeDirectAttempts),
 		slog.Int64("youtube_direct_succeeded", stats.YouTubeDirectSucceeded),
+		slog.Int64("skipped_locked", stats.SkippedLocked),
 		slog.Duration("duration", stats.Duration),
 	)
 
 	return stats, nil
 }
 
+func (s *Service) sourceConcurrency() int {
+	if s.SourceConcurrency > 1 {
+		return s.SourceConcurrency
+	}
+	return 1
+}
+
+// crawlOneSource processes one source under SourceTimeout and returns its
+// own stats (PerSource holds exactly its entry). Running out of
+// SourceTimeout is not an error; only the death of runCtx (shutdown, crawl
+// deadline, another source's critical error) is.
+func (s *Service) crawlOneSource(runCtx context.Context, src *entity.Source, run *crawlRun) (*CrawlStats, error) {
+	if s.Locker != nil {
+		unlock, acquired, err := s.Locker.TryLockSource(runCtx, src.ID)
+		switch {
+		case err != nil:
+			slog.Default().WarnContext(runCtx, "source lock failed, crawling without it",
+				slog.Int64("source_id", src.ID), slog.Any("error", err))
+		case !acquired:
+			slog.Default().InfoContext(runCtx, "source is being crawled elsewhere, skipping",
+				slog.Int64("source_id", src.ID))
+			return &CrawlStats{
+				SkippedLocked: 1,
+				PerSource:     []SourceStats{{SourceID: src.ID, Kind: src.Kind, Locked: true}},
+			}, nil
+		default:
+			defer unlock()
+		}
+	}
+
+	ctx := runCtx
+	if s.SourceTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(runCtx, s.SourceTimeout)
+		defer cancel()
+	}
+	stats := &CrawlStats{}
+	err := s.processSingleSource(ctx, src, stats, run)
+	if ctx.Err() != nil && runCtx.Err() == nil {
+		stats.PerSource[0].TimedOut = true
+		slog.Default().WarnContext(ctx, "source crawl timed out, continuing with other sources",
+			slog.Int64("source_id", src.ID),
+			slog.Duration("source_timeout", s.SourceTimeout),
+			slog.Int64("inserted", stats.Inserted),
+			slog.Any("error", err))
+		return stats, nil
+	}
+	return stats, err
+}
+
 // isTranscribeKind reports whether the source is handled by the transcribe
 // path (enqueueTranscribeItems: youtube/podcast, Phase 2 §5) as opposed to
 // the rss summarize path. Used by CrawlAllSources to order transcribe
@@ -243,30 +663,64 @@ func isTranscribeKind(src *entity.Source) bool {
 }
 
 // processSingleSource processes a single feed source by fetching, deduplicating,
-// summarizing, and storing articles. It updates the provided stats atomically.
+// summarizing, and storing articles. stats belongs to this source alone
+// (crawlOneSource merges it into the run); its counters are updated
+// atomically by the per-article goroutines and its PerSource receives the
+// source's SourceStats.
 // Returns error only for critical failures (database errors).
 // Logs and continues for recoverable failures (fetch errors, batch check errors).
-func (s *Service) processSingleSource(ctx context.Context, src *entity.Source, stats *CrawlStats) error {
+func (s *Service) processSingleSource(ctx context.Context, src *entity.Source, stats *CrawlStats, run *crawlRun) error {
+	ctx = logging.WithAttrs(ctx,
+		slog.Int64("source_id", src.ID),
+		slog.String("source_kind", src.Kind))
 	logger := slog.Default()
 	sourceStart := time.Now()
 
-	feedItems, err := s.FeedFetcher.Fetch(ctx, src.FeedURL)
+	// stats はこのソース専用なので、ソース単位の件数はそのまま読める
+	// (記事処理は並列なのでカウンタは atomic で更新・読み出しする)。
+	srcStats := SourceStats{SourceID: src.ID, Kind: src.Kind}
+	defer func() {
+		srcStats.FeedItems = atomic.LoadInt64(&stats.FeedItems)
+		srcStats.Inserted = atomic.LoadInt64(&stats.Inserted)
+		srcStats.Duplicated = atomic.LoadInt64(&stats.Duplicated)
+		srcStats.DuplicatedByHash = atomic.LoadInt64(&stats.DuplicatedByHash)
+		srcStats.SummarizeErrors = atomic.LoadInt64(&stats.SummarizeError)
+		srcStats.Duration = time.Since(sourceStart)
+		stats.PerSource = append(stats.PerSource, srcStats)
+	}()
+
+	feedItems, validators, notModified, err := s.fetchFeed(ctx, src)
+	srcStats.FetchDuration = time.Since(sourceStart)
 	if err != nil {
-		logger.Warn("failed to fetch feed",
-			slog.Int64("source_id", src.ID),
+		srcStats.HTTPStatus = httpStatusOf(err)
+		srcStats.FetchError = err.Error()
+		s.recordHealth(ctx, src.ID, srcStats.HTTPStatus, srcStats.FetchError)
+		logger.WarnContext(ctx, "failed to fetch feed",
 			slog.String("feed_url", src.FeedURL),
+			slog.Int("http_status", srcStats.HTTPStatus),
+			slog.Duration("fetch_duration", srcStats.FetchDuration),
 			slog.Any("error", err))
 		// Continue with other sources even if one fails
 		return nil
 	}
+	if notModified {
+		srcStats.HTTPStatus = http.StatusNotModified
+		srcStats.NotModified = true
+		stats.NotModified++
+		s.recordHealth(ctx, src.ID, srcStats.HTTPStatus, "")
+		logger.InfoContext(ctx, "feed not modified since last crawl",
+			slog.Duration("fetch_duration", srcStats.FetchDuration))
+		return nil
+	}
+	srcStats.HTTPStatus = http.StatusOK
+	s.recordHealth(ctx, src.ID, srcStats.HTTPStatus, "")
 
 	if len(feedItems) == 0 {
-		logger.Info("feed is empty",
-			slog.Int64("source_id", src.ID),
+		logger.InfoContext(ctx, "feed is empty",
 			slog.String("feed_url", src.FeedURL))
+		s.saveFeedValidators(ctx, src, validators)
 		return nil
 	}
-	itemsFound := int64(len(feedItems))
 
 	// D-15/D-15b バックログカットオフ(全 kind): published_at が
 	// BackfillCutoff より古い item はここで落とす。dedupe(ExistsByURLBatch)
@@ -294,9 +748,7 @@ func (s *Service) processSingleSource(ctx context.Context, src *entity.Source, s
 		// スキップした item も FeedItems(観測した件数)には数える。
 		atomic.AddInt64(&stats.FeedItems, skippedBackfill)
 		atomic.AddInt64(&stats.SkippedBackfill, skippedBackfill)
-		logger.Info("skipped backlog items older than cutoff (D-15)",
-			slog.Int64("source_id", src.ID),
-			slog.String("source_kind", src.Kind),
+		logger.InfoContext(ctx, "skipped backlog items older than cutoff (D-15)",
 			slog.Int64("skipped_backfill", skippedBackfill),
 			slog.Duration("cutoff", BackfillCutoff))
 	}
@@ -310,16 +762,17 @@ func (s *Service) processSingleSource(ctx context.Context, src *entity.Source, s
 	}
 	existsMap, err := s.ArticleRepo.ExistsByURLBatch(ctx, urls)
 	if err != nil {
-		logger.Warn("failed to batch check URLs",
-			slog.Int64("source_id", src.ID),
+		logger.WarnContext(ctx, "failed to batch check URLs",
 			slog.Any("error", err))
 		// Continue with other sources even if batch check fails
 		return nil
 	}
-
-	// Track stats before processing for logging
-	beforeInserted := atomic.LoadInt64(&stats.Inserted)
-	beforeDuplicated := atomic.LoadInt64(&stats.Duplicated)
+	feedItems, err = s.dropHashDuplicates(ctx, src, feedItems, existsMap, stats)
+	if err != nil {
+		logger.WarnContext(ctx, "failed to batch check content hashes",
+			slog.Any("error", err))
+		return nil
+	}
 
 	// kind 分岐 (Phase 2 §5): youtube/podcast share the gofeed new-item
 	// detection above but never touch go-readability or the summarizer —
@@ -328,33 +781,152 @@ func (s *Service) processSingleSource(ctx context.Context, src *entity.Source, s
 	// transcript fills content (§4: content が NULL のうちは要約対象外).
 	switch src.Kind {
 	case entity.SourceKindYouTube, entity.SourceKindPodcast:
-		if err := s.enqueueTranscribeItems(ctx, src, feedItems, existsMap, stats); err != nil {
+		if err := s.enqueueTranscribeItems(ctx, src, feedItems, existsMap, stats, run); err != nil {
 			return fmt.Errorf("enqueue transcribe items: %w", err)
 		}
 	default: // '' / 'rss': 既存挙動そのまま
-		if err := s.processFeedItems(ctx, src, feedItems, existsMap, stats); err != nil {
+		if err := s.processFeedItems(ctx, src, feedItems, existsMap, stats, run); err != nil {
 			return fmt.Errorf("process feed items: %w", err)
 		}
 	}
 
-	sourceDuration := time.Since(sourceStart)
-	itemsInserted := atomic.LoadInt64(&stats.Inserted) - beforeInserted
-	itemsDuplicated := atomic.LoadInt64(&stats.Duplicated) - beforeDuplicated
-
-	logger.Info("source crawl completed",
-		slog.Int64("source_id", src.ID),
-		slog.Int64("feed_items", itemsFound),
-		slog.Int64("inserted", itemsInserted),
-		slog.Int64("duplicated", itemsDuplicated),
-		slog.Duration("duration", sourceDuration),
+	// 要約に失敗した記事は次のクロールで拾い直す(§8)ので、その場合は
+	// 検証子を消して次回を無条件 GET にする(304 だと取りこぼす)。
+	if atomic.LoadInt64(&stats.SummarizeError) > 0 {
+		validators = FeedValidators{}
+	}
+	s.saveFeedValidators(ctx, src, validators)
+
+	logger.InfoContext(ctx, "source crawl completed",
+		slog.Int("http_status", srcStats.HTTPStatus),
+		slog.Duration("fetch_duration", srcStats.FetchDuration),
+		slog.Int64("feed_items", atomic.LoadInt64(&stats.FeedItems)),
+		slog.Int64("inserted", atomic.LoadInt64(&stats.Inserted)),
+		slog.Int64("duplicated", atomic.LoadInt64(&stats.Duplicated)),
+		slog.Int64("duplicated_by_hash", atomic.LoadInt64(&stats.DuplicatedByHash)),
+		slog.Int64("near_duplicates", atomic.LoadInt64(&stats.NearDuplicates)),
+		slog.Int64("filtered_junk", atomic.LoadInt64(&stats.FilteredJunk)),
+		slog.Int64("summarize_errors", atomic.LoadInt64(&stats.SummarizeError)),
+		slog.Duration("duration", time.Since(sourceStart)),
 	)
 
 	return nil
 }
 
+// fetchFeed fetches the source's feed, conditionally when the FeedFetcher
+// supports it. The returned validators are the ones to store once the
+// items are processed; notModified means the feed is unchanged.
+func (s *Service) fetchFeed(ctx context.Context, src *entity.Source) ([]FeedItem, FeedValidators, bool, error) {
+	cf, ok := s.FeedFetcher.(ConditionalFeedFetcher)
+	if !ok {
+		items, err := s.FeedFetcher.Fetch(ctx, src.FeedURL)
+		return items, FeedValidators{}, false, err
+	}
+	resp, err := cf.FetchConditional(ctx, src.FeedURL, FeedValidators{ETag: src.ETag, LastModified: src.LastModified})
+	if err != nil {
+		return nil, FeedValidators{}, false, err
+	}
+	return resp.Items, resp.Validators, resp.NotModified, nil
+}
+
+// saveFeedValidators persists the validators for the next conditional GET
+// when they changed. It is called only after the feed's items were
+// processed: storing them earlier would let a failed run's items be
+// skipped as 304 on the next crawl. Failures are logged only.
+func (s *Service) saveFeedValidators(ctx context.Context, src *entity.Source, v FeedValidators) {
+	if v.ETag == src.ETag && v.LastModified == src.LastModified {
+		return
+	}
+	if err := s.SourceRepo.UpdateFeedValidators(ctx, src.ID, v.ETag, v.LastModified); err != nil {
+		slog.Default().WarnContext(ctx, "failed to store feed validators", slog.Any("error", err))
+	}
+}
+
+// startRunRecord inserts the running crawl_runs row of a run. It returns
+// nil when RunRepo is unset or the insert failed; finishRunRecord then
+// does nothing.
+func (s *Service) startRunRecord(ctx context.Context, startedAt time.Time, sources int) *entity.CrawlRun {
+	if s.RunRepo == nil {
+		return nil
+	}
+	record := &entity.CrawlRun{Status: entity.CrawlRunStatusRunning, StartedAt: startedAt, SourceCount: sources}
+	if err := s.RunRepo.Start(ctx, record); err != nil {
+		slog.Default().WarnContext(ctx, "failed to record crawl run start", slog.Any("error", err))
+		return nil
+	}
+	return record
+}
+
+// finishRunRecord stores the outcome of the run started by
+// startRunRecord. The write ignores ctx cancellation: a run cut short by
+// CRAWL_TIMEOUT is exactly the one worth recording.
+func (s *Service) finishRunRecord(ctx context.Context, record *entity.CrawlRun, stats *CrawlStats, runErr error) {
+	if record == nil {
+		return
+	}
+	finishedAt := time.Now()
+	record.Status = entity.CrawlRunStatusSucceeded
+	record.FinishedAt = &finishedAt
+	record.FetchFailedSources = stats.FetchFailedSources()
+	record.FeedItems = stats.FeedItems
+	record.Inserted = stats.Inserted
+	record.Duplicated = stats.Duplicated
+	record.SummarizeErrors = stats.SummarizeError
+	record.Sources = make([]entity.CrawlRunSource, 0, len(stats.PerSource))
+	for _, ps := range stats.PerSource {
+		record.Sources = append(record.Sources, entity.CrawlRunSource{
+			SourceID:        ps.SourceID,
+			Kind:            ps.Kind,
+			HTTPStatus:      ps.HTTPStatus,
+			FetchError:      ps.FetchError,
+			FeedItems:       ps.FeedItems,
+			Inserted:        ps.Inserted,
+			Duplicated:      ps.Duplicated,
+			SummarizeErrors: ps.SummarizeErrors,
+			TimedOut:        ps.TimedOut,
+			NotModified:     ps.NotModified,
+			Locked:          ps.Locked,
+			DurationMS:      ps.Duration.Milliseconds(),
+		})
+	}
+	if runErr != nil {
+		msg := runErr.Error()
+		record.Status = entity.CrawlRunStatusFailed
+		record.Error = &msg
+	}
+	if err := s.RunRepo.Finish(context.WithoutCancel(ctx), record); err != nil {
+		slog.Default().WarnContext(ctx, "failed to record crawl run result",
+			slog.Int64("crawl_run_id", record.ID), slog.Any("error", err))
+	}
+}
+
+// recordHealth stores the feed fetch outcome in source_health. Failures
+// are logged and never affect the crawl.
+func (s *Service) recordHealth(ctx context.Context, sourceID int64, httpStatus int, fetchErr string) {
+	if s.HealthRepo == nil {
+		return
+	}
+	if err := s.HealthRepo.RecordFetch(ctx, sourceID, time.Now(), httpStatus, fetchErr); err != nil {
+		slog.Default().WarnContext(ctx, "failed to record source health", slog.Any("error", err))
+	}
+}
+
+// httpStatusOf extracts the feed response status from a fetch error; 0
+// means the request never got a response.
+func httpStatusOf(err error) int {
+	var statusErr *FeedStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode
+	}
+	return 0
+}
+
 // processFeedItems processes all feed items from a source in parallel,
-// summarizing and storing new articles while tracking statistics.
+// summarizing new articles while tracking statistics, then stores them
+// with one batch insert (storeSummarized).
 // Uses two-tier parallelism: configurable concurrent content fetches, 5 concurrent AI summarizations.
+// The semaphores belong to the run, so the limits hold across concurrently
+// crawled sources.
 //
 // Error Handling:
 //   - Context cancellation (context.Canceled, context.DeadlineExceeded): Propagates immediately (aborts crawl)
@@ -366,10 +938,15 @@ func (s *Service) processFeedItems(
 	feedItems []FeedItem,
 	existsMap map[string]bool,
 	stats *CrawlStats,
+	run *crawlRun,
 ) error {
-	contentSem := make(chan struct{}, s.contentConfig.Parallelism)
-	summarySem := make(chan struct{}, summarizerParallelism)
+	contentSem := run.contentSem
+	summarySem := run.summarySem
 	eg, egCtx := errgroup.WithContext(ctx)
+	var (
+		mu      sync.Mutex
+		pending []summarizedItem
+	)
 
 	for _, feedItem := range feedItems {
 		item := feedItem
@@ -383,16 +960,40 @@ func (s *Service) processFeedItems(
 		}
 
 		eg.Go(func() error {
+			// 記事単位の相関属性: content 取得・要約チェーン(provider ごとの
+			// 成否ログ)・保存までを同じ url で辿れるようにする。
+			itemCtx := logging.WithAttrs(egCtx, slog.String("url", item.URL))
+
 			// Step 1: Content enhancement (higher parallelism for I/O-bound)
 			contentSem <- struct{}{}
-			content := s.enhanceContent(egCtx, item)
+			content, page := s.enhanceContent(itemCtx, src, item)
 			<-contentSem
 
-			// Step 2: AI summarization (lower parallelism, rate-limited)
-			summarySem <- struct{}{}
-			defer func() { <-summarySem }()
+			// Junk never reaches the summarizer.
+			reason := s.Quality.Check(item, content)
+			if reason != "" {
+				atomic.AddInt64(&stats.FilteredJunk, 1)
+				slog.InfoContext(itemCtx, "quality filter rejected item",
+					slog.String("title", item.Title),
+					slog.String("reason", reason),
+					slog.Bool("marked", s.Quality.Marks()))
+				if !s.Quality.Marks() {
+					return nil
+				}
+			}
 
-			summary, provider, err := s.summarize(egCtx, content)
+			// Step 2: AI summarization (lower parallelism, rate-limited)
+			var (
+				summary, provider string
+				err               error
+			)
+			if reason != "" {
+				summary, provider = qualityNote(reason), entity.SummaryProviderFiltered
+			} else {
+				summarySem <- struct{}{}
+				defer func() { <-summarySem }()
+				summary, provider, err = s.summarize(itemCtx, content)
+			}
 			if err != nil {
 				// Only a dead group context (shutdown or crawl deadline) is
 				// critical. Judge by egCtx directly, NOT errors.Is on the
@@ -407,20 +1008,15 @@ func (s *Service) processFeedItems(
 				atomic.AddInt64(&stats.SummarizeError, 1)
 
 				// Log warning and skip this article instead of stopping entire crawl
-				logger := slog.Default()
-				logger.Warn("summarization failed, skipping article",
-					slog.Int64("source_id", src.ID),
-					slog.String("url", item.URL),
+				slog.WarnContext(itemCtx, "summarization failed, skipping article",
 					slog.String("title", item.Title),
 					slog.Any("error", err))
 				return nil // Continue processing other articles
 			}
 
-			// Persist article + summary atomically: a summary failure rolls
-			// the article back, so no article can end up permanently
-			// unsummarized — the URL stays unknown and the next hourly
-			// crawl retries it (§8). summaries.provider records which
-			// chain leg produced the summary (§4 fallback observability).
+			// Stored with the source's other new articles by storeSummarized.
+			// summaries.provider records which chain leg produced the
+			// summary (§4 fallback observability).
 			if provider == "" {
 				provider = entity.SummaryProviderUnknown
 			}
@@ -432,29 +1028,144 @@ func (s *Service) processFeedItems(
 				Summary:     summary, // read-only join field; persisted via summaries row below
 				PublishedAt: item.PublishedAt,
 				CrawledAt:   time.Now(),
+				ContentHash: contentHashForItem(src, item),
 			}
-			sum := &entity.Summary{Body: summary, Provider: provider}
-			if err := s.ArticleRepo.CreateWithSummary(egCtx, art, sum); err != nil {
-				return fmt.Errorf("create article with summary in repository: %w", err)
+			if page != nil {
+				art.ImageURL = page.ImageURL
 			}
-			atomic.AddInt64(&stats.Inserted, 1)
-
-			slog.Info("article summarized",
-				slog.Int64("article_id", art.ID),
-				slog.String("url", art.URL),
-				slog.String("summary_provider", provider))
-
+			s.markNearDuplicate(itemCtx, art, stats)
+			mu.Lock()
+			pending = append(pending, summarizedItem{
+				article:  art,
+				summary:  &entity.Summary{Body: summary, Provider: provider},
+				page:     page,
+				filtered: reason != "",
+			})
+			mu.Unlock()
 			return nil
 		})
 	}
 
-	if err := eg.Wait(); err != nil {
+	waitErr := eg.Wait()
+	// The summaries already produced are stored even when the source is
+	// aborting (shutdown, crawl deadline): the LLM work is done and the
+	// rows are a few statements away.
+	storeCtx := ctx
+	if waitErr != nil {
+		var cancel context.CancelFunc
+		storeCtx, cancel = context.WithTimeout(context.WithoutCancel(ctx), storeOnAbortTimeout)
+		defer cancel()
+	}
+	if err := s.storeSummarized(storeCtx, pending, stats); err != nil {
+		return errors.Join(waitErr, err)
+	}
+	return waitErr
+}
+
+// storeOnAbortTimeout bounds storeSummarized after the source's context
+// has ended.
+const storeOnAbortTimeout = 10 * time.Second
+
+// summarizedItem is a new feed item waiting for the per-source batch
+// insert.
+type summarizedItem struct {
+	article *entity.Article
+	summary *entity.Summary
+	page    *FetchedPage
+	// filtered marks a junk item stored with the quality filter's note
+	// instead of a summary; it publishes no article.created.
+	filtered bool
+}
+
+// storeSummarized inserts the source's summarized articles with one
+// CreateBatch instead of a transaction per article. Article and summary
+// still land together, so no article can end up permanently unsummarized
+// — on failure the URLs stay unknown and the next hourly crawl retries
+// them (§8). An article skipped by the batch (its URL or content hash was
+// stored concurrently, e.g. by another source's crawl) counts as
+// DuplicatedByHash. Articles marked by the quality filter are stored
+// without an article.created event, so junk reaches no webhook or alert.
+func (s *Service) storeSummarized(ctx context.Context, items []summarizedItem, stats *CrawlStats) error {
+	if len(items) == 0 {
+		return nil
+	}
+	batch := make([]repository.NewArticle, len(items))
+	articles := make([]*entity.Article, 0, len(items))
+	for i, item := range items {
+		batch[i] = repository.NewArticle{Article: item.article, Summary: item.summary}
+		if !item.filtered {
+			articles = append(articles, item.article)
+		}
+	}
+	if err := s.createArticles(ctx, articles, func(ctx context.Context) error {
+		_, err := s.ArticleRepo.CreateBatch(ctx, batch)
 		return err
+	}); err != nil {
+		return fmt.Errorf("create articles with summaries in repository: %w", err)
 	}
 
+	for _, item := range items {
+		if item.article.ID == 0 {
+			atomic.AddInt64(&stats.DuplicatedByHash, 1)
+			continue
+		}
+		atomic.AddInt64(&stats.Inserted, 1)
+		s.saveContent(ctx, item.article.ID, item.page)
+		slog.InfoContext(ctx, "article summarized",
+			slog.String("url", item.article.URL),
+			slog.Int64("article_id", item.article.ID),
+			slog.String("summary_provider", item.summary.Provider))
+	}
 	return nil
 }
 
+// dropHashDuplicates removes the items whose URL is new but whose content
+// hash is already stored, or repeats an earlier item of the same feed, and
+// counts them in FeedItems and DuplicatedByHash. Items with a known URL are
+// kept: the kind-specific loops count them as Duplicated.
+func (s *Service) dropHashDuplicates(ctx context.Context, src *entity.Source, feedItems []FeedItem, existsMap map[string]bool, stats *CrawlStats) ([]FeedItem, error) {
+	hashes := make([]string, 0, len(feedItems))
+	for _, item := range feedItems {
+		if !existsMap[articleURLForItem(src, item)] {
+			hashes = append(hashes, contentHashForItem(src, item))
+		}
+	}
+	if len(hashes) == 0 {
+		return feedItems, nil
+	}
+	known, err := s.ArticleRepo.ExistsByContentHashBatch(ctx, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make([]FeedItem, 0, len(feedItems))
+	seen := make(map[string]bool, len(hashes))
+	for _, item := range feedItems {
+		if existsMap[articleURLForItem(src, item)] {
+			kept = append(kept, item)
+			continue
+		}
+		hash := contentHashForItem(src, item)
+		if known[hash] || seen[hash] {
+			atomic.AddInt64(&stats.FeedItems, 1)
+			atomic.AddInt64(&stats.DuplicatedByHash, 1)
+			slog.InfoContext(ctx, "skipped article with known content hash",
+				slog.String("url", item.URL))
+			continue
+		}
+		seen[hash] = true
+		kept = append(kept, item)
+	}
+	return kept, nil
+}
+
+// contentHashForItem is the articles.content_hash of a feed item. It hashes
+// the feed's own content, never the enhanced full text, so the key is the
+// same on every crawl.
+func contentHashForItem(src *entity.Source, item FeedItem) string {
+	return entity.ArticleContentHash(articleURLForItem(src, item), item.Title, item.Content)
+}
+
 // articleURLForItem resolves the value stored in articles.url for a feed
 // item. Podcast episodes may lack a <link> while still carrying a valid
 // enclosure (§5.2); falling back to the enclosure URL keeps the row's
@@ -492,6 +1203,7 @@ func (s *Service) enqueueTranscribeItems(
 	feedItems []FeedItem,
 	existsMap map[string]bool,
 	stats *CrawlStats,
+	run *crawlRun,
 ) error {
 	logger := slog.Default()
 
@@ -521,9 +1233,8 @@ func (s *Service) enqueueTranscribeItems(
 		// URL が空の item は動画を特定できないので第1段(Gemini 呼び出し
 		// +cap 1枠)を消費させず、下の SkippedNoMedia 経路へ直行させる。
 		if src.Kind == entity.SourceKindYouTube && s.VideoDescriber != nil && item.URL != "" {
-			if atomic.LoadInt64(&stats.YouTubeDirectAttempts) >= YouTubeDirectMaxPerCycle {
-				logger.Info("youtube direct cap reached for this cycle, deferring to transcribe queue",
-					slog.Int64("source_id", src.ID),
+			if !run.reserveYouTubeDirect() {
+				logger.InfoContext(ctx, "youtube direct cap reached for this cycle, deferring to transcribe queue",
 					slog.String("url", item.URL),
 					slog.Int("cap", YouTubeDirectMaxPerCycle))
 			} else {
@@ -544,9 +1255,7 @@ func (s *Service) enqueueTranscribeItems(
 		}
 		if mediaURL == "" {
 			atomic.AddInt64(&stats.SkippedNoMedia, 1)
-			logger.Warn("no media URL for feed item, skipping",
-				slog.Int64("source_id", src.ID),
-				slog.String("source_kind", src.Kind),
+			logger.WarnContext(ctx, "no media URL for feed item, skipping",
 				slog.String("url", item.URL),
 				slog.String("title", item.Title))
 			continue
@@ -559,17 +1268,25 @@ func (s *Service) enqueueTranscribeItems(
 			Content:     "", // stored as NULL; the Mac transcribe worker fills it (§5)
 			PublishedAt: item.PublishedAt,
 			CrawledAt:   time.Now(),
+			ContentHash: contentHashForItem(src, item),
 		}
-		if err := s.ArticleRepo.CreateWithTranscribeJob(ctx, art, mediaURL, src.Kind); err != nil {
+		// Announced now, without a summary: the transcript (and with it
+		// the summary) may take until the next night.
+		if err := s.createArticle(ctx, art, func(ctx context.Context) error {
+			return s.ArticleRepo.CreateWithTranscribeJob(ctx, art, mediaURL, src.Kind)
+		}); err != nil {
+			if errors.Is(err, repository.ErrDuplicateContentHash) {
+				atomic.AddInt64(&stats.DuplicatedByHash, 1)
+				continue
+			}
 			return fmt.Errorf("create article with transcribe job in repository: %w", err)
 		}
 		atomic.AddInt64(&stats.Inserted, 1)
 		atomic.AddInt64(&stats.TranscribeEnqueued, 1)
 
-		logger.Info("article enqueued for transcription",
+		logger.InfoContext(ctx, "article enqueued for transcription",
 			slog.Int64("article_id", art.ID),
 			slog.String("url", art.URL),
-			slog.String("source_kind", src.Kind),
 			slog.String("media_url", mediaURL))
 	}
 
@@ -594,8 +1311,7 @@ func (s *Service) describeVideoDirect(ctx context.Context, src *entity.Source, i
 		if ctx.Err() != nil {
 			return false, err
 		}
-		logger.Warn("youtube direct description failed, falling back to transcribe queue",
-			slog.Int64("source_id", src.ID),
+		logger.WarnContext(ctx, "youtube direct description failed, falling back to transcribe queue",
 			slog.String("url", item.URL),
 			slog.String("title", item.Title),
 			slog.Any("error", err))
@@ -619,15 +1335,22 @@ func (s *Service) describeVideoDirect(ctx context.Context, src *entity.Source, i
 		Summary:     summary, // read-only join field; persisted via summaries row below
 		PublishedAt: item.PublishedAt,
 		CrawledAt:   time.Now(),
+		ContentHash: contentHashForItem(src, item),
 	}
 	sum := &entity.Summary{Body: summary, Provider: provider}
-	if err := s.ArticleRepo.CreateWithSummary(ctx, art, sum); err != nil {
+	if err := s.createArticle(ctx, art, func(ctx context.Context) error {
+		return s.ArticleRepo.CreateWithSummary(ctx, art, sum)
+	}); err != nil {
+		if errors.Is(err, repository.ErrDuplicateContentHash) {
+			atomic.AddInt64(&stats.DuplicatedByHash, 1)
+			return true, nil
+		}
 		return false, fmt.Errorf("create article with summary in repository: %w", err)
 	}
 	atomic.AddInt64(&stats.Inserted, 1)
 	atomic.AddInt64(&stats.YouTubeDirectSucceeded, 1)
 
-	logger.Info("youtube video described directly",
+	logger.InfoContext(ctx, "youtube video described directly",
 		slog.Int64("article_id", art.ID),
 		slog.String("url", art.URL),
 		slog.String("summary_provider", provider),
@@ -635,6 +1358,100 @@ func (s *Service) describeVideoDirect(ctx context.Context, src *entity.Source, i
 	return true, nil
 }
 
+// createArticle runs create (one of the ArticleRepo Create methods) and
+// publishes article.created for art. With s.Tx both land in one
+// transaction, so the event cannot be lost after the insert; without it
+// the publish is best-effort.
+func (s *Service) createArticle(ctx context.Context, art *entity.Article, create func(ctx context.Context) error) error {
+	return s.createArticles(ctx, []*entity.Article{art}, create)
+}
+
+// createArticles is createArticle for a batch: article.created goes out
+// for every article create inserted (ID set); skipped ones stay silent.
+func (s *Service) createArticles(ctx context.Context, arts []*entity.Article, create func(ctx context.Context) error) error {
+	if s.Tx == nil || s.Events == nil {
+		if err := create(ctx); err != nil {
+			return err
+		}
+		for _, art := range arts {
+			if art.ID != 0 {
+				s.publish(ctx, entity.WebhookEventArticleCreated, entity.NewWebhookArticleData(art))
+			}
+		}
+		return nil
+	}
+	return s.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := create(ctx); err != nil {
+			return err
+		}
+		for _, art := range arts {
+			if art.ID == 0 {
+				continue
+			}
+			if err := s.Events.Publish(ctx, entity.WebhookEventArticleCreated, entity.NewWebhookArticleData(art)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// publish hands event to s.Events, if any. Failures are logged only: a
+// lost webhook must not fail the crawl that produced it.
+func (s *Service) publish(ctx context.Context, event string, data any) {
+	if s.Events == nil {
+		return
+	}
+	if err := s.Events.Publish(ctx, event, data); err != nil {
+		slog.WarnContext(ctx, "webhook event publish failed",
+			slog.String("event", event),
+			slog.Any("error", err))
+	}
+}
+
+// markNearDuplicate fingerprints art and, when near-duplicate detection
+// is on and another source has a close article, points art.DuplicateOf at
+// that article's group.
+func (s *Service) markNearDuplicate(ctx context.Context, art *entity.Article, stats *CrawlStats) {
+	art.SimHash = entity.ArticleSimHash(art.Title, art.Content)
+	if s.NearDuplicates == nil || s.NearDuplicateMaxDistance <= 0 || art.SimHash == 0 {
+		return
+	}
+	since := art.CrawledAt.Add(-NearDuplicateWindow)
+	canonical, err := s.NearDuplicates.FindNearDuplicate(ctx, art.SimHash, s.NearDuplicateMaxDistance, since, art.SourceID)
+	if err != nil {
+		slog.WarnContext(ctx, "near-duplicate lookup failed", slog.Any("error", err))
+		return
+	}
+	if canonical == 0 {
+		return
+	}
+	art.DuplicateOf = &canonical
+	atomic.AddInt64(&stats.NearDuplicates, 1)
+	slog.InfoContext(ctx, "article marked as near-duplicate",
+		slog.Int64("duplicate_of", canonical))
+}
+
+// saveContent stores the page fetched for articleID in s.ContentRepo, if
+// any. A nil page (RSS content used, or plain ContentFetcher) stores
+// nothing. Failures are logged only: the article is already committed.
+func (s *Service) saveContent(ctx context.Context, articleID int64, page *FetchedPage) {
+	if s.ContentRepo == nil || page == nil {
+		return
+	}
+	content := &entity.ArticleContent{
+		ArticleID: articleID,
+		URL:       page.URL,
+		HTML:      page.HTML,
+		Text:      page.Text,
+	}
+	if err := s.ContentRepo.Save(ctx, content); err != nil {
+		slog.WarnContext(ctx, "failed to store article content",
+			slog.Int64("article_id", articleID),
+			slog.Any("error", err))
+	}
+}
+
 // summarize runs the configured summarizer, additionally reporting the
 // provider name when the summarizer supports it (fallback chain).
 // Returns an empty provider for plain Summarizer implementations.
@@ -648,7 +1465,8 @@ func (s *Service) summarize(ctx context.Context, content string) (summary string
 
 // enhanceContent enhances RSS content by fetching full article content if needed.
 // This method implements the content enhancement logic:
-//  1. Check if ContentFetcher is enabled (nil check)
+//  1. Pick the fetcher (HeadlessFetcher for RenderJS sources, else
+//     ContentFetcher) and check it is enabled (nil check)
 //  2. Check if RSS content is sufficient (>= threshold)
 //  3. Attempt to fetch full content from source URL
 //  4. Use fetched content if longer than RSS content
@@ -658,64 +1476,67 @@ func (s *Service) summarize(ctx context.Context, content string) (summary string
 // This ensures that content fetching failures do not break the crawl pipeline.
 //
 // Parameters:
-//   - ctx: Context for cancellation and timeout
+//   - ctx: Context for cancellation and timeout; carries the item url as a
+//     log attribute (processFeedItems), so the logs here omit it
+//   - src: The item's source (RenderJS selects the headless fetcher)
 //   - item: Feed item containing URL and RSS content
 //
 // Returns:
 //   - string: Enhanced content (either fetched or RSS fallback)
+//   - *FetchedPage: The fetched page when ContentFetcher implements
+//     PageFetcher and the fetch succeeded (stored via ContentRepo even if
+//     the RSS content wins); nil otherwise
 //
 // Behavior:
-//   - ContentFetcher == nil → return RSS content (feature disabled)
+//   - no fetcher for the source → return RSS content (feature disabled)
 //   - RSS length >= threshold → return RSS content (skip fetch)
 //   - RSS length < threshold → attempt fetch, fallback to RSS on error
 //   - Fetched content shorter than RSS → return RSS content
 //
 // Example:
 //
-//	content := s.enhanceContent(ctx, feedItem)
+//	content, page := s.enhanceContent(ctx, src, feedItem)
 //	// content is guaranteed to be non-error, either enhanced or RSS
-func (s *Service) enhanceContent(ctx context.Context, item FeedItem) string {
+func (s *Service) enhanceContent(ctx context.Context, src *entity.Source, item FeedItem) (string, *FetchedPage) {
 	logger := slog.Default()
 
 	// Check if content fetching is enabled
-	if s.ContentFetcher == nil {
+	fetcher, headless := s.contentFetcherFor(src)
+	if fetcher == nil {
 		// Feature disabled, use RSS content
-		return item.Content
+		return item.Content, nil
 	}
 
 	// Check RSS content length threshold
 	rssLength := len(item.Content)
 	if rssLength >= s.contentConfig.Threshold {
 		// RSS content is sufficient, skip fetching
-		logger.Debug("RSS content sufficient, skipping fetch",
-			slog.String("url", item.URL),
+		logger.DebugContext(ctx, "RSS content sufficient, skipping fetch",
 			slog.Int("rss_length", rssLength),
 			slog.Int("threshold", s.contentConfig.Threshold))
-		return item.Content
+		return item.Content, nil
 	}
 
 	// RSS content is insufficient, fetch full article
-	logger.Info("Fetching full article content",
-		slog.String("url", item.URL),
-		slog.Int("rss_length", rssLength))
+	logger.InfoContext(ctx, "Fetching full article content",
+		slog.Int("rss_length", rssLength),
+		slog.Bool("headless", headless))
 
 	fetchStart := time.Now()
-	fullContent, err := s.ContentFetcher.FetchContent(ctx, item.URL)
+	fullContent, page, err := fetchContent(ctx, fetcher, item.URL)
 	fetchDuration := time.Since(fetchStart)
 
 	if err != nil {
 		// Content fetch failed, use RSS fallback
-		logger.Warn("Content fetch failed, using RSS fallback",
-			slog.String("url", item.URL),
+		logger.WarnContext(ctx, "Content fetch failed, using RSS fallback",
 			slog.Any("error", err),
 			slog.Duration("fetch_duration", fetchDuration))
-		return item.Content
+		return item.Content, nil
 	}
 
 	// Content fetch successful
 	fetchedLength := len(fullContent)
-	logger.Info("Content fetch successful",
-		slog.String("url", item.URL),
+	logger.InfoContext(ctx, "Content fetch successful",
 		slog.Int("rss_length", rssLength),
 		slog.Int("fetched_length", fetchedLength),
 		slog.Duration("fetch_duration", fetchDuration))
@@ -723,13 +1544,36 @@ func (s *Service) enhanceContent(ctx context.Context, item FeedItem) string {
 	// Use fetched content only if it's longer than RSS content
 	// This prevents using truncated or poor-quality extracted content
 	if fetchedLength > rssLength {
-		return fullContent
+		return fullContent, page
 	}
 
 	// Fetched content is shorter than RSS, use RSS content
-	logger.Debug("Fetched content shorter than RSS, using RSS",
-		slog.String("url", item.URL),
+	logger.DebugContext(ctx, "Fetched content shorter than RSS, using RSS",
 		slog.Int("rss_length", rssLength),
 		slog.Int("fetched_length", fetchedLength))
-	return item.Content
+	return item.Content, page
+}
+
+// contentFetcherFor returns the fetcher for src's article pages: the
+// headless browser (headless = true) for RenderJS sources when it is
+// enabled, else ContentFetcher. nil disables content enhancement.
+func (s *Service) contentFetcherFor(src *entity.Source) (fetcher ContentFetcher, headless bool) {
+	if src != nil && src.RenderJS && s.HeadlessFetcher != nil {
+		return s.HeadlessFetcher, true
+	}
+	return s.ContentFetcher, false
+}
+
+// fetchContent fetches url through fetcher, using FetchPage when the
+// fetcher implements PageFetcher so the raw page can be stored.
+func fetchContent(ctx context.Context, fetcher ContentFetcher, url string) (string, *FetchedPage, error) {
+	if pf, ok := fetcher.(PageFetcher); ok {
+		page, err := pf.FetchPage(ctx, url)
+		if err != nil {
+			return "", nil, err
+		}
+		return page.Text, page, nil
+	}
+	text, err := fetcher.FetchContent(ctx, url)
+	return text, nil, err
 }
@@ -20,16 +20,26 @@ import (
 type stubSourceRepo struct {
 	sources       []*entity.Source
 	listActiveErr error
+
+	mu         sync.Mutex
+	validators map[int64]fetchUC.FeedValidators
 }
 
 func (s *stubSourceRepo) ListActive(_ context.Context) ([]*entity.Source, error) {
 	return s.sources, s.listActiveErr
 }
 
-// 以下は未使用だが、インターフェース満たすために実装
-func (s *stubSourceRepo) Get(_ context.Context, _ int64) (*entity.Source, error) {
+// Get は CrawlSource(ソース個別スケジュール)用
+func (s *stubSourceRepo) Get(_ context.Context, id int64) (*entity.Source, error) {
+	for _, src := range s.sources {
+		if src.ID == id {
+			return src, nil
+		}
+	}
 	return nil, nil
 }
+
+// 以下は未使用だが、インターフェース満たすために実装
 func (s *stubSourceRepo) List(_ context.Context) ([]*entity.Source, error) {
 	return nil, nil
 }
@@ -45,6 +55,17 @@ func (s *stubSourceRepo) Update(_ context.Context, _ *entity.Source) error {
 func (s *stubSourceRepo) Delete(_ context.Context, _ int64) error {
 	return nil
 }
+
+// UpdateFeedValidators は条件付き GET のテスト用に保存内容を記録する
+func (s *stubSourceRepo) UpdateFeedValidators(_ context.Context, id int64, etag, lastModified string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.validators == nil {
+		s.validators = make(map[int64]fetchUC.FeedValidators)
+	}
+	s.validators[id] = fetchUC.FeedValidators{ETag: etag, LastModified: lastModified}
+	return nil
+}
 func (s *stubSourceRepo) SearchWithFilters(_ context.Context, _ []string, _ repository.SourceSearchFilters) ([]*entity.Source, error) {
 	return nil, nil
 }
@@ -58,19 +79,24 @@ type transcribeJob struct {
 }
 
 // stubArticleRepo はArticleRepositoryのモック実装。
-// summaries は CreateWithSummary で記事と同時に永続化された要約を
-// article_id ごとに記録する（summaries.provider の検証用）。
+// summaries は CreateBatch / CreateWithSummary で記事と同時に永続化された
+// 要約を article_id ごとに記録する（summaries.provider の検証用）。
 // transcribeJobs は CreateWithTranscribeJob の enqueue 記録。
+// batchConflicts の URL は CreateBatch が ON CONFLICT DO NOTHING で
+// 読み飛ばす(並行クロールが先に入れた記事)。
 type stubArticleRepo struct {
 	mu                  sync.Mutex
 	articles            []*entity.Article
 	summaries           map[int64]*entity.Summary
 	transcribeJobs      []transcribeJob
 	existsMap           map[string]bool
+	hashExists          map[string]bool
 	existsErr           error
 	createErr           error
 	listUnsummarizedErr error
 	nextID              int64
+	batchConflicts      map[string]bool
+	batchCalls          int
 }
 
 func (s *stubArticleRepo) ExistsByURLBatch(_ context.Context, urls []string) (map[string]bool, error) {
@@ -86,6 +112,16 @@ func (s *stubArticleRepo) ExistsByURLBatch(_ context.Context, urls []string) (ma
 	return result, nil
 }
 
+func (s *stubArticleRepo) ExistsByContentHashBatch(_ context.Context, hashes []string) (map[string]bool, error) {
+	result := make(map[string]bool)
+	for _, hash := range hashes {
+		if s.hashExists[hash] {
+			result[hash] = true
+		}
+	}
+	return result, nil
+}
+
 func (s *stubArticleRepo) Create(_ context.Context, a *entity.Article) error {
 	if s.createErr != nil {
 		return s.createErr
@@ -115,6 +151,31 @@ func (s *stubArticleRepo) CreateWithSummary(_ context.Context, a *entity.Article
 	return nil
 }
 
+func (s *stubArticleRepo) CreateBatch(_ context.Context, items []repository.NewArticle) (int, error) {
+	if s.createErr != nil {
+		return 0, s.createErr
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batchCalls++
+	inserted := 0
+	for _, item := range items {
+		if s.batchConflicts[item.Article.URL] {
+			continue
+		}
+		s.nextID++
+		item.Article.ID = s.nextID
+		s.articles = append(s.articles, item.Article)
+		item.Summary.ArticleID = item.Article.ID
+		if s.summaries == nil {
+			s.summaries = make(map[int64]*entity.Summary)
+		}
+		s.summaries[item.Article.ID] = item.Summary
+		inserted++
+	}
+	return inserted, nil
+}
+
 func (s *stubArticleRepo) CreateWithTranscribeJob(_ context.Context, a *entity.Article, mediaURL, sourceKind string) error {
 	if s.createErr != nil {
 		return s.createErr
@@ -160,7 +221,14 @@ func (s *stubArticleRepo) ListUnsummarized(_ context.Context, limit int) ([]*ent
 func (s *stubArticleRepo) List(_ context.Context) ([]*entity.Article, error) {
 	return nil, nil
 }
-func (s *stubArticleRepo) Get(_ context.Context, _ int64) (*entity.Article, error) {
+func (s *stubArticleRepo) Get(_ context.Context, id int64) (*entity.Article, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, a := range s.articles {
+		if a.ID == id {
+			return a, nil
+		}
+	}
 	return nil, nil
 }
 func (s *stubArticleRepo) Search(_ context.Context, _ string) ([]*entity.Article, error) {
@@ -172,6 +240,12 @@ func (s *stubArticleRepo) Update(_ context.Context, _ *entity.Article) error {
 func (s *stubArticleRepo) Delete(_ context.Context, _ int64) error {
 	return nil
 }
+func (s *stubArticleRepo) DeleteBatch(_ context.Context, _ []int64) ([]int64, error) {
+	return nil, nil
+}
+func (s *stubArticleRepo) ForEachWithSource(_ context.Context, _ []string, _ repository.ArticleSearchFilters, _ func(repository.ArticleWithSource) error) error {
+	return nil
+}
 func (s *stubArticleRepo) ExistsByURL(_ context.Context, _ string) (bool, error) {
 	return false, nil
 }
@@ -197,6 +271,10 @@ func (s *stubArticleRepo) SearchWithFiltersPaginated(_ context.Context, _ []stri
 	return nil, nil
 }
 
+func (s *stubArticleRepo) ListWithSourceAfter(_ context.Context, _ []string, _ repository.ArticleSearchFilters, _ *repository.ArticleCursor, _ int) ([]repository.ArticleWithSource, error) {
+	return nil, nil
+}
+
 // stubFeedFetcher はFeedFetcherのモック実装
 type stubFeedFetcher struct {
 	items []fetchUC.FeedItem
@@ -1151,3 +1229,73 @@ func TestService_CrawlAllSources_ContextCancellation(t *testing.T) {
 	// Stats may vary depending on timing, so we just verify error was returned
 	_ = stats // Stats are not deterministic with concurrent operations
 }
+
+// urlFeedFetcher returns a per-feed-URL result, for crawls over several
+// sources that must behave differently.
+type urlFeedFetcher struct {
+	items map[string][]fetchUC.FeedItem
+	errs  map[string]error
+}
+
+func (f *urlFeedFetcher) Fetch(_ context.Context, url string) ([]fetchUC.FeedItem, error) {
+	return f.items[url], f.errs[url]
+}
+
+// TestService_CrawlAllSources_PerSourceStats: ソース単位の統計で、壊れた
+// フィード(404)を集計値に埋もれさせずに特定できること。
+func TestService_CrawlAllSources_PerSourceStats(t *testing.T) {
+	now := time.Now()
+	srcRepo := &stubSourceRepo{
+		sources: []*entity.Source{
+			{ID: 1, FeedURL: "https://a.example/feed", Active: true},
+			{ID: 2, FeedURL: "https://b.example/feed", Active: true},
+			{ID: 3, FeedURL: "https://c.example/feed", Active: true},
+		},
+	}
+	artRepo := &stubArticleRepo{
+		existsMap: map[string]bool{"https://a.example/1": true},
+	}
+	fetcher := &urlFeedFetcher{
+		items: map[string][]fetchUC.FeedItem{
+			"https://a.example/feed": {
+				{Title: "A1", URL: "https://a.example/1", Content: "c", PublishedAt: now},
+				{Title: "A2", URL: "https://a.example/2", Content: "c", PublishedAt: now},
+			},
+		},
+		errs: map[string]error{
+			"https://b.example/feed": &fetchUC.FeedStatusError{StatusCode: 404, Status: "404 Not Found"},
+			"https://c.example/feed": errors.New("dial tcp: i/o timeout"),
+		},
+	}
+
+	svc := fetchUC.NewService(srcRepo, artRepo, &stubSummarizer{result: "s"}, fetcher, nil,
+		fetchUC.ContentFetchConfig{Parallelism: 10, Threshold: 1500})
+
+	stats, err := svc.CrawlAllSources(context.Background())
+	if err != nil {
+		t.Fatalf("CrawlAllSources() error = %v", err)
+	}
+	if !assert.Len(t, stats.PerSource, 3) {
+		return
+	}
+
+	a := stats.PerSource[0]
+	assert.Equal(t, int64(1), a.SourceID)
+	assert.Equal(t, 200, a.HTTPStatus)
+	assert.Equal(t, int64(2), a.FeedItems)
+	assert.Equal(t, int64(1), a.Inserted)
+	assert.Equal(t, int64(1), a.Duplicated)
+	assert.Empty(t, a.FetchError)
+
+	b := stats.PerSource[1]
+	assert.Equal(t, int64(2), b.SourceID)
+	assert.Equal(t, 404, b.HTTPStatus)
+	assert.NotEmpty(t, b.FetchError)
+	assert.Zero(t, b.FeedItems)
+
+	c := stats.PerSource[2]
+	assert.Equal(t, 0, c.HTTPStatus, "no response received")
+	assert.NotEmpty(t, c.FetchError)
+
+	assert.Equal(t, 2, stats.FetchFailedSources())
+}
@@ -0,0 +1,175 @@
+package fetch_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	fetchUC "catchup-feed/internal/usecase/fetch"
+)
+
+// concurrencyFetcher は同時に走っている Fetch の最大数を記録する。
+// hang に含まれる URL は ctx が切れるまで戻らない(遅いフィードの再現)。
+type concurrencyFetcher struct {
+	inFlight atomic.Int64
+	maxSeen  atomic.Int64
+	delay    time.Duration
+	hang     map[string]bool
+
+	mu    sync.Mutex
+	order []string
+}
+
+func (f *concurrencyFetcher) Fetch(ctx context.Context, url string) ([]fetchUC.FeedItem, error) {
+	f.mu.Lock()
+	f.order = append(f.order, url)
+	f.mu.Unlock()
+
+	n := f.inFlight.Add(1)
+	defer f.inFlight.Add(-1)
+	for {
+		seen := f.maxSeen.Load()
+		if n <= seen || f.maxSeen.CompareAndSwap(seen, n) {
+			break
+		}
+	}
+
+	if f.hang[url] {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	select {
+	case <-time.After(f.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return []fetchUC.FeedItem{
+		{Title: "A", URL: url + "/a", Content: "c", PublishedAt: time.Now()},
+		{Title: "B", URL: url + "/b", Content: "c", PublishedAt: time.Now()},
+	}, nil
+}
+
+func rssSources(n int) []*entity.Source {
+	srcs := make([]*entity.Source, n)
+	for i := range srcs {
+		srcs[i] = &entity.Source{
+			ID:      int64(i + 1),
+			FeedURL: fmt.Sprintf("https://example.com/feed%d", i+1),
+			Kind:    entity.SourceKindRSS,
+			Active:  true,
+		}
+	}
+	return srcs
+}
+
+func newConcurrentService(srcs []*entity.Source, fetcher fetchUC.FeedFetcher, concurrency int) (fetchUC.Service, *stubArticleRepo) {
+	artRepo := &stubArticleRepo{}
+	svc := fetchUC.NewService(
+		&stubSourceRepo{sources: srcs}, artRepo, &stubSummarizer{result: "summary"}, fetcher, nil,
+		fetchUC.ContentFetchConfig{Parallelism: 10, Threshold: 1500},
+	)
+	svc.SourceConcurrency = concurrency
+	return svc, artRepo
+}
+
+/* ───────── ソース並列数 ───────── */
+
+func TestService_CrawlAllSources_BoundedSourceConcurrency(t *testing.T) {
+	fetcher := &concurrencyFetcher{delay: 30 * time.Millisecond}
+	svc, artRepo := newConcurrentService(rssSources(8), fetcher, 3)
+
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+
+	assert.LessOrEqual(t, fetcher.maxSeen.Load(), int64(3), "never more than CRAWL_CONCURRENCY sources at once")
+	assert.Greater(t, fetcher.maxSeen.Load(), int64(1), "sources must overlap")
+
+	// 集計はソース単位の合計と一致する
+	assert.Equal(t, 8, stats.Sources)
+	assert.Equal(t, int64(16), stats.FeedItems)
+	assert.Equal(t, int64(16), stats.Inserted)
+	assert.Len(t, artRepo.articles, 16)
+	require.Len(t, stats.PerSource, 8)
+	for i, ps := range stats.PerSource {
+		assert.Equal(t, int64(i+1), ps.SourceID, "PerSource keeps the dispatch order")
+		assert.Equal(t, int64(2), ps.FeedItems)
+		assert.Equal(t, int64(2), ps.Inserted)
+	}
+}
+
+func TestService_CrawlAllSources_SequentialByDefault(t *testing.T) {
+	fetcher := &concurrencyFetcher{delay: 5 * time.Millisecond}
+	svc, _ := newConcurrentService(rssSources(4), fetcher, 0)
+
+	_, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), fetcher.maxSeen.Load())
+	assert.Equal(t, []string{
+		"https://example.com/feed1",
+		"https://example.com/feed2",
+		"https://example.com/feed3",
+		"https://example.com/feed4",
+	}, fetcher.order)
+}
+
+/* ───────── ソース単位のタイムアウト ───────── */
+
+func TestService_CrawlAllSources_SourceTimeoutSkipsOnlyThatSource(t *testing.T) {
+	fetcher := &concurrencyFetcher{hang: map[string]bool{"https://example.com/feed2": true}}
+	svc, _ := newConcurrentService(rssSources(3), fetcher, 2)
+	svc.SourceTimeout = 50 * time.Millisecond
+
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err, "a slow source must not abort the crawl")
+	assert.Equal(t, int64(4), stats.Inserted)
+
+	require.Len(t, stats.PerSource, 3)
+	assert.False(t, stats.PerSource[0].TimedOut)
+	assert.True(t, stats.PerSource[1].TimedOut)
+	assert.NotEmpty(t, stats.PerSource[1].FetchError)
+	assert.False(t, stats.PerSource[2].TimedOut)
+}
+
+func TestService_CrawlAllSources_ParentCancelStillAborts(t *testing.T) {
+	fetcher := &concurrencyFetcher{hang: map[string]bool{"https://example.com/feed1": true}}
+	svc, _ := newConcurrentService(rssSources(1), fetcher, 2)
+	svc.SourceTimeout = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	stats, err := svc.CrawlAllSources(ctx)
+	require.NoError(t, err, "a fetch failure is recorded, not returned")
+	require.Len(t, stats.PerSource, 1)
+	assert.False(t, stats.PerSource[0].TimedOut, "the crawl deadline is not a source timeout")
+}
+
+/* ───────── §5.1 第1段の上限はサイクル全体で共有 ───────── */
+
+func TestService_CrawlAllSources_YouTubeDirectCapSharedAcrossSources(t *testing.T) {
+	srcs := make([]*entity.Source, 4)
+	feeds := map[string][]fetchUC.FeedItem{}
+	for i := range srcs {
+		url := fmt.Sprintf("https://example.com/yt%d", i+1)
+		srcs[i] = &entity.Source{ID: int64(i + 1), FeedURL: url, Kind: entity.SourceKindYouTube, Active: true}
+		feeds[url] = []fetchUC.FeedItem{
+			{Title: "V1", URL: fmt.Sprintf("https://www.youtube.com/watch?v=%d-1", i+1), PublishedAt: time.Now()},
+			{Title: "V2", URL: fmt.Sprintf("https://www.youtube.com/watch?v=%d-2", i+1), PublishedAt: time.Now()},
+		}
+	}
+	svc, _ := newConcurrentService(srcs, &orderRecordingFetcher{feeds: feeds}, 4)
+	describer := &stubVideoDescriber{transcript: "t", summary: "s"}
+	svc.VideoDescriber = describer
+
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, fetchUC.YouTubeDirectMaxPerCycle, describer.callCount())
+	assert.Equal(t, int64(fetchUC.YouTubeDirectMaxPerCycle), stats.YouTubeDirectAttempts)
+	assert.Equal(t, int64(8-fetchUC.YouTubeDirectMaxPerCycle), stats.TranscribeEnqueued)
+}
@@ -0,0 +1,69 @@
+package fetch_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	fetchUC "catchup-feed/internal/usecase/fetch"
+)
+
+// stubHealthRepo は RecordFetch の呼び出しを記録する。
+type stubHealthRepo struct {
+	mu      sync.Mutex
+	records []healthRecord
+}
+
+type healthRecord struct {
+	SourceID   int64
+	HTTPStatus int
+	FetchErr   string
+}
+
+func (s *stubHealthRepo) RecordFetch(_ context.Context, sourceID int64, _ time.Time, httpStatus int, fetchErr string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, healthRecord{sourceID, httpStatus, fetchErr})
+	return nil
+}
+
+func (s *stubHealthRepo) Get(_ context.Context, _ int64) (*entity.SourceHealth, error) {
+	return nil, nil
+}
+
+func (s *stubHealthRepo) List(_ context.Context) ([]*entity.SourceHealth, error) {
+	return nil, nil
+}
+
+func TestService_CrawlAllSources_RecordsSourceHealth(t *testing.T) {
+	srcRepo := &stubSourceRepo{
+		sources: []*entity.Source{
+			{ID: 1, FeedURL: "https://example.com/ok", Kind: entity.SourceKindRSS, Active: true},
+			{ID: 2, FeedURL: "https://example.com/broken", Kind: entity.SourceKindRSS, Active: true},
+		},
+	}
+	fetcher := &orderRecordingFetcher{
+		feeds: map[string][]fetchUC.FeedItem{
+			"https://example.com/ok": {{Title: "A", URL: "https://example.com/a", Content: "c", PublishedAt: time.Now()}},
+		},
+	}
+	health := &stubHealthRepo{}
+	svc := fetchUC.NewService(
+		srcRepo, &stubArticleRepo{}, &stubSummarizer{}, fetcher, nil,
+		fetchUC.ContentFetchConfig{Parallelism: 10, Threshold: 1500},
+	)
+	svc.HealthRepo = health
+
+	_, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []healthRecord{
+		{SourceID: 1, HTTPStatus: 200},
+		{SourceID: 2, HTTPStatus: 0, FetchErr: "unknown feed URL"},
+	}, health.records)
+}
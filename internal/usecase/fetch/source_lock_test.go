@@ -0,0 +1,88 @@
+package fetch_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	fetchUC "catchup-feed/internal/usecase/fetch"
+)
+
+// stubSourceLocker は held のソースのロック取得を拒否し、解放を記録する。
+type stubSourceLocker struct {
+	mu       sync.Mutex
+	held     map[int64]bool
+	err      error
+	unlocked []int64
+}
+
+func (l *stubSourceLocker) TryLockSource(_ context.Context, sourceID int64) (func(), bool, error) {
+	if l.err != nil {
+		return nil, false, l.err
+	}
+	if l.held[sourceID] {
+		return nil, false, nil
+	}
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.unlocked = append(l.unlocked, sourceID)
+	}, true, nil
+}
+
+func newLockingService(locker fetchUC.SourceLocker) (fetchUC.Service, *orderRecordingFetcher) {
+	srcRepo := &stubSourceRepo{
+		sources: []*entity.Source{
+			{ID: 1, FeedURL: "https://example.com/one", Kind: entity.SourceKindRSS, Active: true},
+			{ID: 2, FeedURL: "https://example.com/two", Kind: entity.SourceKindRSS, Active: true},
+		},
+	}
+	fetcher := &orderRecordingFetcher{
+		feeds: map[string][]fetchUC.FeedItem{
+			"https://example.com/one": {{Title: "A", URL: "https://example.com/a", Content: "c", PublishedAt: time.Now()}},
+			"https://example.com/two": {{Title: "B", URL: "https://example.com/b", Content: "c", PublishedAt: time.Now()}},
+		},
+	}
+	svc := fetchUC.NewService(
+		srcRepo, &stubArticleRepo{}, &stubSummarizer{result: "summary"}, fetcher, nil,
+		fetchUC.ContentFetchConfig{Parallelism: 10, Threshold: 1500},
+	)
+	svc.Locker = locker
+	return svc, fetcher
+}
+
+/* ───────── ソース単位のクロールロック ───────── */
+
+func TestService_CrawlAllSources_SkipsSourcesLockedElsewhere(t *testing.T) {
+	locker := &stubSourceLocker{held: map[int64]bool{2: true}}
+	svc, fetcher := newLockingService(locker)
+
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"https://example.com/one"}, fetcher.order,
+		"a source locked by another replica must not be fetched")
+	assert.Equal(t, int64(1), stats.Inserted)
+	assert.Equal(t, int64(1), stats.SkippedLocked)
+	require.Len(t, stats.PerSource, 2)
+	assert.False(t, stats.PerSource[0].Locked)
+	assert.True(t, stats.PerSource[1].Locked)
+	assert.Equal(t, 0, stats.FetchFailedSources(), "a skipped source is not a fetch failure")
+	assert.Equal(t, []int64{1}, locker.unlocked)
+}
+
+func TestService_CrawlAllSources_LockErrorCrawlsAnyway(t *testing.T) {
+	svc, fetcher := newLockingService(&stubSourceLocker{err: errors.New("db down")})
+
+	stats, err := svc.CrawlAllSources(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, fetcher.order, 2)
+	assert.Equal(t, int64(2), stats.Inserted)
+	assert.Zero(t, stats.SkippedLocked)
+}
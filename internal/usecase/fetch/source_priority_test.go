@@ -15,17 +15,22 @@ import (
 )
 
 // orderRecordingFetcher records the order in which feed URLs are fetched.
-// CrawlAllSources はソースを逐次処理するので、fetch 順 = ソース処理順。
+// SourceConcurrency 未設定の CrawlAllSources はソースを逐次処理するので、
+// fetch 順 = ソース処理順。
 type orderRecordingFetcher struct {
 	mu    sync.Mutex
 	order []string
 	feeds map[string][]fetchUC.FeedItem
+	errs  map[string]error
 }
 
 func (f *orderRecordingFetcher) Fetch(_ context.Context, url string) ([]fetchUC.FeedItem, error) {
 	f.mu.Lock()
 	f.order = append(f.order, url)
 	f.mu.Unlock()
+	if err, ok := f.errs[url]; ok {
+		return nil, err
+	}
 	if items, ok := f.feeds[url]; ok {
 		return items, nil
 	}
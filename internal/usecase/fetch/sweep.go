@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/pkg/logging"
 )
 
 // DefaultSweepLimit bounds one sweep cycle (§5.2b: 1サイクルの処理上限).
@@ -27,7 +28,7 @@ type SweepStats struct {
 
 // SweepUnsummarized summarizes articles whose content was filled in after
 // insert — the transcribe path of Phase 2 §5.2b. RSS articles never
-// qualify: CreateWithSummary persists article + summary atomically, so
+// qualify: CreateBatch persists article + summary atomically, so
 // "content present, summary missing" is by itself the correct target
 // definition and no kind filter is needed.
 //
@@ -50,7 +51,7 @@ func (s *Service) SweepUnsummarized(ctx context.Context) (*SweepStats, error) {
 	stats.Candidates = len(articles)
 	stats.LimitHit = len(articles) == DefaultSweepLimit
 	if stats.LimitHit {
-		logger.Warn("summary sweep hit the per-cycle limit, remainder deferred to next cycle",
+		logger.WarnContext(ctx, "summary sweep hit the per-cycle limit, remainder deferred to next cycle",
 			slog.Int("limit", DefaultSweepLimit))
 	}
 
@@ -63,7 +64,12 @@ func (s *Service) SweepUnsummarized(ctx context.Context) (*SweepStats, error) {
 			return stats, ctx.Err()
 		}
 
-		summary, provider, err := s.summarize(ctx, art.Content)
+		// 要約チェーン内の provider ごとのログにも article を載せる。
+		artCtx := logging.WithAttrs(ctx,
+			slog.Int64("article_id", art.ID),
+			slog.String("url", art.URL))
+
+		summary, provider, err := s.summarize(artCtx, art.Content)
 		if err != nil {
 			// Judge criticality by ctx, not errors.Is: provider timeouts
 			// wrap context.DeadlineExceeded while the sweep itself is
@@ -73,9 +79,7 @@ func (s *Service) SweepUnsummarized(ctx context.Context) (*SweepStats, error) {
 				return stats, err
 			}
 			stats.Failed++
-			logger.Warn("sweep summarization failed, article left for next cycle",
-				slog.Int64("article_id", art.ID),
-				slog.String("url", art.URL),
+			logger.WarnContext(artCtx, "sweep summarization failed, article left for next cycle",
 				slog.Any("error", err))
 			continue
 		}
@@ -90,9 +94,7 @@ func (s *Service) SweepUnsummarized(ctx context.Context) (*SweepStats, error) {
 		}
 		stats.Summarized++
 
-		logger.Info("swept article summarized",
-			slog.Int64("article_id", art.ID),
-			slog.String("url", art.URL),
+		logger.InfoContext(artCtx, "swept article summarized",
 			slog.String("summary_provider", provider))
 	}
 
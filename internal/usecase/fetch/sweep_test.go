@@ -208,3 +208,58 @@ func TestService_SweepUnsummarized_Limit(t *testing.T) {
 	assert.Equal(t, int64(fetchUC.DefaultSweepLimit), stats.Summarized)
 	assert.Len(t, sumRepo.upserts, fetchUC.DefaultSweepLimit)
 }
+
+/* ───────── SummarizeArticle (POST /articles/{id}/summarize) ───────── */
+
+func TestService_SummarizeArticle(t *testing.T) {
+	artRepo := &stubArticleRepo{articles: []*entity.Article{
+		{ID: 7, URL: "https://example.com/a", Content: "full text", Summary: "old summary"},
+	}}
+	sumRepo := &stubSummaryRepo{}
+	svc := newSweepService(artRepo, sumRepo, &stubSummarizer{result: "new summary"})
+
+	sum, err := svc.SummarizeArticle(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, &entity.Summary{ArticleID: 7, Body: "new summary", Provider: entity.SummaryProviderUnknown}, sum)
+	assert.Equal(t, sum, sumRepo.upserts[7], "the summary is replaced")
+}
+
+func TestService_SummarizeArticle_Errors(t *testing.T) {
+	artRepo := &stubArticleRepo{articles: []*entity.Article{
+		{ID: 7, Content: "full text"},
+		{ID: 8, Content: "  "},
+	}}
+
+	t.Run("unknown article", func(t *testing.T) {
+		svc := newSweepService(artRepo, &stubSummaryRepo{}, &stubSummarizer{})
+		_, err := svc.SummarizeArticle(context.Background(), 99)
+		assert.ErrorIs(t, err, fetchUC.ErrArticleNotFound)
+	})
+
+	t.Run("no content", func(t *testing.T) {
+		svc := newSweepService(artRepo, &stubSummaryRepo{}, &stubSummarizer{})
+		_, err := svc.SummarizeArticle(context.Background(), 8)
+		assert.ErrorIs(t, err, fetchUC.ErrNoContent)
+	})
+
+	t.Run("summarizer failure is returned", func(t *testing.T) {
+		sumRepo := &stubSummaryRepo{}
+		svc := newSweepService(artRepo, sumRepo, &stubSummarizer{err: errors.New("all providers down")})
+		_, err := svc.SummarizeArticle(context.Background(), 7)
+		assert.ErrorIs(t, err, fetchUC.ErrSummarizationFailed)
+		assert.Empty(t, sumRepo.upserts, "the old summary is kept")
+	})
+
+	t.Run("upsert failure", func(t *testing.T) {
+		svc := newSweepService(artRepo, &stubSummaryRepo{upsertErr: errors.New("db down")}, &stubSummarizer{})
+		_, err := svc.SummarizeArticle(context.Background(), 7)
+		assert.Error(t, err)
+	})
+
+	t.Run("requires SummaryRepo", func(t *testing.T) {
+		svc := newSweepService(artRepo, &stubSummaryRepo{}, &stubSummarizer{})
+		svc.SummaryRepo = nil
+		_, err := svc.SummarizeArticle(context.Background(), 7)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,29 @@
+// Package mfa provides TOTP multi-factor authentication for admin
+// accounts: enrollment (secret + otpauth:// provisioning URI, confirmed by
+// a first valid code), disabling, and the code check of the second login
+// step. Codes are single-use: an accepted time step is recorded and never
+// accepted again.
+package mfa
+
+import "errors"
+
+// Sentinel errors. Messages contain respond.SafeError's safe words
+// ("cannot be", "not found", "invalid") so they reach the client verbatim.
+var (
+	// ErrAdminOnly rejects enrollment for viewers and custom roles.
+	ErrAdminOnly = errors.New("mfa cannot be enabled for this account: admin accounts only")
+
+	// ErrAccountNotFound indicates the subject is not an active account.
+	ErrAccountNotFound = errors.New("account not found")
+
+	// ErrAlreadyEnabled rejects a new enrollment while MFA is on; it must
+	// be disabled (with a valid code) first.
+	ErrAlreadyEnabled = errors.New("mfa cannot be enrolled again while it is enabled")
+
+	// ErrNotEnrolled indicates there is no pending (confirm) or enabled
+	// (verify / disable) enrollment.
+	ErrNotEnrolled = errors.New("mfa enrollment not found")
+
+	// ErrInvalidCode indicates a wrong, expired or already used code.
+	ErrInvalidCode = errors.New("code is invalid")
+)
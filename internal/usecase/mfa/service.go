@@ -0,0 +1,179 @@
+package mfa
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// roleAdmin mirrors usecase/user.RoleAdmin: MFA is offered to admins only.
+const roleAdmin = "admin"
+
+// DefaultIssuer labels the entry in the authenticator app.
+const DefaultIssuer = "catchup-feed"
+
+// codeSkew is how many 30-second steps of clock drift are tolerated either
+// way.
+const codeSkew = 1
+
+// Enrollment is a started enrollment: the secret for manual entry and the
+// otpauth:// URI for a QR code.
+type Enrollment struct {
+	Secret string
+	URI    string
+}
+
+// Service provides the MFA use cases. Accounts are identified by email
+// (the JWT sub).
+type Service struct {
+	Users repository.UserRepository
+	MFA   repository.MFARepository
+	// Issuer is the authenticator app label; "" means DefaultIssuer.
+	Issuer string
+	// Now returns the current time; nil means time.Now.
+	Now func() time.Time
+}
+
+func (s *Service) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+func (s *Service) issuer() string {
+	if s.Issuer != "" {
+		return s.Issuer
+	}
+	return DefaultIssuer
+}
+
+func (s *Service) account(ctx context.Context, email string) (*entity.User, error) {
+	user, err := s.Users.GetActiveByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		return nil, fmt.Errorf("get account: %w", err)
+	}
+	if user == nil {
+		return nil, ErrAccountNotFound
+	}
+	return user, nil
+}
+
+// Enroll starts (or restarts) enrollment for an admin: a new secret is
+// stored pending and returned once. MFA is not required until Confirm.
+func (s *Service) Enroll(ctx context.Context, email string) (*Enrollment, error) {
+	user, err := s.account(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != roleAdmin {
+		return nil, ErrAdminOnly
+	}
+	secret, err := entity.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	saved, err := s.MFA.SavePending(ctx, user.ID, secret)
+	if err != nil {
+		return nil, fmt.Errorf("save mfa enrollment: %w", err)
+	}
+	if !saved {
+		return nil, ErrAlreadyEnabled
+	}
+	return &Enrollment{Secret: secret, URI: entity.TOTPProvisioningURI(s.issuer(), user.Email, secret)}, nil
+}
+
+// Confirm enables the pending enrollment once code proves the
+// authenticator app is set up. From then on login needs a code.
+func (s *Service) Confirm(ctx context.Context, email, code string) error {
+	user, err := s.account(ctx, email)
+	if err != nil {
+		return err
+	}
+	m, err := s.MFA.Get(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("get mfa enrollment: %w", err)
+	}
+	if m == nil {
+		return ErrNotEnrolled
+	}
+	if m.IsEnabled() {
+		return ErrAlreadyEnabled
+	}
+	step, ok := entity.MatchTOTP(m.Secret, strings.TrimSpace(code), s.now(), codeSkew)
+	if !ok {
+		return ErrInvalidCode
+	}
+	if err := s.MFA.Enable(ctx, user.ID, step, s.now()); err != nil {
+		return fmt.Errorf("enable mfa: %w", err)
+	}
+	return nil
+}
+
+// Disable turns MFA off. A valid code is required, so a hijacked session
+// alone cannot remove the second factor.
+func (s *Service) Disable(ctx context.Context, email, code string) error {
+	user, err := s.account(ctx, email)
+	if err != nil {
+		return err
+	}
+	if err := s.verify(ctx, user.ID, code); err != nil {
+		return err
+	}
+	if err := s.MFA.Delete(ctx, user.ID); err != nil {
+		return fmt.Errorf("disable mfa: %w", err)
+	}
+	return nil
+}
+
+// Required reports whether email's login needs a code (MFA enabled).
+// Unknown accounts report false; their login fails elsewhere.
+func (s *Service) Required(ctx context.Context, email string) (bool, error) {
+	user, err := s.Users.GetActiveByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		return false, fmt.Errorf("get account: %w", err)
+	}
+	if user == nil {
+		return false, nil
+	}
+	m, err := s.MFA.Get(ctx, user.ID)
+	if err != nil {
+		return false, fmt.Errorf("get mfa enrollment: %w", err)
+	}
+	return m != nil && m.IsEnabled(), nil
+}
+
+// Verify checks the second-step login code. Each code is accepted once.
+func (s *Service) Verify(ctx context.Context, email, code string) error {
+	user, err := s.account(ctx, email)
+	if err != nil {
+		return err
+	}
+	return s.verify(ctx, user.ID, code)
+}
+
+func (s *Service) verify(ctx context.Context, userID int64, code string) error {
+	m, err := s.MFA.Get(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get mfa enrollment: %w", err)
+	}
+	if m == nil || !m.IsEnabled() {
+		return ErrNotEnrolled
+	}
+	step, ok := entity.MatchTOTP(m.Secret, strings.TrimSpace(code), s.now(), codeSkew)
+	if !ok || step <= m.LastStep {
+		return ErrInvalidCode
+	}
+	fresh, err := s.MFA.UseStep(ctx, userID, step)
+	if err != nil {
+		return fmt.Errorf("record mfa code: %w", err)
+	}
+	if !fresh {
+		return ErrInvalidCode
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package mfa
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+/* ───────── モック実装 ───────── */
+
+type stubUserRepo struct {
+	users []*entity.User
+}
+
+func (s *stubUserRepo) Create(context.Context, *entity.User) error { return nil }
+func (s *stubUserRepo) Get(context.Context, int64) (*entity.User, error) {
+	return nil, nil
+}
+func (s *stubUserRepo) List(context.Context) ([]*entity.User, error) { return s.users, nil }
+func (s *stubUserRepo) Update(context.Context, *entity.User) error   { return nil }
+func (s *stubUserRepo) Deactivate(context.Context, int64, time.Time) error {
+	return nil
+}
+func (s *stubUserRepo) Reactivate(context.Context, int64) error { return nil }
+func (s *stubUserRepo) Delete(context.Context, int64) error     { return nil }
+func (s *stubUserRepo) CountActiveAdmins(context.Context) (int, error) {
+	return 1, nil
+}
+
+func (s *stubUserRepo) GetActiveByEmail(_ context.Context, email string) (*entity.User, error) {
+	for _, u := range s.users {
+		if u.Email == email && u.IsActive() {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+type stubMFARepo struct {
+	rows map[int64]*entity.UserMFA
+}
+
+func (s *stubMFARepo) Get(_ context.Context, userID int64) (*entity.UserMFA, error) {
+	return s.rows[userID], nil
+}
+
+func (s *stubMFARepo) SavePending(_ context.Context, userID int64, secret string) (bool, error) {
+	if m, ok := s.rows[userID]; ok && m.IsEnabled() {
+		return false, nil
+	}
+	s.rows[userID] = &entity.UserMFA{UserID: userID, Secret: secret}
+	return true, nil
+}
+
+func (s *stubMFARepo) Enable(_ context.Context, userID, step int64, t time.Time) error {
+	m := s.rows[userID]
+	m.EnabledAt, m.LastStep = &t, step
+	return nil
+}
+
+func (s *stubMFARepo) UseStep(_ context.Context, userID, step int64) (bool, error) {
+	m := s.rows[userID]
+	if m.LastStep >= step {
+		return false, nil
+	}
+	m.LastStep = step
+	return true, nil
+}
+
+func (s *stubMFARepo) Delete(_ context.Context, userID int64) error {
+	delete(s.rows, userID)
+	return nil
+}
+
+/* ───────── テストケース ───────── */
+
+const adminEmail = "admin@example.com"
+
+func newTestService(now *time.Time) (*Service, *stubMFARepo) {
+	users := &stubUserRepo{users: []*entity.User{
+		{ID: 1, Email: adminEmail, Role: roleAdmin},
+		{ID: 2, Email: "friend@example.com", Role: "viewer"},
+	}}
+	repo := &stubMFARepo{rows: map[int64]*entity.UserMFA{}}
+	return &Service{Users: users, MFA: repo, Now: func() time.Time { return *now }}, repo
+}
+
+func codeAt(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+	code, err := entity.TOTPCode(secret, entity.TOTPStep(at))
+	require.NoError(t, err)
+	return code
+}
+
+func TestService_EnrollConfirmVerify(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc, _ := newTestService(&now)
+
+	enrollment, err := svc.Enroll(ctx, adminEmail)
+	require.NoError(t, err)
+	assert.Contains(t, enrollment.URI, "otpauth://totp/catchup-feed:admin@example.com?")
+
+	// 確認前はログインに不要。
+	required, err := svc.Required(ctx, adminEmail)
+	require.NoError(t, err)
+	assert.False(t, required)
+
+	assert.ErrorIs(t, svc.Confirm(ctx, adminEmail, "000000"), ErrInvalidCode)
+	require.NoError(t, svc.Confirm(ctx, adminEmail, codeAt(t, enrollment.Secret, now)))
+	required, err = svc.Required(ctx, adminEmail)
+	require.NoError(t, err)
+	assert.True(t, required)
+
+	// 有効化中の再登録は不可。
+	_, err = svc.Enroll(ctx, adminEmail)
+	assert.ErrorIs(t, err, ErrAlreadyEnabled)
+
+	// 確認に使ったコードはログインに再利用できない。
+	assert.ErrorIs(t, svc.Verify(ctx, adminEmail, codeAt(t, enrollment.Secret, now)), ErrInvalidCode)
+
+	now = now.Add(entity.TOTPPeriod)
+	code := codeAt(t, enrollment.Secret, now)
+	require.NoError(t, svc.Verify(ctx, adminEmail, code))
+	assert.ErrorIs(t, svc.Verify(ctx, adminEmail, code), ErrInvalidCode, "replay")
+}
+
+func TestService_Disable(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc, repo := newTestService(&now)
+
+	enrollment, err := svc.Enroll(ctx, adminEmail)
+	require.NoError(t, err)
+	require.NoError(t, svc.Confirm(ctx, adminEmail, codeAt(t, enrollment.Secret, now)))
+
+	now = now.Add(entity.TOTPPeriod)
+	assert.ErrorIs(t, svc.Disable(ctx, adminEmail, "123456"), ErrInvalidCode)
+	require.NoError(t, svc.Disable(ctx, adminEmail, codeAt(t, enrollment.Secret, now)))
+	assert.Empty(t, repo.rows)
+
+	assert.ErrorIs(t, svc.Disable(ctx, adminEmail, "123456"), ErrNotEnrolled)
+}
+
+func TestService_EnrollRejects(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	svc, _ := newTestService(&now)
+
+	_, err := svc.Enroll(ctx, "friend@example.com")
+	assert.ErrorIs(t, err, ErrAdminOnly)
+	_, err = svc.Enroll(ctx, "nobody@example.com")
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+	assert.ErrorIs(t, svc.Confirm(ctx, adminEmail, "123456"), ErrNotEnrolled)
+}
@@ -0,0 +1,205 @@
+// Package readstate provides per-user read/unread tracking of articles.
+// Read marks belong to dashboard accounts (users table); the caller is
+// identified by the login subject (users.email) the auth middleware puts
+// in the request context.
+package readstate
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"catchup-feed/internal/repository"
+)
+
+// MaxBulkArticles bounds one bulk mark request.
+const MaxBulkArticles = 500
+
+// MaxSyncChanges bounds the client changes of one sync request.
+const MaxSyncChanges = 1000
+
+// syncOverlap is subtracted from SyncResult.SyncedAt: a concurrent mark
+// may have taken its timestamp before the sync read but commit after it,
+// so the next sync asks again for that short window. Re-delivered changes
+// are harmless — applying a change twice is a no-op.
+const syncOverlap = 5 * time.Second
+
+// Sentinel errors. Messages contain respond.SafeError's safe words so they
+// reach the client verbatim.
+var (
+	// ErrAccountNotFound indicates the caller has no active account, e.g.
+	// an API key identity: read state is kept per user.
+	ErrAccountNotFound = errors.New("account not found: read state requires a user account")
+
+	// ErrArticleNotFound indicates the article to mark does not exist.
+	ErrArticleNotFound = errors.New("article not found")
+
+	// ErrInvalidArticleIDs indicates an empty, oversized or non-positive
+	// ID list.
+	ErrInvalidArticleIDs = fmt.Errorf("article_ids are invalid: must be 1 to %d positive IDs", MaxBulkArticles)
+
+	// ErrInvalidChanges indicates an oversized sync change list or a
+	// change without a positive article ID or a changed_at.
+	ErrInvalidChanges = fmt.Errorf("changes are invalid: must be at most %d changes with positive article IDs and changed_at", MaxSyncChanges)
+)
+
+// Service marks articles read / unread for the calling user.
+type Service struct {
+	Users  repository.UserRepository
+	States repository.ReadStateRepository
+	// Now is the clock for read_at; nil means time.Now.
+	Now func() time.Time
+}
+
+func (s *Service) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+func (s *Service) userID(ctx context.Context, subject string) (int64, error) {
+	user, err := s.Users.GetActiveByEmail(ctx, strings.ToLower(strings.TrimSpace(subject)))
+	if err != nil {
+		return 0, fmt.Errorf("get account: %w", err)
+	}
+	if user == nil {
+		return 0, ErrAccountNotFound
+	}
+	return user.ID, nil
+}
+
+// MarkArticleRead marks one article read. Marking an already read article
+// again just moves read_at.
+func (s *Service) MarkArticleRead(ctx context.Context, subject string, articleID int64) error {
+	n, err := s.MarkRead(ctx, subject, []int64{articleID})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrArticleNotFound
+	}
+	return nil
+}
+
+// MarkRead marks the articles read and returns how many exist; unknown
+// IDs are skipped.
+func (s *Service) MarkRead(ctx context.Context, subject string, articleIDs []int64) (int64, error) {
+	if err := validateIDs(articleIDs); err != nil {
+		return 0, err
+	}
+	userID, err := s.userID(ctx, subject)
+	if err != nil {
+		return 0, err
+	}
+	n, err := s.States.MarkRead(ctx, userID, articleIDs, s.now())
+	if err != nil {
+		return 0, fmt.Errorf("mark read: %w", err)
+	}
+	return n, nil
+}
+
+// MarkUnread turns read marks into unread marks and returns how many
+// articles were read before. Already unread articles are not an error
+// (idempotent).
+func (s *Service) MarkUnread(ctx context.Context, subject string, articleIDs []int64) (int64, error) {
+	if err := validateIDs(articleIDs); err != nil {
+		return 0, err
+	}
+	userID, err := s.userID(ctx, subject)
+	if err != nil {
+		return 0, err
+	}
+	n, err := s.States.MarkUnread(ctx, userID, articleIDs, s.now())
+	if err != nil {
+		return 0, fmt.Errorf("mark unread: %w", err)
+	}
+	return n, nil
+}
+
+// UnreadCounts returns the caller's unread article count per active
+// source.
+func (s *Service) UnreadCounts(ctx context.Context, subject string) ([]repository.SourceUnreadCount, error) {
+	userID, err := s.userID(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.States.UnreadCounts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("unread counts: %w", err)
+	}
+	return counts, nil
+}
+
+// SyncResult is the outcome of a read-state sync.
+type SyncResult struct {
+	// Changes are the caller's read-state changes since the requested
+	// time, including the ones just merged, oldest first.
+	Changes []repository.ReadStateChange
+	// Applied is how many of the client's changes won over the stored
+	// state.
+	Applied int64
+	// SyncedAt is the since to send with the next sync.
+	SyncedAt time.Time
+}
+
+// Sync merges the client's offline changes last-writer-wins and returns
+// the caller's changes at or after since (the zero time = the full
+// state), so a client keeps its unread flags current by sending only
+// deltas. changed_at in the future (client clock skew) is clamped to now,
+// and of several changes to one article only the latest counts.
+// Changes of unknown articles are ignored.
+func (s *Service) Sync(ctx context.Context, subject string, since time.Time, changes []repository.ReadStateChange) (*SyncResult, error) {
+	if len(changes) > MaxSyncChanges {
+		return nil, ErrInvalidChanges
+	}
+	now := s.now()
+	latest := make(map[int64]repository.ReadStateChange, len(changes))
+	for _, c := range changes {
+		if c.ArticleID <= 0 || c.ChangedAt.IsZero() {
+			return nil, ErrInvalidChanges
+		}
+		if c.ChangedAt.After(now) {
+			c.ChangedAt = now
+		}
+		if prev, ok := latest[c.ArticleID]; !ok || c.ChangedAt.After(prev.ChangedAt) {
+			latest[c.ArticleID] = c
+		}
+	}
+	userID, err := s.userID(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SyncResult{SyncedAt: now.Add(-syncOverlap)}
+	if len(latest) > 0 {
+		merged := make([]repository.ReadStateChange, 0, len(latest))
+		for _, c := range latest {
+			merged = append(merged, c)
+		}
+		slices.SortFunc(merged, func(a, b repository.ReadStateChange) int { return cmp.Compare(a.ArticleID, b.ArticleID) })
+		if result.Applied, err = s.States.Merge(ctx, userID, merged); err != nil {
+			return nil, fmt.Errorf("merge read state: %w", err)
+		}
+	}
+	if result.Changes, err = s.States.Changes(ctx, userID, since); err != nil {
+		return nil, fmt.Errorf("read state changes: %w", err)
+	}
+	return result, nil
+}
+
+func validateIDs(ids []int64) error {
+	if len(ids) == 0 || len(ids) > MaxBulkArticles {
+		return ErrInvalidArticleIDs
+	}
+	for _, id := range ids {
+		if id <= 0 {
+			return ErrInvalidArticleIDs
+		}
+	}
+	return nil
+}
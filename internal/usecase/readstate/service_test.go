@@ -0,0 +1,181 @@
+package readstate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+/* ───────── モック実装 ───────── */
+
+// stubUserRepo implements only GetActiveByEmail.
+type stubUserRepo struct {
+	repository.UserRepository
+	users map[string]*entity.User
+}
+
+func (s *stubUserRepo) GetActiveByEmail(_ context.Context, email string) (*entity.User, error) {
+	return s.users[email], nil
+}
+
+// stubStates keeps read marks of existing articles in memory.
+type stubStates struct {
+	articles map[int64]bool
+	read     map[int64]map[int64]time.Time // user → article → read_at
+	merged   []repository.ReadStateChange
+	changes  []repository.ReadStateChange // Changes の応答
+}
+
+func (s *stubStates) MarkRead(_ context.Context, userID int64, ids []int64, at time.Time) (int64, error) {
+	if s.read[userID] == nil {
+		s.read[userID] = map[int64]time.Time{}
+	}
+	var n int64
+	for _, id := range ids {
+		if s.articles[id] {
+			s.read[userID][id] = at
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *stubStates) MarkUnread(_ context.Context, userID int64, ids []int64, _ time.Time) (int64, error) {
+	var n int64
+	for _, id := range ids {
+		if _, ok := s.read[userID][id]; ok {
+			delete(s.read[userID], id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *stubStates) UnreadCounts(_ context.Context, userID int64) ([]repository.SourceUnreadCount, error) {
+	return []repository.SourceUnreadCount{
+		{SourceID: 1, SourceName: "Go Blog", Unread: int64(len(s.articles) - len(s.read[userID]))},
+	}, nil
+}
+
+func (s *stubStates) Changes(_ context.Context, _ int64, since time.Time) ([]repository.ReadStateChange, error) {
+	var out []repository.ReadStateChange
+	for _, c := range s.changes {
+		if !c.ChangedAt.Before(since) {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (s *stubStates) Merge(_ context.Context, _ int64, changes []repository.ReadStateChange) (int64, error) {
+	s.merged = append(s.merged, changes...)
+	return int64(len(changes)), nil
+}
+
+func newService() (*Service, *stubStates) {
+	states := &stubStates{articles: map[int64]bool{10: true, 11: true, 12: true}, read: map[int64]map[int64]time.Time{}}
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	return &Service{
+		Users:  &stubUserRepo{users: map[string]*entity.User{"alice@example.com": {ID: 7, Email: "alice@example.com"}}},
+		States: states,
+		Now:    func() time.Time { return now },
+	}, states
+}
+
+/* ───────── テスト ───────── */
+
+func TestService_MarkReadAndUnread(t *testing.T) {
+	svc, states := newService()
+	ctx := context.Background()
+
+	n, err := svc.MarkRead(ctx, " Alice@Example.com ", []int64{10, 11, 99})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n, "unknown IDs are skipped")
+	assert.Len(t, states.read[7], 2)
+
+	counts, err := svc.UnreadCounts(ctx, "alice@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), counts[0].Unread)
+
+	n, err = svc.MarkUnread(ctx, "alice@example.com", []int64{10, 12})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n, "already unread articles are not counted")
+}
+
+func TestService_MarkArticleRead(t *testing.T) {
+	svc, states := newService()
+	ctx := context.Background()
+
+	require.NoError(t, svc.MarkArticleRead(ctx, "alice@example.com", 12))
+	assert.Equal(t, svc.Now(), states.read[7][12])
+
+	assert.ErrorIs(t, svc.MarkArticleRead(ctx, "alice@example.com", 99), ErrArticleNotFound)
+	assert.ErrorIs(t, svc.MarkArticleRead(ctx, "apikey:ci", 12), ErrAccountNotFound)
+}
+
+func TestService_InvalidIDs(t *testing.T) {
+	svc, _ := newService()
+	ctx := context.Background()
+
+	for _, ids := range [][]int64{nil, {0}, {10, -1}, make([]int64, MaxBulkArticles+1)} {
+		_, err := svc.MarkRead(ctx, "alice@example.com", ids)
+		assert.ErrorIs(t, err, ErrInvalidArticleIDs)
+		_, err = svc.MarkUnread(ctx, "alice@example.com", ids)
+		assert.ErrorIs(t, err, ErrInvalidArticleIDs)
+	}
+}
+
+func TestService_Sync(t *testing.T) {
+	svc, states := newService()
+	ctx := context.Background()
+	now := svc.Now()
+	states.changes = []repository.ReadStateChange{
+		{ArticleID: 11, Read: true, ChangedAt: now.Add(-2 * time.Hour)},
+		{ArticleID: 12, Read: false, ChangedAt: now.Add(-time.Minute)},
+	}
+
+	result, err := svc.Sync(ctx, "alice@example.com", now.Add(-time.Hour), []repository.ReadStateChange{
+		{ArticleID: 12, Read: true, ChangedAt: now.Add(-10 * time.Minute)},
+		{ArticleID: 10, Read: false, ChangedAt: now.Add(time.Hour)}, // 端末の時計が進んでいる
+		{ArticleID: 12, Read: false, ChangedAt: now.Add(-5 * time.Minute)},
+	})
+	require.NoError(t, err)
+	// 記事ごとに最新の変更だけを、未来の時刻は now に丸めて渡す。
+	assert.Equal(t, []repository.ReadStateChange{
+		{ArticleID: 10, Read: false, ChangedAt: now},
+		{ArticleID: 12, Read: false, ChangedAt: now.Add(-5 * time.Minute)},
+	}, states.merged)
+	assert.Equal(t, int64(2), result.Applied)
+	assert.Equal(t, []repository.ReadStateChange{states.changes[1]}, result.Changes)
+	assert.Equal(t, now.Add(-syncOverlap), result.SyncedAt)
+
+	// 取得のみ(since なし = 全状態)。
+	states.merged = nil
+	result, err = svc.Sync(ctx, "alice@example.com", time.Time{}, nil)
+	require.NoError(t, err)
+	assert.Len(t, result.Changes, 2)
+	assert.Nil(t, states.merged)
+
+	_, err = svc.Sync(ctx, "apikey:ci", time.Time{}, nil)
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+}
+
+func TestService_SyncInvalidChanges(t *testing.T) {
+	svc, _ := newService()
+	ctx := context.Background()
+
+	for _, changes := range [][]repository.ReadStateChange{
+		{{ArticleID: 0, Read: true, ChangedAt: svc.Now()}},
+		{{ArticleID: 10, Read: true}},
+		make([]repository.ReadStateChange, MaxSyncChanges+1),
+	} {
+		_, err := svc.Sync(ctx, "alice@example.com", time.Time{}, changes)
+		assert.ErrorIs(t, err, ErrInvalidChanges)
+	}
+}
@@ -0,0 +1,18 @@
+// Package refreshtoken provides the refresh token use cases behind
+// /auth/token and /auth/refresh: issuance at login, single-use rotation
+// with reuse detection, and revocation at logout.
+package refreshtoken
+
+import "errors"
+
+// Sentinel errors. Both map to 401 in the auth handlers; they are kept
+// apart so reuse can be logged as a security event.
+var (
+	// ErrInvalidToken is the generic failure: unknown, expired or revoked
+	// token. Deliberately indistinguishable.
+	ErrInvalidToken = errors.New("refresh token is invalid")
+
+	// ErrTokenReused indicates an already-rotated token was presented
+	// again. Its whole family has been revoked.
+	ErrTokenReused = errors.New("refresh token is invalid: reuse detected")
+)
@@ -0,0 +1,153 @@
+package refreshtoken
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// DefaultTTL is the lifetime of a refresh token when Service.TTL is 0.
+// Each rotation issues a fresh token, so an active browser stays signed
+// in indefinitely while an idle one must log in again after DefaultTTL.
+const DefaultTTL = 30 * 24 * time.Hour
+
+// Issued is a newly issued refresh token. Token is the plaintext, handed to
+// the client once and never stored.
+type Issued struct {
+	Token     string
+	Subject   string
+	Role      string
+	ExpiresAt time.Time
+}
+
+// Service provides the refresh token use cases.
+type Service struct {
+	Tokens repository.RefreshTokenRepository
+	// TTL is the refresh token lifetime; 0 means DefaultTTL.
+	TTL    time.Duration
+	Logger *slog.Logger
+	// Now returns the current time; nil means time.Now.
+	Now func() time.Time
+}
+
+func (s *Service) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+func (s *Service) ttl() time.Duration {
+	if s.TTL > 0 {
+		return s.TTL
+	}
+	return DefaultTTL
+}
+
+func (s *Service) logger() *slog.Logger {
+	if s.Logger != nil {
+		return s.Logger
+	}
+	return slog.Default()
+}
+
+// Issue starts a new token family for a successful login.
+func (s *Service) Issue(ctx context.Context, subject, role string) (*Issued, error) {
+	familyID, err := entity.NewRefreshFamilyID()
+	if err != nil {
+		return nil, err
+	}
+	return s.create(ctx, familyID, subject, role)
+}
+
+func (s *Service) create(ctx context.Context, familyID, subject, role string) (*Issued, error) {
+	plaintext, hash, err := entity.GenerateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	token := &entity.RefreshToken{
+		FamilyID:  familyID,
+		Subject:   subject,
+		Role:      role,
+		TokenHash: hash,
+		ExpiresAt: s.now().Add(s.ttl()),
+	}
+	if err := s.Tokens.Create(ctx, token); err != nil {
+		return nil, fmt.Errorf("create refresh token: %w", err)
+	}
+	return &Issued{Token: plaintext, Subject: subject, Role: role, ExpiresAt: token.ExpiresAt}, nil
+}
+
+// Rotate consumes plaintext and issues its successor in the same family.
+// A token that was already rotated is a replay (the legitimate client and
+// an attacker both hold it): the whole family is revoked and
+// ErrTokenReused returned, logging the legitimate client out too.
+func (s *Service) Rotate(ctx context.Context, plaintext string) (*Issued, error) {
+	if plaintext == "" {
+		return nil, ErrInvalidToken
+	}
+	token, err := s.Tokens.GetByHash(ctx, entity.HashRefreshToken(plaintext))
+	if err != nil {
+		return nil, fmt.Errorf("rotate refresh token: %w", err)
+	}
+	now := s.now()
+	if token == nil || token.RevokedAt != nil || token.IsExpired(now) {
+		return nil, ErrInvalidToken
+	}
+	if token.UsedAt != nil {
+		return nil, s.reused(ctx, token, now)
+	}
+
+	consumed, err := s.Tokens.MarkUsed(ctx, token.ID, now)
+	if err != nil {
+		return nil, fmt.Errorf("rotate refresh token: %w", err)
+	}
+	if !consumed {
+		// A concurrent refresh consumed (or a logout revoked) it between
+		// the read and the update: treat it the same as a replay.
+		return nil, s.reused(ctx, token, now)
+	}
+	return s.create(ctx, token.FamilyID, token.Subject, token.Role)
+}
+
+func (s *Service) reused(ctx context.Context, token *entity.RefreshToken, now time.Time) error {
+	s.logger().WarnContext(ctx, "refresh token reuse detected, revoking family",
+		slog.String("user_email", token.Subject),
+		slog.Int64("refresh_token_id", token.ID))
+	if err := s.Tokens.RevokeFamily(ctx, token.FamilyID, now); err != nil {
+		return fmt.Errorf("revoke refresh token family: %w", err)
+	}
+	return ErrTokenReused
+}
+
+// Revoke revokes plaintext's whole family (logout). Unknown tokens are
+// ignored: logout is idempotent.
+func (s *Service) Revoke(ctx context.Context, plaintext string) error {
+	if plaintext == "" {
+		return nil
+	}
+	token, err := s.Tokens.GetByHash(ctx, entity.HashRefreshToken(plaintext))
+	if err != nil {
+		return fmt.Errorf("revoke refresh token: %w", err)
+	}
+	if token == nil {
+		return nil
+	}
+	if err := s.Tokens.RevokeFamily(ctx, token.FamilyID, s.now()); err != nil {
+		return fmt.Errorf("revoke refresh token: %w", err)
+	}
+	return nil
+}
+
+// Cleanup deletes expired tokens and returns how many were deleted.
+func (s *Service) Cleanup(ctx context.Context) (int64, error) {
+	n, err := s.Tokens.DeleteExpired(ctx, s.now())
+	if err != nil {
+		return 0, fmt.Errorf("cleanup refresh tokens: %w", err)
+	}
+	return n, nil
+}
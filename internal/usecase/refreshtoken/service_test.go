@@ -0,0 +1,179 @@
+package refreshtoken
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+/* ───────── モック実装 ───────── */
+
+type stubTokenRepo struct {
+	tokens map[int64]*entity.RefreshToken
+}
+
+func newStubTokenRepo() *stubTokenRepo {
+	return &stubTokenRepo{tokens: map[int64]*entity.RefreshToken{}}
+}
+
+func (s *stubTokenRepo) Create(_ context.Context, t *entity.RefreshToken) error {
+	t.ID = int64(len(s.tokens) + 1)
+	t.CreatedAt = time.Now()
+	s.tokens[t.ID] = t
+	return nil
+}
+
+func (s *stubTokenRepo) GetByHash(_ context.Context, hash string) (*entity.RefreshToken, error) {
+	for _, t := range s.tokens {
+		if t.TokenHash == hash {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *stubTokenRepo) MarkUsed(_ context.Context, id int64, at time.Time) (bool, error) {
+	t, ok := s.tokens[id]
+	if !ok || t.UsedAt != nil || t.RevokedAt != nil {
+		return false, nil
+	}
+	t.UsedAt = &at
+	return true, nil
+}
+
+func (s *stubTokenRepo) RevokeFamily(_ context.Context, familyID string, at time.Time) error {
+	for _, t := range s.tokens {
+		if t.FamilyID == familyID && t.RevokedAt == nil {
+			t.RevokedAt = &at
+		}
+	}
+	return nil
+}
+
+func (s *stubTokenRepo) DeleteExpired(_ context.Context, at time.Time) (int64, error) {
+	var n int64
+	for id, t := range s.tokens {
+		if t.ExpiresAt.Before(at) {
+			delete(s.tokens, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+/* ───────── テストケース ───────── */
+
+func TestService_IssueAndRotate(t *testing.T) {
+	repo := newStubTokenRepo()
+	svc := &Service{Tokens: repo, TTL: time.Hour}
+	ctx := context.Background()
+
+	first, err := svc.Issue(ctx, "admin@example.com", "admin")
+	require.NoError(t, err)
+	assert.Contains(t, first.Token, "cfr_")
+	// 平文は保存しない(ハッシュのみ)。
+	assert.NotEqual(t, first.Token, repo.tokens[1].TokenHash)
+
+	second, err := svc.Rotate(ctx, first.Token)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Token, second.Token)
+	assert.Equal(t, "admin@example.com", second.Subject)
+	assert.Equal(t, "admin", second.Role)
+	// ローテーション後も同じ系列。
+	assert.Equal(t, repo.tokens[1].FamilyID, repo.tokens[2].FamilyID)
+
+	third, err := svc.Rotate(ctx, second.Token)
+	require.NoError(t, err)
+	assert.NotEmpty(t, third.Token)
+}
+
+func TestService_Rotate_ReuseRevokesFamily(t *testing.T) {
+	repo := newStubTokenRepo()
+	svc := &Service{Tokens: repo}
+	ctx := context.Background()
+
+	first, err := svc.Issue(ctx, "viewer@example.com", "viewer")
+	require.NoError(t, err)
+	second, err := svc.Rotate(ctx, first.Token)
+	require.NoError(t, err)
+
+	// 使用済みトークンの再提示 = 漏洩。系列ごと失効させる。
+	_, err = svc.Rotate(ctx, first.Token)
+	assert.ErrorIs(t, err, ErrTokenReused)
+
+	// 正規クライアントが持つ最新トークンも使えなくなる。
+	_, err = svc.Rotate(ctx, second.Token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	// 別系列(別ログイン)は影響を受けない。
+	other, err := svc.Issue(ctx, "viewer@example.com", "viewer")
+	require.NoError(t, err)
+	_, err = svc.Rotate(ctx, other.Token)
+	assert.NoError(t, err)
+}
+
+func TestService_Rotate_Invalid(t *testing.T) {
+	repo := newStubTokenRepo()
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	svc := &Service{Tokens: repo, TTL: time.Hour, Now: func() time.Time { return now }}
+	ctx := context.Background()
+
+	issued, err := svc.Issue(ctx, "admin@example.com", "admin")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		token string
+		at    time.Time
+	}{
+		{name: "empty", token: "", at: now},
+		{name: "unknown", token: "cfr_unknown", at: now},
+		{name: "expired", token: issued.Token, at: now.Add(time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc.Now = func() time.Time { return tt.at }
+			_, err := svc.Rotate(ctx, tt.token)
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
+
+func TestService_Revoke(t *testing.T) {
+	repo := newStubTokenRepo()
+	svc := &Service{Tokens: repo}
+	ctx := context.Background()
+
+	issued, err := svc.Issue(ctx, "admin@example.com", "admin")
+	require.NoError(t, err)
+
+	require.NoError(t, svc.Revoke(ctx, issued.Token))
+	_, err = svc.Rotate(ctx, issued.Token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	// ログアウトは冪等: 未知・空のトークンはエラーにしない。
+	assert.NoError(t, svc.Revoke(ctx, "cfr_unknown"))
+	assert.NoError(t, svc.Revoke(ctx, ""))
+}
+
+func TestService_Cleanup(t *testing.T) {
+	repo := newStubTokenRepo()
+	now := time.Now()
+	svc := &Service{Tokens: repo, TTL: time.Hour, Now: func() time.Time { return now }}
+	ctx := context.Background()
+
+	_, err := svc.Issue(ctx, "admin@example.com", "admin")
+	require.NoError(t, err)
+
+	svc.Now = func() time.Time { return now.Add(2 * time.Hour) }
+	n, err := svc.Cleanup(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.Empty(t, repo.tokens)
+}
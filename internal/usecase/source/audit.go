@@ -0,0 +1,46 @@
+package source
+
+import (
+	"context"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/usecase/audit"
+)
+
+// auditSnapshot is the audit_logs before/after shape of a source.
+type auditSnapshot struct {
+	Name     string `json:"name"`
+	FeedURL  string `json:"feed_url"`
+	Category string `json:"category"`
+	Lang     string `json:"lang"`
+	Kind     string `json:"kind"`
+	Active   bool   `json:"active"`
+}
+
+func toAuditSnapshot(src *entity.Source) any {
+	if src == nil {
+		return nil
+	}
+	return auditSnapshot{
+		Name:     src.Name,
+		FeedURL:  src.FeedURL,
+		Category: src.Category,
+		Lang:     src.Lang,
+		Kind:     src.Kind,
+		Active:   src.Active,
+	}
+}
+
+// record writes one audit entry when auditing is enabled (Audit != nil).
+func (s *Service) record(ctx context.Context, action string, id int64, before, after *entity.Source) {
+	if s.Audit == nil {
+		return
+	}
+	s.Audit.Record(ctx, audit.Entry{
+		Action:       action,
+		ResourceType: entity.AuditResourceSource,
+		ResourceID:   &id,
+		Before:       toAuditSnapshot(before),
+		After:        toAuditSnapshot(after),
+	})
+}
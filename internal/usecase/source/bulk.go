@@ -0,0 +1,111 @@
+package source
+
+import (
+	"context"
+	"errors"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// MaxBulkSources bounds one bulk request (PATCH /sources/bulk).
+const MaxBulkSources = 500
+
+// BulkUpdateInput is a change applied to every source in IDs, for
+// switching a group of sources on or off (seasonal feeds) or moving them
+// together. Only the fields that make sense for many sources at once are
+// offered: nil / empty fields are not updated, with the same meaning as in
+// UpdateInput.
+type BulkUpdateInput struct {
+	IDs           []int64
+	Active        *bool
+	Category      string
+	Lang          string
+	CrawlSchedule *string
+	RetentionDays *int
+	RenderJS      *bool
+}
+
+// empty reports whether in changes nothing.
+func (in BulkUpdateInput) empty() bool {
+	return in.Active == nil && in.Category == "" && in.Lang == "" &&
+		in.CrawlSchedule == nil && in.RetentionDays == nil && in.RenderJS == nil
+}
+
+// BulkUpdate applies in to the sources in one transaction (with Tx) and
+// returns the IDs that existed and were updated; missing IDs are skipped,
+// not treated as errors, like DeleteBatch of articles. Duplicate IDs are
+// collapsed. Any other failure rolls every update back.
+// Returns ErrInvalidSourceIDs for an empty, oversized (> MaxBulkSources)
+// or non-positive ID list, ErrNoBulkChanges when in changes no field, and
+// a ValidationError if an updated field is invalid.
+func (s *Service) BulkUpdate(ctx context.Context, in BulkUpdateInput) ([]int64, error) {
+	if len(in.IDs) == 0 || len(in.IDs) > MaxBulkSources {
+		return nil, ErrInvalidSourceIDs
+	}
+	unique := make([]int64, 0, len(in.IDs))
+	seen := make(map[int64]struct{}, len(in.IDs))
+	for _, id := range in.IDs {
+		if id <= 0 {
+			return nil, ErrInvalidSourceIDs
+		}
+		if _, dup := seen[id]; dup {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	if in.empty() {
+		return nil, ErrNoBulkChanges
+	}
+	// 不正な値は1件目の更新前に弾く(Tx なしでも中途半端に適用しない)
+	if in.CrawlSchedule != nil {
+		if _, err := normalizeCrawlSchedule(*in.CrawlSchedule); err != nil {
+			return nil, err
+		}
+	}
+	if in.RetentionDays != nil {
+		if _, err := normalizeRetentionDays(*in.RetentionDays); err != nil {
+			return nil, err
+		}
+	}
+
+	type change struct{ before, after *entity.Source }
+	var changes []change
+	apply := func(ctx context.Context) error {
+		for _, id := range unique {
+			before, after, err := s.update(ctx, UpdateInput{
+				ID:            id,
+				Category:      in.Category,
+				Lang:          in.Lang,
+				Active:        in.Active,
+				CrawlSchedule: in.CrawlSchedule,
+				RetentionDays: in.RetentionDays,
+				RenderJS:      in.RenderJS,
+			})
+			if errors.Is(err, ErrSourceNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			changes = append(changes, change{before, after})
+		}
+		return nil
+	}
+	var err error
+	if s.Tx != nil {
+		err = s.Tx.WithinTx(ctx, apply)
+	} else {
+		err = apply(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	updated := make([]int64, 0, len(changes))
+	for _, c := range changes {
+		updated = append(updated, c.after.ID)
+		s.record(ctx, entity.AuditActionUpdate, c.after.ID, c.before, c.after)
+	}
+	return updated, nil
+}
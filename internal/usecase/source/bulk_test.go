@@ -0,0 +1,159 @@
+package source_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"catchup-feed/internal/domain/entity"
+	srcUC "catchup-feed/internal/usecase/source"
+)
+
+/*────────────────────  インメモリスタブ  ────────────────────*/
+
+// stubTx rolls the stubRepo back to its state before WithinTx when fn
+// fails, like the database transaction would.
+type stubTx struct {
+	repo  *stubRepo
+	calls int
+}
+
+func (tx *stubTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx.calls++
+	saved := make(map[int64]*entity.Source, len(tx.repo.data))
+	for id, src := range tx.repo.data {
+		copied := *src
+		saved[id] = &copied
+	}
+	if err := fn(ctx); err != nil {
+		tx.repo.data = saved
+		return err
+	}
+	return nil
+}
+
+// failingUpdateRepo fails the Update of one source.
+type failingUpdateRepo struct {
+	*stubRepo
+	failID int64
+}
+
+func (s *failingUpdateRepo) Update(ctx context.Context, src *entity.Source) error {
+	if src.ID == s.failID {
+		return errors.New("db down")
+	}
+	return s.stubRepo.Update(ctx, src)
+}
+
+func newBulkStub() *stubRepo {
+	stub := newStub()
+	for _, id := range []int64{1, 2, 3} {
+		stub.data[id] = &entity.Source{ID: id, Name: "s", FeedURL: "https://example.com/feed", Category: "go", Active: true}
+	}
+	return stub
+}
+
+/*────────────────────  テストケース  ────────────────────*/
+
+func TestService_BulkUpdate(t *testing.T) {
+	stub := newBulkStub()
+	rec := &stubRecorder{}
+	tx := &stubTx{repo: stub}
+	svc := srcUC.Service{Repo: stub, Audit: rec, Tx: tx}
+
+	inactive := false
+	updated, err := svc.BulkUpdate(context.Background(), srcUC.BulkUpdateInput{
+		IDs: []int64{2, 99, 1, 2}, Active: &inactive, Category: "seasonal",
+	})
+	if err != nil {
+		t.Fatalf("BulkUpdate err=%v", err)
+	}
+	if !slices.Equal(updated, []int64{2, 1}) {
+		t.Errorf("updated = %v, want [2 1] (missing skipped, duplicates collapsed)", updated)
+	}
+	if tx.calls != 1 {
+		t.Errorf("WithinTx calls = %d, want 1", tx.calls)
+	}
+	for _, id := range []int64{1, 2} {
+		if got := stub.data[id]; got.Active || got.Category != "seasonal" || got.Name != "s" {
+			t.Errorf("source %d = %#v", id, got)
+		}
+	}
+	if !stub.data[3].Active {
+		t.Errorf("source 3 must be untouched")
+	}
+	if len(rec.entries) != 2 {
+		t.Errorf("want 2 audit entries, got %d", len(rec.entries))
+	}
+}
+
+func TestService_BulkUpdate_rollsBack(t *testing.T) {
+	stub := newBulkStub()
+	rec := &stubRecorder{}
+	repo := &failingUpdateRepo{stubRepo: stub, failID: 3}
+	svc := srcUC.Service{Repo: repo, Audit: rec, Tx: &stubTx{repo: stub}}
+
+	inactive := false
+	if _, err := svc.BulkUpdate(context.Background(), srcUC.BulkUpdateInput{
+		IDs: []int64{1, 2, 3}, Active: &inactive,
+	}); err == nil {
+		t.Fatal("want error")
+	}
+	for id, src := range stub.data {
+		if !src.Active {
+			t.Errorf("source %d must be rolled back", id)
+		}
+	}
+	if len(rec.entries) != 0 {
+		t.Errorf("a rolled back bulk update must not be audited, got %d entries", len(rec.entries))
+	}
+}
+
+func TestService_BulkUpdate_validation(t *testing.T) {
+	active := true
+	badSchedule := "every now and then"
+	badRetention := 1
+	tooMany := make([]int64, srcUC.MaxBulkSources+1)
+	for i := range tooMany {
+		tooMany[i] = int64(i + 1)
+	}
+
+	tests := []struct {
+		name string
+		in   srcUC.BulkUpdateInput
+		want error
+	}{
+		{"no ids", srcUC.BulkUpdateInput{Active: &active}, srcUC.ErrInvalidSourceIDs},
+		{"too many ids", srcUC.BulkUpdateInput{IDs: tooMany, Active: &active}, srcUC.ErrInvalidSourceIDs},
+		{"non-positive id", srcUC.BulkUpdateInput{IDs: []int64{1, 0}, Active: &active}, srcUC.ErrInvalidSourceIDs},
+		{"no changes", srcUC.BulkUpdateInput{IDs: []int64{1}}, srcUC.ErrNoBulkChanges},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := srcUC.Service{Repo: newBulkStub()}
+			if _, err := svc.BulkUpdate(context.Background(), tt.in); !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// 不正な値は Tx なしでもどのソースにも適用しない
+	for name, in := range map[string]srcUC.BulkUpdateInput{
+		"crawl schedule": {IDs: []int64{1, 2}, Category: "x", CrawlSchedule: &badSchedule},
+		"retention days": {IDs: []int64{1, 2}, Category: "x", RetentionDays: &badRetention},
+	} {
+		t.Run(name, func(t *testing.T) {
+			stub := newBulkStub()
+			svc := srcUC.Service{Repo: stub}
+			_, err := svc.BulkUpdate(context.Background(), in)
+			var verr *entity.ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("want ValidationError, got %v", err)
+			}
+			if stub.data[1].Category != "go" {
+				t.Errorf("source 1 must be untouched: %#v", stub.data[1])
+			}
+		})
+	}
+}
@@ -3,7 +3,10 @@
 // including validation and interaction with the source repository.
 package source
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Sentinel errors for source use case operations.
 var (
@@ -19,4 +22,31 @@ var (
 	// ErrDuplicateSource indicates that a source with the same feed URL already exists.
 	// This prevents duplicate sources from being created in the system.
 	ErrDuplicateSource = errors.New("source with this feed URL already exists")
+
+	// ErrFeedUnreachable indicates that ValidateFeed could not download the
+	// feed (network failure, non-2xx response or redirect loop).
+	ErrFeedUnreachable = errors.New("feed could not be fetched")
+
+	// ErrNotAFeed indicates that ValidateFeed downloaded the URL but it is
+	// not RSS, Atom or JSON Feed (typically the site's HTML page).
+	ErrNotAFeed = errors.New("url is not a valid RSS, Atom or JSON feed")
+
+	// ErrInvalidSourceIDs indicates an empty, oversized or non-positive
+	// ID list in a bulk request.
+	ErrInvalidSourceIDs = fmt.Errorf("ids are invalid: must be 1 to %d positive source IDs", MaxBulkSources)
+
+	// ErrNoBulkChanges indicates a bulk update that sets no field.
+	ErrNoBulkChanges = errors.New("at least one field to update is required")
+
+	// ErrSourceGroupNotFound indicates that the requested source group does
+	// not exist.
+	ErrSourceGroupNotFound = errors.New("source group not found")
+
+	// ErrSourceGroupNameTaken indicates that another source group has the
+	// name.
+	ErrSourceGroupNameTaken = errors.New("source group already exists")
+
+	// ErrInvalidGroupSourceIDs indicates an oversized or non-positive
+	// member list.
+	ErrInvalidGroupSourceIDs = fmt.Errorf("source_ids are invalid: must be at most %d positive source IDs", MaxGroupSources)
 )
@@ -0,0 +1,141 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// MaxGroupSources caps the member list of one PUT
+// /source-groups/{id}/sources, like MaxBulkSources for bulk updates.
+const MaxGroupSources = MaxBulkSources
+
+// GroupInput carries the fields of POST /source-groups and
+// PUT /source-groups/{id}. CrawlSchedule is the schedule of the members
+// without their own crawl_schedule; empty follows the worker's global
+// CRON_SCHEDULE. CrawlPaused leaves the members out of the scheduled
+// crawls. Notify nil means true: the members' articles are notified
+// unless it is explicitly turned off.
+type GroupInput struct {
+	Name          string
+	CrawlSchedule string
+	CrawlPaused   bool
+	Notify        *bool
+}
+
+// apply validates in and copies it onto group.
+func (in GroupInput) apply(group *entity.SourceGroup) error {
+	name := strings.TrimSpace(in.Name)
+	if err := entity.ValidateSourceGroupName(name); err != nil {
+		return err
+	}
+	crawlSchedule, err := normalizeCrawlSchedule(in.CrawlSchedule)
+	if err != nil {
+		return err
+	}
+	group.Name = name
+	group.CrawlSchedule = crawlSchedule
+	group.CrawlPaused = in.CrawlPaused
+	group.Notify = in.Notify == nil || *in.Notify
+	return nil
+}
+
+// ListGroups returns every source group with its members, ordered by name.
+func (s *Service) ListGroups(ctx context.Context) ([]*entity.SourceGroup, error) {
+	groups, err := s.Groups.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list source groups: %w", err)
+	}
+	return groups, nil
+}
+
+// GetGroup returns the group, or ErrSourceGroupNotFound.
+func (s *Service) GetGroup(ctx context.Context, id int64) (*entity.SourceGroup, error) {
+	group, err := s.Groups.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get source group: %w", err)
+	}
+	if group == nil {
+		return nil, ErrSourceGroupNotFound
+	}
+	return group, nil
+}
+
+// CreateGroup adds an empty group; members are set with SetGroupSources.
+func (s *Service) CreateGroup(ctx context.Context, in GroupInput) (*entity.SourceGroup, error) {
+	group := &entity.SourceGroup{SourceIDs: []int64{}}
+	if err := in.apply(group); err != nil {
+		return nil, err
+	}
+	if err := s.Groups.Create(ctx, group); err != nil {
+		if errors.Is(err, repository.ErrDuplicateSourceGroupName) {
+			return nil, ErrSourceGroupNameTaken
+		}
+		return nil, fmt.Errorf("create source group: %w", err)
+	}
+	return group, nil
+}
+
+// UpdateGroup replaces the group's name and settings. The members are
+// kept.
+func (s *Service) UpdateGroup(ctx context.Context, id int64, in GroupInput) (*entity.SourceGroup, error) {
+	group, err := s.GetGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := in.apply(group); err != nil {
+		return nil, err
+	}
+	if err := s.Groups.Update(ctx, group); err != nil {
+		if errors.Is(err, repository.ErrDuplicateSourceGroupName) {
+			return nil, ErrSourceGroupNameTaken
+		}
+		return nil, fmt.Errorf("update source group: %w", err)
+	}
+	return group, nil
+}
+
+// DeleteGroup removes the group; its members become ungrouped and follow
+// their own settings again.
+func (s *Service) DeleteGroup(ctx context.Context, id int64) error {
+	if _, err := s.GetGroup(ctx, id); err != nil {
+		return err
+	}
+	if err := s.Groups.Delete(ctx, id); err != nil {
+		return fmt.Errorf("delete source group: %w", err)
+	}
+	return nil
+}
+
+// SetGroupSources makes sourceIDs the members of the group, moving them
+// out of any other group; an empty list empties the group. It returns the
+// members, ascending: IDs of sources that do not exist are left out.
+func (s *Service) SetGroupSources(ctx context.Context, id int64, sourceIDs []int64) ([]int64, error) {
+	if len(sourceIDs) > MaxGroupSources {
+		return nil, ErrInvalidGroupSourceIDs
+	}
+	ids := make([]int64, 0, len(sourceIDs))
+	seen := make(map[int64]struct{}, len(sourceIDs))
+	for _, sourceID := range sourceIDs {
+		if sourceID <= 0 {
+			return nil, ErrInvalidGroupSourceIDs
+		}
+		if _, dup := seen[sourceID]; dup {
+			continue
+		}
+		seen[sourceID] = struct{}{}
+		ids = append(ids, sourceID)
+	}
+	if _, err := s.GetGroup(ctx, id); err != nil {
+		return nil, err
+	}
+	members, err := s.Groups.SetSources(ctx, id, ids)
+	if err != nil {
+		return nil, fmt.Errorf("set source group members: %w", err)
+	}
+	return members, nil
+}
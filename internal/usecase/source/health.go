@@ -0,0 +1,106 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/pkg/schedule"
+)
+
+// DefaultStaleAfter is how long a source may go without a successful
+// fetch before it is reported stale. Two daily CRON_SCHEDULE runs; a
+// source with a slower crawl_schedule gets two of its own periods instead.
+const DefaultStaleAfter = 48 * time.Hour
+
+// HealthReport is the health of one source with its derived status
+// (entity.SourceHealthOK / Failing / Stale / NeverCrawled). Health is nil
+// for a source that has never been fetched.
+type HealthReport struct {
+	Source *entity.Source
+	Health *entity.SourceHealth
+	Status string
+}
+
+// Health returns the health report of a source.
+// Returns ErrSourceNotFound if the source does not exist.
+func (s *Service) Health(ctx context.Context, id int64) (*HealthReport, error) {
+	if id <= 0 {
+		return nil, &entity.ValidationError{Field: "id", Message: "must be positive"}
+	}
+	src, err := s.Repo.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get source: %w", err)
+	}
+	if src == nil {
+		return nil, ErrSourceNotFound
+	}
+	var health *entity.SourceHealth
+	if s.HealthRepo != nil {
+		if health, err = s.HealthRepo.Get(ctx, id); err != nil {
+			return nil, fmt.Errorf("get source health: %w", err)
+		}
+	}
+	report := s.report(src, health, s.now())
+	return &report, nil
+}
+
+// ListHealth returns the health report of every source (active and
+// inactive) in source ID order. status, when non-empty, keeps only the
+// reports with that status.
+func (s *Service) ListHealth(ctx context.Context, status string) ([]HealthReport, error) {
+	sources, err := s.Repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list sources: %w", err)
+	}
+	byID := map[int64]*entity.SourceHealth{}
+	if s.HealthRepo != nil {
+		list, err := s.HealthRepo.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list source health: %w", err)
+		}
+		for _, h := range list {
+			byID[h.SourceID] = h
+		}
+	}
+	now := s.now()
+	reports := make([]HealthReport, 0, len(sources))
+	for _, src := range sources {
+		report := s.report(src, byID[src.ID], now)
+		if status != "" && report.Status != status {
+			continue
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func (s *Service) report(src *entity.Source, health *entity.SourceHealth, now time.Time) HealthReport {
+	return HealthReport{
+		Source: src,
+		Health: health,
+		Status: health.Status(now, staleAfter(src, now)),
+	}
+}
+
+// staleAfter is DefaultStaleAfter, or two periods of the source's own
+// crawl_schedule when that is longer.
+func staleAfter(src *entity.Source, now time.Time) time.Duration {
+	if src.CrawlSchedule == nil {
+		return DefaultStaleAfter
+	}
+	sched, err := schedule.Parse(*src.CrawlSchedule)
+	if err != nil {
+		return DefaultStaleAfter
+	}
+	next := sched.Next(now)
+	return max(DefaultStaleAfter, 2*sched.Next(next).Sub(next))
+}
+
+func (s *Service) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,112 @@
+package source_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	srcUC "catchup-feed/internal/usecase/source"
+)
+
+// stubHealthRepo serves fixed source_health rows.
+type stubHealthRepo struct {
+	rows map[int64]*entity.SourceHealth
+}
+
+func (s *stubHealthRepo) RecordFetch(context.Context, int64, time.Time, int, string) error {
+	return nil
+}
+func (s *stubHealthRepo) Get(_ context.Context, id int64) (*entity.SourceHealth, error) {
+	return s.rows[id], nil
+}
+func (s *stubHealthRepo) List(_ context.Context) ([]*entity.SourceHealth, error) {
+	out := make([]*entity.SourceHealth, 0, len(s.rows))
+	for _, h := range s.rows {
+		out = append(out, h)
+	}
+	return out, nil
+}
+
+func newHealthService() srcUC.Service {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) *time.Time { t := now.Add(-d); return &t }
+	status := func(code int) *int { return &code }
+	weekly := "@weekly"
+
+	stub := newStub()
+	stub.data[1] = &entity.Source{ID: 1, Name: "ok", Active: true}
+	stub.data[2] = &entity.Source{ID: 2, Name: "broken", Active: true}
+	stub.data[3] = &entity.Source{ID: 3, Name: "new", Active: true}
+	stub.data[4] = &entity.Source{ID: 4, Name: "quiet", Active: true}
+	stub.data[5] = &entity.Source{ID: 5, Name: "weekly", Active: true, CrawlSchedule: &weekly}
+
+	msg := "feed returned 404"
+	health := &stubHealthRepo{rows: map[int64]*entity.SourceHealth{
+		1: {SourceID: 1, LastCrawledAt: ago(time.Hour), LastSuccessAt: ago(time.Hour), LastHTTPStatus: status(200)},
+		2: {SourceID: 2, LastCrawledAt: ago(time.Hour), LastSuccessAt: ago(30 * time.Hour), LastHTTPStatus: status(404), ConsecutiveFailures: 2, LastError: &msg},
+		4: {SourceID: 4, LastCrawledAt: ago(time.Hour), LastSuccessAt: ago(72 * time.Hour)},
+		// 週1スケジュールなら 72時間前の成功はまだ stale ではない
+		5: {SourceID: 5, LastCrawledAt: ago(72 * time.Hour), LastSuccessAt: ago(72 * time.Hour)},
+	}}
+	return srcUC.Service{Repo: stub, HealthRepo: health, Now: func() time.Time { return now }}
+}
+
+func TestService_ListHealth(t *testing.T) {
+	svc := newHealthService()
+
+	reports, err := svc.ListHealth(context.Background(), "")
+	if err != nil {
+		t.Fatalf("ListHealth err=%v", err)
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Source.ID < reports[j].Source.ID })
+	want := []string{
+		entity.SourceHealthOK,
+		entity.SourceHealthFailing,
+		entity.SourceHealthNeverCrawled,
+		entity.SourceHealthStale,
+		entity.SourceHealthOK,
+	}
+	if len(reports) != len(want) {
+		t.Fatalf("len = %d, want %d", len(reports), len(want))
+	}
+	for i, report := range reports {
+		if report.Status != want[i] {
+			t.Errorf("source %d status = %q, want %q", report.Source.ID, report.Status, want[i])
+		}
+	}
+
+	failing, err := svc.ListHealth(context.Background(), entity.SourceHealthFailing)
+	if err != nil {
+		t.Fatalf("ListHealth err=%v", err)
+	}
+	if len(failing) != 1 || failing[0].Source.ID != 2 {
+		t.Fatalf("failing = %+v, want only source 2", failing)
+	}
+}
+
+func TestService_Health(t *testing.T) {
+	svc := newHealthService()
+
+	report, err := svc.Health(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("Health err=%v", err)
+	}
+	if report.Status != entity.SourceHealthFailing || report.Health.ConsecutiveFailures != 2 {
+		t.Errorf("report = %+v", report)
+	}
+
+	report, err = svc.Health(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("Health err=%v", err)
+	}
+	if report.Health != nil || report.Status != entity.SourceHealthNeverCrawled {
+		t.Errorf("report = %+v, want never crawled", report)
+	}
+
+	if _, err := svc.Health(context.Background(), 99); !errors.Is(err, srcUC.ErrSourceNotFound) {
+		t.Errorf("err = %v, want ErrSourceNotFound", err)
+	}
+}
@@ -0,0 +1,110 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"catchup-feed/internal/domain/entity"
+)
+
+// MaxImportEntries bounds one OPML import: every entry costs a feed URL
+// validation (DNS lookup for the SSRF check) and an INSERT.
+const MaxImportEntries = 1000
+
+// Import result statuses.
+const (
+	ImportStatusCreated   = "created"
+	ImportStatusDuplicate = "duplicate"
+	ImportStatusInvalid   = "invalid"
+	ImportStatusFailed    = "failed"
+)
+
+// ErrTooManyImportEntries rejects an import above MaxImportEntries.
+var ErrTooManyImportEntries = fmt.Errorf("import is invalid: must be at most %d entries", MaxImportEntries)
+
+// ImportEntry is one feed to import (an OPML outline). Category is the
+// enclosing folder; Lang and Kind default like in Create.
+type ImportEntry struct {
+	Name     string
+	FeedURL  string
+	Category string
+	Lang     string
+	Kind     string
+}
+
+// ImportResult reports what happened to one entry. Error explains invalid
+// and failed entries.
+type ImportResult struct {
+	Name     string
+	FeedURL  string
+	Category string
+	Status   string
+	Error    string
+}
+
+// Import creates a source for each entry whose feed URL is not yet
+// registered, for users migrating from another RSS reader. Duplicates —
+// of existing sources or of earlier entries in the same import — are
+// skipped, not updated. The import is not atomic: each entry is created on
+// its own and reported in the result, so one bad entry does not block the
+// rest. Only failing to load the existing sources aborts the whole import.
+func (s *Service) Import(ctx context.Context, entries []ImportEntry) ([]ImportResult, error) {
+	if len(entries) > MaxImportEntries {
+		return nil, ErrTooManyImportEntries
+	}
+	existing, err := s.Repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list sources: %w", err)
+	}
+	seen := make(map[string]struct{}, len(existing)+len(entries))
+	for _, src := range existing {
+		seen[normalizeFeedURL(src.FeedURL)] = struct{}{}
+	}
+
+	results := make([]ImportResult, 0, len(entries))
+	for _, e := range entries {
+		e.FeedURL = strings.TrimSpace(e.FeedURL)
+		res := ImportResult{Name: e.Name, FeedURL: e.FeedURL, Category: e.Category}
+		key := normalizeFeedURL(e.FeedURL)
+		if _, dup := seen[key]; dup {
+			res.Status = ImportStatusDuplicate
+			results = append(results, res)
+			continue
+		}
+
+		err := s.Create(ctx, CreateInput{
+			Name: e.Name, FeedURL: e.FeedURL, Category: e.Category, Lang: e.Lang, Kind: e.Kind,
+		})
+		var verr *entity.ValidationError
+		switch {
+		case err == nil:
+			res.Status = ImportStatusCreated
+			seen[key] = struct{}{}
+		case errors.As(err, &verr):
+			res.Status, res.Error = ImportStatusInvalid, verr.Error()
+		default:
+			// Repository errors are not shown to the client (they may
+			// carry SQL details); the per-entry report only says it failed.
+			res.Status, res.Error = ImportStatusFailed, "source could not be created"
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// normalizeFeedURL is the dedup key of a feed URL: scheme and host are
+// case-insensitive, and a trailing slash does not make a different feed.
+func normalizeFeedURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return strings.TrimSpace(raw)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.Fragment = ""
+	return u.String()
+}
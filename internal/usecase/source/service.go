@@ -3,39 +3,78 @@ package source
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/pkg/schedule"
 	"catchup-feed/internal/repository"
+	"catchup-feed/internal/usecase/audit"
 )
 
+// MaxRetentionDays bounds a source's retention_days (about ten years).
+const MaxRetentionDays = 3650
+
 // CreateInput represents the input parameters for creating a new source.
 // Category drives the radio script corner assignment (§4) and is required;
 // Lang defaults to 'en' when empty. Kind selects the content pipeline
 // (Phase 2 §4: rss | youtube | podcast) and defaults to 'rss' when empty.
+// CrawlSchedule is the source's own crawl schedule (cron expression or
+// interval, internal/pkg/schedule); empty follows the worker's global
+// CRON_SCHEDULE. RetentionDays is the source's own article retention
+// window; 0 follows the worker's global RETENTION_DAYS. RenderJS fetches
+// the article pages with the worker's headless browser.
 type CreateInput struct {
-	Name     string
-	FeedURL  string
-	Category string
-	Lang     string
-	Kind     string
+	Name          string
+	FeedURL       string
+	Category      string
+	Lang          string
+	Kind          string
+	CrawlSchedule string
+	RetentionDays int
+	RenderJS      bool
 }
 
 // UpdateInput represents the input parameters for updating an existing source.
 // Empty string fields and nil Active field will not be updated.
+// CrawlSchedule is nil to keep the current schedule and points to an empty
+// string to clear it (back to the global CRON_SCHEDULE). RetentionDays
+// works the same way: nil keeps it, 0 clears it (back to RETENTION_DAYS).
+// RenderJS is nil to keep the current setting, like Active.
 type UpdateInput struct {
-	ID       int64
-	Name     string
-	FeedURL  string
-	Category string
-	Lang     string
-	Kind     string
-	Active   *bool
+	ID            int64
+	Name          string
+	FeedURL       string
+	Category      string
+	Lang          string
+	Kind          string
+	Active        *bool
+	CrawlSchedule *string
+	RetentionDays *int
+	RenderJS      *bool
 }
 
 // Service provides source management use cases.
 // It handles business logic for source operations and delegates persistence to the repository.
 type Service struct {
 	Repo repository.SourceRepository
+	// Audit records create / update / delete into audit_logs; nil
+	// disables auditing.
+	Audit audit.Recorder
+	// HealthRepo reads the per-source crawl health the worker records; nil
+	// reports every source as never crawled.
+	HealthRepo repository.SourceHealthRepository
+	// Now returns the current time for health status; nil means time.Now.
+	Now func() time.Time
+	// Previewer fetches feeds for ValidateFeed; nil disables
+	// POST /sources/validate.
+	Previewer FeedPreviewer
+	// Tx makes BulkUpdate one unit of work; nil applies the updates one by
+	// one, so a failure leaves the earlier ones in place.
+	Tx repository.TxManager
+	// Groups persists the source groups (folders) and their membership;
+	// required by the group use cases only.
+	Groups repository.SourceGroupRepository
 }
 
 // List retrieves all sources from the repository.
@@ -93,10 +132,21 @@ func (s *Service) Create(ctx context.Context, in CreateInput) error {
 		Lang:     in.Lang,
 		Kind:     in.Kind,
 		Active:   true,
+		RenderJS: in.RenderJS,
 	}
 	if err := src.Validate(); err != nil {
 		return err
 	}
+	crawlSchedule, err := normalizeCrawlSchedule(in.CrawlSchedule)
+	if err != nil {
+		return err
+	}
+	src.CrawlSchedule = crawlSchedule
+	retentionDays, err := normalizeRetentionDays(in.RetentionDays)
+	if err != nil {
+		return err
+	}
+	src.RetentionDays = retentionDays
 
 	// URL形式検証
 	if err := entity.ValidateURL(in.FeedURL); err != nil {
@@ -106,6 +156,7 @@ func (s *Service) Create(ctx context.Context, in CreateInput) error {
 	if err := s.Repo.Create(ctx, src); err != nil {
 		return fmt.Errorf("create source: %w", err)
 	}
+	s.record(ctx, entity.AuditActionCreate, src.ID, nil, src)
 	return nil
 }
 
@@ -118,13 +169,26 @@ func (s *Service) Update(ctx context.Context, in UpdateInput) error {
 		return &entity.ValidationError{Field: "id", Message: "must be positive"}
 	}
 
+	before, src, err := s.update(ctx, in)
+	if err != nil {
+		return err
+	}
+	s.record(ctx, entity.AuditActionUpdate, src.ID, before, src)
+	return nil
+}
+
+// update applies in to the stored source and returns it before and after
+// the change, without auditing (BulkUpdate audits once its transaction has
+// committed).
+func (s *Service) update(ctx context.Context, in UpdateInput) (*entity.Source, *entity.Source, error) {
 	src, err := s.Repo.Get(ctx, in.ID)
 	if err != nil {
-		return fmt.Errorf("get source: %w", err)
+		return nil, nil, fmt.Errorf("get source: %w", err)
 	}
 	if src == nil {
-		return ErrSourceNotFound
+		return nil, nil, ErrSourceNotFound
 	}
+	before := *src
 
 	if in.Name != "" {
 		src.Name = in.Name
@@ -132,7 +196,7 @@ func (s *Service) Update(ctx context.Context, in UpdateInput) error {
 	if in.FeedURL != "" {
 		// URL形式検証
 		if err := entity.ValidateURL(in.FeedURL); err != nil {
-			return fmt.Errorf("validate feed URL: %w", err)
+			return nil, nil, fmt.Errorf("validate feed URL: %w", err)
 		}
 		src.FeedURL = in.FeedURL
 	}
@@ -148,14 +212,62 @@ func (s *Service) Update(ctx context.Context, in UpdateInput) error {
 	if in.Active != nil {
 		src.Active = *in.Active
 	}
+	if in.CrawlSchedule != nil {
+		crawlSchedule, err := normalizeCrawlSchedule(*in.CrawlSchedule)
+		if err != nil {
+			return nil, nil, err
+		}
+		src.CrawlSchedule = crawlSchedule
+	}
+	if in.RetentionDays != nil {
+		retentionDays, err := normalizeRetentionDays(*in.RetentionDays)
+		if err != nil {
+			return nil, nil, err
+		}
+		src.RetentionDays = retentionDays
+	}
+	if in.RenderJS != nil {
+		src.RenderJS = *in.RenderJS
+	}
 	if src.Kind != "" && !entity.ValidSourceKind(src.Kind) {
-		return &entity.ValidationError{Field: "kind", Message: "must be one of rss, youtube, podcast"}
+		return nil, nil, &entity.ValidationError{Field: "kind", Message: "must be one of rss, youtube, podcast"}
 	}
 
 	if err := s.Repo.Update(ctx, src); err != nil {
-		return fmt.Errorf("update source: %w", err)
+		return nil, nil, fmt.Errorf("update source: %w", err)
 	}
-	return nil
+	return &before, src, nil
+}
+
+// normalizeCrawlSchedule validates a crawl schedule from the API. Blank
+// means "follow the global schedule" and is stored as NULL.
+func normalizeCrawlSchedule(spec string) (*string, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, nil
+	}
+	if err := schedule.Validate(spec); err != nil {
+		return nil, &entity.ValidationError{
+			Field:   "crawlSchedule",
+			Message: fmt.Sprintf("must be a 5-field cron expression or an interval of at least %s", schedule.MinInterval),
+		}
+	}
+	return &spec, nil
+}
+
+// normalizeRetentionDays validates a retention window from the API. 0
+// means "follow the global RETENTION_DAYS" and is stored as NULL.
+func normalizeRetentionDays(days int) (*int, error) {
+	if days == 0 {
+		return nil, nil
+	}
+	if days < entity.MinRetentionDays || days > MaxRetentionDays {
+		return nil, &entity.ValidationError{
+			Field:   "retentionDays",
+			Message: fmt.Sprintf("must be between %d and %d", entity.MinRetentionDays, MaxRetentionDays),
+		}
+	}
+	return &days, nil
 }
 
 // Delete removes a source by its ID.
@@ -166,8 +278,18 @@ func (s *Service) Delete(ctx context.Context, id int64) error {
 		return &entity.ValidationError{Field: "id", Message: "must be positive"}
 	}
 
+	// 監査ログの before 用。監査無効時は余分なクエリを発行しない。
+	var before *entity.Source
+	if s.Audit != nil {
+		var err error
+		if before, err = s.Repo.Get(ctx, id); err != nil {
+			return fmt.Errorf("get source: %w", err)
+		}
+	}
+
 	if err := s.Repo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("delete source: %w", err)
 	}
+	s.record(ctx, entity.AuditActionDelete, id, before, nil)
 	return nil
 }
@@ -8,6 +8,7 @@ import (
 
 	"catchup-feed/internal/domain/entity"
 	"catchup-feed/internal/repository"
+	"catchup-feed/internal/usecase/audit"
 	srcUC "catchup-feed/internal/usecase/source"
 )
 
@@ -70,6 +71,10 @@ func (s *stubRepo) Delete(_ context.Context, id int64) error {
 	delete(s.data, id)
 	return nil
 }
+
+func (s *stubRepo) UpdateFeedValidators(_ context.Context, _ int64, _, _ string) error {
+	return nil
+}
 func (s *stubRepo) TouchCrawledAt(ctx context.Context, id int64, t time.Time) error {
 	return nil // ユースケースでは使用しない
 }
@@ -190,6 +195,133 @@ func TestService_Update_ok(t *testing.T) {
 	}
 }
 
+/* 4a. Create / Update: crawl_schedule の設定・維持・解除・バリデーション */
+func TestService_CrawlSchedule(t *testing.T) {
+	stub := newStub()
+	svc := srcUC.Service{Repo: stub}
+
+	err := svc.Create(context.Background(), srcUC.CreateInput{
+		Name: "Go Blog", FeedURL: "https://go.dev/blog/feed.atom", Category: "go",
+		CrawlSchedule: " */30 * * * * ",
+	})
+	if err != nil {
+		t.Fatalf("Create err=%v", err)
+	}
+	if got := stub.data[1].CrawlSchedule; got == nil || *got != "*/30 * * * *" {
+		t.Fatalf("crawl schedule = %v, want trimmed cron expression", got)
+	}
+
+	err = svc.Create(context.Background(), srcUC.CreateInput{
+		Name: "Bad", FeedURL: "https://example.com/feed", Category: "go",
+		CrawlSchedule: "* * * * *",
+	})
+	var verr *entity.ValidationError
+	if !errors.As(err, &verr) || verr.Field != "crawlSchedule" {
+		t.Fatalf("want crawlSchedule validation error, got %v", err)
+	}
+
+	// nil keeps the schedule
+	if err := svc.Update(context.Background(), srcUC.UpdateInput{ID: 1, Name: "Go"}); err != nil {
+		t.Fatalf("Update err=%v", err)
+	}
+	if stub.data[1].CrawlSchedule == nil {
+		t.Fatal("crawl schedule was cleared by an update without crawl_schedule")
+	}
+
+	interval := "2h"
+	if err := svc.Update(context.Background(), srcUC.UpdateInput{ID: 1, CrawlSchedule: &interval}); err != nil {
+		t.Fatalf("Update err=%v", err)
+	}
+	if got := stub.data[1].CrawlSchedule; got == nil || *got != "2h" {
+		t.Fatalf("crawl schedule = %v, want 2h", got)
+	}
+
+	// "" clears it (back to CRON_SCHEDULE)
+	empty := ""
+	if err := svc.Update(context.Background(), srcUC.UpdateInput{ID: 1, CrawlSchedule: &empty}); err != nil {
+		t.Fatalf("Update err=%v", err)
+	}
+	if got := stub.data[1].CrawlSchedule; got != nil {
+		t.Fatalf("crawl schedule = %q, want nil", *got)
+	}
+}
+
+func TestService_RetentionDays(t *testing.T) {
+	stub := newStub()
+	svc := srcUC.Service{Repo: stub}
+
+	err := svc.Create(context.Background(), srcUC.CreateInput{
+		Name: "Go Blog", FeedURL: "https://go.dev/blog/feed.atom", Category: "go",
+		RetentionDays: 90,
+	})
+	if err != nil {
+		t.Fatalf("Create err=%v", err)
+	}
+	if got := stub.data[1].RetentionDays; got == nil || *got != 90 {
+		t.Fatalf("retention days = %v, want 90", got)
+	}
+
+	for _, days := range []int{entity.MinRetentionDays - 1, srcUC.MaxRetentionDays + 1, -1} {
+		err = svc.Create(context.Background(), srcUC.CreateInput{
+			Name: "Bad", FeedURL: "https://example.com/feed", Category: "go",
+			RetentionDays: days,
+		})
+		var verr *entity.ValidationError
+		if !errors.As(err, &verr) || verr.Field != "retentionDays" {
+			t.Fatalf("days=%d: want retentionDays validation error, got %v", days, err)
+		}
+	}
+
+	// nil keeps the window
+	if err := svc.Update(context.Background(), srcUC.UpdateInput{ID: 1, Name: "Go"}); err != nil {
+		t.Fatalf("Update err=%v", err)
+	}
+	if stub.data[1].RetentionDays == nil {
+		t.Fatal("retention days were cleared by an update without retention_days")
+	}
+
+	// 0 clears it (back to RETENTION_DAYS)
+	zero := 0
+	if err := svc.Update(context.Background(), srcUC.UpdateInput{ID: 1, RetentionDays: &zero}); err != nil {
+		t.Fatalf("Update err=%v", err)
+	}
+	if got := stub.data[1].RetentionDays; got != nil {
+		t.Fatalf("retention days = %d, want nil", *got)
+	}
+}
+
+func TestService_RenderJS(t *testing.T) {
+	stub := newStub()
+	svc := srcUC.Service{Repo: stub}
+
+	err := svc.Create(context.Background(), srcUC.CreateInput{
+		Name: "SPA Blog", FeedURL: "https://example.com/feed", Category: "dev",
+		RenderJS: true,
+	})
+	if err != nil {
+		t.Fatalf("Create err=%v", err)
+	}
+	if !stub.data[1].RenderJS {
+		t.Fatal("render_js was not stored")
+	}
+
+	// nil keeps the flag
+	if err := svc.Update(context.Background(), srcUC.UpdateInput{ID: 1, Name: "SPA"}); err != nil {
+		t.Fatalf("Update err=%v", err)
+	}
+	if !stub.data[1].RenderJS {
+		t.Fatal("render_js was cleared by an update without render_js")
+	}
+
+	off := false
+	if err := svc.Update(context.Background(), srcUC.UpdateInput{ID: 1, RenderJS: &off}); err != nil {
+		t.Fatalf("Update err=%v", err)
+	}
+	if stub.data[1].RenderJS {
+		t.Fatal("render_js = true, want false")
+	}
+}
+
 /* 4b. Update: kind の変更・維持・バリデーション (Phase 2 §4) */
 func TestService_Update_kind(t *testing.T) {
 	tests := []struct {
@@ -742,3 +874,107 @@ func TestService_Delete_success(t *testing.T) {
 		})
 	}
 }
+
+/* 13. 監査ログ: create / update / delete が before/after 付きで記録される */
+type stubRecorder struct{ entries []audit.Entry }
+
+func (r *stubRecorder) Record(_ context.Context, e audit.Entry) {
+	r.entries = append(r.entries, e)
+}
+
+func TestService_Audit(t *testing.T) {
+	stub := newStub()
+	rec := &stubRecorder{}
+	svc := srcUC.Service{Repo: stub, Audit: rec}
+	ctx := context.Background()
+
+	if err := svc.Create(ctx, srcUC.CreateInput{
+		Name: "Qiita", FeedURL: "https://qiita.com/feed", Category: "community",
+	}); err != nil {
+		t.Fatalf("Create err=%v", err)
+	}
+	if err := svc.Update(ctx, srcUC.UpdateInput{ID: 1, Name: "Qiita Go"}); err != nil {
+		t.Fatalf("Update err=%v", err)
+	}
+	if err := svc.Delete(ctx, 1); err != nil {
+		t.Fatalf("Delete err=%v", err)
+	}
+	// 失敗した変更は記録しない
+	_ = svc.Update(ctx, srcUC.UpdateInput{ID: 99, Name: "x"})
+
+	wantActions := []string{entity.AuditActionCreate, entity.AuditActionUpdate, entity.AuditActionDelete}
+	if len(rec.entries) != len(wantActions) {
+		t.Fatalf("want %d entries, got %d", len(wantActions), len(rec.entries))
+	}
+	for i, e := range rec.entries {
+		if e.Action != wantActions[i] || e.ResourceType != entity.AuditResourceSource {
+			t.Errorf("entry %d = %s/%s", i, e.Action, e.ResourceType)
+		}
+		if e.ResourceID == nil || *e.ResourceID != 1 {
+			t.Errorf("entry %d resource id = %v, want 1", i, e.ResourceID)
+		}
+	}
+	if rec.entries[0].Before != nil || rec.entries[2].After != nil {
+		t.Errorf("create must have no before, delete no after")
+	}
+	// update の before は変更前の値を保持する
+	if rec.entries[1].Before == rec.entries[1].After {
+		t.Errorf("update before/after must differ: %#v", rec.entries[1])
+	}
+}
+
+/* Import: フィード URL で重複排除し、エントリごとの結果を返す */
+func TestService_Import(t *testing.T) {
+	stub := newStub()
+	stub.data[1] = &entity.Source{ID: 1, Name: "Go Blog", FeedURL: "https://go.dev/blog/feed.atom", Category: "go"}
+	stub.nextID = 2
+	svc := srcUC.Service{Repo: stub}
+
+	results, err := svc.Import(context.Background(), []srcUC.ImportEntry{
+		{Name: "Go Blog", FeedURL: "HTTPS://GO.DEV/blog/feed.atom/", Category: "go"},
+		{Name: "Qiita", FeedURL: "https://qiita.com/feed", Category: "community"},
+		{Name: "Qiita again", FeedURL: "https://qiita.com/feed", Category: "community"},
+		{Name: "Broken", FeedURL: "ftp://example.com/feed", Category: "misc"},
+		{Name: "", FeedURL: "https://example.com/feed", Category: "misc"},
+	})
+	if err != nil {
+		t.Fatalf("Import err=%v", err)
+	}
+
+	want := []string{
+		srcUC.ImportStatusDuplicate,
+		srcUC.ImportStatusCreated,
+		srcUC.ImportStatusDuplicate,
+		srcUC.ImportStatusInvalid,
+		srcUC.ImportStatusInvalid,
+	}
+	if len(results) != len(want) {
+		t.Fatalf("results = %d, want %d", len(results), len(want))
+	}
+	for i, status := range want {
+		if results[i].Status != status {
+			t.Errorf("results[%d].Status = %q, want %q (error %q)", i, results[i].Status, status, results[i].Error)
+		}
+	}
+	if results[3].Error == "" {
+		t.Error("invalid entry should explain why")
+	}
+	if len(stub.data) != 2 {
+		t.Errorf("want 2 sources, got %d", len(stub.data))
+	}
+}
+
+func TestService_Import_limits(t *testing.T) {
+	svc := srcUC.Service{Repo: newStub()}
+	entries := make([]srcUC.ImportEntry, srcUC.MaxImportEntries+1)
+	if _, err := svc.Import(context.Background(), entries); !errors.Is(err, srcUC.ErrTooManyImportEntries) {
+		t.Fatalf("err = %v, want ErrTooManyImportEntries", err)
+	}
+
+	stub := newStub()
+	stub.err = errors.New("db down")
+	svc = srcUC.Service{Repo: stub}
+	if _, err := svc.Import(context.Background(), []srcUC.ImportEntry{{Name: "a"}}); err == nil {
+		t.Fatal("want error when existing sources cannot be listed")
+	}
+}
@@ -0,0 +1,54 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/usecase/fetch"
+)
+
+// PreviewItemLimit is the number of sample entries ValidateFeed returns.
+const PreviewItemLimit = 5
+
+// FeedPreviewer fetches and parses a feed without storing anything
+// (implemented by scraper.FeedPreviewer, which also guards against SSRF).
+type FeedPreviewer interface {
+	Preview(ctx context.Context, feedURL string, maxItems int) (*fetch.FeedPreview, error)
+}
+
+// ValidateFeed fetches feedURL and reports what the crawler would see, so a
+// feed can be checked before it is registered as a source.
+// Returns a ValidationError for a malformed URL or one resolving to a
+// private address, ErrFeedUnreachable when the download fails and
+// ErrNotAFeed when the response does not parse as a feed.
+func (s *Service) ValidateFeed(ctx context.Context, feedURL string) (*fetch.FeedPreview, error) {
+	if s.Previewer == nil {
+		return nil, errors.New("validate feed: feed previewer is not configured")
+	}
+	if err := entity.ValidateURL(feedURL); err != nil {
+		return nil, err
+	}
+
+	preview, err := s.Previewer.Preview(ctx, feedURL, PreviewItemLimit)
+	if err == nil {
+		return preview, nil
+	}
+	var statusErr *fetch.FeedStatusError
+	var urlErr *url.Error
+	switch {
+	case errors.Is(err, fetch.ErrInvalidURL), errors.Is(err, fetch.ErrPrivateIP):
+		return nil, &entity.ValidationError{Field: "feedURL", Message: "feed URL is invalid or resolves to a private address"}
+	case errors.As(err, &statusErr):
+		return nil, fmt.Errorf("%w: HTTP %d", ErrFeedUnreachable, statusErr.StatusCode)
+	case errors.Is(err, fetch.ErrTooManyRedirects):
+		return nil, fmt.Errorf("%w: too many redirects", ErrFeedUnreachable)
+	case errors.As(err, &urlErr), errors.Is(err, context.DeadlineExceeded):
+		return nil, ErrFeedUnreachable
+	default:
+		// 取得できたがフィードとして解釈できない(HTML ページ、壊れた XML など)
+		return nil, ErrNotAFeed
+	}
+}
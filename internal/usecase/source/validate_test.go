@@ -0,0 +1,68 @@
+package source_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/usecase/fetch"
+	srcUC "catchup-feed/internal/usecase/source"
+)
+
+type stubPreviewer struct {
+	preview *fetch.FeedPreview
+	err     error
+}
+
+func (s stubPreviewer) Preview(context.Context, string, int) (*fetch.FeedPreview, error) {
+	return s.preview, s.err
+}
+
+func TestService_ValidateFeed(t *testing.T) {
+	tests := []struct {
+		name        string
+		feedURL     string
+		previewErr  error
+		wantErr     error
+		wantInvalid bool
+	}{
+		{name: "ok", feedURL: "https://go.dev/blog/feed.atom"},
+		{name: "bad scheme", feedURL: "ftp://go.dev/feed", wantInvalid: true},
+		{name: "private address", feedURL: "https://internal.example/feed",
+			previewErr: fmt.Errorf("%w: hostname resolves to private IP 10.0.0.1", fetch.ErrPrivateIP), wantInvalid: true},
+		{name: "http status", feedURL: "https://go.dev/feed",
+			previewErr: &fetch.FeedStatusError{StatusCode: 404}, wantErr: srcUC.ErrFeedUnreachable},
+		{name: "network", feedURL: "https://go.dev/feed",
+			previewErr: &url.Error{Op: "Get", URL: "https://go.dev/feed", Err: errors.New("connection refused")}, wantErr: srcUC.ErrFeedUnreachable},
+		{name: "not a feed", feedURL: "https://go.dev/",
+			previewErr: fmt.Errorf("%w: failed to detect feed type", fetch.ErrInvalidFeedFormat), wantErr: srcUC.ErrNotAFeed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := srcUC.Service{Previewer: stubPreviewer{
+				preview: &fetch.FeedPreview{Type: "atom", Title: "The Go Blog"},
+				err:     tt.previewErr,
+			}}
+			preview, err := svc.ValidateFeed(context.Background(), tt.feedURL)
+
+			var verr *entity.ValidationError
+			switch {
+			case tt.wantInvalid:
+				if !errors.As(err, &verr) {
+					t.Fatalf("err = %v, want ValidationError", err)
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+			default:
+				if err != nil || preview.Title != "The Go Blog" {
+					t.Fatalf("preview = %+v, err = %v", preview, err)
+				}
+			}
+		})
+	}
+}
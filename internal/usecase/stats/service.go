@@ -0,0 +1,103 @@
+// Package stats provides the admin dashboard statistics (GET /stats):
+// article counts per source and per day / week, the summarize error rate
+// and crawl durations from the crawl history. Aggregation is plain SQL in
+// the repository — no metrics stack (設計原則 1).
+package stats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// Window bounds. Defaults cover a month of daily counts and a quarter of
+// weekly counts; the caps keep the series short enough to chart.
+const (
+	DefaultDays  = 30
+	MaxDays      = 366
+	DefaultWeeks = 12
+	MaxWeeks     = 104
+)
+
+// jst is the day boundary of the buckets, like the broadcast day.
+var jst = time.FixedZone("JST", 9*60*60)
+
+// Stats is the GET /stats report.
+type Stats struct {
+	// Since is the start (JST midnight) of the Days window that the
+	// per-source recent counts and the crawl stats cover.
+	Since   time.Time
+	Days    int
+	Weeks   int
+	Sources []*entity.SourceArticleStats
+	PerDay  []entity.ArticleCountBucket
+	// PerWeek buckets start on Mondays.
+	PerWeek []entity.ArticleCountBucket
+	Crawls  entity.CrawlRunStats
+	// SummarizeErrorRate is summarize errors per inserted article over the
+	// window; nil when nothing was inserted.
+	SummarizeErrorRate *float64
+}
+
+// Service computes the dashboard statistics.
+type Service struct {
+	Repo repository.StatsRepository
+	// Now returns the current time; nil means time.Now. Injected for
+	// deterministic windows in tests.
+	Now func() time.Time
+}
+
+func (s *Service) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// Get computes the report for the last days days (daily counts, recent
+// per-source counts, crawl stats) and the last weeks weeks (weekly
+// counts), both including today. Values <= 0 fall back to the defaults
+// and are capped at MaxDays / MaxWeeks. Windows start on JST day
+// boundaries, so repeated calls within a day query the same ranges (and
+// share cache entries).
+func (s *Service) Get(ctx context.Context, days, weeks int) (*Stats, error) {
+	if days <= 0 {
+		days = DefaultDays
+	}
+	days = min(days, MaxDays)
+	if weeks <= 0 {
+		weeks = DefaultWeeks
+	}
+	weeks = min(weeks, MaxWeeks)
+
+	now := s.now().In(jst)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, jst)
+	since := today.AddDate(0, 0, -(days - 1))
+	// Monday of the current ISO week.
+	monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+
+	out := &Stats{Since: since, Days: days, Weeks: weeks}
+	var err error
+	if out.Sources, err = s.Repo.ArticleCountsBySource(ctx, since); err != nil {
+		return nil, fmt.Errorf("stats by source: %w", err)
+	}
+	if out.PerDay, err = s.Repo.ArticleCountsByPeriod(ctx, since, today, 1); err != nil {
+		return nil, fmt.Errorf("stats per day: %w", err)
+	}
+	if out.PerWeek, err = s.Repo.ArticleCountsByPeriod(ctx, monday.AddDate(0, 0, -7*(weeks-1)), monday, 7); err != nil {
+		return nil, fmt.Errorf("stats per week: %w", err)
+	}
+	crawls, err := s.Repo.CrawlRunStats(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("crawl stats: %w", err)
+	}
+	out.Crawls = *crawls
+	if crawls.Inserted > 0 {
+		rate := float64(crawls.SummarizeErrors) / float64(crawls.Inserted)
+		out.SummarizeErrorRate = &rate
+	}
+	return out, nil
+}
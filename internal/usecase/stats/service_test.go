@@ -0,0 +1,89 @@
+package stats_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	statsUC "catchup-feed/internal/usecase/stats"
+)
+
+type periodCall struct {
+	from, to string
+	stepDays int
+}
+
+type stubStatsRepo struct {
+	sourcesSince time.Time
+	crawlsSince  time.Time
+	periods      []periodCall
+	crawls       entity.CrawlRunStats
+	err          error
+}
+
+func (s *stubStatsRepo) ArticleCountsBySource(_ context.Context, since time.Time) ([]*entity.SourceArticleStats, error) {
+	s.sourcesSince = since
+	return []*entity.SourceArticleStats{{SourceID: 1, SourceName: "Go Blog", Total: 10, Recent: 3}}, s.err
+}
+
+func (s *stubStatsRepo) ArticleCountsByPeriod(_ context.Context, from, to time.Time, stepDays int) ([]entity.ArticleCountBucket, error) {
+	s.periods = append(s.periods, periodCall{from.Format(time.DateOnly), to.Format(time.DateOnly), stepDays})
+	return []entity.ArticleCountBucket{{Day: from.Format(time.DateOnly), Count: 1}}, nil
+}
+
+func (s *stubStatsRepo) CrawlRunStats(_ context.Context, since time.Time) (*entity.CrawlRunStats, error) {
+	s.crawlsSince = since
+	crawls := s.crawls
+	return &crawls, nil
+}
+
+// 2026-10-15 (Thu) 20:00 UTC is already Friday 05:00 in JST.
+func fixedNow() time.Time {
+	return time.Date(2026, 10, 15, 20, 0, 0, 0, time.UTC)
+}
+
+func TestService_Get_Windows(t *testing.T) {
+	repo := &stubStatsRepo{crawls: entity.CrawlRunStats{Runs: 7, Inserted: 40, SummarizeErrors: 2}}
+	svc := &statsUC.Service{Repo: repo, Now: fixedNow}
+
+	got, err := svc.Get(context.Background(), 7, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2026-10-10T00:00:00+09:00", got.Since.Format(time.RFC3339), "7 days including today (JST)")
+	assert.Equal(t, got.Since, repo.sourcesSince)
+	assert.Equal(t, got.Since, repo.crawlsSince)
+	assert.Equal(t, []periodCall{
+		{"2026-10-10", "2026-10-16", 1},
+		{"2026-10-05", "2026-10-12", 7}, // Mondays
+	}, repo.periods)
+
+	assert.Equal(t, 7, got.Days)
+	assert.Equal(t, 2, got.Weeks)
+	require.Len(t, got.Sources, 1)
+	assert.Equal(t, int64(7), got.Crawls.Runs)
+	require.NotNil(t, got.SummarizeErrorRate)
+	assert.InDelta(t, 0.05, *got.SummarizeErrorRate, 1e-9)
+}
+
+func TestService_Get_Defaults(t *testing.T) {
+	repo := &stubStatsRepo{}
+	svc := &statsUC.Service{Repo: repo, Now: fixedNow}
+
+	got, err := svc.Get(context.Background(), 0, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, statsUC.DefaultDays, got.Days)
+	assert.Equal(t, statsUC.MaxWeeks, got.Weeks)
+	assert.Nil(t, got.SummarizeErrorRate, "no inserted articles, no rate")
+}
+
+func TestService_Get_Error(t *testing.T) {
+	svc := &statsUC.Service{Repo: &stubStatsRepo{err: errors.New("db down")}, Now: fixedNow}
+
+	_, err := svc.Get(context.Background(), 0, 0)
+	assert.ErrorContains(t, err, "db down")
+}
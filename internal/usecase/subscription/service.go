@@ -0,0 +1,118 @@
+// Package subscription provides per-user source subscriptions: a
+// dashboard account follows a subset of the sources, and the article
+// list, search and event stream of accounts other than admins are scoped
+// to them. Not to be confused with the podcast feed subscribers (package
+// subscriber). The caller is identified by the login subject
+// (users.email) the auth middleware puts in the request context.
+package subscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// Sentinel errors. Messages contain respond.SafeError's safe words so they
+// reach the client verbatim.
+var (
+	// ErrAccountNotFound indicates the caller has no active account, e.g.
+	// an API key identity: subscriptions are kept per user.
+	ErrAccountNotFound = errors.New("account not found: subscriptions require a user account")
+
+	// ErrSourceNotFound indicates the source to subscribe to does not
+	// exist.
+	ErrSourceNotFound = errors.New("source not found")
+)
+
+// Service follows and unfollows sources for the calling user.
+type Service struct {
+	Users         repository.UserRepository
+	Subscriptions repository.SourceSubscriptionRepository
+}
+
+// userID resolves the subject to an active account. ok is false when
+// there is none.
+func (s *Service) userID(ctx context.Context, subject string) (id int64, ok bool, err error) {
+	user, err := s.Users.GetActiveByEmail(ctx, strings.ToLower(strings.TrimSpace(subject)))
+	if err != nil {
+		return 0, false, fmt.Errorf("get account: %w", err)
+	}
+	if user == nil {
+		return 0, false, nil
+	}
+	return user.ID, true, nil
+}
+
+// Subscribe follows the source. Subscribing again is a no-op.
+func (s *Service) Subscribe(ctx context.Context, subject string, sourceID int64) error {
+	userID, ok, err := s.userID(ctx, subject)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrAccountNotFound
+	}
+	exists, err := s.Subscriptions.Subscribe(ctx, userID, sourceID)
+	if err != nil {
+		return fmt.Errorf("subscribe: %w", err)
+	}
+	if !exists {
+		return ErrSourceNotFound
+	}
+	return nil
+}
+
+// Unsubscribe unfollows the source. Unfollowed sources are not an error
+// (idempotent).
+func (s *Service) Unsubscribe(ctx context.Context, subject string, sourceID int64) error {
+	userID, ok, err := s.userID(ctx, subject)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrAccountNotFound
+	}
+	if err := s.Subscriptions.Unsubscribe(ctx, userID, sourceID); err != nil {
+		return fmt.Errorf("unsubscribe: %w", err)
+	}
+	return nil
+}
+
+// List returns the sources the caller subscribes to.
+func (s *Service) List(ctx context.Context, subject string) ([]*entity.Source, error) {
+	userID, ok, err := s.userID(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrAccountNotFound
+	}
+	sources, err := s.Subscriptions.ListSources(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list subscriptions: %w", err)
+	}
+	return sources, nil
+}
+
+// SubscribedSourceIDs returns the IDs of the sources the caller
+// subscribes to. Like favorite.Service.FavoritedIDs, a caller without an
+// account has none rather than an error; it scopes the article event
+// stream.
+func (s *Service) SubscribedSourceIDs(ctx context.Context, subject string) (map[int64]bool, error) {
+	userID, ok, err := s.userID(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return map[int64]bool{}, nil
+	}
+	ids, err := s.Subscriptions.SourceIDs(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("subscribed source ids: %w", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,120 @@
+package subscription
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+/* ───────── モック実装 ───────── */
+
+// stubUserRepo implements only GetActiveByEmail.
+type stubUserRepo struct {
+	repository.UserRepository
+}
+
+func (stubUserRepo) GetActiveByEmail(_ context.Context, email string) (*entity.User, error) {
+	if email == "alice@example.com" {
+		return &entity.User{ID: 7, Email: email}, nil
+	}
+	return nil, nil
+}
+
+// stubSubscriptions keeps subscriptions of existing sources (ID < 100)
+// in memory.
+type stubSubscriptions struct {
+	followed map[int64]map[int64]bool // user → source
+}
+
+func (s *stubSubscriptions) Subscribe(_ context.Context, userID, sourceID int64) (bool, error) {
+	if sourceID >= 100 {
+		return false, nil
+	}
+	if s.followed[userID] == nil {
+		s.followed[userID] = map[int64]bool{}
+	}
+	s.followed[userID][sourceID] = true
+	return true, nil
+}
+
+func (s *stubSubscriptions) Unsubscribe(_ context.Context, userID, sourceID int64) error {
+	delete(s.followed[userID], sourceID)
+	return nil
+}
+
+func (s *stubSubscriptions) ListSources(_ context.Context, userID int64) ([]*entity.Source, error) {
+	out := []*entity.Source{}
+	for id := range s.followed[userID] {
+		out = append(out, &entity.Source{ID: id})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func (s *stubSubscriptions) SourceIDs(_ context.Context, userID int64) (map[int64]bool, error) {
+	out := map[int64]bool{}
+	for id := range s.followed[userID] {
+		out[id] = true
+	}
+	return out, nil
+}
+
+func newService() (*Service, *stubSubscriptions) {
+	subs := &stubSubscriptions{followed: map[int64]map[int64]bool{}}
+	return &Service{Users: stubUserRepo{}, Subscriptions: subs}, subs
+}
+
+/* ───────── テスト ───────── */
+
+func TestService_SubscribeAndUnsubscribe(t *testing.T) {
+	svc, subs := newService()
+	ctx := context.Background()
+
+	require.NoError(t, svc.Subscribe(ctx, " Alice@Example.com ", 3))
+	require.NoError(t, svc.Subscribe(ctx, "alice@example.com", 3), "subscribing twice is a no-op")
+	assert.Equal(t, map[int64]bool{3: true}, subs.followed[7])
+
+	assert.ErrorIs(t, svc.Subscribe(ctx, "alice@example.com", 100), ErrSourceNotFound)
+	assert.ErrorIs(t, svc.Subscribe(ctx, "apikey:ci", 3), ErrAccountNotFound)
+
+	require.NoError(t, svc.Unsubscribe(ctx, "alice@example.com", 3))
+	require.NoError(t, svc.Unsubscribe(ctx, "alice@example.com", 3), "unsubscribing twice is a no-op")
+	assert.Empty(t, subs.followed[7])
+	assert.ErrorIs(t, svc.Unsubscribe(ctx, "apikey:ci", 3), ErrAccountNotFound)
+}
+
+func TestService_List(t *testing.T) {
+	svc, _ := newService()
+	ctx := context.Background()
+	require.NoError(t, svc.Subscribe(ctx, "alice@example.com", 5))
+	require.NoError(t, svc.Subscribe(ctx, "alice@example.com", 3))
+
+	got, err := svc.List(ctx, "alice@example.com")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, int64(3), got[0].ID)
+
+	_, err = svc.List(ctx, "apikey:ci")
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+}
+
+func TestService_SubscribedSourceIDs(t *testing.T) {
+	svc, _ := newService()
+	ctx := context.Background()
+	require.NoError(t, svc.Subscribe(ctx, "alice@example.com", 3))
+
+	got, err := svc.SubscribedSourceIDs(ctx, "alice@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]bool{3: true}, got)
+
+	// アカウントのない呼び出し元は購読なし。
+	got, err = svc.SubscribedSourceIDs(ctx, "apikey:ci")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
@@ -0,0 +1,45 @@
+// Package tag provides the article tag use cases: CRUD over the tag
+// vocabulary, replacing an article's tags, and tag suggestions derived
+// from the article's feed category and the tags its sibling articles
+// already carry.
+package tag
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxTagsPerArticle bounds PUT /articles/{id}/tags.
+const MaxTagsPerArticle = 20
+
+// MaxBulkArticles bounds POST /articles/bulk-tags.
+const MaxBulkArticles = 500
+
+// Sentinel errors. Messages contain respond.SafeError's safe words so they
+// reach the client verbatim.
+var (
+	// ErrTagNotFound indicates the tag does not exist.
+	ErrTagNotFound = errors.New("tag not found")
+
+	// ErrArticleNotFound indicates the article to tag does not exist.
+	ErrArticleNotFound = errors.New("article not found")
+
+	// ErrInvalidID indicates a non-positive tag or article ID.
+	ErrInvalidID = errors.New("invalid ID: must be positive")
+
+	// ErrTagNameTaken indicates another tag already uses the name
+	// (tags.name UNIQUE, HTTP 409).
+	ErrTagNameTaken = errors.New("tag already exists")
+
+	// ErrTooManyTags indicates more than MaxTagsPerArticle tags for one
+	// article.
+	ErrTooManyTags = fmt.Errorf("tags are invalid: must be at most %d per article", MaxTagsPerArticle)
+
+	// ErrInvalidArticleIDs indicates an empty, oversized or non-positive
+	// ID list in a bulk request.
+	ErrInvalidArticleIDs = fmt.Errorf("article_ids are invalid: must be 1 to %d positive IDs", MaxBulkArticles)
+
+	// ErrNoTagChanges indicates a bulk tag update with neither tags to add
+	// nor tags to remove.
+	ErrNoTagChanges = errors.New("tags are invalid: add or remove at least one tag")
+)
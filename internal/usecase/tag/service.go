@@ -0,0 +1,245 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// DefaultSuggestionLimit is how many tags Suggest returns at most.
+const DefaultSuggestionLimit = 5
+
+// Service provides tag management and article tagging.
+type Service struct {
+	Tags     repository.TagRepository
+	Articles repository.ArticleRepository
+	Sources  repository.SourceRepository
+}
+
+// List returns every tag with its article count, ordered by name.
+func (s *Service) List(ctx context.Context) ([]*entity.Tag, error) {
+	tags, err := s.Tags.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+	return tags, nil
+}
+
+// Create adds a tag. The name is normalized first; a validation failure is
+// returned as *entity.ValidationError.
+func (s *Service) Create(ctx context.Context, name string) (*entity.Tag, error) {
+	tag := &entity.Tag{Name: entity.NormalizeTagName(name)}
+	if err := entity.ValidateTagName(tag.Name); err != nil {
+		return nil, err
+	}
+	if err := s.Tags.Create(ctx, tag); err != nil {
+		return nil, mapDuplicate("create tag", err)
+	}
+	return tag, nil
+}
+
+// Rename changes a tag's name. Articles keep the tag (assignments follow
+// the ID).
+func (s *Service) Rename(ctx context.Context, id int64, name string) (*entity.Tag, error) {
+	tag, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	tag.Name = entity.NormalizeTagName(name)
+	if err := entity.ValidateTagName(tag.Name); err != nil {
+		return nil, err
+	}
+	if err := s.Tags.Update(ctx, tag); err != nil {
+		return nil, mapDuplicate("rename tag", err)
+	}
+	return tag, nil
+}
+
+// Delete removes a tag and its assignments.
+func (s *Service) Delete(ctx context.Context, id int64) error {
+	if _, err := s.get(ctx, id); err != nil {
+		return err
+	}
+	if err := s.Tags.Delete(ctx, id); err != nil {
+		return fmt.Errorf("delete tag: %w", err)
+	}
+	return nil
+}
+
+// ArticleTags returns the article's tags.
+func (s *Service) ArticleTags(ctx context.Context, articleID int64) ([]*entity.Tag, error) {
+	if _, err := s.article(ctx, articleID); err != nil {
+		return nil, err
+	}
+	tags, err := s.Tags.ListByArticle(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("list article tags: %w", err)
+	}
+	return tags, nil
+}
+
+// SetArticleTags replaces the article's tags with names. Names are
+// normalized and deduplicated; unknown names create new tags. An empty
+// list removes every tag.
+func (s *Service) SetArticleTags(ctx context.Context, articleID int64, names []string) ([]*entity.Tag, error) {
+	if _, err := s.article(ctx, articleID); err != nil {
+		return nil, err
+	}
+	normalized, err := normalizeNames(names)
+	if err != nil {
+		return nil, err
+	}
+	if len(normalized) > MaxTagsPerArticle {
+		return nil, ErrTooManyTags
+	}
+	tags, err := s.Tags.SetArticleTags(ctx, articleID, normalized)
+	if err != nil {
+		return nil, fmt.Errorf("set article tags: %w", err)
+	}
+	return tags, nil
+}
+
+// UpdateArticlesTags adds and removes tags on many articles in one
+// transaction and returns the IDs of the articles that exist; missing IDs
+// are skipped. Removals are applied before additions, so a name in both
+// lists ends up assigned. Names are normalized like SetArticleTags.
+func (s *Service) UpdateArticlesTags(ctx context.Context, articleIDs []int64, add, remove []string) ([]int64, error) {
+	if len(articleIDs) == 0 || len(articleIDs) > MaxBulkArticles {
+		return nil, ErrInvalidArticleIDs
+	}
+	ids := make([]int64, 0, len(articleIDs))
+	seen := make(map[int64]struct{}, len(articleIDs))
+	for _, id := range articleIDs {
+		if id <= 0 {
+			return nil, ErrInvalidArticleIDs
+		}
+		if _, dup := seen[id]; dup {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	addNames, err := normalizeNames(add)
+	if err != nil {
+		return nil, err
+	}
+	removeNames, err := normalizeNames(remove)
+	if err != nil {
+		return nil, err
+	}
+	if len(addNames) == 0 && len(removeNames) == 0 {
+		return nil, ErrNoTagChanges
+	}
+	if len(addNames) > MaxTagsPerArticle {
+		return nil, ErrTooManyTags
+	}
+
+	updated, err := s.Tags.UpdateArticlesTags(ctx, ids, addNames, removeNames)
+	if err != nil {
+		return nil, fmt.Errorf("update articles tags: %w", err)
+	}
+	return updated, nil
+}
+
+// Suggest proposes tags for an article that it does not carry yet: first
+// its feed's category (sources.category, the same label that drives the
+// radio corners), then the tags most used on other articles of the same
+// feed. At most DefaultSuggestionLimit names are returned.
+func (s *Service) Suggest(ctx context.Context, articleID int64) ([]string, error) {
+	art, err := s.article(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	assigned, err := s.Tags.ListByArticle(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("list article tags: %w", err)
+	}
+	skip := make(map[string]struct{}, len(assigned))
+	for _, t := range assigned {
+		skip[t.Name] = struct{}{}
+	}
+
+	var candidates []string
+	src, err := s.Sources.Get(ctx, art.SourceID)
+	if err != nil {
+		return nil, fmt.Errorf("get source: %w", err)
+	}
+	if src != nil {
+		candidates = append(candidates, entity.NormalizeTagName(src.Category))
+	}
+	top, err := s.Tags.ListTopBySource(ctx, art.SourceID, DefaultSuggestionLimit+len(assigned))
+	if err != nil {
+		return nil, fmt.Errorf("list source tags: %w", err)
+	}
+	candidates = append(candidates, top...)
+
+	out := make([]string, 0, DefaultSuggestionLimit)
+	for _, name := range candidates {
+		if _, ok := skip[name]; ok || entity.ValidateTagName(name) != nil {
+			continue
+		}
+		skip[name] = struct{}{}
+		out = append(out, name)
+		if len(out) == DefaultSuggestionLimit {
+			break
+		}
+	}
+	return out, nil
+}
+
+func (s *Service) get(ctx context.Context, id int64) (*entity.Tag, error) {
+	if id <= 0 {
+		return nil, ErrInvalidID
+	}
+	tag, err := s.Tags.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get tag: %w", err)
+	}
+	if tag == nil {
+		return nil, ErrTagNotFound
+	}
+	return tag, nil
+}
+
+func (s *Service) article(ctx context.Context, id int64) (*entity.Article, error) {
+	if id <= 0 {
+		return nil, ErrInvalidID
+	}
+	art, err := s.Articles.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get article: %w", err)
+	}
+	if art == nil {
+		return nil, ErrArticleNotFound
+	}
+	return art, nil
+}
+
+// normalizeNames normalizes, validates and deduplicates tag names.
+func normalizeNames(names []string) ([]string, error) {
+	normalized := make([]string, 0, len(names))
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		name = entity.NormalizeTagName(name)
+		if err := entity.ValidateTagName(name); err != nil {
+			return nil, err
+		}
+		if _, dup := seen[name]; dup {
+			continue
+		}
+		seen[name] = struct{}{}
+		normalized = append(normalized, name)
+	}
+	return normalized, nil
+}
+
+func mapDuplicate(op string, err error) error {
+	if errors.Is(err, repository.ErrDuplicateTagName) {
+		return ErrTagNameTaken
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
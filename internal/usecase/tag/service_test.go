@@ -0,0 +1,283 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+/* ───────── モック実装 ───────── */
+
+// stubTagRepo keeps tags and assignments in memory.
+type stubTagRepo struct {
+	tags     map[int64]*entity.Tag
+	assigned map[int64][]string // article ID → tag names
+	top      []string
+	nextID   int64
+}
+
+func newStubTagRepo() *stubTagRepo {
+	return &stubTagRepo{tags: map[int64]*entity.Tag{}, assigned: map[int64][]string{}}
+}
+
+func (s *stubTagRepo) byName(name string) *entity.Tag {
+	for _, t := range s.tags {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+func (s *stubTagRepo) List(context.Context) ([]*entity.Tag, error) {
+	out := make([]*entity.Tag, 0, len(s.tags))
+	for _, t := range s.tags {
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+func (s *stubTagRepo) Get(_ context.Context, id int64) (*entity.Tag, error) {
+	t, ok := s.tags[id]
+	if !ok {
+		return nil, nil
+	}
+	cp := *t
+	return &cp, nil
+}
+
+func (s *stubTagRepo) Create(_ context.Context, tag *entity.Tag) error {
+	if s.byName(tag.Name) != nil {
+		return repository.ErrDuplicateTagName
+	}
+	s.nextID++
+	tag.ID = s.nextID
+	s.tags[tag.ID] = tag
+	return nil
+}
+
+func (s *stubTagRepo) Update(_ context.Context, tag *entity.Tag) error {
+	if other := s.byName(tag.Name); other != nil && other.ID != tag.ID {
+		return repository.ErrDuplicateTagName
+	}
+	s.tags[tag.ID] = tag
+	return nil
+}
+
+func (s *stubTagRepo) Delete(_ context.Context, id int64) error {
+	delete(s.tags, id)
+	return nil
+}
+
+func (s *stubTagRepo) ListByArticle(_ context.Context, articleID int64) ([]*entity.Tag, error) {
+	var out []*entity.Tag
+	for _, name := range s.assigned[articleID] {
+		out = append(out, s.byName(name))
+	}
+	return out, nil
+}
+
+func (s *stubTagRepo) SetArticleTags(ctx context.Context, articleID int64, names []string) ([]*entity.Tag, error) {
+	for _, name := range names {
+		if s.byName(name) == nil {
+			_ = s.Create(ctx, &entity.Tag{Name: name})
+		}
+	}
+	s.assigned[articleID] = names
+	return s.ListByArticle(ctx, articleID)
+}
+
+// UpdateArticlesTags treats the keys of assigned as the existing articles.
+func (s *stubTagRepo) UpdateArticlesTags(ctx context.Context, articleIDs []int64, add, remove []string) ([]int64, error) {
+	updated := []int64{}
+	for _, id := range articleIDs {
+		names, ok := s.assigned[id]
+		if !ok {
+			continue
+		}
+		kept := []string{}
+		for _, name := range names {
+			if !containsName(remove, name) && !containsName(add, name) {
+				kept = append(kept, name)
+			}
+		}
+		for _, name := range add {
+			if s.byName(name) == nil {
+				_ = s.Create(ctx, &entity.Tag{Name: name})
+			}
+		}
+		s.assigned[id] = append(kept, add...)
+		updated = append(updated, id)
+	}
+	return updated, nil
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *stubTagRepo) ListTopBySource(_ context.Context, _ int64, limit int) ([]string, error) {
+	if len(s.top) > limit {
+		return s.top[:limit], nil
+	}
+	return s.top, nil
+}
+
+// stubArticleRepo implements only Get; the embedded interface is nil, so
+// any other call panics.
+type stubArticleRepo struct {
+	repository.ArticleRepository
+	articles map[int64]*entity.Article
+}
+
+func (s *stubArticleRepo) Get(_ context.Context, id int64) (*entity.Article, error) {
+	return s.articles[id], nil
+}
+
+// stubSourceRepo implements only Get.
+type stubSourceRepo struct {
+	repository.SourceRepository
+	sources map[int64]*entity.Source
+}
+
+func (s *stubSourceRepo) Get(_ context.Context, id int64) (*entity.Source, error) {
+	return s.sources[id], nil
+}
+
+func newService() (*Service, *stubTagRepo) {
+	tags := newStubTagRepo()
+	return &Service{
+		Tags: tags,
+		Articles: &stubArticleRepo{articles: map[int64]*entity.Article{
+			1: {ID: 1, SourceID: 10, Title: "Go 1.26 released"},
+		}},
+		Sources: &stubSourceRepo{sources: map[int64]*entity.Source{
+			10: {ID: 10, Name: "Go Blog", Category: "Go"},
+		}},
+	}, tags
+}
+
+/* ───────── テスト ───────── */
+
+func TestService_CreateAndRename(t *testing.T) {
+	svc, _ := newService()
+	ctx := context.Background()
+
+	created, err := svc.Create(ctx, "  Machine   Learning ")
+	require.NoError(t, err)
+	assert.Equal(t, "machine learning", created.Name)
+
+	_, err = svc.Create(ctx, "machine learning")
+	assert.ErrorIs(t, err, ErrTagNameTaken)
+
+	other, err := svc.Create(ctx, "ml")
+	require.NoError(t, err)
+	_, err = svc.Rename(ctx, other.ID, "Machine Learning")
+	assert.ErrorIs(t, err, ErrTagNameTaken)
+
+	renamed, err := svc.Rename(ctx, other.ID, "AI")
+	require.NoError(t, err)
+	assert.Equal(t, "ai", renamed.Name)
+
+	_, err = svc.Rename(ctx, 99, "x")
+	assert.ErrorIs(t, err, ErrTagNotFound)
+	assert.ErrorIs(t, svc.Delete(ctx, 99), ErrTagNotFound)
+	assert.NoError(t, svc.Delete(ctx, other.ID))
+}
+
+func TestService_Create_Invalid(t *testing.T) {
+	svc, _ := newService()
+	var verr *entity.ValidationError
+
+	for _, name := range []string{"", "   ", "a,b", string(make([]byte, entity.MaxTagNameLength+1))} {
+		_, err := svc.Create(context.Background(), name)
+		assert.True(t, errors.As(err, &verr), "%q: %v", name, err)
+	}
+}
+
+func TestService_SetArticleTags(t *testing.T) {
+	svc, repo := newService()
+	ctx := context.Background()
+
+	tags, err := svc.SetArticleTags(ctx, 1, []string{"Go", "release", "go "})
+	require.NoError(t, err)
+	assert.Len(t, tags, 2, "duplicates collapse after normalization")
+	assert.Equal(t, []string{"go", "release"}, repo.assigned[1])
+
+	_, err = svc.SetArticleTags(ctx, 2, []string{"go"})
+	assert.ErrorIs(t, err, ErrArticleNotFound)
+	_, err = svc.SetArticleTags(ctx, 0, []string{"go"})
+	assert.ErrorIs(t, err, ErrInvalidID)
+
+	many := make([]string, MaxTagsPerArticle+1)
+	for i := range many {
+		many[i] = string(rune('a' + i))
+	}
+	_, err = svc.SetArticleTags(ctx, 1, many)
+	assert.ErrorIs(t, err, ErrTooManyTags)
+
+	tags, err = svc.SetArticleTags(ctx, 1, nil)
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+}
+
+func TestService_Suggest(t *testing.T) {
+	svc, repo := newService()
+	ctx := context.Background()
+	repo.top = []string{"release", "go", "tooling"}
+
+	got, err := svc.Suggest(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go", "release", "tooling"}, got, "feed category first, then sibling tags")
+
+	_, err = svc.SetArticleTags(ctx, 1, []string{"go"})
+	require.NoError(t, err)
+	got, err = svc.Suggest(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"release", "tooling"}, got, "assigned tags are not suggested")
+
+	_, err = svc.Suggest(ctx, 2)
+	assert.ErrorIs(t, err, ErrArticleNotFound)
+}
+
+func TestService_UpdateArticlesTags(t *testing.T) {
+	svc, repo := newService()
+	ctx := context.Background()
+	repo.assigned[1] = []string{"old", "go"}
+	repo.assigned[2] = []string{}
+
+	updated, err := svc.UpdateArticlesTags(ctx, []int64{1, 2, 2, 99}, []string{" Go ", "New"}, []string{"OLD"})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, updated)
+	assert.ElementsMatch(t, []string{"go", "new"}, repo.assigned[1])
+	assert.ElementsMatch(t, []string{"go", "new"}, repo.assigned[2])
+	assert.NotNil(t, repo.byName("new"))
+}
+
+func TestService_UpdateArticlesTags_Invalid(t *testing.T) {
+	svc, _ := newService()
+	ctx := context.Background()
+
+	_, err := svc.UpdateArticlesTags(ctx, nil, []string{"go"}, nil)
+	assert.ErrorIs(t, err, ErrInvalidArticleIDs)
+	_, err = svc.UpdateArticlesTags(ctx, []int64{-1}, []string{"go"}, nil)
+	assert.ErrorIs(t, err, ErrInvalidArticleIDs)
+	_, err = svc.UpdateArticlesTags(ctx, []int64{1}, nil, nil)
+	assert.ErrorIs(t, err, ErrNoTagChanges)
+	_, err = svc.UpdateArticlesTags(ctx, []int64{1}, []string{"  "}, nil)
+	var verr *entity.ValidationError
+	assert.ErrorAs(t, err, &verr)
+}
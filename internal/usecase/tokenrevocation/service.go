@@ -0,0 +1,60 @@
+// Package tokenrevocation provides the access-token denylist behind
+// /auth/revoke and logout. Access JWTs are self-contained and stay valid
+// until exp; denylisting their "jti" lets the auth middleware reject a
+// compromised or logged-out token before then.
+package tokenrevocation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"catchup-feed/internal/repository"
+)
+
+// Service provides the token revocation use cases.
+type Service struct {
+	Tokens repository.RevokedTokenRepository
+	// Now returns the current time; nil means time.Now.
+	Now func() time.Time
+}
+
+func (s *Service) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// Revoke denylists the token jti until expiresAt, its own expiry; after
+// that the signature check rejects it anyway. Idempotent. Tokens without a
+// jti (issued before revocation existed) and already expired tokens are
+// ignored.
+func (s *Service) Revoke(ctx context.Context, jti, subject string, expiresAt time.Time) error {
+	if jti == "" || !expiresAt.After(s.now()) {
+		return nil
+	}
+	if err := s.Tokens.Add(ctx, jti, subject, expiresAt); err != nil {
+		return fmt.Errorf("revoke token: %w", err)
+	}
+	return nil
+}
+
+// IsRevoked reports whether the token jti has been revoked.
+func (s *Service) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	revoked, err := s.Tokens.Exists(ctx, jti)
+	if err != nil {
+		return false, fmt.Errorf("check token revocation: %w", err)
+	}
+	return revoked, nil
+}
+
+// Cleanup deletes denylist entries whose token has expired and returns how
+// many were deleted.
+func (s *Service) Cleanup(ctx context.Context) (int64, error) {
+	n, err := s.Tokens.DeleteExpired(ctx, s.now())
+	if err != nil {
+		return 0, fmt.Errorf("cleanup revoked tokens: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,66 @@
+package tokenrevocation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/* ───────── モック実装 ───────── */
+
+type stubRevokedRepo struct {
+	entries map[string]time.Time
+}
+
+func (s *stubRevokedRepo) Add(_ context.Context, jti, _ string, expiresAt time.Time) error {
+	if _, ok := s.entries[jti]; !ok {
+		s.entries[jti] = expiresAt
+	}
+	return nil
+}
+
+func (s *stubRevokedRepo) Exists(_ context.Context, jti string) (bool, error) {
+	_, ok := s.entries[jti]
+	return ok, nil
+}
+
+func (s *stubRevokedRepo) DeleteExpired(_ context.Context, t time.Time) (int64, error) {
+	var n int64
+	for jti, exp := range s.entries {
+		if exp.Before(t) {
+			delete(s.entries, jti)
+			n++
+		}
+	}
+	return n, nil
+}
+
+/* ───────── テストケース ───────── */
+
+func TestService_Revoke(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo := &stubRevokedRepo{entries: map[string]time.Time{}}
+	svc := &Service{Tokens: repo, Now: func() time.Time { return now }}
+
+	require.NoError(t, svc.Revoke(ctx, "live", "admin@example.com", now.Add(30*time.Minute)))
+	// jti のない旧トークン・期限切れトークンは記録しない。
+	require.NoError(t, svc.Revoke(ctx, "", "admin@example.com", now.Add(30*time.Minute)))
+	require.NoError(t, svc.Revoke(ctx, "dead", "admin@example.com", now.Add(-time.Minute)))
+	assert.Len(t, repo.entries, 1)
+
+	revoked, err := svc.IsRevoked(ctx, "live")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+	revoked, err = svc.IsRevoked(ctx, "other")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	now = now.Add(time.Hour)
+	n, err := svc.Cleanup(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+}
@@ -0,0 +1,51 @@
+// Package user provides the dashboard account use cases: admin-managed
+// CRUD over every account in the users table (admins and viewers alike),
+// the administrator login / per-request checks the auth layer delegates
+// here, and the one-time bootstrap of the first admin from the
+// ADMIN_USER / ADMIN_PASSWORD_HASH environment variables.
+package user
+
+import "errors"
+
+// Sentinel errors. Messages deliberately contain respond.SafeError's safe
+// words ("not found", "required", "invalid", "already", "cannot be") so
+// they reach the client verbatim instead of being masked as internal
+// errors.
+var (
+	// ErrUserNotFound indicates the user does not exist.
+	ErrUserNotFound = errors.New("user not found")
+
+	// ErrNameRequired indicates a missing user name.
+	ErrNameRequired = errors.New("name is required")
+
+	// ErrInvalidEmail indicates a malformed email address. The email is the
+	// login identifier, so it is validated at the door.
+	ErrInvalidEmail = errors.New("email is invalid")
+
+	// ErrInvalidRole indicates a role other than admin / viewer or a custom
+	// role defined by AUTH_ROLES.
+	ErrInvalidRole = errors.New("role is invalid: must be admin, viewer or a role defined in AUTH_ROLES")
+
+	// ErrEmailTaken indicates another account already uses the email
+	// (users.email UNIQUE, HTTP 409).
+	ErrEmailTaken = errors.New("email is already registered")
+
+	// ErrPasswordTooShort indicates the password is shorter than
+	// MinPasswordLength.
+	ErrPasswordTooShort = errors.New("password is required and must be at least 8 characters")
+
+	// ErrPasswordTooLong indicates the password exceeds bcrypt's 72-byte
+	// input limit (MaxPasswordLength).
+	ErrPasswordTooLong = errors.New("password is invalid: must be at most 72 bytes")
+
+	// ErrLastAdmin rejects demoting, deactivating or deleting the only
+	// remaining active administrator (HTTP 409): nobody could manage the
+	// accounts afterwards, and the environment bootstrap only runs while no
+	// admin exists at all.
+	ErrLastAdmin = errors.New("the last active admin cannot be removed, deactivated or demoted")
+
+	// ErrInvalidCredentials is the generic login failure: unknown email,
+	// wrong password or deactivated account. Deliberately
+	// indistinguishable so login responses do not enumerate accounts.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
@@ -0,0 +1,397 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// Built-in roles: the same values as the JWT role claim (auth.RoleAdmin /
+// auth.RoleViewer, D-27). Custom roles come from Service.CustomRoles.
+const (
+	RoleAdmin  = "admin"
+	RoleViewer = "viewer"
+)
+
+// maxEmailLength is the RFC 5321 ceiling for a complete address.
+const maxEmailLength = 254
+
+// MinPasswordLength / MaxPasswordLength bound user passwords: the same
+// typo floor and bcrypt 72-byte ceiling as viewer passwords
+// (usecase/viewer).
+const (
+	MinPasswordLength = 8
+	MaxPasswordLength = 72
+)
+
+// dummyBcryptHash is compared when the email does not belong to an active
+// account, so login timing does not reveal whether the account exists. Same
+// value and cost (bcrypt.DefaultCost) as the viewer and env-provider
+// dummies.
+const dummyBcryptHash = "$2a$10$2liJaVtwjkEHDTCuT02M2.Fk2DMXjYqQhpWzlKwPwD.B5SfFQ0fpm"
+
+// CreateInput carries the fields for POST /users. All fields required.
+type CreateInput struct {
+	Name     string
+	Email    string
+	Role     string
+	Password string
+}
+
+// UpdateInput carries the fields for PUT /users/{id}. Name / Email / Role
+// are full replacements; Password is optional (nil keeps the current hash).
+type UpdateInput struct {
+	Name     string
+	Email    string
+	Role     string
+	Password *string
+}
+
+// Service provides the dashboard account use cases: admin-managed CRUD
+// over the users table, the login (AuthenticateAccount) and per-request
+// re-check (IsActiveAccount) for admins and custom roles, and the
+// first-admin bootstrap. At least one active admin always remains
+// (ErrLastAdmin).
+type Service struct {
+	Users repository.UserRepository
+	// CustomRoles are the role names defined by AUTH_ROLES, accepted in
+	// addition to admin / viewer.
+	CustomRoles []string
+	// Now returns the current time; nil means time.Now.
+	Now func() time.Time
+}
+
+func (s *Service) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// validateEmail rejects anything that is not a single bare address with a
+// dotted domain. Same rules as viewer emails (usecase/viewer).
+func validateEmail(email string) error {
+	if email == "" || len(email) > maxEmailLength || strings.TrimSpace(email) != email {
+		return ErrInvalidEmail
+	}
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return ErrInvalidEmail
+	}
+	at := strings.LastIndex(addr.Address, "@")
+	if at < 0 || !strings.Contains(addr.Address[at+1:], ".") {
+		return ErrInvalidEmail
+	}
+	return nil
+}
+
+func validatePassword(password string) error {
+	if len(password) < MinPasswordLength {
+		return ErrPasswordTooShort
+	}
+	if len(password) > MaxPasswordLength {
+		return ErrPasswordTooLong
+	}
+	return nil
+}
+
+func (s *Service) validateRole(role string) error {
+	if role != RoleAdmin && role != RoleViewer && !slices.Contains(s.CustomRoles, role) {
+		return ErrInvalidRole
+	}
+	return nil
+}
+
+// normalizeEmail lowercases the address so lookups, the UNIQUE constraint
+// and login treat Alice@example.com and alice@example.com as one account.
+func normalizeEmail(email string) string {
+	return strings.ToLower(email)
+}
+
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// ensureNotLastAdmin fails with ErrLastAdmin when user is the only active
+// administrator. Called before any change that would take user out of the
+// active admin set. The count-then-write is not atomic; two admins
+// removing each other at the same instant is not a scenario worth a lock
+// at this scale.
+func (s *Service) ensureNotLastAdmin(ctx context.Context, user *entity.User) error {
+	if user.Role != RoleAdmin || !user.IsActive() {
+		return nil
+	}
+	n, err := s.Users.CountActiveAdmins(ctx)
+	if err != nil {
+		return fmt.Errorf("count active admins: %w", err)
+	}
+	if n <= 1 {
+		return ErrLastAdmin
+	}
+	return nil
+}
+
+// List returns all users, every role, active and deactivated, oldest first.
+func (s *Service) List(ctx context.Context) ([]*entity.User, error) {
+	users, err := s.Users.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list users: %w", err)
+	}
+	return users, nil
+}
+
+// Get returns the user or ErrUserNotFound.
+func (s *Service) Get(ctx context.Context, id int64) (*entity.User, error) {
+	user, err := s.Users.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// Create registers a new account. The password is bcrypt-hashed before it
+// reaches the repository; the plaintext is never stored.
+func (s *Service) Create(ctx context.Context, in CreateInput) (*entity.User, error) {
+	if strings.TrimSpace(in.Name) == "" {
+		return nil, ErrNameRequired
+	}
+	in.Email = normalizeEmail(in.Email)
+	if err := validateEmail(in.Email); err != nil {
+		return nil, err
+	}
+	if err := s.validateRole(in.Role); err != nil {
+		return nil, err
+	}
+	if err := validatePassword(in.Password); err != nil {
+		return nil, err
+	}
+	hash, err := hashPassword(in.Password)
+	if err != nil {
+		return nil, err
+	}
+	user := &entity.User{Name: in.Name, Email: in.Email, Role: in.Role, PasswordHash: hash}
+	if err := s.Users.Create(ctx, user); err != nil {
+		if errors.Is(err, repository.ErrDuplicateUserEmail) {
+			return nil, ErrEmailTaken
+		}
+		return nil, fmt.Errorf("create user: %w", err)
+	}
+	return user, nil
+}
+
+// Update rewrites name / email / role and, when Password is non-nil,
+// re-hashes and replaces the password. Demoting the last active admin is
+// rejected with ErrLastAdmin.
+func (s *Service) Update(ctx context.Context, id int64, in UpdateInput) (*entity.User, error) {
+	if strings.TrimSpace(in.Name) == "" {
+		return nil, ErrNameRequired
+	}
+	in.Email = normalizeEmail(in.Email)
+	if err := validateEmail(in.Email); err != nil {
+		return nil, err
+	}
+	if err := s.validateRole(in.Role); err != nil {
+		return nil, err
+	}
+	if in.Password != nil {
+		if err := validatePassword(*in.Password); err != nil {
+			return nil, err
+		}
+	}
+	user, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if in.Role != user.Role {
+		if err := s.ensureNotLastAdmin(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+	user.Name = in.Name
+	user.Email = in.Email
+	user.Role = in.Role
+	if in.Password != nil {
+		hash, err := hashPassword(*in.Password)
+		if err != nil {
+			return nil, err
+		}
+		user.PasswordHash = hash
+	}
+	if err := s.Users.Update(ctx, user); err != nil {
+		if errors.Is(err, repository.ErrDuplicateUserEmail) {
+			return nil, ErrEmailTaken
+		}
+		return nil, fmt.Errorf("update user: %w", err)
+	}
+	return user, nil
+}
+
+// SetActive toggles the user's logical activation (PUT /users/{id}/active).
+// Deactivation blocks login immediately and — via the middleware's
+// per-request re-check — cuts off existing JWTs on their next request.
+// Idempotent in both directions. Returns the resulting user.
+func (s *Service) SetActive(ctx context.Context, id int64, active bool) (*entity.User, error) {
+	user, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if active {
+		if err := s.Users.Reactivate(ctx, id); err != nil {
+			return nil, fmt.Errorf("reactivate user: %w", err)
+		}
+	} else {
+		if err := s.ensureNotLastAdmin(ctx, user); err != nil {
+			return nil, err
+		}
+		if err := s.Users.Deactivate(ctx, id, s.now()); err != nil {
+			return nil, fmt.Errorf("deactivate user: %w", err)
+		}
+	}
+	return s.Get(ctx, id)
+}
+
+// Delete removes the user physically. The last active admin cannot be
+// deleted.
+func (s *Service) Delete(ctx context.Context, id int64) error {
+	user, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.ensureNotLastAdmin(ctx, user); err != nil {
+		return err
+	}
+	if err := s.Users.Delete(ctx, id); err != nil {
+		return fmt.Errorf("delete user: %w", err)
+	}
+	return nil
+}
+
+// AuthenticateAccount validates a login (POST /auth/token) and returns the
+// account's role. Unknown emails, wrong passwords and deactivated accounts
+// all fail with the same ErrInvalidCredentials; a bcrypt comparison runs in
+// every path so timing does not reveal whether the account exists.
+func (s *Service) AuthenticateAccount(ctx context.Context, email, password string) (string, error) {
+	if email == "" || password == "" {
+		return "", ErrInvalidCredentials
+	}
+	user, err := s.Users.GetActiveByEmail(ctx, normalizeEmail(email))
+	if err != nil {
+		return "", fmt.Errorf("authenticate account: %w", err)
+	}
+	hash := dummyBcryptHash
+	if user != nil {
+		hash = user.PasswordHash
+	}
+	passErr := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if user == nil || passErr != nil {
+		return "", ErrInvalidCredentials
+	}
+	return user.Role, nil
+}
+
+// IsActiveAccount reports whether email belongs to an existing, active
+// user that still has role. The auth middleware calls this on every admin
+// and custom-role request, so deactivation, deletion or a role change
+// takes effect immediately.
+func (s *Service) IsActiveAccount(ctx context.Context, email, role string) (bool, error) {
+	user, err := s.Users.GetActiveByEmail(ctx, normalizeEmail(email))
+	if err != nil {
+		return false, fmt.Errorf("check account activity: %w", err)
+	}
+	return user != nil && user.Role == role, nil
+}
+
+// externalPasswordHash marks an account provisioned by an external
+// identity provider (OIDC). It is not a bcrypt hash, so password login can
+// never succeed for it; an admin may still set a password via PUT
+// /users/{id}.
+const externalPasswordHash = "!external"
+
+// LoginExternal resolves an identity verified by an external provider
+// (OIDC) to a users row. An existing active account is used as-is: its
+// role, managed through /users, wins over the provider's claims. An
+// unknown email is provisioned just in time with role, the role the
+// claim mapping granted; an empty role (no mapping matched) or a
+// deactivated account fails with ErrInvalidCredentials.
+func (s *Service) LoginExternal(ctx context.Context, email, name, role string) (*entity.User, error) {
+	email = normalizeEmail(email)
+	user, err := s.Users.GetActiveByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("external login: %w", err)
+	}
+	if user != nil {
+		return user, nil
+	}
+	if role == "" || validateEmail(email) != nil {
+		return nil, ErrInvalidCredentials
+	}
+	if err := s.validateRole(role); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(name) == "" {
+		name = email
+	}
+	user = &entity.User{Name: name, Email: email, Role: role, PasswordHash: externalPasswordHash}
+	if err := s.Users.Create(ctx, user); err != nil {
+		if errors.Is(err, repository.ErrDuplicateUserEmail) {
+			// The email belongs to a deactivated account.
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("provision external user: %w", err)
+	}
+	return user, nil
+}
+
+// HasActiveAdmin reports whether at least one active administrator
+// exists, i.e. whether the environment bootstrap is still needed.
+func (s *Service) HasActiveAdmin(ctx context.Context) (bool, error) {
+	n, err := s.Users.CountActiveAdmins(ctx)
+	if err != nil {
+		return false, fmt.Errorf("count active admins: %w", err)
+	}
+	return n > 0, nil
+}
+
+// BootstrapAdmin creates the first administrator from the ADMIN_USER login
+// and the pre-computed ADMIN_PASSWORD_HASH (validated by the caller). It is
+// a no-op returning (nil, nil) once any active admin exists, so the
+// environment variables never override accounts managed through the API.
+// The login keeps working as-is even when it is not an email address.
+func (s *Service) BootstrapAdmin(ctx context.Context, login, passwordHash string) (*entity.User, error) {
+	exists, err := s.HasActiveAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, nil
+	}
+	user := &entity.User{
+		Name:         login,
+		Email:        normalizeEmail(login),
+		Role:         RoleAdmin,
+		PasswordHash: passwordHash,
+	}
+	if err := s.Users.Create(ctx, user); err != nil {
+		if errors.Is(err, repository.ErrDuplicateUserEmail) {
+			return nil, fmt.Errorf("bootstrap admin: %w", ErrEmailTaken)
+		}
+		return nil, fmt.Errorf("bootstrap admin: %w", err)
+	}
+	return user, nil
+}
@@ -0,0 +1,316 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+/* ───────── モック実装 ───────── */
+
+type stubUserRepo struct {
+	users     map[int64]*entity.User
+	createErr error
+}
+
+func newStubUserRepo(users ...*entity.User) *stubUserRepo {
+	s := &stubUserRepo{users: map[int64]*entity.User{}}
+	for _, u := range users {
+		s.users[u.ID] = u
+	}
+	return s
+}
+
+func (s *stubUserRepo) Create(_ context.Context, u *entity.User) error {
+	if s.createErr != nil {
+		return s.createErr
+	}
+	u.ID = int64(len(s.users) + 1)
+	u.CreatedAt, u.UpdatedAt = time.Now(), time.Now()
+	s.users[u.ID] = u
+	return nil
+}
+
+func (s *stubUserRepo) Get(_ context.Context, id int64) (*entity.User, error) {
+	return s.users[id], nil
+}
+
+func (s *stubUserRepo) List(_ context.Context) ([]*entity.User, error) {
+	out := make([]*entity.User, 0, len(s.users))
+	for _, u := range s.users {
+		out = append(out, u)
+	}
+	return out, nil
+}
+
+func (s *stubUserRepo) Update(_ context.Context, u *entity.User) error {
+	s.users[u.ID] = u
+	return nil
+}
+
+func (s *stubUserRepo) Deactivate(_ context.Context, id int64, t time.Time) error {
+	if u, ok := s.users[id]; ok && u.DeactivatedAt == nil {
+		at := t
+		u.DeactivatedAt = &at
+	}
+	return nil
+}
+
+func (s *stubUserRepo) Reactivate(_ context.Context, id int64) error {
+	if u, ok := s.users[id]; ok {
+		u.DeactivatedAt = nil
+	}
+	return nil
+}
+
+func (s *stubUserRepo) Delete(_ context.Context, id int64) error {
+	delete(s.users, id)
+	return nil
+}
+
+func (s *stubUserRepo) GetActiveByEmail(_ context.Context, email string) (*entity.User, error) {
+	for _, u := range s.users {
+		if u.Email == email && u.DeactivatedAt == nil {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *stubUserRepo) CountActiveAdmins(_ context.Context) (int, error) {
+	n := 0
+	for _, u := range s.users {
+		if u.Role == RoleAdmin && u.DeactivatedAt == nil {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func mustHash(t *testing.T, password string) string {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	return string(hash)
+}
+
+/* ───────── テストケース ───────── */
+
+func TestService_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      CreateInput
+		repoErr error
+		wantErr error
+	}{
+		{name: "valid admin", in: CreateInput{Name: "Root", Email: "Root@Example.com", Role: RoleAdmin, Password: "password-123"}},
+		{name: "valid viewer", in: CreateInput{Name: "Alice", Email: "alice@example.com", Role: RoleViewer, Password: "password-123"}},
+		{name: "missing name", in: CreateInput{Email: "a@example.com", Role: RoleAdmin, Password: "password-123"}, wantErr: ErrNameRequired},
+		{name: "invalid email", in: CreateInput{Name: "A", Email: "nope", Role: RoleAdmin, Password: "password-123"}, wantErr: ErrInvalidEmail},
+		{name: "invalid role", in: CreateInput{Name: "A", Email: "a@example.com", Role: "owner", Password: "password-123"}, wantErr: ErrInvalidRole},
+		{name: "short password", in: CreateInput{Name: "A", Email: "a@example.com", Role: RoleAdmin, Password: "short"}, wantErr: ErrPasswordTooShort},
+		{
+			name:    "duplicate email",
+			in:      CreateInput{Name: "A", Email: "a@example.com", Role: RoleAdmin, Password: "password-123"},
+			repoErr: repository.ErrDuplicateUserEmail,
+			wantErr: ErrEmailTaken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newStubUserRepo()
+			repo.createErr = tt.repoErr
+			svc := &Service{Users: repo}
+
+			got, err := svc.Create(context.Background(), tt.in)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.in.Role, got.Role)
+			assert.Equal(t, normalizeEmail(tt.in.Email), got.Email)
+			assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(got.PasswordHash), []byte(tt.in.Password)))
+		})
+	}
+}
+
+// TestService_LastAdminGuard: 最後のアクティブ admin は降格・無効化・削除
+// できない(管理者不在になると API からも環境変数からも復旧できない)。
+func TestService_LastAdminGuard(t *testing.T) {
+	ctx := context.Background()
+	newRepo := func(admins int) *stubUserRepo {
+		repo := newStubUserRepo(&entity.User{ID: 1, Name: "Root", Email: "root@example.com", Role: RoleAdmin, PasswordHash: "h"})
+		for i := 1; i < admins; i++ {
+			id := int64(i + 1)
+			repo.users[id] = &entity.User{ID: id, Name: "Ops", Email: "ops@example.com", Role: RoleAdmin, PasswordHash: "h"}
+		}
+		return repo
+	}
+
+	t.Run("demote last admin", func(t *testing.T) {
+		svc := &Service{Users: newRepo(1)}
+		_, err := svc.Update(ctx, 1, UpdateInput{Name: "Root", Email: "root@example.com", Role: RoleViewer})
+		assert.ErrorIs(t, err, ErrLastAdmin)
+	})
+	t.Run("deactivate last admin", func(t *testing.T) {
+		svc := &Service{Users: newRepo(1)}
+		_, err := svc.SetActive(ctx, 1, false)
+		assert.ErrorIs(t, err, ErrLastAdmin)
+	})
+	t.Run("delete last admin", func(t *testing.T) {
+		svc := &Service{Users: newRepo(1)}
+		assert.ErrorIs(t, svc.Delete(ctx, 1), ErrLastAdmin)
+	})
+	t.Run("another admin remains", func(t *testing.T) {
+		repo := newRepo(2)
+		svc := &Service{Users: repo}
+		_, err := svc.SetActive(ctx, 1, false)
+		require.NoError(t, err)
+		assert.False(t, repo.users[1].IsActive())
+		assert.ErrorIs(t, svc.Delete(ctx, 2), ErrLastAdmin)
+	})
+	t.Run("editing the last admin without a role change", func(t *testing.T) {
+		svc := &Service{Users: newRepo(1)}
+		got, err := svc.Update(ctx, 1, UpdateInput{Name: "Root 2", Email: "root@example.com", Role: RoleAdmin})
+		require.NoError(t, err)
+		assert.Equal(t, "Root 2", got.Name)
+	})
+}
+
+func TestService_AuthenticateAccount(t *testing.T) {
+	deactivatedAt := time.Now()
+	repo := newStubUserRepo(
+		&entity.User{ID: 1, Email: "root@example.com", Role: RoleAdmin, PasswordHash: mustHash(t, "admin-pass-1")},
+		&entity.User{ID: 2, Email: "ed@example.com", Role: "editor", PasswordHash: mustHash(t, "editor-pass-1")},
+		&entity.User{ID: 3, Email: "old@example.com", Role: RoleAdmin, PasswordHash: mustHash(t, "old-pass-1"), DeactivatedAt: &deactivatedAt},
+	)
+	svc := &Service{Users: repo, CustomRoles: []string{"editor"}}
+
+	tests := []struct {
+		name     string
+		email    string
+		password string
+		wantRole string
+		wantErr  bool
+	}{
+		{name: "admin", email: "Root@example.com", password: "admin-pass-1", wantRole: RoleAdmin},
+		{name: "custom role", email: "ed@example.com", password: "editor-pass-1", wantRole: "editor"},
+		{name: "wrong password", email: "root@example.com", password: "nope-nope-1", wantErr: true},
+		{name: "deactivated account", email: "old@example.com", password: "old-pass-1", wantErr: true},
+		{name: "unknown email", email: "who@example.com", password: "admin-pass-1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, err := svc.AuthenticateAccount(context.Background(), tt.email, tt.password)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidCredentials)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRole, role)
+		})
+	}
+
+	ok, err := svc.IsActiveAccount(context.Background(), "ROOT@example.com", RoleAdmin)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	// role が変わったら(降格・付け替え)既存トークンは通さない。
+	ok, err = svc.IsActiveAccount(context.Background(), "ed@example.com", RoleAdmin)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestService_CustomRoles(t *testing.T) {
+	svc := &Service{Users: newStubUserRepo(), CustomRoles: []string{"editor"}}
+
+	got, err := svc.Create(context.Background(), CreateInput{Name: "Ed", Email: "ed@example.com", Role: "editor", Password: "password-123"})
+	require.NoError(t, err)
+	assert.Equal(t, "editor", got.Role)
+
+	_, err = svc.Create(context.Background(), CreateInput{Name: "Al", Email: "al@example.com", Role: "analyst", Password: "password-123"})
+	assert.ErrorIs(t, err, ErrInvalidRole)
+}
+
+func TestService_LoginExternal(t *testing.T) {
+	ctx := context.Background()
+	deactivatedAt := time.Now()
+	newSvc := func() (*Service, *stubUserRepo) {
+		repo := newStubUserRepo(
+			&entity.User{ID: 1, Name: "Root", Email: "root@example.com", Role: RoleAdmin, PasswordHash: "h"},
+			&entity.User{ID: 2, Name: "Old", Email: "old@example.com", Role: RoleViewer, PasswordHash: "h", DeactivatedAt: &deactivatedAt},
+		)
+		return &Service{Users: repo, CustomRoles: []string{"editor"}}, repo
+	}
+
+	t.Run("existing account keeps its managed role", func(t *testing.T) {
+		svc, _ := newSvc()
+		got, err := svc.LoginExternal(ctx, "Root@Example.com", "Root", RoleViewer)
+		require.NoError(t, err)
+		assert.Equal(t, RoleAdmin, got.Role)
+	})
+	t.Run("unknown email is provisioned with the mapped role", func(t *testing.T) {
+		svc, repo := newSvc()
+		got, err := svc.LoginExternal(ctx, "Ed@example.com", "Ed", "editor")
+		require.NoError(t, err)
+		assert.Equal(t, "editor", got.Role)
+		assert.Equal(t, "ed@example.com", got.Email)
+		assert.Len(t, repo.users, 3)
+
+		// 外部 IdP で作られたアカウントはパスワードではログインできない。
+		_, err = svc.AuthenticateAccount(ctx, "ed@example.com", externalPasswordHash)
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+	t.Run("no mapped role", func(t *testing.T) {
+		svc, repo := newSvc()
+		_, err := svc.LoginExternal(ctx, "who@example.com", "Who", "")
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+		assert.Len(t, repo.users, 2)
+	})
+	t.Run("undefined role", func(t *testing.T) {
+		svc, _ := newSvc()
+		_, err := svc.LoginExternal(ctx, "who@example.com", "Who", "owner")
+		assert.ErrorIs(t, err, ErrInvalidRole)
+	})
+	t.Run("deactivated account is not re-provisioned", func(t *testing.T) {
+		svc, repo := newSvc()
+		repo.createErr = repository.ErrDuplicateUserEmail // users.email UNIQUE
+		_, err := svc.LoginExternal(ctx, "old@example.com", "Old", RoleViewer)
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+}
+
+func TestService_BootstrapAdmin(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates the first admin from env credentials", func(t *testing.T) {
+		repo := newStubUserRepo()
+		svc := &Service{Users: repo}
+
+		created, err := svc.BootstrapAdmin(ctx, "Admin", "$2a$12$hash")
+		require.NoError(t, err)
+		require.NotNil(t, created)
+		assert.Equal(t, RoleAdmin, created.Role)
+		assert.Equal(t, "admin", created.Email)
+		assert.Equal(t, "$2a$12$hash", created.PasswordHash)
+	})
+
+	t.Run("no-op once an admin exists", func(t *testing.T) {
+		repo := newStubUserRepo(&entity.User{ID: 1, Email: "root@example.com", Role: RoleAdmin, PasswordHash: "h"})
+		svc := &Service{Users: repo}
+
+		created, err := svc.BootstrapAdmin(ctx, "admin", "$2a$12$hash")
+		require.NoError(t, err)
+		assert.Nil(t, created)
+		assert.Len(t, repo.users, 1)
+	})
+}
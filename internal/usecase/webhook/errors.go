@@ -0,0 +1,28 @@
+// Package webhook provides the outbound webhook use cases: admin-managed
+// subscriptions (URL + signing secret + events), their delivery history,
+// and Publish, which fans an event out to the subscribed webhooks through
+// the jobs queue.
+package webhook
+
+import "errors"
+
+// Sentinel errors. Messages contain respond.SafeError's safe words so they
+// reach the client verbatim.
+var (
+	// ErrWebhookNotFound indicates the webhook does not exist.
+	ErrWebhookNotFound = errors.New("webhook not found")
+
+	// ErrInvalidURL indicates a missing or unsafe target URL.
+	ErrInvalidURL = errors.New("url is invalid: must be a public http or https URL")
+
+	// ErrInvalidSecret indicates a secret shorter than MinSecretLength.
+	ErrInvalidSecret = errors.New("secret is invalid: must be at least 16 characters")
+
+	// ErrInvalidEvents indicates an empty or unknown event list.
+	ErrInvalidEvents = errors.New("events is invalid: must list article.created and/or crawl.completed")
+
+	// ErrInvalidRoute indicates a route that cannot apply: conditions on a
+	// webhook without article.created, fallback combined with conditions,
+	// a non-positive source ID or too many entries.
+	ErrInvalidRoute = errors.New("route is invalid: must be article.created conditions or fallback alone, with at most 50 entries each")
+)
@@ -0,0 +1,259 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"catchup-feed/internal/domain/entity"
+	"catchup-feed/internal/repository"
+)
+
+// MinSecretLength is the shortest accepted signing secret.
+const MinSecretLength = 16
+
+// MaxRouteEntries caps each list of a webhook route.
+const MaxRouteEntries = 50
+
+// Delivery history limits of ListDeliveries.
+const (
+	DefaultDeliveryLimit = 50
+	MaxDeliveryLimit     = 200
+)
+
+// CreateInput carries the fields for POST /webhooks.
+type CreateInput struct {
+	URL    string
+	Secret string
+	Events []string
+	// Route narrows the article.created events; the zero value receives
+	// every article.
+	Route entity.WebhookRoute
+}
+
+// SourceGetter resolves an article's source for category routes.
+// Satisfied by repository.SourceRepository.
+type SourceGetter interface {
+	Get(ctx context.Context, id int64) (*entity.Source, error)
+}
+
+// Service provides the webhook use cases.
+type Service struct {
+	Webhooks repository.WebhookRepository
+	// Sources resolves source categories for category routes; nil means
+	// category conditions never match.
+	Sources SourceGetter
+	Logger  *slog.Logger
+	// Now returns the current time; nil means time.Now.
+	Now func() time.Time
+}
+
+func (s *Service) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+func (s *Service) logger() *slog.Logger {
+	if s.Logger != nil {
+		return s.Logger
+	}
+	return slog.Default()
+}
+
+// validate trims the input and dedupes the events.
+func validate(in *CreateInput) error {
+	in.URL = strings.TrimSpace(in.URL)
+	if err := entity.ValidateURL(in.URL); err != nil {
+		return ErrInvalidURL
+	}
+	if len(in.Secret) < MinSecretLength {
+		return ErrInvalidSecret
+	}
+	if len(in.Events) == 0 {
+		return ErrInvalidEvents
+	}
+	events := make([]string, 0, len(in.Events))
+	for _, event := range in.Events {
+		if !entity.IsValidWebhookEvent(event) {
+			return ErrInvalidEvents
+		}
+		if !slices.Contains(events, event) {
+			events = append(events, event)
+		}
+	}
+	in.Events = events
+	return validateRoute(&in.Route, in.Events)
+}
+
+// validateRoute trims, dedupes and checks a route. Conditions and
+// fallback only make sense for article.created.
+func validateRoute(route *entity.WebhookRoute, events []string) error {
+	if route.IsZero() {
+		return nil
+	}
+	if !slices.Contains(events, entity.WebhookEventArticleCreated) || (route.Fallback && route.HasConditions()) {
+		return ErrInvalidRoute
+	}
+	var sourceIDs []int64
+	for _, id := range route.SourceIDs {
+		if id <= 0 {
+			return ErrInvalidRoute
+		}
+		if !slices.Contains(sourceIDs, id) {
+			sourceIDs = append(sourceIDs, id)
+		}
+	}
+	route.SourceIDs = sourceIDs
+	route.Categories = compactTerms(route.Categories)
+	route.Keywords = compactTerms(route.Keywords)
+	if len(route.SourceIDs) > MaxRouteEntries || len(route.Categories) > MaxRouteEntries || len(route.Keywords) > MaxRouteEntries {
+		return ErrInvalidRoute
+	}
+	if route.Fallback == route.HasConditions() {
+		// Only blank terms were given: nothing left to route on.
+		return ErrInvalidRoute
+	}
+	return nil
+}
+
+// compactTerms trims terms and drops blanks and case-insensitive repeats.
+func compactTerms(terms []string) []string {
+	var out []string
+	for _, term := range terms {
+		term = strings.TrimSpace(term)
+		if term == "" || slices.ContainsFunc(out, func(t string) bool { return strings.EqualFold(t, term) }) {
+			continue
+		}
+		out = append(out, term)
+	}
+	return out
+}
+
+// publishRouted fans an article out to the webhooks its routes select.
+// Without any routed subscriber it is the plain CreateDeliveries; the
+// source is looked up only when a category condition needs it, and a
+// failed lookup just leaves the category unmatched.
+func (s *Service) publishRouted(ctx context.Context, event string, body json.RawMessage, article entity.WebhookArticleData) (int64, error) {
+	webhooks, err := s.Webhooks.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	subscribed := slices.DeleteFunc(webhooks, func(w *entity.Webhook) bool { return !w.Subscribes(event) })
+	if !slices.ContainsFunc(subscribed, func(w *entity.Webhook) bool { return !w.Route.IsZero() }) {
+		return s.Webhooks.CreateDeliveries(ctx, event, body)
+	}
+
+	routed := entity.WebhookRouteArticle{SourceID: article.SourceID, Title: article.Title, Summary: article.Summary}
+	if s.Sources != nil && slices.ContainsFunc(subscribed, func(w *entity.Webhook) bool { return len(w.Route.Categories) > 0 }) {
+		source, err := s.Sources.Get(ctx, article.SourceID)
+		if err != nil {
+			s.logger().WarnContext(ctx, "webhook routing: source lookup failed, category routes skipped",
+				slog.Int64("source_id", article.SourceID), slog.Any("error", err))
+		} else if source != nil {
+			routed.Category = source.Category
+		}
+	}
+	return s.Webhooks.CreateDeliveriesTo(ctx, entity.RouteWebhookArticle(subscribed, routed), event, body)
+}
+
+// List returns all webhooks.
+func (s *Service) List(ctx context.Context) ([]*entity.Webhook, error) {
+	webhooks, err := s.Webhooks.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// Get returns the webhook or ErrWebhookNotFound.
+func (s *Service) Get(ctx context.Context, id int64) (*entity.Webhook, error) {
+	webhook, err := s.Webhooks.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook: %w", err)
+	}
+	if webhook == nil {
+		return nil, ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+// Create registers a webhook.
+func (s *Service) Create(ctx context.Context, in CreateInput) (*entity.Webhook, error) {
+	if err := validate(&in); err != nil {
+		return nil, err
+	}
+	webhook := &entity.Webhook{URL: in.URL, Secret: in.Secret, Events: in.Events, Route: in.Route}
+	if err := s.Webhooks.Create(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("create webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+// Delete removes the webhook together with its delivery history. Pending
+// deliveries are dropped.
+func (s *Service) Delete(ctx context.Context, id int64) error {
+	if _, err := s.Get(ctx, id); err != nil {
+		return err
+	}
+	if err := s.Webhooks.Delete(ctx, id); err != nil {
+		return fmt.Errorf("delete webhook: %w", err)
+	}
+	return nil
+}
+
+// ListDeliveries returns the webhook's latest deliveries, newest first.
+// limit <= 0 means DefaultDeliveryLimit; larger values are capped at
+// MaxDeliveryLimit.
+func (s *Service) ListDeliveries(ctx context.Context, id int64, limit int) ([]*entity.WebhookDelivery, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = DefaultDeliveryLimit
+	}
+	limit = min(limit, MaxDeliveryLimit)
+	deliveries, err := s.Webhooks.ListDeliveries(ctx, id, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// Publish queues event with data for every webhook subscribed to it. The
+// body is rendered once here, so all webhooks and all retries receive the
+// same bytes. An article.created event goes through the webhooks' routes
+// (entity.RouteWebhookArticle). Called inside a repository.TxManager
+// unit of work, the deliveries commit with the caller's writes and a
+// failure rolls them back; otherwise callers treat a failure as
+// best-effort: the event is lost, the work that produced it is not.
+func (s *Service) Publish(ctx context.Context, event string, data any) error {
+	body, err := json.Marshal(entity.WebhookPayload{
+		Event:      event,
+		OccurredAt: s.now().UTC(),
+		Data:       data,
+	})
+	if err != nil {
+		return fmt.Errorf("publish %s: %w", event, err)
+	}
+	var n int64
+	if article, ok := data.(entity.WebhookArticleData); ok && event == entity.WebhookEventArticleCreated {
+		n, err = s.publishRouted(ctx, event, body, article)
+	} else {
+		n, err = s.Webhooks.CreateDeliveries(ctx, event, body)
+	}
+	if err != nil {
+		return fmt.Errorf("publish %s: %w", event, err)
+	}
+	if n > 0 {
+		s.logger().DebugContext(ctx, "webhook event queued",
+			slog.String("event", event),
+			slog.Int64("deliveries", n))
+	}
+	return nil
+}
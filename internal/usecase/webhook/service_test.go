@@ -0,0 +1,238 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"catchup-feed/internal/domain/entity"
+	webhookUC "catchup-feed/internal/usecase/webhook"
+)
+
+/* ───────── モック実装 ───────── */
+
+type stubWebhookRepo struct {
+	webhooks     map[int64]*entity.Webhook
+	published    []json.RawMessage
+	lastEvent    string
+	lastLimit    int
+	deleted      []int64
+	createDelErr error
+	// routedTo is the ID list of the last CreateDeliveriesTo; nil when
+	// the unrouted CreateDeliveries was used.
+	routedTo []int64
+}
+
+func newStubRepo() *stubWebhookRepo {
+	return &stubWebhookRepo{webhooks: map[int64]*entity.Webhook{}}
+}
+
+func (s *stubWebhookRepo) Create(_ context.Context, w *entity.Webhook) error {
+	w.ID = int64(len(s.webhooks) + 1)
+	s.webhooks[w.ID] = w
+	return nil
+}
+
+func (s *stubWebhookRepo) Get(_ context.Context, id int64) (*entity.Webhook, error) {
+	return s.webhooks[id], nil
+}
+
+func (s *stubWebhookRepo) List(_ context.Context) ([]*entity.Webhook, error) {
+	out := make([]*entity.Webhook, 0, len(s.webhooks))
+	for _, w := range s.webhooks {
+		out = append(out, w)
+	}
+	return out, nil
+}
+
+func (s *stubWebhookRepo) Delete(_ context.Context, id int64) error {
+	s.deleted = append(s.deleted, id)
+	delete(s.webhooks, id)
+	return nil
+}
+
+func (s *stubWebhookRepo) CreateDeliveries(_ context.Context, event string, payload json.RawMessage) (int64, error) {
+	if s.createDelErr != nil {
+		return 0, s.createDelErr
+	}
+	s.lastEvent = event
+	s.published = append(s.published, payload)
+	return 1, nil
+}
+
+func (s *stubWebhookRepo) CreateDeliveriesTo(_ context.Context, ids []int64, event string, payload json.RawMessage) (int64, error) {
+	if s.createDelErr != nil {
+		return 0, s.createDelErr
+	}
+	s.routedTo = append([]int64{}, ids...)
+	s.lastEvent = event
+	s.published = append(s.published, payload)
+	return int64(len(ids)), nil
+}
+
+func (s *stubWebhookRepo) GetDelivery(_ context.Context, _ int64) (*entity.WebhookDelivery, error) {
+	return nil, nil
+}
+
+func (s *stubWebhookRepo) UpdateDelivery(_ context.Context, _ *entity.WebhookDelivery) error {
+	return nil
+}
+
+func (s *stubWebhookRepo) ListDeliveries(_ context.Context, _ int64, limit int) ([]*entity.WebhookDelivery, error) {
+	s.lastLimit = limit
+	return []*entity.WebhookDelivery{}, nil
+}
+
+type stubSourceGetter struct {
+	source *entity.Source
+	err    error
+	calls  int
+}
+
+func (s *stubSourceGetter) Get(_ context.Context, _ int64) (*entity.Source, error) {
+	s.calls++
+	return s.source, s.err
+}
+
+/* ───────── テストケース ───────── */
+
+func TestService_Create(t *testing.T) {
+	const secret = "0123456789abcdef"
+
+	tests := []struct {
+		name    string
+		in      webhookUC.CreateInput
+		wantErr error
+	}{
+		{"valid", webhookUC.CreateInput{URL: " https://hooks.example.com/x ", Secret: secret, Events: []string{"article.created", "article.created", "crawl.completed"}}, nil},
+		{"missing url", webhookUC.CreateInput{Secret: secret, Events: []string{"article.created"}}, webhookUC.ErrInvalidURL},
+		{"non-http url", webhookUC.CreateInput{URL: "ftp://hooks.example.com", Secret: secret, Events: []string{"article.created"}}, webhookUC.ErrInvalidURL},
+		{"private url", webhookUC.CreateInput{URL: "http://127.0.0.1/hook", Secret: secret, Events: []string{"article.created"}}, webhookUC.ErrInvalidURL},
+		{"short secret", webhookUC.CreateInput{URL: "https://hooks.example.com", Secret: "short", Events: []string{"article.created"}}, webhookUC.ErrInvalidSecret},
+		{"no events", webhookUC.CreateInput{URL: "https://hooks.example.com", Secret: secret}, webhookUC.ErrInvalidEvents},
+		{"unknown event", webhookUC.CreateInput{URL: "https://hooks.example.com", Secret: secret, Events: []string{"article.deleted"}}, webhookUC.ErrInvalidEvents},
+		{"route without article.created", webhookUC.CreateInput{URL: "https://hooks.example.com", Secret: secret, Events: []string{"crawl.completed"}, Route: entity.WebhookRoute{Keywords: []string{"go"}}}, webhookUC.ErrInvalidRoute},
+		{"fallback with conditions", webhookUC.CreateInput{URL: "https://hooks.example.com", Secret: secret, Events: []string{"article.created"}, Route: entity.WebhookRoute{Fallback: true, SourceIDs: []int64{1}}}, webhookUC.ErrInvalidRoute},
+		{"non-positive source id", webhookUC.CreateInput{URL: "https://hooks.example.com", Secret: secret, Events: []string{"article.created"}, Route: entity.WebhookRoute{SourceIDs: []int64{0}}}, webhookUC.ErrInvalidRoute},
+		{"blank keywords only", webhookUC.CreateInput{URL: "https://hooks.example.com", Secret: secret, Events: []string{"article.created"}, Route: entity.WebhookRoute{Keywords: []string{" ", ""}}}, webhookUC.ErrInvalidRoute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &webhookUC.Service{Webhooks: newStubRepo()}
+			got, err := svc.Create(context.Background(), tt.in)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "https://hooks.example.com/x", got.URL)
+			assert.Equal(t, []string{"article.created", "crawl.completed"}, got.Events)
+		})
+	}
+}
+
+func TestService_Create_NormalizesRoute(t *testing.T) {
+	svc := &webhookUC.Service{Webhooks: newStubRepo()}
+	got, err := svc.Create(context.Background(), webhookUC.CreateInput{
+		URL:    "https://hooks.example.com/x",
+		Secret: "0123456789abcdef",
+		Events: []string{"article.created"},
+		Route: entity.WebhookRoute{
+			SourceIDs:  []int64{3, 3, 1},
+			Categories: []string{" Tech ", "tech", ""},
+			Keywords:   []string{"Go", " go", "Rust"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity.WebhookRoute{
+		SourceIDs:  []int64{3, 1},
+		Categories: []string{"Tech"},
+		Keywords:   []string{"Go", "Rust"},
+	}, got.Route)
+}
+
+func TestService_DeleteAndDeliveries(t *testing.T) {
+	repo := newStubRepo()
+	repo.webhooks[1] = &entity.Webhook{ID: 1}
+	svc := &webhookUC.Service{Webhooks: repo}
+
+	_, err := svc.ListDeliveries(context.Background(), 1, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, webhookUC.MaxDeliveryLimit, repo.lastLimit)
+
+	_, err = svc.ListDeliveries(context.Background(), 1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, webhookUC.DefaultDeliveryLimit, repo.lastLimit)
+
+	require.NoError(t, svc.Delete(context.Background(), 1))
+	assert.Equal(t, []int64{1}, repo.deleted)
+
+	assert.ErrorIs(t, svc.Delete(context.Background(), 1), webhookUC.ErrWebhookNotFound)
+	_, err = svc.ListDeliveries(context.Background(), 1, 0)
+	assert.ErrorIs(t, err, webhookUC.ErrWebhookNotFound)
+}
+
+func TestService_Publish(t *testing.T) {
+	repo := newStubRepo()
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	svc := &webhookUC.Service{Webhooks: repo, Now: func() time.Time { return now }}
+
+	err := svc.Publish(context.Background(), entity.WebhookEventCrawlCompleted, entity.WebhookCrawlData{Sources: 3, Inserted: 5})
+	require.NoError(t, err)
+	assert.Equal(t, entity.WebhookEventCrawlCompleted, repo.lastEvent)
+	require.Len(t, repo.published, 1)
+	assert.JSONEq(t, `{
+		"event": "crawl.completed",
+		"occurred_at": "2026-10-01T09:00:00Z",
+		"data": {"sources": 3, "fetch_failed_sources": 0, "feed_items": 0, "inserted": 5,
+		         "duplicated": 0, "duplicated_by_hash": 0, "near_duplicates": 0, "filtered_junk": 0, "summarize_errors": 0, "duration_ms": 0}
+	}`, string(repo.published[0]))
+
+	repo.createDelErr = errors.New("db down")
+	assert.Error(t, svc.Publish(context.Background(), entity.WebhookEventCrawlCompleted, nil))
+}
+
+func TestService_Publish_Routes(t *testing.T) {
+	article := entity.WebhookArticleData{ID: 10, SourceID: 5, Title: "Go 1.26 released", Summary: "generics"}
+
+	t.Run("no routed webhook uses plain fan-out", func(t *testing.T) {
+		repo := newStubRepo()
+		repo.webhooks[1] = &entity.Webhook{ID: 1, Events: []string{"article.created"}}
+		sources := &stubSourceGetter{}
+		svc := &webhookUC.Service{Webhooks: repo, Sources: sources}
+
+		require.NoError(t, svc.Publish(context.Background(), entity.WebhookEventArticleCreated, article))
+		assert.Nil(t, repo.routedTo)
+		assert.Len(t, repo.published, 1)
+		assert.Zero(t, sources.calls)
+	})
+
+	t.Run("routes by keyword and category with fallback", func(t *testing.T) {
+		repo := newStubRepo()
+		repo.webhooks[1] = &entity.Webhook{ID: 1, Events: []string{"article.created"}, Route: entity.WebhookRoute{Keywords: []string{"go 1.26"}}}
+		repo.webhooks[2] = &entity.Webhook{ID: 2, Events: []string{"article.created"}, Route: entity.WebhookRoute{Categories: []string{"security"}}}
+		repo.webhooks[3] = &entity.Webhook{ID: 3, Events: []string{"article.created"}, Route: entity.WebhookRoute{Fallback: true}}
+		repo.webhooks[4] = &entity.Webhook{ID: 4, Events: []string{"crawl.completed"}}
+		sources := &stubSourceGetter{source: &entity.Source{ID: 5, Category: "Tech"}}
+		svc := &webhookUC.Service{Webhooks: repo, Sources: sources}
+
+		require.NoError(t, svc.Publish(context.Background(), entity.WebhookEventArticleCreated, article))
+		assert.Equal(t, []int64{1}, repo.routedTo)
+		assert.Equal(t, 1, sources.calls)
+	})
+
+	t.Run("failed source lookup leaves categories unmatched", func(t *testing.T) {
+		repo := newStubRepo()
+		repo.webhooks[2] = &entity.Webhook{ID: 2, Events: []string{"article.created"}, Route: entity.WebhookRoute{Categories: []string{"tech"}}}
+		repo.webhooks[3] = &entity.Webhook{ID: 3, Events: []string{"article.created"}, Route: entity.WebhookRoute{Fallback: true}}
+		svc := &webhookUC.Service{Webhooks: repo, Sources: &stubSourceGetter{err: errors.New("db down")}}
+
+		require.NoError(t, svc.Publish(context.Background(), entity.WebhookEventArticleCreated, article))
+		assert.Equal(t, []int64{3}, repo.routedTo)
+	})
+}
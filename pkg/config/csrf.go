@@ -0,0 +1,38 @@
+package config
+
+// CSRFConfig contains the configuration for the double-submit CSRF check
+// on cookie-authenticated requests.
+type CSRFConfig struct {
+	// Enabled controls whether unsafe requests carrying the auth cookie
+	// must echo the CSRF cookie in the X-CSRF-Token header
+	Enabled bool
+
+	// ExemptPaths lists additional paths that are never checked (e.g.
+	// inbound webhook receivers); a trailing '/' matches the subtree.
+	// The token issuance endpoints are always exempt
+	ExemptPaths []string
+
+	// CookieDomain is the Domain attribute of the CSRF cookie; it follows
+	// the auth cookie's AUTH_COOKIE_DOMAIN so the frontend can read it
+	CookieDomain string
+}
+
+// LoadCSRFConfig loads the CSRF protection configuration from environment variables.
+//
+// Environment variables:
+//   - CSRF_ENABLED: Enable/disable the CSRF check (default: false)
+//   - CSRF_EXEMPT_PATHS: Comma-separated additional exempt paths (default: none)
+//   - AUTH_COOKIE_DOMAIN: Domain of the CSRF cookie (default: none)
+//
+// Returns:
+//   - *CSRFConfig: CSRF configuration
+//   - error: Always nil
+func LoadCSRFConfig() (*CSRFConfig, error) {
+	config := &CSRFConfig{
+		Enabled:      GetEnvBool("CSRF_ENABLED", false),
+		ExemptPaths:  GetEnvStringList("CSRF_EXEMPT_PATHS", nil),
+		CookieDomain: GetEnvString("AUTH_COOKIE_DOMAIN", ""),
+	}
+
+	return config, nil
+}
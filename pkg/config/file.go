@@ -0,0 +1,196 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileEnv names the environment variable that points at an optional
+// configuration file.
+const FileEnv = "CONFIG_FILE"
+
+// redacted replaces secret values in the effective-config summary.
+const redacted = "[REDACTED]"
+
+var fileKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// secretKeyParts mark a key whose value must never be logged.
+var secretKeyParts = []string{"SECRET", "PASSWORD", "TOKEN", "KEY", "DSN", "WEBHOOK_URL", "CREDENTIAL"}
+
+// FileEntry is one setting read from the configuration file.
+type FileEntry struct {
+	Key   string
+	Value string
+	// FromEnv reports that the environment already set Key, so the file
+	// value was ignored.
+	FromEnv bool
+}
+
+// File is a parsed configuration file.
+//
+// The file is a flat YAML mapping of environment variable names to scalar
+// values (or lists of scalars, joined with commas as GetEnvStringList
+// expects). Every binary keeps reading its settings through os.Getenv and
+// the GetEnv* helpers; the file only fills in what the environment leaves
+// unset, so an environment variable always overrides the file.
+type File struct {
+	Path    string
+	Entries []FileEntry
+}
+
+// LoadFile reads and validates the configuration file at path.
+//
+// Unknown structure is an error rather than a warning: a nested mapping,
+// a null value or a key that is not an environment variable name would
+// otherwise be silently ignored.
+//
+// Example:
+//
+//	LOG_LEVEL: debug
+//	CORS_ALLOWED_ORIGINS: [https://a.example, https://b.example]
+func LoadFile(path string) (*File, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path comes from the operator (CONFIG_FILE)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	f := &File{Path: path}
+	var errs []string
+	for key, v := range raw {
+		if !fileKeyPattern.MatchString(key) || key == FileEnv {
+			errs = append(errs, fmt.Sprintf("%s: not a valid setting name", key))
+			continue
+		}
+		value, err := fileValue(v)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", key, err))
+			continue
+		}
+		_, set := os.LookupEnv(key)
+		f.Entries = append(f.Entries, FileEntry{Key: key, Value: value, FromEnv: set})
+	}
+	if len(errs) > 0 {
+		slices.Sort(errs)
+		return nil, fmt.Errorf("invalid config file %s: %s", path, strings.Join(errs, "; "))
+	}
+	slices.SortFunc(f.Entries, func(a, b FileEntry) int { return strings.Compare(a.Key, b.Key) })
+	return f, nil
+}
+
+func fileValue(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			if _, nested := item.([]any); nested {
+				return "", fmt.Errorf("nested lists are not supported")
+			}
+			s, err := fileValue(item)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, s)
+		}
+		return strings.Join(parts, ","), nil
+	case nil:
+		return "", fmt.Errorf("value is empty")
+	default:
+		return "", fmt.Errorf("unsupported value of type %T (use a scalar or a list)", v)
+	}
+}
+
+// Apply exports every entry the environment does not already set.
+func (f *File) Apply() error {
+	for _, e := range f.Entries {
+		if e.FromEnv {
+			continue
+		}
+		if err := os.Setenv(e.Key, e.Value); err != nil {
+			return fmt.Errorf("apply config %s: %w", e.Key, err)
+		}
+	}
+	return nil
+}
+
+// ApplyFileFromEnv loads and applies the file named by CONFIG_FILE. It
+// returns (nil, nil) when CONFIG_FILE is unset.
+//
+// Call it before anything reads the environment (including logger setup,
+// which reads LOG_LEVEL), and treat an error as fatal.
+func ApplyFileFromEnv() (*File, error) {
+	path := os.Getenv(FileEnv)
+	if path == "" {
+		return nil, nil
+	}
+	f, err := LoadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Apply(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// LogSummary logs the effective value of every setting in the file, with
+// secrets redacted, and whether the file or the environment supplied it.
+// A nil File logs nothing.
+func (f *File) LogSummary(logger *slog.Logger) {
+	if f == nil {
+		return
+	}
+	attrs := make([]any, 0, len(f.Entries))
+	for _, e := range f.Entries {
+		source := "file"
+		if e.FromEnv {
+			source = "env"
+		}
+		attrs = append(attrs, slog.Group(e.Key,
+			slog.String("value", RedactValue(e.Key, os.Getenv(e.Key))),
+			slog.String("source", source),
+		))
+	}
+	logger.Info("configuration file loaded",
+		slog.String("path", f.Path),
+		slog.Group("settings", attrs...))
+}
+
+// RedactValue returns value safe for logging: secret-like keys are replaced
+// entirely and URL passwords are masked.
+func RedactValue(key, value string) string {
+	if value == "" {
+		return ""
+	}
+	for _, part := range secretKeyParts {
+		if strings.Contains(key, part) {
+			return redacted
+		}
+	}
+	if strings.Contains(value, "://") {
+		if u, err := url.Parse(value); err == nil && u.User != nil {
+			return u.Redacted()
+		}
+	}
+	return value
+}
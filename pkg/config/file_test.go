@@ -0,0 +1,131 @@
+package config
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFile(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(body), 0o600))
+	return path
+}
+
+/* ───────── LoadFile ───────── */
+
+func TestLoadFile_ScalarsAndLists(t *testing.T) {
+	path := writeConfigFile(t, `
+CFG_TEST_STRING: hello
+CFG_TEST_INT: 42
+CFG_TEST_BOOL: true
+CFG_TEST_LIST: [a, b]
+`)
+
+	f, err := LoadFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, []FileEntry{
+		{Key: "CFG_TEST_BOOL", Value: "true"},
+		{Key: "CFG_TEST_INT", Value: "42"},
+		{Key: "CFG_TEST_LIST", Value: "a,b"},
+		{Key: "CFG_TEST_STRING", Value: "hello"},
+	}, f.Entries)
+}
+
+func TestLoadFile_ReportsEveryInvalidEntry(t *testing.T) {
+	path := writeConfigFile(t, `
+lower_case: x
+CFG_TEST_NESTED:
+  inner: 1
+CFG_TEST_NULL:
+CONFIG_FILE: other.yaml
+`)
+
+	_, err := LoadFile(path)
+	require.Error(t, err)
+	for _, want := range []string{"lower_case", "CFG_TEST_NESTED", "CFG_TEST_NULL", "CONFIG_FILE"} {
+		assert.Contains(t, err.Error(), want)
+	}
+}
+
+func TestLoadFile_Unreadable(t *testing.T) {
+	_, err := LoadFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+
+	_, err = LoadFile(writeConfigFile(t, "- not\n- a mapping\n"))
+	assert.Error(t, err)
+}
+
+/* ───────── ApplyFileFromEnv ───────── */
+
+func TestApplyFileFromEnv_EnvironmentOverridesFile(t *testing.T) {
+	t.Setenv("CFG_TEST_FROM_ENV", "env-value")
+	t.Setenv("CFG_TEST_FROM_FILE", "")
+	require.NoError(t, os.Unsetenv("CFG_TEST_FROM_FILE"))
+	t.Setenv(FileEnv, writeConfigFile(t, "CFG_TEST_FROM_ENV: file-value\nCFG_TEST_FROM_FILE: file-value\n"))
+
+	f, err := ApplyFileFromEnv()
+	require.NoError(t, err)
+
+	assert.Equal(t, "env-value", os.Getenv("CFG_TEST_FROM_ENV"))
+	assert.Equal(t, "file-value", os.Getenv("CFG_TEST_FROM_FILE"))
+	assert.Equal(t, []FileEntry{
+		{Key: "CFG_TEST_FROM_ENV", Value: "file-value", FromEnv: true},
+		{Key: "CFG_TEST_FROM_FILE", Value: "file-value"},
+	}, f.Entries)
+}
+
+func TestApplyFileFromEnv_Unset(t *testing.T) {
+	t.Setenv(FileEnv, "")
+
+	f, err := ApplyFileFromEnv()
+	assert.NoError(t, err)
+	assert.Nil(t, f)
+}
+
+/* ───────── Redaction ───────── */
+
+func TestRedactValue(t *testing.T) {
+	tests := []struct {
+		key, value, want string
+	}{
+		{"JWT_SECRET", "s3cret", redacted},
+		{"GEMINI_API_KEY", "abc", redacted},
+		{"SLACK_WEBHOOK_URL", "https://hooks.slack.com/x", redacted},
+		{"DATABASE_URL", "postgres://user:pw@db:5432/app", "postgres://user:xxxxx@db:5432/app"},
+		{"LOG_LEVEL", "debug", "debug"},
+		{"JWT_SECRET", "", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, RedactValue(tt.key, tt.value), tt.key)
+	}
+}
+
+func TestFile_LogSummary_RedactsSecrets(t *testing.T) {
+	t.Setenv("CFG_TEST_PASSWORD", "hunter2")
+	t.Setenv("CFG_TEST_LEVEL", "debug")
+	f := &File{Path: "config.yaml", Entries: []FileEntry{
+		{Key: "CFG_TEST_LEVEL", Value: "debug"},
+		{Key: "CFG_TEST_PASSWORD", Value: "hunter2", FromEnv: true},
+	}}
+
+	var buf bytes.Buffer
+	f.LogSummary(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	out := buf.String()
+	assert.NotContains(t, out, "hunter2")
+	assert.True(t, strings.Contains(out, "settings.CFG_TEST_PASSWORD.value="+redacted), out)
+	assert.Contains(t, out, "settings.CFG_TEST_PASSWORD.source=env")
+	assert.Contains(t, out, "settings.CFG_TEST_LEVEL.value=debug")
+	assert.Contains(t, out, "settings.CFG_TEST_LEVEL.source=file")
+
+	(*File)(nil).LogSummary(slog.New(slog.NewTextHandler(&buf, nil)))
+}